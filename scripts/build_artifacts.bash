@@ -6,6 +6,9 @@ VERSION=$(git describe --always --dirty --tags)
 GOARCH=amd64
 DIR="$( cd "$( dirname "${BASH_SOURCE[0]}" )" && pwd )"
 
+export GO111MODULE=off
+export GOPATH=$PWD/vendor:$PWD
+
 build() {
   NAME=$1
   GOOS=$2
@@ -13,7 +16,8 @@ build() {
   PKG_DIR="$DIST/$RELEASE"
 
   # do the actual build
-  GOOS=$GOOS GOARCH=$GOARCH gb build  -ldflags "-X main.version=$VERSION"
+  GOOS=$GOOS GOARCH=$GOARCH go build -ldflags "-X main.version=$VERSION" \
+    -o bin/$(srcBin $NAME $GOOS) ./src/github.com/stellar/gateway/cmd/$NAME
 
   # make package directory
   rm -rf $PKG_DIR
@@ -2,13 +2,16 @@
 
 set -e
 
+export GO111MODULE=off
+export GOPATH=$PWD/vendor:$PWD
+
 PACKAGES=$(find src/github.com/stellar/gateway -type d | sed -e 's/^src\///')
 
 for i in $PACKAGES; do
   has_tests=`ls -1 src/$i/*_test.go 2>/dev/null | wc -l`
 
   if [ $has_tests != 0 ]; then
-    $GOPATH/bin/gb test $i
+    go test ./src/$i
   else 
     echo "skipping $i, no tests"
   fi
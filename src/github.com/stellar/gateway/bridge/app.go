@@ -1,55 +1,83 @@
 package bridge
 
 import (
+	"context"
 	"errors"
 	"flag"
 	"fmt"
 	log "github.com/Sirupsen/logrus"
 	"net/http"
 	"os"
+	"syscall"
 	"time"
 
 	"github.com/facebookgo/inject"
+	"github.com/stellar/gateway/bridge/auth"
 	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/bridge/grpc"
 	"github.com/stellar/gateway/bridge/handlers"
 	"github.com/stellar/gateway/db"
 	"github.com/stellar/gateway/db/drivers/mysql"
 	"github.com/stellar/gateway/db/drivers/postgres"
+	"github.com/stellar/gateway/db/drivers/sqlite"
+	"github.com/stellar/gateway/events"
 	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/limits"
 	"github.com/stellar/gateway/listener"
+	"github.com/stellar/gateway/metrics"
+	"github.com/stellar/gateway/monitor"
 	"github.com/stellar/gateway/protocols/federation"
 	"github.com/stellar/gateway/protocols/stellartoml"
+	"github.com/stellar/gateway/protocols/webauth"
+	"github.com/stellar/gateway/sandbox"
 	"github.com/stellar/gateway/server"
+	"github.com/stellar/gateway/signer"
 	"github.com/stellar/gateway/submitter"
+	"github.com/stellar/gateway/tracing"
 	"github.com/zenazn/goji"
+	"github.com/zenazn/goji/graceful"
+	"github.com/zenazn/goji/web"
 	"github.com/zenazn/goji/web/middleware"
 )
 
+const (
+	defaultMonitorInterval     = 60 * time.Second
+	defaultStatsDPort          = 8125
+	defaultShutdownTimeout     = 30 * time.Second
+	defaultFeePercentile       = 90
+	horizonHealthCheckInterval = 10 * time.Second
+	recentErrorsSize           = 20
+)
+
+// Version is a version of the bridge server returned by /admin/status. It's set by cmd/bridge.
+var Version string
+
+// defaultRateLimitEndpoints are limited when rate_limit.endpoints is not set
+var defaultRateLimitEndpoints = []string{"/payment", "/builder"}
+
 // App is the application object
 type App struct {
-	config         config.Config
-	requestHandler handlers.RequestHandler
+	config                config.Config
+	requestHandler        handlers.RequestHandler
+	tenantRequestHandlers []*handlers.RequestHandler
+	grpcServer            *grpc.Server
+	// authenticator is nil when `auth` is not configured
+	authenticator *auth.Authenticator
 }
 
 // NewApp constructs an new App instance from the provided config.
-func NewApp(config config.Config, migrateFlag bool) (app *App, err error) {
+// When migrateCommand is not empty it runs the migrate command (see db.Migrate) and exits.
+func NewApp(config config.Config, migrateCommand string, migrateLimit int) (app *App, err error) {
 	var g inject.Graph
 
-	var driver db.Driver
-	switch config.Database.Type {
-	case "mysql":
-		driver = &mysql.Driver{}
-	case "postgres":
-		driver = &postgres.Driver{}
-	case "":
-		// Allow to start gateway server with a single endpoint: /payment
-		break
-	default:
-		return nil, fmt.Errorf("%s database has no driver", config.Database.Type)
+	driver, err := newDriver(config.Database.Type)
+	if err != nil {
+		return
 	}
 
 	var entityManager db.EntityManagerInterface
 	var repository db.RepositoryInterface
+	var dbRepository db.Repository
 
 	if driver != nil {
 		err = driver.Init(config.Database.URL)
@@ -58,41 +86,306 @@ func NewApp(config config.Config, migrateFlag bool) (app *App, err error) {
 			return
 		}
 
+		db.ConfigurePool(driver, db.PoolOptions{
+			MaxOpenConns:    config.Database.MaxOpenConnections,
+			MaxIdleConns:    config.Database.MaxIdleConnections,
+			ConnMaxLifetime: time.Duration(config.Database.ConnectionMaxLifetime) * time.Second,
+		})
+
 		entityManager = db.NewEntityManager(driver)
-		repository = db.NewRepository(driver)
+		if config.Database.NotifyChannel != "" {
+			entityManager = db.NewNotifyEntityManager(entityManager, driver.DB(), config.Database.NotifyChannel)
+		}
+		dbRepository = db.NewRepository(driver)
+		if config.Database.StatementCache {
+			dbRepository = dbRepository.WithStatementCache(db.NewStatementCache(driver.DB()))
+		}
+		repository = dbRepository
 	}
 
-	if migrateFlag {
+	if migrateCommand != "" {
 		if driver == nil {
 			log.Fatal("No database driver.")
 			return
 		}
 
-		var migrationsApplied int
-		migrationsApplied, err = driver.MigrateUp("gateway")
+		err = db.Migrate(driver, "gateway", migrateCommand, migrateLimit)
 		if err != nil {
 			return
 		}
 
-		log.Info("Applied migrations: ", migrationsApplied)
 		os.Exit(0)
 		return
 	}
 
-	h := horizon.New(config.Horizon)
+	if config.StatsD.Host != "" {
+		port := config.StatsD.Port
+		if port == 0 {
+			port = defaultStatsDPort
+		}
 
-	log.Print("Creating and initializing TransactionSubmitter")
-	ts := submitter.NewTransactionSubmitter(&h, entityManager, config.NetworkPassphrase, time.Now)
+		var sink *metrics.StatsDSink
+		sink, err = metrics.NewStatsDSink(fmt.Sprintf("%s:%d", config.StatsD.Host, port), config.StatsD.Prefix, config.StatsD.Tags)
+		if err != nil {
+			err = fmt.Errorf("Cannot create StatsD sink: %s", err)
+			return
+		}
+		metrics.Default.AddSink(sink)
+	}
+
+	if config.Tracing.Enabled() {
+		tracing.Default = config.Tracing.NewTracer("bridge")
+		tracing.Default.Start()
+	}
+
+	var h horizon.HorizonInterface
+	var sandboxHorizon *sandbox.Horizon
+	if config.Sandbox {
+		log.Warning("Sandbox mode: Stellar network is simulated locally, transactions are not sent to Horizon")
+		sandboxHorizon = sandbox.NewHorizon(config.NetworkPassphrase)
+		h = sandboxHorizon
+	} else if len(config.HorizonFallbacks) > 0 {
+		failover := horizon.NewFailover(append([]string{config.Horizon}, config.HorizonFallbacks...), config.Timeouts.HorizonTimeout())
+		failover.StartHealthChecks(horizonHealthCheckInterval)
+		h = failover
+	} else {
+		horizonClient := horizon.New(config.Horizon)
+		horizonClient.Timeout = config.Timeouts.HorizonTimeout()
+		h = &horizonClient
+	}
+
+	if config.HorizonCacheTTL > 0 && !config.Sandbox {
+		h = horizon.NewCache(h, time.Duration(config.HorizonCacheTTL)*time.Second)
+	}
+
+	signers, err := signer.New(config.Signer)
+	if err != nil {
+		return
+	}
+	if keystore := signers.Keystore(); keystore != nil && keystore.Locked() {
+		log.Warning("Keystore is locked, unlock it using POST /admin/keystore/unlock")
+	}
+
+	feeStrategy := newFeeStrategy(&config, h)
+	ts, err := newTransactionSubmitter(&config, h, entityManager, feeStrategy, signers)
+	if err != nil {
+		return
+	}
+
+	paymentListener, err := startPaymentListener(&config, entityManager, h, repository)
+	if err != nil {
+		return
+	}
+
+	err = startComplianceRetry(&config, entityManager, repository)
 	if err != nil {
 		return
 	}
 
+	startDustSweeper(&config, entityManager, repository, &ts)
+
+	var publisher events.Publisher
+	if config.PubSub.Topic != "" {
+		var pubSubPublisher *events.PubSubPublisher
+		pubSubPublisher, err = events.NewPubSubPublisher(config.PubSub.Project, config.PubSub.Topic, config.PubSub.CredentialsFile)
+		if err != nil {
+			return
+		}
+		publisher = pubSubPublisher
+	}
+
+	startTrustlineListener(&config, h)
+
+	if len(config.APIKey) > 0 && len(config.APIKey) < 15 {
+		err = errors.New("api-key have to be at least 15 chars long")
+		return
+	}
+
+	requestHandler := handlers.RequestHandler{
+		EntityManager:   entityManager,
+		Repository:      repository,
+		Sandbox:         sandboxHorizon,
+		PaymentListener: paymentListener,
+		Publisher:       publisher,
+		FeeStrategy:     feeStrategy,
+		Signers:         signers,
+		RecentErrors:    &server.RecentErrors{Size: recentErrorsSize},
+		Version:         Version,
+	}
+	log.AddHook(requestHandler.RecentErrors)
+	// Limits can be added by reloading the config when a DB is used
+	if config.Database.Type != "" {
+		requestHandler.Limiter = limits.NewLimiter(&config, entityManager, repository, time.Now)
+	}
+	if config.ComplianceTLS.Enabled() {
+		requestHandler.ComplianceClient, err = config.ComplianceTLS.NewClient(config.Timeouts.ComplianceTimeout())
+		if err != nil {
+			return
+		}
+	}
+
+	federationResolver := &federation.Resolver{}
+	if config.FederationCacheTTL > 0 {
+		requestHandler.FederationCache = federation.NewCache(
+			federationResolver,
+			time.Duration(config.FederationCacheTTL)*time.Second,
+			time.Duration(config.FederationNegativeCacheTTL)*time.Second,
+		)
+		requestHandler.FederationResolver = requestHandler.FederationCache
+	}
+
+	err = g.Provide(
+		&inject.Object{Value: &requestHandler},
+		&inject.Object{Value: &config},
+		&inject.Object{Value: &stellartoml.Resolver{}},
+		&inject.Object{Value: federationResolver},
+		&inject.Object{Value: h},
+		&inject.Object{Value: &ts},
+		&inject.Object{Value: &http.Client{Timeout: config.Timeouts.CallbacksTimeout()}},
+	)
+
+	if err != nil {
+		log.Fatal("Injector: ", err)
+	}
+
+	if err := g.Populate(); err != nil {
+		log.Fatal("Injector: ", err)
+	}
+
+	app = &App{
+		config:         config,
+		requestHandler: requestHandler,
+	}
+	if config.Auth.Enabled() {
+		app.authenticator = auth.NewAuthenticator(&config, repository)
+	}
+
+	monitoredAccounts := monitor.Accounts(&config, signers)
+
+	for _, tenant := range config.Tenants {
+		tenantConfig := config.ForTenant(tenant)
+		monitoredAccounts = append(monitoredAccounts, monitor.Accounts(&tenantConfig, signers)...)
+		log.Print("Initializing tenant ", tenant.Name)
+
+		var tenantTs submitter.TransactionSubmitter
+		tenantTs, err = newTransactionSubmitter(&tenantConfig, h, entityManager, feeStrategy, signers)
+		if err != nil {
+			return
+		}
+
+		tenantRepository := dbRepository.ForTenant(tenant.Name)
+		var tenantPaymentListener *listener.PaymentListener
+		tenantPaymentListener, err = startPaymentListener(&tenantConfig, entityManager, h, tenantRepository)
+		if err != nil {
+			return
+		}
+
+		err = startComplianceRetry(&tenantConfig, entityManager, tenantRepository)
+		if err != nil {
+			return
+		}
+
+		startDustSweeper(&tenantConfig, entityManager, tenantRepository, &tenantTs)
+
+		startTrustlineListener(&tenantConfig, h)
+
+		tenantRequestHandler := requestHandler
+		tenantRequestHandler.Config = &tenantConfig
+		tenantRequestHandler.TransactionSubmitter = &tenantTs
+		tenantRequestHandler.Repository = tenantRepository
+		tenantRequestHandler.PaymentListener = tenantPaymentListener
+		if tenantConfig.Database.Type != "" {
+			tenantRequestHandler.Limiter = limits.NewLimiter(&tenantConfig, entityManager, tenantRepository, time.Now)
+		}
+		app.tenantRequestHandlers = append(app.tenantRequestHandlers, &tenantRequestHandler)
+	}
+
+	monitorInterval := time.Duration(config.Monitor.Interval) * time.Second
+	if monitorInterval == 0 {
+		monitorInterval = defaultMonitorInterval
+	}
+	monitor.NewBalanceMonitor(h, monitoredAccounts, monitorInterval).Start()
+
+	if config.Monitor.SignersSnapshot != "" {
+		var signersMonitor *monitor.SignersMonitor
+		signersMonitor, err = monitor.NewSignersMonitor(h, monitoredAccounts, config.Monitor.SignersSnapshot, monitorInterval)
+		if err != nil {
+			return
+		}
+		signersMonitor.Start()
+	}
+	return
+}
+
+// newDriver returns a DB driver of databaseType. It returns nil when the DB is not configured.
+func newDriver(databaseType string) (db.Driver, error) {
+	switch databaseType {
+	case "mysql":
+		return &mysql.Driver{}, nil
+	case "postgres":
+		return &postgres.Driver{}, nil
+	case "sqlite3":
+		return &sqlite.Driver{}, nil
+	case "":
+		// Allow to start gateway server with a single endpoint: /payment
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("%s database has no driver", databaseType)
+	}
+}
+
+// newFeeStrategy creates a FeeStrategy using `fee` config group. It's shared by all tenants.
+func newFeeStrategy(c *config.Config, h horizon.HorizonInterface) *submitter.FeeStrategy {
+	feeStrategy := &submitter.FeeStrategy{
+		Horizon:           h,
+		NetworkPassphrase: c.NetworkPassphrase,
+		BaseFee:           c.Fee.BaseFee,
+		MaxFee:            c.Fee.MaxFee,
+	}
+
+	if c.Fee.Type == config.FeeStrategyPercentile {
+		feeStrategy.Percentile = c.Fee.Percentile
+		if feeStrategy.Percentile == 0 {
+			feeStrategy.Percentile = defaultFeePercentile
+		}
+	}
+	return feeStrategy
+}
+
+// newTransactionSubmitter creates a TransactionSubmitter and initializes accounts from config
+func newTransactionSubmitter(
+	config *config.Config,
+	h horizon.HorizonInterface,
+	entityManager db.EntityManagerInterface,
+	feeStrategy *submitter.FeeStrategy,
+	signers *signer.Signers,
+) (ts submitter.TransactionSubmitter, err error) {
+	log.Print("Creating and initializing TransactionSubmitter")
+	ts = submitter.NewTransactionSubmitter(h, entityManager, config.NetworkPassphrase, time.Now)
+	ts.Tenant = config.Tenant
+	ts.FeeStrategy = feeStrategy
+	ts.Signers = signers
+
+	// Address of a key reference is not known when the config is validated
+	if signer.IsReference(config.Accounts.ReceivingSeed) {
+		var receiving signer.Signer
+		receiving, err = signers.Signer(config.Accounts.ReceivingSeed)
+		if err != nil {
+			return
+		}
+
+		if receiving.Address() != config.Accounts.ReceivingAccountID {
+			err = errors.New("accounts.receiving_seed does not match accounts.receiving_account_id")
+			return
+		}
+	}
+
 	log.Print("Initializing Authorizing account")
 
 	if config.Accounts.AuthorizingSeed == "" {
 		log.Warning("No accounts.authorizing_seed param. Skipping...")
 	} else {
-		err = ts.InitAccount(config.Accounts.AuthorizingSeed)
+		err = ts.InitAccount(context.Background(), config.Accounts.AuthorizingSeed)
 		if err != nil {
 			return
 		}
@@ -102,27 +395,46 @@ func NewApp(config config.Config, migrateFlag bool) (app *App, err error) {
 		log.Warning("No accounts.base_seed param. Skipping...")
 	} else {
 		log.Print("Initializing Base account")
-		err = ts.InitAccount(config.Accounts.BaseSeed)
+		err = ts.InitAccount(context.Background(), config.Accounts.BaseSeed)
+		if err != nil {
+			return
+		}
+	}
+
+	if len(config.Accounts.ChannelSeeds) > 0 {
+		log.Printf("Initializing %d channel accounts", len(config.Accounts.ChannelSeeds))
+		err = ts.InitChannels(context.Background(), config.Accounts.ChannelSeeds)
 		if err != nil {
 			return
 		}
 	}
 
 	log.Print("TransactionSubmitter created")
+	return
+}
 
+// startPaymentListener creates a PaymentListener and starts it if receiving account and receive callback
+// (or payments_poll, pubsub.topic or sep31.assets) are set.
+// It returns nil when the listener is not started.
+func startPaymentListener(
+	config *config.Config,
+	entityManager db.EntityManagerInterface,
+	h horizon.HorizonInterface,
+	repository db.RepositoryInterface,
+) (paymentListener *listener.PaymentListener, err error) {
 	log.Print("Creating and starting PaymentListener")
 
-	var paymentListener listener.PaymentListener
-
 	if config.Accounts.ReceivingAccountID == "" {
 		log.Warning("No accounts.receiving_account_id param. Skipping...")
-	} else if config.Callbacks.Receive == "" {
+	} else if !config.Callbacks.HasReceive() && !config.PaymentsPoll && config.PubSub.Topic == "" && !config.Sep31.Enabled() && !config.TransferServer.HasWithdrawals() {
 		log.Warning("No callbacks.receive param. Skipping...")
 	} else {
-		paymentListener, err = listener.NewPaymentListener(&config, entityManager, &h, repository, time.Now)
+		var pl listener.PaymentListener
+		pl, err = listener.NewPaymentListener(config, entityManager, h, repository, time.Now)
 		if err != nil {
 			return
 		}
+		paymentListener = &pl
 		err = paymentListener.Listen()
 		if err != nil {
 			return
@@ -131,37 +443,46 @@ func NewApp(config config.Config, migrateFlag bool) (app *App, err error) {
 		log.Print("PaymentListener created")
 	}
 
-	if len(config.APIKey) > 0 && len(config.APIKey) < 15 {
-		err = errors.New("api-key have to be at least 15 chars long")
-		return
-	}
-
-	requestHandler := handlers.RequestHandler{}
+	return
+}
 
-	err = g.Provide(
-		&inject.Object{Value: &requestHandler},
-		&inject.Object{Value: &config},
-		&inject.Object{Value: &stellartoml.Resolver{}},
-		&inject.Object{Value: &federation.Resolver{}},
-		&inject.Object{Value: &h},
-		&inject.Object{Value: &ts},
-		&inject.Object{Value: &paymentListener},
-		&inject.Object{Value: &http.Client{}},
-	)
+// startComplianceRetry starts sending /payment requests pending at the destination compliance
+// server again when `callbacks.compliance` is set
+func startComplianceRetry(
+	config *config.Config,
+	entityManager db.EntityManagerInterface,
+	repository db.RepositoryInterface,
+) error {
+	if config.Callbacks.Compliance == "" {
+		return nil
+	}
 
+	complianceRetry, err := listener.NewComplianceRetry(config, entityManager, repository, time.Now)
 	if err != nil {
-		log.Fatal("Injector: ", err)
+		return err
 	}
+	complianceRetry.Start()
+	return nil
+}
 
-	if err := g.Populate(); err != nil {
-		log.Fatal("Injector: ", err)
+// startDustSweeper starts sending dust payments to `dust.sweep_account` when it's set
+func startDustSweeper(
+	config *config.Config,
+	entityManager db.EntityManagerInterface,
+	repository db.RepositoryInterface,
+	ts submitter.TransactionSubmitterInterface,
+) {
+	if !config.Dust.SweepEnabled() {
+		return
 	}
 
-	app = &App{
-		config:         config,
-		requestHandler: requestHandler,
+	// Tenants have their own accounts so receiving_seed is checked here
+	if config.Accounts.ReceivingSeed == "" {
+		log.WithField("tenant", config.Tenant).Warning("No accounts.receiving_seed param. Dust is not swept.")
+		return
 	}
-	return
+
+	listener.NewDustSweeper(config, entityManager, repository, ts, time.Now).Start()
 }
 
 // Serve starts the server
@@ -172,20 +493,322 @@ func (a *App) Serve() {
 	goji.Abandon(middleware.Logger)
 	goji.Use(server.StripTrailingSlashMiddleware())
 	goji.Use(server.HeadersMiddleware())
-	if a.config.APIKey != "" {
-		goji.Use(server.APIKeyMiddleware(a.config.APIKey))
+	goji.Use(server.RequestIDMiddleware())
+	goji.Use(server.TracingMiddleware())
+	if a.config.RateLimit.Enabled() {
+		// redis_url is checked in config validation
+		limiter, _ := a.config.RateLimit.NewLimiter("bridge:rate_limit:")
+		endpoints := a.config.RateLimit.Endpoints
+		if len(endpoints) == 0 {
+			endpoints = defaultRateLimitEndpoints
+		}
+		goji.Use(server.RateLimitMiddleware(endpoints, limiter, a.config.RateLimit.TrustProxy))
+	}
+	var apiKeyMiddleware func(next http.Handler) http.Handler
+	if a.authenticator != nil {
+		apiKeyMiddleware = a.authenticator.Middleware("", auth.Permission)
+	} else if len(a.tenantRequestHandlers) > 0 {
+		tenantAPIKeys := map[string]string{}
+		for _, rh := range a.tenantRequestHandlers {
+			tenantAPIKeys[rh.Config.Tenant] = rh.Config.APIKey
+		}
+		apiKeyMiddleware = server.TenantMiddleware(a.config.APIKey, tenantAPIKeys)
+	} else if a.config.APIKey != "" {
+		apiKeyMiddleware = server.APIKeyMiddleware(a.config.APIKey)
+	}
+	if apiKeyMiddleware != nil {
+		// Wallets, sending anchors and orchestrator probes authenticate without API key
+		publicPaths := []string{"/healthz", "/readyz"}
+		if a.config.WebAuth.Enabled() {
+			publicPaths = append(publicPaths, "/auth", "/sep31/")
+		}
+		if a.config.Federation.Enabled() {
+			publicPaths = append(publicPaths, "/federation")
+		}
+		if a.config.ApprovalServer.Enabled() {
+			publicPaths = append(publicPaths, "/tx_approve")
+		}
+		if a.config.TransferServer.Enabled() {
+			publicPaths = append(publicPaths, "/sep6/", "/sep24/")
+		}
+		apiKeyMiddleware = server.ExceptPathsMiddleware(apiKeyMiddleware, publicPaths...)
+		goji.Use(apiKeyMiddleware)
+	}
+	if len(a.config.WebAuth.Endpoints) > 0 {
+		// jwt_key is checked in config validation
+		key, _ := a.config.WebAuth.Key()
+		goji.Use(server.BearerTokenMiddleware(a.config.WebAuth.Endpoints, func(token string) error {
+			_, err := webauth.ParseToken(token, key, time.Now())
+			return err
+		}))
 	}
 
-	if a.config.Accounts.AuthorizingSeed != "" {
-		goji.Post("/authorize", a.requestHandler.Authorize)
-	} else {
-		log.Warning("accounts.authorizing_seed not provided. /authorize endpoint will not be available.")
+	RegisterRoutes(goji.DefaultMux, "", &a.requestHandler)
+	goji.Get("/metrics", metrics.Handler(metrics.Default))
+	goji.Get("/healthz", a.requestHandler.Healthz)
+	goji.Get("/readyz", a.requestHandler.Readyz)
+	if a.config.WebAuth.Enabled() {
+		goji.Get("/auth", a.requestHandler.AuthChallenge)
+		goji.Post("/auth", a.requestHandler.AuthToken)
+	}
+	if a.config.Federation.Enabled() {
+		goji.Get("/federation", a.requestHandler.Federation)
+	}
+	if a.config.ApprovalServer.Enabled() {
+		goji.Post("/tx_approve", a.requestHandler.TxApprove)
+	}
+	if a.config.TransferServer.Enabled() {
+		goji.Get("/sep6/info", a.requestHandler.TransferInfo)
+		goji.Get("/sep6/deposit", a.requestHandler.TransferDeposit)
+		goji.Get("/sep6/withdraw", a.requestHandler.TransferWithdraw)
+		goji.Get("/sep6/transaction", a.requestHandler.TransferTransaction)
+	}
+	if a.config.TransferServer.Interactive() {
+		goji.Get("/sep24/info", a.requestHandler.InteractiveInfo)
+		goji.Post("/sep24/transactions/deposit/interactive", a.requestHandler.InteractiveDeposit)
+		goji.Post("/sep24/transactions/withdraw/interactive", a.requestHandler.InteractiveWithdraw)
+		goji.Get("/sep24/transaction", a.requestHandler.TransferTransaction)
+	}
+	if a.config.Sep31.Enabled() {
+		goji.Get("/sep31/info", a.requestHandler.Sep31Info)
+		goji.Post("/sep31/transactions", a.requestHandler.Sep31CreateTransaction)
+		goji.Get("/sep31/transactions/:id", a.requestHandler.Sep31Transaction)
+	}
+	for _, rh := range a.tenantRequestHandlers {
+		RegisterRoutes(goji.DefaultMux, "/tenants/"+rh.Config.Tenant, rh)
 	}
 
-	goji.Post("/create-keypair", a.requestHandler.CreateKeypair)
-	goji.Post("/builder", a.requestHandler.Builder)
-	goji.Post("/payment", a.requestHandler.Payment)
-	goji.Get("/payment", a.requestHandler.Payment)
+	if a.config.Admin.Port != nil {
+		a.serveAdmin()
+	}
+
+	if a.config.GRPC.Port != nil {
+		a.serveGRPC()
+	}
 
+	a.handleShutdown()
 	goji.Serve()
+	log.Info("Bridge server stopped")
+}
+
+// handleShutdown makes the server stop gracefully on SIGINT and SIGTERM. Servers stop accepting
+// new connections (so new /payment requests are rejected) and requests in progress, including
+// transaction submissions, are finished. Payment listeners stop processing new payments and
+// callback retries and payments in progress are saved with their cursor. Connections still
+// open after shutdown_timeout are closed.
+func (a *App) handleShutdown() {
+	timeout := defaultShutdownTimeout
+	if a.config.ShutdownTimeout > 0 {
+		timeout = time.Duration(a.config.ShutdownTimeout) * time.Second
+	}
+
+	// Goji handles SIGINT only
+	graceful.AddSignal(syscall.SIGTERM)
+	graceful.Timeout(timeout)
+
+	var deadline time.Time
+	graceful.PreHook(func() {
+		log.WithField("timeout", timeout).Info("Shutting down")
+		deadline = time.Now().Add(timeout)
+		if a.grpcServer != nil {
+			a.grpcServer.Stop()
+		}
+		for _, pl := range a.paymentListeners() {
+			pl.Stop()
+		}
+	})
+	graceful.PostHook(func() {
+		// Wait is called for every listener, also after a timeout, so requests still in
+		// progress are cancelled
+		timedOut := false
+		for _, pl := range a.paymentListeners() {
+			if !pl.Wait(deadline.Sub(time.Now())) {
+				timedOut = true
+			}
+		}
+		if timedOut {
+			log.Warning("Timeout waiting for payments in progress. They will be processed again after restart.")
+		}
+	})
+	// Export spans of requests and payments finished during shutdown
+	graceful.PostHook(tracing.Default.Stop)
+}
+
+// Reload applies params of validated config c which can be changed without restarting the
+// server (see config.Config.Reload) to all tenants. Payment listeners keep streaming from
+// their cursors. Other changed params are logged and ignored until restart.
+func (a *App) Reload(c config.Config) {
+	ignored := a.requestHandler.Config.Reload(c)
+	// Top level params are also compared in tenant configs
+	global := map[string]bool{}
+	for _, name := range ignored {
+		global[name] = true
+	}
+
+	tenants := map[string]config.Tenant{}
+	for _, tenant := range c.Tenants {
+		tenants[tenant.Name] = tenant
+	}
+	tenantsChanged := len(tenants) != len(a.tenantRequestHandlers)
+	for _, rh := range a.tenantRequestHandlers {
+		tenant, ok := tenants[rh.Config.Tenant]
+		if !ok {
+			tenantsChanged = true
+			continue
+		}
+
+		for _, name := range rh.Config.Reload(c.ForTenant(tenant)) {
+			if !global[name] {
+				ignored = append(ignored, "tenants."+tenant.Name+"."+name)
+			}
+		}
+	}
+	if tenantsChanged {
+		ignored = append(ignored, "tenants")
+	}
+
+	level := log.InfoLevel
+	if c.LogLevel != "" {
+		// Validated by Config.Validate
+		level, _ = log.ParseLevel(c.LogLevel)
+	}
+	log.SetLevel(level)
+
+	if len(ignored) > 0 {
+		log.WithField("params", ignored).Warning("Config reloaded, changes of some params require restart")
+	} else {
+		log.Info("Config reloaded")
+	}
+}
+
+// paymentListeners returns running payment listeners of all tenants
+func (a *App) paymentListeners() (listeners []*listener.PaymentListener) {
+	for _, rh := range append([]*handlers.RequestHandler{&a.requestHandler}, a.tenantRequestHandlers...) {
+		if rh.PaymentListener != nil {
+			listeners = append(listeners, rh.PaymentListener)
+		}
+	}
+	return
+}
+
+// startTrustlineListener starts a TrustlineListener if issuing account and trustline callback are set
+func startTrustlineListener(config *config.Config, h horizon.HorizonInterface) {
+	if config.Accounts.IssuingAccountID == "" || config.Callbacks.Trustline == "" {
+		return
+	}
+
+	trustlineListener := listener.NewTrustlineListener(config, h)
+	trustlineListener.Listen()
+	log.Print("TrustlineListener started")
+}
+
+// RegisterRoutes registers bridge endpoints of a single tenant under prefix
+func RegisterRoutes(mux *web.Mux, prefix string, rh *handlers.RequestHandler) {
+	if rh.Config.Accounts.AuthorizingSeed != "" {
+		mux.Post(prefix+"/authorize", rh.Authorize)
+	} else {
+		log.Warning("accounts.authorizing_seed not provided. " + prefix + "/authorize endpoint will not be available.")
+	}
+
+	mux.Post(prefix+"/create-keypair", rh.CreateKeypair)
+	mux.Post(prefix+"/muxed-address", rh.MuxedAddress)
+	mux.Post(prefix+"/builder", rh.Builder)
+	mux.Post(prefix+"/payment", rh.Payment)
+	mux.Get(prefix+"/payment", rh.Payment)
+	mux.Post(prefix+"/payment/preview", rh.PaymentPreview)
+	mux.Get(prefix+"/quote", rh.Quote)
+	mux.Get(prefix+"/accounts/:id/balances", rh.AccountBalances)
+	mux.Post(prefix+"/claim", rh.Claim)
+	mux.Post(prefix+"/trust", rh.Trust)
+	mux.Post(prefix+"/allow-trust", rh.AllowTrust)
+	mux.Post(prefix+"/create-account", rh.CreateAccount)
+
+	if rh.Repository != nil {
+		mux.Get(prefix+"/payments/poll", rh.PaymentsPoll)
+		mux.Get(prefix+"/received-payments", rh.ReceivedPayments)
+		mux.Get(prefix+"/sent-payments", rh.SentPayments)
+		mux.Get(prefix+"/transactions/:hash", rh.Transaction)
+		mux.Post(prefix+"/sign", rh.Sign)
+	}
+}
+
+// serveAdmin starts admin server in a separate goroutine
+func (a *App) serveAdmin() {
+	admin := web.New()
+	admin.Use(server.StripTrailingSlashMiddleware())
+	admin.Use(server.HeadersMiddleware())
+	admin.Use(server.RequestIDMiddleware())
+	admin.Use(server.TracingMiddleware())
+	if a.authenticator != nil {
+		admin.Use(a.authenticator.Middleware("/admin", func(string) string { return config.PermissionAdmin }))
+	} else {
+		admin.Use(server.APIKeyMiddleware(a.config.Admin.APIKey))
+	}
+
+	RegisterAdminRoutes(admin, "/admin", &a.requestHandler)
+	if a.config.Auth.Database {
+		admin.Get("/admin/api-keys", a.requestHandler.AdminAPIKeys)
+		admin.Post("/admin/api-keys", a.requestHandler.AdminCreateAPIKey)
+		admin.Delete("/admin/api-keys/:id", a.requestHandler.AdminDeleteAPIKey)
+	}
+	if a.requestHandler.Signers.Keystore() != nil {
+		admin.Get("/admin/keystore", a.requestHandler.AdminKeystore)
+		admin.Post("/admin/keystore/unlock", a.requestHandler.AdminUnlockKeystore)
+	}
+	if a.requestHandler.FederationCache != nil {
+		admin.Post("/admin/federation-cache/flush", a.requestHandler.AdminFlushFederationCache)
+	}
+	for _, rh := range a.tenantRequestHandlers {
+		RegisterAdminRoutes(admin, "/admin/tenants/"+rh.Config.Tenant, rh)
+	}
+
+	adminPortString := fmt.Sprintf(":%d", *a.config.Admin.Port)
+	log.Println("Starting admin server on", adminPortString)
+	go func() {
+		err := graceful.ListenAndServe(adminPortString, admin)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}()
+}
+
+// serveGRPC starts gRPC server in a separate goroutine
+func (a *App) serveGRPC() {
+	a.grpcServer = grpc.NewServer(&a.requestHandler, a.tenantRequestHandlers)
+	a.grpcServer.Authenticator = a.authenticator
+
+	grpcPortString := fmt.Sprintf(":%d", *a.config.GRPC.Port)
+	log.Println("Starting gRPC server on", grpcPortString)
+	go func() {
+		err := a.grpcServer.ListenAndServe(grpcPortString)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}()
+}
+
+// RegisterAdminRoutes registers admin endpoints of a single tenant under prefix
+func RegisterAdminRoutes(mux *web.Mux, prefix string, rh *handlers.RequestHandler) {
+	mux.Get(prefix+"/status", rh.AdminStatus)
+	mux.Get(prefix+"/callbacks", rh.AdminCallbacks)
+	mux.Get(prefix+"/customers", rh.AdminCustomers)
+	mux.Post(prefix+"/customers", rh.AdminCreateCustomer)
+	mux.Get(prefix+"/customers/:id", rh.AdminCustomer)
+	mux.Put(prefix+"/customers/:id", rh.AdminUpdateCustomer)
+	mux.Delete(prefix+"/customers/:id", rh.AdminDeleteCustomer)
+	mux.Get(prefix+"/received-payments/:id", rh.AdminReceivedPayment)
+	mux.Post(prefix+"/received-payments/:id/refund", rh.AdminRefundReceivedPayment)
+	mux.Post(prefix+"/received-payments/:id/resend-callback", rh.AdminResendReceivedPaymentCallback)
+	mux.Post(prefix+"/received-payments/:id/reprocess", rh.AdminReprocessReceivedPayment)
+	mux.Post(prefix+"/received-payments/:id/requeue", rh.AdminRequeueReceivedPayment)
+	mux.Post(prefix+"/received-payments/:id/resolve", rh.AdminResolveReceivedPayment)
+	mux.Get(prefix+"/sent-payments/:id", rh.AdminSentPayment)
+	if rh.Config.TransferServer.Enabled() {
+		mux.Get(prefix+"/transfers/:id", rh.AdminTransfer)
+		mux.Put(prefix+"/transfers/:id", rh.AdminUpdateTransfer)
+	}
+
+	if rh.Sandbox != nil {
+		mux.Post(prefix+"/sandbox/payments", rh.AdminSandboxPayment)
+	}
 }
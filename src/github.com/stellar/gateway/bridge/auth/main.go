@@ -0,0 +1,249 @@
+// Package auth authenticates requests to the bridge server using API keys and JWT bearer tokens
+// configured in `auth` config group. Every key and token has permissions (payment, builder,
+// admin) and can be limited to endpoints of a single tenant.
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/db"
+	"github.com/stellar/gateway/protocols"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stellar/gateway/protocols/webauth"
+	"github.com/stellar/gateway/server"
+)
+
+// ErrInvalidCredentials is returned when an API key or a token is missing, unknown or expired
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+// Principal is an authenticated API key or token
+type Principal struct {
+	// Name is a name of the API key or a subject of the token
+	Name string
+	// Tenant is a tenant the principal is limited to or empty string
+	Tenant      string
+	Permissions []string
+}
+
+// Can returns true when the principal has permission
+func (p *Principal) Can(permission string) bool {
+	for _, granted := range p.Permissions {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// CanAccessTenant returns true when the principal can access endpoints of tenant (empty string
+// for the default tenant)
+func (p *Principal) CanAccessTenant(tenant string) bool {
+	return p.Tenant == "" || p.Tenant == tenant
+}
+
+// Claims are claims of tokens accepted by Authenticator. Tokens are signed (HS256) using
+// `auth.jwt_key` by another service and must expire.
+type Claims struct {
+	Subject     string   `json:"sub"`
+	Tenant      string   `json:"tenant,omitempty"`
+	Permissions []string `json:"permissions"`
+	ExpiresAt   int64    `json:"exp"`
+}
+
+// Authenticator authenticates API keys and tokens
+type Authenticator struct {
+	// keys are static keys by HashKey of the key
+	keys       map[string]*Principal
+	jwtKey     []byte
+	repository db.RepositoryInterface
+	now        func() time.Time
+}
+
+// NewAuthenticator creates an Authenticator of keys and tokens configured in c. `admin.api_key`
+// is accepted with admin permission. repository is used to find keys stored in the DB when
+// `auth.database` is set.
+func NewAuthenticator(c *config.Config, repository db.RepositoryInterface) *Authenticator {
+	a := &Authenticator{
+		keys: make(map[string]*Principal),
+		now:  time.Now,
+	}
+
+	for _, apiKey := range c.Auth.APIKeys {
+		a.keys[HashKey(apiKey.Key)] = &Principal{
+			Name:        apiKey.Name,
+			Tenant:      apiKey.Tenant,
+			Permissions: apiKey.Permissions,
+		}
+	}
+
+	if c.Admin.APIKey != "" {
+		a.keys[HashKey(c.Admin.APIKey)] = &Principal{Name: "admin", Permissions: []string{config.PermissionAdmin}}
+	}
+
+	if c.Auth.JWTKey != "" {
+		a.jwtKey = []byte(c.Auth.JWTKey)
+	}
+
+	if c.Auth.Database {
+		a.repository = repository
+	}
+
+	return a
+}
+
+// HashKey returns a hex-encoded SHA-256 hash of an API key
+func HashKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+// NewKey generates a random API key
+func NewKey() (string, error) {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(key), nil
+}
+
+// Authenticate returns a principal of token or, when token is empty, of apiKey. It returns
+// ErrInvalidCredentials when the credentials are not valid.
+func (a *Authenticator) Authenticate(apiKey, token string) (*Principal, error) {
+	if token != "" {
+		return a.authenticateToken(token)
+	}
+
+	if apiKey == "" {
+		return nil, ErrInvalidCredentials
+	}
+
+	hash := HashKey(apiKey)
+	if principal, ok := a.keys[hash]; ok {
+		return principal, nil
+	}
+
+	if a.repository == nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	stored, err := a.repository.GetAPIKeyByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if stored == nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	return &Principal{
+		Name:        stored.Name,
+		Tenant:      stored.Tenant,
+		Permissions: stored.PermissionsList(),
+	}, nil
+}
+
+func (a *Authenticator) authenticateToken(token string) (*Principal, error) {
+	if a.jwtKey == nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	var claims Claims
+	err := webauth.DecodeToken(token, a.jwtKey, &claims)
+	if err != nil || claims.Subject == "" || a.now().Unix() >= claims.ExpiresAt {
+		return nil, ErrInvalidCredentials
+	}
+
+	return &Principal{
+		Name:        claims.Subject,
+		Tenant:      claims.Tenant,
+		Permissions: claims.Permissions,
+	}, nil
+}
+
+// Permission returns a permission required by an endpoint of the bridge server (path without
+// `/tenants/<name>` prefix). Endpoints not sending nor building transactions can be accessed
+// by all principals.
+func Permission(path string) string {
+	switch path {
+	case "/payment", "/payment/preview", "/authorize", "/claim", "/trust", "/allow-trust", "/create-account", "/sign":
+		return config.PermissionPayment
+	case "/builder":
+		return config.PermissionBuilder
+	default:
+		return ""
+	}
+}
+
+type principalKey struct{}
+
+// FromRequest returns a principal authenticated by Middleware or nil
+func FromRequest(r *http.Request) *Principal {
+	principal, _ := r.Context().Value(principalKey{}).(*Principal)
+	return principal
+}
+
+// Middleware authenticates requests using a token in `Authorization: Bearer <token>` header
+// or `apiKey` parameter and checks a permission returned by permission for a request path
+// (without prefix and `/tenants/<name>`). Endpoints of tenants are prefixed with
+// prefix + `/tenants/<name>`. Requests of principals limited to a tenant sent to endpoints
+// without a tenant are routed to endpoints of their tenant.
+func (a *Authenticator) Middleware(prefix string, permission func(path string) string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			token := ""
+			if authorization := r.Header.Get("Authorization"); strings.HasPrefix(authorization, "Bearer ") {
+				token = strings.TrimPrefix(authorization, "Bearer ")
+			}
+
+			principal, err := a.Authenticate(r.FormValue("apiKey"), token)
+			if err == ErrInvalidCredentials {
+				server.Write(w, bridge.Unauthenticated)
+				return
+			} else if err != nil {
+				server.Logger(r).WithField("err", err).Error("Error authenticating request")
+				server.Write(w, protocols.InternalServerError)
+				return
+			}
+
+			tenant, path := splitTenant(prefix, r.URL.Path)
+			if !principal.CanAccessTenant(tenant) {
+				if tenant != "" {
+					server.Write(w, bridge.NewPermissionDeniedError(""))
+					return
+				}
+				tenant = principal.Tenant
+				r.URL.Path = prefix + "/tenants/" + tenant + path
+			}
+
+			if required := permission(path); required != "" && !principal.Can(required) {
+				server.Write(w, bridge.NewPermissionDeniedError(required))
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), principalKey{}, principal)))
+		}
+		return http.HandlerFunc(fn)
+	}
+}
+
+// splitTenant returns a tenant of an endpoint (empty string for the default tenant) and its
+// path without prefix and `/tenants/<name>`
+func splitTenant(prefix, path string) (tenant, endpoint string) {
+	endpoint = strings.TrimPrefix(path, prefix)
+	if strings.HasPrefix(endpoint, "/tenants/") {
+		parts := strings.SplitN(strings.TrimPrefix(endpoint, "/tenants/"), "/", 2)
+		if len(parts) == 2 {
+			return parts[0], "/" + parts[1]
+		}
+	}
+	return "", endpoint
+}
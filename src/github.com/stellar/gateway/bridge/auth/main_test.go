@@ -0,0 +1,167 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const jwtKey = "0123456789abcdef0123456789abcdef"
+
+func newAuthenticator(repository *mocks.MockRepository) *Authenticator {
+	a := NewAuthenticator(&config.Config{
+		Admin: config.Admin{APIKey: "admin-api-key-value"},
+		Auth: config.Auth{
+			APIKeys: []config.APIKey{
+				{Name: "payments", Key: "payments-api-key-value", Permissions: []string{config.PermissionPayment}},
+				{Name: "acme", Key: "acme-api-key-value", Tenant: "acme", Permissions: []string{config.PermissionPayment, config.PermissionBuilder}},
+			},
+			JWTKey:   jwtKey,
+			Database: true,
+		},
+	}, repository)
+	a.now = func() time.Time { return time.Unix(1500000000, 0) }
+	return a
+}
+
+// newToken returns a token signed like tokens of other services using HS256
+func newToken(t *testing.T, claims Claims, key string) string {
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	unsigned := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`)) + "." +
+		base64.RawURLEncoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(unsigned))
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestAuthenticate(t *testing.T) {
+	repository := new(mocks.MockRepository)
+	a := newAuthenticator(repository)
+
+	principal, err := a.Authenticate("payments-api-key-value", "")
+	require.NoError(t, err)
+	assert.Equal(t, "payments", principal.Name)
+	assert.True(t, principal.Can(config.PermissionPayment))
+	assert.False(t, principal.Can(config.PermissionBuilder))
+	assert.True(t, principal.CanAccessTenant("acme"))
+
+	principal, err = a.Authenticate("admin-api-key-value", "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{config.PermissionAdmin}, principal.Permissions)
+
+	principal, err = a.Authenticate("acme-api-key-value", "")
+	require.NoError(t, err)
+	assert.True(t, principal.CanAccessTenant("acme"))
+	assert.False(t, principal.CanAccessTenant(""))
+
+	repository.On("GetAPIKeyByHash", HashKey("stored-api-key-value")).Return(&entities.APIKey{
+		Name:        "stored",
+		Permissions: "builder,admin",
+	}, nil).Once()
+	principal, err = a.Authenticate("stored-api-key-value", "")
+	require.NoError(t, err)
+	assert.Equal(t, "stored", principal.Name)
+	assert.Equal(t, []string{config.PermissionBuilder, config.PermissionAdmin}, principal.Permissions)
+
+	repository.On("GetAPIKeyByHash", HashKey("unknown")).Return(nil, nil).Once()
+	_, err = a.Authenticate("unknown", "")
+	assert.Equal(t, ErrInvalidCredentials, err)
+
+	_, err = a.Authenticate("", "")
+	assert.Equal(t, ErrInvalidCredentials, err)
+
+	token := newToken(t, Claims{Subject: "service", Tenant: "acme", Permissions: []string{"builder"}, ExpiresAt: 1500000060}, jwtKey)
+	principal, err = a.Authenticate("", token)
+	require.NoError(t, err)
+	assert.Equal(t, &Principal{Name: "service", Tenant: "acme", Permissions: []string{"builder"}}, principal)
+
+	for _, token := range []string{
+		newToken(t, Claims{Subject: "service", ExpiresAt: 1500000000}, jwtKey),
+		newToken(t, Claims{Subject: "service", ExpiresAt: 1500000060}, "fedcba9876543210fedcba9876543210"),
+		newToken(t, Claims{ExpiresAt: 1500000060}, jwtKey),
+		"invalid",
+	} {
+		_, err = a.Authenticate("", token)
+		assert.Equal(t, ErrInvalidCredentials, err, token)
+	}
+
+	repository.AssertExpectations(t)
+}
+
+func TestMiddleware(t *testing.T) {
+	a := newAuthenticator(new(mocks.MockRepository))
+
+	var path string
+	var principal *Principal
+	handler := a.Middleware("", Permission)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		principal = FromRequest(r)
+	}))
+
+	tests := []struct {
+		url           string
+		authorization string
+		status        int
+		path          string
+	}{
+		{"/payment?apiKey=payments-api-key-value", "", http.StatusOK, "/payment"},
+		{"/tenants/acme/payment?apiKey=payments-api-key-value", "", http.StatusOK, "/tenants/acme/payment"},
+		{"/builder?apiKey=payments-api-key-value", "", http.StatusForbidden, ""},
+		{"/create-keypair?apiKey=payments-api-key-value", "", http.StatusOK, "/create-keypair"},
+		{"/builder?apiKey=acme-api-key-value", "", http.StatusOK, "/tenants/acme/builder"},
+		{"/tenants/other/builder?apiKey=acme-api-key-value", "", http.StatusForbidden, ""},
+		{"/payment?apiKey=admin-api-key-value", "", http.StatusForbidden, ""},
+		{"/payment", "", http.StatusUnauthorized, ""},
+		{"/payment?apiKey=payments-api-key-value", "Bearer invalid", http.StatusUnauthorized, ""},
+		{
+			"/payment",
+			"Bearer " + newToken(t, Claims{Subject: "service", Permissions: []string{"payment"}, ExpiresAt: 1500000060}, jwtKey),
+			http.StatusOK,
+			"/payment",
+		},
+	}
+
+	for _, test := range tests {
+		path = ""
+		principal = nil
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", test.url, nil)
+		r.Header.Set("Authorization", test.authorization)
+		handler.ServeHTTP(w, r)
+
+		assert.Equal(t, test.status, w.Code, test.url)
+		assert.Equal(t, test.path, path, test.url)
+		if test.status == http.StatusOK {
+			assert.NotNil(t, principal, test.url)
+		}
+	}
+
+	admin := a.Middleware("/admin", func(string) string { return config.PermissionAdmin })(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+	}))
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("GET", "/admin/tenants/acme/customers?apiKey=admin-api-key-value", nil)
+	admin.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "/admin/tenants/acme/customers", path)
+
+	w = httptest.NewRecorder()
+	r, _ = http.NewRequest("GET", "/admin/customers?apiKey=acme-api-key-value", nil)
+	admin.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), `"permission": "admin"`)
+}
@@ -0,0 +1,227 @@
+package bridge
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/protocols"
+	"github.com/stellar/gateway/signer"
+	"github.com/stellar/go-stellar-base/keypair"
+	"github.com/stellar/go-stellar-base/strkey"
+)
+
+// CheckResult is a result of a single check run by CheckConfig
+type CheckResult struct {
+	// Name of the checked param, ex. `horizon`
+	Name string
+	// Err is nil when the check passed
+	Err error
+	// Hint explains how to fix Err
+	Hint string
+}
+
+// CheckConfig validates config c (see config.Config.Validate), checks assets and callback URLs
+// and connects to Horizon servers and the DB. It doesn't stop at the first failed check so
+// all problems are reported at once.
+func CheckConfig(c config.Config) (results []CheckResult) {
+	check := func(name string, err error, hint string) {
+		if err == nil {
+			hint = ""
+		}
+		results = append(results, CheckResult{Name: name, Err: err, Hint: hint})
+	}
+
+	check("config", c.Validate(), "fix the param in config_bridge.toml")
+
+	check("assets", checkAssets(c.Assets), "use `CODE:ISSUER` assets, issuer must be a public key (G...) copied without typos")
+	for i, limit := range c.Limits {
+		check(fmt.Sprintf("limits[%d].asset", i), checkAssets([]config.Asset{limit.Asset}), "use a `CODE:ISSUER` asset, issuer must be a public key (G...) copied without typos")
+	}
+	for _, tenant := range c.Tenants {
+		check("tenants."+tenant.Name+".assets", checkAssets(tenant.Assets), "use `CODE:ISSUER` assets, issuer must be a public key (G...) copied without typos")
+	}
+
+	callbacks := []param{
+		{"callbacks.receive", c.Callbacks.Receive},
+		{"callbacks.error", c.Callbacks.Error},
+		{"callbacks.alert", c.Callbacks.Alert},
+		{"callbacks.trustline", c.Callbacks.Trustline},
+		{"callbacks.compliance", c.Callbacks.Compliance},
+		{"callbacks.approve", c.Callbacks.Approve},
+		{"callbacks.deposit", c.Callbacks.Deposit},
+		{"callbacks.transfer_status", c.Callbacks.TransferStatus},
+		{"dead_letter.webhook", c.DeadLetter.Webhook},
+	}
+	for _, tenant := range c.Tenants {
+		prefix := "tenants." + tenant.Name + ".callbacks."
+		callbacks = append(callbacks,
+			param{prefix + "receive", tenant.Callbacks.Receive},
+			param{prefix + "error", tenant.Callbacks.Error},
+			param{prefix + "alert", tenant.Callbacks.Alert},
+			param{prefix + "trustline", tenant.Callbacks.Trustline},
+			param{prefix + "compliance", tenant.Callbacks.Compliance},
+			param{prefix + "approve", tenant.Callbacks.Approve},
+		)
+	}
+	for _, callback := range callbacks {
+		if callback.value != "" {
+			check(callback.name, checkCallbackURL(callback.value), "use an absolute http(s) URL, ex. https://example.com/receive")
+		}
+	}
+
+	if !c.Sandbox && c.Horizon != "" {
+		h := horizon.New(c.Horizon)
+		err := checkHorizon(&h, c.NetworkPassphrase)
+		check("horizon", err, "check that the URL is correct, the server is reachable from this host and it belongs to the network of network_passphrase")
+
+		for i, fallback := range c.HorizonFallbacks {
+			f := horizon.New(fallback)
+			check(fmt.Sprintf("horizon_fallbacks[%d]", i), checkHorizon(&f, c.NetworkPassphrase), "check that the URL is correct and the server is reachable from this host")
+		}
+
+		// Accounts can be checked only when the main Horizon server works
+		if err == nil {
+			for _, account := range accountIDs(c) {
+				_, err := h.LoadAccount(context.Background(), account.value)
+				if statusErr, ok := err.(*horizon.StatusError); ok && statusErr.StatusCode == 404 {
+					err = fmt.Errorf("account %s does not exist", account.value)
+				}
+				check(account.name, err, "create the account and fund it with XLM")
+			}
+		}
+	}
+
+	if c.Database.Type != "" {
+		check("database", checkDatabase(c), "check database.type and database.url, run `bridge --migrate up` when migrations are pending")
+	}
+	return
+}
+
+// param is a config param value with its name
+type param struct {
+	name  string
+	value string
+}
+
+// checkAssets checks codes and issuer checksums of assets. Assets set as `code` and `issuer`
+// keys are not validated when the config is decoded.
+func checkAssets(assets []config.Asset) error {
+	for _, asset := range assets {
+		if asset.Code == "" && asset.Issuer == "" {
+			// native
+			continue
+		}
+
+		if asset.Code != config.AssetWildcard && !protocols.IsValidAssetCode(asset.Code) {
+			return fmt.Errorf("invalid asset code: %s", asset.Code)
+		}
+
+		if asset.Issuer != config.AssetWildcard {
+			_, err := strkey.Decode(strkey.VersionByteAccountID, asset.Issuer)
+			if err != nil {
+				return fmt.Errorf("invalid issuer of %s: %s (%s)", asset.Code, asset.Issuer, err)
+			}
+		}
+	}
+	return nil
+}
+
+func checkCallbackURL(callbackURL string) error {
+	u, err := url.Parse(callbackURL)
+	if err != nil {
+		return err
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%s is not an http(s) URL", callbackURL)
+	}
+
+	if u.Host == "" {
+		return fmt.Errorf("%s has no host", callbackURL)
+	}
+	return nil
+}
+
+func checkHorizon(h *horizon.Horizon, networkPassphrase string) error {
+	root, err := h.LoadRoot(context.Background())
+	if err != nil {
+		return err
+	}
+
+	if root.NetworkPassphrase != networkPassphrase {
+		return fmt.Errorf("server network passphrase (%s) is different than network_passphrase", root.NetworkPassphrase)
+	}
+	return nil
+}
+
+// accountIDs returns accounts of `accounts` config group (and tenants). Key references are
+// skipped, their addresses are not known without the signer backend.
+func accountIDs(c config.Config) (ids []param) {
+	add := func(prefix string, accounts config.Accounts) {
+		seeds := []param{
+			{prefix + "base_seed", accounts.BaseSeed},
+			{prefix + "authorizing_seed", accounts.AuthorizingSeed},
+		}
+		for i, seed := range accounts.ChannelSeeds {
+			seeds = append(seeds, param{fmt.Sprintf("%schannel_seeds[%d]", prefix, i), seed})
+		}
+		for _, seed := range seeds {
+			if seed.value == "" || signer.IsReference(seed.value) {
+				continue
+			}
+			// Invalid seeds are reported by config check
+			kp, err := keypair.Parse(seed.value)
+			if err == nil {
+				ids = append(ids, param{seed.name, kp.Address()})
+			}
+		}
+
+		if accounts.IssuingAccountID != "" {
+			ids = append(ids, param{prefix + "issuing_account_id", accounts.IssuingAccountID})
+		}
+		if accounts.ReceivingAccountID != "" {
+			ids = append(ids, param{prefix + "receiving_account_id", accounts.ReceivingAccountID})
+		}
+		for i, accountID := range accounts.ReceivingAccountIDs {
+			ids = append(ids, param{fmt.Sprintf("%sreceiving_account_ids[%d]", prefix, i), accountID})
+		}
+	}
+
+	add("accounts.", c.Accounts)
+	for _, tenant := range c.Tenants {
+		add("tenants."+tenant.Name+".accounts.", tenant.Accounts)
+	}
+	return
+}
+
+func checkDatabase(c config.Config) error {
+	driver, err := newDriver(c.Database.Type)
+	if err != nil {
+		return err
+	}
+
+	err = driver.Init(c.Database.URL)
+	if err != nil {
+		return fmt.Errorf("cannot connect to the DB: %s", err)
+	}
+	defer driver.DB().Close()
+
+	statuses, err := driver.MigrationStatus("gateway")
+	if err != nil {
+		return err
+	}
+
+	pending := 0
+	for _, status := range statuses {
+		if status.AppliedAt == nil {
+			pending++
+		}
+	}
+	if pending > 0 {
+		return fmt.Errorf("%d pending migrations", pending)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package bridge
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stellar/gateway/bridge/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckConfig(t *testing.T) {
+	existing := "GATKP6ZQM5CSLECPMTAC5226PE367QALCPM6AFHTSULPPZMT62OOPMQB"
+	horizon := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			w.Write([]byte(`{"network_passphrase": "Test SDF Network ; September 2015"}`))
+		case "/accounts/" + existing:
+			w.Write([]byte(`{"id": "` + existing + `", "sequence": "1"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"status": 404}`))
+		}
+	}))
+	defer horizon.Close()
+
+	port := 8006
+	c := config.Config{
+		Port:              &port,
+		Horizon:           horizon.URL,
+		NetworkPassphrase: "Test SDF Network ; September 2015",
+		Assets: []config.Asset{
+			{Code: "USD", Issuer: "GDSIKW43UA6JTOA47WVEBCZ4MYC74M3GNKNXTVDXFHXYYTNO5GGVN632"},
+			{Code: "EUR", Issuer: "*"},
+		},
+		Accounts: config.Accounts{
+			IssuingAccountID:   existing,
+			ReceivingAccountID: "GAHA6GRCLCCN7XE2NEEUDSIVOFBOQ6GLSYXVLYCJXJKLPMDR5XB5XZZJ",
+		},
+		Callbacks: config.Callbacks{Receive: "https://example.com/receive"},
+	}
+
+	results := CheckConfig(c)
+	assert.Equal(t, []string{
+		"config",
+		"assets",
+		"callbacks.receive",
+		"horizon",
+		"accounts.issuing_account_id",
+		"accounts.receiving_account_id",
+	}, checkNames(results))
+	for _, result := range results[:5] {
+		assert.NoError(t, result.Err, result.Name)
+		assert.Empty(t, result.Hint)
+	}
+	assert.EqualError(t, results[5].Err, "account GAHA6GRCLCCN7XE2NEEUDSIVOFBOQ6GLSYXVLYCJXJKLPMDR5XB5XZZJ does not exist")
+	assert.NotEmpty(t, results[5].Hint)
+
+	// Accounts are not checked when Horizon fails
+	c.NetworkPassphrase = "Public Global Stellar Network ; September 2015"
+	c.Assets[0].Issuer = "GDSIKW43UA6JTOA47WVEBCZ4MYC74M3GNKNXTVDXFHXYYTNO5GGVN633"
+	c.Callbacks.Receive = "localhost:8005/receive"
+	results = CheckConfig(c)
+	assert.Equal(t, []string{"config", "assets", "callbacks.receive", "horizon"}, checkNames(results))
+	assert.NoError(t, results[0].Err)
+	assert.EqualError(t, results[1].Err, "invalid issuer of USD: GDSIKW43UA6JTOA47WVEBCZ4MYC74M3GNKNXTVDXFHXYYTNO5GGVN633 (invalid checksum)")
+	assert.EqualError(t, results[2].Err, "localhost:8005/receive is not an http(s) URL")
+	assert.EqualError(t, results[3].Err, "server network passphrase (Test SDF Network ; September 2015) is different than network_passphrase")
+
+	c.Port = nil
+	results = CheckConfig(c)
+	assert.EqualError(t, results[0].Err, "port param is required")
+}
+
+func checkNames(results []CheckResult) (names []string) {
+	for _, result := range results {
+		names = append(names, result.Name)
+	}
+	return
+}
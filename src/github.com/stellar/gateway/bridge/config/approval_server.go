@@ -0,0 +1,45 @@
+package config
+
+import (
+	"errors"
+)
+
+// ApprovalServer contains values of `approval_server` config group. SEP-8 /tx_approve endpoint
+// is enabled when Assets are set.
+type ApprovalServer struct {
+	// Assets are codes of regulated assets issued by `accounts.issuing_account_id`
+	Assets []string
+}
+
+// Enabled returns true when /tx_approve endpoint is enabled
+func (a ApprovalServer) Enabled() bool {
+	return len(a.Assets) > 0
+}
+
+// Regulates returns true when asset of the issuing account with given code is regulated
+func (a ApprovalServer) Regulates(code string) bool {
+	for _, asset := range a.Assets {
+		if asset == code {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Config) validateApprovalServer() error {
+	if !c.ApprovalServer.Enabled() {
+		return nil
+	}
+
+	// callbacks.approve requires accounts.authorizing_seed and accounts.issuing_account_id
+	if c.Callbacks.Approve == "" {
+		return errors.New("callbacks.approve param is required when approval_server.assets are set")
+	}
+
+	for _, code := range c.ApprovalServer.Assets {
+		if code == "" || len(code) > 12 {
+			return errors.New("Invalid approval_server.assets param: " + code)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package config
+
+// AssetWildcard used as a code or an issuer of `assets` entry matches any asset code or issuer
+const AssetWildcard = "*"
+
+// AssetFilter checks if assets are allowed by `assets` config entries. Entries can contain
+// wildcards: `*:ISSUER` allows any asset issued by ISSUER and `USD:*` allows USD issued by
+// any account. Native asset is allowed only by `native` entry, it is not matched by wildcards.
+type AssetFilter []Asset
+
+// Allows returns true if asset with given code and issuer (both empty for native asset)
+// matches one of entries
+func (f AssetFilter) Allows(code, issuer string) bool {
+	for _, asset := range f {
+		if asset.Matches(code, issuer) {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches returns true if asset with given code and issuer (both empty for native asset)
+// matches this entry
+func (a Asset) Matches(code, issuer string) bool {
+	if code == "" && issuer == "" {
+		return a.Code == "" && a.Issuer == ""
+	}
+
+	return (a.Code == AssetWildcard || a.Code == code) &&
+		(a.Issuer == AssetWildcard || a.Issuer == issuer)
+}
+
+// AssetFilter returns filter of assets allowed by `assets` config param
+func (c *Config) AssetFilter() AssetFilter {
+	reloadMutex.RLock()
+	defer reloadMutex.RUnlock()
+	return AssetFilter(c.Assets)
+}
@@ -0,0 +1,136 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Permissions of API keys and tokens
+const (
+	// PermissionPayment allows sending transactions: /payment, /authorize and /claim
+	PermissionPayment = "payment"
+	// PermissionBuilder allows building transactions: /builder
+	PermissionBuilder = "builder"
+	// PermissionAdmin allows using admin API
+	PermissionAdmin = "admin"
+)
+
+// Permissions are all valid permissions
+var Permissions = []string{PermissionPayment, PermissionBuilder, PermissionAdmin}
+
+// IsValidPermission returns true when permission is one of Permissions
+func IsValidPermission(permission string) bool {
+	for _, valid := range Permissions {
+		if permission == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// Auth contains values of `auth` config group. When it's enabled, requests to bridge and admin
+// servers must contain an API key (`apiKey` parameter) or a token (`Authorization: Bearer
+// <token>` header) with a permission required by the endpoint.
+type Auth struct {
+	// APIKeys are static API keys
+	APIKeys []APIKey `mapstructure:"api_keys"`
+	// JWTKey is an HMAC key of HS256 tokens issued by another service
+	JWTKey string `mapstructure:"jwt_key"`
+	// Database enables API keys stored in the DB and managed using admin API
+	Database bool
+}
+
+// APIKey contains values of a single `auth.api_keys` config group entry
+type APIKey struct {
+	Name string
+	Key  string
+	// Tenant limits the key to endpoints of a single tenant. Keys without a tenant can access
+	// endpoints of all tenants.
+	Tenant      string
+	Permissions []string
+}
+
+// Enabled returns true when requests are authenticated using `auth` config group
+func (a Auth) Enabled() bool {
+	return len(a.APIKeys) > 0 || a.JWTKey != "" || a.Database
+}
+
+func (c *Config) validateAuth() (err error) {
+	if !c.Auth.Enabled() {
+		return
+	}
+
+	if c.APIKey != "" {
+		err = errors.New("api_key param cannot be used with auth, add it to auth.api_keys")
+		return
+	}
+
+	tenants := map[string]bool{}
+	for _, tenant := range c.Tenants {
+		if tenant.APIKey != "" {
+			err = fmt.Errorf("tenants.api_key of %s tenant cannot be used with auth, add it to auth.api_keys", tenant.Name)
+			return
+		}
+		tenants[tenant.Name] = true
+	}
+
+	names := map[string]bool{}
+	keys := map[string]bool{c.Admin.APIKey: true}
+	for _, apiKey := range c.Auth.APIKeys {
+		if apiKey.Name == "" {
+			err = errors.New("auth.api_keys.name param is required")
+			return
+		}
+
+		if names[apiKey.Name] {
+			err = fmt.Errorf("Duplicate auth.api_keys.name param: %s", apiKey.Name)
+			return
+		}
+		names[apiKey.Name] = true
+
+		if len(apiKey.Key) < 15 {
+			err = fmt.Errorf("auth.api_keys.key of %s key have to be at least 15 chars long", apiKey.Name)
+			return
+		}
+
+		if keys[apiKey.Key] {
+			err = fmt.Errorf("auth.api_keys.key of %s key is already used", apiKey.Name)
+			return
+		}
+		keys[apiKey.Key] = true
+
+		if apiKey.Tenant != "" && !tenants[apiKey.Tenant] {
+			err = fmt.Errorf("auth.api_keys.tenant of %s key is not one of tenants: %s", apiKey.Name, apiKey.Tenant)
+			return
+		}
+
+		if len(apiKey.Permissions) == 0 {
+			err = fmt.Errorf("auth.api_keys.permissions of %s key are required", apiKey.Name)
+			return
+		}
+
+		for _, permission := range apiKey.Permissions {
+			if !IsValidPermission(permission) {
+				err = fmt.Errorf("Invalid auth.api_keys.permissions element of %s key: %s", apiKey.Name, permission)
+				return
+			}
+		}
+	}
+
+	if c.Auth.JWTKey != "" && len(c.Auth.JWTKey) < 32 {
+		err = errors.New("auth.jwt_key have to be at least 32 chars long")
+		return
+	}
+
+	if c.Auth.JWTKey != "" && len(c.WebAuth.Endpoints) > 0 {
+		err = errors.New("auth.jwt_key param cannot be used with web_auth.endpoints")
+		return
+	}
+
+	if c.Auth.Database && c.Database.Type == "" {
+		err = errors.New("database param is required when auth.database is set")
+		return
+	}
+
+	return
+}
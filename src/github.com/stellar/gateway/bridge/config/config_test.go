@@ -0,0 +1,253 @@
+package config
+
+import (
+	"io/ioutil"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stellar/gateway/signer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeAssets(t *testing.T) {
+	issuer := "GDSIKW43UA6JTOA47WVEBCZ4MYC74M3GNKNXTVDXFHXYYTNO5GGVN632"
+
+	var c Config
+	err := Decode(map[string]interface{}{
+		"horizon": "https://horizon-testnet.stellar.org",
+		"assets": []interface{}{
+			"EUR:" + issuer,
+			map[string]interface{}{"code": "USD", "issuer": issuer},
+		},
+		"tenants": []map[string]interface{}{
+			{"name": "acme", "assets": []interface{}{"GBP:" + issuer}},
+		},
+	}, &c)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://horizon-testnet.stellar.org", c.Horizon)
+	assert.Equal(t, []Asset{{"EUR", issuer}, {"USD", issuer}}, c.Assets)
+	assert.Equal(t, []Asset{{"GBP", issuer}}, c.Tenants[0].Assets)
+
+	err = Decode(map[string]interface{}{"assets": []interface{}{"EUR"}}, &c)
+	assert.Error(t, err)
+}
+
+func TestAssetFilter(t *testing.T) {
+	issuer := "GDSIKW43UA6JTOA47WVEBCZ4MYC74M3GNKNXTVDXFHXYYTNO5GGVN632"
+	other := "GAHA6GRCLCCN7XE2NEEUDSIVOFBOQ6GLSYXVLYCJXJKLPMDR5XB5XZZJ"
+
+	var c Config
+	err := Decode(map[string]interface{}{
+		"assets": []interface{}{"*:" + issuer, "USD:*"},
+	}, &c)
+	assert.NoError(t, err)
+	assert.Equal(t, []Asset{{"*", issuer}, {"USD", "*"}}, c.Assets)
+
+	filter := c.AssetFilter()
+	assert.True(t, filter.Allows("EUR", issuer))
+	assert.True(t, filter.Allows("USD", other))
+	assert.False(t, filter.Allows("EUR", other))
+	assert.False(t, filter.Allows("", ""))
+
+	filter = AssetFilter{{"", ""}}
+	assert.True(t, filter.Allows("", ""))
+	assert.False(t, filter.Allows("EUR", issuer))
+
+	err = Decode(map[string]interface{}{"assets": []interface{}{"*:GINVALID"}}, &c)
+	assert.Error(t, err)
+}
+
+func TestReload(t *testing.T) {
+	issuer := "GDSIKW43UA6JTOA47WVEBCZ4MYC74M3GNKNXTVDXFHXYYTNO5GGVN632"
+	port := 8001
+	newPort := 8002
+
+	c := Config{
+		Port:      &port,
+		Assets:    []Asset{{"USD", issuer}},
+		Callbacks: Callbacks{Receive: "http://localhost/receive"},
+	}
+	n := c
+	n.Assets = []Asset{{"EUR", issuer}}
+	n.Limits = []Limit{{Asset: Asset{"EUR", issuer}, MaxAmount: "100"}}
+	n.LogLevel = "debug"
+	n.Callbacks = Callbacks{Receive: "http://localhost/receive-v2", Trustline: "http://localhost/trustline"}
+
+	assert.Equal(t, []string{"callbacks"}, c.Reload(n))
+	assert.True(t, c.AssetFilter().Allows("EUR", issuer))
+	assert.False(t, c.AssetFilter().Allows("USD", issuer))
+	assert.Equal(t, "100", c.LimitFor("EUR", issuer).MaxAmount)
+	assert.Equal(t, "debug", c.LogLevel)
+	assert.Equal(t, "http://localhost/receive-v2", c.CurrentCallbacks().Receive)
+	// Trustline listener is not started without `trustline` callback
+	assert.Equal(t, "", c.CurrentCallbacks().Trustline)
+
+	n = c
+	n.Port = &newPort
+	n.Horizon = "https://horizon.stellar.org"
+	assert.Equal(t, []string{"port", "horizon"}, c.Reload(n))
+	assert.Equal(t, 8001, *c.Port)
+}
+
+func TestApplyEnv(t *testing.T) {
+	issuer := "GDSIKW43UA6JTOA47WVEBCZ4MYC74M3GNKNXTVDXFHXYYTNO5GGVN632"
+	seed := "SDZT3EJZ7FZRYNTLOZ7VH6G5UYBFO2IO3Q5PGONMILPCZU3AL7QNZHTE"
+
+	file, err := ioutil.TempFile("", "seed")
+	assert.NoError(t, err)
+	defer os.Remove(file.Name())
+	file.WriteString(seed + "\n")
+	file.Close()
+
+	env := map[string]string{
+		"BRIDGE_PORT":                    "9000",
+		"BRIDGE_ASSETS":                  "USD:" + issuer + ", native",
+		"BRIDGE_ACCOUNTS_BASE_SEED_FILE": file.Name(),
+		"BRIDGE_CALLBACKS_RECEIVE":       "https://example.com/receive",
+		"BRIDGE_ADMIN_API_KEY":           "admin-api-key-123",
+		"BRIDGE_STATSD_TAGS":             "env:prod,region:eu",
+	}
+	lookup := func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+
+	settings := map[string]interface{}{
+		"port":     8006,
+		"horizon":  "https://horizon-testnet.stellar.org",
+		"accounts": map[string]interface{}{"issuing_account_id": issuer},
+	}
+	assert.NoError(t, ApplyEnv(settings, EnvPrefix, lookup))
+
+	var c Config
+	assert.NoError(t, Decode(settings, &c))
+	assert.Equal(t, 9000, *c.Port)
+	assert.Equal(t, "https://horizon-testnet.stellar.org", c.Horizon)
+	assert.Equal(t, []Asset{{"USD", issuer}, {"", ""}}, c.Assets)
+	assert.Equal(t, seed, c.Accounts.BaseSeed)
+	assert.Equal(t, issuer, c.Accounts.IssuingAccountID)
+	assert.Equal(t, "https://example.com/receive", c.Callbacks.Receive)
+	assert.Equal(t, "admin-api-key-123", c.Admin.APIKey)
+	assert.Equal(t, []string{"env:prod", "region:eu"}, c.StatsD.Tags)
+
+	env["BRIDGE_ACCOUNTS_BASE_SEED"] = seed
+	assert.EqualError(t, ApplyEnv(settings, EnvPrefix, lookup), "BRIDGE_ACCOUNTS_BASE_SEED and BRIDGE_ACCOUNTS_BASE_SEED_FILE environment variables cannot be both set")
+
+	delete(env, "BRIDGE_ACCOUNTS_BASE_SEED")
+	env["BRIDGE_ACCOUNTS_BASE_SEED_FILE"] = "/nonexistent/seed"
+	assert.Error(t, ApplyEnv(settings, EnvPrefix, lookup))
+}
+
+func TestReceivingAccounts(t *testing.T) {
+	primary := "GATKP6ZQM5CSLECPMTAC5226PE367QALCPM6AFHTSULPPZMT62OOPMQB"
+	other := "GAHA6GRCLCCN7XE2NEEUDSIVOFBOQ6GLSYXVLYCJXJKLPMDR5XB5XZZJ"
+
+	assert.Nil(t, Accounts{}.ReceivingAccounts())
+
+	accounts := Accounts{ReceivingAccountID: primary, ReceivingAccountIDs: []string{other, primary}}
+	assert.Equal(t, []string{primary, other}, accounts.ReceivingAccounts())
+	assert.True(t, accounts.IsReceivingAccount(other))
+	assert.False(t, accounts.IsReceivingAccount(""))
+	assert.NoError(t, accounts.validate("accounts", signer.Config{}))
+
+	accounts = Accounts{ReceivingAccountIDs: []string{other}}
+	assert.Error(t, accounts.validate("accounts", signer.Config{}))
+
+	accounts = Accounts{ReceivingAccountID: primary, ReceivingAccountIDs: []string{"GINVALID"}}
+	assert.Error(t, accounts.validate("accounts", signer.Config{}))
+}
+
+func TestAccountsKeyReferences(t *testing.T) {
+	accounts := Accounts{BaseSeed: "vault:base", ChannelSeeds: []string{"vault:channel-1", "vault:channel-2"}}
+	assert.Error(t, accounts.validate("accounts", signer.Config{}))
+	assert.NoError(t, accounts.validate("accounts", signer.Config{Vault: signer.VaultConfig{URL: "http://localhost:8200"}}))
+
+	accounts = Accounts{BaseSeed: "vault:base", ChannelSeeds: []string{"vault:base"}}
+	assert.Error(t, accounts.validate("accounts", signer.Config{Vault: signer.VaultConfig{URL: "http://localhost:8200"}}))
+
+	accounts = Accounts{AuthorizingSeed: "aws_kms:"}
+	assert.Error(t, accounts.validate("accounts", signer.Config{AWSKMS: signer.AWSKMSConfig{Region: "us-east-1"}}))
+}
+
+func TestMemoFilter(t *testing.T) {
+	assert.True(t, MemoFilter{}.Allows("", ""))
+	assert.True(t, MemoFilter{}.Allows("id", "1"))
+
+	filter := MemoFilter{Prefix: "acme-"}
+	assert.True(t, filter.Allows("text", "acme-1"))
+	assert.False(t, filter.Allows("text", "other-1"))
+	assert.False(t, filter.Allows("id", "1"))
+
+	filter = MemoFilter{Regexp: "^[a-z]+-[0-9]+$"}
+	assert.True(t, filter.Allows("text", "acme-1"))
+	assert.False(t, filter.Allows("text", "acme-x"))
+	assert.NoError(t, filter.validate())
+	assert.Error(t, MemoFilter{Regexp: "("}.validate())
+}
+
+func TestSep31AssetFee(t *testing.T) {
+	asset := Sep31Asset{FeeFixed: "1", FeePercent: "0.5"}
+	assert.Equal(t, "1.5000000", asset.Fee(1000000000).String())
+	assert.Equal(t, "0.0000000", Sep31Asset{}.Fee(1000000000).String())
+}
+
+func TestDust(t *testing.T) {
+	issuer := "GDSIKW43UA6JTOA47WVEBCZ4MYC74M3GNKNXTVDXFHXYYTNO5GGVN632"
+	c := Config{Dust: Dust{Minimums: []DustMinimum{
+		{Asset: Asset{"USD", issuer}, Amount: "0.5"},
+		{Asset: Asset{AssetWildcard, AssetWildcard}, Amount: "0.01"},
+	}}}
+	require.NoError(t, c.validateDust())
+
+	minimum, ok := c.DustMinimumFor("USD", issuer)
+	assert.True(t, ok)
+	assert.Equal(t, "0.5000000", minimum.String())
+	minimum, ok = c.DustMinimumFor("EUR", issuer)
+	assert.True(t, ok)
+	assert.Equal(t, "0.0100000", minimum.String())
+	_, ok = (&Config{}).DustMinimumFor("", "")
+	assert.False(t, ok)
+
+	c.Dust.Minimums[0].Amount = "1e3"
+	assert.Error(t, c.validateDust())
+	c.Dust.Minimums[0].Amount = "1"
+
+	c.Dust.SweepAccount = issuer
+	assert.EqualError(t, c.validateDust(), "dust.sweep_account requires accounts.receiving_seed")
+	c.Accounts.ReceivingSeed = "SDZT3EJZ7FZRYNTLOZ7VH6G5UYBFO2IO3Q5PGONMILPCZU3AL7QNZHTE"
+	assert.Error(t, c.validateDust())
+	c.Database.Type = "sqlite3"
+	assert.NoError(t, c.validateDust())
+
+	c.Dust.SweepAccount = "GABC"
+	assert.EqualError(t, c.validateDust(), "dust.sweep_account is invalid")
+}
+
+func TestTimeouts(t *testing.T) {
+	var timeouts Timeouts
+	assert.Equal(t, DefaultHorizonTimeout*time.Second, timeouts.HorizonTimeout())
+	assert.Equal(t, DefaultComplianceTimeout*time.Second, timeouts.ComplianceTimeout())
+	assert.Equal(t, DefaultCallbacksTimeout*time.Second, timeouts.CallbacksTimeout())
+
+	timeouts = Timeouts{Horizon: 5, Compliance: 10, Callbacks: 15, Database: 2}
+	assert.Equal(t, 5*time.Second, timeouts.HorizonTimeout())
+	assert.Equal(t, 10*time.Second, timeouts.ComplianceTimeout())
+	assert.Equal(t, 15*time.Second, timeouts.CallbacksTimeout())
+
+	query := url.Values{"statement_timeout": {"100"}}
+	timeouts.databaseParams("postgres", query)
+	assert.Equal(t, "100", query.Get("statement_timeout"))
+	query = url.Values{}
+	timeouts.databaseParams("postgres", query)
+	assert.Equal(t, "2000", query.Get("statement_timeout"))
+	timeouts.databaseParams("sqlite3", query)
+	assert.Equal(t, "2000", query.Get("_busy_timeout"))
+	timeouts.databaseParams("mysql", query)
+	assert.Equal(t, "2s", query.Get("timeout"))
+
+	c := Config{Timeouts: Timeouts{Callbacks: -1}}
+	assert.EqualError(t, c.validateTimeouts(), "timeouts params cannot be negative")
+}
@@ -0,0 +1,37 @@
+package config
+
+import (
+	"errors"
+
+	"github.com/stellar/go-stellar-base/amount"
+)
+
+// DefaultStartingBalance is a starting balance of accounts created by /create-account endpoint
+// when it's not set in a request nor config
+const DefaultStartingBalance = "2"
+
+// CreateAccount contains values of `create_account` config group used by /create-account endpoint
+type CreateAccount struct {
+	// StartingBalance is an amount of XLM sent to created accounts. Default: DefaultStartingBalance.
+	StartingBalance string `mapstructure:"starting_balance"`
+}
+
+// StartingBalanceOrDefault returns StartingBalance or DefaultStartingBalance when it's not set
+func (c CreateAccount) StartingBalanceOrDefault() string {
+	if c.StartingBalance == "" {
+		return DefaultStartingBalance
+	}
+	return c.StartingBalance
+}
+
+func (c CreateAccount) validate() error {
+	if c.StartingBalance == "" {
+		return nil
+	}
+
+	_, err := amount.Parse(c.StartingBalance)
+	if err != nil {
+		return errors.New("Invalid create_account.starting_balance param")
+	}
+	return nil
+}
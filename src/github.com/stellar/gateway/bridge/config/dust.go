@@ -0,0 +1,86 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/stellar/gateway/protocols"
+	"github.com/stellar/gateway/protocols/amount"
+)
+
+// DefaultDustSweepInterval is a number of seconds between dust sweeps when
+// `dust.sweep_interval` is not set
+const DefaultDustSweepInterval = 86400
+
+// Dust contains values of `dust` config group. Received payments with amounts below the
+// minimum of their asset are saved with `dust_skipped` status and not sent to
+// `callbacks.receive`. When SweepAccount is set, skipped payments are periodically sent
+// from the receiving account to SweepAccount (one payment per asset).
+type Dust struct {
+	Minimums []DustMinimum
+	// SweepAccount is an account ID dust is sent to, dust is not swept when empty
+	SweepAccount string `mapstructure:"sweep_account"`
+	// SweepInterval is a number of seconds between sweeps. Default: DefaultDustSweepInterval.
+	SweepInterval int `mapstructure:"sweep_interval"`
+}
+
+// DustMinimum contains values of a single `dust.minimums` config array entry. Payments of
+// assets matching Asset (wildcards are allowed, see AssetFilter) with amounts lower than
+// Amount are dust.
+type DustMinimum struct {
+	Asset  Asset
+	Amount string
+}
+
+// DustMinimumFor returns minimum amount of received payments of asset with given code and
+// issuer (both empty for native asset) from the first matching `dust.minimums` entry. It
+// returns false when there is no minimum.
+func (c *Config) DustMinimumFor(code, issuer string) (amount.Amount, bool) {
+	reloadMutex.RLock()
+	defer reloadMutex.RUnlock()
+
+	for _, minimum := range c.Dust.Minimums {
+		if minimum.Asset.Matches(code, issuer) {
+			// Amount is checked in config validation
+			value, _ := amount.Parse(minimum.Amount)
+			return value, true
+		}
+	}
+	return 0, false
+}
+
+// SweepEnabled returns true when dust is swept to SweepAccount
+func (d Dust) SweepEnabled() bool {
+	return d.SweepAccount != ""
+}
+
+func (c *Config) validateDust() error {
+	for i, minimum := range c.Dust.Minimums {
+		_, err := amount.Parse(minimum.Amount)
+		if err != nil {
+			return fmt.Errorf("dust.minimums[%d].amount is invalid: %s", i, err)
+		}
+	}
+
+	if c.Dust.SweepInterval < 0 {
+		return errors.New("dust.sweep_interval cannot be negative")
+	}
+
+	if !c.Dust.SweepEnabled() {
+		return nil
+	}
+
+	if !protocols.IsValidAccountID(c.Dust.SweepAccount) {
+		return errors.New("dust.sweep_account is invalid")
+	}
+
+	if c.Accounts.ReceivingSeed == "" {
+		return errors.New("dust.sweep_account requires accounts.receiving_seed")
+	}
+
+	if c.Database.Type == "" {
+		return errors.New("database param is required when dust.sweep_account is set")
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"strings"
+)
+
+// EnvPrefix is a prefix of environment variables overriding config params, see ApplyEnv
+const EnvPrefix = "BRIDGE"
+
+// ApplyEnv overrides settings (ex. viper.AllSettings()) with environment variables returned
+// by lookup (ex. os.LookupEnv). A variable name is prefix followed by uppercased path of the
+// param, ex. BRIDGE_ACCOUNTS_BASE_SEED overrides `accounts.base_seed`. When <NAME>_FILE is
+// set instead, the param is read from this file (trailing newline is removed) so secrets
+// can be mounted as files. Arrays of strings and assets are comma-separated. Arrays of tables
+// (ex. `tenants`) cannot be overridden.
+func ApplyEnv(settings map[string]interface{}, prefix string, lookup func(string) (string, bool)) error {
+	return applyEnv(settings, reflect.TypeOf(Config{}), prefix, lookup)
+}
+
+func applyEnv(settings map[string]interface{}, t reflect.Type, prefix string, lookup func(string) (string, bool)) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			// unexported
+			continue
+		}
+
+		key := field.Tag.Get("mapstructure")
+		if key == "" {
+			key = strings.ToLower(field.Name)
+		}
+		name := prefix + "_" + strings.ToUpper(key)
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		switch fieldType.Kind() {
+		case reflect.Struct:
+			child, _ := settings[key].(map[string]interface{})
+			if child == nil {
+				child = map[string]interface{}{}
+			}
+			err := applyEnv(child, fieldType, name, lookup)
+			if err != nil {
+				return err
+			}
+			if len(child) > 0 {
+				settings[key] = child
+			}
+		case reflect.Slice:
+			elem := fieldType.Elem()
+			if elem.Kind() != reflect.String && elem != reflect.TypeOf(Asset{}) {
+				continue
+			}
+
+			value, ok, err := lookupEnv(name, lookup)
+			if err != nil {
+				return err
+			}
+			if ok {
+				values := []interface{}{}
+				for _, v := range strings.Split(value, ",") {
+					if v = strings.TrimSpace(v); v != "" {
+						values = append(values, v)
+					}
+				}
+				settings[key] = values
+			}
+		case reflect.String, reflect.Bool, reflect.Int, reflect.Int32, reflect.Int64, reflect.Uint32, reflect.Float64:
+			value, ok, err := lookupEnv(name, lookup)
+			if err != nil {
+				return err
+			}
+			if ok {
+				settings[key] = value
+			}
+		}
+	}
+	return nil
+}
+
+// lookupEnv returns a value of variable name or a content of a file pointed by name_FILE
+func lookupEnv(name string, lookup func(string) (string, bool)) (string, bool, error) {
+	value, ok := lookup(name)
+	file, fileOK := lookup(name + "_FILE")
+	if ok && fileOK {
+		return "", false, fmt.Errorf("%s and %s_FILE environment variables cannot be both set", name, name)
+	}
+
+	if !fileOK {
+		return value, ok, nil
+	}
+
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return "", false, fmt.Errorf("Cannot read %s_FILE: %s", name, err)
+	}
+	return strings.TrimRight(string(content), "\r\n"), true, nil
+}
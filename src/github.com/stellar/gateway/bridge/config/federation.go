@@ -0,0 +1,112 @@
+package config
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/stellar/go-stellar-base/keypair"
+)
+
+// Federation contains values of `federation` config group. When Domain is set, /federation
+// endpoint resolves `name*domain` addresses using Addresses and Query.
+type Federation struct {
+	Domain string
+	// Query is an SQL query returning `account_id`, `memo_type` and `memo` columns of a name
+	// passed as the only query param (`?`). Memo columns are optional.
+	Query string
+	// Addresses are resolved without querying the DB
+	Addresses []FederationAddress
+}
+
+// FederationAddress contains values of a single `federation.addresses` config array entry
+type FederationAddress struct {
+	Name      string
+	AccountID string `mapstructure:"account_id"`
+	MemoType  string `mapstructure:"memo_type"`
+	Memo      string
+}
+
+// Enabled returns true when /federation endpoint is enabled
+func (f Federation) Enabled() bool {
+	return f.Domain != ""
+}
+
+// Address returns `federation.addresses` entry of a name or nil when it's not found
+func (f Federation) Address(name string) *FederationAddress {
+	for i := range f.Addresses {
+		if f.Addresses[i].Name == name {
+			return &f.Addresses[i]
+		}
+	}
+	return nil
+}
+
+func (c *Config) validateFederation() error {
+	f := c.Federation
+	if !f.Enabled() {
+		if f.Query != "" || len(f.Addresses) > 0 {
+			return errors.New("federation.domain param is required when federation.query or federation.addresses are set")
+		}
+		return nil
+	}
+
+	if f.Query == "" && len(f.Addresses) == 0 {
+		return errors.New("federation.query or federation.addresses param is required when federation.domain is set")
+	}
+
+	if f.Query != "" && c.Database.Type == "" {
+		return errors.New("database param is required when federation.query is set")
+	}
+
+	names := map[string]bool{}
+	for _, address := range f.Addresses {
+		if address.Name == "" {
+			return errors.New("federation.addresses.name param is required")
+		}
+
+		if names[address.Name] {
+			return fmt.Errorf("Duplicate federation.addresses.name param: %s", address.Name)
+		}
+		names[address.Name] = true
+
+		_, err := keypair.Parse(address.AccountID)
+		if err != nil || address.AccountID[0] != 'G' {
+			return fmt.Errorf("Invalid federation.addresses.account_id param of %s", address.Name)
+		}
+
+		err = validateFederationMemo(address.MemoType, address.Memo)
+		if err != nil {
+			return fmt.Errorf("Invalid federation.addresses memo of %s: %s", address.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func validateFederationMemo(memoType, memo string) error {
+	switch memoType {
+	case "":
+		if memo != "" {
+			return errors.New("memo_type is required")
+		}
+	case "text":
+		if memo == "" || len(memo) > 28 {
+			return errors.New("text memo must be 1-28 bytes long")
+		}
+	case "id":
+		_, err := strconv.ParseUint(memo, 10, 64)
+		if err != nil {
+			return errors.New("id memo must be an unsigned integer")
+		}
+	case "hash":
+		hash, err := hex.DecodeString(memo)
+		if err != nil || len(hash) != 32 {
+			return errors.New("hash memo must be 32 bytes (hex encoded)")
+		}
+	default:
+		return errors.New("memo_type must be one of: text, id, hash")
+	}
+	return nil
+}
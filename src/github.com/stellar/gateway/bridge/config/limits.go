@@ -0,0 +1,60 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/stellar/gateway/protocols/amount"
+)
+
+// Limit contains values of a single `limits` config array entry. It limits amounts of
+// payments of assets matching Asset (wildcards are allowed, see AssetFilter) sent using
+// /payment endpoint. Amounts are decimal strings, a limit is not enforced when empty.
+type Limit struct {
+	Asset Asset
+	// MaxAmount is a maximum amount of a single payment
+	MaxAmount string `mapstructure:"max_amount"`
+	// DestinationDaily is a maximum amount sent to a single destination during a day (UTC)
+	DestinationDaily string `mapstructure:"destination_daily"`
+	// Hourly is a maximum amount of the asset sent during an hour
+	Hourly string
+}
+
+// LimitFor returns the first `limits` entry matching asset with given code and issuer
+// (both empty for native asset) or nil when payments of the asset are not limited
+func (c *Config) LimitFor(code, issuer string) *Limit {
+	reloadMutex.RLock()
+	defer reloadMutex.RUnlock()
+
+	for i := range c.Limits {
+		if c.Limits[i].Asset.Matches(code, issuer) {
+			return &c.Limits[i]
+		}
+	}
+	return nil
+}
+
+func (c *Config) validateLimits() error {
+	if len(c.Limits) > 0 && c.Database.Type == "" {
+		return errors.New("database param is required when limits are set")
+	}
+
+	for i, limit := range c.Limits {
+		for name, value := range map[string]string{
+			"max_amount":        limit.MaxAmount,
+			"destination_daily": limit.DestinationDaily,
+			"hourly":            limit.Hourly,
+		} {
+			if value == "" {
+				continue
+			}
+
+			_, err := amount.Parse(value)
+			if err != nil {
+				return fmt.Errorf("limits[%d].%s is invalid: %s", i, name, err)
+			}
+		}
+	}
+
+	return nil
+}
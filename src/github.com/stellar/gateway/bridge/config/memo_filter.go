@@ -0,0 +1,52 @@
+package config
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// MemoFilter contains values of `memo_filter` config group. When set, only payments with a
+// text memo starting with Prefix and matching Regexp are sent to `callbacks.receive`. It
+// allows to split payments to a single receiving account between many bridge servers.
+type MemoFilter struct {
+	Prefix string
+	Regexp string
+}
+
+// Enabled returns true when memo filter is configured
+func (f MemoFilter) Enabled() bool {
+	return f.Prefix != "" || f.Regexp != ""
+}
+
+// Allows returns true if payment with given memo should be processed. All memos are allowed
+// when the filter is not configured, otherwise only `text` memos can match.
+func (f MemoFilter) Allows(memoType, memo string) bool {
+	if !f.Enabled() {
+		return true
+	}
+
+	if memoType != "text" || !strings.HasPrefix(memo, f.Prefix) {
+		return false
+	}
+
+	if f.Regexp == "" {
+		return true
+	}
+
+	// Regexp is checked in config validation
+	matched, _ := regexp.MatchString(f.Regexp, memo)
+	return matched
+}
+
+func (f MemoFilter) validate() error {
+	if f.Regexp == "" {
+		return nil
+	}
+
+	_, err := regexp.Compile(f.Regexp)
+	if err != nil {
+		return errors.New("Invalid memo_filter.regexp param: " + err.Error())
+	}
+	return nil
+}
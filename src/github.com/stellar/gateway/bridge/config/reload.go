@@ -0,0 +1,59 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// reloadMutex guards params changed by Reload. They must be read using AssetFilter, LimitFor,
+// DustMinimumFor and CurrentCallbacks while the server is running.
+var reloadMutex sync.RWMutex
+
+// Reload applies params of validated config n which can be changed without restarting the
+// server: `assets`, `limits`, `dust.minimums`, `log_level` and `error`, `receive` and
+// `trustline` callbacks.
+// `receive` and `trustline` URLs can be changed but not added or removed because listeners
+// are started only when they are set. Reload returns names of other params that differ, they are ignored until
+// restart. Tenants are not compared, reload configs created by ForTenant instead.
+func (c *Config) Reload(n Config) (ignored []string) {
+	reloadMutex.Lock()
+	defer reloadMutex.Unlock()
+
+	c.Assets = n.Assets
+	c.Limits = n.Limits
+	c.Dust.Minimums = n.Dust.Minimums
+	c.LogLevel = n.LogLevel
+	c.Callbacks.Error = n.Callbacks.Error
+	if c.Callbacks.Receive != "" && n.Callbacks.Receive != "" {
+		c.Callbacks.Receive = n.Callbacks.Receive
+	}
+	if c.Callbacks.Trustline != "" && n.Callbacks.Trustline != "" {
+		c.Callbacks.Trustline = n.Callbacks.Trustline
+	}
+
+	current := reflect.ValueOf(*c)
+	next := reflect.ValueOf(n)
+	for i := 0; i < current.NumField(); i++ {
+		field := current.Type().Field(i)
+		if field.Name == "Tenants" || field.Name == "Tenant" {
+			continue
+		}
+
+		if !reflect.DeepEqual(current.Field(i).Interface(), next.Field(i).Interface()) {
+			name := field.Tag.Get("mapstructure")
+			if name == "" {
+				name = strings.ToLower(field.Name)
+			}
+			ignored = append(ignored, name)
+		}
+	}
+	return
+}
+
+// CurrentCallbacks returns `callbacks` config group. It's safe to call during Reload.
+func (c *Config) CurrentCallbacks() Callbacks {
+	reloadMutex.RLock()
+	defer reloadMutex.RUnlock()
+	return c.Callbacks
+}
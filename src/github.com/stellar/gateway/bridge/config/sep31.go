@@ -0,0 +1,119 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/stellar/gateway/protocols/amount"
+)
+
+// Sep31 contains values of `sep31` config group. SEP-31 direct payment endpoints
+// (/sep31/info, /sep31/transactions) are enabled when Assets are set.
+type Sep31 struct {
+	Assets []Sep31Asset
+	// Fields are `transaction` fields the sending anchor must provide
+	Fields []Sep31Field
+}
+
+// Sep31Asset contains values of a single `sep31.assets` config array entry. Amounts are
+// decimal strings.
+type Sep31Asset struct {
+	Asset      Asset
+	FeeFixed   string `mapstructure:"fee_fixed"`
+	FeePercent string `mapstructure:"fee_percent"`
+	MinAmount  string `mapstructure:"min_amount"`
+	MaxAmount  string `mapstructure:"max_amount"`
+}
+
+// Sep31Field contains values of a single `sep31.fields` config array entry
+type Sep31Field struct {
+	Name        string
+	Description string
+	Optional    bool
+	Choices     []string
+}
+
+// Enabled returns true when SEP-31 endpoints are enabled
+func (s Sep31) Enabled() bool {
+	return len(s.Assets) > 0
+}
+
+// AssetFor returns `sep31.assets` entry of asset with given code and issuer or nil when
+// the asset cannot be received using SEP-31. Empty issuer matches the first asset with the code.
+func (s Sep31) AssetFor(code, issuer string) *Sep31Asset {
+	for i := range s.Assets {
+		if s.Assets[i].Asset.Code == code && (issuer == "" || s.Assets[i].Asset.Issuer == issuer) {
+			return &s.Assets[i]
+		}
+	}
+	return nil
+}
+
+// Fee returns a fee charged for receiving amountIn of the asset
+func (a Sep31Asset) Fee(amountIn amount.Amount) amount.Amount {
+	return fee(a.FeeFixed, a.FeePercent, amountIn)
+}
+
+// fee returns feeFixed + feePercent of amountIn. Fee values are checked in config validation.
+func fee(feeFixed, feePercent string, amountIn amount.Amount) amount.Amount {
+	fixed, _ := parseOptionalAmount(feeFixed)
+	percent, _ := parseOptionalAmount(feePercent)
+
+	// amountIn * percent / 100, percent is in stroops as well
+	percentFee := new(big.Int).Mul(big.NewInt(int64(amountIn)), big.NewInt(int64(percent)))
+	percentFee.Quo(percentFee, big.NewInt(100*10000000))
+
+	return fixed + amount.Amount(percentFee.Int64())
+}
+
+func parseOptionalAmount(value string) (amount.Amount, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return amount.Parse(value)
+}
+
+func (c *Config) validateSep31() error {
+	if !c.Sep31.Enabled() {
+		return nil
+	}
+
+	if c.Database.Type == "" {
+		return errors.New("database param is required when sep31.assets are set")
+	}
+
+	if !c.WebAuth.Enabled() {
+		return errors.New("web_auth.signing_seed param is required when sep31.assets are set")
+	}
+
+	if c.Accounts.ReceivingAccountID == "" {
+		return errors.New("accounts.receiving_account_id param is required when sep31.assets are set")
+	}
+
+	for i, asset := range c.Sep31.Assets {
+		if asset.Asset.Code == "" || asset.Asset.Code == AssetWildcard || asset.Asset.Issuer == AssetWildcard {
+			return fmt.Errorf("sep31.assets[%d].asset must be a credit asset without wildcards", i)
+		}
+
+		for name, value := range map[string]string{
+			"fee_fixed":   asset.FeeFixed,
+			"fee_percent": asset.FeePercent,
+			"min_amount":  asset.MinAmount,
+			"max_amount":  asset.MaxAmount,
+		} {
+			_, err := parseOptionalAmount(value)
+			if err != nil {
+				return fmt.Errorf("sep31.assets[%d].%s is invalid: %s", i, name, err)
+			}
+		}
+	}
+
+	for i, field := range c.Sep31.Fields {
+		if field.Name == "" {
+			return fmt.Errorf("sep31.fields[%d].name param is required", i)
+		}
+	}
+
+	return nil
+}
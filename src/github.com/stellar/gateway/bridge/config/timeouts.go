@@ -0,0 +1,84 @@
+package config
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Default timeouts (in seconds) used when `timeouts` params are not set
+const (
+	DefaultHorizonTimeout    = 30
+	DefaultComplianceTimeout = 60
+	DefaultCallbacksTimeout  = 60
+)
+
+// Timeouts contains values of `timeouts` config group. Every value is a number of seconds
+// after which a request to a downstream service is cancelled so a hung service can't block
+// the bridge server forever. Defaults are used when values are 0.
+type Timeouts struct {
+	// Horizon limits requests to Horizon (except streams). Default: DefaultHorizonTimeout.
+	Horizon int
+	// Compliance limits requests to the compliance server. Default: DefaultComplianceTimeout.
+	Compliance int
+	// Callbacks limits requests to `callbacks` URLs. Default: DefaultCallbacksTimeout.
+	Callbacks int
+	// Database limits DB queries, driver defaults are used when 0. See databaseParams.
+	Database int
+}
+
+// HorizonTimeout returns the timeout of requests to Horizon
+func (t Timeouts) HorizonTimeout() time.Duration {
+	return timeout(t.Horizon, DefaultHorizonTimeout)
+}
+
+// ComplianceTimeout returns the timeout of requests to the compliance server
+func (t Timeouts) ComplianceTimeout() time.Duration {
+	return timeout(t.Compliance, DefaultComplianceTimeout)
+}
+
+// CallbacksTimeout returns the timeout of requests to callbacks
+func (t Timeouts) CallbacksTimeout() time.Duration {
+	return timeout(t.Callbacks, DefaultCallbacksTimeout)
+}
+
+func timeout(seconds, defaultSeconds int) time.Duration {
+	if seconds == 0 {
+		seconds = defaultSeconds
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// databaseParams adds params limiting duration of queries to query of `database.url` of a
+// given type: `statement_timeout` (postgres), `_busy_timeout` (sqlite3) and `timeout`
+// (mysql, the old driver does not support query timeouts so only connecting is limited).
+// Params already present in `database.url` are not changed.
+func (t Timeouts) databaseParams(databaseType string, query url.Values) {
+	if t.Database == 0 {
+		return
+	}
+
+	milliseconds := strconv.Itoa(t.Database * 1000)
+	switch databaseType {
+	case "postgres":
+		setDefault(query, "statement_timeout", milliseconds)
+	case "sqlite3":
+		setDefault(query, "_busy_timeout", milliseconds)
+	case "mysql":
+		setDefault(query, "timeout", (time.Duration(t.Database) * time.Second).String())
+	}
+}
+
+func setDefault(query url.Values, key, value string) {
+	if query.Get(key) == "" {
+		query.Set(key, value)
+	}
+}
+
+func (c *Config) validateTimeouts() error {
+	if c.Timeouts.Horizon < 0 || c.Timeouts.Compliance < 0 || c.Timeouts.Callbacks < 0 || c.Timeouts.Database < 0 {
+		return errors.New("timeouts params cannot be negative")
+	}
+	return nil
+}
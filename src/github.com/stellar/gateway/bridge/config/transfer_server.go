@@ -0,0 +1,158 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/stellar/gateway/protocols/amount"
+)
+
+// TransferServer contains values of `transfer_server` config group. SEP-6 deposit and
+// withdrawal endpoints (/sep6/...) are enabled when Assets are set.
+type TransferServer struct {
+	Assets []TransferAsset
+	// InteractiveURL is a URL of a web app collecting user info of SEP-24 interactive
+	// deposits and withdrawals. SEP-24 endpoints (/sep24/...) are enabled when it's set.
+	InteractiveURL string `mapstructure:"interactive_url"`
+}
+
+// TransferAsset contains values of a single `transfer_server.assets` config array entry.
+// Amounts are decimal strings.
+type TransferAsset struct {
+	Asset    Asset
+	Deposit  bool
+	Withdraw bool
+	// DepositTypes and WithdrawTypes are accepted values of `type` param, ex. `SEPA`.
+	// Any type is accepted when empty.
+	DepositTypes  []string `mapstructure:"deposit_types"`
+	WithdrawTypes []string `mapstructure:"withdraw_types"`
+	FeeFixed      string   `mapstructure:"fee_fixed"`
+	FeePercent    string   `mapstructure:"fee_percent"`
+	MinAmount     string   `mapstructure:"min_amount"`
+	MaxAmount     string   `mapstructure:"max_amount"`
+}
+
+// Enabled returns true when SEP-6 endpoints are enabled
+func (t TransferServer) Enabled() bool {
+	return len(t.Assets) > 0
+}
+
+// Interactive returns true when SEP-24 endpoints are enabled
+func (t TransferServer) Interactive() bool {
+	return t.Enabled() && t.InteractiveURL != ""
+}
+
+// AssetFor returns `transfer_server.assets` entry of asset with given code or nil when it's
+// not found
+func (t TransferServer) AssetFor(code string) *TransferAsset {
+	for i := range t.Assets {
+		if t.Assets[i].Asset.Code == code {
+			return &t.Assets[i]
+		}
+	}
+	return nil
+}
+
+// HasDeposits returns true when at least one asset can be deposited
+func (t TransferServer) HasDeposits() bool {
+	for _, asset := range t.Assets {
+		if asset.Deposit {
+			return true
+		}
+	}
+	return false
+}
+
+// HasWithdrawals returns true when at least one asset can be withdrawn
+func (t TransferServer) HasWithdrawals() bool {
+	for _, asset := range t.Assets {
+		if asset.Withdraw {
+			return true
+		}
+	}
+	return false
+}
+
+// Fee returns a fee charged for depositing or withdrawing amountIn of the asset
+func (a TransferAsset) Fee(amountIn amount.Amount) amount.Amount {
+	return fee(a.FeeFixed, a.FeePercent, amountIn)
+}
+
+// AllowsType returns true when `type` param value is accepted by deposits (or withdrawals
+// when withdraw is true) of the asset
+func (a TransferAsset) AllowsType(transferType string, withdraw bool) bool {
+	types := a.DepositTypes
+	if withdraw {
+		types = a.WithdrawTypes
+	}
+	if len(types) == 0 {
+		return true
+	}
+	for _, t := range types {
+		if t == transferType {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Config) validateTransferServer() error {
+	t := c.TransferServer
+	if !t.Enabled() {
+		return nil
+	}
+
+	if c.Database.Type == "" {
+		return errors.New("database param is required when transfer_server.assets are set")
+	}
+
+	if !c.WebAuth.Enabled() {
+		return errors.New("web_auth.signing_seed param is required when transfer_server.assets are set")
+	}
+
+	if t.InteractiveURL != "" {
+		u, err := url.Parse(t.InteractiveURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.New("Cannot parse transfer_server.interactive_url param")
+		}
+	}
+
+	if t.HasDeposits() && c.Callbacks.Deposit == "" {
+		return errors.New("callbacks.deposit param is required when transfer_server.assets can be deposited")
+	}
+
+	if t.HasWithdrawals() && c.Accounts.ReceivingAccountID == "" {
+		return errors.New("accounts.receiving_account_id param is required when transfer_server.assets can be withdrawn")
+	}
+
+	codes := map[string]bool{}
+	for i, asset := range t.Assets {
+		if asset.Asset.Code == "" || asset.Asset.Code == AssetWildcard || asset.Asset.Issuer == AssetWildcard {
+			return fmt.Errorf("transfer_server.assets[%d].asset must be a credit asset without wildcards", i)
+		}
+
+		if codes[asset.Asset.Code] {
+			return fmt.Errorf("Duplicate transfer_server.assets asset code: %s", asset.Asset.Code)
+		}
+		codes[asset.Asset.Code] = true
+
+		if !asset.Deposit && !asset.Withdraw {
+			return fmt.Errorf("transfer_server.assets[%d] must enable deposit or withdraw", i)
+		}
+
+		for name, value := range map[string]string{
+			"fee_fixed":   asset.FeeFixed,
+			"fee_percent": asset.FeePercent,
+			"min_amount":  asset.MinAmount,
+			"max_amount":  asset.MaxAmount,
+		} {
+			_, err := parseOptionalAmount(value)
+			if err != nil {
+				return fmt.Errorf("transfer_server.assets[%d].%s is invalid: %s", i, name, err)
+			}
+		}
+	}
+
+	return nil
+}
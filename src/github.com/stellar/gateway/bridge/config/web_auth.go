@@ -0,0 +1,84 @@
+package config
+
+import (
+	"errors"
+
+	"github.com/stellar/go-stellar-base/keypair"
+	"github.com/stellar/go-stellar-base/strkey"
+)
+
+// WebAuth contains values of `web_auth` config group. SEP-10 `/auth` endpoint is enabled
+// when SigningSeed is set.
+type WebAuth struct {
+	// SigningSeed is a secret key of the account signing challenge transactions
+	SigningSeed string `mapstructure:"signing_seed"`
+	// JWTKey is a Stellar secret key used as an HMAC key of issued tokens
+	JWTKey string `mapstructure:"jwt_key"`
+	// HomeDomain is used in challenge transactions and as an issuer of tokens
+	HomeDomain string `mapstructure:"home_domain"`
+	JWTTTL     int    `mapstructure:"jwt_ttl"` // seconds, default: 86400
+	// Endpoints require a token (`Authorization: Bearer <token>` header), ex. `/payment`
+	Endpoints []string
+	// Accounts allowed to authenticate. All accounts are allowed when empty.
+	Accounts []string
+}
+
+// Enabled returns true when `/auth` endpoint is enabled
+func (w WebAuth) Enabled() bool {
+	return w.SigningSeed != ""
+}
+
+// Key returns decoded JWTKey
+func (w WebAuth) Key() ([]byte, error) {
+	return strkey.Decode(strkey.VersionByteSeed, w.JWTKey)
+}
+
+// AllowsAccount returns true when accountID is allowed to authenticate
+func (w WebAuth) AllowsAccount(accountID string) bool {
+	if len(w.Accounts) == 0 {
+		return true
+	}
+
+	for _, account := range w.Accounts {
+		if account == accountID {
+			return true
+		}
+	}
+	return false
+}
+
+func (w WebAuth) validate() error {
+	if !w.Enabled() {
+		if len(w.Endpoints) > 0 {
+			return errors.New("web_auth.signing_seed param is required when web_auth.endpoints are set")
+		}
+		return nil
+	}
+
+	_, err := keypair.Parse(w.SigningSeed)
+	if err != nil {
+		return errors.New("web_auth.signing_seed is invalid")
+	}
+
+	_, err = w.Key()
+	if err != nil {
+		return errors.New("web_auth.jwt_key must be a Stellar secret key")
+	}
+
+	if w.HomeDomain == "" {
+		return errors.New("web_auth.home_domain param is required")
+	}
+
+	if w.JWTTTL < 0 {
+		return errors.New("web_auth.jwt_ttl cannot be negative")
+	}
+
+	for _, account := range w.Accounts {
+		_, err = strkey.Decode(strkey.VersionByteAccountID, account)
+		if err != nil {
+			return errors.New("web_auth.accounts contains invalid account ID: " + account)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,379 @@
+package grpc
+
+import (
+	"time"
+
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/protocols"
+	"github.com/stellar/gateway/protocols/bridge"
+)
+
+// Message is a protobuf message defined in bridge.proto
+type Message interface {
+	Marshal() []byte
+	Unmarshal(data []byte) error
+}
+
+// Asset is `Asset` message
+type Asset struct {
+	Code   string
+	Issuer string
+}
+
+// Marshal encodes the message
+func (m *Asset) Marshal() []byte {
+	e := &encoder{}
+	e.string(1, m.Code)
+	e.string(2, m.Issuer)
+	return e.buf
+}
+
+// Unmarshal decodes the message
+func (m *Asset) Unmarshal(data []byte) error {
+	return decode(data, func(field, wireType int, value uint64, data []byte) error {
+		switch field {
+		case 1:
+			m.Code = string(data)
+		case 2:
+			m.Issuer = string(data)
+		}
+		return nil
+	})
+}
+
+// PaymentRequest is `PaymentRequest` message
+type PaymentRequest struct {
+	Source            string
+	Sender            string
+	Destination       string
+	MemoType          string
+	Memo              string
+	Amount            string
+	AssetCode         string
+	AssetIssuer       string
+	Asset             string
+	SendMax           string
+	SendAssetCode     string
+	SendAssetIssuer   string
+	SendAsset         string
+	Path              []Asset
+	ExtraMemo         string
+	ReceivedPaymentID string
+	Metadata          string
+	IdempotencyKey    string
+}
+
+// Marshal encodes the message
+func (m *PaymentRequest) Marshal() []byte {
+	e := &encoder{}
+	for i, value := range m.strings() {
+		e.string(i+1, *value)
+	}
+	for i := range m.Path {
+		e.message(14, &m.Path[i])
+	}
+	return e.buf
+}
+
+// Unmarshal decodes the message
+func (m *PaymentRequest) Unmarshal(data []byte) error {
+	fields := m.strings()
+	return decode(data, func(field, wireType int, value uint64, data []byte) error {
+		if field == 14 {
+			var asset Asset
+			err := asset.Unmarshal(data)
+			if err != nil {
+				return err
+			}
+			m.Path = append(m.Path, asset)
+		} else if field >= 1 && field <= len(fields) {
+			*fields[field-1] = string(data)
+		}
+		return nil
+	})
+}
+
+// strings returns string fields in order of their numbers. Field 14 (path) is
+// a placeholder as it's the only field that is not a string.
+func (m *PaymentRequest) strings() []*string {
+	var path string
+	return []*string{
+		&m.Source, &m.Sender, &m.Destination, &m.MemoType, &m.Memo, &m.Amount,
+		&m.AssetCode, &m.AssetIssuer, &m.Asset, &m.SendMax, &m.SendAssetCode,
+		&m.SendAssetIssuer, &m.SendAsset, &path, &m.ExtraMemo, &m.ReceivedPaymentID,
+		&m.Metadata, &m.IdempotencyKey,
+	}
+}
+
+// toBridgeRequest transforms the message to a request of /payment endpoint
+func (m *PaymentRequest) toBridgeRequest() *bridge.PaymentRequest {
+	request := &bridge.PaymentRequest{
+		Source:            m.Source,
+		Sender:            m.Sender,
+		Destination:       m.Destination,
+		MemoType:          m.MemoType,
+		Memo:              m.Memo,
+		Amount:            m.Amount,
+		AssetCode:         m.AssetCode,
+		AssetIssuer:       m.AssetIssuer,
+		Asset:             m.Asset,
+		SendMax:           m.SendMax,
+		SendAssetCode:     m.SendAssetCode,
+		SendAssetIssuer:   m.SendAssetIssuer,
+		SendAsset:         m.SendAsset,
+		ExtraMemo:         m.ExtraMemo,
+		ReceivedPaymentID: m.ReceivedPaymentID,
+		Metadata:          m.Metadata,
+		IdempotencyKey:    m.IdempotencyKey,
+	}
+	for _, asset := range m.Path {
+		request.Path = append(request.Path, protocols.Asset{Code: asset.Code, Issuer: asset.Issuer})
+	}
+	return request
+}
+
+// PaymentResponse is `PaymentResponse` message
+type PaymentResponse struct {
+	Hash      string
+	Ledger    uint64
+	ResultXdr string
+}
+
+// Marshal encodes the message
+func (m *PaymentResponse) Marshal() []byte {
+	e := &encoder{}
+	e.string(1, m.Hash)
+	e.uint64(2, m.Ledger)
+	e.string(3, m.ResultXdr)
+	return e.buf
+}
+
+// Unmarshal decodes the message
+func (m *PaymentResponse) Unmarshal(data []byte) error {
+	return decode(data, func(field, wireType int, value uint64, data []byte) error {
+		switch field {
+		case 1:
+			m.Hash = string(data)
+		case 2:
+			m.Ledger = value
+		case 3:
+			m.ResultXdr = string(data)
+		}
+		return nil
+	})
+}
+
+// Operation is `Operation` message
+type Operation struct {
+	Type string
+	// JSON object
+	Body string
+}
+
+// Marshal encodes the message
+func (m *Operation) Marshal() []byte {
+	e := &encoder{}
+	e.string(1, m.Type)
+	e.string(2, m.Body)
+	return e.buf
+}
+
+// Unmarshal decodes the message
+func (m *Operation) Unmarshal(data []byte) error {
+	return decode(data, func(field, wireType int, value uint64, data []byte) error {
+		switch field {
+		case 1:
+			m.Type = string(data)
+		case 2:
+			m.Body = string(data)
+		}
+		return nil
+	})
+}
+
+// BuilderRequest is `BuilderRequest` message
+type BuilderRequest struct {
+	Source         string
+	SequenceNumber string
+	Operations     []Operation
+	Signers        []string
+}
+
+// Marshal encodes the message
+func (m *BuilderRequest) Marshal() []byte {
+	e := &encoder{}
+	e.string(1, m.Source)
+	e.string(2, m.SequenceNumber)
+	for i := range m.Operations {
+		e.message(3, &m.Operations[i])
+	}
+	for _, signer := range m.Signers {
+		e.bytes(4, []byte(signer))
+	}
+	return e.buf
+}
+
+// Unmarshal decodes the message
+func (m *BuilderRequest) Unmarshal(data []byte) error {
+	return decode(data, func(field, wireType int, value uint64, data []byte) error {
+		switch field {
+		case 1:
+			m.Source = string(data)
+		case 2:
+			m.SequenceNumber = string(data)
+		case 3:
+			var operation Operation
+			err := operation.Unmarshal(data)
+			if err != nil {
+				return err
+			}
+			m.Operations = append(m.Operations, operation)
+		case 4:
+			m.Signers = append(m.Signers, string(data))
+		}
+		return nil
+	})
+}
+
+// BuilderResponse is `BuilderResponse` message
+type BuilderResponse struct {
+	TransactionEnvelope string
+}
+
+// Marshal encodes the message
+func (m *BuilderResponse) Marshal() []byte {
+	e := &encoder{}
+	e.string(1, m.TransactionEnvelope)
+	return e.buf
+}
+
+// Unmarshal decodes the message
+func (m *BuilderResponse) Unmarshal(data []byte) error {
+	return decode(data, func(field, wireType int, value uint64, data []byte) error {
+		if field == 1 {
+			m.TransactionEnvelope = string(data)
+		}
+		return nil
+	})
+}
+
+// GetReceivedPaymentRequest is `GetReceivedPaymentRequest` message
+type GetReceivedPaymentRequest struct {
+	ID string
+}
+
+// Marshal encodes the message
+func (m *GetReceivedPaymentRequest) Marshal() []byte {
+	e := &encoder{}
+	e.string(1, m.ID)
+	return e.buf
+}
+
+// Unmarshal decodes the message
+func (m *GetReceivedPaymentRequest) Unmarshal(data []byte) error {
+	return decode(data, func(field, wireType int, value uint64, data []byte) error {
+		if field == 1 {
+			m.ID = string(data)
+		}
+		return nil
+	})
+}
+
+// StreamReceivedPaymentsRequest is `StreamReceivedPaymentsRequest` message
+type StreamReceivedPaymentsRequest struct {
+	Cursor int64
+}
+
+// Marshal encodes the message
+func (m *StreamReceivedPaymentsRequest) Marshal() []byte {
+	e := &encoder{}
+	e.uint64(1, uint64(m.Cursor))
+	return e.buf
+}
+
+// Unmarshal decodes the message
+func (m *StreamReceivedPaymentsRequest) Unmarshal(data []byte) error {
+	return decode(data, func(field, wireType int, value uint64, data []byte) error {
+		if field == 1 {
+			m.Cursor = int64(value)
+		}
+		return nil
+	})
+}
+
+// ReceivedPayment is `ReceivedPayment` message
+type ReceivedPayment struct {
+	ID                   string
+	Status               string
+	ProcessedAt          string
+	From                 string
+	To                   string
+	Amount               string
+	AssetCode            string
+	AssetIssuer          string
+	Asset                string
+	MemoType             string
+	Memo                 string
+	OutgoingTransactions []string
+	Cursor               int64
+}
+
+// newReceivedPayment creates ReceivedPayment from a DB entity and links of transactions sent on its behalf
+func newReceivedPayment(payment *entities.ReceivedPayment, links []entities.PaymentLink) *ReceivedPayment {
+	response := bridge.NewReceivedPayment(payment, links)
+	return &ReceivedPayment{
+		ID:                   response.ID,
+		Status:               response.Status,
+		ProcessedAt:          response.ProcessedAt.UTC().Format(time.RFC3339),
+		From:                 response.From,
+		To:                   response.To,
+		Amount:               response.Amount,
+		AssetCode:            response.AssetCode,
+		AssetIssuer:          response.AssetIssuer,
+		Asset:                response.Asset,
+		MemoType:             response.MemoType,
+		Memo:                 response.Memo,
+		OutgoingTransactions: response.OutgoingTransactions,
+		Cursor:               *payment.ID,
+	}
+}
+
+// Marshal encodes the message
+func (m *ReceivedPayment) Marshal() []byte {
+	e := &encoder{}
+	e.string(1, m.ID)
+	e.string(2, m.Status)
+	e.string(3, m.ProcessedAt)
+	e.string(4, m.From)
+	e.string(5, m.To)
+	e.string(6, m.Amount)
+	e.string(7, m.AssetCode)
+	e.string(8, m.AssetIssuer)
+	e.string(9, m.Asset)
+	e.string(10, m.MemoType)
+	e.string(11, m.Memo)
+	for _, transactionID := range m.OutgoingTransactions {
+		e.bytes(12, []byte(transactionID))
+	}
+	e.uint64(13, uint64(m.Cursor))
+	return e.buf
+}
+
+// Unmarshal decodes the message
+func (m *ReceivedPayment) Unmarshal(data []byte) error {
+	fields := []*string{
+		&m.ID, &m.Status, &m.ProcessedAt, &m.From, &m.To, &m.Amount,
+		&m.AssetCode, &m.AssetIssuer, &m.Asset, &m.MemoType, &m.Memo,
+	}
+	return decode(data, func(field, wireType int, value uint64, data []byte) error {
+		switch {
+		case field == 12:
+			m.OutgoingTransactions = append(m.OutgoingTransactions, string(data))
+		case field == 13:
+			m.Cursor = int64(value)
+		case field >= 1 && field <= len(fields):
+			*fields[field-1] = string(data)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,563 @@
+// Package grpc implements gRPC API of the bridge server defined in bridge.proto. It's served
+// over HTTP/2 without TLS (h2c) and calls the same handlers as HTTP API so requests are
+// validated and processed exactly like /payment and /builder requests.
+package grpc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/bridge/auth"
+	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/bridge/handlers"
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/protocols"
+	"github.com/stellar/gateway/protocols/bridge"
+	"golang.org/x/net/http2"
+)
+
+// ServiceName is a fully qualified name of the service defined in bridge.proto
+const ServiceName = "stellar.bridge.v1.Bridge"
+
+// maxMessageSize is the maximum size of a request message
+const maxMessageSize = 4 << 20
+
+// pollInterval is an interval of DB queries made while streaming received payments
+var pollInterval = time.Second
+
+// gRPC status codes
+const (
+	codeOK                 = 0
+	codeCanceled           = 1
+	codeInvalidArgument    = 3
+	codeNotFound           = 5
+	codePermissionDenied   = 7
+	codeResourceExhausted  = 8
+	codeFailedPrecondition = 9
+	codeAborted            = 10
+	codeUnimplemented      = 12
+	codeInternal           = 13
+	codeUnavailable        = 14
+	codeUnauthenticated    = 16
+)
+
+// Status is a status of a gRPC call sent in `grpc-status` and `grpc-message` trailers.
+// ErrorCode is a `code` of the HTTP API error response sent in `bridge-error-code` trailer.
+type Status struct {
+	Code      int
+	Message   string
+	ErrorCode string
+}
+
+var (
+	statusOK              = &Status{Code: codeOK}
+	statusInternal        = &Status{Code: codeInternal, Message: protocols.InternalServerError.Message, ErrorCode: protocols.InternalServerError.Code}
+	statusInvalidMessage  = &Status{Code: codeInvalidArgument, Message: "Invalid request message."}
+	statusUnauthenticated = &Status{Code: codeUnauthenticated, Message: "Invalid api-key."}
+	statusUnknownTenant   = &Status{Code: codeNotFound, Message: "Unknown tenant."}
+	statusNoDatabase      = &Status{Code: codeFailedPrecondition, Message: "Received payments are not stored. database is required."}
+)
+
+// newStatus creates Status of an error response written by HTTP API handlers
+func newStatus(errorResponse *protocols.ErrorResponse) *Status {
+	status := &Status{Message: errorResponse.Message, ErrorCode: errorResponse.Code}
+	switch errorResponse.Status {
+	case http.StatusBadRequest:
+		status.Code = codeInvalidArgument
+	case http.StatusUnauthorized:
+		status.Code = codeUnauthenticated
+	case http.StatusForbidden:
+		status.Code = codePermissionDenied
+	case http.StatusNotFound:
+		status.Code = codeNotFound
+	case http.StatusConflict:
+		status.Code = codeAborted
+	case http.StatusTooManyRequests:
+		status.Code = codeResourceExhausted
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		status.Code = codeUnavailable
+	default:
+		status.Code = codeInternal
+	}
+	return status
+}
+
+// Server serves gRPC API. Requests are authenticated using `api-key` metadata and
+// routed to tenants like HTTP API requests: using `tenant` metadata or the api key.
+type Server struct {
+	// Default is a request handler of the default tenant
+	Default *handlers.RequestHandler
+	// Tenants are request handlers of other tenants
+	Tenants []*handlers.RequestHandler
+	// Authenticator authenticates requests using `api-key` or `authorization: Bearer <token>`
+	// metadata when `auth` is configured. API keys of tenants are not used then.
+	Authenticator *auth.Authenticator
+
+	h2       http2.Server
+	mu       sync.Mutex
+	listener net.Listener
+	stopped  chan struct{}
+	stopOnce sync.Once
+}
+
+// NewServer creates a new Server
+func NewServer(defaultHandler *handlers.RequestHandler, tenants []*handlers.RequestHandler) *Server {
+	return &Server{
+		Default: defaultHandler,
+		Tenants: tenants,
+		stopped: make(chan struct{}),
+	}
+}
+
+// ListenAndServe listens on addr and serves gRPC requests until Stop is called
+func (s *Server) ListenAndServe(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(listener)
+}
+
+// Serve serves gRPC requests of connections accepted by listener until Stop is called
+func (s *Server) Serve(listener net.Listener) error {
+	s.mu.Lock()
+	s.listener = listener
+	s.mu.Unlock()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			select {
+			case <-s.stopped:
+				return nil
+			default:
+				return err
+			}
+		}
+		go s.h2.ServeConn(conn, &http2.ServeConnOpts{Handler: s})
+	}
+}
+
+// Stop stops accepting new connections and ends streams of received payments
+func (s *Server) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopped)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.listener != nil {
+			s.listener.Close()
+		}
+	})
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || !strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+		http.Error(w, "Only gRPC requests are supported", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/grpc")
+	w.Header().Set("Trailer", "Grpc-Status, Grpc-Message, Bridge-Error-Code")
+	w.WriteHeader(http.StatusOK)
+
+	status := s.call(w, r)
+
+	w.Header().Set("Grpc-Status", strconv.Itoa(status.Code))
+	if status.Message != "" {
+		w.Header().Set("Grpc-Message", encodeMessage(status.Message))
+	}
+	if status.ErrorCode != "" {
+		w.Header().Set("Bridge-Error-Code", status.ErrorCode)
+	}
+}
+
+// call reads request message and calls the method from request path
+func (s *Server) call(w http.ResponseWriter, r *http.Request) *Status {
+	var rh *handlers.RequestHandler
+	if s.Authenticator != nil {
+		var status *Status
+		rh, status = s.authenticate(r)
+		if status != nil {
+			return status
+		}
+	} else {
+		rh = s.requestHandler(r)
+		if rh == nil {
+			return statusUnauthenticated
+		}
+	}
+
+	data, err := readMessage(r.Body)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error reading gRPC request message")
+		return statusInvalidMessage
+	}
+
+	var response Message
+	var status *Status
+	switch r.URL.Path {
+	case "/" + ServiceName + "/Payment":
+		response, status = s.payment(rh, r, data)
+	case "/" + ServiceName + "/Build":
+		response, status = s.build(rh, r, data)
+	case "/" + ServiceName + "/GetReceivedPayment":
+		response, status = s.getReceivedPayment(rh, data)
+	case "/" + ServiceName + "/StreamReceivedPayments":
+		return s.streamReceivedPayments(rh, w, r, data)
+	default:
+		return &Status{Code: codeUnimplemented, Message: "Unknown method " + r.URL.Path}
+	}
+
+	if status != nil {
+		return status
+	}
+
+	err = writeMessage(w, response)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error writing gRPC response message")
+		return statusInternal
+	}
+	return statusOK
+}
+
+// requestHandler returns request handler of a tenant selected by request metadata. It
+// returns nil when the api key is invalid.
+func (s *Server) requestHandler(r *http.Request) *handlers.RequestHandler {
+	key := r.Header.Get("Api-Key")
+
+	if name := r.Header.Get("Tenant"); name != "" {
+		for _, rh := range s.Tenants {
+			if rh.Config.Tenant == name && (rh.Config.APIKey == "" || key == rh.Config.APIKey) {
+				return rh
+			}
+		}
+		return nil
+	}
+
+	for _, rh := range s.Tenants {
+		if rh.Config.APIKey != "" && key == rh.Config.APIKey {
+			return rh
+		}
+	}
+
+	if s.Default.Config.APIKey != "" && key != s.Default.Config.APIKey {
+		return nil
+	}
+	return s.Default
+}
+
+// authenticate authenticates a request using Authenticator and returns request handler of
+// a tenant selected by `tenant` metadata or the tenant of the principal
+func (s *Server) authenticate(r *http.Request) (*handlers.RequestHandler, *Status) {
+	token := ""
+	if authorization := r.Header.Get("Authorization"); strings.HasPrefix(authorization, "Bearer ") {
+		token = strings.TrimPrefix(authorization, "Bearer ")
+	}
+
+	principal, err := s.Authenticator.Authenticate(r.Header.Get("Api-Key"), token)
+	if err == auth.ErrInvalidCredentials {
+		return nil, statusUnauthenticated
+	} else if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error authenticating gRPC request")
+		return nil, statusInternal
+	}
+
+	tenant := r.Header.Get("Tenant")
+	if tenant == "" {
+		tenant = principal.Tenant
+	}
+	if !principal.CanAccessTenant(tenant) {
+		return nil, newStatus(bridge.NewPermissionDeniedError(""))
+	}
+
+	if permission := methodPermission(r.URL.Path); permission != "" && !principal.Can(permission) {
+		return nil, newStatus(bridge.NewPermissionDeniedError(permission))
+	}
+
+	if tenant == "" {
+		return s.Default, nil
+	}
+	for _, rh := range s.Tenants {
+		if rh.Config.Tenant == tenant {
+			return rh, nil
+		}
+	}
+	return nil, statusUnknownTenant
+}
+
+// methodPermission returns a permission required by a method, like the permission of its HTTP
+// API endpoint
+func methodPermission(path string) string {
+	switch path {
+	case "/" + ServiceName + "/Payment":
+		return config.PermissionPayment
+	case "/" + ServiceName + "/Build":
+		return config.PermissionBuilder
+	default:
+		return ""
+	}
+}
+
+// payment implements Payment method using /payment endpoint handler
+func (s *Server) payment(rh *handlers.RequestHandler, r *http.Request, data []byte) (Message, *Status) {
+	var request PaymentRequest
+	err := request.Unmarshal(data)
+	if err != nil {
+		return nil, statusInvalidMessage
+	}
+
+	body := request.toBridgeRequest().ToValues().Encode()
+	recorder := &responseRecorder{}
+	rh.Payment(recorder, newRequest(r, "/payment", "application/x-www-form-urlencoded", body))
+	if status := recorder.status(); status != nil {
+		return nil, status
+	}
+
+	var submitResponse horizon.SubmitTransactionResponse
+	err = json.Unmarshal(recorder.body.Bytes(), &submitResponse)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error decoding /payment response")
+		return nil, statusInternal
+	}
+
+	response := &PaymentResponse{Hash: submitResponse.Hash}
+	if submitResponse.Ledger != nil {
+		response.Ledger = *submitResponse.Ledger
+	}
+	if submitResponse.ResultXdr != nil {
+		response.ResultXdr = *submitResponse.ResultXdr
+	}
+	return response, nil
+}
+
+// build implements Build method using /builder endpoint handler
+func (s *Server) build(rh *handlers.RequestHandler, r *http.Request, data []byte) (Message, *Status) {
+	var request BuilderRequest
+	err := request.Unmarshal(data)
+	if err != nil {
+		return nil, statusInvalidMessage
+	}
+
+	builderRequest := bridge.BuilderRequest{
+		Source:         request.Source,
+		SequenceNumber: request.SequenceNumber,
+		Operations:     []bridge.Operation{},
+		Signers:        request.Signers,
+	}
+	for i, operation := range request.Operations {
+		body := json.RawMessage(operation.Body)
+		if !json.Valid(body) {
+			return nil, newStatus(protocols.NewInvalidParameterError(fmt.Sprintf("operations[%d].body", i), operation.Body))
+		}
+		builderRequest.Operations = append(builderRequest.Operations, bridge.Operation{
+			Type:    bridge.OperationType(operation.Type),
+			RawBody: body,
+		})
+	}
+
+	body, err := json.Marshal(builderRequest)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error encoding /builder request")
+		return nil, statusInternal
+	}
+
+	recorder := &responseRecorder{}
+	rh.Builder(recorder, newRequest(r, "/builder", "application/json", string(body)))
+	if status := recorder.status(); status != nil {
+		return nil, status
+	}
+
+	var builderResponse bridge.BuilderResponse
+	err = json.Unmarshal(recorder.body.Bytes(), &builderResponse)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error decoding /builder response")
+		return nil, statusInternal
+	}
+
+	return &BuilderResponse{TransactionEnvelope: builderResponse.TransactionEnvelope}, nil
+}
+
+// getReceivedPayment implements GetReceivedPayment method
+func (s *Server) getReceivedPayment(rh *handlers.RequestHandler, data []byte) (Message, *Status) {
+	var request GetReceivedPaymentRequest
+	err := request.Unmarshal(data)
+	if err != nil {
+		return nil, statusInvalidMessage
+	}
+
+	if rh.Repository == nil {
+		return nil, statusNoDatabase
+	}
+
+	payment, err := rh.Repository.GetReceivedPaymentByOperationID(request.ID)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error getting received payment")
+		return nil, statusInternal
+	}
+
+	if payment == nil {
+		return nil, newStatus(bridge.ReceivedPaymentNotFound)
+	}
+
+	links, err := rh.Repository.GetPaymentLinksByReceivedPaymentID(*payment.ID)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error getting payment links")
+		return nil, statusInternal
+	}
+
+	return newReceivedPayment(payment, links), nil
+}
+
+// streamReceivedPayments implements StreamReceivedPayments method. Payments are sent in the
+// same order as by /payments/poll endpoint until the client cancels the call or server stops.
+func (s *Server) streamReceivedPayments(rh *handlers.RequestHandler, w http.ResponseWriter, r *http.Request, data []byte) *Status {
+	var request StreamReceivedPaymentsRequest
+	err := request.Unmarshal(data)
+	if err != nil || request.Cursor < 0 {
+		return statusInvalidMessage
+	}
+
+	if rh.Repository == nil {
+		return statusNoDatabase
+	}
+
+	cursor := request.Cursor
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		payments, err := rh.Repository.GetReceivedPaymentsAfterID(cursor, bridge.MaxPollLimit)
+		if err != nil {
+			log.WithFields(log.Fields{"err": err}).Error("Error getting received payments")
+			return statusInternal
+		}
+
+		for i := range payments {
+			err = writeMessage(w, newReceivedPayment(&payments[i], nil))
+			if err != nil {
+				return &Status{Code: codeCanceled}
+			}
+			cursor = *payments[i].ID
+		}
+
+		// More payments are waiting
+		if len(payments) == bridge.MaxPollLimit {
+			continue
+		}
+
+		select {
+		case <-ticker.C:
+		case <-r.Context().Done():
+			return &Status{Code: codeCanceled}
+		case <-s.stopped:
+			return statusOK
+		}
+	}
+}
+
+// newRequest creates a request to HTTP API handler for a gRPC request
+func newRequest(r *http.Request, path, contentType, body string) *http.Request {
+	request, _ := http.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	request.Header.Set("Content-Type", contentType)
+	request.RemoteAddr = r.RemoteAddr
+	return request.WithContext(r.Context())
+}
+
+// readMessage reads a length-prefixed message from gRPC request body
+func readMessage(body io.Reader) ([]byte, error) {
+	var header [5]byte
+	_, err := io.ReadFull(body, header[:])
+	if err != nil {
+		return nil, err
+	}
+
+	if header[0] != 0 {
+		return nil, errors.New("compressed messages are not supported")
+	}
+
+	length := binary.BigEndian.Uint32(header[1:])
+	if length > maxMessageSize {
+		return nil, errors.New("message is too large")
+	}
+
+	data := make([]byte, length)
+	_, err = io.ReadFull(body, data)
+	return data, err
+}
+
+// writeMessage writes a length-prefixed message to gRPC response body
+func writeMessage(w http.ResponseWriter, m Message) error {
+	data := m.Marshal()
+	frame := make([]byte, 5, 5+len(data))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(data)))
+	_, err := w.Write(append(frame, data...))
+	if err != nil {
+		return err
+	}
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return nil
+}
+
+// encodeMessage percent-encodes `grpc-message` value
+func encodeMessage(message string) string {
+	var encoded strings.Builder
+	for i := 0; i < len(message); i++ {
+		c := message[i]
+		if c < ' ' || c > '~' || c == '%' {
+			fmt.Fprintf(&encoded, "%%%02X", c)
+		} else {
+			encoded.WriteByte(c)
+		}
+	}
+	return encoded.String()
+}
+
+// responseRecorder records a response written by HTTP API handler
+type responseRecorder struct {
+	header http.Header
+	code   int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) Header() http.Header {
+	if r.header == nil {
+		r.header = http.Header{}
+	}
+	return r.header
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	return r.body.Write(data)
+}
+
+func (r *responseRecorder) WriteHeader(code int) {
+	r.code = code
+}
+
+// status returns nil when the handler wrote a success response or Status of the error response
+func (r *responseRecorder) status() *Status {
+	if r.code == 0 || r.code/100 == 2 {
+		return nil
+	}
+
+	errorResponse := protocols.ErrorResponse{Status: r.code}
+	err := json.Unmarshal(r.body.Bytes(), &errorResponse)
+	if err != nil {
+		return statusInternal
+	}
+	return newStatus(&errorResponse)
+}
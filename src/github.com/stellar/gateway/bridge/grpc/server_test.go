@@ -0,0 +1,181 @@
+package grpc
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/bridge/handlers"
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
+)
+
+// testClient sends gRPC requests to a Server listening on a random port
+type testClient struct {
+	t      *testing.T
+	server *Server
+	url    string
+	http   *http.Client
+}
+
+func newTestClient(t *testing.T, server *Server) *testClient {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go server.Serve(listener)
+
+	transport := &http2.Transport{
+		AllowHTTP: true,
+		DialTLS: func(network, addr string, cfg *tls.Config) (net.Conn, error) {
+			return net.Dial(network, addr)
+		},
+	}
+	return &testClient{t: t, server: server, url: "http://" + listener.Addr().String(), http: &http.Client{Transport: transport}}
+}
+
+// call sends the request and returns raw response messages and trailers
+func (c *testClient) call(method string, request Message, apiKey string) ([][]byte, http.Header) {
+	data := request.Marshal()
+	frame := make([]byte, 5)
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(data)))
+
+	r, err := http.NewRequest("POST", c.url+"/"+ServiceName+"/"+method, bytes.NewReader(append(frame, data...)))
+	require.NoError(c.t, err)
+	r.Header.Set("Content-Type", "application/grpc")
+	r.Header.Set("Api-Key", apiKey)
+
+	response, err := c.http.Do(r)
+	require.NoError(c.t, err)
+	defer response.Body.Close()
+	require.Equal(c.t, http.StatusOK, response.StatusCode)
+
+	var messages [][]byte
+	for {
+		message, err := readMessage(response.Body)
+		if err == io.EOF {
+			break
+		}
+		require.NoError(c.t, err)
+		messages = append(messages, message)
+	}
+	_, err = ioutil.ReadAll(response.Body)
+	require.NoError(c.t, err)
+	return messages, response.Trailer
+}
+
+func receivedPaymentEntity(id int64, operationID string) entities.ReceivedPayment {
+	return entities.ReceivedPayment{
+		ID:               &id,
+		OperationID:      operationID,
+		ProcessedAt:      time.Unix(1500000000, 0),
+		Status:           entities.ReceivedPaymentStatusSuccess,
+		FromAccount:      "GBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFZYYQ",
+		ReceivingAccount: "GATKP6ZQM5CSLECPMTAC5226PE367QALCPM6AFHTSULPPZMT62OOPMQB",
+		Amount:           "10.0000000",
+		AssetCode:        "USD",
+		AssetIssuer:      "GATKP6ZQM5CSLECPMTAC5226PE367QALCPM6AFHTSULPPZMT62OOPMQB",
+	}
+}
+
+func TestGetReceivedPayment(t *testing.T) {
+	mockRepository := new(mocks.MockRepository)
+	rh := &handlers.RequestHandler{Config: &config.Config{APIKey: "secret"}, Repository: mockRepository}
+	server := NewServer(rh, nil)
+	defer server.Stop()
+	client := newTestClient(t, server)
+
+	payment := receivedPaymentEntity(7, "100")
+	mockRepository.On("GetReceivedPaymentByOperationID", "100").Return(&payment, nil)
+	mockRepository.On("GetPaymentLinksByReceivedPaymentID", int64(7)).
+		Return([]entities.PaymentLink{{TransactionID: "abc"}}, nil)
+	mockRepository.On("GetReceivedPaymentByOperationID", "200").Return(nil, nil)
+
+	messages, trailer := client.call("GetReceivedPayment", &GetReceivedPaymentRequest{ID: "100"}, "secret")
+	assert.Equal(t, "0", trailer.Get("Grpc-Status"))
+	require.Len(t, messages, 1)
+
+	var response ReceivedPayment
+	require.NoError(t, response.Unmarshal(messages[0]))
+	assert.Equal(t, "100", response.ID)
+	assert.Equal(t, "2017-07-14T02:40:00Z", response.ProcessedAt)
+	assert.Equal(t, payment.ReceivingAccount, response.To)
+	assert.Equal(t, "USD:"+payment.AssetIssuer, response.Asset)
+	assert.Equal(t, []string{"abc"}, response.OutgoingTransactions)
+	assert.Equal(t, int64(7), response.Cursor)
+
+	messages, trailer = client.call("GetReceivedPayment", &GetReceivedPaymentRequest{ID: "200"}, "secret")
+	assert.Empty(t, messages)
+	assert.Equal(t, "5", trailer.Get("Grpc-Status"))
+	assert.Equal(t, "received_payment_not_found", trailer.Get("Bridge-Error-Code"))
+
+	messages, trailer = client.call("GetReceivedPayment", &GetReceivedPaymentRequest{ID: "100"}, "invalid")
+	assert.Empty(t, messages)
+	assert.Equal(t, "16", trailer.Get("Grpc-Status"))
+	assert.Equal(t, "Invalid api-key.", trailer.Get("Grpc-Message"))
+}
+
+func TestBuild(t *testing.T) {
+	rh := &handlers.RequestHandler{Config: &config.Config{NetworkPassphrase: "Test SDF Network ; September 2015"}}
+	server := NewServer(rh, nil)
+	defer server.Stop()
+	client := newTestClient(t, server)
+
+	request := &BuilderRequest{
+		Source:         "GBWJES3WOKK7PRLJKZVGIPVFGQSSGCRMY7H3GCZ7BEG6ZTDB4FZXTPJ5",
+		SequenceNumber: "123",
+		Operations: []Operation{{
+			Type: "create_account",
+			Body: `{"destination": "GCOEGO43PFSLE4K7WRZQNRO3PIOTRLKRASP32W7DSPBF65XFT4V6PSV3", "starting_balance": "50"}`,
+		}},
+		Signers: []string{"SABY7FRMMJWPBTKQQ2ZN43AUJQ3Z2ZAK36VYSG2SPE2ABNQXA66H5E5G"},
+	}
+
+	messages, trailer := client.call("Build", request, "")
+	assert.Equal(t, "0", trailer.Get("Grpc-Status"))
+	require.Len(t, messages, 1)
+
+	var response BuilderResponse
+	require.NoError(t, response.Unmarshal(messages[0]))
+	assert.Equal(t, "AAAAAGySS3ZylffFaVZqZD6lNCUjCizHz7MLPwkN7Mxh4XN5AAAAZAAAAAAAAAB7AAAAAAAAAAAAAAABAAAAAAAAAAAAAAAAnEM7m3lksnFftHMGxdt6HTitUQSfvVvjk8JfduWfK+cAAAAAHc1lAAAAAAAAAAABn420/AAAAECXY+neSolhAeHUXf+UrOV6PjeJnvLM/HqjOlOEWD3hmu/z9aBksDu9zqa26jS14eMpZzq8sofnnvt248FUO+cP", response.TransactionEnvelope)
+
+	request.Operations[0].Body = "{"
+	_, trailer = client.call("Build", request, "")
+	assert.Equal(t, "3", trailer.Get("Grpc-Status"))
+	assert.Equal(t, "invalid_parameter", trailer.Get("Bridge-Error-Code"))
+}
+
+func TestStreamReceivedPayments(t *testing.T) {
+	pollInterval = 10 * time.Millisecond
+	defer func() { pollInterval = time.Second }()
+
+	mockRepository := new(mocks.MockRepository)
+	rh := &handlers.RequestHandler{Config: &config.Config{}, Repository: mockRepository}
+	server := NewServer(rh, nil)
+	client := newTestClient(t, server)
+
+	mockRepository.On("GetReceivedPaymentsAfterID", int64(5), mock.Anything).
+		Return([]entities.ReceivedPayment{receivedPaymentEntity(6, "100"), receivedPaymentEntity(8, "101")}, nil).Once()
+	mockRepository.On("GetReceivedPaymentsAfterID", int64(8), mock.Anything).
+		Run(func(args mock.Arguments) { server.Stop() }).
+		Return([]entities.ReceivedPayment{}, nil)
+
+	messages, trailer := client.call("StreamReceivedPayments", &StreamReceivedPaymentsRequest{Cursor: 5}, "")
+	assert.Equal(t, "0", trailer.Get("Grpc-Status"))
+	require.Len(t, messages, 2)
+
+	var payment ReceivedPayment
+	require.NoError(t, payment.Unmarshal(messages[1]))
+	assert.Equal(t, "101", payment.ID)
+	assert.Equal(t, int64(8), payment.Cursor)
+	mockRepository.AssertExpectations(t)
+}
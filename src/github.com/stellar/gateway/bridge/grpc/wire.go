@@ -0,0 +1,104 @@
+package grpc
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// Protocol buffers wire types used by bridge.proto messages
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+var errMalformed = errors.New("malformed protobuf message")
+
+// encoder writes fields of a protobuf message. Fields with default values are
+// skipped like proto3 encoders do.
+type encoder struct {
+	buf []byte
+}
+
+func (e *encoder) tag(field, wireType int) {
+	e.buf = binary.AppendUvarint(e.buf, uint64(field)<<3|uint64(wireType))
+}
+
+func (e *encoder) string(field int, value string) {
+	if value == "" {
+		return
+	}
+	e.bytes(field, []byte(value))
+}
+
+func (e *encoder) bytes(field int, value []byte) {
+	e.tag(field, wireBytes)
+	e.buf = binary.AppendUvarint(e.buf, uint64(len(value)))
+	e.buf = append(e.buf, value...)
+}
+
+func (e *encoder) uint64(field int, value uint64) {
+	if value == 0 {
+		return
+	}
+	e.tag(field, wireVarint)
+	e.buf = binary.AppendUvarint(e.buf, value)
+}
+
+// message writes embedded message. It's used for repeated fields so it's always written.
+func (e *encoder) message(field int, m Message) {
+	e.bytes(field, m.Marshal())
+}
+
+// decode calls fn for every field of a protobuf message. value is set for varint
+// fields and data for length-delimited fields. Fixed size fields are skipped.
+func decode(buf []byte, fn func(field, wireType int, value uint64, data []byte) error) error {
+	for len(buf) > 0 {
+		key, n := binary.Uvarint(buf)
+		if n <= 0 {
+			return errMalformed
+		}
+		buf = buf[n:]
+
+		field, wireType := int(key>>3), int(key&7)
+		var value uint64
+		var data []byte
+
+		switch wireType {
+		case wireVarint:
+			value, n = binary.Uvarint(buf)
+			if n <= 0 {
+				return errMalformed
+			}
+			buf = buf[n:]
+		case wireBytes:
+			length, n := binary.Uvarint(buf)
+			if n <= 0 || uint64(len(buf)-n) < length {
+				return errMalformed
+			}
+			data = buf[n : n+int(length)]
+			buf = buf[n+int(length):]
+		case wireFixed64:
+			if len(buf) < 8 {
+				return errMalformed
+			}
+			buf = buf[8:]
+			continue
+		case wireFixed32:
+			if len(buf) < 4 {
+				return errMalformed
+			}
+			buf = buf[4:]
+			continue
+		default:
+			return errMalformed
+		}
+
+		err := fn(field, wireType, value, data)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -1,11 +1,22 @@
 package handlers
 
 import (
+	"time"
+
 	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/db"
+	"github.com/stellar/gateway/events"
 	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/limits"
+	"github.com/stellar/gateway/listener"
 	"github.com/stellar/gateway/net"
+	"github.com/stellar/gateway/protocols"
+	"github.com/stellar/gateway/protocols/bridge"
 	"github.com/stellar/gateway/protocols/federation"
 	"github.com/stellar/gateway/protocols/stellartoml"
+	"github.com/stellar/gateway/sandbox"
+	"github.com/stellar/gateway/server"
+	"github.com/stellar/gateway/signer"
 	"github.com/stellar/gateway/submitter"
 )
 
@@ -17,13 +28,62 @@ type RequestHandler struct {
 	StellarTomlResolver  stellartoml.ResolverInterface           `inject:""`
 	FederationResolver   federation.ResolverInterface            `inject:""`
 	TransactionSubmitter submitter.TransactionSubmitterInterface `inject:""`
+	// EntityManager and Repository are nil when bridge server is started without a DB
+	// so they are set by the app instead of the injector.
+	EntityManager db.EntityManagerInterface
+	Repository    db.RepositoryInterface
+	// Sandbox is set when bridge server is started in sandbox mode
+	Sandbox *sandbox.Horizon
+	// PaymentListener is nil when it's not running
+	PaymentListener *listener.PaymentListener
+	// Publisher publishes statuses of sent payments. It's nil when no publisher is configured.
+	Publisher events.Publisher
+	// FeeStrategy chooses fees of payments sent without compliance server. Default fee is
+	// used when it's nil.
+	FeeStrategy *submitter.FeeStrategy
+	// Limiter enforces `limits` of sent payments. It's nil when DB is not configured.
+	Limiter *limits.Limiter
+	// ComplianceClient sends requests to the compliance server when `compliance_tls` is
+	// configured. Client is used when it's nil.
+	ComplianceClient net.HTTPClientInterface
+	// Signers returns signers of seeds and key references of accounts
+	Signers *signer.Signers
+	// FederationCache caches destinations resolved using federation. It's shared by all
+	// tenants and it's nil when `federation_cache_ttl` is not set.
+	FederationCache *federation.Cache
+	// RecentErrors keeps messages logged with error level returned by /admin/status
+	RecentErrors *server.RecentErrors
+	// Version is a version of the bridge server binary
+	Version string
+}
+
+// complianceClient returns a client of requests to the compliance server
+func (rh *RequestHandler) complianceClient() net.HTTPClientInterface {
+	if rh.ComplianceClient != nil {
+		return rh.ComplianceClient
+	}
+	return rh.Client
+}
+
+// submitErrorResponse returns an error response of an error returned when signing or
+// submitting a transaction
+func submitErrorResponse(err error) *protocols.ErrorResponse {
+	if err == signer.ErrLocked {
+		return bridge.KeystoreLocked
+	}
+	return protocols.InternalServerError
 }
 
-func (rh *RequestHandler) isAssetAllowed(code string, issuer string) bool {
-	for _, asset := range rh.Config.Assets {
-		if asset.Code == code && asset.Issuer == issuer {
-			return true
+// preconditions returns preconditions of a transaction built by /payment or /builder endpoint.
+// When max_time is not set in a request, transaction expires `transaction_timeout` seconds
+// after it becomes valid.
+func (rh *RequestHandler) preconditions(preconditions bridge.TransactionPreconditions) submitter.Preconditions {
+	if preconditions.MaxTime == 0 && rh.Config.TransactionTimeout > 0 {
+		validFrom := uint64(time.Now().Unix())
+		if preconditions.MinTime > validFrom {
+			validFrom = preconditions.MinTime
 		}
+		preconditions.MaxTime = validFrom + uint64(rh.Config.TransactionTimeout)
 	}
-	return false
+	return submitter.Preconditions(preconditions)
 }
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"net/http"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/protocols"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stellar/gateway/server"
+	"github.com/stellar/go-stellar-base/strkey"
+	"github.com/zenazn/goji/web"
+)
+
+// AccountBalances implements GET /accounts/:id/balances endpoint. It returns balances of an
+// account loaded from Horizon. Native balance is always returned, credit balances only when the
+// asset is allowed by `assets` config param.
+func (rh *RequestHandler) AccountBalances(c web.C, w http.ResponseWriter, r *http.Request) {
+	accountID := c.URLParams["id"]
+	_, err := strkey.Decode(strkey.VersionByteAccountID, accountID)
+	if err != nil {
+		errorResponse := protocols.NewInvalidParameterError("id", accountID)
+		log.WithFields(errorResponse.LogData).Error(errorResponse.Error())
+		server.Write(w, errorResponse)
+		return
+	}
+
+	account, err := rh.Horizon.LoadAccount(r.Context(), accountID)
+	if err != nil {
+		if statusError, ok := err.(*horizon.StatusError); ok && statusError.StatusCode == http.StatusNotFound {
+			server.Write(w, bridge.AccountNotFound)
+			return
+		}
+		log.WithFields(log.Fields{"err": err, "account_id": accountID}).Error("Error loading account")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	response := &bridge.AccountBalancesResponse{AccountID: accountID, Balances: []bridge.AccountBalance{}}
+	assetFilter := rh.Config.AssetFilter()
+	for _, balance := range account.Balances {
+		if balance.AssetType == "native" {
+			response.Balances = append(response.Balances, bridge.AccountBalance{
+				Asset:   protocols.NativeAssetString,
+				Balance: balance.Balance,
+			})
+			continue
+		}
+
+		if !assetFilter.Allows(balance.AssetCode, balance.AssetIssuer) {
+			continue
+		}
+
+		response.Balances = append(response.Balances, bridge.AccountBalance{
+			Asset:                             protocols.Asset{Code: balance.AssetCode, Issuer: balance.AssetIssuer}.String(),
+			AssetCode:                         balance.AssetCode,
+			AssetIssuer:                       balance.AssetIssuer,
+			Balance:                           balance.Balance,
+			Limit:                             balance.Limit,
+			IsAuthorized:                      balance.IsAuthorized,
+			IsAuthorizedToMaintainLiabilities: balance.IsAuthorizedToMaintainLiabilities,
+		})
+	}
+
+	server.Write(w, response)
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/mocks"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zenazn/goji/web"
+)
+
+func TestAccountBalances(t *testing.T) {
+	usdIssuer := "GBSTRH4QOTWNSVA6E4HFERETX4ZLSR3CIUBLK7AXYII277PFJC4BBYOG"
+	c := &config.Config{Assets: []config.Asset{{Code: "USD", Issuer: usdIssuer}}}
+	mockHorizon := new(mocks.MockHorizon)
+	rh := RequestHandler{Config: c, Horizon: mockHorizon}
+
+	get := func(accountID string) (int, []byte) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("GET", "/accounts/"+accountID+"/balances", nil)
+		rh.AccountBalances(web.C{URLParams: map[string]string{"id": accountID}}, w, r)
+		return w.Code, w.Body.Bytes()
+	}
+
+	accountID := "GAMVF7G4GJC4A7JMFJWLUAEIBFQD5RT3DCB5DC5TJDEKQBBACQ4JZVEE"
+	authorized, unauthorized := true, false
+	mockHorizon.On("LoadAccount", accountID).Return(horizon.AccountResponse{
+		AccountID: accountID,
+		Balances: []horizon.Balance{
+			{AssetType: "credit_alphanum4", AssetCode: "USD", AssetIssuer: usdIssuer, Balance: "10.0000000", Limit: "1000.0000000", IsAuthorized: &unauthorized},
+			// Not in `assets`
+			{AssetType: "credit_alphanum4", AssetCode: "EUR", AssetIssuer: usdIssuer, Balance: "5.0000000", IsAuthorized: &authorized},
+			{AssetType: "native", Balance: "100.0000000"},
+		},
+	}, nil).Once()
+
+	code, body := get(accountID)
+	assert.Equal(t, http.StatusOK, code)
+	var response bridge.AccountBalancesResponse
+	require.NoError(t, json.Unmarshal(body, &response))
+	assert.Equal(t, accountID, response.AccountID)
+	assert.Equal(t, []bridge.AccountBalance{
+		{Asset: "USD:" + usdIssuer, AssetCode: "USD", AssetIssuer: usdIssuer, Balance: "10.0000000", Limit: "1000.0000000", IsAuthorized: &unauthorized},
+		{Asset: "native", Balance: "100.0000000"},
+	}, response.Balances)
+
+	// Not found
+	mockHorizon.On("LoadAccount", accountID).Return(horizon.AccountResponse{}, &horizon.StatusError{StatusCode: http.StatusNotFound}).Once()
+	code, body = get(accountID)
+	assert.Equal(t, http.StatusNotFound, code)
+	assert.Contains(t, string(body), bridge.AccountNotFound.Code)
+
+	// Horizon error
+	mockHorizon.On("LoadAccount", accountID).Return(horizon.AccountResponse{}, errors.New("connection refused")).Once()
+	code, _ = get(accountID)
+	assert.Equal(t, http.StatusInternalServerError, code)
+
+	// Invalid account ID
+	code, _ = get("SDWLS4G3XCNIYPKXJWWGGJT6UDY63WV6PEFTWP7JZMQB4RE7EUJQN5XM")
+	assert.Equal(t, http.StatusBadRequest, code)
+	mockHorizon.AssertExpectations(t)
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/bridge/auth"
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/protocols"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stellar/gateway/server"
+	"github.com/zenazn/goji/web"
+)
+
+// AdminAPIKeys implements GET /admin/api-keys endpoint
+func (rh *RequestHandler) AdminAPIKeys(w http.ResponseWriter, r *http.Request) {
+	apiKeys, err := rh.Repository.GetAPIKeys()
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error getting API keys")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	response := bridge.APIKeysResponse{APIKeys: []bridge.APIKey{}}
+	for i := range apiKeys {
+		response.APIKeys = append(response.APIKeys, bridge.NewAPIKey(&apiKeys[i]))
+	}
+
+	server.Write(w, &response)
+}
+
+// AdminCreateAPIKey implements POST /admin/api-keys endpoint. The key is returned only in
+// the response, the DB contains its hash.
+func (rh *RequestHandler) AdminCreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	request := &bridge.APIKeyRequest{}
+	request.FromRequest(r)
+
+	err := request.Validate()
+	if err != nil {
+		errorResponse := err.(*protocols.ErrorResponse)
+		log.WithFields(errorResponse.LogData).Error(errorResponse.Error())
+		server.Write(w, errorResponse)
+		return
+	}
+
+	if request.Tenant != "" && !rh.isTenant(request.Tenant) {
+		server.Write(w, protocols.NewInvalidParameterError("tenant", request.Tenant))
+		return
+	}
+
+	key, err := auth.NewKey()
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error generating API key")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	apiKey := &entities.APIKey{
+		Name:        request.Name,
+		KeyHash:     auth.HashKey(key),
+		Tenant:      request.Tenant,
+		Permissions: request.Permissions,
+		CreatedAt:   time.Now(),
+	}
+
+	err = rh.EntityManager.Persist(apiKey)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error persisting API key")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	log.WithFields(log.Fields{"id": *apiKey.ID, "name": apiKey.Name}).Info("API key created")
+
+	response := &bridge.APIKeyResponse{APIKey: bridge.NewAPIKey(apiKey)}
+	response.Key = key
+	server.Write(w, response)
+}
+
+// AdminDeleteAPIKey implements DELETE /admin/api-keys/:id endpoint
+func (rh *RequestHandler) AdminDeleteAPIKey(c web.C, w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(c.URLParams["id"], 10, 64)
+	if err != nil {
+		server.Write(w, bridge.APIKeyNotFound)
+		return
+	}
+
+	apiKey, err := rh.Repository.GetAPIKeyByID(id)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error getting API key")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	if apiKey == nil {
+		server.Write(w, bridge.APIKeyNotFound)
+		return
+	}
+
+	err = rh.EntityManager.Delete(apiKey)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error deleting API key")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	log.WithFields(log.Fields{"id": id, "name": apiKey.Name}).Info("API key deleted")
+	w.WriteHeader(http.StatusOK)
+}
+
+// isTenant returns true when name is a name of one of configured tenants
+func (rh *RequestHandler) isTenant(name string) bool {
+	for _, tenant := range rh.Config.Tenants {
+		if tenant.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"net/http"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/protocols"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stellar/gateway/server"
+)
+
+// AdminCallbacks implements GET /admin/callbacks endpoint. It returns receive callback
+// attempts matching query params, newest first.
+func (rh *RequestHandler) AdminCallbacks(w http.ResponseWriter, r *http.Request) {
+	request := &bridge.CallbacksRequest{}
+	request.FromRequest(r)
+
+	filter, err := request.Parse()
+	if err != nil {
+		errorResponse := err.(*protocols.ErrorResponse)
+		log.WithFields(errorResponse.LogData).Error(errorResponse.Error())
+		server.Write(w, errorResponse)
+		return
+	}
+
+	attempts, err := rh.Repository.GetCallbackAttempts(filter)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error getting callback attempts")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	response := bridge.CallbacksResponse{Callbacks: []bridge.CallbackAttempt{}}
+	for i := range attempts {
+		response.Callbacks = append(response.Callbacks, bridge.NewCallbackAttempt(&attempts[i]))
+	}
+
+	server.Write(w, &response)
+}
@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/protocols"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stellar/gateway/server"
+	"github.com/zenazn/goji/web"
+)
+
+// AdminCustomers implements GET /admin/customers endpoint
+func (rh *RequestHandler) AdminCustomers(w http.ResponseWriter, r *http.Request) {
+	customers, err := rh.Repository.GetCustomers()
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error getting customers")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	response := bridge.CustomersResponse{Customers: []bridge.Customer{}}
+	for i := range customers {
+		response.Customers = append(response.Customers, bridge.NewCustomer(&customers[i]))
+	}
+
+	server.Write(w, &response)
+}
+
+// AdminCustomer implements GET /admin/customers/:id endpoint
+func (rh *RequestHandler) AdminCustomer(c web.C, w http.ResponseWriter, r *http.Request) {
+	customer := rh.loadCustomer(c, w)
+	if customer == nil {
+		return
+	}
+
+	server.Write(w, &bridge.CustomerResponse{Customer: bridge.NewCustomer(customer)})
+}
+
+// AdminCreateCustomer implements POST /admin/customers endpoint
+func (rh *RequestHandler) AdminCreateCustomer(w http.ResponseWriter, r *http.Request) {
+	request := &bridge.CustomerRequest{}
+	request.FromRequest(r)
+
+	err := request.Validate()
+	if err != nil {
+		errorResponse := err.(*protocols.ErrorResponse)
+		log.WithFields(errorResponse.LogData).Error(errorResponse.Error())
+		server.Write(w, errorResponse)
+		return
+	}
+
+	if !rh.isCustomerMemoFree(w, request, nil) {
+		return
+	}
+
+	customer := &entities.Customer{
+		MemoType:    request.MemoType,
+		Memo:        request.Memo,
+		CustomerID:  request.CustomerID,
+		CallbackURL: request.CallbackURL,
+		CreatedAt:   time.Now(),
+		Tenant:      rh.Config.Tenant,
+	}
+
+	err = rh.EntityManager.Persist(customer)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error persisting customer")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	server.Write(w, &bridge.CustomerResponse{Customer: bridge.NewCustomer(customer)})
+}
+
+// AdminUpdateCustomer implements PUT /admin/customers/:id endpoint
+func (rh *RequestHandler) AdminUpdateCustomer(c web.C, w http.ResponseWriter, r *http.Request) {
+	customer := rh.loadCustomer(c, w)
+	if customer == nil {
+		return
+	}
+
+	request := &bridge.CustomerRequest{}
+	request.FromRequest(r)
+
+	err := request.Validate()
+	if err != nil {
+		errorResponse := err.(*protocols.ErrorResponse)
+		log.WithFields(errorResponse.LogData).Error(errorResponse.Error())
+		server.Write(w, errorResponse)
+		return
+	}
+
+	if !rh.isCustomerMemoFree(w, request, customer) {
+		return
+	}
+
+	customer.MemoType = request.MemoType
+	customer.Memo = request.Memo
+	customer.CustomerID = request.CustomerID
+	customer.CallbackURL = request.CallbackURL
+
+	err = rh.EntityManager.Persist(customer)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error persisting customer")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	server.Write(w, &bridge.CustomerResponse{Customer: bridge.NewCustomer(customer)})
+}
+
+// AdminDeleteCustomer implements DELETE /admin/customers/:id endpoint
+func (rh *RequestHandler) AdminDeleteCustomer(c web.C, w http.ResponseWriter, r *http.Request) {
+	customer := rh.loadCustomer(c, w)
+	if customer == nil {
+		return
+	}
+
+	err := rh.EntityManager.Delete(customer)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error deleting customer")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// loadCustomer finds a customer using `id` URL param. When customer cannot be
+// found it writes an error response and returns nil.
+func (rh *RequestHandler) loadCustomer(c web.C, w http.ResponseWriter) *entities.Customer {
+	id, err := strconv.ParseInt(c.URLParams["id"], 10, 64)
+	if err != nil {
+		server.Write(w, bridge.CustomerNotFound)
+		return nil
+	}
+
+	customer, err := rh.Repository.GetCustomerByID(id)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error getting customer")
+		server.Write(w, protocols.InternalServerError)
+		return nil
+	}
+
+	if customer == nil {
+		server.Write(w, bridge.CustomerNotFound)
+		return nil
+	}
+
+	return customer
+}
+
+// isCustomerMemoFree checks if the memo in request is not assigned to a customer
+// other than current. Writes an error response when it returns false.
+func (rh *RequestHandler) isCustomerMemoFree(w http.ResponseWriter, request *bridge.CustomerRequest, current *entities.Customer) bool {
+	existing, err := rh.Repository.GetCustomerByMemo(request.MemoType, request.Memo)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error getting customer")
+		server.Write(w, protocols.InternalServerError)
+		return false
+	}
+
+	if existing != nil && (current == nil || *existing.ID != *current.ID) {
+		server.Write(w, bridge.CustomerMemoTaken)
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,20 @@
+package handlers
+
+import (
+	"net/http"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stellar/gateway/server"
+)
+
+// AdminFlushFederationCache implements POST /admin/federation-cache/flush endpoint. It removes
+// `address` from the federation cache or all cached addresses when `address` is not set.
+func (rh *RequestHandler) AdminFlushFederationCache(w http.ResponseWriter, r *http.Request) {
+	request := &bridge.FlushFederationCacheRequest{}
+	request.FromRequest(r)
+
+	flushed := rh.FederationCache.Flush(request.Address)
+	log.WithFields(log.Fields{"address": request.Address, "flushed": flushed}).Info("Federation cache flushed")
+	server.Write(w, &bridge.FlushFederationCacheResponse{Flushed: flushed})
+}
@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stellar/gateway/mocks"
+	"github.com/stellar/gateway/protocols/federation"
+	"github.com/stellar/gateway/protocols/stellartoml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminFlushFederationCache(t *testing.T) {
+	mockFederationResolver := new(mocks.MockFederationResolver)
+	cache := federation.NewCache(mockFederationResolver, time.Minute, time.Minute)
+	rh := RequestHandler{FederationCache: cache}
+
+	for _, address := range []string{"alice*stellar.org", "bob*stellar.org"} {
+		mockFederationResolver.On("Resolve", address).Return(federation.Response{}, stellartoml.StellarToml{}, nil).Once()
+		_, _, err := cache.Resolve(address)
+		require.NoError(t, err)
+	}
+
+	flush := func(values url.Values) string {
+		r := httptest.NewRequest("POST", "/admin/federation-cache/flush", strings.NewReader(values.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		rh.AdminFlushFederationCache(w, r)
+		require.Equal(t, http.StatusOK, w.Code)
+		return w.Body.String()
+	}
+
+	assert.Contains(t, flush(url.Values{"address": {"alice*stellar.org"}}), `"flushed": 1`)
+	assert.Contains(t, flush(url.Values{"address": {"alice*stellar.org"}}), `"flushed": 0`)
+	assert.Contains(t, flush(url.Values{}), `"flushed": 1`)
+	mockFederationResolver.AssertExpectations(t)
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"net/http"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/protocols"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stellar/gateway/server"
+	"github.com/stellar/gateway/signer"
+)
+
+// AdminKeystore implements GET /admin/keystore endpoint
+func (rh *RequestHandler) AdminKeystore(w http.ResponseWriter, r *http.Request) {
+	server.Write(w, keystoreResponse(rh.Signers.Keystore()))
+}
+
+// AdminUnlockKeystore implements POST /admin/keystore/unlock endpoint. Transactions of key
+// references of the keystore cannot be signed until it's unlocked.
+func (rh *RequestHandler) AdminUnlockKeystore(w http.ResponseWriter, r *http.Request) {
+	request := &bridge.UnlockKeystoreRequest{}
+	request.FromRequest(r)
+
+	err := request.Validate()
+	if err != nil {
+		errorResponse := err.(*protocols.ErrorResponse)
+		log.WithFields(errorResponse.LogData).Error(errorResponse.Error())
+		server.Write(w, errorResponse)
+		return
+	}
+
+	keystore := rh.Signers.Keystore()
+	err = keystore.Unlock(request.Passphrase)
+	if err == signer.ErrInvalidPassphrase {
+		log.Warn("Invalid keystore passphrase")
+		server.Write(w, bridge.KeystoreInvalidPassphrase)
+		return
+	} else if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error unlocking keystore")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	log.Info("Keystore unlocked")
+	server.Write(w, keystoreResponse(keystore))
+}
+
+func keystoreResponse(keystore *signer.Keystore) *bridge.KeystoreResponse {
+	return &bridge.KeystoreResponse{
+		Locked: keystore.Locked(),
+		Keys:   keystore.Keys(),
+	}
+}
@@ -0,0 +1,354 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/protocols"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stellar/gateway/server"
+	b "github.com/stellar/go-stellar-base/build"
+	"github.com/zenazn/goji/web"
+)
+
+// AdminReceivedPayment implements GET /admin/received-payments/:id endpoint
+func (rh *RequestHandler) AdminReceivedPayment(c web.C, w http.ResponseWriter, r *http.Request) {
+	payment := rh.loadReceivedPayment(c, w)
+	if payment == nil {
+		return
+	}
+
+	links, err := rh.Repository.GetPaymentLinksByReceivedPaymentID(*payment.ID)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error getting payment links")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	server.Write(w, &bridge.ReceivedPaymentResponse{ReceivedPayment: bridge.NewReceivedPayment(payment, links)})
+}
+
+// AdminSentPayment implements GET /admin/sent-payments/:id endpoint. It returns metadata of
+// a transaction sent by /payment endpoint and the received payment it originates from.
+func (rh *RequestHandler) AdminSentPayment(c web.C, w http.ResponseWriter, r *http.Request) {
+	transactionID := c.URLParams["id"]
+
+	sentTransaction, err := rh.Repository.GetSentTransactionByTransactionID(transactionID)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error getting sent transaction")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	link, err := rh.Repository.GetPaymentLinkByTransactionID(transactionID)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error getting payment link")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	if link == nil && (sentTransaction == nil || sentTransaction.Metadata == nil) {
+		server.Write(w, bridge.SentPaymentNotFound)
+		return
+	}
+
+	response := &bridge.SentPaymentResponse{TransactionID: transactionID}
+
+	if sentTransaction != nil {
+		response.Status = string(sentTransaction.Status)
+		if sentTransaction.Metadata != nil {
+			metadata := json.RawMessage(*sentTransaction.Metadata)
+			response.Metadata = &metadata
+		}
+	}
+
+	if link != nil {
+		payment, err := rh.Repository.GetReceivedPaymentByID(link.ReceivedPaymentID)
+		if err != nil {
+			log.WithFields(log.Fields{"err": err}).Error("Error getting received payment")
+			server.Write(w, protocols.InternalServerError)
+			return
+		}
+
+		if payment == nil {
+			server.Write(w, bridge.ReceivedPaymentNotFound)
+			return
+		}
+
+		links, err := rh.Repository.GetPaymentLinksByReceivedPaymentID(link.ReceivedPaymentID)
+		if err != nil {
+			log.WithFields(log.Fields{"err": err}).Error("Error getting payment links")
+			server.Write(w, protocols.InternalServerError)
+			return
+		}
+
+		receivedPayment := bridge.NewReceivedPayment(payment, links)
+		response.ReceivedPayment = &receivedPayment
+	}
+
+	server.Write(w, response)
+}
+
+// AdminResendReceivedPaymentCallback implements POST /admin/received-payments/:id/resend-callback endpoint
+func (rh *RequestHandler) AdminResendReceivedPaymentCallback(c web.C, w http.ResponseWriter, r *http.Request) {
+	payment := rh.loadReceivedPayment(c, w)
+	if payment == nil {
+		return
+	}
+
+	if rh.PaymentListener == nil || !rh.Config.Callbacks.HasReceive() {
+		server.Write(w, bridge.ReceiveCallbackNotConfigured)
+		return
+	}
+
+	if payment.FromAccount == "" {
+		server.Write(w, bridge.ReceivedPaymentNoDetails)
+		return
+	}
+
+	err := rh.PaymentListener.ResendCallback(r.Context(), payment)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err, "operation_id": payment.OperationID}).Error("Error resending receive callback")
+		server.Write(w, bridge.ReceiveCallbackFailed)
+		return
+	}
+
+	links, err := rh.Repository.GetPaymentLinksByReceivedPaymentID(*payment.ID)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error getting payment links")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	server.Write(w, &bridge.ReceivedPaymentResponse{ReceivedPayment: bridge.NewReceivedPayment(payment, links)})
+}
+
+// AdminReprocessReceivedPayment implements POST /admin/received-payments/:id/reprocess endpoint.
+// It processes a payment that was not processed successfully again, as if it was just received.
+func (rh *RequestHandler) AdminReprocessReceivedPayment(c web.C, w http.ResponseWriter, r *http.Request) {
+	payment := rh.loadReceivedPayment(c, w)
+	if payment == nil {
+		return
+	}
+
+	if rh.PaymentListener == nil {
+		server.Write(w, bridge.PaymentListenerNotRunning)
+		return
+	}
+
+	if payment.IsFinal() {
+		server.Write(w, bridge.ReceivedPaymentNotReprocessable)
+		return
+	}
+
+	err := rh.PaymentListener.Reprocess(r.Context(), payment)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err, "operation_id": payment.OperationID}).Error("Error reprocessing received payment")
+		server.Write(w, bridge.ReprocessFailed)
+		return
+	}
+
+	links, err := rh.Repository.GetPaymentLinksByReceivedPaymentID(*payment.ID)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error getting payment links")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	server.Write(w, &bridge.ReceivedPaymentResponse{ReceivedPayment: bridge.NewReceivedPayment(payment, links)})
+}
+
+// AdminRequeueReceivedPayment implements POST /admin/received-payments/:id/requeue endpoint.
+// It adds a dead-lettered payment to the callback retry queue.
+func (rh *RequestHandler) AdminRequeueReceivedPayment(c web.C, w http.ResponseWriter, r *http.Request) {
+	payment := rh.loadReceivedPayment(c, w)
+	if payment == nil {
+		return
+	}
+
+	if rh.PaymentListener == nil || !rh.Config.Callbacks.HasReceive() {
+		server.Write(w, bridge.ReceiveCallbackNotConfigured)
+		return
+	}
+
+	if rh.Config.MaxCallbackAttempts() == 0 {
+		server.Write(w, bridge.DeadLetterNotConfigured)
+		return
+	}
+
+	if !payment.IsDeadLetter() {
+		server.Write(w, bridge.ReceivedPaymentNotDeadLetter)
+		return
+	}
+
+	if payment.FromAccount == "" {
+		server.Write(w, bridge.ReceivedPaymentNoDetails)
+		return
+	}
+
+	err := rh.PaymentListener.Requeue(payment)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err, "operation_id": payment.OperationID}).Error("Error requeuing received payment")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	links, err := rh.Repository.GetPaymentLinksByReceivedPaymentID(*payment.ID)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error getting payment links")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	server.Write(w, &bridge.ReceivedPaymentResponse{ReceivedPayment: bridge.NewReceivedPayment(payment, links)})
+}
+
+// AdminResolveReceivedPayment implements POST /admin/received-payments/:id/resolve endpoint.
+// It marks a payment that was not processed as resolved or ignored by an operator.
+func (rh *RequestHandler) AdminResolveReceivedPayment(c web.C, w http.ResponseWriter, r *http.Request) {
+	payment := rh.loadReceivedPayment(c, w)
+	if payment == nil {
+		return
+	}
+
+	request := &bridge.ResolveRequest{}
+	request.FromRequest(r)
+
+	err := request.Validate()
+	if err != nil {
+		errorResponse := err.(*protocols.ErrorResponse)
+		log.WithFields(errorResponse.LogData).Error(errorResponse.Error())
+		server.Write(w, errorResponse)
+		return
+	}
+
+	if payment.IsFinal() {
+		server.Write(w, bridge.ReceivedPaymentNotResolvable)
+		return
+	}
+
+	now := time.Now()
+	payment.Status = request.PaymentStatus()
+	payment.ResolutionReason = &request.Reason
+	payment.ResolvedBy = &request.Operator
+	payment.ResolvedAt = &now
+
+	err = rh.EntityManager.Persist(payment)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err, "operation_id": payment.OperationID}).Error("Error saving resolved payment")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	log.WithFields(log.Fields{
+		"operation_id": payment.OperationID,
+		"status":       payment.Status,
+		"operator":     request.Operator,
+	}).Info("Received payment resolved")
+
+	links, err := rh.Repository.GetPaymentLinksByReceivedPaymentID(*payment.ID)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error getting payment links")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	server.Write(w, &bridge.ReceivedPaymentResponse{ReceivedPayment: bridge.NewReceivedPayment(payment, links)})
+}
+
+// AdminRefundReceivedPayment implements POST /admin/received-payments/:id/refund endpoint.
+// It sends the received amount back to the sender and marks the payment as refunded.
+func (rh *RequestHandler) AdminRefundReceivedPayment(c web.C, w http.ResponseWriter, r *http.Request) {
+	payment := rh.loadReceivedPayment(c, w)
+	if payment == nil {
+		return
+	}
+
+	request := &bridge.RefundRequest{}
+	request.FromRequest(r)
+
+	memoMutator, err := request.MemoMutator()
+	if err != nil {
+		errorResponse := err.(*protocols.ErrorResponse)
+		log.WithFields(errorResponse.LogData).Error(errorResponse.Error())
+		server.Write(w, errorResponse)
+		return
+	}
+
+	if payment.RefundTransactionID != nil || payment.FromAccount == "" || payment.Amount == "" {
+		server.Write(w, bridge.ReceivedPaymentNotRefundable)
+		return
+	}
+
+	if rh.Config.Accounts.BaseSeed == "" {
+		server.Write(w, bridge.RefundNoBaseSeed)
+		return
+	}
+
+	var amount interface{}
+	if payment.AssetCode == "" {
+		amount = b.NativeAmount{payment.Amount}
+	} else {
+		amount = b.CreditAmount{payment.AssetCode, payment.AssetIssuer, payment.Amount}
+	}
+
+	operationMutator := b.Payment(
+		b.Destination{payment.FromAccount},
+		amount,
+	)
+
+	var memo interface{}
+	if memoMutator != nil {
+		memo = memoMutator
+	}
+
+	submitResponse, err := rh.TransactionSubmitter.SubmitTransaction(r.Context(),
+		rh.Config.Accounts.BaseSeed,
+		operationMutator,
+		memo,
+	)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error submitting transaction")
+		server.Write(w, submitErrorResponse(err))
+		return
+	}
+
+	errorResponse := bridge.ErrorFromHorizonResponse(submitResponse)
+	if errorResponse != nil {
+		log.WithFields(errorResponse.LogData).Error(errorResponse.Error())
+		server.Write(w, errorResponse)
+		return
+	}
+
+	payment.Status = entities.ReceivedPaymentStatusRefunded
+	payment.RefundTransactionID = &submitResponse.Hash
+	err = rh.EntityManager.Persist(payment)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err, "operation_id": payment.OperationID}).Error("Error saving refunded payment")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	server.Write(w, &submitResponse)
+}
+
+// loadReceivedPayment finds a received payment using operation ID in `id` URL param.
+// When payment cannot be found it writes an error response and returns nil.
+func (rh *RequestHandler) loadReceivedPayment(c web.C, w http.ResponseWriter) *entities.ReceivedPayment {
+	payment, err := rh.Repository.GetReceivedPaymentByOperationID(c.URLParams["id"])
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error getting received payment")
+		server.Write(w, protocols.InternalServerError)
+		return nil
+	}
+
+	if payment == nil {
+		server.Write(w, bridge.ReceivedPaymentNotFound)
+		return nil
+	}
+
+	return payment
+}
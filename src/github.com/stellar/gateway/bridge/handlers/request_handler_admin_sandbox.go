@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"net/http"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/protocols"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stellar/gateway/server"
+	"github.com/stellar/go-stellar-base/keypair"
+)
+
+// AdminSandboxPayment implements POST /admin/sandbox/payments endpoint. It generates a payment
+// that is streamed to the payment listener as if it was received from the network.
+func (rh *RequestHandler) AdminSandboxPayment(w http.ResponseWriter, r *http.Request) {
+	request := &bridge.SandboxPaymentRequest{}
+	request.FromRequest(r)
+
+	err := request.Validate()
+	if err != nil {
+		errorResponse := err.(*protocols.ErrorResponse)
+		log.WithFields(errorResponse.LogData).Error(errorResponse.Error())
+		server.Write(w, errorResponse)
+		return
+	}
+
+	if request.From == "" {
+		kp, err := keypair.Random()
+		if err != nil {
+			log.WithFields(log.Fields{"err": err}).Error("Error generating random keypair")
+			server.Write(w, protocols.InternalServerError)
+			return
+		}
+		request.From = kp.Address()
+	}
+
+	if request.To == "" {
+		if rh.Config.Accounts.ReceivingAccountID == "" {
+			server.Write(w, protocols.NewMissingParameter("to"))
+			return
+		}
+		request.To = rh.Config.Accounts.ReceivingAccountID
+	}
+
+	payment := horizon.PaymentResponse{
+		From:        request.From,
+		To:          request.To,
+		AssetCode:   request.AssetCode,
+		AssetIssuer: request.AssetIssuer,
+		Amount:      request.Amount,
+	}
+	payment.Memo.Type = request.MemoType
+	payment.Memo.Value = request.Memo
+
+	payment, err = rh.Sandbox.ReceivePayment(payment)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error generating sandbox payment")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	server.Write(w, &bridge.SandboxPaymentResponse{PaymentResponse: payment})
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stellar/gateway/server"
+)
+
+// AdminStatus implements GET /admin/status endpoint. It returns operational state of the
+// server: Horizon latest ledger, DB connectivity, cursors of the payment listener and their
+// lag, depths of DB queues and recent errors. 503 status is returned when Horizon or the DB
+// cannot be reached so it can be used as a health probe.
+func (rh *RequestHandler) AdminStatus(w http.ResponseWriter, r *http.Request) {
+	response := &bridge.StatusResponse{
+		Status:       bridge.StatusOK,
+		Version:      rh.Version,
+		Tenant:       rh.Config.Tenant,
+		RecentErrors: []server.RecentError{},
+	}
+
+	root, err := rh.Horizon.LoadRoot(r.Context())
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Warn("Error loading Horizon root")
+		response.Status = bridge.StatusUnhealthy
+		response.Horizon.Error = err.Error()
+	} else {
+		response.Horizon.LatestLedger = root.HistoryLatestLedger
+	}
+
+	if rh.Repository != nil {
+		response.Database = &bridge.DatabaseStatus{Type: rh.Config.Database.Type, Connected: true}
+		err = rh.Repository.Ping()
+		if err != nil {
+			response.Status = bridge.StatusUnhealthy
+			response.Database.Connected = false
+			response.Database.Error = err.Error()
+		}
+	}
+
+	if rh.PaymentListener != nil {
+		response.Listener.Running = true
+		response.Listener.Leader = rh.PaymentListener.IsLeader()
+	}
+
+	response.Listener.Accounts = []bridge.ListenerAccountStatus{}
+	if response.Database != nil && response.Database.Connected {
+		for _, accountID := range rh.Config.Accounts.ReceivingAccounts() {
+			response.Listener.Accounts = append(response.Listener.Accounts, rh.listenerAccountStatus(accountID, response.Horizon.LatestLedger))
+		}
+
+		queues, err := rh.Repository.GetQueueDepths()
+		if err != nil {
+			log.WithFields(log.Fields{"err": err}).Error("Error loading queue depths")
+		} else {
+			response.Queues = &queues
+		}
+	}
+
+	if rh.RecentErrors != nil {
+		response.RecentErrors = rh.RecentErrors.Errors()
+	}
+
+	server.Write(w, response)
+}
+
+// listenerAccountStatus returns the cursor of accountID stream. Lag is returned when the
+// latest ledger is known.
+func (rh *RequestHandler) listenerAccountStatus(accountID string, latestLedger int32) (status bridge.ListenerAccountStatus) {
+	status.AccountID = accountID
+
+	cursor, err := rh.Repository.GetLastCursorValue(accountID)
+	if err != nil {
+		status.Error = err.Error()
+		return
+	}
+	if cursor == nil {
+		return
+	}
+	status.Cursor = *cursor
+
+	// Paging tokens of operations contain the ledger sequence in the upper 32 bits
+	pagingToken, err := strconv.ParseInt(*cursor, 10, 64)
+	if err != nil {
+		return
+	}
+	status.Ledger = int32(pagingToken >> 32)
+
+	if status.Ledger > 0 && latestLedger > 0 {
+		lag := latestLedger - status.Ledger
+		if lag < 0 {
+			lag = 0
+		}
+		status.Lag = &lag
+	}
+	return
+}
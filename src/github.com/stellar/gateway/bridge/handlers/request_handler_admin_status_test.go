@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/mocks"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stellar/gateway/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminStatus(t *testing.T) {
+	c := &config.Config{}
+	c.Database.Type = "sqlite3"
+	c.Accounts.ReceivingAccountID = "GATKP6ZQM5CSLECPMTAC5226PE367QALCPM6AFHTSULPPZMT62OOPMQB"
+	c.Accounts.ReceivingAccountIDs = []string{"GBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFZYYQ"}
+
+	mockHorizon := new(mocks.MockHorizon)
+	mockRepository := new(mocks.MockRepository)
+	recentErrors := &server.RecentErrors{Size: 2}
+	rh := RequestHandler{
+		Config:       c,
+		Horizon:      mockHorizon,
+		Repository:   mockRepository,
+		RecentErrors: recentErrors,
+		Version:      "v1.2.3",
+	}
+
+	logger := log.New()
+	logger.Hooks.Add(recentErrors)
+	logger.Out = ioutil.Discard
+	for _, message := range []string{"first", "second", "third"} {
+		logger.WithField("id", 1).Error(message)
+	}
+	logger.Warn("not an error")
+
+	status := func() (*httptest.ResponseRecorder, bridge.StatusResponse) {
+		w := httptest.NewRecorder()
+		rh.AdminStatus(w, httptest.NewRequest("GET", "/admin/status", nil))
+		var response bridge.StatusResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w, response
+	}
+
+	cursor := "429496729600001" // ledger 100000
+	mockHorizon.On("LoadRoot").Return(horizon.RootResponse{HistoryLatestLedger: 100010}, nil).Once()
+	mockRepository.On("Ping").Return(nil).Once()
+	mockRepository.On("GetLastCursorValue", c.Accounts.ReceivingAccountID).Return(&cursor, nil).Once()
+	mockRepository.On("GetLastCursorValue", c.Accounts.ReceivingAccountIDs[0]).Return((*string)(nil), nil).Once()
+	mockRepository.On("GetQueueDepths").Return(entities.QueueDepths{CallbackRetries: 3, DeadLetters: 1}, nil).Once()
+
+	w, response := status()
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, bridge.StatusOK, response.Status)
+	assert.Equal(t, "v1.2.3", response.Version)
+	assert.Equal(t, int32(100010), response.Horizon.LatestLedger)
+	assert.Equal(t, &bridge.DatabaseStatus{Type: "sqlite3", Connected: true}, response.Database)
+	assert.False(t, response.Listener.Running)
+	require.Len(t, response.Listener.Accounts, 2)
+	assert.Equal(t, cursor, response.Listener.Accounts[0].Cursor)
+	assert.Equal(t, int32(100000), response.Listener.Accounts[0].Ledger)
+	require.NotNil(t, response.Listener.Accounts[0].Lag)
+	assert.Equal(t, int32(10), *response.Listener.Accounts[0].Lag)
+	assert.Equal(t, "", response.Listener.Accounts[1].Cursor)
+	assert.Nil(t, response.Listener.Accounts[1].Lag)
+	assert.Equal(t, &entities.QueueDepths{CallbackRetries: 3, DeadLetters: 1}, response.Queues)
+	require.Len(t, response.RecentErrors, 2)
+	assert.Equal(t, "third", response.RecentErrors[0].Message)
+	assert.Equal(t, "second", response.RecentErrors[1].Message)
+	assert.Equal(t, map[string]string{"id": "1"}, response.RecentErrors[0].Fields)
+	mockRepository.AssertExpectations(t)
+
+	// Unhealthy
+	mockHorizon.On("LoadRoot").Return(horizon.RootResponse{}, errors.New("connection refused")).Once()
+	mockRepository.On("Ping").Return(errors.New("database is closed")).Once()
+
+	w, response = status()
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, bridge.StatusUnhealthy, response.Status)
+	assert.Equal(t, "connection refused", response.Horizon.Error)
+	assert.False(t, response.Database.Connected)
+	assert.Equal(t, "database is closed", response.Database.Error)
+	assert.Empty(t, response.Listener.Accounts)
+	assert.Nil(t, response.Queues)
+	mockHorizon.AssertExpectations(t)
+	mockRepository.AssertExpectations(t)
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"net/http"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/protocols"
+	"github.com/stellar/gateway/protocols/amount"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stellar/gateway/server"
+	"github.com/zenazn/goji/web"
+)
+
+// AdminTransfer implements GET /admin/transfers/:id endpoint. `:id` is the transaction ID
+// returned to the wallet.
+func (rh *RequestHandler) AdminTransfer(c web.C, w http.ResponseWriter, r *http.Request) {
+	transaction := rh.loadTransfer(c, w)
+	if transaction == nil {
+		return
+	}
+
+	server.Write(w, &bridge.TransferTransactionResponse{
+		Transaction: bridge.NewTransferTransaction(transaction, rh.Config.Accounts.ReceivingAccountID),
+	})
+}
+
+// AdminUpdateTransfer implements PUT /admin/transfers/:id endpoint. It's used by the anchor
+// (ex. `transfer_server.interactive_url` web app) to move a transaction forward: an amount
+// can be set until the user starts the transfer. The new status is sent to
+// `callbacks.transfer_status`.
+func (rh *RequestHandler) AdminUpdateTransfer(c web.C, w http.ResponseWriter, r *http.Request) {
+	transaction := rh.loadTransfer(c, w)
+	if transaction == nil {
+		return
+	}
+
+	request := &bridge.TransferUpdateRequest{}
+	request.FromRequest(r)
+
+	err := request.Validate()
+	if err != nil {
+		errorResponse := err.(*protocols.ErrorResponse)
+		log.WithFields(errorResponse.LogData).Error(errorResponse.Error())
+		server.Write(w, errorResponse)
+		return
+	}
+
+	if transaction.Status == entities.TransferStatusCompleted {
+		server.Write(w, bridge.TransferTransactionCompleted)
+		return
+	}
+
+	if request.Amount != "" {
+		if transaction.Status != entities.TransferStatusIncomplete && transaction.Status != entities.TransferStatusPendingUserTransferStart {
+			server.Write(w, protocols.NewInvalidParameterError("amount", request.Amount))
+			return
+		}
+
+		asset := rh.Config.TransferServer.AssetFor(transaction.AssetCode)
+		if asset == nil {
+			server.Write(w, bridge.TransferAssetNotSupported)
+			return
+		}
+
+		// Amount is checked in Validate
+		amountIn, _ := amount.Parse(request.Amount)
+		fee, ok := transferFee(asset, amountIn)
+		if !ok {
+			server.Write(w, bridge.TransferInvalidAmount)
+			return
+		}
+		setTransferAmounts(transaction, amountIn, fee)
+	}
+
+	transaction.Status = request.Status
+	if request.Message != "" {
+		transaction.StatusMessage = &request.Message
+	}
+
+	err = rh.EntityManager.Persist(transaction)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error persisting transfer transaction")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	log.WithFields(log.Fields{"id": transaction.PublicID, "status": transaction.Status}).Info("Transfer transaction updated")
+	rh.notifyTransferStatus(r.Context(), transaction)
+
+	server.Write(w, &bridge.TransferTransactionResponse{
+		Transaction: bridge.NewTransferTransaction(transaction, rh.Config.Accounts.ReceivingAccountID),
+	})
+}
+
+// loadTransfer returns transfer transaction with `:id` URL param or writes an error response
+// and returns nil
+func (rh *RequestHandler) loadTransfer(c web.C, w http.ResponseWriter) *entities.TransferTransaction {
+	transaction, err := rh.Repository.GetTransferTransactionByPublicID(c.URLParams["id"])
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error getting transfer transaction")
+		server.Write(w, protocols.InternalServerError)
+		return nil
+	}
+
+	if transaction == nil {
+		server.Write(w, bridge.TransferTransactionNotFound)
+		return nil
+	}
+
+	return transaction
+}
@@ -15,7 +15,7 @@ func (rh *RequestHandler) Authorize(w http.ResponseWriter, r *http.Request) {
 	request := &bridge.AuthorizeRequest{}
 	request.FromRequest(r)
 
-	err := request.Validate(rh.Config.Assets, rh.Config.Accounts.IssuingAccountID)
+	err := request.Validate(rh.Config.AssetFilter(), rh.Config.Accounts.IssuingAccountID)
 	if err != nil {
 		errorResponse := err.(*protocols.ErrorResponse)
 		log.WithFields(errorResponse.LogData).Error(errorResponse.Error())
@@ -29,7 +29,7 @@ func (rh *RequestHandler) Authorize(w http.ResponseWriter, r *http.Request) {
 		b.AllowTrustAsset{request.AssetCode},
 	)
 
-	submitResponse, err := rh.TransactionSubmitter.SubmitTransaction(
+	submitResponse, err := rh.TransactionSubmitter.SubmitTransaction(r.Context(),
 		rh.Config.Accounts.AuthorizingSeed,
 		operationMutator,
 		nil,
@@ -37,7 +37,7 @@ func (rh *RequestHandler) Authorize(w http.ResponseWriter, r *http.Request) {
 
 	if err != nil {
 		log.WithFields(log.Fields{"err": err}).Error("Error submitting transaction")
-		server.Write(w, protocols.InternalServerError)
+		server.Write(w, submitErrorResponse(err))
 		return
 	}
 
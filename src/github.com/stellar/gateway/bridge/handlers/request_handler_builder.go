@@ -1,15 +1,23 @@
 package handlers
 
 import (
+	"bytes"
+	"encoding/hex"
 	"encoding/json"
 	log "github.com/Sirupsen/logrus"
 	"net/http"
 	"strconv"
+	"time"
 
+	"github.com/stellar/gateway/db/entities"
 	"github.com/stellar/gateway/protocols"
 	"github.com/stellar/gateway/protocols/bridge"
 	"github.com/stellar/gateway/server"
+	"github.com/stellar/gateway/signer"
+	"github.com/stellar/gateway/submitter"
 	b "github.com/stellar/go-stellar-base/build"
+	"github.com/stellar/go-stellar-base/keypair"
+	"github.com/stellar/go-stellar-base/xdr"
 )
 
 // Builder implements /builder endpoint
@@ -48,31 +56,163 @@ func (rh *RequestHandler) Builder(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	mutators := []b.TransactionMutator{
-		b.SourceAccount{request.Source},
-		b.Sequence{sequenceNumber},
-		b.Network{rh.Config.NetworkPassphrase},
+	preconditions := rh.preconditions(request.TransactionPreconditions)
+
+	var txeB64, hash string
+	if request.HasRawOperations() || preconditions.IsV2() {
+		txeB64, hash, err = rh.buildRawTransaction(request, sequenceNumber, preconditions)
+		if err != nil {
+			errorResponse, ok := err.(*protocols.ErrorResponse)
+			if !ok {
+				log.WithFields(log.Fields{"err": err, "request": request}).Error("Error encoding transaction")
+				errorResponse = protocols.InternalServerError
+			}
+			server.Write(w, errorResponse)
+			return
+		}
+	} else {
+		mutators := []b.TransactionMutator{
+			b.SourceAccount{request.Source},
+			b.Sequence{sequenceNumber},
+			b.Network{rh.Config.NetworkPassphrase},
+		}
+
+		for _, operation := range request.Operations {
+			mutators = append(mutators, operation.Body.ToTransactionMutator())
+		}
+
+		tx := b.Transaction(mutators...)
+
+		if tx.Err != nil {
+			log.WithFields(log.Fields{"err": err, "request": request}).Error("TransactionBuilder returned error")
+			server.Write(w, protocols.InternalServerError)
+			return
+		}
+
+		tx.TX.TimeBounds = preconditions.TimeBounds()
+		txe := tx.Sign(request.Signers...)
+		txeB64, err = txe.Base64()
+		if err != nil {
+			log.WithFields(log.Fields{"err": err, "request": request}).Error("Error encoding transaction envelope")
+			server.Write(w, protocols.InternalServerError)
+			return
+		}
+
+		hash, err = tx.HashHex()
+		if err != nil {
+			log.WithFields(log.Fields{"err": err, "request": request}).Error("Error hashing transaction")
+			server.Write(w, protocols.InternalServerError)
+			return
+		}
 	}
 
-	for _, operation := range request.Operations {
-		mutators = append(mutators, operation.Body.ToTransactionMutator())
+	if rh.EntityManager != nil {
+		err = rh.saveBuiltTransaction(hash, request.Source, txeB64)
+		if err != nil {
+			log.WithFields(log.Fields{"err": err}).Error("Error saving built transaction")
+			server.Write(w, protocols.InternalServerError)
+			return
+		}
 	}
 
-	tx := b.Transaction(mutators...)
+	server.Write(w, &bridge.BuilderResponse{TransactionEnvelope: txeB64})
+}
 
-	if tx.Err != nil {
-		log.WithFields(log.Fields{"err": err, "request": request}).Error("TransactionBuilder returned error")
-		server.Write(w, protocols.InternalServerError)
-		return
+// saveBuiltTransaction saves a transaction built by /builder endpoint with `sending` status so its
+// outcome can be checked using /transactions/:hash endpoint after the client submits it
+func (rh *RequestHandler) saveBuiltTransaction(hash, source, txeB64 string) error {
+	existing, err := rh.Repository.GetSentTransactionByTransactionID(hash)
+	if err != nil || existing != nil {
+		return err
+	}
+
+	return rh.EntityManager.Persist(&entities.SentTransaction{
+		TransactionID: hash,
+		Status:        entities.SentTransactionStatusSending,
+		Source:        source,
+		SubmittedAt:   time.Now(),
+		EnvelopeXdr:   txeB64,
+		Tenant:        rh.Config.Tenant,
+	})
+}
+
+// builderOperationFee is a fee per operation of transactions built by /builder endpoint, the
+// same as set by go-stellar-base
+const builderOperationFee = 100
+
+// buildRawTransaction returns envelope and hash of a transaction containing operations not
+// supported by go-stellar-base (bridge.RawOperationBody) or preconditions other than time
+// bounds. Other operations are encoded using go-stellar-base one by one.
+func (rh *RequestHandler) buildRawTransaction(request bridge.BuilderRequest, sequenceNumber uint64, preconditions submitter.Preconditions) (txeB64, hash string, err error) {
+	signers := make([]signer.Signer, 0, len(request.Signers))
+	for i, seed := range request.Signers {
+		kp, _ := keypair.Parse(seed)
+		full, ok := kp.(*keypair.Full)
+		if !ok {
+			err = protocols.NewInvalidParameterError("signers["+strconv.Itoa(i)+"]", seed)
+			return
+		}
+		signers = append(signers, full)
+	}
+
+	operations := make([][]byte, 0, len(request.Operations))
+	for _, operation := range request.Operations {
+		var encoded []byte
+		if raw, ok := operation.Body.(bridge.RawOperationBody); ok {
+			encoded, err = encodeRawOperation(raw)
+		} else {
+			encoded, err = rh.encodeOperation(request.Source, operation.Body)
+		}
+		if err != nil {
+			return
+		}
+		operations = append(operations, encoded)
 	}
 
-	txe := tx.Sign(request.Signers...)
-	txeB64, err := txe.Base64()
+	txeB64, txHash, err := submitter.BuildRawTransaction(
+		request.Source,
+		sequenceNumber,
+		builderOperationFee,
+		preconditions,
+		operations,
+		rh.Config.NetworkPassphrase,
+		signers...,
+	)
+	hash = hex.EncodeToString(txHash[:])
+	return
+}
+
+// encodeRawOperation returns XDR of an operation not supported by go-stellar-base
+func encodeRawOperation(operation bridge.RawOperationBody) ([]byte, error) {
+	source, operationType, body, err := operation.RawOperation()
 	if err != nil {
-		log.WithFields(log.Fields{"err": err, "request": request}).Error("Error encoding transaction envelope")
-		server.Write(w, protocols.InternalServerError)
-		return
+		return nil, err
 	}
 
-	server.Write(w, &bridge.BuilderResponse{TransactionEnvelope: txeB64})
+	var sourceID *xdr.AccountId
+	if source != nil {
+		sourceID = &xdr.AccountId{}
+		err = sourceID.SetAddress(*source)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return submitter.RawOperation(sourceID, operationType, body)
+}
+
+// encodeOperation returns XDR of an operation encoded using go-stellar-base
+func (rh *RequestHandler) encodeOperation(source string, operation bridge.OperationBody) ([]byte, error) {
+	tx := b.Transaction(
+		b.SourceAccount{source},
+		b.Network{rh.Config.NetworkPassphrase},
+		operation.ToTransactionMutator(),
+	)
+	if tx.Err != nil {
+		return nil, tx.Err
+	}
+
+	var encoded bytes.Buffer
+	_, err := xdr.Marshal(&encoded, tx.TX.Operations[0])
+	return encoded.Bytes(), err
 }
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"net/http"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/protocols"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stellar/gateway/server"
+)
+
+// Claim implements /claim endpoint. It claims a claimable balance by the receiving account.
+func (rh *RequestHandler) Claim(w http.ResponseWriter, r *http.Request) {
+	request := &bridge.ClaimRequest{}
+	request.FromRequest(r)
+
+	err := request.Validate()
+	if err != nil {
+		errorResponse := err.(*protocols.ErrorResponse)
+		log.WithFields(errorResponse.LogData).Error(errorResponse.Error())
+		server.Write(w, errorResponse)
+		return
+	}
+
+	if rh.Config.Accounts.ReceivingSeed == "" {
+		server.Write(w, bridge.ClaimNoReceivingSeed)
+		return
+	}
+
+	submitResponse, err := rh.TransactionSubmitter.ClaimClaimableBalance(r.Context(), rh.Config.Accounts.ReceivingSeed, request.BalanceID)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error submitting transaction")
+		server.Write(w, submitErrorResponse(err))
+		return
+	}
+
+	errorResponse := bridge.ErrorFromClaimResponse(submitResponse)
+	if errorResponse != nil {
+		log.WithFields(errorResponse.LogData).Error(errorResponse.Error())
+		server.Write(w, errorResponse)
+		return
+	}
+
+	server.Write(w, &submitResponse)
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"net/http"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/protocols"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stellar/gateway/server"
+	"github.com/stellar/go-stellar-base/amount"
+	"github.com/stellar/go-stellar-base/keypair"
+	"github.com/stellar/go-stellar-base/xdr"
+)
+
+// CreateAccount implements /create-account endpoint. It creates and funds a new account using
+// the base account and adds trustlines of the requested assets in a single transaction.
+func (rh *RequestHandler) CreateAccount(w http.ResponseWriter, r *http.Request) {
+	logger := server.Logger(r)
+	request := &bridge.CreateAccountRequest{}
+	request.FromRequest(r)
+
+	err := request.Validate()
+	if err != nil {
+		errorResponse := err.(*protocols.ErrorResponse)
+		logger.WithFields(errorResponse.LogData).Error(errorResponse.Error())
+		server.Write(w, errorResponse)
+		return
+	}
+
+	if rh.Config.Accounts.BaseSeed == "" {
+		server.Write(w, bridge.CreateAccountNoBaseSeed)
+		return
+	}
+
+	assets := make([]xdr.Asset, 0, len(request.Assets))
+	for _, asset := range request.Assets {
+		if !rh.Config.AssetFilter().Allows(asset.Code, asset.Issuer) {
+			logger.WithFields(log.Fields{"asset": asset.String()}).Print("Asset not allowed")
+			server.Write(w, bridge.PaymentAssetCodeNotAllowed)
+			return
+		}
+
+		xdrAsset, err := asset.ToBaseAsset().ToXdrObject()
+		if err != nil {
+			server.Write(w, protocols.NewInvalidParameterError("trustlines", request.Trustlines))
+			return
+		}
+		assets = append(assets, xdrAsset)
+	}
+
+	var newAccount *keypair.Full
+	var generatedSeed string
+	if request.Seed != "" {
+		kp, _ := keypair.Parse(request.Seed)
+		newAccount = kp.(*keypair.Full)
+	} else {
+		newAccount, err = keypair.Random()
+		if err != nil {
+			logger.WithFields(log.Fields{"err": err}).Error("Error generating random keypair")
+			server.Write(w, protocols.InternalServerError)
+			return
+		}
+		generatedSeed = newAccount.Seed()
+	}
+
+	if request.StartingBalance == "" {
+		request.StartingBalance = rh.Config.CreateAccount.StartingBalanceOrDefault()
+	}
+
+	startingBalance, err := amount.Parse(request.StartingBalance)
+	if err != nil {
+		server.Write(w, protocols.NewInvalidParameterError("starting_balance", request.StartingBalance))
+		return
+	}
+
+	submitResponse, err := rh.TransactionSubmitter.CreateAccount(r.Context(),
+		rh.Config.Accounts.BaseSeed,
+		newAccount,
+		startingBalance,
+		assets,
+		request.IsSponsored(),
+	)
+	if err != nil {
+		logger.WithFields(log.Fields{"err": err}).Error("Error submitting transaction")
+		server.Write(w, submitErrorResponse(err))
+		return
+	}
+
+	errorResponse := bridge.ErrorFromCreateAccountResponse(submitResponse)
+	if errorResponse != nil {
+		logger.WithFields(errorResponse.LogData).Error(errorResponse.Error())
+		server.Write(w, errorResponse)
+		return
+	}
+
+	response := &bridge.CreateAccountResponse{
+		AccountID: newAccount.Address(),
+		Seed:      generatedSeed,
+		Hash:      submitResponse.Hash,
+		Sponsored: request.IsSponsored(),
+	}
+	if submitResponse.Ledger != nil {
+		response.Ledger = *submitResponse.Ledger
+	}
+
+	account, err := rh.Horizon.LoadAccount(r.Context(), newAccount.Address())
+	if err != nil {
+		logger.WithFields(log.Fields{"err": err}).Warn("Error loading created account")
+	} else {
+		response.Balances = account.Balances
+	}
+
+	server.Write(w, response)
+}
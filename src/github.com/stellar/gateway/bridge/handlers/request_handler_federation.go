@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/protocols"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stellar/gateway/server"
+)
+
+// Federation implements GET /federation endpoint. It resolves `name*domain` addresses
+// of `federation.domain` using `federation.addresses` and `federation.query`.
+func (rh *RequestHandler) Federation(w http.ResponseWriter, r *http.Request) {
+	// Federation servers are queried by wallets from browsers
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	query := r.URL.Query()
+	if query.Get("type") != "name" {
+		server.Write(w, bridge.FederationTypeNotSupported)
+		return
+	}
+
+	q := query.Get("q")
+	tokens := strings.Split(q, "*")
+	if len(tokens) != 2 || tokens[0] == "" || tokens[1] == "" {
+		server.Write(w, protocols.NewInvalidParameterError("q", q))
+		return
+	}
+
+	if !strings.EqualFold(tokens[1], rh.Config.Federation.Domain) {
+		server.Write(w, bridge.FederationNotFound)
+		return
+	}
+
+	response := &bridge.FederationResponse{StellarAddress: q}
+	name := tokens[0]
+
+	if address := rh.Config.Federation.Address(name); address != nil {
+		response.AccountID = address.AccountID
+		response.MemoType = address.MemoType
+		response.Memo = address.Memo
+	} else if rh.Config.Federation.Query != "" {
+		record, err := rh.Repository.GetFederationRecord(rh.Config.Federation.Query, name)
+		if err != nil {
+			log.WithFields(log.Fields{"err": err, "name": name}).Error("Error running federation query")
+			server.Write(w, protocols.InternalServerError)
+			return
+		}
+
+		if record == nil || record.AccountID == "" {
+			server.Write(w, bridge.FederationNotFound)
+			return
+		}
+
+		response.AccountID = record.AccountID
+		response.MemoType = record.MemoType
+		response.Memo = record.Memo
+	} else {
+		server.Write(w, bridge.FederationNotFound)
+		return
+	}
+
+	server.Write(w, response)
+}
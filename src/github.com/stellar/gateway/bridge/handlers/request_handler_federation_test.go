@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/mocks"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestHandlerFederation(t *testing.T) {
+	mockRepository := new(mocks.MockRepository)
+	c := &config.Config{}
+	c.Federation = config.Federation{
+		Domain: "example.com",
+		Query:  "SELECT account_id, memo_type, memo FROM users WHERE name = ?",
+		Addresses: []config.FederationAddress{
+			{Name: "alice", AccountID: "GATKP6ZQM5CSLECPMTAC5226PE367QALCPM6AFHTSULPPZMT62OOPMQB"},
+		},
+	}
+	rh := RequestHandler{Config: c, Repository: mockRepository}
+
+	get := func(queryType, q string) (int, bridge.FederationResponse) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/federation?"+url.Values{"type": {queryType}, "q": {q}}.Encode(), nil)
+		rh.Federation(w, r)
+		assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+
+		var response bridge.FederationResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		return w.Code, response
+	}
+
+	mockRepository.On("GetFederationRecord", c.Federation.Query, "bob").Return(&entities.FederationRecord{
+		AccountID: "GBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFZYYQ",
+		MemoType:  "id",
+		Memo:      "42",
+	}, nil)
+	mockRepository.On("GetFederationRecord", c.Federation.Query, "carol").Return(nil, nil)
+
+	// Static address
+	code, response := get("name", "alice*example.com")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "alice*example.com", response.StellarAddress)
+	assert.Equal(t, "GATKP6ZQM5CSLECPMTAC5226PE367QALCPM6AFHTSULPPZMT62OOPMQB", response.AccountID)
+	assert.Equal(t, "", response.MemoType)
+
+	// Query
+	code, response = get("name", "bob*EXAMPLE.com")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "GBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFZYYQ", response.AccountID)
+	assert.Equal(t, "id", response.MemoType)
+	assert.Equal(t, "42", response.Memo)
+
+	code, _ = get("name", "carol*example.com")
+	assert.Equal(t, http.StatusNotFound, code)
+
+	code, _ = get("name", "alice*other.com")
+	assert.Equal(t, http.StatusNotFound, code)
+
+	code, _ = get("name", "alice")
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	code, _ = get("id", "GATKP6ZQM5CSLECPMTAC5226PE367QALCPM6AFHTSULPPZMT62OOPMQB")
+	assert.Equal(t, http.StatusNotImplemented, code)
+
+	mockRepository.AssertExpectations(t)
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stellar/gateway/server"
+)
+
+// Healthz implements GET /healthz endpoint (liveness probe). It returns 200 OK when the
+// process is able to serve requests; dependencies are not checked so a restart is not
+// triggered when Horizon or the DB is down.
+func (rh *RequestHandler) Healthz(w http.ResponseWriter, r *http.Request) {
+	server.Write(w, &bridge.HealthResponse{Status: bridge.StatusOK})
+}
+
+// Readyz implements GET /readyz endpoint (readiness probe). It returns 503 Service Unavailable
+// when the server cannot submit payments or process received ones: the DB or Horizon cannot
+// be reached, the payment listener has been stopped or the base seed cannot be loaded (ex.
+// locked keystore).
+func (rh *RequestHandler) Readyz(w http.ResponseWriter, r *http.Request) {
+	response := &bridge.HealthResponse{Status: bridge.StatusOK, Checks: map[string]string{}}
+	check := func(name string, err error) {
+		if err == nil {
+			response.Checks[name] = bridge.StatusOK
+			return
+		}
+		log.WithFields(log.Fields{"check": name, "err": err}).Warn("Readiness check failed")
+		response.Status = bridge.StatusUnavailable
+		response.Checks[name] = err.Error()
+	}
+
+	if rh.Repository != nil {
+		check("database", rh.Repository.Ping())
+	}
+
+	_, err := rh.Horizon.LoadRoot(r.Context())
+	check("horizon", err)
+
+	if rh.PaymentListener != nil {
+		err = nil
+		if !rh.PaymentListener.IsRunning() {
+			err = errors.New("payment listener is stopped")
+		}
+		check("listener", err)
+	}
+
+	if rh.Config.Accounts.BaseSeed != "" {
+		_, err = rh.Signers.Signer(rh.Config.Accounts.BaseSeed)
+		check("base_seed", err)
+	}
+
+	server.Write(w, response)
+}
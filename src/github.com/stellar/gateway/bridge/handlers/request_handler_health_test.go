@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/mocks"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthz(t *testing.T) {
+	rh := RequestHandler{}
+	w := httptest.NewRecorder()
+	rh.Healthz(w, httptest.NewRequest("GET", "/healthz", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"status": "ok"`)
+}
+
+func TestReadyz(t *testing.T) {
+	c := &config.Config{}
+	c.Accounts.BaseSeed = "SDWLS4G3XCNIYPKXJWWGGJT6UDY63WV6PEFTWP7JZMQB4RE7EUJQN5XM"
+
+	mockHorizon := new(mocks.MockHorizon)
+	mockRepository := new(mocks.MockRepository)
+	rh := RequestHandler{Config: c, Horizon: mockHorizon, Repository: mockRepository}
+
+	readyz := func() (int, bridge.HealthResponse) {
+		w := httptest.NewRecorder()
+		rh.Readyz(w, httptest.NewRequest("GET", "/readyz", nil))
+		var response bridge.HealthResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w.Code, response
+	}
+
+	mockHorizon.On("LoadRoot").Return(horizon.RootResponse{}, nil).Once()
+	mockRepository.On("Ping").Return(nil).Once()
+
+	code, response := readyz()
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, bridge.HealthResponse{
+		Status: bridge.StatusOK,
+		Checks: map[string]string{"database": "ok", "horizon": "ok", "base_seed": "ok"},
+	}, response)
+
+	// Horizon unreachable and key reference without a signer
+	c.Accounts.BaseSeed = "keystore:base"
+	mockHorizon.On("LoadRoot").Return(horizon.RootResponse{}, errors.New("connection refused")).Once()
+	mockRepository.On("Ping").Return(nil).Once()
+
+	code, response = readyz()
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, bridge.StatusUnavailable, response.Status)
+	assert.Equal(t, "ok", response.Checks["database"])
+	assert.Equal(t, "connection refused", response.Checks["horizon"])
+	assert.Contains(t, response.Checks["base_seed"], "signer is not configured")
+	mockHorizon.AssertExpectations(t)
+	mockRepository.AssertExpectations(t)
+}
@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"net/http"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/protocols"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stellar/gateway/server"
+)
+
+// MuxedAddress implements /muxed-address endpoint. It converts a muxed address to
+// account ID and memo ID or account ID and memo ID to a muxed address.
+func (rh *RequestHandler) MuxedAddress(w http.ResponseWriter, r *http.Request) {
+	request := &bridge.MuxedAddressRequest{}
+	request.FromRequest(r)
+
+	response, err := request.Process()
+	if err != nil {
+		errorResponse := err.(*protocols.ErrorResponse)
+		log.WithFields(errorResponse.LogData).Error(errorResponse.Error())
+		server.Write(w, errorResponse)
+		return
+	}
+
+	server.Write(w, response)
+}
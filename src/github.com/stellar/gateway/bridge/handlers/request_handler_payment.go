@@ -1,21 +1,30 @@
 package handlers
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
-	"fmt"
 	log "github.com/Sirupsen/logrus"
 	"io/ioutil"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/events"
 	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/limits"
+	"github.com/stellar/gateway/listener"
+	"github.com/stellar/gateway/metrics"
 	"github.com/stellar/gateway/protocols"
 	"github.com/stellar/gateway/protocols/bridge"
 	"github.com/stellar/gateway/protocols/compliance"
 	"github.com/stellar/gateway/server"
+	"github.com/stellar/gateway/signer"
+	"github.com/stellar/gateway/submitter"
+	"github.com/stellar/gateway/tracing"
 	"github.com/stellar/go-stellar-base/amount"
 	b "github.com/stellar/go-stellar-base/build"
 	"github.com/stellar/go-stellar-base/keypair"
@@ -24,13 +33,21 @@ import (
 
 // Payment implements /payment endpoint
 func (rh *RequestHandler) Payment(w http.ResponseWriter, r *http.Request) {
+	rh.payment(w, r, false)
+}
+
+// payment sends a payment of /payment request. When preview is true the transaction is built
+// and checked but it's not submitted, see PaymentPreview.
+func (rh *RequestHandler) payment(w http.ResponseWriter, r *http.Request, preview bool) {
+	logger := server.Logger(r)
+	span := tracing.FromRequest(r)
 	request := &bridge.PaymentRequest{}
 	request.FromRequest(r)
 
 	err := request.Validate()
 	if err != nil {
 		errorResponse := err.(*protocols.ErrorResponse)
-		log.WithFields(errorResponse.LogData).Error(errorResponse.Error())
+		logger.WithFields(errorResponse.LogData).Error(errorResponse.Error())
 		server.Write(w, errorResponse)
 		return
 	}
@@ -39,21 +56,204 @@ func (rh *RequestHandler) Payment(w http.ResponseWriter, r *http.Request) {
 		request.Source = rh.Config.Accounts.BaseSeed
 	}
 
-	sourceKeypair, _ := keypair.Parse(request.Source)
+	// Validated in PaymentRequest.Validate. Transactions built by the compliance server cannot
+	// be changed and channel accounts are sources of transactions sent using them.
+	requestPreconditions, _ := request.Preconditions()
+	if (!requestPreconditions.IsEmpty() && request.ExtraMemo != "" && rh.Config.Compliance != "") ||
+		(requestPreconditions.IsV2() && len(rh.Config.Accounts.ChannelSeeds) > 0) {
+		server.Write(w, bridge.PaymentPreconditionsNotSupported)
+		return
+	}
+
+	// Source is a secret seed of the request or a seed or a key reference of the base account
+	sourceKeypair, err := rh.Signers.Signer(request.Source)
+	if err != nil {
+		logger.WithFields(log.Fields{"err": err}).Error("Cannot load source account signer")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	// Native asset can always be sent, ex. to create destination accounts
+	if request.AssetCode != "" && !rh.Config.AssetFilter().Allows(request.AssetCode, request.AssetIssuer) {
+		logger.WithFields(log.Fields{"asset_code": request.AssetCode, "asset_issuer": request.AssetIssuer}).Print("Asset not allowed")
+		server.Write(w, bridge.PaymentAssetCodeNotAllowed)
+		return
+	}
+
+	// Path of a path payment sent without path[] is found using Horizon
+	if request.SendMax != "" && len(request.Path) == 0 &&
+		(request.SendAssetCode != request.AssetCode || request.SendAssetIssuer != request.AssetIssuer) {
+		pathSpan := span.Child("horizon.find_paths", tracing.KindClient)
+		path, sourceAmount, err := rh.findPaymentPath(r.Context(), request)
+		pathSpan.End(err)
+		if err != nil {
+			logger.WithFields(log.Fields{"err": err}).Error("Error finding payment path")
+			server.Write(w, protocols.InternalServerError)
+			return
+		}
+
+		if path == nil {
+			logger.Print("Payment path not found")
+			server.Write(w, bridge.PaymentTooFewOffers)
+			return
+		}
+
+		// Validated in PaymentRequest.Validate
+		sendMax, _ := amount.Parse(request.SendMax)
+		if sourceAmount > sendMax {
+			logger.WithFields(log.Fields{"source_amount": path.SourceAmount, "send_max": request.SendMax}).Print("Payment path exceeds send_max")
+			server.Write(w, bridge.PaymentOverSendmax)
+			return
+		}
+
+		for _, asset := range path.Path {
+			request.Path = append(request.Path, protocols.Asset{Code: asset.AssetCode, Issuer: asset.AssetIssuer})
+		}
+		logger.WithFields(log.Fields{"source_amount": path.SourceAmount, "path": request.Path}).Info("Payment path found")
+	}
+
+	var receivedPayment *entities.ReceivedPayment
+	if request.ReceivedPaymentID != "" {
+		if rh.Repository == nil {
+			logger.Print("received_payment_id given but bridge server is started without a DB")
+			server.Write(w, protocols.NewInvalidParameterError("received_payment_id", request.ReceivedPaymentID))
+			return
+		}
+
+		receivedPayment, err = rh.Repository.GetReceivedPaymentByOperationID(request.ReceivedPaymentID)
+		if err != nil {
+			logger.WithFields(log.Fields{"err": err}).Error("Error getting received payment")
+			server.Write(w, protocols.InternalServerError)
+			return
+		}
+
+		if receivedPayment == nil {
+			server.Write(w, bridge.ReceivedPaymentNotFound)
+			return
+		}
+	}
+
+	var deposit *entities.TransferTransaction
+	if request.TransferID != "" {
+		if rh.Repository == nil {
+			logger.Print("transfer_id given but bridge server is started without a DB")
+			server.Write(w, protocols.NewInvalidParameterError("transfer_id", request.TransferID))
+			return
+		}
+
+		deposit, err = rh.Repository.GetTransferTransactionByPublicID(request.TransferID)
+		if err != nil {
+			logger.WithFields(log.Fields{"err": err}).Error("Error getting deposit")
+			server.Write(w, protocols.InternalServerError)
+			return
+		}
+
+		if deposit == nil || deposit.Kind != entities.TransferKindDeposit {
+			server.Write(w, bridge.TransferTransactionNotFound)
+			return
+		}
+
+		// Info of interactive deposits must be collected first
+		if deposit.Status == entities.TransferStatusCompleted || deposit.Status == entities.TransferStatusIncomplete {
+			server.Write(w, protocols.NewInvalidParameterError("transfer_id", request.TransferID))
+			return
+		}
+	}
+
+	if request.Metadata != "" && rh.Repository == nil {
+		logger.Print("metadata given but bridge server is started without a DB")
+		server.Write(w, protocols.NewInvalidParameterError("metadata", request.Metadata))
+		return
+	}
+
+	// Reserved sent transaction of a request with idempotency_key. It is removed when the
+	// request fails before the transaction is submitted, so the key can be used again.
+	var reserved *entities.SentTransaction
+	var submitted bool
+	if request.IdempotencyKey != "" && !preview {
+		if rh.Repository == nil {
+			logger.Print("idempotency_key given but bridge server is started without a DB")
+			server.Write(w, protocols.NewInvalidParameterError("idempotency_key", request.IdempotencyKey))
+			return
+		}
+
+		var duplicate bool
+		reserveSpan := span.Child("db.reserve_idempotency_key", tracing.KindInternal)
+		reserved, duplicate, err = rh.reserveIdempotencyKey(request.IdempotencyKey, sourceKeypair.Address())
+		reserveSpan.End(err)
+		if err != nil {
+			logger.WithFields(log.Fields{"err": err}).Error("Error reserving idempotency key")
+			server.Write(w, protocols.InternalServerError)
+			return
+		}
+
+		if duplicate {
+			logger.WithFields(log.Fields{"idempotency_key": request.IdempotencyKey}).Info("Duplicate idempotency key")
+			rh.writeIdempotentResult(w, reserved)
+			return
+		}
+
+		defer func() {
+			if submitted {
+				return
+			}
+			err := rh.EntityManager.Delete(reserved)
+			if err != nil {
+				logger.WithFields(log.Fields{"err": err, "idempotency_key": request.IdempotencyKey}).Error("Error releasing idempotency key")
+			}
+		}()
+	}
+
+	// Payment is removed from limit counters when it's not sent (always in preview)
+	releaseLimits := func() {}
+	if rh.Limiter != nil {
+		releaseLimits, err = rh.Limiter.Reserve(request.AssetCode, request.AssetIssuer, request.Destination, request.Amount)
+		if exceeded, ok := err.(*limits.ExceededError); ok {
+			logger.WithFields(log.Fields{"limit": exceeded.Limit, "destination": request.Destination, "amount": request.Amount}).Info("Payment limit exceeded")
+			server.Write(w, bridge.NewPaymentLimitExceededError(exceeded.Limit, exceeded.Max.String(), exceeded.Remaining.String(), exceeded.ResetsAt))
+			return
+		} else if err != nil {
+			logger.WithFields(log.Fields{"err": err}).Error("Error checking payment limits")
+			server.Write(w, protocols.InternalServerError)
+			return
+		}
+
+		defer func() {
+			if !submitted {
+				releaseLimits()
+			}
+		}()
+	}
 
 	var submitResponse horizon.SubmitTransactionResponse
 	var submitError error
+	// Envelope of the transaction submitted directly to Horizon (without TransactionSubmitter)
+	// and its hash which includes preconditions not supported by go-stellar-base
+	var envelopeXdr, envelopeHash string
 
 	if request.ExtraMemo != "" && rh.Config.Compliance != "" {
 		// Compliance server part
-		sendRequest := request.ToComplianceSendRequest()
+		sendRequest := request.ToComplianceSendRequest(sourceKeypair.Address())
+
+		// Compliance request is cancelled when the client disconnects or after `timeouts.compliance`
+		complianceCtx, cancelCompliance := context.WithTimeout(r.Context(), rh.Config.Timeouts.ComplianceTimeout())
+		defer cancelCompliance()
+
+		complianceRequest, err := http.NewRequestWithContext(complianceCtx, "POST", rh.Config.Compliance+"/send", strings.NewReader(sendRequest.ToValues().Encode()))
+		if err != nil {
+			logger.WithFields(log.Fields{"err": err}).Error("Error creating compliance server request")
+			server.Write(w, protocols.InternalServerError)
+			return
+		}
+		complianceRequest.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		complianceRequest.Header.Set(server.RequestIDHeader, server.RequestID(r))
 
-		resp, err := rh.Client.PostForm(
-			rh.Config.Compliance+"/send",
-			sendRequest.ToValues(),
-		)
+		complianceSpan := span.Child("compliance.send", tracing.KindClient)
+		complianceSpan.Inject(complianceRequest.Header)
+		resp, err := rh.complianceClient().Do(complianceRequest)
+		complianceSpan.End(err)
 		if err != nil {
-			log.WithFields(log.Fields{"err": err}).Error("Error sending request to compliance server")
+			logger.WithFields(log.Fields{"err": err}).Error("Error sending request to compliance server")
 			server.Write(w, protocols.InternalServerError)
 			return
 		}
@@ -61,13 +261,13 @@ func (rh *RequestHandler) Payment(w http.ResponseWriter, r *http.Request) {
 		defer resp.Body.Close()
 		body, err := ioutil.ReadAll(resp.Body)
 		if err != nil {
-			log.Error("Error reading compliance server response")
+			logger.Error("Error reading compliance server response")
 			server.Write(w, protocols.InternalServerError)
 			return
 		}
 
 		if resp.StatusCode != 200 {
-			log.WithFields(log.Fields{
+			logger.WithFields(log.Fields{
 				"status": resp.StatusCode,
 				"body":   string(body),
 			}).Error("Error response from compliance server")
@@ -78,21 +278,27 @@ func (rh *RequestHandler) Payment(w http.ResponseWriter, r *http.Request) {
 		var complianceSendResponse compliance.SendResponse
 		err = json.Unmarshal(body, &complianceSendResponse)
 		if err != nil {
-			log.Error("Error unmarshalling from compliance server")
+			logger.Error("Error unmarshalling from compliance server")
 			server.Write(w, protocols.InternalServerError)
 			return
 		}
 
 		if complianceSendResponse.AuthResponse.InfoStatus == compliance.AuthStatusPending ||
 			complianceSendResponse.AuthResponse.TxStatus == compliance.AuthStatusPending {
-			log.WithFields(log.Fields{"response": complianceSendResponse}).Info("Compliance response pending")
-			server.Write(w, bridge.NewPaymentPendingError(complianceSendResponse.AuthResponse.Pending))
+			logger.WithFields(log.Fields{"response": complianceSendResponse}).Info("Compliance response pending")
+			errorResponse, err := rh.compliancePending(sendRequest, complianceSendResponse.AuthResponse, preview)
+			if err != nil {
+				logger.WithFields(log.Fields{"err": err}).Error("Error saving pending compliance request")
+				server.Write(w, protocols.InternalServerError)
+				return
+			}
+			server.Write(w, errorResponse)
 			return
 		}
 
 		if complianceSendResponse.AuthResponse.InfoStatus == compliance.AuthStatusDenied ||
 			complianceSendResponse.AuthResponse.TxStatus == compliance.AuthStatusDenied {
-			log.WithFields(log.Fields{"response": complianceSendResponse}).Info("Compliance response denied")
+			logger.WithFields(log.Fields{"response": complianceSendResponse}).Info("Compliance response denied")
 			server.Write(w, bridge.PaymentDenied)
 			return
 		}
@@ -100,24 +306,31 @@ func (rh *RequestHandler) Payment(w http.ResponseWriter, r *http.Request) {
 		var tx xdr.Transaction
 		err = xdr.SafeUnmarshalBase64(complianceSendResponse.TransactionXdr, &tx)
 		if err != nil {
-			log.Error("Error unmarshalling transaction returned by compliance server")
+			logger.Error("Error unmarshalling transaction returned by compliance server")
 			server.Write(w, protocols.InternalServerError)
 			return
 		}
 
-		submitResponse, submitError = rh.TransactionSubmitter.SignAndSubmitRawTransaction(request.Source, &tx)
+		if preview {
+			rh.writePaymentPreview(w, r, &tx)
+			return
+		}
+
+		submitSpan := span.Child("transaction_submitter.submit", tracing.KindInternal)
+		submitResponse, submitError = submitter.WithLogger(rh.TransactionSubmitter, logger).SignAndSubmitRawTransaction(r.Context(), request.Source, &tx)
+		submitSpan.End(submitError)
 	} else {
 		// Payment without compliance server
 		destinationObject, _, err := rh.FederationResolver.Resolve(request.Destination)
 		if err != nil {
-			log.WithFields(log.Fields{"destination": request.Destination, "err": err}).Print("Cannot resolve address")
+			logger.WithFields(log.Fields{"destination": request.Destination, "err": err}).Print("Cannot resolve address")
 			server.Write(w, bridge.PaymentCannotResolveDestination)
 			return
 		}
 
 		_, err = keypair.Parse(destinationObject.AccountID)
 		if err != nil {
-			log.WithFields(log.Fields{"AccountId": destinationObject.AccountID}).Print("Invalid AccountId in destination")
+			logger.WithFields(log.Fields{"AccountId": destinationObject.AccountID}).Print("Invalid AccountId in destination")
 			server.Write(w, protocols.NewInvalidParameterError("destination", request.Destination))
 			return
 		}
@@ -135,29 +348,17 @@ func (rh *RequestHandler) Payment(w http.ResponseWriter, r *http.Request) {
 
 			payWith := b.PayWith(sendAsset, request.SendMax)
 
-			for i := 0; ; i++ {
-				codeFieldName := fmt.Sprintf("path[%d][asset_code]", i)
-				issuerFieldName := fmt.Sprintf("path[%d][asset_issuer]", i)
-
-				// If the element does not exist in PostForm break the loop
-				if _, exists := r.PostForm[codeFieldName]; !exists {
-					break
-				}
-
-				code := r.PostFormValue(codeFieldName)
-				issuer := r.PostFormValue(issuerFieldName)
-
-				if code == "" && issuer == "" {
-					payWith = payWith.Through(b.NativeAsset())
-				} else {
-					payWith = payWith.Through(b.CreditAsset(code, issuer))
-				}
+			for _, asset := range request.Path {
+				payWith = payWith.Through(asset.ToBaseAsset())
 			}
 
 			payWithMutator = &payWith
 		}
 
 		var operationBuilder interface{}
+		// destinationAccount is nil when it has not been loaded yet or it does not exist
+		var destinationAccount *horizon.AccountResponse
+		var createAccount bool
 
 		if request.AssetCode != "" && request.AssetIssuer != "" {
 			mutators := []interface{}{
@@ -181,12 +382,17 @@ func (rh *RequestHandler) Payment(w http.ResponseWriter, r *http.Request) {
 			}
 
 			// Check if destination account exist
-			_, err = rh.Horizon.LoadAccount(destinationObject.AccountID)
+			loadSpan := span.Child("horizon.load_account", tracing.KindClient)
+			account, err := rh.Horizon.LoadAccount(r.Context(), destinationObject.AccountID)
+			// Destination account that does not exist is created
+			loadSpan.End(nil)
 			if err != nil {
-				log.WithFields(log.Fields{"error": err}).Error("Error loading account")
+				logger.WithFields(log.Fields{"error": err}).Error("Error loading account")
 				operationBuilder = b.CreateAccount(mutators...)
+				createAccount = true
 			} else {
 				operationBuilder = b.Payment(mutators...)
+				destinationAccount = &account
 			}
 		}
 
@@ -195,7 +401,7 @@ func (rh *RequestHandler) Payment(w http.ResponseWriter, r *http.Request) {
 
 		if destinationObject.MemoType != "" {
 			if request.MemoType != "" {
-				log.Print("Memo given in request but federation returned memo fields.")
+				logger.Print("Memo given in request but federation returned memo fields.")
 				server.Write(w, bridge.PaymentCannotUseMemo)
 				return
 			}
@@ -204,66 +410,82 @@ func (rh *RequestHandler) Payment(w http.ResponseWriter, r *http.Request) {
 			memo = destinationObject.Memo
 		}
 
-		var memoMutator interface{}
-		switch {
-		case memoType == "":
-			break
-		case memoType == "id":
-			id, err := strconv.ParseUint(memo, 10, 64)
-			if err != nil {
-				log.WithFields(log.Fields{"memo": memo}).Print("Cannot convert memo_id value to uint64")
-				server.Write(w, protocols.NewInvalidParameterError("memo", request.Memo))
-				return
+		// SEP-29: destinations like exchanges require a memo identifying the recipient, funds
+		// sent without it could be lost
+		if memoType == "" && !createAccount {
+			if destinationAccount == nil {
+				loadSpan := span.Child("horizon.load_account", tracing.KindClient)
+				account, err := rh.Horizon.LoadAccount(r.Context(), destinationObject.AccountID)
+				loadSpan.End(err)
+				if err != nil {
+					// Payment to a destination that does not exist fails when it's submitted
+					logger.WithFields(log.Fields{"error": err}).Error("Error loading destination account")
+				} else {
+					destinationAccount = &account
+				}
 			}
-			memoMutator = b.MemoID{id}
-		case memoType == "text":
-			memoMutator = &b.MemoText{memo}
-		case memoType == "hash":
-			memoBytes, err := hex.DecodeString(memo)
-			if err != nil || len(memoBytes) != 32 {
-				log.WithFields(log.Fields{"memo": memo}).Print("Cannot decode hash memo value")
-				server.Write(w, protocols.NewInvalidParameterError("memo", request.Memo))
+
+			if destinationAccount != nil && destinationAccount.MemoRequired() {
+				logger.WithFields(log.Fields{"destination": destinationObject.AccountID}).Info("Destination requires memo")
+				server.Write(w, bridge.PaymentMemoRequired)
 				return
 			}
-			var b32 [32]byte
-			copy(b32[:], memoBytes[0:32])
-			hash := xdr.Hash(b32)
-			memoMutator = &b.MemoHash{hash}
-		default:
-			log.Print("Not supported memo type: ", memoType)
-			server.Write(w, protocols.NewInvalidParameterError("memo", request.Memo))
+		}
+
+		memoMutator, err := bridge.MemoMutator(memoType, memo)
+		if err != nil {
+			logger.WithFields(log.Fields{"memo_type": memoType, "memo": memo}).Print("Invalid memo")
+			server.Write(w, err.(*protocols.ErrorResponse))
 			return
 		}
 
-		accountResponse, err := rh.Horizon.LoadAccount(sourceKeypair.Address())
+		operationMutators := []b.TransactionMutator{operationBuilder.(b.TransactionMutator)}
+		// authorizer signs allow_trust operations of regulated assets. It's nil for other payments.
+		var authorizer signer.Signer
+		trustlines := rh.regulatedTrustlines(request, sourceKeypair.Address(), destinationObject.AccountID)
+		if len(trustlines) > 0 {
+			var errorResponse *protocols.ErrorResponse
+			operationMutators, authorizer, errorResponse = rh.regulatedPayment(r.Context(),
+				logger, request, sourceKeypair.Address(), destinationObject.AccountID, trustlines, operationMutators[0], preview,
+			)
+			if errorResponse != nil {
+				server.Write(w, errorResponse)
+				return
+			}
+		}
+
+		// Sequence number must be current so the source account is not loaded from the cache
+		loadSpan := span.Child("horizon.load_account", tracing.KindClient)
+		accountResponse, err := horizon.Uncached(rh.Horizon).LoadAccount(r.Context(), sourceKeypair.Address())
+		loadSpan.End(err)
 		if err != nil {
-			log.WithFields(log.Fields{"error": err}).Error("Cannot load source account")
+			logger.WithFields(log.Fields{"error": err}).Error("Cannot load source account")
 			server.Write(w, bridge.PaymentSourceNotExist)
 			return
 		}
 
 		sequenceNumber, err := strconv.ParseUint(accountResponse.SequenceNumber, 10, 64)
 		if err != nil {
-			log.WithFields(log.Fields{"error": err}).Error("Cannot convert SequenceNumber")
+			logger.WithFields(log.Fields{"error": err}).Error("Cannot convert SequenceNumber")
 			server.Write(w, protocols.InternalServerError)
 			return
 		}
 
 		transactionMutators := []b.TransactionMutator{
-			b.SourceAccount{request.Source},
+			b.SourceAccount{sourceKeypair.Address()},
 			b.Sequence{sequenceNumber + 1},
 			b.Network{rh.Config.NetworkPassphrase},
-			operationBuilder.(b.TransactionMutator),
 		}
+		transactionMutators = append(transactionMutators, operationMutators...)
 
 		if memoMutator != nil {
-			transactionMutators = append(transactionMutators, memoMutator.(b.TransactionMutator))
+			transactionMutators = append(transactionMutators, memoMutator)
 		}
 
 		tx := b.Transaction(transactionMutators...)
 
 		if tx.Err != nil {
-			log.WithFields(log.Fields{"err": tx.Err}).Print("Transaction builder error")
+			logger.WithFields(log.Fields{"err": tx.Err}).Print("Transaction builder error")
 			// TODO when build.OperationBuilder interface is ready check for
 			// create_account and payment errors separately
 			switch {
@@ -278,52 +500,398 @@ func (rh *RequestHandler) Payment(w http.ResponseWriter, r *http.Request) {
 					protocols.NewInvalidParameterError("amount", request.Amount),
 				)
 			default:
-				log.WithFields(log.Fields{"err": tx.Err}).Print("Transaction builder error")
+				logger.WithFields(log.Fields{"err": tx.Err}).Print("Transaction builder error")
 				server.Write(w, protocols.InternalServerError)
 			}
 			return
 		}
 
-		txe := tx.Sign(request.Source)
-		txeB64, err := txe.Base64()
+		preconditions := rh.preconditions(requestPreconditions)
+		tx.TX.TimeBounds = preconditions.TimeBounds()
 
-		if err != nil {
-			log.WithFields(log.Fields{"error": err}).Error("Cannot encode transaction envelope")
-			server.Write(w, protocols.InternalServerError)
+		if preview {
+			rh.writePaymentPreview(w, r, tx.TX)
 			return
 		}
 
-		submitResponse, submitError = rh.Horizon.SubmitTransaction(txeB64)
+		// Channel accounts are not used for regulated payments that are also signed by authorizer
+		if len(rh.Config.Accounts.ChannelSeeds) > 0 && authorizer == nil {
+			// Sequence number and fee are set by TransactionSubmitter using a channel account
+			submitSpan := span.Child("transaction_submitter.submit", tracing.KindInternal)
+			submitResponse, submitError = submitter.WithLogger(rh.TransactionSubmitter, logger).SignAndSubmitRawTransaction(r.Context(), request.Source, tx.TX)
+			submitSpan.End(submitError)
+		} else {
+			var fee uint32
+			if rh.FeeStrategy != nil {
+				fee = rh.FeeStrategy.Fee(r.Context())
+				tx.TX.Fee = xdr.Uint32(fee * uint32(len(tx.TX.Operations)))
+			}
+
+			// Preconditions other than time bounds are not supported by go-stellar-base
+			signers := []signer.Signer{sourceKeypair}
+			if authorizer != nil {
+				signers = append(signers, authorizer)
+			}
+			txeB64, hash, err := submitter.EncodeTransaction(tx.TX, preconditions, rh.Config.NetworkPassphrase, signers...)
+			if err != nil {
+				logger.WithFields(log.Fields{"error": err}).Error("Cannot sign transaction")
+				server.Write(w, submitErrorResponse(err))
+				return
+			}
+
+			// Accounts with multiple signers can require more signatures than the bridge server
+			// adds. Transaction is saved and submitted when they are collected using /sign.
+			if len(accountResponse.Signers) > 0 {
+				signatures, err := submitter.RawEnvelopeSignatures(txeB64)
+				if err != nil {
+					logger.WithFields(log.Fields{"error": err}).Error("Cannot decode transaction envelope")
+					server.Write(w, protocols.InternalServerError)
+					return
+				}
+
+				signedWeight := signaturesWeight(accountResponse, hash, signatures)
+				requiredWeight := paymentThreshold(accountResponse)
+				if signedWeight < requiredWeight {
+					submitted = rh.startPendingTransaction(w, r, request, reserved, sourceKeypair.Address(), transactionID(hash), txeB64, signedWeight, requiredWeight)
+					return
+				}
+			}
+
+			envelopeXdr = txeB64
+			submitSpan := span.Child("horizon.submit_transaction", tracing.KindClient)
+			if rh.FeeStrategy != nil {
+				submitResponse, submitError = rh.FeeStrategy.Submit(r.Context(), txeB64, len(tx.TX.Operations), fee, sourceKeypair)
+			} else {
+				submitResponse, submitError = rh.Horizon.SubmitTransaction(r.Context(), txeB64)
+			}
+			submitSpan.End(submitError)
+			envelopeHash = transactionID(hash)
+		}
+
+		// Horizon does not return hashes of failed transactions
+		if submitResponse.Hash == "" {
+			if envelopeHash == "" {
+				hash, err := submitter.TransactionHash(tx.TX, rh.Config.NetworkPassphrase)
+				if err != nil {
+					logger.WithFields(log.Fields{"error": err}).Error("Cannot calculate transaction hash")
+				} else {
+					envelopeHash = hex.EncodeToString(hash[:])
+				}
+			}
+			submitResponse.Hash = envelopeHash
+		}
+	}
+	submitted = true
+	span.SetAttribute("transaction_id", submitResponse.Hash)
+
+	saveSentTransaction := func() error {
+		saveSpan := span.Child("db.save_sent_transaction", tracing.KindInternal)
+		err := rh.saveSentTransaction(reserved, submitResponse, sourceKeypair.Address(), envelopeXdr, request)
+		saveSpan.End(err)
+		return err
 	}
 
 	if submitError != nil {
-		log.WithFields(log.Fields{"error": submitError}).Error("Error submitting transaction")
-		server.Write(w, protocols.InternalServerError)
+		metrics.AddCounter("transactions_submitted", 1, metrics.Tags{"result": "error"})
+		logger.WithFields(log.Fields{"error": submitError}).Error("Error submitting transaction")
+		// Transactions submitted by TransactionSubmitter are saved by it. Reserved sent transaction
+		// of these stays in `sending` status.
+		if rh.Repository != nil && envelopeXdr != "" {
+			err = saveSentTransaction()
+			if err != nil {
+				logger.WithFields(log.Fields{"err": err}).Error("Error saving timed out transaction")
+			}
+		}
+		server.Write(w, submitErrorResponse(submitError))
 		return
 	}
 
 	errorResponse := bridge.ErrorFromHorizonResponse(submitResponse)
 	if errorResponse != nil {
-		log.WithFields(errorResponse.LogData).Error(errorResponse.Error())
+		metrics.AddCounter("transactions_submitted", 1, metrics.Tags{"result": "failure"})
+		logger.WithFields(errorResponse.LogData).Error(errorResponse.Error())
+		releaseLimits()
+		if rh.Repository != nil {
+			err = saveSentTransaction()
+			if err != nil {
+				logger.WithFields(log.Fields{"err": err}).Error("Error saving failed transaction")
+			}
+		}
+		rh.publishPaymentSent(request, submitResponse.Hash, errorResponse.Code)
+		rh.notifyPaymentSent(r.Context(), request, submitResponse.Hash, submitResponse.Ledger, errorResponse.Code)
 		server.Write(w, errorResponse)
 		return
 	}
 
-	// Path payment send amount
-	if submitResponse.ResultXdr != nil {
-		var transactionResult xdr.TransactionResult
-		reader := strings.NewReader(*submitResponse.ResultXdr)
-		b64r := base64.NewDecoder(base64.StdEncoding, reader)
-		_, err := xdr.Unmarshal(b64r, &transactionResult)
+	metrics.AddCounter("transactions_submitted", 1, metrics.Tags{"result": "success"})
 
-		if err == nil && transactionResult.Result.Code == xdr.TransactionResultCodeTxSuccess {
-			operationResult := (*transactionResult.Result.Results)[0]
-			if operationResult.Tr.PathPaymentResult != nil {
-				sendAmount := operationResult.Tr.PathPaymentResult.SendAmount()
-				submitResponse.SendAmount = amount.String(sendAmount)
-			}
+	setSendAmount(&submitResponse)
+
+	if receivedPayment != nil {
+		link := &entities.PaymentLink{
+			ReceivedPaymentID: *receivedPayment.ID,
+			TransactionID:     submitResponse.Hash,
+			CreatedAt:         time.Now(),
+			Tenant:            rh.Config.Tenant,
+		}
+		// Transaction has already been sent so only log the error
+		err = rh.EntityManager.Persist(link)
+		if err != nil {
+			logger.WithFields(log.Fields{
+				"err":                 err,
+				"received_payment_id": request.ReceivedPaymentID,
+				"transaction_id":      submitResponse.Hash,
+			}).Error("Error saving payment link")
+		}
+	}
+
+	if rh.Repository != nil {
+		// Transaction has already been sent so only log the error
+		err = saveSentTransaction()
+		if err != nil {
+			logger.WithFields(log.Fields{
+				"err":            err,
+				"transaction_id": submitResponse.Hash,
+			}).Error("Error saving sent transaction")
 		}
 	}
 
+	if deposit != nil {
+		rh.completeDeposit(r.Context(), logger, deposit, request.Amount, submitResponse.Hash)
+	}
+
+	rh.publishPaymentSent(request, submitResponse.Hash, "")
+	rh.notifyPaymentSent(r.Context(), request, submitResponse.Hash, submitResponse.Ledger, "")
 	server.Write(w, &submitResponse)
 }
+
+// findPaymentPath finds the cheapest path converting send asset of request to amount of the
+// destination asset and returns it with its source amount. Path is nil when Horizon found none.
+func (rh *RequestHandler) findPaymentPath(ctx context.Context, request *bridge.PaymentRequest) (*horizon.PathResponse, xdr.Int64, error) {
+	sendAsset := protocols.Asset{Code: request.SendAssetCode, Issuer: request.SendAssetIssuer}
+	destinationAsset := protocols.Asset{Code: request.AssetCode, Issuer: request.AssetIssuer}
+
+	paths, err := rh.Horizon.FindPaths(ctx, sendAsset.String(), destinationAsset.String(), request.Amount)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var cheapest *horizon.PathResponse
+	var cheapestAmount xdr.Int64
+	for i := range paths {
+		sourceAmount, err := amount.Parse(paths[i].SourceAmount)
+		if err != nil {
+			return nil, 0, err
+		}
+		if cheapest == nil || sourceAmount < cheapestAmount {
+			cheapest = &paths[i]
+			cheapestAmount = sourceAmount
+		}
+	}
+	return cheapest, cheapestAmount, nil
+}
+
+// publishPaymentSent publishes status of a payment sent by /payment endpoint. Status is
+// `failure` when errorCode is not empty. Errors are only logged.
+func (rh *RequestHandler) publishPaymentSent(request *bridge.PaymentRequest, transactionID, errorCode string) {
+	if rh.Publisher == nil {
+		return
+	}
+
+	payload := events.PaymentSent{
+		TransactionID: transactionID,
+		Status:        string(entities.SentTransactionStatusSuccess),
+		Error:         errorCode,
+		Destination:   request.Destination,
+		Amount:        request.Amount,
+		Asset:         protocols.Asset{Code: request.AssetCode, Issuer: request.AssetIssuer}.String(),
+	}
+
+	if errorCode != "" {
+		payload.Status = string(entities.SentTransactionStatusFailure)
+	}
+
+	if request.Metadata != "" {
+		metadata := json.RawMessage(request.Metadata)
+		payload.Metadata = &metadata
+	}
+
+	err := rh.Publisher.Publish(events.Event{
+		Type:    events.TypePaymentSent,
+		Tenant:  rh.Config.Tenant,
+		Payload: payload,
+	})
+	if err != nil {
+		log.WithFields(log.Fields{"err": err, "transaction_id": transactionID}).Error("Error publishing sent payment")
+	}
+}
+
+// notifyPaymentSent sends status of a payment sent by /payment endpoint to `callbacks.sent`.
+// Errors are only logged. The transaction is already submitted so the callback is sent also
+// when the client disconnected.
+func (rh *RequestHandler) notifyPaymentSent(ctx context.Context, request *bridge.PaymentRequest, transactionID string, ledger *uint64, errorCode string) {
+	err := listener.NotifyPaymentSent(context.WithoutCancel(ctx), rh.Client, rh.Config, request, transactionID, ledger, errorCode)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err, "transaction_id": transactionID}).Error("Error sending sent payment to sent callback")
+	}
+}
+
+// saveSentTransaction saves payment details, metadata and idempotency key with the sent
+// transaction. Transactions submitted directly to Horizon are not saved by TransactionSubmitter
+// so reserved sent transaction (or a new one) is used for them. reserved is nil when idempotency_key is not given. Transaction
+// is saved with `timeout` status when submitResponse contains no result.
+func (rh *RequestHandler) saveSentTransaction(
+	reserved *entities.SentTransaction,
+	submitResponse horizon.SubmitTransactionResponse,
+	source, envelopeXdr string,
+	request *bridge.PaymentRequest,
+) error {
+	var sentTransaction *entities.SentTransaction
+	if submitResponse.Hash != "" {
+		var err error
+		sentTransaction, err = rh.Repository.GetSentTransactionByTransactionID(submitResponse.Hash)
+		if err != nil {
+			return err
+		}
+	}
+
+	if sentTransaction != nil && reserved != nil {
+		// Transaction saved by TransactionSubmitter takes over the idempotency key
+		err := rh.EntityManager.Delete(reserved)
+		if err != nil {
+			return err
+		}
+		sentTransaction.IdempotencyKey = reserved.IdempotencyKey
+		sentTransaction.ResultXdr = submitResponse.ResultXdr
+	}
+
+	if sentTransaction == nil {
+		sentTransaction = reserved
+		if sentTransaction == nil {
+			sentTransaction = &entities.SentTransaction{
+				Source:      source,
+				SubmittedAt: time.Now(),
+				Tenant:      rh.Config.Tenant,
+			}
+		}
+
+		sentTransaction.TransactionID = submitResponse.Hash
+		sentTransaction.EnvelopeXdr = envelopeXdr
+		setSentTransactionResult(sentTransaction, submitResponse)
+	}
+
+	if request.Metadata != "" {
+		sentTransaction.Metadata = &request.Metadata
+	}
+	asset := protocols.Asset{Code: request.AssetCode, Issuer: request.AssetIssuer}.String()
+	sentTransaction.Destination = &request.Destination
+	sentTransaction.Amount = &request.Amount
+	sentTransaction.Asset = &asset
+	return rh.EntityManager.Persist(sentTransaction)
+}
+
+// setSentTransactionResult updates status of sentTransaction using Horizon response
+func setSentTransactionResult(sentTransaction *entities.SentTransaction, submitResponse horizon.SubmitTransactionResponse) {
+	if submitResponse.Ledger != nil {
+		sentTransaction.MarkSucceeded(*submitResponse.Ledger)
+		sentTransaction.ResultXdr = submitResponse.ResultXdr
+	} else if submitResponse.Extras != nil {
+		sentTransaction.MarkFailed(submitResponse.Extras.ResultXdr)
+	} else {
+		sentTransaction.MarkTimedOut()
+	}
+}
+
+// reserveIdempotencyKey saves a sent transaction with `sending` status and idempotency key.
+// When the key has already been used, the sent transaction of the original request is returned
+// with duplicate set to true.
+func (rh *RequestHandler) reserveIdempotencyKey(key, source string) (sentTransaction *entities.SentTransaction, duplicate bool, err error) {
+	sentTransaction, err = rh.Repository.GetSentTransactionByIdempotencyKey(key)
+	if err != nil || sentTransaction != nil {
+		return sentTransaction, sentTransaction != nil, err
+	}
+
+	sentTransaction = &entities.SentTransaction{
+		Status:         entities.SentTransactionStatusSending,
+		Source:         source,
+		SubmittedAt:    time.Now(),
+		Tenant:         rh.Config.Tenant,
+		IdempotencyKey: &key,
+	}
+	err = rh.EntityManager.Persist(sentTransaction)
+	if err != nil {
+		// Unique index prevents concurrent requests with the same key from sending a payment
+		existing, getErr := rh.Repository.GetSentTransactionByIdempotencyKey(key)
+		if getErr == nil && existing != nil {
+			return existing, true, nil
+		}
+		return nil, false, err
+	}
+
+	return sentTransaction, false, nil
+}
+
+// writeIdempotentResult writes the result of the original request with the same idempotency key
+func (rh *RequestHandler) writeIdempotentResult(w http.ResponseWriter, sentTransaction *entities.SentTransaction) {
+	switch sentTransaction.Status {
+	case entities.SentTransactionStatusSuccess:
+		response := horizon.SubmitTransactionResponse{
+			Hash:      sentTransaction.TransactionID,
+			Ledger:    sentTransaction.Ledger,
+			ResultXdr: sentTransaction.ResultXdr,
+		}
+		setSendAmount(&response)
+		server.Write(w, &response)
+	case entities.SentTransactionStatusFailure:
+		response := horizon.SubmitTransactionResponse{Extras: &horizon.SubmitTransactionResponseExtras{}}
+		if sentTransaction.ResultXdr != nil {
+			response.Extras.ResultXdr = *sentTransaction.ResultXdr
+		}
+		errorResponse := bridge.ErrorFromHorizonResponse(response)
+		if errorResponse == nil {
+			errorResponse = protocols.InternalServerError
+		}
+		server.Write(w, errorResponse)
+	default:
+		server.Write(w, bridge.PaymentIdempotencyKeyInProgress)
+	}
+}
+
+// setSendAmount sets send amount of a path payment using result XDR
+func setSendAmount(response *horizon.SubmitTransactionResponse) {
+	if response.ResultXdr == nil {
+		return
+	}
+
+	var transactionResult xdr.TransactionResult
+	reader := strings.NewReader(*response.ResultXdr)
+	b64r := base64.NewDecoder(base64.StdEncoding, reader)
+	_, err := xdr.Unmarshal(b64r, &transactionResult)
+
+	if err == nil && transactionResult.Result.Code == xdr.TransactionResultCodeTxSuccess {
+		operationResult := (*transactionResult.Result.Results)[0]
+		if operationResult.Tr.PathPaymentResult != nil {
+			sendAmount := operationResult.Tr.PathPaymentResult.SendAmount()
+			response.SendAmount = amount.String(sendAmount)
+		}
+	}
+}
+
+// compliancePending returns an error response of a payment pending in the compliance server.
+// When `callbacks.compliance` is set, the request is saved to be sent again by
+// listener.ComplianceRetry that notifies the callback. Previews are not saved because they
+// are never submitted.
+func (rh *RequestHandler) compliancePending(sendRequest compliance.SendRequest, authResponse compliance.AuthResponse, preview bool) (*protocols.ErrorResponse, error) {
+	errorResponse := bridge.NewPaymentPendingError(authResponse.Pending)
+	if rh.Config.Callbacks.Compliance == "" || preview {
+		return errorResponse, nil
+	}
+
+	pendingCompliance := listener.NewPendingCompliance(rh.Config, sendRequest.ToValues(), authResponse, time.Now())
+	err := rh.EntityManager.Persist(pendingCompliance)
+	if err != nil {
+		return nil, err
+	}
+	errorResponse.Data["pending_id"] = *pendingCompliance.ID
+	return errorResponse, nil
+}
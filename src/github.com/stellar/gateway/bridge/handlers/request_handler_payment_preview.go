@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/protocols"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stellar/gateway/server"
+	"github.com/stellar/gateway/tracing"
+	"github.com/stellar/go-stellar-base/amount"
+	"github.com/stellar/go-stellar-base/xdr"
+)
+
+// PaymentPreview implements /payment/preview endpoint. It accepts params of /payment and runs
+// the same steps (federation, compliance, limits) but the transaction is not submitted.
+func (rh *RequestHandler) PaymentPreview(w http.ResponseWriter, r *http.Request) {
+	rh.payment(w, r, true)
+}
+
+// writePaymentPreview writes unsigned transaction tx built by /payment/preview request with
+// errors it would fail with when submitted
+func (rh *RequestHandler) writePaymentPreview(w http.ResponseWriter, r *http.Request, tx *xdr.Transaction) {
+	logger := server.Logger(r)
+
+	if rh.FeeStrategy != nil {
+		tx.Fee = xdr.Uint32(rh.FeeStrategy.Fee(r.Context()) * uint32(len(tx.Operations)))
+	}
+
+	// Every transaction is accepted in sandbox mode
+	errors := []*protocols.ErrorResponse{}
+	if rh.Sandbox == nil {
+		span := tracing.FromRequest(r).Child("payment.preview", tracing.KindInternal)
+		errors = rh.paymentPreviewErrors(r.Context(), logger, tx)
+		span.End(nil)
+	}
+
+	txB64, err := xdr.MarshalBase64(tx)
+	if err != nil {
+		logger.WithFields(log.Fields{"err": err}).Error("Cannot encode transaction")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	server.Write(w, &bridge.PaymentPreviewResponse{
+		TransactionXdr: txB64,
+		Fee:            uint32(tx.Fee),
+		Errors:         errors,
+	})
+}
+
+// paymentPreviewErrors checks balances and trustlines of accounts of payment operations of tx.
+// Sequence number of tx is set to the next sequence number of its source account. Reserves
+// are not checked.
+func (rh *RequestHandler) paymentPreviewErrors(ctx context.Context, logger *log.Entry, tx *xdr.Transaction) []*protocols.ErrorResponse {
+	errors := []*protocols.ErrorResponse{}
+	add := func(err *protocols.ErrorResponse) {
+		if err != nil {
+			errors = append(errors, err)
+		}
+	}
+
+	// accounts are loaded once, nil means the account does not exist
+	accounts := map[string]*horizon.AccountResponse{}
+	loadFrom := func(h horizon.HorizonInterface, accountID string) *horizon.AccountResponse {
+		account, loaded := accounts[accountID]
+		if loaded {
+			return account
+		}
+
+		response, err := h.LoadAccount(ctx, accountID)
+		if err != nil {
+			logger.WithFields(log.Fields{"err": err, "account_id": accountID}).Info("Cannot load account")
+		} else {
+			account = &response
+		}
+		accounts[accountID] = account
+		return account
+	}
+	load := func(accountID string) *horizon.AccountResponse {
+		return loadFrom(rh.Horizon, accountID)
+	}
+
+	source := tx.SourceAccount.Address()
+	// Sequence number must be current so the source account is not loaded from the cache
+	sourceAccount := loadFrom(horizon.Uncached(rh.Horizon), source)
+	if sourceAccount == nil {
+		add(bridge.PaymentSourceNotExist)
+		return errors
+	}
+
+	sequenceNumber, err := strconv.ParseUint(sourceAccount.SequenceNumber, 10, 64)
+	if err == nil {
+		tx.SeqNum = xdr.SequenceNumber(sequenceNumber + 1)
+	}
+
+	for _, op := range tx.Operations {
+		opSource := source
+		if op.SourceAccount != nil {
+			opSource = op.SourceAccount.Address()
+		}
+
+		// Fee is paid in XLM by the transaction source account
+		var fee xdr.Int64
+		if opSource == source {
+			fee = xdr.Int64(tx.Fee)
+		}
+
+		switch op.Body.Type {
+		case xdr.OperationTypeCreateAccount:
+			createAccount := op.Body.MustCreateAccountOp()
+			add(checkSendBalance(load(opSource), opSource, xdr.Asset{Type: xdr.AssetTypeAssetTypeNative}, createAccount.StartingBalance, fee))
+		case xdr.OperationTypePayment:
+			payment := op.Body.MustPaymentOp()
+			add(checkSendBalance(load(opSource), opSource, payment.Asset, payment.Amount, fee))
+			add(checkDestinationTrustline(load(payment.Destination.Address()), payment.Destination.Address(), payment.Asset))
+		case xdr.OperationTypePathPayment:
+			pathPayment := op.Body.MustPathPaymentOp()
+			add(checkSendBalance(load(opSource), opSource, pathPayment.SendAsset, pathPayment.SendMax, fee))
+			add(checkDestinationTrustline(load(pathPayment.Destination.Address()), pathPayment.Destination.Address(), pathPayment.DestAsset))
+		}
+	}
+	return errors
+}
+
+// checkSendBalance returns an error when account cannot send value of asset and pay fee in XLM.
+// Issuers can always send their assets.
+func checkSendBalance(account *horizon.AccountResponse, accountID string, asset xdr.Asset, value, fee xdr.Int64) *protocols.ErrorResponse {
+	if account == nil {
+		return bridge.PaymentSourceNotExist
+	}
+
+	var assetType, code, issuer string
+	asset.MustExtract(&assetType, &code, &issuer)
+
+	nativeBalance, err := amount.Parse(account.NativeBalance())
+	if err != nil || nativeBalance < fee {
+		return bridge.PaymentUnderfunded
+	}
+
+	balance := account.NativeBalance()
+	if assetType == "native" {
+		value += fee
+	} else {
+		if issuer == accountID {
+			return nil
+		}
+
+		creditBalance, ok := account.CreditBalance(code, issuer)
+		if !ok {
+			return bridge.PaymentSrcNoTrust
+		}
+		if creditBalance.IsAuthorized != nil && !*creditBalance.IsAuthorized {
+			return bridge.PaymentSrcNotAuthorized
+		}
+		balance = creditBalance.Balance
+	}
+
+	available, err := amount.Parse(balance)
+	if err != nil || available < value {
+		return bridge.PaymentUnderfunded
+	}
+	return nil
+}
+
+// checkDestinationTrustline returns an error when account does not exist or cannot receive
+// asset. /payment creates destinations of native payments using create_account operation.
+func checkDestinationTrustline(account *horizon.AccountResponse, accountID string, asset xdr.Asset) *protocols.ErrorResponse {
+	var assetType, code, issuer string
+	asset.MustExtract(&assetType, &code, &issuer)
+
+	if account == nil {
+		return bridge.PaymentNoDestination
+	}
+
+	if assetType == "native" || issuer == accountID {
+		return nil
+	}
+
+	balance, ok := account.CreditBalance(code, issuer)
+	if !ok {
+		return bridge.PaymentNoTrust
+	}
+	if balance.IsAuthorized != nil && !*balance.IsAuthorized {
+		return bridge.PaymentNotAuthorized
+	}
+	return nil
+}
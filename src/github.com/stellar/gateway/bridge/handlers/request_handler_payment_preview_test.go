@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/mocks"
+	"github.com/stellar/gateway/protocols"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stellar/gateway/protocols/compliance"
+	b "github.com/stellar/go-stellar-base/build"
+	"github.com/stellar/go-stellar-base/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaymentPreviewErrors(t *testing.T) {
+	source := "GBKGH7QZVCZ2ZA5OUGZSTHFNXTBHL3MPCKSCBJUAQODGPMWP7OMMRKDW"
+	destination := "GAHA6GRCLCCN7XE2NEEUDSIVOFBOQ6GLSYXVLYCJXJKLPMDR5XB5XZZJ"
+	issuer := "GDSIKW43UA6JTOA47WVEBCZ4MYC74M3GNKNXTVDXFHXYYTNO5GGVN632"
+	authorized := true
+	unauthorized := false
+
+	build := func(mutators ...interface{}) *xdr.Transaction {
+		tx := b.Transaction(
+			b.SourceAccount{source},
+			b.Sequence{1},
+			b.TestNetwork,
+			b.Payment(mutators...),
+		)
+		require.NoError(t, tx.Err)
+		return tx.TX
+	}
+	usd := b.CreditAmount{"USD", issuer, "50"}
+
+	tests := []struct {
+		name        string
+		tx          *xdr.Transaction
+		source      *horizon.AccountResponse
+		destination *horizon.AccountResponse
+		errors      []*protocols.ErrorResponse
+	}{
+		{
+			name: "ok",
+			tx:   build(b.Destination{destination}, usd),
+			source: &horizon.AccountResponse{SequenceNumber: "10", Balances: []horizon.Balance{
+				{AssetType: "native", Balance: "1.0000000"},
+				{AssetType: "credit_alphanum4", AssetCode: "USD", AssetIssuer: issuer, Balance: "100.0000000", IsAuthorized: &authorized},
+			}},
+			destination: &horizon.AccountResponse{Balances: []horizon.Balance{
+				{AssetType: "credit_alphanum4", AssetCode: "USD", AssetIssuer: issuer, Balance: "0.0000000"},
+			}},
+			errors: []*protocols.ErrorResponse{},
+		},
+		{
+			name: "source does not exist",
+			tx:   build(b.Destination{destination}, usd),
+			errors: []*protocols.ErrorResponse{
+				bridge.PaymentSourceNotExist,
+			},
+		},
+		{
+			name: "underfunded and no destination trustline",
+			tx:   build(b.Destination{destination}, usd),
+			source: &horizon.AccountResponse{SequenceNumber: "10", Balances: []horizon.Balance{
+				{AssetType: "native", Balance: "1.0000000"},
+				{AssetType: "credit_alphanum4", AssetCode: "USD", AssetIssuer: issuer, Balance: "49.9999999"},
+			}},
+			destination: &horizon.AccountResponse{Balances: []horizon.Balance{
+				{AssetType: "native", Balance: "1.0000000"},
+			}},
+			errors: []*protocols.ErrorResponse{
+				bridge.PaymentUnderfunded,
+				bridge.PaymentNoTrust,
+			},
+		},
+		{
+			name: "no source trustline and destination does not exist",
+			tx:   build(b.Destination{destination}, usd),
+			source: &horizon.AccountResponse{SequenceNumber: "10", Balances: []horizon.Balance{
+				{AssetType: "native", Balance: "1.0000000"},
+			}},
+			errors: []*protocols.ErrorResponse{
+				bridge.PaymentSrcNoTrust,
+				bridge.PaymentNoDestination,
+			},
+		},
+		{
+			name: "destination not authorized",
+			tx:   build(b.Destination{destination}, usd),
+			source: &horizon.AccountResponse{SequenceNumber: "10", Balances: []horizon.Balance{
+				{AssetType: "native", Balance: "1.0000000"},
+				{AssetType: "credit_alphanum4", AssetCode: "USD", AssetIssuer: issuer, Balance: "100.0000000"},
+			}},
+			destination: &horizon.AccountResponse{Balances: []horizon.Balance{
+				{AssetType: "credit_alphanum4", AssetCode: "USD", AssetIssuer: issuer, Balance: "0.0000000", IsAuthorized: &unauthorized},
+			}},
+			errors: []*protocols.ErrorResponse{
+				bridge.PaymentNotAuthorized,
+			},
+		},
+		{
+			name: "native amount and fee exceed balance",
+			tx:   build(b.Destination{destination}, b.NativeAmount{"10"}),
+			source: &horizon.AccountResponse{SequenceNumber: "10", Balances: []horizon.Balance{
+				{AssetType: "native", Balance: "10.0000000"},
+			}},
+			destination: &horizon.AccountResponse{},
+			errors: []*protocols.ErrorResponse{
+				bridge.PaymentUnderfunded,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		mockHorizon := new(mocks.MockHorizon)
+		for accountID, account := range map[string]*horizon.AccountResponse{source: test.source, destination: test.destination} {
+			if account != nil {
+				mockHorizon.On("LoadAccount", accountID).Return(*account, nil)
+			} else {
+				mockHorizon.On("LoadAccount", accountID).Return(horizon.AccountResponse{}, errors.New("not found"))
+			}
+		}
+
+		rh := RequestHandler{Horizon: mockHorizon}
+		result := rh.paymentPreviewErrors(context.Background(), log.NewEntry(log.StandardLogger()), test.tx)
+		assert.Equal(t, test.errors, result, test.name)
+		if test.source != nil {
+			assert.Equal(t, xdr.SequenceNumber(11), test.tx.SeqNum, test.name)
+		}
+	}
+}
+
+func TestCompliancePendingPreview(t *testing.T) {
+	c := &config.Config{}
+	c.Callbacks.Compliance = "http://compliance"
+	mockEntityManager := new(mocks.MockEntityManager)
+	rh := RequestHandler{Config: c, EntityManager: mockEntityManager}
+
+	sendRequest := compliance.SendRequest{Source: "GBKGH7QZVCZ2ZA5OUGZSTHFNXTBHL3MPCKSCBJUAQODGPMWP7OMMRKDW"}
+	authResponse := compliance.AuthResponse{InfoStatus: compliance.AuthStatusPending, Pending: 60}
+
+	// Previews are never submitted so nothing is saved
+	errorResponse, err := rh.compliancePending(sendRequest, authResponse, true)
+	require.NoError(t, err)
+	assert.Equal(t, bridge.PaymentPending.Code, errorResponse.Code)
+	assert.NotContains(t, errorResponse.Data, "pending_id")
+	mockEntityManager.AssertNotCalled(t, "Persist", mock.Anything)
+
+	mockEntityManager.On("Persist", mock.AnythingOfType("*entities.PendingCompliance")).Run(func(args mock.Arguments) {
+		id := int64(7)
+		args.Get(0).(*entities.PendingCompliance).ID = &id
+	}).Return(nil).Once()
+	errorResponse, err = rh.compliancePending(sendRequest, authResponse, false)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), errorResponse.Data["pending_id"])
+	mockEntityManager.AssertExpectations(t)
+}
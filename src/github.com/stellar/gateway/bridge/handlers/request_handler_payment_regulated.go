@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"context"
+	"net/url"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/listener"
+	"github.com/stellar/gateway/protocols"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stellar/gateway/signer"
+	b "github.com/stellar/go-stellar-base/build"
+)
+
+// regulatedTrustline is a trustline to an asset of the issuing account that is authorized
+// only for the duration of a payment
+type regulatedTrustline struct {
+	trustor   string
+	assetCode string
+}
+
+// regulatedTrustlines returns trustlines of source and destination to assets of the issuing
+// account sent or received in /payment request. It's empty when `callbacks.approve` is not set.
+func (rh *RequestHandler) regulatedTrustlines(request *bridge.PaymentRequest, source, destination string) []regulatedTrustline {
+	issuer := rh.Config.Accounts.IssuingAccountID
+	if rh.Config.Callbacks.Approve == "" || issuer == "" {
+		return nil
+	}
+
+	sendAssetCode, sendAssetIssuer := request.AssetCode, request.AssetIssuer
+	if request.SendMax != "" {
+		sendAssetCode, sendAssetIssuer = request.SendAssetCode, request.SendAssetIssuer
+	}
+
+	var trustlines []regulatedTrustline
+	if sendAssetCode != "" && sendAssetIssuer == issuer && source != issuer {
+		trustlines = append(trustlines, regulatedTrustline{source, sendAssetCode})
+	}
+	if request.AssetCode != "" && request.AssetIssuer == issuer && destination != issuer {
+		trustlines = append(trustlines, regulatedTrustline{destination, request.AssetCode})
+	}
+	return trustlines
+}
+
+// regulatedPayment wraps operation of a payment of regulated assets: trustlines are authorized
+// by allow_trust operations of the issuing account before the payment and the authorization is
+// revoked after it (when the issuing account has AUTH_REVOCABLE flag). The payment is sent to
+// `callbacks.approve` first unless it's a preview. Signer of `accounts.authorizing_seed` is
+// returned to sign the transaction. operation is returned unchanged when the issuing account
+// does not have AUTH_REQUIRED flag.
+func (rh *RequestHandler) regulatedPayment(
+	ctx context.Context,
+	logger *log.Entry,
+	request *bridge.PaymentRequest,
+	source, destination string,
+	trustlines []regulatedTrustline,
+	operation b.TransactionMutator,
+	preview bool,
+) ([]b.TransactionMutator, signer.Signer, *protocols.ErrorResponse) {
+	issuer := rh.Config.Accounts.IssuingAccountID
+	asset, err := rh.Horizon.LoadAsset(ctx, request.AssetCode, issuer)
+	if err != nil {
+		logger.WithFields(log.Fields{"err": err}).Error("Cannot load asset")
+		return nil, nil, protocols.InternalServerError
+	}
+
+	if !asset.Flags.AuthRequired {
+		return []b.TransactionMutator{operation}, nil, nil
+	}
+
+	if !preview {
+		approval, err := listener.RequestApproval(ctx, rh.Client, rh.Config, url.Values{
+			"source":       {source},
+			"destination":  {destination},
+			"amount":       {request.Amount},
+			"asset_code":   {request.AssetCode},
+			"asset_issuer": {request.AssetIssuer},
+			"send_max":     {request.SendMax},
+			"memo_type":    {request.MemoType},
+			"memo":         {request.Memo},
+		})
+		if err != nil {
+			logger.WithFields(log.Fields{"err": err}).Error("Error sending payment to approve callback")
+			return nil, nil, bridge.ApproveCallbackFailed
+		}
+
+		if approval.Status == listener.ApprovalStatusRejected {
+			logger.WithFields(log.Fields{"error": approval.Error}).Info("Payment rejected by approve callback")
+			return nil, nil, bridge.NewPaymentNotApprovedError(approval.Error)
+		}
+
+		// action_required and pending can be handled by SEP-8 wallets only
+		if approval.Status != listener.ApprovalStatusApproved {
+			logger.WithFields(log.Fields{"status": approval.Status}).Error("Approve callback status not supported by /payment")
+			return nil, nil, bridge.ApproveCallbackFailed
+		}
+	}
+
+	authorizer, err := rh.Signers.Signer(rh.Config.Accounts.AuthorizingSeed)
+	if err != nil {
+		logger.WithFields(log.Fields{"err": err}).Error("Cannot load authorizing seed")
+		return nil, nil, submitErrorResponse(err)
+	}
+
+	return regulatedOperations(issuer, trustlines, operation, asset.Flags.AuthRevocable), authorizer, nil
+}
+
+// regulatedOperations returns operation wrapped in allow_trust operations of the issuer
+// authorizing trustlines and revoking the authorization in reverse order when revocable is true
+func regulatedOperations(issuer string, trustlines []regulatedTrustline, operation b.TransactionMutator, revocable bool) []b.TransactionMutator {
+	allowTrust := func(trustline regulatedTrustline, authorize bool) b.TransactionMutator {
+		return b.AllowTrust(
+			b.SourceAccount{issuer},
+			b.Trustor{trustline.trustor},
+			b.AllowTrustAsset{trustline.assetCode},
+			b.Authorize{authorize},
+		)
+	}
+
+	var mutators []b.TransactionMutator
+	for _, trustline := range trustlines {
+		mutators = append(mutators, allowTrust(trustline, true))
+	}
+	mutators = append(mutators, operation)
+	if revocable {
+		for i := len(trustlines) - 1; i >= 0; i-- {
+			mutators = append(mutators, allowTrust(trustlines[i], false))
+		}
+	}
+	return mutators
+}
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/mocks"
+	"github.com/stellar/gateway/protocols/bridge"
+	b "github.com/stellar/go-stellar-base/build"
+	"github.com/stellar/go-stellar-base/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegulatedPayment(t *testing.T) {
+	issuer := "GDSIKW43UA6JTOA47WVEBCZ4MYC74M3GNKNXTVDXFHXYYTNO5GGVN632"
+	source := "GAHA6GRCLCCN7XE2NEEUDSIVOFBOQ6GLSYXVLYCJXJKLPMDR5XB5XZZJ"
+	destination := "GAMVF7G4GJC4A7JMFJWLUAEIBFQD5RT3DCB5DC5TJDEKQBBACQ4JZVEE"
+
+	c := &config.Config{NetworkPassphrase: "Test SDF Network ; September 2015"}
+	c.Accounts.IssuingAccountID = issuer
+	c.Accounts.AuthorizingSeed = "SBKKWO3ZVDDEHDJILGHPHCJCFD2GNUAYIUDMRAS326HLUEQ7ZFXWIGQK"
+	mockHorizon := new(mocks.MockHorizon)
+	mockHTTPClient := new(mocks.MockHTTPClient)
+	rh := RequestHandler{Config: c, Horizon: mockHorizon, Client: mockHTTPClient}
+
+	request := &bridge.PaymentRequest{Amount: "10", AssetCode: "USD", AssetIssuer: issuer}
+
+	// Disabled
+	assert.Empty(t, rh.regulatedTrustlines(request, source, destination))
+
+	c.Callbacks.Approve = "http://approve"
+	trustlines := rh.regulatedTrustlines(request, source, destination)
+	assert.Equal(t, []regulatedTrustline{{source, "USD"}, {destination, "USD"}}, trustlines)
+	// Payments of the issuer and of other assets
+	assert.Equal(t, []regulatedTrustline{{destination, "USD"}}, rh.regulatedTrustlines(request, issuer, destination))
+	assert.Empty(t, rh.regulatedTrustlines(&bridge.PaymentRequest{Amount: "10"}, source, destination))
+
+	operation := b.Payment(b.Destination{destination}, b.CreditAmount{"USD", issuer, "10"})
+	operations := func(mutators []b.TransactionMutator) []xdr.Operation {
+		mutators = append([]b.TransactionMutator{b.SourceAccount{source}, b.Sequence{1}, b.Network{c.NetworkPassphrase}}, mutators...)
+		tx := b.Transaction(mutators...)
+		require.NoError(t, tx.Err)
+		return tx.TX.Operations
+	}
+	approve := func(body string) {
+		mockHTTPClient.On("Do", mock.MatchedBy(func(r *http.Request) bool {
+			r.ParseForm()
+			return r.URL.String() == "http://approve" &&
+				r.PostForm.Get("source") == source &&
+				r.PostForm.Get("destination") == destination &&
+				r.PostForm.Get("amount") == "10"
+		})).Return(&http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader(body))}, nil).Once()
+	}
+	logger := log.WithField("test", t.Name())
+
+	// Issuing account without AUTH_REQUIRED
+	mockHorizon.On("LoadAsset", "USD", issuer).Return(horizon.AssetResponse{}, nil).Once()
+	mutators, authorizer, errorResponse := rh.regulatedPayment(context.Background(), logger, request, source, destination, trustlines, operation, false)
+	require.Nil(t, errorResponse)
+	assert.Nil(t, authorizer)
+	assert.Len(t, operations(mutators), 1)
+
+	// Approved
+	mockHorizon.On("LoadAsset", "USD", issuer).Return(horizon.AssetResponse{Flags: horizon.Flags{AuthRequired: true, AuthRevocable: true}}, nil).Once()
+	approve(`{"status": "approved"}`)
+	mutators, authorizer, errorResponse = rh.regulatedPayment(context.Background(), logger, request, source, destination, trustlines, operation, false)
+	require.Nil(t, errorResponse)
+	assert.Equal(t, "GAHA6GRCLCCN7XE2NEEUDSIVOFBOQ6GLSYXVLYCJXJKLPMDR5XB5XZZJ", authorizer.Address())
+
+	ops := operations(mutators)
+	require.Len(t, ops, 5)
+	expected := []struct {
+		trustor   string
+		authorize bool
+	}{{source, true}, {destination, true}, {"", false}, {destination, false}, {source, false}}
+	for i, op := range ops {
+		if i == 2 {
+			assert.Equal(t, xdr.OperationTypePayment, op.Body.Type)
+			continue
+		}
+		require.Equal(t, xdr.OperationTypeAllowTrust, op.Body.Type)
+		assert.Equal(t, issuer, op.SourceAccount.Address())
+		assert.Equal(t, expected[i].trustor, op.Body.AllowTrustOp.Trustor.Address())
+		assert.Equal(t, expected[i].authorize, op.Body.AllowTrustOp.Authorize)
+	}
+
+	// Issuing account without AUTH_REVOCABLE keeps trustlines authorized
+	mockHorizon.On("LoadAsset", "USD", issuer).Return(horizon.AssetResponse{Flags: horizon.Flags{AuthRequired: true}}, nil).Once()
+	approve(`{"status": "approved"}`)
+	mutators, _, errorResponse = rh.regulatedPayment(context.Background(), logger, request, source, destination, trustlines, operation, false)
+	require.Nil(t, errorResponse)
+	assert.Len(t, operations(mutators), 3)
+
+	// Preview does not send the payment to the callback
+	mockHorizon.On("LoadAsset", "USD", issuer).Return(horizon.AssetResponse{Flags: horizon.Flags{AuthRequired: true}}, nil).Once()
+	mutators, _, errorResponse = rh.regulatedPayment(context.Background(), logger, request, source, destination, trustlines, operation, true)
+	require.Nil(t, errorResponse)
+	assert.Len(t, operations(mutators), 3)
+
+	// Rejected
+	mockHorizon.On("LoadAsset", "USD", issuer).Return(horizon.AssetResponse{Flags: horizon.Flags{AuthRequired: true}}, nil).Once()
+	approve(`{"status": "rejected", "error": "Destination is not verified"}`)
+	_, _, errorResponse = rh.regulatedPayment(context.Background(), logger, request, source, destination, trustlines, operation, false)
+	require.NotNil(t, errorResponse)
+	assert.Equal(t, bridge.PaymentNotApproved.Code, errorResponse.Code)
+	assert.Equal(t, "Destination is not verified", errorResponse.Data["error"])
+
+	// Unknown status
+	mockHorizon.On("LoadAsset", "USD", issuer).Return(horizon.AssetResponse{Flags: horizon.Flags{AuthRequired: true}}, nil).Once()
+	approve(`{"status": "pending"}`)
+	_, _, errorResponse = rh.regulatedPayment(context.Background(), logger, request, source, destination, trustlines, operation, false)
+	assert.Equal(t, bridge.ApproveCallbackFailed, errorResponse)
+
+	mockHorizon.AssertExpectations(t)
+	mockHTTPClient.AssertExpectations(t)
+}
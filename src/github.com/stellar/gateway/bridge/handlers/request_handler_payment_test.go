@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"encoding/hex"
 	"errors"
 	"net/http"
@@ -12,9 +13,11 @@ import (
 	"github.com/facebookgo/inject"
 	. "github.com/smartystreets/goconvey/convey"
 	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/db/entities"
 	"github.com/stellar/gateway/horizon"
 	"github.com/stellar/gateway/mocks"
 	"github.com/stellar/gateway/net"
+	"github.com/stellar/gateway/protocols/bridge"
 	"github.com/stellar/gateway/protocols/compliance"
 	"github.com/stellar/gateway/protocols/federation"
 	"github.com/stellar/gateway/protocols/stellartoml"
@@ -22,12 +25,14 @@ import (
 	"github.com/stellar/go-stellar-base/xdr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestRequestHandlerPayment(t *testing.T) {
 	c := &config.Config{
 		NetworkPassphrase: "Test SDF Network ; September 2015",
 		Compliance:        "http://compliance",
+		Assets:            []config.Asset{{Code: config.AssetWildcard, Issuer: config.AssetWildcard}},
 		Accounts: config.Accounts{
 			// GAHA6GRCLCCN7XE2NEEUDSIVOFBOQ6GLSYXVLYCJXJKLPMDR5XB5XZZJ
 			BaseSeed: "SBKKWO3ZVDDEHDJILGHPHCJCFD2GNUAYIUDMRAS326HLUEQ7ZFXWIGQK",
@@ -322,6 +327,12 @@ func TestRequestHandlerPayment(t *testing.T) {
 			).Once()
 
 			Convey("it should return error", func() {
+				// Checking if destination requires memo
+				mockHorizon.On(
+					"LoadAccount",
+					"GDSIKW43UA6JTOA47WVEBCZ4MYC74M3GNKNXTVDXFHXYYTNO5GGVN632",
+				).Return(horizon.AccountResponse{}, nil).Once()
+
 				mockHorizon.On(
 					"LoadAccount",
 					"GBKGH7QZVCZ2ZA5OUGZSTHFNXTBHL3MPCKSCBJUAQODGPMWP7OMMRKDW",
@@ -363,25 +374,6 @@ func TestRequestHandlerPayment(t *testing.T) {
 			assetCode := "USD"
 			assetIssuer := "GDSIKW43UA6JTOA47WVEBCZ4MYC74M3GNKNXTVDXFHXYYTNO5GGVN632"
 
-			mockFederationResolver.On(
-				"Resolve",
-				"GDSIKW43UA6JTOA47WVEBCZ4MYC74M3GNKNXTVDXFHXYYTNO5GGVN632",
-			).Return(
-				federation.Response{AccountID: "GDSIKW43UA6JTOA47WVEBCZ4MYC74M3GNKNXTVDXFHXYYTNO5GGVN632"},
-				stellartoml.StellarToml{},
-				nil,
-			).Once()
-
-			mockHorizon.On(
-				"LoadAccount",
-				"GBKGH7QZVCZ2ZA5OUGZSTHFNXTBHL3MPCKSCBJUAQODGPMWP7OMMRKDW",
-			).Return(
-				horizon.AccountResponse{
-					SequenceNumber: "100",
-				},
-				nil,
-			).Once()
-
 			Convey("it should return error", func() {
 				statusCode, response := net.GetResponse(
 					testServer,
@@ -399,7 +391,8 @@ func TestRequestHandlerPayment(t *testing.T) {
   "code": "invalid_parameter",
   "message": "Invalid parameter.",
   "data": {
-    "name": "amount"
+    "name": "amount",
+    "reason": "amount must be a decimal number like 100 or 12.5"
   }
 }`)
 				assert.Equal(t, expected, test.StringToJSONMap(responseString))
@@ -424,6 +417,12 @@ func TestRequestHandlerPayment(t *testing.T) {
 				nil,
 			).Once()
 
+			// Checking if destination requires memo
+			mockHorizon.On(
+				"LoadAccount",
+				"GDSIKW43UA6JTOA47WVEBCZ4MYC74M3GNKNXTVDXFHXYYTNO5GGVN632",
+			).Return(horizon.AccountResponse{}, nil).Once()
+
 			// Loading sequence number
 			mockHorizon.On(
 				"LoadAccount",
@@ -618,7 +617,36 @@ func TestRequestHandlerPayment(t *testing.T) {
 				})
 			})
 
+			Convey("destination requires memo", func() {
+				mockHorizon.On(
+					"LoadAccount",
+					"GDSIKW43UA6JTOA47WVEBCZ4MYC74M3GNKNXTVDXFHXYYTNO5GGVN632",
+				).Return(
+					horizon.AccountResponse{
+						Data: map[string]string{"config.memo_required": "MQ=="},
+					},
+					nil,
+				).Once()
+
+				Convey("it should return error", func() {
+					statusCode, response := net.GetResponse(testServer, validParams)
+					responseString := strings.TrimSpace(string(response))
+					assert.Equal(t, 400, statusCode)
+					expected := test.StringToJSONMap(`{
+  "code": "memo_required",
+  "message": "Destination account requires a memo (SEP-29)."
+}`)
+					assert.Equal(t, expected, test.StringToJSONMap(responseString))
+				})
+			})
+
 			Convey("source account does not exist", func() {
+				// Checking if destination requires memo
+				mockHorizon.On(
+					"LoadAccount",
+					"GDSIKW43UA6JTOA47WVEBCZ4MYC74M3GNKNXTVDXFHXYYTNO5GGVN632",
+				).Return(horizon.AccountResponse{}, nil).Once()
+
 				mockHorizon.On(
 					"LoadAccount",
 					"GCF3WVYTHF75PEG6622G5G6KU26GOSDQPDHSCJ3DQD7VONH4EYVDOGKJ",
@@ -637,6 +665,12 @@ func TestRequestHandlerPayment(t *testing.T) {
 			})
 
 			Convey("transaction failed in horizon", func() {
+				// Checking if destination requires memo
+				mockHorizon.On(
+					"LoadAccount",
+					"GDSIKW43UA6JTOA47WVEBCZ4MYC74M3GNKNXTVDXFHXYYTNO5GGVN632",
+				).Return(horizon.AccountResponse{}, nil).Once()
+
 				mockHorizon.On(
 					"LoadAccount",
 					"GCF3WVYTHF75PEG6622G5G6KU26GOSDQPDHSCJ3DQD7VONH4EYVDOGKJ",
@@ -725,6 +759,12 @@ func TestRequestHandlerPayment(t *testing.T) {
 			})
 
 			Convey("transaction success (credit)", func() {
+				// Checking if destination requires memo
+				mockHorizon.On(
+					"LoadAccount",
+					"GDSIKW43UA6JTOA47WVEBCZ4MYC74M3GNKNXTVDXFHXYYTNO5GGVN632",
+				).Return(horizon.AccountResponse{}, nil).Once()
+
 				mockHorizon.On(
 					"LoadAccount",
 					"GCF3WVYTHF75PEG6622G5G6KU26GOSDQPDHSCJ3DQD7VONH4EYVDOGKJ",
@@ -919,14 +959,13 @@ func TestRequestHandlerPayment(t *testing.T) {
 
 			Convey("it should return error when compliance server returns error", func() {
 				mockHTTPClient.On(
-					"PostForm",
-					"http://compliance/send",
-					mock.AnythingOfType("url.Values"),
+					"Do",
+					mock.MatchedBy(isComplianceSendRequest),
 				).Return(
 					net.BuildHTTPResponse(400, "error"),
 					nil,
 				).Run(func(args mock.Arguments) {
-					values := args.Get(1).(url.Values)
+					values := complianceSendValues(args.Get(0).(*http.Request))
 					// bridge server does not send source seed to compliance
 					assert.Equal(t, []string{"GAW77Z6GPWXSODJOMF5L5BMX6VMYGEJRKUNBC2CZ725JTQZORK74HQQD"}, values["source"])
 					values.Del("source")
@@ -946,14 +985,13 @@ func TestRequestHandlerPayment(t *testing.T) {
 
 			Convey("it should return denied when compliance server returns denied", func() {
 				mockHTTPClient.On(
-					"PostForm",
-					"http://compliance/send",
-					mock.AnythingOfType("url.Values"),
+					"Do",
+					mock.MatchedBy(isComplianceSendRequest),
 				).Return(
 					net.BuildHTTPResponse(200, "{\"auth_response\": {\"tx_status\": \"denied\"}}"),
 					nil,
 				).Run(func(args mock.Arguments) {
-					values := args.Get(1).(url.Values)
+					values := complianceSendValues(args.Get(0).(*http.Request))
 					// bridge server does not send source seed to compliance
 					assert.Equal(t, []string{"GAW77Z6GPWXSODJOMF5L5BMX6VMYGEJRKUNBC2CZ725JTQZORK74HQQD"}, values["source"])
 					values.Del("source")
@@ -973,14 +1011,13 @@ func TestRequestHandlerPayment(t *testing.T) {
 
 			Convey("it should return pending when compliance server returns pending", func() {
 				mockHTTPClient.On(
-					"PostForm",
-					"http://compliance/send",
-					mock.AnythingOfType("url.Values"),
+					"Do",
+					mock.MatchedBy(isComplianceSendRequest),
 				).Return(
 					net.BuildHTTPResponse(200, "{\"auth_response\": {\"info_status\": \"pending\", \"pending\": 3600}}"),
 					nil,
 				).Run(func(args mock.Arguments) {
-					values := args.Get(1).(url.Values)
+					values := complianceSendValues(args.Get(0).(*http.Request))
 					// bridge server does not send source seed to compliance
 					assert.Equal(t, []string{"GAW77Z6GPWXSODJOMF5L5BMX6VMYGEJRKUNBC2CZ725JTQZORK74HQQD"}, values["source"])
 					values.Del("source")
@@ -1058,14 +1095,13 @@ func TestRequestHandlerPayment(t *testing.T) {
 				}
 
 				mockHTTPClient.On(
-					"PostForm",
-					"http://compliance/send",
-					mock.AnythingOfType("url.Values"),
+					"Do",
+					mock.MatchedBy(isComplianceSendRequest),
 				).Return(
 					net.BuildHTTPResponse(200, string(complianceResponse.Marshal())),
 					nil,
 				).Run(func(args mock.Arguments) {
-					values := args.Get(1).(url.Values)
+					values := complianceSendValues(args.Get(0).(*http.Request))
 					assert.Equal(t, []string{"GAW77Z6GPWXSODJOMF5L5BMX6VMYGEJRKUNBC2CZ725JTQZORK74HQQD"}, values["source"])
 					values.Del("source")
 					params.Del("source")
@@ -1105,9 +1141,8 @@ func TestRequestHandlerPayment(t *testing.T) {
 				}
 
 				mockHTTPClient.On(
-					"PostForm",
-					"http://compliance/send",
-					mock.AnythingOfType("url.Values"),
+					"Do",
+					mock.MatchedBy(isComplianceSendRequest),
 				).Return(
 					net.BuildHTTPResponse(200, string(complianceResponse.Marshal())),
 					nil,
@@ -1134,3 +1169,159 @@ func TestRequestHandlerPayment(t *testing.T) {
 		})
 	})
 }
+
+func TestRequestHandlerPaymentIdempotencyKey(t *testing.T) {
+	mockEntityManager := new(mocks.MockEntityManager)
+	mockRepository := new(mocks.MockRepository)
+	mockFederationResolver := new(mocks.MockFederationResolver)
+	requestHandler := RequestHandler{
+		Config: &config.Config{
+			Accounts: config.Accounts{
+				BaseSeed: "SBKKWO3ZVDDEHDJILGHPHCJCFD2GNUAYIUDMRAS326HLUEQ7ZFXWIGQK",
+			},
+		},
+		FederationResolver: mockFederationResolver,
+		EntityManager:      mockEntityManager,
+		Repository:         mockRepository,
+	}
+
+	send := func(key string) *httptest.ResponseRecorder {
+		form := url.Values{
+			"destination":     {"bob*stellar.org"},
+			"amount":          {"20"},
+			"idempotency_key": {key},
+		}
+		req := httptest.NewRequest("POST", "/payment", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		requestHandler.Payment(w, req)
+		return w
+	}
+
+	// Original result is returned for a duplicate key
+	ledger := uint64(123)
+	mockRepository.On("GetSentTransactionByIdempotencyKey", "sent").Return(&entities.SentTransaction{
+		TransactionID: "ad71fc31bfae25b0bd14add4cc5306661edf84cdd73f1353d2906363899167e1",
+		Status:        entities.SentTransactionStatusSuccess,
+		Ledger:        &ledger,
+	}, nil).Once()
+	w := send("sent")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"hash": "ad71fc31bfae25b0bd14add4cc5306661edf84cdd73f1353d2906363899167e1"`)
+	assert.Contains(t, w.Body.String(), `"ledger": 123`)
+
+	// Payment with unknown result is not sent again
+	mockRepository.On("GetSentTransactionByIdempotencyKey", "sending").Return(&entities.SentTransaction{
+		Status: entities.SentTransactionStatusSending,
+	}, nil).Once()
+	w = send("sending")
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "idempotency_key_in_progress")
+
+	// Key is released when the payment is not submitted
+	mockRepository.On("GetSentTransactionByIdempotencyKey", "new").Return(nil, nil).Once()
+	mockEntityManager.On("Persist", mock.AnythingOfType("*entities.SentTransaction")).Return(nil).Once()
+	mockEntityManager.On("Delete", mock.AnythingOfType("*entities.SentTransaction")).Return(nil).Once()
+	mockFederationResolver.On("Resolve", "bob*stellar.org").Return(
+		federation.Response{},
+		stellartoml.StellarToml{},
+		errors.New("stellar.toml response status code indicates error"),
+	).Once()
+	w = send("new")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "cannot_resolve_destination")
+
+	mockRepository.AssertExpectations(t)
+	mockEntityManager.AssertExpectations(t)
+}
+
+func TestPaymentPathFinding(t *testing.T) {
+	issuer := "GDSIKW43UA6JTOA47WVEBCZ4MYC74M3GNKNXTVDXFHXYYTNO5GGVN632"
+	eur := "EUR:" + issuer
+	usd := "USD:" + issuer
+	mockHorizon := new(mocks.MockHorizon)
+	mockFederationResolver := new(mocks.MockFederationResolver)
+	requestHandler := RequestHandler{
+		Config: &config.Config{
+			Accounts: config.Accounts{
+				BaseSeed: "SBKKWO3ZVDDEHDJILGHPHCJCFD2GNUAYIUDMRAS326HLUEQ7ZFXWIGQK",
+			},
+			Assets: []config.Asset{{Code: "USD", Issuer: issuer}},
+		},
+		Horizon:            mockHorizon,
+		FederationResolver: mockFederationResolver,
+	}
+
+	send := func(extra url.Values) *httptest.ResponseRecorder {
+		form := url.Values{
+			"destination": {"bob*stellar.org"},
+			"amount":      {"10"},
+			"asset":       {usd},
+			"send_asset":  {eur},
+			"send_max":    {"9.5"},
+		}
+		for name, values := range extra {
+			form[name] = values
+		}
+		req := httptest.NewRequest("POST", "/payment", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		requestHandler.Payment(w, req)
+		return w
+	}
+
+	// No path
+	mockHorizon.On("FindPaths", eur, usd, "10").Return([]horizon.PathResponse{}, nil).Once()
+	w := send(nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "payment_too_few_offers")
+
+	// Cheapest path exceeds send_max
+	mockHorizon.On("FindPaths", eur, usd, "10").Return([]horizon.PathResponse{
+		{SourceAmount: "9.6000000"},
+		{SourceAmount: "9.5000001"},
+	}, nil).Once()
+	w = send(nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "payment_over_sendmax")
+
+	// Path found, payment continues
+	mockHorizon.On("FindPaths", eur, usd, "10").Return([]horizon.PathResponse{
+		{SourceAmount: "9.4000000"},
+		{SourceAmount: "9.2000000", Path: []horizon.PathAsset{{AssetType: "native"}}},
+	}, nil).Once()
+	mockFederationResolver.On("Resolve", "bob*stellar.org").Return(
+		federation.Response{},
+		stellartoml.StellarToml{},
+		errors.New("stellar.toml response status code indicates error"),
+	)
+	w = send(nil)
+	assert.Contains(t, w.Body.String(), "cannot_resolve_destination")
+
+	// Explicit path and the same asset are not looked up
+	send(url.Values{"path[0][asset_code]": {""}, "path[0][asset_issuer]": {""}})
+	send(url.Values{"send_asset": {usd}})
+	mockHorizon.AssertExpectations(t)
+
+	// Cheapest path
+	mockHorizon.On("FindPaths", eur, usd, "10").Return([]horizon.PathResponse{
+		{SourceAmount: "9.4000000"},
+		{SourceAmount: "9.2000000", Path: []horizon.PathAsset{{AssetType: "native"}}},
+	}, nil).Once()
+	path, sourceAmount, err := requestHandler.findPaymentPath(context.Background(), &bridge.PaymentRequest{
+		Amount: "10", AssetCode: "USD", AssetIssuer: issuer, SendAssetCode: "EUR", SendAssetIssuer: issuer,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "9.2000000", path.SourceAmount)
+	assert.Equal(t, xdr.Int64(92000000), sourceAmount)
+}
+
+func isComplianceSendRequest(r *http.Request) bool {
+	return r.Method == "POST" && r.URL.String() == "http://compliance/send"
+}
+
+// complianceSendValues returns form values of a request sent to compliance server
+func complianceSendValues(r *http.Request) url.Values {
+	r.ParseForm()
+	return r.PostForm
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/protocols"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stellar/gateway/server"
+)
+
+// cursorHeader contains cursor of the next page of CSV responses
+const cursorHeader = "X-Cursor"
+
+// ReceivedPayments implements GET /received-payments endpoint. It returns received payments
+// matching query params, oldest first. CSV is returned when requested in Accept header.
+func (rh *RequestHandler) ReceivedPayments(w http.ResponseWriter, r *http.Request) {
+	request := &bridge.PaymentsExportRequest{}
+	request.FromRequest(r)
+
+	filter, err := request.ParseReceived()
+	if err != nil {
+		errorResponse := err.(*protocols.ErrorResponse)
+		log.WithFields(errorResponse.LogData).Error(errorResponse.Error())
+		server.Write(w, errorResponse)
+		return
+	}
+
+	payments, err := rh.Repository.GetReceivedPayments(filter)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error getting received payments")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	response := &bridge.ReceivedPaymentsResponse{
+		Payments: []bridge.ReceivedPayment{},
+		Cursor:   strconv.FormatInt(filter.Cursor, 10),
+	}
+	for i := range payments {
+		response.Payments = append(response.Payments, bridge.NewReceivedPayment(&payments[i], nil))
+	}
+	if len(payments) > 0 {
+		response.Cursor = strconv.FormatInt(*payments[len(payments)-1].ID, 10)
+	}
+
+	if server.AcceptsCSV(r) {
+		w.Header().Set(cursorHeader, response.Cursor)
+		server.WriteCSV(w, response)
+		return
+	}
+	server.Write(w, response)
+}
+
+// SentPayments implements GET /sent-payments endpoint. It returns payments sent by /payment
+// endpoint matching query params, oldest first. CSV is returned when requested in Accept header.
+func (rh *RequestHandler) SentPayments(w http.ResponseWriter, r *http.Request) {
+	request := &bridge.PaymentsExportRequest{}
+	request.FromRequest(r)
+
+	filter, err := request.ParseSent()
+	if err != nil {
+		errorResponse := err.(*protocols.ErrorResponse)
+		log.WithFields(errorResponse.LogData).Error(errorResponse.Error())
+		server.Write(w, errorResponse)
+		return
+	}
+
+	transactions, err := rh.Repository.GetSentPayments(filter)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error getting sent payments")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	response := &bridge.SentPaymentsResponse{
+		Payments: []bridge.SentTransaction{},
+		Cursor:   strconv.FormatInt(filter.Cursor, 10),
+	}
+	for i := range transactions {
+		response.Payments = append(response.Payments, bridge.NewSentTransaction(&transactions[i]))
+	}
+	if len(transactions) > 0 {
+		response.Cursor = strconv.FormatInt(*transactions[len(transactions)-1].ID, 10)
+	}
+
+	if server.AcceptsCSV(r) {
+		w.Header().Set(cursorHeader, response.Cursor)
+		server.WriteCSV(w, response)
+		return
+	}
+	server.Write(w, response)
+}
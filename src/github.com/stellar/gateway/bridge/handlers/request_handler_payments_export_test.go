@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/mocks"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReceivedPayments(t *testing.T) {
+	mockRepository := new(mocks.MockRepository)
+	rh := RequestHandler{Repository: mockRepository}
+
+	id := int64(7)
+	processedAt := time.Date(2017, 7, 14, 0, 0, 0, 0, time.UTC)
+	payments := []entities.ReceivedPayment{{
+		ID:          &id,
+		OperationID: "100",
+		ProcessedAt: processedAt,
+		Status:      entities.ReceivedPaymentStatusSuccess,
+		FromAccount: "GAMVF7G4GJC4A7JMFJWLUAEIBFQD5RT3DCB5DC5TJDEKQBBACQ4JZVEE",
+		Amount:      "10",
+		MemoType:    "text",
+		Memo:        "a, b",
+	}}
+	since := processedAt
+	native := ""
+	mockRepository.On("GetReceivedPayments", entities.ReceivedPaymentFilter{
+		Cursor:      5,
+		AssetCode:   &native,
+		AssetIssuer: &native,
+		Since:       &since,
+		Limit:       bridge.DefaultPaymentsExportLimit,
+	}).Return(payments, nil).Twice()
+
+	url := "/received-payments?cursor=5&asset=native&since=2017-07-14T00:00:00Z"
+	w := httptest.NewRecorder()
+	rh.ReceivedPayments(w, httptest.NewRequest("GET", url, nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response bridge.ReceivedPaymentsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "7", response.Cursor)
+	require.Len(t, response.Payments, 1)
+	assert.Equal(t, "100", response.Payments[0].ID)
+	assert.Equal(t, "native", response.Payments[0].Asset)
+
+	// CSV
+	r := httptest.NewRequest("GET", url, nil)
+	r.Header.Set("Accept", "text/csv")
+	w = httptest.NewRecorder()
+	rh.ReceivedPayments(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+	assert.Equal(t, "7", w.Header().Get("X-Cursor"))
+	assert.Equal(t,
+		"id,status,processed_at,from,to,amount,asset,memo_type,memo,refund_transaction_id\n"+
+			"100,Success,2017-07-14T00:00:00Z,GAMVF7G4GJC4A7JMFJWLUAEIBFQD5RT3DCB5DC5TJDEKQBBACQ4JZVEE,,10,native,text,\"a, b\",\n",
+		w.Body.String(),
+	)
+
+	// Invalid params
+	for _, query := range []string{"cursor=-1", "status=unknown", "asset=USD", "since=yesterday", "limit=201"} {
+		w = httptest.NewRecorder()
+		rh.ReceivedPayments(w, httptest.NewRequest("GET", "/received-payments?"+query, nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+	mockRepository.AssertExpectations(t)
+}
+
+func TestSentPayments(t *testing.T) {
+	mockRepository := new(mocks.MockRepository)
+	rh := RequestHandler{Repository: mockRepository}
+
+	id := int64(3)
+	ledger := uint64(1234)
+	destination, amount, asset, metadata := "GAMVF7G4GJC4A7JMFJWLUAEIBFQD5RT3DCB5DC5TJDEKQBBACQ4JZVEE", "100", "native", `{"invoice":"1"}`
+	transactions := []entities.SentTransaction{{
+		ID:            &id,
+		TransactionID: "abc",
+		Status:        entities.SentTransactionStatusSuccess,
+		Source:        "GBSTRH4QOTWNSVA6E4HFERETX4ZLSR3CIUBLK7AXYII277PFJC4BBYOG",
+		SubmittedAt:   time.Date(2017, 7, 14, 0, 0, 0, 0, time.UTC),
+		Ledger:        &ledger,
+		Destination:   &destination,
+		Amount:        &amount,
+		Asset:         &asset,
+		Metadata:      &metadata,
+	}}
+	mockRepository.On("GetSentPayments", entities.SentTransactionFilter{
+		Status: entities.SentTransactionStatusSuccess,
+		Limit:  10,
+	}).Return(transactions, nil).Twice()
+
+	url := "/sent-payments?status=success&limit=10"
+	w := httptest.NewRecorder()
+	rh.SentPayments(w, httptest.NewRequest("GET", url, nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response bridge.SentPaymentsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "3", response.Cursor)
+	require.Len(t, response.Payments, 1)
+	assert.Equal(t, destination, response.Payments[0].Destination)
+	assert.JSONEq(t, metadata, string(response.Payments[0].Metadata))
+
+	r := httptest.NewRequest("GET", url, nil)
+	r.Header.Set("Accept", "text/csv")
+	w = httptest.NewRecorder()
+	rh.SentPayments(w, r)
+	assert.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+	assert.Equal(t,
+		"hash,status,submitted_at,succeeded_at,ledger,source,destination,amount,asset,metadata\n"+
+			"abc,success,2017-07-14T00:00:00Z,,1234,GBSTRH4QOTWNSVA6E4HFERETX4ZLSR3CIUBLK7AXYII277PFJC4BBYOG,GAMVF7G4GJC4A7JMFJWLUAEIBFQD5RT3DCB5DC5TJDEKQBBACQ4JZVEE,100,native,\"{\"\"invoice\"\":\"\"1\"\"}\"\n",
+		w.Body.String(),
+	)
+
+	// Empty page keeps the cursor
+	mockRepository.On("GetSentPayments", entities.SentTransactionFilter{Cursor: 3, Limit: bridge.DefaultPaymentsExportLimit}).Return([]entities.SentTransaction{}, nil).Once()
+	w = httptest.NewRecorder()
+	rh.SentPayments(w, httptest.NewRequest("GET", "/sent-payments?cursor=3", nil))
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "3", response.Cursor)
+	assert.Empty(t, response.Payments)
+	mockRepository.AssertExpectations(t)
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/protocols"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stellar/gateway/server"
+)
+
+// pollInterval is an interval of DB queries made while waiting for new payments
+var pollInterval = time.Second
+
+// PaymentsPoll implements GET /payments/poll endpoint. It returns payments received after
+// the cursor or waits for new payments until timeout.
+func (rh *RequestHandler) PaymentsPoll(w http.ResponseWriter, r *http.Request) {
+	request := &bridge.PollRequest{}
+	request.FromRequest(r)
+
+	cursor, timeout, limit, err := request.Parse()
+	if err != nil {
+		errorResponse := err.(*protocols.ErrorResponse)
+		log.WithFields(errorResponse.LogData).Error(errorResponse.Error())
+		server.Write(w, errorResponse)
+		return
+	}
+
+	deadline := time.After(timeout)
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		payments, err := rh.Repository.GetReceivedPaymentsAfterID(cursor, limit)
+		if err != nil {
+			log.WithFields(log.Fields{"err": err}).Error("Error getting received payments")
+			server.Write(w, protocols.InternalServerError)
+			return
+		}
+
+		if len(payments) > 0 {
+			response := &bridge.PollResponse{Payments: []bridge.ReceivedPayment{}}
+			for i := range payments {
+				response.Payments = append(response.Payments, bridge.NewReceivedPayment(&payments[i], nil))
+			}
+			response.Cursor = strconv.FormatInt(*payments[len(payments)-1].ID, 10)
+			server.Write(w, response)
+			return
+		}
+
+		select {
+		case <-ticker.C:
+		case <-deadline:
+			server.Write(w, &bridge.PollResponse{
+				Payments: []bridge.ReceivedPayment{},
+				Cursor:   strconv.FormatInt(cursor, 10),
+			})
+			return
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
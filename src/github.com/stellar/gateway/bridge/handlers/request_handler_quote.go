@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/protocols"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stellar/gateway/server"
+	"github.com/stellar/go-stellar-base/amount"
+)
+
+// maxQuotes is the maximum number of quotes returned by /quote endpoint
+const maxQuotes = 5
+
+// Quote implements GET /quote endpoint. It returns rates of converting source asset to the
+// requested amount of destination asset using paths found by Horizon.
+func (rh *RequestHandler) Quote(w http.ResponseWriter, r *http.Request) {
+	logger := server.Logger(r)
+	request := &bridge.QuoteRequest{}
+	request.FromRequest(r)
+
+	sourceAsset, destAsset, err := request.Parse()
+	if err != nil {
+		errorResponse := err.(*protocols.ErrorResponse)
+		logger.WithFields(errorResponse.LogData).Error(errorResponse.Error())
+		server.Write(w, errorResponse)
+		return
+	}
+
+	paths, err := rh.Horizon.FindPaths(r.Context(), sourceAsset.String(), destAsset.String(), request.Amount)
+	if err != nil {
+		logger.WithFields(log.Fields{"err": err}).Error("Error finding payment paths")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	sort.SliceStable(paths, func(i, j int) bool {
+		first, _ := amount.Parse(paths[i].SourceAmount)
+		second, _ := amount.Parse(paths[j].SourceAmount)
+		return first < second
+	})
+	if len(paths) > maxQuotes {
+		paths = paths[:maxQuotes]
+	}
+
+	response := bridge.QuoteResponse{
+		SourceAsset: sourceAsset.String(),
+		DestAsset:   destAsset.String(),
+		Amount:      request.Amount,
+		Quotes:      []bridge.Quote{},
+	}
+
+	bestBids := map[string]float64{}
+	for _, path := range paths {
+		spotRate, err := rh.spotRate(r.Context(), sourceAsset, destAsset, path, bestBids)
+		if err != nil {
+			// Quote is returned without slippage
+			logger.WithFields(log.Fields{"err": err}).Warn("Error loading order book")
+		}
+		response.Quotes = append(response.Quotes, bridge.NewQuote(path, spotRate))
+	}
+
+	server.Write(w, &response)
+}
+
+// spotRate returns a rate of the best offers on path: a product of the best bid prices of
+// order books of every conversion. It returns 0 when one of the order books has no bids.
+// bestBids contains prices of order books that have already been loaded.
+func (rh *RequestHandler) spotRate(ctx context.Context, sourceAsset, destAsset protocols.Asset, path horizon.PathResponse, bestBids map[string]float64) (float64, error) {
+	assets := []string{sourceAsset.String()}
+	for _, asset := range path.Path {
+		assets = append(assets, protocols.Asset{Code: asset.AssetCode, Issuer: asset.AssetIssuer}.String())
+	}
+	assets = append(assets, destAsset.String())
+
+	rate := 1.0
+	for i := 0; i+1 < len(assets); i++ {
+		key := assets[i] + "/" + assets[i+1]
+		price, ok := bestBids[key]
+		if !ok {
+			orderBook, err := rh.Horizon.LoadOrderBook(ctx, assets[i], assets[i+1])
+			if err != nil {
+				return 0, err
+			}
+			if len(orderBook.Bids) > 0 {
+				price, _ = strconv.ParseFloat(orderBook.Bids[0].Price, 64)
+			}
+			bestBids[key] = price
+		}
+		rate *= price
+	}
+	return rate, nil
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/mocks"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuote(t *testing.T) {
+	eur := "EUR:GDSIKW43UA6JTOA47WVEBCZ4MYC74M3GNKNXTVDXFHXYYTNO5GGVN632"
+	usd := "USD:GDSIKW43UA6JTOA47WVEBCZ4MYC74M3GNKNXTVDXFHXYYTNO5GGVN632"
+	mockHorizon := new(mocks.MockHorizon)
+	rh := RequestHandler{Horizon: mockHorizon}
+
+	quote := func(query string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		rh.Quote(w, httptest.NewRequest("GET", "/quote?"+query, nil))
+		return w
+	}
+
+	mockHorizon.On("FindPaths", eur, usd, "10").Return([]horizon.PathResponse{
+		{SourceAmount: "9.5000000", DestinationAmount: "10.0000000", Path: []horizon.PathAsset{{AssetType: "native"}}},
+		{SourceAmount: "9.2000000", DestinationAmount: "10.0000000", Path: []horizon.PathAsset{}},
+	}, nil).Once()
+	mockHorizon.On("LoadOrderBook", eur, usd).Return(horizon.OrderBookResponse{Bids: []horizon.OrderBookEntry{{Price: "1.1000000"}}}, nil).Once()
+	mockHorizon.On("LoadOrderBook", eur, "native").Return(horizon.OrderBookResponse{Bids: []horizon.OrderBookEntry{{Price: "10.0000000"}}}, nil).Once()
+	mockHorizon.On("LoadOrderBook", "native", usd).Return(horizon.OrderBookResponse{}, errors.New("timeout")).Once()
+
+	w := quote("source_asset=" + eur + "&dest_asset=" + usd + "&amount=10")
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response bridge.QuoteResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, eur, response.SourceAsset)
+	assert.Equal(t, usd, response.DestAsset)
+	require.Len(t, response.Quotes, 2)
+
+	// Sorted by source amount
+	assert.Equal(t, "9.2000000", response.Quotes[0].SourceAmount)
+	assert.Equal(t, "1.0869565", response.Quotes[0].Rate)
+	assert.Equal(t, "1.19", response.Quotes[0].Slippage)
+	assert.Equal(t, []string{}, response.Quotes[0].Path)
+
+	// Order book error
+	assert.Equal(t, "9.5000000", response.Quotes[1].SourceAmount)
+	assert.Equal(t, "", response.Quotes[1].Slippage)
+	assert.Equal(t, []string{"native"}, response.Quotes[1].Path)
+	mockHorizon.AssertExpectations(t)
+
+	w = quote("source_asset=" + eur + "&amount=10")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "missing_parameter")
+}
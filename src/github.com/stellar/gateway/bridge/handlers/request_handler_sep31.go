@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/protocols"
+	"github.com/stellar/gateway/protocols/amount"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stellar/gateway/server"
+	"github.com/zenazn/goji/web"
+)
+
+// Sep31Info implements GET /sep31/info endpoint
+func (rh *RequestHandler) Sep31Info(w http.ResponseWriter, r *http.Request) {
+	fields := map[string]bridge.Sep31Field{}
+	for _, field := range rh.Config.Sep31.Fields {
+		fields[field.Name] = bridge.Sep31Field{
+			Description: field.Description,
+			Optional:    field.Optional,
+			Choices:     field.Choices,
+		}
+	}
+
+	response := bridge.Sep31InfoResponse{Receive: map[string]bridge.Sep31AssetInfo{}}
+	for _, asset := range rh.Config.Sep31.Assets {
+		info := bridge.Sep31AssetInfo{
+			Enabled:    true,
+			FeeFixed:   asset.FeeFixed,
+			FeePercent: asset.FeePercent,
+			MinAmount:  asset.MinAmount,
+			MaxAmount:  asset.MaxAmount,
+		}
+		info.Fields.Transaction = fields
+		response.Receive[asset.Asset.Code] = info
+	}
+
+	server.Write(w, &response)
+}
+
+// Sep31CreateTransaction implements POST /sep31/transactions endpoint. It creates a transaction
+// and returns a memo the sending anchor must use in a payment to the receiving account.
+func (rh *RequestHandler) Sep31CreateTransaction(w http.ResponseWriter, r *http.Request) {
+	clientAccount, ok := rh.webAuthAccount(r)
+	if !ok {
+		server.Write(w, bridge.Sep31Unauthorized)
+		return
+	}
+
+	var request bridge.Sep31TransactionRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		server.Write(w, protocols.InvalidParameterError)
+		return
+	}
+
+	err = request.Validate()
+	if err != nil {
+		errorResponse := err.(*protocols.ErrorResponse)
+		log.WithFields(errorResponse.LogData).Error(errorResponse.Error())
+		server.Write(w, errorResponse)
+		return
+	}
+
+	asset := rh.Config.Sep31.AssetFor(request.AssetCode, request.AssetIssuer)
+	if asset == nil {
+		server.Write(w, bridge.Sep31AssetNotSupported)
+		return
+	}
+
+	amountIn, err := amount.Parse(request.Amount)
+	if err != nil {
+		server.Write(w, protocols.NewInvalidParameterError("amount", request.Amount))
+		return
+	}
+
+	// Limits are checked in config validation
+	minAmount, _ := amount.Parse(asset.MinAmount)
+	maxAmount, _ := amount.Parse(asset.MaxAmount)
+	fee := asset.Fee(amountIn)
+	if (asset.MinAmount != "" && amountIn < minAmount) ||
+		(asset.MaxAmount != "" && amountIn > maxAmount) ||
+		fee >= amountIn {
+		server.Write(w, bridge.Sep31InvalidAmount)
+		return
+	}
+
+	missing := map[string]bridge.Sep31Field{}
+	for _, field := range rh.Config.Sep31.Fields {
+		if !field.Optional && request.Fields.Transaction[field.Name] == "" {
+			missing[field.Name] = bridge.Sep31Field{Description: field.Description, Choices: field.Choices}
+		}
+	}
+	if len(missing) > 0 {
+		server.Write(w, bridge.NewSep31TransactionInfoNeededError(missing))
+		return
+	}
+
+	fields, err := json.Marshal(request.Fields.Transaction)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error marshaling transaction fields")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	id, err := newUUID()
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error generating transaction ID")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	memo := make([]byte, 32)
+	_, err = rand.Read(memo)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error generating memo")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	transaction := &entities.Sep31Transaction{
+		PublicID:      id,
+		Status:        entities.Sep31StatusPendingSender,
+		AmountIn:      amountIn.String(),
+		AmountFee:     fee.String(),
+		AmountOut:     (amountIn - fee).String(),
+		AssetCode:     asset.Asset.Code,
+		AssetIssuer:   asset.Asset.Issuer,
+		SenderID:      request.SenderID,
+		ReceiverID:    request.ReceiverID,
+		Fields:        string(fields),
+		ClientAccount: clientAccount,
+		MemoType:      "hash",
+		Memo:          base64.StdEncoding.EncodeToString(memo),
+		StartedAt:     time.Now(),
+		Tenant:        rh.Config.Tenant,
+	}
+
+	err = rh.EntityManager.Persist(transaction)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error persisting SEP-31 transaction")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	log.WithFields(log.Fields{"id": id, "client_account": clientAccount}).Info("SEP-31 transaction created")
+
+	server.Write(w, &bridge.Sep31CreateTransactionResponse{
+		ID:               transaction.PublicID,
+		StellarAccountID: rh.Config.Accounts.ReceivingAccountID,
+		StellarMemoType:  transaction.MemoType,
+		StellarMemo:      transaction.Memo,
+	})
+}
+
+// Sep31Transaction implements GET /sep31/transactions/:id endpoint. Transactions can be fetched
+// only by the account that created them.
+func (rh *RequestHandler) Sep31Transaction(c web.C, w http.ResponseWriter, r *http.Request) {
+	clientAccount, ok := rh.webAuthAccount(r)
+	if !ok {
+		server.Write(w, bridge.Sep31Unauthorized)
+		return
+	}
+
+	transaction, err := rh.Repository.GetSep31TransactionByPublicID(c.URLParams["id"])
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error getting SEP-31 transaction")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	if transaction == nil || transaction.ClientAccount != clientAccount {
+		server.Write(w, bridge.Sep31TransactionNotFound)
+		return
+	}
+
+	server.Write(w, &bridge.Sep31TransactionResponse{
+		Transaction: bridge.NewSep31Transaction(transaction, rh.Config.Accounts.ReceivingAccountID),
+	})
+}
+
+// newUUID returns a random (version 4) UUID
+func newUUID() (string, error) {
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
@@ -0,0 +1,391 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/protocols"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stellar/gateway/server"
+	"github.com/stellar/gateway/submitter"
+	"github.com/stellar/go-stellar-base/strkey"
+	"github.com/stellar/go-stellar-base/xdr"
+)
+
+// Sign implements /sign endpoint. It adds signatures to a transaction built by /payment
+// endpoint for a source account requiring more signatures than the bridge server can add.
+// The transaction is submitted when signatures meet the source account threshold.
+func (rh *RequestHandler) Sign(w http.ResponseWriter, r *http.Request) {
+	logger := server.Logger(r)
+	request := &bridge.SignRequest{}
+	request.FromRequest(r)
+
+	err := request.Validate()
+	if err != nil {
+		errorResponse := err.(*protocols.ErrorResponse)
+		logger.WithFields(errorResponse.LogData).Error(errorResponse.Error())
+		server.Write(w, errorResponse)
+		return
+	}
+
+	pending, errorResponse := rh.signPendingTransaction(r.Context(), logger, request)
+	if errorResponse != nil {
+		server.Write(w, errorResponse)
+		return
+	}
+
+	if pending.Status == entities.PendingTransactionStatusPending {
+		server.Write(w, pendingTransactionResponse(pending))
+		return
+	}
+
+	rh.submitPendingTransaction(w, r, pending)
+}
+
+// signUpdateAttempts is a number of times /sign request loads a pending transaction again
+// when it has been changed by a concurrent request
+const signUpdateAttempts = 5
+
+// signPendingTransaction adds signatures of request to a pending transaction and saves it.
+// The transaction is saved with `submitted` status when signatures meet the source account
+// threshold, only one of concurrent requests can do it so the transaction is submitted once.
+// Updates are retried when the transaction has been changed by a concurrent request, so
+// signatures added by it are not lost.
+func (rh *RequestHandler) signPendingTransaction(ctx context.Context, logger *log.Entry, request *bridge.SignRequest) (*entities.PendingTransaction, *protocols.ErrorResponse) {
+	for attempt := 0; attempt < signUpdateAttempts; attempt++ {
+		pending, err := rh.Repository.GetPendingTransactionByTransactionID(request.TransactionID)
+		if err != nil {
+			logger.WithFields(log.Fields{"err": err}).Error("Error getting pending transaction")
+			return nil, protocols.InternalServerError
+		}
+
+		if pending == nil {
+			return nil, bridge.SignTransactionNotFound
+		}
+
+		if pending.Status != entities.PendingTransactionStatusPending {
+			return nil, bridge.SignTransactionSubmitted
+		}
+
+		// Envelope can have PRECOND_V2 preconditions not supported by go-stellar-base
+		_, _, hash, err := submitter.ParseRawEnvelope(pending.EnvelopeXdr, rh.Config.NetworkPassphrase)
+		if err != nil {
+			logger.WithFields(log.Fields{"err": err}).Error("Error decoding pending transaction envelope")
+			return nil, protocols.InternalServerError
+		}
+
+		signed, err := submitter.RawEnvelopeSignatures(pending.EnvelopeXdr)
+		if err != nil {
+			logger.WithFields(log.Fields{"err": err}).Error("Error decoding pending transaction signatures")
+			return nil, protocols.InternalServerError
+		}
+
+		signatures, errorResponse := requestSignatures(request, hash, rh.Config.NetworkPassphrase)
+		if errorResponse != nil {
+			return nil, errorResponse
+		}
+
+		// Signers and thresholds could have changed since the transaction was built
+		account, err := rh.Horizon.LoadAccount(ctx, pending.Source)
+		if err != nil {
+			logger.WithFields(log.Fields{"err": err}).Error("Cannot load source account")
+			return nil, bridge.PaymentSourceNotExist
+		}
+
+		for _, signature := range signatures {
+			if accountSigner(account, hash, signature) == "" {
+				return nil, bridge.SignInvalidSignature
+			}
+		}
+
+		signed = mergeSignatures(account, hash, signed, signatures)
+		txeB64, err := submitter.SetRawEnvelopeSignatures(pending.EnvelopeXdr, signed)
+		if err != nil {
+			logger.WithFields(log.Fields{"err": err}).Error("Error encoding transaction envelope")
+			return nil, protocols.InternalServerError
+		}
+
+		pending.EnvelopeXdr = txeB64
+		pending.SignedWeight = signaturesWeight(account, hash, signed)
+		pending.RequiredWeight = paymentThreshold(account)
+		pending.UpdatedAt = time.Now()
+		if pending.SignedWeight >= pending.RequiredWeight {
+			pending.Status = entities.PendingTransactionStatusSubmitted
+		}
+
+		updated, err := rh.Repository.UpdatePendingTransaction(pending)
+		if err != nil {
+			logger.WithFields(log.Fields{"err": err}).Error("Error saving pending transaction")
+			return nil, protocols.InternalServerError
+		}
+		if updated {
+			return pending, nil
+		}
+
+		logger.WithFields(log.Fields{"transaction_id": pending.TransactionID}).Info("Pending transaction changed by another request, signing again")
+	}
+
+	logger.WithFields(log.Fields{"transaction_id": request.TransactionID}).Error("Pending transaction keeps changing, cannot save signatures")
+	return nil, protocols.InternalServerError
+}
+
+// startPendingTransaction saves a payment transaction signed by the bridge server that needs
+// more signatures and writes PendingTransactionResponse. It returns false when the transaction
+// cannot be saved so the payment is not sent.
+func (rh *RequestHandler) startPendingTransaction(
+	w http.ResponseWriter,
+	r *http.Request,
+	request *bridge.PaymentRequest,
+	reserved *entities.SentTransaction,
+	source, transactionID, txeB64 string,
+	signedWeight, requiredWeight int32,
+) bool {
+	logger := server.Logger(r)
+
+	if rh.Repository == nil {
+		logger.WithFields(log.Fields{"signed_weight": signedWeight, "required_weight": requiredWeight}).Print("Source account requires more signatures but bridge server is started without a DB")
+		server.Write(w, bridge.PaymentSignaturesRequired)
+		return false
+	}
+
+	now := time.Now()
+	pending := &entities.PendingTransaction{
+		TransactionID:  transactionID,
+		Status:         entities.PendingTransactionStatusPending,
+		Source:         source,
+		EnvelopeXdr:    txeB64,
+		RequiredWeight: requiredWeight,
+		SignedWeight:   signedWeight,
+		Destination:    request.Destination,
+		Amount:         request.Amount,
+		Asset:          protocols.Asset{Code: request.AssetCode, Issuer: request.AssetIssuer}.String(),
+		CreatedAt:      now,
+		UpdatedAt:      now,
+		Tenant:         rh.Config.Tenant,
+	}
+	if request.Metadata != "" {
+		pending.Metadata = &request.Metadata
+	}
+
+	err := rh.EntityManager.Persist(pending)
+	if err != nil {
+		logger.WithFields(log.Fields{"err": err}).Error("Error saving pending transaction")
+		server.Write(w, protocols.InternalServerError)
+		return false
+	}
+
+	// Requests repeated with the same idempotency key return `idempotency_key_in_progress`
+	// until the transaction is submitted
+	if reserved != nil {
+		reserved.TransactionID = transactionID
+		reserved.EnvelopeXdr = txeB64
+		err = rh.EntityManager.Persist(reserved)
+		if err != nil {
+			logger.WithFields(log.Fields{"err": err}).Error("Error saving idempotency key of pending transaction")
+		}
+	}
+
+	logger.WithFields(log.Fields{"transaction_id": transactionID, "signed_weight": signedWeight, "required_weight": requiredWeight}).Info("Transaction waiting for signatures")
+	server.Write(w, pendingTransactionResponse(pending))
+	return true
+}
+
+// submitPendingTransaction submits a transaction with all required signatures (already saved
+// with `submitted` status) and writes Horizon response like /payment endpoint
+func (rh *RequestHandler) submitPendingTransaction(w http.ResponseWriter, r *http.Request, pending *entities.PendingTransaction) {
+	logger := server.Logger(r)
+
+	submitResponse, err := rh.Horizon.SubmitTransaction(r.Context(), pending.EnvelopeXdr)
+	if err != nil {
+		// Transaction is pending again so it can be submitted by the next /sign request
+		logger.WithFields(log.Fields{"err": err}).Error("Error submitting transaction")
+		pending.Status = entities.PendingTransactionStatusPending
+		pending.UpdatedAt = time.Now()
+		_, updateErr := rh.Repository.UpdatePendingTransaction(pending)
+		if updateErr != nil {
+			logger.WithFields(log.Fields{"err": updateErr}).Error("Error saving pending transaction")
+		}
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	submitResponse.Hash = pending.TransactionID
+	err = rh.saveSubmittedPendingTransaction(pending, submitResponse)
+	if err != nil {
+		logger.WithFields(log.Fields{"err": err, "transaction_id": pending.TransactionID}).Error("Error saving sent transaction")
+	}
+
+	asset, _ := protocols.ParseAsset(pending.Asset)
+	request := &bridge.PaymentRequest{
+		Destination: pending.Destination,
+		Amount:      pending.Amount,
+		AssetCode:   asset.Code,
+		AssetIssuer: asset.Issuer,
+	}
+	if pending.Metadata != nil {
+		request.Metadata = *pending.Metadata
+	}
+
+	errorResponse := bridge.ErrorFromHorizonResponse(submitResponse)
+	if errorResponse != nil {
+		logger.WithFields(errorResponse.LogData).Error(errorResponse.Error())
+		rh.publishPaymentSent(request, pending.TransactionID, errorResponse.Code)
+		rh.notifyPaymentSent(r.Context(), request, pending.TransactionID, submitResponse.Ledger, errorResponse.Code)
+		server.Write(w, errorResponse)
+		return
+	}
+
+	setSendAmount(&submitResponse)
+	rh.publishPaymentSent(request, pending.TransactionID, "")
+	rh.notifyPaymentSent(r.Context(), request, pending.TransactionID, submitResponse.Ledger, "")
+	server.Write(w, &submitResponse)
+}
+
+// saveSubmittedPendingTransaction saves result of a submitted pending transaction. Sent
+// transaction reserved by idempotency key of the /payment request is updated.
+func (rh *RequestHandler) saveSubmittedPendingTransaction(pending *entities.PendingTransaction, submitResponse horizon.SubmitTransactionResponse) error {
+	sentTransaction, err := rh.Repository.GetSentTransactionByTransactionID(pending.TransactionID)
+	if err != nil {
+		return err
+	}
+
+	if sentTransaction == nil {
+		sentTransaction = &entities.SentTransaction{
+			TransactionID: pending.TransactionID,
+			Source:        pending.Source,
+			SubmittedAt:   time.Now(),
+			Tenant:        rh.Config.Tenant,
+		}
+	}
+
+	sentTransaction.EnvelopeXdr = pending.EnvelopeXdr
+	sentTransaction.Metadata = pending.Metadata
+	sentTransaction.Destination = &pending.Destination
+	sentTransaction.Amount = &pending.Amount
+	sentTransaction.Asset = &pending.Asset
+	setSentTransactionResult(sentTransaction, submitResponse)
+	return rh.EntityManager.Persist(sentTransaction)
+}
+
+// requestSignatures returns signatures of /sign request. Envelope must contain a transaction
+// with hash.
+func requestSignatures(request *bridge.SignRequest, hash [32]byte, networkPassphrase string) ([]xdr.DecoratedSignature, *protocols.ErrorResponse) {
+	var signatures []xdr.DecoratedSignature
+
+	if request.EnvelopeXdr != "" {
+		_, _, envelopeHash, err := submitter.ParseRawEnvelope(request.EnvelopeXdr, networkPassphrase)
+		if err != nil {
+			return nil, protocols.NewInvalidParameterError("envelope_xdr", request.EnvelopeXdr)
+		}
+
+		if envelopeHash != hash {
+			return nil, bridge.SignEnvelopeMismatch
+		}
+
+		signatures, err = submitter.RawEnvelopeSignatures(request.EnvelopeXdr)
+		if err != nil {
+			return nil, protocols.NewInvalidParameterError("envelope_xdr", request.EnvelopeXdr)
+		}
+	}
+
+	if request.Signature != "" {
+		// Validated in SignRequest.Validate
+		publicKey, _ := strkey.Decode(strkey.VersionByteAccountID, request.PublicKey)
+		signature, _ := base64.StdEncoding.DecodeString(request.Signature)
+
+		var hint xdr.SignatureHint
+		copy(hint[:], publicKey[len(publicKey)-4:])
+		signatures = append(signatures, xdr.DecoratedSignature{Hint: hint, Signature: xdr.Signature(signature)})
+	}
+
+	return signatures, nil
+}
+
+// accountSigner returns ed25519 signer of account which signed hash with signature or an
+// empty string when the signature is invalid
+func accountSigner(account horizon.AccountResponse, hash [32]byte, signature xdr.DecoratedSignature) string {
+	for _, s := range account.Signers {
+		publicKey, err := strkey.Decode(strkey.VersionByteAccountID, s.ID())
+		if err != nil || !bytes.Equal(publicKey[len(publicKey)-4:], signature.Hint[:]) {
+			continue
+		}
+
+		if ed25519.Verify(ed25519.PublicKey(publicKey), hash[:], signature.Signature) {
+			return s.ID()
+		}
+	}
+	return ""
+}
+
+// signaturesWeight returns total weight of account signers which signed hash. Every signer is
+// counted once and invalid signatures are ignored.
+func signaturesWeight(account horizon.AccountResponse, hash [32]byte, signatures []xdr.DecoratedSignature) int32 {
+	weights := make(map[string]int32, len(account.Signers))
+	for _, s := range account.Signers {
+		weights[s.ID()] = s.Weight
+	}
+
+	var weight int32
+	counted := make(map[string]bool)
+	for _, signature := range signatures {
+		signer := accountSigner(account, hash, signature)
+		if signer == "" || counted[signer] {
+			continue
+		}
+		counted[signer] = true
+		weight += weights[signer]
+	}
+	return weight
+}
+
+// mergeSignatures returns signatures with added signatures of signers which have not
+// signed hash yet
+func mergeSignatures(account horizon.AccountResponse, hash [32]byte, signatures, added []xdr.DecoratedSignature) []xdr.DecoratedSignature {
+	signed := make(map[string]bool)
+	for _, signature := range signatures {
+		signed[accountSigner(account, hash, signature)] = true
+	}
+
+	for _, signature := range added {
+		signer := accountSigner(account, hash, signature)
+		if signed[signer] {
+			continue
+		}
+		signed[signer] = true
+		signatures = append(signatures, signature)
+	}
+	return signatures
+}
+
+// paymentThreshold returns weight of signatures required by payment and create_account
+// operations (medium threshold) of account. At least one signature is always required.
+func paymentThreshold(account horizon.AccountResponse) int32 {
+	if account.Thresholds.MedThreshold == 0 {
+		return 1
+	}
+	return int32(account.Thresholds.MedThreshold)
+}
+
+// pendingTransactionResponse returns PendingTransactionResponse of pending
+func pendingTransactionResponse(pending *entities.PendingTransaction) *bridge.PendingTransactionResponse {
+	return &bridge.PendingTransactionResponse{
+		Status:         bridge.PendingTransactionStatus,
+		TransactionID:  pending.TransactionID,
+		EnvelopeXdr:    pending.EnvelopeXdr,
+		RequiredWeight: pending.RequiredWeight,
+		SignedWeight:   pending.SignedWeight,
+	}
+}
+
+// transactionID returns hex-encoded hash
+func transactionID(hash [32]byte) string {
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/mocks"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stellar/gateway/signer"
+	b "github.com/stellar/go-stellar-base/build"
+	"github.com/stellar/go-stellar-base/keypair"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestHandlerSign(t *testing.T) {
+	source, err := keypair.Random()
+	require.NoError(t, err)
+	cosigner, err := keypair.Random()
+	require.NoError(t, err)
+	other, err := keypair.Random()
+	require.NoError(t, err)
+
+	tx := b.Transaction(
+		b.SourceAccount{source.Address()},
+		b.Sequence{1},
+		b.TestNetwork,
+		b.Payment(b.Destination{other.Address()}, b.NativeAmount{"1"}),
+	)
+	require.NoError(t, tx.Err)
+	hash, err := tx.Hash()
+	require.NoError(t, err)
+
+	txe, err := signer.SignEnvelope(tx, source)
+	require.NoError(t, err)
+	txeB64, err := txe.Base64()
+	require.NoError(t, err)
+
+	account := horizon.AccountResponse{
+		Signers: []horizon.Signer{
+			{Key: source.Address(), Weight: 1},
+			{Key: cosigner.Address(), Weight: 1},
+		},
+		Thresholds: horizon.Thresholds{MedThreshold: 2},
+	}
+
+	assert.Equal(t, int32(1), signaturesWeight(account, hash, txe.E.Signatures))
+	assert.Equal(t, int32(2), paymentThreshold(account))
+	assert.Equal(t, int32(1), paymentThreshold(horizon.AccountResponse{}))
+
+	mockHorizon := new(mocks.MockHorizon)
+	mockRepository := new(mocks.MockRepository)
+	mockEntityManager := new(mocks.MockEntityManager)
+	rh := RequestHandler{
+		Config:        &config.Config{NetworkPassphrase: b.TestNetwork.Passphrase},
+		Horizon:       mockHorizon,
+		Repository:    mockRepository,
+		EntityManager: mockEntityManager,
+	}
+
+	sign := func(values url.Values) (int, []byte) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/sign", strings.NewReader(values.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rh.Sign(w, r)
+		return w.Code, w.Body.Bytes()
+	}
+
+	id := transactionID(hash)
+	pending := &entities.PendingTransaction{
+		TransactionID:  id,
+		Status:         entities.PendingTransactionStatusPending,
+		Source:         source.Address(),
+		EnvelopeXdr:    txeB64,
+		RequiredWeight: 2,
+		SignedWeight:   1,
+		Destination:    other.Address(),
+		Amount:         "1",
+		Asset:          "native",
+	}
+	pending.SetExists()
+
+	// Every request loads a copy of the saved transaction
+	load := func(saved entities.PendingTransaction) *entities.PendingTransaction {
+		loaded := saved
+		mockRepository.On("GetPendingTransactionByTransactionID", id).Return(&loaded, nil).Once()
+		return &loaded
+	}
+
+	unknown := strings.Repeat("0", 64)
+	mockRepository.On("GetPendingTransactionByTransactionID", unknown).Return(nil, nil)
+	mockHorizon.On("LoadAccount", source.Address()).Return(account, nil)
+
+	status, _ := sign(url.Values{"transaction_id": {unknown}, "signature": {"AA=="}})
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	status, _ = sign(url.Values{"transaction_id": {unknown}, "envelope_xdr": {txeB64}})
+	assert.Equal(t, http.StatusNotFound, status)
+
+	// Signature of a key which is not a signer of the source account
+	signature, err := other.Sign(hash[:])
+	require.NoError(t, err)
+	load(*pending)
+	status, body := sign(url.Values{
+		"transaction_id": {id},
+		"public_key":     {other.Address()},
+		"signature":      {base64.StdEncoding.EncodeToString(signature)},
+	})
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, string(body), bridge.SignInvalidSignature.Code)
+
+	cosignerSignature, err := cosigner.Sign(hash[:])
+	require.NoError(t, err)
+	cosign := func() (int, []byte) {
+		return sign(url.Values{
+			"transaction_id": {id},
+			"public_key":     {cosigner.Address()},
+			"signature":      {base64.StdEncoding.EncodeToString(cosignerSignature)},
+		})
+	}
+	submitted := *pending
+	submitted.Status = entities.PendingTransactionStatusSubmitted
+
+	// Transaction submitted by a concurrent request is not submitted again
+	load(*pending)
+	load(submitted)
+	mockRepository.On("UpdatePendingTransaction", mock.AnythingOfType("*entities.PendingTransaction")).Return(false, nil).Once()
+	status, body = cosign()
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, string(body), bridge.SignTransactionSubmitted.Code)
+	mockHorizon.AssertNotCalled(t, "SubmitTransaction", mock.Anything)
+
+	// Signature that meets the threshold submits the transaction, the update is retried when
+	// the transaction has been changed by a concurrent request
+	load(*pending)
+	updated := load(*pending)
+	mockRepository.On("UpdatePendingTransaction", mock.AnythingOfType("*entities.PendingTransaction")).Return(false, nil).Once()
+	mockRepository.On("UpdatePendingTransaction", updated).Return(true, nil).Once()
+	ledger := uint64(10)
+	mockHorizon.On("SubmitTransaction", mock.AnythingOfType("string")).
+		Return(horizon.SubmitTransactionResponse{Ledger: &ledger}, nil).Once()
+	mockRepository.On("GetSentTransactionByTransactionID", id).Return(nil, nil)
+	mockEntityManager.On("Persist", mock.Anything).Return(nil)
+
+	status, body = cosign()
+	assert.Equal(t, http.StatusOK, status)
+	var response horizon.SubmitTransactionResponse
+	require.NoError(t, json.Unmarshal(body, &response))
+	assert.Equal(t, id, response.Hash)
+	assert.Equal(t, entities.PendingTransactionStatusSubmitted, updated.Status)
+	assert.Equal(t, int32(2), updated.SignedWeight)
+	mockHorizon.AssertExpectations(t)
+	mockRepository.AssertExpectations(t)
+
+	load(submitted)
+	status, body = sign(url.Values{"transaction_id": {id}, "envelope_xdr": {txeB64}})
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, string(body), bridge.SignTransactionSubmitted.Code)
+}
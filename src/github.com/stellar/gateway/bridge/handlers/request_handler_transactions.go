@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"net/http"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/protocols"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stellar/gateway/server"
+	"github.com/zenazn/goji/web"
+)
+
+// Transaction implements GET /transactions/:hash endpoint. It returns a status of a transaction
+// sent using /payment endpoint or built using /builder endpoint. Pending transactions are
+// checked in Horizon and updated before returning.
+func (rh *RequestHandler) Transaction(c web.C, w http.ResponseWriter, r *http.Request) {
+	transaction, err := rh.Repository.GetSentTransactionByTransactionID(c.URLParams["hash"])
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error getting sent transaction")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	if transaction == nil {
+		server.Write(w, bridge.TransactionNotFound)
+		return
+	}
+
+	if transaction.IsPending() {
+		response, err := rh.Horizon.LoadTransaction(r.Context(), transaction.TransactionID)
+		switch err := err.(type) {
+		case nil:
+			if response.Successful == nil || *response.Successful {
+				transaction.MarkSucceeded(response.Ledger)
+			} else {
+				transaction.MarkFailed(response.ResultXdr)
+			}
+
+			err = rh.EntityManager.Persist(transaction)
+			if err != nil {
+				log.WithFields(log.Fields{"err": err}).Error("Error updating sent transaction")
+				server.Write(w, protocols.InternalServerError)
+				return
+			}
+		case *horizon.StatusError:
+			// Transaction has not been included in a ledger (yet)
+			if err.StatusCode != http.StatusNotFound {
+				log.WithFields(log.Fields{"err": err}).Error("Error loading transaction from horizon")
+			}
+		default:
+			log.WithFields(log.Fields{"err": err}).Error("Error loading transaction from horizon")
+		}
+	}
+
+	server.Write(w, &bridge.TransactionResponse{Transaction: bridge.NewSentTransaction(transaction)})
+}
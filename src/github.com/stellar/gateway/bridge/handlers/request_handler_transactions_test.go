@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/mocks"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/zenazn/goji/web"
+)
+
+func TestRequestHandlerTransaction(t *testing.T) {
+	mockHorizon := new(mocks.MockHorizon)
+	mockRepository := new(mocks.MockRepository)
+	mockEntityManager := new(mocks.MockEntityManager)
+	rh := RequestHandler{
+		Config:        &config.Config{},
+		Horizon:       mockHorizon,
+		Repository:    mockRepository,
+		EntityManager: mockEntityManager,
+	}
+
+	get := func(hash string) (int, bridge.TransactionResponse) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/transactions/"+hash, nil)
+		rh.Transaction(web.C{URLParams: map[string]string{"hash": hash}}, w, r)
+
+		var response bridge.TransactionResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		return w.Code, response
+	}
+
+	newTransaction := func(hash string, status entities.SentTransactionStatus) *entities.SentTransaction {
+		transaction := &entities.SentTransaction{
+			TransactionID: hash,
+			Status:        status,
+			Source:        "GBWJES3WOKK7PRLJKZVGIPVFGQSSGCRMY7H3GCZ7BEG6ZTDB4FZXTPJ5",
+			SubmittedAt:   time.Now(),
+		}
+		transaction.SetExists()
+		return transaction
+	}
+
+	mockRepository.On("GetSentTransactionByTransactionID", "unknown").Return(nil, nil)
+	status, _ := get("unknown")
+	assert.Equal(t, http.StatusNotFound, status)
+
+	// Pending transaction not in a ledger yet
+	mockRepository.On("GetSentTransactionByTransactionID", "pending").
+		Return(newTransaction("pending", entities.SentTransactionStatusTimeout), nil)
+	mockHorizon.On("LoadTransaction", "pending").
+		Return(horizon.TransactionResponse{}, &horizon.StatusError{StatusCode: http.StatusNotFound})
+	status, response := get("pending")
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "timeout", response.Transaction.Status)
+
+	// Pending transaction included in a ledger
+	successful := true
+	mockRepository.On("GetSentTransactionByTransactionID", "included").
+		Return(newTransaction("included", entities.SentTransactionStatusSending), nil)
+	mockHorizon.On("LoadTransaction", "included").
+		Return(horizon.TransactionResponse{Hash: "included", Ledger: 123, Successful: &successful}, nil)
+	mockEntityManager.On("Persist", mock.AnythingOfType("*entities.SentTransaction")).Return(nil).Once()
+	status, response = get("included")
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "success", response.Transaction.Status)
+	require.NotNil(t, response.Transaction.Ledger)
+	assert.Equal(t, uint64(123), *response.Transaction.Ledger)
+
+	// Final status is not checked in horizon
+	mockRepository.On("GetSentTransactionByTransactionID", "failed").
+		Return(newTransaction("failed", entities.SentTransactionStatusFailure), nil)
+	status, response = get("failed")
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "failure", response.Transaction.Status)
+
+	mockHorizon.AssertExpectations(t)
+	mockEntityManager.AssertExpectations(t)
+}
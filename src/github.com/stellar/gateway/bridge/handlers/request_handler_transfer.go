@@ -0,0 +1,333 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/listener"
+	"github.com/stellar/gateway/protocols"
+	"github.com/stellar/gateway/protocols/amount"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stellar/gateway/server"
+)
+
+// TransferInfo implements GET /sep6/info endpoint
+func (rh *RequestHandler) TransferInfo(w http.ResponseWriter, r *http.Request) {
+	// Transfer servers are queried by wallets from browsers
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	response := bridge.TransferInfoResponse{
+		Deposit:     map[string]bridge.TransferAssetInfo{},
+		Withdraw:    map[string]bridge.TransferAssetInfo{},
+		Transaction: bridge.TransferFeatureInfo{Enabled: true, AuthenticationRequired: true},
+	}
+
+	for _, asset := range rh.Config.TransferServer.Assets {
+		info := bridge.TransferAssetInfo{
+			Enabled:                true,
+			AuthenticationRequired: true,
+			FeeFixed:               asset.FeeFixed,
+			FeePercent:             asset.FeePercent,
+			MinAmount:              asset.MinAmount,
+			MaxAmount:              asset.MaxAmount,
+		}
+
+		if asset.Deposit {
+			deposit := info
+			if len(asset.DepositTypes) > 0 {
+				deposit.Fields = map[string]bridge.TransferField{
+					"type": {Description: "Type of deposit", Choices: asset.DepositTypes},
+				}
+			}
+			response.Deposit[asset.Asset.Code] = deposit
+		}
+
+		if asset.Withdraw {
+			withdraw := info
+			withdraw.Types = map[string]bridge.TransferWithdrawType{}
+			for _, withdrawType := range asset.WithdrawTypes {
+				withdraw.Types[withdrawType] = bridge.TransferWithdrawType{Fields: map[string]bridge.TransferField{}}
+			}
+			response.Withdraw[asset.Asset.Code] = withdraw
+		}
+	}
+
+	server.Write(w, &response)
+}
+
+// TransferDeposit implements GET /sep6/deposit endpoint. It creates a deposit and returns
+// instructions for the user returned by `callbacks.deposit`. The deposit is completed by
+// /payment request with `transfer_id` param.
+func (rh *RequestHandler) TransferDeposit(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	transaction, asset, errorResponse := rh.newTransferTransaction(r, r.URL.Query(), entities.TransferKindDeposit)
+	if errorResponse != nil {
+		server.Write(w, errorResponse)
+		return
+	}
+
+	deposit := url.Values{
+		"id":           {transaction.PublicID},
+		"account":      {transaction.Account},
+		"asset_code":   {transaction.AssetCode},
+		"asset_issuer": {transaction.AssetIssuer},
+		"type":         {transaction.Type},
+		"fields":       {transaction.Fields},
+	}
+	if transaction.AmountIn != nil {
+		deposit.Set("amount", *transaction.AmountIn)
+	}
+	if transaction.MemoType != nil {
+		deposit.Set("memo_type", *transaction.MemoType)
+		deposit.Set("memo", *transaction.Memo)
+	}
+
+	instructions, err := listener.RequestDepositInstructions(r.Context(), rh.Client, rh.Config, deposit)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err, "id": transaction.PublicID}).Error("Error sending deposit to deposit callback")
+		server.Write(w, bridge.DepositCallbackFailed)
+		return
+	}
+
+	err = rh.EntityManager.Persist(transaction)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error persisting deposit")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	log.WithFields(log.Fields{"id": transaction.PublicID, "account": transaction.Account}).Info("Deposit created")
+	rh.notifyTransferStatus(r.Context(), transaction)
+
+	server.Write(w, &bridge.TransferDepositResponse{
+		ID:         transaction.PublicID,
+		How:        instructions.How,
+		ETA:        instructions.ETA,
+		FeeFixed:   asset.FeeFixed,
+		FeePercent: asset.FeePercent,
+		MinAmount:  asset.MinAmount,
+		MaxAmount:  asset.MaxAmount,
+		ExtraInfo:  instructions.ExtraInfo,
+	})
+}
+
+// TransferWithdraw implements GET /sep6/withdraw endpoint. It creates a withdrawal and returns
+// a memo the user must use in a payment to the receiving account. The payment is sent to the
+// receive callback with withdrawal details.
+func (rh *RequestHandler) TransferWithdraw(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	transaction, asset, errorResponse := rh.newTransferTransaction(r, r.URL.Query(), entities.TransferKindWithdrawal)
+	if errorResponse != nil {
+		server.Write(w, errorResponse)
+		return
+	}
+
+	err := setWithdrawalMemo(transaction)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error generating memo")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	err = rh.EntityManager.Persist(transaction)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error persisting withdrawal")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	log.WithFields(log.Fields{"id": transaction.PublicID, "account": transaction.Account}).Info("Withdrawal created")
+	rh.notifyTransferStatus(r.Context(), transaction)
+
+	server.Write(w, &bridge.TransferWithdrawResponse{
+		ID:         transaction.PublicID,
+		AccountID:  rh.Config.Accounts.ReceivingAccountID,
+		MemoType:   *transaction.MemoType,
+		Memo:       *transaction.Memo,
+		FeeFixed:   asset.FeeFixed,
+		FeePercent: asset.FeePercent,
+		MinAmount:  asset.MinAmount,
+		MaxAmount:  asset.MaxAmount,
+	})
+}
+
+// TransferTransaction implements GET /sep6/transaction and GET /sep24/transaction endpoints.
+// Transactions can be fetched only by the account that created them.
+func (rh *RequestHandler) TransferTransaction(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	account, ok := rh.webAuthAccount(r)
+	if !ok {
+		server.Write(w, bridge.TransferUnauthorized)
+		return
+	}
+
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		server.Write(w, protocols.NewMissingParameter("id"))
+		return
+	}
+
+	transaction, err := rh.Repository.GetTransferTransactionByPublicID(id)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error getting transfer transaction")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	if transaction == nil || transaction.Account != account {
+		server.Write(w, bridge.TransferTransactionNotFound)
+		return
+	}
+
+	server.Write(w, &bridge.TransferTransactionResponse{
+		Transaction: bridge.NewTransferTransaction(transaction, rh.Config.Accounts.ReceivingAccountID),
+	})
+}
+
+// newTransferTransaction validates deposit or withdrawal request params and returns a new
+// (not persisted) transaction and the asset it transfers
+func (rh *RequestHandler) newTransferTransaction(r *http.Request, values url.Values, kind string) (*entities.TransferTransaction, *config.TransferAsset, *protocols.ErrorResponse) {
+	account, ok := rh.webAuthAccount(r)
+	if !ok {
+		return nil, nil, bridge.TransferUnauthorized
+	}
+
+	var request bridge.TransferRequest
+	request.FromValues(values)
+	err := request.Validate()
+	if err != nil {
+		errorResponse := err.(*protocols.ErrorResponse)
+		log.WithFields(errorResponse.LogData).Error(errorResponse.Error())
+		return nil, nil, errorResponse
+	}
+
+	if request.Account != "" && request.Account != account {
+		return nil, nil, protocols.NewInvalidParameterError("account", request.Account)
+	}
+
+	withdraw := kind == entities.TransferKindWithdrawal
+	asset := rh.Config.TransferServer.AssetFor(request.AssetCode)
+	if asset == nil || (withdraw && !asset.Withdraw) || (!withdraw && !asset.Deposit) {
+		return nil, nil, bridge.TransferAssetNotSupported
+	}
+
+	if !asset.AllowsType(request.Type, withdraw) {
+		return nil, nil, protocols.NewInvalidParameterError("type", request.Type)
+	}
+
+	id, err := newUUID()
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error generating transaction ID")
+		return nil, nil, protocols.InternalServerError
+	}
+
+	fields, err := json.Marshal(request.Fields)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error marshaling transaction fields")
+		return nil, nil, protocols.InternalServerError
+	}
+
+	transaction := &entities.TransferTransaction{
+		PublicID:    id,
+		Kind:        kind,
+		Status:      entities.TransferStatusPendingUserTransferStart,
+		AssetCode:   asset.Asset.Code,
+		AssetIssuer: asset.Asset.Issuer,
+		Account:     account,
+		Type:        request.Type,
+		Fields:      string(fields),
+		StartedAt:   time.Now(),
+		Tenant:      rh.Config.Tenant,
+	}
+
+	if request.Amount != "" {
+		// Amount is checked in Validate
+		amountIn, _ := amount.Parse(request.Amount)
+		fee, ok := transferFee(asset, amountIn)
+		if !ok {
+			return nil, nil, bridge.TransferInvalidAmount
+		}
+		setTransferAmounts(transaction, amountIn, fee)
+	}
+
+	if !withdraw && request.MemoType != "" {
+		transaction.MemoType = &request.MemoType
+		transaction.Memo = &request.Memo
+	}
+
+	return transaction, asset, nil
+}
+
+// setWithdrawalMemo sets a random hash memo the user must send withdrawal payment with
+func setWithdrawalMemo(transaction *entities.TransferTransaction) error {
+	memo := make([]byte, 32)
+	_, err := rand.Read(memo)
+	if err != nil {
+		return err
+	}
+	memoType, memoValue := "hash", base64.StdEncoding.EncodeToString(memo)
+	transaction.MemoType = &memoType
+	transaction.Memo = &memoValue
+	return nil
+}
+
+// transferFee returns a fee of amountIn of the asset. ok is false when amountIn is not within
+// the asset limits or it does not cover the fee.
+func transferFee(asset *config.TransferAsset, amountIn amount.Amount) (fee amount.Amount, ok bool) {
+	// Limits are checked in config validation
+	minAmount, _ := amount.Parse(asset.MinAmount)
+	maxAmount, _ := amount.Parse(asset.MaxAmount)
+	fee = asset.Fee(amountIn)
+	ok = (asset.MinAmount == "" || amountIn >= minAmount) &&
+		(asset.MaxAmount == "" || amountIn <= maxAmount) &&
+		fee < amountIn
+	return
+}
+
+// setTransferAmounts sets amounts of a transfer transaction
+func setTransferAmounts(transaction *entities.TransferTransaction, amountIn, fee amount.Amount) {
+	in, out, feeValue := amountIn.String(), (amountIn - fee).String(), fee.String()
+	transaction.AmountIn = &in
+	transaction.AmountFee = &feeValue
+	transaction.AmountOut = &out
+}
+
+// completeDeposit sets status of a deposit which payment has been sent by /payment to completed.
+// Payment has already been sent so errors are only logged.
+func (rh *RequestHandler) completeDeposit(ctx context.Context, logger *log.Entry, deposit *entities.TransferTransaction, amountOut, transactionID string) {
+	now := time.Now()
+	deposit.Status = entities.TransferStatusCompleted
+	deposit.StellarTransactionID = &transactionID
+	deposit.CompletedAt = &now
+	if deposit.AmountOut == nil {
+		deposit.AmountOut = &amountOut
+	}
+
+	err := rh.EntityManager.Persist(deposit)
+	if err != nil {
+		logger.WithFields(log.Fields{"err": err, "transfer_id": deposit.PublicID}).Error("Error completing deposit")
+		return
+	}
+	rh.notifyTransferStatus(ctx, deposit)
+}
+
+// notifyTransferStatus sends a new status of a persisted transaction to
+// `callbacks.transfer_status`. Errors are only logged. The status is already persisted so
+// the callback is sent also when the client disconnected.
+func (rh *RequestHandler) notifyTransferStatus(ctx context.Context, transaction *entities.TransferTransaction) {
+	err := listener.NotifyTransferStatus(context.WithoutCancel(ctx), rh.Client, rh.Config, transaction)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err, "id": transaction.PublicID}).Error("Error sending status to transfer status callback")
+	}
+}
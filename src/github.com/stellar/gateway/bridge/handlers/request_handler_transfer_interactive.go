@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/protocols"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stellar/gateway/protocols/webauth"
+	"github.com/stellar/gateway/server"
+)
+
+// interactiveTokenTTL is a lifetime of tokens added to interactive URLs
+const interactiveTokenTTL = 5 * time.Minute
+
+// InteractiveInfo implements GET /sep24/info endpoint
+func (rh *RequestHandler) InteractiveInfo(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	response := bridge.InteractiveInfoResponse{
+		Deposit:  map[string]bridge.TransferAssetInfo{},
+		Withdraw: map[string]bridge.TransferAssetInfo{},
+	}
+
+	for _, asset := range rh.Config.TransferServer.Assets {
+		info := bridge.TransferAssetInfo{
+			Enabled:                true,
+			AuthenticationRequired: true,
+			FeeFixed:               asset.FeeFixed,
+			FeePercent:             asset.FeePercent,
+			MinAmount:              asset.MinAmount,
+			MaxAmount:              asset.MaxAmount,
+		}
+		if asset.Deposit {
+			response.Deposit[asset.Asset.Code] = info
+		}
+		if asset.Withdraw {
+			response.Withdraw[asset.Asset.Code] = info
+		}
+	}
+
+	server.Write(w, &response)
+}
+
+// InteractiveDeposit implements POST /sep24/transactions/deposit/interactive endpoint
+func (rh *RequestHandler) InteractiveDeposit(w http.ResponseWriter, r *http.Request) {
+	rh.interactive(w, r, entities.TransferKindDeposit)
+}
+
+// InteractiveWithdraw implements POST /sep24/transactions/withdraw/interactive endpoint
+func (rh *RequestHandler) InteractiveWithdraw(w http.ResponseWriter, r *http.Request) {
+	rh.interactive(w, r, entities.TransferKindWithdrawal)
+}
+
+// interactive creates an incomplete transaction and returns a URL of `interactive_url` web app
+// collecting the rest of user info. The app moves the transaction forward using Admin API
+// (PUT /admin/transfers/:id), then it's processed like SEP-6 transactions.
+func (rh *RequestHandler) interactive(w http.ResponseWriter, r *http.Request, kind string) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	values, err := interactiveRequestValues(r)
+	if err != nil {
+		server.Write(w, protocols.InvalidParameterError)
+		return
+	}
+
+	transaction, _, errorResponse := rh.newTransferTransaction(r, values, kind)
+	if errorResponse != nil {
+		server.Write(w, errorResponse)
+		return
+	}
+	transaction.Status = entities.TransferStatusIncomplete
+
+	if kind == entities.TransferKindWithdrawal {
+		err = setWithdrawalMemo(transaction)
+		if err != nil {
+			log.WithFields(log.Fields{"err": err}).Error("Error generating memo")
+			server.Write(w, protocols.InternalServerError)
+			return
+		}
+	}
+
+	interactiveURL, err := rh.interactiveURL(transaction, values.Get("lang"))
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error creating interactive URL")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	err = rh.EntityManager.Persist(transaction)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error persisting interactive transaction")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	log.WithFields(log.Fields{"id": transaction.PublicID, "kind": kind, "account": transaction.Account}).Info("Interactive transaction created")
+	rh.notifyTransferStatus(r.Context(), transaction)
+
+	server.Write(w, &bridge.InteractiveResponse{
+		Type: bridge.InteractiveCustomerInfoNeeded,
+		URL:  interactiveURL,
+		ID:   transaction.PublicID,
+	})
+}
+
+// interactiveURL returns `interactive_url` with transaction params and a short-lived token of
+// the user (`jti` claim is the transaction ID) the web app can verify using `jwt_key`
+func (rh *RequestHandler) interactiveURL(transaction *entities.TransferTransaction, lang string) (string, error) {
+	key, err := rh.Config.WebAuth.Key()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	token, err := webauth.NewToken(webauth.Claims{
+		Issuer:    rh.Config.WebAuth.HomeDomain,
+		Subject:   transaction.Account,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(interactiveTokenTTL).Unix(),
+		ID:        transaction.PublicID,
+	}, key)
+	if err != nil {
+		return "", err
+	}
+
+	// interactive_url is checked in config validation
+	u, _ := url.Parse(rh.Config.TransferServer.InteractiveURL)
+	query := u.Query()
+	query.Set("transaction_id", transaction.PublicID)
+	query.Set("kind", transaction.Kind)
+	query.Set("asset_code", transaction.AssetCode)
+	query.Set("token", token)
+	if lang != "" {
+		query.Set("lang", lang)
+	}
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// interactiveRequestValues returns params of SEP-24 request sent as a form (multipart or
+// url-encoded) or a JSON object
+func interactiveRequestValues(r *http.Request) (url.Values, error) {
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		var params map[string]string
+		err := json.NewDecoder(r.Body).Decode(&params)
+		if err != nil {
+			return nil, err
+		}
+
+		values := url.Values{}
+		for name, value := range params {
+			values.Set(name, value)
+		}
+		return values, nil
+	}
+
+	err := r.ParseMultipartForm(1 << 20)
+	if err == http.ErrNotMultipart {
+		err = r.ParseForm()
+	}
+	return r.PostForm, err
+}
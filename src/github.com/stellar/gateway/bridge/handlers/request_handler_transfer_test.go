@@ -0,0 +1,278 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/mocks"
+	"github.com/stellar/gateway/protocols"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stellar/gateway/protocols/webauth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/zenazn/goji/web"
+)
+
+func TestRequestHandlerTransfer(t *testing.T) {
+	issuer := "GDSIKW43UA6JTOA47WVEBCZ4MYC74M3GNKNXTVDXFHXYYTNO5GGVN632"
+	account := "GBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFZYYQ"
+	receivingAccount := "GATKP6ZQM5CSLECPMTAC5226PE367QALCPM6AFHTSULPPZMT62OOPMQB"
+
+	c := &config.Config{}
+	c.Accounts.ReceivingAccountID = receivingAccount
+	c.Callbacks.Deposit = "http://deposit"
+	c.WebAuth.JWTKey = "SBKKWO3ZVDDEHDJILGHPHCJCFD2GNUAYIUDMRAS326HLUEQ7ZFXWIGQK"
+	c.TransferServer.Assets = []config.TransferAsset{{
+		Asset:         config.Asset{Code: "USD", Issuer: issuer},
+		Deposit:       true,
+		Withdraw:      true,
+		WithdrawTypes: []string{"bank_account"},
+		FeeFixed:      "1",
+		MinAmount:     "10",
+	}}
+	mockEntityManager := new(mocks.MockEntityManager)
+	mockRepository := new(mocks.MockRepository)
+	mockHTTPClient := new(mocks.MockHTTPClient)
+	rh := RequestHandler{Config: c, EntityManager: mockEntityManager, Repository: mockRepository, Client: mockHTTPClient}
+
+	key, err := c.WebAuth.Key()
+	require.NoError(t, err)
+	token, err := webauth.NewToken(webauth.Claims{Subject: account, ExpiresAt: time.Now().Add(time.Hour).Unix()}, key)
+	require.NoError(t, err)
+
+	get := func(handler http.HandlerFunc, path string, authorized bool) (int, []byte) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", path, nil)
+		if authorized {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		handler(w, r)
+		return w.Code, w.Body.Bytes()
+	}
+
+	// Info
+	code, body := get(rh.TransferInfo, "/sep6/info", false)
+	require.Equal(t, http.StatusOK, code)
+	var info bridge.TransferInfoResponse
+	require.NoError(t, json.Unmarshal(body, &info))
+	assert.True(t, info.Deposit["USD"].Enabled)
+	assert.Equal(t, "10", info.Withdraw["USD"].MinAmount)
+	assert.Contains(t, info.Withdraw["USD"].Types, "bank_account")
+
+	// Invalid requests
+	code, _ = get(rh.TransferDeposit, "/sep6/deposit?asset_code=USD", false)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	code, body = get(rh.TransferDeposit, "/sep6/deposit?asset_code=EUR", true)
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Contains(t, string(body), bridge.TransferAssetNotSupported.Code)
+	_, body = get(rh.TransferDeposit, "/sep6/deposit?asset_code=USD&amount=5", true)
+	assert.Contains(t, string(body), bridge.TransferInvalidAmount.Code)
+	_, body = get(rh.TransferWithdraw, "/sep6/withdraw?asset_code=USD&type=cash", true)
+	assert.Contains(t, string(body), protocols.InvalidParameterError.Code)
+	_, body = get(rh.TransferDeposit, "/sep6/deposit?asset_code=USD&account="+receivingAccount, true)
+	assert.Contains(t, string(body), protocols.InvalidParameterError.Code)
+
+	// Deposit
+	mockHTTPClient.On("Do", mock.MatchedBy(func(r *http.Request) bool {
+		r.ParseForm()
+		return r.URL.String() == "http://deposit" &&
+			r.PostForm.Get("account") == account &&
+			r.PostForm.Get("amount") == "100.0000000" &&
+			r.PostForm.Get("memo") == "42"
+	})).Return(&http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader(`{"how": "IBAN DE89 3704 0044 0532 0130 00", "extra_info": {"message": "Use reference 42"}}`))}, nil).Once()
+	var deposit *entities.TransferTransaction
+	mockEntityManager.On("Persist", mock.AnythingOfType("*entities.TransferTransaction")).Run(func(args mock.Arguments) {
+		deposit = args.Get(0).(*entities.TransferTransaction)
+	}).Return(nil).Once()
+
+	code, body = get(rh.TransferDeposit, "/sep6/deposit?asset_code=USD&amount=100&memo_type=id&memo=42&email_address=a@example.com", true)
+	require.Equal(t, http.StatusOK, code)
+	var depositResponse bridge.TransferDepositResponse
+	require.NoError(t, json.Unmarshal(body, &depositResponse))
+	assert.Equal(t, "IBAN DE89 3704 0044 0532 0130 00", depositResponse.How)
+	assert.JSONEq(t, `{"message": "Use reference 42"}`, string(*depositResponse.ExtraInfo))
+	require.NotNil(t, deposit)
+	assert.Equal(t, depositResponse.ID, deposit.PublicID)
+	assert.Equal(t, entities.TransferKindDeposit, deposit.Kind)
+	assert.Equal(t, "99.0000000", *deposit.AmountOut)
+	assert.Equal(t, "42", *deposit.Memo)
+	assert.JSONEq(t, `{"email_address": "a@example.com"}`, deposit.Fields)
+
+	// Withdrawal
+	var withdrawal *entities.TransferTransaction
+	mockEntityManager.On("Persist", mock.AnythingOfType("*entities.TransferTransaction")).Run(func(args mock.Arguments) {
+		withdrawal = args.Get(0).(*entities.TransferTransaction)
+	}).Return(nil).Once()
+
+	code, body = get(rh.TransferWithdraw, "/sep6/withdraw?asset_code=USD&type=bank_account&dest=DE89370400440532013000", true)
+	require.Equal(t, http.StatusOK, code)
+	var withdrawResponse bridge.TransferWithdrawResponse
+	require.NoError(t, json.Unmarshal(body, &withdrawResponse))
+	assert.Equal(t, receivingAccount, withdrawResponse.AccountID)
+	assert.Equal(t, "hash", withdrawResponse.MemoType)
+	require.NotNil(t, withdrawal)
+	assert.Equal(t, withdrawResponse.Memo, *withdrawal.Memo)
+	assert.Nil(t, withdrawal.AmountIn)
+	assert.JSONEq(t, `{"dest": "DE89370400440532013000"}`, withdrawal.Fields)
+
+	// Transaction
+	mockRepository.On("GetTransferTransactionByPublicID", withdrawal.PublicID).Return(withdrawal, nil)
+	code, body = get(rh.TransferTransaction, "/sep6/transaction?id="+withdrawal.PublicID, true)
+	require.Equal(t, http.StatusOK, code)
+	var transactionResponse bridge.TransferTransactionResponse
+	require.NoError(t, json.Unmarshal(body, &transactionResponse))
+	assert.Equal(t, entities.TransferStatusPendingUserTransferStart, transactionResponse.Transaction.Status)
+	assert.Equal(t, receivingAccount, transactionResponse.Transaction.WithdrawAnchorAccount)
+	assert.Equal(t, account, transactionResponse.Transaction.From)
+
+	// Transactions of other accounts are not found
+	withdrawal.Account = receivingAccount
+	code, _ = get(rh.TransferTransaction, "/sep6/transaction?id="+withdrawal.PublicID, true)
+	assert.Equal(t, http.StatusNotFound, code)
+
+	mockEntityManager.AssertExpectations(t)
+	mockHTTPClient.AssertExpectations(t)
+}
+
+func TestRequestHandlerInteractiveTransfer(t *testing.T) {
+	issuer := "GDSIKW43UA6JTOA47WVEBCZ4MYC74M3GNKNXTVDXFHXYYTNO5GGVN632"
+	account := "GBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFZYYQ"
+
+	c := &config.Config{}
+	c.Accounts.ReceivingAccountID = "GATKP6ZQM5CSLECPMTAC5226PE367QALCPM6AFHTSULPPZMT62OOPMQB"
+	c.Callbacks.TransferStatus = "http://status"
+	c.WebAuth.JWTKey = "SBKKWO3ZVDDEHDJILGHPHCJCFD2GNUAYIUDMRAS326HLUEQ7ZFXWIGQK"
+	c.TransferServer.InteractiveURL = "https://anchor.example.com/interactive?theme=dark"
+	c.TransferServer.Assets = []config.TransferAsset{{
+		Asset:     config.Asset{Code: "USD", Issuer: issuer},
+		Deposit:   true,
+		Withdraw:  true,
+		FeeFixed:  "1",
+		MinAmount: "10",
+	}}
+	mockEntityManager := new(mocks.MockEntityManager)
+	mockRepository := new(mocks.MockRepository)
+	mockHTTPClient := new(mocks.MockHTTPClient)
+	rh := RequestHandler{Config: c, EntityManager: mockEntityManager, Repository: mockRepository, Client: mockHTTPClient}
+
+	key, err := c.WebAuth.Key()
+	require.NoError(t, err)
+	token, err := webauth.NewToken(webauth.Claims{Subject: account, ExpiresAt: time.Now().Add(time.Hour).Unix()}, key)
+	require.NoError(t, err)
+
+	post := func(handler http.HandlerFunc, contentType, body string) (int, []byte) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/sep24/transactions/deposit/interactive", strings.NewReader(body))
+		r.Header.Set("Content-Type", contentType)
+		r.Header.Set("Authorization", "Bearer "+token)
+		handler(w, r)
+		return w.Code, w.Body.Bytes()
+	}
+
+	var statuses []string
+	mockHTTPClient.On("Do", mock.MatchedBy(func(r *http.Request) bool {
+		return r.URL.String() == "http://status"
+	})).Run(func(args mock.Arguments) {
+		r := args.Get(0).(*http.Request)
+		r.ParseForm()
+		statuses = append(statuses, r.PostForm.Get("kind")+":"+r.PostForm.Get("status"))
+	}).Return(&http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader(""))}, nil)
+
+	// Info
+	w := httptest.NewRecorder()
+	rh.InteractiveInfo(w, httptest.NewRequest("GET", "/sep24/info", nil))
+	var info bridge.InteractiveInfoResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &info))
+	assert.Equal(t, "1", info.Deposit["USD"].FeeFixed)
+	assert.True(t, info.Withdraw["USD"].Enabled)
+
+	// Deposit
+	var deposit *entities.TransferTransaction
+	mockEntityManager.On("Persist", mock.AnythingOfType("*entities.TransferTransaction")).Run(func(args mock.Arguments) {
+		deposit = args.Get(0).(*entities.TransferTransaction)
+	}).Return(nil).Once()
+
+	code, body := post(rh.InteractiveDeposit, "application/json", `{"asset_code": "USD", "amount": "100", "lang": "de"}`)
+	require.Equal(t, http.StatusOK, code)
+	var response bridge.InteractiveResponse
+	require.NoError(t, json.Unmarshal(body, &response))
+	assert.Equal(t, bridge.InteractiveCustomerInfoNeeded, response.Type)
+	require.NotNil(t, deposit)
+	assert.Equal(t, deposit.PublicID, response.ID)
+	assert.Equal(t, entities.TransferStatusIncomplete, deposit.Status)
+	assert.Equal(t, "99.0000000", *deposit.AmountOut)
+	assert.JSONEq(t, `{}`, deposit.Fields)
+
+	interactiveURL, err := url.Parse(response.URL)
+	require.NoError(t, err)
+	assert.Equal(t, "anchor.example.com", interactiveURL.Host)
+	assert.Equal(t, "dark", interactiveURL.Query().Get("theme"))
+	assert.Equal(t, deposit.PublicID, interactiveURL.Query().Get("transaction_id"))
+	assert.Equal(t, "de", interactiveURL.Query().Get("lang"))
+	claims, err := webauth.ParseToken(interactiveURL.Query().Get("token"), key, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, account, claims.Subject)
+	assert.Equal(t, deposit.PublicID, claims.ID)
+
+	// Withdrawal
+	var withdrawal *entities.TransferTransaction
+	mockEntityManager.On("Persist", mock.AnythingOfType("*entities.TransferTransaction")).Run(func(args mock.Arguments) {
+		withdrawal = args.Get(0).(*entities.TransferTransaction)
+	}).Return(nil).Once()
+
+	code, _ = post(rh.InteractiveWithdraw, "application/x-www-form-urlencoded", "asset_code=USD")
+	require.Equal(t, http.StatusOK, code)
+	require.NotNil(t, withdrawal)
+	assert.Equal(t, entities.TransferKindWithdrawal, withdrawal.Kind)
+	assert.Equal(t, "hash", *withdrawal.MemoType)
+	assert.Nil(t, withdrawal.AmountIn)
+
+	code, body = post(rh.InteractiveWithdraw, "application/x-www-form-urlencoded", "asset_code=EUR")
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Contains(t, string(body), bridge.TransferAssetNotSupported.Code)
+
+	// Admin API moves the withdrawal forward once the user provides info
+	update := func(id, form string) (int, []byte) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/admin/transfers/"+id, strings.NewReader(form))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rh.AdminUpdateTransfer(web.C{URLParams: map[string]string{"id": id}}, w, r)
+		return w.Code, w.Body.Bytes()
+	}
+
+	mockRepository.On("GetTransferTransactionByPublicID", withdrawal.PublicID).Return(withdrawal, nil)
+	mockRepository.On("GetTransferTransactionByPublicID", "missing").Return(nil, nil)
+	mockEntityManager.On("Persist", withdrawal).Return(nil).Once()
+
+	code, _ = update("missing", "status=pending_anchor")
+	assert.Equal(t, http.StatusNotFound, code)
+	code, _ = update(withdrawal.PublicID, "status=completed")
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, body = update(withdrawal.PublicID, "status=pending_user_transfer_start&amount=5")
+	assert.Contains(t, string(body), bridge.TransferInvalidAmount.Code)
+
+	code, body = update(withdrawal.PublicID, "status=pending_user_transfer_start&amount=50")
+	require.Equal(t, http.StatusOK, code)
+	var transactionResponse bridge.TransferTransactionResponse
+	require.NoError(t, json.Unmarshal(body, &transactionResponse))
+	assert.Equal(t, entities.TransferStatusPendingUserTransferStart, transactionResponse.Transaction.Status)
+	assert.Equal(t, "49.0000000", *transactionResponse.Transaction.AmountOut)
+	assert.Equal(t, *withdrawal.Memo, *transactionResponse.Transaction.WithdrawMemo)
+
+	// Completed transactions cannot be updated
+	withdrawal.Status = entities.TransferStatusCompleted
+	_, body = update(withdrawal.PublicID, "status=error")
+	assert.Contains(t, string(body), bridge.TransferTransactionCompleted.Code)
+
+	assert.Equal(t, []string{"deposit:incomplete", "withdrawal:incomplete", "withdrawal:pending_user_transfer_start"}, statuses)
+	mockEntityManager.AssertExpectations(t)
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"net/http"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/protocols"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stellar/gateway/server"
+	b "github.com/stellar/go-stellar-base/build"
+)
+
+// Trust implements /trust endpoint. It creates, changes or removes a trustline of the source
+// account (base account by default).
+func (rh *RequestHandler) Trust(w http.ResponseWriter, r *http.Request) {
+	logger := server.Logger(r)
+	request := &bridge.TrustRequest{}
+	request.FromRequest(r)
+
+	err := request.Validate()
+	if err != nil {
+		errorResponse := err.(*protocols.ErrorResponse)
+		logger.WithFields(errorResponse.LogData).Error(errorResponse.Error())
+		server.Write(w, errorResponse)
+		return
+	}
+
+	if request.Source == "" {
+		request.Source = rh.Config.Accounts.BaseSeed
+	}
+
+	if request.Source == "" {
+		server.Write(w, protocols.NewMissingParameter("source"))
+		return
+	}
+
+	if !rh.Config.AssetFilter().Allows(request.AssetCode, request.AssetIssuer) {
+		logger.WithFields(log.Fields{"asset_code": request.AssetCode, "asset_issuer": request.AssetIssuer}).Print("Asset not allowed")
+		server.Write(w, bridge.PaymentAssetCodeNotAllowed)
+		return
+	}
+
+	operation := bridge.ChangeTrustOperationBody{
+		Asset: protocols.Asset{Code: request.AssetCode, Issuer: request.AssetIssuer},
+	}
+	if request.Limit != "" {
+		operation.Limit = &request.Limit
+	}
+
+	rh.submitOperation(w, r, request.Source, operation.ToTransactionMutator())
+}
+
+// AllowTrust implements /allow-trust endpoint. It authorizes or revokes a trustline of an asset
+// issued by the source account. By default it's sent by the authorizing account on behalf of
+// the issuing account.
+func (rh *RequestHandler) AllowTrust(w http.ResponseWriter, r *http.Request) {
+	logger := server.Logger(r)
+	request := &bridge.AllowTrustRequest{}
+	request.FromRequest(r)
+
+	err := request.Validate()
+	if err != nil {
+		errorResponse := err.(*protocols.ErrorResponse)
+		logger.WithFields(errorResponse.LogData).Error(errorResponse.Error())
+		server.Write(w, errorResponse)
+		return
+	}
+
+	// Authorizing account is a signer of the issuing account
+	var operationSource *string
+	if request.Source == "" {
+		request.Source = rh.Config.Accounts.AuthorizingSeed
+		if issuingAccountID := rh.Config.Accounts.IssuingAccountID; issuingAccountID != "" {
+			operationSource = &issuingAccountID
+		}
+	}
+
+	if request.Source == "" {
+		server.Write(w, bridge.AllowTrustNoAuthorizingSeed)
+		return
+	}
+
+	// Source is a secret seed of the request or a seed or a key reference of the authorizing account
+	sourceKeypair, err := rh.Signers.Signer(request.Source)
+	if err != nil {
+		logger.WithFields(log.Fields{"err": err}).Error("Cannot load source account signer")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	issuer := sourceKeypair.Address()
+	if operationSource != nil {
+		issuer = *operationSource
+	}
+
+	if !rh.Config.AssetFilter().Allows(request.AssetCode, issuer) {
+		server.Write(w, protocols.NewInvalidParameterError("asset_code", request.AssetCode))
+		return
+	}
+
+	operation := bridge.AllowTrustOperationBody{
+		Source:    operationSource,
+		AssetCode: request.AssetCode,
+		Trustor:   request.AccountID,
+		Authorize: request.Authorized(),
+	}
+
+	rh.submitOperation(w, r, request.Source, operation.ToTransactionMutator())
+}
+
+// submitOperation submits a transaction with a single operation using TransactionSubmitter and
+// writes Horizon response
+func (rh *RequestHandler) submitOperation(w http.ResponseWriter, r *http.Request, seed string, operation b.TransactionMutator) {
+	logger := server.Logger(r)
+
+	submitResponse, err := rh.TransactionSubmitter.SubmitTransaction(r.Context(), seed, operation, nil)
+	if err != nil {
+		logger.WithFields(log.Fields{"err": err}).Error("Error submitting transaction")
+		server.Write(w, submitErrorResponse(err))
+		return
+	}
+
+	errorResponse := bridge.ErrorFromHorizonResponse(submitResponse)
+	if errorResponse != nil {
+		logger.WithFields(errorResponse.LogData).Error(errorResponse.Error())
+		server.Write(w, errorResponse)
+		return
+	}
+
+	server.Write(w, &submitResponse)
+}
@@ -0,0 +1,264 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/listener"
+	"github.com/stellar/gateway/protocols"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stellar/gateway/server"
+	"github.com/stellar/gateway/signer"
+	"github.com/stellar/gateway/submitter"
+	"github.com/stellar/go-stellar-base/amount"
+	b "github.com/stellar/go-stellar-base/build"
+	"github.com/stellar/go-stellar-base/xdr"
+)
+
+// xdrOperation adds an already decoded operation to a transaction
+type xdrOperation xdr.Operation
+
+// MutateTransaction appends the operation to the transaction
+func (op xdrOperation) MutateTransaction(o *b.TransactionBuilder) error {
+	o.TX.Operations = append(o.TX.Operations, xdr.Operation(op))
+	return nil
+}
+
+// TxApprove implements POST /tx_approve endpoint (SEP-8 approval server). It accepts transactions
+// with a single payment of an asset of `approval_server.assets` which is sent to `callbacks.approve`.
+// Approved transactions are revised: the payment is wrapped in allow_trust operations authorizing
+// trustlines for its duration. Transactions already wrapped this way are signed without revision.
+func (rh *RequestHandler) TxApprove(w http.ResponseWriter, r *http.Request) {
+	// Approval servers are queried by wallets from browsers
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	var request struct {
+		Tx string `json:"tx"`
+	}
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		err := json.NewDecoder(r.Body).Decode(&request)
+		if err != nil {
+			server.Write(w, bridge.NewTxApproveRejectedResponse("Invalid request body."))
+			return
+		}
+	} else {
+		request.Tx = r.PostFormValue("tx")
+	}
+
+	if request.Tx == "" {
+		server.Write(w, bridge.NewTxApproveRejectedResponse("Missing parameter \"tx\"."))
+		return
+	}
+
+	tx, err := submitter.DecodeRawEnvelope(request.Tx)
+	if err != nil {
+		server.Write(w, bridge.NewTxApproveRejectedResponse("Invalid transaction envelope."))
+		return
+	}
+
+	issuer := rh.Config.Accounts.IssuingAccountID
+	source := tx.SourceAccount.Address()
+	if source == issuer {
+		server.Write(w, bridge.NewTxApproveRejectedResponse("Transaction source account cannot be the issuer."))
+		return
+	}
+
+	// The payment is the only operation not sent by the issuer
+	var payment *xdr.Operation
+	for i, op := range tx.Operations {
+		if op.SourceAccount != nil && op.SourceAccount.Address() == issuer {
+			continue
+		}
+		if payment != nil || op.Body.Type != xdr.OperationTypePayment {
+			server.Write(w, bridge.NewTxApproveRejectedResponse("There are one or more unexpected operations in the provided transaction."))
+			return
+		}
+		payment = &tx.Operations[i]
+	}
+	if payment == nil {
+		server.Write(w, bridge.NewTxApproveRejectedResponse("Transaction must contain a payment of a regulated asset."))
+		return
+	}
+
+	paymentOp := payment.Body.MustPaymentOp()
+	var assetType, assetCode, assetIssuer string
+	paymentOp.Asset.MustExtract(&assetType, &assetCode, &assetIssuer)
+	if assetIssuer != issuer || !rh.Config.ApprovalServer.Regulates(assetCode) {
+		server.Write(w, bridge.NewTxApproveRejectedResponse("Payment asset is not regulated by this server."))
+		return
+	}
+
+	paymentSource := source
+	if payment.SourceAccount != nil {
+		paymentSource = payment.SourceAccount.Address()
+	}
+	destination := paymentOp.Destination.Address()
+
+	// Operations of the issuer are skipped above so paymentSource is not the issuer
+	trustlines := []regulatedTrustline{{paymentSource, assetCode}}
+	if destination != issuer {
+		trustlines = append(trustlines, regulatedTrustline{destination, assetCode})
+	}
+
+	logger := log.WithFields(log.Fields{"source": paymentSource, "destination": destination, "asset_code": assetCode})
+
+	asset, err := rh.Horizon.LoadAsset(r.Context(), assetCode, issuer)
+	if err != nil {
+		logger.WithFields(log.Fields{"err": err}).Error("Cannot load asset")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+	if !asset.Flags.AuthRequired {
+		logger.Error("Issuing account does not have AUTH_REQUIRED flag")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	// Transaction is expected to contain the payment only or the same operations a revised
+	// transaction contains
+	expected := b.Transaction(
+		b.SourceAccount{source},
+		b.Sequence{uint64(tx.SeqNum)},
+		b.Network{rh.Config.NetworkPassphrase},
+	)
+	expected.Mutate(regulatedOperations(issuer, trustlines, xdrOperation(*payment), asset.Flags.AuthRevocable)...)
+	if expected.Err != nil {
+		logger.WithFields(log.Fields{"err": expected.Err}).Error("Cannot build revised transaction")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	revise := len(tx.Operations) == 1
+	if !revise && !equalOperations(tx.Operations, expected.TX.Operations) {
+		server.Write(w, bridge.NewTxApproveRejectedResponse("There are one or more unexpected operations in the provided transaction."))
+		return
+	}
+
+	memoType, memo := memoValues(tx.Memo)
+	approval, err := listener.RequestApproval(r.Context(), rh.Client, rh.Config, url.Values{
+		"source":       {paymentSource},
+		"destination":  {destination},
+		"amount":       {amount.String(paymentOp.Amount)},
+		"asset_code":   {assetCode},
+		"asset_issuer": {assetIssuer},
+		"memo_type":    {memoType},
+		"memo":         {memo},
+		"tx":           {request.Tx},
+	})
+	if err != nil {
+		logger.WithFields(log.Fields{"err": err}).Error("Error sending transaction to approve callback")
+		server.Write(w, bridge.ApproveCallbackFailed)
+		return
+	}
+
+	switch approval.Status {
+	case listener.ApprovalStatusRejected:
+		logger.WithFields(log.Fields{"error": approval.Error}).Info("Transaction rejected by approve callback")
+		server.Write(w, bridge.NewTxApproveRejectedResponse(approval.Error))
+		return
+	case listener.ApprovalStatusPending:
+		timeout := approval.Timeout
+		server.Write(w, &bridge.TxApproveResponse{Status: bridge.TxApproveStatusPending, Message: approval.Message, Timeout: &timeout})
+		return
+	case listener.ApprovalStatusActionRequired:
+		server.Write(w, &bridge.TxApproveResponse{
+			Status:       bridge.TxApproveStatusActionRequired,
+			Message:      approval.Message,
+			ActionURL:    approval.ActionURL,
+			ActionMethod: approval.ActionMethod,
+			ActionFields: approval.ActionFields,
+		})
+		return
+	}
+
+	authorizer, err := rh.Signers.Signer(rh.Config.Accounts.AuthorizingSeed)
+	if err != nil {
+		logger.WithFields(log.Fields{"err": err}).Error("Cannot load authorizing seed")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	response := &bridge.TxApproveResponse{Status: bridge.TxApproveStatusSuccess, Message: approval.Message}
+	if revise {
+		revised := *expected.TX
+		revised.Memo = tx.Memo
+		// Fee per operation of the original transaction
+		revised.Fee = tx.Fee * xdr.Uint32(len(revised.Operations))
+		var preconditions submitter.Preconditions
+		if tx.TimeBounds != nil {
+			preconditions.MinTime = uint64(tx.TimeBounds.MinTime)
+			preconditions.MaxTime = uint64(tx.TimeBounds.MaxTime)
+		}
+
+		response.Status = bridge.TxApproveStatusRevised
+		response.Tx, _, err = submitter.EncodeTransaction(&revised, preconditions, rh.Config.NetworkPassphrase, authorizer)
+	} else {
+		response.Tx, err = addSignature(request.Tx, rh.Config.NetworkPassphrase, authorizer)
+	}
+	if err != nil {
+		logger.WithFields(log.Fields{"err": err}).Error("Cannot sign transaction")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	logger.WithFields(log.Fields{"status": response.Status}).Info("Transaction approved")
+	server.Write(w, response)
+}
+
+// equalOperations returns true when XDR encodings of operations are equal
+func equalOperations(a, b []xdr.Operation) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		var encodedA, encodedB bytes.Buffer
+		_, errA := xdr.Marshal(&encodedA, a[i])
+		_, errB := xdr.Marshal(&encodedB, b[i])
+		if errA != nil || errB != nil || !bytes.Equal(encodedA.Bytes(), encodedB.Bytes()) {
+			return false
+		}
+	}
+	return true
+}
+
+// addSignature returns base64-encoded envelope txeB64 with a signature of s added
+func addSignature(txeB64, networkPassphrase string, s signer.Signer) (string, error) {
+	_, _, hash, err := submitter.ParseRawEnvelope(txeB64, networkPassphrase)
+	if err != nil {
+		return "", err
+	}
+
+	signatures, err := submitter.RawEnvelopeSignatures(txeB64)
+	if err != nil {
+		return "", err
+	}
+
+	signature, err := s.SignDecorated(hash[:])
+	if err != nil {
+		return "", err
+	}
+	return submitter.SetRawEnvelopeSignatures(txeB64, append(signatures, signature))
+}
+
+// memoValues returns memo_type and memo of a transaction memo as sent to callbacks
+func memoValues(memo xdr.Memo) (memoType, value string) {
+	switch memo.Type {
+	case xdr.MemoTypeMemoText:
+		return "text", memo.MustText()
+	case xdr.MemoTypeMemoId:
+		return "id", strconv.FormatUint(uint64(memo.MustId()), 10)
+	case xdr.MemoTypeMemoHash:
+		hash := memo.MustHash()
+		return "hash", hex.EncodeToString(hash[:])
+	case xdr.MemoTypeMemoReturn:
+		hash := memo.MustRetHash()
+		return "return", hex.EncodeToString(hash[:])
+	}
+	return "", ""
+}
@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/mocks"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stellar/gateway/submitter"
+	b "github.com/stellar/go-stellar-base/build"
+	"github.com/stellar/go-stellar-base/keypair"
+	"github.com/stellar/go-stellar-base/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestHandlerTxApprove(t *testing.T) {
+	issuer := "GDSIKW43UA6JTOA47WVEBCZ4MYC74M3GNKNXTVDXFHXYYTNO5GGVN632"
+	authorizer := keypair.MustParse("SBKKWO3ZVDDEHDJILGHPHCJCFD2GNUAYIUDMRAS326HLUEQ7ZFXWIGQK")
+	source, err := keypair.Random()
+	require.NoError(t, err)
+	destination, err := keypair.Random()
+	require.NoError(t, err)
+
+	c := &config.Config{NetworkPassphrase: "Test SDF Network ; September 2015"}
+	c.Accounts.IssuingAccountID = issuer
+	c.Accounts.AuthorizingSeed = "SBKKWO3ZVDDEHDJILGHPHCJCFD2GNUAYIUDMRAS326HLUEQ7ZFXWIGQK"
+	c.Callbacks.Approve = "http://approve"
+	c.ApprovalServer.Assets = []string{"USD"}
+	mockHorizon := new(mocks.MockHorizon)
+	mockHTTPClient := new(mocks.MockHTTPClient)
+	rh := RequestHandler{Config: c, Horizon: mockHorizon, Client: mockHTTPClient}
+
+	mockHorizon.On("LoadAsset", "USD", issuer).Return(horizon.AssetResponse{Flags: horizon.Flags{AuthRequired: true, AuthRevocable: true}}, nil)
+	approve := func(body string) {
+		mockHTTPClient.On("Do", mock.MatchedBy(func(r *http.Request) bool {
+			r.ParseForm()
+			return r.URL.String() == "http://approve" &&
+				r.PostForm.Get("source") == source.Address() &&
+				r.PostForm.Get("destination") == destination.Address() &&
+				r.PostForm.Get("amount") == "10.0000000" &&
+				r.PostForm.Get("memo") == "42"
+		})).Return(&http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader(body))}, nil).Once()
+	}
+
+	payment := b.Payment(b.Destination{destination.Address()}, b.CreditAmount{"USD", issuer, "10"})
+	envelope := func(operations ...b.TransactionMutator) string {
+		mutators := []b.TransactionMutator{
+			b.SourceAccount{source.Address()},
+			b.Sequence{5},
+			b.Network{c.NetworkPassphrase},
+			b.MemoID{42},
+		}
+		tx := b.Transaction(append(mutators, operations...)...)
+		require.NoError(t, tx.Err)
+		txe := tx.Sign(source.Seed())
+		txeB64, err := txe.Base64()
+		require.NoError(t, err)
+		return txeB64
+	}
+	post := func(txeB64 string) (int, bridge.TxApproveResponse) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/tx_approve", strings.NewReader(url.Values{"tx": {txeB64}}.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rh.TxApprove(w, r)
+
+		var response bridge.TxApproveResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w.Code, response
+	}
+
+	// Invalid requests
+	code, response := post("")
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, bridge.TxApproveStatusRejected, response.Status)
+	_, response = post(envelope(b.Payment(b.Destination{destination.Address()}, b.CreditAmount{"EUR", issuer, "10"})))
+	assert.Equal(t, "Payment asset is not regulated by this server.", response.Error)
+	_, response = post(envelope(payment, b.Payment(b.Destination{destination.Address()}, b.NativeAmount{"1"})))
+	assert.Equal(t, "There are one or more unexpected operations in the provided transaction.", response.Error)
+
+	// Revised
+	approve(`{"status": "approved"}`)
+	code, response = post(envelope(payment))
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, bridge.TxApproveStatusRevised, response.Status)
+
+	revised, err := submitter.DecodeRawEnvelope(response.Tx)
+	require.NoError(t, err)
+	assert.Equal(t, xdr.SequenceNumber(5), revised.SeqNum)
+	assert.Equal(t, xdr.Uint32(500), revised.Fee)
+	assert.Equal(t, xdr.MemoTypeMemoId, revised.Memo.Type)
+	require.Len(t, revised.Operations, 5)
+	assert.Equal(t, xdr.OperationTypePayment, revised.Operations[2].Body.Type)
+	signatures, err := submitter.RawEnvelopeSignatures(response.Tx)
+	require.NoError(t, err)
+	require.Len(t, signatures, 1)
+	_, _, hash, err := submitter.ParseRawEnvelope(response.Tx, c.NetworkPassphrase)
+	require.NoError(t, err)
+	assert.NoError(t, authorizer.Verify(hash[:], signatures[0].Signature))
+
+	// Already revised transaction is signed
+	trustlines := []regulatedTrustline{{source.Address(), "USD"}, {destination.Address(), "USD"}}
+	txeB64 := envelope(regulatedOperations(issuer, trustlines, payment, true)...)
+	approve(`{"status": "approved"}`)
+	code, response = post(txeB64)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, bridge.TxApproveStatusSuccess, response.Status)
+	signatures, err = submitter.RawEnvelopeSignatures(response.Tx)
+	require.NoError(t, err)
+	assert.Len(t, signatures, 2)
+
+	// Revised transaction with authorization not revoked
+	txeB64 = envelope(regulatedOperations(issuer, trustlines, payment, false)...)
+	_, response = post(txeB64)
+	assert.Equal(t, bridge.TxApproveStatusRejected, response.Status)
+
+	// Callback statuses
+	approve(`{"status": "rejected", "error": "Destination is not verified"}`)
+	code, response = post(envelope(payment))
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, "Destination is not verified", response.Error)
+
+	approve(`{"status": "pending", "message": "Under review", "timeout": 3600000}`)
+	_, response = post(envelope(payment))
+	assert.Equal(t, bridge.TxApproveStatusPending, response.Status)
+	require.NotNil(t, response.Timeout)
+	assert.Equal(t, 3600000, *response.Timeout)
+	assert.Empty(t, response.Tx)
+
+	approve(`{"status": "action_required", "message": "KYC needed", "action_url": "https://example.com/kyc", "action_method": "POST", "action_fields": ["email_address"]}`)
+	_, response = post(envelope(payment))
+	assert.Equal(t, bridge.TxApproveStatusActionRequired, response.Status)
+	assert.Equal(t, "https://example.com/kyc", response.ActionURL)
+	assert.Equal(t, []string{"email_address"}, response.ActionFields)
+
+	mockHTTPClient.AssertExpectations(t)
+}
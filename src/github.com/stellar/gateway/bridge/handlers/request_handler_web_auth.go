@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/protocols"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stellar/gateway/protocols/webauth"
+	"github.com/stellar/gateway/server"
+	"github.com/stellar/go-stellar-base/keypair"
+	"github.com/stellar/go-stellar-base/strkey"
+)
+
+const defaultJWTTTL = 24 * time.Hour
+
+// AuthChallenge implements GET /auth endpoint. It returns a SEP-10 challenge transaction for
+// `account`.
+func (rh *RequestHandler) AuthChallenge(w http.ResponseWriter, r *http.Request) {
+	accountID := r.URL.Query().Get("account")
+	_, err := strkey.Decode(strkey.VersionByteAccountID, accountID)
+	if err != nil {
+		server.Write(w, protocols.NewInvalidParameterError("account", accountID))
+		return
+	}
+
+	if !rh.Config.WebAuth.AllowsAccount(accountID) {
+		server.Write(w, bridge.WebAuthAccountNotAllowed)
+		return
+	}
+
+	txeB64, err := webauth.NewChallenge(
+		rh.Config.WebAuth.SigningSeed,
+		accountID,
+		rh.Config.WebAuth.HomeDomain,
+		rh.Config.NetworkPassphrase,
+		time.Now(),
+	)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error creating challenge transaction")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	server.Write(w, &bridge.WebAuthChallengeResponse{
+		Transaction:       txeB64,
+		NetworkPassphrase: rh.Config.NetworkPassphrase,
+	})
+}
+
+// AuthToken implements POST /auth endpoint. It verifies a signed challenge transaction sent
+// in `transaction` param (form or JSON) and returns a JWT.
+func (rh *RequestHandler) AuthToken(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		Transaction string `json:"transaction"`
+	}
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		err := json.NewDecoder(r.Body).Decode(&request)
+		if err != nil {
+			server.Write(w, protocols.InvalidParameterError)
+			return
+		}
+	} else {
+		request.Transaction = r.PostFormValue("transaction")
+	}
+
+	if request.Transaction == "" {
+		server.Write(w, protocols.NewMissingParameter("transaction"))
+		return
+	}
+
+	serverKeypair, err := keypair.Parse(rh.Config.WebAuth.SigningSeed)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Invalid web_auth.signing_seed")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	now := time.Now()
+	challenge, err := webauth.ReadChallenge(
+		request.Transaction,
+		serverKeypair.Address(),
+		rh.Config.WebAuth.HomeDomain,
+		rh.Config.NetworkPassphrase,
+		now,
+	)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Info("Invalid challenge transaction")
+		server.Write(w, bridge.WebAuthInvalidChallenge)
+		return
+	}
+
+	if !rh.Config.WebAuth.AllowsAccount(challenge.ClientAccountID) {
+		server.Write(w, bridge.WebAuthAccountNotAllowed)
+		return
+	}
+
+	signed, err := rh.challengeSigned(r.Context(), challenge)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error loading client account")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	if !signed {
+		server.Write(w, bridge.WebAuthNotSigned)
+		return
+	}
+
+	key, err := rh.Config.WebAuth.Key()
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Invalid web_auth.jwt_key")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	ttl := defaultJWTTTL
+	if rh.Config.WebAuth.JWTTTL != 0 {
+		ttl = time.Duration(rh.Config.WebAuth.JWTTTL) * time.Second
+	}
+
+	hash := challenge.Hash()
+	token, err := webauth.NewToken(webauth.Claims{
+		Issuer:    rh.Config.WebAuth.HomeDomain,
+		Subject:   challenge.ClientAccountID,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(ttl).Unix(),
+		ID:        hex.EncodeToString(hash[:]),
+	}, key)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error creating token")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	log.WithFields(log.Fields{"account": challenge.ClientAccountID}).Info("Account authenticated")
+	server.Write(w, &bridge.WebAuthTokenResponse{Token: token})
+}
+
+// challengeSigned checks if the challenge is signed by signers of the client account with
+// a total weight meeting the medium threshold. Accounts that do not exist must sign with
+// the master key.
+func (rh *RequestHandler) challengeSigned(ctx context.Context, challenge *webauth.Challenge) (bool, error) {
+	account, err := rh.Horizon.LoadAccount(ctx, challenge.ClientAccountID)
+	if statusError, ok := err.(*horizon.StatusError); ok && statusError.StatusCode == http.StatusNotFound {
+		return challenge.SignedBy(challenge.ClientAccountID), nil
+	} else if err != nil {
+		return false, err
+	}
+
+	var weight int32
+	for _, signer := range account.Signers {
+		if challenge.SignedBy(signer.ID()) {
+			weight += signer.Weight
+		}
+	}
+
+	return weight > 0 && weight >= int32(account.Thresholds.MedThreshold), nil
+}
+
+// webAuthAccount returns an account authenticated by a SEP-10 token sent in Authorization header
+func (rh *RequestHandler) webAuthAccount(r *http.Request) (string, bool) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if token == "" {
+		return "", false
+	}
+
+	key, err := rh.Config.WebAuth.Key()
+	if err != nil {
+		return "", false
+	}
+
+	claims, err := webauth.ParseToken(token, key, time.Now())
+	if err != nil {
+		return "", false
+	}
+	return claims.Subject, true
+}
@@ -0,0 +1,58 @@
+package bridgetest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+
+	"github.com/stellar/gateway/protocols/compliance"
+)
+
+// FakeCompliance is an in-process compliance server. It records requests sent to
+// `POST /send` and responds with a response set using SetResponse.
+type FakeCompliance struct {
+	*httptest.Server
+
+	mutex    sync.Mutex
+	response compliance.SendResponse
+	requests []url.Values
+}
+
+// NewFakeCompliance starts a new FakeCompliance
+func NewFakeCompliance() *FakeCompliance {
+	c := &FakeCompliance{}
+	c.Server = httptest.NewServer(http.HandlerFunc(c.serveHTTP))
+	return c
+}
+
+// SetResponse sets a response returned by `/send` endpoint
+func (c *FakeCompliance) SetResponse(response compliance.SendResponse) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.response = response
+}
+
+// Requests returns form values of all requests sent to `/send` endpoint so far
+func (c *FakeCompliance) Requests() []url.Values {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return append([]url.Values{}, c.requests...)
+}
+
+func (c *FakeCompliance) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" || r.URL.Path != "/send" {
+		http.NotFound(w, r)
+		return
+	}
+
+	r.ParseForm()
+
+	c.mutex.Lock()
+	c.requests = append(c.requests, r.PostForm)
+	response := c.response
+	c.mutex.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(response.Marshal())
+}
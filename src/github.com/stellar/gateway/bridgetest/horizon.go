@@ -0,0 +1,135 @@
+package bridgetest
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/submitter"
+	"github.com/stellar/go-stellar-base/xdr"
+)
+
+// FakeHorizon is an in-process Horizon server. It serves `GET /accounts/{id}` for accounts
+// added using AddAccount and accepts every transaction sent to `POST /transactions`:
+// source account sequence number is bumped and a success response is returned.
+// Operations are not applied and streaming endpoints are not implemented.
+type FakeHorizon struct {
+	*httptest.Server
+
+	// OnSubmit, when set, is called with every submitted transaction. If it returns
+	// a non-nil response it is sent to the client instead of the success response.
+	OnSubmit func(envelope xdr.TransactionEnvelope) *horizon.SubmitTransactionResponse
+
+	networkPassphrase string
+	mutex             sync.Mutex
+	accounts          map[string]*horizon.AccountResponse
+	submitted         []xdr.TransactionEnvelope
+	ledger            uint64
+}
+
+// NewFakeHorizon starts a new FakeHorizon. Transaction hashes are calculated using networkPassphrase.
+func NewFakeHorizon(networkPassphrase string) *FakeHorizon {
+	h := &FakeHorizon{
+		networkPassphrase: networkPassphrase,
+		accounts:          make(map[string]*horizon.AccountResponse),
+		ledger:            1,
+	}
+	h.Server = httptest.NewServer(http.HandlerFunc(h.serveHTTP))
+	return h
+}
+
+// AddAccount adds (or replaces) an account with a given native balance
+func (h *FakeHorizon) AddAccount(accountID, nativeBalance string) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.accounts[accountID] = &horizon.AccountResponse{
+		AccountID:      accountID,
+		SequenceNumber: "0",
+		Balances:       []horizon.Balance{{AssetType: "native", Balance: nativeBalance}},
+		Thresholds:     horizon.Thresholds{},
+	}
+}
+
+// Submitted returns all transactions submitted so far
+func (h *FakeHorizon) Submitted() []xdr.TransactionEnvelope {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return append([]xdr.TransactionEnvelope{}, h.submitted...)
+}
+
+func (h *FakeHorizon) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/accounts/"):
+		h.serveAccount(w, strings.TrimPrefix(r.URL.Path, "/accounts/"))
+	case r.Method == "POST" && r.URL.Path == "/transactions":
+		h.serveSubmit(w, r.PostFormValue("tx"))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *FakeHorizon) serveAccount(w http.ResponseWriter, accountID string) {
+	h.mutex.Lock()
+	account, ok := h.accounts[accountID]
+	var response horizon.AccountResponse
+	if ok {
+		response = *account
+	}
+	h.mutex.Unlock()
+
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"status": 404, "title": "Resource Missing"}`))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (h *FakeHorizon) serveSubmit(w http.ResponseWriter, txeB64 string) {
+	var envelope xdr.TransactionEnvelope
+	err := xdr.SafeUnmarshalBase64(txeB64, &envelope)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"status": 400, "title": "Transaction Malformed"}`))
+		return
+	}
+
+	if h.OnSubmit != nil {
+		if response := h.OnSubmit(envelope); response != nil {
+			writeJSON(w, http.StatusBadRequest, response)
+			return
+		}
+	}
+
+	hash, err := submitter.TransactionHash(&envelope.Tx, h.networkPassphrase)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.mutex.Lock()
+	h.submitted = append(h.submitted, envelope)
+	if account, ok := h.accounts[envelope.Tx.SourceAccount.Address()]; ok {
+		account.SequenceNumber = strconv.FormatInt(int64(envelope.Tx.SeqNum), 10)
+	}
+	h.ledger++
+	ledger := h.ledger
+	h.mutex.Unlock()
+
+	writeJSON(w, http.StatusOK, horizon.SubmitTransactionResponse{
+		Hash:   hex.EncodeToString(hash[:]),
+		Ledger: &ledger,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, response interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,148 @@
+// Package bridgetest helps writing Go tests against the bridge server. It exposes mocks of
+// bridge server dependencies and starts an in-process bridge server, with real request
+// handlers, connected to fake Horizon and compliance servers.
+package bridgetest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/stellar/gateway/bridge"
+	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/bridge/handlers"
+	"github.com/stellar/gateway/db"
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/protocols/federation"
+	"github.com/stellar/gateway/protocols/stellartoml"
+	"github.com/stellar/gateway/server"
+	"github.com/stellar/gateway/submitter"
+	"github.com/stellar/go-stellar-base/keypair"
+	"github.com/stellar/go-stellar-base/network"
+	"github.com/stretchr/testify/mock"
+	"github.com/zenazn/goji/web"
+)
+
+// DefaultBalance is a native balance of accounts created by NewBridge
+const DefaultBalance = "10000.0000000"
+
+// Bridge is an in-process bridge server
+type Bridge struct {
+	// Server serves bridge endpoints (`/payment`, `/builder`, ...)
+	Server *httptest.Server
+	// AdminServer serves admin endpoints (`/admin/...`)
+	AdminServer *httptest.Server
+	Horizon     *FakeHorizon
+	Compliance  *FakeCompliance
+	// RequestHandler used by Server and AdminServer. Its dependencies can be replaced
+	// with mocks, ex. FederationResolver.
+	RequestHandler *handlers.RequestHandler
+}
+
+// Options contains optional dependencies of Bridge
+type Options struct {
+	// EntityManager used by the bridge. When nil a MockEntityManager accepting all calls is used.
+	EntityManager db.EntityManagerInterface
+	// Repository used by the bridge. When nil endpoints requiring a DB are not available.
+	Repository db.RepositoryInterface
+}
+
+// NewBridge starts a new Bridge using cfg. `horizon` and `compliance` params are set to URLs of
+// fake servers and network passphrase defaults to test network. Accounts of `accounts.*` params
+// are added to the fake Horizon with DefaultBalance.
+// Payment and trustline listeners and monitors are not started. Call Close when done.
+func NewBridge(cfg config.Config, options Options) (*Bridge, error) {
+	if cfg.NetworkPassphrase == "" {
+		cfg.NetworkPassphrase = network.TestNetworkPassphrase
+	}
+
+	fakeHorizon := NewFakeHorizon(cfg.NetworkPassphrase)
+	fakeCompliance := NewFakeCompliance()
+	cfg.Horizon = fakeHorizon.URL
+	cfg.Compliance = fakeCompliance.URL
+
+	for _, seed := range []string{cfg.Accounts.AuthorizingSeed, cfg.Accounts.BaseSeed} {
+		if seed == "" {
+			continue
+		}
+		kp, err := keypair.Parse(seed)
+		if err != nil {
+			fakeHorizon.Close()
+			fakeCompliance.Close()
+			return nil, err
+		}
+		fakeHorizon.AddAccount(kp.Address(), DefaultBalance)
+	}
+
+	for _, accountID := range []string{cfg.Accounts.IssuingAccountID, cfg.Accounts.ReceivingAccountID} {
+		if accountID != "" {
+			fakeHorizon.AddAccount(accountID, DefaultBalance)
+		}
+	}
+
+	entityManager := options.EntityManager
+	if entityManager == nil {
+		mockEntityManager := &MockEntityManager{}
+		mockEntityManager.On("Persist", mock.Anything).Return(nil)
+		mockEntityManager.On("Delete", mock.Anything).Return(nil)
+		entityManager = mockEntityManager
+	}
+
+	h := horizon.New(cfg.Horizon)
+
+	ts := submitter.NewTransactionSubmitter(&h, entityManager, cfg.NetworkPassphrase, time.Now)
+	for _, seed := range []string{cfg.Accounts.AuthorizingSeed, cfg.Accounts.BaseSeed} {
+		if seed == "" {
+			continue
+		}
+		err := ts.InitAccount(context.Background(), seed)
+		if err != nil {
+			fakeHorizon.Close()
+			fakeCompliance.Close()
+			return nil, err
+		}
+	}
+
+	stellarTomlResolver := &stellartoml.Resolver{}
+
+	rh := &handlers.RequestHandler{
+		Config:               &cfg,
+		Client:               &http.Client{},
+		Horizon:              &h,
+		StellarTomlResolver:  stellarTomlResolver,
+		FederationResolver:   &federation.Resolver{StellarTomlResolver: stellarTomlResolver},
+		TransactionSubmitter: &ts,
+		EntityManager:        entityManager,
+		Repository:           options.Repository,
+	}
+
+	mux := newMux()
+	bridge.RegisterRoutes(mux, "", rh)
+
+	adminMux := newMux()
+	bridge.RegisterAdminRoutes(adminMux, "/admin", rh)
+
+	return &Bridge{
+		Server:         httptest.NewServer(mux),
+		AdminServer:    httptest.NewServer(adminMux),
+		Horizon:        fakeHorizon,
+		Compliance:     fakeCompliance,
+		RequestHandler: rh,
+	}, nil
+}
+
+// Close stops bridge and fake servers
+func (b *Bridge) Close() {
+	b.Server.Close()
+	b.AdminServer.Close()
+	b.Horizon.Close()
+	b.Compliance.Close()
+}
+
+func newMux() *web.Mux {
+	mux := web.New()
+	mux.Use(server.StripTrailingSlashMiddleware())
+	mux.Use(server.HeadersMiddleware())
+	return mux
+}
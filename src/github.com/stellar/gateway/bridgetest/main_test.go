@@ -0,0 +1,127 @@
+package bridgetest
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stellar/go-stellar-base/keypair"
+	"github.com/stellar/go-stellar-base/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBridgePayment(t *testing.T) {
+	base, err := keypair.Random()
+	require.NoError(t, err)
+	destination, err := keypair.Random()
+	require.NoError(t, err)
+
+	cfg := config.Config{}
+	cfg.Accounts.BaseSeed = base.Seed()
+
+	b, err := NewBridge(cfg, Options{})
+	require.NoError(t, err)
+	defer b.Close()
+
+	b.Horizon.AddAccount(destination.Address(), "1.0000000")
+
+	for i := 0; i < 2; i++ {
+		resp, err := http.PostForm(b.Server.URL+"/payment", url.Values{
+			"destination": {destination.Address()},
+			"amount":      {"20"},
+		})
+		require.NoError(t, err)
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+		var response horizon.SubmitTransactionResponse
+		require.NoError(t, json.Unmarshal(body, &response))
+		assert.Len(t, response.Hash, 64)
+	}
+
+	submitted := b.Horizon.Submitted()
+	require.Len(t, submitted, 2)
+	assert.Equal(t, base.Address(), submitted[0].Tx.SourceAccount.Address())
+	assert.Equal(t, xdr.SequenceNumber(1), submitted[0].Tx.SeqNum)
+	assert.Equal(t, xdr.SequenceNumber(2), submitted[1].Tx.SeqNum)
+	assert.Equal(t, xdr.OperationTypePayment, submitted[0].Tx.Operations[0].Body.Type)
+}
+
+func TestBridgeOnSubmit(t *testing.T) {
+	base, err := keypair.Random()
+	require.NoError(t, err)
+	destination, err := keypair.Random()
+	require.NoError(t, err)
+
+	cfg := config.Config{}
+	cfg.Accounts.BaseSeed = base.Seed()
+
+	b, err := NewBridge(cfg, Options{})
+	require.NoError(t, err)
+	defer b.Close()
+
+	// tx_bad_seq
+	b.Horizon.OnSubmit = func(envelope xdr.TransactionEnvelope) *horizon.SubmitTransactionResponse {
+		return &horizon.SubmitTransactionResponse{
+			Extras: &horizon.SubmitTransactionResponseExtras{ResultXdr: "AAAAAAAAAAD////7AAAAAA=="},
+		}
+	}
+
+	resp, err := http.PostForm(b.Server.URL+"/payment", url.Values{
+		"destination": {destination.Address()},
+		"amount":      {"20"},
+	})
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Empty(t, b.Horizon.Submitted())
+}
+
+func TestBridgePaymentsPoll(t *testing.T) {
+	repository := &MockRepository{}
+	b, err := NewBridge(config.Config{}, Options{Repository: repository})
+	require.NoError(t, err)
+	defer b.Close()
+
+	id := int64(8)
+	repository.On("GetReceivedPaymentsAfterID", int64(7), 50).Return([]entities.ReceivedPayment{
+		{ID: &id, OperationID: "1234", Status: entities.ReceivedPaymentStatusSuccess, Amount: "10.0000000"},
+	}, nil).Once()
+	repository.On("GetReceivedPaymentsAfterID", int64(8), 50).Return([]entities.ReceivedPayment{}, nil)
+
+	poll := func(query string) (int, bridge.PollResponse) {
+		resp, err := http.Get(b.Server.URL + "/payments/poll?" + query)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var response bridge.PollResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+		return resp.StatusCode, response
+	}
+
+	status, response := poll("cursor=7")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "8", response.Cursor)
+	if assert.Len(t, response.Payments, 1) {
+		assert.Equal(t, "1234", response.Payments[0].ID)
+	}
+
+	status, response = poll("cursor=8&timeout=1")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "8", response.Cursor)
+	assert.Empty(t, response.Payments)
+
+	status, _ = poll("cursor=abc")
+	assert.Equal(t, http.StatusBadRequest, status)
+}
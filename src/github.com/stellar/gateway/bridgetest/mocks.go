@@ -0,0 +1,24 @@
+package bridgetest
+
+import (
+	"github.com/stellar/gateway/mocks"
+)
+
+// Mocks of bridge server dependencies. They are testify mocks so expectations
+// are set using `On` method, ex. `horizon.On("LoadAccount", accountID).Return(...)`.
+type (
+	// MockEntityManager mocks db.EntityManagerInterface
+	MockEntityManager = mocks.MockEntityManager
+	// MockFederationResolver mocks federation.ResolverInterface
+	MockFederationResolver = mocks.MockFederationResolver
+	// MockHTTPClient mocks net.HTTPClientInterface
+	MockHTTPClient = mocks.MockHTTPClient
+	// MockHorizon mocks horizon.HorizonInterface
+	MockHorizon = mocks.MockHorizon
+	// MockRepository mocks db.RepositoryInterface
+	MockRepository = mocks.MockRepository
+	// MockStellartomlResolver mocks stellartoml.ResolverInterface
+	MockStellartomlResolver = mocks.MockStellartomlResolver
+	// MockTransactionSubmitter mocks submitter.TransactionSubmitterInterface
+	MockTransactionSubmitter = mocks.MockTransactionSubmitter
+)
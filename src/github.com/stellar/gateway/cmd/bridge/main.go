@@ -1,18 +1,34 @@
 package main
 
 import (
+	"bufio"
+	"fmt"
 	log "github.com/Sirupsen/logrus"
+	"os"
+	"os/signal"
 	"runtime"
+	"strings"
+	"syscall"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/stellar/gateway/bridge"
 	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/db"
+	"github.com/stellar/gateway/signer"
+	"golang.org/x/crypto/ssh/terminal"
 )
 
 var app *bridge.App
 var rootCmd *cobra.Command
 var migrateFlag bool
+var migrateCommand string
+var migrateLimit int
+var sandboxFlag bool
+var checkConfigFlag bool
+
+// version is set using -ldflags "-X main.version=..." when building release binaries
+var version = "devel"
 
 func main() {
 	runtime.GOMAXPROCS(runtime.NumCPU())
@@ -31,34 +47,184 @@ func init() {
 		Run:   run,
 	}
 
-	rootCmd.Flags().BoolVarP(&migrateFlag, "migrate-db", "", false, "migrate DB to the newest schema version")
+	rootCmd.Flags().StringVarP(&migrateCommand, "migrate", "", "", "run DB migrations: up, down or status")
+	rootCmd.Flags().IntVarP(&migrateLimit, "migrate-limit", "", 0, "maximum number of migrations applied by up (default all) or reverted by down (default 1)")
+	rootCmd.Flags().BoolVarP(&migrateFlag, "migrate-db", "", false, "migrate DB to the newest schema version (deprecated, use --migrate up)")
+	rootCmd.Flags().BoolVarP(&sandboxFlag, "sandbox", "", false, "simulate Stellar network locally, no transactions are sent to Horizon")
+	rootCmd.Flags().BoolVarP(&checkConfigFlag, "check-config", "", false, "validate config_bridge.toml, check connections to Horizon and DB and exit (non-zero exit code on failure)")
+
+	keystoreCmd := &cobra.Command{
+		Use:   "keystore",
+		Short: "manage encrypted keystore of secret seeds (signer.keystore.file)",
+	}
+	keystoreCmd.AddCommand(&cobra.Command{
+		Use:   "add <file> <name>",
+		Short: "encrypt a secret seed and add it to the keystore as keystore:<name>, the file is created when it doesn't exist",
+		Run:   keystoreAdd,
+	})
+	rootCmd.AddCommand(keystoreCmd)
 }
 
-func run(cmd *cobra.Command, args []string) {
-	err := viper.ReadInConfig()
+// keystoreAdd reads a passphrase and a secret seed from the terminal (or two lines of stdin)
+// and adds the seed to the keystore
+func keystoreAdd(cmd *cobra.Command, args []string) {
+	if len(args) != 2 {
+		log.Fatal("Usage: bridge keystore add <file> <name>")
+	}
+
+	stdin := bufio.NewReader(os.Stdin)
+	passphrase := readSecret(stdin, "Keystore passphrase: ")
+	seed := readSecret(stdin, "Secret seed: ")
+	if passphrase == "" {
+		log.Fatal("Passphrase is required")
+	}
+
+	address, err := signer.AddKey(args[0], passphrase, args[1], seed)
 	if err != nil {
-		log.Fatal("Error reading config_bridge.toml file: ", err)
+		log.Fatal(err.Error())
 	}
 
-	var config config.Config
-	err = viper.Unmarshal(&config)
+	fmt.Printf("Added %s (%s), use it as \"%s%s\"\n", args[1], address, signer.PrefixKeystore, args[1])
+}
+
+func readSecret(stdin *bufio.Reader, prompt string) string {
+	fd := int(os.Stdin.Fd())
+	if !terminal.IsTerminal(fd) {
+		line, _ := stdin.ReadString('\n')
+		return strings.TrimSpace(line)
+	}
 
-	err = config.Validate()
+	fmt.Fprint(os.Stderr, prompt)
+	secret, err := terminal.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
 	if err != nil {
 		log.Fatal(err.Error())
+	}
+	return strings.TrimSpace(string(secret))
+}
+
+func run(cmd *cobra.Command, args []string) {
+	if checkConfigFlag {
+		checkConfig()
 		return
 	}
 
-	if config.LogFormat == "json" {
+	cfg, err := loadConfig()
+	if err != nil {
+		log.Fatal(err.Error())
+		return
+	}
+
+	if cfg.LogFormat == "json" {
 		log.SetFormatter(&log.JSONFormatter{})
 	}
 
-	app, err = bridge.NewApp(config, migrateFlag)
+	if cfg.LogLevel != "" {
+		// Validated by Config.Validate
+		level, _ := log.ParseLevel(cfg.LogLevel)
+		log.SetLevel(level)
+	}
+
+	if migrateFlag && migrateCommand == "" {
+		migrateCommand = db.MigrateCommandUp
+	}
+
+	bridge.Version = version
+	app, err = bridge.NewApp(cfg, migrateCommand, migrateLimit)
 
 	if err != nil {
 		log.Fatal(err.Error())
 		return
 	}
 
+	go reloadOnSIGHUP()
 	app.Serve()
 }
+
+// loadConfig reads and validates config_bridge.toml file
+func loadConfig() (cfg config.Config, err error) {
+	cfg, err = readConfig()
+	if err != nil {
+		return
+	}
+
+	err = cfg.Validate()
+	return
+}
+
+// readConfig reads config_bridge.toml file and BRIDGE_* environment variables without
+// validating the config. The file is optional when params are set using variables.
+func readConfig() (cfg config.Config, err error) {
+	err = viper.ReadInConfig()
+	if _, notFound := err.(viper.ConfigFileNotFoundError); notFound {
+		log.Warning("config_bridge.toml file not found, using environment variables only")
+		err = nil
+	}
+	if err != nil {
+		err = fmt.Errorf("Error reading config_bridge.toml file: %s", err)
+		return
+	}
+
+	settings := viper.AllSettings()
+	err = config.ApplyEnv(settings, config.EnvPrefix, os.LookupEnv)
+	if err != nil {
+		return
+	}
+
+	err = config.Decode(settings, &cfg)
+	if err != nil {
+		err = fmt.Errorf("Error decoding config_bridge.toml file: %s", err)
+		return
+	}
+
+	cfg.Sandbox = cfg.Sandbox || sandboxFlag
+	return
+}
+
+// checkConfig prints results of bridge.CheckConfig and exits with status 1 when any check failed
+func checkConfig() {
+	cfg, err := readConfig()
+	if err != nil {
+		fmt.Println("FAIL", err)
+		os.Exit(1)
+	}
+
+	// Results are printed, logs of Horizon requests would only obscure them
+	log.SetLevel(log.PanicLevel)
+
+	failed := 0
+	for _, result := range bridge.CheckConfig(cfg) {
+		if result.Err == nil {
+			fmt.Println("OK  ", result.Name)
+			continue
+		}
+
+		failed++
+		fmt.Printf("FAIL %s: %s\n", result.Name, result.Err)
+		fmt.Printf("     %s\n", result.Hint)
+	}
+
+	if failed > 0 {
+		fmt.Printf("%d checks failed\n", failed)
+		os.Exit(1)
+	}
+	fmt.Println("Config is valid")
+}
+
+// reloadOnSIGHUP reloads config_bridge.toml file every time SIGHUP is received. Invalid
+// config is logged and the server keeps using the current one.
+func reloadOnSIGHUP() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+
+	for range signals {
+		log.Info("SIGHUP received, reloading config_bridge.toml file")
+		cfg, err := loadConfig()
+		if err != nil {
+			log.WithField("err", err).Error("Config not reloaded")
+			continue
+		}
+
+		app.Reload(cfg)
+	}
+}
@@ -8,11 +8,14 @@ import (
 	"github.com/spf13/viper"
 	"github.com/stellar/gateway/compliance"
 	"github.com/stellar/gateway/compliance/config"
+	"github.com/stellar/gateway/db"
 )
 
 var app *compliance.App
 var rootCmd *cobra.Command
 var migrateFlag bool
+var migrateCommand string
+var migrateLimit int
 
 func main() {
 	runtime.GOMAXPROCS(runtime.NumCPU())
@@ -31,7 +34,9 @@ func init() {
 		Run:   run,
 	}
 
-	rootCmd.Flags().BoolVarP(&migrateFlag, "migrate-db", "", false, "migrate DB to the newest schema version")
+	rootCmd.Flags().StringVarP(&migrateCommand, "migrate", "", "", "run DB migrations: up, down or status")
+	rootCmd.Flags().IntVarP(&migrateLimit, "migrate-limit", "", 0, "maximum number of migrations applied by up (default all) or reverted by down (default 1)")
+	rootCmd.Flags().BoolVarP(&migrateFlag, "migrate-db", "", false, "migrate DB to the newest schema version (deprecated, use --migrate up)")
 }
 
 func run(cmd *cobra.Command, args []string) {
@@ -53,7 +58,17 @@ func run(cmd *cobra.Command, args []string) {
 		log.SetFormatter(&log.JSONFormatter{})
 	}
 
-	app, err = compliance.NewApp(config, migrateFlag)
+	if config.LogLevel != "" {
+		// Validated by Config.Validate
+		level, _ := log.ParseLevel(config.LogLevel)
+		log.SetLevel(level)
+	}
+
+	if migrateFlag && migrateCommand == "" {
+		migrateCommand = db.MigrateCommandUp
+	}
+
+	app, err = compliance.NewApp(config, migrateCommand, migrateLimit)
 
 	if err != nil {
 		log.Fatal(err.Error())
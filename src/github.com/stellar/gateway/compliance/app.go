@@ -5,21 +5,36 @@ import (
 	log "github.com/Sirupsen/logrus"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/facebookgo/inject"
 	"github.com/stellar/gateway/compliance/config"
 	"github.com/stellar/gateway/compliance/handlers"
+	"github.com/stellar/gateway/compliance/sanctions"
+	"github.com/stellar/gateway/compliance/senderinfo"
 	"github.com/stellar/gateway/crypto"
 	"github.com/stellar/gateway/db"
 	"github.com/stellar/gateway/db/drivers/mysql"
 	"github.com/stellar/gateway/db/drivers/postgres"
+	"github.com/stellar/gateway/db/drivers/sqlite"
+	"github.com/stellar/gateway/metrics"
+	"github.com/stellar/gateway/mtls"
+	"github.com/stellar/gateway/protocols/compliance"
 	"github.com/stellar/gateway/protocols/federation"
 	"github.com/stellar/gateway/protocols/stellartoml"
 	"github.com/stellar/gateway/server"
+	"github.com/stellar/gateway/tracing"
 	"github.com/zenazn/goji/graceful"
 	"github.com/zenazn/goji/web"
 )
 
+// defaultSanctionsRefreshInterval is used when sanctions.refresh_interval is not set
+const defaultSanctionsRefreshInterval = 24 * time.Hour
+
+// defaultRateLimitEndpoints are limited when rate_limit.endpoints is not set: the auth endpoint
+// of the external server and /receive of the internal server
+var defaultRateLimitEndpoints = []string{"/", "/receive"}
+
 // App is the application object
 type App struct {
 	config         config.Config
@@ -27,7 +42,8 @@ type App struct {
 }
 
 // NewApp constructs an new App instance from the provided config.
-func NewApp(config config.Config, migrateFlag bool) (app *App, err error) {
+// When migrateCommand is not empty it runs the migrate command (see db.Migrate) and exits.
+func NewApp(config config.Config, migrateCommand string, migrateLimit int) (app *App, err error) {
 	var g inject.Graph
 
 	var driver db.Driver
@@ -36,6 +52,8 @@ func NewApp(config config.Config, migrateFlag bool) (app *App, err error) {
 		driver = &mysql.Driver{}
 	case "postgres":
 		driver = &postgres.Driver{}
+	case "sqlite3":
+		driver = &sqlite.Driver{}
 	default:
 		return nil, fmt.Errorf("%s database has no driver", config.Database.Type)
 	}
@@ -48,29 +66,42 @@ func NewApp(config config.Config, migrateFlag bool) (app *App, err error) {
 	entityManager := db.NewEntityManager(driver)
 	repository := db.NewRepository(driver)
 
-	if migrateFlag {
-		var migrationsApplied int
-		migrationsApplied, err = driver.MigrateUp("compliance")
+	if migrateCommand != "" {
+		err = db.Migrate(driver, "compliance", migrateCommand, migrateLimit)
 		if err != nil {
 			return
 		}
 
-		log.Info("Applied migrations: ", migrationsApplied)
 		os.Exit(0)
 		return
 	}
 
+	if config.Tracing.Enabled() {
+		tracing.Default = config.Tracing.NewTracer("compliance")
+		tracing.Default.Start()
+	}
+
+	httpClient := &http.Client{}
 	requestHandler := handlers.RequestHandler{}
 
+	requestHandler.SenderInfo, err = newSenderInfoFetcher(config, httpClient, repository)
+	if err != nil {
+		return
+	}
+
+	if len(config.Sanctions.Lists) > 0 {
+		requestHandler.Sanctions = newSanctionsScreener(config)
+	}
+
 	err = g.Provide(
 		&inject.Object{Value: &requestHandler},
 		&inject.Object{Value: &config},
 		&inject.Object{Value: &entityManager},
 		&inject.Object{Value: &repository},
 		&inject.Object{Value: &crypto.SignerVerifier{}},
-		&inject.Object{Value: &stellartoml.Resolver{}},
+		&inject.Object{Value: stellartoml.NewResolver(time.Duration(config.StellarToml.CacheTTL) * time.Second)},
 		&inject.Object{Value: &federation.Resolver{}},
-		&inject.Object{Value: &http.Client{}},
+		&inject.Object{Value: httpClient},
 	)
 
 	if err != nil {
@@ -81,6 +112,12 @@ func NewApp(config config.Config, migrateFlag bool) (app *App, err error) {
 		log.Fatal("Injector: ", err)
 	}
 
+	encryptionKey, err := compliance.EncryptionPublicKey(config.Keys.EncryptionKey)
+	if err != nil {
+		return
+	}
+	log.Println("ENCRYPTION_KEY to publish in stellar.toml:", encryptionKey)
+
 	app = &App{
 		config:         config,
 		requestHandler: requestHandler,
@@ -88,13 +125,102 @@ func NewApp(config config.Config, migrateFlag bool) (app *App, err error) {
 	return
 }
 
+// newSenderInfoFetcher creates a cached sender info fetcher using configured backend.
+// Returns nil when sender info should not be sent.
+func newSenderInfoFetcher(c config.Config, client *http.Client, repository db.RepositoryInterface) (senderinfo.FetcherInterface, error) {
+	if c.KYC.Store {
+		// Store entries can be updated at any time so they are not cached
+		return &senderinfo.StoreFetcher{Repository: repository}, nil
+	}
+
+	if c.SenderInfo.Backend == config.SenderInfoBackendStatic {
+		senders := make(map[string]map[string]string)
+		for _, sender := range c.SenderInfo.Static {
+			senders[sender.Sender] = sender.Info
+		}
+
+		// Static info is already in memory so it's not cached
+		fetcher, err := senderinfo.NewStaticFetcher(senders)
+		if err != nil {
+			return nil, err
+		}
+		return fetcher, nil
+	}
+
+	if c.Callbacks.FetchInfo == "" {
+		return nil, nil
+	}
+
+	fetcher := &senderinfo.CallbackFetcher{
+		URL:    c.Callbacks.FetchInfo,
+		Fields: c.SenderInfo.Fields,
+		Client: client,
+	}
+	return senderinfo.NewCache(fetcher, time.Duration(c.SenderInfo.CacheTTL)*time.Second), nil
+}
+
+// newSanctionsScreener creates a Screener of configured sanctions lists and starts loading them
+func newSanctionsScreener(c config.Config) *sanctions.Screener {
+	lists := []sanctions.List{}
+	for _, list := range c.Sanctions.Lists {
+		lists = append(lists, sanctions.List{Source: list.Source, Format: list.Format})
+	}
+
+	refreshInterval := defaultSanctionsRefreshInterval
+	if c.Sanctions.RefreshInterval > 0 {
+		refreshInterval = time.Duration(c.Sanctions.RefreshInterval) * time.Second
+	}
+
+	// Lists are large so downloads have a longer timeout than callbacks
+	screener := sanctions.NewScreener(lists, refreshInterval, &http.Client{Timeout: 5 * time.Minute})
+	screener.Start()
+	return screener
+}
+
+// pruneAuthNonces periodically deletes nonces that cannot be replayed anymore. Requests are
+// accepted up to the window before and after their timestamp so nonces are kept for 2 windows.
+func (a *App) pruneAuthNonces() {
+	window := time.Duration(a.config.ReplayProtection.Window) * time.Second
+	for range time.Tick(window) {
+		err := a.requestHandler.Repository.DeleteAuthNoncesBefore(time.Now().Add(-2 * window))
+		if err != nil {
+			log.WithFields(log.Fields{"err": err}).Error("Error deleting auth nonces")
+		}
+	}
+}
+
 // Serve starts the server
 func (a *App) Serve() {
+	if a.config.ReplayProtection.Enabled() {
+		go a.pruneAuthNonces()
+	}
+
+	var rateLimitMiddleware func(next http.Handler) http.Handler
+	if a.config.RateLimit.Enabled() {
+		// redis_url is checked in config validation
+		limiter, _ := a.config.RateLimit.NewLimiter("compliance:rate_limit:")
+		endpoints := a.config.RateLimit.Endpoints
+		if len(endpoints) == 0 {
+			endpoints = defaultRateLimitEndpoints
+		}
+		rateLimitMiddleware = server.RateLimitMiddleware(endpoints, limiter, a.config.RateLimit.TrustProxy)
+	}
+
 	// External endpoints
 	external := web.New()
 	external.Use(server.StripTrailingSlashMiddleware())
 	external.Use(server.HeadersMiddleware())
+	external.Use(server.RequestIDMiddleware())
+	external.Use(server.TracingMiddleware())
+	if rateLimitMiddleware != nil {
+		external.Use(rateLimitMiddleware)
+	}
 	external.Post("/", a.requestHandler.HandlerAuth)
+	if a.config.Sep12.Enabled() {
+		external.Get("/sep12/customer", a.requestHandler.HandlerSep12Customer)
+		external.Put("/sep12/customer", a.requestHandler.HandlerSep12PutCustomer)
+		external.Delete("/sep12/customer/:account", a.requestHandler.HandlerSep12DeleteCustomer)
+	}
 	externalPortString := fmt.Sprintf(":%d", *a.config.ExternalPort)
 	log.Println("Starting external server on", externalPortString)
 	go func() {
@@ -119,14 +245,52 @@ func (a *App) Serve() {
 	internal := web.New()
 	internal.Use(server.StripTrailingSlashMiddleware())
 	internal.Use(server.HeadersMiddleware())
+	internal.Use(server.RequestIDMiddleware())
+	internal.Use(server.TracingMiddleware())
+	if rateLimitMiddleware != nil {
+		internal.Use(rateLimitMiddleware)
+	}
 	internal.Post("/send", a.requestHandler.HandlerSend)
 	internal.Post("/receive", a.requestHandler.HandlerReceive)
 	internal.Post("/allow_access", a.requestHandler.HandlerAllowAccess)
 	internal.Post("/remove_access", a.requestHandler.HandlerRemoveAccess)
+	internal.Get("/metrics", metrics.Handler(metrics.Default))
+	internal.Get("/compliance/transactions", a.requestHandler.HandlerTransactions)
+	internal.Get("/compliance/transactions/:id", a.requestHandler.HandlerTransaction)
+	internal.Post("/compliance/transactions/:id/complete", a.requestHandler.HandlerCompleteTransaction)
+	if a.config.KYC.Store {
+		internal.Get("/kyc/customers", a.requestHandler.HandlerKYCCustomers)
+		internal.Post("/kyc/customers", a.requestHandler.HandlerCreateKYCCustomer)
+		internal.Get("/kyc/customers/:id", a.requestHandler.HandlerKYCCustomer)
+		internal.Put("/kyc/customers/:id", a.requestHandler.HandlerUpdateKYCCustomer)
+		internal.Delete("/kyc/customers/:id", a.requestHandler.HandlerDeleteKYCCustomer)
+	}
+	if a.config.Sep12.Enabled() {
+		internal.Get("/sep12/customers/:id", a.requestHandler.HandlerSep12CustomerDetails)
+		internal.Put("/sep12/customers/:id", a.requestHandler.HandlerSep12UpdateCustomerStatus)
+	}
 	internalPortString := fmt.Sprintf(":%d", *a.config.InternalPort)
 	log.Println("Starting internal server on", internalPortString)
-	err := graceful.ListenAndServe(internalPortString, internal)
+	var err error
+	if a.config.InternalTLS.Enabled() {
+		err = serveTLS(internalPortString, internal, a.config.InternalTLS)
+	} else {
+		err = graceful.ListenAndServe(internalPortString, internal)
+	}
 	if err != nil {
 		log.Fatal(err)
 	}
+
+	// Export spans of requests finished during shutdown
+	tracing.Default.Stop()
+}
+
+// serveTLS serves handler over TLS requiring client certificates when `client_ca_file` is set
+func serveTLS(addr string, handler http.Handler, c mtls.ServerConfig) error {
+	tlsConfig, err := c.TLSConfig()
+	if err != nil {
+		return err
+	}
+	srv := &graceful.Server{Addr: addr, Handler: handler, TLSConfig: tlsConfig}
+	return srv.ListenAndServeTLS(c.CertificateFile, c.PrivateKeyFile)
 }
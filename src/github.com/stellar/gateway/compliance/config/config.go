@@ -4,6 +4,12 @@ import (
 	"errors"
 	"net/url"
 
+	"github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/compliance/sanctions"
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/mtls"
+	"github.com/stellar/gateway/ratelimit"
+	"github.com/stellar/gateway/tracing"
 	"github.com/stellar/go-stellar-base/keypair"
 )
 
@@ -12,6 +18,7 @@ type Config struct {
 	ExternalPort      *int   `mapstructure:"external_port"`
 	InternalPort      *int   `mapstructure:"internal_port"`
 	LogFormat         string `mapstructure:"log_format"`
+	LogLevel          string `mapstructure:"log_level"`
 	NeedsAuth         bool   `mapstructure:"needs_auth"`
 	NetworkPassphrase string `mapstructure:"network_passphrase"`
 	Database          struct {
@@ -20,10 +27,23 @@ type Config struct {
 	}
 	Keys
 	Callbacks
-	TLS struct {
+	SenderInfo  SenderInfo  `mapstructure:"sender_info"`
+	StellarToml StellarToml `mapstructure:"stellar_toml"`
+	KYC         KYC
+	Sanctions   Sanctions
+	Sep12       Sep12
+	TLS         struct {
 		CertificateFile string `mapstructure:"certificate_file"`
 		PrivateKeyFile  string `mapstructure:"private_key_file"`
 	}
+	// Tracing exports OpenTelemetry spans to OTLP/HTTP collector
+	Tracing tracing.Config
+	// RateLimit limits requests of clients to auth and /receive endpoints
+	RateLimit ratelimit.Config `mapstructure:"rate_limit"`
+	// InternalTLS serves the internal server over TLS and can require client certificates
+	InternalTLS mtls.ServerConfig `mapstructure:"internal_tls"`
+	// ReplayProtection verifies timestamps and nonces of received auth requests
+	ReplayProtection ReplayProtection `mapstructure:"replay_protection"`
 }
 
 // Keys contains values of `keys` config group
@@ -39,6 +59,73 @@ type Callbacks struct {
 	FetchInfo string `mapstructure:"fetch_info"`
 }
 
+// KYC contains values of `kyc` config group
+type KYC struct {
+	// Store enables the embedded KYC store which replaces sanctions, ask_user and fetch_info
+	// callbacks and sender_info backend
+	Store bool
+	// UnknownSender is a sanctions status of senders not found in the store: `ok` (default),
+	// `pending` or `denied`
+	UnknownSender string `mapstructure:"unknown_sender"`
+}
+
+// Sanctions contains values of `sanctions` config group. When Lists are set, senders of
+// incoming payments are screened against them before `callbacks.sanctions` or KYC store.
+type Sanctions struct {
+	Lists []SanctionsList
+	// RefreshInterval is a number of seconds between list downloads (default: 86400)
+	RefreshInterval int `mapstructure:"refresh_interval"`
+	// MatchStatus is a sanctions status of senders found in the lists: `denied` (default) or `pending`
+	MatchStatus string `mapstructure:"match_status"`
+}
+
+// SanctionsList contains values of `sanctions.lists` config entry
+type SanctionsList struct {
+	// Source is http(s) URL or a local file path
+	Source string
+	// Format is `ofac_sdn`, `ofac_alt` or `eu`
+	Format string
+}
+
+// ReplayProtection contains values of `replay_protection` config group
+type ReplayProtection struct {
+	// Window is a number of seconds auth requests are accepted for after their timestamp.
+	// Received requests without a timestamp and a nonce are accepted when it's 0.
+	Window int
+}
+
+// Enabled returns true when received auth requests are checked for replays
+func (r ReplayProtection) Enabled() bool {
+	return r.Window > 0
+}
+
+// StellarToml contains values of `stellar_toml` config group
+type StellarToml struct {
+	// CacheTTL is a number of seconds stellar.toml files of other FIs are cached for (default: 300)
+	CacheTTL int `mapstructure:"cache_ttl"`
+}
+
+// Sender info backends
+const (
+	SenderInfoBackendCallback = "callback"
+	SenderInfoBackendStatic   = "static"
+)
+
+// SenderInfo contains values of `sender_info` config group
+type SenderInfo struct {
+	// Backend is `callback` (default) or `static`
+	Backend  string
+	Fields   []string
+	CacheTTL int `mapstructure:"cache_ttl"`
+	Static   []StaticSenderInfo
+}
+
+// StaticSenderInfo contains values of `sender_info.static` config entry
+type StaticSenderInfo struct {
+	Sender string
+	Info   map[string]string
+}
+
 // Validate validates config and returns error if any of config values is incorrect
 func (c *Config) Validate() (err error) {
 	if c.ExternalPort == nil {
@@ -56,6 +143,14 @@ func (c *Config) Validate() (err error) {
 		return
 	}
 
+	if c.LogLevel != "" {
+		_, err = logrus.ParseLevel(c.LogLevel)
+		if err != nil {
+			err = errors.New("Invalid log_level param: " + c.LogLevel)
+			return
+		}
+	}
+
 	if c.Keys.SigningSeed == "" || c.Keys.EncryptionKey == "" {
 		err = errors.New("keys.signing_seed and keys.encryption_key params are required")
 		return
@@ -91,7 +186,7 @@ func (c *Config) Validate() (err error) {
 		query.Set("parseTime", "true")
 		dbURL.RawQuery = query.Encode()
 		c.Database.URL = dbURL.String()
-	case "postgres":
+	case "postgres", "sqlite3":
 		break
 	default:
 		err = errors.New("Invalid database.type param")
@@ -130,5 +225,124 @@ func (c *Config) Validate() (err error) {
 		}
 	}
 
+	switch c.SenderInfo.Backend {
+	case "", SenderInfoBackendCallback:
+		if len(c.SenderInfo.Static) > 0 {
+			err = errors.New("sender_info.static requires sender_info.backend = \"static\"")
+			return
+		}
+	case SenderInfoBackendStatic:
+		for _, sender := range c.SenderInfo.Static {
+			if sender.Sender == "" {
+				err = errors.New("sender_info.static entries require sender param")
+				return
+			}
+		}
+	default:
+		err = errors.New("Invalid sender_info.backend param")
+		return
+	}
+
+	if c.SenderInfo.CacheTTL < 0 {
+		err = errors.New("sender_info.cache_ttl cannot be negative")
+		return
+	}
+
+	if c.ReplayProtection.Window < 0 {
+		err = errors.New("replay_protection.window cannot be negative")
+		return
+	}
+
+	if c.StellarToml.CacheTTL < 0 {
+		err = errors.New("stellar_toml.cache_ttl cannot be negative")
+		return
+	}
+
+	err = c.KYC.validate(c)
+	if err != nil {
+		return
+	}
+
+	err = c.Sanctions.validate()
+	if err != nil {
+		return
+	}
+
+	err = c.Sep12.validate()
+	if err != nil {
+		return
+	}
+
+	err = c.Tracing.Validate()
+	if err != nil {
+		return
+	}
+
+	if c.RateLimit.PerAPIKey.Enabled() {
+		err = errors.New("rate_limit.per_api_key param is not supported by compliance server")
+		return
+	}
+
+	err = c.RateLimit.Validate()
+	if err != nil {
+		return
+	}
+
+	err = c.InternalTLS.Validate()
+	return
+}
+
+func (s Sanctions) validate() (err error) {
+	for _, list := range s.Lists {
+		if list.Source == "" {
+			err = errors.New("sanctions.lists entries require source param")
+			return
+		}
+
+		switch list.Format {
+		case sanctions.FormatOFACSDN, sanctions.FormatOFACAlt, sanctions.FormatEU:
+		default:
+			err = errors.New("Invalid sanctions.lists.format param: " + list.Format)
+			return
+		}
+	}
+
+	if s.RefreshInterval < 0 {
+		err = errors.New("sanctions.refresh_interval cannot be negative")
+		return
+	}
+
+	switch s.MatchStatus {
+	case "", entities.KYCStatusDenied, entities.KYCStatusPending:
+	default:
+		err = errors.New("Invalid sanctions.match_status param")
+	}
+	return
+}
+
+func (k KYC) validate(c *Config) (err error) {
+	if !k.Store {
+		if k.UnknownSender != "" {
+			err = errors.New("kyc.unknown_sender requires kyc.store = true")
+		}
+		return
+	}
+
+	if c.Callbacks.Sanctions != "" || c.Callbacks.AskUser != "" || c.Callbacks.FetchInfo != "" {
+		err = errors.New("callbacks.sanctions, callbacks.ask_user and callbacks.fetch_info cannot be used with kyc.store")
+		return
+	}
+
+	if c.SenderInfo.Backend == SenderInfoBackendStatic {
+		err = errors.New("sender_info.backend = \"static\" cannot be used with kyc.store")
+		return
+	}
+
+	switch k.UnknownSender {
+	case "", entities.KYCStatusOk, entities.KYCStatusPending, entities.KYCStatusDenied:
+	default:
+		err = errors.New("Invalid kyc.unknown_sender param")
+	}
+
 	return
 }
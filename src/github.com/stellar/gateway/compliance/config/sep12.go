@@ -0,0 +1,132 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/stellar/go-stellar-base/strkey"
+)
+
+// Types of SEP-12 fields
+const (
+	Sep12FieldTypeString = "string"
+	Sep12FieldTypeNumber = "number"
+	// Sep12FieldTypeDate values are in YYYY-MM-DD format
+	Sep12FieldTypeDate = "date"
+	// Sep12FieldTypeBinary values are files uploaded in multipart/form-data requests
+	Sep12FieldTypeBinary = "binary"
+)
+
+// Sep12 contains values of `sep12` config group. SEP-12 customer endpoints (/sep12/customer)
+// are enabled on the external server when JWTKey is set.
+type Sep12 struct {
+	// JWTKey verifies SEP-10 tokens issued by the bridge server (its `web_auth.jwt_key`)
+	JWTKey string `mapstructure:"jwt_key"`
+	// AutoAccept sets status of customers who provided all required fields to ACCEPTED.
+	// Otherwise they are PROCESSING until the status is set using internal API.
+	AutoAccept bool `mapstructure:"auto_accept"`
+	// Fields is a schema of SEP-9 fields customers can provide
+	Fields []Sep12Field
+}
+
+// Sep12Field contains values of a single `sep12.fields` config array entry
+type Sep12Field struct {
+	Name        string
+	Type        string
+	Description string
+	Choices     []string
+	Optional    bool
+	// CustomerTypes are values of `type` param the field is required for, ex. `sep31-sender`.
+	// The field is required for all types when empty.
+	CustomerTypes []string `mapstructure:"customer_types"`
+}
+
+// Enabled returns true when SEP-12 endpoints are enabled
+func (s Sep12) Enabled() bool {
+	return s.JWTKey != ""
+}
+
+// Key returns decoded JWTKey
+func (s Sep12) Key() ([]byte, error) {
+	return strkey.Decode(strkey.VersionByteSeed, s.JWTKey)
+}
+
+// FieldFor returns `sep12.fields` entry with given name or nil when it's not found
+func (s Sep12) FieldFor(name string) *Sep12Field {
+	for i := range s.Fields {
+		if s.Fields[i].Name == name {
+			return &s.Fields[i]
+		}
+	}
+	return nil
+}
+
+// FieldsFor returns fields of customers of given type
+func (s Sep12) FieldsFor(customerType string) []Sep12Field {
+	fields := []Sep12Field{}
+	for _, field := range s.Fields {
+		if field.AppliesTo(customerType) {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// AppliesTo returns true when the field is used by customers of given type
+func (f Sep12Field) AppliesTo(customerType string) bool {
+	if len(f.CustomerTypes) == 0 {
+		return true
+	}
+	for _, t := range f.CustomerTypes {
+		if t == customerType {
+			return true
+		}
+	}
+	return false
+}
+
+// FieldType returns Type or Sep12FieldTypeString when it's empty
+func (f Sep12Field) FieldType() string {
+	if f.Type == "" {
+		return Sep12FieldTypeString
+	}
+	return f.Type
+}
+
+func (s Sep12) validate() error {
+	if !s.Enabled() {
+		if len(s.Fields) > 0 {
+			return errors.New("sep12.fields require sep12.jwt_key")
+		}
+		return nil
+	}
+
+	_, err := s.Key()
+	if err != nil {
+		return errors.New("sep12.jwt_key is invalid")
+	}
+
+	names := map[string]bool{}
+	for i, field := range s.Fields {
+		if field.Name == "" {
+			return fmt.Errorf("sep12.fields[%d].name param is required", i)
+		}
+
+		if names[field.Name] {
+			return fmt.Errorf("Duplicate sep12.fields name: %s", field.Name)
+		}
+		names[field.Name] = true
+
+		switch field.FieldType() {
+		case Sep12FieldTypeString, Sep12FieldTypeNumber, Sep12FieldTypeDate, Sep12FieldTypeBinary:
+		default:
+			return fmt.Errorf("Invalid sep12.fields[%d].type param: %s", i, field.Type)
+		}
+
+		if len(field.Choices) > 0 && field.FieldType() != Sep12FieldTypeString {
+			return fmt.Errorf("sep12.fields[%d].choices can be set for string fields only", i)
+		}
+	}
+
+	return nil
+}
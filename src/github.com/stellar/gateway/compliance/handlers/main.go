@@ -2,6 +2,8 @@ package handlers
 
 import (
 	"github.com/stellar/gateway/compliance/config"
+	"github.com/stellar/gateway/compliance/sanctions"
+	"github.com/stellar/gateway/compliance/senderinfo"
 	"github.com/stellar/gateway/crypto"
 	"github.com/stellar/gateway/db"
 	"github.com/stellar/gateway/net"
@@ -18,4 +20,8 @@ type RequestHandler struct {
 	SignatureSignerVerifier crypto.SignerVerifierInterface `inject:""`
 	StellarTomlResolver     stellartoml.ResolverInterface  `inject:""`
 	FederationResolver      federation.ResolverInterface   `inject:""`
+	// SenderInfo is nil when sender info is not sent
+	SenderInfo senderinfo.FetcherInterface
+	// Sanctions is nil when sanctions lists are not configured
+	Sanctions sanctions.ScreenerInterface
 }
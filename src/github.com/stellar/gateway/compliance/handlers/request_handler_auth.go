@@ -15,6 +15,7 @@ import (
 	log "github.com/Sirupsen/logrus"
 
 	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/metrics"
 	"github.com/stellar/gateway/protocols"
 	"github.com/stellar/gateway/protocols/compliance"
 	"github.com/stellar/gateway/protocols/memo"
@@ -47,6 +48,21 @@ func (rh *RequestHandler) HandlerAuth(c web.C, w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	// Data is stored with decrypted memo so /receive returns the memo preimage
+	data := authreq.Data
+	if authData.EncryptedMemo != "" {
+		decryptedMemo, err := compliance.DecryptMemo(authData.EncryptedMemo, rh.Config.Keys.EncryptionKey)
+		if err != nil {
+			errorResponse := protocols.NewInvalidParameterError("data.encrypted_memo", authData.EncryptedMemo)
+			log.WithFields(log.Fields{"err": err}).Warn("Cannot decrypt memo")
+			server.Write(w, errorResponse)
+			return
+		}
+		authData.Memo = string(decryptedMemo)
+		authData.EncryptedMemo = ""
+		data = string(authData.Marshal())
+	}
+
 	senderStellarToml, err := rh.StellarTomlResolver.GetStellarTomlByAddress(authData.Sender)
 	if err != nil {
 		log.WithFields(log.Fields{"err": err, "sender": authData.Sender}).Warn("Cannot get stellar.toml of sender")
@@ -81,6 +97,19 @@ func (rh *RequestHandler) HandlerAuth(c web.C, w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	if rh.Config.ReplayProtection.Enabled() {
+		errorResponse := rh.checkAuthReplay(senderStellarToml.SigningKey, authData)
+		if errorResponse != nil {
+			log.WithFields(log.Fields{
+				"sender":    authData.Sender,
+				"timestamp": authData.Timestamp,
+				"nonce":     authData.Nonce,
+			}).Warn(errorResponse.Error())
+			server.Write(w, errorResponse)
+			return
+		}
+	}
+
 	b64r := base64.NewDecoder(base64.StdEncoding, strings.NewReader(authData.Tx))
 	var tx xdr.Transaction
 	_, err = xdr.Unmarshal(b64r, &tx)
@@ -148,7 +177,35 @@ func (rh *RequestHandler) HandlerAuth(c web.C, w http.ResponseWriter, r *http.Re
 	response := compliance.AuthResponse{}
 
 	// Sanctions check
-	if rh.Config.Callbacks.Sanctions == "" {
+	var sanctionsMatch string
+	if rh.Sanctions != nil {
+		sanctionsMatch, err = rh.Sanctions.Screen(memoPreimage.Transaction.SenderInfo)
+		if err != nil {
+			log.WithFields(log.Fields{"err": err}).Error("Error screening sender against sanctions lists")
+			server.Write(w, protocols.InternalServerError)
+			return
+		}
+	}
+
+	if sanctionsMatch != "" {
+		log.WithFields(log.Fields{"sender": authData.Sender, "match": sanctionsMatch}).Warn("Sender found in sanctions list")
+		response.TxStatus = compliance.AuthStatusDenied
+		if rh.Config.Sanctions.MatchStatus == entities.KYCStatusPending {
+			response.TxStatus = compliance.AuthStatusPending
+			response.Pending = pendingKYCStatus
+		}
+	} else if rh.Config.KYC.Store {
+		response.TxStatus, err = rh.kycSanctionsStatus(authData.Sender)
+		if err != nil {
+			log.WithFields(log.Fields{"err": err}).Error("Error getting sender from KYC store")
+			server.Write(w, protocols.InternalServerError)
+			return
+		}
+
+		if response.TxStatus == compliance.AuthStatusPending {
+			response.Pending = pendingKYCStatus
+		}
+	} else if rh.Config.Callbacks.Sanctions == "" {
 		response.TxStatus = compliance.AuthStatusOk
 	} else {
 		resp, err := rh.Client.PostForm(
@@ -200,7 +257,14 @@ func (rh *RequestHandler) HandlerAuth(c web.C, w http.ResponseWriter, r *http.Re
 
 	// User info
 	if authData.NeedInfo {
-		if rh.Config.Callbacks.AskUser == "" {
+		if rh.Config.KYC.Store {
+			response.InfoStatus, response.DestInfo, err = rh.kycDestInfo(memoPreimage.Transaction.Route)
+			if err != nil {
+				log.WithFields(log.Fields{"err": err}).Error("Error getting receiver from KYC store")
+				server.Write(w, protocols.InternalServerError)
+				return
+			}
+		} else if rh.Config.Callbacks.AskUser == "" {
 			response.InfoStatus = compliance.AuthStatusDenied
 
 			// Check AllowedFi
@@ -303,7 +367,8 @@ func (rh *RequestHandler) HandlerAuth(c web.C, w http.ResponseWriter, r *http.Re
 			}
 		}
 
-		if response.InfoStatus == compliance.AuthStatusOk {
+		// Info of receivers in the KYC store has been already set
+		if response.InfoStatus == compliance.AuthStatusOk && !rh.Config.KYC.Store {
 			// Fetch Info
 			fetchInfoRequest := compliance.FetchInfoRequest{Address: memoPreimage.Transaction.Route}
 			resp, err := rh.Client.PostForm(
@@ -352,7 +417,7 @@ func (rh *RequestHandler) HandlerAuth(c web.C, w http.ResponseWriter, r *http.Re
 			Memo:           base64.StdEncoding.EncodeToString(memoBytes[:]),
 			TransactionXdr: authData.Tx,
 			AuthorizedAt:   time.Now(),
-			Data:           authreq.Data,
+			Data:           data,
 		}
 		err = rh.EntityManager.Persist(authorizedTransaction)
 		if err != nil {
@@ -362,5 +427,48 @@ func (rh *RequestHandler) HandlerAuth(c web.C, w http.ResponseWriter, r *http.Re
 		}
 	}
 
+	err = rh.recordComplianceTransaction(&entities.ComplianceTransaction{
+		Direction:          entities.ComplianceDirectionReceived,
+		TransactionID:      hex.EncodeToString(transactionHash[:]),
+		Memo:               base64.StdEncoding.EncodeToString(memoBytes[:]),
+		Sender:             authData.Sender,
+		Route:              memoPreimage.Transaction.Route,
+		CounterpartyDomain: addressDomain(authData.Sender),
+	}, &response)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error persisting compliance transaction")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	metrics.AddCounter("compliance_auth_requests", 1, metrics.Tags{
+		"info_status": string(response.InfoStatus),
+		"tx_status":   string(response.TxStatus),
+	})
 	server.Write(w, &response)
 }
+
+// checkAuthReplay returns an error response when signed auth data is outside of the replay
+// protection window or its nonce has already been used by signingKey
+func (rh *RequestHandler) checkAuthReplay(signingKey string, authData compliance.AuthData) *protocols.ErrorResponse {
+	window := time.Duration(rh.Config.ReplayProtection.Window) * time.Second
+	now := time.Now()
+	timestamp := time.Unix(authData.Timestamp, 0)
+	if authData.Timestamp == 0 || timestamp.Before(now.Add(-window)) || timestamp.After(now.Add(window)) {
+		return compliance.AuthRequestExpired
+	}
+
+	if authData.Nonce == "" || len(authData.Nonce) > 64 {
+		return protocols.NewInvalidParameterError("data.nonce", authData.Nonce)
+	}
+
+	saved, err := rh.Repository.SaveAuthNonce(signingKey, authData.Nonce, now)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error saving auth nonce")
+		return protocols.InternalServerError
+	}
+	if !saved {
+		return compliance.AuthRequestReplayed
+	}
+	return nil
+}
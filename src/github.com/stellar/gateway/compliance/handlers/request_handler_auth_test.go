@@ -36,6 +36,8 @@ func TestRequestHandlerAuth(t *testing.T) {
 	mockHTTPClient := new(mocks.MockHTTPClient)
 	mockEntityManager := new(mocks.MockEntityManager)
 	mockRepository := new(mocks.MockRepository)
+	mockRepository.On("GetComplianceTransactionByTransactionID", mock.Anything, mock.Anything).Return(nil, nil)
+	mockEntityManager.On("Persist", mock.AnythingOfType("*entities.ComplianceTransaction")).Return(nil)
 	mockFederationResolver := new(mocks.MockFederationResolver)
 	mockSignerVerifier := new(mocks.MockSignerVerifier)
 	mockStellartomlResolver := new(mocks.MockStellartomlResolver)
@@ -649,3 +651,30 @@ func TestRequestHandlerAuth(t *testing.T) {
 		})
 	})
 }
+
+func TestCheckAuthReplay(t *testing.T) {
+	mockRepository := new(mocks.MockRepository)
+	rh := RequestHandler{
+		Config:     &config.Config{ReplayProtection: config.ReplayProtection{Window: 300}},
+		Repository: mockRepository,
+	}
+	signingKey := "GBYJZW5XFAI6XV73H5SAIUYK6XZI4CGGVBUBO3ANA2SV7KKDAXTV6AEB"
+	now := time.Now().Unix()
+
+	assert.Equal(t, compliance.AuthRequestExpired, rh.checkAuthReplay(signingKey, compliance.AuthData{Nonce: "a"}))
+	assert.Equal(t, compliance.AuthRequestExpired, rh.checkAuthReplay(signingKey, compliance.AuthData{Timestamp: now - 301, Nonce: "a"}))
+	assert.Equal(t, compliance.AuthRequestExpired, rh.checkAuthReplay(signingKey, compliance.AuthData{Timestamp: now + 301, Nonce: "a"}))
+
+	errorResponse := rh.checkAuthReplay(signingKey, compliance.AuthData{Timestamp: now})
+	if assert.NotNil(t, errorResponse) {
+		assert.Equal(t, "invalid_parameter", errorResponse.Code)
+	}
+
+	mockRepository.On("SaveAuthNonce", signingKey, "a", mock.AnythingOfType("time.Time")).Return(true, nil).Once()
+	assert.Nil(t, rh.checkAuthReplay(signingKey, compliance.AuthData{Timestamp: now - 60, Nonce: "a"}))
+
+	mockRepository.On("SaveAuthNonce", signingKey, "a", mock.AnythingOfType("time.Time")).Return(false, nil).Once()
+	assert.Equal(t, compliance.AuthRequestReplayed, rh.checkAuthReplay(signingKey, compliance.AuthData{Timestamp: now, Nonce: "a"}))
+
+	mockRepository.AssertExpectations(t)
+}
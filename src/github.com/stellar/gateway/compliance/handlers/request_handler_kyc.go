@@ -0,0 +1,200 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/protocols"
+	"github.com/stellar/gateway/protocols/compliance"
+	"github.com/stellar/gateway/server"
+	"github.com/zenazn/goji/web"
+)
+
+// pendingKYCStatus is a number of seconds sent in `pending` field of auth responses when
+// a status in the KYC store is pending
+const pendingKYCStatus = 600
+
+// HandlerKYCCustomers implements GET /kyc/customers endpoint
+func (rh *RequestHandler) HandlerKYCCustomers(c web.C, w http.ResponseWriter, r *http.Request) {
+	customers, err := rh.Repository.GetKYCCustomers()
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error getting KYC customers")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	response := compliance.KYCCustomersResponse{Customers: []compliance.KYCCustomer{}}
+	for i := range customers {
+		response.Customers = append(response.Customers, compliance.NewKYCCustomer(&customers[i]))
+	}
+
+	server.Write(w, &response)
+}
+
+// HandlerKYCCustomer implements GET /kyc/customers/:id endpoint
+func (rh *RequestHandler) HandlerKYCCustomer(c web.C, w http.ResponseWriter, r *http.Request) {
+	customer := rh.loadKYCCustomer(c, w)
+	if customer == nil {
+		return
+	}
+
+	server.Write(w, &compliance.KYCCustomerResponse{KYCCustomer: compliance.NewKYCCustomer(customer)})
+}
+
+// HandlerCreateKYCCustomer implements POST /kyc/customers endpoint
+func (rh *RequestHandler) HandlerCreateKYCCustomer(c web.C, w http.ResponseWriter, r *http.Request) {
+	customer := &entities.KYCCustomer{CreatedAt: time.Now()}
+	rh.saveKYCCustomer(w, r, customer)
+}
+
+// HandlerUpdateKYCCustomer implements PUT /kyc/customers/:id endpoint
+func (rh *RequestHandler) HandlerUpdateKYCCustomer(c web.C, w http.ResponseWriter, r *http.Request) {
+	customer := rh.loadKYCCustomer(c, w)
+	if customer == nil {
+		return
+	}
+
+	rh.saveKYCCustomer(w, r, customer)
+}
+
+// HandlerDeleteKYCCustomer implements DELETE /kyc/customers/:id endpoint
+func (rh *RequestHandler) HandlerDeleteKYCCustomer(c web.C, w http.ResponseWriter, r *http.Request) {
+	customer := rh.loadKYCCustomer(c, w)
+	if customer == nil {
+		return
+	}
+
+	err := rh.EntityManager.Delete(customer)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error deleting KYC customer")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// saveKYCCustomer sets customer fields using request params and persists it
+func (rh *RequestHandler) saveKYCCustomer(w http.ResponseWriter, r *http.Request, customer *entities.KYCCustomer) {
+	request := &compliance.KYCCustomerRequest{}
+	request.FromRequest(r)
+
+	err := request.Validate()
+	if err != nil {
+		errorResponse := err.(*protocols.ErrorResponse)
+		log.WithFields(errorResponse.LogData).Error(errorResponse.Error())
+		server.Write(w, errorResponse)
+		return
+	}
+
+	taken, err := rh.isKYCCustomerTaken(request, customer)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error getting KYC customer")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	if taken {
+		server.Write(w, compliance.KYCCustomerAddressTaken)
+		return
+	}
+
+	customer.Address = request.Address
+	customer.Route = nil
+	if request.Route != "" {
+		customer.Route = &request.Route
+	}
+	customer.Info = request.Info
+	if customer.Info == "" {
+		customer.Info = "{}"
+	}
+	customer.Status = request.Status
+	if customer.Status == "" {
+		customer.Status = entities.KYCStatusOk
+	}
+	customer.ShareInfo = request.ShareInfo == "true"
+	customer.UpdatedAt = time.Now()
+
+	err = rh.EntityManager.Persist(customer)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error persisting KYC customer")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	server.Write(w, &compliance.KYCCustomerResponse{KYCCustomer: compliance.NewKYCCustomer(customer)})
+}
+
+// isKYCCustomerTaken returns true when address or route in request is assigned to a customer
+// other than current
+func (rh *RequestHandler) isKYCCustomerTaken(request *compliance.KYCCustomerRequest, current *entities.KYCCustomer) (bool, error) {
+	isOther := func(existing *entities.KYCCustomer) bool {
+		return existing != nil && (current.IsNew() || *existing.ID != *current.ID)
+	}
+
+	existing, err := rh.Repository.GetKYCCustomerByAddress(request.Address)
+	if err != nil || isOther(existing) || request.Route == "" {
+		return isOther(existing), err
+	}
+
+	existing, err = rh.Repository.GetKYCCustomerByRoute(request.Route)
+	return isOther(existing), err
+}
+
+// loadKYCCustomer finds a KYC customer using `id` URL param. When customer cannot be
+// found it writes an error response and returns nil.
+func (rh *RequestHandler) loadKYCCustomer(c web.C, w http.ResponseWriter) *entities.KYCCustomer {
+	id, err := strconv.ParseInt(c.URLParams["id"], 10, 64)
+	if err != nil {
+		server.Write(w, compliance.KYCCustomerNotFound)
+		return nil
+	}
+
+	customer, err := rh.Repository.GetKYCCustomerByID(id)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error getting KYC customer")
+		server.Write(w, protocols.InternalServerError)
+		return nil
+	}
+
+	if customer == nil {
+		server.Write(w, compliance.KYCCustomerNotFound)
+		return nil
+	}
+
+	return customer
+}
+
+// kycSanctionsStatus returns a sanctions status of sender using the KYC store
+func (rh *RequestHandler) kycSanctionsStatus(sender string) (compliance.AuthStatus, error) {
+	customer, err := rh.Repository.GetKYCCustomerByAddress(sender)
+	if err != nil {
+		return "", err
+	}
+
+	status := rh.Config.KYC.UnknownSender
+	if customer != nil {
+		status = customer.Status
+	}
+	if status == "" {
+		status = entities.KYCStatusOk
+	}
+	return compliance.AuthStatus(status), nil
+}
+
+// kycDestInfo returns compliance information of the customer receiving payments with route
+// when the customer allows sharing it. Info status is denied when the customer is not found.
+func (rh *RequestHandler) kycDestInfo(route string) (compliance.AuthStatus, string, error) {
+	customer, err := rh.Repository.GetKYCCustomerByRoute(route)
+	if err != nil {
+		return "", "", err
+	}
+
+	if customer == nil || !customer.ShareInfo {
+		return compliance.AuthStatusDenied, "", nil
+	}
+	return compliance.AuthStatusOk, customer.Info, nil
+}
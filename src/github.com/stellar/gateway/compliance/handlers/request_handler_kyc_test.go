@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stellar/gateway/compliance/config"
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/mocks"
+	"github.com/stellar/gateway/protocols/compliance"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/zenazn/goji/web"
+)
+
+func TestKYCStore(t *testing.T) {
+	mockRepository := new(mocks.MockRepository)
+	rh := RequestHandler{
+		Config:     &config.Config{KYC: config.KYC{Store: true, UnknownSender: "pending"}},
+		Repository: mockRepository,
+	}
+
+	id := int64(1)
+	route := "alice"
+	alice := &entities.KYCCustomer{
+		ID:        &id,
+		Address:   "alice*acme.com",
+		Route:     &route,
+		Info:      `{"first_name":"Alice"}`,
+		Status:    entities.KYCStatusDenied,
+		ShareInfo: true,
+	}
+	alice.SetExists()
+
+	mockRepository.On("GetKYCCustomerByAddress", "alice*acme.com").Return(alice, nil)
+	mockRepository.On("GetKYCCustomerByAddress", "bob*acme.com").Return(nil, nil)
+	mockRepository.On("GetKYCCustomerByRoute", "alice").Return(alice, nil)
+	mockRepository.On("GetKYCCustomerByRoute", "bob").Return(nil, nil)
+
+	status, err := rh.kycSanctionsStatus("alice*acme.com")
+	require.NoError(t, err)
+	assert.Equal(t, compliance.AuthStatusDenied, status)
+
+	status, err = rh.kycSanctionsStatus("bob*acme.com")
+	require.NoError(t, err)
+	assert.Equal(t, compliance.AuthStatusPending, status)
+
+	status, info, err := rh.kycDestInfo("alice")
+	require.NoError(t, err)
+	assert.Equal(t, compliance.AuthStatusOk, status)
+	assert.Equal(t, `{"first_name":"Alice"}`, info)
+
+	status, _, err = rh.kycDestInfo("bob")
+	require.NoError(t, err)
+	assert.Equal(t, compliance.AuthStatusDenied, status)
+}
+
+func TestHandlerCreateKYCCustomer(t *testing.T) {
+	mockRepository := new(mocks.MockRepository)
+	mockEntityManager := new(mocks.MockEntityManager)
+	rh := RequestHandler{
+		Config:        &config.Config{KYC: config.KYC{Store: true}},
+		Repository:    mockRepository,
+		EntityManager: mockEntityManager,
+	}
+
+	id := int64(2)
+	existing := &entities.KYCCustomer{ID: &id, Address: "alice*acme.com"}
+	existing.SetExists()
+
+	mockRepository.On("GetKYCCustomerByAddress", "alice*acme.com").Return(existing, nil)
+	mockRepository.On("GetKYCCustomerByAddress", "bob*acme.com").Return(nil, nil)
+	mockRepository.On("GetKYCCustomerByRoute", "bob").Return(nil, nil)
+	mockEntityManager.On("Persist", mock.AnythingOfType("*entities.KYCCustomer")).Return(nil).Run(func(args mock.Arguments) {
+		args.Get(0).(*entities.KYCCustomer).SetID(3)
+	}).Once()
+
+	post := func(values url.Values) *httptest.ResponseRecorder {
+		r, _ := http.NewRequest("POST", "/kyc/customers", strings.NewReader(values.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		rh.HandlerCreateKYCCustomer(web.C{}, w, r)
+		return w
+	}
+
+	w := post(url.Values{"address": {"alice*acme.com"}})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "kyc_customer_address_taken")
+
+	w = post(url.Values{"address": {"bob*acme.com"}, "info": {"not json"}})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid_parameter")
+
+	w = post(url.Values{"address": {"bob*acme.com"}, "route": {"bob"}, "info": {`{"first_name":"Bob"}`}, "share_info": {"true"}})
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"first_name": "Bob"`)
+	assert.Contains(t, w.Body.String(), `"status": "ok"`)
+	assert.Contains(t, w.Body.String(), `"share_info": true`)
+
+	mockEntityManager.AssertExpectations(t)
+}
@@ -4,6 +4,7 @@ import (
 	log "github.com/Sirupsen/logrus"
 	"net/http"
 
+	"github.com/stellar/gateway/db/entities"
 	"github.com/stellar/gateway/protocols"
 	"github.com/stellar/gateway/protocols/compliance"
 	"github.com/stellar/gateway/server"
@@ -36,6 +37,19 @@ func (rh *RequestHandler) HandlerReceive(c web.C, w http.ResponseWriter, r *http
 		return
 	}
 
+	// The payment has been received so the compliance transaction is completed. /receive is
+	// sent by the bridge server processing the payment so errors do not fail the request.
+	transaction, err := rh.Repository.GetComplianceTransactionByTransactionID(
+		entities.ComplianceDirectionReceived,
+		authorizedTransaction.TransactionID,
+	)
+	if err == nil && transaction != nil {
+		err = rh.completeComplianceTransaction(transaction)
+	}
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error completing compliance transaction")
+	}
+
 	response := compliance.ReceiveResponse{Data: authorizedTransaction.Data}
 	server.Write(w, &response)
 }
@@ -15,6 +15,7 @@ import (
 	"github.com/stellar/gateway/net"
 	"github.com/stellar/gateway/protocols/compliance"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/zenazn/goji/web"
 )
 
@@ -30,6 +31,7 @@ func TestRequestHandlerReceive(t *testing.T) {
 	mockHTTPClient := new(mocks.MockHTTPClient)
 	mockEntityManager := new(mocks.MockEntityManager)
 	mockRepository := new(mocks.MockRepository)
+	mockRepository.On("GetComplianceTransactionByTransactionID", mock.Anything, mock.Anything).Return(nil, nil)
 	mockFederationResolver := new(mocks.MockFederationResolver)
 	mockSignerVerifier := new(mocks.MockSignerVerifier)
 	mockStellartomlResolver := new(mocks.MockStellartomlResolver)
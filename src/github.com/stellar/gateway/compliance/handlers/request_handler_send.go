@@ -4,16 +4,21 @@ import (
 	"bytes"
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
+	"time"
 
 	log "github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/compliance/senderinfo"
+	"github.com/stellar/gateway/db/entities"
 	"github.com/stellar/gateway/protocols"
 	"github.com/stellar/gateway/protocols/compliance"
 	"github.com/stellar/gateway/protocols/memo"
 	"github.com/stellar/gateway/server"
 	"github.com/stellar/gateway/submitter"
+	"github.com/stellar/gateway/tracing"
 	b "github.com/stellar/go-stellar-base/build"
 	"github.com/stellar/go-stellar-base/xdr"
 	"github.com/zenazn/goji/web"
@@ -21,20 +26,21 @@ import (
 
 // HandlerSend implements /send endpoint
 func (rh *RequestHandler) HandlerSend(c web.C, w http.ResponseWriter, r *http.Request) {
+	logger := server.Logger(r)
 	request := &compliance.SendRequest{}
 	request.FromRequest(r)
 
 	err := request.Validate()
 	if err != nil {
 		errorResponse := err.(*protocols.ErrorResponse)
-		log.WithFields(errorResponse.LogData).Error(errorResponse.Error())
+		logger.WithFields(errorResponse.LogData).Error(errorResponse.Error())
 		server.Write(w, errorResponse)
 		return
 	}
 
 	destinationObject, stellarToml, err := rh.FederationResolver.Resolve(request.Destination)
 	if err != nil {
-		log.WithFields(log.Fields{
+		logger.WithFields(log.Fields{
 			"destination": request.Destination,
 			"err":         err,
 		}).Print("Cannot resolve address")
@@ -43,7 +49,7 @@ func (rh *RequestHandler) HandlerSend(c web.C, w http.ResponseWriter, r *http.Re
 	}
 
 	if stellarToml.AuthServer == "" {
-		log.Print("No AUTH_SERVER in stellar.toml")
+		logger.Print("No AUTH_SERVER in stellar.toml")
 		server.Write(w, compliance.AuthServerNotDefined)
 		return
 	}
@@ -58,7 +64,7 @@ func (rh *RequestHandler) HandlerSend(c web.C, w http.ResponseWriter, r *http.Re
 		} else if request.SendAssetCode == "" && request.SendAssetIssuer == "" {
 			sendAsset = b.NativeAsset()
 		} else {
-			log.Print("Missing send asset param.")
+			logger.Print("Missing send asset param.")
 			server.Write(w, protocols.MissingParameterError)
 			return
 		}
@@ -91,7 +97,7 @@ func (rh *RequestHandler) HandlerSend(c web.C, w http.ResponseWriter, r *http.Re
 
 	operationMutator := b.Payment(mutators...)
 	if operationMutator.Err != nil {
-		log.WithFields(log.Fields{
+		logger.WithFields(log.Fields{
 			"err": operationMutator.Err,
 		}).Error("Error creating operation")
 		server.Write(w, protocols.InternalServerError)
@@ -101,43 +107,33 @@ func (rh *RequestHandler) HandlerSend(c web.C, w http.ResponseWriter, r *http.Re
 	// Fetch Sender Info
 	senderInfo := ""
 
-	if rh.Config.Callbacks.FetchInfo != "" {
-		fetchInfoRequest := compliance.FetchInfoRequest{Address: request.Sender}
-		resp, err := rh.Client.PostForm(
-			rh.Config.Callbacks.FetchInfo,
-			fetchInfoRequest.ToValues(),
-		)
-		if err != nil {
-			log.WithFields(log.Fields{
-				"fetch_info": rh.Config.Callbacks.FetchInfo,
-				"err":        err,
-			}).Error("Error sending request to fetch_info server")
-			server.Write(w, protocols.InternalServerError)
+	if request.SenderCustomerID != "" {
+		if !rh.Config.Sep12.Enabled() {
+			server.Write(w, protocols.NewInvalidParameterError("sender_customer_id", request.SenderCustomerID))
 			return
 		}
 
-		defer resp.Body.Close()
-		body, err := ioutil.ReadAll(resp.Body)
-		if err != nil {
-			log.WithFields(log.Fields{
-				"fetch_info": rh.Config.Callbacks.FetchInfo,
-				"err":        err,
-			}).Error("Error reading fetch_info server response")
-			server.Write(w, protocols.InternalServerError)
+		var errorResponse *protocols.ErrorResponse
+		senderInfo, errorResponse = rh.sep12SenderInfo(request.SenderCustomerID)
+		if errorResponse != nil {
+			logger.WithFields(log.Fields{"sender_customer_id": request.SenderCustomerID}).Print("Cannot use SEP-12 customer as sender info")
+			server.Write(w, errorResponse)
 			return
 		}
-
-		if resp.StatusCode != http.StatusOK {
-			log.WithFields(log.Fields{
-				"fetch_info": rh.Config.Callbacks.FetchInfo,
-				"status":     resp.StatusCode,
-				"body":       string(body),
-			}).Error("Error response from fetch_info server")
+	} else if rh.SenderInfo != nil {
+		senderInfo, err = rh.SenderInfo.Fetch(request.Sender)
+		if err == senderinfo.ErrNotFound {
+			logger.WithFields(log.Fields{"sender": request.Sender}).Print("Sender info not found")
+			server.Write(w, compliance.SenderInfoNotFound)
+			return
+		} else if err != nil {
+			logger.WithFields(log.Fields{
+				"sender": request.Sender,
+				"err":    err,
+			}).Error("Error fetching sender info")
 			server.Write(w, protocols.InternalServerError)
 			return
 		}
-
-		senderInfo = string(body)
 	}
 
 	memoPreimage := &memo.Memo{
@@ -162,29 +158,52 @@ func (rh *RequestHandler) HandlerSend(c web.C, w http.ResponseWriter, r *http.Re
 	var txBytes bytes.Buffer
 	_, err = xdr.Marshal(&txBytes, transaction)
 	if err != nil {
-		log.Error("Error mashaling transaction")
+		logger.Error("Error mashaling transaction")
 		server.Write(w, protocols.InternalServerError)
 		return
 	}
 
 	txBase64 := base64.StdEncoding.EncodeToString(txBytes.Bytes())
 
+	nonce, err := newUUID()
+	if err != nil {
+		logger.WithFields(log.Fields{"err": err}).Error("Error generating nonce")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
 	authData := compliance.AuthData{
-		Sender:   request.Sender,
-		NeedInfo: rh.Config.NeedsAuth,
-		Tx:       txBase64,
-		Memo:     string(memoJSON),
+		Sender:    request.Sender,
+		NeedInfo:  rh.Config.NeedsAuth,
+		Tx:        txBase64,
+		Memo:      string(memoJSON),
+		Timestamp: time.Now().Unix(),
+		Nonce:     nonce,
+	}
+
+	// Encrypt memo preimage if receiving FI published ENCRYPTION_KEY
+	if stellarToml.EncryptionKey != "" {
+		authData.EncryptedMemo, err = compliance.EncryptMemo(memoJSON, stellarToml.EncryptionKey)
+		if err != nil {
+			logger.WithFields(log.Fields{
+				"encryption_key": stellarToml.EncryptionKey,
+				"err":            err,
+			}).Warn("Error encrypting memo")
+			server.Write(w, compliance.EncryptionKeyInvalid)
+			return
+		}
+		authData.Memo = ""
 	}
 
 	data, err := json.Marshal(authData)
 	if err != nil {
-		log.Error("Error mashaling authData")
+		logger.Error("Error mashaling authData")
 		server.Write(w, protocols.InternalServerError)
 		return
 	}
 	sig, err := rh.SignatureSignerVerifier.Sign(rh.Config.Keys.SigningSeed, data)
 	if err != nil {
-		log.Error("Error signing authData")
+		logger.Error("Error signing authData")
 		server.Write(w, protocols.InternalServerError)
 		return
 	}
@@ -193,12 +212,16 @@ func (rh *RequestHandler) HandlerSend(c web.C, w http.ResponseWriter, r *http.Re
 		Data:      string(data),
 		Signature: sig,
 	}
+	// Auth server belongs to another organization so trace context is not sent to it
+	authSpan := tracing.FromRequest(r).Child("auth_server.request", tracing.KindClient)
+	authSpan.SetAttribute("auth_server", stellarToml.AuthServer)
 	resp, err := rh.Client.PostForm(
 		stellarToml.AuthServer,
 		authRequest.ToValues(),
 	)
+	authSpan.End(err)
 	if err != nil {
-		log.WithFields(log.Fields{
+		logger.WithFields(log.Fields{
 			"auth_server": stellarToml.AuthServer,
 			"err":         err,
 		}).Error("Error sending request to auth server")
@@ -209,13 +232,13 @@ func (rh *RequestHandler) HandlerSend(c web.C, w http.ResponseWriter, r *http.Re
 	defer resp.Body.Close()
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		log.Error("Error reading auth server response")
+		logger.Error("Error reading auth server response")
 		server.Write(w, protocols.InternalServerError)
 		return
 	}
 
 	if resp.StatusCode != 200 {
-		log.WithFields(log.Fields{
+		logger.WithFields(log.Fields{
 			"status": resp.StatusCode,
 			"body":   string(body),
 		}).Error("Error response from auth server")
@@ -226,7 +249,7 @@ func (rh *RequestHandler) HandlerSend(c web.C, w http.ResponseWriter, r *http.Re
 	var authResponse compliance.AuthResponse
 	err = json.Unmarshal(body, &authResponse)
 	if err != nil {
-		log.WithFields(log.Fields{
+		logger.WithFields(log.Fields{
 			"status": resp.StatusCode,
 			"body":   string(body),
 		}).Error("Error unmarshalling auth response")
@@ -234,6 +257,27 @@ func (rh *RequestHandler) HandlerSend(c web.C, w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	transactionHash, err := submitter.TransactionHash(transaction, rh.Config.NetworkPassphrase)
+	if err != nil {
+		logger.WithFields(log.Fields{"err": err}).Error("Error calculating tx hash")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	err = rh.recordComplianceTransaction(&entities.ComplianceTransaction{
+		Direction:          entities.ComplianceDirectionSent,
+		TransactionID:      hex.EncodeToString(transactionHash[:]),
+		Memo:               base64.StdEncoding.EncodeToString(memoHashBytes[:]),
+		Sender:             request.Sender,
+		Route:              destinationObject.Memo,
+		CounterpartyDomain: addressDomain(request.Destination),
+	}, &authResponse)
+	if err != nil {
+		logger.WithFields(log.Fields{"err": err}).Error("Error persisting compliance transaction")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
 	response := compliance.SendResponse{
 		AuthResponse:   authResponse,
 		TransactionXdr: txBase64,
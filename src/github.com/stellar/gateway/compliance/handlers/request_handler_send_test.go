@@ -10,6 +10,7 @@ import (
 	"github.com/facebookgo/inject"
 	. "github.com/smartystreets/goconvey/convey"
 	"github.com/stellar/gateway/compliance/config"
+	"github.com/stellar/gateway/compliance/senderinfo"
 	"github.com/stellar/gateway/mocks"
 	"github.com/stellar/gateway/net"
 	"github.com/stellar/gateway/protocols/compliance"
@@ -17,6 +18,7 @@ import (
 	"github.com/stellar/gateway/protocols/stellartoml"
 	"github.com/stellar/gateway/test"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/zenazn/goji/web"
 )
 
@@ -35,6 +37,8 @@ func TestRequestHandlerSend(t *testing.T) {
 	mockHTTPClient := new(mocks.MockHTTPClient)
 	mockEntityManager := new(mocks.MockEntityManager)
 	mockRepository := new(mocks.MockRepository)
+	mockRepository.On("GetComplianceTransactionByTransactionID", mock.Anything, mock.Anything).Return(nil, nil)
+	mockEntityManager.On("Persist", mock.AnythingOfType("*entities.ComplianceTransaction")).Return(nil)
 	mockFederationResolver := new(mocks.MockFederationResolver)
 	mockSignerVerifier := new(mocks.MockSignerVerifier)
 	mockStellartomlResolver := new(mocks.MockStellartomlResolver)
@@ -61,6 +65,11 @@ func TestRequestHandlerSend(t *testing.T) {
 		panic(err)
 	}
 
+	requestHandler.SenderInfo = &senderinfo.CallbackFetcher{
+		URL:    c.Callbacks.FetchInfo,
+		Client: mockHTTPClient,
+	}
+
 	httpHandle := func(w http.ResponseWriter, r *http.Request) {
 		requestHandler.HandlerSend(web.C{}, w, r)
 	}
@@ -0,0 +1,498 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/compliance/config"
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/protocols"
+	"github.com/stellar/gateway/protocols/compliance"
+	"github.com/stellar/gateway/protocols/webauth"
+	"github.com/stellar/gateway/server"
+	"github.com/zenazn/goji/web"
+)
+
+// maxSep12RequestSize is the maximum size of PUT /sep12/customer request (with uploaded files)
+const maxSep12RequestSize = 10 << 20
+
+// sep12RequestParams are params of SEP-12 requests that are not SEP-9 fields
+var sep12RequestParams = map[string]bool{
+	"id":        true,
+	"account":   true,
+	"memo":      true,
+	"memo_type": true,
+	"type":      true,
+}
+
+// HandlerSep12Customer implements GET /sep12/customer endpoint. It returns the status of a
+// customer and fields required for customers of `type`.
+func (rh *RequestHandler) HandlerSep12Customer(c web.C, w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	query := r.URL.Query()
+	memoType := sep12MemoType(query.Get("memo_type"), query.Get("memo"))
+	customer, errorResponse := rh.findSep12Customer(r, query.Get("id"), query.Get("account"), memoType, query.Get("memo"))
+	if errorResponse != nil {
+		server.Write(w, errorResponse)
+		return
+	}
+
+	fields := map[string]string{}
+	if customer != nil {
+		// Fields are marshaled by PUT /sep12/customer
+		json.Unmarshal([]byte(customer.Fields), &fields)
+	}
+
+	response := compliance.Sep12CustomerResponse{
+		Fields:         map[string]compliance.Sep12Field{},
+		ProvidedFields: map[string]compliance.Sep12Field{},
+	}
+	for _, field := range rh.Config.Sep12.FieldsFor(query.Get("type")) {
+		responseField := compliance.Sep12Field{
+			Type:        field.FieldType(),
+			Description: field.Description,
+			Choices:     field.Choices,
+			Optional:    field.Optional,
+		}
+		if _, ok := fields[field.Name]; ok {
+			response.ProvidedFields[field.Name] = responseField
+		} else {
+			response.Fields[field.Name] = responseField
+		}
+	}
+
+	response.Status = rh.sep12Status(customer, fields, query.Get("type"))
+	if customer != nil {
+		response.ID = customer.PublicID
+		response.Message = customer.Message
+	}
+
+	server.Write(w, &response)
+}
+
+// HandlerSep12PutCustomer implements PUT /sep12/customer endpoint. It creates or updates
+// a customer with SEP-9 fields sent as a form (files in multipart/form-data) or JSON.
+func (rh *RequestHandler) HandlerSep12PutCustomer(c web.C, w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	values, files, err := sep12RequestValues(w, r)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Print("Cannot parse SEP-12 request")
+		server.Write(w, protocols.InvalidParameterError)
+		return
+	}
+
+	get := func(name string) string {
+		if len(values[name]) == 0 {
+			return ""
+		}
+		return values[name][0]
+	}
+
+	memoType := sep12MemoType(get("memo_type"), get("memo"))
+	if !validSep12Memo(memoType, get("memo")) {
+		server.Write(w, protocols.NewInvalidParameterError("memo", get("memo")))
+		return
+	}
+
+	customer, errorResponse := rh.findSep12Customer(r, get("id"), get("account"), memoType, get("memo"))
+	if errorResponse != nil {
+		server.Write(w, errorResponse)
+		return
+	}
+
+	fields := map[string]string{}
+	if customer == nil {
+		if get("id") != "" {
+			server.Write(w, compliance.Sep12CustomerNotFound)
+			return
+		}
+
+		id, err := newUUID()
+		if err != nil {
+			log.WithFields(log.Fields{"err": err}).Error("Error generating customer ID")
+			server.Write(w, protocols.InternalServerError)
+			return
+		}
+
+		account, _ := rh.sep12Account(r)
+		customer = &entities.Sep12Customer{
+			PublicID:  id,
+			Account:   account,
+			MemoType:  memoType,
+			Memo:      get("memo"),
+			CreatedAt: time.Now(),
+		}
+	} else {
+		// Fields are marshaled by PUT /sep12/customer
+		json.Unmarshal([]byte(customer.Fields), &fields)
+	}
+
+	for name, value := range values {
+		if sep12RequestParams[name] {
+			continue
+		}
+
+		field := rh.Config.Sep12.FieldFor(name)
+		if field == nil || !validSep12Value(*field, value[0]) {
+			server.Write(w, protocols.NewInvalidParameterError(name, value[0]))
+			return
+		}
+		fields[name] = value[0]
+	}
+
+	for name, content := range files {
+		field := rh.Config.Sep12.FieldFor(name)
+		if field == nil || field.FieldType() != config.Sep12FieldTypeBinary {
+			server.Write(w, protocols.NewInvalidParameterError(name, ""))
+			return
+		}
+		fields[name] = base64.StdEncoding.EncodeToString(content)
+	}
+
+	fieldsJSON, err := json.Marshal(fields)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error marshaling customer fields")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	// Customers are reviewed again when they change their fields
+	if customer.Fields != string(fieldsJSON) && customer.Status != entities.Sep12StatusRejected {
+		customer.Status = ""
+	}
+	customer.Fields = string(fieldsJSON)
+	customer.Status = rh.sep12Status(customer, fields, get("type"))
+	customer.UpdatedAt = time.Now()
+
+	err = rh.EntityManager.Persist(customer)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error persisting SEP-12 customer")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	log.WithFields(log.Fields{"id": customer.PublicID, "status": customer.Status}).Info("SEP-12 customer saved")
+	server.Write(w, &compliance.Sep12PutCustomerResponse{ID: customer.PublicID})
+}
+
+// HandlerSep12DeleteCustomer implements DELETE /sep12/customer/:account endpoint
+func (rh *RequestHandler) HandlerSep12DeleteCustomer(c web.C, w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	memoType := sep12MemoType(r.FormValue("memo_type"), r.FormValue("memo"))
+	customer, errorResponse := rh.findSep12Customer(r, "", c.URLParams["account"], memoType, r.FormValue("memo"))
+	if errorResponse != nil {
+		server.Write(w, errorResponse)
+		return
+	}
+
+	if customer == nil {
+		server.Write(w, compliance.Sep12CustomerNotFound)
+		return
+	}
+
+	err := rh.EntityManager.Delete(customer)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error deleting SEP-12 customer")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// HandlerSep12CustomerDetails implements GET /sep12/customers/:id endpoint of the internal
+// server. It returns all fields of a customer.
+func (rh *RequestHandler) HandlerSep12CustomerDetails(c web.C, w http.ResponseWriter, r *http.Request) {
+	customer := rh.loadSep12Customer(c, w)
+	if customer == nil {
+		return
+	}
+
+	server.Write(w, &compliance.Sep12CustomerDetailsResponse{Sep12Customer: compliance.NewSep12Customer(customer)})
+}
+
+// HandlerSep12UpdateCustomerStatus implements PUT /sep12/customers/:id endpoint of the
+// internal server. It's used to accept or reject customers after review.
+func (rh *RequestHandler) HandlerSep12UpdateCustomerStatus(c web.C, w http.ResponseWriter, r *http.Request) {
+	customer := rh.loadSep12Customer(c, w)
+	if customer == nil {
+		return
+	}
+
+	request := &compliance.Sep12StatusRequest{}
+	request.FromRequest(r)
+
+	err := request.Validate()
+	if err != nil {
+		errorResponse := err.(*protocols.ErrorResponse)
+		log.WithFields(errorResponse.LogData).Error(errorResponse.Error())
+		server.Write(w, errorResponse)
+		return
+	}
+
+	customer.Status = request.Status
+	customer.Message = nil
+	if request.Message != "" {
+		customer.Message = &request.Message
+	}
+	customer.UpdatedAt = time.Now()
+
+	err = rh.EntityManager.Persist(customer)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error persisting SEP-12 customer")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	server.Write(w, &compliance.Sep12CustomerDetailsResponse{Sep12Customer: compliance.NewSep12Customer(customer)})
+}
+
+// sep12SenderInfo returns sender info of a SEP-12 customer referenced by /send request:
+// its fields without uploaded files
+func (rh *RequestHandler) sep12SenderInfo(customerID string) (string, *protocols.ErrorResponse) {
+	customer, err := rh.Repository.GetSep12CustomerByPublicID(customerID)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error getting SEP-12 customer")
+		return "", protocols.InternalServerError
+	}
+
+	if customer == nil {
+		return "", compliance.Sep12CustomerNotFound
+	}
+
+	if customer.Status != entities.Sep12StatusAccepted {
+		return "", compliance.Sep12CustomerNotAccepted
+	}
+
+	fields := map[string]string{}
+	// Fields are marshaled by PUT /sep12/customer
+	json.Unmarshal([]byte(customer.Fields), &fields)
+	for name := range fields {
+		field := rh.Config.Sep12.FieldFor(name)
+		if field != nil && field.FieldType() == config.Sep12FieldTypeBinary {
+			delete(fields, name)
+		}
+	}
+
+	info, err := json.Marshal(fields)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error marshaling sender info")
+		return "", protocols.InternalServerError
+	}
+	return string(info), nil
+}
+
+// sep12Status returns a status of a customer with given fields as a customer of customerType.
+// Customers missing a required field need info unless they have been rejected.
+func (rh *RequestHandler) sep12Status(customer *entities.Sep12Customer, fields map[string]string, customerType string) string {
+	if customer != nil && customer.Status == entities.Sep12StatusRejected {
+		return entities.Sep12StatusRejected
+	}
+
+	for _, field := range rh.Config.Sep12.FieldsFor(customerType) {
+		if _, ok := fields[field.Name]; !ok && !field.Optional {
+			return entities.Sep12StatusNeedsInfo
+		}
+	}
+
+	if customer == nil || customer.Status == "" || customer.Status == entities.Sep12StatusNeedsInfo {
+		if rh.Config.Sep12.AutoAccept {
+			return entities.Sep12StatusAccepted
+		}
+		return entities.Sep12StatusProcessing
+	}
+	return customer.Status
+}
+
+// findSep12Customer returns a customer of the authenticated account by id or by memo. It returns
+// nil when customer does not exist.
+func (rh *RequestHandler) findSep12Customer(r *http.Request, id, account, memoType, memo string) (*entities.Sep12Customer, *protocols.ErrorResponse) {
+	authenticated, ok := rh.sep12Account(r)
+	if !ok {
+		return nil, compliance.Sep12Unauthorized
+	}
+
+	if account != "" && account != authenticated {
+		return nil, protocols.NewInvalidParameterError("account", account)
+	}
+
+	var customer *entities.Sep12Customer
+	var err error
+	if id != "" {
+		customer, err = rh.Repository.GetSep12CustomerByPublicID(id)
+	} else {
+		customer, err = rh.Repository.GetSep12Customer(authenticated, memoType, memo)
+	}
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error getting SEP-12 customer")
+		return nil, protocols.InternalServerError
+	}
+
+	if customer != nil && customer.Account != authenticated {
+		return nil, compliance.Sep12CustomerNotFound
+	}
+	if customer == nil && id != "" {
+		return nil, compliance.Sep12CustomerNotFound
+	}
+	return customer, nil
+}
+
+// sep12Account returns an account authenticated using SEP-10 token in Authorization header
+func (rh *RequestHandler) sep12Account(r *http.Request) (string, bool) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if token == "" {
+		return "", false
+	}
+
+	key, err := rh.Config.Sep12.Key()
+	if err != nil {
+		return "", false
+	}
+
+	claims, err := webauth.ParseToken(token, key, time.Now())
+	if err != nil {
+		return "", false
+	}
+	return claims.Subject, true
+}
+
+// loadSep12Customer finds a SEP-12 customer using `id` URL param. When customer cannot be
+// found it writes an error response and returns nil.
+func (rh *RequestHandler) loadSep12Customer(c web.C, w http.ResponseWriter) *entities.Sep12Customer {
+	customer, err := rh.Repository.GetSep12CustomerByPublicID(c.URLParams["id"])
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error getting SEP-12 customer")
+		server.Write(w, protocols.InternalServerError)
+		return nil
+	}
+
+	if customer == nil {
+		server.Write(w, compliance.Sep12CustomerNotFound)
+		return nil
+	}
+
+	return customer
+}
+
+// sep12RequestValues returns params and uploaded files of PUT /sep12/customer request
+func sep12RequestValues(w http.ResponseWriter, r *http.Request) (map[string][]string, map[string][]byte, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxSep12RequestSize)
+
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		var params map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&params)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		values := map[string][]string{}
+		for name, value := range params {
+			switch value := value.(type) {
+			case string:
+				values[name] = []string{value}
+			case float64:
+				values[name] = []string{strconv.FormatFloat(value, 'f', -1, 64)}
+			default:
+				return nil, nil, fmt.Errorf("invalid value of %s", name)
+			}
+		}
+		return values, nil, nil
+	}
+
+	err := r.ParseMultipartForm(maxSep12RequestSize)
+	if err == http.ErrNotMultipart {
+		return r.PostForm, nil, r.ParseForm()
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	files := map[string][]byte{}
+	for name, headers := range r.MultipartForm.File {
+		file, err := headers[0].Open()
+		if err != nil {
+			return nil, nil, err
+		}
+		content, err := ioutil.ReadAll(file)
+		file.Close()
+		if err != nil {
+			return nil, nil, err
+		}
+		files[name] = content
+	}
+	return r.PostForm, files, nil
+}
+
+// validSep12Value returns true when value is valid for the field. Binary fields must be
+// uploaded as files.
+func validSep12Value(field config.Sep12Field, value string) bool {
+	switch field.FieldType() {
+	case config.Sep12FieldTypeNumber:
+		_, err := strconv.ParseFloat(value, 64)
+		return err == nil
+	case config.Sep12FieldTypeDate:
+		_, err := time.Parse("2006-01-02", value)
+		return err == nil
+	case config.Sep12FieldTypeBinary:
+		return false
+	}
+
+	if len(field.Choices) == 0 {
+		return value != ""
+	}
+	for _, choice := range field.Choices {
+		if choice == value {
+			return true
+		}
+	}
+	return false
+}
+
+// sep12MemoType returns memoType or `id` (SEP-12 default) when only memo is given
+func sep12MemoType(memoType, memo string) string {
+	if memoType == "" && memo != "" {
+		return "id"
+	}
+	return memoType
+}
+
+// validSep12Memo returns true when memo identifies customers of a shared account
+func validSep12Memo(memoType, memo string) bool {
+	switch memoType {
+	case "":
+		return memo == ""
+	case "id":
+		_, err := strconv.ParseUint(memo, 10, 64)
+		return err == nil
+	case "text":
+		return memo != "" && len(memo) <= 28
+	case "hash":
+		decoded, err := base64.StdEncoding.DecodeString(memo)
+		return err == nil && len(decoded) == 32
+	}
+	return false
+}
+
+// newUUID returns a random (version 4) UUID
+func newUUID() (string, error) {
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
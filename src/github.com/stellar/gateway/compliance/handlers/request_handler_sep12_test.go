@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stellar/gateway/compliance/config"
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/mocks"
+	"github.com/stellar/gateway/protocols/compliance"
+	"github.com/stellar/gateway/protocols/webauth"
+	"github.com/stellar/go-stellar-base/keypair"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/zenazn/goji/web"
+)
+
+func TestHandlerSep12Customer(t *testing.T) {
+	key, err := keypair.Random()
+	require.NoError(t, err)
+	account, err := keypair.Random()
+	require.NoError(t, err)
+
+	mockRepository := new(mocks.MockRepository)
+	mockEntityManager := new(mocks.MockEntityManager)
+	rh := RequestHandler{
+		Config: &config.Config{Sep12: config.Sep12{
+			JWTKey: key.Seed(),
+			Fields: []config.Sep12Field{
+				{Name: "first_name", Description: "First name"},
+				{Name: "birth_date", Type: config.Sep12FieldTypeDate, CustomerTypes: []string{"sep31-sender"}},
+				{Name: "photo_id_front", Type: config.Sep12FieldTypeBinary, Optional: true},
+			},
+		}},
+		Repository:    mockRepository,
+		EntityManager: mockEntityManager,
+	}
+
+	jwtKey, err := rh.Config.Sep12.Key()
+	require.NoError(t, err)
+	token, err := webauth.NewToken(webauth.Claims{
+		Subject:   account.Address(),
+		IssuedAt:  time.Now().Unix(),
+		ExpiresAt: time.Now().Add(time.Hour).Unix(),
+	}, jwtKey)
+	require.NoError(t, err)
+
+	var saved *entities.Sep12Customer
+	mockRepository.On("GetSep12Customer", account.Address(), "", "").Return(nil, nil).Once()
+	mockEntityManager.On("Persist", mock.AnythingOfType("*entities.Sep12Customer")).Return(nil).Run(func(args mock.Arguments) {
+		saved = args.Get(0).(*entities.Sep12Customer)
+	})
+
+	put := func(values url.Values, authorized bool) *httptest.ResponseRecorder {
+		r, _ := http.NewRequest("PUT", "/sep12/customer", strings.NewReader(values.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		if authorized {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		rh.HandlerSep12PutCustomer(web.C{}, w, r)
+		return w
+	}
+
+	w := put(url.Values{"first_name": {"Alice"}}, false)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = put(url.Values{"first_name": {"Alice"}, "type": {"sep31-sender"}}, true)
+	require.Equal(t, http.StatusAccepted, w.Code)
+	require.NotNil(t, saved)
+	assert.Contains(t, w.Body.String(), saved.PublicID)
+	assert.Equal(t, account.Address(), saved.Account)
+	assert.Equal(t, `{"first_name":"Alice"}`, saved.Fields)
+	assert.Equal(t, entities.Sep12StatusNeedsInfo, saved.Status)
+
+	saved.SetExists()
+	mockRepository.On("GetSep12Customer", account.Address(), "", "").Return(saved, nil)
+	mockRepository.On("GetSep12CustomerByPublicID", saved.PublicID).Return(saved, nil)
+
+	w = put(url.Values{"birth_date": {"01/02/1990"}}, true)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "birth_date")
+
+	w = put(url.Values{"id": {saved.PublicID}, "birth_date": {"1990-01-02"}, "type": {"sep31-sender"}}, true)
+	require.Equal(t, http.StatusAccepted, w.Code)
+	assert.Equal(t, entities.Sep12StatusProcessing, saved.Status)
+
+	r, _ := http.NewRequest("GET", "/sep12/customer?type=sep31-sender", nil)
+	r.Header.Set("Authorization", "Bearer "+token)
+	w = httptest.NewRecorder()
+	rh.HandlerSep12Customer(web.C{}, w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+	response := w.Body.String()
+	assert.Contains(t, response, `"status": "PROCESSING"`)
+	assert.Contains(t, response, `"photo_id_front"`)
+	assert.Contains(t, response, `"provided_fields"`)
+
+	_, errorResponse := rh.sep12SenderInfo(saved.PublicID)
+	assert.Equal(t, compliance.Sep12CustomerNotAccepted, errorResponse)
+
+	r, _ = http.NewRequest("PUT", "/sep12/customers/"+saved.PublicID, strings.NewReader("status=ACCEPTED"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w = httptest.NewRecorder()
+	rh.HandlerSep12UpdateCustomerStatus(web.C{URLParams: map[string]string{"id": saved.PublicID}}, w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, entities.Sep12StatusAccepted, saved.Status)
+
+	info, errorResponse := rh.sep12SenderInfo(saved.PublicID)
+	require.Nil(t, errorResponse)
+	assert.Equal(t, `{"birth_date":"1990-01-02","first_name":"Alice"}`, info)
+}
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/protocols"
+	"github.com/stellar/gateway/protocols/compliance"
+	"github.com/stellar/gateway/server"
+	"github.com/zenazn/goji/web"
+)
+
+// HandlerTransactions implements GET /compliance/transactions endpoint. It returns compliance
+// transactions matching query params, newest first.
+func (rh *RequestHandler) HandlerTransactions(c web.C, w http.ResponseWriter, r *http.Request) {
+	request := &compliance.TransactionsRequest{}
+	request.FromRequest(r)
+
+	filter, err := request.Parse()
+	if err != nil {
+		errorResponse := err.(*protocols.ErrorResponse)
+		log.WithFields(errorResponse.LogData).Error(errorResponse.Error())
+		server.Write(w, errorResponse)
+		return
+	}
+
+	transactions, err := rh.Repository.GetComplianceTransactions(filter)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error getting compliance transactions")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	response := compliance.TransactionsResponse{Transactions: []compliance.Transaction{}}
+	for i := range transactions {
+		response.Transactions = append(response.Transactions, compliance.NewTransaction(&transactions[i]))
+	}
+
+	server.Write(w, &response)
+}
+
+// HandlerTransaction implements GET /compliance/transactions/:id endpoint
+func (rh *RequestHandler) HandlerTransaction(c web.C, w http.ResponseWriter, r *http.Request) {
+	transaction := rh.loadComplianceTransaction(c, w)
+	if transaction == nil {
+		return
+	}
+
+	server.Write(w, &compliance.TransactionResponse{Transaction: compliance.NewTransaction(transaction)})
+}
+
+// HandlerCompleteTransaction implements POST /compliance/transactions/:id/complete endpoint.
+// Sending FIs use it to mark approved transactions as completed once they are submitted.
+// Received transactions are completed when the payment is received (see HandlerReceive).
+func (rh *RequestHandler) HandlerCompleteTransaction(c web.C, w http.ResponseWriter, r *http.Request) {
+	transaction := rh.loadComplianceTransaction(c, w)
+	if transaction == nil {
+		return
+	}
+
+	if transaction.Status != entities.ComplianceStatusApproved && transaction.Status != entities.ComplianceStatusCompleted {
+		server.Write(w, compliance.ComplianceTransactionNotApproved)
+		return
+	}
+
+	err := rh.completeComplianceTransaction(transaction)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error persisting compliance transaction")
+		server.Write(w, protocols.InternalServerError)
+		return
+	}
+
+	server.Write(w, &compliance.TransactionResponse{Transaction: compliance.NewTransaction(transaction)})
+}
+
+// loadComplianceTransaction finds a compliance transaction using `id` URL param. When the
+// transaction cannot be found it writes an error response and returns nil.
+func (rh *RequestHandler) loadComplianceTransaction(c web.C, w http.ResponseWriter) *entities.ComplianceTransaction {
+	id, err := strconv.ParseInt(c.URLParams["id"], 10, 64)
+	if err != nil {
+		server.Write(w, compliance.ComplianceTransactionNotFound)
+		return nil
+	}
+
+	transaction, err := rh.Repository.GetComplianceTransactionByID(id)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error getting compliance transaction")
+		server.Write(w, protocols.InternalServerError)
+		return nil
+	}
+
+	if transaction == nil {
+		server.Write(w, compliance.ComplianceTransactionNotFound)
+		return nil
+	}
+
+	return transaction
+}
+
+// recordComplianceTransaction saves a compliance exchange of a transaction and its auth
+// response. Repeated exchanges update statuses of the existing record unless it's completed.
+func (rh *RequestHandler) recordComplianceTransaction(transaction *entities.ComplianceTransaction, response *compliance.AuthResponse) error {
+	existing, err := rh.Repository.GetComplianceTransactionByTransactionID(transaction.Direction, transaction.TransactionID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if existing != nil {
+		if existing.Status == entities.ComplianceStatusCompleted {
+			return nil
+		}
+		transaction.ID = existing.ID
+		transaction.CreatedAt = existing.CreatedAt
+		transaction.SetExists()
+	} else {
+		transaction.CreatedAt = now
+	}
+
+	transaction.Status = response.Status()
+	transaction.TxStatus = string(response.TxStatus)
+	transaction.InfoStatus = string(response.InfoStatus)
+	transaction.UpdatedAt = now
+	return rh.EntityManager.Persist(transaction)
+}
+
+// completeComplianceTransaction sets status of transaction to completed
+func (rh *RequestHandler) completeComplianceTransaction(transaction *entities.ComplianceTransaction) error {
+	if transaction.Status == entities.ComplianceStatusCompleted {
+		return nil
+	}
+
+	transaction.Status = entities.ComplianceStatusCompleted
+	transaction.UpdatedAt = time.Now()
+	return rh.EntityManager.Persist(transaction)
+}
+
+// addressDomain returns a domain of Stellar address, ex. `acme.com` for `alice*acme.com`
+func addressDomain(address string) string {
+	i := strings.LastIndex(address, "*")
+	if i == -1 {
+		return ""
+	}
+	return address[i+1:]
+}
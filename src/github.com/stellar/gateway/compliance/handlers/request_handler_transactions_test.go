@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/mocks"
+	"github.com/stellar/gateway/protocols/compliance"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/zenazn/goji/web"
+)
+
+func TestRecordComplianceTransaction(t *testing.T) {
+	mockEntityManager := new(mocks.MockEntityManager)
+	mockRepository := new(mocks.MockRepository)
+	rh := RequestHandler{EntityManager: mockEntityManager, Repository: mockRepository}
+	mockEntityManager.On("Persist", mock.AnythingOfType("*entities.ComplianceTransaction")).Return(nil)
+
+	newTransaction := func() *entities.ComplianceTransaction {
+		return &entities.ComplianceTransaction{Direction: entities.ComplianceDirectionReceived, TransactionID: "a1"}
+	}
+
+	// New transaction
+	mockRepository.On("GetComplianceTransactionByTransactionID", entities.ComplianceDirectionReceived, "a1").Return(nil, nil).Once()
+	transaction := newTransaction()
+	pending := &compliance.AuthResponse{TxStatus: compliance.AuthStatusOk, InfoStatus: compliance.AuthStatusPending}
+	require.NoError(t, rh.recordComplianceTransaction(transaction, pending))
+	assert.True(t, transaction.IsNew())
+	assert.Equal(t, entities.ComplianceStatusPending, transaction.Status)
+	assert.Equal(t, "ok", transaction.TxStatus)
+	assert.Equal(t, "pending", transaction.InfoStatus)
+
+	// Repeated exchange updates the existing record
+	id := int64(5)
+	createdAt := time.Now().Add(-time.Hour)
+	existing := &entities.ComplianceTransaction{ID: &id, Status: entities.ComplianceStatusPending, CreatedAt: createdAt}
+	existing.SetExists()
+	mockRepository.On("GetComplianceTransactionByTransactionID", entities.ComplianceDirectionReceived, "a1").Return(existing, nil).Once()
+	transaction = newTransaction()
+	approved := &compliance.AuthResponse{TxStatus: compliance.AuthStatusOk, InfoStatus: compliance.AuthStatusOk}
+	require.NoError(t, rh.recordComplianceTransaction(transaction, approved))
+	assert.False(t, transaction.IsNew())
+	assert.Equal(t, id, *transaction.ID)
+	assert.Equal(t, createdAt, transaction.CreatedAt)
+	assert.Equal(t, entities.ComplianceStatusApproved, transaction.Status)
+
+	// Completed transactions are not changed
+	existing.Status = entities.ComplianceStatusCompleted
+	mockRepository.On("GetComplianceTransactionByTransactionID", entities.ComplianceDirectionReceived, "a1").Return(existing, nil).Once()
+	denied := &compliance.AuthResponse{TxStatus: compliance.AuthStatusDenied, InfoStatus: compliance.AuthStatusOk}
+	require.NoError(t, rh.recordComplianceTransaction(newTransaction(), denied))
+	mockEntityManager.AssertNumberOfCalls(t, "Persist", 2)
+	mockRepository.AssertExpectations(t)
+}
+
+func TestHandlerTransactions(t *testing.T) {
+	mockEntityManager := new(mocks.MockEntityManager)
+	mockRepository := new(mocks.MockRepository)
+	rh := RequestHandler{EntityManager: mockEntityManager, Repository: mockRepository}
+
+	id := int64(1)
+	transaction := entities.ComplianceTransaction{
+		ID:                 &id,
+		Direction:          entities.ComplianceDirectionSent,
+		TransactionID:      "a1",
+		Sender:             "alice*acme.com",
+		CounterpartyDomain: "other.com",
+		Status:             entities.ComplianceStatusApproved,
+	}
+	transaction.SetExists()
+
+	since := time.Date(2017, 7, 14, 0, 0, 0, 0, time.UTC)
+	mockRepository.On("GetComplianceTransactions", entities.ComplianceTransactionFilter{
+		CounterpartyDomain: "other.com",
+		Status:             entities.ComplianceStatusApproved,
+		Since:              &since,
+		Limit:              compliance.DefaultTransactionsLimit,
+	}).Return([]entities.ComplianceTransaction{transaction}, nil).Once()
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/compliance/transactions?domain=other.com&status=approved&since=2017-07-14T00:00:00Z", nil)
+	rh.HandlerTransactions(web.C{}, w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"counterparty_domain": "other.com"`)
+	assert.Contains(t, w.Body.String(), `"sender": "alice*acme.com"`)
+
+	for _, query := range []string{"status=unknown", "direction=both", "until=yesterday", "limit=1000"} {
+		w = httptest.NewRecorder()
+		rh.HandlerTransactions(web.C{}, w, httptest.NewRequest("GET", "/compliance/transactions?"+query, nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+
+	complete := func(id string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		rh.HandlerCompleteTransaction(web.C{URLParams: map[string]string{"id": id}}, w, httptest.NewRequest("POST", "/compliance/transactions/"+id+"/complete", nil))
+		return w
+	}
+
+	mockRepository.On("GetComplianceTransactionByID", int64(1)).Return(&transaction, nil).Once()
+	mockEntityManager.On("Persist", &transaction).Return(nil).Once()
+	w = complete("1")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, entities.ComplianceStatusCompleted, transaction.Status)
+
+	pending := entities.ComplianceTransaction{ID: &id, Status: entities.ComplianceStatusPending}
+	mockRepository.On("GetComplianceTransactionByID", int64(1)).Return(&pending, nil).Once()
+	assert.Equal(t, http.StatusBadRequest, complete("1").Code)
+
+	mockRepository.On("GetComplianceTransactionByID", int64(2)).Return(nil, nil).Once()
+	assert.Equal(t, http.StatusNotFound, complete("2").Code)
+
+	mockRepository.AssertExpectations(t)
+	mockEntityManager.AssertExpectations(t)
+}
+
+func TestAddressDomain(t *testing.T) {
+	assert.Equal(t, "acme.com", addressDomain("alice*acme.com"))
+	assert.Equal(t, "acme.com", addressDomain("alice@example.com*acme.com"))
+	assert.Equal(t, "", addressDomain("GBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFZYYQ"))
+}
@@ -0,0 +1,215 @@
+// Package sanctions screens senders of incoming payments against sanctions lists
+// (OFAC SDN, EU consolidated list) loaded and refreshed by the compliance server.
+package sanctions
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/stellar/go/support/errors"
+)
+
+// Formats of sanctions lists
+const (
+	// FormatOFACSDN is OFAC SDN list (sdn.csv)
+	FormatOFACSDN = "ofac_sdn"
+	// FormatOFACAlt is OFAC SDN aliases list (alt.csv)
+	FormatOFACAlt = "ofac_alt"
+	// FormatEU is EU consolidated financial sanctions list (CSV v1.1)
+	FormatEU = "eu"
+)
+
+// ErrNotLoaded is returned by Screen when one of the lists has never been loaded
+var ErrNotLoaded = errors.New("sanctions list has not been loaded")
+
+// retryInterval is an interval of loading attempts of a list that failed to load
+var retryInterval = time.Minute
+
+// ScreenerInterface helps mocking Screener
+type ScreenerInterface interface {
+	// Screen returns a matched sanctions list entry or empty string when sender info does not match any list
+	Screen(senderInfo string) (match string, err error)
+}
+
+// List is a single sanctions list source. Source is http(s) URL or a local file path.
+type List struct {
+	Source string
+	Format string
+}
+
+// Screener matches names in sender info against sanctions lists. Names are compared
+// ignoring case, punctuation and order of words.
+type Screener struct {
+	Lists           []List
+	RefreshInterval time.Duration
+	Client          *http.Client
+
+	mutex sync.RWMutex
+	// names of every list by their normalized form
+	names  []map[string]string
+	stop   chan struct{}
+	stopWG sync.WaitGroup
+}
+
+// NewScreener creates a new Screener
+func NewScreener(lists []List, refreshInterval time.Duration, client *http.Client) *Screener {
+	return &Screener{
+		Lists:           lists,
+		RefreshInterval: refreshInterval,
+		Client:          client,
+		names:           make([]map[string]string, len(lists)),
+		stop:            make(chan struct{}),
+	}
+}
+
+// Start loads all lists and refreshes them in background until Stop is called. Lists that
+// failed to load are retried every minute; Screen returns ErrNotLoaded until they're loaded.
+func (s *Screener) Start() {
+	for i := range s.Lists {
+		loaded := s.load(i)
+
+		s.stopWG.Add(1)
+		go s.refresh(i, loaded)
+	}
+}
+
+// Stop stops refreshing lists
+func (s *Screener) Stop() {
+	close(s.stop)
+	s.stopWG.Wait()
+}
+
+func (s *Screener) refresh(i int, loaded bool) {
+	defer s.stopWG.Done()
+
+	for {
+		interval := s.RefreshInterval
+		if !loaded {
+			interval = retryInterval
+		}
+
+		select {
+		case <-time.After(interval):
+			// Previous names are kept when refresh fails
+			loaded = s.load(i) || loaded
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// load loads list i and returns true when it succeeded
+func (s *Screener) load(i int) bool {
+	list := s.Lists[i]
+	names, err := s.fetch(list)
+	if err != nil {
+		log.WithFields(log.Fields{"source": list.Source, "err": err}).Error("Error loading sanctions list")
+		return false
+	}
+
+	s.mutex.Lock()
+	s.names[i] = names
+	s.mutex.Unlock()
+
+	log.WithFields(log.Fields{"source": list.Source, "names": len(names)}).Info("Sanctions list loaded")
+	return true
+}
+
+func (s *Screener) fetch(list List) (map[string]string, error) {
+	var body io.ReadCloser
+	if strings.HasPrefix(list.Source, "http://") || strings.HasPrefix(list.Source, "https://") {
+		resp, err := s.Client.Get(list.Source)
+		if err != nil {
+			return nil, errors.Wrap(err, "http request errored")
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, errors.Errorf("response status code indicates error (%d)", resp.StatusCode)
+		}
+		body = resp.Body
+	} else {
+		file, err := os.Open(list.Source)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot open file")
+		}
+		body = file
+	}
+	defer body.Close()
+
+	entries, err := parse(body, list.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		if key := normalize(entry); key != "" {
+			names[key] = entry
+		}
+	}
+	return names, nil
+}
+
+// Screen checks names found in JSON encoded SEP-9 sender info: `name` and `first_name`,
+// `middle_name` and `last_name` (with and without middle name).
+func (s *Screener) Screen(senderInfo string) (string, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	for _, names := range s.names {
+		if names == nil {
+			return "", ErrNotLoaded
+		}
+	}
+
+	for _, candidate := range candidateNames(senderInfo) {
+		key := normalize(candidate)
+		if key == "" {
+			continue
+		}
+		for _, names := range s.names {
+			if entry, ok := names[key]; ok {
+				return entry, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// candidateNames returns names of a sender found in sender info
+func candidateNames(senderInfo string) []string {
+	fields := map[string]interface{}{}
+	// Sender info that is not a JSON object has no names to screen
+	_ = json.Unmarshal([]byte(senderInfo), &fields)
+
+	field := func(name string) string {
+		value, _ := fields[name].(string)
+		return value
+	}
+
+	names := []string{field("name")}
+	if first, last := field("first_name"), field("last_name"); first != "" || last != "" {
+		names = append(names, first+" "+last)
+		if middle := field("middle_name"); middle != "" {
+			names = append(names, first+" "+middle+" "+last)
+		}
+	}
+	return names
+}
+
+// normalize returns uppercase words of name without punctuation, sorted alphabetically
+func normalize(name string) string {
+	words := strings.FieldsFunc(strings.ToUpper(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	sort.Strings(words)
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,106 @@
+package sanctions
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const ofacSDN = `36,"AEROCARIBBEAN AIRLINES",-0- ,"CUBA",-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0-
+173,"ANGLO-CARIBBEAN CO., LTD.",-0- ,"CUBA",-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0-
+2674,"ABDUL RAHMAN, Mohammed","individual","SDGT",-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,"DOB 1970"
+`
+
+const ofacAlt = `36,12,"aka","AERO-CARIBBEAN",-0-
+2674,1290,"aka","ABDULRAHMAN, Mohammed",-0-
+`
+
+const euList = "\ufeffFileGenerationDate;Entity_LogicalId;NameAlias_LastName;NameAlias_FirstName;NameAlias_WholeName\n" +
+	"28/10/2022;13;Hussein;Saddam;Saddam Hussein Al-Tikriti\n" +
+	"28/10/2022;13;;;\n"
+
+func TestParse(t *testing.T) {
+	names, err := parse(strings.NewReader(ofacSDN), FormatOFACSDN)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"AEROCARIBBEAN AIRLINES", "ANGLO-CARIBBEAN CO., LTD.", "ABDUL RAHMAN, Mohammed"}, names)
+
+	names, err = parse(strings.NewReader(ofacAlt), FormatOFACAlt)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"AERO-CARIBBEAN", "ABDULRAHMAN, Mohammed"}, names)
+
+	names, err = parse(strings.NewReader(euList), FormatEU)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Saddam Hussein Al-Tikriti"}, names)
+
+	_, err = parse(strings.NewReader("a;b\n1;2\n"), FormatEU)
+	assert.Error(t, err)
+
+	_, err = parse(strings.NewReader(""), FormatOFACSDN)
+	assert.Error(t, err)
+}
+
+func TestScreener(t *testing.T) {
+	responses := make(chan string, 2)
+	responses <- ofacSDN
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case body := <-responses:
+			w.Write([]byte(body))
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	file, err := ioutil.TempFile("", "eu")
+	require.NoError(t, err)
+	defer os.Remove(file.Name())
+	_, err = file.WriteString(euList)
+	require.NoError(t, err)
+	file.Close()
+
+	screener := NewScreener([]List{
+		{Source: server.URL, Format: FormatOFACSDN},
+		{Source: file.Name(), Format: FormatEU},
+	}, 10*time.Millisecond, server.Client())
+	screener.Start()
+
+	match, err := screener.Screen(`{"first_name": "Mohammed", "last_name": "Abdul-Rahman"}`)
+	require.NoError(t, err)
+	assert.Equal(t, "ABDUL RAHMAN, Mohammed", match)
+
+	match, err = screener.Screen(`{"name": "saddam hussein al tikriti"}`)
+	require.NoError(t, err)
+	assert.Equal(t, "Saddam Hussein Al-Tikriti", match)
+
+	match, err = screener.Screen(`{"first_name": "Alice", "middle_name": "B", "last_name": "Doe"}`)
+	require.NoError(t, err)
+	assert.Equal(t, "", match)
+
+	match, err = screener.Screen("not json")
+	require.NoError(t, err)
+	assert.Equal(t, "", match)
+
+	// Names are kept when refresh fails
+	time.Sleep(50 * time.Millisecond)
+	screener.Stop()
+	match, err = screener.Screen(`{"name": "Aerocaribbean Airlines"}`)
+	require.NoError(t, err)
+	assert.Equal(t, "AEROCARIBBEAN AIRLINES", match)
+}
+
+func TestScreenerNotLoaded(t *testing.T) {
+	screener := NewScreener([]List{{Source: "/nonexistent/sdn.csv", Format: FormatOFACSDN}}, time.Hour, http.DefaultClient)
+	screener.Start()
+	defer screener.Stop()
+
+	_, err := screener.Screen(`{"name": "Alice Doe"}`)
+	assert.Equal(t, ErrNotLoaded, err)
+}
@@ -0,0 +1,76 @@
+package sanctions
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+
+	"github.com/stellar/go/support/errors"
+)
+
+// Columns of names in lists
+const (
+	ofacSDNNameColumn = 1
+	ofacAltNameColumn = 3
+	euNameColumn      = "NameAlias_WholeName"
+)
+
+// parse returns names of all entries of a list
+func parse(r io.Reader, format string) ([]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	column := -1
+	switch format {
+	case FormatOFACSDN:
+		column = ofacSDNNameColumn
+	case FormatOFACAlt:
+		column = ofacAltNameColumn
+	case FormatEU:
+		reader.Comma = ';'
+	default:
+		return nil, errors.Errorf("unknown list format: %s", format)
+	}
+
+	var names []string
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot parse list")
+		}
+
+		// EU list has a header
+		if column == -1 {
+			for i, name := range record {
+				// The first column may start with UTF-8 BOM
+				if strings.TrimPrefix(name, "\ufeff") == euNameColumn {
+					column = i
+				}
+			}
+			if column == -1 {
+				return nil, errors.Errorf("%s column not found", euNameColumn)
+			}
+			continue
+		}
+
+		if len(record) <= column {
+			continue
+		}
+
+		// OFAC uses -0- for empty values
+		name := strings.TrimSpace(record[column])
+		if name == "" || name == "-0-" {
+			continue
+		}
+		names = append(names, name)
+	}
+
+	if len(names) == 0 {
+		return nil, errors.New("list is empty")
+	}
+	return names, nil
+}
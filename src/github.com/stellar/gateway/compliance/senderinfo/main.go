@@ -0,0 +1,159 @@
+// Package senderinfo provides compliance information of senders of outgoing payments.
+package senderinfo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stellar/gateway/db"
+	"github.com/stellar/gateway/net"
+	"github.com/stellar/gateway/protocols/compliance"
+	"github.com/stellar/go/support/errors"
+)
+
+// ErrNotFound is returned when compliance information of a sender is not available
+var ErrNotFound = errors.New("sender info not found")
+
+// FetcherInterface helps mocking fetchers
+type FetcherInterface interface {
+	// Fetch returns JSON encoded compliance information of sender
+	Fetch(sender string) (info string, err error)
+}
+
+// CallbackFetcher fetches sender information from `callbacks.fetch_info`.
+//
+// When Fields is not empty, the list of additional SEP-9 fields is sent to the callback
+// in a comma separated `fields` parameter.
+type CallbackFetcher struct {
+	URL    string
+	Fields []string
+	Client net.HTTPClientInterface
+}
+
+// Fetch sends a request to the callback and returns its response body
+func (f *CallbackFetcher) Fetch(sender string) (string, error) {
+	request := compliance.FetchInfoRequest{
+		Address: sender,
+		Fields:  strings.Join(f.Fields, ","),
+	}
+
+	resp, err := f.Client.PostForm(f.URL, request.ToValues())
+	if err != nil {
+		return "", errors.Wrap(err, "http request errored")
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", errors.Wrap(err, "reading response failed")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("fetch_info response status code indicates error (%d): %s", resp.StatusCode, body)
+	}
+
+	return string(body), nil
+}
+
+// StaticFetcher returns sender information defined in config. Senders are matched
+// case-insensitively.
+type StaticFetcher struct {
+	senders map[string]string
+}
+
+// NewStaticFetcher creates a new StaticFetcher from a map of sender address => SEP-9 fields
+func NewStaticFetcher(senders map[string]map[string]string) (*StaticFetcher, error) {
+	f := &StaticFetcher{senders: make(map[string]string)}
+	for sender, fields := range senders {
+		info, err := json.Marshal(fields)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot marshal sender info")
+		}
+		f.senders[strings.ToLower(sender)] = string(info)
+	}
+	return f, nil
+}
+
+// Fetch returns sender information or ErrNotFound when sender is not defined
+func (f *StaticFetcher) Fetch(sender string) (string, error) {
+	info, ok := f.senders[strings.ToLower(sender)]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return info, nil
+}
+
+// StoreFetcher returns sender information saved in the embedded KYC store
+type StoreFetcher struct {
+	Repository db.RepositoryInterface
+}
+
+// Fetch returns sender information or ErrNotFound when sender is not in the store
+func (f *StoreFetcher) Fetch(sender string) (string, error) {
+	customer, err := f.Repository.GetKYCCustomerByAddress(sender)
+	if err != nil {
+		return "", errors.Wrap(err, "cannot get KYC customer")
+	}
+	if customer == nil {
+		return "", ErrNotFound
+	}
+	return customer.Info, nil
+}
+
+// Cache caches sender information returned by Fetcher for TTL. Errors are not cached.
+type Cache struct {
+	Fetcher FetcherInterface
+	ttl     time.Duration
+	now     func() time.Time
+
+	mutex sync.Mutex
+	cache map[string]cachedInfo
+}
+
+type cachedInfo struct {
+	info      string
+	fetchedAt time.Time
+}
+
+const defaultTTL = 5 * time.Minute
+
+// NewCache creates a new Cache. When ttl is 0 the default of 5 minutes is used.
+func NewCache(fetcher FetcherInterface, ttl time.Duration) *Cache {
+	if ttl == 0 {
+		ttl = defaultTTL
+	}
+
+	return &Cache{
+		Fetcher: fetcher,
+		ttl:     ttl,
+		now:     time.Now,
+		cache:   make(map[string]cachedInfo),
+	}
+}
+
+// Fetch returns cached sender information or fetches it using Fetcher
+func (c *Cache) Fetch(sender string) (string, error) {
+	c.mutex.Lock()
+	cached, ok := c.cache[sender]
+	c.mutex.Unlock()
+
+	if ok && c.now().Sub(cached.fetchedAt) < c.ttl {
+		return cached.info, nil
+	}
+
+	info, err := c.Fetcher.Fetch(sender)
+	if err != nil {
+		return "", err
+	}
+
+	c.mutex.Lock()
+	c.cache[sender] = cachedInfo{info: info, fetchedAt: c.now()}
+	c.mutex.Unlock()
+
+	return info, nil
+}
@@ -0,0 +1,106 @@
+package senderinfo
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stellar/gateway/mocks"
+	"github.com/stellar/gateway/net"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingFetcher struct {
+	calls int
+	err   error
+}
+
+func (f *countingFetcher) Fetch(sender string) (string, error) {
+	f.calls++
+	if f.err != nil {
+		return "", f.err
+	}
+	return `{"name":"` + sender + `"}`, nil
+}
+
+func TestCallbackFetcher(t *testing.T) {
+	client := new(mocks.MockHTTPClient)
+	fetcher := &CallbackFetcher{
+		URL:    "http://fetch_info",
+		Fields: []string{"email_address", "birth_country"},
+		Client: client,
+	}
+
+	client.On(
+		"PostForm",
+		"http://fetch_info",
+		url.Values{"address": {"alice*acme.com"}, "fields": {"email_address,birth_country"}},
+	).Return(net.BuildHTTPResponse(200, `{"name":"Alice Doe"}`), nil).Once()
+
+	info, err := fetcher.Fetch("alice*acme.com")
+	require.NoError(t, err)
+	assert.Equal(t, `{"name":"Alice Doe"}`, info)
+
+	client.On(
+		"PostForm",
+		"http://fetch_info",
+		url.Values{"address": {"bob*acme.com"}, "fields": {"email_address,birth_country"}},
+	).Return(net.BuildHTTPResponse(500, "error"), nil).Once()
+
+	_, err = fetcher.Fetch("bob*acme.com")
+	assert.Error(t, err)
+	client.AssertExpectations(t)
+}
+
+func TestStaticFetcher(t *testing.T) {
+	fetcher, err := NewStaticFetcher(map[string]map[string]string{
+		"Alice*acme.com": {"name": "Alice Doe", "email_address": "alice@acme.com"},
+	})
+	require.NoError(t, err)
+
+	info, err := fetcher.Fetch("alice*ACME.com")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"Alice Doe","email_address":"alice@acme.com"}`, info)
+
+	_, err = fetcher.Fetch("bob*acme.com")
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestCache(t *testing.T) {
+	fetcher := &countingFetcher{}
+	now := time.Now()
+	cache := NewCache(fetcher, time.Minute)
+	cache.now = func() time.Time { return now }
+
+	info, err := cache.Fetch("alice*acme.com")
+	require.NoError(t, err)
+	assert.Equal(t, `{"name":"alice*acme.com"}`, info)
+	assert.Equal(t, 1, fetcher.calls)
+
+	// cached
+	_, err = cache.Fetch("alice*acme.com")
+	require.NoError(t, err)
+	assert.Equal(t, 1, fetcher.calls)
+
+	// other sender
+	info, err = cache.Fetch("bob*acme.com")
+	require.NoError(t, err)
+	assert.Equal(t, `{"name":"bob*acme.com"}`, info)
+	assert.Equal(t, 2, fetcher.calls)
+
+	// expired
+	now = now.Add(2 * time.Minute)
+	_, err = cache.Fetch("alice*acme.com")
+	require.NoError(t, err)
+	assert.Equal(t, 3, fetcher.calls)
+
+	// errors are not cached
+	fetcher.err = errors.New("unavailable")
+	_, err = cache.Fetch("carol*acme.com")
+	assert.Error(t, err)
+	_, err = cache.Fetch("carol*acme.com")
+	assert.Error(t, err)
+	assert.Equal(t, 5, fetcher.calls)
+}
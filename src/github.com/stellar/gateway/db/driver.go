@@ -1,7 +1,10 @@
 package db
 
 import (
+	"time"
+
 	"github.com/jmoiron/sqlx"
+	migrate "github.com/rubenv/sql-migrate"
 	"github.com/stellar/gateway/db/entities"
 )
 
@@ -9,7 +12,8 @@ import (
 type Driver interface {
 	Init(url string) (err error)
 	DB() *sqlx.DB
-	MigrateUp(component string) (migrationsApplied int, err error)
+	Migrate(component string, direction migrate.MigrationDirection, max int) (migrationsCount int, err error)
+	MigrationStatus(component string) ([]MigrationStatus, error)
 
 	Insert(object entities.Entity) (id int64, err error)
 	Update(object entities.Entity) (err error)
@@ -17,3 +21,25 @@ type Driver interface {
 
 	GetOne(object entities.Entity, where string, params ...interface{}) (entities.Entity, error)
 }
+
+// PoolOptions configures the pool of DB connections. database/sql defaults are used for
+// zero values.
+type PoolOptions struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+}
+
+// ConfigurePool applies options to the connection pool of driver
+func ConfigurePool(driver Driver, options PoolOptions) {
+	database := driver.DB()
+	if options.MaxOpenConns > 0 {
+		database.SetMaxOpenConns(options.MaxOpenConns)
+	}
+	if options.MaxIdleConns > 0 {
+		database.SetMaxIdleConns(options.MaxIdleConns)
+	}
+	if options.ConnMaxLifetime > 0 {
+		database.SetConnMaxLifetime(options.ConnMaxLifetime)
+	}
+}
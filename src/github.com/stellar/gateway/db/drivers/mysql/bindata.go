@@ -1,7 +1,35 @@
 // Code generated by go-bindata.
 // sources:
 // migrations_gateway/01_init.sql
+// migrations_gateway/02_exchange_rates.sql
+// migrations_gateway/03_customers.sql
+// migrations_gateway/04_tenants.sql
+// migrations_gateway/05_from_address.sql
+// migrations_gateway/06_payment_details.sql
+// migrations_gateway/07_payment_links.sql
+// migrations_gateway/08_payment_resolution.sql
+// migrations_gateway/09_sent_transaction_metadata.sql
+// migrations_gateway/10_callback_retries.sql
+// migrations_gateway/11_idempotency_keys.sql
+// migrations_gateway/12_limit_counters.sql
+// migrations_gateway/13_sep31_transactions.sql
+// migrations_gateway/14_receiving_accounts.sql
+// migrations_gateway/15_callback_attempts.sql
+// migrations_gateway/16_api_keys.sql
+// migrations_gateway/17_pending_transactions.sql
+// migrations_gateway/18_listener_leases.sql
+// migrations_gateway/19_callback_deliveries.sql
+// migrations_gateway/20_return_memos.sql
+// migrations_gateway/21_pending_compliance.sql
+// migrations_gateway/22_sent_transaction_payment.sql
+// migrations_gateway/23_transfer_transactions.sql
+// migrations_gateway/24_received_payment_transaction.sql
+// migrations_gateway/25_pending_transaction_version.sql
 // migrations_compliance/01_init.sql
+// migrations_compliance/02_kyc_customers.sql
+// migrations_compliance/03_compliance_transactions.sql
+// migrations_compliance/04_sep12_customers.sql
+// migrations_compliance/05_auth_nonces.sql
 // DO NOT EDIT!
 
 package mysql
@@ -69,7 +97,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _migrations_gateway01_initSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x94\x93\x41\x6f\xe2\x30\x10\x85\xef\xf9\x15\x73\x4c\xb4\x8b\xb4\xa0\x05\x55\x42\x1c\x02\x71\xdb\xa8\x21\xd0\xe0\x1c\x38\x25\x26\x99\xa6\x56\x89\x83\x1c\x87\xd2\x7f\x5f\x87\xaa\xa5\x31\x2a\x6d\x8f\xb6\xbf\x79\x9e\x79\x4f\xd3\xeb\xc1\x9f\x92\x17\x92\x29\x84\x78\x67\xcd\x22\xe2\x52\x02\xd4\x9d\x06\x04\xd2\x08\x33\xe4\x7b\xcc\x97\xec\xa5\x44\xa1\x52\xb0\x2d\x80\x94\xe7\x29\x70\xa1\xec\x7e\xdf\x81\x70\x41\x21\x8c\x83\x00\xdc\x98\x2e\x12\x3f\xd4\xf5\x73\x12\xd2\xbf\x2d\x57\xed\x50\xcb\xf2\x4a\x24\x6d\xc5\x9e\xc9\xec\x91\x49\x7b\x30\x1c\x9e\xca\x8e\xdc\x4e\x56\x19\xd6\x35\xe6\x09\xd3\x5f\xe4\xba\x13\xc5\x4b\x34\x18\x56\x70\x51\x24\xaa\x7a\x42\x71\x49\xab\x56\x4c\x35\xf5\x05\x62\x19\xf9\x73\x37\x5a\xc3\x1d\x59\x83\xdd\x8e\xe2\xb4\xb7\x71\xe8\xdf\xc7\xe4\x78\x69\xb4\x6d\x77\xcf\x8e\xe5\x00\x09\x6f\xfc\x90\x4c\x7c\x21\x2a\x6f\x0a\x1e\xb9\x76\xe3\x80\xc2\xec\xd6\x8d\x56\x84\x4e\x1a\xf5\x70\x35\xb6\x0c\x23\x57\xda\x3d\x2a\x99\xa8\x59\xd6\x2a\xfd\xd2\x48\x75\xaa\xec\x58\x39\xfa\xff\xcd\xf4\xfd\x7f\x26\x50\x35\x32\xc3\x13\x30\x1c\x99\x40\xb3\x29\xb9\x52\x17\xb3\xa8\x9b\x2c\x43\xcc\x4d\xe6\xdd\x88\x0f\x6e\x8b\x79\x81\x32\x85\x0d\x2f\xda\x29\x07\xba\x99\x33\x06\xc5\x1e\xb7\xda\xe1\xe4\x90\x6b\x52\xe1\x41\x75\xff\x92\x58\x37\x5b\xf5\xf6\xda\xc9\xd4\x54\x3a\xcf\xf5\xa7\x49\x7d\xde\x00\xaf\x7a\x16\x96\x17\x2d\x96\x5f\x6d\xc0\xb8\xf3\x6a\xc6\x3a\xb6\x5e\x03\x00\x00\xff\xff\x83\xe1\xb3\xac\x4f\x03\x00\x00")
+var _migrations_gateway01_initSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x93\x41\xcf\x9a\x40\x10\x86\xef\xfc\x8a\x39\x42\x5a\x13\x35\xd5\x34\x31\x1e\x50\xb6\x2d\x29\xa2\xc5\xe5\xe0\x09\x56\x98\xd2\x4d\x65\x97\x2c\x83\xb5\xff\xbe\xc1\xc6\x5a\xd6\xd4\x7e\xdf\x71\x77\x9e\x99\x9d\x79\xdf\x9d\xd1\x08\xde\xd4\xb2\x32\x82\x10\xd2\xc6\x59\x27\xcc\xe7\x0c\xb8\xbf\x8a\x18\xe4\x09\x16\x28\xcf\x58\xee\xc4\xcf\x1a\x15\xe5\xe0\x3a\x00\xb9\x2c\x73\x90\x8a\xdc\xc9\xc4\x83\x78\xcb\x21\x4e\xa3\x08\xfc\x94\x6f\xb3\x30\x5e\x27\x6c\xc3\x62\xfe\xb6\xe7\x74\x83\x46\x90\xd4\x2a\xeb\x33\xce\xc2\x14\xdf\x84\x71\xa7\xb3\xd9\x3d\xed\xca\x35\x46\x17\xd8\xb6\x58\x66\x82\x72\x28\x05\x21\xc9\x1a\x2d\x46\x54\x52\x55\x19\xe9\xef\xa8\x9e\xd5\x6a\x49\x50\xd7\x3e\x21\x76\x49\xb8\xf1\x93\x03\x7c\x66\x07\x70\xfb\x51\xbc\xbe\x87\x34\x0e\xbf\xa4\xec\x7a\x69\xb5\xed\x0e\xcf\x9e\xe3\x01\x8b\x3f\x86\x31\x5b\x86\x4a\xe9\x60\x05\x01\xfb\xe0\xa7\x11\x87\xf5\x27\x3f\xd9\x33\xbe\xec\xe8\xeb\xfb\x85\x63\x09\xb9\x47\x45\xdc\x08\xd5\x8a\xa2\xaf\xf4\x4a\x21\xe9\x9e\x39\x90\x72\xfe\xee\x3f\xd3\x4f\xc6\x36\xa0\x3b\x53\xe0\x1d\x98\xcd\x6d\xa0\x3b\xd6\x92\xe8\xa9\x17\x6d\x57\x14\x88\xa5\xcd\xdc\x84\xf8\xc3\x9d\xb0\xac\xd0\xe4\x70\x94\x55\xff\x5d\xa6\x63\xef\x91\x41\x75\xc6\x93\x6e\x30\xbb\x94\x26\x07\xc2\x0b\x0d\xdf\x32\xd8\x76\x27\xfa\x1d\xbd\x35\x7d\xf5\xd4\xae\xf4\xe8\xeb\x4b\x9d\xfa\x7b\x03\x02\xfd\x43\x39\x41\xb2\xdd\xfd\x6b\x03\x16\x83\xa8\x6d\xeb\xc2\xf9\x35\x00\x83\xe1\xb3\xac\x4f\x03\x00\x00")
 
 func migrations_gateway01_initSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -84,12 +112,492 @@ func migrations_gateway01_initSql() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "migrations_gateway/01_init.sql", size: 847, mode: os.FileMode(420), modTime: time.Unix(1472146842, 0)}
+	info := bindataFileInfo{name: "migrations_gateway/01_init.sql", size: 847, mode: os.FileMode(436), modTime: time.Unix(1479378373, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _migrations_compliance01_initSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xac\x94\x4d\x53\xc2\x30\x10\x86\xef\xfd\x15\x7b\x2c\x23\x1c\x70\x84\x71\x86\xe1\x50\x68\xd0\x8e\xa5\x60\x4d\x0f\x9c\xda\x48\x83\x64\xa4\x09\x13\x52\x41\x7f\xbd\x6d\xd5\x7e\xf1\x25\xa3\x37\xd8\x3e\xbb\x7d\xf7\x69\x26\xad\x16\x5c\x45\xec\x45\x12\x45\xc1\x5b\x6b\x43\x17\x19\x18\x01\x36\x06\x36\x82\xc0\x88\xd5\x52\x48\xf6\x41\x43\x2c\x09\xdf\x90\xb9\x62\x82\x07\xa0\x6b\x00\x01\x0b\x03\x60\x5c\xe9\xed\x76\x03\x9c\x09\x06\xc7\xb3\x6d\x30\x3c\x3c\xf1\x2d\x27\x99\x32\x46\x0e\x6e\xa6\x9c\x2a\x3a\xfd\xb4\x67\xbe\x24\x52\xef\xde\x14\x4d\x19\x15\xd1\x48\x04\xf0\x46\xe4\xe1\xc7\xe5\x21\xbb\x50\x06\xa0\xe8\x4e\x55\x11\x92\x67\xf5\x89\x0a\x20\x4c\x16\x52\x2c\xa2\x55\x28\xa9\x92\x03\xcd\x53\xd7\x1a\x1b\xee\x0c\x1e\xd0\x0c\xf4\x74\xb3\x86\xd6\x00\xe4\xdc\x59\x0e\xea\x5b\x9c\x0b\x73\x00\x26\x1a\x19\x9e\x8d\x61\x78\x6f\xb8\x4f\x08\xf7\x63\xb5\xb8\xed\x69\x75\x5f\xab\x95\xd8\xd2\x70\x64\x5d\xe8\x88\x93\x88\x16\xdb\x5f\x77\x3a\xb5\xf5\x43\x11\x11\xc6\x4f\x11\xeb\xf8\x79\xc5\xe6\xfe\x2b\x7d\xff\x36\xdc\xe9\xd6\x08\xf2\x95\xed\xb8\x9c\x7d\x09\x69\xd5\x73\xac\x47\x0f\x65\xc5\x3c\x86\xfe\xf3\x6b\x8f\x28\xc7\xd0\xcb\xff\xfe\x26\xd4\xdb\x50\x79\xa1\xd2\x05\xf3\xcf\x59\x4d\x90\xf3\x62\x13\xe8\xbc\xdb\x38\xc9\x97\x1d\xee\xe3\x73\xfe\x41\x7f\x25\x8a\x9f\xbf\x53\xaf\x65\x6c\x16\x79\x7e\x6d\xbd\x7c\x0b\x98\x62\xcb\x35\xd3\x9d\x4c\x4f\xdf\x02\xbd\x2a\x93\x9f\xfc\x83\xf5\xec\x03\xf6\xb4\xcf\x00\x00\x00\xff\xff\xb0\xd9\x8a\xda\x6d\x04\x00\x00")
+var _migrations_gateway02_exchange_ratesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\xcf\xb1\x6e\x83\x30\x10\xc6\xf1\xdd\x4f\x71\x63\xab\xc2\xd0\xaa\xea\xc2\xe4\xc6\x64\x72\x00\x21\x3c\x63\xeb\x38\x01\x03\x26\x3a\x19\x12\xde\x3e\xca\x94\x84\x28\x89\x94\xf9\xfb\x7e\xc3\x3f\x8e\xe1\x6b\xe8\x5b\x76\x81\xc0\xec\x85\xd4\x55\x5a\x42\x25\xff\x75\x0a\xb6\x24\xa4\x7e\xa6\xa6\x70\xcb\x40\x3e\x58\x01\x20\x95\x82\x4d\xae\xcd\x2e\x03\x4b\x47\xec\x9c\x6f\xa9\x3e\x63\x0b\xb3\x63\xec\x1c\x7f\xfc\xfd\x7e\x82\x4a\xb7\xd2\xe8\x0a\x32\xa3\x75\xb4\x62\x38\xfa\x99\x38\x50\x53\xbb\x61\x9c\x7c\x78\x47\xe2\xc4\x4c\x1e\x97\x8b\xfd\xfe\xb9\xb5\x89\x10\xd7\x69\x6a\x3c\xf8\x97\x71\xaa\xcc\x8b\x07\x75\xd1\x7a\xbe\xab\x78\xf2\xc0\x89\x99\x3c\x2e\x36\x11\xa7\x01\x00\x3f\x61\xe3\x48\x6f\x01\x00\x00")
+
+func migrations_gateway02_exchange_ratesSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway02_exchange_ratesSql,
+		"migrations_gateway/02_exchange_rates.sql",
+	)
+}
+
+func migrations_gateway02_exchange_ratesSql() (*asset, error) {
+	bytes, err := migrations_gateway02_exchange_ratesSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/02_exchange_rates.sql", size: 367, mode: os.FileMode(420), modTime: time.Unix(1792052461, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway03_customersSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x74\x90\xc1\x4f\x83\x30\x18\xc5\xef\xfd\x2b\xbe\xdb\x20\x6e\x87\x19\x67\x4c\x96\x1d\x3a\xf8\x54\x22\x2b\xb3\xb6\x87\x9d\x68\x85\xaa\x44\x0a\x4b\x2d\x1a\xff\x7b\x83\x92\x31\x67\xbc\xf6\xfd\x5e\xdf\xf7\xde\x6c\x06\x67\xb6\x7a\x76\xda\x1b\x90\x7b\x12\x71\xa4\x02\x41\xd0\x75\x8a\xa0\xa2\xee\xcd\xb7\xd6\x38\x05\x01\x01\x50\x55\xa9\xa0\x6a\x7c\x30\x9f\x87\xc0\x32\x01\x4c\xa6\x29\x50\x29\xb2\x3c\x61\x11\xc7\x0d\x32\x31\xed\x39\x6b\x6c\x9b\xfb\xcf\xbd\x51\xf0\xae\x5d\xf1\xa2\x5d\x70\x31\x3a\x0e\xc8\xa8\x5e\x9e\xca\xc5\x10\x9c\x57\xe5\x48\x9d\x2f\x16\xa7\x98\xae\xeb\x47\x5d\xbc\xe6\x9d\xab\xff\xe1\x20\xc6\x6b\x2a\x53\x01\x93\xc9\x8f\xc5\x19\xed\x4d\x99\x6b\xaf\xa0\xd4\xde\xf8\xca\x9a\x5f\x9f\x6e\x79\xb2\xa1\x7c\x07\x77\xb8\x83\xa0\xaf\x1c\xf6\x3e\xc9\x92\x7b\x89\xdf\x8f\xc3\xed\xc1\x51\xcd\xe9\x50\x28\x24\x21\x20\xbb\x49\x18\xae\x92\xa6\x69\xe3\xf5\x21\x3d\xba\xa5\xfc\x01\xc5\xaa\xf3\x4f\x57\x4b\x42\x8e\x57\x8f\xdb\x8f\x86\xc4\x3c\xdb\xfe\x59\x7d\x49\xbe\x06\x00\xd9\x0f\x66\xce\x9e\x01\x00\x00")
+
+func migrations_gateway03_customersSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway03_customersSql,
+		"migrations_gateway/03_customers.sql",
+	)
+}
+
+func migrations_gateway03_customersSql() (*asset, error) {
+	bytes, err := migrations_gateway03_customersSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/03_customers.sql", size: 414, mode: os.FileMode(420), modTime: time.Unix(1792052686, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway04_tenantsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x92\x3f\x4f\xc3\x30\x10\xc5\xf7\x7c\x8a\xb7\x35\x15\xe9\x86\x58\x98\x42\x6d\xa4\x0a\x93\x94\x60\x4b\x30\xd5\x56\x7a\x82\x0c\xb6\x23\xd7\x14\xf5\xdb\xa3\x56\x14\xd5\x51\xf8\x2b\xc6\xf3\x9d\xdf\xfb\x3d\x9f\x67\x33\x9c\xd9\xee\x29\x98\x48\x50\x7d\x56\x0a\xc9\x1b\xc8\xf2\x4a\x70\xe8\x86\x5a\xea\xb6\xb4\x5e\x9a\x9d\x25\x17\x75\x06\x94\x8c\x61\x5e\x0b\x75\x5b\x41\x47\x72\xc6\x45\x8d\xad\x09\xed\xb3\x09\xf9\xc5\xf9\x14\x55\x2d\x51\x29\x21\xc0\xf8\x75\xa9\x84\xc4\x64\x52\x64\x00\x6b\xea\x25\x16\x15\xe3\x0f\xd0\xbe\xa7\x60\x62\xe7\xdd\xaa\x5b\xeb\xe2\x5d\x53\x55\x8b\x3b\xc5\x71\xc3\x1f\x07\x03\xc8\x8f\x3e\xc5\xa0\x33\xbd\xcc\x52\xdc\x7b\x72\x51\x06\xe3\x36\xa6\xdd\xcb\xeb\xbf\xc0\x0e\x35\xe7\x2f\x9b\xe8\x2d\x85\x7f\xca\x6e\xc9\xfa\xd1\xcc\x87\x46\x92\x75\x7f\xb2\x8a\xbb\x9e\x8e\xc5\x21\xf0\xe9\xba\x98\x7f\x75\x9f\xd3\xfe\xca\x76\xc4\xec\x03\x7d\x10\xf9\xdb\x57\xff\xc9\xa5\x91\x9f\x75\xca\x9b\xec\x79\x8c\x3b\x19\x40\x9e\xd6\x5f\xa0\xbf\x0d\x00\xd8\xbd\x29\x05\xee\x02\x00\x00")
+
+func migrations_gateway04_tenantsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway04_tenantsSql,
+		"migrations_gateway/04_tenants.sql",
+	)
+}
+
+func migrations_gateway04_tenantsSql() (*asset, error) {
+	bytes, err := migrations_gateway04_tenantsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/04_tenants.sql", size: 750, mode: os.FileMode(420), modTime: time.Unix(1792052851, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway05_from_addressSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd2\xd5\x55\xd0\xce\xcd\x4c\x2f\x4a\x2c\x49\x55\x08\x2d\xe0\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x48\x08\x4a\x4d\x4e\xcd\x2c\x4b\x4d\x09\x48\xac\xcc\x4d\xcd\x2b\x49\x50\x70\x74\x71\x51\x70\xf6\xf7\x09\xf5\xf5\x53\x48\x48\x2b\xca\xcf\x8d\x4f\x4c\x49\x29\x4a\x2d\x2e\x4e\x50\x28\x4b\x2c\x4a\xce\x48\x2c\xd2\x30\x32\x35\xd5\x54\x70\x71\x75\x73\x0c\xf5\x09\x51\xf0\x0b\xf5\xf1\xb1\xe6\xe2\x42\xb6\xc2\x25\xbf\x3c\x8f\x80\x25\x2e\x41\xfe\x01\xd8\x6d\xb1\xe6\x02\x0c\x00\x70\x81\x92\xa5\xae\x00\x00\x00")
+
+func migrations_gateway05_from_addressSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway05_from_addressSql,
+		"migrations_gateway/05_from_address.sql",
+	)
+}
+
+func migrations_gateway05_from_addressSql() (*asset, error) {
+	bytes, err := migrations_gateway05_from_addressSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/05_from_address.sql", size: 174, mode: os.FileMode(420), modTime: time.Unix(1792052966, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway06_payment_detailsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x91\x31\x6f\xf2\x30\x10\x86\x77\xff\x8a\xdb\xf8\x3e\x15\x86\xa2\x86\x85\x29\xad\xd3\xc9\x4d\x50\x94\xcc\xce\xc9\x39\x5a\x0f\xb6\x91\xed\x50\xf1\xef\xab\x48\xad\x4c\x21\xa8\x61\xbd\x57\xcf\xdd\xe9\x7d\x56\x2b\x78\x30\xfa\xdd\x63\x24\x68\x0f\x2c\x17\x4d\x51\x43\x93\x3f\x8b\x02\xba\x9a\x14\xe9\x23\xf5\x3b\x3c\x19\xb2\xb1\x63\x00\x39\xe7\xf0\x52\x89\xf6\xad\x84\x6e\xef\x9d\x91\xa8\x94\x1b\x6c\xec\xe0\x88\x5e\x7d\xa0\xff\x97\x6d\xfe\x43\x59\x35\x50\xb6\x42\x00\x2f\x5e\xf3\x56\x34\xb0\x58\x2c\x2f\x60\x34\xbf\xb1\xcd\xd3\x3c\x2c\x04\x8a\x52\xb9\x9e\x12\xfa\xb8\xbe\x03\xd5\x21\x0c\xe4\xef\x7e\xd7\x90\x71\x32\x9e\x0e\x67\x67\xe7\x3d\x3c\x82\x89\x59\x67\xd9\x2c\xca\xd3\x7e\xb0\xbd\x8c\x1e\x6d\x40\x15\xb5\xb3\x52\xf7\x69\xcd\x58\xd6\x0f\x3c\x6e\xda\x32\x76\xee\x91\xbb\x4f\xfb\xa7\x49\x5e\x57\xbb\x69\x95\xcb\xcb\x14\xcd\x8d\x79\x92\x71\x23\xfb\x6e\xfb\x2a\x4d\x75\x4e\x46\xd7\xd3\xe9\x42\xb6\xec\x6b\x00\x6f\x17\x01\x47\xbf\x02\x00\x00")
+
+func migrations_gateway06_payment_detailsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway06_payment_detailsSql,
+		"migrations_gateway/06_payment_details.sql",
+	)
+}
+
+func migrations_gateway06_payment_detailsSql() (*asset, error) {
+	bytes, err := migrations_gateway06_payment_detailsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/06_payment_details.sql", size: 703, mode: os.FileMode(420), modTime: time.Unix(1792053279, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway07_payment_linksSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\x91\x4d\x4b\xc4\x30\x10\x86\xef\xf9\x15\x73\xdb\x14\x77\x0f\x0b\x22\xc2\xb2\x87\x6c\x1b\xb5\xd8\x4d\x4b\x4c\x0f\x7b\x6a\x43\x1b\x35\x48\xa7\x4b\x1c\x57\xfc\xf7\xd2\x15\x3f\xa8\x39\x66\xf2\xcc\xc3\x3b\xbc\xab\x15\x5c\x0c\xfe\x29\x58\x72\x50\x1f\x59\xaa\xa5\x30\x12\x8c\xd8\x15\x12\xda\xca\x7e\x0c\x0e\xa9\xf0\xf8\xd2\x02\x67\x00\xad\xef\x5b\xf0\x48\x7c\xbd\x4e\x40\x95\x06\x54\x5d\x14\x20\x6a\x53\x36\xb9\x4a\xb5\xdc\x4b\x65\x96\x13\x17\x5c\xe7\xfc\xc9\xf5\xcd\xf1\x4b\xd1\xc4\x16\xcf\x24\x05\x8b\xaf\xb6\x23\x3f\xe2\x19\x3a\xd9\xd0\x3d\xdb\xc0\xaf\x2e\x67\x60\x17\x9c\x25\xd7\x37\x96\x5a\xe8\x2d\x39\xf2\x83\x9b\xa9\x1c\x5a\xa4\xb8\x02\x32\x79\x23\xea\xc2\xc0\x62\x31\xd9\x2a\x9d\xef\x85\x3e\xc0\xbd\x3c\x00\x9f\xae\x4a\xa6\xe9\xf4\x8a\x47\xe7\xd1\xf1\xef\xd2\xfc\x0a\xfe\x1d\x66\xf9\xef\x2f\x61\x09\x48\x75\x9b\x2b\xb9\xcd\x11\xc7\x6c\xf7\x13\x2d\xbd\x13\xfa\x41\x9a\xed\x1b\x3d\x5e\x6f\x18\xfb\x5b\x4d\x36\xbe\x23\xcb\x74\x59\xc5\xaa\xd9\xb0\xcf\x01\x00\xa4\x5a\x92\xcd\xc6\x01\x00\x00")
+
+func migrations_gateway07_payment_linksSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway07_payment_linksSql,
+		"migrations_gateway/07_payment_links.sql",
+	)
+}
+
+func migrations_gateway07_payment_linksSql() (*asset, error) {
+	bytes, err := migrations_gateway07_payment_linksSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/07_payment_links.sql", size: 454, mode: os.FileMode(420), modTime: time.Unix(1792053416, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway08_payment_resolutionSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\xcf\xbf\x6e\x83\x30\x10\xc7\xf1\xdd\x4f\x71\x63\xab\xc2\x52\x89\x89\xc9\xad\xe9\xe4\x02\x42\x78\xc6\x17\x38\x25\x96\x82\x1d\x99\x0b\x09\x6f\x9f\x25\x43\x22\x91\x3f\xf3\xdd\x57\xfa\x7d\xd2\x14\xbe\x46\xb7\x8d\xc8\x04\xe6\x20\xa4\x6e\x8b\x06\x5a\xf9\xa3\x0b\xb0\x0d\xf5\xe4\x66\x1a\x6a\x5c\x46\xf2\x6c\x05\x80\x54\x0a\x7e\x2b\x6d\xfe\x4b\xb0\x91\xa6\xb0\x3f\xb2\x0b\xbe\x8b\x84\x53\xf0\x16\x98\xce\x0c\xaa\xf8\x93\x46\xb7\x50\x1a\xad\x93\xb5\x66\xa6\xa1\xdb\x2c\x16\x66\x8c\xfd\x0e\xe3\xc7\x77\x96\x7d\xbe\x57\x21\x5b\x18\x90\x89\xdd\x48\x77\x45\x2e\xc4\xad\x44\x85\x93\x7f\x69\x51\x4d\x55\x3f\xc1\x24\xab\x2f\xd7\xed\x8f\x8f\xc8\x36\x17\x97\x01\x00\xfc\xca\x4d\x15\x56\x01\x00\x00")
+
+func migrations_gateway08_payment_resolutionSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway08_payment_resolutionSql,
+		"migrations_gateway/08_payment_resolution.sql",
+	)
+}
+
+func migrations_gateway08_payment_resolutionSql() (*asset, error) {
+	bytes, err := migrations_gateway08_payment_resolutionSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/08_payment_resolution.sql", size: 342, mode: os.FileMode(420), modTime: time.Unix(1792054092, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway09_sent_transaction_metadataSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\xcc\x31\x0e\xc2\x30\x0c\x05\xd0\x3d\xa7\xf8\x3b\xea\x09\x3a\x05\x1c\x26\xd3\xa2\x92\xec\xb6\xc0\x42\x1d\xea\xa2\x62\x09\x8e\xcf\x8a\xc4\xc0\x05\x5e\xd7\x61\xb7\xcc\xf7\x4d\xc3\xd0\x1e\x29\x73\x2d\x13\x6a\xde\x73\x81\x5c\xcc\xa3\x6e\xea\x4f\xbd\xc6\xbc\xba\x20\x13\xe1\x30\x72\x3b\x0d\x90\xc5\x42\x6f\x1a\x2a\x08\x7b\x07\xa8\x1c\x73\xe3\x8a\xa1\x31\xf7\x29\x7d\xb3\xb4\xbe\xfc\x0f\x4c\xd3\x78\xfe\x95\xfb\xf4\x19\x00\x81\xbd\xc6\xf6\x9e\x00\x00\x00")
+
+func migrations_gateway09_sent_transaction_metadataSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway09_sent_transaction_metadataSql,
+		"migrations_gateway/09_sent_transaction_metadata.sql",
+	)
+}
+
+func migrations_gateway09_sent_transaction_metadataSql() (*asset, error) {
+	bytes, err := migrations_gateway09_sent_transaction_metadataSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/09_sent_transaction_metadata.sql", size: 158, mode: os.FileMode(420), modTime: time.Unix(1792054275, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway10_callback_retriesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x91\xcf\x4f\xfa\x40\x10\xc5\xef\xfb\x57\xcc\x8d\x36\x5f\x38\x90\x7c\x63\x4c\x08\x87\xd2\xae\xda\x58\x16\x5c\xb7\x07\x4e\xdd\xb1\x1d\xb5\x91\x2e\x64\x19\x11\xfe\x7b\xd3\xc6\x5f\x84\xea\xf5\xbd\xcf\x4b\xde\xcc\x1b\x8d\xe0\x5f\x53\x3f\x79\x64\x82\x7c\x2b\x62\x2d\x23\x23\xc1\x44\xb3\x4c\x82\x8d\x71\xbd\x7e\xc0\xf2\x45\x13\xfb\xa3\x85\x40\x00\xd8\xba\xb2\x50\x3b\x0e\xc6\xe3\x10\xd4\xc2\x80\xca\xb3\x0c\xa2\xdc\x2c\x8a\x54\xc5\x5a\xce\xa5\x32\xc3\x96\xf3\x54\x52\xbd\xa7\xaa\xd8\xe2\xb1\x21\xc7\x45\x5f\xb0\x23\x91\x99\x9a\x2d\xef\x7e\xb1\x1d\x1d\xb8\xf8\x60\x0a\x64\x0b\x15\x32\x71\xdd\xd0\x29\xb6\xc6\x1d\x17\xe4\xfd\xc6\x5b\x60\x3a\xf0\xa9\x5b\x7a\x42\xa6\xea\x8f\x3c\x93\x43\xc7\x16\xf6\xe8\xcb\x67\xf4\xc1\xc5\xff\xef\x1e\x90\xc8\xab\x28\xcf\x0c\x0c\x06\x2d\xbb\xd4\xe9\x3c\xd2\x2b\xb8\x95\x2b\x08\xda\x87\x84\xad\x9a\xab\xf4\x2e\x97\x9d\xd8\x7f\x7c\xd0\x2b\x77\xd9\x2e\x74\x76\x68\xf0\x59\x6a\x78\x6e\x86\x22\x04\xa9\xae\x53\x25\xa7\xa9\x73\x9b\x64\xf6\xd5\x31\xbe\x89\xf4\xbd\x34\xd3\x57\x7e\xbc\x9c\x08\xf1\x73\xe0\x64\xf3\xe6\x44\xa2\x17\xcb\xfe\x81\x27\xe2\x7d\x00\x7d\xc0\xe0\x5e\x0e\x02\x00\x00")
+
+func migrations_gateway10_callback_retriesSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway10_callback_retriesSql,
+		"migrations_gateway/10_callback_retries.sql",
+	)
+}
+
+func migrations_gateway10_callback_retriesSql() (*asset, error) {
+	bytes, err := migrations_gateway10_callback_retriesSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/10_callback_retries.sql", size: 526, mode: os.FileMode(420), modTime: time.Unix(1792055078, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway11_idempotency_keysSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\x8f\xb1\xaa\xc2\x30\x18\x46\xf7\x3c\xc5\x3f\xb6\xdc\x76\xb8\x4e\x42\xa7\x68\x22\x14\x63\xaa\x35\x01\x9d\x4c\xa8\x41\x8b\xf4\x4f\x89\x41\xe9\xdb\xbb\x28\x08\x55\x9c\x0f\xe7\xe3\x7c\x79\x0e\x7f\x5d\x7b\x0a\x36\x3a\xd0\x3d\xa1\x42\xf1\x1a\x14\x9d\x09\x0e\x66\xeb\x30\xaa\x60\xf1\x6a\x9b\xd8\x7a\x34\x04\x80\x32\x06\xf3\x4a\xe8\x95\x04\xd3\x1e\x5d\xd7\xfb\xe8\xb0\x19\x0e\x17\x37\x18\xb8\xd9\xd0\x9c\x6d\x48\xfe\x27\xd3\x14\x18\x5f\x50\x2d\x14\x48\x2d\x44\xf6\x34\xb5\x2c\x37\x9a\xc3\x92\xef\x3f\xd8\x89\x89\x0e\x2d\x46\x93\x8d\x61\x5a\x10\xf2\x5e\xca\xfc\x1d\x7f\xb6\xb2\xba\x5a\x43\x29\x19\xdf\x8d\x07\xb3\x17\xff\x76\xa6\x20\x8f\x01\x00\x24\x60\x2e\x9a\x1a\x01\x00\x00")
+
+func migrations_gateway11_idempotency_keysSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway11_idempotency_keysSql,
+		"migrations_gateway/11_idempotency_keys.sql",
+	)
+}
+
+func migrations_gateway11_idempotency_keysSql() (*asset, error) {
+	bytes, err := migrations_gateway11_idempotency_keysSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/11_idempotency_keys.sql", size: 282, mode: os.FileMode(420), modTime: time.Unix(1792055838, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway12_limit_countersSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\x90\x5d\x4b\xf3\x30\x14\xc7\xef\xf3\x29\xce\xdd\x5a\x9e\x0e\x9e\x0d\x27\xc2\xd8\x45\xd6\x46\x2d\x76\xe9\x8c\xc9\xc5\xae\x9a\xb8\xc6\x19\xa4\xa9\xd4\x33\x8b\xdf\x5e\x32\xd1\xda\xe1\xed\xe1\x77\xfe\x6f\xd3\x29\xfc\x6b\xdc\xa1\x33\x68\x41\xbd\x92\x54\x30\x2a\x19\x48\xba\x2e\x18\xe8\xc2\x35\x0e\xd3\xf6\xe8\xd1\x76\x1a\x22\x02\xa0\x5d\xad\xc1\x79\x8c\x66\xb3\x18\x78\x29\x81\xab\xa2\x00\xaa\x64\x59\xe5\x3c\x15\x6c\xc3\xb8\x4c\x02\xb7\xff\xfa\xaa\x5e\xec\x87\x86\x77\xd3\xed\x9f\x4d\x17\xcd\x17\x8b\xe1\xeb\x84\xf5\xce\xd7\x6d\x5f\xbd\xa1\xe9\x50\x43\x6d\xd0\xa2\x6b\xec\x98\x31\x4d\xd0\xd2\xf0\xe8\x0e\xc1\x79\xfe\xff\x4c\x03\xad\x37\x1e\x07\x97\xcb\x8b\x01\x80\x8c\x5d\x53\x55\x48\x98\x4c\x02\xbb\x15\xf9\x86\x8a\x1d\xdc\xb1\x1d\x44\xa1\x4b\x1c\xae\x8a\xe7\xf7\x8a\x9d\x8e\xe3\xdc\xd1\xb7\x76\x32\x2e\x94\x9c\x05\x8f\x49\x0c\x8c\xdf\xe4\x9c\xad\x72\xef\xdb\x6c\xfd\x63\x9b\xde\x52\xf1\xc0\xe4\xea\x88\x4f\x57\x4b\x42\x7e\xaf\x9d\xb5\xbd\x27\x99\x28\xb7\x7f\xae\xbd\x24\x9f\x03\x00\xea\xd8\x43\x51\x9a\x01\x00\x00")
+
+func migrations_gateway12_limit_countersSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway12_limit_countersSql,
+		"migrations_gateway/12_limit_counters.sql",
+	)
+}
+
+func migrations_gateway12_limit_countersSql() (*asset, error) {
+	bytes, err := migrations_gateway12_limit_countersSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/12_limit_counters.sql", size: 410, mode: os.FileMode(420), modTime: time.Unix(1792056900, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway13_sep31_transactionsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\x93\x41\x6f\x9c\x30\x10\x85\xef\xfc\x8a\xb9\x85\x55\x13\xa9\x9b\x74\xa3\x4a\x51\x0e\x64\xd7\x6d\x51\x37\x6c\x4a\xe0\x90\x93\x71\xcd\x6c\x6a\x09\x6c\x64\x0f\x69\xf3\xef\x2b\xd3\x26\x40\x20\xbb\x47\xcc\x37\xa3\xf7\x66\xe6\x9d\x9d\xc1\x87\x5a\x3d\x5a\x41\x08\x79\x13\xac\x53\x16\x65\x0c\xb2\xe8\x66\xcb\xa0\xb8\xc7\xe6\x62\x99\x59\xa1\x9d\x90\xa4\x8c\x2e\x20\x0c\x00\x0a\x55\x16\xa0\x34\x85\xcb\xe5\x02\x92\x5d\x06\x49\xbe\xdd\x42\x94\x67\x3b\x1e\x27\xeb\x94\xdd\xb2\x24\x3b\xf5\x5c\xd3\xfe\xac\x94\xe4\x1e\x7f\x12\x56\xfe\x12\x36\xbc\xb8\xec\x4b\x3a\xc6\x91\xa0\xd6\x0d\x80\xf3\x37\x80\xa8\x4d\xab\x89\x2b\xdd\x33\x97\x9f\xe6\x99\x3d\xe2\x71\xc8\xb4\x74\x08\x72\x0e\x89\x4b\x53\x0e\x3a\x2d\x27\x92\x3a\x48\x39\xd7\xa2\xed\xb1\xd5\xc4\x1a\xea\x12\xed\xc8\xfe\xf9\x6a\xf5\x06\xb2\x28\x51\x3d\x1d\xc5\xf6\x0a\xab\xd2\x15\x40\xf8\x87\xc6\x7f\x64\xa5\x50\x13\x17\x52\xfa\x39\x1d\x90\x53\x63\x6d\x38\x3d\x37\x43\x67\x1f\x67\x98\x03\xd3\x71\x84\x55\x25\x2c\xa7\xfe\x24\x46\xba\xbb\x02\x7f\x0c\x1b\xf6\x25\xca\xb7\x53\x9b\x25\x6f\xc4\x73\xed\xf5\x4e\xed\xce\xd6\xfd\x3b\x0f\x5e\xa3\x73\xe2\x11\x5f\xfc\xbf\x87\x5a\xc2\x92\x0b\x2a\xa0\x14\x84\xa4\x6a\x1c\xcb\x97\xa6\x6e\x2a\x9c\x32\xb3\xed\x08\xb5\xd0\x34\x3f\x8c\x57\xfa\xe4\xc4\xb3\x77\x69\x7c\x1b\xa5\x0f\xf0\x9d\x3d\x40\xe8\xd3\xb1\xf0\xaf\x79\x12\xff\xc8\x59\xf7\x38\x4c\x42\xf8\xd2\xf9\x74\x18\x90\x49\x85\xdf\xd6\x18\xee\xf7\xf7\xff\xa3\x58\x04\x0b\x60\xc9\xd7\x38\x61\xd7\xb1\xd6\x66\x73\xf3\xaa\x6b\xfd\x2d\x4a\xef\x59\x76\xdd\xd2\xfe\xf3\x55\x10\x0c\x43\xbe\x31\xbf\x75\xb0\x49\x77\x77\xef\x86\xfc\x2a\xf8\x3b\x00\x83\x19\x9f\x65\x15\x04\x00\x00")
+
+func migrations_gateway13_sep31_transactionsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway13_sep31_transactionsSql,
+		"migrations_gateway/13_sep31_transactions.sql",
+	)
+}
+
+func migrations_gateway13_sep31_transactionsSql() (*asset, error) {
+	bytes, err := migrations_gateway13_sep31_transactionsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/13_sep31_transactions.sql", size: 1045, mode: os.FileMode(420), modTime: time.Unix(1792057670, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway14_receiving_accountsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\xcd\x31\x0e\xc2\x20\x18\x06\xd0\x9d\x53\x7c\x5b\x35\xa6\xa3\x2e\x9d\x50\x70\xfa\x85\x86\xc0\x2c\x04\x49\x65\x28\x35\xa4\xd6\x78\x7b\xa3\x93\x93\xce\x6f\x78\x6d\x8b\xcd\x98\x87\x1a\xe6\x04\x77\x63\x9c\xac\x34\xb0\x7c\x4f\x12\xde\xa4\x98\xf2\x92\x2e\x7d\x78\x8e\xa9\xcc\x1e\x5c\x08\x1c\x34\xb9\x93\x82\xaf\x1f\xcc\x65\x38\x87\x18\xa7\xfb\x9b\x97\x50\xe3\x35\xd4\xd5\x76\xb7\x86\xd2\x16\xca\x11\x41\xc8\x23\x77\x64\xd1\x34\x1d\x63\xdf\x9b\x98\x1e\xe5\xcf\x27\x8c\xee\x7f\x84\x1d\x7b\x0d\x00\x98\x82\x7e\xf7\xbe\x00\x00\x00")
+
+func migrations_gateway14_receiving_accountsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway14_receiving_accountsSql,
+		"migrations_gateway/14_receiving_accounts.sql",
+	)
+}
+
+func migrations_gateway14_receiving_accountsSql() (*asset, error) {
+	bytes, err := migrations_gateway14_receiving_accountsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/14_receiving_accounts.sql", size: 190, mode: os.FileMode(420), modTime: time.Unix(1792059801, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway15_callback_attemptsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x74\x52\x4d\x6f\x82\x40\x10\xbd\xf3\x2b\xe6\x26\xa4\x9a\x54\x53\x9b\x26\xc6\x03\xc2\xb6\x25\x45\x34\x14\x0e\x9e\x60\x85\xa9\xdd\x14\x16\xb2\x3b\xb4\xf5\xdf\x37\x98\x28\x14\xe3\x71\xe7\x7d\xec\x7b\xbb\x33\x99\xc0\x5d\x29\x0e\x8a\x13\x42\x5c\x1b\x4e\xc8\xec\x88\x41\x64\xaf\x7c\x06\xa9\xc3\x8b\x62\xcf\xb3\x2f\x9b\x08\xcb\x9a\x52\x30\x0d\x80\x54\xe4\x29\xec\xc5\x41\x48\x82\x60\x13\x41\x10\xfb\x3e\xd8\x71\xb4\x49\xbc\xc0\x09\xd9\x9a\x05\xd1\xb8\xa5\x55\x35\x2a\x4e\xa2\x92\x49\x2b\xf8\xe6\x2a\xfb\xe4\xca\x9c\xcd\xe7\xd6\x45\x76\xe2\xf1\xb3\xb9\x90\x64\x4e\xa7\x03\xb4\x51\x45\x27\x9e\xde\xcf\x1e\x06\xb8\x6e\xb2\x0c\xb5\x4e\x81\x84\x3c\x9e\x1c\x86\x04\xe2\xd4\xe8\x24\xab\x72\xec\xae\x70\xd9\xb3\x1d\xfb\x3d\x56\xc1\x09\x65\x76\x4c\x4a\x7d\xd5\xed\x84\x2b\xd4\x75\x25\x35\xa6\x40\xf8\x4b\xd7\x7a\x54\xaa\x52\xb7\xc0\x4c\x21\x27\xcc\x13\x4e\x29\xe4\x9c\x90\x44\x89\xff\xed\x09\x25\x97\xd4\x15\x7d\xec\xd5\xbc\xf8\x8d\x46\x6d\x94\x6d\xe8\xad\xed\x70\x07\x6f\x6c\x07\x66\xfb\x17\x56\x3b\x6d\x4f\x83\x17\x37\xcf\xae\xe3\x01\xd2\x09\xfa\xc1\xfa\xf4\xde\xdc\x32\x2c\x60\xc1\x8b\x17\xb0\xa5\x27\x65\xe5\xae\x2e\x71\x9c\x57\x3b\x7c\x67\xd1\xb2\xa1\x8f\xa7\x85\x61\xf4\x17\xc9\xad\x7e\xa4\xe1\x86\x9b\xed\xad\x45\x5a\x18\x7f\x03\x00\xa3\x10\x51\x5b\x78\x02\x00\x00")
+
+func migrations_gateway15_callback_attemptsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway15_callback_attemptsSql,
+		"migrations_gateway/15_callback_attempts.sql",
+	)
+}
+
+func migrations_gateway15_callback_attemptsSql() (*asset, error) {
+	bytes, err := migrations_gateway15_callback_attemptsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/15_callback_attempts.sql", size: 632, mode: os.FileMode(420), modTime: time.Unix(1792061082, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway16_api_keysSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x90\x31\x4f\xc3\x30\x10\x85\x77\xff\x8a\xdb\x9a\x08\xba\x20\x8a\x90\xaa\x0e\x6e\x72\x80\xd5\xd4\x09\xc6\x1e\x3a\xc5\xa6\x31\x8d\x85\xe2\x54\x89\x01\xf5\xdf\xa3\x64\x68\x43\x07\xb6\xd3\xbd\x4f\xef\xde\xbd\xf9\x1c\x6e\x1a\x77\xe8\x4c\xb0\xa0\x8e\x24\x11\x48\x25\x82\xa4\xeb\x0c\x41\xd3\x82\x6d\xec\x49\x43\x44\x00\xb4\xab\x34\xbc\xbb\x83\xf3\x01\x78\x2e\x81\xab\x2c\x03\xaa\x64\x5e\x32\x9e\x08\xdc\x22\x97\xb7\x03\xe6\x4d\x63\x35\x7c\x9b\x6e\x5f\x9b\x2e\xba\x5b\x2c\xe2\x33\x3e\xea\x9f\xf6\x54\xd6\xa6\xaf\x35\x8c\xc0\xc3\xfd\x95\x1e\xac\x37\x3e\x5c\x1c\xa6\x00\xa4\xf8\x44\x55\x26\x61\x36\x1b\xd9\xa3\xed\x1a\xd7\xf7\xae\xf5\xfd\x7f\x27\xf7\x9d\x35\xc1\x56\xa5\x09\x1a\x2a\x13\x6c\x70\x8d\xfd\x43\x14\x82\x6d\xa9\xd8\xc1\x06\x77\x10\x0d\x8f\xc6\x83\xbd\xe2\xec\x55\xe1\xb8\x9c\xa4\x8e\x2e\x73\x4c\x62\x40\xfe\xcc\x38\xae\x98\xf7\x6d\xba\x3e\xe7\x4b\x5e\xa8\x78\x43\xb9\xfa\x0a\x1f\x8f\x4b\x42\xa6\x25\xa7\xed\x8f\x27\xa9\xc8\x8b\xab\x92\x97\xe4\x77\x00\x82\x79\x16\x7a\x8b\x01\x00\x00")
+
+func migrations_gateway16_api_keysSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway16_api_keysSql,
+		"migrations_gateway/16_api_keys.sql",
+	)
+}
+
+func migrations_gateway16_api_keysSql() (*asset, error) {
+	bytes, err := migrations_gateway16_api_keysSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/16_api_keys.sql", size: 395, mode: os.FileMode(420), modTime: time.Unix(1792062394, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway17_pending_transactionsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\x92\x51\x8f\x9a\x40\x14\x85\xdf\xf9\x15\xf7\x4d\x48\x35\x69\x6d\xb5\x4d\x8c\x0f\x28\xd3\x96\x14\xd1\xb2\xf0\xe0\x13\x33\xcb\xdc\xc5\x49\x96\xc1\x1d\x2e\xea\xcf\xdf\x40\x5c\x61\xd9\xc4\x7d\x9d\xf3\x9d\x39\xe7\xe6\xde\xc9\x04\xbe\x14\x2a\x37\x82\x10\x92\xa3\xb5\x8e\x98\x1b\x33\x88\xdd\x55\xc0\x80\xef\x50\x4b\xa5\xf3\xd8\x08\x5d\x89\x8c\x54\xa9\x39\xd8\x16\x00\x57\x92\xc3\xa3\xca\x95\x26\x08\xb7\x31\x84\x49\x10\x80\x9b\xc4\xdb\xd4\x0f\xd7\x11\xdb\xb0\x30\x1e\x37\x18\x75\xc6\xb4\xb1\x64\x07\x61\xec\xf9\x0f\xe7\x66\x6a\xa9\x8a\x04\xd5\x15\x87\x93\x30\x2d\xf0\x6d\x3e\x04\xca\xda\x64\x78\xb5\xcf\x86\x2a\xea\x13\x3e\x97\x47\x4c\x2f\xd2\x70\x20\xbc\x74\x9d\x5a\xb7\xc1\x97\x5a\x19\x94\xe9\x19\x55\x7e\x20\x0e\xfd\xd6\x2d\x51\xa9\x5c\xdf\xd3\x25\x56\xa4\xb4\x68\xc6\xe8\x5a\x4e\x67\xb3\x41\x11\x51\x94\xb5\xa6\x8e\xf8\x3e\x1d\x02\x55\x85\x3d\xfd\xe7\xd7\x81\x5e\x20\x09\x29\x48\x5c\xa7\xf0\xd8\x6f\x37\x09\x7a\x7a\x66\x50\x10\xca\x54\x10\x07\x29\x08\x49\x15\xf8\xfe\x87\xfa\x28\x3f\x21\x08\xb5\xe8\x97\xec\x6f\xe3\x96\x38\x1a\x35\x79\xbb\xc8\xdf\xb8\xd1\x1e\xfe\xb1\x3d\xd8\xcd\xca\x9d\xe6\x35\x09\xfd\xff\x09\x6b\x1f\xaf\x9f\xa5\xc3\x35\xdb\x6f\x29\xe3\x0f\x27\xe0\x58\x0e\xb0\xf0\x8f\x1f\xb2\xa5\xaf\x75\xe9\xad\x6e\x99\xeb\xbf\x6e\xf4\xc0\xe2\x65\x4d\x4f\xbf\x16\x96\xd5\xbf\x4b\xaf\x3c\x6b\xcb\x8b\xb6\xbb\x3b\x77\xb9\xb0\x5e\x07\x00\xfa\xa6\x08\xf4\xca\x02\x00\x00")
+
+func migrations_gateway17_pending_transactionsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway17_pending_transactionsSql,
+		"migrations_gateway/17_pending_transactions.sql",
+	)
+}
+
+func migrations_gateway17_pending_transactionsSql() (*asset, error) {
+	bytes, err := migrations_gateway17_pending_transactionsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/17_pending_transactions.sql", size: 714, mode: os.FileMode(420), modTime: time.Unix(1792065158, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway18_listener_leasesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\xd0\xcd\x4e\xc3\x30\x10\x04\xe0\xbb\x9f\x62\x6e\x4d\x44\x7b\x41\x14\x21\x55\x3d\xb8\x8d\x81\x08\x93\x56\xc6\x39\xf4\x84\x57\x74\xa1\x96\x88\x53\x39\xcb\xcf\xe3\x23\x10\xff\xea\x79\x3f\xed\x8c\x66\x32\xc1\x51\x17\x1f\x32\x09\xa3\xdd\xab\xa5\x33\xda\x1b\x78\xbd\xb0\x06\xc1\xc6\x41\x38\x71\xb6\x4c\x03\x07\x14\x0a\x08\x89\x3a\x0e\x78\xa6\x7c\xb7\xa3\x5c\x9c\x9e\x94\x68\x56\x1e\x4d\x6b\xed\xf8\xfd\x2c\x9c\x28\xc9\x61\x80\xca\x9c\xeb\xd6\x7a\x8c\x46\x1f\x76\xd7\x3f\x6e\x39\xff\xd8\xe3\xe9\xf4\xdf\x37\x7e\xdd\xc7\xcc\xc3\x2d\x49\xc0\x96\x84\x25\x76\xfc\x47\xac\x5d\x7d\xad\xdd\x06\x57\x66\x83\xe2\x2b\x7c\xfc\xd9\xb2\x54\x25\x4c\x73\x51\x37\x66\x5e\xa7\xd4\x57\x8b\xef\x02\xcb\x4b\xed\x6e\x8c\x9f\x3f\xc9\xfd\xd9\x4c\xa9\xdf\x23\x54\xfd\x4b\x52\x95\x5b\xad\x0f\x8f\x30\x53\x6f\x03\x00\xbe\x54\x3d\xae\x32\x01\x00\x00")
+
+func migrations_gateway18_listener_leasesSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway18_listener_leasesSql,
+		"migrations_gateway/18_listener_leases.sql",
+	)
+}
+
+func migrations_gateway18_listener_leasesSql() (*asset, error) {
+	bytes, err := migrations_gateway18_listener_leasesSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/18_listener_leases.sql", size: 306, mode: os.FileMode(420), modTime: time.Unix(1792066535, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway19_callback_deliveriesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\x90\xc1\x4b\xc3\x30\x14\xc6\xef\xf9\x2b\xde\x6d\x2d\x76\xa0\xc3\x89\x30\x76\xc8\x9a\xa8\xc5\xda\x8d\x98\x1e\x76\x5a\x9e\x6b\xd4\x60\x97\x8c\xf0\x36\xf1\xbf\x17\x45\x6d\x7b\x90\x1d\x1f\xef\xc7\xc7\xef\xfb\xc6\x63\x38\xdb\xb9\x97\x88\x64\xa1\xde\xb3\x5c\x49\xae\x25\x68\xbe\x28\x25\x98\x1c\xdb\xf6\x09\xb7\x6f\xc2\xb6\xee\x68\xe3\x87\x81\x84\x01\x98\xe6\xe7\xdc\xb8\xc6\xc0\x11\xe3\xf6\x15\x63\x32\x99\x4e\x53\xa8\x96\x1a\xaa\xba\x2c\xb3\x2f\x8c\xac\x47\x4f\x1d\x71\x75\xd9\x01\x20\xe4\x0d\xaf\x4b\x0d\xa3\xd1\x37\x1b\xf6\x36\x22\xb9\xe0\x4f\x64\x1e\x62\xdb\xbd\x2f\xce\x27\xbd\xc8\xac\xa7\x66\x9b\x0d\x92\x81\x06\xc9\x92\xdb\xd9\x01\xb3\x52\xc5\x03\x57\x6b\xb8\x97\x6b\x48\x7e\x25\xb3\x61\xab\x94\xa5\x20\xab\xdb\xa2\x92\xf3\xc2\xfb\x20\x16\x7f\xbe\xf9\x1d\x57\x8f\x52\xcf\x0f\xf4\x7c\x3d\x63\xac\x3f\x9f\x08\xef\x9e\x09\xb5\x5c\xfd\x3b\xdf\x8c\x7d\x0e\x00\xa8\xeb\x06\xf1\x6f\x01\x00\x00")
+
+func migrations_gateway19_callback_deliveriesSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway19_callback_deliveriesSql,
+		"migrations_gateway/19_callback_deliveries.sql",
+	)
+}
+
+func migrations_gateway19_callback_deliveriesSql() (*asset, error) {
+	bytes, err := migrations_gateway19_callback_deliveriesSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/19_callback_deliveries.sql", size: 367, mode: os.FileMode(420), modTime: time.Unix(1792067943, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway20_return_memosSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x8f\x41\x4b\x44\x21\x14\x85\xf7\xfe\x8a\xb3\xb3\xa1\x06\x0a\x66\x37\xb4\x30\x74\x28\xb0\x99\xc1\x94\x68\x95\xf2\xba\xf4\x66\xa1\x0e\x6a\x2f\xde\xbf\x0f\x0b\xa2\xa2\x36\x6d\xef\xe5\x7c\xe7\x7c\xcb\x25\x4e\xe3\xe1\xb9\x84\x46\x70\x47\x26\xb4\x55\x06\x56\x5c\x69\x05\x6f\x68\xa0\xc3\x44\x4f\xfb\x30\x47\x4a\xcd\xe3\x76\x27\x6f\x36\x0f\xf0\x91\x62\x7e\x6c\xf3\x91\x3c\xa6\x50\x86\x31\x94\x93\x8b\xf3\x05\xb6\x3b\x8b\xad\xd3\x1a\x52\x6d\x84\xd3\x16\x9c\xaf\x19\xfb\xda\x20\xf3\x6b\xea\x07\x43\xed\xa5\x24\x74\x4e\x45\x28\x84\x31\xd4\x91\xea\xd9\x27\x6e\xb5\xc0\x10\x52\xca\x0d\xb5\xe5\x42\xf0\xe5\x3d\xe1\xd1\x5b\x99\xdb\x4b\x61\x7f\x1b\x78\xa7\xec\xb7\x75\x97\xe0\x9d\xcc\x71\x7f\xad\x8c\xfa\xf9\xfa\x60\xf2\xf5\xff\xad\x57\x7f\x48\xbf\x0d\x00\x49\xf0\xcc\xd7\x56\x01\x00\x00")
+
+func migrations_gateway20_return_memosSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway20_return_memosSql,
+		"migrations_gateway/20_return_memos.sql",
+	)
+}
+
+func migrations_gateway20_return_memosSql() (*asset, error) {
+	bytes, err := migrations_gateway20_return_memosSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/20_return_memos.sql", size: 342, mode: os.FileMode(420), modTime: time.Unix(1792075355, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway21_pending_complianceSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\xd2\xcf\x4e\xb3\x40\x10\x00\xf0\xfb\x3e\xc5\xdc\x0a\xf9\xda\xe4\x6b\x62\x8c\x49\xd3\x03\x85\x55\x89\x94\x36\xb8\x1c\x7a\x62\x37\x65\x5a\x37\x29\x0b\x2e\x83\xf2\xf8\x86\xaa\x6d\x15\x25\xf1\xb8\x99\xdf\xcc\x66\xfe\x4c\x26\xf0\xaf\xd0\x7b\xab\x08\x21\xad\x98\x9f\x70\x4f\x70\x10\xde\x22\xe2\x20\xd7\x68\x72\x6d\xf6\x7e\x59\x54\x07\xad\xcc\x16\x25\x38\x0c\x40\xea\x5c\x82\x36\xe4\x4c\xa7\x2e\xc4\x2b\x01\x71\x1a\x45\xe0\xa5\x62\x95\x85\xb1\x9f\xf0\x25\x8f\xc5\xb8\x73\x84\x46\x19\x92\xf0\xa2\xec\xf6\x49\x59\xe7\xfa\xea\xc2\x07\xfc\xd6\x4b\x23\x01\xa3\xd1\xd1\xd6\x68\xf2\xcc\xe2\x73\x83\x35\x49\x20\x6c\xe9\x44\xdf\xe3\xa4\xa8\xa9\xcf\xb5\xa6\xff\xcf\xb5\x8e\x80\xda\x6c\xc8\x7c\xff\x4f\x9b\x5d\xf9\xa7\x04\x45\x84\x45\x45\x75\xbf\xf5\x63\xd8\x60\x4b\xd9\x87\xc9\x14\x49\xc8\x15\x21\xe9\x02\xbf\x32\x6c\x2b\x6d\xb1\x1e\x10\x07\x55\x53\x86\xd6\x96\xf6\xa7\x31\x6c\x2d\x2a\xc2\x7c\x20\xbf\xa9\xf2\x61\xb1\x4e\xc2\xa5\x97\x6c\xe0\x81\x6f\xc0\xe9\x76\xe9\x76\x79\xdd\xab\xdf\x84\xf3\xb9\xc3\xf1\x69\x03\xe3\x3e\x73\x99\x0b\x3c\xbe\x0b\x63\x3e\x0f\x8d\x29\x83\xc5\x69\x76\xfe\xbd\x97\x3c\x72\x31\x6f\x68\x77\x33\x63\xec\xf2\xda\x82\xf2\xd5\xb0\x20\x59\xad\x7f\xbf\xb6\x19\x7b\x1b\x00\x56\x0f\x78\xbd\x9f\x02\x00\x00")
+
+func migrations_gateway21_pending_complianceSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway21_pending_complianceSql,
+		"migrations_gateway/21_pending_compliance.sql",
+	)
+}
+
+func migrations_gateway21_pending_complianceSql() (*asset, error) {
+	bytes, err := migrations_gateway21_pending_complianceSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/21_pending_compliance.sql", size: 671, mode: os.FileMode(420), modTime: time.Unix(1792070331, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway22_sent_transaction_paymentSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd2\xd5\x55\xd0\xce\xcd\x4c\x2f\x4a\x2c\x49\x55\x08\x2d\xe0\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x48\x08\x4e\xcd\x2b\x09\x29\x4a\xcc\x2b\x4e\x4c\x2e\xc9\xcc\xcf\x4b\x50\x70\x74\x71\x51\x70\xf6\xf7\x09\xf5\xf5\x53\x48\x48\x49\x2d\x2e\xc9\xcc\x4b\x84\x48\x94\x25\x16\x25\x67\x24\x16\x69\x18\x99\x9a\x6a\x2a\xb8\xb8\xba\x39\x86\xfa\x84\x28\xf8\x85\xfa\xf8\x58\x93\x60\x62\x62\x6e\x7e\x69\x5e\x09\xc2\x30\x63\x23\x0a\xcc\x2a\x2e\x4e\x45\x32\xca\xdc\x00\xdd\x28\x2e\x64\x8f\xbb\xe4\x97\xe7\x11\x30\xdc\x25\xc8\x3f\x00\xab\xdf\xad\x49\xd1\x08\xf5\x22\x69\x7a\x8a\x8b\x53\x4b\x12\xac\xb9\x00\x03\x00\xd0\xd0\x3b\x20\xaa\x01\x00\x00")
+
+func migrations_gateway22_sent_transaction_paymentSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway22_sent_transaction_paymentSql,
+		"migrations_gateway/22_sent_transaction_payment.sql",
+	)
+}
+
+func migrations_gateway22_sent_transaction_paymentSql() (*asset, error) {
+	bytes, err := migrations_gateway22_sent_transaction_paymentSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/22_sent_transaction_payment.sql", size: 426, mode: os.FileMode(420), modTime: time.Unix(1792071167, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway23_transfer_transactionsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x94\xcf\x6e\x9c\x30\x10\xc6\xef\x3c\xc5\xdc\x02\x6a\x22\x65\xd3\x6e\x54\x29\xca\x81\xec\xba\x2d\xea\x86\x4d\x29\x1c\x72\x32\xae\x99\x4d\xad\x62\x1b\xd9\x43\xda\xbc\x7d\x05\x6d\xca\x9f\xec\x46\xdb\x13\x82\xf9\xcd\x37\x1f\x9e\x19\x9f\x9d\xc1\x1b\xad\x1e\x9c\x20\x84\xa2\x09\x56\x19\x8b\x73\x06\x79\x7c\xb3\x61\x50\xe6\x4e\x18\xbf\x43\xd7\x3f\x85\x24\x65\x4d\x09\x61\x00\x50\xaa\xaa\x04\x65\x28\x5c\x2c\x22\x48\xb7\x39\xa4\xc5\x66\x03\x71\x91\x6f\x79\x92\xae\x32\x76\xcb\xd2\xfc\xb4\xe3\x9a\xf6\x5b\xad\x24\xef\xf0\x47\xe1\xe4\x77\xe1\xc2\xb7\x97\x43\x4a\xcf\xfc\x50\x66\x14\x5e\x9c\xcf\xc2\x9e\x04\xb5\x7e\x94\x7f\x31\x03\x84\xf7\x48\x5c\xda\x0a\x47\x2a\xfb\x21\xe5\x7d\x8b\x6e\xc0\x96\x73\x2f\x42\xdb\xd6\x10\x57\x66\x60\x2e\xdf\x45\x7d\x1c\xd6\xec\x43\x5c\x6c\x5e\xc2\x3b\xc4\xff\xa0\x6d\x4b\x47\xd1\x52\x76\x4e\x5e\xb1\x4a\x4f\xcd\xbc\xee\x36\x9f\xaa\x9d\x9c\xf4\xe4\x4e\x61\x5d\xf9\x12\x08\x7f\xd1\x54\x43\xa3\xb6\x7c\x2a\xb4\x38\x3f\x64\xa9\x83\x67\x05\xf7\x72\x9e\xb0\xae\x85\xe3\x34\x4c\xcd\x64\x00\x0e\x67\x3a\x94\xa8\x1e\xb1\xe2\x8d\x78\xd2\x68\x68\x92\x76\xb1\x5c\x1e\xae\xd8\x8d\x08\xd7\xe8\xbd\x78\xc0\xe7\x1f\x3d\x84\x3a\xc2\x8a\x0b\x2a\xa1\x12\x84\xa4\x34\x4e\xcf\x44\x5a\xdd\xd4\xf8\x92\xd9\x2b\x47\x68\x84\xa1\xa3\xda\x70\x97\x25\xb7\x71\x76\x0f\x9f\xd9\x3d\x84\xdd\x02\x45\x9d\x42\x91\x26\x5f\x0a\xd6\x7f\x1c\x2f\x4b\xf8\xac\x7c\x3a\xde\xa1\x3e\xa3\x47\xff\xf4\x62\x4c\x0d\xad\xfc\xfb\x52\x46\x41\x04\x2c\xfd\x98\xa4\xec\x3a\x31\xc6\xae\x6f\xfe\x19\x5a\x7d\x8a\xb3\xaf\x2c\xbf\x6e\x69\xf7\xfe\x2a\x08\xc6\x77\xc0\xda\xfe\x34\xc1\x3a\xdb\xde\xbd\x76\x07\x5c\x05\xbf\x07\x00\x71\x38\x89\x61\x37\x04\x00\x00")
+
+func migrations_gateway23_transfer_transactionsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway23_transfer_transactionsSql,
+		"migrations_gateway/23_transfer_transactions.sql",
+	)
+}
+
+func migrations_gateway23_transfer_transactionsSql() (*asset, error) {
+	bytes, err := migrations_gateway23_transfer_transactionsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/23_transfer_transactions.sql", size: 1079, mode: os.FileMode(420), modTime: time.Unix(1792072117, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway24_received_payment_transactionSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x90\x31\x4f\xc3\x30\x10\x85\x77\xff\x8a\x1b\x41\xa4\x1b\x42\x42\x9d\x02\x0e\x93\x69\xab\x28\x99\xed\xc3\x39\x1c\x4b\x8d\x8d\xec\x6b\x11\xff\x1e\xb5\x62\x30\x01\x4a\x36\xcb\x7a\x4f\xef\xbe\x6f\xb5\x82\x9b\xc9\xbb\x84\x4c\xd0\xbf\x89\x5a\x75\x4d\x0b\x5d\xfd\xa0\x1a\x30\x2d\x59\xf2\x47\x1a\x76\xf8\x31\x51\x60\x23\x00\x6a\x29\xe1\x71\xab\xfa\xe7\x0d\x18\x4e\x18\x32\x5a\xf6\x31\xe8\x11\xf3\x68\xe0\x88\xc9\x8e\x98\xae\xee\x6e\xaf\x41\x36\x4f\x75\xaf\x3a\xd8\xf4\x4a\x55\xb3\xe6\x9e\x06\x47\xc9\xc0\x8b\x77\x3e\xf0\x82\xa8\xb6\xfb\x98\x49\xb3\x9f\xc8\xc0\x80\x4c\xa7\xd7\x39\x7f\xb1\xfc\x4a\xa4\x4f\x07\x39\x1a\x16\x8d\x95\x44\x39\x1e\x92\x25\x8d\xd6\xc6\x43\xe0\x82\xed\xfe\x3b\xdb\x5a\x88\xd2\xa1\x8c\xef\xe1\x5f\x8b\xb2\xdd\xee\x7e\x1d\x3d\x6b\xac\xe6\x89\x2f\x5d\x7f\xfc\x97\x6e\x7e\x44\x4a\x03\xd5\xa5\xe5\x19\xee\x5a\x7c\x0e\x00\x2a\x40\x1e\x0e\x19\x02\x00\x00")
+
+func migrations_gateway24_received_payment_transactionSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway24_received_payment_transactionSql,
+		"migrations_gateway/24_received_payment_transaction.sql",
+	)
+}
+
+func migrations_gateway24_received_payment_transactionSql() (*asset, error) {
+	bytes, err := migrations_gateway24_received_payment_transactionSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/24_received_payment_transaction.sql", size: 537, mode: os.FileMode(420), modTime: time.Unix(1792073476, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway25_pending_transaction_versionSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\xcd\xb1\x0a\xc2\x30\x10\x06\xe0\x3d\x4f\xf1\xef\x52\x70\xef\x14\x4d\x9d\xce\xa4\x94\xcb\x9e\xa8\x21\xdc\xe0\x55\xd2\xa0\xaf\x2f\x38\x09\x0e\xae\xdf\xf2\x0d\x03\x76\x77\xa9\x2d\xf7\x82\xf8\x30\x96\x78\x5a\xc0\xf6\x40\x13\xd2\x5c\xf4\x26\x5a\xb9\x65\xdd\xf2\xb5\xcb\xaa\x09\xd6\x39\x1c\x03\xc5\xb3\x47\x7a\x96\xb6\x7d\xf0\x22\x55\xb4\xc3\x07\x86\x8f\x44\x70\xd3\xc9\x46\x62\xec\x47\x63\xbe\x03\xb7\xbe\xf4\x7f\xe1\x96\x30\xff\x1c\xa3\x79\x0f\x00\x43\x66\xed\xac\xaa\x00\x00\x00")
+
+func migrations_gateway25_pending_transaction_versionSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway25_pending_transaction_versionSql,
+		"migrations_gateway/25_pending_transaction_version.sql",
+	)
+}
+
+func migrations_gateway25_pending_transaction_versionSql() (*asset, error) {
+	bytes, err := migrations_gateway25_pending_transaction_versionSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/25_pending_transaction_version.sql", size: 170, mode: os.FileMode(420), modTime: time.Unix(1792075703, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_compliance01_initSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x94\x4d\x6f\xda\x30\x18\xc7\xef\xf9\x14\xcf\x31\x68\x70\x60\x1a\x68\x12\xe2\x10\x88\xd9\xa2\x85\xc0\x32\xfb\xc0\xc9\xf6\x88\x33\xac\x11\x07\x39\x4e\xa1\xfd\xf4\x55\x68\x9b\x37\xde\x8a\xda\x5b\xe4\xfc\xff\xf6\xef\xf9\xc9\x72\xaf\x07\x5f\x12\xf9\x4f\x73\x23\x80\xec\xac\x69\x88\x1c\x8c\x00\x3b\x13\x1f\x01\x73\x72\xb3\x49\xb5\x7c\x12\x11\xd6\x5c\x65\x7c\x6d\x64\xaa\x18\xd8\x16\x00\x93\x11\x03\xa9\x8c\xdd\xef\x77\x20\x58\x60\x08\x88\xef\x83\x43\xf0\x82\x7a\xc1\x34\x44\x73\x14\xe0\x6e\x91\x33\x55\x93\x16\x9d\xf5\x86\x6b\x7b\xf8\xad\x2a\x1d\x53\x89\x48\x52\x06\x0f\x5c\x9f\xff\x5d\xdf\xe4\x10\x69\x06\x46\x1c\x4c\x33\xc2\x4b\x56\xca\x0d\x83\x88\x1b\x61\x64\x22\x9a\xa1\x88\x1b\x7e\xa6\xbc\x0c\xbd\xb9\x13\xae\xe0\x17\x5a\x81\x5d\x4c\xd6\xb1\x3a\x80\x82\x1f\x5e\x80\xc6\x9e\x52\xa9\x3b\x01\x17\xcd\x1c\xe2\x63\x98\xfe\x74\xc2\x3f\x08\x8f\x73\x13\x7f\x1f\x59\x6d\x5f\xdb\x6d\xba\x17\xd1\xcc\xbb\xd3\x91\xe2\x89\xa8\xa6\xff\x3a\x18\xb4\xc6\x8f\xd2\x84\x4b\x75\x2d\xb1\xcb\xff\x6e\xe5\x9a\xfe\x17\x8f\xaf\x86\x07\xc3\x56\x82\xbf\xb0\x5d\x96\x73\x2a\xa1\x58\x25\x81\xf7\x9b\xa0\xa3\x99\x12\xc3\x7e\xfb\x3a\x49\xd4\x31\xec\x3a\xd4\xc7\x84\x92\x4c\xe8\x3b\x95\xc6\x92\xde\xb2\x1a\x4b\x7a\x5b\x6c\x2c\xe9\x6d\xb7\x79\x26\xf4\xf1\x72\x5f\xde\xe7\x13\xf4\x37\x50\x68\x79\xa6\xdd\x62\xec\x56\x3c\xef\xb6\x5e\x7f\x05\xdc\x74\xaf\x2c\x37\x5c\x2c\xaf\xbf\x02\xa3\x66\xa6\xbc\xf9\x67\xd7\x49\x26\x34\x1b\x59\xcf\x03\x00\xb0\xd9\x8a\xda\x6d\x04\x00\x00")
 
 func migrations_compliance01_initSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -104,7 +612,87 @@ func migrations_compliance01_initSql() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "migrations_compliance/01_init.sql", size: 1133, mode: os.FileMode(420), modTime: time.Unix(1472146842, 0)}
+	info := bindataFileInfo{name: "migrations_compliance/01_init.sql", size: 1133, mode: os.FileMode(436), modTime: time.Unix(1479378373, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_compliance02_kyc_customersSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x91\xcd\x6e\xc2\x30\x10\x84\xef\x7e\x8a\x3d\x26\x6a\x91\x4a\x25\xaa\x4a\x88\x83\x49\xdc\x36\x22\x18\xea\xda\x07\x4e\xd8\xc2\xa6\xf8\x80\x83\x9c\x4d\x7f\xde\xbe\x4a\x84\x20\xd0\xaa\xb7\xd5\xea\xdb\x99\xd5\xcc\x60\x00\x37\x7b\xff\x1e\x0d\x3a\x50\x07\x92\x09\x46\x25\x03\x49\xa7\x25\x03\x3d\x5b\x65\x59\x53\x63\xb5\x77\x51\x43\x42\x00\xb4\xb7\x1a\x7c\xc0\x64\x38\x4c\x81\x2f\x24\x70\x55\x96\x40\x95\x5c\xac\x0b\x9e\x09\x36\x67\x5c\xde\xb6\x9c\xb1\x36\xba\xba\xd6\xf0\x61\xe2\x66\x67\x62\x72\x3f\x1a\x9d\x2f\x3a\x24\x56\x0d\xba\x6b\xa0\x95\xcb\xd9\x13\x55\x65\x8f\xf4\x61\x5b\x69\x40\xf7\x85\x97\x0a\x35\x1a\x6c\x7a\x1e\xc3\x87\x2b\x8b\x7a\x67\xa2\x5b\x1f\xcf\x7d\xf8\xee\x3e\x3f\x33\x27\xa7\xbb\x8e\xde\x44\x67\xd0\xd9\xb5\x41\x0d\xd6\xa0\x43\xbf\x77\x97\x7a\xcd\xc1\xfe\x4f\x2c\x45\x31\xa7\x62\x05\x33\xb6\x82\xa4\x0d\x2b\x6d\x95\x15\x2f\x5e\x15\xeb\x96\xe7\x60\x92\xd3\xf8\x8b\x39\x26\x93\x1c\x87\x94\xa4\xc0\xf8\x73\xc1\xd9\xa4\x08\xa1\xca\xa7\xa7\xbf\xb3\x17\x2a\xde\x98\x9c\x34\xb8\x7d\x1c\x13\xd2\xef\x32\xaf\x3e\x03\xc9\xc5\x62\xf9\x57\x97\x63\xf2\x33\x00\x09\xd4\xda\xbc\xf7\x01\x00\x00")
+
+func migrations_compliance02_kyc_customersSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_compliance02_kyc_customersSql,
+		"migrations_compliance/02_kyc_customers.sql",
+	)
+}
+
+func migrations_compliance02_kyc_customersSql() (*asset, error) {
+	bytes, err := migrations_compliance02_kyc_customersSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_compliance/02_kyc_customers.sql", size: 503, mode: os.FileMode(420), modTime: time.Unix(1792057895, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_compliance03_compliance_transactionsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\xd2\x3d\x6f\x83\x30\x10\x06\xe0\x9d\x5f\x71\x23\x51\x93\xa1\x55\x13\x45\x8a\x32\x10\x70\x5b\x54\xe2\xa4\xd4\x0c\x99\xc0\xc5\x4e\x6a\xa9\xd8\xc8\x1c\xfd\xf8\xf7\x15\xa8\x2a\xe4\x43\x64\x85\xe7\x5e\xcb\x7e\x6f\x32\x81\x9b\x42\x1d\x2c\x47\x09\x49\xe9\xf8\x31\xf1\x18\x01\xe6\xad\x22\x02\x99\x6f\x8a\xf2\x43\x71\x9d\x4b\x66\xb9\xae\x78\x8e\xca\xe8\x0c\x5c\x07\x20\x53\x22\x83\x37\x75\x50\x1a\x81\x6e\x18\xd0\x24\x8a\xc0\x4b\xd8\x26\x0d\xa9\x1f\x93\x35\xa1\x6c\xdc\x30\xa1\xac\xfc\x1b\xfb\xe4\x36\x7f\xe7\xd6\x9d\x8f\xfe\x27\x5a\x82\x5d\x76\xda\xa4\xb6\x68\x76\x7f\xa2\x0a\x59\x98\x2e\xe3\xec\x77\x25\xb5\x90\xb6\x03\x77\xd3\xe9\x89\xb0\xa6\x46\x39\x04\x72\x53\x6b\x94\xb6\xe4\x16\x7f\x52\x61\x0a\xae\xf4\x10\xaf\x90\x63\x5d\x75\xe2\x76\x76\x02\xf0\x3b\xbd\x6a\x94\xde\x9b\xeb\x2a\xb7\x92\xa3\x14\x29\xc7\x0c\x04\x47\x89\xaa\x90\xc7\xa2\x2e\xc5\xb0\xd8\xc6\xe1\xda\x8b\x77\xf0\x4c\x76\xe0\x36\xe5\x8d\x9a\xf3\x13\x1a\xbe\x24\xa4\xfd\x78\x56\x83\xdb\xeb\x6e\x7c\xd6\x52\x3b\xde\xce\x5d\x7c\x36\xf7\xe2\x6b\x8e\x8f\xae\xd2\x8b\xe8\xdd\xcf\x3d\x22\xce\x08\x08\x7d\x0c\x29\x59\x86\x5a\x9b\x60\x05\x01\x79\xf0\x92\x88\x81\xff\xe4\xc5\xaf\x84\x2d\x6b\xdc\xcf\x17\x8e\xd3\x5f\xe3\xc0\x7c\x69\x27\x88\x37\xdb\xe1\x35\x5e\x38\xbf\x03\x00\x1a\x48\xe7\xe7\xfc\x02\x00\x00")
+
+func migrations_compliance03_compliance_transactionsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_compliance03_compliance_transactionsSql,
+		"migrations_compliance/03_compliance_transactions.sql",
+	)
+}
+
+func migrations_compliance03_compliance_transactionsSql() (*asset, error) {
+	bytes, err := migrations_compliance03_compliance_transactionsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_compliance/03_compliance_transactions.sql", size: 764, mode: os.FileMode(420), modTime: time.Unix(1792069662, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_compliance04_sep12_customersSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\xd2\xcf\x6f\x82\x30\x14\x07\xf0\x3b\x7f\xc5\xbb\x09\x99\x1e\x70\xd3\x98\x18\x0f\x08\xdd\x46\x86\xe8\xb0\x1c\x3c\xd1\x0e\xaa\x6b\x62\x81\xc0\xeb\x7e\xfc\xf7\x0b\xc6\x01\xce\x6d\xc7\x92\x0f\x5f\xca\xf7\xbd\xd1\x08\x6e\x94\x3c\x54\x1c\x05\xc4\xa5\xe1\x46\xc4\xa1\x04\xa8\xb3\x0c\x08\xb0\xad\x28\xed\xb1\xab\x6b\x2c\x94\xa8\x18\x98\x06\x00\x93\x19\x03\x99\xa3\x69\xdb\x16\x84\x6b\x0a\x61\x1c\x04\xe0\xc4\x74\x9d\xf8\xa1\x1b\x91\x15\x09\xe9\xb0\x71\xa5\x7e\x39\xca\x34\x69\xf8\x1b\xaf\xd2\x57\x5e\x99\xb7\xd3\xee\x95\x93\xe1\x69\x5a\xe8\x1c\x3b\x31\xf9\x29\x94\x50\x45\x82\x9f\xa5\xe8\xcc\xac\x23\xe0\x91\x7b\x27\x0e\x28\x0c\x06\xad\xee\xe0\xf4\xee\x6f\xb9\x97\xe2\x98\xd5\x0c\x94\xc8\xa4\x56\x28\x3e\xb0\xa5\xa7\xa4\x1a\x39\xea\xba\xcb\xb2\xaf\x2f\x56\xd7\xfc\xd0\xbb\xd6\x78\x32\xb1\x2e\x3f\xd5\xda\xb4\x12\x1c\x45\x96\x70\x64\x90\x71\x14\x28\x95\xb8\x4c\xd3\x65\xf6\xbf\xd8\x44\xfe\xca\x89\x76\xf0\x44\x76\x60\x36\x33\xb0\x9a\xe4\x38\xf4\x9f\x63\x72\x7a\xd8\xef\xdb\xec\x1d\xae\xdc\xb9\xf3\xa4\x69\xb6\xa1\xdf\x33\x18\xf6\xcb\x3e\x1f\x98\x65\x58\x40\xc2\x07\x3f\x24\x0b\x3f\xcf\x0b\x6f\xd9\xfe\x9c\xfb\xe8\x44\x5b\x42\x17\x1a\xf7\xb3\xb9\x61\xf4\xd7\xc8\x2b\xde\x73\xc3\x8b\xd6\x9b\xdf\xd7\x68\x6e\x7c\x0d\x00\xba\xe1\x60\x91\x74\x02\x00\x00")
+
+func migrations_compliance04_sep12_customersSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_compliance04_sep12_customersSql,
+		"migrations_compliance/04_sep12_customers.sql",
+	)
+}
+
+func migrations_compliance04_sep12_customersSql() (*asset, error) {
+	bytes, err := migrations_compliance04_sep12_customersSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_compliance/04_sep12_customers.sql", size: 628, mode: os.FileMode(420), modTime: time.Unix(1792072929, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_compliance05_auth_noncesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x64\x90\x41\x4b\xc3\x40\x10\x85\xef\xfb\x2b\xde\x31\xc1\xf6\xa6\x45\x28\x3d\x6c\x9b\x51\x83\x71\x5b\xd6\xcd\xa1\xa7\xec\x92\xae\x49\x90\x6e\x64\x9d\x28\xfe\x7b\x89\x52\x48\xf4\x38\xf3\xbd\xf9\x06\xde\x72\x89\xab\x73\xd7\x44\xc7\x1e\xe5\x9b\xd8\x69\x92\x86\x60\xe4\xb6\x20\x58\x39\x70\xab\xfa\x50\x7b\x8b\x44\x00\xf6\xbd\x6b\x42\x17\x9a\xea\xd5\x7f\x59\x7c\xb8\x58\xb7\x2e\x26\x37\xab\x14\x6a\x6f\xa0\xca\xa2\x58\x8c\xa9\xf0\x7b\x71\xe1\xab\xeb\x3f\xbc\x8e\xde\xb1\x3f\x55\x8e\x2d\x4e\x8e\x3d\x77\x67\x3f\x4b\x1c\x74\xfe\x24\xf5\x11\x8f\x74\x44\x32\x7b\xba\xb8\xd8\xd3\xd1\x34\x72\xeb\x06\x6e\xab\x9f\x65\x35\x15\x27\x76\x32\xa5\x22\x05\xa9\xfb\x5c\xd1\x26\x0f\xa1\xcf\xb6\xc8\xe8\x4e\x96\x85\xc1\xee\x41\xea\x67\x32\x9b\x81\x5f\x6e\xd7\x42\x4c\xdb\xc8\xfa\xcf\x20\x32\xbd\x3f\xfc\x6f\x63\x2d\xbe\x07\x00\x91\x80\x05\xb4\x37\x01\x00\x00")
+
+func migrations_compliance05_auth_noncesSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_compliance05_auth_noncesSql,
+		"migrations_compliance/05_auth_nonces.sql",
+	)
+}
+
+func migrations_compliance05_auth_noncesSql() (*asset, error) {
+	bytes, err := migrations_compliance05_auth_noncesSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_compliance/05_auth_nonces.sql", size: 311, mode: os.FileMode(420), modTime: time.Unix(1792073351, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -161,19 +749,49 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
-	"migrations_gateway/01_init.sql": migrations_gateway01_initSql,
-	"migrations_compliance/01_init.sql": migrations_compliance01_initSql,
+	"migrations_gateway/01_init.sql":                         migrations_gateway01_initSql,
+	"migrations_gateway/02_exchange_rates.sql":               migrations_gateway02_exchange_ratesSql,
+	"migrations_gateway/03_customers.sql":                    migrations_gateway03_customersSql,
+	"migrations_gateway/04_tenants.sql":                      migrations_gateway04_tenantsSql,
+	"migrations_gateway/05_from_address.sql":                 migrations_gateway05_from_addressSql,
+	"migrations_gateway/06_payment_details.sql":              migrations_gateway06_payment_detailsSql,
+	"migrations_gateway/07_payment_links.sql":                migrations_gateway07_payment_linksSql,
+	"migrations_gateway/08_payment_resolution.sql":           migrations_gateway08_payment_resolutionSql,
+	"migrations_gateway/09_sent_transaction_metadata.sql":    migrations_gateway09_sent_transaction_metadataSql,
+	"migrations_gateway/10_callback_retries.sql":             migrations_gateway10_callback_retriesSql,
+	"migrations_gateway/11_idempotency_keys.sql":             migrations_gateway11_idempotency_keysSql,
+	"migrations_gateway/12_limit_counters.sql":               migrations_gateway12_limit_countersSql,
+	"migrations_gateway/13_sep31_transactions.sql":           migrations_gateway13_sep31_transactionsSql,
+	"migrations_gateway/14_receiving_accounts.sql":           migrations_gateway14_receiving_accountsSql,
+	"migrations_gateway/15_callback_attempts.sql":            migrations_gateway15_callback_attemptsSql,
+	"migrations_gateway/16_api_keys.sql":                     migrations_gateway16_api_keysSql,
+	"migrations_gateway/17_pending_transactions.sql":         migrations_gateway17_pending_transactionsSql,
+	"migrations_gateway/18_listener_leases.sql":              migrations_gateway18_listener_leasesSql,
+	"migrations_gateway/19_callback_deliveries.sql":          migrations_gateway19_callback_deliveriesSql,
+	"migrations_gateway/20_return_memos.sql":                 migrations_gateway20_return_memosSql,
+	"migrations_gateway/21_pending_compliance.sql":           migrations_gateway21_pending_complianceSql,
+	"migrations_gateway/22_sent_transaction_payment.sql":     migrations_gateway22_sent_transaction_paymentSql,
+	"migrations_gateway/23_transfer_transactions.sql":        migrations_gateway23_transfer_transactionsSql,
+	"migrations_gateway/24_received_payment_transaction.sql": migrations_gateway24_received_payment_transactionSql,
+	"migrations_gateway/25_pending_transaction_version.sql":  migrations_gateway25_pending_transaction_versionSql,
+	"migrations_compliance/01_init.sql":                      migrations_compliance01_initSql,
+	"migrations_compliance/02_kyc_customers.sql":             migrations_compliance02_kyc_customersSql,
+	"migrations_compliance/03_compliance_transactions.sql":   migrations_compliance03_compliance_transactionsSql,
+	"migrations_compliance/04_sep12_customers.sql":           migrations_compliance04_sep12_customersSql,
+	"migrations_compliance/05_auth_nonces.sql":               migrations_compliance05_auth_noncesSql,
 }
 
 // AssetDir returns the file names below a certain
 // directory embedded in the file by go-bindata.
 // For example if you run go-bindata on data/... and data contains the
 // following hierarchy:
-//     data/
-//       foo.txt
-//       img/
-//         a.png
-//         b.png
+//
+//	data/
+//	  foo.txt
+//	  img/
+//	    a.png
+//	    b.png
+//
 // then AssetDir("data") would return []string{"foo.txt", "img"}
 // AssetDir("data/img") would return []string{"a.png", "b.png"}
 // AssetDir("foo.txt") and AssetDir("notexist") would return an error
@@ -204,12 +822,41 @@ type bintree struct {
 	Func     func() (*asset, error)
 	Children map[string]*bintree
 }
+
 var _bintree = &bintree{nil, map[string]*bintree{
 	"migrations_compliance": &bintree{nil, map[string]*bintree{
-		"01_init.sql": &bintree{migrations_compliance01_initSql, map[string]*bintree{}},
+		"01_init.sql":                    &bintree{migrations_compliance01_initSql, map[string]*bintree{}},
+		"02_kyc_customers.sql":           &bintree{migrations_compliance02_kyc_customersSql, map[string]*bintree{}},
+		"03_compliance_transactions.sql": &bintree{migrations_compliance03_compliance_transactionsSql, map[string]*bintree{}},
+		"04_sep12_customers.sql":         &bintree{migrations_compliance04_sep12_customersSql, map[string]*bintree{}},
+		"05_auth_nonces.sql":             &bintree{migrations_compliance05_auth_noncesSql, map[string]*bintree{}},
 	}},
 	"migrations_gateway": &bintree{nil, map[string]*bintree{
-		"01_init.sql": &bintree{migrations_gateway01_initSql, map[string]*bintree{}},
+		"01_init.sql":                         &bintree{migrations_gateway01_initSql, map[string]*bintree{}},
+		"02_exchange_rates.sql":               &bintree{migrations_gateway02_exchange_ratesSql, map[string]*bintree{}},
+		"03_customers.sql":                    &bintree{migrations_gateway03_customersSql, map[string]*bintree{}},
+		"04_tenants.sql":                      &bintree{migrations_gateway04_tenantsSql, map[string]*bintree{}},
+		"05_from_address.sql":                 &bintree{migrations_gateway05_from_addressSql, map[string]*bintree{}},
+		"06_payment_details.sql":              &bintree{migrations_gateway06_payment_detailsSql, map[string]*bintree{}},
+		"07_payment_links.sql":                &bintree{migrations_gateway07_payment_linksSql, map[string]*bintree{}},
+		"08_payment_resolution.sql":           &bintree{migrations_gateway08_payment_resolutionSql, map[string]*bintree{}},
+		"09_sent_transaction_metadata.sql":    &bintree{migrations_gateway09_sent_transaction_metadataSql, map[string]*bintree{}},
+		"10_callback_retries.sql":             &bintree{migrations_gateway10_callback_retriesSql, map[string]*bintree{}},
+		"11_idempotency_keys.sql":             &bintree{migrations_gateway11_idempotency_keysSql, map[string]*bintree{}},
+		"12_limit_counters.sql":               &bintree{migrations_gateway12_limit_countersSql, map[string]*bintree{}},
+		"13_sep31_transactions.sql":           &bintree{migrations_gateway13_sep31_transactionsSql, map[string]*bintree{}},
+		"14_receiving_accounts.sql":           &bintree{migrations_gateway14_receiving_accountsSql, map[string]*bintree{}},
+		"15_callback_attempts.sql":            &bintree{migrations_gateway15_callback_attemptsSql, map[string]*bintree{}},
+		"16_api_keys.sql":                     &bintree{migrations_gateway16_api_keysSql, map[string]*bintree{}},
+		"17_pending_transactions.sql":         &bintree{migrations_gateway17_pending_transactionsSql, map[string]*bintree{}},
+		"18_listener_leases.sql":              &bintree{migrations_gateway18_listener_leasesSql, map[string]*bintree{}},
+		"19_callback_deliveries.sql":          &bintree{migrations_gateway19_callback_deliveriesSql, map[string]*bintree{}},
+		"20_return_memos.sql":                 &bintree{migrations_gateway20_return_memosSql, map[string]*bintree{}},
+		"21_pending_compliance.sql":           &bintree{migrations_gateway21_pending_complianceSql, map[string]*bintree{}},
+		"22_sent_transaction_payment.sql":     &bintree{migrations_gateway22_sent_transaction_paymentSql, map[string]*bintree{}},
+		"23_transfer_transactions.sql":        &bintree{migrations_gateway23_transfer_transactionsSql, map[string]*bintree{}},
+		"24_received_payment_transaction.sql": &bintree{migrations_gateway24_received_payment_transactionSql, map[string]*bintree{}},
+		"25_pending_transaction_version.sql":  &bintree{migrations_gateway25_pending_transaction_versionSql, map[string]*bintree{}},
 	}},
 }}
 
@@ -259,4 +906,3 @@ func _filePath(dir, name string) string {
 	cannonicalName := strings.Replace(name, "\\", "/", -1)
 	return filepath.Join(append([]string{dir}, strings.Split(cannonicalName, "/")...)...)
 }
-
@@ -0,0 +1,17 @@
+-- +migrate Up
+CREATE TABLE `KYCCustomer` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `address` varchar(255) NOT NULL,
+  `route` varchar(255) NULL DEFAULT NULL,
+  `info` text NOT NULL,
+  `status` varchar(16) NOT NULL,
+  `share_info` tinyint(1) NOT NULL DEFAULT 0,
+  `created_at` datetime NOT NULL,
+  `updated_at` datetime NOT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `address` (`address`),
+  UNIQUE KEY `route` (`route`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+-- +migrate Down
+DROP TABLE `KYCCustomer`;
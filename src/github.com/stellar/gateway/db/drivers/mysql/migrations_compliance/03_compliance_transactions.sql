@@ -0,0 +1,22 @@
+-- +migrate Up
+CREATE TABLE `ComplianceTransaction` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `direction` varchar(8) NOT NULL,
+  `transaction_id` char(64) NOT NULL,
+  `memo` varchar(64) NOT NULL,
+  `sender` varchar(255) NOT NULL,
+  `route` varchar(255) NOT NULL,
+  `counterparty_domain` varchar(255) NOT NULL,
+  `status` varchar(16) NOT NULL,
+  `tx_status` varchar(16) NOT NULL,
+  `info_status` varchar(16) NOT NULL,
+  `created_at` datetime NOT NULL,
+  `updated_at` datetime NOT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `transaction_id` (`direction`, `transaction_id`),
+  KEY `counterparty_domain` (`counterparty_domain`, `created_at`),
+  KEY `created_at` (`created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+-- +migrate Down
+DROP TABLE `ComplianceTransaction`;
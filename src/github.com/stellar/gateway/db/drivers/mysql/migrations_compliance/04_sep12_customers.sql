@@ -0,0 +1,19 @@
+-- +migrate Up
+CREATE TABLE `Sep12Customer` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `public_id` varchar(36) NOT NULL,
+  `account` varchar(56) NOT NULL,
+  `memo_type` varchar(8) NOT NULL DEFAULT '',
+  `memo` varchar(64) NOT NULL DEFAULT '',
+  `fields` mediumtext NOT NULL,
+  `status` varchar(16) NOT NULL,
+  `message` varchar(255) NULL DEFAULT NULL,
+  `created_at` datetime NOT NULL,
+  `updated_at` datetime NOT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `public_id` (`public_id`),
+  UNIQUE KEY `account_memo` (`account`, `memo_type`, `memo`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+-- +migrate Down
+DROP TABLE `Sep12Customer`;
@@ -0,0 +1,11 @@
+-- +migrate Up
+CREATE TABLE `AuthNonce` (
+  `signing_key` varchar(56) NOT NULL,
+  `nonce` varchar(64) NOT NULL,
+  `created_at` datetime NOT NULL,
+  PRIMARY KEY (`signing_key`, `nonce`),
+  KEY `auth_nonce_created_at` (`created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+-- +migrate Down
+DROP TABLE `AuthNonce`;
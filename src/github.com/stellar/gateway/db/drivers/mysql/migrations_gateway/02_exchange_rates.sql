@@ -0,0 +1,11 @@
+-- +migrate Up
+ALTER TABLE `ReceivedPayment`
+  ADD COLUMN `exchange_rate` varchar(64) DEFAULT NULL,
+  ADD COLUMN `converted_amount` varchar(64) DEFAULT NULL,
+  ADD COLUMN `converted_currency` varchar(12) DEFAULT NULL;
+
+-- +migrate Down
+ALTER TABLE `ReceivedPayment`
+  DROP COLUMN `exchange_rate`,
+  DROP COLUMN `converted_amount`,
+  DROP COLUMN `converted_currency`;
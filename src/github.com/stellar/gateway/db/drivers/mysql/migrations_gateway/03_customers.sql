@@ -0,0 +1,14 @@
+-- +migrate Up
+CREATE TABLE `Customer` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `memo_type` varchar(4) NOT NULL,
+  `memo` varchar(64) NOT NULL,
+  `customer_id` varchar(255) NOT NULL,
+  `callback_url` varchar(255) NOT NULL DEFAULT '',
+  `created_at` datetime NOT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `memo` (`memo_type`, `memo`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+-- +migrate Down
+DROP TABLE `Customer`;
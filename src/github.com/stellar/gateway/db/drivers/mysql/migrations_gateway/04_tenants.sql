@@ -0,0 +1,25 @@
+-- +migrate Up
+ALTER TABLE `ReceivedPayment`
+  ADD COLUMN `tenant` varchar(64) NOT NULL DEFAULT '',
+  DROP INDEX `operation_id`,
+  ADD UNIQUE KEY `operation_id` (`tenant`, `operation_id`);
+
+ALTER TABLE `SentTransaction` ADD COLUMN `tenant` varchar(64) NOT NULL DEFAULT '';
+
+ALTER TABLE `Customer`
+  ADD COLUMN `tenant` varchar(64) NOT NULL DEFAULT '',
+  DROP INDEX `memo`,
+  ADD UNIQUE KEY `memo` (`tenant`, `memo_type`, `memo`);
+
+-- +migrate Down
+ALTER TABLE `Customer`
+  DROP INDEX `memo`,
+  ADD UNIQUE KEY `memo` (`memo_type`, `memo`),
+  DROP COLUMN `tenant`;
+
+ALTER TABLE `SentTransaction` DROP COLUMN `tenant`;
+
+ALTER TABLE `ReceivedPayment`
+  DROP INDEX `operation_id`,
+  ADD UNIQUE KEY `operation_id` (`operation_id`),
+  DROP COLUMN `tenant`;
@@ -0,0 +1,19 @@
+-- +migrate Up
+ALTER TABLE `ReceivedPayment`
+  ADD COLUMN `from_account` varchar(56) NOT NULL DEFAULT '',
+  ADD COLUMN `amount` varchar(64) NOT NULL DEFAULT '',
+  ADD COLUMN `asset_code` varchar(12) NOT NULL DEFAULT '',
+  ADD COLUMN `asset_issuer` varchar(56) NOT NULL DEFAULT '',
+  ADD COLUMN `memo_type` varchar(4) NOT NULL DEFAULT '',
+  ADD COLUMN `memo` varchar(255) NOT NULL DEFAULT '',
+  ADD COLUMN `refund_transaction_id` varchar(64) DEFAULT NULL;
+
+-- +migrate Down
+ALTER TABLE `ReceivedPayment`
+  DROP COLUMN `from_account`,
+  DROP COLUMN `amount`,
+  DROP COLUMN `asset_code`,
+  DROP COLUMN `asset_issuer`,
+  DROP COLUMN `memo_type`,
+  DROP COLUMN `memo`,
+  DROP COLUMN `refund_transaction_id`;
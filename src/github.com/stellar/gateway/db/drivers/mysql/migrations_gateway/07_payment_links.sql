@@ -0,0 +1,14 @@
+-- +migrate Up
+CREATE TABLE `PaymentLink` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `received_payment_id` int(11) NOT NULL,
+  `transaction_id` varchar(64) NOT NULL,
+  `created_at` datetime NOT NULL,
+  `tenant` varchar(64) NOT NULL DEFAULT '',
+  PRIMARY KEY (`id`),
+  KEY `received_payment_id` (`received_payment_id`),
+  KEY `transaction_id` (`tenant`, `transaction_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+-- +migrate Down
+DROP TABLE `PaymentLink`;
@@ -0,0 +1,11 @@
+-- +migrate Up
+ALTER TABLE `ReceivedPayment`
+  ADD COLUMN `resolution_reason` text DEFAULT NULL,
+  ADD COLUMN `resolved_by` varchar(255) DEFAULT NULL,
+  ADD COLUMN `resolved_at` datetime DEFAULT NULL;
+
+-- +migrate Down
+ALTER TABLE `ReceivedPayment`
+  DROP COLUMN `resolution_reason`,
+  DROP COLUMN `resolved_by`,
+  DROP COLUMN `resolved_at`;
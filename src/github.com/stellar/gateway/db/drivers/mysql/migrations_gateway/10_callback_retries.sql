@@ -0,0 +1,16 @@
+-- +migrate Up
+CREATE TABLE `CallbackRetry` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `received_payment_id` int(11) NOT NULL,
+  `attempts` int(11) NOT NULL,
+  `next_attempt_at` datetime NOT NULL,
+  `last_error` text NOT NULL,
+  `created_at` datetime NOT NULL,
+  `tenant` varchar(64) NOT NULL DEFAULT '',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `received_payment_id` (`received_payment_id`),
+  KEY `next_attempt_at` (`tenant`, `next_attempt_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+-- +migrate Down
+DROP TABLE `CallbackRetry`;
@@ -0,0 +1,9 @@
+-- +migrate Up
+ALTER TABLE `SentTransaction`
+  ADD COLUMN `idempotency_key` varchar(128) DEFAULT NULL,
+  ADD UNIQUE KEY `idempotency_key` (`tenant`, `idempotency_key`);
+
+-- +migrate Down
+ALTER TABLE `SentTransaction`
+  DROP INDEX `idempotency_key`,
+  DROP COLUMN `idempotency_key`;
@@ -0,0 +1,13 @@
+-- +migrate Up
+CREATE TABLE `LimitCounter` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `counter_key` varchar(255) NOT NULL,
+  `window_start` datetime NOT NULL,
+  `amount` bigint(20) NOT NULL,
+  `tenant` varchar(64) NOT NULL DEFAULT '',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `counter_key` (`tenant`, `counter_key`, `window_start`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+-- +migrate Down
+DROP TABLE `LimitCounter`;
@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE `ReceivedPayment` ADD COLUMN `receiving_account` varchar(56) NOT NULL DEFAULT '';
+
+-- +migrate Down
+ALTER TABLE `ReceivedPayment` DROP COLUMN `receiving_account`;
@@ -0,0 +1,20 @@
+-- +migrate Up
+CREATE TABLE `CallbackAttempt` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `operation_id` varchar(255) NOT NULL,
+  `attempt` int(11) NOT NULL,
+  `url` varchar(1024) NOT NULL,
+  `success` tinyint(1) NOT NULL,
+  `status_code` int(11) DEFAULT NULL,
+  `latency_ms` bigint NOT NULL,
+  `response` text DEFAULT NULL,
+  `error` text DEFAULT NULL,
+  `created_at` datetime NOT NULL,
+  `tenant` varchar(64) NOT NULL DEFAULT '',
+  PRIMARY KEY (`id`),
+  KEY `operation_id` (`tenant`, `operation_id`),
+  KEY `created_at` (`tenant`, `created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+-- +migrate Down
+DROP TABLE `CallbackAttempt`;
@@ -0,0 +1,14 @@
+-- +migrate Up
+CREATE TABLE `APIKey` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `name` varchar(255) NOT NULL,
+  `key_hash` char(64) NOT NULL,
+  `tenant` varchar(64) NOT NULL DEFAULT '',
+  `permissions` varchar(255) NOT NULL,
+  `created_at` datetime NOT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `key_hash` (`key_hash`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+-- +migrate Down
+DROP TABLE `APIKey`;
@@ -0,0 +1,22 @@
+-- +migrate Up
+CREATE TABLE `PendingTransaction` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `transaction_id` char(64) NOT NULL,
+  `status` varchar(16) NOT NULL,
+  `source` char(56) NOT NULL,
+  `envelope_xdr` text NOT NULL,
+  `required_weight` int NOT NULL,
+  `signed_weight` int NOT NULL,
+  `destination` varchar(255) NOT NULL,
+  `amount` varchar(32) NOT NULL,
+  `asset` varchar(70) NOT NULL,
+  `metadata` text DEFAULT NULL,
+  `created_at` datetime NOT NULL,
+  `updated_at` datetime NOT NULL,
+  `tenant` varchar(64) NOT NULL DEFAULT '',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `tenant_transaction_id` (`tenant`, `transaction_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+-- +migrate Down
+DROP TABLE `PendingTransaction`;
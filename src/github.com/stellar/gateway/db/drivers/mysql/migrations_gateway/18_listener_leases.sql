@@ -0,0 +1,11 @@
+-- +migrate Up
+CREATE TABLE `ListenerLease` (
+  `name` varchar(64) NOT NULL,
+  `tenant` varchar(64) NOT NULL DEFAULT '',
+  `holder` varchar(255) NOT NULL,
+  `expires_at` datetime NOT NULL,
+  PRIMARY KEY (`tenant`, `name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+-- +migrate Down
+DROP TABLE `ListenerLease`;
@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE `ReceivedPayment` MODIFY `memo_type` varchar(10) NOT NULL DEFAULT '';
+
+-- +migrate Down
+-- Return memos are hashes, varchar(4) cannot store `return` type
+UPDATE `ReceivedPayment` SET `memo_type` = 'hash' WHERE `memo_type` = 'return';
+ALTER TABLE `ReceivedPayment` MODIFY `memo_type` varchar(4) NOT NULL DEFAULT '';
@@ -0,0 +1,20 @@
+-- +migrate Up
+CREATE TABLE `PendingCompliance` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `tenant` varchar(64) NOT NULL DEFAULT '',
+  `send_request` text NOT NULL,
+  `status` varchar(10) NOT NULL,
+  `tx_status` varchar(10) NOT NULL DEFAULT '',
+  `info_status` varchar(10) NOT NULL DEFAULT '',
+  `attempts` int(11) NOT NULL,
+  `next_attempt_at` datetime NOT NULL,
+  `expires_at` datetime NOT NULL,
+  `last_error` text NOT NULL,
+  `created_at` datetime NOT NULL,
+  `updated_at` datetime NOT NULL,
+  PRIMARY KEY (`id`),
+  KEY `next_attempt_at` (`tenant`, `status`, `next_attempt_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+-- +migrate Down
+DROP TABLE `PendingCompliance`;
@@ -0,0 +1,15 @@
+-- +migrate Up
+ALTER TABLE `ReceivedPayment`
+  ADD COLUMN `transaction_hash` varchar(64) DEFAULT NULL,
+  ADD COLUMN `ledger` bigint DEFAULT NULL,
+  ADD COLUMN `ledger_close_time` datetime NULL DEFAULT NULL,
+  ADD COLUMN `fee_charged` bigint DEFAULT NULL,
+  ADD COLUMN `transaction_source_account` varchar(69) DEFAULT NULL;
+
+-- +migrate Down
+ALTER TABLE `ReceivedPayment`
+  DROP COLUMN `transaction_hash`,
+  DROP COLUMN `ledger`,
+  DROP COLUMN `ledger_close_time`,
+  DROP COLUMN `fee_charged`,
+  DROP COLUMN `transaction_source_account`;
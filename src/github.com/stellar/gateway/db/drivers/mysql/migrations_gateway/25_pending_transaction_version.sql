@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE `PendingTransaction` ADD COLUMN `version` bigint NOT NULL DEFAULT 0;
+
+-- +migrate Down
+ALTER TABLE `PendingTransaction` DROP COLUMN `version`;
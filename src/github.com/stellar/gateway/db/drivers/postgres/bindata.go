@@ -1,7 +1,35 @@
 // Code generated by go-bindata.
 // sources:
 // migrations_gateway/01_init.sql
+// migrations_gateway/02_exchange_rates.sql
+// migrations_gateway/03_customers.sql
+// migrations_gateway/04_tenants.sql
+// migrations_gateway/05_from_address.sql
+// migrations_gateway/06_payment_details.sql
+// migrations_gateway/07_payment_links.sql
+// migrations_gateway/08_payment_resolution.sql
+// migrations_gateway/09_sent_transaction_metadata.sql
+// migrations_gateway/10_callback_retries.sql
+// migrations_gateway/11_idempotency_keys.sql
+// migrations_gateway/12_limit_counters.sql
+// migrations_gateway/13_sep31_transactions.sql
+// migrations_gateway/14_receiving_accounts.sql
+// migrations_gateway/15_callback_attempts.sql
+// migrations_gateway/16_api_keys.sql
+// migrations_gateway/17_pending_transactions.sql
+// migrations_gateway/18_listener_leases.sql
+// migrations_gateway/19_callback_deliveries.sql
+// migrations_gateway/20_return_memos.sql
+// migrations_gateway/21_pending_compliance.sql
+// migrations_gateway/22_sent_transaction_payment.sql
+// migrations_gateway/23_transfer_transactions.sql
+// migrations_gateway/24_received_payment_transaction.sql
+// migrations_gateway/25_pending_transaction_version.sql
 // migrations_compliance/01_init.sql
+// migrations_compliance/02_kyc_customers.sql
+// migrations_compliance/03_compliance_transactions.sql
+// migrations_compliance/04_sep12_customers.sql
+// migrations_compliance/05_auth_nonces.sql
 // DO NOT EDIT!
 
 package postgres
@@ -69,7 +97,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _migrations_gateway01_initSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x7c\x92\x41\x4f\xc3\x30\x0c\x85\xef\xf9\x15\x3e\x6e\x82\x49\x80\xd8\x2e\x3b\x15\x16\xa4\x89\xb2\x8d\xd2\x1e\x76\xaa\xb2\xc4\x2a\x11\x6d\x52\x25\xee\x18\xff\x9e\x0c\x09\xd6\x86\xc1\x31\xfa\x5e\x6c\x3f\x3f\x4f\x26\x70\xd1\xe8\xca\x09\x42\x28\x5a\x76\x9f\xf1\x24\xe7\x90\x27\x77\x29\x87\x0c\x25\xea\x3d\xaa\x8d\xf8\x68\xd0\x10\x8c\x18\x80\x56\xb0\xd3\x95\x47\xa7\x45\x7d\x19\xde\xb6\xc5\xf0\x57\x5b\x53\x06\xb2\x17\x4e\xbe\x0a\x37\xba\x99\x4e\xc7\x50\xac\x96\xcf\x05\x87\xd5\x3a\x87\x55\x91\xa6\x47\x71\xeb\xac\x44\xef\x51\x95\x82\x80\x74\x83\x9e\x44\xd3\x0e\x25\xa2\xd2\xa6\x2a\xc9\xbe\xa1\x19\xd6\xeb\xab\xc2\x3f\xea\xfc\xdf\x7c\x93\x2d\x9f\x92\x6c\x0b\x8f\x7c\x0b\x23\xad\xc6\x6c\x3c\x67\x43\x6f\x2f\xc1\x50\xee\x84\xf1\x42\x1e\xa7\xff\xf6\x76\x32\x46\x27\xd8\xb7\x36\xbb\xed\x75\x82\xdf\xa3\x5c\x5f\x45\x93\xda\xce\x49\xfc\xc1\xd3\x59\x84\xbb\x5d\xa3\x89\xfe\xdb\x88\xef\xa4\x44\x54\xb1\x64\xc1\x1f\x92\x22\x3d\xc9\x6a\x54\x15\xba\x63\x38\x3a\x44\x15\x53\x34\x7b\xac\x43\x56\xe5\x41\x39\x20\x3c\xd0\xa0\x85\x43\xdf\xd5\xf4\xc5\x06\x2b\x8d\xab\x9c\x5d\x6b\xff\x82\x16\xf6\xdd\xb0\x45\xb6\xde\x9c\xbf\xa0\x79\x9f\x45\x09\xcc\xd9\x67\x00\x00\x00\xff\xff\x4d\x61\x55\x6b\x8b\x02\x00\x00")
+var _migrations_gateway01_initSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x92\xcf\x4f\xfa\x40\x10\xc5\xef\xfb\x57\xcc\x11\xf2\xfd\x92\xa8\x11\x2e\x9c\xaa\xac\x09\xb1\x02\xd6\xf6\xc0\xa9\x59\x76\x27\x75\x62\xbb\xdb\xec\x4e\x11\xff\x7b\x03\x09\xf6\x07\xe8\xf9\xf3\x32\xf3\xde\xbc\x99\x4c\xe0\x5f\x45\x85\x57\x8c\x90\xd5\xe2\x31\x91\x51\x2a\x21\x8d\x1e\x62\x09\x09\x6a\xa4\x3d\x9a\x8d\xfa\xaa\xd0\x32\x8c\x04\x00\x19\xd8\x51\x11\xd0\x93\x2a\xff\x0b\x00\x57\xa3\x57\x4c\xce\xe6\x64\x60\xaf\xbc\x7e\x57\x7e\x74\x37\x9d\x8e\x21\x5b\x2d\x5f\x33\x09\xab\x75\x0a\xab\x2c\x8e\x8f\xe2\xda\x3b\x8d\x21\xa0\xc9\x15\x03\x53\x85\x81\x55\x55\xf7\x25\xaa\x20\x5b\xe4\xec\x3e\xd0\xf6\xe7\x75\x55\x81\x15\x37\xe1\x77\xbe\x49\x96\x2f\x51\xb2\x85\x67\xb9\x85\x11\x99\xb1\x18\xcf\x45\x3f\xdb\x1b\x5a\x4e\xbd\xb2\x41\xe9\xa3\xfb\x73\xb6\x36\x18\xb7\xb0\x1b\x6d\x76\xdf\xd9\x04\x97\x56\x6e\x6f\xfa\x4e\x82\x6b\xbc\xc6\x1f\x3c\x9d\x0d\x70\xb3\xab\x88\xf9\xaf\x8b\x84\x46\x6b\x44\x33\x94\x2c\xe4\x53\x94\xc5\xad\xac\x44\x53\xa0\x3f\x96\x43\x96\x2f\x28\xda\x3d\x96\xae\xc6\xfc\x60\x3c\x30\x1e\xb8\xb7\xc2\x63\x68\x4a\x3e\xb1\xb3\xd1\x53\x85\xc3\x29\x57\xcf\xda\xfd\xa0\x85\xfb\xb4\x62\x91\xac\x37\xd7\x3f\x68\xde\x65\x83\x06\xe6\xe2\x7b\x00\x4d\x61\x55\x6b\x8b\x02\x00\x00")
 
 func migrations_gateway01_initSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -84,12 +112,492 @@ func migrations_gateway01_initSql() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "migrations_gateway/01_init.sql", size: 651, mode: os.FileMode(420), modTime: time.Unix(1473881382, 0)}
+	info := bindataFileInfo{name: "migrations_gateway/01_init.sql", size: 651, mode: os.FileMode(436), modTime: time.Unix(1479378373, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _migrations_compliance01_initSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x94\x93\xc1\x6e\xf2\x30\x10\x84\xef\x7e\x8a\x3d\x12\xfd\x70\xf9\x55\xb8\x70\x4a\x8b\x2b\x45\xa5\x81\x46\x89\x54\x4e\xd6\x82\x5d\xb0\x1a\xc7\xc8\x38\x05\xfa\xf4\x75\x4a\x21\x71\x21\xa0\x4a\xb9\xc4\x3b\xbb\x3b\xf3\x25\xee\xf5\xe0\x9f\x92\x4b\x83\x56\x40\xb6\x26\x0f\x09\x0d\x53\x0a\x69\x78\x3f\xa6\x10\x96\x76\xa5\x8d\xfc\x14\x3c\x35\x58\x6c\x70\x61\xa5\x2e\xa0\x43\x00\x24\x87\xb9\x5c\x6e\x84\x91\x98\x77\xdd\xbb\xad\xeb\xcc\xd5\x3e\xd0\x2c\x56\x68\x3a\x83\xbb\x00\xe2\x49\x0a\x71\x36\x1e\x57\x32\x25\x94\x6e\x2d\x36\x67\xec\xb8\x01\x2b\x76\xd6\x13\xe0\xc9\x0e\x43\x0b\x56\x2a\xb1\xb1\xa8\xd6\x9e\x86\xa3\xc5\xf3\x4e\xf7\x4c\x93\xe8\x39\x4c\x66\xf0\x44\x67\xd0\x91\x3c\x20\xc1\x90\xfc\x4a\x9b\xe7\x7a\x2b\xf8\x63\x74\x31\x61\x81\x4a\x9c\xac\xff\xef\xf7\x7d\xef\x5c\x2b\x94\x45\x7b\x7d\x5d\xce\x73\xb9\x60\xef\x62\x0f\xdf\x82\xfe\xc0\xaf\xe3\x61\x77\x7b\xae\x33\xfb\x24\x80\x3a\x40\x16\x47\x2f\x19\x85\x28\x1e\xd1\x57\xc0\x37\xc9\xe6\x7b\xf6\x63\x69\x12\x37\x83\x1d\x0e\x5d\xf4\x2b\x8d\x0d\xaf\x7e\x73\x5d\x68\x63\x97\x39\x5e\x17\xe9\xb9\xc9\xd7\x01\x3a\xc1\x2d\x86\x4e\x72\x0b\x63\xe9\x36\x36\xff\xbf\xb3\x19\x7f\xe7\x1c\xb4\x51\x2e\x2b\x56\x9e\x27\x76\x5c\x5f\x63\x3b\x00\xf1\x54\xdd\xa3\xcb\x6a\x32\x69\x5e\xbf\x91\xde\x16\x64\x94\x4c\xa6\xd7\xae\xdf\xd0\x53\x1c\x3f\xce\xa5\xd3\x6a\xf7\x90\x7c\x05\x00\x00\xff\xff\x02\xc5\x23\x8a\xe0\x03\x00\x00")
+var _migrations_gateway02_exchange_ratesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\xd0\xc1\x0a\x82\x40\x10\xc6\xf1\xbb\x4f\x31\xc7\x22\x3c\x14\xd1\xc5\xd3\xd6\xda\x69\x53\x11\xf7\x2c\xcb\x38\xa8\x07\xc7\x18\x56\xcb\xb7\x0f\x3a\x44\x48\x84\x44\x0f\xf0\xff\x0d\xf3\x85\x21\x6c\xba\xb6\x16\xe7\x09\xec\x35\x50\xa6\x88\x73\x28\xd4\xd1\xc4\x90\x13\x52\x3b\x52\x95\xb9\xa9\x23\xf6\xa0\xb4\x86\x53\x6a\xec\x25\x01\xba\x63\xe3\xb8\xa6\xf2\xd9\x8d\x4e\xb0\x71\xb2\x3a\xec\xd7\xa0\xe3\xb3\xb2\xa6\x80\xc4\x1a\x13\x2d\xe5\xb0\xe7\x91\xc4\x53\x55\xba\xae\x1f\xd8\xff\x53\xc4\x41\x84\x18\xa7\x97\xb9\xdd\xcd\xcd\xe0\x7d\x04\xdd\xdf\xf8\xeb\x15\x9d\xa7\xd9\xc7\x1d\xa2\xc5\xd9\xfc\xdf\x5f\x4a\x1c\x44\x88\x71\x8a\x82\xc7\x00\xf3\x24\xab\x88\xc3\x01\x00\x00")
+
+func migrations_gateway02_exchange_ratesSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway02_exchange_ratesSql,
+		"migrations_gateway/02_exchange_rates.sql",
+	)
+}
+
+func migrations_gateway02_exchange_ratesSql() (*asset, error) {
+	bytes, err := migrations_gateway02_exchange_ratesSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/02_exchange_rates.sql", size: 451, mode: os.FileMode(420), modTime: time.Unix(1792052461, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway03_customersSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\x90\x41\x4b\xc3\x40\x14\x84\xef\xef\x57\xcc\xad\x09\xb6\x17\xb1\x5e\x7a\x8a\xcd\x0a\xc5\x98\xd6\x90\x3d\xf4\x14\x5e\xb3\x8f\xba\xb8\x6b\xc2\x66\xab\xf8\xef\xc5\xa0\x41\x83\xe7\xf9\x66\x60\xbe\xd5\x0a\x57\xde\x9e\x03\x47\x81\xee\x69\x5b\xa9\xac\x56\xa8\xb3\xbb\x42\x61\x7b\x19\x62\xe7\x25\x20\x21\xc0\x1a\x9c\xec\x79\x90\x60\xd9\x2d\x09\xf0\xe2\xbb\x26\x7e\xf4\x82\x37\x0e\xed\x33\x87\xe4\x26\x45\xb9\xaf\x51\xea\xa2\xf8\x01\xa6\xec\x76\x16\xb6\xdf\xd3\x8d\x35\x13\x73\xbd\x5e\xcf\x20\x76\xee\xc4\xed\x4b\x73\x09\xee\x7f\x0a\xb9\xba\xcf\x74\x51\x63\xb1\x18\x0b\x41\x38\x8a\x69\x38\x22\x5a\x2f\x43\x64\xdf\xff\x59\x3c\x54\xbb\xc7\xac\x3a\xe2\x41\x1d\x91\x58\x93\x7e\x95\x74\xb9\x7b\xd2\x0a\xc9\x74\x68\x39\x7e\x4b\x29\xdd\x10\xfd\xd6\x93\x77\xef\xaf\x94\x57\xfb\xc3\x4c\xcf\x86\x3e\x07\x00\xcd\x35\x22\x3c\x45\x01\x00\x00")
+
+func migrations_gateway03_customersSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway03_customersSql,
+		"migrations_gateway/03_customers.sql",
+	)
+}
+
+func migrations_gateway03_customersSql() (*asset, error) {
+	bytes, err := migrations_gateway03_customersSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/03_customers.sql", size: 325, mode: os.FileMode(420), modTime: time.Unix(1792052686, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway04_tenantsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x93\xc1\x6b\x83\x30\x14\xc6\xef\xfd\x2b\xde\xad\x95\xad\xb7\xb1\x8b\x27\xa7\x0e\x0a\x59\xec\x6c\x72\x96\x60\x1f\x9b\x8c\x24\x92\x66\x1d\xfe\xf7\xa3\x44\x8a\x35\x35\x62\x6f\x81\xef\xf9\x7b\xdf\xfb\x7c\x6f\xbb\x85\x27\xd9\x7c\x19\x61\x11\x78\xbb\x4a\x08\xcb\x4b\x60\xc9\x1b\xc9\xa1\xc4\x1a\x9b\x33\x1e\xf7\xa2\x93\xa8\x2c\x24\x59\x06\x69\x41\xf8\x07\x05\x8b\x4a\x28\x0b\x67\x61\xea\x6f\x61\x36\xaf\x2f\x11\xd0\x82\x01\xe5\x84\x40\x96\xbf\x27\x9c\x30\x58\xaf\xe3\x20\x2e\x2b\x8b\x3d\xa4\x05\x3d\xb0\x32\xd9\x51\x06\xa6\xd7\x5b\xa7\x57\xba\x45\x23\x6c\xa3\x55\xd5\x1c\xab\x1f\xec\xc2\x34\x67\x6e\x12\xe6\x0c\x7b\x4c\xe0\x74\xf7\xc9\x73\xd8\x38\xfd\x19\x86\x05\x51\xbc\xba\x69\x79\x40\x65\x99\x11\xea\x24\xea\x0b\xe2\x91\x3c\x6e\x78\xe9\xef\xc9\x6a\x89\xe6\x11\xd0\x5d\xce\x38\xd1\xba\x17\x2a\x89\x52\x57\xb6\x6b\xd1\xbd\xbc\x30\x47\x4e\x7c\x40\x1f\x9f\xcf\xf1\x02\xbc\x96\xb8\xe7\x25\xc3\xe1\x8a\x65\xfa\x4f\x2d\x34\x3f\xd9\x7b\xe1\x0c\x01\xf3\xbe\xe9\x90\xc5\xc1\x8f\x9a\xd9\x90\xd9\x0f\x96\xde\xc4\xc4\x1a\xc7\x41\xe8\xcc\x69\x8c\x61\xd7\x54\x86\x42\x14\x6e\x71\x6f\xd0\xff\x01\x00\xf1\x3f\x81\x8f\x5a\x04\x00\x00")
+
+func migrations_gateway04_tenantsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway04_tenantsSql,
+		"migrations_gateway/04_tenants.sql",
+	)
+}
+
+func migrations_gateway04_tenantsSql() (*asset, error) {
+	bytes, err := migrations_gateway04_tenantsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/04_tenants.sql", size: 1114, mode: os.FileMode(420), modTime: time.Unix(1792052851, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway05_from_addressSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd2\xd5\x55\xd0\xce\xcd\x4c\x2f\x4a\x2c\x49\x55\x08\x2d\xe0\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x08\x4a\x4d\x4e\xcd\x2c\x4b\x4d\x09\x48\xac\xcc\x4d\xcd\x2b\x51\x70\x74\x71\x51\x70\xf6\xf7\x09\xf5\xf5\x53\x48\x2b\xca\xcf\x8d\x4f\x4c\x49\x29\x4a\x2d\x2e\x56\x28\x4b\x2c\x4a\xce\x48\x2c\xd2\x30\x32\x35\xd5\x54\x70\x71\x75\x73\x0c\xf5\x09\x51\xf0\x0b\xf5\xf1\xb1\xe6\xe2\x42\x36\xde\x25\xbf\x3c\x0f\xaf\x05\x2e\x41\xfe\x01\xd8\x6c\xb0\xe6\x02\x0c\x00\xdb\xf6\x02\x87\xa6\x00\x00\x00")
+
+func migrations_gateway05_from_addressSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway05_from_addressSql,
+		"migrations_gateway/05_from_address.sql",
+	)
+}
+
+func migrations_gateway05_from_addressSql() (*asset, error) {
+	bytes, err := migrations_gateway05_from_addressSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/05_from_address.sql", size: 166, mode: os.FileMode(420), modTime: time.Unix(1792052963, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway06_payment_detailsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\xd2\xb1\x4e\xc3\x30\x10\x06\xe0\x3d\x4f\x71\x5b\x41\xa8\x03\x15\xe9\x92\x29\xe0\x30\x99\xa4\x8a\x92\xd9\x3a\x39\x57\xf0\x60\xbb\xb2\x9d\xa2\xbe\x3d\x8a\x10\x06\xa4\xaa\x72\x94\xec\xfe\xbf\xf3\xe9\xbf\xed\x16\x1e\xb4\x7a\x77\x18\x08\xfa\x53\x56\xf2\xae\x6a\xa1\x2b\x9f\x79\x05\x2d\x49\x52\x67\x1a\x0e\x78\xd1\x64\x02\x94\x8c\xc1\x4b\xc3\xfb\xb7\x1a\x8e\xce\x6a\x81\x52\xda\xd1\x04\x38\xa3\x93\x1f\xe8\xee\xf2\xfd\x3d\xd4\x4d\x07\x75\xcf\x39\xb0\xea\xb5\xec\x79\x07\x9b\x4d\x91\x8a\xa2\xfe\xc7\xed\x9f\x16\x72\xde\x53\x10\xd2\x0e\x14\xc9\xc7\xdd\x1a\xa4\xf2\x7e\x24\xb7\xda\xda\x9a\xb4\x15\xe1\x72\xfa\xfd\xe6\xc2\xc5\x27\x30\x5a\xbb\x3c\x5f\xa6\x39\x3a\x8e\x66\x10\xc1\xa1\xf1\x28\x83\xb2\x46\xa8\x21\xf2\x53\x49\x3f\xe8\x34\xa1\xc8\xb2\xbf\x17\xc5\xec\xa7\xb9\x39\x88\xb5\xcd\xe1\xda\x51\x15\xc9\x29\xd4\x33\xdf\xc7\xb3\x98\x9b\xf9\xee\x3d\x3d\x15\x8b\x9d\x17\x49\x7f\x7d\xb5\x9a\x22\xfb\x1a\x00\x4b\xc8\xa0\xb4\xd3\x03\x00\x00")
+
+func migrations_gateway06_payment_detailsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway06_payment_detailsSql,
+		"migrations_gateway/06_payment_details.sql",
+	)
+}
+
+func migrations_gateway06_payment_detailsSql() (*asset, error) {
+	bytes, err := migrations_gateway06_payment_detailsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/06_payment_details.sql", size: 979, mode: os.FileMode(420), modTime: time.Unix(1792053279, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway07_payment_linksSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x91\x41\x6b\x83\x50\x0c\xc7\xef\xef\x53\xe4\x56\x65\xed\x6d\xec\xe2\xc9\xcd\x37\x28\x7b\x53\x11\x85\xf5\x24\x99\x86\x2e\xb4\xa6\xf2\x0c\x1d\xfb\xf6\x43\xa4\x30\x9d\xf4\xfe\xcb\x3f\xf9\xe5\xbf\xdb\xc1\x43\xc7\x47\x8f\x4a\x50\xf5\xe6\xa5\xb0\x71\x69\xa1\x8c\x9f\x9d\x85\x1c\x7f\x3a\x12\x75\x2c\x27\x08\x0c\x00\xb7\xf0\xc9\xc7\x81\x3c\xe3\x79\x6b\x00\x3c\x35\xc4\x57\x6a\xeb\x7e\x02\xeb\x09\x60\x51\x48\xb3\x12\xd2\xca\xb9\x11\x53\x8f\x32\x60\xa3\x7c\x91\x91\xb8\xa2\x6f\xbe\xd0\x07\x4f\x8f\xe1\x0c\x6b\x3c\xa1\x52\x5b\xa3\x82\x72\x47\x83\x62\xd7\xcf\x73\x48\x50\x74\x75\x1e\x12\xfb\x1a\x57\xae\x84\xcd\x66\xdc\x98\x17\xfb\xf7\xb8\x38\xc0\x9b\x3d\x40\xc0\x6d\x68\xc2\xe8\x66\xb6\x4f\x13\xfb\x01\xb7\x83\xcf\x2c\xa7\x7a\x4d\x23\x4b\xe7\xf6\x2b\xcc\xdd\xcc\x85\xf3\x32\x6e\x52\xd9\x2e\x5e\x13\x46\xc6\xfc\xed\x23\xb9\x7c\x8b\x49\x8a\x2c\xff\xdf\x47\x64\x7e\x07\x00\x75\xb7\x0c\x6b\xb9\x01\x00\x00")
+
+func migrations_gateway07_payment_linksSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway07_payment_linksSql,
+		"migrations_gateway/07_payment_links.sql",
+	)
+}
+
+func migrations_gateway07_payment_linksSql() (*asset, error) {
+	bytes, err := migrations_gateway07_payment_linksSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/07_payment_links.sql", size: 441, mode: os.FileMode(420), modTime: time.Unix(1792053416, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway08_payment_resolutionSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\xcf\xb1\xaa\xc2\x30\x14\x80\xe1\xbd\x4f\x71\xc6\x7b\x91\x2e\x42\xa7\x4e\xd1\xd4\x29\xb6\xa5\x34\x73\x39\xb6\x07\x0d\x98\xa4\xa4\xc7\x68\xdf\xde\xc1\x45\x04\x85\xea\x03\xfc\x1f\xfc\x69\x0a\x2b\x6b\x8e\x01\x99\x40\x8f\x89\x50\x6d\xd1\x40\x2b\x36\xaa\x80\x86\x7a\x32\x91\x86\x1a\x67\x4b\x8e\x41\x48\x09\xdb\x4a\xe9\x7d\x09\x81\x26\x7f\xbe\xb0\xf1\xae\x0b\x84\x93\x77\xc0\x74\x63\x90\xc5\x4e\x68\xd5\x42\xa9\x95\xca\x17\x59\x91\x86\xee\x30\x43\xc4\xd0\x9f\x30\xfc\xad\xb3\xec\xff\x47\x0d\x19\xd8\x58\x9a\x18\xed\xf8\x42\x25\xcf\xd3\xd2\x5f\xdd\x47\x5c\x36\x55\xfd\xf6\x3b\x5f\x96\x3e\x36\xbf\x88\x90\xf3\xe4\x3e\x00\xb7\x2d\x2c\x4a\xab\x01\x00\x00")
+
+func migrations_gateway08_payment_resolutionSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway08_payment_resolutionSql,
+		"migrations_gateway/08_payment_resolution.sql",
+	)
+}
+
+func migrations_gateway08_payment_resolutionSql() (*asset, error) {
+	bytes, err := migrations_gateway08_payment_resolutionSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/08_payment_resolution.sql", size: 427, mode: os.FileMode(420), modTime: time.Unix(1792054092, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway09_sent_transaction_metadataSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\xcc\xb1\x0d\xc2\x40\x0c\x05\xd0\xfe\xa6\xf8\x3d\xca\x04\xa9\x0e\x7c\x54\x26\x41\xc1\x1e\xc0\x02\x0b\xa5\x88\x83\x82\x25\x18\x9f\x16\x51\xb0\xc0\xeb\x3a\xec\x96\xf9\xbe\x59\x3a\xf4\x51\x2a\x4b\x9b\x20\x75\xcf\x0d\x17\x8f\x94\xcd\xe2\x69\xd7\x9c\xd7\x40\x25\xc2\x61\x64\x3d\x0d\x58\x3c\xed\x66\x69\x48\x7f\x27\xa8\x1d\xab\xb2\x60\x50\xe6\xbe\x94\x6f\x92\xd6\x57\xfc\x45\x69\x1a\xcf\xbf\x6a\x5f\x3e\x03\x00\xbd\x88\x64\x84\x96\x00\x00\x00")
+
+func migrations_gateway09_sent_transaction_metadataSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway09_sent_transaction_metadataSql,
+		"migrations_gateway/09_sent_transaction_metadata.sql",
+	)
+}
+
+func migrations_gateway09_sent_transaction_metadataSql() (*asset, error) {
+	bytes, err := migrations_gateway09_sent_transaction_metadataSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/09_sent_transaction_metadata.sql", size: 150, mode: os.FileMode(420), modTime: time.Unix(1792054275, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway10_callback_retriesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x74\x91\x4f\x6b\xb3\x40\x10\x87\xef\xfb\x29\xe6\x16\xe5\x4d\x6e\x2f\xbd\x78\xb2\x71\x0b\x52\xab\xa9\x28\x34\xa7\x65\xa2\x83\x5d\xaa\x1b\x19\x87\x34\xf9\xf6\x45\x92\xfe\xd1\xda\xf3\x3c\xfb\xc0\x3e\xbf\xcd\x06\xfe\x75\xb6\x61\x14\x82\xb2\x57\xdb\x5c\x87\x85\x86\x22\xbc\x4f\x34\x6c\xb1\x6d\x0f\x58\xbd\xe5\x24\x7c\x01\x4f\x01\xd8\x1a\x0e\xb6\x19\x88\x2d\xb6\x6b\x05\xc0\x54\x91\x3d\x51\x6d\x7a\xbc\x74\xe4\xc4\x5c\x01\xeb\x04\xd2\xac\x80\xb4\x4c\x92\x11\x43\x11\xea\x7a\x19\xc0\x3a\xa1\x86\x78\x72\x74\x74\x16\x73\x23\x0c\x0a\x88\xed\x68\x10\xec\xfa\x09\xd5\xe2\x20\x86\x98\x8f\x0c\x42\xe7\xa9\xbe\x62\x42\xa1\xfa\xef\xc7\x42\x0e\x9d\xc0\x09\xb9\x7a\x45\xf6\xee\xfe\xfb\x5f\x67\x88\xf4\x43\x58\x26\x05\xac\x56\x23\xb9\xcb\xe3\xa7\x30\xdf\xc3\xa3\xde\x83\x67\x6b\x5f\xf9\xc1\x67\x93\x32\x8d\x9f\x4b\x0d\x71\x1a\xe9\x17\xa8\x6e\x69\x0c\x8f\x6d\xcc\x52\x87\x2c\x9d\x07\x5c\xa0\xbe\xf5\x8b\xde\x79\x9b\xdf\xce\xeb\xd7\xd6\x30\x23\xfd\x40\xa9\x9f\xd3\x46\xc7\x77\xa7\xa2\x3c\xdb\x2d\x4d\x1b\xa8\x8f\x01\x00\x08\x27\xcd\x03\x06\x02\x00\x00")
+
+func migrations_gateway10_callback_retriesSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway10_callback_retriesSql,
+		"migrations_gateway/10_callback_retries.sql",
+	)
+}
+
+func migrations_gateway10_callback_retriesSql() (*asset, error) {
+	bytes, err := migrations_gateway10_callback_retriesSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/10_callback_retries.sql", size: 518, mode: os.FileMode(420), modTime: time.Unix(1792055078, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway11_idempotency_keysSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x90\xc1\x8a\xc2\x30\x10\x40\xef\xf9\x8a\x39\xb6\xec\xf6\xb0\x7b\x5a\xc8\x29\x6b\x22\x14\x62\xaa\x6d\x72\x2e\xa1\x06\x2d\xd2\x69\x49\x07\xa5\x7f\x2f\xe8\x41\x29\x22\xc5\xf3\x0c\xef\xbd\x99\x2c\x83\xaf\xae\x3d\x44\x4f\x01\xdc\xc0\x84\xb6\xaa\x04\x2b\xfe\xb5\x82\x2a\x20\xd9\xe8\x71\xf4\x0d\xb5\x3d\x82\x90\x12\x56\x85\x76\x1b\x03\xed\x3e\x74\x43\x4f\x01\x9b\xa9\x3e\x85\x09\xce\x3e\x36\x47\x1f\x93\x9f\xdf\xbf\x14\xa4\x5a\x0b\xa7\x2d\x18\xa7\x35\x5f\x40\x34\x95\x2d\x45\x6e\x2c\x8c\x01\x89\x1e\xe3\x9a\x02\x7a\xa4\x7a\x26\xbb\x09\x9d\xc9\x77\x4e\x41\x72\x5f\xf9\x9e\x07\xa5\x9c\xb1\xe7\xcb\x64\x7f\xc1\xb7\x25\xb2\x2c\xb6\x9f\xa5\xf0\x25\xdc\x57\x4f\xe3\xec\x3a\x00\x49\xeb\x10\x06\x7c\x01\x00\x00")
+
+func migrations_gateway11_idempotency_keysSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway11_idempotency_keysSql,
+		"migrations_gateway/11_idempotency_keys.sql",
+	)
+}
+
+func migrations_gateway11_idempotency_keysSql() (*asset, error) {
+	bytes, err := migrations_gateway11_idempotency_keysSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/11_idempotency_keys.sql", size: 380, mode: os.FileMode(420), modTime: time.Unix(1792055838, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway12_limit_countersSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\x90\x41\x4f\x83\x40\x10\x85\xef\xfb\x2b\xde\xad\x10\xe9\xc5\x58\x2f\x9c\xb0\xac\x09\x11\xa1\x12\x36\xb1\x27\xb2\x96\x4d\x9d\xd8\x5d\x9a\x65\x94\xf8\xef\xcd\xa6\x51\xc1\x78\x9e\x97\xef\xcd\xf7\xd6\x6b\x5c\x59\x3a\x7a\xcd\x06\xea\x2c\xb6\x8d\xcc\x5a\x89\x36\xbb\x2b\x25\x4a\xb2\xc4\xdb\xe1\xdd\xb1\xf1\x88\x04\x40\x3d\x5e\xe8\x38\x1a\x4f\xfa\x94\x08\xe0\x70\xb9\x75\x6f\xe6\x13\x1f\xda\x1f\x5e\xb5\x8f\xae\x37\x9b\x18\x55\xdd\xa2\x52\x65\x19\x42\x13\xb9\x7e\x98\xba\x91\xb5\x67\x30\x59\x33\xb2\xb6\xe7\x45\x44\xdb\x00\x0a\x6c\x72\xbc\xb8\xb0\x71\xda\xf1\x0f\xfc\xf6\xe6\x97\x8d\x5c\xde\x67\xaa\x6c\xb1\x5a\x85\x9a\x5d\x53\x3c\x66\xcd\x1e\x0f\x72\x8f\x88\xfa\x58\xc4\xe9\xb7\x8d\xaa\x8a\x27\x25\x51\x54\xb9\x7c\xc6\x29\x48\x75\xf3\xcf\xeb\xea\x8f\xe9\xa5\x34\x99\xeb\x25\x0b\x8d\x38\x15\x62\x3e\x5c\x3e\x4c\x4e\xe4\x4d\xbd\xfb\x67\xb8\x54\x7c\x0d\x00\x84\x39\xc4\x18\x63\x01\x00\x00")
+
+func migrations_gateway12_limit_countersSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway12_limit_countersSql,
+		"migrations_gateway/12_limit_counters.sql",
+	)
+}
+
+func migrations_gateway12_limit_countersSql() (*asset, error) {
+	bytes, err := migrations_gateway12_limit_countersSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/12_limit_counters.sql", size: 355, mode: os.FileMode(420), modTime: time.Unix(1792056900, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway13_sep31_transactionsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x93\x4f\x6f\x9b\x40\x10\xc5\xef\xfb\x29\xe6\x16\xa3\x26\x52\x93\xd4\xb9\xf8\x44\x0b\x95\xac\x52\x9c\x52\x90\x9a\xd3\x6a\xb3\x3b\x71\x57\x62\x17\xb4\x3b\xa4\xf5\xb7\xaf\xb0\x5b\xfe\x18\xa8\x73\x43\xcc\x6f\x47\xef\xcd\xcc\xbb\xb9\x81\x77\x46\xef\x9d\x20\x84\xa2\x66\x9f\xb2\x38\xcc\x63\xc8\xc3\x8f\x49\x0c\xdf\xb1\xbe\xbf\xcd\x9d\xb0\x5e\x48\xd2\x95\x85\x15\x03\xd0\x0a\x9e\xf5\xde\xa3\xd3\xa2\xbc\x66\x00\x75\xf3\x5c\x6a\xc9\xb5\x82\x57\xe1\xe4\x4f\xe1\x56\xf7\x0f\x01\xa4\xbb\x1c\xd2\x22\x49\x5a\xc2\x93\xa0\xc6\xf7\xe5\xbb\x71\x59\x98\xaa\xb1\xc4\xb5\xed\x88\x87\x0f\xb3\xc4\x0b\xe2\x25\xa4\x6a\x68\x19\xf1\x1e\x89\xcb\x4a\xf5\x5d\x6e\xcf\xa5\x1c\x11\xed\x7d\x83\xae\x83\xd6\xe7\x76\xd0\x2a\x74\x43\xc3\x77\xeb\xf5\x18\x71\x28\x51\xbf\x5e\x80\x5e\x34\x96\xca\x03\xe1\x6f\x1a\xfd\x97\xa5\x46\x4b\x5c\x48\xd9\xba\x5e\x94\x61\xd0\x54\x9c\x0e\xf5\xc0\xcd\xfb\x29\xb1\x38\x0d\x4f\x58\x96\xc2\x71\xea\xd7\x3b\x54\x7b\x5c\x41\x91\x24\x10\xc5\x9f\xc3\x22\x99\x58\x53\xbc\x16\x07\xd3\xea\x9c\x58\x9c\x7b\x75\x3a\x01\x6e\xd0\x7b\xb1\xc7\xbf\x9e\x17\x40\x47\xa8\xb8\x20\x20\x6d\xd0\x93\x30\xf5\x48\xb7\xac\x4c\x5d\xe2\x14\x99\x6b\x46\x68\x85\xa5\xd9\x11\x74\xec\xd5\x55\x4b\x3e\x66\xdb\xaf\x61\xf6\x04\x5f\xe2\x27\x58\x69\x15\xb0\x60\xf3\x2f\x0a\x45\xba\xfd\x56\xc4\xb0\x4d\xa3\xf8\x07\xf8\x36\x11\xa3\x99\xf5\xe7\xbf\x4b\x67\x02\x73\x92\x70\xdd\xa7\xe4\xcd\x8d\x8f\xdb\xfb\x6f\xcf\xee\x02\x4e\x9f\xc1\x86\xb1\x61\x9a\xa3\xea\x97\x65\x51\xb6\x7b\x5c\x48\xf3\x86\xfd\x19\x00\x03\x7f\x27\x94\xfc\x03\x00\x00")
+
+func migrations_gateway13_sep31_transactionsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway13_sep31_transactionsSql,
+		"migrations_gateway/13_sep31_transactions.sql",
+	)
+}
+
+func migrations_gateway13_sep31_transactionsSql() (*asset, error) {
+	bytes, err := migrations_gateway13_sep31_transactionsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/13_sep31_transactions.sql", size: 1020, mode: os.FileMode(420), modTime: time.Unix(1792057670, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway14_receiving_accountsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\xcd\xb1\xae\xc2\x20\x14\x06\xe0\x9d\xa7\xf8\xb7\xde\x1b\xd3\x51\x97\x4e\x28\x38\x1d\xa1\x21\x30\x1b\x82\x27\x95\xa1\xd4\x10\xac\xf1\xed\x4d\x9c\x5c\x74\xfe\x86\xaf\xef\xb1\x99\xf3\x54\x63\x63\x84\x9b\x90\xe4\xb5\x83\x97\x7b\xd2\x70\x9c\x38\xaf\x7c\x19\xe3\x73\xe6\xd2\x20\x95\xc2\xc1\x52\x38\x19\xd4\x37\xe5\x32\x9d\x63\x4a\xcb\xbd\x34\xac\xb1\xa6\x6b\xac\x7f\xdb\xdd\x3f\x8c\xf5\x30\x81\x08\x4a\x1f\x65\x20\x8f\xae\x1b\x84\xf8\x9c\xd4\xf2\x28\x3f\x2f\xe5\xec\xf8\x35\x1b\xc4\x6b\x00\x30\x50\xf1\x1c\xb6\x00\x00\x00")
+
+func migrations_gateway14_receiving_accountsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway14_receiving_accountsSql,
+		"migrations_gateway/14_receiving_accounts.sql",
+	)
+}
+
+func migrations_gateway14_receiving_accountsSql() (*asset, error) {
+	bytes, err := migrations_gateway14_receiving_accountsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/14_receiving_accounts.sql", size: 182, mode: os.FileMode(420), modTime: time.Unix(1792059801, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway15_callback_attemptsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\x92\xcf\x6b\xfa\x40\x10\xc5\xef\xfb\x57\xcc\x4d\xc3\x57\xe1\x5b\xa9\xbd\x78\x4a\x4d\x0a\xd2\x34\x4a\x50\xa8\xa7\x30\x6e\x06\xbb\x34\xd9\x0d\xb3\x63\x7f\xfc\xf7\x45\x6a\x63\x16\xa5\x3d\xcf\x7b\x9f\xdd\x37\x6f\xc6\x63\xf8\xd7\x98\x3d\xa3\x10\x6c\x5a\x35\x2f\xd2\x78\x9d\xc2\x3a\xbe\xcf\x52\x98\x63\x5d\xef\x50\xbf\xc6\x22\xd4\xb4\x02\x43\x05\x60\x2a\xd8\x99\xbd\x27\x36\x58\x8f\x14\x80\x6b\x89\x51\x8c\xb3\xa5\xa9\xe0\x0d\x59\xbf\x20\x0f\x27\xd3\x69\x04\xf9\x72\x0d\xf9\x26\xcb\x8e\x2a\x3c\x11\x8c\x15\xda\x13\x07\xb3\x03\xd7\x9d\xf1\xe6\xff\xe4\x36\x74\xfa\x83\xd6\xe4\x3d\xec\x9c\xab\x09\x6d\x38\x13\x94\x83\x2f\xb5\xab\xa8\x23\x27\xe9\x43\xbc\xc9\xce\x9a\x1a\x85\xac\xfe\x2c\x1b\x7f\xfc\xb7\xb1\x12\x10\x98\x7c\xeb\xac\x27\x10\xfa\x90\x0b\x2f\x31\x3b\xbe\x3e\xd2\x4c\x28\x54\x95\x28\x20\xa6\x21\x2f\xd8\xb4\x01\x59\xc8\xa2\x95\x2e\xd8\x5d\x2f\x56\x07\x1b\x0c\x8e\xa8\x55\xb1\x78\x8a\x8b\x2d\x3c\xa6\x5b\x18\x9a\x2a\x52\xd1\xec\xa7\x86\x45\x9e\xa4\xcf\xa0\x4f\x35\x94\xa7\x2d\x96\xc1\xce\x97\xf9\x65\x4f\xdf\x8f\x8f\x82\x72\xfe\xa4\xf6\x22\xfd\xc6\x3c\xcb\xa2\x99\x52\xfd\xf3\x49\xdc\xbb\x55\x49\xb1\x5c\x5d\x3f\x9f\x99\xfa\x1a\x00\x2e\x9e\x8f\xc3\x6c\x02\x00\x00")
+
+func migrations_gateway15_callback_attemptsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway15_callback_attemptsSql,
+		"migrations_gateway/15_callback_attempts.sql",
+	)
+}
+
+func migrations_gateway15_callback_attemptsSql() (*asset, error) {
+	bytes, err := migrations_gateway15_callback_attemptsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/15_callback_attempts.sql", size: 620, mode: os.FileMode(420), modTime: time.Unix(1792061082, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway16_api_keysSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x74\x90\xc1\x6a\xeb\x30\x10\x45\xf7\xfa\x8a\xbb\x8b\xcd\x7b\xd9\x94\xa6\x1b\xaf\xdc\x5a\x05\x13\x57\x76\x8d\x05\xcd\xca\x4c\xe3\x21\x16\xad\x14\x23\x89\x96\xfc\x7d\x71\x21\x26\x5e\x74\x3b\xe7\x0e\x73\xe7\x6c\xb7\xf8\x67\xcd\xc9\x53\x64\xe8\x49\x3c\xb5\x32\xef\x24\xba\xfc\xb1\x92\xc8\x9b\x72\xcf\x17\x24\x02\x30\x03\xde\xcd\x29\xb0\x37\xf4\xf9\x5f\x00\x8e\x2c\xe3\x8b\xfc\x71\x24\x9f\xdc\xed\x76\x29\x54\xdd\x41\xe9\xaa\x9a\xe9\x07\x5f\xfa\x91\xc2\x88\x5f\xfc\x70\xbf\xa6\x91\x1d\xb9\xb8\x6c\xdf\x62\x14\xf2\x39\xd7\x55\x87\xcd\x66\x4e\x4e\xec\xad\x09\xc1\x9c\x5d\xf8\xfb\xd8\xd1\x33\x45\x1e\x7a\x8a\x88\xc6\x72\x88\x64\xa7\x55\xa0\x69\xcb\x97\xbc\x3d\x60\x2f\x0f\x48\xcc\x90\x8a\x34\xbb\xfe\xa9\x55\xf9\xaa\x25\x4a\x55\xc8\x37\xd0\x64\xfa\xb9\xf9\xd2\xbe\x56\x8b\x82\xeb\x2c\xcd\x84\xb8\x55\x56\x9c\xbf\x9d\x28\xda\xba\x59\x29\xcb\xc4\xcf\x00\x95\x73\xe5\xe7\x57\x01\x00\x00")
+
+func migrations_gateway16_api_keysSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway16_api_keysSql,
+		"migrations_gateway/16_api_keys.sql",
+	)
+}
+
+func migrations_gateway16_api_keysSql() (*asset, error) {
+	bytes, err := migrations_gateway16_api_keysSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/16_api_keys.sql", size: 343, mode: os.FileMode(420), modTime: time.Unix(1792062394, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway17_pending_transactionsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x92\xc1\x8e\xda\x40\x0c\x86\xef\xf3\x14\xbe\x41\x54\x90\x5a\x5a\xe8\x81\x53\xda\xa4\x12\x6a\x1a\x68\x94\x48\xcb\x29\xf2\x66\xac\x60\x89\x4c\xb2\x33\x0e\xf0\xf8\x2b\x36\x5a\x48\xa2\x5d\xae\xf3\x7d\x96\xed\xf1\x3f\x9f\xc3\x97\x8a\x4b\x8b\x42\x90\x35\xea\x77\x12\xfa\x69\x08\xa9\xff\x2b\x0a\x61\x47\x46\xb3\x29\x53\x8b\xc6\x61\x21\x5c\x1b\x98\x2a\x00\xd6\xf0\xcc\xa5\x23\xcb\x78\x9c\x29\x00\xb9\xf3\x9c\x35\x14\x07\xb4\xd3\xd5\x0f\x0f\xe2\x6d\x0a\x71\x16\x45\x57\xc7\x09\x4a\xeb\xe0\x84\xf6\x0d\x7f\x5b\x8d\x70\xdd\xda\x82\xba\xd2\xe5\x88\x91\x39\xd1\xb1\x6e\x28\xbf\x68\x0b\x42\x17\x19\x50\x4b\x2f\x2d\x5b\xd2\xf9\x99\xb8\x3c\x08\xb0\x11\x2a\xc9\x0e\x1c\xc7\xa5\x79\x6c\x68\x72\xc2\x06\xaf\x2b\xdc\x66\x5c\x2c\x97\xc3\x41\xb0\xaa\x5b\x23\x37\xfe\x7d\x31\xc2\xce\xd1\x9d\xfe\xfc\x3a\xa4\x15\x09\x6a\x14\xec\x36\x08\xc2\x3f\x7e\x16\xdd\x69\x61\x09\x85\x74\x8e\x02\xc2\x15\x39\xc1\xaa\x19\x94\xb7\x8d\x7e\x2c\x08\x19\xec\x0d\xd7\xff\xff\x5b\xb7\xc9\xe4\x6a\xee\x92\xcd\x3f\x3f\xd9\xc3\xdf\x70\x0f\x53\xd6\x9e\xf2\xd6\xef\x67\xcf\xe2\xcd\xff\x2c\x84\x4d\x1c\x84\x4f\xd0\x74\xd7\xcf\xfb\xe7\xed\xba\x0c\x9e\x58\xc3\x36\xfe\x30\x2a\x9d\x3c\x1b\xe5\xc3\x5b\x2b\xd5\x0f\x5d\x50\x9f\x8d\x0a\x92\xed\xee\xd3\xd0\xad\xd5\xeb\x00\x38\x84\x84\xd5\xa5\x02\x00\x00")
+
+func migrations_gateway17_pending_transactionsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway17_pending_transactionsSql,
+		"migrations_gateway/17_pending_transactions.sql",
+	)
+}
+
+func migrations_gateway17_pending_transactionsSql() (*asset, error) {
+	bytes, err := migrations_gateway17_pending_transactionsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/17_pending_transactions.sql", size: 677, mode: os.FileMode(420), modTime: time.Unix(1792065158, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway18_listener_leasesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\x8f\x41\x4b\xc4\x30\x14\x84\xef\xef\x57\xcc\x6d\x5b\xdc\xbd\x88\xeb\xa5\xa7\x68\x23\x88\xb1\x2d\x21\x3d\xf4\x24\x0f\x7d\xd8\x80\x49\x4b\x12\xd4\x9f\x2f\x2a\x88\x85\x9e\xe7\x63\x66\xbe\xd3\x09\x17\xc1\xbf\x26\x2e\x82\x71\xa5\x5b\xab\x95\xd3\x70\xea\xc6\x68\x18\x9f\x8b\x44\x49\x46\x38\x0b\x2a\x02\x22\x07\xc1\x3b\xa7\xe7\x99\x53\x75\x7d\x55\xa3\xeb\x1d\xba\xd1\x98\x23\x01\x45\x22\xc7\xb2\x1b\xa3\xd5\x77\x6a\x34\x0e\x87\xc3\x37\x39\x2f\x6f\x2f\x92\xfe\xc8\xcb\xf3\x79\xdb\x24\x9f\xab\x4f\x92\x9f\xb8\xa0\xf8\x20\xb9\x70\x58\x37\xc0\x60\xef\x1f\x95\x9d\xf0\xa0\x27\x54\xbf\xbb\xc7\x9f\x73\x35\xd5\x0d\xd1\x7f\xa9\x76\xf9\x88\xd4\xda\x7e\xd8\x93\x6a\xe8\x6b\x00\x4a\x55\x5c\x80\x00\x01\x00\x00")
+
+func migrations_gateway18_listener_leasesSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway18_listener_leasesSql,
+		"migrations_gateway/18_listener_leases.sql",
+	)
+}
+
+func migrations_gateway18_listener_leasesSql() (*asset, error) {
+	bytes, err := migrations_gateway18_listener_leasesSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/18_listener_leases.sql", size: 256, mode: os.FileMode(420), modTime: time.Unix(1792066535, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway19_callback_deliveriesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x90\x41\x4b\x03\x31\x10\x85\xef\xf3\x2b\xde\xad\xbb\xb8\x05\x2d\xd6\x4b\x4f\xb1\x89\x20\xc6\xb6\x84\xec\xa1\xa7\x32\x76\x83\x06\xb3\xd9\x25\xc6\x8a\xff\x5e\x44\xd9\x5d\x0f\xf6\x38\x7c\x8f\xc7\x9b\x6f\x3e\xc7\x45\xeb\x9f\x13\x67\x87\xba\xa7\xb5\x51\xc2\x2a\x58\x71\xab\x15\xd6\x1c\xc2\x13\x1f\x5f\xa5\x0b\xfe\xe4\xd2\x27\x0a\x02\x9a\xdf\xe3\xe0\x1b\x9c\x38\x1d\x5f\x38\x15\x8b\xe5\xb2\xc4\x66\x6b\xb1\xa9\xb5\xae\x08\xc8\x2e\x72\xcc\x03\xbf\xb9\x1e\x31\xa4\xba\x13\xb5\xb6\x98\xcd\xbe\x93\x5d\xef\x12\x67\xdf\xc5\xb3\x7d\xef\x29\x0c\xf0\xea\x72\x31\xa9\xab\xc6\x49\xae\x39\x70\x46\xf6\xad\x7b\xcb\xdc\xf6\x7f\x22\x3b\x73\xff\x28\xcc\x1e\x0f\x6a\x8f\xe2\x67\x5d\x35\x7d\xa5\xa4\x72\x45\x34\x95\x21\xbb\x8f\x48\xd2\x6c\x77\xff\xc8\x58\xd1\xd7\x00\x79\x73\xf7\x42\x3b\x01\x00\x00")
+
+func migrations_gateway19_callback_deliveriesSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway19_callback_deliveriesSql,
+		"migrations_gateway/19_callback_deliveries.sql",
+	)
+}
+
+func migrations_gateway19_callback_deliveriesSql() (*asset, error) {
+	bytes, err := migrations_gateway19_callback_deliveriesSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/19_callback_deliveries.sql", size: 315, mode: os.FileMode(420), modTime: time.Unix(1792067943, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway20_return_memosSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x8f\xc1\x6b\x83\x30\x18\xc5\xef\xf9\x2b\xde\xcd\xc9\x26\x6c\xe0\x4d\x76\x70\x33\xb0\x83\xdb\x24\x8b\x8c\x9e\x6a\xb0\x1f\xd5\x43\x12\x49\x52\x8b\xff\x7d\x51\xa1\x58\x7a\xec\xf5\x7b\xfc\x7e\xdf\x7b\x49\x82\x67\xdd\x1f\x9d\x0a\x84\x7a\x60\x79\x29\xb9\x80\xcc\x3f\x4a\x0e\x41\x2d\xf5\x23\x1d\x2a\x35\x69\x32\x01\x6b\xf6\xf9\x5b\xd6\xdf\x3f\xd0\xa4\xed\x3e\x4c\x03\x41\xee\x2a\x8e\x51\xb9\xb6\x53\xee\xe9\xed\x35\xce\x18\xdb\x4a\x0b\x7b\x36\xf3\x41\x50\x38\x39\xb3\x70\x1e\xca\x11\x3a\xe5\x3b\xf2\x2f\x57\x34\x8d\xd1\x2a\x63\x6c\x80\x0f\xd6\x11\x1a\xb7\x10\x0d\xe6\x2f\xac\xae\x8a\x5c\xde\x77\xfa\xe3\x72\x53\xe5\x1d\xd1\x6c\x8d\xf0\xff\xc5\x05\xbf\x0d\x56\x5b\x94\x3d\x3c\x31\x8d\x33\x76\x19\x00\xe2\x5f\x42\xdb\x36\x01\x00\x00")
+
+func migrations_gateway20_return_memosSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway20_return_memosSql,
+		"migrations_gateway/20_return_memos.sql",
+	)
+}
+
+func migrations_gateway20_return_memosSql() (*asset, error) {
+	bytes, err := migrations_gateway20_return_memosSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/20_return_memos.sql", size: 310, mode: os.FileMode(420), modTime: time.Unix(1792075355, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway21_pending_complianceSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x92\x41\x4b\xf3\x40\x10\x86\xef\xfb\x2b\xe6\xd6\x86\xaf\x85\x4f\x10\x2f\x3d\xc5\x66\x85\x62\x4c\x4b\x48\xc1\x9e\x96\x31\x3b\xc6\x85\x66\xb3\xee\x4e\x35\x3f\x5f\x62\x6b\xd4\x68\x0a\x9e\xe7\xd9\x19\xde\x7d\xde\xf9\x1c\xfe\xd5\xa6\xf2\xc8\x04\x5b\x27\x96\xb9\x8c\x0b\x09\x45\x7c\x9d\x4a\xd8\x90\xd5\xc6\x56\xcb\xa6\x76\x7b\x83\xb6\x24\x98\x0a\x00\xa3\xe1\xc1\x54\x81\xbc\xc1\xfd\x4c\x00\x30\x59\xb4\x0c\x2f\xe8\xcb\x27\xf4\xd3\xab\xcb\x08\xb2\x75\x01\xd9\x36\x4d\x21\x91\x37\xf1\x36\x2d\x60\x32\xe9\xc8\x40\x56\x2b\x4f\xcf\x07\x0a\x0c\x4c\x2d\xf7\xe0\xfb\x94\x91\x0f\xa1\xdf\x73\xf1\xff\x73\x4f\x37\xe6\x56\x9d\x21\x06\x97\x8c\x7d\x6c\xfe\x80\x23\x33\xd5\x8e\x03\x18\xcb\x54\x91\xef\xb9\xee\xb0\xa5\x96\xd5\x89\x50\xc8\xc0\xa6\xa6\xc0\x58\xbb\x6f\x14\xb5\xce\x78\x0a\xe3\xc0\x1e\x03\x2b\xf2\xbe\xf1\x3f\xa3\x97\x9e\x90\x49\x8f\x3f\x3e\x38\x7d\x1e\xd8\xe4\xab\xbb\x38\xdf\xc1\xad\xdc\xc1\xd4\xe8\x48\x44\x8b\x0f\x97\xab\x2c\x91\xf7\xe0\x8e\x2e\x55\xd9\xcb\x54\xc3\x60\xeb\xec\x37\xe3\x47\xbd\xb3\x93\x9e\xd9\xf0\x3b\xa2\x85\x10\x5f\x3b\x94\x34\xaf\x56\x24\xf9\x7a\x33\xd6\xa1\x85\x78\x1b\x00\x8f\xea\x0d\x3f\x73\x02\x00\x00")
+
+func migrations_gateway21_pending_complianceSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway21_pending_complianceSql,
+		"migrations_gateway/21_pending_compliance.sql",
+	)
+}
+
+func migrations_gateway21_pending_complianceSql() (*asset, error) {
+	bytes, err := migrations_gateway21_pending_complianceSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/21_pending_compliance.sql", size: 627, mode: os.FileMode(420), modTime: time.Unix(1792070331, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway22_sent_transaction_paymentSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd2\xd5\x55\xd0\xce\xcd\x4c\x2f\x4a\x2c\x49\x55\x08\x2d\xe0\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x08\x4e\xcd\x2b\x09\x29\x4a\xcc\x2b\x4e\x4c\x2e\xc9\xcc\xcf\x53\x70\x74\x71\x51\x70\xf6\xf7\x09\xf5\xf5\x53\x48\x49\x2d\x2e\xc9\xcc\x4b\x04\x0b\x97\x25\x16\x25\x67\x24\x16\x69\x18\x99\x9a\x6a\x2a\xb8\xb8\xba\x39\x86\xfa\x84\x28\xf8\x85\xfa\xf8\x58\x13\x6b\x5a\x62\x6e\x7e\x69\x5e\x09\xdc\x20\x63\x23\x72\xcd\x29\x2e\x4e\x45\x18\x63\x6e\x80\x6e\x0c\x17\xb2\x67\x5d\xf2\xcb\xf3\xf0\x1a\xec\x12\xe4\x1f\x80\xc5\xbf\xd6\x44\x6b\x82\x78\x8b\x04\xf5\xc5\xc5\xa9\x25\xd6\x5c\x80\x01\x00\xbf\xdf\x1a\x9d\x92\x01\x00\x00")
+
+func migrations_gateway22_sent_transaction_paymentSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway22_sent_transaction_paymentSql,
+		"migrations_gateway/22_sent_transaction_payment.sql",
+	)
+}
+
+func migrations_gateway22_sent_transaction_paymentSql() (*asset, error) {
+	bytes, err := migrations_gateway22_sent_transaction_paymentSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/22_sent_transaction_payment.sql", size: 402, mode: os.FileMode(420), modTime: time.Unix(1792071167, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway23_transfer_transactionsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x93\xc1\x8e\x9b\x30\x10\x86\xef\x7e\x8a\xb9\x6d\x50\x77\xa5\xdd\x6d\xb3\x97\x9c\x68\xa1\x52\x54\x0a\x5b\x04\x52\xf7\x64\x39\x66\x92\x5a\xc5\x06\xd9\x43\xda\xbc\x7d\x45\x92\x86\x38\x81\x36\x27\x90\xe6\x9b\x7f\xc6\x33\xf3\x3f\x3c\xc0\x3b\xad\x36\x56\x10\x42\xd9\xb2\x4f\x79\x1c\x16\x31\x14\xe1\xc7\x24\x86\xc2\x0a\xe3\xd6\x68\xf7\x5f\x21\x49\x35\x06\x66\x0c\x40\x55\xb0\x52\x1b\x87\x56\x89\xfa\x9e\x01\xb4\xdd\xaa\x56\x92\xab\x0a\xb6\xc2\xca\x1f\xc2\xce\xde\xbf\x04\x90\x66\x05\xa4\x65\x92\xf4\xc4\x4f\x65\x86\xe0\xd3\xa3\x1f\x74\x24\xa8\x73\x43\xee\xb3\x1f\x16\xce\x21\x71\xd9\x54\x38\x28\x8c\x22\xca\xb9\x0e\xed\x09\x9a\x5f\xf4\x20\x74\xd3\x19\xe2\xca\x9c\x88\x97\x0f\xc1\x3e\x0a\x51\xfc\x39\x2c\x93\x2b\x74\x8d\x78\x33\xdb\x74\x74\x03\x2b\x65\x2f\x3c\xd9\x22\xed\xda\x8b\x8a\x59\xe1\x2b\xdd\xdd\xf5\x3a\x6b\x85\x75\xe5\x80\xf0\x37\x79\xf9\x1a\x75\xc3\x3d\x91\xa7\xc7\x89\x56\x7a\xd4\x2f\x35\x46\x39\xc2\xba\x16\x96\xd3\x70\x01\xe7\x6b\x9e\xcc\xb3\x28\x51\x6d\xb1\xe2\xad\xd8\x69\x34\x74\x9e\xf4\x3c\x9f\x4f\x56\xeb\x0f\x81\x6b\x74\x4e\x6c\xf0\xf8\xbc\x09\xd0\x12\x56\x5c\x10\x90\xd2\xe8\x48\xe8\xd6\x1b\x84\x6c\x74\x5b\xe3\x35\x32\x26\x46\x68\x84\xa1\x5b\xc6\xfe\x9a\x2f\xbf\x86\xf9\x1b\x7c\x89\xdf\x60\xa6\xaa\x80\x05\x8b\xbf\x86\x29\xd3\xe5\xb7\x32\x86\x65\x1a\xc5\xdf\x81\x8e\xbe\xf1\xc6\x36\x98\x24\x4b\xc7\x9d\x75\x68\xe4\x7e\xb0\xd3\x20\xff\x0f\xdd\xfd\x22\xff\x27\x79\x3a\x8c\xc3\x6f\xb0\x60\xec\xdc\xf8\x51\xf3\xcb\xb0\x28\xcf\x5e\xa7\x8d\xbf\x60\x7f\x06\x00\xaa\x6e\x68\x5c\x2a\x04\x00\x00")
+
+func migrations_gateway23_transfer_transactionsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway23_transfer_transactionsSql,
+		"migrations_gateway/23_transfer_transactions.sql",
+	)
+}
+
+func migrations_gateway23_transfer_transactionsSql() (*asset, error) {
+	bytes, err := migrations_gateway23_transfer_transactionsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/23_transfer_transactions.sql", size: 1066, mode: os.FileMode(420), modTime: time.Unix(1792072117, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway24_received_payment_transactionSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x91\x41\x4b\xc4\x30\x10\x85\xef\xfd\x15\x73\x54\x64\x6f\x22\x48\x4f\xd1\xd4\x53\xdc\x5d\x4a\x73\x0e\x63\x3a\xa6\x81\x4d\xb2\x24\xb3\x2b\xfe\x7b\x29\x1e\x8c\x22\x52\xb7\x97\x9c\xf2\xde\x9b\xf7\xbd\xcd\x06\x6e\x82\x77\x19\x99\x40\x1f\x1b\xa1\x86\xae\x87\x41\x3c\xa8\x0e\x7a\xb2\xe4\xcf\x34\xee\xf1\x3d\x50\x64\x10\x52\xc2\xe3\x4e\xe9\xe7\x2d\x70\xc6\x58\xd0\xb2\x4f\xd1\x4c\x58\x26\x38\x63\xb6\x13\xe6\xab\xbb\xdb\x6b\x90\xdd\x93\xd0\x6a\x80\xad\x56\xaa\x5d\xea\x78\xa0\xd1\x51\x86\x17\xef\x7c\xe4\x35\x16\xc6\x1e\x52\x21\xc3\x3e\x10\xcc\x4f\x61\x0c\xc7\xcb\x0c\x5f\x89\xcc\xdc\xca\xd1\xb8\xea\xb0\x9a\x56\x49\xa7\x6c\xc9\xa0\xb5\xe9\x14\xf9\x8b\xdb\xfd\x4f\x6e\x4d\xbd\x8c\x4c\x6f\xf1\xcf\x34\xd9\xef\xf6\xbf\xc5\xcd\xe3\xb4\x8b\x95\x9f\x04\xff\xfb\xbf\x22\xbe\x5c\x5a\xb1\x6d\x2f\x6a\xf6\x1d\x64\xdb\x7c\x0c\x00\x70\x80\xca\x01\xc9\x02\x00\x00")
+
+func migrations_gateway24_received_payment_transactionSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway24_received_payment_transactionSql,
+		"migrations_gateway/24_received_payment_transaction.sql",
+	)
+}
+
+func migrations_gateway24_received_payment_transactionSql() (*asset, error) {
+	bytes, err := migrations_gateway24_received_payment_transactionSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/24_received_payment_transaction.sql", size: 713, mode: os.FileMode(420), modTime: time.Unix(1792073476, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway25_pending_transaction_versionSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\xcc\xb1\x0a\xc2\x30\x10\x06\xe0\x3d\x4f\xf1\xef\x52\x70\xef\x14\xbd\x3a\x9d\x49\x29\x97\x07\x88\x1a\xc2\x0d\x5e\x25\x0d\xfa\xfa\xe2\x26\x2e\xae\xdf\xf0\x0d\x03\x76\x77\xad\x2d\xf7\x82\xf4\x70\x9e\x65\x5a\x20\xfe\xc0\x13\xe6\x62\x37\xb5\x2a\x2d\xdb\x96\xaf\x5d\x57\x83\x27\xc2\x31\x72\x3a\x07\x3c\x4b\xdb\x3e\x74\xd1\xaa\xd6\x11\xa2\x20\x24\x66\xd0\x74\xf2\x89\x05\xfb\xd1\xb9\xef\x9c\xd6\x97\xfd\xeb\x69\x89\xf3\xcf\x3f\xba\xf7\x00\x7f\x1c\x7a\x6b\xa2\x00\x00\x00")
+
+func migrations_gateway25_pending_transaction_versionSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway25_pending_transaction_versionSql,
+		"migrations_gateway/25_pending_transaction_version.sql",
+	)
+}
+
+func migrations_gateway25_pending_transaction_versionSql() (*asset, error) {
+	bytes, err := migrations_gateway25_pending_transaction_versionSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/25_pending_transaction_version.sql", size: 162, mode: os.FileMode(420), modTime: time.Unix(1792075703, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_compliance01_initSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x93\xcf\x6e\xb2\x40\x14\xc5\xf7\xf3\x14\x77\x29\xf9\x74\xf3\xa5\xba\x61\x45\x2b\x4d\x48\x2d\x5a\x02\x49\x5d\x4d\xae\xce\xa0\x37\x65\xc0\x0c\x43\xd5\x3e\x7d\x63\xfd\x03\x53\x05\xd3\xed\x9c\x73\xef\x9c\xf3\x83\x19\x0c\xe0\x9f\xa2\x95\x46\x23\x21\xd9\xb0\xa7\xc8\xf7\x62\x1f\x62\xef\x71\xe2\x83\x57\x99\x75\xa1\xe9\x4b\x8a\x58\x63\x5e\xe2\xd2\x50\x91\x43\x8f\x01\x90\x80\x05\xad\x4a\xa9\x09\xb3\x3e\x03\x30\xb5\xce\x49\xc0\x27\xea\xe5\x1a\x75\x6f\xf4\xe0\x40\x38\x8d\x21\x4c\x26\x93\x83\x4d\x49\x55\xb4\x8a\xcd\x1d\x3b\xa1\xc1\xc8\x9d\xb1\x0c\x78\x89\xc3\xd1\x80\x21\x25\x4b\x83\x6a\x63\x79\x04\x1a\xbc\x9e\x64\x00\xb3\x28\x78\xf5\xa2\x39\xbc\xf8\x73\xe8\x91\x70\x98\xe3\xb2\x5f\x6d\xb3\xac\xd8\x4a\xf1\x1c\xdc\x6c\x98\xa3\x92\x97\xe8\xff\x87\x43\x3b\xbb\x28\x14\x52\xde\xae\x6f\xaa\x45\x46\x4b\xfe\x21\xf7\xf0\x63\x18\x8e\x6c\x1d\x8f\x77\xb7\xf7\xba\x8a\xcf\x1c\xa8\x0b\x24\x61\xf0\x96\xf8\x10\x84\x63\xff\x1d\x30\x25\xbe\xd8\xf3\x53\xa4\x69\xd8\x2c\x76\x3c\x74\xdc\xae\xc1\x46\x56\x7b\xb8\x16\xda\xd8\x25\xa5\xd4\x37\xe9\xa5\xc4\xbb\x01\xa6\xc4\xef\x31\x4c\x89\xdf\xc3\x58\x95\x52\x37\xff\xbf\xab\x1d\x7f\xe7\xec\xb4\x51\xae\x0e\xac\xac\x4c\xfc\x7c\x7d\x8d\xed\x08\xc4\x72\xf5\xe1\x64\x3b\x6c\x66\xcd\xe7\x37\x2e\xb6\x39\x1b\x47\xd3\x59\xd7\xf3\x73\x2d\xc7\xf9\xe3\xdc\x3a\x4d\x4a\xa9\x5d\xf6\x3d\x00\x02\xc5\x23\x8a\xe0\x03\x00\x00")
 
 func migrations_compliance01_initSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -104,7 +612,87 @@ func migrations_compliance01_initSql() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "migrations_compliance/01_init.sql", size: 992, mode: os.FileMode(420), modTime: time.Unix(1475617538, 0)}
+	info := bindataFileInfo{name: "migrations_compliance/01_init.sql", size: 992, mode: os.FileMode(436), modTime: time.Unix(1479378373, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_compliance02_kyc_customersSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x91\xcb\x6e\xab\x30\x10\x86\xf7\x7e\x8a\x59\x06\x9d\x93\x45\x2b\xa5\x1b\x56\x14\x5c\x09\xc5\x85\x14\x61\xa9\xac\xd0\x04\x4f\x12\xab\x80\x91\x6d\x7a\x79\xfb\x2a\x69\x2e\x25\x95\xaa\x6e\x67\xfe\xf9\x46\xfa\xfe\xf9\x1c\xfe\x75\x7a\x6b\xd1\x13\xc8\x81\xc5\x05\x8f\x4a\x0e\x65\x74\x2f\x38\x2c\xab\x38\x1e\x9d\x37\x1d\x59\x98\x31\x00\xad\x60\xad\xb7\x8e\xac\xc6\xf6\x3f\x03\x40\xa5\x2c\x39\x07\xaf\x68\x9b\x1d\xda\xd9\xed\x62\x11\x40\x96\x97\x90\x49\x21\xf6\x01\x6b\x46\x4f\x57\x6b\x29\x04\x24\xfc\x21\x92\xe2\x92\xd3\xfd\xc6\x80\xa7\x77\x3f\xb9\x76\x1e\xfd\x78\xa1\xdf\xdc\x4d\xe1\x6e\x87\x96\xea\xc3\xe9\xda\x98\x96\xb0\x3f\xaf\xcf\x0f\x36\xd8\x3a\xda\x87\x1b\x4b\xe8\x49\xd5\xe8\xc1\xeb\x8e\x9c\xc7\x6e\x98\xd0\xc6\x41\xfd\x1e\x58\x15\xe9\x63\x54\x54\xb0\xe4\x15\xcc\xb4\x0a\x58\x10\x9e\x6c\xc9\x2c\x7d\x92\x1c\xd2\x2c\xe1\xcf\xf0\xf2\xd1\xd4\xcd\xd1\x5a\x7d\x32\x94\x67\x53\x99\xc7\xf9\x5f\x10\x5f\x0e\xaf\x01\x87\x69\x10\x32\xf6\xbd\xbf\xc4\xbc\xf5\x2c\x29\xf2\xd5\xcf\xfe\x42\xf6\x39\x00\xbc\xb0\xb1\xe7\xe9\x01\x00\x00")
+
+func migrations_compliance02_kyc_customersSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_compliance02_kyc_customersSql,
+		"migrations_compliance/02_kyc_customers.sql",
+	)
+}
+
+func migrations_compliance02_kyc_customersSql() (*asset, error) {
+	bytes, err := migrations_compliance02_kyc_customersSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_compliance/02_kyc_customers.sql", size: 489, mode: os.FileMode(420), modTime: time.Unix(1792057895, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_compliance03_compliance_transactionsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x93\x4f\x6b\xf2\x40\x10\xc6\xef\xfb\x29\xe6\xa8\xbc\x7a\x78\x4b\x95\x82\x27\xab\x39\x48\x6d\xb4\x21\x81\x7a\x0a\xd3\xec\xd4\x0e\xb8\xbb\x61\x77\xd2\x3f\xdf\xbe\xb4\xa5\x31\x11\x13\x7b\x7e\x7e\xbb\x0f\x3c\x3f\x66\x3c\x86\x7f\x86\xf7\x1e\x85\x20\x2b\xd5\x22\x89\xe6\x69\x04\xe9\xfc\x76\x1d\xc1\xc2\x99\xf2\xc0\x68\x0b\x4a\x3d\xda\x80\x85\xb0\xb3\x30\x50\x00\xac\xe1\x89\xf7\x81\x3c\xe3\x61\xa4\x00\x34\x7b\xfa\x49\x5f\xd1\x17\x2f\xe8\x07\x37\x43\x88\x37\x29\xc4\xd9\x7a\xfd\x05\xc8\xf1\x83\x9c\x35\x7c\x23\xd3\xeb\x36\x63\xc8\xb8\xfa\xfd\x69\x18\xc8\x6a\xf2\x75\x7c\x35\x99\xb4\x73\xef\x2a\xa1\xee\xb8\x70\x95\x15\xf2\x25\x7a\xf9\xc8\xb5\x33\xc8\xb6\x1b\x0e\x82\x52\x85\x3a\xff\x3f\x6d\xc7\xf2\x9e\x5f\x20\xd8\x3e\xbb\x4b\x4c\xe1\x09\x85\x74\x8e\x02\xc2\x86\x82\xa0\x29\x5b\x40\x55\xea\x7e\x60\x9b\xac\xee\xe7\xc9\x0e\xee\xa2\x1d\x0c\x58\x0f\xd5\x70\xf6\xeb\x2f\x8b\x57\x0f\x59\x04\xab\x78\x19\x3d\x42\x51\x6b\xcc\x9b\x1a\x4e\x94\x6c\xe2\x2e\xdf\xb5\xdc\xd1\x89\xc6\x63\x5f\x6f\xd1\xb9\xed\xbb\xdb\xce\xd0\xa3\xc6\x58\x7f\xed\x3c\xae\xdb\x53\xd5\xfc\x55\x35\x2f\x61\xe9\xde\xac\x5a\x26\x9b\x6d\xdf\x25\xcc\xd4\xe7\x00\xd4\x8a\x6d\x5f\x3d\x03\x00\x00")
+
+func migrations_compliance03_compliance_transactionsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_compliance03_compliance_transactionsSql,
+		"migrations_compliance/03_compliance_transactions.sql",
+	)
+}
+
+func migrations_compliance03_compliance_transactionsSql() (*asset, error) {
+	bytes, err := migrations_compliance03_compliance_transactionsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_compliance/03_compliance_transactions.sql", size: 829, mode: os.FileMode(420), modTime: time.Unix(1792069662, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_compliance04_sep12_customersSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x92\x5f\x4f\xf2\x30\x14\xc6\xef\xfb\x29\xce\x1d\x2c\x2f\x5c\xc0\x2b\xc4\x84\xab\xc9\x6a\x42\x9c\x03\xe7\x96\xc8\x55\x53\xda\x8a\x4d\x28\x6b\xda\x33\xff\x7c\x7b\x83\x6e\x9d\x80\x1a\xef\x9a\x3c\xbf\x3e\x39\x39\xbf\x33\x1c\xc2\x3f\xa3\xb7\x8e\xa3\x82\xd2\x92\x79\x4e\xe3\x82\x42\x11\x5f\xa5\x14\xee\x95\x1d\x8d\xe7\xb5\xc7\xca\x28\x07\x7d\x02\xa0\x25\x6c\xf4\xd6\x2b\xa7\xf9\x6e\x40\x00\x6c\xbd\xd9\x69\xc1\xb4\x84\x67\xee\xc4\x13\x77\xfd\xff\xd3\x08\xb2\x65\x01\x59\x99\xa6\x07\x82\x0b\x51\xd5\x7b\x0c\xf9\xe4\x24\x37\xca\x54\x0c\xdf\xac\x0a\xc4\x65\x07\x40\x42\xaf\xe3\x32\x2d\xa0\xd7\x6b\xd9\x80\x4d\x2f\x7e\xe4\x1e\xb5\xda\x49\x0f\xa8\x5e\x31\x20\x87\xff\x1e\x39\xd6\x3e\x34\x8c\xce\x46\xf1\x9e\x6f\xbb\x41\xc6\x93\x49\x74\x5c\xdf\x92\xc2\x29\x8e\x4a\x32\x8e\x80\xda\x28\x8f\xdc\xd8\xa3\xaa\xda\xca\xdf\x81\x55\xbe\xb8\x8d\xf3\x35\xdc\xd0\x35\xf4\xb5\x8c\x48\x34\x6b\x77\x5f\x66\x8b\xbb\x92\xc2\x22\x4b\xe8\x03\xf8\x83\x02\x26\x1a\x07\xac\xdb\xf7\x32\x3b\xd5\x13\xb2\xbf\x55\x35\x62\xd8\xc7\x52\xcf\xdb\x9a\x78\xd0\x09\xfa\x7c\x46\x33\x42\xbe\xde\x4c\x52\xbd\xec\x49\x92\x2f\x57\xdf\xdd\xcc\x8c\xbc\x0f\x00\x32\x60\x95\x63\x5f\x02\x00\x00")
+
+func migrations_compliance04_sep12_customersSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_compliance04_sep12_customersSql,
+		"migrations_compliance/04_sep12_customers.sql",
+	)
+}
+
+func migrations_compliance04_sep12_customersSql() (*asset, error) {
+	bytes, err := migrations_compliance04_sep12_customersSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_compliance/04_sep12_customers.sql", size: 607, mode: os.FileMode(420), modTime: time.Unix(1792072929, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_compliance05_auth_noncesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x5c\x8f\xc1\x6a\x84\x30\x18\x84\xef\xff\x53\xcc\xd1\xd0\xdd\x5b\xbb\x17\x4f\x69\xcd\x61\xa9\x8d\x4b\x50\xa8\xa7\x10\x6c\xd0\x50\x8c\x12\x63\x4b\xdf\xbe\x60\x91\xc6\x3d\x7f\xc3\xcc\x7c\xe7\x33\x1e\x46\xd7\x07\x13\x2d\x9a\x99\x5e\x94\xe0\xb5\x40\xcd\x9f\x4b\x01\xbe\xc6\x41\x4e\xbe\xb3\xc8\x08\x58\x5c\xef\x9d\xef\xf5\xa7\xfd\xc1\x97\x09\xdd\x60\x42\xf6\x74\x61\x90\x55\x0d\xd9\x94\xe5\x89\x00\xbf\xa5\x77\x7a\x79\x3c\xd2\x2e\x58\x13\xed\x87\x36\x11\xd1\x8d\x76\x89\x66\x9c\x0f\x81\x9b\xba\xbe\x71\xd5\xe2\x55\xb4\xc8\x92\xbd\xd3\x5f\x31\x23\x96\xef\x0f\xaf\xb2\x10\xef\x30\x6b\x1c\xf4\xc6\x74\x52\x5e\xc9\xf4\xfa\x3f\x60\x39\x51\xea\x5b\x4c\xdf\x9e\x0a\x55\xdd\xee\x7d\x73\xfa\x1d\x00\xd4\x43\x86\xe3\x17\x01\x00\x00")
+
+func migrations_compliance05_auth_noncesSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_compliance05_auth_noncesSql,
+		"migrations_compliance/05_auth_nonces.sql",
+	)
+}
+
+func migrations_compliance05_auth_noncesSql() (*asset, error) {
+	bytes, err := migrations_compliance05_auth_noncesSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_compliance/05_auth_nonces.sql", size: 279, mode: os.FileMode(420), modTime: time.Unix(1792073351, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -161,19 +749,49 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
-	"migrations_gateway/01_init.sql": migrations_gateway01_initSql,
-	"migrations_compliance/01_init.sql": migrations_compliance01_initSql,
+	"migrations_gateway/01_init.sql":                         migrations_gateway01_initSql,
+	"migrations_gateway/02_exchange_rates.sql":               migrations_gateway02_exchange_ratesSql,
+	"migrations_gateway/03_customers.sql":                    migrations_gateway03_customersSql,
+	"migrations_gateway/04_tenants.sql":                      migrations_gateway04_tenantsSql,
+	"migrations_gateway/05_from_address.sql":                 migrations_gateway05_from_addressSql,
+	"migrations_gateway/06_payment_details.sql":              migrations_gateway06_payment_detailsSql,
+	"migrations_gateway/07_payment_links.sql":                migrations_gateway07_payment_linksSql,
+	"migrations_gateway/08_payment_resolution.sql":           migrations_gateway08_payment_resolutionSql,
+	"migrations_gateway/09_sent_transaction_metadata.sql":    migrations_gateway09_sent_transaction_metadataSql,
+	"migrations_gateway/10_callback_retries.sql":             migrations_gateway10_callback_retriesSql,
+	"migrations_gateway/11_idempotency_keys.sql":             migrations_gateway11_idempotency_keysSql,
+	"migrations_gateway/12_limit_counters.sql":               migrations_gateway12_limit_countersSql,
+	"migrations_gateway/13_sep31_transactions.sql":           migrations_gateway13_sep31_transactionsSql,
+	"migrations_gateway/14_receiving_accounts.sql":           migrations_gateway14_receiving_accountsSql,
+	"migrations_gateway/15_callback_attempts.sql":            migrations_gateway15_callback_attemptsSql,
+	"migrations_gateway/16_api_keys.sql":                     migrations_gateway16_api_keysSql,
+	"migrations_gateway/17_pending_transactions.sql":         migrations_gateway17_pending_transactionsSql,
+	"migrations_gateway/18_listener_leases.sql":              migrations_gateway18_listener_leasesSql,
+	"migrations_gateway/19_callback_deliveries.sql":          migrations_gateway19_callback_deliveriesSql,
+	"migrations_gateway/20_return_memos.sql":                 migrations_gateway20_return_memosSql,
+	"migrations_gateway/21_pending_compliance.sql":           migrations_gateway21_pending_complianceSql,
+	"migrations_gateway/22_sent_transaction_payment.sql":     migrations_gateway22_sent_transaction_paymentSql,
+	"migrations_gateway/23_transfer_transactions.sql":        migrations_gateway23_transfer_transactionsSql,
+	"migrations_gateway/24_received_payment_transaction.sql": migrations_gateway24_received_payment_transactionSql,
+	"migrations_gateway/25_pending_transaction_version.sql":  migrations_gateway25_pending_transaction_versionSql,
+	"migrations_compliance/01_init.sql":                      migrations_compliance01_initSql,
+	"migrations_compliance/02_kyc_customers.sql":             migrations_compliance02_kyc_customersSql,
+	"migrations_compliance/03_compliance_transactions.sql":   migrations_compliance03_compliance_transactionsSql,
+	"migrations_compliance/04_sep12_customers.sql":           migrations_compliance04_sep12_customersSql,
+	"migrations_compliance/05_auth_nonces.sql":               migrations_compliance05_auth_noncesSql,
 }
 
 // AssetDir returns the file names below a certain
 // directory embedded in the file by go-bindata.
 // For example if you run go-bindata on data/... and data contains the
 // following hierarchy:
-//     data/
-//       foo.txt
-//       img/
-//         a.png
-//         b.png
+//
+//	data/
+//	  foo.txt
+//	  img/
+//	    a.png
+//	    b.png
+//
 // then AssetDir("data") would return []string{"foo.txt", "img"}
 // AssetDir("data/img") would return []string{"a.png", "b.png"}
 // AssetDir("foo.txt") and AssetDir("notexist") would return an error
@@ -204,12 +822,41 @@ type bintree struct {
 	Func     func() (*asset, error)
 	Children map[string]*bintree
 }
+
 var _bintree = &bintree{nil, map[string]*bintree{
 	"migrations_compliance": &bintree{nil, map[string]*bintree{
-		"01_init.sql": &bintree{migrations_compliance01_initSql, map[string]*bintree{}},
+		"01_init.sql":                    &bintree{migrations_compliance01_initSql, map[string]*bintree{}},
+		"02_kyc_customers.sql":           &bintree{migrations_compliance02_kyc_customersSql, map[string]*bintree{}},
+		"03_compliance_transactions.sql": &bintree{migrations_compliance03_compliance_transactionsSql, map[string]*bintree{}},
+		"04_sep12_customers.sql":         &bintree{migrations_compliance04_sep12_customersSql, map[string]*bintree{}},
+		"05_auth_nonces.sql":             &bintree{migrations_compliance05_auth_noncesSql, map[string]*bintree{}},
 	}},
 	"migrations_gateway": &bintree{nil, map[string]*bintree{
-		"01_init.sql": &bintree{migrations_gateway01_initSql, map[string]*bintree{}},
+		"01_init.sql":                         &bintree{migrations_gateway01_initSql, map[string]*bintree{}},
+		"02_exchange_rates.sql":               &bintree{migrations_gateway02_exchange_ratesSql, map[string]*bintree{}},
+		"03_customers.sql":                    &bintree{migrations_gateway03_customersSql, map[string]*bintree{}},
+		"04_tenants.sql":                      &bintree{migrations_gateway04_tenantsSql, map[string]*bintree{}},
+		"05_from_address.sql":                 &bintree{migrations_gateway05_from_addressSql, map[string]*bintree{}},
+		"06_payment_details.sql":              &bintree{migrations_gateway06_payment_detailsSql, map[string]*bintree{}},
+		"07_payment_links.sql":                &bintree{migrations_gateway07_payment_linksSql, map[string]*bintree{}},
+		"08_payment_resolution.sql":           &bintree{migrations_gateway08_payment_resolutionSql, map[string]*bintree{}},
+		"09_sent_transaction_metadata.sql":    &bintree{migrations_gateway09_sent_transaction_metadataSql, map[string]*bintree{}},
+		"10_callback_retries.sql":             &bintree{migrations_gateway10_callback_retriesSql, map[string]*bintree{}},
+		"11_idempotency_keys.sql":             &bintree{migrations_gateway11_idempotency_keysSql, map[string]*bintree{}},
+		"12_limit_counters.sql":               &bintree{migrations_gateway12_limit_countersSql, map[string]*bintree{}},
+		"13_sep31_transactions.sql":           &bintree{migrations_gateway13_sep31_transactionsSql, map[string]*bintree{}},
+		"14_receiving_accounts.sql":           &bintree{migrations_gateway14_receiving_accountsSql, map[string]*bintree{}},
+		"15_callback_attempts.sql":            &bintree{migrations_gateway15_callback_attemptsSql, map[string]*bintree{}},
+		"16_api_keys.sql":                     &bintree{migrations_gateway16_api_keysSql, map[string]*bintree{}},
+		"17_pending_transactions.sql":         &bintree{migrations_gateway17_pending_transactionsSql, map[string]*bintree{}},
+		"18_listener_leases.sql":              &bintree{migrations_gateway18_listener_leasesSql, map[string]*bintree{}},
+		"19_callback_deliveries.sql":          &bintree{migrations_gateway19_callback_deliveriesSql, map[string]*bintree{}},
+		"20_return_memos.sql":                 &bintree{migrations_gateway20_return_memosSql, map[string]*bintree{}},
+		"21_pending_compliance.sql":           &bintree{migrations_gateway21_pending_complianceSql, map[string]*bintree{}},
+		"22_sent_transaction_payment.sql":     &bintree{migrations_gateway22_sent_transaction_paymentSql, map[string]*bintree{}},
+		"23_transfer_transactions.sql":        &bintree{migrations_gateway23_transfer_transactionsSql, map[string]*bintree{}},
+		"24_received_payment_transaction.sql": &bintree{migrations_gateway24_received_payment_transactionSql, map[string]*bintree{}},
+		"25_pending_transaction_version.sql":  &bintree{migrations_gateway25_pending_transaction_versionSql, map[string]*bintree{}},
 	}},
 }}
 
@@ -259,4 +906,3 @@ func _filePath(dir, name string) string {
 	cannonicalName := strings.Replace(name, "\\", "/", -1)
 	return filepath.Join(append([]string{dir}, strings.Split(cannonicalName, "/")...)...)
 }
-
@@ -10,6 +10,7 @@ import (
 	// To load pq driver
 	_ "github.com/lib/pq"
 	migrate "github.com/rubenv/sql-migrate"
+	"github.com/stellar/gateway/db"
 	"github.com/stellar/gateway/db/entities"
 )
 
@@ -30,13 +31,19 @@ func (d *Driver) DB() *sqlx.DB {
 	return d.database
 }
 
-// MigrateUp migrates DB using migrate files
-func (d *Driver) MigrateUp(component string) (migrationsApplied int, err error) {
+// Migrate applies (migrate.Up) or reverts (migrate.Down) at most max migrations of component.
+// All migrations are applied or reverted when max is 0.
+func (d *Driver) Migrate(component string, direction migrate.MigrationDirection, max int) (migrationsCount int, err error) {
 	source := d.getAssetMigrationSource(component)
-	migrationsApplied, err = migrate.Exec(d.database.DB, "postgres", source, migrate.Up)
+	migrationsCount, err = migrate.ExecMax(d.database.DB, "postgres", source, direction, max)
 	return
 }
 
+// MigrationStatus returns statuses of component migrations
+func (d *Driver) MigrationStatus(component string) ([]db.MigrationStatus, error) {
+	return db.GetMigrationStatus(d.database.DB, "postgres", d.getAssetMigrationSource(component))
+}
+
 // Insert inserts the entity to a DB
 func (d *Driver) Insert(object entities.Entity) (id int64, err error) {
 	value, tableName, err := getTypeData(object)
@@ -68,7 +75,6 @@ func (d *Driver) Insert(object entities.Entity) (id int64, err error) {
 
 	query := "INSERT INTO " + tableName + " (" + strings.Join(fieldNames, ", ") + ") VALUES (" + strings.Join(fieldValues, ", ") + ") RETURNING id;"
 
-	// TODO cache prepared statement
 	stmt, err := d.database.PrepareNamed(query)
 	if err != nil {
 		return
@@ -85,6 +91,32 @@ func (d *Driver) Insert(object entities.Entity) (id int64, err error) {
 		err = stmt.Get(&id, object)
 	case *entities.ReceivedPayment:
 		err = stmt.Get(&id, object)
+	case *entities.Customer:
+		err = stmt.Get(&id, object)
+	case *entities.PaymentLink:
+		err = stmt.Get(&id, object)
+	case *entities.CallbackRetry:
+		err = stmt.Get(&id, object)
+	case *entities.PendingCompliance:
+		err = stmt.Get(&id, object)
+	case *entities.CallbackAttempt:
+		err = stmt.Get(&id, object)
+	case *entities.APIKey:
+		err = stmt.Get(&id, object)
+	case *entities.PendingTransaction:
+		err = stmt.Get(&id, object)
+	case *entities.LimitCounter:
+		err = stmt.Get(&id, object)
+	case *entities.Sep31Transaction:
+		err = stmt.Get(&id, object)
+	case *entities.TransferTransaction:
+		err = stmt.Get(&id, object)
+	case *entities.KYCCustomer:
+		err = stmt.Get(&id, object)
+	case *entities.ComplianceTransaction:
+		err = stmt.Get(&id, object)
+	case *entities.Sep12Customer:
+		err = stmt.Get(&id, object)
 	}
 
 	if err != nil {
@@ -141,6 +173,32 @@ func (d *Driver) Update(object entities.Entity) (err error) {
 		_, err = d.database.NamedExec(query, object)
 	case *entities.ReceivedPayment:
 		_, err = d.database.NamedExec(query, object)
+	case *entities.Customer:
+		_, err = d.database.NamedExec(query, object)
+	case *entities.PaymentLink:
+		_, err = d.database.NamedExec(query, object)
+	case *entities.CallbackRetry:
+		_, err = d.database.NamedExec(query, object)
+	case *entities.PendingCompliance:
+		_, err = d.database.NamedExec(query, object)
+	case *entities.CallbackAttempt:
+		_, err = d.database.NamedExec(query, object)
+	case *entities.APIKey:
+		_, err = d.database.NamedExec(query, object)
+	case *entities.PendingTransaction:
+		_, err = d.database.NamedExec(query, object)
+	case *entities.LimitCounter:
+		_, err = d.database.NamedExec(query, object)
+	case *entities.Sep31Transaction:
+		_, err = d.database.NamedExec(query, object)
+	case *entities.TransferTransaction:
+		_, err = d.database.NamedExec(query, object)
+	case *entities.KYCCustomer:
+		_, err = d.database.NamedExec(query, object)
+	case *entities.ComplianceTransaction:
+		_, err = d.database.NamedExec(query, object)
+	case *entities.Sep12Customer:
+		_, err = d.database.NamedExec(query, object)
 	}
 
 	return
@@ -197,6 +255,45 @@ func getTypeData(object interface{}) (typeValue reflect.Type, tableName string,
 	case *entities.ReceivedPayment:
 		typeValue = reflect.TypeOf(*object)
 		tableName = "ReceivedPayment"
+	case *entities.Customer:
+		typeValue = reflect.TypeOf(*object)
+		tableName = "Customer"
+	case *entities.PaymentLink:
+		typeValue = reflect.TypeOf(*object)
+		tableName = "PaymentLink"
+	case *entities.CallbackRetry:
+		typeValue = reflect.TypeOf(*object)
+		tableName = "CallbackRetry"
+	case *entities.PendingCompliance:
+		typeValue = reflect.TypeOf(*object)
+		tableName = "PendingCompliance"
+	case *entities.CallbackAttempt:
+		typeValue = reflect.TypeOf(*object)
+		tableName = "CallbackAttempt"
+	case *entities.APIKey:
+		typeValue = reflect.TypeOf(*object)
+		tableName = "APIKey"
+	case *entities.PendingTransaction:
+		typeValue = reflect.TypeOf(*object)
+		tableName = "PendingTransaction"
+	case *entities.LimitCounter:
+		typeValue = reflect.TypeOf(*object)
+		tableName = "LimitCounter"
+	case *entities.Sep31Transaction:
+		typeValue = reflect.TypeOf(*object)
+		tableName = "Sep31Transaction"
+	case *entities.TransferTransaction:
+		typeValue = reflect.TypeOf(*object)
+		tableName = "TransferTransaction"
+	case *entities.KYCCustomer:
+		typeValue = reflect.TypeOf(*object)
+		tableName = "KYCCustomer"
+	case *entities.ComplianceTransaction:
+		typeValue = reflect.TypeOf(*object)
+		tableName = "ComplianceTransaction"
+	case *entities.Sep12Customer:
+		typeValue = reflect.TypeOf(*object)
+		tableName = "Sep12Customer"
 	default:
 		return typeValue, tableName, fmt.Errorf("Unknown entity type: %T", object)
 	}
@@ -0,0 +1,17 @@
+-- +migrate Up
+CREATE TABLE KYCCustomer (
+  id bigserial,
+  address varchar(255) NOT NULL,
+  route varchar(255) NULL DEFAULT NULL,
+  info text NOT NULL,
+  status varchar(16) NOT NULL,
+  share_info boolean NOT NULL DEFAULT false,
+  created_at timestamp NOT NULL,
+  updated_at timestamp NOT NULL,
+  PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX kyc_customer_address ON KYCCustomer (address);
+CREATE UNIQUE INDEX kyc_customer_route ON KYCCustomer (route);
+
+-- +migrate Down
+DROP TABLE KYCCustomer;
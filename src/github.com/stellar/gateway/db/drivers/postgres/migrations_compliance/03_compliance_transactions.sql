@@ -0,0 +1,22 @@
+-- +migrate Up
+CREATE TABLE ComplianceTransaction (
+  id bigserial,
+  direction varchar(8) NOT NULL,
+  transaction_id char(64) NOT NULL,
+  memo varchar(64) NOT NULL,
+  sender varchar(255) NOT NULL,
+  route varchar(255) NOT NULL,
+  counterparty_domain varchar(255) NOT NULL,
+  status varchar(16) NOT NULL,
+  tx_status varchar(16) NOT NULL,
+  info_status varchar(16) NOT NULL,
+  created_at timestamp NOT NULL,
+  updated_at timestamp NOT NULL,
+  PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX compliance_transaction_transaction_id ON ComplianceTransaction (direction, transaction_id);
+CREATE INDEX compliance_transaction_counterparty_domain ON ComplianceTransaction (counterparty_domain, created_at);
+CREATE INDEX compliance_transaction_created_at ON ComplianceTransaction (created_at);
+
+-- +migrate Down
+DROP TABLE ComplianceTransaction;
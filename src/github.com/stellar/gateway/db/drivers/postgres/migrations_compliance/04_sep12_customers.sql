@@ -0,0 +1,19 @@
+-- +migrate Up
+CREATE TABLE Sep12Customer (
+  id bigserial,
+  public_id varchar(36) NOT NULL,
+  account varchar(56) NOT NULL,
+  memo_type varchar(8) NOT NULL DEFAULT '',
+  memo varchar(64) NOT NULL DEFAULT '',
+  fields text NOT NULL,
+  status varchar(16) NOT NULL,
+  message varchar(255) NULL DEFAULT NULL,
+  created_at timestamp NOT NULL,
+  updated_at timestamp NOT NULL,
+  PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX sep12_customer_public_id ON Sep12Customer (public_id);
+CREATE UNIQUE INDEX sep12_customer_account_memo ON Sep12Customer (account, memo_type, memo);
+
+-- +migrate Down
+DROP TABLE Sep12Customer;
@@ -0,0 +1,14 @@
+-- +migrate Up
+CREATE TABLE Customer (
+  id bigserial,
+  memo_type varchar(4) NOT NULL,
+  memo varchar(64) NOT NULL,
+  customer_id varchar(255) NOT NULL,
+  callback_url varchar(255) NOT NULL DEFAULT '',
+  created_at timestamp NOT NULL,
+  PRIMARY KEY (id),
+  UNIQUE (memo_type, memo)
+);
+
+-- +migrate Down
+DROP TABLE Customer;
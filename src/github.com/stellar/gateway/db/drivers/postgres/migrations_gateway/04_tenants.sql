@@ -0,0 +1,21 @@
+-- +migrate Up
+ALTER TABLE ReceivedPayment ADD COLUMN tenant varchar(64) NOT NULL DEFAULT '';
+ALTER TABLE ReceivedPayment DROP CONSTRAINT receivedpayment_operation_id_key;
+ALTER TABLE ReceivedPayment ADD CONSTRAINT receivedpayment_tenant_operation_id_key UNIQUE (tenant, operation_id);
+
+ALTER TABLE SentTransaction ADD COLUMN tenant varchar(64) NOT NULL DEFAULT '';
+
+ALTER TABLE Customer ADD COLUMN tenant varchar(64) NOT NULL DEFAULT '';
+ALTER TABLE Customer DROP CONSTRAINT customer_memo_type_memo_key;
+ALTER TABLE Customer ADD CONSTRAINT customer_tenant_memo_type_memo_key UNIQUE (tenant, memo_type, memo);
+
+-- +migrate Down
+ALTER TABLE Customer DROP CONSTRAINT customer_tenant_memo_type_memo_key;
+ALTER TABLE Customer ADD CONSTRAINT customer_memo_type_memo_key UNIQUE (memo_type, memo);
+ALTER TABLE Customer DROP COLUMN tenant;
+
+ALTER TABLE SentTransaction DROP COLUMN tenant;
+
+ALTER TABLE ReceivedPayment DROP CONSTRAINT receivedpayment_tenant_operation_id_key;
+ALTER TABLE ReceivedPayment ADD CONSTRAINT receivedpayment_operation_id_key UNIQUE (operation_id);
+ALTER TABLE ReceivedPayment DROP COLUMN tenant;
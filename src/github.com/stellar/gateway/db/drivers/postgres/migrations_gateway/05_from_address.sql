@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE ReceivedPayment ADD COLUMN from_address varchar(255) DEFAULT NULL;
+
+-- +migrate Down
+ALTER TABLE ReceivedPayment DROP COLUMN from_address;
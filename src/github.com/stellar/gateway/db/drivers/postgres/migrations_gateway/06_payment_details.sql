@@ -0,0 +1,17 @@
+-- +migrate Up
+ALTER TABLE ReceivedPayment ADD COLUMN from_account varchar(56) NOT NULL DEFAULT '';
+ALTER TABLE ReceivedPayment ADD COLUMN amount varchar(64) NOT NULL DEFAULT '';
+ALTER TABLE ReceivedPayment ADD COLUMN asset_code varchar(12) NOT NULL DEFAULT '';
+ALTER TABLE ReceivedPayment ADD COLUMN asset_issuer varchar(56) NOT NULL DEFAULT '';
+ALTER TABLE ReceivedPayment ADD COLUMN memo_type varchar(4) NOT NULL DEFAULT '';
+ALTER TABLE ReceivedPayment ADD COLUMN memo varchar(255) NOT NULL DEFAULT '';
+ALTER TABLE ReceivedPayment ADD COLUMN refund_transaction_id varchar(64) DEFAULT NULL;
+
+-- +migrate Down
+ALTER TABLE ReceivedPayment DROP COLUMN from_account;
+ALTER TABLE ReceivedPayment DROP COLUMN amount;
+ALTER TABLE ReceivedPayment DROP COLUMN asset_code;
+ALTER TABLE ReceivedPayment DROP COLUMN asset_issuer;
+ALTER TABLE ReceivedPayment DROP COLUMN memo_type;
+ALTER TABLE ReceivedPayment DROP COLUMN memo;
+ALTER TABLE ReceivedPayment DROP COLUMN refund_transaction_id;
@@ -0,0 +1,14 @@
+-- +migrate Up
+CREATE TABLE PaymentLink (
+  id bigserial,
+  received_payment_id bigint NOT NULL,
+  transaction_id varchar(64) NOT NULL,
+  created_at timestamp NOT NULL,
+  tenant varchar(64) NOT NULL DEFAULT '',
+  PRIMARY KEY (id)
+);
+CREATE INDEX payment_link_received_payment_id ON PaymentLink (received_payment_id);
+CREATE INDEX payment_link_transaction_id ON PaymentLink (tenant, transaction_id);
+
+-- +migrate Down
+DROP TABLE PaymentLink;
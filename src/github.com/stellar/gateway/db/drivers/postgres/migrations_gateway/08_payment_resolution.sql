@@ -0,0 +1,9 @@
+-- +migrate Up
+ALTER TABLE ReceivedPayment ADD COLUMN resolution_reason text DEFAULT NULL;
+ALTER TABLE ReceivedPayment ADD COLUMN resolved_by varchar(255) DEFAULT NULL;
+ALTER TABLE ReceivedPayment ADD COLUMN resolved_at timestamp DEFAULT NULL;
+
+-- +migrate Down
+ALTER TABLE ReceivedPayment DROP COLUMN resolution_reason;
+ALTER TABLE ReceivedPayment DROP COLUMN resolved_by;
+ALTER TABLE ReceivedPayment DROP COLUMN resolved_at;
@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE SentTransaction ADD COLUMN metadata text DEFAULT NULL;
+
+-- +migrate Down
+ALTER TABLE SentTransaction DROP COLUMN metadata;
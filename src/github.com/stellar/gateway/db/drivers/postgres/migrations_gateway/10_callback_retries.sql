@@ -0,0 +1,16 @@
+-- +migrate Up
+CREATE TABLE CallbackRetry (
+  id bigserial,
+  received_payment_id bigint NOT NULL,
+  attempts integer NOT NULL,
+  next_attempt_at timestamp NOT NULL,
+  last_error text NOT NULL,
+  created_at timestamp NOT NULL,
+  tenant varchar(64) NOT NULL DEFAULT '',
+  PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX callback_retry_received_payment_id ON CallbackRetry (received_payment_id);
+CREATE INDEX callback_retry_next_attempt_at ON CallbackRetry (tenant, next_attempt_at);
+
+-- +migrate Down
+DROP TABLE CallbackRetry;
@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE SentTransaction ADD COLUMN idempotency_key varchar(128) DEFAULT NULL;
+ALTER TABLE SentTransaction ADD CONSTRAINT senttransaction_tenant_idempotency_key_key UNIQUE (tenant, idempotency_key);
+
+-- +migrate Down
+ALTER TABLE SentTransaction DROP CONSTRAINT senttransaction_tenant_idempotency_key_key;
+ALTER TABLE SentTransaction DROP COLUMN idempotency_key;
@@ -0,0 +1,13 @@
+-- +migrate Up
+CREATE TABLE LimitCounter (
+  id bigserial,
+  counter_key varchar(255) NOT NULL,
+  window_start timestamp NOT NULL,
+  amount bigint NOT NULL,
+  tenant varchar(64) NOT NULL DEFAULT '',
+  PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX limit_counter_key ON LimitCounter (tenant, counter_key, window_start);
+
+-- +migrate Down
+DROP TABLE LimitCounter;
@@ -0,0 +1,29 @@
+-- +migrate Up
+CREATE TABLE Sep31Transaction (
+  id bigserial,
+  public_id varchar(36) NOT NULL,
+  status varchar(32) NOT NULL,
+  amount_in varchar(64) NOT NULL,
+  amount_fee varchar(64) NOT NULL,
+  amount_out varchar(64) NOT NULL,
+  asset_code varchar(12) NOT NULL,
+  asset_issuer varchar(56) NOT NULL,
+  sender_id varchar(255) NOT NULL,
+  receiver_id varchar(255) NOT NULL,
+  fields text NOT NULL,
+  client_account varchar(56) NOT NULL,
+  memo_type varchar(10) NOT NULL,
+  memo varchar(64) NOT NULL,
+  stellar_transaction_id varchar(64) NULL DEFAULT NULL,
+  received_payment_id varchar(255) NULL DEFAULT NULL,
+  status_message text NULL DEFAULT NULL,
+  started_at timestamp NOT NULL,
+  completed_at timestamp NULL DEFAULT NULL,
+  tenant varchar(64) NOT NULL DEFAULT '',
+  PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX sep31_transaction_public_id ON Sep31Transaction (tenant, public_id);
+CREATE UNIQUE INDEX sep31_transaction_memo ON Sep31Transaction (tenant, memo_type, memo);
+
+-- +migrate Down
+DROP TABLE Sep31Transaction;
@@ -0,0 +1,20 @@
+-- +migrate Up
+CREATE TABLE CallbackAttempt (
+  id bigserial,
+  operation_id varchar(255) NOT NULL,
+  attempt integer NOT NULL,
+  url varchar(1024) NOT NULL,
+  success boolean NOT NULL,
+  status_code integer DEFAULT NULL,
+  latency_ms bigint NOT NULL,
+  response text DEFAULT NULL,
+  error text DEFAULT NULL,
+  created_at timestamp NOT NULL,
+  tenant varchar(64) NOT NULL DEFAULT '',
+  PRIMARY KEY (id)
+);
+CREATE INDEX callback_attempt_operation_id ON CallbackAttempt (tenant, operation_id);
+CREATE INDEX callback_attempt_created_at ON CallbackAttempt (tenant, created_at);
+
+-- +migrate Down
+DROP TABLE CallbackAttempt;
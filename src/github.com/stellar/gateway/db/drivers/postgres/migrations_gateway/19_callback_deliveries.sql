@@ -0,0 +1,12 @@
+-- +migrate Up
+CREATE TABLE CallbackDelivery (
+  delivery_id varchar(255) NOT NULL,
+  tenant varchar(64) NOT NULL DEFAULT '',
+  operation_id varchar(255) NOT NULL,
+  url varchar(1024) NOT NULL,
+  delivered_at timestamp NOT NULL,
+  PRIMARY KEY (tenant, delivery_id)
+);
+
+-- +migrate Down
+DROP TABLE CallbackDelivery;
@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE ReceivedPayment ALTER COLUMN memo_type TYPE varchar(10);
+
+-- +migrate Down
+-- Return memos are hashes, varchar(4) cannot store `return` type
+UPDATE ReceivedPayment SET memo_type = 'hash' WHERE memo_type = 'return';
+ALTER TABLE ReceivedPayment ALTER COLUMN memo_type TYPE varchar(4);
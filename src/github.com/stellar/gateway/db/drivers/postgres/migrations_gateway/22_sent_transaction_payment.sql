@@ -0,0 +1,9 @@
+-- +migrate Up
+ALTER TABLE SentTransaction ADD COLUMN destination varchar(255) DEFAULT NULL;
+ALTER TABLE SentTransaction ADD COLUMN amount varchar(32) DEFAULT NULL;
+ALTER TABLE SentTransaction ADD COLUMN asset varchar(70) DEFAULT NULL;
+
+-- +migrate Down
+ALTER TABLE SentTransaction DROP COLUMN destination;
+ALTER TABLE SentTransaction DROP COLUMN amount;
+ALTER TABLE SentTransaction DROP COLUMN asset;
@@ -0,0 +1,29 @@
+-- +migrate Up
+CREATE TABLE TransferTransaction (
+  id bigserial,
+  public_id varchar(36) NOT NULL,
+  kind varchar(10) NOT NULL,
+  status varchar(32) NOT NULL,
+  asset_code varchar(12) NOT NULL,
+  asset_issuer varchar(56) NOT NULL,
+  amount_in varchar(64) NULL DEFAULT NULL,
+  amount_fee varchar(64) NULL DEFAULT NULL,
+  amount_out varchar(64) NULL DEFAULT NULL,
+  account varchar(56) NOT NULL,
+  type varchar(64) NOT NULL DEFAULT '',
+  fields text NOT NULL,
+  memo_type varchar(10) NULL DEFAULT NULL,
+  memo varchar(64) NULL DEFAULT NULL,
+  stellar_transaction_id varchar(64) NULL DEFAULT NULL,
+  received_payment_id varchar(255) NULL DEFAULT NULL,
+  status_message text NULL DEFAULT NULL,
+  started_at timestamp NOT NULL,
+  completed_at timestamp NULL DEFAULT NULL,
+  tenant varchar(64) NOT NULL DEFAULT '',
+  PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX transfer_transaction_public_id ON TransferTransaction (tenant, public_id);
+CREATE INDEX transfer_transaction_memo ON TransferTransaction (tenant, memo_type, memo);
+
+-- +migrate Down
+DROP TABLE TransferTransaction;
@@ -0,0 +1,13 @@
+-- +migrate Up
+ALTER TABLE ReceivedPayment ADD COLUMN transaction_hash varchar(64) DEFAULT NULL;
+ALTER TABLE ReceivedPayment ADD COLUMN ledger bigint DEFAULT NULL;
+ALTER TABLE ReceivedPayment ADD COLUMN ledger_close_time timestamp DEFAULT NULL;
+ALTER TABLE ReceivedPayment ADD COLUMN fee_charged bigint DEFAULT NULL;
+ALTER TABLE ReceivedPayment ADD COLUMN transaction_source_account varchar(69) DEFAULT NULL;
+
+-- +migrate Down
+ALTER TABLE ReceivedPayment DROP COLUMN transaction_hash;
+ALTER TABLE ReceivedPayment DROP COLUMN ledger;
+ALTER TABLE ReceivedPayment DROP COLUMN ledger_close_time;
+ALTER TABLE ReceivedPayment DROP COLUMN fee_charged;
+ALTER TABLE ReceivedPayment DROP COLUMN transaction_source_account;
@@ -0,0 +1,586 @@
+// Code generated by go-bindata.
+// sources:
+// migrations_gateway/01_init.sql
+// migrations_gateway/02_receiving_accounts.sql
+// migrations_gateway/03_callback_attempts.sql
+// migrations_gateway/04_api_keys.sql
+// migrations_gateway/05_pending_transactions.sql
+// migrations_gateway/06_listener_leases.sql
+// migrations_gateway/07_callback_deliveries.sql
+// migrations_gateway/08_pending_compliance.sql
+// migrations_gateway/09_sent_transaction_payment.sql
+// migrations_gateway/10_transfer_transactions.sql
+// migrations_gateway/11_received_payment_transaction.sql
+// migrations_gateway/12_pending_transaction_version.sql
+// migrations_compliance/01_init.sql
+// migrations_compliance/02_compliance_transactions.sql
+// migrations_compliance/03_sep12_customers.sql
+// migrations_compliance/04_auth_nonces.sql
+// DO NOT EDIT!
+
+package sqlite
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+func bindataRead(data []byte, name string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewBuffer(data))
+	if err != nil {
+		return nil, fmt.Errorf("Read %q: %v", name, err)
+	}
+
+	var buf bytes.Buffer
+	_, err = io.Copy(&buf, gz)
+	clErr := gz.Close()
+
+	if err != nil {
+		return nil, fmt.Errorf("Read %q: %v", name, err)
+	}
+	if clErr != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+type asset struct {
+	bytes []byte
+	info  os.FileInfo
+}
+
+type bindataFileInfo struct {
+	name    string
+	size    int64
+	mode    os.FileMode
+	modTime time.Time
+}
+
+func (fi bindataFileInfo) Name() string {
+	return fi.name
+}
+func (fi bindataFileInfo) Size() int64 {
+	return fi.size
+}
+func (fi bindataFileInfo) Mode() os.FileMode {
+	return fi.mode
+}
+func (fi bindataFileInfo) ModTime() time.Time {
+	return fi.modTime
+}
+func (fi bindataFileInfo) IsDir() bool {
+	return false
+}
+func (fi bindataFileInfo) Sys() interface{} {
+	return nil
+}
+
+var _migrations_gateway01_initSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x57\x5f\x6f\xe2\x38\x10\x7f\xe7\x53\xf8\x6d\x17\x5d\x57\x5a\xda\x6b\x75\x52\x9f\xb8\x36\x27\x55\xc7\x26\x3d\x16\xa4\xdb\x27\xcb\xd8\x53\xd6\x22\xb1\x23\x7b\x42\xcb\xb7\x3f\x05\x08\xc4\x7f\x92\x86\x5b\xf5\xad\xaa\x7f\x0c\xe3\xdf\x9f\xf1\xf0\xe5\x0b\xf9\xad\x90\x6b\xc3\x10\xc8\xb2\x1c\x3d\xcc\x93\xe9\x22\x21\x8b\xe9\x9f\xb3\x84\xcc\x81\x83\xdc\x82\x78\x66\xbb\x02\x14\x92\xcf\x23\x42\xa4\x20\x52\x21\xac\xc1\x90\xe7\xf9\xd3\xb7\xe9\xfc\x07\xf9\x3b\xf9\x41\xa6\xcb\x45\xf6\x94\x3e\xcc\x93\x6f\x49\xba\xb8\x1a\x11\xa2\x4b\x30\x0c\xa5\x56\x54\x0a\xb2\x65\x86\xff\x64\xe6\xf3\xf5\xed\xed\x98\xa4\xd9\x82\xa4\xcb\xd9\xac\x46\x95\x46\x73\xb0\x16\x04\x65\x48\x50\x16\x60\x91\x15\xa5\x0b\x61\x6b\xa9\xd6\x14\xf5\x06\x54\x77\x21\x8b\x0c\x2b\xdb\x7d\x0e\x6f\xfc\x27\x53\x6b\xa0\xfb\x8b\x36\xb0\xbb\xdf\xc7\xe4\x31\xf9\x6b\xba\x9c\x9d\x91\x5c\xab\x2d\x18\xac\x5b\x2a\x74\xa5\x70\x20\x98\x57\xc6\x80\xe2\xbb\x13\x7c\x72\x1d\xc2\x11\x14\xf3\x2a\x36\x4d\x9e\xb0\x9f\x3e\xd5\xc8\x17\xa3\x0b\xca\x84\x30\x60\xbd\x5b\xf9\x35\x0f\x48\xce\x9d\x5e\x6f\xef\x3a\x2b\x47\x6e\xd5\x85\xb4\x16\x90\x72\x2d\xce\x84\x4d\xae\xdf\x41\x4b\x6b\x2b\x30\x43\xfa\x28\xa0\xd0\x14\x77\xe5\xb9\x78\x77\x27\x35\x36\xae\xad\x07\x34\xf0\x52\x29\x41\xd1\x30\x65\x19\xf7\xed\x17\x53\xd0\x80\xd5\x79\xb5\x47\x1a\x60\x56\x2b\x82\xf0\x86\x71\xd8\x16\x04\x5d\xed\xfa\xe5\x38\x01\x1d\x43\xb7\x51\xa3\xf1\x7d\x13\xb3\x65\xfa\xf4\xcf\x32\x21\x4f\xe9\x63\xf2\x2f\x31\xc7\xb4\xd1\xf2\x10\x37\xea\x44\x28\x4b\xc3\x38\x1e\xec\x74\xe5\x64\x6d\x7c\x3f\x72\x43\xfc\x1d\x14\x2e\xce\x7c\x5c\x14\xe2\x1e\x1e\x7b\xc2\x37\xf9\xea\x1d\xeb\xca\x70\x88\x7a\x62\x7f\x5c\xad\x0a\x89\xd8\x37\x03\x6c\xc5\x39\x80\xe8\x63\xb5\xae\x94\x83\xa8\xaf\xb4\x92\x6b\xa9\x42\x09\x41\x6d\x21\xd7\x25\xd0\x37\x61\x0e\x22\xb7\xbf\xc2\x80\xad\x72\xdc\x9f\xf5\xea\x3b\x3c\xc2\x05\x20\x13\x0c\x59\xdc\x50\x52\x40\x51\x6a\xac\x47\x06\xdd\x40\x7b\x6c\xfc\x31\x1e\x64\x17\x0b\x0a\x3d\xa3\xbb\x15\xb3\x34\xd4\xbe\x71\x8c\x87\x0d\x4c\xf3\x50\x59\xd4\x05\x98\x8b\xdc\xd2\x9b\xe8\x20\xc6\xbe\x89\xf8\xf1\x2b\xdb\x46\x0b\xc6\x38\x67\x79\xbe\x62\x7c\x43\x2b\x93\xc7\x51\x27\xee\x0e\x22\x70\x03\xac\xd7\x5a\xc3\xf4\xec\x12\xe1\xd4\xf4\xfe\x6a\x59\xda\x22\xae\xa1\xfa\xc4\xca\xe1\xcf\x80\xea\x63\x9a\x67\x52\x6d\x2e\x62\x3b\x18\x17\x52\x34\xce\x77\xee\xe7\x38\x24\x7a\xcf\x8f\xe1\xe9\x40\x50\xd3\x5c\x2e\xd5\x86\xc6\x5a\xce\x52\x97\x81\x08\xa6\xb7\xa6\x37\xa2\xfc\x72\x8d\x0a\x2e\x2c\xf4\xfb\xd1\x57\x73\x40\xb3\xfb\x08\x19\x18\x22\x14\x25\xda\x53\xd1\xf6\xa1\x82\x37\xa4\x47\x44\xb7\x02\x39\xb3\x48\xc1\x18\x1d\x99\x5e\x1f\x6d\xf3\x26\x76\xa6\xe6\xa7\x4b\x47\x8f\xc4\x01\x4a\x7a\x75\x7d\x1e\xc2\x9a\x8d\x9c\x1e\x32\xd0\x73\x26\x0b\x89\x0f\xf5\xa2\x73\xe1\x0c\xdb\xaf\x51\x60\x9c\x91\x1c\x4c\xa1\x57\xa9\x84\x7e\xa5\x16\x99\xe9\xe2\xfb\xb8\x65\xc5\x02\xf9\x4b\x4a\xe4\xf5\xc5\x68\xbb\xcb\x2c\xf5\x6e\xdb\x70\xd4\x02\x5d\x39\x2d\x07\x6c\x7d\x87\xf2\x66\xf2\x7f\x77\x84\xb2\x5a\xe5\x92\xb7\x67\xcb\xcd\x5d\xef\x7a\x70\x73\x3d\x8e\x50\x45\xa5\x8a\x72\xd2\x42\xbc\x00\xbc\x07\xd1\x55\x9c\xd9\x01\xeb\xec\xa0\x1d\xb6\x06\x59\x50\xe2\x9d\x67\xea\x68\xfd\x7e\xd0\x8b\x84\x5c\xd8\x48\x96\x73\x59\x67\xaa\x6f\xa5\x8f\xbf\xb6\x93\xaf\x17\x3c\xb7\x16\x21\xcf\x99\xb9\x6c\x55\x0e\x63\xdf\xbb\x2b\x1d\xf6\x42\x5a\x80\xb5\x6c\x0d\xf1\x3d\x68\x1f\xa2\xbe\xc9\xc5\x75\x51\xe6\x10\x0c\x37\xbf\xce\xaf\xc5\xca\xd6\x09\x70\xb8\x38\xdb\x3a\x4b\x23\x01\x69\x42\x76\x82\x0d\x2e\xdc\x6c\x0a\xdd\x35\x23\x1b\x43\xfb\x57\xfa\xa3\x7e\x55\xa3\xc7\x79\xf6\xdc\x91\xde\xfb\xf6\x61\x7b\x34\x38\x07\xce\x60\x75\x4e\x5a\xef\xa7\xfb\x89\xe3\x6a\xe3\xfc\xd3\xdb\x30\x9d\x33\xef\xf7\xca\xfd\xe8\xbf\x01\x00\xae\xb8\x01\xa4\x6c\x10\x00\x00")
+
+func migrations_gateway01_initSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway01_initSql,
+		"migrations_gateway/01_init.sql",
+	)
+}
+
+func migrations_gateway01_initSql() (*asset, error) {
+	bytes, err := migrations_gateway01_initSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/01_init.sql", size: 4204, mode: os.FileMode(420), modTime: time.Unix(1792058902, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway02_receiving_accountsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x94\x41\x6f\xea\x3a\x10\x85\xf7\xf9\x15\xb3\xa3\xe8\xb9\x8b\x56\xaf\xdd\xb0\xca\x03\x57\x42\x2f\x24\x6d\x9a\x48\xb7\xab\xc8\xb5\xa7\x60\x5d\x62\x47\xf6\x84\x96\x7f\x7f\x15\x20\x40\x42\xe8\xed\x0e\x92\x4f\x93\x99\x73\xce\xcc\xed\x2d\xfc\x53\xea\xa5\x13\x84\x90\x57\x41\x18\x65\x3c\x85\x2c\xfc\x2f\xe2\x90\xa2\x44\xbd\x41\xf5\x2c\xb6\x25\x1a\x82\x70\x36\x83\x69\x12\xe5\x8b\x18\xdc\xee\x95\x36\xcb\x42\x48\x69\x6b\x43\xb0\x11\x4e\xae\x84\xbb\x79\x78\x1c\x43\x9c\x64\x10\xe7\x51\x04\x33\xfe\x14\xe6\x51\x06\xa3\xd1\x24\x08\xce\xbf\x34\xb3\x9f\xa6\x79\xf0\xfa\x12\x69\x42\x90\xc2\x18\x4b\xa0\x9c\xad\x40\xda\x75\x5d\x1a\x0f\xde\x02\xad\x10\x48\xbc\xaf\x11\xb4\x07\x87\xef\xb5\x5e\x13\x7c\x6a\x5a\xd9\x9a\x2e\x5b\x08\xa6\x29\x0f\x33\x3e\xdc\x7c\x61\xd7\x0a\x6e\x02\x00\xad\x40\x1b\xc2\x25\x3a\x78\x4e\xe7\x8b\x30\x7d\x83\xff\xf9\x1b\x84\x79\x96\xcc\xe3\x69\xca\x17\x3c\xce\x58\x00\x60\x2b\x74\x82\xb4\x35\x85\x56\xc7\xe1\xee\x1f\x1e\x4e\xd3\x35\x54\xe5\xac\x44\xef\x51\x15\x82\x80\x74\x89\x9e\x44\x59\x75\x11\xb1\x6c\x74\x22\xfb\x1b\xcd\xf5\x42\x9e\x04\xd5\xfe\xfa\x7b\xfc\x92\x2b\x61\x96\x58\xec\x8c\x6a\xb1\xc7\x7f\xc7\x47\x8d\x5b\x52\x5a\xb3\x41\x47\x4d\x4b\x65\xc7\x99\xef\x61\x59\x3b\x87\x46\x6e\x8f\xf8\xdd\xfd\x25\x4e\x68\x44\xaf\x62\xdb\xe4\x91\x1d\x8d\x1a\xf2\xc3\xd9\xb2\x10\x4a\x39\xf4\xbd\xa9\xfa\x35\xf7\xe4\xcf\x52\xd4\xf0\x03\x53\x5d\x23\xbd\x47\x2a\xa4\x55\x27\xc1\xee\xee\xff\x42\x6b\xef\x6b\x74\x3f\xe9\xa3\xc4\xd2\x16\xb4\xad\x4e\xc5\xaf\x77\xd2\xb0\xc3\xde\xf6\x40\x87\x1f\xb5\x51\x05\x39\x61\xbc\x90\xfd\xf8\x0d\x39\xe8\xd0\xdb\x75\xbd\x23\x1d\x0a\x6f\x0d\x10\x7e\xd1\x30\xb6\x41\x55\xbc\x6f\xbf\xb7\xe3\x08\x76\x02\x7d\x4e\x05\xe3\x49\x30\x8f\x5f\x79\x9a\xc1\x3c\xce\x92\xc1\x4d\x7b\xe5\x11\x9f\x66\xa0\x15\xeb\x2c\x12\xeb\x2c\x0c\xeb\xec\x06\x3b\xec\xc0\x45\xda\xd9\x45\xa4\xd9\x40\x6e\xd9\x21\x9c\xac\x13\xbd\xf6\xdf\x3e\x5e\xec\x10\x9e\x6e\x38\x58\xc7\x7a\x76\x32\x76\xff\x93\x0d\x9b\xc2\x2e\x95\x3f\x3c\xda\xab\xdc\xd7\xf2\x29\x4d\x16\x7d\xa9\x26\xc1\x2c\x4d\x9e\x87\x0f\xd6\xe4\xbb\x53\xbc\xd3\x38\xe5\x71\xb8\xe0\x70\xe9\xc0\xa4\xbd\x84\x79\x3c\x7f\xc9\x39\xcc\xe3\x19\xff\x75\xb8\x97\xa8\x8a\xaa\xad\x71\x7e\xe1\x92\xb8\x5f\x05\x6e\x5a\x41\xcf\xc1\xf1\x24\xf8\x33\x00\xcd\x5e\x9d\xd5\x32\x06\x00\x00")
+
+func migrations_gateway02_receiving_accountsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway02_receiving_accountsSql,
+		"migrations_gateway/02_receiving_accounts.sql",
+	)
+}
+
+func migrations_gateway02_receiving_accountsSql() (*asset, error) {
+	bytes, err := migrations_gateway02_receiving_accountsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/02_receiving_accounts.sql", size: 1586, mode: os.FileMode(420), modTime: time.Unix(1792059801, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway03_callback_attemptsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\x92\x4f\x6b\xc2\x40\x10\xc5\xef\xfb\x29\xe6\xa6\x52\x85\x56\x6a\x2f\x9e\x52\xb3\x05\x69\x4c\x24\x24\x50\x4f\x61\xdc\x0c\x76\x69\xb2\x1b\x76\xc7\xfe\xf9\xf6\xa5\x34\xc6\x04\xa5\x3d\xbf\xf7\x7e\xbb\x33\x6f\x66\x33\xb8\xa9\xf5\xc1\x21\x13\xe4\x8d\x58\xa5\x32\xc8\x24\x64\xc1\x63\x24\x61\x85\x55\xb5\x47\xf5\x16\x30\x53\xdd\x30\x8c\x05\x80\x2e\x41\x1b\xa6\x03\x39\xd8\xa6\xeb\x4d\x90\xee\xe0\x59\xee\x20\xc8\xb3\x64\x1d\xaf\x52\xb9\x91\x71\x36\x15\x00\xb6\x21\x87\xac\xad\x29\x74\x09\xef\xe8\xd4\x2b\xba\xf1\x7c\xb1\x98\x40\x9c\x64\x10\xe7\x51\xf4\xe3\xc2\x96\x7c\x42\xf6\xb5\xa3\xab\xba\xe0\xdd\xed\xfc\x7e\x98\xf4\x47\xa5\xc8\x7b\xd8\x5b\x5b\x11\x9a\xa1\xc6\xc8\x47\x5f\x28\x5b\x52\xf7\xd9\x50\x3e\x05\x79\x74\xf6\x54\xc8\x64\xd4\x57\x51\x7b\xd8\xeb\x83\x36\x3c\x20\x38\xf2\x8d\x35\x9e\x80\xe9\x93\x2f\xb2\xe4\x9c\x75\xd7\x25\xe5\x08\x99\xca\x02\x19\x58\xd7\xe4\x19\xeb\x66\x40\x66\x32\x68\xb8\x1b\xec\xa1\x37\x56\x07\x1b\x8d\xc4\x64\x79\xaa\x62\x1d\x87\xf2\x05\x54\x5b\x45\xd1\x6e\xac\x18\xec\x37\x89\x2f\xbb\xfa\x7d\x68\x3a\x28\xe2\x5f\x6a\xef\xfb\x7f\x31\xcf\xb6\xc9\x52\x88\xfe\x09\x85\xf6\xc3\x88\x30\x4d\xb6\xd7\x4f\x68\x29\xbe\x07\x00\xd7\xa8\x0d\xb2\x70\x02\x00\x00")
+
+func migrations_gateway03_callback_attemptsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway03_callback_attemptsSql,
+		"migrations_gateway/03_callback_attempts.sql",
+	)
+}
+
+func migrations_gateway03_callback_attemptsSql() (*asset, error) {
+	bytes, err := migrations_gateway03_callback_attemptsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/03_callback_attempts.sql", size: 624, mode: os.FileMode(420), modTime: time.Unix(1792061082, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway04_api_keysSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x74\x90\x31\x6b\xc3\x30\x10\x85\x77\xfd\x8a\xb7\x25\xa6\xcd\x52\x9a\x2e\x9e\xd4\x58\x05\x13\x47\x76\x8d\x04\xcd\x64\x8e\xe4\x88\x45\xb1\x62\x24\xd1\x92\x7f\x5f\x5c\x88\x49\x86\xac\xf7\xbd\xe3\xde\x7d\xab\x15\x9e\x06\x77\x0a\x94\x18\x76\x14\x9b\x56\x49\xa3\x60\xe4\x7b\xa5\x20\x9b\x72\xcb\x17\x2c\x05\xe0\x8e\x70\x3e\xf1\x89\x03\x9a\xb6\xdc\xc9\x76\x8f\xad\xda\x43\x5a\x53\x97\x7a\xd3\xaa\x9d\xd2\xe6\x59\x00\x9e\x06\xc6\x0f\x85\x43\x4f\x61\xf9\xb2\x5e\x67\xd0\xb5\x81\xb6\x55\x35\xd1\x6f\xbe\x74\x3d\xc5\x1e\xff\xf8\xed\xf5\x9e\x26\xf6\xe4\xd3\xbc\x7d\x8b\x51\xa8\x0f\x69\x2b\x83\xc5\x62\x4a\x8e\x1c\x06\x17\xa3\x3b\xfb\xf8\xf8\xd8\x21\x30\x25\x3e\x76\x94\x90\xdc\xc0\x31\xd1\x30\xce\x01\x91\xe5\xd7\x5f\xad\x2e\x3f\xad\x42\xa9\x0b\xf5\x05\x1a\x5d\x37\xb5\x9c\x9b\xd6\x7a\xd6\x70\x9d\x65\xb9\x10\xb7\xda\x8a\xf3\xaf\x17\x45\x5b\x37\x77\xda\x72\xf1\x37\x00\x89\xd7\x8c\xda\x5b\x01\x00\x00")
+
+func migrations_gateway04_api_keysSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway04_api_keysSql,
+		"migrations_gateway/04_api_keys.sql",
+	)
+}
+
+func migrations_gateway04_api_keysSql() (*asset, error) {
+	bytes, err := migrations_gateway04_api_keysSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/04_api_keys.sql", size: 347, mode: os.FileMode(420), modTime: time.Unix(1792062394, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway05_pending_transactionsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x92\x41\x8f\x9b\x30\x10\x85\xef\xfe\x15\x73\x4b\xa2\x26\x52\x9b\x36\xe9\x21\x27\x1a\x5c\x09\x95\x40\x8a\x40\x6a\x4e\x68\x84\x47\xc4\x52\x31\xac\x3d\x24\xf9\xf9\xab\x2c\x5a\x02\x68\x37\x57\x7f\xef\xd9\x6f\x3c\x6f\xb5\x82\x2f\x95\x2e\x2d\x32\x41\xd6\x88\x7d\x22\xbd\x54\x42\xea\xfd\x0a\x25\x1c\xc9\x28\x6d\xca\xd4\xa2\x71\x58\xb0\xae\x0d\xcc\x05\x80\x56\xa0\x0d\x53\x49\x16\x8e\x49\x70\xf0\x92\x13\xfc\x91\x27\xf0\xb2\x34\x0e\xa2\x7d\x22\x0f\x32\x4a\x97\x02\x80\x1f\xbe\x5c\x2b\x28\xce\x68\xe7\xdb\x1f\x0b\x88\xe2\x14\xa2\x2c\x0c\xef\x1a\xc7\xc8\xad\x83\x0b\xda\x37\xfc\x6d\x3b\xc1\x75\x6b\x0b\xea\xac\x9b\x09\x23\x73\xa1\xff\x75\x43\xf9\x4d\x59\x60\xba\xf1\x88\x5a\x7a\x69\xb5\x25\x95\x5f\x49\x97\x67\xee\x13\x0f\x35\x4e\x97\xe6\xb9\x42\x91\x63\x6d\xf0\x3e\x42\x9f\x71\xbd\xd9\x8c\x83\x60\x55\xb7\x86\x7b\xfe\x7d\x3d\xc1\xce\xd1\x83\xfe\xfc\x3a\xa6\x15\x31\x2a\x64\xec\x26\xf0\xe5\x6f\x2f\x0b\x1f\xb4\xb0\x84\x4c\x2a\x47\x06\xd6\x15\x39\xc6\xaa\x19\xd9\xdb\x46\x3d\x17\x30\x19\x1c\x84\x1b\xfe\x7f\xff\xda\x6c\x26\x16\xbb\xf7\xd5\x67\x51\xf0\x37\x93\x10\x44\xbe\xfc\x07\x4d\xd7\x80\x7c\xb8\xca\xee\xc6\xd1\x91\x56\x10\x47\x1f\xd6\xa5\x13\x2f\x27\x5d\x58\xec\x84\x18\x16\xcf\xaf\xaf\x46\xf8\x49\x7c\xfc\xb4\x78\x3b\xf1\x3a\x00\x51\x2b\xa4\xe1\xa9\x02\x00\x00")
+
+func migrations_gateway05_pending_transactionsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway05_pending_transactionsSql,
+		"migrations_gateway/05_pending_transactions.sql",
+	)
+}
+
+func migrations_gateway05_pending_transactionsSql() (*asset, error) {
+	bytes, err := migrations_gateway05_pending_transactionsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/05_pending_transactions.sql", size: 681, mode: os.FileMode(420), modTime: time.Unix(1792065158, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway06_listener_leasesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\x8f\x41\x4b\xc4\x30\x14\x84\xef\xef\x57\xcc\x6d\x5b\xdc\xbd\x88\xeb\xa5\xa7\x68\x23\x88\xb1\x2d\x21\x3d\xf4\x24\x0f\x7d\xd8\x80\x49\x4b\x12\xd4\x9f\x2f\x2a\x88\x85\x9e\xe7\x63\x66\xbe\xd3\x09\x17\xc1\xbf\x26\x2e\x82\x71\xa5\x5b\xab\x95\xd3\x70\xea\xc6\x68\x18\x9f\x8b\x44\x49\x46\x38\x0b\x2a\x02\x22\x07\xc1\x3b\xa7\xe7\x99\x53\x75\x7d\x55\xa3\xeb\x1d\xba\xd1\x98\x23\x01\x45\x22\xc7\xb2\x1b\xa3\xd5\x77\x6a\x34\x0e\x87\xc3\x37\x39\x2f\x6f\x2f\x92\xfe\xc8\xcb\xf3\x79\xdb\x24\x9f\xab\x4f\x92\x9f\xb8\xa0\xf8\x20\xb9\x70\x58\x37\xc0\x60\xef\x1f\x95\x9d\xf0\xa0\x27\x54\xbf\xbb\xc7\x9f\x73\x35\xd5\x0d\xd1\x7f\xa9\x76\xf9\x88\xd4\xda\x7e\xd8\x93\x6a\xe8\x6b\x00\x4a\x55\x5c\x80\x00\x01\x00\x00")
+
+func migrations_gateway06_listener_leasesSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway06_listener_leasesSql,
+		"migrations_gateway/06_listener_leases.sql",
+	)
+}
+
+func migrations_gateway06_listener_leasesSql() (*asset, error) {
+	bytes, err := migrations_gateway06_listener_leasesSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/06_listener_leases.sql", size: 256, mode: os.FileMode(420), modTime: time.Unix(1792066535, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway07_callback_deliveriesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x90\x41\x4b\x03\x31\x10\x85\xef\xf3\x2b\xde\xad\xbb\xb8\x05\x2d\xd6\x4b\x4f\xb1\x89\x20\xc6\xb6\x84\xec\xa1\xa7\x32\x76\x83\x06\xb3\xd9\x25\xc6\x8a\xff\x5e\x44\xd9\x5d\x0f\xf6\x38\x7c\x8f\xc7\x9b\x6f\x3e\xc7\x45\xeb\x9f\x13\x67\x87\xba\xa7\xb5\x51\xc2\x2a\x58\x71\xab\x15\xd6\x1c\xc2\x13\x1f\x5f\xa5\x0b\xfe\xe4\xd2\x27\x0a\x02\x9a\xdf\xe3\xe0\x1b\x9c\x38\x1d\x5f\x38\x15\x8b\xe5\xb2\xc4\x66\x6b\xb1\xa9\xb5\xae\x08\xc8\x2e\x72\xcc\x03\xbf\xb9\x1e\x31\xa4\xba\x13\xb5\xb6\x98\xcd\xbe\x93\x5d\xef\x12\x67\xdf\xc5\xb3\x7d\xef\x29\x0c\xf0\xea\x72\x31\xa9\xab\xc6\x49\xae\x39\x70\x46\xf6\xad\x7b\xcb\xdc\xf6\x7f\x22\x3b\x73\xff\x28\xcc\x1e\x0f\x6a\x8f\xe2\x67\x5d\x35\x7d\xa5\xa4\x72\x45\x34\x95\x21\xbb\x8f\x48\xd2\x6c\x77\xff\xc8\x58\xd1\xd7\x00\x79\x73\xf7\x42\x3b\x01\x00\x00")
+
+func migrations_gateway07_callback_deliveriesSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway07_callback_deliveriesSql,
+		"migrations_gateway/07_callback_deliveries.sql",
+	)
+}
+
+func migrations_gateway07_callback_deliveriesSql() (*asset, error) {
+	bytes, err := migrations_gateway07_callback_deliveriesSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/07_callback_deliveries.sql", size: 315, mode: os.FileMode(420), modTime: time.Unix(1792067943, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway08_pending_complianceSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x92\xc1\x4a\xc3\x40\x10\x86\xef\xfb\x14\x73\x6b\x8b\x2d\x28\x88\x97\x9e\x62\xb3\x42\x31\xdd\x94\xb0\x01\x7b\x5a\x96\x64\x8c\x0b\xcd\x66\xdd\x9d\x68\x1e\x5f\x62\xdb\x28\xd1\x08\x9e\xe7\x9b\x7f\xf8\xf9\x66\xb5\x82\xab\xda\x54\x5e\x13\x42\xee\xd8\x26\xe3\x91\xe4\x20\xa3\xfb\x84\xc3\x1e\x6d\x69\x6c\xb5\x69\x6a\x77\x34\xda\x16\x08\x73\x06\x60\x4a\x30\x96\xb0\x42\x0f\xfb\x6c\xbb\x8b\xb2\x03\x3c\xf2\x03\x44\xb9\x4c\xb7\x62\x93\xf1\x1d\x17\x72\xc9\x00\x08\xad\xb6\x04\x6f\xda\x17\x2f\xda\xcf\xef\x6e\x17\x20\x52\x09\x22\x4f\x12\x88\xf9\x43\x94\x27\x12\x66\xb3\x9e\x0c\x68\x4b\xe5\xf1\xb5\xc5\x40\x40\xd8\xd1\x00\x7e\x4e\x49\x53\x1b\x86\x9c\x9b\xeb\xaf\x9c\x7e\x4c\x9d\xfa\x83\x18\x5d\x32\xf6\xb9\xf9\x07\xae\x89\xb0\x76\x14\x86\xc2\x17\xae\x3f\x6c\xb1\x23\x75\x26\x94\x26\x20\x53\x63\x20\x5d\xbb\x21\xad\xa7\xb0\x73\xc6\x63\x98\x06\x8e\x3a\x90\x42\xef\x1b\xff\xb3\x7a\xe1\x51\x13\x96\xd3\xcb\xad\x2b\xa7\x01\xb6\x58\x5f\x7c\x6e\x45\xcc\x9f\xc0\x9d\x7c\xaa\x62\x10\xaa\xc6\x25\x52\xf1\x9b\xf5\x93\xca\xe5\x59\xc5\x72\x5c\x7d\xb1\x66\xec\xfb\x1f\xc5\xcd\xbb\x65\x71\x96\xee\xa7\xfe\x68\xcd\x3e\x06\x00\xeb\xb2\xe9\x86\x77\x02\x00\x00")
+
+func migrations_gateway08_pending_complianceSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway08_pending_complianceSql,
+		"migrations_gateway/08_pending_compliance.sql",
+	)
+}
+
+func migrations_gateway08_pending_complianceSql() (*asset, error) {
+	bytes, err := migrations_gateway08_pending_complianceSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/08_pending_compliance.sql", size: 631, mode: os.FileMode(420), modTime: time.Unix(1792070331, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway09_sent_transaction_paymentSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x93\x51\x6f\x9b\x3e\x14\xc5\xdf\xf9\x14\xf7\xad\x89\xfe\xae\xd4\xe6\xbf\x74\x93\xf2\xc4\x82\x2b\xa1\x11\xd3\x12\x90\xd6\xa7\xc8\xc1\x57\x89\x35\xb0\x11\xbe\xb4\xcd\xb7\x9f\x48\x53\x16\xdc\x6c\x9a\xf6\xea\x73\x38\xd7\xbf\x73\xcd\xf5\x35\xfc\x57\xeb\x5d\x2b\x09\xa1\x68\x82\x30\xc9\x79\x06\x79\xf8\x35\xe1\xb0\x46\x43\x79\x2b\x8d\x93\x25\x69\x6b\x20\x8c\x22\x58\xa6\x49\xb1\x12\xa0\xd0\x91\x36\xf2\x78\xfc\x2c\xdb\x72\x2f\xdb\xc9\x6c\x3e\x9f\x42\xc4\xef\xc3\x22\xc9\x41\x14\x49\xb2\xf8\xdb\x34\x59\xdb\xce\xd0\x10\xf4\xff\xec\x5f\x73\x9c\xc3\x5f\x31\x9f\x6f\xfc\x98\xe0\x1c\x36\xb2\x2f\xa6\x3f\x58\x3f\x26\x9a\x10\x4a\x69\x8c\x25\x50\xad\x6d\xa0\xb4\x55\x57\x1b\x07\xce\x02\xed\x11\x48\x6e\x2b\x04\xed\xa0\xc5\x6d\xa7\x2b\x82\x17\x4d\x7b\xdb\x11\x34\xf2\x50\xa3\x21\x50\x48\x52\x57\x2e\x58\x66\x3c\xcc\xf9\xe5\x7b\x6e\x6c\xa5\x60\x12\x00\x68\x05\xda\x10\xee\xb0\x85\x87\x2c\x5e\x85\xd9\x13\x7c\xe3\x4f\x10\x16\x79\x1a\x8b\x65\xc6\x57\x5c\xe4\x2c\x00\xa0\xb3\x6f\xb5\x1a\xa8\xee\x3e\x4d\x41\xa4\x6f\x44\xbd\xcd\x91\xa4\xce\x0d\xf2\xed\x8d\x27\xdb\xae\x2d\x71\x90\xe7\x77\x9e\xdc\x6d\x6b\x4d\x84\x6a\x23\x09\x48\xd7\xe8\x48\xd6\x8d\x67\x29\x4b\x44\xe5\x5b\xce\x9b\xed\x93\x2a\x54\x3d\xd2\x56\xef\xb4\xa1\x0f\x2a\x9a\x67\xac\x6c\x83\x9b\x57\xd5\x02\xe1\x2b\x8d\x46\xb4\xe8\xba\x8a\x8e\xda\x6f\x1f\x53\x3f\x83\xd0\x48\x43\x17\xab\x18\xbc\x57\x57\xbd\xb3\x46\x92\x4a\x92\x7c\x9b\xe5\xe7\x68\x85\x75\x63\x09\x4d\x79\xd8\xfc\xc0\xc3\x10\x78\x3b\xfb\x32\x1e\x1a\x4c\x17\x41\x2c\xd6\x3c\xcb\x21\x16\x79\x7a\x71\xa7\x6b\x9e\xf0\x65\x0e\x5a\x31\x6f\x65\xec\xb4\x1b\x76\x5a\x02\x1b\xb5\xed\x57\xcb\x4e\x0d\xb2\x51\x57\xec\xac\x1b\x76\xe2\x67\x03\x1d\xf3\x49\x02\x80\xfb\x2c\x5d\xf9\xf7\x5c\x04\x51\x96\x3e\x5c\x7e\x97\x7f\xfc\xb9\x8e\x80\x19\x17\xe1\x8a\xc3\x47\xfc\xc5\xfb\x83\x2f\x44\xfc\x58\x70\x88\x45\xc4\xbf\x83\x43\x43\x9b\x71\x13\xe3\xb6\x53\xe1\x07\xc1\xe4\x9d\xcc\xf3\x4e\x17\xc1\xcf\x01\x00\x79\xd8\x09\xfe\xa0\x04\x00\x00")
+
+func migrations_gateway09_sent_transaction_paymentSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway09_sent_transaction_paymentSql,
+		"migrations_gateway/09_sent_transaction_payment.sql",
+	)
+}
+
+func migrations_gateway09_sent_transaction_paymentSql() (*asset, error) {
+	bytes, err := migrations_gateway09_sent_transaction_paymentSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/09_sent_transaction_payment.sql", size: 1184, mode: os.FileMode(420), modTime: time.Unix(1792071177, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway10_transfer_transactionsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x93\x41\x6f\x9c\x30\x10\x85\xef\xfe\x15\x73\x4b\x50\x13\x29\x49\xbb\xb9\xec\x89\x06\x57\x42\x25\x90\x22\x90\x9a\x93\xe5\x9a\xd9\xad\x55\x6c\x90\x3d\xa4\xcd\xbf\xaf\xc8\x36\xb0\xde\x25\xd9\x9e\x40\x9a\x6f\xde\x1b\x7b\xfc\x2e\x2f\xe1\x83\xd1\x5b\x27\x09\xa1\xee\xd9\x5d\xc9\xe3\x8a\x43\x15\x7f\xce\x38\x54\x4e\x5a\xbf\x41\xf7\xf2\x95\x8a\x74\x67\xe1\x9c\x01\xe8\x06\xb4\x25\xdc\xa2\x83\x87\x32\xbd\x8f\xcb\x47\xf8\xca\x1f\x21\xae\xab\x22\xcd\xef\x4a\x7e\xcf\xf3\xea\x82\x01\xf4\xc3\x8f\x56\x2b\xa1\x1b\x78\x92\x4e\xfd\x94\xee\xfc\xe3\x6d\x04\x79\x51\x41\x5e\x67\xd9\x48\xfc\xd2\x76\x2e\x5e\x5f\x85\x45\x4f\x92\x06\x3f\xf7\xde\x84\x65\xe9\x3d\x92\x50\x5d\x83\xb3\xc2\x22\xa2\xbd\x1f\xd0\x4d\xd0\xea\x60\x06\x69\xba\xc1\x92\xd0\x76\x22\x6e\x3f\x45\x90\xf0\x2f\x71\x9d\x1d\x51\x1b\xc4\xff\xc1\xba\x81\xde\xc7\x94\x1a\xb9\x37\x67\xa2\xe7\x3e\xf4\x79\x2d\x4e\x4a\x67\x67\xa3\xdd\x46\x63\xdb\x78\x20\xfc\x43\x41\xbf\x41\xd3\x89\x40\xe4\xfa\xea\x78\x8a\x91\x0a\x5c\x0e\x01\x4f\xd8\xb6\xd2\x09\x9a\x1f\xc0\xfe\x36\x97\x5a\x1c\x2a\xd4\x4f\xd8\x88\x5e\x3e\x1b\xb4\xb4\xcf\xdf\xac\x56\x4b\x1e\xe3\x96\x85\x41\xef\xe5\x16\x77\x47\x59\x60\x1c\x61\x23\x24\x01\x69\x83\x9e\xa4\xe9\x83\xf3\xaa\xce\xf4\x2d\x1e\x21\x87\x3a\x84\x56\x5a\x3a\x75\xb1\x2c\x5a\xbf\xe6\xa0\xce\xd3\x6f\x35\x87\x34\x4f\xf8\x77\xa0\x7f\x71\x08\xae\x63\x7e\xe3\x45\xbe\x1c\x98\x9d\xe9\xc5\x9c\x86\x59\xfe\x1d\xdd\x97\xdd\x9c\x92\x9c\xd6\xbc\xfb\x8d\xd6\x8c\xed\xe7\x39\xe9\x7e\x5b\x96\x94\xc5\xc3\xdb\x79\x5e\xb3\xbf\x03\x00\x00\xb2\xf3\x96\x01\x04\x00\x00")
+
+func migrations_gateway10_transfer_transactionsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway10_transfer_transactionsSql,
+		"migrations_gateway/10_transfer_transactions.sql",
+	)
+}
+
+func migrations_gateway10_transfer_transactionsSql() (*asset, error) {
+	bytes, err := migrations_gateway10_transfer_transactionsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/10_transfer_transactions.sql", size: 1025, mode: os.FileMode(420), modTime: time.Unix(1792072117, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway11_received_payment_transactionSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x94\x4f\x6f\xea\x3a\x10\xc5\xf7\xf9\x14\xb3\x6b\xd1\x73\x17\xad\x5e\x2b\x3d\xb1\xca\x03\x57\x42\x37\x84\x36\x4d\xa4\xdb\x95\x65\xec\x69\xb0\x6e\x62\x47\xb6\x43\xcb\xb7\xbf\x4a\xf9\x97\x84\xc0\xad\x6e\x37\x08\xc2\x2f\x47\x33\x73\xce\xcc\xcd\x0d\xfc\x53\xaa\xdc\x72\x8f\x90\x55\x41\x18\xa5\x34\x81\x34\xfc\x3f\xa2\x90\xa0\x40\xb5\x46\xf9\xc4\x37\x25\x6a\x0f\xe1\x74\x0a\x93\x45\x94\xcd\x63\xf0\x96\x6b\xc7\x85\x57\x46\xb3\x15\x77\x2b\x58\x73\x2b\x56\xdc\x5e\x3f\xfc\x3b\x82\x29\x7d\x0c\xb3\x28\x85\x38\x8b\xa2\xf1\x57\x15\x0b\x94\x39\x5a\x58\xaa\x5c\x69\xff\x1d\x09\x26\x0a\xe3\x90\x79\x55\x22\x34\x1f\xce\xf3\xb2\xfa\x3b\xc1\x37\x44\xd6\x74\x95\xa3\xfc\x56\x61\xed\x69\x39\x53\x5b\x81\x8c\x0b\x61\x6a\xed\x8f\x73\xfb\xaf\x3f\xb7\xa0\xed\xcc\xd4\xbc\xeb\xe6\xc1\xcb\x73\xa4\x3c\x82\xe0\x5a\x1b\x0f\xd2\x9a\x0a\x84\x29\xea\x52\x3b\x70\x06\xfc\x0a\xc1\xf3\x65\x81\xa0\x1c\x58\x5c\xd6\xaa\xf0\xf0\xae\xfc\xca\xd4\xbe\x6d\xd9\xfe\x9d\x60\x92\xd0\x30\xa5\xc3\x0d\x30\x53\x48\xb8\x0e\x00\x94\x04\xa5\x3d\x36\xee\x3c\x25\xb3\x79\x98\xbc\xc2\x0f\xfa\x0a\x61\x96\x2e\x66\xf1\x24\xa1\x73\x1a\xa7\x24\x00\x30\x15\x5a\xde\xc8\x33\x25\x0f\x7d\xdd\xdd\xdf\x8f\x20\x5e\x6c\x9b\x6a\xa8\xca\x1a\x81\xce\xa1\x64\xdc\xb7\xfc\xe9\x20\x3c\x57\x3a\x67\xde\xfc\x42\x7d\x5e\xc8\x79\xee\x6b\x77\xfe\x7f\xfc\x10\x2b\xae\x73\x64\x9f\xd1\x3e\x97\xcf\x86\x14\x46\xaf\xd1\xfa\xa6\xa4\xb2\x6b\xca\x45\x58\xd4\xd6\xa2\x16\x9b\x03\x7e\x7b\x77\x8a\x7b\xd4\xbc\xa7\xb8\x2f\xf2\xc0\x5e\x5d\x35\xe4\x9b\x35\x25\xe3\x52\x5a\x74\xbd\xae\xfa\x9a\x5b\xb2\x17\xa0\xfb\x87\xb3\xca\x03\x5d\x9d\x23\x9d\x43\xcf\x84\x91\xc7\x81\xdd\xde\xfd\x81\x56\xce\xd5\x68\xbf\x52\x47\x89\xa5\x61\x7e\x53\x1d\xc5\xcf\x57\xd2\xb0\xc3\xde\xf6\x40\x8b\x6f\xb5\x96\xac\xbd\x62\x4a\x5e\x74\xd0\xa2\x33\x45\xfd\x19\x54\x8b\xdc\x19\x0d\x1e\x3f\xfc\x30\xb6\x46\xc9\x96\x9b\xcb\x76\x1c\xc0\x4e\xa0\x4f\xa9\xe6\x3c\x34\xb9\xfe\xa2\x73\xc1\x68\x1c\xcc\xe2\x17\x9a\xa4\x30\x8b\xd3\xc5\xe0\x7a\xbe\xd0\x88\x4e\x52\x50\x92\x74\xb6\x8f\x74\xb6\x8c\x74\x16\x8a\xec\x16\xe7\x64\x45\xc8\xc9\x1e\x90\x81\xb0\x93\x5d\xa2\x49\x27\xaf\xfb\x5f\xdb\xce\x08\xec\xde\xef\x24\x8a\x74\xf2\x42\x8e\x69\xd8\x7e\x25\xc3\x4e\x92\x53\xbb\x76\x8f\xb6\xd6\xf4\x0c\x20\x03\x73\x7e\x4c\x16\xf3\xfe\xf4\xc6\xc1\x34\x59\x3c\x0d\x1f\xbe\x8b\x67\xfd\x73\xec\x09\x8d\xc3\x39\x85\x53\x53\xc6\xfb\x8b\x9a\xc5\xb3\xe7\x8c\xc2\x2c\x9e\xd2\x9f\xbb\x92\x50\xb2\x6a\xaf\xd1\xbe\x94\x8b\xb8\xaf\x02\xd7\xfb\x19\xb7\xc1\xd1\x38\xf8\x3d\x00\x07\xdd\x83\x81\xac\x07\x00\x00")
+
+func migrations_gateway11_received_payment_transactionSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway11_received_payment_transactionSql,
+		"migrations_gateway/11_received_payment_transaction.sql",
+	)
+}
+
+func migrations_gateway11_received_payment_transactionSql() (*asset, error) {
+	bytes, err := migrations_gateway11_received_payment_transactionSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/11_received_payment_transaction.sql", size: 1964, mode: os.FileMode(420), modTime: time.Unix(1792073476, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_gateway12_pending_transaction_versionSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\x53\x4d\x6f\xa3\x30\x10\xbd\xf3\x2b\xe6\xd6\x44\xeb\x4a\xdd\xee\xa6\x7b\xe0\xc4\x06\x57\x42\x4b\x20\x25\x20\x6d\x4f\x91\x83\x47\xc4\x52\xb0\x59\x7b\x48\xfa\xf3\x57\x29\x09\x85\x28\x69\xaf\xf3\xde\xd8\xef\xc3\xbe\xbf\x87\x6f\xb5\xaa\xac\x20\x84\xa2\xf1\x82\x38\xe7\x19\xe4\xc1\xef\x98\xc3\x12\xb5\x54\xba\xca\xad\xd0\x4e\x94\xa4\x8c\x86\x20\x0c\x61\x9e\xc6\xc5\x22\x81\x3d\x5a\x77\x1c\x6d\x54\xa5\x34\x41\x92\xe6\x90\x14\x71\x0c\x21\x7f\x0e\x8a\x38\x87\x07\xdf\xf3\x86\x87\x87\xe6\xa0\x8f\x83\xd5\x4b\xac\x08\xa1\x14\x5a\x1b\x02\x69\x4d\x03\xa5\xd9\xb5\xb5\x76\xe0\x0c\xd0\x16\x81\xc4\x66\x87\xa0\x1c\x58\xdc\xb4\x6a\x47\x70\x50\xb4\x35\x2d\xf5\x57\x76\x7c\x6f\x9e\xf1\x20\xe7\x37\xc5\xae\xcd\x4e\xc2\xc4\x03\x50\x12\x94\x26\xac\xd0\xc2\x32\x8b\x16\x41\xf6\x0a\x7f\xf8\x2b\x04\x45\x9e\x46\xc9\x3c\xe3\x0b\x9e\xe4\xcc\x03\xa0\xc1\xae\x92\x50\x6e\x85\x9d\x3c\xfd\x9c\xf6\xd6\x8e\x1c\x47\x82\x5a\x07\x7b\x61\xdf\xe1\xef\x4f\x17\xb0\x69\x6d\x89\xdd\xea\xec\x02\x43\xbd\xc7\x9d\x69\x70\xfd\x26\x2d\x10\xbe\x7d\x64\x76\xdc\xb4\xf8\xaf\x55\x16\xe5\xfa\x80\xaa\xda\x52\xaf\x78\xc8\x71\xaa\xd2\x9f\x33\x24\x3a\x52\x5a\x1c\x2d\xf4\x1a\x1f\x67\xb3\xb1\x10\x51\x9b\x56\x53\x8f\xff\x78\xbc\x80\x9d\xc3\x0f\xf4\xd7\xc3\x18\xad\x91\x84\x14\x24\x3a\x07\xe7\xb2\xcf\x68\x69\x51\x10\xca\xb5\x20\x20\x55\xa3\x23\x51\x37\xa3\xf5\xb6\x91\x9f\x13\x08\xb5\x18\x88\x1b\xe6\xdf\xdf\x76\x77\xe7\x4d\x7d\x2f\x4a\x56\x3c\xcb\x21\x4a\xf2\xf4\x56\xfb\x2b\x1e\xf3\x79\x0e\x4a\xb2\x8b\x72\xd9\xa9\x48\x76\x6a\x8c\x8d\xda\xb9\xd2\x07\x1b\x87\xcf\x86\x49\xb3\x53\xa2\xac\x8b\x8e\xf5\x19\xb1\x41\x1e\x6c\x60\x7d\xe0\xf3\x39\x4b\x17\x57\xd4\xfb\x5e\x98\xa5\xcb\x9b\x4f\xdb\xff\xe2\x9f\xbe\x3f\xfd\x8c\x27\xc1\x82\xc3\xd5\x74\xfc\xf3\xe7\x29\x92\xe8\xa5\xe0\x10\x25\x21\xff\x0b\x4d\xc7\x5b\x0f\xb3\xea\x74\x8e\x46\x4a\x42\x9a\x5c\x39\x14\x26\x1d\xf9\x32\xec\xa9\xef\xfd\x1f\x00\xee\xef\x7e\x03\x66\x04\x00\x00")
+
+func migrations_gateway12_pending_transaction_versionSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_gateway12_pending_transaction_versionSql,
+		"migrations_gateway/12_pending_transaction_version.sql",
+	)
+}
+
+func migrations_gateway12_pending_transaction_versionSql() (*asset, error) {
+	bytes, err := migrations_gateway12_pending_transaction_versionSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_gateway/12_pending_transaction_version.sql", size: 1126, mode: os.FileMode(420), modTime: time.Unix(1792075703, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_compliance01_initSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x94\xcf\x6e\xda\x40\x10\xc6\xef\x7e\x8a\x39\x26\x6a\x22\xb5\x55\xc9\x85\x93\x0b\x8b\x64\x85\xd8\xa9\x65\x4b\xe5\xb4\x1a\xbc\xeb\xb0\x0a\xf6\xa2\xdd\xd9\x26\xf4\xe9\x2b\x83\x83\xbd\x80\x93\x92\xeb\xcc\x37\x7f\xbe\xdf\xd8\x7b\x7b\x0b\x5f\x2a\xf5\x64\x90\x24\xe4\x9b\x60\x92\xb2\x30\x63\x90\x85\x3f\xe7\x0c\x42\x47\x2b\x6d\xd4\x5f\x29\x32\x83\xb5\xc5\x82\x94\xae\xe1\x2a\x00\x50\x02\x54\x4d\xf2\x49\x1a\x78\x4c\xa3\x87\x30\x5d\xc0\x3d\x5b\x40\x98\x67\x49\x14\x4f\x52\xf6\xc0\xe2\xec\x26\x00\xa0\xae\x8e\x2b\x01\x7f\xd0\x14\x2b\x34\x57\x77\x3f\xae\x21\x4e\x32\x88\xf3\xf9\xbc\x91\x55\xb2\xd2\x83\xc9\x7e\x8f\x57\x61\x80\xe4\x2b\x79\x02\x3c\xac\xc9\x91\x80\x54\x25\x2d\x61\xb5\xf1\x34\x02\x09\xfd\xca\xe0\x7a\x1c\x1c\xd9\x5d\xaf\xf5\x8b\x14\xb3\xe8\x22\x8b\x35\x56\xf2\xb0\xfb\xf7\xd1\xc8\x5f\x5e\xe8\x0a\x55\x3d\x9c\xdf\xb8\xe5\x5a\x15\xfc\x59\x6e\x61\x27\x18\xdd\xf9\x79\xdc\xef\x74\xde\x58\x63\xa1\x75\x90\xc7\xd1\xaf\x9c\x41\x14\x4f\xd9\x6f\xc0\x52\xf1\xe5\x96\xb7\xb3\x93\xb8\xef\x6c\x1f\x7c\xbf\xb0\xb7\x94\x5f\xdc\x25\x86\xe0\xe5\x56\x9a\x8b\xf0\x95\x8a\xbf\x4f\xb0\x54\xfc\x23\x88\xa5\xe2\x1f\x71\x74\x56\x9a\xfe\x17\x78\xd2\xe3\x73\xa0\x5d\x83\xcb\x1b\xcf\xdf\x26\x75\xe4\xf6\x4c\x3c\xd5\x0d\xb4\xb2\x13\x8e\xf7\x8b\xc9\xc4\x59\xd2\xd5\x85\x1c\x51\x08\x23\xad\x1d\x36\x68\xb4\xa3\x23\xcc\x53\x36\x0b\xf3\x79\x27\x51\x75\xa9\x4f\x7f\x2f\x4b\x48\xae\x6b\xfc\xed\x88\xac\x5d\xa1\x91\x7c\x57\xba\xd4\x7a\x2d\xb1\x3e\xa4\x0f\x03\xbe\x36\x7d\x0a\x23\x91\x86\x08\x37\x02\xb7\x11\x48\x97\x9e\xe0\x79\x5b\xf0\xa2\x05\xc6\xdf\x20\x24\xb1\xcf\xb1\x8d\xff\x4f\x8b\x3d\xa6\xe3\x06\xbb\x68\x73\xab\xfe\x73\x39\xd5\x2f\x75\x30\x4d\x93\xc7\xd3\xd3\x8d\xfb\xf1\xde\x67\x70\x2e\x3e\x8b\xfc\xe8\xb9\x47\x77\x1c\xfc\x1b\x00\x8d\x5d\xe0\x9a\xa8\x05\x00\x00")
+
+func migrations_compliance01_initSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_compliance01_initSql,
+		"migrations_compliance/01_init.sql",
+	)
+}
+
+func migrations_compliance01_initSql() (*asset, error) {
+	bytes, err := migrations_compliance01_initSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_compliance/01_init.sql", size: 1448, mode: os.FileMode(420), modTime: time.Unix(1792058902, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_compliance02_compliance_transactionsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x93\x4f\x4b\xc3\x40\x10\xc5\xef\xfb\x29\xe6\xd8\x62\x7b\x50\x6c\x11\x7a\x8a\xed\x1e\x82\xed\xa6\x86\x04\xec\x29\x0c\xd9\xb1\x2e\xb8\xbb\x61\x33\xf1\xcf\xb7\x17\x15\xd3\xa4\x34\xad\xe7\xdf\x6f\xf7\xc1\x7b\xcc\x74\x0a\x57\xd6\xec\x03\x32\x41\x5e\x89\x65\x2a\xa3\x4c\x42\x16\xdd\xaf\x25\x2c\xbd\xad\x5e\x0d\xba\x92\xb2\x80\xae\xc6\x92\x8d\x77\x30\x12\x00\x46\x83\x71\x4c\x7b\x0a\xb0\x4d\xe3\x4d\x94\xee\xe0\x41\xee\x20\xca\xb3\x24\x56\xcb\x54\x6e\xa4\xca\x26\x02\x40\x9b\x40\xbf\xaf\xde\x30\x94\x2f\x18\x46\x77\x63\x50\x49\x06\x2a\x5f\xaf\xbf\x05\x3e\x7c\x5c\x18\x0d\x3f\xca\xfc\xb6\xef\x58\xb2\xbe\x7d\x7f\x0c\x6b\x72\x9a\x42\x8b\x6f\x66\xb3\x3e\x0f\xbe\x61\x1a\xc6\xa5\x6f\x1c\x53\xa8\x30\xf0\x67\xa1\xbd\x45\xe3\x86\xe5\x9a\x91\x9b\xba\xe5\xd7\xf3\x3e\xe6\x8f\xe2\x82\x61\xdc\xb3\xbf\xe4\x94\x81\x90\x49\x17\xc8\xc0\xc6\x52\xcd\x68\xab\x9e\xd0\x54\x7a\x58\x10\xe3\xc5\xdf\x86\xb9\x8a\x1f\x73\x09\xb1\x5a\xc9\x27\x28\xdb\x29\x8b\x6e\xe5\x47\xf5\x27\x6a\x68\xf3\x76\xc8\xc9\xd1\x64\x87\xbc\xb3\x41\xa7\x7a\x1e\x4e\x3b\x61\x4f\x3a\xc5\xfc\x37\xf3\xd0\xe4\x99\xa8\xee\xaf\xa2\x7b\x0d\x2b\xff\xee\xc4\x2a\x4d\xb6\xe7\xae\x61\x21\xbe\x06\x00\x36\xba\x68\x67\x41\x03\x00\x00")
+
+func migrations_compliance02_compliance_transactionsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_compliance02_compliance_transactionsSql,
+		"migrations_compliance/02_compliance_transactions.sql",
+	)
+}
+
+func migrations_compliance02_compliance_transactionsSql() (*asset, error) {
+	bytes, err := migrations_compliance02_compliance_transactionsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_compliance/02_compliance_transactions.sql", size: 833, mode: os.FileMode(420), modTime: time.Unix(1792069662, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_compliance03_sep12_customersSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x92\x5f\x4f\xc2\x30\x14\xc5\xdf\xfb\x29\xee\x1b\x2c\xc2\x03\x28\xc4\x84\xa7\xc9\x6a\xb2\x38\x3a\x9c\x5b\x22\x4f\x4b\xdd\xae\xd8\x84\xb2\xa6\xbd\xf3\xcf\xb7\x37\x28\x74\x0a\x92\xf8\xd6\xe4\xfc\x7a\x72\x73\xce\x19\x0e\xe1\x42\xab\xb5\x95\x84\x50\x18\x36\xcf\x78\x98\x73\xc8\xc3\x9b\x84\xc3\x03\x9a\xd1\x78\xde\x3a\x6a\x34\x5a\xe8\x33\x00\x55\x83\xda\x12\xae\xd1\xc2\x32\x8b\x17\x61\xb6\x82\x3b\xbe\x82\xb0\xc8\xd3\x58\xcc\x33\xbe\xe0\x22\x1f\x30\x00\xd3\x3e\x6d\x54\x55\xaa\x1a\x5e\xa5\xad\x5e\xa4\xed\x5f\x4e\x03\x10\x69\x0e\xa2\x48\x92\x1d\x21\xab\xaa\x69\xb7\xe4\xf5\xc9\x91\xae\x51\x37\x25\x7d\x18\xf4\xc4\x75\x07\x40\xc4\x6f\xc3\x22\xc9\xa1\xd7\x3b\xb0\x1e\x9b\x5e\x9d\xe5\x9e\x15\x6e\x6a\x07\x84\xef\xe4\x91\xdd\x7f\x47\x92\x5a\xe7\x1d\x46\x27\xa7\x38\x27\xd7\xdd\x21\xe3\xc9\x24\xf0\xce\x07\xa8\xb2\x28\x09\xeb\x52\x12\x90\xd2\xe8\x48\x6a\xf3\xcb\xa5\x35\xf5\x79\x80\x05\xb3\x43\xf4\x85\x88\xef\x0b\x0e\xb1\x88\xf8\x23\xb8\x5d\x03\x65\xb5\xaf\xa0\xec\x62\x4d\xc5\x71\x3b\x5e\xfb\x9f\xd5\x3e\xff\xf2\x2b\xbb\x53\xb7\xbd\x3c\xe8\x7a\xf8\x7e\x06\x33\xc6\x7e\x4e\x26\x6a\xde\xb6\x2c\xca\xd2\xe5\x5f\x93\x99\xb1\xcf\x01\x00\x76\xb1\xd4\xa4\x5e\x02\x00\x00")
+
+func migrations_compliance03_sep12_customersSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_compliance03_sep12_customersSql,
+		"migrations_compliance/03_sep12_customers.sql",
+	)
+}
+
+func migrations_compliance03_sep12_customersSql() (*asset, error) {
+	bytes, err := migrations_compliance03_sep12_customersSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_compliance/03_sep12_customers.sql", size: 606, mode: os.FileMode(420), modTime: time.Unix(1792072929, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_compliance04_auth_noncesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x5c\x8f\xc1\x6a\x84\x30\x18\x84\xef\xff\x53\xcc\xd1\xd0\xdd\x5b\xbb\x17\x4f\x69\xcd\x61\xa9\x8d\x4b\x50\xa8\xa7\x10\x6c\xd0\x50\x8c\x12\x63\x4b\xdf\xbe\x60\x91\xc6\x3d\x7f\xc3\xcc\x7c\xe7\x33\x1e\x46\xd7\x07\x13\x2d\x9a\x99\x5e\x94\xe0\xb5\x40\xcd\x9f\x4b\x01\xbe\xc6\x41\x4e\xbe\xb3\xc8\x08\x58\x5c\xef\x9d\xef\xf5\xa7\xfd\xc1\x97\x09\xdd\x60\x42\xf6\x74\x61\x90\x55\x0d\xd9\x94\xe5\x89\x00\xbf\xa5\x77\x7a\x79\x3c\xd2\x2e\x58\x13\xed\x87\x36\x11\xd1\x8d\x76\x89\x66\x9c\x0f\x81\x9b\xba\xbe\x71\xd5\xe2\x55\xb4\xc8\x92\xbd\xd3\x5f\x31\x23\x96\xef\x0f\xaf\xb2\x10\xef\x30\x6b\x1c\xf4\xc6\x74\x52\x5e\xc9\xf4\xfa\x3f\x60\x39\x51\xea\x5b\x4c\xdf\x9e\x0a\x55\xdd\xee\x7d\x73\xfa\x1d\x00\xd4\x43\x86\xe3\x17\x01\x00\x00")
+
+func migrations_compliance04_auth_noncesSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_compliance04_auth_noncesSql,
+		"migrations_compliance/04_auth_nonces.sql",
+	)
+}
+
+func migrations_compliance04_auth_noncesSql() (*asset, error) {
+	bytes, err := migrations_compliance04_auth_noncesSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_compliance/04_auth_nonces.sql", size: 279, mode: os.FileMode(420), modTime: time.Unix(1792073351, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+// Asset loads and returns the asset for the given name.
+// It returns an error if the asset could not be found or
+// could not be loaded.
+func Asset(name string) ([]byte, error) {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	if f, ok := _bindata[cannonicalName]; ok {
+		a, err := f()
+		if err != nil {
+			return nil, fmt.Errorf("Asset %s can't read by error: %v", name, err)
+		}
+		return a.bytes, nil
+	}
+	return nil, fmt.Errorf("Asset %s not found", name)
+}
+
+// MustAsset is like Asset but panics when Asset would return an error.
+// It simplifies safe initialization of global variables.
+func MustAsset(name string) []byte {
+	a, err := Asset(name)
+	if err != nil {
+		panic("asset: Asset(" + name + "): " + err.Error())
+	}
+
+	return a
+}
+
+// AssetInfo loads and returns the asset info for the given name.
+// It returns an error if the asset could not be found or
+// could not be loaded.
+func AssetInfo(name string) (os.FileInfo, error) {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	if f, ok := _bindata[cannonicalName]; ok {
+		a, err := f()
+		if err != nil {
+			return nil, fmt.Errorf("AssetInfo %s can't read by error: %v", name, err)
+		}
+		return a.info, nil
+	}
+	return nil, fmt.Errorf("AssetInfo %s not found", name)
+}
+
+// AssetNames returns the names of the assets.
+func AssetNames() []string {
+	names := make([]string, 0, len(_bindata))
+	for name := range _bindata {
+		names = append(names, name)
+	}
+	return names
+}
+
+// _bindata is a table, holding each asset generator, mapped to its name.
+var _bindata = map[string]func() (*asset, error){
+	"migrations_gateway/01_init.sql":                         migrations_gateway01_initSql,
+	"migrations_gateway/02_receiving_accounts.sql":           migrations_gateway02_receiving_accountsSql,
+	"migrations_gateway/03_callback_attempts.sql":            migrations_gateway03_callback_attemptsSql,
+	"migrations_gateway/04_api_keys.sql":                     migrations_gateway04_api_keysSql,
+	"migrations_gateway/05_pending_transactions.sql":         migrations_gateway05_pending_transactionsSql,
+	"migrations_gateway/06_listener_leases.sql":              migrations_gateway06_listener_leasesSql,
+	"migrations_gateway/07_callback_deliveries.sql":          migrations_gateway07_callback_deliveriesSql,
+	"migrations_gateway/08_pending_compliance.sql":           migrations_gateway08_pending_complianceSql,
+	"migrations_gateway/09_sent_transaction_payment.sql":     migrations_gateway09_sent_transaction_paymentSql,
+	"migrations_gateway/10_transfer_transactions.sql":        migrations_gateway10_transfer_transactionsSql,
+	"migrations_gateway/11_received_payment_transaction.sql": migrations_gateway11_received_payment_transactionSql,
+	"migrations_gateway/12_pending_transaction_version.sql":  migrations_gateway12_pending_transaction_versionSql,
+	"migrations_compliance/01_init.sql":                      migrations_compliance01_initSql,
+	"migrations_compliance/02_compliance_transactions.sql":   migrations_compliance02_compliance_transactionsSql,
+	"migrations_compliance/03_sep12_customers.sql":           migrations_compliance03_sep12_customersSql,
+	"migrations_compliance/04_auth_nonces.sql":               migrations_compliance04_auth_noncesSql,
+}
+
+// AssetDir returns the file names below a certain
+// directory embedded in the file by go-bindata.
+// For example if you run go-bindata on data/... and data contains the
+// following hierarchy:
+//
+//	data/
+//	  foo.txt
+//	  img/
+//	    a.png
+//	    b.png
+//
+// then AssetDir("data") would return []string{"foo.txt", "img"}
+// AssetDir("data/img") would return []string{"a.png", "b.png"}
+// AssetDir("foo.txt") and AssetDir("notexist") would return an error
+// AssetDir("") will return []string{"data"}.
+func AssetDir(name string) ([]string, error) {
+	node := _bintree
+	if len(name) != 0 {
+		cannonicalName := strings.Replace(name, "\\", "/", -1)
+		pathList := strings.Split(cannonicalName, "/")
+		for _, p := range pathList {
+			node = node.Children[p]
+			if node == nil {
+				return nil, fmt.Errorf("Asset %s not found", name)
+			}
+		}
+	}
+	if node.Func != nil {
+		return nil, fmt.Errorf("Asset %s not found", name)
+	}
+	rv := make([]string, 0, len(node.Children))
+	for childName := range node.Children {
+		rv = append(rv, childName)
+	}
+	return rv, nil
+}
+
+type bintree struct {
+	Func     func() (*asset, error)
+	Children map[string]*bintree
+}
+
+var _bintree = &bintree{nil, map[string]*bintree{
+	"migrations_compliance": &bintree{nil, map[string]*bintree{
+		"01_init.sql":                    &bintree{migrations_compliance01_initSql, map[string]*bintree{}},
+		"02_compliance_transactions.sql": &bintree{migrations_compliance02_compliance_transactionsSql, map[string]*bintree{}},
+		"03_sep12_customers.sql":         &bintree{migrations_compliance03_sep12_customersSql, map[string]*bintree{}},
+		"04_auth_nonces.sql":             &bintree{migrations_compliance04_auth_noncesSql, map[string]*bintree{}},
+	}},
+	"migrations_gateway": &bintree{nil, map[string]*bintree{
+		"01_init.sql":                         &bintree{migrations_gateway01_initSql, map[string]*bintree{}},
+		"02_receiving_accounts.sql":           &bintree{migrations_gateway02_receiving_accountsSql, map[string]*bintree{}},
+		"03_callback_attempts.sql":            &bintree{migrations_gateway03_callback_attemptsSql, map[string]*bintree{}},
+		"04_api_keys.sql":                     &bintree{migrations_gateway04_api_keysSql, map[string]*bintree{}},
+		"05_pending_transactions.sql":         &bintree{migrations_gateway05_pending_transactionsSql, map[string]*bintree{}},
+		"06_listener_leases.sql":              &bintree{migrations_gateway06_listener_leasesSql, map[string]*bintree{}},
+		"07_callback_deliveries.sql":          &bintree{migrations_gateway07_callback_deliveriesSql, map[string]*bintree{}},
+		"08_pending_compliance.sql":           &bintree{migrations_gateway08_pending_complianceSql, map[string]*bintree{}},
+		"09_sent_transaction_payment.sql":     &bintree{migrations_gateway09_sent_transaction_paymentSql, map[string]*bintree{}},
+		"10_transfer_transactions.sql":        &bintree{migrations_gateway10_transfer_transactionsSql, map[string]*bintree{}},
+		"11_received_payment_transaction.sql": &bintree{migrations_gateway11_received_payment_transactionSql, map[string]*bintree{}},
+		"12_pending_transaction_version.sql":  &bintree{migrations_gateway12_pending_transaction_versionSql, map[string]*bintree{}},
+	}},
+}}
+
+// RestoreAsset restores an asset under the given directory
+func RestoreAsset(dir, name string) error {
+	data, err := Asset(name)
+	if err != nil {
+		return err
+	}
+	info, err := AssetInfo(name)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(_filePath(dir, filepath.Dir(name)), os.FileMode(0755))
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(_filePath(dir, name), data, info.Mode())
+	if err != nil {
+		return err
+	}
+	err = os.Chtimes(_filePath(dir, name), info.ModTime(), info.ModTime())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// RestoreAssets restores an asset under the given directory recursively
+func RestoreAssets(dir, name string) error {
+	children, err := AssetDir(name)
+	// File
+	if err != nil {
+		return RestoreAsset(dir, name)
+	}
+	// Dir
+	for _, child := range children {
+		err = RestoreAssets(dir, filepath.Join(name, child))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func _filePath(dir, name string) string {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	return filepath.Join(append([]string{dir}, strings.Split(cannonicalName, "/")...)...)
+}
@@ -0,0 +1,306 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	// To load sqlite3 driver
+	_ "github.com/mattn/go-sqlite3"
+	migrate "github.com/rubenv/sql-migrate"
+	"github.com/stellar/gateway/db"
+	"github.com/stellar/gateway/db/entities"
+)
+
+//go:generate go-bindata -ignore .+\.go$ -pkg sqlite -o bindata.go ./migrations_gateway ./migrations_compliance
+
+// Driver implements Driver interface using SQLite connection
+type Driver struct {
+	database *sqlx.DB
+}
+
+// Init initializes DB connection. url is a path to a database file.
+func (d *Driver) Init(url string) (err error) {
+	d.database, err = sqlx.Connect("sqlite3", url)
+	if err != nil {
+		return
+	}
+
+	// SQLite allows a single writer at a time. Sharing one connection prevents
+	// `database is locked` errors (and keeps a single `:memory:` database).
+	d.database.SetMaxOpenConns(1)
+	return
+}
+
+func (d *Driver) DB() *sqlx.DB {
+	return d.database
+}
+
+// Migrate applies (migrate.Up) or reverts (migrate.Down) at most max migrations of component.
+// All migrations are applied or reverted when max is 0.
+func (d *Driver) Migrate(component string, direction migrate.MigrationDirection, max int) (migrationsCount int, err error) {
+	source := d.getAssetMigrationSource(component)
+	migrationsCount, err = migrate.ExecMax(d.database.DB, "sqlite3", source, direction, max)
+	return
+}
+
+// MigrationStatus returns statuses of component migrations
+func (d *Driver) MigrationStatus(component string) ([]db.MigrationStatus, error) {
+	return db.GetMigrationStatus(d.database.DB, "sqlite3", d.getAssetMigrationSource(component))
+}
+
+// Insert inserts the entity to a DB
+func (d *Driver) Insert(object entities.Entity) (id int64, err error) {
+	value, tableName, err := getTypeData(object)
+
+	if err != nil {
+		return 0, err
+	}
+
+	fieldsCount := value.NumField()
+	var fieldNames []string
+	var fieldValues []string
+
+	for i := 0; i < fieldsCount; i++ {
+		field := value.Field(i)
+		tag := field.Tag.Get("db")
+		if tag == "" {
+			continue
+		}
+		fieldNames = append(fieldNames, tag)
+		fieldValues = append(fieldValues, ":"+tag)
+	}
+
+	query := "INSERT INTO " + tableName + " (" + strings.Join(fieldNames, ", ") + ") VALUES (" + strings.Join(fieldValues, ", ") + ");"
+
+	var result sql.Result
+	switch object := object.(type) {
+	case *entities.AuthorizedTransaction:
+		result, err = d.database.NamedExec(query, object)
+	case *entities.AllowedFi:
+		result, err = d.database.NamedExec(query, object)
+	case *entities.AllowedUser:
+		result, err = d.database.NamedExec(query, object)
+	case *entities.SentTransaction:
+		result, err = d.database.NamedExec(query, object)
+	case *entities.ReceivedPayment:
+		result, err = d.database.NamedExec(query, object)
+	case *entities.Customer:
+		result, err = d.database.NamedExec(query, object)
+	case *entities.PaymentLink:
+		result, err = d.database.NamedExec(query, object)
+	case *entities.CallbackRetry:
+		result, err = d.database.NamedExec(query, object)
+	case *entities.PendingCompliance:
+		result, err = d.database.NamedExec(query, object)
+	case *entities.CallbackAttempt:
+		result, err = d.database.NamedExec(query, object)
+	case *entities.APIKey:
+		result, err = d.database.NamedExec(query, object)
+	case *entities.PendingTransaction:
+		result, err = d.database.NamedExec(query, object)
+	case *entities.LimitCounter:
+		result, err = d.database.NamedExec(query, object)
+	case *entities.Sep31Transaction:
+		result, err = d.database.NamedExec(query, object)
+	case *entities.TransferTransaction:
+		result, err = d.database.NamedExec(query, object)
+	case *entities.KYCCustomer:
+		result, err = d.database.NamedExec(query, object)
+	case *entities.ComplianceTransaction:
+		result, err = d.database.NamedExec(query, object)
+	case *entities.Sep12Customer:
+		result, err = d.database.NamedExec(query, object)
+	}
+
+	if err != nil {
+		return
+	}
+
+	id, err = result.LastInsertId()
+
+	if id == 0 {
+		// Not autoincrement
+		if object.GetID() == nil {
+			return 0, fmt.Errorf("Not autoincrement but ID nil")
+		}
+		id = *object.GetID()
+	}
+
+	if err == nil {
+		object.SetID(id)
+		object.SetExists()
+	}
+
+	return
+}
+
+// Update updates the entity to a DB
+func (d *Driver) Update(object entities.Entity) (err error) {
+	value, tableName, err := getTypeData(object)
+
+	if err != nil {
+		return err
+	}
+
+	fieldsCount := value.NumField()
+
+	query := "UPDATE " + tableName + " SET "
+	var fields []string
+
+	for i := 0; i < fieldsCount; i++ {
+		field := value.Field(i)
+		if field.Tag.Get("db") == "id" || field.Tag.Get("db") == "" {
+			continue
+		}
+		fields = append(fields, field.Tag.Get("db")+" = :"+field.Tag.Get("db"))
+	}
+
+	query += strings.Join(fields, ", ") + " WHERE id = :id;"
+
+	switch object := object.(type) {
+	case *entities.AuthorizedTransaction:
+		_, err = d.database.NamedExec(query, object)
+	case *entities.AllowedFi:
+		_, err = d.database.NamedExec(query, object)
+	case *entities.AllowedUser:
+		_, err = d.database.NamedExec(query, object)
+	case *entities.SentTransaction:
+		_, err = d.database.NamedExec(query, object)
+	case *entities.ReceivedPayment:
+		_, err = d.database.NamedExec(query, object)
+	case *entities.Customer:
+		_, err = d.database.NamedExec(query, object)
+	case *entities.PaymentLink:
+		_, err = d.database.NamedExec(query, object)
+	case *entities.CallbackRetry:
+		_, err = d.database.NamedExec(query, object)
+	case *entities.PendingCompliance:
+		_, err = d.database.NamedExec(query, object)
+	case *entities.CallbackAttempt:
+		_, err = d.database.NamedExec(query, object)
+	case *entities.APIKey:
+		_, err = d.database.NamedExec(query, object)
+	case *entities.PendingTransaction:
+		_, err = d.database.NamedExec(query, object)
+	case *entities.LimitCounter:
+		_, err = d.database.NamedExec(query, object)
+	case *entities.Sep31Transaction:
+		_, err = d.database.NamedExec(query, object)
+	case *entities.TransferTransaction:
+		_, err = d.database.NamedExec(query, object)
+	case *entities.KYCCustomer:
+		_, err = d.database.NamedExec(query, object)
+	case *entities.ComplianceTransaction:
+		_, err = d.database.NamedExec(query, object)
+	case *entities.Sep12Customer:
+		_, err = d.database.NamedExec(query, object)
+	}
+
+	return
+}
+
+// Delete delets the entity from a DB
+func (d *Driver) Delete(object entities.Entity) (err error) {
+	_, tableName, err := getTypeData(object)
+
+	if err != nil {
+		return
+	}
+
+	query := "DELETE FROM " + tableName + " WHERE id = :id;"
+	_, err = d.database.NamedExec(query, object)
+
+	return
+}
+
+// GetOne returns a single entity based on a seach conditions
+func (d *Driver) GetOne(object entities.Entity, where string, params ...interface{}) (entities.Entity, error) {
+	_, tableName, err := getTypeData(object)
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.database.Get(object, "SELECT * FROM "+tableName+" WHERE "+where+" LIMIT 1;", params...)
+	if err != nil {
+		if err.Error() == "sql: no rows in result set" {
+			return nil, nil
+		}
+		return nil, err
+	}
+	object.SetExists() // Mark this entity as existing
+	return object, err
+}
+
+func getTypeData(object interface{}) (typeValue reflect.Type, tableName string, err error) {
+	switch object := object.(type) {
+	case *entities.AuthorizedTransaction:
+		typeValue = reflect.TypeOf(*object)
+		tableName = "AuthorizedTransaction"
+	case *entities.AllowedFi:
+		typeValue = reflect.TypeOf(*object)
+		tableName = "AllowedFi"
+	case *entities.AllowedUser:
+		typeValue = reflect.TypeOf(*object)
+		tableName = "AllowedUser"
+	case *entities.SentTransaction:
+		typeValue = reflect.TypeOf(*object)
+		tableName = "SentTransaction"
+	case *entities.ReceivedPayment:
+		typeValue = reflect.TypeOf(*object)
+		tableName = "ReceivedPayment"
+	case *entities.Customer:
+		typeValue = reflect.TypeOf(*object)
+		tableName = "Customer"
+	case *entities.PaymentLink:
+		typeValue = reflect.TypeOf(*object)
+		tableName = "PaymentLink"
+	case *entities.CallbackRetry:
+		typeValue = reflect.TypeOf(*object)
+		tableName = "CallbackRetry"
+	case *entities.PendingCompliance:
+		typeValue = reflect.TypeOf(*object)
+		tableName = "PendingCompliance"
+	case *entities.CallbackAttempt:
+		typeValue = reflect.TypeOf(*object)
+		tableName = "CallbackAttempt"
+	case *entities.APIKey:
+		typeValue = reflect.TypeOf(*object)
+		tableName = "APIKey"
+	case *entities.PendingTransaction:
+		typeValue = reflect.TypeOf(*object)
+		tableName = "PendingTransaction"
+	case *entities.LimitCounter:
+		typeValue = reflect.TypeOf(*object)
+		tableName = "LimitCounter"
+	case *entities.Sep31Transaction:
+		typeValue = reflect.TypeOf(*object)
+		tableName = "Sep31Transaction"
+	case *entities.TransferTransaction:
+		typeValue = reflect.TypeOf(*object)
+		tableName = "TransferTransaction"
+	case *entities.KYCCustomer:
+		typeValue = reflect.TypeOf(*object)
+		tableName = "KYCCustomer"
+	case *entities.ComplianceTransaction:
+		typeValue = reflect.TypeOf(*object)
+		tableName = "ComplianceTransaction"
+	case *entities.Sep12Customer:
+		typeValue = reflect.TypeOf(*object)
+		tableName = "Sep12Customer"
+	default:
+		return typeValue, tableName, fmt.Errorf("Unknown entity type: %T", object)
+	}
+	return
+}
+
+func (d *Driver) getAssetMigrationSource(component string) (source *migrate.AssetMigrationSource) {
+	source = &migrate.AssetMigrationSource{
+		Asset:    Asset,
+		AssetDir: AssetDir,
+		Dir:      "migrations_" + component,
+	}
+	return
+}
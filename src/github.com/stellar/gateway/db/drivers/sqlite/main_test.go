@@ -0,0 +1,569 @@
+package sqlite_test
+
+import (
+	"testing"
+	"time"
+
+	migrate "github.com/rubenv/sql-migrate"
+	"github.com/stellar/gateway/db"
+	"github.com/stellar/gateway/db/drivers/sqlite"
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDriver(t *testing.T, component string) *sqlite.Driver {
+	driver := &sqlite.Driver{}
+	require.NoError(t, driver.Init(":memory:"))
+
+	applied, err := driver.Migrate(component, migrate.Up, 0)
+	require.NoError(t, err)
+	assert.True(t, applied > 0)
+	return driver
+}
+
+func TestMigrations(t *testing.T) {
+	for _, component := range []string{"gateway", "compliance"} {
+		driver := newDriver(t, component)
+
+		statuses, err := driver.MigrationStatus(component)
+		require.NoError(t, err)
+		require.NotEmpty(t, statuses)
+		for _, status := range statuses {
+			assert.NotNil(t, status.AppliedAt, status.ID)
+		}
+
+		reverted, err := driver.Migrate(component, migrate.Down, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 1, reverted)
+
+		statuses, err = driver.MigrationStatus(component)
+		require.NoError(t, err)
+		assert.Nil(t, statuses[len(statuses)-1].AppliedAt)
+	}
+}
+
+func TestEntities(t *testing.T) {
+	driver := newDriver(t, "gateway")
+	entityManager := db.NewEntityManager(driver)
+	repository := db.NewRepository(driver).ForTenant("acme")
+
+	processedAt := time.Unix(1500000000, 0).UTC()
+	payment := &entities.ReceivedPayment{
+		OperationID:      "100",
+		ProcessedAt:      processedAt,
+		PagingToken:      "100",
+		Status:           entities.ReceivedPaymentStatusSuccess,
+		Tenant:           "acme",
+		ReceivingAccount: "GATKP6ZQM5CSLECPMTAC5226PE367QALCPM6AFHTSULPPZMT62OOPMQB",
+	}
+	require.NoError(t, entityManager.Persist(payment))
+	require.NotNil(t, payment.ID)
+
+	found, err := repository.GetReceivedPaymentByOperationID("100")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, *payment.ID, *found.ID)
+	assert.True(t, processedAt.Equal(found.ProcessedAt))
+
+	found.Status = "failure"
+	require.NoError(t, entityManager.Persist(found))
+
+	cursor, err := repository.GetLastCursorValue(payment.ReceivingAccount)
+	require.NoError(t, err)
+	assert.Equal(t, "100", *cursor)
+
+	// Payments received by other accounts are not used
+	cursor, err = repository.GetLastCursorValue("GBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFZYYQ")
+	require.NoError(t, err)
+	assert.Nil(t, cursor)
+
+	found, err = repository.GetReceivedPaymentByID(*payment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "failure", found.Status)
+
+	// Other tenant payments are not visible
+	found, err = db.NewRepository(driver).GetReceivedPaymentByOperationID("100")
+	require.NoError(t, err)
+	assert.Nil(t, found)
+
+	require.NoError(t, entityManager.Delete(payment))
+	found, err = repository.GetReceivedPaymentByID(*payment.ID)
+	require.NoError(t, err)
+	assert.Nil(t, found)
+}
+
+func TestGetFederationRecord(t *testing.T) {
+	driver := newDriver(t, "gateway")
+	entityManager := db.NewEntityManager(driver)
+	repository := db.NewRepository(driver)
+
+	customer := &entities.Customer{MemoType: "id", Memo: "42", CustomerID: "bob", CreatedAt: time.Now()}
+	require.NoError(t, entityManager.Persist(customer))
+
+	query := "SELECT 'GBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFZYYQ' AS account_id, memo_type, memo, id FROM Customer WHERE customer_id = ?"
+	record, err := repository.GetFederationRecord(query, "bob")
+	require.NoError(t, err)
+	require.NotNil(t, record)
+	assert.Equal(t, entities.FederationRecord{
+		AccountID: "GBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFZYYQ",
+		MemoType:  "id",
+		Memo:      "42",
+	}, *record)
+
+	record, err = repository.GetFederationRecord(query, "alice")
+	require.NoError(t, err)
+	assert.Nil(t, record)
+}
+
+func TestGetCallbackAttempts(t *testing.T) {
+	driver := newDriver(t, "gateway")
+	entityManager := db.NewEntityManager(driver)
+	repository := db.NewRepository(driver)
+
+	start := time.Date(2017, 7, 14, 0, 0, 0, 0, time.UTC)
+	statusOK, statusError := 200, 500
+	attempts := []*entities.CallbackAttempt{
+		{OperationID: "100", Attempt: 1, URL: "http://callback", StatusCode: &statusError, CreatedAt: start},
+		{OperationID: "100", Attempt: 2, URL: "http://callback", Success: true, StatusCode: &statusOK, CreatedAt: start.Add(time.Hour)},
+		{OperationID: "101", Attempt: 1, URL: "http://callback", Success: true, StatusCode: &statusOK, CreatedAt: start.Add(2 * time.Hour)},
+	}
+	for _, attempt := range attempts {
+		require.NoError(t, entityManager.Persist(attempt))
+	}
+	// Attempts of other tenants are not returned
+	require.NoError(t, entityManager.Persist(&entities.CallbackAttempt{OperationID: "100", Attempt: 1, Tenant: "acme", CreatedAt: start}))
+
+	count, err := repository.CountCallbackAttempts("100")
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	ids := func(filter entities.CallbackAttemptFilter) []int64 {
+		filter.Limit = 10
+		found, err := repository.GetCallbackAttempts(filter)
+		require.NoError(t, err)
+		result := []int64{}
+		for _, attempt := range found {
+			result = append(result, *attempt.ID)
+		}
+		return result
+	}
+
+	success := true
+	since, until := start.Add(time.Hour), start.Add(2*time.Hour)
+	assert.Equal(t, []int64{*attempts[2].ID, *attempts[1].ID, *attempts[0].ID}, ids(entities.CallbackAttemptFilter{}))
+	assert.Equal(t, []int64{*attempts[1].ID, *attempts[0].ID}, ids(entities.CallbackAttemptFilter{OperationID: "100"}))
+	assert.Equal(t, []int64{*attempts[2].ID, *attempts[1].ID}, ids(entities.CallbackAttemptFilter{Success: &success}))
+	assert.Equal(t, []int64{*attempts[0].ID}, ids(entities.CallbackAttemptFilter{StatusCode: &statusError}))
+	assert.Equal(t, []int64{*attempts[1].ID}, ids(entities.CallbackAttemptFilter{Since: &since, Until: &until}))
+}
+
+func TestAPIKeys(t *testing.T) {
+	driver := newDriver(t, "gateway")
+	entityManager := db.NewEntityManager(driver)
+	// API keys are not scoped to a tenant
+	repository := db.NewRepository(driver).ForTenant("acme")
+
+	apiKey := &entities.APIKey{Name: "payments", KeyHash: "abc", Permissions: "payment,builder", CreatedAt: time.Now()}
+	require.NoError(t, entityManager.Persist(apiKey))
+
+	found, err := repository.GetAPIKeyByHash("abc")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, *apiKey.ID, *found.ID)
+	assert.Equal(t, []string{"payment", "builder"}, found.PermissionsList())
+
+	// Hashes are unique
+	assert.Error(t, entityManager.Persist(&entities.APIKey{Name: "other", KeyHash: "abc", Permissions: "admin", CreatedAt: time.Now()}))
+
+	all, err := repository.GetAPIKeys()
+	require.NoError(t, err)
+	assert.Len(t, all, 1)
+
+	require.NoError(t, entityManager.Delete(found))
+	found, err = repository.GetAPIKeyByID(*apiKey.ID)
+	require.NoError(t, err)
+	assert.Nil(t, found)
+}
+
+func TestListenerLease(t *testing.T) {
+	driver := newDriver(t, "gateway")
+	repository := db.NewRepository(driver).ForTenant("acme")
+	now := time.Unix(1500000000, 0)
+
+	acquired, err := repository.AcquireLease("payment_listener", "a", now, now.Add(30*time.Second))
+	require.NoError(t, err)
+	assert.True(t, acquired)
+
+	// Held by a
+	acquired, err = repository.AcquireLease("payment_listener", "b", now.Add(10*time.Second), now.Add(40*time.Second))
+	require.NoError(t, err)
+	assert.False(t, acquired)
+
+	// Other tenant
+	acquired, err = db.NewRepository(driver).AcquireLease("payment_listener", "b", now, now.Add(30*time.Second))
+	require.NoError(t, err)
+	assert.True(t, acquired)
+
+	// Renewed by a
+	acquired, err = repository.AcquireLease("payment_listener", "a", now.Add(10*time.Second), now.Add(40*time.Second))
+	require.NoError(t, err)
+	assert.True(t, acquired)
+
+	// Expired
+	acquired, err = repository.AcquireLease("payment_listener", "b", now.Add(41*time.Second), now.Add(71*time.Second))
+	require.NoError(t, err)
+	assert.True(t, acquired)
+
+	// Released
+	require.NoError(t, repository.ReleaseLease("payment_listener", "a"))
+	acquired, err = repository.AcquireLease("payment_listener", "a", now.Add(42*time.Second), now.Add(72*time.Second))
+	require.NoError(t, err)
+	assert.False(t, acquired)
+	require.NoError(t, repository.ReleaseLease("payment_listener", "b"))
+	acquired, err = repository.AcquireLease("payment_listener", "a", now.Add(42*time.Second), now.Add(72*time.Second))
+	require.NoError(t, err)
+	assert.True(t, acquired)
+}
+
+func TestCallbackDeliveries(t *testing.T) {
+	driver := newDriver(t, "gateway")
+	repository := db.NewRepository(driver).ForTenant("acme")
+	now := time.Unix(1500000000, 0)
+
+	delivered, err := repository.IsCallbackDelivered("1234-abcd")
+	require.NoError(t, err)
+	assert.False(t, delivered)
+
+	require.NoError(t, repository.SaveCallbackDelivery("1234-abcd", "1234", "http://acme.com/receive", now))
+	delivered, err = repository.IsCallbackDelivered("1234-abcd")
+	require.NoError(t, err)
+	assert.True(t, delivered)
+
+	// Saved again (unique constraint)
+	require.NoError(t, repository.SaveCallbackDelivery("1234-abcd", "1234", "http://acme.com/receive", now))
+
+	// Other tenant
+	delivered, err = db.NewRepository(driver).IsCallbackDelivered("1234-abcd")
+	require.NoError(t, err)
+	assert.False(t, delivered)
+}
+
+func TestComplianceTransactions(t *testing.T) {
+	driver := newDriver(t, "compliance")
+	entityManager := db.NewEntityManager(driver)
+	repository := db.NewRepository(driver)
+
+	start := time.Date(2017, 7, 14, 0, 0, 0, 0, time.UTC)
+	transactions := []*entities.ComplianceTransaction{
+		{Direction: entities.ComplianceDirectionSent, TransactionID: "a1", CounterpartyDomain: "acme.com", Status: entities.ComplianceStatusApproved, CreatedAt: start, UpdatedAt: start},
+		{Direction: entities.ComplianceDirectionReceived, TransactionID: "a1", CounterpartyDomain: "acme.com", Status: entities.ComplianceStatusDenied, CreatedAt: start.Add(time.Hour), UpdatedAt: start},
+		{Direction: entities.ComplianceDirectionReceived, TransactionID: "b2", CounterpartyDomain: "other.com", Status: entities.ComplianceStatusPending, CreatedAt: start.Add(2 * time.Hour), UpdatedAt: start},
+	}
+	for _, transaction := range transactions {
+		require.NoError(t, entityManager.Persist(transaction))
+	}
+
+	found, err := repository.GetComplianceTransactionByTransactionID(entities.ComplianceDirectionReceived, "a1")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, *transactions[1].ID, *found.ID)
+
+	found.Status = entities.ComplianceStatusCompleted
+	require.NoError(t, entityManager.Persist(found))
+	found, err = repository.GetComplianceTransactionByID(*transactions[1].ID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.ComplianceStatusCompleted, found.Status)
+
+	found, err = repository.GetComplianceTransactionByTransactionID(entities.ComplianceDirectionSent, "b2")
+	require.NoError(t, err)
+	assert.Nil(t, found)
+
+	ids := func(filter entities.ComplianceTransactionFilter) []int64 {
+		filter.Limit = 10
+		found, err := repository.GetComplianceTransactions(filter)
+		require.NoError(t, err)
+		result := []int64{}
+		for _, transaction := range found {
+			result = append(result, *transaction.ID)
+		}
+		return result
+	}
+
+	since, until := start.Add(time.Hour), start.Add(2*time.Hour)
+	assert.Equal(t, []int64{*transactions[2].ID, *transactions[1].ID, *transactions[0].ID}, ids(entities.ComplianceTransactionFilter{}))
+	assert.Equal(t, []int64{*transactions[1].ID, *transactions[0].ID}, ids(entities.ComplianceTransactionFilter{CounterpartyDomain: "acme.com"}))
+	assert.Equal(t, []int64{*transactions[2].ID, *transactions[1].ID}, ids(entities.ComplianceTransactionFilter{Direction: entities.ComplianceDirectionReceived}))
+	assert.Equal(t, []int64{*transactions[1].ID}, ids(entities.ComplianceTransactionFilter{Status: entities.ComplianceStatusCompleted}))
+	assert.Equal(t, []int64{*transactions[1].ID}, ids(entities.ComplianceTransactionFilter{TransactionID: "a1", Since: &since, Until: &until}))
+}
+
+func TestQueueDepths(t *testing.T) {
+	driver := newDriver(t, "gateway")
+	entityManager := db.NewEntityManager(driver)
+	repository := db.NewRepository(driver).ForTenant("acme")
+	now := time.Unix(1500000000, 0).UTC()
+
+	require.NoError(t, repository.Ping())
+
+	payment := &entities.ReceivedPayment{
+		OperationID: "100",
+		ProcessedAt: now,
+		PagingToken: "100",
+		Status:      entities.ReceivedPaymentStatusDeadLetter,
+		Tenant:      "acme",
+	}
+	require.NoError(t, entityManager.Persist(payment))
+	require.NoError(t, entityManager.Persist(&entities.CallbackRetry{ReceivedPaymentID: *payment.ID, NextAttemptAt: now, CreatedAt: now, Tenant: "acme"}))
+	for _, status := range []string{entities.PendingComplianceStatusPending, entities.PendingComplianceStatusApproved} {
+		require.NoError(t, entityManager.Persist(&entities.PendingCompliance{
+			Tenant:        "acme",
+			Status:        status,
+			NextAttemptAt: now,
+			ExpiresAt:     now.Add(time.Hour),
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}))
+	}
+
+	depths, err := repository.GetQueueDepths()
+	require.NoError(t, err)
+	assert.Equal(t, entities.QueueDepths{CallbackRetries: 1, DeadLetters: 1, PendingCompliance: 1}, depths)
+
+	due, err := repository.GetDuePendingCompliance(now, 10)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	assert.False(t, due[0].IsNew())
+
+	due, err = repository.GetDuePendingCompliance(now.Add(-time.Second), 10)
+	require.NoError(t, err)
+	assert.Empty(t, due)
+
+	// Other tenant
+	depths, err = db.NewRepository(driver).GetQueueDepths()
+	require.NoError(t, err)
+	assert.Equal(t, entities.QueueDepths{}, depths)
+}
+
+func TestPaymentsExport(t *testing.T) {
+	driver := newDriver(t, "gateway")
+	entityManager := db.NewEntityManager(driver)
+	repository := db.NewRepository(driver)
+
+	start := time.Date(2017, 7, 14, 0, 0, 0, 0, time.UTC)
+	received := []*entities.ReceivedPayment{
+		{OperationID: "100", ProcessedAt: start, PagingToken: "100", Status: entities.ReceivedPaymentStatusSuccess, Amount: "10"},
+		{OperationID: "101", ProcessedAt: start.Add(time.Hour), PagingToken: "101", Status: entities.ReceivedPaymentStatusSuccess, Amount: "20", AssetCode: "USD", AssetIssuer: "GBSTRH4QOTWNSVA6E4HFERETX4ZLSR3CIUBLK7AXYII277PFJC4BBYOG"},
+		{OperationID: "102", ProcessedAt: start.Add(2 * time.Hour), PagingToken: "102", Status: entities.ReceivedPaymentStatusRefunded, Amount: "30"},
+	}
+	for _, payment := range received {
+		require.NoError(t, entityManager.Persist(payment))
+	}
+	require.NoError(t, entityManager.Persist(&entities.ReceivedPayment{OperationID: "100", ProcessedAt: start, Status: entities.ReceivedPaymentStatusSuccess, Tenant: "acme"}))
+
+	receivedIDs := func(filter entities.ReceivedPaymentFilter) []int64 {
+		filter.Limit = 10
+		found, err := repository.GetReceivedPayments(filter)
+		require.NoError(t, err)
+		result := []int64{}
+		for _, payment := range found {
+			result = append(result, *payment.ID)
+		}
+		return result
+	}
+
+	native := ""
+	since, until := start.Add(time.Hour), start.Add(2*time.Hour)
+	assert.Equal(t, []int64{*received[0].ID, *received[1].ID, *received[2].ID}, receivedIDs(entities.ReceivedPaymentFilter{}))
+	assert.Equal(t, []int64{*received[1].ID, *received[2].ID}, receivedIDs(entities.ReceivedPaymentFilter{Cursor: *received[0].ID}))
+	assert.Equal(t, []int64{*received[2].ID}, receivedIDs(entities.ReceivedPaymentFilter{Status: entities.ReceivedPaymentStatusRefunded}))
+	assert.Equal(t, []int64{*received[0].ID, *received[2].ID}, receivedIDs(entities.ReceivedPaymentFilter{AssetCode: &native, AssetIssuer: &native}))
+	assert.Equal(t, []int64{*received[1].ID}, receivedIDs(entities.ReceivedPaymentFilter{Since: &since, Until: &until}))
+
+	destination, amount, asset := "GAMVF7G4GJC4A7JMFJWLUAEIBFQD5RT3DCB5DC5TJDEKQBBACQ4JZVEE", "100", "native"
+	sent := []*entities.SentTransaction{
+		{TransactionID: "a", Status: entities.SentTransactionStatusSuccess, SubmittedAt: start, Destination: &destination, Amount: &amount, Asset: &asset},
+		{TransactionID: "b", Status: entities.SentTransactionStatusFailure, SubmittedAt: start.Add(time.Hour), Destination: &destination, Amount: &amount, Asset: &asset},
+		// Sent before payment details were stored
+		{TransactionID: "c", Status: entities.SentTransactionStatusSuccess, SubmittedAt: start.Add(time.Hour)},
+	}
+	for _, transaction := range sent {
+		require.NoError(t, entityManager.Persist(transaction))
+	}
+
+	sentIDs := func(filter entities.SentTransactionFilter) []int64 {
+		filter.Limit = 10
+		found, err := repository.GetSentPayments(filter)
+		require.NoError(t, err)
+		result := []int64{}
+		for _, transaction := range found {
+			result = append(result, *transaction.ID)
+		}
+		return result
+	}
+
+	assert.Equal(t, []int64{*sent[0].ID, *sent[1].ID}, sentIDs(entities.SentTransactionFilter{}))
+	assert.Equal(t, []int64{*sent[1].ID}, sentIDs(entities.SentTransactionFilter{Cursor: *sent[0].ID}))
+	assert.Equal(t, []int64{*sent[0].ID}, sentIDs(entities.SentTransactionFilter{Status: entities.SentTransactionStatusSuccess}))
+	assert.Equal(t, []int64{}, sentIDs(entities.SentTransactionFilter{Asset: "USD:GBSTRH4QOTWNSVA6E4HFERETX4ZLSR3CIUBLK7AXYII277PFJC4BBYOG"}))
+	assert.Equal(t, []int64{*sent[1].ID}, sentIDs(entities.SentTransactionFilter{Since: &since}))
+}
+
+func TestTransferTransactions(t *testing.T) {
+	driver := newDriver(t, "gateway")
+	entityManager := db.NewEntityManager(driver)
+	repository := db.NewRepository(driver)
+
+	memoType, memo := "hash", "bWVtbw=="
+	for _, kind := range []string{entities.TransferKindDeposit, entities.TransferKindWithdrawal} {
+		require.NoError(t, entityManager.Persist(&entities.TransferTransaction{
+			PublicID:    kind,
+			Kind:        kind,
+			Status:      entities.TransferStatusPendingUserTransferStart,
+			AssetCode:   "USD",
+			AssetIssuer: "GATKP6ZQM5CSLECPMTAC5226PE367QALCPM6AFHTSULPPZMT62OOPMQB",
+			Account:     "GBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFZYYQ",
+			Fields:      "{}",
+			MemoType:    &memoType,
+			Memo:        &memo,
+			StartedAt:   time.Now(),
+		}))
+	}
+
+	found, err := repository.GetTransferTransactionByPublicID(entities.TransferKindDeposit)
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, entities.TransferKindDeposit, found.Kind)
+
+	// Deposits with the same memo are not matched with received payments
+	found, err = repository.GetWithdrawalByMemo(memoType, memo)
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, entities.TransferKindWithdrawal, found.PublicID)
+
+	found.Status = entities.TransferStatusCompleted
+	require.NoError(t, entityManager.Persist(found))
+	found, err = repository.GetTransferTransactionByPublicID(entities.TransferKindWithdrawal)
+	require.NoError(t, err)
+	assert.Equal(t, entities.TransferStatusCompleted, found.Status)
+
+	found, err = db.NewRepository(driver).ForTenant("acme").GetTransferTransactionByPublicID(entities.TransferKindDeposit)
+	require.NoError(t, err)
+	assert.Nil(t, found)
+}
+
+func TestSep12Customers(t *testing.T) {
+	driver := newDriver(t, "compliance")
+	entityManager := db.NewEntityManager(driver)
+	repository := db.NewRepository(driver)
+
+	account := "GBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFZYYQ"
+	now := time.Unix(1500000000, 0).UTC()
+	customers := []*entities.Sep12Customer{
+		{PublicID: "a1", Account: account, Fields: `{"first_name":"Alice"}`, Status: entities.Sep12StatusProcessing, CreatedAt: now, UpdatedAt: now},
+		{PublicID: "b2", Account: account, MemoType: "id", Memo: "42", Fields: "{}", Status: entities.Sep12StatusNeedsInfo, CreatedAt: now, UpdatedAt: now},
+	}
+	for _, customer := range customers {
+		require.NoError(t, entityManager.Persist(customer))
+	}
+
+	found, err := repository.GetSep12Customer(account, "id", "42")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, "b2", found.PublicID)
+
+	found, err = repository.GetSep12Customer(account, "", "")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, "a1", found.PublicID)
+
+	message := "Documents verified"
+	found.Status = entities.Sep12StatusAccepted
+	found.Message = &message
+	require.NoError(t, entityManager.Persist(found))
+	found, err = repository.GetSep12CustomerByPublicID("a1")
+	require.NoError(t, err)
+	assert.Equal(t, entities.Sep12StatusAccepted, found.Status)
+	assert.Equal(t, message, *found.Message)
+
+	require.NoError(t, entityManager.Delete(found))
+	found, err = repository.GetSep12CustomerByPublicID("a1")
+	require.NoError(t, err)
+	assert.Nil(t, found)
+}
+
+func TestAuthNonces(t *testing.T) {
+	driver := newDriver(t, "compliance")
+	repository := db.NewRepository(driver)
+	signingKey := "GBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFZYYQ"
+	now := time.Unix(1500000000, 0)
+
+	saved, err := repository.SaveAuthNonce(signingKey, "abc", now)
+	require.NoError(t, err)
+	assert.True(t, saved)
+
+	// Replay
+	saved, err = repository.SaveAuthNonce(signingKey, "abc", now)
+	require.NoError(t, err)
+	assert.False(t, saved)
+
+	// Nonces are unique per signing key
+	saved, err = repository.SaveAuthNonce("GBYJZW5XFAI6XV73H5SAIUYK6XZI4CGGVBUBO3ANA2SV7KKDAXTV6AEB", "abc", now)
+	require.NoError(t, err)
+	assert.True(t, saved)
+
+	require.NoError(t, repository.DeleteAuthNoncesBefore(now.Add(time.Second)))
+	saved, err = repository.SaveAuthNonce(signingKey, "abc", now)
+	require.NoError(t, err)
+	assert.True(t, saved)
+}
+
+func TestStatementCache(t *testing.T) {
+	driver := newDriver(t, "gateway")
+	db.ConfigurePool(driver, db.PoolOptions{MaxIdleConns: 1, ConnMaxLifetime: time.Minute})
+	entityManager := db.NewEntityManager(driver)
+	cache := db.NewStatementCache(driver.DB())
+	defer cache.Close()
+	repository := db.NewRepository(driver).ForTenant("acme").WithStatementCache(cache)
+
+	payment := &entities.ReceivedPayment{
+		OperationID:      "100",
+		ProcessedAt:      time.Unix(1500000000, 0).UTC(),
+		PagingToken:      "100",
+		Status:           entities.ReceivedPaymentStatusSuccess,
+		Tenant:           "acme",
+		ReceivingAccount: "GATKP6ZQM5CSLECPMTAC5226PE367QALCPM6AFHTSULPPZMT62OOPMQB",
+	}
+	require.NoError(t, entityManager.Persist(payment))
+
+	for i := 0; i < 2; i++ {
+		found, err := repository.GetReceivedPaymentByOperationID("100")
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, *payment.ID, *found.ID)
+	}
+
+	found, err := repository.GetReceivedPaymentByOperationID("101")
+	require.NoError(t, err)
+	assert.Nil(t, found)
+
+	cursor, err := repository.GetLastCursorValue(payment.ReceivingAccount)
+	require.NoError(t, err)
+	assert.Equal(t, "100", *cursor)
+
+	stmt, err := cache.Prepare("SELECT COUNT(*) FROM ReceivedPayment WHERE tenant = ?")
+	require.NoError(t, err)
+	cached, err := cache.Prepare("SELECT COUNT(*) FROM ReceivedPayment WHERE tenant = ?")
+	require.NoError(t, err)
+	assert.True(t, stmt == cached)
+
+	_, err = cache.Prepare("SELECT * FROM MissingTable")
+	assert.Error(t, err)
+
+	// Errors are wrapped like in Repo.GetRaw
+	_, err = driver.DB().Exec("DROP TABLE CallbackDelivery")
+	require.NoError(t, err)
+	_, err = repository.IsCallbackDelivered("1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "prepare failed")
+}
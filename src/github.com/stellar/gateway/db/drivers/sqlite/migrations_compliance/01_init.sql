@@ -0,0 +1,48 @@
+-- +migrate Up
+CREATE TABLE AuthorizedTransaction (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  transaction_id varchar(64) NOT NULL,
+  memo varchar(64) NOT NULL,
+  transaction_xdr text NOT NULL,
+  authorized_at timestamp NOT NULL,
+  data text NOT NULL
+);
+
+CREATE TABLE AllowedFI (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  name varchar(255) NOT NULL,
+  domain varchar(255) NOT NULL,
+  public_key char(56) NOT NULL,
+  allowed_at timestamp NOT NULL
+);
+CREATE UNIQUE INDEX afi_by_domain ON AllowedFI (domain);
+CREATE UNIQUE INDEX afi_by_public_key ON AllowedFI (public_key);
+
+CREATE TABLE AllowedUser (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  fi_name varchar(255) NOT NULL,
+  fi_domain varchar(255) NOT NULL,
+  fi_public_key char(56) NOT NULL,
+  user_id varchar(255) NOT NULL,
+  allowed_at timestamp NOT NULL
+);
+CREATE UNIQUE INDEX au_by_fi_public_key_user_id ON AllowedUser (fi_public_key, user_id);
+
+CREATE TABLE KYCCustomer (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  address varchar(255) NOT NULL,
+  route varchar(255) DEFAULT NULL,
+  info text NOT NULL,
+  status varchar(16) NOT NULL,
+  share_info boolean NOT NULL DEFAULT 0,
+  created_at timestamp NOT NULL,
+  updated_at timestamp NOT NULL
+);
+CREATE UNIQUE INDEX kyc_customer_address ON KYCCustomer (address);
+CREATE UNIQUE INDEX kyc_customer_route ON KYCCustomer (route);
+
+-- +migrate Down
+DROP TABLE KYCCustomer;
+DROP TABLE AllowedUser;
+DROP TABLE AllowedFI;
+DROP TABLE AuthorizedTransaction;
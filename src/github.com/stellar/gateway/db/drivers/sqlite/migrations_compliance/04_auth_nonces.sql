@@ -0,0 +1,11 @@
+-- +migrate Up
+CREATE TABLE AuthNonce (
+  signing_key varchar(56) NOT NULL,
+  nonce varchar(64) NOT NULL,
+  created_at timestamp NOT NULL,
+  PRIMARY KEY (signing_key, nonce)
+);
+CREATE INDEX auth_nonce_created_at ON AuthNonce (created_at);
+
+-- +migrate Down
+DROP TABLE AuthNonce;
@@ -0,0 +1,116 @@
+-- +migrate Up
+CREATE TABLE ReceivedPayment (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  operation_id varchar(255) NOT NULL,
+  processed_at timestamp NOT NULL,
+  paging_token varchar(255) NOT NULL,
+  status varchar(255) NOT NULL,
+  exchange_rate varchar(64) DEFAULT NULL,
+  converted_amount varchar(64) DEFAULT NULL,
+  converted_currency varchar(12) DEFAULT NULL,
+  tenant varchar(64) NOT NULL DEFAULT '',
+  from_address varchar(255) DEFAULT NULL,
+  from_account varchar(56) NOT NULL DEFAULT '',
+  amount varchar(64) NOT NULL DEFAULT '',
+  asset_code varchar(12) NOT NULL DEFAULT '',
+  asset_issuer varchar(56) NOT NULL DEFAULT '',
+  memo_type varchar(4) NOT NULL DEFAULT '',
+  memo varchar(255) NOT NULL DEFAULT '',
+  refund_transaction_id varchar(64) DEFAULT NULL,
+  resolution_reason text DEFAULT NULL,
+  resolved_by varchar(255) DEFAULT NULL,
+  resolved_at timestamp DEFAULT NULL
+);
+CREATE UNIQUE INDEX received_payment_operation_id ON ReceivedPayment (tenant, operation_id);
+
+CREATE TABLE SentTransaction (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  transaction_id varchar(64) NOT NULL,
+  status varchar(10) NOT NULL,
+  source varchar(56) NOT NULL,
+  submitted_at timestamp NOT NULL,
+  succeeded_at timestamp DEFAULT NULL,
+  ledger bigint DEFAULT NULL,
+  envelope_xdr text NOT NULL,
+  result_xdr varchar(255) DEFAULT NULL,
+  tenant varchar(64) NOT NULL DEFAULT '',
+  metadata text DEFAULT NULL,
+  idempotency_key varchar(128) DEFAULT NULL
+);
+CREATE UNIQUE INDEX sent_transaction_idempotency_key ON SentTransaction (tenant, idempotency_key);
+
+CREATE TABLE Customer (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  memo_type varchar(4) NOT NULL,
+  memo varchar(64) NOT NULL,
+  customer_id varchar(255) NOT NULL,
+  callback_url varchar(255) NOT NULL DEFAULT '',
+  created_at timestamp NOT NULL,
+  tenant varchar(64) NOT NULL DEFAULT ''
+);
+CREATE UNIQUE INDEX customer_memo ON Customer (tenant, memo_type, memo);
+
+CREATE TABLE PaymentLink (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  received_payment_id bigint NOT NULL,
+  transaction_id varchar(64) NOT NULL,
+  created_at timestamp NOT NULL,
+  tenant varchar(64) NOT NULL DEFAULT ''
+);
+CREATE INDEX payment_link_received_payment_id ON PaymentLink (received_payment_id);
+CREATE INDEX payment_link_transaction_id ON PaymentLink (tenant, transaction_id);
+
+CREATE TABLE CallbackRetry (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  received_payment_id bigint NOT NULL,
+  attempts integer NOT NULL,
+  next_attempt_at timestamp NOT NULL,
+  last_error text NOT NULL,
+  created_at timestamp NOT NULL,
+  tenant varchar(64) NOT NULL DEFAULT ''
+);
+CREATE UNIQUE INDEX callback_retry_received_payment_id ON CallbackRetry (received_payment_id);
+CREATE INDEX callback_retry_next_attempt_at ON CallbackRetry (tenant, next_attempt_at);
+
+CREATE TABLE LimitCounter (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  counter_key varchar(255) NOT NULL,
+  window_start timestamp NOT NULL,
+  amount bigint NOT NULL,
+  tenant varchar(64) NOT NULL DEFAULT ''
+);
+CREATE UNIQUE INDEX limit_counter_key ON LimitCounter (tenant, counter_key, window_start);
+
+CREATE TABLE Sep31Transaction (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  public_id varchar(36) NOT NULL,
+  status varchar(32) NOT NULL,
+  amount_in varchar(64) NOT NULL,
+  amount_fee varchar(64) NOT NULL,
+  amount_out varchar(64) NOT NULL,
+  asset_code varchar(12) NOT NULL,
+  asset_issuer varchar(56) NOT NULL,
+  sender_id varchar(255) NOT NULL,
+  receiver_id varchar(255) NOT NULL,
+  fields text NOT NULL,
+  client_account varchar(56) NOT NULL,
+  memo_type varchar(10) NOT NULL,
+  memo varchar(64) NOT NULL,
+  stellar_transaction_id varchar(64) DEFAULT NULL,
+  received_payment_id varchar(255) DEFAULT NULL,
+  status_message text DEFAULT NULL,
+  started_at timestamp NOT NULL,
+  completed_at timestamp DEFAULT NULL,
+  tenant varchar(64) NOT NULL DEFAULT ''
+);
+CREATE UNIQUE INDEX sep31_transaction_public_id ON Sep31Transaction (tenant, public_id);
+CREATE UNIQUE INDEX sep31_transaction_memo ON Sep31Transaction (tenant, memo_type, memo);
+
+-- +migrate Down
+DROP TABLE Sep31Transaction;
+DROP TABLE LimitCounter;
+DROP TABLE CallbackRetry;
+DROP TABLE PaymentLink;
+DROP TABLE Customer;
+DROP TABLE SentTransaction;
+DROP TABLE ReceivedPayment;
@@ -0,0 +1,13 @@
+-- +migrate Up
+CREATE TABLE APIKey (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  name varchar(255) NOT NULL,
+  key_hash char(64) NOT NULL,
+  tenant varchar(64) NOT NULL DEFAULT '',
+  permissions varchar(255) NOT NULL,
+  created_at timestamp NOT NULL
+);
+CREATE UNIQUE INDEX api_key_key_hash ON APIKey (key_hash);
+
+-- +migrate Down
+DROP TABLE APIKey;
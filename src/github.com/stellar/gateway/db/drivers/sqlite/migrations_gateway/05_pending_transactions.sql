@@ -0,0 +1,21 @@
+-- +migrate Up
+CREATE TABLE PendingTransaction (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  transaction_id char(64) NOT NULL,
+  status varchar(16) NOT NULL,
+  source char(56) NOT NULL,
+  envelope_xdr text NOT NULL,
+  required_weight integer NOT NULL,
+  signed_weight integer NOT NULL,
+  destination varchar(255) NOT NULL,
+  amount varchar(32) NOT NULL,
+  asset varchar(70) NOT NULL,
+  metadata text DEFAULT NULL,
+  created_at timestamp NOT NULL,
+  updated_at timestamp NOT NULL,
+  tenant varchar(64) NOT NULL DEFAULT ''
+);
+CREATE UNIQUE INDEX pending_transaction_tenant_transaction_id ON PendingTransaction (tenant, transaction_id);
+
+-- +migrate Down
+DROP TABLE PendingTransaction;
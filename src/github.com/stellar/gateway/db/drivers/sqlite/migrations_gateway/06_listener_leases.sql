@@ -0,0 +1,11 @@
+-- +migrate Up
+CREATE TABLE ListenerLease (
+  name varchar(64) NOT NULL,
+  tenant varchar(64) NOT NULL DEFAULT '',
+  holder varchar(255) NOT NULL,
+  expires_at timestamp NOT NULL,
+  PRIMARY KEY (tenant, name)
+);
+
+-- +migrate Down
+DROP TABLE ListenerLease;
@@ -0,0 +1,19 @@
+-- +migrate Up
+CREATE TABLE PendingCompliance (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  tenant varchar(64) NOT NULL DEFAULT '',
+  send_request text NOT NULL,
+  status varchar(10) NOT NULL,
+  tx_status varchar(10) NOT NULL DEFAULT '',
+  info_status varchar(10) NOT NULL DEFAULT '',
+  attempts integer NOT NULL,
+  next_attempt_at timestamp NOT NULL,
+  expires_at timestamp NOT NULL,
+  last_error text NOT NULL,
+  created_at timestamp NOT NULL,
+  updated_at timestamp NOT NULL
+);
+CREATE INDEX pending_compliance_next_attempt_at ON PendingCompliance (tenant, status, next_attempt_at);
+
+-- +migrate Down
+DROP TABLE PendingCompliance;
@@ -0,0 +1,27 @@
+-- +migrate Up
+ALTER TABLE SentTransaction ADD COLUMN destination varchar(255) DEFAULT NULL;
+ALTER TABLE SentTransaction ADD COLUMN amount varchar(32) DEFAULT NULL;
+ALTER TABLE SentTransaction ADD COLUMN asset varchar(70) DEFAULT NULL;
+
+-- +migrate Down
+-- SQLite cannot drop columns so the table is rebuilt without payment details
+CREATE TABLE SentTransaction_old (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  transaction_id varchar(64) NOT NULL,
+  status varchar(10) NOT NULL,
+  source varchar(56) NOT NULL,
+  submitted_at timestamp NOT NULL,
+  succeeded_at timestamp DEFAULT NULL,
+  ledger bigint DEFAULT NULL,
+  envelope_xdr text NOT NULL,
+  result_xdr varchar(255) DEFAULT NULL,
+  tenant varchar(64) NOT NULL DEFAULT '',
+  metadata text DEFAULT NULL,
+  idempotency_key varchar(128) DEFAULT NULL
+);
+INSERT INTO SentTransaction_old SELECT id, transaction_id, status, source, submitted_at,
+  succeeded_at, ledger, envelope_xdr, result_xdr, tenant, metadata, idempotency_key
+  FROM SentTransaction;
+DROP TABLE SentTransaction;
+ALTER TABLE SentTransaction_old RENAME TO SentTransaction;
+CREATE UNIQUE INDEX sent_transaction_idempotency_key ON SentTransaction (tenant, idempotency_key);
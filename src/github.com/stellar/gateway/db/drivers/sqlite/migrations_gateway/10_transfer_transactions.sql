@@ -0,0 +1,28 @@
+-- +migrate Up
+CREATE TABLE TransferTransaction (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  public_id varchar(36) NOT NULL,
+  kind varchar(10) NOT NULL,
+  status varchar(32) NOT NULL,
+  asset_code varchar(12) NOT NULL,
+  asset_issuer varchar(56) NOT NULL,
+  amount_in varchar(64) DEFAULT NULL,
+  amount_fee varchar(64) DEFAULT NULL,
+  amount_out varchar(64) DEFAULT NULL,
+  account varchar(56) NOT NULL,
+  type varchar(64) NOT NULL DEFAULT '',
+  fields text NOT NULL,
+  memo_type varchar(10) DEFAULT NULL,
+  memo varchar(64) DEFAULT NULL,
+  stellar_transaction_id varchar(64) DEFAULT NULL,
+  received_payment_id varchar(255) DEFAULT NULL,
+  status_message text DEFAULT NULL,
+  started_at timestamp NOT NULL,
+  completed_at timestamp DEFAULT NULL,
+  tenant varchar(64) NOT NULL DEFAULT ''
+);
+CREATE UNIQUE INDEX transfer_transaction_public_id ON TransferTransaction (tenant, public_id);
+CREATE INDEX transfer_transaction_memo ON TransferTransaction (tenant, memo_type, memo);
+
+-- +migrate Down
+DROP TABLE TransferTransaction;
@@ -0,0 +1,39 @@
+-- +migrate Up
+ALTER TABLE ReceivedPayment ADD COLUMN transaction_hash varchar(64) DEFAULT NULL;
+ALTER TABLE ReceivedPayment ADD COLUMN ledger bigint DEFAULT NULL;
+ALTER TABLE ReceivedPayment ADD COLUMN ledger_close_time timestamp DEFAULT NULL;
+ALTER TABLE ReceivedPayment ADD COLUMN fee_charged bigint DEFAULT NULL;
+ALTER TABLE ReceivedPayment ADD COLUMN transaction_source_account varchar(69) DEFAULT NULL;
+
+-- +migrate Down
+-- SQLite cannot drop columns so the table is rebuilt without transaction columns
+CREATE TABLE ReceivedPayment_old (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  operation_id varchar(255) NOT NULL,
+  processed_at timestamp NOT NULL,
+  paging_token varchar(255) NOT NULL,
+  status varchar(255) NOT NULL,
+  exchange_rate varchar(64) DEFAULT NULL,
+  converted_amount varchar(64) DEFAULT NULL,
+  converted_currency varchar(12) DEFAULT NULL,
+  tenant varchar(64) NOT NULL DEFAULT '',
+  from_address varchar(255) DEFAULT NULL,
+  from_account varchar(56) NOT NULL DEFAULT '',
+  amount varchar(64) NOT NULL DEFAULT '',
+  asset_code varchar(12) NOT NULL DEFAULT '',
+  asset_issuer varchar(56) NOT NULL DEFAULT '',
+  memo_type varchar(4) NOT NULL DEFAULT '',
+  memo varchar(255) NOT NULL DEFAULT '',
+  refund_transaction_id varchar(64) DEFAULT NULL,
+  resolution_reason text DEFAULT NULL,
+  resolved_by varchar(255) DEFAULT NULL,
+  resolved_at timestamp DEFAULT NULL,
+  receiving_account varchar(56) NOT NULL DEFAULT ''
+);
+INSERT INTO ReceivedPayment_old SELECT id, operation_id, processed_at, paging_token, status,
+  exchange_rate, converted_amount, converted_currency, tenant, from_address, from_account, amount,
+  asset_code, asset_issuer, memo_type, memo, refund_transaction_id, resolution_reason, resolved_by,
+  resolved_at, receiving_account FROM ReceivedPayment;
+DROP TABLE ReceivedPayment;
+ALTER TABLE ReceivedPayment_old RENAME TO ReceivedPayment;
+CREATE UNIQUE INDEX received_payment_operation_id ON ReceivedPayment (tenant, operation_id);
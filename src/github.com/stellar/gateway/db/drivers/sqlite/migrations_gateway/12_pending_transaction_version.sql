@@ -0,0 +1,27 @@
+-- +migrate Up
+ALTER TABLE PendingTransaction ADD COLUMN version bigint NOT NULL DEFAULT 0;
+
+-- +migrate Down
+-- SQLite cannot drop columns so the table is rebuilt without version column
+CREATE TABLE PendingTransaction_old (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  transaction_id char(64) NOT NULL,
+  status varchar(16) NOT NULL,
+  source char(56) NOT NULL,
+  envelope_xdr text NOT NULL,
+  required_weight integer NOT NULL,
+  signed_weight integer NOT NULL,
+  destination varchar(255) NOT NULL,
+  amount varchar(32) NOT NULL,
+  asset varchar(70) NOT NULL,
+  metadata text DEFAULT NULL,
+  created_at timestamp NOT NULL,
+  updated_at timestamp NOT NULL,
+  tenant varchar(64) NOT NULL DEFAULT ''
+);
+INSERT INTO PendingTransaction_old SELECT id, transaction_id, status, source, envelope_xdr,
+  required_weight, signed_weight, destination, amount, asset, metadata, created_at, updated_at,
+  tenant FROM PendingTransaction;
+DROP TABLE PendingTransaction;
+ALTER TABLE PendingTransaction_old RENAME TO PendingTransaction;
+CREATE UNIQUE INDEX pending_transaction_tenant_transaction_id ON PendingTransaction (tenant, transaction_id);
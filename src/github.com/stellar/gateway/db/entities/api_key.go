@@ -0,0 +1,52 @@
+package entities
+
+import (
+	"strings"
+	"time"
+)
+
+// APIKey is an API key created using admin API. Only a hash of the key is stored.
+type APIKey struct {
+	exists bool
+	ID     *int64 `db:"id"`
+	Name   string `db:"name"`
+	// KeyHash is a hex-encoded SHA-256 hash of the key
+	KeyHash string `db:"key_hash"`
+	// Tenant limits the key to endpoints of a single tenant when not empty
+	Tenant string `db:"tenant"`
+	// Permissions is a comma-separated list of permissions
+	Permissions string    `db:"permissions"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+// PermissionsList returns Permissions as a slice
+func (e *APIKey) PermissionsList() []string {
+	if e.Permissions == "" {
+		return nil
+	}
+	return strings.Split(e.Permissions, ",")
+}
+
+// GetID returns ID of the entity
+func (e *APIKey) GetID() *int64 {
+	if e.ID == nil {
+		return nil
+	}
+	newID := *e.ID
+	return &newID
+}
+
+// SetID sets ID of the entity
+func (e *APIKey) SetID(id int64) {
+	e.ID = &id
+}
+
+// IsNew returns true if the entity has not been persisted yet
+func (e *APIKey) IsNew() bool {
+	return !e.exists
+}
+
+// SetExists sets entity as persisted
+func (e *APIKey) SetExists() {
+	e.exists = true
+}
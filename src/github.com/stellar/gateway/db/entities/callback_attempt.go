@@ -0,0 +1,55 @@
+package entities
+
+import (
+	"time"
+)
+
+// CallbackAttempt is a single delivery attempt of a receive callback
+type CallbackAttempt struct {
+	exists      bool
+	ID          *int64    `db:"id"`
+	OperationID string    `db:"operation_id"`
+	Attempt     int       `db:"attempt"`
+	URL         string    `db:"url"`
+	Success     bool      `db:"success"`
+	StatusCode  *int      `db:"status_code"` // Not set when no HTTP response was received
+	LatencyMs   int64     `db:"latency_ms"`
+	Response    *string   `db:"response"` // Beginning of the error response body
+	Error       *string   `db:"error"`
+	CreatedAt   time.Time `db:"created_at"`
+	Tenant      string    `db:"tenant"`
+}
+
+// GetID returns ID of the entity
+func (e *CallbackAttempt) GetID() *int64 {
+	if e.ID == nil {
+		return nil
+	}
+	newID := *e.ID
+	return &newID
+}
+
+// SetID sets ID of the entity
+func (e *CallbackAttempt) SetID(id int64) {
+	e.ID = &id
+}
+
+// IsNew returns true if the entity has not been persisted yet
+func (e *CallbackAttempt) IsNew() bool {
+	return !e.exists
+}
+
+// SetExists sets entity as persisted
+func (e *CallbackAttempt) SetExists() {
+	e.exists = true
+}
+
+// CallbackAttemptFilter selects callback attempts. Zero values match all attempts.
+type CallbackAttemptFilter struct {
+	OperationID string
+	Success     *bool
+	StatusCode  *int
+	Since       *time.Time
+	Until       *time.Time
+	Limit       int
+}
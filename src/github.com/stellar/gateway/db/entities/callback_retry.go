@@ -0,0 +1,41 @@
+package entities
+
+import (
+	"time"
+)
+
+// CallbackRetry is an entry of the queue of receive callbacks that will be sent again
+type CallbackRetry struct {
+	exists            bool
+	ID                *int64    `db:"id"`
+	ReceivedPaymentID int64     `db:"received_payment_id"`
+	Attempts          int       `db:"attempts"` // Number of failed deliveries
+	NextAttemptAt     time.Time `db:"next_attempt_at"`
+	LastError         string    `db:"last_error"`
+	CreatedAt         time.Time `db:"created_at"`
+	Tenant            string    `db:"tenant"`
+}
+
+// GetID returns ID of the entity
+func (e *CallbackRetry) GetID() *int64 {
+	if e.ID == nil {
+		return nil
+	}
+	newID := *e.ID
+	return &newID
+}
+
+// SetID sets ID of the entity
+func (e *CallbackRetry) SetID(id int64) {
+	e.ID = &id
+}
+
+// IsNew returns true if the entity has not been persisted yet
+func (e *CallbackRetry) IsNew() bool {
+	return !e.exists
+}
+
+// SetExists sets entity as persisted
+func (e *CallbackRetry) SetExists() {
+	e.exists = true
+}
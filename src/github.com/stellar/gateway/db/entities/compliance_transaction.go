@@ -0,0 +1,78 @@
+package entities
+
+import (
+	"time"
+)
+
+// Directions of compliance transactions
+const (
+	ComplianceDirectionSent     = "sent"
+	ComplianceDirectionReceived = "received"
+)
+
+// Statuses of compliance transactions
+const (
+	ComplianceStatusPending   = "pending"
+	ComplianceStatusDenied    = "denied"
+	ComplianceStatusApproved  = "approved"
+	ComplianceStatusCompleted = "completed"
+)
+
+// ComplianceTransaction is a record of a compliance exchange with another FI: a transaction
+// sent using /send or received by the auth endpoint of the compliance server. Repeated
+// exchanges of the same transaction (ex. after a pending response) update the record.
+type ComplianceTransaction struct {
+	exists    bool
+	ID        *int64 `db:"id"`
+	Direction string `db:"direction"`
+	// TransactionID is a hex-encoded hash of the Stellar transaction
+	TransactionID string `db:"transaction_id"`
+	// Memo is a base64-encoded hash of the memo preimage
+	Memo string `db:"memo"`
+	// Sender is a Stellar address of the sender, ex. `alice*acme.com`
+	Sender string `db:"sender"`
+	// Route is a route of the receiver (memo preimage `route` field)
+	Route string `db:"route"`
+	// CounterpartyDomain is a domain of the other FI
+	CounterpartyDomain string    `db:"counterparty_domain"`
+	Status             string    `db:"status"`
+	TxStatus           string    `db:"tx_status"`
+	InfoStatus         string    `db:"info_status"`
+	CreatedAt          time.Time `db:"created_at"`
+	UpdatedAt          time.Time `db:"updated_at"`
+}
+
+// GetID returns ID of the entity
+func (e *ComplianceTransaction) GetID() *int64 {
+	if e.ID == nil {
+		return nil
+	}
+	newID := *e.ID
+	return &newID
+}
+
+// SetID sets ID of the entity
+func (e *ComplianceTransaction) SetID(id int64) {
+	e.ID = &id
+}
+
+// IsNew returns true if the entity has not been persisted yet
+func (e *ComplianceTransaction) IsNew() bool {
+	return !e.exists
+}
+
+// SetExists sets entity as persisted
+func (e *ComplianceTransaction) SetExists() {
+	e.exists = true
+}
+
+// ComplianceTransactionFilter selects compliance transactions. Zero values match all transactions.
+type ComplianceTransactionFilter struct {
+	Direction          string
+	TransactionID      string
+	CounterpartyDomain string
+	Status             string
+	Since              *time.Time
+	Until              *time.Time
+	Limit              int
+}
@@ -0,0 +1,41 @@
+package entities
+
+import (
+	"time"
+)
+
+// Customer maps a memo of incoming payments to a customer of the gateway
+type Customer struct {
+	exists      bool
+	ID          *int64    `db:"id"`
+	MemoType    string    `db:"memo_type"`
+	Memo        string    `db:"memo"`
+	CustomerID  string    `db:"customer_id"`
+	CallbackURL string    `db:"callback_url"`
+	CreatedAt   time.Time `db:"created_at"`
+	Tenant      string    `db:"tenant"`
+}
+
+// GetID returns ID of the entity
+func (e *Customer) GetID() *int64 {
+	if e.ID == nil {
+		return nil
+	}
+	newID := *e.ID
+	return &newID
+}
+
+// SetID sets ID of the entity
+func (e *Customer) SetID(id int64) {
+	e.ID = &id
+}
+
+// IsNew returns true if the entity has not been persisted yet
+func (e *Customer) IsNew() bool {
+	return !e.exists
+}
+
+// SetExists sets entity as persisted
+func (e *Customer) SetExists() {
+	e.exists = true
+}
@@ -0,0 +1,9 @@
+package entities
+
+// FederationRecord is a destination of a federation name returned by `federation.query`.
+// It's not stored by the bridge server.
+type FederationRecord struct {
+	AccountID string
+	MemoType  string
+	Memo      string
+}
@@ -0,0 +1,56 @@
+package entities
+
+import (
+	"time"
+)
+
+// Sanctions statuses of KYC customers
+const (
+	KYCStatusOk      = "ok"
+	KYCStatusPending = "pending"
+	KYCStatusDenied  = "denied"
+)
+
+// KYCCustomer is an entry of the compliance server embedded KYC store. It contains
+// compliance information of customers of the FI (sent to other FIs) and sanctions
+// statuses of senders of incoming payments.
+type KYCCustomer struct {
+	exists bool
+	ID     *int64 `db:"id"`
+	// Address is a Stellar address of the customer, ex. `alice*acme.com`
+	Address string `db:"address"`
+	// Route is a route of incoming payments of the customer (memo `route` field)
+	Route *string `db:"route"`
+	// Info is a JSON object with SEP-9 fields
+	Info string `db:"info"`
+	// Status is a sanctions status of the customer as a sender of incoming payments
+	Status string `db:"status"`
+	// ShareInfo is true when Info can be shared with FIs sending payments to the customer
+	ShareInfo bool      `db:"share_info"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// GetID returns ID of the entity
+func (e *KYCCustomer) GetID() *int64 {
+	if e.ID == nil {
+		return nil
+	}
+	newID := *e.ID
+	return &newID
+}
+
+// SetID sets ID of the entity
+func (e *KYCCustomer) SetID(id int64) {
+	e.ID = &id
+}
+
+// IsNew returns true if the entity has not been persisted yet
+func (e *KYCCustomer) IsNew() bool {
+	return !e.exists
+}
+
+// SetExists sets entity as persisted
+func (e *KYCCustomer) SetExists() {
+	e.exists = true
+}
@@ -0,0 +1,39 @@
+package entities
+
+import (
+	"time"
+)
+
+// LimitCounter is a total amount of payments sent within a single window of a `limits` config entry
+type LimitCounter struct {
+	exists      bool
+	ID          *int64    `db:"id"`
+	Key         string    `db:"counter_key"` // ex. `destination:GABC...:USD:GXYZ...`
+	WindowStart time.Time `db:"window_start"`
+	Amount      int64     `db:"amount"` // stroops
+	Tenant      string    `db:"tenant"`
+}
+
+// GetID returns ID of the entity
+func (e *LimitCounter) GetID() *int64 {
+	if e.ID == nil {
+		return nil
+	}
+	newID := *e.ID
+	return &newID
+}
+
+// SetID sets ID of the entity
+func (e *LimitCounter) SetID(id int64) {
+	e.ID = &id
+}
+
+// IsNew returns true if the entity has not been persisted yet
+func (e *LimitCounter) IsNew() bool {
+	return !e.exists
+}
+
+// SetExists sets entity as persisted
+func (e *LimitCounter) SetExists() {
+	e.exists = true
+}
@@ -0,0 +1,39 @@
+package entities
+
+import (
+	"time"
+)
+
+// PaymentLink links a transaction sent by /payment endpoint to the received payment it originates from
+type PaymentLink struct {
+	exists            bool
+	ID                *int64    `db:"id"`
+	ReceivedPaymentID int64     `db:"received_payment_id"`
+	TransactionID     string    `db:"transaction_id"`
+	CreatedAt         time.Time `db:"created_at"`
+	Tenant            string    `db:"tenant"`
+}
+
+// GetID returns ID of the entity
+func (e *PaymentLink) GetID() *int64 {
+	if e.ID == nil {
+		return nil
+	}
+	newID := *e.ID
+	return &newID
+}
+
+// SetID sets ID of the entity
+func (e *PaymentLink) SetID(id int64) {
+	e.ID = &id
+}
+
+// IsNew returns true if the entity has not been persisted yet
+func (e *PaymentLink) IsNew() bool {
+	return !e.exists
+}
+
+// SetExists sets entity as persisted
+func (e *PaymentLink) SetExists() {
+	e.exists = true
+}
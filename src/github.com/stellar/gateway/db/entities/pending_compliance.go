@@ -0,0 +1,65 @@
+package entities
+
+import (
+	"time"
+)
+
+// Statuses of PendingCompliance
+const (
+	// PendingComplianceStatusPending is a status of a request waiting for the destination
+	// compliance server decision
+	PendingComplianceStatusPending = "pending"
+	// PendingComplianceStatusApproved is a status of a request approved by the destination
+	// compliance server. The payment can be sent using /payment endpoint.
+	PendingComplianceStatusApproved = "approved"
+	// PendingComplianceStatusDenied is a status of a request denied by the destination
+	// compliance server
+	PendingComplianceStatusDenied = "denied"
+	// PendingComplianceStatusExpired is a status of a request still pending when
+	// `compliance_pending_timeout` passed
+	PendingComplianceStatusExpired = "expired"
+)
+
+// PendingCompliance represents a /payment request pending at the destination compliance
+// server. It's sent to the compliance server again when NextAttemptAt passes until it's
+// approved, denied or expires.
+type PendingCompliance struct {
+	exists bool
+	ID     *int64 `db:"id"`
+	Tenant string `db:"tenant"`
+	// SendRequest contains form values of the compliance server /send request
+	SendRequest   string    `db:"send_request"`
+	Status        string    `db:"status"`
+	TxStatus      string    `db:"tx_status"`
+	InfoStatus    string    `db:"info_status"`
+	Attempts      int       `db:"attempts"` // Number of requests sent to the compliance server
+	NextAttemptAt time.Time `db:"next_attempt_at"`
+	ExpiresAt     time.Time `db:"expires_at"`
+	LastError     string    `db:"last_error"`
+	CreatedAt     time.Time `db:"created_at"`
+	UpdatedAt     time.Time `db:"updated_at"`
+}
+
+// GetID returns ID of the entity
+func (e *PendingCompliance) GetID() *int64 {
+	if e.ID == nil {
+		return nil
+	}
+	newID := *e.ID
+	return &newID
+}
+
+// SetID sets ID of the entity
+func (e *PendingCompliance) SetID(id int64) {
+	e.ID = &id
+}
+
+// IsNew returns true if the entity has not been persisted yet
+func (e *PendingCompliance) IsNew() bool {
+	return !e.exists
+}
+
+// SetExists sets entity as persisted
+func (e *PendingCompliance) SetExists() {
+	e.exists = true
+}
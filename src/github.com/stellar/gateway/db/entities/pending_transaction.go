@@ -0,0 +1,74 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"time"
+)
+
+// PendingTransactionStatus type represents status of a transaction collecting signatures
+type PendingTransactionStatus string
+
+// Value implements driver.Valuer
+func (status PendingTransactionStatus) Value() (driver.Value, error) {
+	return driver.Value(string(status)), nil
+}
+
+var _ driver.Valuer = PendingTransactionStatus("")
+
+const (
+	// PendingTransactionStatusPending is a status of a transaction waiting for signatures
+	PendingTransactionStatusPending PendingTransactionStatus = "pending"
+	// PendingTransactionStatusSubmitted is a status of a transaction submitted after collecting
+	// enough signatures. Result of the transaction is saved in SentTransaction.
+	PendingTransactionStatusSubmitted PendingTransactionStatus = "submitted"
+)
+
+// PendingTransaction represents a transaction built by /payment endpoint that is signed by
+// the source account signers using /sign endpoint because the bridge server key alone does
+// not meet the source account threshold
+type PendingTransaction struct {
+	exists        bool
+	ID            *int64                   `db:"id"`
+	TransactionID string                   `db:"transaction_id"`
+	Status        PendingTransactionStatus `db:"status"`
+	Source        string                   `db:"source"`
+	// EnvelopeXdr contains all signatures collected so far
+	EnvelopeXdr string `db:"envelope_xdr"`
+	// RequiredWeight is a threshold of the source account the signatures must meet
+	RequiredWeight int32  `db:"required_weight"`
+	SignedWeight   int32  `db:"signed_weight"`
+	Destination    string `db:"destination"`
+	Amount         string `db:"amount"`
+	Asset          string `db:"asset"`
+	// Metadata is a JSON object sent in `metadata` param of /payment request
+	Metadata  *string   `db:"metadata"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+	Tenant    string    `db:"tenant"`
+	// Version is incremented by every update, see Repository.UpdatePendingTransaction
+	Version int64 `db:"version"`
+}
+
+// GetID returns ID of the entity
+func (e *PendingTransaction) GetID() *int64 {
+	if e.ID == nil {
+		return nil
+	}
+	newID := *e.ID
+	return &newID
+}
+
+// SetID sets ID of the entity
+func (e *PendingTransaction) SetID(id int64) {
+	e.ID = &id
+}
+
+// IsNew returns true if the entity has not been persisted yet
+func (e *PendingTransaction) IsNew() bool {
+	return !e.exists
+}
+
+// SetExists sets entity as persisted
+func (e *PendingTransaction) SetExists() {
+	e.exists = true
+}
@@ -0,0 +1,13 @@
+package entities
+
+// QueueDepths contains numbers of entries waiting for processing in the DB queues of a tenant
+type QueueDepths struct {
+	// CallbackRetries is a number of received payments which receive callback is retried
+	CallbackRetries int `json:"callback_retries"`
+	// DeadLetters is a number of received payments waiting for an operator
+	DeadLetters int `json:"dead_letters"`
+	// PendingTransactions is a number of transactions collecting signatures
+	PendingTransactions int `json:"pending_transactions"`
+	// PendingCompliance is a number of payments pending at the destination compliance server
+	PendingCompliance int `json:"pending_compliance"`
+}
@@ -4,14 +4,88 @@ import (
 	"time"
 )
 
+// Statuses of received payments
+const (
+	ReceivedPaymentStatusSuccess  = "Success"
+	ReceivedPaymentStatusRefunded = "Refunded"
+	ReceivedPaymentStatusResolved = "Resolved"
+	ReceivedPaymentStatusIgnored  = "Ignored"
+	// Receive callback failed and is queued to be sent again
+	ReceivedPaymentStatusCallbackPending = "Callback pending"
+	// Receive callback failed too many times. Dead-lettered payments can be requeued.
+	ReceivedPaymentStatusDeadLetter = "Dead letter"
+	// Set by older versions instead of ReceivedPaymentStatusDeadLetter
+	ReceivedPaymentStatusCallbackFailed = "Callback failed"
+	// Payment memo is not matched by `memo_filter` so it's handled by another bridge server
+	ReceivedPaymentStatusMemoFiltered = "Memo filtered"
+	// Payment amount is below `dust.minimums` of its asset so it's not sent to the receive callback
+	ReceivedPaymentStatusDustSkipped = "dust_skipped"
+	// Dust payment has been sent to `dust.sweep_account`
+	ReceivedPaymentStatusDustSwept = "dust_swept"
+)
+
 // ReceivedPayment represents payment received by the gateway server
 type ReceivedPayment struct {
-	exists      bool
-	ID          *int64    `db:"id"`
-	OperationID string    `db:"operation_id"`
-	ProcessedAt time.Time `db:"processed_at"`
-	PagingToken string    `db:"paging_token"`
-	Status      string    `db:"status"`
+	exists              bool
+	ID                  *int64     `db:"id"`
+	OperationID         string     `db:"operation_id"`
+	ProcessedAt         time.Time  `db:"processed_at"`
+	PagingToken         string     `db:"paging_token"`
+	Status              string     `db:"status"`
+	ExchangeRate        *string    `db:"exchange_rate"`
+	ConvertedAmount     *string    `db:"converted_amount"`
+	ConvertedCurrency   *string    `db:"converted_currency"`
+	Tenant              string     `db:"tenant"`
+	ReceivingAccount    string     `db:"receiving_account"` // Empty for payments received before it was stored
+	FromAddress         *string    `db:"from_address"`
+	FromAccount         string     `db:"from_account"`
+	Amount              string     `db:"amount"`
+	AssetCode           string     `db:"asset_code"`
+	AssetIssuer         string     `db:"asset_issuer"`
+	MemoType            string     `db:"memo_type"`
+	Memo                string     `db:"memo"`
+	RefundTransactionID *string    `db:"refund_transaction_id"` // ID of the transaction refunding this payment
+	ResolutionReason    *string    `db:"resolution_reason"`
+	ResolvedBy          *string    `db:"resolved_by"` // Operator who resolved or ignored this payment
+	ResolvedAt          *time.Time `db:"resolved_at"`
+	// Details of the transaction the payment was sent in. Empty for payments received
+	// before they were stored.
+	TransactionHash          *string    `db:"transaction_hash"`
+	Ledger                   *int64     `db:"ledger"`
+	LedgerCloseTime          *time.Time `db:"ledger_close_time"`
+	FeeCharged               *int64     `db:"fee_charged"` // In stroops
+	TransactionSourceAccount *string    `db:"transaction_source_account"`
+}
+
+// ReceivedPaymentFilter selects received payments for export. Zero values match all payments.
+// Native asset is selected by an empty AssetCode.
+type ReceivedPaymentFilter struct {
+	Cursor      int64 // Payments with ID greater than Cursor are returned
+	Status      string
+	AssetCode   *string
+	AssetIssuer *string
+	Since       *time.Time
+	Until       *time.Time
+	Limit       int
+}
+
+// IsFinal returns true when payment was processed successfully or has been handled by an operator
+func (e *ReceivedPayment) IsFinal() bool {
+	switch e.Status {
+	case ReceivedPaymentStatusSuccess,
+		ReceivedPaymentStatusRefunded,
+		ReceivedPaymentStatusResolved,
+		ReceivedPaymentStatusIgnored,
+		ReceivedPaymentStatusDustSwept:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsDeadLetter returns true when receive callback of the payment failed too many times
+func (e *ReceivedPayment) IsDeadLetter() bool {
+	return e.Status == ReceivedPaymentStatusDeadLetter || e.Status == ReceivedPaymentStatusCallbackFailed
 }
 
 // GetID returns ID of the entity
@@ -22,6 +22,9 @@ const (
 	SentTransactionStatusSuccess SentTransactionStatus = "success"
 	// SentTransactionStatusFailure is a status indicating that there has been an error while sending a transaction
 	SentTransactionStatusFailure SentTransactionStatus = "failure"
+	// SentTransactionStatusTimeout is a status indicating that submitting a transaction failed or timed out
+	// so its outcome is unknown
+	SentTransactionStatusTimeout SentTransactionStatus = "timeout"
 )
 
 // SentTransaction represents transaction sent by the gateway server
@@ -29,13 +32,32 @@ type SentTransaction struct {
 	exists        bool
 	ID            *int64                `db:"id"`
 	TransactionID string                `db:"transaction_id"`
-	Status        SentTransactionStatus `db:"status"` // sending/success/failure
+	Status        SentTransactionStatus `db:"status"` // sending/success/failure/timeout
 	Source        string                `db:"source"`
 	SubmittedAt   time.Time             `db:"submitted_at"`
 	SucceededAt   *time.Time            `db:"succeeded_at"`
 	Ledger        *uint64               `db:"ledger"`
 	EnvelopeXdr   string                `db:"envelope_xdr"`
 	ResultXdr     *string               `db:"result_xdr"`
+	Tenant        string                `db:"tenant"`
+	Metadata      *string               `db:"metadata"` // JSON object sent in `metadata` param of /payment request
+	// IdempotencyKey is sent in `idempotency_key` param of /payment request. Unique per tenant.
+	IdempotencyKey *string `db:"idempotency_key"`
+	// Destination, Amount and Asset (CODE:ISSUER or "native") of the payment. Empty for
+	// transactions sent before payment details were recorded and for /builder transactions.
+	Destination *string `db:"destination"`
+	Amount      *string `db:"amount"`
+	Asset       *string `db:"asset"`
+}
+
+// SentTransactionFilter selects sent payments for export. Zero values match all payments.
+type SentTransactionFilter struct {
+	Cursor int64 // Transactions with ID greater than Cursor are returned
+	Status SentTransactionStatus
+	Asset  string // CODE:ISSUER or "native"
+	Since  *time.Time
+	Until  *time.Time
+	Limit  int
 }
 
 // GetID returns ID of the entity
@@ -70,6 +92,16 @@ func (e *SentTransaction) MarkSucceeded(ledger uint64) {
 	e.SucceededAt = &now
 }
 
+// IsPending returns true when outcome of the transaction is not known yet
+func (e *SentTransaction) IsPending() bool {
+	return e.Status == SentTransactionStatusSending || e.Status == SentTransactionStatusTimeout
+}
+
+// MarkTimedOut marks transaction which submission failed or timed out
+func (e *SentTransaction) MarkTimedOut() {
+	e.Status = SentTransactionStatusTimeout
+}
+
 // MarkFailed marks transaction as failed
 func (e *SentTransaction) MarkFailed(resultXdr string) {
 	e.Status = SentTransactionStatusFailure
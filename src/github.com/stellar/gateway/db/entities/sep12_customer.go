@@ -0,0 +1,61 @@
+package entities
+
+import (
+	"time"
+)
+
+// Statuses of SEP-12 customers
+const (
+	// Customer has been approved by the FI
+	Sep12StatusAccepted = "ACCEPTED"
+	// Customer has provided all required fields and is waiting for FI review
+	Sep12StatusProcessing = "PROCESSING"
+	// Customer has not provided all fields required by `sep12.fields`
+	Sep12StatusNeedsInfo = "NEEDS_INFO"
+	// Customer has been rejected by the FI
+	Sep12StatusRejected = "REJECTED"
+)
+
+// Sep12Customer is a customer registered using SEP-12 endpoints of the compliance server.
+// It's identified by a Stellar account and an optional memo (customers of a shared account).
+// Compliance and transfer flows reference customers using PublicID.
+type Sep12Customer struct {
+	exists   bool
+	ID       *int64 `db:"id"`
+	PublicID string `db:"public_id"` // ID returned to clients
+	Account  string `db:"account"`
+	// MemoType and Memo are empty when the account belongs to a single customer
+	MemoType string `db:"memo_type"`
+	Memo     string `db:"memo"`
+	// Fields is a JSON object with SEP-9 field values, binary fields are base64-encoded
+	Fields string `db:"fields"`
+	Status string `db:"status"`
+	// Message is a reason of the status shown to the customer
+	Message   *string   `db:"message"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// GetID returns ID of the entity
+func (e *Sep12Customer) GetID() *int64 {
+	if e.ID == nil {
+		return nil
+	}
+	newID := *e.ID
+	return &newID
+}
+
+// SetID sets ID of the entity
+func (e *Sep12Customer) SetID(id int64) {
+	e.ID = &id
+}
+
+// IsNew returns true if the entity has not been persisted yet
+func (e *Sep12Customer) IsNew() bool {
+	return !e.exists
+}
+
+// SetExists sets entity as persisted
+func (e *Sep12Customer) SetExists() {
+	e.exists = true
+}
@@ -0,0 +1,68 @@
+package entities
+
+import (
+	"time"
+)
+
+// Statuses of SEP-31 transactions
+const (
+	// Transaction has been created, waiting for the sender to send a payment
+	Sep31StatusPendingSender = "pending_sender"
+	// Payment has been received, waiting for the receive callback to be delivered
+	Sep31StatusPendingReceiver = "pending_receiver"
+	Sep31StatusCompleted       = "completed"
+	// Received payment asset or amount does not match the transaction
+	Sep31StatusError = "error"
+)
+
+// Sep31Transaction is a transaction created using SEP-31 POST /transactions endpoint. It's
+// matched with a received payment using its memo.
+type Sep31Transaction struct {
+	exists      bool
+	ID          *int64 `db:"id"`
+	PublicID    string `db:"public_id"` // ID returned to the sending anchor
+	Status      string `db:"status"`
+	AmountIn    string `db:"amount_in"`
+	AmountFee   string `db:"amount_fee"`
+	AmountOut   string `db:"amount_out"`
+	AssetCode   string `db:"asset_code"`
+	AssetIssuer string `db:"asset_issuer"`
+	SenderID    string `db:"sender_id"`
+	ReceiverID  string `db:"receiver_id"`
+	// Fields is a JSON object with `transaction` fields sent by the sending anchor
+	Fields string `db:"fields"`
+	// ClientAccount is an account of the sending anchor authenticated using SEP-10
+	ClientAccount        string     `db:"client_account"`
+	MemoType             string     `db:"memo_type"`
+	Memo                 string     `db:"memo"`
+	StellarTransactionID *string    `db:"stellar_transaction_id"`
+	ReceivedPaymentID    *string    `db:"received_payment_id"` // operation ID of the received payment
+	StatusMessage        *string    `db:"status_message"`
+	StartedAt            time.Time  `db:"started_at"`
+	CompletedAt          *time.Time `db:"completed_at"`
+	Tenant               string     `db:"tenant"`
+}
+
+// GetID returns ID of the entity
+func (e *Sep31Transaction) GetID() *int64 {
+	if e.ID == nil {
+		return nil
+	}
+	newID := *e.ID
+	return &newID
+}
+
+// SetID sets ID of the entity
+func (e *Sep31Transaction) SetID(id int64) {
+	e.ID = &id
+}
+
+// IsNew returns true if the entity has not been persisted yet
+func (e *Sep31Transaction) IsNew() bool {
+	return !e.exists
+}
+
+// SetExists sets entity as persisted
+func (e *Sep31Transaction) SetExists() {
+	e.exists = true
+}
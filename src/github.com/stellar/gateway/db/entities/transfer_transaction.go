@@ -0,0 +1,81 @@
+package entities
+
+import (
+	"time"
+)
+
+// Kinds of transfer transactions
+const (
+	TransferKindDeposit    = "deposit"
+	TransferKindWithdrawal = "withdrawal"
+)
+
+// Statuses of transfer transactions (a subset of SEP-6 and SEP-24 statuses)
+const (
+	// SEP-24 interactive transaction waiting for the user to provide info in the interactive
+	// web app. The app moves it to pending_user_transfer_start using Admin API.
+	TransferStatusIncomplete = "incomplete"
+	// Waiting for the user to send funds: off-chain for deposits, a payment for withdrawals
+	TransferStatusPendingUserTransferStart = "pending_user_transfer_start"
+	// Deposit is waiting for the anchor to send a payment, withdrawal payment has been received
+	// and it's waiting for the receive callback to be delivered
+	TransferStatusPendingAnchor = "pending_anchor"
+	TransferStatusCompleted     = "completed"
+	// Received payment asset or amount does not match the withdrawal
+	TransferStatusError = "error"
+)
+
+// TransferTransaction is a deposit or a withdrawal created using SEP-6 or SEP-24 endpoints.
+// Withdrawals are matched with received payments using their memo, deposits are completed by
+// /payment requests with `transfer_id` param.
+type TransferTransaction struct {
+	exists      bool
+	ID          *int64  `db:"id"`
+	PublicID    string  `db:"public_id"` // ID returned to the wallet
+	Kind        string  `db:"kind"`
+	Status      string  `db:"status"`
+	AssetCode   string  `db:"asset_code"`
+	AssetIssuer string  `db:"asset_issuer"`
+	AmountIn    *string `db:"amount_in"`
+	AmountFee   *string `db:"amount_fee"`
+	AmountOut   *string `db:"amount_out"`
+	// Account is a Stellar account of the user authenticated using SEP-10
+	Account string `db:"account"`
+	// Type is a deposit or withdrawal type, ex. `SEPA`
+	Type string `db:"type"`
+	// Fields is a JSON object with other request params (ex. `dest`, `dest_extra`)
+	Fields string `db:"fields"`
+	// MemoType and Memo are set on deposit payments or must be used in withdrawal payments
+	MemoType             *string    `db:"memo_type"`
+	Memo                 *string    `db:"memo"`
+	StellarTransactionID *string    `db:"stellar_transaction_id"`
+	ReceivedPaymentID    *string    `db:"received_payment_id"` // operation ID of the received payment
+	StatusMessage        *string    `db:"status_message"`
+	StartedAt            time.Time  `db:"started_at"`
+	CompletedAt          *time.Time `db:"completed_at"`
+	Tenant               string     `db:"tenant"`
+}
+
+// GetID returns ID of the entity
+func (e *TransferTransaction) GetID() *int64 {
+	if e.ID == nil {
+		return nil
+	}
+	newID := *e.ID
+	return &newID
+}
+
+// SetID sets ID of the entity
+func (e *TransferTransaction) SetID(id int64) {
+	e.ID = &id
+}
+
+// IsNew returns true if the entity has not been persisted yet
+func (e *TransferTransaction) IsNew() bool {
+	return !e.exists
+}
+
+// SetExists sets entity as persisted
+func (e *TransferTransaction) SetExists() {
+	e.exists = true
+}
@@ -0,0 +1,90 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+// Migrate commands
+const (
+	MigrateCommandUp     = "up"
+	MigrateCommandDown   = "down"
+	MigrateCommandStatus = "status"
+)
+
+// MigrationStatus describes a single migration of a component
+type MigrationStatus struct {
+	ID string
+	// AppliedAt is nil when the migration has not been applied yet
+	AppliedAt *time.Time
+}
+
+// GetMigrationStatus returns statuses of all migrations in source. It's used by drivers.
+func GetMigrationStatus(database *sql.DB, dialect string, source migrate.MigrationSource) ([]MigrationStatus, error) {
+	migrations, err := source.FindMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := migrate.GetMigrationRecords(database, dialect)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := map[string]time.Time{}
+	for _, record := range records {
+		applied[record.Id] = record.AppliedAt
+	}
+
+	statuses := make([]MigrationStatus, 0, len(migrations))
+	for _, migration := range migrations {
+		status := MigrationStatus{ID: migration.Id}
+		if appliedAt, ok := applied[migration.Id]; ok {
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// Migrate runs a migrate command (`up`, `down` or `status`) for component schema.
+// limit is a maximum number of migrations to apply or revert. `up` applies all pending
+// migrations and `down` reverts the last migration when limit is 0.
+func Migrate(driver Driver, component, command string, limit int) error {
+	switch command {
+	case MigrateCommandUp:
+		applied, err := driver.Migrate(component, migrate.Up, limit)
+		if err != nil {
+			return err
+		}
+		log.Info("Applied migrations: ", applied)
+	case MigrateCommandDown:
+		if limit == 0 {
+			limit = 1
+		}
+		reverted, err := driver.Migrate(component, migrate.Down, limit)
+		if err != nil {
+			return err
+		}
+		log.Info("Reverted migrations: ", reverted)
+	case MigrateCommandStatus:
+		statuses, err := driver.MigrationStatus(component)
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			if status.AppliedAt == nil {
+				fmt.Printf("%-40s pending\n", status.ID)
+			} else {
+				fmt.Printf("%-40s applied at %s\n", status.ID, status.AppliedAt.Format(time.RFC3339))
+			}
+		}
+	default:
+		return fmt.Errorf("Invalid migrate command: %s (should be up, down or status)", command)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stellar/go/support/errors"
+)
+
+// Execer executes queries. It's implemented by *sqlx.DB.
+type Execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+// NotifyEntityManager is an EntityManagerInterface emitting Postgres NOTIFY on a channel
+// every time a received payment is persisted. Payload of the notification is a JSON-encoded
+// ReceivedPaymentNotification. Other entities are persisted without notifications.
+type NotifyEntityManager struct {
+	EntityManagerInterface
+	db      Execer
+	channel string
+	log     *logrus.Entry
+}
+
+// ReceivedPaymentNotification is a payload of notifications sent by NotifyEntityManager
+type ReceivedPaymentNotification struct {
+	Tenant string `json:"tenant,omitempty"`
+	bridge.ReceivedPayment
+}
+
+// NewNotifyEntityManager creates a new NotifyEntityManager persisting objects using em
+// and sending notifications to channel using db
+func NewNotifyEntityManager(em EntityManagerInterface, db Execer, channel string) *NotifyEntityManager {
+	return &NotifyEntityManager{
+		EntityManagerInterface: em,
+		db:                     db,
+		channel:                channel,
+		log: logrus.WithFields(logrus.Fields{
+			"service": "NotifyEntityManager",
+			"channel": channel,
+		}),
+	}
+}
+
+// Persist persists an object in DB and notifies listeners of the channel when object
+// is a received payment. Notification errors are logged but not returned because
+// the payment has been persisted already.
+func (em *NotifyEntityManager) Persist(object entities.Entity) error {
+	err := em.EntityManagerInterface.Persist(object)
+	if err != nil {
+		return err
+	}
+
+	payment, ok := object.(*entities.ReceivedPayment)
+	if !ok {
+		return nil
+	}
+
+	err = em.notify(payment)
+	if err != nil {
+		em.log.WithFields(logrus.Fields{"err": err, "id": payment.OperationID}).Error("Error sending payment notification")
+	}
+	return nil
+}
+
+func (em *NotifyEntityManager) notify(payment *entities.ReceivedPayment) error {
+	payload, err := json.Marshal(ReceivedPaymentNotification{
+		Tenant:          payment.Tenant,
+		ReceivedPayment: bridge.NewReceivedPayment(payment, nil),
+	})
+	if err != nil {
+		return errors.Wrap(err, "cannot marshal notification payload")
+	}
+
+	_, err = em.db.Exec("SELECT pg_notify($1, $2)", em.channel, string(payload))
+	return err
+}
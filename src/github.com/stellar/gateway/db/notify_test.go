@@ -0,0 +1,65 @@
+package db_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stellar/gateway/db"
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type execCall struct {
+	query string
+	args  []interface{}
+}
+
+type recordingExecer struct {
+	calls []execCall
+}
+
+func (e *recordingExecer) Exec(query string, args ...interface{}) (sql.Result, error) {
+	e.calls = append(e.calls, execCall{query, args})
+	return nil, nil
+}
+
+func TestNotifyEntityManager(t *testing.T) {
+	mockEntityManager := new(mocks.MockEntityManager)
+	execer := &recordingExecer{}
+	em := db.NewNotifyEntityManager(mockEntityManager, execer, "payments")
+
+	payment := &entities.ReceivedPayment{
+		OperationID: "1",
+		ProcessedAt: time.Unix(1500000000, 0).UTC(),
+		Status:      entities.ReceivedPaymentStatusSuccess,
+		Tenant:      "acme",
+		FromAccount: "GBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFZYYQ",
+		Amount:      "10.0000000",
+		AssetCode:   "USD",
+		AssetIssuer: "GD4I7AFSLZGTDL34TQLWJOM2NHLIIOEKD5RHHZUW54HERBLSIRKUOXRR",
+	}
+	mockEntityManager.On("Persist", payment).Return(nil).Once()
+	require.NoError(t, em.Persist(payment))
+	require.Len(t, execer.calls, 1)
+	assert.Equal(t, "SELECT pg_notify($1, $2)", execer.calls[0].query)
+	assert.Equal(t, "payments", execer.calls[0].args[0])
+
+	var notification db.ReceivedPaymentNotification
+	require.NoError(t, json.Unmarshal([]byte(execer.calls[0].args[1].(string)), &notification))
+	assert.Equal(t, "acme", notification.Tenant)
+	assert.Equal(t, "1", notification.ID)
+	assert.Equal(t, "Success", notification.Status)
+	assert.Equal(t, "USD:GD4I7AFSLZGTDL34TQLWJOM2NHLIIOEKD5RHHZUW54HERBLSIRKUOXRR", notification.Asset)
+
+	// Other entities are not notified
+	customer := &entities.Customer{}
+	mockEntityManager.On("Persist", customer).Return(nil).Once()
+	require.NoError(t, em.Persist(customer))
+	assert.Len(t, execer.calls, 1)
+
+	mockEntityManager.AssertExpectations(t)
+}
@@ -1,24 +1,76 @@
 package db
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/Sirupsen/logrus"
 	"github.com/stellar/gateway/db/entities"
 	"github.com/stellar/go/support/db"
+	"github.com/stellar/go/support/errors"
 )
 
 // RepositoryInterface helps mocking Repository
 type RepositoryInterface interface {
-	GetLastCursorValue() (cursor *string, err error)
+	GetLastCursorValue(receivingAccount string) (cursor *string, err error)
 	GetAuthorizedTransactionByMemo(memo string) (*entities.AuthorizedTransaction, error)
 	GetAllowedFiByDomain(domain string) (*entities.AllowedFi, error)
 	GetAllowedUserByDomainAndUserID(domain, userID string) (*entities.AllowedUser, error)
 	GetReceivedPaymentByID(id int64) (*entities.ReceivedPayment, error)
+	GetReceivedPaymentByOperationID(operationID string) (*entities.ReceivedPayment, error)
+	GetCustomerByID(id int64) (*entities.Customer, error)
+	GetCustomerByMemo(memoType, memo string) (*entities.Customer, error)
+	GetCustomers() ([]entities.Customer, error)
+	GetPaymentLinksByReceivedPaymentID(receivedPaymentID int64) ([]entities.PaymentLink, error)
+	GetPaymentLinkByTransactionID(transactionID string) (*entities.PaymentLink, error)
+	GetSentTransactionByTransactionID(transactionID string) (*entities.SentTransaction, error)
+	GetSentTransactionByIdempotencyKey(key string) (*entities.SentTransaction, error)
+	GetPendingTransactionByTransactionID(transactionID string) (*entities.PendingTransaction, error)
+	UpdatePendingTransaction(pending *entities.PendingTransaction) (bool, error)
+	GetReceivedPaymentsAfterID(id int64, limit int) ([]entities.ReceivedPayment, error)
+	GetReceivedPayments(filter entities.ReceivedPaymentFilter) ([]entities.ReceivedPayment, error)
+	GetSentPayments(filter entities.SentTransactionFilter) ([]entities.SentTransaction, error)
+	GetDueCallbackRetries(now time.Time, limit int) ([]entities.CallbackRetry, error)
+	GetDuePendingCompliance(now time.Time, limit int) ([]entities.PendingCompliance, error)
+	GetQueueDepths() (entities.QueueDepths, error)
+	Ping() error
+	CountCallbackAttempts(operationID string) (int, error)
+	GetCallbackAttempts(filter entities.CallbackAttemptFilter) ([]entities.CallbackAttempt, error)
+	GetLimitCounter(key string, windowStart time.Time) (*entities.LimitCounter, error)
+	GetSep31TransactionByPublicID(id string) (*entities.Sep31Transaction, error)
+	GetSep31TransactionByMemo(memoType, memo string) (*entities.Sep31Transaction, error)
+	GetTransferTransactionByPublicID(id string) (*entities.TransferTransaction, error)
+	GetWithdrawalByMemo(memoType, memo string) (*entities.TransferTransaction, error)
+	GetKYCCustomerByID(id int64) (*entities.KYCCustomer, error)
+	GetKYCCustomerByAddress(address string) (*entities.KYCCustomer, error)
+	GetKYCCustomerByRoute(route string) (*entities.KYCCustomer, error)
+	GetKYCCustomers() ([]entities.KYCCustomer, error)
+	GetSep12CustomerByPublicID(id string) (*entities.Sep12Customer, error)
+	GetSep12Customer(account, memoType, memo string) (*entities.Sep12Customer, error)
+	GetComplianceTransactionByID(id int64) (*entities.ComplianceTransaction, error)
+	GetComplianceTransactionByTransactionID(direction, transactionID string) (*entities.ComplianceTransaction, error)
+	GetComplianceTransactions(filter entities.ComplianceTransactionFilter) ([]entities.ComplianceTransaction, error)
+	GetFederationRecord(query, name string) (*entities.FederationRecord, error)
+	GetAPIKeyByID(id int64) (*entities.APIKey, error)
+	GetAPIKeyByHash(keyHash string) (*entities.APIKey, error)
+	GetAPIKeys() ([]entities.APIKey, error)
+	AcquireLease(name, holder string, now, expiresAt time.Time) (bool, error)
+	ReleaseLease(name, holder string) error
+	IsCallbackDelivered(deliveryID string) (bool, error)
+	SaveCallbackDelivery(deliveryID, operationID, url string, deliveredAt time.Time) error
+	SaveAuthNonce(signingKey, nonce string, createdAt time.Time) (bool, error)
+	DeleteAuthNoncesBefore(before time.Time) error
 }
 
-// Repository helps getting data from DB
+// Repository helps getting data from DB. Received payments and customers are
+// scoped to a single tenant (empty string for the default tenant).
 type Repository struct {
-	repo *db.Repo
-	log  *logrus.Entry
+	repo   *db.Repo
+	log    *logrus.Entry
+	tenant string
+	// statements caches prepared statements of hot path queries, queries are not prepared
+	// when it's nil (see WithStatementCache)
+	statements *StatementCache
 }
 
 // NewRepository creates a new Repository using driver
@@ -30,16 +82,67 @@ func NewRepository(driver Driver) (r Repository) {
 	return
 }
 
-// GetLastCursorValue returns last cursor value from a DB
-func (r Repository) GetLastCursorValue() (cursor *string, err error) {
-	receivedPayment, err := r.getLastReceivedPayment()
+// ForTenant returns a copy of the Repository scoped to a given tenant
+func (r Repository) ForTenant(tenant string) Repository {
+	r.tenant = tenant
+	r.log = r.log.WithField("tenant", tenant)
+	return r
+}
+
+// WithStatementCache returns a copy of the Repository running queries of the hot path
+// (payment listener, API keys, limits) as prepared statements cached in cache
+func (r Repository) WithStatementCache(cache *StatementCache) Repository {
+	r.statements = cache
+	return r
+}
+
+// getRaw runs query like Repo.GetRaw using a cached prepared statement when the statement
+// cache is enabled. Queries are logged and errors wrapped the same way in both cases.
+func (r Repository) getRaw(dest interface{}, query string, args ...interface{}) error {
+	if r.statements == nil {
+		return r.repo.GetRaw(dest, query, args...)
+	}
+
+	start := time.Now()
+	stmt, err := r.statements.Prepare(query)
 	if err != nil {
-		return nil, err
-	} else if receivedPayment == nil {
+		return errors.Wrap(err, "prepare failed")
+	}
+
+	err = stmt.Get(dest, args...)
+	r.log.WithFields(logrus.Fields{
+		"args": args,
+		"sql":  query,
+		"dur":  time.Since(start).String(),
+	}).Debug("sql: get (prepared)")
+
+	if err == nil || r.repo.NoRows(err) {
+		return err
+	}
+	return errors.Wrap(err, "get failed")
+}
+
+// GetLastCursorValue returns paging token of the last payment received by receivingAccount.
+// Payments saved without receiving account (before multiple receiving accounts were supported)
+// are included.
+func (r Repository) GetLastCursorValue(receivingAccount string) (cursor *string, err error) {
+	var receivedPayment entities.ReceivedPayment
+	err = r.getRaw(
+		&receivedPayment,
+		"SELECT * FROM ReceivedPayment WHERE tenant = ? AND receiving_account IN (?, '') ORDER BY id DESC LIMIT 1",
+		r.tenant,
+		receivingAccount,
+	)
+
+	if r.repo.NoRows(err) {
 		return nil, nil
-	} else {
-		return &receivedPayment.PagingToken, nil
 	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &receivedPayment.PagingToken, nil
 }
 
 // GetAuthorizedTransactionByMemo returns authorized transaction searching by memo
@@ -131,10 +234,492 @@ func (r Repository) GetReceivedPaymentByID(id int64) (*entities.ReceivedPayment,
 	return &found, nil
 }
 
-// getLastReceivedPayment returns the last received payment
-func (r Repository) getLastReceivedPayment() (*entities.ReceivedPayment, error) {
-	var receivedPayment entities.ReceivedPayment
-	err := r.repo.GetRaw(&receivedPayment, "SELECT * FROM ReceivedPayment ORDER BY id DESC LIMIT 1")
+// GetReceivedPaymentByOperationID returns received payment by operation ID
+func (r Repository) GetReceivedPaymentByOperationID(operationID string) (*entities.ReceivedPayment, error) {
+
+	var found entities.ReceivedPayment
+
+	err := r.getRaw(
+		&found,
+		"SELECT * FROM ReceivedPayment WHERE operation_id = ? AND tenant = ?",
+		operationID,
+		r.tenant,
+	)
+
+	if r.repo.NoRows(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	found.SetExists()
+	return &found, nil
+}
+
+// GetReceivedPaymentsAfterID returns up to limit payments received (with Success or Refunded status)
+// after payment with a given ID, ordered by ID
+func (r Repository) GetReceivedPaymentsAfterID(id int64, limit int) ([]entities.ReceivedPayment, error) {
+	payments := []entities.ReceivedPayment{}
+	err := r.repo.SelectRaw(
+		&payments,
+		"SELECT * FROM ReceivedPayment WHERE id > ? AND tenant = ? AND status IN (?, ?) ORDER BY id LIMIT ?",
+		id,
+		r.tenant,
+		entities.ReceivedPaymentStatusSuccess,
+		entities.ReceivedPaymentStatusRefunded,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range payments {
+		payments[i].SetExists()
+	}
+
+	return payments, nil
+}
+
+// GetReceivedPayments returns received payments matching filter, oldest first
+func (r Repository) GetReceivedPayments(filter entities.ReceivedPaymentFilter) ([]entities.ReceivedPayment, error) {
+	query := "SELECT * FROM ReceivedPayment WHERE tenant = ? AND id > ?"
+	args := []interface{}{r.tenant, filter.Cursor}
+
+	if filter.Status != "" {
+		query += " AND status = ?"
+		args = append(args, filter.Status)
+	}
+	if filter.AssetCode != nil {
+		query += " AND asset_code = ?"
+		args = append(args, *filter.AssetCode)
+	}
+	if filter.AssetIssuer != nil {
+		query += " AND asset_issuer = ?"
+		args = append(args, *filter.AssetIssuer)
+	}
+	if filter.Since != nil {
+		query += " AND processed_at >= ?"
+		args = append(args, *filter.Since)
+	}
+	if filter.Until != nil {
+		query += " AND processed_at < ?"
+		args = append(args, *filter.Until)
+	}
+	query += " ORDER BY id LIMIT ?"
+	args = append(args, filter.Limit)
+
+	payments := []entities.ReceivedPayment{}
+	err := r.repo.SelectRaw(&payments, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range payments {
+		payments[i].SetExists()
+	}
+
+	return payments, nil
+}
+
+// GetSentPayments returns transactions sent by /payment endpoint matching filter, oldest
+// first. Transactions without payment details (sent by older versions) are skipped.
+func (r Repository) GetSentPayments(filter entities.SentTransactionFilter) ([]entities.SentTransaction, error) {
+	query := "SELECT * FROM SentTransaction WHERE tenant = ? AND id > ? AND destination IS NOT NULL"
+	args := []interface{}{r.tenant, filter.Cursor}
+
+	if filter.Status != "" {
+		query += " AND status = ?"
+		args = append(args, filter.Status)
+	}
+	if filter.Asset != "" {
+		query += " AND asset = ?"
+		args = append(args, filter.Asset)
+	}
+	if filter.Since != nil {
+		query += " AND submitted_at >= ?"
+		args = append(args, *filter.Since)
+	}
+	if filter.Until != nil {
+		query += " AND submitted_at < ?"
+		args = append(args, *filter.Until)
+	}
+	query += " ORDER BY id LIMIT ?"
+	args = append(args, filter.Limit)
+
+	transactions := []entities.SentTransaction{}
+	err := r.repo.SelectRaw(&transactions, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range transactions {
+		transactions[i].SetExists()
+	}
+
+	return transactions, nil
+}
+
+// GetDueCallbackRetries returns callback retries that should be attempted at now, oldest first
+func (r Repository) GetDueCallbackRetries(now time.Time, limit int) ([]entities.CallbackRetry, error) {
+	retries := []entities.CallbackRetry{}
+	err := r.repo.SelectRaw(
+		&retries,
+		"SELECT * FROM CallbackRetry WHERE tenant = ? AND next_attempt_at <= ? ORDER BY next_attempt_at, id LIMIT ?",
+		r.tenant,
+		now,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range retries {
+		retries[i].SetExists()
+	}
+
+	return retries, nil
+}
+
+// GetDuePendingCompliance returns pending compliance requests that should be sent to the
+// compliance server again at now, oldest first
+func (r Repository) GetDuePendingCompliance(now time.Time, limit int) ([]entities.PendingCompliance, error) {
+	pending := []entities.PendingCompliance{}
+	err := r.repo.SelectRaw(
+		&pending,
+		"SELECT * FROM PendingCompliance WHERE tenant = ? AND status = ? AND next_attempt_at <= ? ORDER BY next_attempt_at, id LIMIT ?",
+		r.tenant,
+		entities.PendingComplianceStatusPending,
+		now,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range pending {
+		pending[i].SetExists()
+	}
+
+	return pending, nil
+}
+
+// GetQueueDepths returns numbers of entries in the DB queues of the tenant
+func (r Repository) GetQueueDepths() (depths entities.QueueDepths, err error) {
+	queries := []struct {
+		count *int
+		query string
+		args  []interface{}
+	}{
+		{
+			&depths.CallbackRetries,
+			"SELECT COUNT(*) FROM CallbackRetry WHERE tenant = ?",
+			[]interface{}{r.tenant},
+		},
+		{
+			&depths.DeadLetters,
+			"SELECT COUNT(*) FROM ReceivedPayment WHERE tenant = ? AND status IN (?, ?)",
+			[]interface{}{r.tenant, entities.ReceivedPaymentStatusDeadLetter, entities.ReceivedPaymentStatusCallbackFailed},
+		},
+		{
+			&depths.PendingTransactions,
+			"SELECT COUNT(*) FROM PendingTransaction WHERE tenant = ? AND status = ?",
+			[]interface{}{r.tenant, entities.PendingTransactionStatusPending},
+		},
+		{
+			&depths.PendingCompliance,
+			"SELECT COUNT(*) FROM PendingCompliance WHERE tenant = ? AND status = ?",
+			[]interface{}{r.tenant, entities.PendingComplianceStatusPending},
+		},
+	}
+
+	for _, q := range queries {
+		err = r.repo.GetRaw(q.count, q.query, q.args...)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// Ping checks the DB connection
+func (r Repository) Ping() error {
+	return r.repo.DB.Ping()
+}
+
+// CountCallbackAttempts returns the number of receive callback attempts of a payment
+func (r Repository) CountCallbackAttempts(operationID string) (int, error) {
+	var count int
+	err := r.getRaw(
+		&count,
+		"SELECT COUNT(*) FROM CallbackAttempt WHERE tenant = ? AND operation_id = ?",
+		r.tenant,
+		operationID,
+	)
+	return count, err
+}
+
+// GetCallbackAttempts returns receive callback attempts matching filter, newest first
+func (r Repository) GetCallbackAttempts(filter entities.CallbackAttemptFilter) ([]entities.CallbackAttempt, error) {
+	query := "SELECT * FROM CallbackAttempt WHERE tenant = ?"
+	args := []interface{}{r.tenant}
+
+	if filter.OperationID != "" {
+		query += " AND operation_id = ?"
+		args = append(args, filter.OperationID)
+	}
+	if filter.Success != nil {
+		query += " AND success = ?"
+		args = append(args, *filter.Success)
+	}
+	if filter.StatusCode != nil {
+		query += " AND status_code = ?"
+		args = append(args, *filter.StatusCode)
+	}
+	if filter.Since != nil {
+		query += " AND created_at >= ?"
+		args = append(args, *filter.Since)
+	}
+	if filter.Until != nil {
+		query += " AND created_at < ?"
+		args = append(args, *filter.Until)
+	}
+	query += " ORDER BY id DESC LIMIT ?"
+	args = append(args, filter.Limit)
+
+	attempts := []entities.CallbackAttempt{}
+	err := r.repo.SelectRaw(&attempts, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range attempts {
+		attempts[i].SetExists()
+	}
+
+	return attempts, nil
+}
+
+// GetCustomerByID returns customer by id
+func (r Repository) GetCustomerByID(id int64) (*entities.Customer, error) {
+
+	var found entities.Customer
+
+	err := r.repo.GetRaw(
+		&found,
+		"SELECT * FROM Customer WHERE id = ? AND tenant = ?",
+		id,
+		r.tenant,
+	)
+
+	if r.repo.NoRows(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	found.SetExists()
+	return &found, nil
+}
+
+// GetCustomerByMemo returns customer that payments with a given memo belong to
+func (r Repository) GetCustomerByMemo(memoType, memo string) (*entities.Customer, error) {
+
+	var found entities.Customer
+
+	err := r.repo.GetRaw(
+		&found,
+		"SELECT * FROM Customer WHERE memo_type = ? AND memo = ? AND tenant = ?",
+		memoType,
+		memo,
+		r.tenant,
+	)
+
+	if r.repo.NoRows(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	found.SetExists()
+	return &found, nil
+}
+
+// GetCustomers returns all customers
+func (r Repository) GetCustomers() ([]entities.Customer, error) {
+	customers := []entities.Customer{}
+	err := r.repo.SelectRaw(&customers, "SELECT * FROM Customer WHERE tenant = ? ORDER BY id", r.tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range customers {
+		customers[i].SetExists()
+	}
+
+	return customers, nil
+}
+
+// GetPaymentLinksByReceivedPaymentID returns links of transactions sent on behalf of a received payment
+func (r Repository) GetPaymentLinksByReceivedPaymentID(receivedPaymentID int64) ([]entities.PaymentLink, error) {
+	links := []entities.PaymentLink{}
+	err := r.repo.SelectRaw(
+		&links,
+		"SELECT * FROM PaymentLink WHERE received_payment_id = ? AND tenant = ? ORDER BY id",
+		receivedPaymentID,
+		r.tenant,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range links {
+		links[i].SetExists()
+	}
+
+	return links, nil
+}
+
+// GetPaymentLinkByTransactionID returns link of a sent transaction to the received payment it originates from
+func (r Repository) GetPaymentLinkByTransactionID(transactionID string) (*entities.PaymentLink, error) {
+
+	var found entities.PaymentLink
+
+	err := r.repo.GetRaw(
+		&found,
+		"SELECT * FROM PaymentLink WHERE transaction_id = ? AND tenant = ?",
+		transactionID,
+		r.tenant,
+	)
+
+	if r.repo.NoRows(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	found.SetExists()
+	return &found, nil
+}
+
+// GetSentTransactionByTransactionID returns sent transaction by transaction hash
+func (r Repository) GetSentTransactionByTransactionID(transactionID string) (*entities.SentTransaction, error) {
+	var found entities.SentTransaction
+
+	err := r.repo.GetRaw(
+		&found,
+		"SELECT * FROM SentTransaction WHERE transaction_id = ? AND tenant = ?",
+		transactionID,
+		r.tenant,
+	)
+
+	if r.repo.NoRows(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	found.SetExists()
+	return &found, nil
+}
+
+// GetSentTransactionByIdempotencyKey returns sent transaction by `idempotency_key` of /payment request
+func (r Repository) GetSentTransactionByIdempotencyKey(key string) (*entities.SentTransaction, error) {
+	var found entities.SentTransaction
+
+	err := r.getRaw(
+		&found,
+		"SELECT * FROM SentTransaction WHERE idempotency_key = ? AND tenant = ?",
+		key,
+		r.tenant,
+	)
+
+	if r.repo.NoRows(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	found.SetExists()
+	return &found, nil
+}
+
+// GetPendingTransactionByTransactionID returns a transaction collecting signatures by transaction hash
+func (r Repository) GetPendingTransactionByTransactionID(transactionID string) (*entities.PendingTransaction, error) {
+	var found entities.PendingTransaction
+
+	err := r.repo.GetRaw(
+		&found,
+		"SELECT * FROM PendingTransaction WHERE transaction_id = ? AND tenant = ?",
+		transactionID,
+		r.tenant,
+	)
+
+	if r.repo.NoRows(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	found.SetExists()
+	return &found, nil
+}
+
+// UpdatePendingTransaction saves signatures, weights and status of a pending transaction
+// when it has not been changed since it was loaded (its version is the same). It returns
+// false when the transaction has been changed concurrently, reload it and try again then.
+func (r Repository) UpdatePendingTransaction(pending *entities.PendingTransaction) (bool, error) {
+	result, err := r.repo.ExecRaw(
+		"UPDATE PendingTransaction SET envelope_xdr = ?, signed_weight = ?, required_weight = ?, status = ?, updated_at = ?, version = version + 1 WHERE id = ? AND tenant = ? AND version = ?",
+		pending.EnvelopeXdr,
+		pending.SignedWeight,
+		pending.RequiredWeight,
+		pending.Status,
+		pending.UpdatedAt,
+		*pending.ID,
+		r.tenant,
+		pending.Version,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if updated == 0 {
+		return false, nil
+	}
+
+	pending.Version++
+	return true, nil
+}
+
+// GetLimitCounter returns a limit counter of a window starting at windowStart
+func (r Repository) GetLimitCounter(key string, windowStart time.Time) (*entities.LimitCounter, error) {
+	var found entities.LimitCounter
+
+	err := r.getRaw(
+		&found,
+		"SELECT * FROM LimitCounter WHERE counter_key = ? AND window_start = ? AND tenant = ?",
+		key,
+		windowStart,
+		r.tenant,
+	)
 
 	if r.repo.NoRows(err) {
 		return nil, nil
@@ -144,5 +729,537 @@ func (r Repository) getLastReceivedPayment() (*entities.ReceivedPayment, error)
 		return nil, err
 	}
 
-	return &receivedPayment, nil
+	found.SetExists()
+	return &found, nil
+}
+
+// GetSep31TransactionByPublicID returns SEP-31 transaction by ID returned to the sending anchor
+func (r Repository) GetSep31TransactionByPublicID(id string) (*entities.Sep31Transaction, error) {
+	var found entities.Sep31Transaction
+
+	err := r.repo.GetRaw(
+		&found,
+		"SELECT * FROM Sep31Transaction WHERE public_id = ? AND tenant = ?",
+		id,
+		r.tenant,
+	)
+
+	if r.repo.NoRows(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	found.SetExists()
+	return &found, nil
+}
+
+// GetSep31TransactionByMemo returns SEP-31 transaction that payments with a given memo belong to
+func (r Repository) GetSep31TransactionByMemo(memoType, memo string) (*entities.Sep31Transaction, error) {
+	var found entities.Sep31Transaction
+
+	err := r.repo.GetRaw(
+		&found,
+		"SELECT * FROM Sep31Transaction WHERE memo_type = ? AND memo = ? AND tenant = ?",
+		memoType,
+		memo,
+		r.tenant,
+	)
+
+	if r.repo.NoRows(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	found.SetExists()
+	return &found, nil
+}
+
+// GetTransferTransactionByPublicID returns a deposit or a withdrawal by ID returned to the wallet
+func (r Repository) GetTransferTransactionByPublicID(id string) (*entities.TransferTransaction, error) {
+	var found entities.TransferTransaction
+
+	err := r.repo.GetRaw(
+		&found,
+		"SELECT * FROM TransferTransaction WHERE public_id = ? AND tenant = ?",
+		id,
+		r.tenant,
+	)
+
+	if r.repo.NoRows(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	found.SetExists()
+	return &found, nil
+}
+
+// GetWithdrawalByMemo returns a withdrawal that payments with a given memo belong to
+func (r Repository) GetWithdrawalByMemo(memoType, memo string) (*entities.TransferTransaction, error) {
+	var found entities.TransferTransaction
+
+	err := r.repo.GetRaw(
+		&found,
+		"SELECT * FROM TransferTransaction WHERE kind = ? AND memo_type = ? AND memo = ? AND tenant = ?",
+		entities.TransferKindWithdrawal,
+		memoType,
+		memo,
+		r.tenant,
+	)
+
+	if r.repo.NoRows(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	found.SetExists()
+	return &found, nil
+}
+
+// GetKYCCustomerByID returns KYC store customer by id
+func (r Repository) GetKYCCustomerByID(id int64) (*entities.KYCCustomer, error) {
+	var found entities.KYCCustomer
+
+	err := r.repo.GetRaw(
+		&found,
+		"SELECT * FROM KYCCustomer WHERE id = ?",
+		id,
+	)
+
+	if r.repo.NoRows(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	found.SetExists()
+	return &found, nil
+}
+
+// GetKYCCustomerByAddress returns KYC store customer by Stellar address
+func (r Repository) GetKYCCustomerByAddress(address string) (*entities.KYCCustomer, error) {
+	var found entities.KYCCustomer
+
+	err := r.repo.GetRaw(
+		&found,
+		"SELECT * FROM KYCCustomer WHERE address = ?",
+		address,
+	)
+
+	if r.repo.NoRows(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	found.SetExists()
+	return &found, nil
+}
+
+// GetKYCCustomerByRoute returns KYC store customer receiving payments with a given route
+func (r Repository) GetKYCCustomerByRoute(route string) (*entities.KYCCustomer, error) {
+	var found entities.KYCCustomer
+
+	err := r.repo.GetRaw(
+		&found,
+		"SELECT * FROM KYCCustomer WHERE route = ?",
+		route,
+	)
+
+	if r.repo.NoRows(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	found.SetExists()
+	return &found, nil
+}
+
+// GetSep12CustomerByPublicID returns SEP-12 customer by public ID
+func (r Repository) GetSep12CustomerByPublicID(id string) (*entities.Sep12Customer, error) {
+	var found entities.Sep12Customer
+
+	err := r.repo.GetRaw(
+		&found,
+		"SELECT * FROM Sep12Customer WHERE public_id = ?",
+		id,
+	)
+
+	if r.repo.NoRows(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	found.SetExists()
+	return &found, nil
+}
+
+// GetSep12Customer returns SEP-12 customer of a Stellar account and memo (empty when the
+// account belongs to a single customer)
+func (r Repository) GetSep12Customer(account, memoType, memo string) (*entities.Sep12Customer, error) {
+	var found entities.Sep12Customer
+
+	err := r.repo.GetRaw(
+		&found,
+		"SELECT * FROM Sep12Customer WHERE account = ? AND memo_type = ? AND memo = ?",
+		account,
+		memoType,
+		memo,
+	)
+
+	if r.repo.NoRows(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	found.SetExists()
+	return &found, nil
+}
+
+// GetKYCCustomers returns all KYC store customers
+func (r Repository) GetKYCCustomers() ([]entities.KYCCustomer, error) {
+	customers := []entities.KYCCustomer{}
+	err := r.repo.SelectRaw(&customers, "SELECT * FROM KYCCustomer ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range customers {
+		customers[i].SetExists()
+	}
+
+	return customers, nil
+}
+
+// GetComplianceTransactionByID returns compliance transaction by id
+func (r Repository) GetComplianceTransactionByID(id int64) (*entities.ComplianceTransaction, error) {
+	var found entities.ComplianceTransaction
+
+	err := r.repo.GetRaw(
+		&found,
+		"SELECT * FROM ComplianceTransaction WHERE id = ?",
+		id,
+	)
+
+	if r.repo.NoRows(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	found.SetExists()
+	return &found, nil
+}
+
+// GetComplianceTransactionByTransactionID returns compliance transaction by direction and
+// hash of the Stellar transaction
+func (r Repository) GetComplianceTransactionByTransactionID(direction, transactionID string) (*entities.ComplianceTransaction, error) {
+	var found entities.ComplianceTransaction
+
+	err := r.repo.GetRaw(
+		&found,
+		"SELECT * FROM ComplianceTransaction WHERE direction = ? AND transaction_id = ?",
+		direction,
+		transactionID,
+	)
+
+	if r.repo.NoRows(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	found.SetExists()
+	return &found, nil
+}
+
+// GetComplianceTransactions returns compliance transactions matching filter, newest first
+func (r Repository) GetComplianceTransactions(filter entities.ComplianceTransactionFilter) ([]entities.ComplianceTransaction, error) {
+	query := "SELECT * FROM ComplianceTransaction WHERE 1 = 1"
+	args := []interface{}{}
+
+	if filter.Direction != "" {
+		query += " AND direction = ?"
+		args = append(args, filter.Direction)
+	}
+	if filter.TransactionID != "" {
+		query += " AND transaction_id = ?"
+		args = append(args, filter.TransactionID)
+	}
+	if filter.CounterpartyDomain != "" {
+		query += " AND counterparty_domain = ?"
+		args = append(args, filter.CounterpartyDomain)
+	}
+	if filter.Status != "" {
+		query += " AND status = ?"
+		args = append(args, filter.Status)
+	}
+	if filter.Since != nil {
+		query += " AND created_at >= ?"
+		args = append(args, *filter.Since)
+	}
+	if filter.Until != nil {
+		query += " AND created_at < ?"
+		args = append(args, *filter.Until)
+	}
+	query += " ORDER BY id DESC LIMIT ?"
+	args = append(args, filter.Limit)
+
+	transactions := []entities.ComplianceTransaction{}
+	err := r.repo.SelectRaw(&transactions, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range transactions {
+		transactions[i].SetExists()
+	}
+
+	return transactions, nil
+}
+
+// GetAPIKeyByID returns API key by id. API keys are not scoped to a tenant.
+func (r Repository) GetAPIKeyByID(id int64) (*entities.APIKey, error) {
+	var found entities.APIKey
+
+	err := r.repo.GetRaw(
+		&found,
+		"SELECT * FROM APIKey WHERE id = ?",
+		id,
+	)
+
+	if r.repo.NoRows(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	found.SetExists()
+	return &found, nil
+}
+
+// GetAPIKeyByHash returns API key by a hex-encoded SHA-256 hash of the key
+func (r Repository) GetAPIKeyByHash(keyHash string) (*entities.APIKey, error) {
+	var found entities.APIKey
+
+	err := r.getRaw(
+		&found,
+		"SELECT * FROM APIKey WHERE key_hash = ?",
+		keyHash,
+	)
+
+	if r.repo.NoRows(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	found.SetExists()
+	return &found, nil
+}
+
+// GetAPIKeys returns all API keys
+func (r Repository) GetAPIKeys() ([]entities.APIKey, error) {
+	apiKeys := []entities.APIKey{}
+	err := r.repo.SelectRaw(&apiKeys, "SELECT * FROM APIKey ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range apiKeys {
+		apiKeys[i].SetExists()
+	}
+
+	return apiKeys, nil
+}
+
+// GetFederationRecord runs `federation.query` with name param and returns the first row
+// or nil when name is not found. Columns other than account_id, memo_type and memo are ignored.
+func (r Repository) GetFederationRecord(query, name string) (*entities.FederationRecord, error) {
+	rows, err := r.repo.QueryRaw(query, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+
+	row := map[string]interface{}{}
+	err = rows.MapScan(row)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.FederationRecord{
+		AccountID: columnString(row["account_id"]),
+		MemoType:  columnString(row["memo_type"]),
+		Memo:      columnString(row["memo"]),
+	}, nil
+}
+
+// AcquireLease acquires lease name for holder (or renews it when holder already holds it) until
+// expiresAt. It returns false when the lease is held by another holder and has not expired at now.
+func (r Repository) AcquireLease(name, holder string, now, expiresAt time.Time) (bool, error) {
+	result, err := r.repo.ExecRaw(
+		"UPDATE ListenerLease SET holder = ?, expires_at = ? WHERE tenant = ? AND name = ? AND (holder = ? OR expires_at < ?)",
+		holder,
+		expiresAt.UTC(),
+		r.tenant,
+		name,
+		holder,
+		now.UTC(),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if updated > 0 {
+		return true, nil
+	}
+
+	_, err = r.repo.ExecRaw(
+		"INSERT INTO ListenerLease (name, tenant, holder, expires_at) VALUES (?, ?, ?, ?)",
+		name,
+		r.tenant,
+		holder,
+		expiresAt.UTC(),
+	)
+	if err != nil {
+		// Lease row has been created by another holder
+		var count int
+		countErr := r.repo.GetRaw(&count, "SELECT COUNT(*) FROM ListenerLease WHERE tenant = ? AND name = ?", r.tenant, name)
+		if countErr == nil && count > 0 {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ReleaseLease releases lease name when it's held by holder so other holders can acquire it
+// without waiting for it to expire
+func (r Repository) ReleaseLease(name, holder string) error {
+	_, err := r.repo.ExecRaw(
+		"DELETE FROM ListenerLease WHERE tenant = ? AND name = ? AND holder = ?",
+		r.tenant,
+		name,
+		holder,
+	)
+	return err
+}
+
+// IsCallbackDelivered returns true when a callback with deliveryID has been acknowledged
+func (r Repository) IsCallbackDelivered(deliveryID string) (bool, error) {
+	var count int
+	err := r.getRaw(
+		&count,
+		"SELECT COUNT(*) FROM CallbackDelivery WHERE tenant = ? AND delivery_id = ?",
+		r.tenant,
+		deliveryID,
+	)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// SaveCallbackDelivery saves an acknowledged callback. Saving a delivery that has already
+// been saved (by another instance) is not an error.
+func (r Repository) SaveCallbackDelivery(deliveryID, operationID, url string, deliveredAt time.Time) error {
+	_, err := r.repo.ExecRaw(
+		"INSERT INTO CallbackDelivery (delivery_id, tenant, operation_id, url, delivered_at) VALUES (?, ?, ?, ?, ?)",
+		deliveryID,
+		r.tenant,
+		operationID,
+		url,
+		deliveredAt.UTC(),
+	)
+	if err != nil {
+		delivered, deliveredErr := r.IsCallbackDelivered(deliveryID)
+		if deliveredErr == nil && delivered {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// SaveAuthNonce saves a nonce of an auth request signed by signingKey. It returns false when
+// the nonce has already been saved (the request is a replay).
+func (r Repository) SaveAuthNonce(signingKey, nonce string, createdAt time.Time) (bool, error) {
+	_, err := r.repo.ExecRaw(
+		"INSERT INTO AuthNonce (signing_key, nonce, created_at) VALUES (?, ?, ?)",
+		signingKey,
+		nonce,
+		createdAt.UTC(),
+	)
+	if err != nil {
+		var count int
+		countErr := r.repo.GetRaw(&count, "SELECT COUNT(*) FROM AuthNonce WHERE signing_key = ? AND nonce = ?", signingKey, nonce)
+		if countErr == nil && count > 0 {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// DeleteAuthNoncesBefore deletes nonces saved before given time
+func (r Repository) DeleteAuthNoncesBefore(before time.Time) error {
+	_, err := r.repo.ExecRaw("DELETE FROM AuthNonce WHERE created_at < ?", before.UTC())
+	return err
+}
+
+// columnString converts a value scanned by MapScan to string. NULL is an empty string.
+func columnString(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(value)
+	case string:
+		return value
+	default:
+		return fmt.Sprint(value)
+	}
 }
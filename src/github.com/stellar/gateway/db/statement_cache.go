@@ -0,0 +1,58 @@
+package db
+
+import (
+	"sync"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// StatementCache prepares every query once and reuses the prepared statement afterwards, so
+// queries run often are not parsed and planned by the DB every time. database/sql prepares
+// statements again on new connections of the pool. It's safe for concurrent use.
+type StatementCache struct {
+	db         *sqlx.DB
+	mutex      sync.Mutex
+	statements map[string]*sqlx.Stmt
+}
+
+// NewStatementCache creates a new StatementCache preparing statements in database
+func NewStatementCache(database *sqlx.DB) *StatementCache {
+	return &StatementCache{
+		db:         database,
+		statements: map[string]*sqlx.Stmt{},
+	}
+}
+
+// Prepare returns a prepared statement of query. Bindvars (`?`) are converted to the DB
+// format like in Repo.GetRaw.
+func (c *StatementCache) Prepare(query string) (*sqlx.Stmt, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if stmt, ok := c.statements[query]; ok {
+		return stmt, nil
+	}
+
+	stmt, err := c.db.Preparex(c.db.Rebind(query))
+	if err != nil {
+		return nil, err
+	}
+	c.statements[query] = stmt
+	return stmt, nil
+}
+
+// Close closes all cached statements
+func (c *StatementCache) Close() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var err error
+	for query, stmt := range c.statements {
+		closeErr := stmt.Close()
+		if closeErr != nil && err == nil {
+			err = closeErr
+		}
+		delete(c.statements, query)
+	}
+	return err
+}
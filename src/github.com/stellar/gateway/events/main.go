@@ -0,0 +1,38 @@
+// Package events publishes bridge server events (received payments, sent payment statuses)
+// to message brokers, alongside HTTP callbacks.
+package events
+
+import (
+	"encoding/json"
+)
+
+// Event types
+const (
+	TypePaymentReceived = "payment_received"
+	TypePaymentSent     = "payment_sent"
+)
+
+// Event is a single bridge server event. Payload is encoded as JSON.
+type Event struct {
+	Type    string
+	Tenant  string
+	Payload interface{}
+}
+
+// Publisher publishes events. Implementations must be safe for concurrent use.
+type Publisher interface {
+	Publish(event Event) error
+}
+
+// PaymentSent is a payload of TypePaymentSent events
+type PaymentSent struct {
+	TransactionID string `json:"transaction_id,omitempty"`
+	// Status is `success` or `failure`
+	Status string `json:"status"`
+	// Error is an error code returned by /payment endpoint when status is `failure`
+	Error       string           `json:"error,omitempty"`
+	Destination string           `json:"destination"`
+	Amount      string           `json:"amount"`
+	Asset       string           `json:"asset"`
+	Metadata    *json.RawMessage `json:"metadata,omitempty"`
+}
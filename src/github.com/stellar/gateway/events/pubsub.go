@@ -0,0 +1,233 @@
+package events
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/stellar/go/support/errors"
+)
+
+const (
+	pubSubEndpoint = "https://pubsub.googleapis.com/"
+	publishTimeout = 10 * time.Second
+	tokenLifetime  = time.Hour
+)
+
+// HTTP represents an http client that a publisher can use to make HTTP requests.
+type HTTP interface {
+	Do(req *http.Request) (resp *http.Response, err error)
+}
+
+// serviceAccount contains fields of a Google service account JSON key file used by PubSubPublisher
+type serviceAccount struct {
+	ProjectID    string `json:"project_id"`
+	PrivateKeyID string `json:"private_key_id"`
+	PrivateKey   string `json:"private_key"`
+	ClientEmail  string `json:"client_email"`
+}
+
+// PubSubPublisher publishes events to a Google Cloud Pub/Sub topic using Pub/Sub REST API.
+// Requests are authorized with a JWT signed by a service account key.
+//
+// Every message has `type` and `tenant` attributes and JSON-encoded event payload as data.
+type PubSubPublisher struct {
+	// Endpoint is a Pub/Sub API URL
+	Endpoint string
+	project  string
+	topic    string
+	account  serviceAccount
+	key      *rsa.PrivateKey
+	client   HTTP
+	now      func() time.Time
+	log      *logrus.Entry
+
+	mutex       sync.Mutex
+	token       string
+	tokenExpiry time.Time
+}
+
+// NewPubSubPublisher creates a new PubSubPublisher publishing to topic. Credentials are read
+// from a service account JSON key file. When project is empty, project of the service account is used.
+func NewPubSubPublisher(project, topic, credentialsFile string) (*PubSubPublisher, error) {
+	data, err := ioutil.ReadFile(credentialsFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading credentials file failed")
+	}
+
+	var account serviceAccount
+	err = json.Unmarshal(data, &account)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot unmarshal credentials file")
+	}
+
+	key, err := parsePrivateKey(account.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if project == "" {
+		project = account.ProjectID
+	}
+
+	if project == "" || account.ClientEmail == "" {
+		return nil, errors.New("credentials file must contain project_id and client_email")
+	}
+
+	return &PubSubPublisher{
+		Endpoint: pubSubEndpoint,
+		project:  project,
+		topic:    topic,
+		account:  account,
+		key:      key,
+		client:   &http.Client{Timeout: publishTimeout},
+		now:      time.Now,
+		log:      logrus.WithFields(logrus.Fields{"service": "PubSubPublisher"}),
+	}, nil
+}
+
+type pubSubMessage struct {
+	Data       string            `json:"data"`
+	Attributes map[string]string `json:"attributes"`
+}
+
+type pubSubPublishRequest struct {
+	Messages []pubSubMessage `json:"messages"`
+}
+
+// Publish publishes event to the topic
+func (p *PubSubPublisher) Publish(event Event) error {
+	data, err := json.Marshal(event.Payload)
+	if err != nil {
+		return errors.Wrap(err, "cannot marshal event payload")
+	}
+
+	body, err := json.Marshal(pubSubPublishRequest{
+		Messages: []pubSubMessage{{
+			Data:       base64.StdEncoding.EncodeToString(data),
+			Attributes: map[string]string{"type": event.Type, "tenant": event.Tenant},
+		}},
+	})
+	if err != nil {
+		return errors.Wrap(err, "cannot marshal publish request")
+	}
+
+	token, err := p.getToken()
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%sv1/projects/%s/topics/%s:publish", p.Endpoint, p.project, p.topic)
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "configure http request failed")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "http request errored")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := ioutil.ReadAll(resp.Body)
+		p.log.WithFields(logrus.Fields{
+			"status": resp.StatusCode,
+			"body":   string(respBody),
+		}).Error("Error response from Pub/Sub")
+		return fmt.Errorf("Pub/Sub response status code indicates error (%d)", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// getToken returns a cached JWT or signs a new one when it's about to expire
+func (p *PubSubPublisher) getToken() (string, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	now := p.now()
+	if p.token != "" && now.Add(5*time.Minute).Before(p.tokenExpiry) {
+		return p.token, nil
+	}
+
+	expiry := now.Add(tokenLifetime)
+	token, err := p.signToken(now, expiry)
+	if err != nil {
+		return "", err
+	}
+
+	p.token = token
+	p.tokenExpiry = expiry
+	return token, nil
+}
+
+func (p *PubSubPublisher) signToken(issuedAt, expiry time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{
+		"alg": "RS256",
+		"typ": "JWT",
+		"kid": p.account.PrivateKeyID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	claims, err := json.Marshal(map[string]interface{}{
+		"iss": p.account.ClientEmail,
+		"sub": p.account.ClientEmail,
+		"aud": pubSubEndpoint,
+		"iat": issuedAt.Unix(),
+		"exp": expiry.Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	encoding := base64.RawURLEncoding
+	signingInput := encoding.EncodeToString(header) + "." + encoding.EncodeToString(claims)
+
+	hash := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, p.key, crypto.SHA256, hash[:])
+	if err != nil {
+		return "", errors.Wrap(err, "signing token failed")
+	}
+
+	return signingInput + "." + encoding.EncodeToString(signature), nil
+}
+
+func parsePrivateKey(data string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, errors.New("credentials file does not contain a PEM encoded private_key")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot parse private_key")
+	}
+
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private_key is not an RSA key")
+	}
+
+	return key, nil
+}
@@ -0,0 +1,114 @@
+package events
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeCredentials(t *testing.T, key *rsa.PrivateKey) string {
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	credentials, err := json.Marshal(serviceAccount{
+		ProjectID:    "acme",
+		PrivateKeyID: "key1",
+		PrivateKey:   string(keyPEM),
+		ClientEmail:  "bridge@acme.iam.gserviceaccount.com",
+	})
+	require.NoError(t, err)
+
+	file, err := ioutil.TempFile("", "credentials")
+	require.NoError(t, err)
+	defer file.Close()
+	_, err = file.Write(credentials)
+	require.NoError(t, err)
+	return file.Name()
+}
+
+func TestPubSubPublisher(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	credentialsFile := writeCredentials(t, key)
+	defer os.Remove(credentialsFile)
+
+	var tokens []string
+	var request pubSubPublishRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/projects/acme/topics/payments:publish", r.URL.Path)
+		tokens = append(tokens, strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		w.Write([]byte(`{"messageIds": ["1"]}`))
+	}))
+	defer srv.Close()
+
+	publisher, err := NewPubSubPublisher("", "payments", credentialsFile)
+	require.NoError(t, err)
+	publisher.Endpoint = srv.URL + "/"
+
+	err = publisher.Publish(Event{Type: TypePaymentSent, Tenant: "acme", Payload: PaymentSent{Status: "success"}})
+	require.NoError(t, err)
+
+	if assert.Len(t, request.Messages, 1) {
+		message := request.Messages[0]
+		assert.Equal(t, map[string]string{"type": "payment_sent", "tenant": "acme"}, message.Attributes)
+		data, err := base64.StdEncoding.DecodeString(message.Data)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"status": "success", "destination": "", "amount": "", "asset": ""}`, string(data))
+	}
+
+	// token is signed by the service account key
+	parts := strings.Split(tokens[0], ".")
+	require.Len(t, parts, 3)
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	require.NoError(t, err)
+	hash := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, hash[:], signature))
+
+	claims, err := base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(t, err)
+	assert.Contains(t, string(claims), `"iss":"bridge@acme.iam.gserviceaccount.com"`)
+
+	// token is reused
+	err = publisher.Publish(Event{Type: TypePaymentSent, Payload: PaymentSent{Status: "failure"}})
+	require.NoError(t, err)
+	assert.Equal(t, tokens[0], tokens[1])
+}
+
+func TestPubSubPublisherErrorResponse(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	credentialsFile := writeCredentials(t, key)
+	defer os.Remove(credentialsFile)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	publisher, err := NewPubSubPublisher("other", "payments", credentialsFile)
+	require.NoError(t, err)
+	publisher.Endpoint = srv.URL + "/"
+
+	err = publisher.Publish(Event{Type: TypePaymentSent, Payload: PaymentSent{}})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "403")
+	}
+}
+
+func TestNewPubSubPublisherInvalidCredentials(t *testing.T) {
+	_, err := NewPubSubPublisher("", "payments", "/does/not/exist.json")
+	assert.Error(t, err)
+}
@@ -2,6 +2,79 @@ package horizon
 
 // AccountResponse contains account data returned by Horizon
 type AccountResponse struct {
-	AccountID      string `json:"id"`
-	SequenceNumber string `json:"sequence"`
+	AccountID      string     `json:"id"`
+	SequenceNumber string     `json:"sequence"`
+	HomeDomain     string     `json:"home_domain"`
+	Balances       []Balance  `json:"balances"`
+	Signers        []Signer   `json:"signers"`
+	Thresholds     Thresholds `json:"thresholds"`
+	Flags          Flags      `json:"flags"`
+	// Data contains base64-encoded values of data entries of the account
+	Data map[string]string `json:"data"`
+}
+
+// MemoRequired returns true when the account requires payments to contain a memo using
+// `config.memo_required` data entry (SEP-29)
+func (a AccountResponse) MemoRequired() bool {
+	return a.Data["config.memo_required"] == "MQ=="
+}
+
+// Flags contains authorization flags of an (issuing) account
+type Flags struct {
+	AuthRequired  bool `json:"auth_required"`
+	AuthRevocable bool `json:"auth_revocable"`
+}
+
+// Signer contains a single signer of an account
+type Signer struct {
+	PublicKey string `json:"public_key"`
+	Key       string `json:"key"`
+	Weight    int32  `json:"weight"`
+}
+
+// ID returns signer key. Older Horizon versions return it in `public_key` field.
+func (s Signer) ID() string {
+	if s.Key != "" {
+		return s.Key
+	}
+	return s.PublicKey
+}
+
+// Thresholds contains thresholds of an account
+type Thresholds struct {
+	LowThreshold  byte `json:"low_threshold"`
+	MedThreshold  byte `json:"med_threshold"`
+	HighThreshold byte `json:"high_threshold"`
+}
+
+// Balance contains a single balance of an account
+type Balance struct {
+	Balance     string `json:"balance"`
+	AssetType   string `json:"asset_type"`
+	AssetCode   string `json:"asset_code"`
+	AssetIssuer string `json:"asset_issuer"`
+	// Limit, IsAuthorized and IsAuthorizedToMaintainLiabilities are empty for native balance
+	Limit                             string `json:"limit,omitempty"`
+	IsAuthorized                      *bool  `json:"is_authorized"`
+	IsAuthorizedToMaintainLiabilities *bool  `json:"is_authorized_to_maintain_liabilities,omitempty"`
+}
+
+// NativeBalance returns XLM balance of the account or "0" if not found
+func (a AccountResponse) NativeBalance() string {
+	for _, balance := range a.Balances {
+		if balance.AssetType == "native" {
+			return balance.Balance
+		}
+	}
+	return "0"
+}
+
+// CreditBalance returns balance of a credit asset or false if the account has no trustline for it
+func (a AccountResponse) CreditBalance(code, issuer string) (Balance, bool) {
+	for _, balance := range a.Balances {
+		if balance.AssetType != "native" && balance.AssetCode == code && balance.AssetIssuer == issuer {
+			return balance, true
+		}
+	}
+	return Balance{}, false
 }
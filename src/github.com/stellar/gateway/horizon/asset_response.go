@@ -0,0 +1,12 @@
+package horizon
+
+// AssetResponse contains stats of a credit asset returned by Horizon. Flags are flags of the
+// issuing account.
+type AssetResponse struct {
+	AssetType   string `json:"asset_type"`
+	AssetCode   string `json:"asset_code"`
+	AssetIssuer string `json:"asset_issuer"`
+	Amount      string `json:"amount"`
+	NumAccounts int32  `json:"num_accounts"`
+	Flags       Flags  `json:"flags"`
+}
@@ -0,0 +1,238 @@
+package horizon
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/stellar/gateway/metrics"
+)
+
+// cacheMaxAccounts is a number of cached accounts after which expired accounts are removed
+// from the cache. New accounts are not cached while all cached accounts are fresh.
+const cacheMaxAccounts = 1000
+
+// cacheMaxAssets is a number of cached assets after which expired assets are removed
+const cacheMaxAssets = 100
+
+// Cache implements HorizonInterface caching account, asset and fee stats responses of another
+// HorizonInterface for TTL, so bursts of requests loading the same accounts are served
+// without querying Horizon. Errors are not cached. Every submitted transaction clears
+// cached accounts because it changes sequence numbers and balances (also when it failed),
+// and cached assets because it can change flags of their issuers.
+// Other requests are passed through.
+// Transactions can also be submitted by other processes so cached sequence numbers can be
+// stale: use Uncached to load accounts whose sequence numbers are used in transactions.
+type Cache struct {
+	horizon HorizonInterface
+	ttl     time.Duration
+	now     func() time.Time
+
+	mutex    sync.Mutex
+	accounts map[string]cachedAccount
+	assets   map[string]cachedAsset
+	feeStats *cachedFeeStats
+	// generation is incremented by Invalidate so accounts and assets loaded before are not cached
+	generation uint64
+}
+
+type cachedAccount struct {
+	response  AccountResponse
+	expiresAt time.Time
+}
+
+type cachedAsset struct {
+	response  AssetResponse
+	expiresAt time.Time
+}
+
+type cachedFeeStats struct {
+	response  FeeStatsResponse
+	expiresAt time.Time
+}
+
+var _ HorizonInterface = &Cache{}
+
+// NewCache creates a new Cache of h responses
+func NewCache(h HorizonInterface, ttl time.Duration) *Cache {
+	return &Cache{
+		horizon:  h,
+		ttl:      ttl,
+		now:      time.Now,
+		accounts: map[string]cachedAccount{},
+		assets:   map[string]cachedAsset{},
+	}
+}
+
+// LoadAccount loads a single account or returns it from the cache
+func (c *Cache) LoadAccount(ctx context.Context, accountID string) (response AccountResponse, err error) {
+	c.mutex.Lock()
+	cached, ok := c.accounts[accountID]
+	generation := c.generation
+	c.mutex.Unlock()
+	if ok && c.now().Before(cached.expiresAt) {
+		metrics.AddCounter("horizon_cache_hits", 1, metrics.Tags{"request": "load_account"})
+		return cached.response, nil
+	}
+
+	response, err = c.horizon.LoadAccount(ctx, accountID)
+	if err != nil {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	// Account could have been changed by a transaction submitted while it was loading
+	if generation != c.generation {
+		return
+	}
+	if len(c.accounts) >= cacheMaxAccounts {
+		c.removeExpiredAccounts()
+	}
+	if len(c.accounts) < cacheMaxAccounts {
+		c.accounts[accountID] = cachedAccount{response: response, expiresAt: c.now().Add(c.ttl)}
+	}
+	return
+}
+
+func (c *Cache) removeExpiredAccounts() {
+	now := c.now()
+	for accountID, cached := range c.accounts {
+		if !now.Before(cached.expiresAt) {
+			delete(c.accounts, accountID)
+		}
+	}
+}
+
+// LoadAsset loads stats and flags of an asset or returns them from the cache
+func (c *Cache) LoadAsset(ctx context.Context, assetCode, assetIssuer string) (response AssetResponse, err error) {
+	key := assetCode + ":" + assetIssuer
+	c.mutex.Lock()
+	cached, ok := c.assets[key]
+	generation := c.generation
+	c.mutex.Unlock()
+	if ok && c.now().Before(cached.expiresAt) {
+		metrics.AddCounter("horizon_cache_hits", 1, metrics.Tags{"request": "load_asset"})
+		return cached.response, nil
+	}
+
+	response, err = c.horizon.LoadAsset(ctx, assetCode, assetIssuer)
+	if err != nil {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	// Issuer flags could have been changed by a transaction submitted while it was loading
+	if generation != c.generation {
+		return
+	}
+	if len(c.assets) >= cacheMaxAssets {
+		now := c.now()
+		for key, cached := range c.assets {
+			if !now.Before(cached.expiresAt) {
+				delete(c.assets, key)
+			}
+		}
+	}
+	if len(c.assets) < cacheMaxAssets {
+		c.assets[key] = cachedAsset{response: response, expiresAt: c.now().Add(c.ttl)}
+	}
+	return
+}
+
+// LoadFeeStats loads fee stats of recent ledgers or returns them from the cache
+func (c *Cache) LoadFeeStats(ctx context.Context) (response FeeStatsResponse, err error) {
+	c.mutex.Lock()
+	cached := c.feeStats
+	c.mutex.Unlock()
+	if cached != nil && c.now().Before(cached.expiresAt) {
+		metrics.AddCounter("horizon_cache_hits", 1, metrics.Tags{"request": "load_fee_stats"})
+		return cached.response, nil
+	}
+
+	response, err = c.horizon.LoadFeeStats(ctx)
+	if err != nil {
+		return
+	}
+
+	c.mutex.Lock()
+	c.feeStats = &cachedFeeStats{response: response, expiresAt: c.now().Add(c.ttl)}
+	c.mutex.Unlock()
+	return
+}
+
+// SubmitTransaction submits a transaction to Stellar network and clears cached accounts and assets
+func (c *Cache) SubmitTransaction(ctx context.Context, txeBase64 string) (response SubmitTransactionResponse, err error) {
+	response, err = c.horizon.SubmitTransaction(ctx, txeBase64)
+	c.Invalidate()
+	return
+}
+
+// Invalidate clears cached accounts and assets
+func (c *Cache) Invalidate() {
+	c.mutex.Lock()
+	c.accounts = map[string]cachedAccount{}
+	c.assets = map[string]cachedAsset{}
+	c.generation++
+	c.mutex.Unlock()
+}
+
+// Uncached returns HorizonInterface h caches responses of, or h when it's not a Cache. Use
+// it to load accounts with current sequence numbers.
+func Uncached(h HorizonInterface) HorizonInterface {
+	if c, ok := h.(*Cache); ok {
+		return c.horizon
+	}
+	return h
+}
+
+// LoadMemo loads memo and details of a transaction in PaymentResponse
+func (c *Cache) LoadMemo(ctx context.Context, p *PaymentResponse) (err error) {
+	return c.horizon.LoadMemo(ctx, p)
+}
+
+// LoadRoot loads Horizon root endpoint. It's not cached so the latest ledger is current.
+func (c *Cache) LoadRoot(ctx context.Context) (response RootResponse, err error) {
+	return c.horizon.LoadRoot(ctx)
+}
+
+// LoadOperation loads a single operation
+func (c *Cache) LoadOperation(ctx context.Context, operationID string) (payment PaymentResponse, err error) {
+	return c.horizon.LoadOperation(ctx, operationID)
+}
+
+// LoadTransaction loads a single transaction
+func (c *Cache) LoadTransaction(ctx context.Context, hash string) (transaction TransactionResponse, err error) {
+	return c.horizon.LoadTransaction(ctx, hash)
+}
+
+// LoadClaimableBalanceID loads ID of a balance created in create_claimable_balance operation
+func (c *Cache) LoadClaimableBalanceID(ctx context.Context, p *PaymentResponse) (err error) {
+	return c.horizon.LoadClaimableBalanceID(ctx, p)
+}
+
+// FindPaths finds payment paths converting sourceAsset to destinationAmount of destinationAsset
+func (c *Cache) FindPaths(ctx context.Context, sourceAsset, destinationAsset, destinationAmount string) (paths []PathResponse, err error) {
+	return c.horizon.FindPaths(ctx, sourceAsset, destinationAsset, destinationAmount)
+}
+
+// LoadOrderBook loads the best offers of sellingAsset/buyingAsset order book
+func (c *Cache) LoadOrderBook(ctx context.Context, sellingAsset, buyingAsset string) (response OrderBookResponse, err error) {
+	return c.horizon.LoadOrderBook(ctx, sellingAsset, buyingAsset)
+}
+
+// StreamPayments streams incoming payments
+func (c *Cache) StreamPayments(ctx context.Context, accountID string, cursor *string, onPaymentHandler PaymentHandler) (err error) {
+	return c.horizon.StreamPayments(ctx, accountID, cursor, onPaymentHandler)
+}
+
+// StreamOperations streams all operations of the account
+func (c *Cache) StreamOperations(ctx context.Context, accountID string, cursor *string, onPaymentHandler PaymentHandler) (err error) {
+	return c.horizon.StreamOperations(ctx, accountID, cursor, onPaymentHandler)
+}
+
+// StreamEffects streams effects of all accounts
+func (c *Cache) StreamEffects(ctx context.Context, cursor *string, onEffectHandler EffectHandler) (err error) {
+	return c.horizon.StreamEffects(ctx, cursor, onEffectHandler)
+}
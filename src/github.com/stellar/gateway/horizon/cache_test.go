@@ -0,0 +1,138 @@
+package horizon
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache(t *testing.T) {
+	requests := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+		switch r.URL.Path {
+		case "/accounts/GAMVF7G4GJC4A7JMFJWLUAEIBFQD5RT3DCB5DC5TJDEKQBBACQ4JJVEE":
+			w.Write([]byte(`{"id": "GAMVF7G4GJC4A7JMFJWLUAEIBFQD5RT3DCB5DC5TJDEKQBBACQ4JJVEE", "sequence": "12"}`))
+		case "/fee_stats":
+			w.Write([]byte(`{"last_ledger_base_fee": "100"}`))
+		case "/assets":
+			if r.URL.Query().Get("asset_code") == "USD" {
+				w.Write([]byte(`{"_embedded": {"records": [{"asset_code": "USD", "asset_issuer": "GBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFZYYQ", "flags": {"auth_required": true}}]}}`))
+			} else {
+				w.Write([]byte(`{"_embedded": {"records": []}}`))
+			}
+		case "/transactions":
+			w.Write([]byte(`{"hash": "abc", "ledger": 1}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	h := New(server.URL)
+	c := NewCache(&h, time.Minute)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	loadAccount := func() {
+		account, err := c.LoadAccount(context.Background(), "GAMVF7G4GJC4A7JMFJWLUAEIBFQD5RT3DCB5DC5TJDEKQBBACQ4JJVEE")
+		require.NoError(t, err)
+		assert.Equal(t, "12", account.SequenceNumber)
+	}
+	accountPath := "/accounts/GAMVF7G4GJC4A7JMFJWLUAEIBFQD5RT3DCB5DC5TJDEKQBBACQ4JJVEE"
+
+	loadAccount()
+	loadAccount()
+	assert.Equal(t, 1, requests[accountPath])
+
+	// Errors are not cached
+	for i := 0; i < 2; i++ {
+		_, err := c.LoadAccount(context.Background(), "GBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFZYYQ")
+		assert.Error(t, err)
+	}
+	assert.Equal(t, 2, requests["/accounts/GBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFZYYQ"])
+
+	loadAsset := func(code string) (AssetResponse, error) {
+		return c.LoadAsset(context.Background(), code, "GBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFZYYQ")
+	}
+	for i := 0; i < 2; i++ {
+		asset, err := loadAsset("USD")
+		require.NoError(t, err)
+		assert.True(t, asset.Flags.AuthRequired)
+	}
+	assert.Equal(t, 1, requests["/assets"])
+
+	// Unknown assets are not found and not cached
+	_, err := loadAsset("EUR")
+	statusError, ok := err.(*StatusError)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusNotFound, statusError.StatusCode)
+	assert.Equal(t, 2, requests["/assets"])
+
+	// Submitted transaction clears accounts and assets
+	c.SubmitTransaction(context.Background(), "AAAA")
+	loadAccount()
+	assert.Equal(t, 2, requests[accountPath])
+	_, err = loadAsset("USD")
+	require.NoError(t, err)
+	assert.Equal(t, 3, requests["/assets"])
+
+	// Expired responses are loaded again
+	for i := 0; i < 2; i++ {
+		_, err := c.LoadFeeStats(context.Background())
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, requests["/fee_stats"])
+
+	now = now.Add(time.Minute)
+	loadAccount()
+	_, err = c.LoadFeeStats(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, requests[accountPath])
+	assert.Equal(t, 2, requests["/fee_stats"])
+}
+
+func TestCacheInvalidateDuringLoad(t *testing.T) {
+	var requests int32
+	loading := make(chan struct{})
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			close(loading)
+			<-release
+		}
+		w.Write([]byte(`{"id": "GAMVF7G4GJC4A7JMFJWLUAEIBFQD5RT3DCB5DC5TJDEKQBBACQ4JJVEE", "sequence": "12"}`))
+	}))
+	defer server.Close()
+
+	h := New(server.URL)
+	c := NewCache(&h, time.Minute)
+
+	done := make(chan error)
+	go func() {
+		_, err := c.LoadAccount(context.Background(), "GAMVF7G4GJC4A7JMFJWLUAEIBFQD5RT3DCB5DC5TJDEKQBBACQ4JJVEE")
+		done <- err
+	}()
+
+	// Transaction submitted while the account is loading makes the response stale
+	<-loading
+	c.Invalidate()
+	close(release)
+	require.NoError(t, <-done)
+
+	_, err := c.LoadAccount(context.Background(), "GAMVF7G4GJC4A7JMFJWLUAEIBFQD5RT3DCB5DC5TJDEKQBBACQ4JJVEE")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+
+	// Uncached accounts are always loaded from Horizon
+	_, err = Uncached(c).LoadAccount(context.Background(), "GAMVF7G4GJC4A7JMFJWLUAEIBFQD5RT3DCB5DC5TJDEKQBBACQ4JJVEE")
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+	assert.Equal(t, &h, Uncached(&h))
+}
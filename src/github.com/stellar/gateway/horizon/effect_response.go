@@ -0,0 +1,15 @@
+package horizon
+
+// EffectResponse contains a single effect data returned by Horizon
+type EffectResponse struct {
+	ID          string `json:"id"`
+	PagingToken string `json:"paging_token"`
+	Type        string `json:"type"`
+	Account     string `json:"account"`
+
+	// trustline_* fields
+	AssetType   string `json:"asset_type"`
+	AssetCode   string `json:"asset_code"`
+	AssetIssuer string `json:"asset_issuer"`
+	Limit       string `json:"limit"`
+}
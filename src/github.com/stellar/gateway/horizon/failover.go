@@ -0,0 +1,299 @@
+package horizon
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/metrics"
+)
+
+const (
+	// failoverMaxFailures is a number of consecutive failures of a server after which
+	// its circuit is opened
+	failoverMaxFailures = 3
+	// failoverCooldown is a time requests are not sent to a server after its circuit is opened
+	failoverCooldown = 30 * time.Second
+	// healthCheckTimeout is a timeout of a single health check request
+	healthCheckTimeout = 5 * time.Second
+)
+
+// Failover implements HorizonInterface using multiple Horizon servers. Requests are sent
+// to the first available server in order. A server becomes unavailable (its circuit is open)
+// after failoverMaxFailures consecutive failures or a failed health check. It's available
+// again after a successful health check or failoverCooldown (a single request is sent then
+// and the circuit is opened again if it fails).
+//
+// Failed requests (connection errors and 5xx responses) are retried using the next server.
+// Streams are not retried: a failed stream returns an error and the next stream is opened
+// using the next available server. Submitting a transaction again is safe because it can be
+// applied only once.
+type Failover struct {
+	servers []*failoverServer
+	log     *logrus.Entry
+
+	mutex sync.Mutex
+}
+
+type failoverServer struct {
+	url     string
+	horizon HorizonInterface
+
+	failures  int
+	openUntil time.Time
+}
+
+var _ HorizonInterface = &Failover{}
+
+// NewFailover creates a new Failover using Horizon servers at serverURLs. The first
+// server is the primary one. Requests to every server are limited by timeout (see Horizon.Timeout).
+func NewFailover(serverURLs []string, timeout time.Duration) *Failover {
+	f := &Failover{
+		log: logrus.WithFields(logrus.Fields{"service": "HorizonFailover"}),
+	}
+	for _, serverURL := range serverURLs {
+		h := New(serverURL)
+		h.Timeout = timeout
+		f.servers = append(f.servers, &failoverServer{url: serverURL, horizon: &h})
+	}
+	return f
+}
+
+// StartHealthChecks checks every server each interval in a new goroutine. Server is healthy
+// when its root endpoint responds with 200 OK.
+func (f *Failover) StartHealthChecks(interval time.Duration) {
+	go func() {
+		for {
+			f.CheckHealth()
+			time.Sleep(interval)
+		}
+	}()
+}
+
+// CheckHealth checks every server once and opens circuits of unhealthy servers
+func (f *Failover) CheckHealth() {
+	client := http.Client{Timeout: healthCheckTimeout}
+	for _, server := range f.servers {
+		resp, err := client.Get(server.url)
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				err = fmt.Errorf("health check status code: %d", resp.StatusCode)
+			}
+		}
+
+		f.mutex.Lock()
+		if err != nil {
+			f.log.WithFields(logrus.Fields{"server": server.url, "err": err}).Warn("Horizon server is unhealthy")
+			f.open(server)
+		} else {
+			server.failures = 0
+			server.openUntil = time.Time{}
+		}
+		f.mutex.Unlock()
+	}
+}
+
+// available returns servers in order of use: servers with closed circuits first
+func (f *Failover) available() []*failoverServer {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	now := time.Now()
+	servers := make([]*failoverServer, 0, len(f.servers))
+	var open []*failoverServer
+	for _, server := range f.servers {
+		if now.Before(server.openUntil) {
+			open = append(open, server)
+		} else {
+			servers = append(servers, server)
+		}
+	}
+	// Servers with open circuits are used only when all servers are unavailable
+	return append(servers, open...)
+}
+
+// record updates the circuit of server after a request that failed with err
+func (f *Failover) record(server *failoverServer, err error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if err == nil {
+		server.failures = 0
+		server.openUntil = time.Time{}
+		return
+	}
+
+	server.failures++
+	// A single failure opens the circuit again after the cooldown
+	if server.failures >= failoverMaxFailures || !server.openUntil.IsZero() {
+		f.open(server)
+	}
+}
+
+func (f *Failover) open(server *failoverServer) {
+	if time.Now().Before(server.openUntil) {
+		return
+	}
+	server.openUntil = time.Now().Add(failoverCooldown)
+	f.log.WithFields(logrus.Fields{"server": server.url}).Error("Horizon server circuit opened")
+	metrics.AddCounter("horizon_circuit_opened", 1, metrics.Tags{"server": server.url})
+}
+
+// do calls request using available servers until it succeeds or returns an error that
+// is not caused by a server failure. Requests cancelled by ctx are not retried and don't
+// open circuits.
+func (f *Failover) do(ctx context.Context, request func(h HorizonInterface) error) (err error) {
+	for _, server := range f.available() {
+		err = request(server.horizon)
+		if ctx.Err() != nil {
+			return
+		}
+		if !isServerError(err) {
+			f.record(server, nil)
+			return
+		}
+
+		f.record(server, err)
+		f.log.WithFields(logrus.Fields{"server": server.url, "err": err}).Warn("Horizon request failed, trying next server")
+	}
+	return
+}
+
+// stream opens a stream using the first available server
+func (f *Failover) stream(ctx context.Context, stream func(h HorizonInterface) error) error {
+	server := f.available()[0]
+	err := stream(server.horizon)
+	if err != ErrStopStreaming && ctx.Err() == nil {
+		f.record(server, err)
+	}
+	return err
+}
+
+// isServerError returns false when err is nil or Horizon responded with 4xx status code
+func isServerError(err error) bool {
+	if err == nil {
+		return false
+	}
+	statusError, ok := err.(*StatusError)
+	return !ok || statusError.StatusCode >= 500
+}
+
+// LoadAccount loads a single account
+func (f *Failover) LoadAccount(ctx context.Context, accountID string) (response AccountResponse, err error) {
+	err = f.do(ctx, func(h HorizonInterface) (err error) {
+		response, err = h.LoadAccount(ctx, accountID)
+		return
+	})
+	return
+}
+
+// LoadMemo loads memo and details of a transaction in PaymentResponse
+func (f *Failover) LoadMemo(ctx context.Context, p *PaymentResponse) (err error) {
+	return f.do(ctx, func(h HorizonInterface) error {
+		return h.LoadMemo(ctx, p)
+	})
+}
+
+// LoadOperation loads a single operation
+func (f *Failover) LoadOperation(ctx context.Context, operationID string) (payment PaymentResponse, err error) {
+	err = f.do(ctx, func(h HorizonInterface) (err error) {
+		payment, err = h.LoadOperation(ctx, operationID)
+		return
+	})
+	return
+}
+
+// LoadTransaction loads a single transaction
+func (f *Failover) LoadTransaction(ctx context.Context, hash string) (transaction TransactionResponse, err error) {
+	err = f.do(ctx, func(h HorizonInterface) (err error) {
+		transaction, err = h.LoadTransaction(ctx, hash)
+		return
+	})
+	return
+}
+
+// LoadClaimableBalanceID loads ID of a balance created in create_claimable_balance operation
+func (f *Failover) LoadClaimableBalanceID(ctx context.Context, p *PaymentResponse) (err error) {
+	return f.do(ctx, func(h HorizonInterface) error {
+		return h.LoadClaimableBalanceID(ctx, p)
+	})
+}
+
+// LoadFeeStats loads fee stats of recent ledgers
+func (f *Failover) LoadFeeStats(ctx context.Context) (response FeeStatsResponse, err error) {
+	err = f.do(ctx, func(h HorizonInterface) (err error) {
+		response, err = h.LoadFeeStats(ctx)
+		return
+	})
+	return
+}
+
+// LoadAsset loads stats and flags of an asset
+func (f *Failover) LoadAsset(ctx context.Context, assetCode, assetIssuer string) (response AssetResponse, err error) {
+	err = f.do(ctx, func(h HorizonInterface) (err error) {
+		response, err = h.LoadAsset(ctx, assetCode, assetIssuer)
+		return
+	})
+	return
+}
+
+// LoadRoot loads Horizon root endpoint
+func (f *Failover) LoadRoot(ctx context.Context) (response RootResponse, err error) {
+	err = f.do(ctx, func(h HorizonInterface) (err error) {
+		response, err = h.LoadRoot(ctx)
+		return
+	})
+	return
+}
+
+// FindPaths finds payment paths converting sourceAsset to destinationAmount of destinationAsset
+func (f *Failover) FindPaths(ctx context.Context, sourceAsset, destinationAsset, destinationAmount string) (paths []PathResponse, err error) {
+	err = f.do(ctx, func(h HorizonInterface) (err error) {
+		paths, err = h.FindPaths(ctx, sourceAsset, destinationAsset, destinationAmount)
+		return
+	})
+	return
+}
+
+// LoadOrderBook loads the best offers of sellingAsset/buyingAsset order book
+func (f *Failover) LoadOrderBook(ctx context.Context, sellingAsset, buyingAsset string) (response OrderBookResponse, err error) {
+	err = f.do(ctx, func(h HorizonInterface) (err error) {
+		response, err = h.LoadOrderBook(ctx, sellingAsset, buyingAsset)
+		return
+	})
+	return
+}
+
+// StreamPayments streams incoming payments
+func (f *Failover) StreamPayments(ctx context.Context, accountID string, cursor *string, onPaymentHandler PaymentHandler) (err error) {
+	return f.stream(ctx, func(h HorizonInterface) error {
+		return h.StreamPayments(ctx, accountID, cursor, onPaymentHandler)
+	})
+}
+
+// StreamOperations streams all operations of the account
+func (f *Failover) StreamOperations(ctx context.Context, accountID string, cursor *string, onPaymentHandler PaymentHandler) (err error) {
+	return f.stream(ctx, func(h HorizonInterface) error {
+		return h.StreamOperations(ctx, accountID, cursor, onPaymentHandler)
+	})
+}
+
+// StreamEffects streams effects of all accounts
+func (f *Failover) StreamEffects(ctx context.Context, cursor *string, onEffectHandler EffectHandler) (err error) {
+	return f.stream(ctx, func(h HorizonInterface) error {
+		return h.StreamEffects(ctx, cursor, onEffectHandler)
+	})
+}
+
+// SubmitTransaction submits a transaction to Stellar network
+func (f *Failover) SubmitTransaction(ctx context.Context, txeBase64 string) (response SubmitTransactionResponse, err error) {
+	err = f.do(ctx, func(h HorizonInterface) (err error) {
+		response, err = h.SubmitTransaction(ctx, txeBase64)
+		return
+	})
+	return
+}
@@ -0,0 +1,100 @@
+package horizon
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFailover(t *testing.T) {
+	var primaryRequests int
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		primaryRequests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer primary.Close()
+
+	secondary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			w.Write([]byte(`{}`))
+		case "/accounts/GAMVF7G4GJC4A7JMFJWLUAEIBFQD5RT3DCB5DC5TJDEKQBBACQ4JJVEE":
+			w.Write([]byte(`{"id": "GAMVF7G4GJC4A7JMFJWLUAEIBFQD5RT3DCB5DC5TJDEKQBBACQ4JJVEE", "sequence": "12"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer secondary.Close()
+
+	f := NewFailover([]string{primary.URL, secondary.URL}, time.Second)
+
+	for i := 0; i < failoverMaxFailures; i++ {
+		account, err := f.LoadAccount(context.Background(), "GAMVF7G4GJC4A7JMFJWLUAEIBFQD5RT3DCB5DC5TJDEKQBBACQ4JJVEE")
+		require.NoError(t, err)
+		assert.Equal(t, "12", account.SequenceNumber)
+	}
+	assert.Equal(t, failoverMaxFailures, primaryRequests)
+
+	// Primary server circuit is open
+	_, err := f.LoadAccount(context.Background(), "GAMVF7G4GJC4A7JMFJWLUAEIBFQD5RT3DCB5DC5TJDEKQBBACQ4JJVEE")
+	require.NoError(t, err)
+	assert.Equal(t, failoverMaxFailures, primaryRequests)
+
+	// 4xx responses are not retried
+	_, err = f.LoadAccount(context.Background(), "GCLOMB72ODBFUGK4E2BK7VMR3RNZ5WSTMEOGNA2YUVHFR3WMH2XBAB6H")
+	statusError, ok := err.(*StatusError)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusNotFound, statusError.StatusCode)
+	assert.Equal(t, failoverMaxFailures, primaryRequests)
+
+	// Health check closes the circuit of a healthy server
+	f.CheckHealth()
+	assert.True(t, f.servers[0].openUntil.After(f.servers[1].openUntil))
+	assert.True(t, f.servers[1].openUntil.IsZero())
+}
+
+func TestTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	h := New(server.URL)
+	h.Timeout = 50 * time.Millisecond
+	_, err := h.LoadFeeStats(context.Background())
+	assert.Error(t, err)
+
+	f := NewFailover([]string{server.URL}, 50*time.Millisecond)
+	_, err = f.LoadFeeStats(context.Background())
+	assert.Error(t, err)
+}
+
+func TestFailoverCancel(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	f := NewFailover([]string{server.URL, server.URL}, time.Second)
+
+	// Cancelled requests are not retried and don't open circuits
+	for i := 0; i < failoverMaxFailures; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		_, err := f.LoadFeeStats(ctx)
+		cancel()
+		assert.Error(t, err)
+	}
+	assert.Equal(t, int32(failoverMaxFailures), atomic.LoadInt32(&requests))
+	assert.Zero(t, f.servers[0].failures)
+	assert.True(t, f.servers[0].openUntil.IsZero())
+}
@@ -0,0 +1,25 @@
+package horizon
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// FeeStatsResponse contains fee stats of recent ledgers returned by Horizon. Fees are in stroops.
+type FeeStatsResponse struct {
+	LastLedgerBaseFee string `json:"last_ledger_base_fee"`
+	// FeeCharged contains distribution of fees charged by transactions: `min`, `mode`, `max`
+	// and percentiles (`p10`, `p20`, ..., `p90`, `p95`, `p99`)
+	FeeCharged map[string]string `json:"fee_charged"`
+}
+
+// FeeChargedPercentile returns percentile p of fees charged by transactions
+func (r FeeStatsResponse) FeeChargedPercentile(p int) (uint32, error) {
+	value, ok := r.FeeCharged[fmt.Sprintf("p%d", p)]
+	if !ok {
+		return 0, fmt.Errorf("p%d fee not found in fee stats", p)
+	}
+
+	fee, err := strconv.ParseUint(value, 10, 32)
+	return uint32(fee), err
+}
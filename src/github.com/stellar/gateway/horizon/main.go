@@ -2,8 +2,10 @@ package horizon
 
 import (
 	"bufio"
+	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/Sirupsen/logrus"
 	"io"
@@ -13,27 +15,64 @@ import (
 	"strings"
 	"time"
 
+	"github.com/stellar/gateway/metrics"
 	"github.com/stellar/go-stellar-base/xdr"
 )
 
 // PaymentHandler is a function that is called when a new payment is received
 type PaymentHandler func(PaymentResponse) error
 
-// HorizonInterface allows mocking Horizon struct object
+// ErrStopStreaming can be returned by a PaymentHandler or EffectHandler to close the stream. Other handler
+// errors are retried. Stream methods return it when the stream is closed this way.
+var ErrStopStreaming = errors.New("streaming stopped by handler")
+
+// EffectHandler is a function that is called when a new effect is received
+type EffectHandler func(EffectResponse) error
+
+// HorizonInterface allows mocking Horizon struct object. Requests are cancelled when ctx is done.
 type HorizonInterface interface {
-	LoadAccount(accountID string) (response AccountResponse, err error)
-	LoadMemo(p *PaymentResponse) (err error)
-	StreamPayments(accountID string, cursor *string, onPaymentHandler PaymentHandler) (err error)
-	SubmitTransaction(txeBase64 string) (response SubmitTransactionResponse, err error)
+	LoadAccount(ctx context.Context, accountID string) (response AccountResponse, err error)
+	LoadMemo(ctx context.Context, p *PaymentResponse) (err error)
+	LoadOperation(ctx context.Context, operationID string) (payment PaymentResponse, err error)
+	LoadTransaction(ctx context.Context, hash string) (transaction TransactionResponse, err error)
+	LoadClaimableBalanceID(ctx context.Context, p *PaymentResponse) (err error)
+	LoadFeeStats(ctx context.Context) (response FeeStatsResponse, err error)
+	LoadAsset(ctx context.Context, assetCode, assetIssuer string) (response AssetResponse, err error)
+	FindPaths(ctx context.Context, sourceAsset, destinationAsset, destinationAmount string) (paths []PathResponse, err error)
+	LoadOrderBook(ctx context.Context, sellingAsset, buyingAsset string) (response OrderBookResponse, err error)
+	LoadRoot(ctx context.Context) (response RootResponse, err error)
+	StreamPayments(ctx context.Context, accountID string, cursor *string, onPaymentHandler PaymentHandler) (err error)
+	StreamOperations(ctx context.Context, accountID string, cursor *string, onPaymentHandler PaymentHandler) (err error)
+	StreamEffects(ctx context.Context, cursor *string, onEffectHandler EffectHandler) (err error)
+	SubmitTransaction(ctx context.Context, txeBase64 string) (response SubmitTransactionResponse, err error)
 }
 
-// Horizon implements methods to get (or submit) data from Horizon server
+// Horizon implements methods to get (or submit) data from Horizon server.
+// It talks to Horizon directly instead of wrapping github.com/stellar/go/clients/horizonclient
+// because the vendored stellar/go predates that package (clients/horizon lacks context,
+// claimable balance and asset support). Once stellar/go is upgraded it can be swapped in
+// behind HorizonInterface without touching the mocks.
 type Horizon struct {
 	ServerURL string
-	log       *logrus.Entry
+	// Timeout limits requests to Horizon (except streams), DefaultTimeout is used when 0
+	Timeout time.Duration
+	log     *logrus.Entry
+}
+
+// DefaultTimeout is a timeout of requests to Horizon used when Horizon.Timeout is not set
+const DefaultTimeout = 30 * time.Second
+
+const rootTimeout = 10 * time.Second
+
+// StatusError is returned when Horizon responds with an error status code
+type StatusError struct {
+	StatusCode int
+	Body       []byte
 }
 
-const submitTimeout = 30 * time.Second
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("StatusCode indicates error: %s", e.Body)
+}
 
 // New creates a new Horizon instance
 func New(serverURL string) (horizon Horizon) {
@@ -44,12 +83,32 @@ func New(serverURL string) (horizon Horizon) {
 	return
 }
 
+// client returns a client of requests to Horizon limited by Timeout
+func (h *Horizon) client() *http.Client {
+	timeout := h.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	return &http.Client{Timeout: timeout}
+}
+
+// get sends a GET request to url limited by Timeout. It's cancelled when ctx is done.
+func (h *Horizon) get(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return h.client().Do(req)
+}
+
 // LoadAccount loads a single account from Horizon server
-func (h *Horizon) LoadAccount(accountID string) (response AccountResponse, err error) {
+func (h *Horizon) LoadAccount(ctx context.Context, accountID string) (response AccountResponse, err error) {
+	defer observeRequest("load_account", time.Now())
+
 	h.log.WithFields(logrus.Fields{
 		"accountID": accountID,
 	}).Info("Loading account")
-	resp, err := http.Get(h.ServerURL + "/accounts/" + accountID)
+	resp, err := h.get(ctx, h.ServerURL+"/accounts/"+accountID)
 	if err != nil {
 		return
 	}
@@ -64,7 +123,7 @@ func (h *Horizon) LoadAccount(accountID string) (response AccountResponse, err e
 		h.log.WithFields(logrus.Fields{
 			"accountID": accountID,
 		}).Error("Account does not exist")
-		err = fmt.Errorf("StatusCode indicates error: %s", body)
+		err = &StatusError{StatusCode: resp.StatusCode, Body: body}
 		return
 	}
 
@@ -79,24 +138,355 @@ func (h *Horizon) LoadAccount(accountID string) (response AccountResponse, err e
 	return
 }
 
-// LoadMemo loads memo for a transaction in PaymentResponse
-func (h *Horizon) LoadMemo(p *PaymentResponse) (err error) {
-	res, err := http.Get(p.Links.Transaction.Href)
+// LoadMemo loads memo and details of a transaction in PaymentResponse
+func (h *Horizon) LoadMemo(ctx context.Context, p *PaymentResponse) (err error) {
+	defer observeRequest("load_memo", time.Now())
+
+	res, err := h.get(ctx, p.Links.Transaction.Href)
 	if err != nil {
 		return err
 	}
 	defer res.Body.Close()
-	return json.NewDecoder(res.Body).Decode(&p.Memo)
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(body, &p.Memo)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, &p.Transaction)
+}
+
+// LoadOperation loads a single operation. Only payment operations can be loaded.
+func (h *Horizon) LoadOperation(ctx context.Context, operationID string) (payment PaymentResponse, err error) {
+	defer observeRequest("load_operation", time.Now())
+
+	resp, err := h.get(ctx, h.ServerURL+"/operations/"+operationID)
+	if err != nil {
+		return
+	}
+
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return
+	}
+
+	if resp.StatusCode != 200 {
+		err = &StatusError{StatusCode: resp.StatusCode, Body: body}
+		return
+	}
+
+	err = json.Unmarshal(body, &payment)
+	return
+}
+
+// LoadTransaction loads a single transaction. StatusError with 404 status code is returned
+// when the transaction is not in the ledger.
+func (h *Horizon) LoadTransaction(ctx context.Context, hash string) (transaction TransactionResponse, err error) {
+	defer observeRequest("load_transaction", time.Now())
+
+	resp, err := h.get(ctx, h.ServerURL+"/transactions/"+hash)
+	if err != nil {
+		return
+	}
+
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return
+	}
+
+	if resp.StatusCode != 200 {
+		err = &StatusError{StatusCode: resp.StatusCode, Body: body}
+		return
+	}
+
+	err = json.Unmarshal(body, &transaction)
+	return
+}
+
+// LoadClaimableBalanceID loads ID of a balance created in create_claimable_balance
+// operation from operation effects
+func (h *Horizon) LoadClaimableBalanceID(ctx context.Context, p *PaymentResponse) (err error) {
+	defer observeRequest("load_claimable_balance_id", time.Now())
+
+	resp, err := h.get(ctx, h.ServerURL+"/operations/"+p.ID+"/effects")
+	if err != nil {
+		return
+	}
+
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return
+	}
+
+	if resp.StatusCode != 200 {
+		err = &StatusError{StatusCode: resp.StatusCode, Body: body}
+		return
+	}
+
+	var effects struct {
+		Embedded struct {
+			Records []struct {
+				Type      string `json:"type"`
+				BalanceID string `json:"balance_id"`
+			} `json:"records"`
+		} `json:"_embedded"`
+	}
+	err = json.Unmarshal(body, &effects)
+	if err != nil {
+		return
+	}
+
+	for _, effect := range effects.Embedded.Records {
+		if effect.Type == "claimable_balance_created" {
+			p.BalanceID = effect.BalanceID
+			return nil
+		}
+	}
+
+	return fmt.Errorf("claimable_balance_created effect not found in operation %s", p.ID)
+}
+
+// LoadFeeStats loads fee stats of recent ledgers
+func (h *Horizon) LoadFeeStats(ctx context.Context) (response FeeStatsResponse, err error) {
+	defer observeRequest("load_fee_stats", time.Now())
+
+	resp, err := h.get(ctx, h.ServerURL+"/fee_stats")
+	if err != nil {
+		return
+	}
+
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return
+	}
+
+	if resp.StatusCode != 200 {
+		err = &StatusError{StatusCode: resp.StatusCode, Body: body}
+		return
+	}
+
+	err = json.Unmarshal(body, &response)
+	return
+}
+
+// FindPaths finds payment paths converting sourceAsset to destinationAmount of destinationAsset
+// (strict receive). Assets are `native` or `CODE:ISSUER`. Paths are sorted by source amount.
+func (h *Horizon) FindPaths(ctx context.Context, sourceAsset, destinationAsset, destinationAmount string) (paths []PathResponse, err error) {
+	defer observeRequest("find_paths", time.Now())
+
+	params := url.Values{}
+	params.Set("source_assets", sourceAsset)
+	assetParams(params, "destination", destinationAsset)
+	params.Set("destination_amount", destinationAmount)
+
+	resp, err := h.get(ctx, h.ServerURL+"/paths/strict-receive?"+params.Encode())
+	if err != nil {
+		return
+	}
+
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return
+	}
+
+	if resp.StatusCode != 200 {
+		err = &StatusError{StatusCode: resp.StatusCode, Body: body}
+		return
+	}
+
+	var response struct {
+		Embedded struct {
+			Records []PathResponse `json:"records"`
+		} `json:"_embedded"`
+	}
+	err = json.Unmarshal(body, &response)
+	paths = response.Embedded.Records
+	return
+}
+
+// LoadAsset loads stats and flags of assetCode issued by assetIssuer. StatusError with 404
+// status code is returned when Horizon knows no such asset.
+func (h *Horizon) LoadAsset(ctx context.Context, assetCode, assetIssuer string) (response AssetResponse, err error) {
+	defer observeRequest("load_asset", time.Now())
+
+	params := url.Values{}
+	params.Set("asset_code", assetCode)
+	params.Set("asset_issuer", assetIssuer)
+
+	resp, err := h.get(ctx, h.ServerURL+"/assets?"+params.Encode())
+	if err != nil {
+		return
+	}
+
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return
+	}
+
+	if resp.StatusCode != 200 {
+		err = &StatusError{StatusCode: resp.StatusCode, Body: body}
+		return
+	}
+
+	var assets struct {
+		Embedded struct {
+			Records []AssetResponse `json:"records"`
+		} `json:"_embedded"`
+	}
+	err = json.Unmarshal(body, &assets)
+	if err != nil {
+		return
+	}
+	if len(assets.Embedded.Records) == 0 {
+		err = &StatusError{StatusCode: http.StatusNotFound, Body: body}
+		return
+	}
+
+	response = assets.Embedded.Records[0]
+	return
+}
+
+// LoadOrderBook loads the best offers of sellingAsset/buyingAsset order book. Assets are
+// `native` or `CODE:ISSUER`.
+func (h *Horizon) LoadOrderBook(ctx context.Context, sellingAsset, buyingAsset string) (response OrderBookResponse, err error) {
+	defer observeRequest("load_order_book", time.Now())
+
+	params := url.Values{}
+	assetParams(params, "selling", sellingAsset)
+	assetParams(params, "buying", buyingAsset)
+	params.Set("limit", "1")
+
+	resp, err := h.get(ctx, h.ServerURL+"/order_book?"+params.Encode())
+	if err != nil {
+		return
+	}
+
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return
+	}
+
+	if resp.StatusCode != 200 {
+		err = &StatusError{StatusCode: resp.StatusCode, Body: body}
+		return
+	}
+
+	err = json.Unmarshal(body, &response)
+	return
+}
+
+// LoadRoot loads Horizon root endpoint containing versions and network passphrase of the server
+func (h *Horizon) LoadRoot(ctx context.Context) (response RootResponse, err error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", h.ServerURL+"/", nil)
+	if err != nil {
+		return
+	}
+
+	client := http.Client{
+		Timeout: rootTimeout,
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return
+	}
+
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return
+	}
+
+	if resp.StatusCode != 200 {
+		err = &StatusError{StatusCode: resp.StatusCode, Body: body}
+		return
+	}
+
+	err = json.Unmarshal(body, &response)
+	return
 }
 
 // StreamPayments streams incoming payments
-func (h *Horizon) StreamPayments(accountID string, cursor *string, onPaymentHandler PaymentHandler) (err error) {
-	url := h.ServerURL + "/accounts/" + accountID + "/payments"
+func (h *Horizon) StreamPayments(ctx context.Context, accountID string, cursor *string, onPaymentHandler PaymentHandler) (err error) {
+	return h.streamOperations(ctx, h.ServerURL+"/accounts/"+accountID+"/payments", cursor, onPaymentHandler)
+}
+
+// StreamOperations streams all operations of the account. Unlike StreamPayments it also
+// streams operations creating claimable balances the account can claim.
+func (h *Horizon) StreamOperations(ctx context.Context, accountID string, cursor *string, onPaymentHandler PaymentHandler) (err error) {
+	return h.streamOperations(ctx, h.ServerURL+"/accounts/"+accountID+"/operations", cursor, onPaymentHandler)
+}
+
+func (h *Horizon) streamOperations(ctx context.Context, url string, cursor *string, onPaymentHandler PaymentHandler) (err error) {
 	if cursor != nil {
 		url += "?cursor=" + *cursor
 	}
 
-	req, _ := http.NewRequest("GET", url, nil)
+	return h.stream(ctx, url, func(data []byte) error {
+		var payment PaymentResponse
+		err := json.Unmarshal(data, &payment)
+		if err != nil {
+			return err
+		}
+
+		return h.retry(ctx, func() error { return onPaymentHandler(payment) })
+	})
+}
+
+// StreamEffects streams effects of all accounts
+func (h *Horizon) StreamEffects(ctx context.Context, cursor *string, onEffectHandler EffectHandler) (err error) {
+	url := h.ServerURL + "/effects"
+	if cursor != nil {
+		url += "?cursor=" + *cursor
+	}
+
+	return h.stream(ctx, url, func(data []byte) error {
+		var effect EffectResponse
+		err := json.Unmarshal(data, &effect)
+		if err != nil {
+			return err
+		}
+
+		return h.retry(ctx, func() error { return onEffectHandler(effect) })
+	})
+}
+
+// observeRequest records duration of a Horizon request started at start
+func observeRequest(request string, start time.Time) {
+	metrics.ObserveDuration("horizon_request_duration_seconds", time.Since(start), metrics.Tags{"request": request})
+}
+
+// retry calls handler until it returns no error or ctx is done
+func (h *Horizon) retry(ctx context.Context, handler func() error) error {
+	for {
+		err := handler()
+		if err == nil || err == ErrStopStreaming {
+			return err
+		}
+
+		h.log.Error("Error from handler: ", err)
+		h.log.Info("Sleeping...")
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(10 * time.Second):
+		}
+	}
+}
+
+// stream opens SSE connection to url and calls onMessage with data of every message. The
+// connection is closed when ctx is done.
+func (h *Horizon) stream(ctx context.Context, url string, onMessage func(data []byte) error) (err error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return
 	}
@@ -108,6 +498,12 @@ func (h *Horizon) StreamPayments(accountID string, cursor *string, onPaymentHand
 		return err
 	}
 	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("stream response status code indicates error (%d): %s", resp.StatusCode, body)
+	}
+
 	scanner := bufio.NewScanner(resp.Body)
 	scanner.Split(splitSSE)
 
@@ -125,23 +521,10 @@ func (h *Horizon) StreamPayments(accountID string, cursor *string, onPaymentHand
 			continue
 		}
 
-		var payment PaymentResponse
-		data := ev.Data.(string)
-		err = json.Unmarshal([]byte(data), &payment)
+		err = onMessage([]byte(ev.Data.(string)))
 		if err != nil {
 			return err
 		}
-
-		for {
-			err = onPaymentHandler(payment)
-			if err != nil {
-				h.log.Error("Error from onPaymentHandler: ", err)
-				h.log.Info("Sleeping...")
-				time.Sleep(10 * time.Second)
-			} else {
-				break
-			}
-		}
 	}
 
 	err = scanner.Err()
@@ -157,14 +540,19 @@ func (h *Horizon) StreamPayments(accountID string, cursor *string, onPaymentHand
 }
 
 // SubmitTransaction submits a transaction to Stellar network via Horizon server
-func (h *Horizon) SubmitTransaction(txeBase64 string) (response SubmitTransactionResponse, err error) {
+func (h *Horizon) SubmitTransaction(ctx context.Context, txeBase64 string) (response SubmitTransactionResponse, err error) {
+	defer observeRequest("submit_transaction", time.Now())
+
 	v := url.Values{}
 	v.Set("tx", txeBase64)
 
-	client := http.Client{
-		Timeout: submitTimeout,
+	req, err := http.NewRequestWithContext(ctx, "POST", h.ServerURL+"/transactions", strings.NewReader(v.Encode()))
+	if err != nil {
+		return
 	}
-	resp, err := client.PostForm(h.ServerURL+"/transactions", v)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := h.client().Do(req)
 	if err != nil {
 		return
 	}
@@ -0,0 +1,15 @@
+package horizon
+
+// OrderBookResponse contains offers of an order book returned by Horizon. Bids are offers
+// buying the selling asset of the order book, asks are offers selling it. Prices are in
+// units of the buying asset for one unit of the selling asset.
+type OrderBookResponse struct {
+	Bids []OrderBookEntry `json:"bids"`
+	Asks []OrderBookEntry `json:"asks"`
+}
+
+// OrderBookEntry is a price level of an order book
+type OrderBookEntry struct {
+	Price  string `json:"price"`
+	Amount string `json:"amount"`
+}
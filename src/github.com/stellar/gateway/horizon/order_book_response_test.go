@@ -0,0 +1,33 @@
+package horizon
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadOrderBook(t *testing.T) {
+	var query url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/order_book", r.URL.Path)
+		query = r.URL.Query()
+		w.Write([]byte(`{"bids": [{"price": "1.1000000", "amount": "100.0000000"}], "asks": []}`))
+	}))
+	defer srv.Close()
+
+	h := New(srv.URL)
+	orderBook, err := h.LoadOrderBook(context.Background(), "EUR:GDSIKW43UA6JTOA47WVEBCZ4MYC74M3GNKNXTVDXFHXYYTNO5GGVN632", "native")
+	require.NoError(t, err)
+	assert.Equal(t, []OrderBookEntry{{Price: "1.1000000", Amount: "100.0000000"}}, orderBook.Bids)
+	assert.Empty(t, orderBook.Asks)
+
+	assert.Equal(t, "credit_alphanum4", query.Get("selling_asset_type"))
+	assert.Equal(t, "EUR", query.Get("selling_asset_code"))
+	assert.Equal(t, "native", query.Get("buying_asset_type"))
+	assert.Equal(t, "1", query.Get("limit"))
+}
@@ -0,0 +1,46 @@
+package horizon
+
+import (
+	"net/url"
+	"strings"
+)
+
+// PathResponse contains a single payment path returned by Horizon. Assets are `native` or
+// `CODE:ISSUER`.
+type PathResponse struct {
+	SourceAssetType        string      `json:"source_asset_type"`
+	SourceAssetCode        string      `json:"source_asset_code"`
+	SourceAssetIssuer      string      `json:"source_asset_issuer"`
+	SourceAmount           string      `json:"source_amount"`
+	DestinationAssetType   string      `json:"destination_asset_type"`
+	DestinationAssetCode   string      `json:"destination_asset_code"`
+	DestinationAssetIssuer string      `json:"destination_asset_issuer"`
+	DestinationAmount      string      `json:"destination_amount"`
+	Path                   []PathAsset `json:"path"`
+}
+
+// PathAsset is an intermediate asset of a payment path
+type PathAsset struct {
+	AssetType   string `json:"asset_type"`
+	AssetCode   string `json:"asset_code"`
+	AssetIssuer string `json:"asset_issuer"`
+}
+
+// assetParams adds params of asset (`native` or `CODE:ISSUER`) prefixed with prefix to params
+func assetParams(params url.Values, prefix, asset string) {
+	if asset == "native" {
+		params.Set(prefix+"_asset_type", "native")
+		return
+	}
+
+	tokens := strings.SplitN(asset, ":", 2)
+	assetType := "credit_alphanum4"
+	if len(tokens[0]) > 4 {
+		assetType = "credit_alphanum12"
+	}
+	params.Set(prefix+"_asset_type", assetType)
+	params.Set(prefix+"_asset_code", tokens[0])
+	if len(tokens) == 2 {
+		params.Set(prefix+"_asset_issuer", tokens[1])
+	}
+}
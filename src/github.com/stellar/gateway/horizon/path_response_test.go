@@ -0,0 +1,58 @@
+package horizon
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindPaths(t *testing.T) {
+	var query url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/paths/strict-receive", r.URL.Path)
+		query = r.URL.Query()
+		w.Write([]byte(`{"_embedded": {"records": [{
+			"source_asset_type": "credit_alphanum4",
+			"source_asset_code": "EUR",
+			"source_asset_issuer": "GDSIKW43UA6JTOA47WVEBCZ4MYC74M3GNKNXTVDXFHXYYTNO5GGVN632",
+			"source_amount": "9.1000000",
+			"destination_asset_type": "credit_alphanum4",
+			"destination_asset_code": "USD",
+			"destination_asset_issuer": "GDSIKW43UA6JTOA47WVEBCZ4MYC74M3GNKNXTVDXFHXYYTNO5GGVN632",
+			"destination_amount": "10.0000000",
+			"path": [{"asset_type": "native"}]
+		}]}}`))
+	}))
+	defer srv.Close()
+
+	h := New(srv.URL)
+	paths, err := h.FindPaths(context.Background(),
+		"EUR:GDSIKW43UA6JTOA47WVEBCZ4MYC74M3GNKNXTVDXFHXYYTNO5GGVN632",
+		"USD:GDSIKW43UA6JTOA47WVEBCZ4MYC74M3GNKNXTVDXFHXYYTNO5GGVN632",
+		"10",
+	)
+	require.NoError(t, err)
+	require.Len(t, paths, 1)
+	assert.Equal(t, "9.1000000", paths[0].SourceAmount)
+	assert.Equal(t, []PathAsset{{AssetType: "native"}}, paths[0].Path)
+
+	assert.Equal(t, "EUR:GDSIKW43UA6JTOA47WVEBCZ4MYC74M3GNKNXTVDXFHXYYTNO5GGVN632", query.Get("source_assets"))
+	assert.Equal(t, "credit_alphanum4", query.Get("destination_asset_type"))
+	assert.Equal(t, "USD", query.Get("destination_asset_code"))
+	assert.Equal(t, "GDSIKW43UA6JTOA47WVEBCZ4MYC74M3GNKNXTVDXFHXYYTNO5GGVN632", query.Get("destination_asset_issuer"))
+	assert.Equal(t, "10", query.Get("destination_amount"))
+
+	_, err = h.FindPaths(context.Background(), "native", "LONGASSET:GDSIKW43UA6JTOA47WVEBCZ4MYC74M3GNKNXTVDXFHXYYTNO5GGVN632", "10")
+	require.NoError(t, err)
+	assert.Equal(t, "credit_alphanum12", query.Get("destination_asset_type"))
+
+	_, err = h.FindPaths(context.Background(), "EUR:GDSIKW43UA6JTOA47WVEBCZ4MYC74M3GNKNXTVDXFHXYYTNO5GGVN632", "native", "10")
+	require.NoError(t, err)
+	assert.Equal(t, "native", query.Get("destination_asset_type"))
+	assert.Equal(t, "", query.Get("destination_asset_code"))
+}
@@ -1,10 +1,26 @@
 package horizon
 
-// PaymentResponse contains a single payment data returned by Horizon
+import (
+	"encoding/json"
+)
+
+// PaymentResponse contains a single payment data returned by Horizon. Fields unknown to the
+// bridge server are ignored and fields added in newer Horizon versions are empty when sent by
+// older ones.
 type PaymentResponse struct {
 	ID          string `json:"id"`
 	Type        string `json:"type"`
 	PagingToken string `json:"paging_token"`
+	// TransactionHash and CreatedAt are sent in version 2 receive callbacks only
+	TransactionHash string `json:"transaction_hash"`
+	CreatedAt       string `json:"created_at"`
+	// TransactionSuccessful is false for operations of failed transactions, nil when Horizon
+	// does not send it
+	TransactionSuccessful *bool `json:"transaction_successful"`
+	// SourceAccountMuxed and SourceAccountMuxedID are set when source of the operation is
+	// a muxed account. They're sent to callbacks as from_muxed of claimable balances.
+	SourceAccountMuxed   string `json:"source_account_muxed"`
+	SourceAccountMuxedID string `json:"source_account_muxed_id"`
 
 	Links struct {
 		Transaction struct {
@@ -14,15 +30,59 @@ type PaymentResponse struct {
 
 	// payment/path_payment fields
 	From        string `json:"from"`
+	FromMuxed   string `json:"from_muxed"`
+	FromMuxedID string `json:"from_muxed_id"`
 	To          string `json:"to"`
+	ToMuxed     string `json:"to_muxed"`
+	ToMuxedID   string `json:"to_muxed_id"`
 	AssetType   string `json:"asset_type"`
 	AssetCode   string `json:"asset_code"`
 	AssetIssuer string `json:"asset_issuer"`
 	Amount      string `json:"amount"`
 
+	// create_claimable_balance/claim_claimable_balance fields
+	SourceAccount string     `json:"source_account"`
+	Asset         string     `json:"asset"`
+	Claimants     []Claimant `json:"claimants"`
+	Claimant      string     `json:"claimant"`
+	// BalanceID is sent by Horizon for claim_claimable_balance operations only. For
+	// create_claimable_balance operations it's loaded using LoadClaimableBalanceID.
+	BalanceID string `json:"balance_id"`
+
 	// transaction fields
 	Memo struct {
 		Type  string `json:"memo_type"`
 		Value string `json:"memo"`
 	}
+	// Transaction is loaded by LoadMemo
+	Transaction PaymentTransaction `json:"-"`
+}
+
+// PaymentTransaction contains details of the transaction a payment was sent in
+type PaymentTransaction struct {
+	Hash   string `json:"hash"`
+	Ledger uint64 `json:"ledger"`
+	// CreatedAt is the close time of the ledger
+	CreatedAt string `json:"created_at"`
+	// FeeCharged is a fee in stroops. Newer Horizon versions send it as a string.
+	FeeCharged    json.Number `json:"fee_charged"`
+	SourceAccount string      `json:"source_account"`
+	// FeeAccount paid the fee. It differs from SourceAccount for fee bump transactions and
+	// is empty when sent by older Horizon versions.
+	FeeAccount string `json:"fee_account"`
+}
+
+// Claimant is a single claimant of a claimable balance. Predicate is not used by the bridge server.
+type Claimant struct {
+	Destination string `json:"destination"`
+}
+
+// IsClaimant returns true when accountID can claim a balance created in a create_claimable_balance operation
+func (p PaymentResponse) IsClaimant(accountID string) bool {
+	for _, claimant := range p.Claimants {
+		if claimant.Destination == accountID {
+			return true
+		}
+	}
+	return false
 }
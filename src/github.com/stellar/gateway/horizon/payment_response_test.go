@@ -0,0 +1,111 @@
+package horizon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Protocol 19 payment and fee bump transaction returned by Horizon 2.x, including fields
+// unknown to the bridge server
+const (
+	protocol19Payment = `{
+		"_links": {"transaction": {"href": "%s"}},
+		"id": "12884905985",
+		"paging_token": "12884905985",
+		"transaction_successful": true,
+		"source_account": "GBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFZYYQ",
+		"source_account_muxed": "MBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFYAAAAAAAAAAAAGZOKQ",
+		"source_account_muxed_id": "13",
+		"type": "payment",
+		"type_i": 1,
+		"created_at": "2022-05-05T10:00:00Z",
+		"transaction_hash": "3389e9f0f1a65f19736cacf544c2e825313e8447f569233bb8db39aa607c8889",
+		"asset_type": "native",
+		"from": "GBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFZYYQ",
+		"from_muxed": "MBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFYAAAAAAAAAAAAGZOKQ",
+		"from_muxed_id": "13",
+		"to": "GATKP6ZQM5CSLECPMTAC5226PE367QALCPM6AFHTSULPPZMT62OOPMQB",
+		"amount": "10.0000000"
+	}`
+	protocol19Transaction = `{
+		"memo_type": "text",
+		"memo": "order-1",
+		"memo_bytes": "b3JkZXItMQ==",
+		"id": "3389e9f0f1a65f19736cacf544c2e825313e8447f569233bb8db39aa607c8889",
+		"hash": "3389e9f0f1a65f19736cacf544c2e825313e8447f569233bb8db39aa607c8889",
+		"ledger": 3,
+		"created_at": "2022-05-05T10:00:00Z",
+		"successful": true,
+		"source_account": "GBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFZYYQ",
+		"source_account_sequence": "12884901889",
+		"fee_account": "GATKP6ZQM5CSLECPMTAC5226PE367QALCPM6AFHTSULPPZMT62OOPMQB",
+		"fee_charged": "200",
+		"max_fee": "1000",
+		"operation_count": 1,
+		"signatures": ["signature"],
+		"preconditions": {
+			"timebounds": {"min_time": "0", "max_time": "1651748400"},
+			"ledgerbounds": {"min_ledger": 2},
+			"min_account_sequence_age": "60",
+			"extra_signers": ["GBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFZYYQ"]
+		},
+		"fee_bump_transaction": {"hash": "3389e9f0f1a65f19736cacf544c2e825313e8447f569233bb8db39aa607c8889", "signatures": []},
+		"inner_transaction": {"hash": "e98869bba8bce08c10b78406202127f3888c25454cd37b02600862452751f526", "max_fee": "100"}
+	}`
+)
+
+func TestLoadMemoProtocol19(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(protocol19Transaction))
+	}))
+	defer srv.Close()
+
+	var payment PaymentResponse
+	require.NoError(t, json.Unmarshal([]byte(fmt.Sprintf(protocol19Payment, srv.URL)), &payment))
+	require.NotNil(t, payment.TransactionSuccessful)
+	assert.True(t, *payment.TransactionSuccessful)
+	assert.Equal(t, "13", payment.SourceAccountMuxedID)
+	assert.Equal(t, "MBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFYAAAAAAAAAAAAGZOKQ", payment.FromMuxed)
+	assert.Equal(t, "13", payment.FromMuxedID)
+	assert.Equal(t, "", payment.ToMuxed)
+
+	h := New("")
+	require.NoError(t, h.LoadMemo(context.Background(), &payment))
+	assert.Equal(t, "text", payment.Memo.Type)
+	assert.Equal(t, "order-1", payment.Memo.Value)
+
+	transaction := payment.Transaction
+	assert.Equal(t, uint64(3), transaction.Ledger)
+	assert.Equal(t, "200", transaction.FeeCharged.String())
+	assert.Equal(t, "GATKP6ZQM5CSLECPMTAC5226PE367QALCPM6AFHTSULPPZMT62OOPMQB", transaction.FeeAccount)
+	assert.Equal(t, "GBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFZYYQ", transaction.SourceAccount)
+}
+
+func TestLoadTransactionProtocol19(t *testing.T) {
+	response := protocol19Transaction
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/transactions/3389e9f0f1a65f19736cacf544c2e825313e8447f569233bb8db39aa607c8889", r.URL.Path)
+		w.Write([]byte(response))
+	}))
+	defer srv.Close()
+
+	h := New(srv.URL)
+	transaction, err := h.LoadTransaction(context.Background(), "3389e9f0f1a65f19736cacf544c2e825313e8447f569233bb8db39aa607c8889")
+	require.NoError(t, err)
+	assert.True(t, *transaction.Successful)
+	assert.Equal(t, uint64(3), transaction.Ledger)
+
+	// Older Horizon versions send fees as numbers
+	response = `{"hash": "3389e9f0f1a65f19736cacf544c2e825313e8447f569233bb8db39aa607c8889", "ledger": 3, "fee_paid": 100, "fee_charged": 100, "max_fee": 100, "valid_after": "1970-01-01T00:00:00Z"}`
+	transaction, err = h.LoadTransaction(context.Background(), "3389e9f0f1a65f19736cacf544c2e825313e8447f569233bb8db39aa607c8889")
+	require.NoError(t, err)
+	assert.Nil(t, transaction.Successful)
+	assert.Equal(t, uint64(3), transaction.Ledger)
+}
@@ -0,0 +1,10 @@
+package horizon
+
+// RootResponse contains a part of Horizon root endpoint response
+type RootResponse struct {
+	HorizonVersion    string `json:"horizon_version"`
+	CoreVersion       string `json:"core_version"`
+	NetworkPassphrase string `json:"network_passphrase"`
+	// HistoryLatestLedger is the latest ledger ingested by Horizon
+	HistoryLatestLedger int32 `json:"history_latest_ledger"`
+}
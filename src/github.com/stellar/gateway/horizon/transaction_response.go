@@ -0,0 +1,11 @@
+package horizon
+
+// TransactionResponse contains a transaction loaded from Horizon
+type TransactionResponse struct {
+	Hash   string `json:"hash"`
+	Ledger uint64 `json:"ledger"`
+	// Successful is false when the transaction failed. Older Horizon versions return
+	// successful transactions only and do not send it.
+	Successful *bool  `json:"successful"`
+	ResultXdr  string `json:"result_xdr"`
+}
@@ -0,0 +1,214 @@
+// Package limits enforces `limits` config entries on payments sent by the bridge server.
+package limits
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/db"
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/protocols"
+	"github.com/stellar/gateway/protocols/amount"
+	"github.com/stellar/go/support/errors"
+)
+
+// Names of limits reported in ExceededError
+const (
+	LimitMaxAmount        = "max_amount"
+	LimitDestinationDaily = "destination_daily"
+	LimitHourly           = "hourly"
+)
+
+// ExceededError is returned by Reserve when a payment would exceed one of limits
+type ExceededError struct {
+	// Limit is one of Limit* constants
+	Limit string
+	Max   amount.Amount
+	// Remaining is an amount that can still be sent in the current window
+	Remaining amount.Amount
+	// ResetsAt is an end of the current window. It's zero for LimitMaxAmount.
+	ResetsAt time.Time
+}
+
+func (e *ExceededError) Error() string {
+	return fmt.Sprintf("%s limit exceeded (max: %s, remaining: %s)", e.Limit, e.Max, e.Remaining)
+}
+
+// Limiter checks payments against limits of a single tenant. Amounts sent during every
+// window are counted in LimitCounter entities so limits survive restarts. Counters are
+// cached and updated under a mutex so a single bridge server instance must send payments
+// of a tenant.
+type Limiter struct {
+	config        *config.Config
+	entityManager db.EntityManagerInterface
+	repository    db.RepositoryInterface
+	now           func() time.Time
+	log           *logrus.Entry
+
+	mutex    sync.Mutex
+	counters map[counterID]*entities.LimitCounter
+}
+
+type counterID struct {
+	key         string
+	windowStart time.Time
+}
+
+type window struct {
+	limit    string
+	max      amount.Amount
+	key      string
+	start    time.Time
+	duration time.Duration
+}
+
+// NewLimiter creates a new Limiter enforcing `limits` of config c
+func NewLimiter(c *config.Config, entityManager db.EntityManagerInterface, repository db.RepositoryInterface, now func() time.Time) *Limiter {
+	return &Limiter{
+		config:        c,
+		entityManager: entityManager,
+		repository:    repository,
+		now:           now,
+		log:           logrus.WithFields(logrus.Fields{"service": "Limiter", "tenant": c.Tenant}),
+		counters:      make(map[counterID]*entities.LimitCounter),
+	}
+}
+
+// Reserve checks if a payment of value of asset (code and issuer are empty for native asset)
+// to destination is within limits and adds it to counters. *ExceededError is returned when
+// a limit would be exceeded. The returned release function removes the payment from counters
+// and should be called when the payment has not been sent.
+func (l *Limiter) Reserve(code, issuer, destination, value string) (release func(), err error) {
+	release = func() {}
+
+	limit := l.config.LimitFor(code, issuer)
+	if limit == nil {
+		return
+	}
+
+	paymentAmount, err := amount.Parse(value)
+	if err != nil {
+		return release, errors.Wrap(err, "cannot parse amount")
+	}
+
+	if limit.MaxAmount != "" {
+		max, _ := amount.Parse(limit.MaxAmount)
+		if paymentAmount > max {
+			return release, &ExceededError{Limit: LimitMaxAmount, Max: max, Remaining: max}
+		}
+	}
+
+	now := l.now().UTC()
+	asset := protocols.Asset{Code: code, Issuer: issuer}.String()
+
+	var windows []window
+	if limit.DestinationDaily != "" {
+		max, _ := amount.Parse(limit.DestinationDaily)
+		windows = append(windows, window{
+			limit:    LimitDestinationDaily,
+			max:      max,
+			key:      "destination:" + destination + ":" + asset,
+			start:    now.Truncate(24 * time.Hour),
+			duration: 24 * time.Hour,
+		})
+	}
+	if limit.Hourly != "" {
+		max, _ := amount.Parse(limit.Hourly)
+		windows = append(windows, window{
+			limit:    LimitHourly,
+			max:      max,
+			key:      "asset:" + asset,
+			start:    now.Truncate(time.Hour),
+			duration: time.Hour,
+		})
+	}
+
+	if len(windows) == 0 {
+		return
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.removeExpired(now)
+
+	counters := make([]*entities.LimitCounter, len(windows))
+	for i, w := range windows {
+		counters[i], err = l.counter(w)
+		if err != nil {
+			return
+		}
+
+		used := amount.Amount(counters[i].Amount)
+		if used+paymentAmount > w.max {
+			remaining := w.max - used
+			if remaining < 0 {
+				remaining = 0
+			}
+			return release, &ExceededError{Limit: w.limit, Max: w.max, Remaining: remaining, ResetsAt: w.start.Add(w.duration)}
+		}
+	}
+
+	err = l.add(counters, int64(paymentAmount))
+	if err != nil {
+		return
+	}
+
+	release = func() {
+		l.mutex.Lock()
+		defer l.mutex.Unlock()
+		err := l.add(counters, -int64(paymentAmount))
+		if err != nil {
+			l.log.WithFields(logrus.Fields{"err": err}).Error("Error releasing payment limits")
+		}
+	}
+	return
+}
+
+// counter returns a cached counter of window w or loads it from the DB
+func (l *Limiter) counter(w window) (*entities.LimitCounter, error) {
+	id := counterID{w.key, w.start}
+	if counter, ok := l.counters[id]; ok {
+		return counter, nil
+	}
+
+	counter, err := l.repository.GetLimitCounter(w.key, w.start)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot load limit counter")
+	}
+
+	if counter == nil {
+		counter = &entities.LimitCounter{Key: w.key, WindowStart: w.start, Tenant: l.config.Tenant}
+	}
+
+	l.counters[id] = counter
+	return counter, nil
+}
+
+// add adds value to counters and persists them. Cached counters are restored when
+// any of them cannot be saved.
+func (l *Limiter) add(counters []*entities.LimitCounter, value int64) error {
+	for i, counter := range counters {
+		counter.Amount += value
+		err := l.entityManager.Persist(counter)
+		if err != nil {
+			for _, saved := range counters[:i+1] {
+				saved.Amount -= value
+			}
+			return errors.Wrap(err, "cannot save limit counter")
+		}
+	}
+	return nil
+}
+
+// removeExpired removes counters of windows that ended before now from the cache
+func (l *Limiter) removeExpired(now time.Time) {
+	for id := range l.counters {
+		if id.windowStart.Add(24 * time.Hour).Before(now) {
+			delete(l.counters, id)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package limits
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiterReserve(t *testing.T) {
+	usd := config.Asset{Code: "USD", Issuer: "GD4I7AFSLZGTDL34TQLWJOM2NHLIIOEKD5RHHZUW54HERBLSIRKUOXRR"}
+
+	c := &config.Config{
+		Limits: []config.Limit{
+			{Asset: usd, MaxAmount: "100", DestinationDaily: "150", Hourly: "1000"},
+		},
+	}
+
+	now := time.Date(2017, 6, 1, 10, 30, 0, 0, time.UTC)
+	day := time.Date(2017, 6, 1, 0, 0, 0, 0, time.UTC)
+	hour := time.Date(2017, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	mockEntityManager := new(mocks.MockEntityManager)
+	mockRepository := new(mocks.MockRepository)
+	limiter := NewLimiter(c, mockEntityManager, mockRepository, func() time.Time { return now })
+
+	destinationKey := "destination:GAMVF7G4GJC4A7JMFJWLUAEIBFQD5RT3DCB5DC5TJDEKQBBACQ4JJVEE:USD:GD4I7AFSLZGTDL34TQLWJOM2NHLIIOEKD5RHHZUW54HERBLSIRKUOXRR"
+	assetKey := "asset:USD:GD4I7AFSLZGTDL34TQLWJOM2NHLIIOEKD5RHHZUW54HERBLSIRKUOXRR"
+	mockRepository.On("GetLimitCounter", destinationKey, day).Return(
+		&entities.LimitCounter{Key: destinationKey, WindowStart: day, Amount: 200000000}, nil,
+	).Once()
+	mockRepository.On("GetLimitCounter", assetKey, hour).Return(nil, nil).Once()
+	mockEntityManager.On("Persist", mock.AnythingOfType("*entities.LimitCounter")).Return(nil)
+
+	// Other assets are not limited
+	release, err := limiter.Reserve("", "", "GAMVF7G4GJC4A7JMFJWLUAEIBFQD5RT3DCB5DC5TJDEKQBBACQ4JJVEE", "100000")
+	require.NoError(t, err)
+	release()
+
+	_, err = limiter.Reserve("USD", usd.Issuer, "GAMVF7G4GJC4A7JMFJWLUAEIBFQD5RT3DCB5DC5TJDEKQBBACQ4JJVEE", "100.0000001")
+	exceeded, ok := err.(*ExceededError)
+	require.True(t, ok)
+	assert.Equal(t, LimitMaxAmount, exceeded.Limit)
+	assert.True(t, exceeded.ResetsAt.IsZero())
+
+	// 20 USD have been sent to the destination today
+	release, err = limiter.Reserve("USD", usd.Issuer, "GAMVF7G4GJC4A7JMFJWLUAEIBFQD5RT3DCB5DC5TJDEKQBBACQ4JJVEE", "100")
+	require.NoError(t, err)
+
+	_, err = limiter.Reserve("USD", usd.Issuer, "GAMVF7G4GJC4A7JMFJWLUAEIBFQD5RT3DCB5DC5TJDEKQBBACQ4JJVEE", "40")
+	exceeded, ok = err.(*ExceededError)
+	require.True(t, ok)
+	assert.Equal(t, LimitDestinationDaily, exceeded.Limit)
+	assert.Equal(t, "150.0000000", exceeded.Max.String())
+	assert.Equal(t, "30.0000000", exceeded.Remaining.String())
+	assert.Equal(t, day.Add(24*time.Hour), exceeded.ResetsAt)
+
+	// Released payment is not counted
+	release()
+	_, err = limiter.Reserve("USD", usd.Issuer, "GAMVF7G4GJC4A7JMFJWLUAEIBFQD5RT3DCB5DC5TJDEKQBBACQ4JJVEE", "40")
+	require.NoError(t, err)
+
+	mockRepository.AssertExpectations(t)
+}
@@ -0,0 +1,80 @@
+package listener
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+
+	"github.com/stellar/gateway/bridge/config"
+)
+
+// Statuses returned by `callbacks.approve`
+const (
+	ApprovalStatusApproved = "approved"
+	ApprovalStatusRejected = "rejected"
+	// ApprovalStatusActionRequired and ApprovalStatusPending are supported by /tx_approve only
+	ApprovalStatusActionRequired = "action_required"
+	ApprovalStatusPending        = "pending"
+)
+
+// ApprovalResponse is a response of `callbacks.approve`
+type ApprovalResponse struct {
+	Status string `json:"status"`
+	// Error is a reason of the rejection returned to the client
+	Error string `json:"error"`
+	// Message is shown to the user when the status is action_required or pending
+	Message string `json:"message"`
+	// ActionURL, ActionMethod and ActionFields describe the action the user must take when
+	// the status is action_required
+	ActionURL    string   `json:"action_url"`
+	ActionMethod string   `json:"action_method"`
+	ActionFields []string `json:"action_fields"`
+	// Timeout is a number of milliseconds after which a pending payment can be sent again
+	Timeout int `json:"timeout"`
+}
+
+// RequestApproval sends a payment of a regulated asset to `callbacks.approve` and returns its
+// decision. An error is returned when the callback does not respond with 200 OK and a known status.
+// The request is cancelled when ctx is done.
+func RequestApproval(ctx context.Context, client HTTP, c *config.Config, payment url.Values) (response ApprovalResponse, err error) {
+	signer, err := newSigner(c)
+	if err != nil {
+		return
+	}
+
+	resp, err := postForm(ctx, client, signer, c.Callbacks.Approve, payment)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		err = fmt.Errorf("approve callback response status code indicates error (%d)", resp.StatusCode)
+		return
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		err = fmt.Errorf("cannot unmarshal approve callback response: %s", err)
+		return
+	}
+
+	switch response.Status {
+	case ApprovalStatusApproved, ApprovalStatusRejected, ApprovalStatusPending:
+	case ApprovalStatusActionRequired:
+		if response.ActionURL == "" {
+			err = errors.New("approve callback action_required status without action_url")
+		}
+	default:
+		err = fmt.Errorf("unknown approve callback status: %s", response.Status)
+	}
+	return
+}
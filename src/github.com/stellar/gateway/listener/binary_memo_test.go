@@ -0,0 +1,47 @@
+package listener
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBinaryMemoCallback(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {}))
+	defer srv.Close()
+
+	c := &config.Config{}
+	c.Callbacks.Receive = srv.URL
+
+	mockEntityManager := new(mocks.MockEntityManager)
+	mockRepository := new(mocks.MockRepository)
+	pl, err := NewPaymentListener(c, mockEntityManager, new(mocks.MockHorizon), mockRepository, time.Now)
+	require.NoError(t, err)
+	mockRepository.On("CountCallbackAttempts", mock.Anything).Return(0, nil)
+	mockRepository.On("IsCallbackDelivered", mock.Anything).Return(false, nil)
+	mockRepository.On("SaveCallbackDelivery", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockEntityManager.On("Persist", mock.AnythingOfType("*entities.CallbackAttempt")).Return(nil)
+
+	for _, memoType := range []string{"hash", "return"} {
+		payment := horizon.PaymentResponse{ID: "1234-" + memoType, Amount: "10"}
+		payment.Memo.Type = memoType
+		payment.Memo.Value = "ASNFZ4mrze8BI0VniavN7wEjRWeJq83vASNFZ4mrze8="
+
+		values, err := pl.sendReceiveCallback(context.Background(), payment, &entities.ReceivedPayment{}, false)
+		require.NoError(t, err)
+		assert.Equal(t, memoType, values.Get("memo_type"))
+		assert.Equal(t, payment.Memo.Value, values.Get("memo"))
+		assert.Equal(t, "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef", values.Get("memo_hex"))
+		assert.Equal(t, "", values.Get("route"))
+	}
+}
@@ -0,0 +1,57 @@
+package listener
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/db/entities"
+)
+
+// maxCallbackResponseLength is the number of bytes of the error response body that are stored
+const maxCallbackResponseLength = 512
+
+// recordCallbackAttempt saves a single receive callback delivery in the audit log. Errors are
+// only logged so that a failing audit log does not affect callback delivery.
+func (pl *PaymentListener) recordCallbackAttempt(operationID, callbackURL string, start time.Time, callbackErr error) {
+	attempts, err := pl.repository.CountCallbackAttempts(operationID)
+	if err != nil {
+		pl.log.WithFields(logrus.Fields{"err": err}).Error("Error counting callback attempts")
+		return
+	}
+
+	attempt := &entities.CallbackAttempt{
+		OperationID: operationID,
+		Attempt:     attempts + 1,
+		URL:         callbackURL,
+		Success:     callbackErr == nil,
+		LatencyMs:   int64(time.Since(start) / time.Millisecond),
+		CreatedAt:   start,
+		Tenant:      pl.config.Tenant,
+	}
+
+	// Brokers do not return HTTP status codes of the consumer
+	if !pl.config.CurrentCallbacks().ReceiveBroker() {
+		if callbackErr == nil {
+			statusCode := http.StatusOK
+			attempt.StatusCode = &statusCode
+		} else if responseErr, ok := callbackErr.(*CallbackResponseError); ok {
+			attempt.StatusCode = &responseErr.StatusCode
+			response := responseErr.Body
+			if len(response) > maxCallbackResponseLength {
+				response = response[:maxCallbackResponseLength]
+			}
+			attempt.Response = &response
+		}
+	}
+
+	if callbackErr != nil {
+		message := callbackErr.Error()
+		attempt.Error = &message
+	}
+
+	err = pl.entityManager.Persist(attempt)
+	if err != nil {
+		pl.log.WithFields(logrus.Fields{"err": err}).Error("Error saving callback attempt")
+	}
+}
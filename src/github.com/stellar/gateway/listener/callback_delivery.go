@@ -0,0 +1,39 @@
+package listener
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/Sirupsen/logrus"
+)
+
+// deliveryIDHeader contains delivery ID of the receive callback so receivers can deduplicate
+// callbacks of the same payment
+const deliveryIDHeader = "X-Delivery-ID"
+
+// callbackDeliveryID returns ID of a delivery of payment operationID to callbackURL. It's
+// the same for every attempt and is unique for every callback URL of the payment.
+func callbackDeliveryID(operationID, callbackURL string) string {
+	hash := sha256.Sum256([]byte(callbackURL))
+	return operationID + "-" + hex.EncodeToString(hash[:8])
+}
+
+// callbackDelivered returns true when the receive callback has already been acknowledged, so
+// it's not sent again after restarts or when the cursor is rewound
+func (pl *PaymentListener) callbackDelivered(deliveryID string) (bool, error) {
+	delivered, err := pl.repository.IsCallbackDelivered(deliveryID)
+	if err != nil {
+		pl.log.WithFields(logrus.Fields{"err": err}).Error("Error checking callback delivery")
+		return false, err
+	}
+	return delivered, nil
+}
+
+// recordCallbackDelivery saves an acknowledged receive callback. Errors are only logged as
+// the callback has already been delivered.
+func (pl *PaymentListener) recordCallbackDelivery(deliveryID, operationID, callbackURL string) {
+	err := pl.repository.SaveCallbackDelivery(deliveryID, operationID, callbackURL, pl.now())
+	if err != nil {
+		pl.log.WithFields(logrus.Fields{"err": err, "delivery_id": deliveryID}).Error("Error saving callback delivery")
+	}
+}
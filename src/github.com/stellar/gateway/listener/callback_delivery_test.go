@@ -0,0 +1,71 @@
+package listener
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallbackDeliveryID(t *testing.T) {
+	id := callbackDeliveryID("1234", "http://acme.com/receive")
+	assert.Equal(t, id, callbackDeliveryID("1234", "http://acme.com/receive"))
+	assert.Regexp(t, "^1234-[0-9a-f]{16}$", id)
+	assert.NotEqual(t, id, callbackDeliveryID("1234", "http://acme.com/customer"))
+	assert.NotEqual(t, id, callbackDeliveryID("1235", "http://acme.com/receive"))
+}
+
+func TestCallbackDelivery(t *testing.T) {
+	var deliveryIDs []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		deliveryIDs = append(deliveryIDs, req.Header.Get("X-Delivery-ID"))
+	}))
+	defer srv.Close()
+
+	c := &config.Config{}
+	c.Callbacks.Receive = srv.URL
+
+	now := time.Now()
+	mockEntityManager := new(mocks.MockEntityManager)
+	mockRepository := new(mocks.MockRepository)
+	pl, err := NewPaymentListener(c, mockEntityManager, new(mocks.MockHorizon), mockRepository, func() time.Time { return now })
+	require.NoError(t, err)
+	mockRepository.On("CountCallbackAttempts", "1234").Return(0, nil)
+	mockEntityManager.On("Persist", mock.AnythingOfType("*entities.CallbackAttempt")).Return(nil)
+
+	payment := horizon.PaymentResponse{ID: "1234", Amount: "10"}
+	payment.Memo.Type = "none"
+	deliveryID := callbackDeliveryID("1234", srv.URL)
+
+	// Delivered and saved
+	mockRepository.On("IsCallbackDelivered", deliveryID).Return(false, nil).Once()
+	mockRepository.On("SaveCallbackDelivery", deliveryID, "1234", srv.URL, now).Return(nil).Once()
+	values, err := pl.sendReceiveCallback(context.Background(), payment, &entities.ReceivedPayment{}, false)
+	require.NoError(t, err)
+	assert.Equal(t, "1234", values.Get("id"))
+	assert.Equal(t, []string{deliveryID}, deliveryIDs)
+
+	// Already delivered (after restart or cursor rewind)
+	mockRepository.On("IsCallbackDelivered", deliveryID).Return(true, nil).Once()
+	values, err = pl.sendReceiveCallback(context.Background(), payment, &entities.ReceivedPayment{}, false)
+	require.NoError(t, err)
+	assert.Equal(t, "1234", values.Get("id"))
+	assert.Len(t, deliveryIDs, 1)
+
+	// Resent by admin
+	mockRepository.On("SaveCallbackDelivery", deliveryID, "1234", srv.URL, now).Return(nil).Once()
+	_, err = pl.sendReceiveCallback(context.Background(), payment, &entities.ReceivedPayment{}, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{deliveryID, deliveryID}, deliveryIDs)
+
+	mockRepository.AssertExpectations(t)
+}
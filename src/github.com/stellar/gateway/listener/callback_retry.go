@@ -0,0 +1,191 @@
+package listener
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/db/entities"
+)
+
+const (
+	defaultRetryInitialInterval = 10 * time.Second
+	defaultRetryMaxInterval     = time.Hour
+	retryBatchSize              = 50
+)
+
+// retryPollInterval is a time between checks of the callback retry queue
+var retryPollInterval = 5 * time.Second
+
+// queueCallbackRetry saves a payment which receive callback failed and adds it to
+// the callback retry queue.
+func (pl *PaymentListener) queueCallbackRetry(ctx context.Context, dbPayment *entities.ReceivedPayment, callbackErr error) error {
+	dbPayment.Status = entities.ReceivedPaymentStatusCallbackPending
+	err := pl.entityManager.Persist(dbPayment)
+	if err != nil {
+		pl.log.WithFields(logrus.Fields{"err": err}).Error("Error saving payment to the DB")
+		return err
+	}
+
+	retry := &entities.CallbackRetry{
+		ReceivedPaymentID: *dbPayment.ID,
+		CreatedAt:         pl.now(),
+		Tenant:            pl.config.Tenant,
+	}
+	return pl.failCallbackRetry(ctx, dbPayment, retry, callbackErr)
+}
+
+// failCallbackRetry records a failed delivery. Next attempt is scheduled using exponential
+// backoff. When the maximum number of attempts is reached the retry is removed from the queue
+// and payment is dead-lettered.
+func (pl *PaymentListener) failCallbackRetry(ctx context.Context, dbPayment *entities.ReceivedPayment, retry *entities.CallbackRetry, callbackErr error) error {
+	retry.Attempts++
+	retry.LastError = callbackErr.Error()
+
+	if retry.Attempts >= pl.config.MaxCallbackAttempts() {
+		err := pl.deadLetter(ctx, dbPayment, retry.Attempts, callbackErr)
+		if err != nil {
+			return err
+		}
+
+		if retry.IsNew() {
+			return nil
+		}
+		return pl.entityManager.Delete(retry)
+	}
+
+	retry.NextAttemptAt = pl.now().Add(pl.retryInterval(retry.Attempts))
+	pl.log.WithFields(logrus.Fields{
+		"id":              dbPayment.OperationID,
+		"attempts":        retry.Attempts,
+		"err":             callbackErr,
+		"next_attempt_at": retry.NextAttemptAt,
+	}).Warn("Receive callback failed. Retry scheduled")
+	return pl.entityManager.Persist(retry)
+}
+
+// retryInterval returns time to wait before the next attempt after a given number of
+// failed attempts. The interval doubles after every attempt up to callback_retry.max_interval
+// and is then randomly changed by up to callback_retry.jitter percent.
+func (pl *PaymentListener) retryInterval(attempts int) time.Duration {
+	interval := time.Duration(pl.config.CallbackRetry.InitialInterval) * time.Second
+	if interval == 0 {
+		interval = defaultRetryInitialInterval
+	}
+
+	maxInterval := time.Duration(pl.config.CallbackRetry.MaxInterval) * time.Second
+	if maxInterval == 0 {
+		maxInterval = defaultRetryMaxInterval
+	}
+
+	for i := 1; i < attempts && interval < maxInterval; i++ {
+		interval *= 2
+	}
+
+	if interval > maxInterval {
+		interval = maxInterval
+	}
+
+	if jitter := pl.config.CallbackRetry.Jitter; jitter > 0 {
+		interval += time.Duration((rand.Float64()*2 - 1) * float64(jitter) / 100 * float64(interval))
+	}
+	return interval
+}
+
+// retryCallbacks processes the callback retry queue every retryPollInterval
+func (pl *PaymentListener) retryCallbacks() {
+	ctx := pl.ctx
+	pl.log.Info("Started callback retry worker")
+	for !pl.drainer.isStopped() {
+		time.Sleep(retryPollInterval)
+		pl.processCallbackRetries(ctx)
+	}
+}
+
+// processCallbackRetries sends callbacks which next attempt is due
+func (pl *PaymentListener) processCallbackRetries(ctx context.Context) {
+	// Retries are sent by the instance holding the listener lease
+	if !pl.isLeader() {
+		return
+	}
+
+	retries, err := pl.repository.GetDueCallbackRetries(pl.now(), retryBatchSize)
+	if err != nil {
+		pl.log.WithFields(logrus.Fields{"err": err}).Error("Error loading callback retries")
+		return
+	}
+
+	workers := pl.config.CallbackRetry.Workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	// Retries are sent by workers; the next batch is loaded when all retries are processed
+	// so a retry is never sent twice at the same time
+	queue := make(chan *entities.CallbackRetry)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for retry := range queue {
+				pl.processCallbackRetry(ctx, retry)
+			}
+		}()
+	}
+
+	for i := range retries {
+		if !pl.drainer.begin() {
+			break
+		}
+		queue <- &retries[i]
+	}
+	close(queue)
+	wg.Wait()
+}
+
+// processCallbackRetry sends a callback retry. drainer.begin must be called before.
+func (pl *PaymentListener) processCallbackRetry(ctx context.Context, retry *entities.CallbackRetry) {
+	defer pl.drainer.done()
+	err := pl.retryCallback(ctx, retry)
+	if err != nil {
+		pl.log.WithFields(logrus.Fields{
+			"err":                 err,
+			"received_payment_id": retry.ReceivedPaymentID,
+		}).Error("Error processing callback retry")
+	}
+}
+
+// retryCallback sends receive callback of a queued payment again
+func (pl *PaymentListener) retryCallback(ctx context.Context, retry *entities.CallbackRetry) error {
+	dbPayment, err := pl.repository.GetReceivedPaymentByID(retry.ReceivedPaymentID)
+	if err != nil {
+		return err
+	}
+
+	// Payment has been resolved by an operator in the meantime
+	if dbPayment == nil || dbPayment.IsFinal() {
+		return pl.entityManager.Delete(retry)
+	}
+
+	pl.log.WithFields(logrus.Fields{"id": dbPayment.OperationID, "attempts": retry.Attempts}).Info("Retrying receive callback")
+
+	payment := pl.storedPayment(dbPayment)
+	callbackValues, err := pl.sendReceiveCallback(ctx, payment, dbPayment, false)
+	if err == nil {
+		err = pl.publishReceived(payment, dbPayment, callbackValues)
+	}
+	if err != nil {
+		return pl.failCallbackRetry(ctx, dbPayment, retry, err)
+	}
+
+	dbPayment.Status = entities.ReceivedPaymentStatusSuccess
+	err = pl.entityManager.Persist(dbPayment)
+	if err != nil {
+		return err
+	}
+
+	return pl.entityManager.Delete(retry)
+}
@@ -0,0 +1,169 @@
+package listener
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryInterval(t *testing.T) {
+	pl := PaymentListener{config: &config.Config{}}
+	assert.Equal(t, 10*time.Second, pl.retryInterval(1))
+	assert.Equal(t, 20*time.Second, pl.retryInterval(2))
+	assert.Equal(t, 80*time.Second, pl.retryInterval(4))
+	assert.Equal(t, time.Hour, pl.retryInterval(100))
+
+	pl.config.CallbackRetry = config.CallbackRetry{InitialInterval: 60, MaxInterval: 300}
+	assert.Equal(t, time.Minute, pl.retryInterval(1))
+	assert.Equal(t, 4*time.Minute, pl.retryInterval(3))
+	assert.Equal(t, 5*time.Minute, pl.retryInterval(4))
+}
+
+func TestRetryIntervalJitter(t *testing.T) {
+	pl := PaymentListener{config: &config.Config{}}
+	pl.config.CallbackRetry = config.CallbackRetry{InitialInterval: 100, Jitter: 20}
+	for i := 0; i < 100; i++ {
+		interval := pl.retryInterval(2)
+		assert.True(t, interval >= 160*time.Second && interval <= 240*time.Second, interval.String())
+	}
+}
+
+func TestRetryCallback(t *testing.T) {
+	status := http.StatusInternalServerError
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	c := &config.Config{}
+	c.Callbacks.Receive = srv.URL
+	c.CallbackRetry.MaxAttempts = 3
+
+	now := time.Now()
+	mockEntityManager := new(mocks.MockEntityManager)
+	mockRepository := new(mocks.MockRepository)
+	expectCallbackAttempts(mockEntityManager, mockRepository)
+	pl, err := NewPaymentListener(c, mockEntityManager, new(mocks.MockHorizon), mockRepository, func() time.Time { return now })
+	require.NoError(t, err)
+
+	id := int64(1)
+	dbPayment := &entities.ReceivedPayment{
+		ID:          &id,
+		OperationID: "1234",
+		Status:      entities.ReceivedPaymentStatusCallbackPending,
+		FromAccount: "GBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFZYYQ",
+		Amount:      "100.0000000",
+		MemoType:    "none",
+	}
+	dbPayment.SetExists()
+	retry := &entities.CallbackRetry{ReceivedPaymentID: id, Attempts: 1}
+	retry.SetExists()
+
+	mockRepository.On("GetReceivedPaymentByID", id).Return(dbPayment, nil)
+
+	// Failed attempt is rescheduled
+	mockEntityManager.On("Persist", retry).Return(nil).Once()
+	require.NoError(t, pl.retryCallback(context.Background(), retry))
+	assert.Equal(t, 2, retry.Attempts)
+	assert.Equal(t, now.Add(20*time.Second), retry.NextAttemptAt)
+	assert.Contains(t, retry.LastError, "Error response from receive callback (500)")
+	assert.Equal(t, entities.ReceivedPaymentStatusCallbackPending, dbPayment.Status)
+
+	// Successful attempt removes retry
+	status = http.StatusOK
+	mockEntityManager.On("Persist", mock.AnythingOfType("*entities.ReceivedPayment")).Return(nil).Once()
+	mockEntityManager.On("Delete", retry).Return(nil).Once()
+	require.NoError(t, pl.retryCallback(context.Background(), retry))
+	assert.Equal(t, entities.ReceivedPaymentStatusSuccess, dbPayment.Status)
+
+	// Last failed attempt moves payment to dead letter
+	status = http.StatusInternalServerError
+	dbPayment.Status = entities.ReceivedPaymentStatusCallbackPending
+	mockEntityManager.On("Persist", mock.AnythingOfType("*entities.ReceivedPayment")).Return(nil).Once()
+	mockEntityManager.On("Delete", retry).Return(nil).Once()
+	require.NoError(t, pl.retryCallback(context.Background(), retry))
+	assert.Equal(t, 3, retry.Attempts)
+	assert.Equal(t, entities.ReceivedPaymentStatusDeadLetter, dbPayment.Status)
+
+	// Payments resolved by an operator are removed from the queue
+	dbPayment.Status = entities.ReceivedPaymentStatusResolved
+	mockEntityManager.On("Delete", retry).Return(nil).Once()
+	require.NoError(t, pl.retryCallback(context.Background(), retry))
+
+	mockEntityManager.AssertExpectations(t)
+}
+
+func TestDeadLetter(t *testing.T) {
+	notifications := make(chan url.Values, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/dead-letter" {
+			req.ParseForm()
+			notifications <- req.PostForm
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	c := &config.Config{Tenant: "acme", Assets: []config.Asset{{}}}
+	c.Accounts.ReceivingAccountID = "GATKP6ZQM5CSLECPMTAC5226PE367QALCPM6AFHTSULPPZMT62OOPMQB"
+	c.Callbacks.Receive = srv.URL
+	c.DeadLetter = config.DeadLetter{MaxAttempts: 2, Webhook: srv.URL + "/dead-letter"}
+
+	now := time.Now()
+	mockEntityManager := new(mocks.MockEntityManager)
+	mockRepository := new(mocks.MockRepository)
+	pl, err := NewPaymentListener(c, mockEntityManager, new(mocks.MockHorizon), mockRepository, func() time.Time { return now })
+	require.NoError(t, err)
+	mockEntityManager.On("Persist", mock.AnythingOfType("*entities.CallbackAttempt")).Return(nil)
+	mockRepository.On("IsCallbackDelivered", mock.AnythingOfType("string")).Return(false, nil)
+
+	id := int64(1)
+	dbPayment := &entities.ReceivedPayment{ID: &id, OperationID: "1234", FromAccount: "GBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFZYYQ", Amount: "10"}
+	payment := pl.storedPayment(dbPayment)
+	payment.Type = "payment"
+	payment.Memo.Type = "none"
+	mockHorizon := pl.horizon.(*mocks.MockHorizon)
+	mockHorizon.On("LoadMemo", mock.AnythingOfType("*horizon.PaymentResponse")).Return(nil)
+
+	// Payment blocks newer payments until max attempts is reached
+	// (attempts are counted before saving an attempt and after the callback failed)
+	for _, count := range []int{0, 1, 1, 2} {
+		mockRepository.On("CountCallbackAttempts", "1234").Return(count, nil).Once()
+	}
+	require.Error(t, pl.processPayment(context.Background(), payment, dbPayment, true, nil))
+
+	mockEntityManager.On("Persist", dbPayment).Return(nil).Once()
+	require.NoError(t, pl.processPayment(context.Background(), payment, dbPayment, true, nil))
+	assert.Equal(t, entities.ReceivedPaymentStatusDeadLetter, dbPayment.Status)
+
+	notification := <-notifications
+	assert.Equal(t, "1234", notification.Get("id"))
+	assert.Equal(t, "acme", notification.Get("tenant"))
+	assert.Equal(t, "2", notification.Get("attempts"))
+	assert.Contains(t, notification.Get("error"), "Error response from receive callback (500)")
+
+	// Requeued payment is sent by the retry worker
+	mockEntityManager.On("Persist", dbPayment).Return(nil).Once()
+	mockEntityManager.On("Persist", &entities.CallbackRetry{
+		ReceivedPaymentID: id,
+		NextAttemptAt:     now,
+		CreatedAt:         now,
+		Tenant:            "acme",
+	}).Return(nil).Once()
+	require.NoError(t, pl.Requeue(dbPayment))
+	assert.Equal(t, entities.ReceivedPaymentStatusCallbackPending, dbPayment.Status)
+
+	mockEntityManager.AssertExpectations(t)
+	mockRepository.AssertExpectations(t)
+}
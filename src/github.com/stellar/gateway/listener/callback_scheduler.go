@@ -0,0 +1,60 @@
+package listener
+
+import (
+	"context"
+	"net/url"
+	"sync"
+
+	"github.com/stellar/gateway/protocols/bridge"
+)
+
+// HostLimitedCallbackSender sends callbacks using Sender but limits a number of callbacks sent
+// to a single host of callbackURL concurrently to MaxPerHost, so a slow callback host does not
+// take up all connections. Send blocks until a callback to the host can be sent or ctx is done.
+type HostLimitedCallbackSender struct {
+	Sender     CallbackSender
+	MaxPerHost int
+
+	mutex sync.Mutex
+	hosts map[string]chan struct{}
+}
+
+// Send sends the callback using Sender
+func (s *HostLimitedCallbackSender) Send(ctx context.Context, callbackURL string, form url.Values, payload *bridge.ReceiveCallback) error {
+	slots := s.slots(callbackHost(callbackURL))
+	select {
+	case slots <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { <-slots }()
+
+	return s.Sender.Send(ctx, callbackURL, form, payload)
+}
+
+// slots returns a semaphore of host
+func (s *HostLimitedCallbackSender) slots(host string) chan struct{} {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.hosts == nil {
+		s.hosts = make(map[string]chan struct{})
+	}
+
+	slots, ok := s.hosts[host]
+	if !ok {
+		slots = make(chan struct{}, s.MaxPerHost)
+		s.hosts[host] = slots
+	}
+	return slots
+}
+
+// callbackHost returns host of callbackURL. Callbacks sent to message brokers (empty
+// callbackURL) share a single host.
+func callbackHost(callbackURL string) string {
+	parsed, err := url.Parse(callbackURL)
+	if err != nil {
+		return callbackURL
+	}
+	return parsed.Host
+}
@@ -0,0 +1,55 @@
+package listener
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stretchr/testify/assert"
+)
+
+type concurrencySender struct {
+	mutex   sync.Mutex
+	current map[string]int
+	max     map[string]int
+}
+
+func (s *concurrencySender) Send(ctx context.Context, callbackURL string, form url.Values, payload *bridge.ReceiveCallback) error {
+	host := callbackHost(callbackURL)
+	s.mutex.Lock()
+	s.current[host]++
+	if s.current[host] > s.max[host] {
+		s.max[host] = s.current[host]
+	}
+	s.mutex.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+
+	s.mutex.Lock()
+	s.current[host]--
+	s.mutex.Unlock()
+	return nil
+}
+
+func TestHostLimitedCallbackSender(t *testing.T) {
+	sender := &concurrencySender{current: map[string]int{}, max: map[string]int{}}
+	limited := &HostLimitedCallbackSender{Sender: sender, MaxPerHost: 2}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		for _, callbackURL := range []string{"http://slow.example.com/receive", "http://fast.example.com/receive?customer=1"} {
+			wg.Add(1)
+			go func(callbackURL string) {
+				defer wg.Done()
+				assert.NoError(t, limited.Send(context.Background(), callbackURL, url.Values{}, &bridge.ReceiveCallback{}))
+			}(callbackURL)
+		}
+	}
+	wg.Wait()
+
+	assert.True(t, sender.max["slow.example.com"] <= 2)
+	assert.True(t, sender.max["fast.example.com"] <= 2)
+}
@@ -0,0 +1,243 @@
+package listener
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stellar/go/support/errors"
+)
+
+// CallbackSender delivers receive callbacks. callbackURL is the receive callback of the
+// payment (or its customer) and is used only by transports sending callbacks over HTTP.
+// Delivery is cancelled when ctx is done.
+type CallbackSender interface {
+	Send(ctx context.Context, callbackURL string, form url.Values, payload *bridge.ReceiveCallback) error
+}
+
+// NewCallbackSender creates a CallbackSender for `callbacks.receive_transport` config param
+func NewCallbackSender(client HTTP, c *config.Config) (CallbackSender, error) {
+	switch c.Callbacks.ReceiveTransport {
+	case config.TransportAMQP:
+		return &AMQPCallbackSender{
+			Client:     client,
+			URL:        c.Callbacks.AMQP.URL,
+			VHost:      c.Callbacks.AMQP.VHost,
+			Exchange:   c.Callbacks.AMQP.Exchange,
+			RoutingKey: c.Callbacks.AMQP.RoutingKey,
+			Tenant:     c.Tenant,
+		}, nil
+	case config.TransportKafka:
+		return &KafkaCallbackSender{
+			Client: client,
+			URL:    c.Callbacks.Kafka.URL,
+			Topic:  c.Callbacks.Kafka.Topic,
+		}, nil
+	default:
+		signer, err := newSigner(c)
+		if err != nil {
+			return nil, err
+		}
+		return &HTTPCallbackSender{
+			Client:  client,
+			Signer:  signer,
+			Version: config.PayloadVersion(c.Callbacks.ReceiveVersion),
+		}, nil
+	}
+}
+
+// HTTPCallbackSender POSTs callbacks to callback URL: form when Version is config.PayloadVersion1
+// and JSON-encoded payload when Version is config.PayloadVersion2.
+type HTTPCallbackSender struct {
+	Client  HTTP
+	Signer  Signer
+	Version int
+}
+
+// Send sends the callback with delivery ID in X-Delivery-ID header. Error is returned when the
+// callback does not respond with 200 OK.
+func (s *HTTPCallbackSender) Send(ctx context.Context, callbackURL string, form url.Values, payload *bridge.ReceiveCallback) error {
+	header := http.Header{}
+	header.Set(deliveryIDHeader, callbackDeliveryID(payload.ID, callbackURL))
+	resp, err := sendCallback(ctx, s.Client, s.Signer, callbackURL, s.Version, form, payload, header)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return errors.Wrap(err, "reading receive callback response failed")
+		}
+		return &CallbackResponseError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	return nil
+}
+
+// CallbackResponseError is returned by HTTPCallbackSender when the callback responds
+// with a status code other than 200 OK
+type CallbackResponseError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *CallbackResponseError) Error() string {
+	return fmt.Sprintf("Error response from receive callback (%d): %s", e.StatusCode, e.Body)
+}
+
+// AMQPCallbackSender publishes JSON-encoded (version 2) payloads to a RabbitMQ exchange
+// using the management HTTP API.
+type AMQPCallbackSender struct {
+	Client     HTTP
+	URL        string
+	VHost      string
+	Exchange   string
+	RoutingKey string
+	Tenant     string
+}
+
+type amqpPublishRequest struct {
+	Properties      amqpProperties `json:"properties"`
+	RoutingKey      string         `json:"routing_key"`
+	Payload         string         `json:"payload"`
+	PayloadEncoding string         `json:"payload_encoding"`
+}
+
+type amqpProperties struct {
+	ContentType  string            `json:"content_type"`
+	DeliveryMode int               `json:"delivery_mode"`
+	MessageID    string            `json:"message_id"`
+	Headers      map[string]string `json:"headers"`
+}
+
+// Send publishes payload as a persistent message. Error is returned when the message
+// is not routed to any queue.
+func (s *AMQPCallbackSender) Send(ctx context.Context, callbackURL string, form url.Values, payload *bridge.ReceiveCallback) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "marshal payload failed")
+	}
+
+	headers := map[string]string{
+		payloadVersionHeader: strconv.Itoa(config.PayloadVersion2),
+		deliveryIDHeader:     callbackDeliveryID(payload.ID, callbackURL),
+	}
+	if s.Tenant != "" {
+		headers["tenant"] = s.Tenant
+	}
+
+	request, err := json.Marshal(amqpPublishRequest{
+		Properties: amqpProperties{
+			ContentType:  "application/json",
+			DeliveryMode: 2,
+			MessageID:    payload.ID,
+			Headers:      headers,
+		},
+		RoutingKey:      s.RoutingKey,
+		Payload:         string(body),
+		PayloadEncoding: "string",
+	})
+	if err != nil {
+		return errors.Wrap(err, "marshal publish request failed")
+	}
+
+	vhost := s.VHost
+	if vhost == "" {
+		vhost = "/"
+	}
+	publishURL := strings.TrimRight(s.URL, "/") + "/api/exchanges/" + url.PathEscape(vhost) + "/" + url.PathEscape(s.Exchange) + "/publish"
+
+	var response struct {
+		Routed bool `json:"routed"`
+	}
+	err = postJSON(ctx, s.Client, publishURL, "application/json", request, &response)
+	if err != nil {
+		return errors.Wrap(err, "publishing to AMQP exchange failed")
+	}
+
+	if !response.Routed {
+		return errors.New("AMQP message was not routed to any queue")
+	}
+
+	return nil
+}
+
+// KafkaCallbackSender produces JSON-encoded (version 2) payloads to a Kafka topic using
+// Kafka REST Proxy (v2 API). Payment ID is used as a record key.
+type KafkaCallbackSender struct {
+	Client HTTP
+	URL    string
+	Topic  string
+}
+
+type kafkaRecord struct {
+	Key   string                  `json:"key"`
+	Value *bridge.ReceiveCallback `json:"value"`
+}
+
+// Send produces payload to the topic
+func (s *KafkaCallbackSender) Send(ctx context.Context, callbackURL string, form url.Values, payload *bridge.ReceiveCallback) error {
+	request, err := json.Marshal(map[string][]kafkaRecord{
+		"records": {{Key: payload.ID, Value: payload}},
+	})
+	if err != nil {
+		return errors.Wrap(err, "marshal produce request failed")
+	}
+
+	var response struct {
+		Offsets []struct {
+			ErrorCode *int   `json:"error_code"`
+			Error     string `json:"error"`
+		} `json:"offsets"`
+	}
+	topicURL := strings.TrimRight(s.URL, "/") + "/topics/" + url.PathEscape(s.Topic)
+	err = postJSON(ctx, s.Client, topicURL, "application/vnd.kafka.json.v2+json", request, &response)
+	if err != nil {
+		return errors.Wrap(err, "producing to Kafka topic failed")
+	}
+
+	for _, offset := range response.Offsets {
+		if offset.ErrorCode != nil || offset.Error != "" {
+			return errors.New("Kafka record was not produced: " + offset.Error)
+		}
+	}
+
+	return nil
+}
+
+// postJSON sends body to url and decodes JSON response into response. Error is returned when
+// the server does not respond with 2xx status code.
+func postJSON(ctx context.Context, client HTTP, url, contentType string, body []byte, response interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "configure http request failed")
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "http request errored")
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "reading response failed")
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("response status code indicates error (%d): %s", resp.StatusCode, respBody)
+	}
+
+	return json.Unmarshal(respBody, response)
+}
@@ -0,0 +1,90 @@
+package listener
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAMQPCallbackSender(t *testing.T) {
+	var path, body string
+	routed := true
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		path = req.URL.EscapedPath()
+		raw, _ := ioutil.ReadAll(req.Body)
+		body = string(raw)
+		json.NewEncoder(w).Encode(map[string]bool{"routed": routed})
+	}))
+	defer srv.Close()
+
+	c := &config.Config{Tenant: "acme"}
+	c.Callbacks.ReceiveTransport = config.TransportAMQP
+	c.Callbacks.AMQP = config.AMQP{URL: srv.URL, Exchange: "payments", RoutingKey: "received"}
+	sender, err := NewCallbackSender(http.DefaultClient, c)
+	require.NoError(t, err)
+
+	payload := &bridge.ReceiveCallback{Event: bridge.CallbackEventPaymentReceived, ID: "1234", Amount: "10.0000000"}
+	require.NoError(t, sender.Send(context.Background(), "", nil, payload))
+	assert.Equal(t, "/api/exchanges/%2F/payments/publish", path)
+
+	var request amqpPublishRequest
+	require.NoError(t, json.Unmarshal([]byte(body), &request))
+	assert.Equal(t, "received", request.RoutingKey)
+	assert.Equal(t, "string", request.PayloadEncoding)
+	assert.Equal(t, "1234", request.Properties.MessageID)
+	assert.Equal(t, 2, request.Properties.DeliveryMode)
+	assert.Equal(t, map[string]string{"X-Payload-Version": "2", "X-Delivery-ID": callbackDeliveryID("1234", ""), "tenant": "acme"}, request.Properties.Headers)
+
+	var published bridge.ReceiveCallback
+	require.NoError(t, json.Unmarshal([]byte(request.Payload), &published))
+	assert.Equal(t, *payload, published)
+
+	routed = false
+	assert.EqualError(t, sender.Send(context.Background(), "", nil, payload), "AMQP message was not routed to any queue")
+}
+
+func TestKafkaCallbackSender(t *testing.T) {
+	var path, contentType, body string
+	response := `{"offsets":[{"partition":0,"offset":1,"error_code":null,"error":null}]}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		path = req.URL.Path
+		contentType = req.Header.Get("Content-Type")
+		raw, _ := ioutil.ReadAll(req.Body)
+		body = string(raw)
+		w.Write([]byte(response))
+	}))
+	defer srv.Close()
+
+	c := &config.Config{}
+	c.Callbacks.ReceiveTransport = config.TransportKafka
+	c.Callbacks.Kafka = config.Kafka{URL: srv.URL, Topic: "payments"}
+	sender, err := NewCallbackSender(http.DefaultClient, c)
+	require.NoError(t, err)
+
+	payload := &bridge.ReceiveCallback{Event: bridge.CallbackEventPaymentReceived, ID: "1234"}
+	require.NoError(t, sender.Send(context.Background(), "", nil, payload))
+	assert.Equal(t, "/topics/payments", path)
+	assert.Equal(t, "application/vnd.kafka.json.v2+json", contentType)
+
+	var request struct {
+		Records []struct {
+			Key   string                 `json:"key"`
+			Value bridge.ReceiveCallback `json:"value"`
+		} `json:"records"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(body), &request))
+	require.Len(t, request.Records, 1)
+	assert.Equal(t, "1234", request.Records[0].Key)
+	assert.Equal(t, *payload, request.Records[0].Value)
+
+	response = `{"offsets":[{"partition":null,"offset":null,"error_code":50003,"error":"Kafka error"}]}`
+	assert.EqualError(t, sender.Send(context.Background(), "", nil, payload), "Kafka record was not produced: Kafka error")
+}
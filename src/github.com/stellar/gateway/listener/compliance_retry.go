@@ -0,0 +1,263 @@
+package listener
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/db"
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/protocols/compliance"
+)
+
+const (
+	defaultCompliancePendingTimeout  = 24 * time.Hour
+	defaultCompliancePendingInterval = time.Minute
+	complianceRetryBatchSize         = 50
+)
+
+// complianceRetryPollInterval is a time between checks of pending compliance requests
+var complianceRetryPollInterval = 5 * time.Second
+
+// NewPendingCompliance creates a PendingCompliance of a compliance server /send request
+// which response is pending. It's sent again after the number of seconds returned by the
+// destination compliance server.
+func NewPendingCompliance(c *config.Config, sendRequest url.Values, response compliance.AuthResponse, now time.Time) *entities.PendingCompliance {
+	timeout := time.Duration(c.CompliancePendingTimeout) * time.Second
+	if timeout == 0 {
+		timeout = defaultCompliancePendingTimeout
+	}
+
+	return &entities.PendingCompliance{
+		Tenant:        c.Tenant,
+		SendRequest:   sendRequest.Encode(),
+		Status:        entities.PendingComplianceStatusPending,
+		TxStatus:      string(response.TxStatus),
+		InfoStatus:    string(response.InfoStatus),
+		Attempts:      1,
+		NextAttemptAt: now.Add(pendingInterval(response.Pending)),
+		ExpiresAt:     now.Add(timeout),
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// pendingInterval returns time to wait before sending a pending request again
+func pendingInterval(seconds int) time.Duration {
+	if seconds <= 0 {
+		return defaultCompliancePendingInterval
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// ComplianceRetry sends /payment requests pending at the destination compliance server to
+// the compliance server again when their pending interval passes. `callbacks.compliance` is
+// notified when a request is approved, denied or expires. Approved payments are not sent:
+// the client sends the /payment request again.
+type ComplianceRetry struct {
+	client        HTTP
+	config        *config.Config
+	entityManager db.EntityManagerInterface
+	repository    db.RepositoryInterface
+	now           func() time.Time
+	log           *logrus.Entry
+	// complianceClient sends requests to the compliance server when `compliance_tls` is
+	// configured, client is used when it's nil
+	complianceClient HTTP
+}
+
+// NewComplianceRetry creates a new ComplianceRetry
+func NewComplianceRetry(
+	config *config.Config,
+	entityManager db.EntityManagerInterface,
+	repository db.RepositoryInterface,
+	now func() time.Time,
+) (r *ComplianceRetry, err error) {
+	r = &ComplianceRetry{
+		client:        &http.Client{Timeout: config.Timeouts.CallbacksTimeout()},
+		config:        config,
+		entityManager: entityManager,
+		repository:    repository,
+		now:           now,
+		log:           logrus.WithFields(logrus.Fields{"service": "ComplianceRetry"}),
+	}
+	if config.ComplianceTLS.Enabled() {
+		r.complianceClient, err = config.ComplianceTLS.NewClient(config.Timeouts.ComplianceTimeout())
+		if err != nil {
+			return nil, err
+		}
+	}
+	if config.Tenant != "" {
+		r.log = r.log.WithField("tenant", config.Tenant)
+	}
+	return r, nil
+}
+
+// Start starts processing pending compliance requests every complianceRetryPollInterval
+func (r *ComplianceRetry) Start() {
+	r.log.Info("Started compliance retry worker")
+	go func() {
+		for {
+			time.Sleep(complianceRetryPollInterval)
+			r.Process()
+		}
+	}()
+}
+
+// Process sends pending compliance requests which next attempt is due
+func (r *ComplianceRetry) Process() {
+	pending, err := r.repository.GetDuePendingCompliance(r.now(), complianceRetryBatchSize)
+	if err != nil {
+		r.log.WithFields(logrus.Fields{"err": err}).Error("Error loading pending compliance requests")
+		return
+	}
+
+	for i := range pending {
+		err = r.retry(&pending[i])
+		if err != nil {
+			r.log.WithFields(logrus.Fields{
+				"err": err,
+				"id":  *pending[i].ID,
+			}).Error("Error processing pending compliance request")
+		}
+	}
+}
+
+// retry sends a pending request to the compliance server again. The request stays pending
+// until `callbacks.compliance` is notified about the decision.
+func (r *ComplianceRetry) retry(pending *entities.PendingCompliance) error {
+	now := r.now()
+	pending.UpdatedAt = now
+
+	if !now.Before(pending.ExpiresAt) {
+		return r.finish(pending, entities.PendingComplianceStatusExpired)
+	}
+
+	r.log.WithFields(logrus.Fields{"id": *pending.ID, "attempts": pending.Attempts}).Info("Sending pending compliance request")
+
+	pending.Attempts++
+	response, err := r.send(pending)
+	if err != nil {
+		pending.LastError = err.Error()
+		pending.NextAttemptAt = now.Add(defaultCompliancePendingInterval)
+		persistErr := r.entityManager.Persist(pending)
+		if persistErr != nil {
+			return persistErr
+		}
+		return err
+	}
+
+	pending.TxStatus = string(response.AuthResponse.TxStatus)
+	pending.InfoStatus = string(response.AuthResponse.InfoStatus)
+
+	switch {
+	case response.AuthResponse.InfoStatus == compliance.AuthStatusDenied ||
+		response.AuthResponse.TxStatus == compliance.AuthStatusDenied:
+		return r.finish(pending, entities.PendingComplianceStatusDenied)
+	case response.AuthResponse.InfoStatus == compliance.AuthStatusPending ||
+		response.AuthResponse.TxStatus == compliance.AuthStatusPending:
+		pending.NextAttemptAt = now.Add(pendingInterval(response.AuthResponse.Pending))
+		return r.entityManager.Persist(pending)
+	default:
+		return r.finish(pending, entities.PendingComplianceStatusApproved)
+	}
+}
+
+// send sends /send request to the compliance server
+func (r *ComplianceRetry) send(pending *entities.PendingCompliance) (*compliance.SendResponse, error) {
+	req, err := http.NewRequest("POST", r.config.Compliance+"/send", strings.NewReader(pending.SendRequest))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	client := r.client
+	if r.complianceClient != nil {
+		client = r.complianceClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("compliance server response status code indicates error (%d)", resp.StatusCode)
+	}
+
+	var response compliance.SendResponse
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// finish notifies `callbacks.compliance` and saves the request with final status. When the
+// notification fails the request stays pending and is processed again.
+func (r *ComplianceRetry) finish(pending *entities.PendingCompliance, status string) error {
+	err := r.notify(pending, status)
+	if err != nil {
+		pending.LastError = err.Error()
+		pending.NextAttemptAt = r.now().Add(defaultCompliancePendingInterval)
+		persistErr := r.entityManager.Persist(pending)
+		if persistErr != nil {
+			return persistErr
+		}
+		return err
+	}
+
+	r.log.WithFields(logrus.Fields{"id": *pending.ID, "status": status}).Info("Pending compliance request finished")
+	pending.Status = status
+	pending.LastError = ""
+	return r.entityManager.Persist(pending)
+}
+
+// notify sends a decision of a pending request to `callbacks.compliance`
+func (r *ComplianceRetry) notify(pending *entities.PendingCompliance, status string) error {
+	sendRequest, err := url.ParseQuery(pending.SendRequest)
+	if err != nil {
+		return err
+	}
+
+	form := url.Values{
+		"id":          {strconv.FormatInt(*pending.ID, 10)},
+		"tenant":      {r.config.Tenant},
+		"status":      {status},
+		"tx_status":   {pending.TxStatus},
+		"info_status": {pending.InfoStatus},
+	}
+	for _, name := range []string{"sender", "destination", "amount", "asset_code", "asset_issuer", "extra_memo"} {
+		form.Set(name, sendRequest.Get(name))
+	}
+
+	signer, err := newSigner(r.config)
+	if err != nil {
+		return err
+	}
+
+	resp, err := postForm(context.Background(), r.client, signer, r.config.Callbacks.Compliance, form)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("compliance callback response status code indicates error (%d)", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package listener
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/mocks"
+	"github.com/stellar/gateway/protocols/compliance"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComplianceRetry(t *testing.T) {
+	complianceResponse := `{"auth_response": {"info_status": "ok", "tx_status": "pending", "pending": 120}}`
+	complianceServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		require.NoError(t, req.ParseForm())
+		assert.Equal(t, "/send", req.URL.Path)
+		assert.Equal(t, "bob*stellar.org", req.PostForm.Get("destination"))
+		w.Write([]byte(complianceResponse))
+	}))
+	defer complianceServer.Close()
+
+	var callbacks []url.Values
+	callbackStatus := http.StatusInternalServerError
+	callbackServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		require.NoError(t, req.ParseForm())
+		callbacks = append(callbacks, req.PostForm)
+		w.WriteHeader(callbackStatus)
+	}))
+	defer callbackServer.Close()
+
+	c := &config.Config{Compliance: complianceServer.URL}
+	c.Callbacks.Compliance = callbackServer.URL
+
+	now := time.Now()
+	mockEntityManager := new(mocks.MockEntityManager)
+	mockRepository := new(mocks.MockRepository)
+	r, err := NewComplianceRetry(c, mockEntityManager, mockRepository, func() time.Time { return now })
+	require.NoError(t, err)
+
+	sendRequest := url.Values{"destination": {"bob*stellar.org"}, "amount": {"20"}}
+	pending := NewPendingCompliance(c, sendRequest, compliance.AuthResponse{TxStatus: compliance.AuthStatusPending}, now)
+	assert.Equal(t, now.Add(time.Minute), pending.NextAttemptAt)
+	assert.Equal(t, now.Add(24*time.Hour), pending.ExpiresAt)
+	pending.SetID(1)
+	pending.SetExists()
+
+	// Still pending
+	mockEntityManager.On("Persist", pending).Return(nil)
+	require.NoError(t, r.retry(pending))
+	assert.Equal(t, entities.PendingComplianceStatusPending, pending.Status)
+	assert.Equal(t, 2, pending.Attempts)
+	assert.Equal(t, now.Add(120*time.Second), pending.NextAttemptAt)
+	assert.Empty(t, callbacks)
+
+	// Approved but callback failed
+	complianceResponse = `{"auth_response": {"info_status": "ok", "tx_status": "ok"}}`
+	assert.Error(t, r.retry(pending))
+	assert.Equal(t, entities.PendingComplianceStatusPending, pending.Status)
+	assert.Equal(t, now.Add(time.Minute), pending.NextAttemptAt)
+	assert.Contains(t, pending.LastError, "(500)")
+	require.Len(t, callbacks, 1)
+
+	// Approved
+	callbackStatus = http.StatusOK
+	require.NoError(t, r.retry(pending))
+	assert.Equal(t, entities.PendingComplianceStatusApproved, pending.Status)
+	assert.Equal(t, "ok", pending.TxStatus)
+	assert.Empty(t, pending.LastError)
+	require.Len(t, callbacks, 2)
+	assert.Equal(t, "1", callbacks[1].Get("id"))
+	assert.Equal(t, "approved", callbacks[1].Get("status"))
+	assert.Equal(t, "bob*stellar.org", callbacks[1].Get("destination"))
+	assert.Equal(t, "20", callbacks[1].Get("amount"))
+
+	// Expired requests are not sent to the compliance server
+	pending.Status = entities.PendingComplianceStatusPending
+	now = now.Add(25 * time.Hour)
+	complianceServer.Close()
+	require.NoError(t, r.retry(pending))
+	assert.Equal(t, entities.PendingComplianceStatusExpired, pending.Status)
+	assert.Equal(t, "expired", callbacks[2].Get("status"))
+	assert.Equal(t, 4, pending.Attempts)
+}
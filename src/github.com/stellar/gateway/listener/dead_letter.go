@@ -0,0 +1,102 @@
+package listener
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/metrics"
+)
+
+// callbackFailed handles a failed receive callback of a new payment. When retries are enabled
+// the payment is added to the callback retry queue so newer payments are not blocked. Otherwise
+// error is returned, so the payment is processed again before newer payments, until
+// dead_letter.max_attempts deliveries fail.
+func (pl *PaymentListener) callbackFailed(ctx context.Context, dbPayment *entities.ReceivedPayment, callbackErr error) error {
+	if pl.config.CallbackRetry.MaxAttempts > 0 {
+		return pl.queueCallbackRetry(ctx, dbPayment, callbackErr)
+	}
+
+	if pl.config.DeadLetter.MaxAttempts == 0 {
+		return callbackErr
+	}
+
+	attempts, err := pl.repository.CountCallbackAttempts(dbPayment.OperationID)
+	if err != nil {
+		pl.log.WithFields(logrus.Fields{"err": err}).Error("Error counting callback attempts")
+		return callbackErr
+	}
+
+	if attempts < pl.config.DeadLetter.MaxAttempts {
+		return callbackErr
+	}
+	return pl.deadLetter(ctx, dbPayment, attempts, callbackErr)
+}
+
+// deadLetter saves a payment which receive callback failed too many times with Dead letter
+// status and notifies dead_letter.webhook. Dead-lettered payments can be requeued by an operator.
+func (pl *PaymentListener) deadLetter(ctx context.Context, dbPayment *entities.ReceivedPayment, attempts int, callbackErr error) error {
+	pl.log.WithFields(logrus.Fields{
+		"id":       dbPayment.OperationID,
+		"attempts": attempts,
+		"err":      callbackErr,
+	}).Error("Receive callback failed too many times. Payment moved to dead letter")
+
+	dbPayment.Status = entities.ReceivedPaymentStatusDeadLetter
+	err := pl.entityManager.Persist(dbPayment)
+	if err != nil {
+		pl.log.WithFields(logrus.Fields{"err": err}).Error("Error saving payment to the DB")
+		return err
+	}
+
+	metrics.AddCounter("receive_callback_dead_letters", 1, metrics.Tags{"tenant": pl.config.Tenant})
+
+	if pl.config.DeadLetter.Webhook != "" {
+		err = pl.notifyDeadLetter(ctx, dbPayment, attempts, callbackErr)
+		if err != nil {
+			pl.log.WithFields(logrus.Fields{"err": err}).Error("Error sending dead letter notification")
+		}
+	}
+	return nil
+}
+
+// notifyDeadLetter sends a dead-lettered payment to dead_letter.webhook
+func (pl *PaymentListener) notifyDeadLetter(ctx context.Context, dbPayment *entities.ReceivedPayment, attempts int, callbackErr error) error {
+	resp, err := pl.postForm(ctx, pl.config.DeadLetter.Webhook, url.Values{
+		"id":       {dbPayment.OperationID},
+		"tenant":   {pl.config.Tenant},
+		"attempts": {strconv.Itoa(attempts)},
+		"error":    {callbackErr.Error()},
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("dead letter webhook response status code indicates error (%d)", resp.StatusCode)
+	}
+	return nil
+}
+
+// Requeue adds a dead-lettered payment to the callback retry queue. Its receive callback is
+// sent again by the retry worker and it's dead-lettered again when all attempts fail.
+func (pl *PaymentListener) Requeue(dbPayment *entities.ReceivedPayment) error {
+	dbPayment.Status = entities.ReceivedPaymentStatusCallbackPending
+	err := pl.entityManager.Persist(dbPayment)
+	if err != nil {
+		return err
+	}
+
+	pl.log.WithFields(logrus.Fields{"id": dbPayment.OperationID}).Info("Dead letter requeued")
+
+	return pl.entityManager.Persist(&entities.CallbackRetry{
+		ReceivedPaymentID: *dbPayment.ID,
+		NextAttemptAt:     pl.now(),
+		CreatedAt:         pl.now(),
+		Tenant:            pl.config.Tenant,
+	})
+}
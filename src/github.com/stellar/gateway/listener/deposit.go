@@ -0,0 +1,59 @@
+package listener
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+
+	"github.com/stellar/gateway/bridge/config"
+)
+
+// DepositInstructions is a response of `callbacks.deposit`
+type DepositInstructions struct {
+	// How describes how the user should send funds to the anchor
+	How string `json:"how"`
+	// ETA is an estimated number of seconds until the deposit is completed
+	ETA int `json:"eta"`
+	// ExtraInfo is an optional JSON object returned to the wallet
+	ExtraInfo *json.RawMessage `json:"extra_info"`
+}
+
+// RequestDepositInstructions sends a SEP-6 deposit to `callbacks.deposit` and returns
+// instructions for the user. An error is returned when the callback does not respond with
+// 200 OK and `how` value. The request is cancelled when ctx is done.
+func RequestDepositInstructions(ctx context.Context, client HTTP, c *config.Config, deposit url.Values) (response DepositInstructions, err error) {
+	signer, err := newSigner(c)
+	if err != nil {
+		return
+	}
+
+	resp, err := postForm(ctx, client, signer, c.Callbacks.Deposit, deposit)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		err = fmt.Errorf("deposit callback response status code indicates error (%d)", resp.StatusCode)
+		return
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		err = fmt.Errorf("cannot unmarshal deposit callback response: %s", err)
+		return
+	}
+
+	if response.How == "" {
+		err = errors.New("deposit callback response without how")
+	}
+	return
+}
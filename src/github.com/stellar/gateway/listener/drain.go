@@ -0,0 +1,62 @@
+package listener
+
+import (
+	"sync"
+	"time"
+)
+
+// drainer tracks work in progress so it can be finished before the server exits
+type drainer struct {
+	mu       sync.Mutex
+	stopped  bool
+	inFlight sync.WaitGroup
+}
+
+// begin marks a start of a unit of work. It returns false when drainer has been
+// stopped and no new work should be started. done must be called when it returns true.
+func (d *drainer) begin() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.stopped {
+		return false
+	}
+	d.inFlight.Add(1)
+	return true
+}
+
+// done marks an end of a unit of work started with begin
+func (d *drainer) done() {
+	d.inFlight.Done()
+}
+
+// isStopped returns true when stop has been called
+func (d *drainer) isStopped() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.stopped
+}
+
+// stop prevents new units of work from being started
+func (d *drainer) stop() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.stopped = true
+}
+
+// wait waits until all units of work in progress are done. It returns false when
+// timeout passes first.
+func (d *drainer) wait(timeout time.Duration) bool {
+	finished := make(chan struct{})
+	go func() {
+		d.inFlight.Wait()
+		close(finished)
+	}()
+
+	select {
+	case <-finished:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
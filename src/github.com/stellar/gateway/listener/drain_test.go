@@ -0,0 +1,64 @@
+package listener
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDrainer(t *testing.T) {
+	d := &drainer{}
+	require.True(t, d.begin())
+
+	d.stop()
+	assert.True(t, d.isStopped())
+	assert.False(t, d.begin())
+	assert.False(t, d.wait(10*time.Millisecond))
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		d.done()
+	}()
+	assert.True(t, d.wait(time.Second))
+}
+
+func TestStopListener(t *testing.T) {
+	c := &config.Config{PaymentsPoll: true}
+	c.Accounts.ReceivingAccountID = "GATKP6ZQM5CSLECPMTAC5226PE367QALCPM6AFHTSULPPZMT62OOPMQB"
+
+	mockHorizon := new(mocks.MockHorizon)
+	mockRepository := new(mocks.MockRepository)
+	pl, err := NewPaymentListener(c, new(mocks.MockEntityManager), mockHorizon, mockRepository, time.Now)
+	require.NoError(t, err)
+
+	cursor := "100"
+	handlerErr := make(chan error, 1)
+	mockHorizon.On("LoadAccount", c.Accounts.ReceivingAccountID).Return(horizon.AccountResponse{}, nil)
+	mockRepository.On("GetLastCursorValue", c.Accounts.ReceivingAccountID).Return(&cursor, nil)
+	mockHorizon.On("StreamPayments", c.Accounts.ReceivingAccountID, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			pl.Stop()
+			handler := args.Get(2).(horizon.PaymentHandler)
+			handlerErr <- handler(horizon.PaymentResponse{ID: "1", PagingToken: "101"})
+		}).
+		Return(horizon.ErrStopStreaming).
+		Once()
+
+	require.NoError(t, pl.Listen())
+
+	select {
+	case err := <-handlerErr:
+		// Payment is not processed so it's not loaded from the DB
+		assert.Equal(t, horizon.ErrStopStreaming, err)
+	case <-time.After(time.Second):
+		t.Fatal("payment was not streamed")
+	}
+	assert.True(t, pl.Wait(time.Second))
+	mockHorizon.AssertExpectations(t)
+}
@@ -0,0 +1,204 @@
+package listener
+
+import (
+	"context"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/db"
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/protocols/amount"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stellar/gateway/submitter"
+	b "github.com/stellar/go-stellar-base/build"
+)
+
+const dustSweepBatchSize = 100
+
+// dustAsset identifies an asset of swept payments, both fields are empty for native asset
+type dustAsset struct {
+	code   string
+	issuer string
+}
+
+// DustSweeper periodically sends payments skipped because of `dust.minimums` from the
+// receiving account to `dust.sweep_account`. Payments of each asset are summed up and sent
+// in a single transaction, swept payments are linked to it (see entities.PaymentLink).
+type DustSweeper struct {
+	config        *config.Config
+	entityManager db.EntityManagerInterface
+	repository    db.RepositoryInterface
+	submitter     submitter.TransactionSubmitterInterface
+	now           func() time.Time
+	log           *logrus.Entry
+}
+
+// NewDustSweeper creates a new DustSweeper
+func NewDustSweeper(
+	config *config.Config,
+	entityManager db.EntityManagerInterface,
+	repository db.RepositoryInterface,
+	submitter submitter.TransactionSubmitterInterface,
+	now func() time.Time,
+) *DustSweeper {
+	s := &DustSweeper{
+		config:        config,
+		entityManager: entityManager,
+		repository:    repository,
+		submitter:     submitter,
+		now:           now,
+		log:           logrus.WithFields(logrus.Fields{"service": "DustSweeper"}),
+	}
+	if config.Tenant != "" {
+		s.log = s.log.WithField("tenant", config.Tenant)
+	}
+	return s
+}
+
+// Start starts sweeping dust every `dust.sweep_interval` seconds
+func (s *DustSweeper) Start() {
+	interval := time.Duration(s.config.Dust.SweepInterval) * time.Second
+	if interval == 0 {
+		interval = config.DefaultDustSweepInterval * time.Second
+	}
+
+	s.log.WithFields(logrus.Fields{"interval": interval}).Info("Started dust sweeper")
+	go func() {
+		for {
+			time.Sleep(interval)
+			s.Sweep()
+		}
+	}()
+}
+
+// Sweep sends all skipped dust payments to `dust.sweep_account`. Errors are logged, payments
+// of assets that could not be sent stay skipped and are sent during the next sweep.
+func (s *DustSweeper) Sweep() {
+	payments, err := s.loadDust()
+	if err != nil {
+		s.log.WithFields(logrus.Fields{"err": err}).Error("Error loading dust payments")
+		return
+	}
+
+	assets := []dustAsset{}
+	byAsset := map[dustAsset][]*entities.ReceivedPayment{}
+	for _, payment := range payments {
+		asset := dustAsset{payment.AssetCode, payment.AssetIssuer}
+		if _, ok := byAsset[asset]; !ok {
+			assets = append(assets, asset)
+		}
+		byAsset[asset] = append(byAsset[asset], payment)
+	}
+
+	for _, asset := range assets {
+		err = s.sweep(asset, byAsset[asset])
+		if err != nil {
+			s.log.WithFields(logrus.Fields{
+				"err":          err,
+				"asset_code":   asset.code,
+				"asset_issuer": asset.issuer,
+			}).Error("Error sweeping dust")
+		}
+	}
+}
+
+// loadDust returns skipped dust payments received by the account `accounts.receiving_seed`
+// belongs to
+func (s *DustSweeper) loadDust() ([]*entities.ReceivedPayment, error) {
+	dust := []*entities.ReceivedPayment{}
+	filter := entities.ReceivedPaymentFilter{
+		Status: entities.ReceivedPaymentStatusDustSkipped,
+		Limit:  dustSweepBatchSize,
+	}
+
+	for {
+		payments, err := s.repository.GetReceivedPayments(filter)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range payments {
+			payment := &payments[i]
+			// Payments saved before receiving accounts were stored have empty ReceivingAccount
+			if payment.ReceivingAccount == "" || payment.ReceivingAccount == s.config.Accounts.ReceivingAccountID {
+				dust = append(dust, payment)
+			}
+		}
+
+		if len(payments) < filter.Limit {
+			return dust, nil
+		}
+		filter.Cursor = *payments[len(payments)-1].ID
+	}
+}
+
+// sweep sends the total amount of payments of a single asset to `dust.sweep_account` and
+// marks them as swept
+func (s *DustSweeper) sweep(asset dustAsset, payments []*entities.ReceivedPayment) error {
+	var total amount.Amount
+	for _, payment := range payments {
+		value, err := amount.Parse(payment.Amount)
+		if err != nil {
+			return err
+		}
+		total += value
+	}
+
+	if total == 0 {
+		return nil
+	}
+
+	var sweepAmount interface{}
+	if asset.code == "" {
+		sweepAmount = b.NativeAmount{total.String()}
+	} else {
+		sweepAmount = b.CreditAmount{asset.code, asset.issuer, total.String()}
+	}
+
+	submitResponse, err := s.submitter.SubmitTransaction(
+		context.Background(),
+		s.config.Accounts.ReceivingSeed,
+		b.Payment(b.Destination{s.config.Dust.SweepAccount}, sweepAmount),
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	errorResponse := bridge.ErrorFromHorizonResponse(submitResponse)
+	if errorResponse != nil {
+		return errorResponse
+	}
+
+	s.log.WithFields(logrus.Fields{
+		"asset_code":     asset.code,
+		"asset_issuer":   asset.issuer,
+		"amount":         total.String(),
+		"payments":       len(payments),
+		"transaction_id": submitResponse.Hash,
+	}).Info("Dust swept")
+
+	// Transaction has already been sent so errors are only logged
+	now := s.now()
+	for _, payment := range payments {
+		payment.Status = entities.ReceivedPaymentStatusDustSwept
+		err = s.entityManager.Persist(payment)
+		if err != nil {
+			s.log.WithFields(logrus.Fields{"err": err, "operation_id": payment.OperationID}).Error("Error saving swept payment")
+			continue
+		}
+
+		err = s.entityManager.Persist(&entities.PaymentLink{
+			ReceivedPaymentID: *payment.ID,
+			TransactionID:     submitResponse.Hash,
+			CreatedAt:         now,
+			Tenant:            s.config.Tenant,
+		})
+		if err != nil {
+			s.log.WithFields(logrus.Fields{"err": err, "operation_id": payment.OperationID}).Error("Error saving payment link")
+		}
+	}
+
+	return nil
+}
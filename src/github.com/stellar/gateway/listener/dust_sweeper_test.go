@@ -0,0 +1,86 @@
+package listener
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDustSweeper(t *testing.T) {
+	issuer := "GDSIKW43UA6JTOA47WVEBCZ4MYC74M3GNKNXTVDXFHXYYTNO5GGVN632"
+	c := &config.Config{Dust: config.Dust{SweepAccount: "GBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFZYYQ"}}
+	c.Accounts.ReceivingAccountID = "GATKP6ZQM5CSLECPMTAC5226PE367QALCPM6AFHTSULPPZMT62OOPMQB"
+	c.Accounts.ReceivingSeed = "SDZT3EJZ7FZRYNTLOZ7VH6G5UYBFO2IO3Q5PGONMILPCZU3AL7QNZHTE"
+
+	payment := func(id int64, receivingAccount, assetCode, value string) entities.ReceivedPayment {
+		p := entities.ReceivedPayment{
+			OperationID:      "op",
+			ReceivingAccount: receivingAccount,
+			AssetCode:        assetCode,
+			Amount:           value,
+			Status:           entities.ReceivedPaymentStatusDustSkipped,
+		}
+		if assetCode != "" {
+			p.AssetIssuer = issuer
+		}
+		p.SetID(id)
+		p.SetExists()
+		return p
+	}
+	payments := []entities.ReceivedPayment{
+		payment(1, c.Accounts.ReceivingAccountID, "USD", "0.1000000"),
+		payment(2, "", "USD", "0.2500000"),
+		payment(3, c.Accounts.ReceivingAccountID, "", "0.0000100"),
+		// received by another receiving account
+		payment(4, "GBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFZYYQ", "USD", "0.5000000"),
+	}
+
+	now := time.Now()
+	mockEntityManager := new(mocks.MockEntityManager)
+	mockRepository := new(mocks.MockRepository)
+	mockSubmitter := new(mocks.MockTransactionSubmitter)
+	s := NewDustSweeper(c, mockEntityManager, mockRepository, mockSubmitter, func() time.Time { return now })
+
+	mockRepository.On("GetReceivedPayments", entities.ReceivedPaymentFilter{
+		Status: entities.ReceivedPaymentStatusDustSkipped,
+		Limit:  dustSweepBatchSize,
+	}).Return(payments, nil).Once()
+
+	ledger := uint64(100)
+	mockSubmitter.On("SubmitTransaction", c.Accounts.ReceivingSeed, mock.Anything, nil).
+		Return(horizon.SubmitTransactionResponse{Hash: "usd-hash", Ledger: &ledger}, nil).Once()
+	mockSubmitter.On("SubmitTransaction", c.Accounts.ReceivingSeed, mock.Anything, nil).
+		Return(horizon.SubmitTransactionResponse{}, errors.New("tx_failed")).Once()
+
+	var links []*entities.PaymentLink
+	mockEntityManager.On("Persist", mock.AnythingOfType("*entities.ReceivedPayment")).Return(nil)
+	mockEntityManager.On("Persist", mock.AnythingOfType("*entities.PaymentLink")).Return(nil).Run(func(args mock.Arguments) {
+		links = append(links, args.Get(0).(*entities.PaymentLink))
+	})
+
+	s.Sweep()
+
+	assert.Equal(t, entities.ReceivedPaymentStatusDustSwept, payments[0].Status)
+	assert.Equal(t, entities.ReceivedPaymentStatusDustSwept, payments[1].Status)
+	// Native payment failed and is swept during the next sweep
+	assert.Equal(t, entities.ReceivedPaymentStatusDustSkipped, payments[2].Status)
+	assert.Equal(t, entities.ReceivedPaymentStatusDustSkipped, payments[3].Status)
+
+	require.Len(t, links, 2)
+	for i, link := range links {
+		assert.Equal(t, *payments[i].ID, link.ReceivedPaymentID)
+		assert.Equal(t, "usd-hash", link.TransactionID)
+		assert.Equal(t, now, link.CreatedAt)
+	}
+
+	mockRepository.AssertExpectations(t)
+	mockSubmitter.AssertExpectations(t)
+}
@@ -0,0 +1,138 @@
+package listener
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+)
+
+const (
+	listenerLeaseName = "payment_listener"
+	defaultLeaseTTL   = 30 * time.Second
+)
+
+// listenerLease tracks the listener lease held by this instance and streams started when
+// it was acquired
+type listenerLease struct {
+	mutex       sync.Mutex
+	leaderUntil time.Time
+	streaming   map[string]bool
+}
+
+// defaultInstanceID returns ID of this instance used when `listener_lease.instance_id` is not set
+func defaultInstanceID() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "bridge"
+	}
+	return fmt.Sprintf("%s-%d", hostname, os.Getpid())
+}
+
+// leaseTTL returns a time the listener lease is valid for
+func (pl *PaymentListener) leaseTTL() time.Duration {
+	if pl.config.ListenerLease.TTL > 0 {
+		return time.Duration(pl.config.ListenerLease.TTL) * time.Second
+	}
+	return defaultLeaseTTL
+}
+
+// isLeader returns true when this instance holds the listener lease (or the lease is disabled)
+// and should process received payments and callback retries
+func (pl *PaymentListener) isLeader() bool {
+	if !pl.config.ListenerLease.Enabled {
+		return true
+	}
+
+	pl.lease.mutex.Lock()
+	defer pl.lease.mutex.Unlock()
+	return pl.now().Before(pl.lease.leaderUntil)
+}
+
+// IsRunning returns false when the listener has been stopped
+func (pl *PaymentListener) IsRunning() bool {
+	return !pl.drainer.isStopped()
+}
+
+// IsLeader returns true when this instance processes received payments and callback retries
+func (pl *PaymentListener) IsLeader() bool {
+	return pl.isLeader()
+}
+
+// lead acquires or renews the listener lease every third of its TTL until the listener is
+// stopped. Streams are started when the lease is acquired and stop when it's lost.
+func (pl *PaymentListener) lead() {
+	pl.log.WithField("instance_id", pl.instanceID).Info("Waiting for listener lease")
+	for !pl.drainer.isStopped() {
+		pl.renewLease()
+		time.Sleep(pl.leaseTTL() / 3)
+	}
+}
+
+// renewLease acquires or renews the listener lease
+func (pl *PaymentListener) renewLease() {
+	ttl := pl.leaseTTL()
+	now := pl.now()
+	wasLeader := pl.isLeader()
+
+	acquired, err := pl.repository.AcquireLease(listenerLeaseName, pl.instanceID, now, now.Add(ttl))
+	if err != nil {
+		// Leadership ends when the lease is not renewed before leaderUntil
+		pl.log.WithFields(logrus.Fields{"err": err}).Error("Error renewing listener lease")
+		return
+	}
+
+	if !acquired {
+		if wasLeader {
+			pl.log.WithField("instance_id", pl.instanceID).Warn("Listener lease lost")
+		}
+		return
+	}
+
+	// Leadership ends a third of TTL before the lease expires, so other instances do not take
+	// over while this instance is still processing payments
+	pl.lease.mutex.Lock()
+	pl.lease.leaderUntil = now.Add(ttl * 2 / 3)
+	pl.lease.mutex.Unlock()
+
+	if !wasLeader {
+		pl.log.WithField("instance_id", pl.instanceID).Info("Listener lease acquired")
+		pl.startStreams()
+	}
+}
+
+// releaseLease releases the listener lease so other instances can take over without
+// waiting for it to expire
+func (pl *PaymentListener) releaseLease() {
+	if !pl.config.ListenerLease.Enabled {
+		return
+	}
+
+	err := pl.repository.ReleaseLease(listenerLeaseName, pl.instanceID)
+	if err != nil {
+		pl.log.WithFields(logrus.Fields{"err": err}).Error("Error releasing listener lease")
+	}
+}
+
+// startStreams starts streams of receiving accounts which are not streamed
+func (pl *PaymentListener) startStreams() {
+	pl.lease.mutex.Lock()
+	defer pl.lease.mutex.Unlock()
+
+	for _, accountID := range pl.config.Accounts.ReceivingAccounts() {
+		if pl.lease.streaming[accountID] {
+			continue
+		}
+		pl.lease.streaming[accountID] = true
+		go pl.stream(accountID)
+	}
+}
+
+// streamStopped marks the stream of accountID as stopped
+func (pl *PaymentListener) streamStopped(accountID string) {
+	pl.lease.mutex.Lock()
+	defer pl.lease.mutex.Unlock()
+	pl.lease.streaming[accountID] = false
+}
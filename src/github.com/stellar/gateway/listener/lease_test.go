@@ -0,0 +1,63 @@
+package listener
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListenerLease(t *testing.T) {
+	c := &config.Config{PaymentsPoll: true}
+	c.Accounts.ReceivingAccountID = "GATKP6ZQM5CSLECPMTAC5226PE367QALCPM6AFHTSULPPZMT62OOPMQB"
+	c.ListenerLease = config.ListenerLease{Enabled: true, TTL: 30, InstanceID: "bridge-1"}
+
+	now := time.Unix(1500000000, 0)
+	mockHorizon := new(mocks.MockHorizon)
+	mockRepository := new(mocks.MockRepository)
+	pl, err := NewPaymentListener(c, new(mocks.MockEntityManager), mockHorizon, mockRepository, func() time.Time { return now })
+	require.NoError(t, err)
+
+	// Held by other instance
+	mockRepository.On("AcquireLease", "payment_listener", "bridge-1", now, now.Add(30*time.Second)).Return(false, nil).Once()
+	pl.renewLease()
+	assert.False(t, pl.isLeader())
+
+	// Acquired, streams are started
+	now = now.Add(10 * time.Second)
+	streamed := make(chan string, 1)
+	cursor := "100"
+	mockRepository.On("AcquireLease", "payment_listener", "bridge-1", now, now.Add(30*time.Second)).Return(true, nil).Once()
+	mockRepository.On("GetLastCursorValue", c.Accounts.ReceivingAccountID).Return(&cursor, nil).Once()
+	mockHorizon.On("StreamPayments", c.Accounts.ReceivingAccountID, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			streamed <- *args.Get(1).(*string)
+		}).
+		Return(horizon.ErrStopStreaming).
+		Once()
+	pl.renewLease()
+	assert.True(t, pl.isLeader())
+
+	select {
+	case streamedCursor := <-streamed:
+		assert.Equal(t, "100", streamedCursor)
+	case <-time.After(time.Second):
+		t.Fatal("account was not streamed")
+	}
+
+	// Leadership ends before the lease expires when it's not renewed
+	now = now.Add(20 * time.Second)
+	assert.False(t, pl.isLeader())
+
+	mockRepository.On("ReleaseLease", "payment_listener", "bridge-1").Return(nil).Once()
+	pl.Stop()
+	assert.True(t, pl.Wait(time.Second))
+
+	mockHorizon.AssertExpectations(t)
+	mockRepository.AssertExpectations(t)
+}
@@ -0,0 +1,87 @@
+package listener
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/Sirupsen/logrus"
+)
+
+// Types of listener errors sent to `callbacks.error`
+const (
+	// ListenerErrorFailing is sent when streaming payments of an account failed
+	// listenerErrorThreshold times in a row. The listener keeps reconnecting.
+	ListenerErrorFailing = "listener_failing"
+	// ListenerErrorStopped is sent when the listener stopped streaming payments of an account
+	// and must be restarted
+	ListenerErrorStopped = "listener_stopped"
+)
+
+// listenerErrorThreshold is a number of consecutive stream failures after which
+// `callbacks.error` is notified
+const listenerErrorThreshold = 5
+
+// ListenerError describes a failure of the payment listener sent to `callbacks.error`
+type ListenerError struct {
+	Type      string
+	AccountID string
+	// Cursor is a paging token the stream is resumed from (`now` when it was started without
+	// a saved cursor), empty when it has not been loaded
+	Cursor string
+	// OperationID is an ID of the payment which processing failed, empty when the failure is
+	// not related to a single payment (ex. Horizon is down)
+	OperationID string
+	Failures    int
+	Err         error
+}
+
+// ToValues creates url.Values from listener error
+func (e ListenerError) ToValues(tenant string) url.Values {
+	values := url.Values{
+		"type":       {e.Type},
+		"tenant":     {tenant},
+		"account_id": {e.AccountID},
+		"cursor":     {e.Cursor},
+		"failures":   {strconv.Itoa(e.Failures)},
+	}
+	if e.OperationID != "" {
+		values.Set("operation_id", e.OperationID)
+	}
+	if e.Err != nil {
+		values.Set("error", e.Err.Error())
+	}
+	return values
+}
+
+// notifyError sends a listener failure to `callbacks.error`. Errors are only logged.
+func (pl *PaymentListener) notifyError(ctx context.Context, listenerError ListenerError) {
+	callbackURL := pl.config.CurrentCallbacks().Error
+	if callbackURL == "" {
+		return
+	}
+
+	err := pl.sendError(ctx, callbackURL, listenerError)
+	if err != nil {
+		pl.log.WithFields(logrus.Fields{"err": err, "type": listenerError.Type}).Error("Error sending listener error to error callback")
+	}
+}
+
+func (pl *PaymentListener) sendError(ctx context.Context, callbackURL string, listenerError ListenerError) error {
+	signer, err := newSigner(pl.config)
+	if err != nil {
+		return err
+	}
+
+	resp, err := postForm(ctx, pl.client, signer, callbackURL, listenerError.ToValues(pl.config.Tenant))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("error callback response status code indicates error (%d)", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package listener
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListenerError(t *testing.T) {
+	var received []url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		require.NoError(t, req.ParseForm())
+		received = append(received, req.PostForm)
+	}))
+	defer srv.Close()
+
+	accountID := "GATKP6ZQM5CSLECPMTAC5226PE367QALCPM6AFHTSULPPZMT62OOPMQB"
+	c := &config.Config{Tenant: "acme"}
+	c.Accounts.ReceivingAccountID = accountID
+
+	mockRepository := new(mocks.MockRepository)
+	pl, err := NewPaymentListener(c, new(mocks.MockEntityManager), new(mocks.MockHorizon), mockRepository, time.Now)
+	require.NoError(t, err)
+
+	// Not sent when the callback is not set
+	pl.notifyError(context.Background(), ListenerError{Type: ListenerErrorFailing, AccountID: accountID})
+	assert.Empty(t, received)
+
+	c.Callbacks.Error = srv.URL
+	pl.notifyError(context.Background(), ListenerError{
+		Type:        ListenerErrorFailing,
+		AccountID:   accountID,
+		Cursor:      "12345",
+		OperationID: "12346",
+		Failures:    listenerErrorThreshold,
+		Err:         errors.New("callback failed"),
+	})
+	require.Len(t, received, 1)
+	assert.Equal(t, url.Values{
+		"type":         {"listener_failing"},
+		"tenant":       {"acme"},
+		"account_id":   {accountID},
+		"cursor":       {"12345"},
+		"operation_id": {"12346"},
+		"failures":     {"5"},
+		"error":        {"callback failed"},
+	}, received[0])
+
+	// Stream stops when the cursor cannot be loaded
+	mockRepository.On("GetLastCursorValue", accountID).Return((*string)(nil), errors.New("db down")).Once()
+	pl.stream(accountID)
+	require.Len(t, received, 2)
+	assert.Equal(t, "listener_stopped", received[1].Get("type"))
+	assert.Equal(t, "db down", received[1].Get("error"))
+	assert.Equal(t, "", received[1].Get("operation_id"))
+	mockRepository.AssertExpectations(t)
+}
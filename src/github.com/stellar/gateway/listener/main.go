@@ -0,0 +1,141 @@
+// Package listener listens for Stellar network events concerning bridge server accounts
+// and notifies callbacks about them.
+package listener
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/protocols/signature"
+	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/support/errors"
+)
+
+// HTTP represents an http client that a listener can use to make HTTP
+// requests.
+type HTTP interface {
+	Do(req *http.Request) (resp *http.Response, err error)
+}
+
+// payloadVersionHeader advertises version of the callback payload
+const payloadVersionHeader = "X-Payload-Version"
+
+// Delays before reconnecting to Horizon streams after errors
+const (
+	minReconnectDelay = time.Second
+	maxReconnectDelay = time.Minute
+)
+
+// reconnectDelay returns delay before reconnecting to a stream after a given number of
+// consecutive failures. The delay is doubled after every failure.
+func reconnectDelay(failures int) time.Duration {
+	delay := minReconnectDelay
+	for i := 1; i < failures && delay < maxReconnectDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > maxReconnectDelay {
+		delay = maxReconnectDelay
+	}
+	return delay
+}
+
+// Signer contains keys authenticating callback requests. X_PAYLOAD_MAC header is added when
+// MACKey is set and X-Payload-Signature header (payload signature v2) when Keys are set.
+type Signer struct {
+	MACKey string
+	Keys   []signature.Key
+}
+
+// newSigner creates a Signer using `mac_key` and `signing_keys` config params
+func newSigner(c *config.Config) (Signer, error) {
+	keys, err := c.SignatureKeys()
+	if err != nil {
+		return Signer{}, errors.Wrap(err, "invalid signing key")
+	}
+	return Signer{MACKey: c.MACKey, Keys: keys}, nil
+}
+
+// sign adds authentication headers of body to req
+func (s Signer) sign(req *http.Request, body []byte) error {
+	if s.MACKey != "" {
+		rawMAC, err := getMAC(s.MACKey, body)
+		if err != nil {
+			return errors.Wrap(err, "getMAC failed")
+		}
+
+		encMAC := base64.StdEncoding.EncodeToString(rawMAC)
+		req.Header.Set("X_PAYLOAD_MAC", encMAC)
+	}
+
+	if len(s.Keys) > 0 {
+		req.Header.Set(signature.Header, signature.Sign(body, time.Now(), s.Keys...))
+	}
+
+	return nil
+}
+
+// sendCallback sends a callback payload to url: form when version is config.PayloadVersion1 and
+// JSON-encoded payload when version is config.PayloadVersion2. Headers in header are added
+// to the request. The request is cancelled when ctx is done.
+func sendCallback(ctx context.Context, client HTTP, signer Signer, url string, version int, form url.Values, payload interface{}, header http.Header) (*http.Response, error) {
+	if version == config.PayloadVersion2 {
+		body, err := json.Marshal(payload)
+		if err != nil {
+			return nil, errors.Wrap(err, "marshal payload failed")
+		}
+		return post(ctx, client, signer, url, "application/json", body, version, header)
+	}
+
+	return post(ctx, client, signer, url, "application/x-www-form-urlencoded", []byte(form.Encode()), config.PayloadVersion1, header)
+}
+
+// postForm sends form to url with authentication headers of signer. The request is cancelled
+// when ctx is done.
+func postForm(ctx context.Context, client HTTP, signer Signer, url string, form url.Values) (*http.Response, error) {
+	return post(ctx, client, signer, url, "application/x-www-form-urlencoded", []byte(form.Encode()), config.PayloadVersion1, nil)
+}
+
+func post(ctx context.Context, client HTTP, signer Signer, url, contentType string, body []byte, version int, header http.Header) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrap(err, "configure http request failed")
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set(payloadVersionHeader, strconv.Itoa(version))
+	for name := range header {
+		req.Header.Set(name, header.Get(name))
+	}
+
+	err = signer.sign(req, body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "http request errored")
+	}
+
+	return resp, nil
+}
+
+func getMAC(key string, raw []byte) ([]byte, error) {
+	rawkey, err := strkey.Decode(strkey.VersionByteSeed, key)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid MAC key")
+	}
+
+	macer := hmac.New(sha256.New, rawkey)
+	macer.Write(raw)
+	return macer.Sum(nil), nil
+}
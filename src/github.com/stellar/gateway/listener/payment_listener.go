@@ -1,48 +1,74 @@
 package listener
 
 import (
-	"crypto/hmac"
-	"crypto/sha256"
+	"context"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"path"
 	"strconv"
 	"strings"
 	"time"
 
-	"encoding/base64"
-
 	"github.com/Sirupsen/logrus"
 	"github.com/stellar/gateway/bridge/config"
 	"github.com/stellar/gateway/db"
 	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/events"
 	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/metrics"
+	"github.com/stellar/gateway/protocols"
+	"github.com/stellar/gateway/protocols/amount"
+	"github.com/stellar/gateway/protocols/bridge"
 	"github.com/stellar/gateway/protocols/compliance"
+	"github.com/stellar/gateway/protocols/federation"
 	"github.com/stellar/gateway/protocols/memo"
-	"github.com/stellar/go/strkey"
+	"github.com/stellar/gateway/protocols/muxed"
+	"github.com/stellar/gateway/protocols/stellartoml"
+	"github.com/stellar/gateway/rates"
+	"github.com/stellar/gateway/tracing"
 	"github.com/stellar/go/support/errors"
 )
 
+// paymentTypes are types of operations processed as received payments. Horizon 1.0+
+// (protocol 12) sends path payments as path_payment_strict_receive and path_payment_strict_send.
+var paymentTypes = map[string]bool{
+	"payment":                     true,
+	"path_payment":                true,
+	"path_payment_strict_receive": true,
+	"path_payment_strict_send":    true,
+	"create_claimable_balance":    true,
+}
+
 // PaymentListener is listening for a new payments received by ReceivingAccount
 type PaymentListener struct {
 	client        HTTP
 	config        *config.Config
 	entityManager db.EntityManagerInterface
+	federation    federation.ResolverInterface
 	horizon       horizon.HorizonInterface
 	log           *logrus.Entry
+	publisher     events.Publisher
+	rates         rates.ProviderInterface
 	repository    db.RepositoryInterface
+	sender        CallbackSender
 	now           func() time.Time
+	drainer       *drainer
+	lease         *listenerLease
+	// instanceID identifies this instance holding the listener lease
+	instanceID string
+	// complianceClient sends requests to the compliance server when `compliance_tls` is
+	// configured, client is used when it's nil
+	complianceClient HTTP
+	// ctx is a context of streams, callback retries and payments processing. It's cancelled
+	// by Wait so Horizon requests and callbacks still in progress at shutdown are cancelled.
+	ctx    context.Context
+	cancel context.CancelFunc
 }
 
-// HTTP represents an http client that a payment listener can use to make HTTP
-// requests.
-type HTTP interface {
-	Do(req *http.Request) (resp *http.Response, err error)
-}
-
-const callbackTimeout = 60 * time.Second
-
 // NewPaymentListener creates a new PaymentListener
 func NewPaymentListener(
 	config *config.Config,
@@ -52,77 +78,218 @@ func NewPaymentListener(
 	now func() time.Time,
 ) (pl PaymentListener, err error) {
 	pl.client = &http.Client{
-		Timeout: callbackTimeout,
+		Timeout: config.Timeouts.CallbacksTimeout(),
+	}
+	if config.ComplianceTLS.Enabled() {
+		pl.complianceClient, err = config.ComplianceTLS.NewClient(config.Timeouts.ComplianceTimeout())
+		if err != nil {
+			return
+		}
 	}
 	pl.config = config
+	pl.sender, err = NewCallbackSender(pl.client, config)
+	if err != nil {
+		return
+	}
+	if config.CallbackRetry.MaxPerHost > 0 {
+		pl.sender = &HostLimitedCallbackSender{Sender: pl.sender, MaxPerHost: config.CallbackRetry.MaxPerHost}
+	}
 	pl.entityManager = entityManager
 	pl.horizon = horizon
 	pl.repository = repository
 	pl.now = now
+	pl.drainer = &drainer{}
+	pl.ctx, pl.cancel = context.WithCancel(context.Background())
+	pl.lease = &listenerLease{streaming: make(map[string]bool)}
+	pl.instanceID = config.ListenerLease.InstanceID
+	if pl.instanceID == "" {
+		pl.instanceID = defaultInstanceID()
+	}
 	pl.log = logrus.WithFields(logrus.Fields{
 		"service": "PaymentListener",
 	})
+	if config.Tenant != "" {
+		pl.log = pl.log.WithField("tenant", config.Tenant)
+	}
+
+	if config.ExchangeRates.URL != "" {
+		pl.rates = rates.NewHTTPProvider(
+			config.ExchangeRates.URL,
+			config.ExchangeRates.Currency,
+			time.Duration(config.ExchangeRates.CacheTTL)*time.Second,
+		)
+	}
+
+	if config.ReverseFederation {
+		pl.federation = &federation.Resolver{StellarTomlResolver: &stellartoml.Resolver{}}
+	}
+
+	if config.PubSub.Topic != "" {
+		pl.publisher, err = events.NewPubSubPublisher(
+			config.PubSub.Project,
+			config.PubSub.Topic,
+			config.PubSub.CredentialsFile,
+		)
+	}
 	return
 }
 
-// Listen starts listening for new payments
+// Listen starts listening for new payments received by all receiving accounts. Every account
+// is streamed separately and has its own cursor.
 func (pl *PaymentListener) Listen() (err error) {
-	accountID := pl.config.Accounts.ReceivingAccountID
+	accounts := pl.config.Accounts.ReceivingAccounts()
+	for _, accountID := range accounts {
+		_, err = pl.horizon.LoadAccount(pl.ctx, accountID)
+		if err != nil {
+			return
+		}
+	}
 
-	_, err = pl.horizon.LoadAccount(accountID)
-	if err != nil {
-		return
+	// Only the instance holding the lease streams payments when the lease is enabled
+	if pl.config.ListenerLease.Enabled {
+		go pl.lead()
+	} else {
+		pl.startStreams()
+	}
+
+	// Requeued dead letters are sent by the retry worker also when retries are disabled
+	if pl.config.MaxCallbackAttempts() > 0 {
+		go pl.retryCallbacks()
 	}
 
-	go func() {
-		for {
-			cursor, err := pl.repository.GetLastCursorValue()
+	return
+}
+
+// stream streams payments received by accountID until the listener is stopped (or this
+// instance loses the listener lease), reconnecting when the stream is closed
+func (pl *PaymentListener) stream(accountID string) {
+	defer pl.streamStopped(accountID)
+	ctx := pl.ctx
+
+	// Paging token of the last processed payment. Streaming is resumed from it after
+	// reconnecting, also when the stream was started with `now` cursor.
+	var cursor string
+	var failures int
+	for !pl.drainer.isStopped() && pl.isLeader() {
+		if cursor == "" {
+			lastCursor, err := pl.repository.GetLastCursorValue(accountID)
 			if err != nil {
 				pl.log.WithFields(logrus.Fields{"error": err}).Error("Could not load last cursor from the DB")
+				pl.notifyError(ctx, ListenerError{Type: ListenerErrorStopped, AccountID: accountID, Failures: failures, Err: err})
 				return
 			}
 
-			var cursorValue string
-			if cursor != nil {
-				cursorValue = *cursor
+			if lastCursor != nil {
+				cursor = *lastCursor
 			} else {
 				// If no last cursor saved set it to: `now`
-				cursorValue = "now"
-				cursor = &cursorValue
+				cursor = "now"
 			}
+		}
 
-			pl.log.WithFields(logrus.Fields{
-				"accountId": accountID,
-				"cursor":    cursorValue,
-			}).Info("Started listening for new payments")
-
-			err = pl.horizon.StreamPayments(
-				accountID,
-				cursor,
-				pl.onPayment,
-			)
-			if err != nil {
-				pl.log.Error("Error while streaming: ", err)
-				pl.log.Info("Sleeping...")
-				time.Sleep(10 * time.Second)
+		pl.log.WithFields(logrus.Fields{
+			"accountId": accountID,
+			"cursor":    cursor,
+		}).Info("Started listening for new payments")
+
+		streamCursor := cursor
+		// ID of the payment which processing failed and closed the stream
+		var failedOperationID string
+		var handler horizon.PaymentHandler = func(payment horizon.PaymentResponse) error {
+			// Payment will be processed again after restart (or by the new lease holder) as
+			// cursor is not moved
+			if !pl.isLeader() || !pl.drainer.begin() {
+				return horizon.ErrStopStreaming
+			}
+			defer pl.drainer.done()
+
+			err := pl.onPayment(ctx, accountID, payment)
+			if err == nil {
+				cursor = payment.PagingToken
+				failures = 0
+			} else {
+				failedOperationID = payment.ID
 			}
-			pl.log.Info("Streaming connection closed. Restarting...")
+			return err
 		}
-	}()
 
-	return
+		var last *paymentTurn
+		if pl.config.ListenerWorkers > 1 {
+			handler = pl.concurrentHandler(ctx, accountID, make(chan struct{}, pl.config.ListenerWorkers), &last)
+		}
+
+		var err error
+		if pl.config.ClaimableBalances {
+			err = pl.horizon.StreamOperations(ctx, accountID, &streamCursor, handler)
+		} else {
+			err = pl.horizon.StreamPayments(ctx, accountID, &streamCursor, handler)
+		}
+
+		// Payments being processed by workers are finished before reconnecting so they are
+		// not streamed again
+		if last != nil && last.wait() {
+			cursor = last.pagingToken
+			failures = 0
+		}
+		if err == horizon.ErrStopStreaming || ctx.Err() != nil {
+			break
+		} else if err != nil {
+			failures++
+			delay := reconnectDelay(failures)
+			pl.log.WithFields(logrus.Fields{"err": err, "delay": delay, "accountId": accountID}).Error("Error while streaming")
+			if failures == listenerErrorThreshold {
+				pl.notifyError(ctx, ListenerError{
+					Type:        ListenerErrorFailing,
+					AccountID:   accountID,
+					Cursor:      cursor,
+					OperationID: failedOperationID,
+					Failures:    failures,
+					Err:         err,
+				})
+			}
+			select {
+			case <-ctx.Done():
+			case <-time.After(delay):
+			}
+		} else {
+			failures = 0
+		}
+		pl.log.WithFields(logrus.Fields{"accountId": accountID}).Info("Streaming connection closed. Restarting...")
+	}
 }
 
-func (pl *PaymentListener) onPayment(payment horizon.PaymentResponse) (err error) {
-	pl.log.WithFields(logrus.Fields{"id": payment.ID}).Info("New received payment")
+// Stop stops processing new payments and callback retries. Payments received after Stop
+// are processed when the server is started again.
+func (pl *PaymentListener) Stop() {
+	pl.drainer.stop()
+}
 
-	id, err := strconv.ParseInt(payment.ID, 10, 64)
-	if err != nil {
-		pl.log.WithFields(logrus.Fields{"err": err}).Error("Error converting ID to int64")
-		return err
+// Wait waits until payments and callback retries being processed when Stop was called are
+// saved (and their callbacks delivered). Cursor of the stream is persisted with saved payments.
+// The listener lease is then released. It returns false when timeout passes first. Streams,
+// Horizon requests and callbacks still in progress are cancelled when Wait returns.
+func (pl *PaymentListener) Wait(timeout time.Duration) bool {
+	defer pl.cancel()
+
+	if !pl.drainer.wait(timeout) {
+		return false
 	}
 
-	existingPayment, err := pl.repository.GetReceivedPaymentByID(id)
+	pl.releaseLease()
+	return true
+}
+
+// onPayment processes a payment streamed for receivingAccount
+func (pl *PaymentListener) onPayment(ctx context.Context, receivingAccount string, payment horizon.PaymentResponse) error {
+	return pl.onPaymentInTurn(ctx, receivingAccount, payment, nil)
+}
+
+// onPaymentInTurn processes a payment streamed for receivingAccount. When turn is not nil,
+// the payment is saved after all earlier payments of the stream are done.
+func (pl *PaymentListener) onPaymentInTurn(ctx context.Context, receivingAccount string, payment horizon.PaymentResponse, turn *paymentTurn) (err error) {
+	pl.log.WithFields(logrus.Fields{"id": payment.ID}).Info("New received payment")
+
+	existingPayment, err := pl.repository.GetReceivedPaymentByOperationID(payment.ID)
 	if err != nil {
 		pl.log.WithFields(logrus.Fields{"err": err}).Error("Error checking if receive payment exists")
 		return err
@@ -134,59 +301,358 @@ func (pl *PaymentListener) onPayment(payment horizon.PaymentResponse) (err error
 	}
 
 	dbPayment := entities.ReceivedPayment{
-		OperationID: payment.ID,
-		ProcessedAt: pl.now(),
-		PagingToken: payment.PagingToken,
+		OperationID:      payment.ID,
+		ProcessedAt:      pl.now(),
+		PagingToken:      payment.PagingToken,
+		Tenant:           pl.config.Tenant,
+		ReceivingAccount: receivingAccount,
 	}
 
+	return pl.processPayment(ctx, payment, &dbPayment, true, turn)
+}
+
+// Reprocess loads operation of a stored payment from Horizon and processes it again as if
+// it was just received: memo, exchange rate and sender are loaded again, the receive callback
+// is sent and payment is saved with a new status. Failed callbacks are not queued for retries.
+func (pl *PaymentListener) Reprocess(ctx context.Context, dbPayment *entities.ReceivedPayment) error {
+	payment, err := pl.horizon.LoadOperation(ctx, dbPayment.OperationID)
+	if err != nil {
+		return errors.Wrap(err, "cannot load operation")
+	}
+
+	pl.log.WithFields(logrus.Fields{"id": payment.ID}).Info("Reprocessing received payment")
+
+	dbPayment.ProcessedAt = pl.now()
+	dbPayment.ExchangeRate = nil
+	dbPayment.ConvertedAmount = nil
+	dbPayment.ConvertedCurrency = nil
+	dbPayment.FromAddress = nil
+	return pl.processPayment(ctx, payment, dbPayment, false, nil)
+}
+
+// processPayment processes a payment and saves it. When handleFailure is true and the receive
+// callback fails, the failure is handled by callbackFailed instead of returning an error.
+// When turn is not nil the payment is saved after all earlier payments are done.
+func (pl *PaymentListener) processPayment(ctx context.Context, payment horizon.PaymentResponse, dbPayment *entities.ReceivedPayment, handleFailure bool, turn *paymentTurn) (err error) {
+	span := tracing.Start("payment_listener.process_payment", tracing.KindInternal)
+	span.SetAttribute("operation_id", payment.ID)
+	defer func() {
+		span.SetAttribute("status", dbPayment.Status)
+		span.End(err)
+	}()
+
 	savePayment := func(payment *entities.ReceivedPayment) (err error) {
+		if !turn.waitPrevious() {
+			return errTurnAborted
+		}
+		saveSpan := span.Child("db.save_received_payment", tracing.KindInternal)
 		err = pl.entityManager.Persist(payment)
+		saveSpan.End(err)
+		return
+	}
+
+	// Horizon streams operations of failed transactions when asked to include them
+	if payment.TransactionSuccessful != nil && !*payment.TransactionSuccessful {
+		dbPayment.Status = "Transaction failed"
+		savePayment(dbPayment)
 		return
 	}
 
-	if payment.Type != "payment" && payment.Type != "path_payment" {
+	if payment.Type == "claim_claimable_balance" && pl.config.Accounts.IsReceivingAccount(payment.Claimant) {
+		dbPayment.Status = "Claimable balance claimed"
+		savePayment(dbPayment)
+		return
+	}
+
+	if payment.Type == "create_claimable_balance" {
+		err = pl.loadClaimableBalance(ctx, &payment)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !paymentTypes[payment.Type] {
 		dbPayment.Status = "Not a payment operation"
-		savePayment(&dbPayment)
+		savePayment(dbPayment)
 		return
 	}
 
-	if payment.To != pl.config.Accounts.ReceivingAccountID {
+	muxedID := pl.resolveMuxedDestination(&payment)
+
+	if !pl.config.Accounts.IsReceivingAccount(payment.To) {
 		dbPayment.Status = "Operation sent not received"
-		savePayment(&dbPayment)
+		savePayment(dbPayment)
 		return nil
 	}
+	dbPayment.ReceivingAccount = payment.To
+
+	defer func() {
+		if err == nil {
+			metrics.AddCounter("payments_received", 1, metrics.Tags{"status": dbPayment.Status, "tenant": pl.config.Tenant})
+		}
+	}()
+
+	// Callbacks always receive amounts in canonical form with 7 digits after the decimal point
+	if normalizedAmount, normalizeErr := amount.Normalize(payment.Amount); normalizeErr == nil {
+		payment.Amount = normalizedAmount
+	} else {
+		pl.log.WithFields(logrus.Fields{"amount": payment.Amount, "err": normalizeErr}).Warn("Cannot normalize amount")
+	}
+
+	dbPayment.FromAccount = payment.From
+	dbPayment.Amount = payment.Amount
+	dbPayment.AssetCode = payment.AssetCode
+	dbPayment.AssetIssuer = payment.AssetIssuer
 
-	if !pl.isAssetAllowed(payment.AssetCode, payment.AssetIssuer) {
+	if !pl.config.AssetFilter().Allows(payment.AssetCode, payment.AssetIssuer) {
 		dbPayment.Status = "Asset not allowed"
-		savePayment(&dbPayment)
+		savePayment(dbPayment)
 		return nil
 	}
 
-	err = pl.horizon.LoadMemo(&payment)
+	loadSpan := span.Child("horizon.load_memo", tracing.KindClient)
+	err = pl.horizon.LoadMemo(ctx, &payment)
+	loadSpan.End(err)
 	if err != nil {
 		pl.log.Error("Unable to load transaction memo")
 		return err
 	}
+	pl.setTransaction(dbPayment, payment)
+
+	// Payments to muxed accounts identify customers the same way as memo ID payments
+	if muxedID != "" {
+		if payment.Memo.Type != "" && payment.Memo.Type != "none" {
+			pl.log.WithFields(logrus.Fields{"id": payment.ID, "memo_type": payment.Memo.Type}).Warn("Payment to muxed account has a memo. Memo is replaced with muxed account ID")
+		}
+		payment.Memo.Type = "id"
+		payment.Memo.Value = muxedID
+	}
+
+	dbPayment.MemoType = payment.Memo.Type
+	dbPayment.Memo = payment.Memo.Value
+
+	if !pl.config.MemoFilter.Allows(payment.Memo.Type, payment.Memo.Value) {
+		dbPayment.Status = entities.ReceivedPaymentStatusMemoFiltered
+		return savePayment(dbPayment)
+	}
+
+	if pl.isDust(payment) {
+		dbPayment.Status = entities.ReceivedPaymentStatusDustSkipped
+		return savePayment(dbPayment)
+	}
+
+	if pl.rates != nil {
+		pl.convertAmount(dbPayment, payment)
+	}
+
+	if pl.federation != nil {
+		pl.resolveSender(ctx, dbPayment, payment)
+	}
+
+	callbackSpan := span.Child("receive_callback", tracing.KindClient)
+	callbackValues, err := pl.sendReceiveCallback(ctx, payment, dbPayment, false)
+	callbackSpan.End(err)
+	if err != nil {
+		if handleFailure {
+			if !turn.waitPrevious() {
+				return errTurnAborted
+			}
+			return pl.callbackFailed(ctx, dbPayment, err)
+		}
+		return err
+	}
+
+	err = pl.publishReceived(payment, dbPayment, callbackValues)
+	if err != nil {
+		return err
+	}
+
+	dbPayment.Status = entities.ReceivedPaymentStatusSuccess
+	err = savePayment(dbPayment)
+	if err != nil {
+		pl.log.Error("Error saving payment to the DB")
+		return err
+	}
+
+	return nil
+}
+
+// loadClaimableBalance sets payment fields of create_claimable_balance operation so it can be
+// processed like a payment: From is a creator of the balance and To is the first receiving account
+// that is one of claimants. Balance ID is loaded only for balances a receiving account can claim.
+func (pl *PaymentListener) loadClaimableBalance(ctx context.Context, payment *horizon.PaymentResponse) error {
+	payment.From = payment.SourceAccount
+	payment.FromMuxed = payment.SourceAccountMuxed
+	payment.FromMuxedID = payment.SourceAccountMuxedID
+	payment.To = ""
+	for _, accountID := range pl.config.Accounts.ReceivingAccounts() {
+		if payment.IsClaimant(accountID) {
+			payment.To = accountID
+			break
+		}
+	}
+	if payment.To == "" {
+		return nil
+	}
+
+	if payment.Asset == "native" {
+		payment.AssetType = "native"
+	} else if parts := strings.SplitN(payment.Asset, ":", 2); len(parts) == 2 {
+		payment.AssetCode = parts[0]
+		payment.AssetIssuer = parts[1]
+	}
+
+	err := pl.horizon.LoadClaimableBalanceID(ctx, payment)
+	if err != nil {
+		pl.log.WithFields(logrus.Fields{"err": err, "id": payment.ID}).Error("Unable to load claimable balance ID")
+		return err
+	}
+	return nil
+}
+
+// resolveMuxedDestination sets To of a payment sent to a muxed account to the underlying
+// account ID (and ToMuxed, ToMuxedID to muxed address and ID) and returns muxed account ID.
+// It returns an empty string for other payments.
+func (pl *PaymentListener) resolveMuxedDestination(payment *horizon.PaymentResponse) string {
+	if payment.ToMuxedID != "" {
+		return payment.ToMuxedID
+	}
+
+	if !muxed.IsMuxed(payment.To) {
+		return ""
+	}
+
+	accountID, id, err := muxed.Decode(payment.To)
+	if err != nil {
+		pl.log.WithFields(logrus.Fields{"to": payment.To, "err": err}).Warn("Cannot decode muxed address")
+		return ""
+	}
+
+	payment.ToMuxed = payment.To
+	payment.ToMuxedID = strconv.FormatUint(id, 10)
+	payment.To = accountID
+	return payment.ToMuxedID
+}
+
+// ResendCallback sends receive callback of a payment stored in the DB again, regardless of its status.
+// Stored exchange rate and sender address are sent; they are not fetched again.
+func (pl *PaymentListener) ResendCallback(ctx context.Context, dbPayment *entities.ReceivedPayment) error {
+	if dbPayment.FromAccount == "" {
+		return errors.New("Payment details are not stored")
+	}
+
+	payment := pl.storedPayment(dbPayment)
+
+	pl.log.WithFields(logrus.Fields{"id": payment.ID}).Info("Resending receive callback")
+	// Callback is sent even when it has already been delivered
+	_, err := pl.sendReceiveCallback(ctx, payment, dbPayment, true)
+	return err
+}
+
+// storedPayment recreates horizon payment from details of a payment stored in the DB
+func (pl *PaymentListener) storedPayment(dbPayment *entities.ReceivedPayment) horizon.PaymentResponse {
+	payment := horizon.PaymentResponse{
+		ID:          dbPayment.OperationID,
+		PagingToken: dbPayment.PagingToken,
+		Type:        "payment",
+		From:        dbPayment.FromAccount,
+		To:          dbPayment.ReceivingAccount,
+		AssetCode:   dbPayment.AssetCode,
+		AssetIssuer: dbPayment.AssetIssuer,
+		Amount:      dbPayment.Amount,
+	}
+	if payment.To == "" {
+		payment.To = pl.config.Accounts.ReceivingAccountID
+	}
+	payment.Memo.Type = dbPayment.MemoType
+	payment.Memo.Value = dbPayment.Memo
+
+	if dbPayment.TransactionHash != nil {
+		payment.TransactionHash = *dbPayment.TransactionHash
+		payment.Transaction.Hash = *dbPayment.TransactionHash
+	}
+	if dbPayment.Ledger != nil {
+		payment.Transaction.Ledger = uint64(*dbPayment.Ledger)
+	}
+	if dbPayment.LedgerCloseTime != nil {
+		payment.Transaction.CreatedAt = dbPayment.LedgerCloseTime.UTC().Format(time.RFC3339)
+	}
+	if dbPayment.FeeCharged != nil {
+		payment.Transaction.FeeCharged = json.Number(strconv.FormatInt(*dbPayment.FeeCharged, 10))
+	}
+	if dbPayment.TransactionSourceAccount != nil {
+		payment.Transaction.SourceAccount = *dbPayment.TransactionSourceAccount
+	}
+	return payment
+}
+
+// publishReceived publishes received payment event when publisher is configured
+func (pl *PaymentListener) publishReceived(payment horizon.PaymentResponse, dbPayment *entities.ReceivedPayment, callbackValues url.Values) error {
+	if pl.publisher == nil {
+		return nil
+	}
+
+	err := pl.publisher.Publish(events.Event{
+		Type:    events.TypePaymentReceived,
+		Tenant:  pl.config.Tenant,
+		Payload: newReceiveCallback(payment, dbPayment, callbackValues),
+	})
+	if err != nil {
+		pl.log.WithFields(logrus.Fields{"err": err}).Error("Error publishing received payment")
+	}
+	return err
+}
 
+// sendReceiveCallback sends payment to the receive callback (or callback URL of the customer
+// the payment belongs to) or publishes it to a message broker and returns version 1 callback
+// values. Error is returned when the callback cannot be delivered. Callbacks that have already
+// been acknowledged are not sent again unless redeliver is true.
+func (pl *PaymentListener) sendReceiveCallback(ctx context.Context, payment horizon.PaymentResponse, dbPayment *entities.ReceivedPayment, redeliver bool) (url.Values, error) {
 	var receiveResponse compliance.ReceiveResponse
 	var route string
 
-	// Request extra_memo from compliance server
-	if pl.config.Compliance != "" && payment.Memo.Type == "hash" {
-		resp, err := pl.postForm(
+	sep31Transaction, err := pl.sep31Transaction(payment)
+	if err != nil {
+		pl.log.WithFields(logrus.Fields{"err": err}).Error("Error getting SEP-31 transaction")
+		return nil, err
+	}
+
+	var withdrawal *entities.TransferTransaction
+	if sep31Transaction == nil {
+		withdrawal, err = pl.withdrawal(ctx, payment)
+		if err != nil {
+			pl.log.WithFields(logrus.Fields{"err": err}).Error("Error getting withdrawal")
+			return nil, err
+		}
+	}
+
+	if sep31Transaction != nil {
+		// SEP-31 transaction memos are not known to the compliance server
+		route = sep31Transaction.ReceiverID
+	} else if withdrawal != nil {
+		// Withdrawal memos are not known to the compliance server either
+		route = withdrawal.PublicID
+	} else if pl.config.Compliance != "" && payment.Memo.Type == "hash" {
+		// Request extra_memo from compliance server
+		complianceClient := pl.client
+		if pl.complianceClient != nil {
+			complianceClient = pl.complianceClient
+		}
+		resp, err := pl.postFormUsing(ctx,
+			complianceClient,
 			pl.config.Compliance+"/receive",
 			url.Values{"memo": {string(payment.Memo.Value)}},
 		)
 		if err != nil {
 			pl.log.WithFields(logrus.Fields{"err": err}).Error("Error sending request to compliance server")
-			return err
+			return nil, err
 		}
 
 		defer resp.Body.Close()
 		body, err := ioutil.ReadAll(resp.Body)
 		if err != nil {
 			pl.log.Error("Error reading compliance server response")
-			return err
+			return nil, err
 		}
 
 		if resp.StatusCode != 200 {
@@ -194,125 +660,513 @@ func (pl *PaymentListener) onPayment(payment horizon.PaymentResponse) (err error
 				"status": resp.StatusCode,
 				"body":   string(body),
 			}).Error("Error response from compliance server")
-			return err
+			return nil, errors.New("Error response from compliance server")
 		}
 
 		err = json.Unmarshal([]byte(body), &receiveResponse)
 		if err != nil {
 			pl.log.WithFields(logrus.Fields{"err": err}).Error("Cannot unmarshal receiveResponse")
-			return err
+			return nil, err
 		}
 
 		var authData compliance.AuthData
 		err = json.Unmarshal([]byte(receiveResponse.Data), &authData)
 		if err != nil {
 			pl.log.WithFields(logrus.Fields{"err": err}).Error("Cannot unmarshal authData")
-			return err
+			return nil, err
 		}
 
 		var memo memo.Memo
 		err = json.Unmarshal([]byte(authData.Memo), &memo)
 		if err != nil {
 			pl.log.WithFields(logrus.Fields{"err": err}).Error("Cannot unmarshal memo")
-			return err
+			return nil, err
 		}
 
 		route = memo.Transaction.Route
-	} else if payment.Memo.Type != "hash" {
+	} else if !bridge.IsBinaryMemo(payment.Memo.Type) {
 		route = payment.Memo.Value
 	}
 
-	resp, err := pl.postForm(
-		pl.config.Callbacks.Receive,
-		url.Values{
-			"id":         {payment.ID},
-			"from":       {payment.From},
-			"route":      {route},
-			"amount":     {payment.Amount},
-			"asset_code": {payment.AssetCode},
-			"memo_type":  {payment.Memo.Type},
-			"memo":       {payment.Memo.Value},
-			"data":       {receiveResponse.Data},
-		},
-	)
-	if err != nil {
-		pl.log.Error("Error sending request to receive callback")
-		return err
+	callbackValues := url.Values{
+		"id":         {payment.ID},
+		"from":       {payment.From},
+		"to":         {payment.To},
+		"route":      {route},
+		"amount":     {payment.Amount},
+		"asset_code": {payment.AssetCode},
+		"asset":      {protocols.Asset{Code: payment.AssetCode, Issuer: payment.AssetIssuer}.String()},
+		"memo_type":  {payment.Memo.Type},
+		"memo":       {payment.Memo.Value},
+		"data":       {receiveResponse.Data},
 	}
 
-	if resp.StatusCode != 200 {
-		defer resp.Body.Close()
-		body, err := ioutil.ReadAll(resp.Body)
+	// Horizon returns values of binary memos encoded using base64
+	if bridge.IsBinaryMemo(payment.Memo.Type) {
+		if hash, ok := bridge.DecodeMemoHash(payment.Memo.Value); ok {
+			callbackValues.Set("memo_hex", hex.EncodeToString(hash[:]))
+		}
+	}
+
+	if sep31Transaction != nil {
+		callbackValues.Set("sep31_transaction_id", sep31Transaction.PublicID)
+		callbackValues.Set("sep31_status", sep31Transaction.Status)
+		callbackValues.Set("sep31_sender_id", sep31Transaction.SenderID)
+		callbackValues.Set("sep31_receiver_id", sep31Transaction.ReceiverID)
+		callbackValues.Set("sep31_amount_fee", sep31Transaction.AmountFee)
+		callbackValues.Set("sep31_amount_out", sep31Transaction.AmountOut)
+		callbackValues.Set("sep31_fields", sep31Transaction.Fields)
+	}
+
+	if withdrawal != nil {
+		callbackValues.Set("withdrawal_id", withdrawal.PublicID)
+		callbackValues.Set("withdrawal_status", withdrawal.Status)
+		callbackValues.Set("withdrawal_account", withdrawal.Account)
+		callbackValues.Set("withdrawal_type", withdrawal.Type)
+		callbackValues.Set("withdrawal_fields", withdrawal.Fields)
+		// Amounts are not known when the payment does not match the withdrawal
+		if withdrawal.AmountOut != nil {
+			callbackValues.Set("withdrawal_amount_fee", *withdrawal.AmountFee)
+			callbackValues.Set("withdrawal_amount_out", *withdrawal.AmountOut)
+		}
+	}
+
+	callbackURL := pl.config.CurrentCallbacks().Receive
+
+	if payment.Memo.Type == "id" || payment.Memo.Type == "text" {
+		customer, err := pl.repository.GetCustomerByMemo(payment.Memo.Type, payment.Memo.Value)
 		if err != nil {
-			pl.log.Error("Error reading receive callback response")
-			return err
+			pl.log.WithFields(logrus.Fields{"err": err}).Error("Error getting customer")
+			return nil, err
 		}
 
-		pl.log.WithFields(logrus.Fields{
-			"status": resp.StatusCode,
-			"body":   string(body),
-		}).Error("Error response from receive callback")
-		return errors.New("Error response from receive callback")
+		if customer != nil {
+			callbackValues.Set("customer_id", customer.CustomerID)
+			if customer.CallbackURL != "" {
+				callbackURL = customer.CallbackURL
+			}
+		}
+	}
+
+	// Payments are only stored for /payments/poll endpoint when callbacks.receive is not set
+	if callbackURL == "" && !pl.config.CurrentCallbacks().ReceiveBroker() {
+		return callbackValues, pl.completeTransfers(ctx, sep31Transaction, withdrawal)
+	}
+
+	if payment.BalanceID != "" {
+		callbackValues.Set("claimable_balance_id", payment.BalanceID)
+	}
+
+	if payment.ToMuxed != "" {
+		callbackValues.Set("to_muxed", payment.ToMuxed)
+		callbackValues.Set("to_muxed_id", payment.ToMuxedID)
+	}
+
+	if payment.FromMuxed != "" {
+		callbackValues.Set("from_muxed", payment.FromMuxed)
+		callbackValues.Set("from_muxed_id", payment.FromMuxedID)
 	}
 
-	dbPayment.Status = "Success"
-	err = savePayment(&dbPayment)
+	if dbPayment.FromAddress != nil {
+		callbackValues.Set("from_address", *dbPayment.FromAddress)
+	}
+
+	if payment.Transaction.Hash != "" {
+		callbackValues.Set("transaction_hash", payment.Transaction.Hash)
+		callbackValues.Set("ledger", strconv.FormatUint(payment.Transaction.Ledger, 10))
+		callbackValues.Set("ledger_close_time", payment.Transaction.CreatedAt)
+		callbackValues.Set("fee_charged", payment.Transaction.FeeCharged.String())
+		callbackValues.Set("transaction_source_account", payment.Transaction.SourceAccount)
+		if payment.Transaction.FeeAccount != "" {
+			callbackValues.Set("fee_account", payment.Transaction.FeeAccount)
+		}
+	}
+
+	if dbPayment.ConvertedAmount != nil {
+		callbackValues.Set("exchange_rate", *dbPayment.ExchangeRate)
+		callbackValues.Set("converted_amount", *dbPayment.ConvertedAmount)
+		callbackValues.Set("converted_currency", *dbPayment.ConvertedCurrency)
+	}
+
+	deliveryID := callbackDeliveryID(payment.ID, callbackURL)
+	if !redeliver {
+		delivered, err := pl.callbackDelivered(deliveryID)
+		if err != nil {
+			return nil, err
+		}
+		if delivered {
+			pl.log.WithFields(logrus.Fields{"id": payment.ID, "delivery_id": deliveryID}).Info("Receive callback already delivered")
+			return callbackValues, pl.completeTransfers(ctx, sep31Transaction, withdrawal)
+		}
+	}
+
+	payload := newReceiveCallback(payment, dbPayment, callbackValues)
+
+	metricsTags := metrics.Tags{"tenant": pl.config.Tenant}
+	start := time.Now()
+	err = pl.sender.Send(ctx, callbackURL, callbackValues, payload)
+	metrics.ObserveDuration("receive_callback_duration_seconds", time.Since(start), metricsTags)
+	pl.recordCallbackAttempt(payment.ID, callbackURL, start, err)
 	if err != nil {
-		pl.log.Error("Error saving payment to the DB")
-		return err
+		metrics.AddCounter("receive_callback_failures", 1, metricsTags)
+		pl.log.WithFields(logrus.Fields{"err": err}).Error("Error sending receive callback")
+		return nil, err
 	}
+	pl.recordCallbackDelivery(deliveryID, payment.ID, callbackURL)
 
-	return nil
+	return callbackValues, pl.completeTransfers(ctx, sep31Transaction, withdrawal)
+}
+
+// sep31Transaction returns SEP-31 transaction the payment belongs to or nil. Details of the
+// payment are saved in transactions waiting for it; when asset or amount of the payment do
+// not match the transaction, its status is set to error.
+func (pl *PaymentListener) sep31Transaction(payment horizon.PaymentResponse) (*entities.Sep31Transaction, error) {
+	if payment.Memo.Type != "hash" || !pl.config.Sep31.Enabled() {
+		return nil, nil
+	}
+
+	transaction, err := pl.repository.GetSep31TransactionByMemo(payment.Memo.Type, payment.Memo.Value)
+	if err != nil || transaction == nil || transaction.Status != entities.Sep31StatusPendingSender {
+		return transaction, err
+	}
+
+	transaction.ReceivedPaymentID = &payment.ID
+	if payment.Links.Transaction.Href != "" {
+		hash := path.Base(payment.Links.Transaction.Href)
+		transaction.StellarTransactionID = &hash
+	}
+
+	received, _ := amount.Parse(payment.Amount)
+	expected, _ := amount.Parse(transaction.AmountIn)
+	if payment.AssetCode != transaction.AssetCode || payment.AssetIssuer != transaction.AssetIssuer || received != expected {
+		message := fmt.Sprintf(
+			"Received %s %s, expected %s %s",
+			payment.Amount,
+			protocols.Asset{Code: payment.AssetCode, Issuer: payment.AssetIssuer}.String(),
+			transaction.AmountIn,
+			protocols.Asset{Code: transaction.AssetCode, Issuer: transaction.AssetIssuer}.String(),
+		)
+		pl.log.WithFields(logrus.Fields{"id": transaction.PublicID, "payment": payment.ID}).Warn("SEP-31 payment does not match transaction: " + message)
+		transaction.Status = entities.Sep31StatusError
+		transaction.StatusMessage = &message
+	} else {
+		transaction.Status = entities.Sep31StatusPendingReceiver
+	}
+
+	return transaction, pl.entityManager.Persist(transaction)
+}
+
+// completeSep31Transaction sets status of a SEP-31 transaction which payment has been
+// delivered to the receiver to completed
+func (pl *PaymentListener) completeSep31Transaction(transaction *entities.Sep31Transaction) error {
+	if transaction == nil || transaction.Status != entities.Sep31StatusPendingReceiver {
+		return nil
+	}
+
+	now := pl.now()
+	transaction.Status = entities.Sep31StatusCompleted
+	transaction.CompletedAt = &now
+	return pl.entityManager.Persist(transaction)
 }
 
-func (pl *PaymentListener) isAssetAllowed(code string, issuer string) bool {
-	for _, asset := range pl.config.Assets {
-		if asset.Code == code && asset.Issuer == issuer {
-			return true
+// withdrawal returns SEP-6 withdrawal the payment belongs to or nil. Details of the payment
+// are saved in withdrawals waiting for it; when asset or amount of the payment do not match the
+// withdrawal (or asset limits when the amount was not given), its status is set to error.
+func (pl *PaymentListener) withdrawal(ctx context.Context, payment horizon.PaymentResponse) (*entities.TransferTransaction, error) {
+	if payment.Memo.Type != "hash" || !pl.config.TransferServer.Enabled() {
+		return nil, nil
+	}
+
+	withdrawal, err := pl.repository.GetWithdrawalByMemo(payment.Memo.Type, payment.Memo.Value)
+	if err != nil || withdrawal == nil || withdrawal.Status != entities.TransferStatusPendingUserTransferStart {
+		return withdrawal, err
+	}
+
+	withdrawal.ReceivedPaymentID = &payment.ID
+	if payment.Links.Transaction.Href != "" {
+		hash := path.Base(payment.Links.Transaction.Href)
+		withdrawal.StellarTransactionID = &hash
+	}
+
+	received, _ := amount.Parse(payment.Amount)
+	var message string
+	if payment.AssetCode != withdrawal.AssetCode || payment.AssetIssuer != withdrawal.AssetIssuer {
+		message = fmt.Sprintf(
+			"Received %s, expected %s",
+			protocols.Asset{Code: payment.AssetCode, Issuer: payment.AssetIssuer}.String(),
+			protocols.Asset{Code: withdrawal.AssetCode, Issuer: withdrawal.AssetIssuer}.String(),
+		)
+	} else if withdrawal.AmountIn != nil {
+		expected, _ := amount.Parse(*withdrawal.AmountIn)
+		if received != expected {
+			message = fmt.Sprintf("Received %s, expected %s", payment.Amount, *withdrawal.AmountIn)
+		}
+	} else if asset := pl.config.TransferServer.AssetFor(withdrawal.AssetCode); asset != nil {
+		fee := asset.Fee(received)
+		minAmount, _ := amount.Parse(asset.MinAmount)
+		maxAmount, _ := amount.Parse(asset.MaxAmount)
+		if (asset.MinAmount != "" && received < minAmount) || (asset.MaxAmount != "" && received > maxAmount) || fee >= received {
+			message = fmt.Sprintf("Received %s is not within the asset limits or it does not cover the fee", payment.Amount)
+		} else {
+			amountIn, amountFee, amountOut := received.String(), fee.String(), (received - fee).String()
+			withdrawal.AmountIn = &amountIn
+			withdrawal.AmountFee = &amountFee
+			withdrawal.AmountOut = &amountOut
 		}
 	}
-	return false
+
+	if message != "" {
+		pl.log.WithFields(logrus.Fields{"id": withdrawal.PublicID, "payment": payment.ID}).Warn("Withdrawal payment does not match withdrawal: " + message)
+		withdrawal.Status = entities.TransferStatusError
+		withdrawal.StatusMessage = &message
+	} else {
+		withdrawal.Status = entities.TransferStatusPendingAnchor
+	}
+
+	err = pl.entityManager.Persist(withdrawal)
+	if err != nil {
+		return withdrawal, err
+	}
+	pl.notifyTransferStatus(ctx, withdrawal)
+	return withdrawal, nil
 }
 
-func (pl *PaymentListener) postForm(
-	url string,
-	form url.Values,
-) (*http.Response, error) {
+// completeTransfers sets status of a SEP-31 transaction or a withdrawal which payment has been
+// delivered to the receiver to completed
+func (pl *PaymentListener) completeTransfers(ctx context.Context, sep31Transaction *entities.Sep31Transaction, withdrawal *entities.TransferTransaction) error {
+	err := pl.completeSep31Transaction(sep31Transaction)
+	if err != nil || withdrawal == nil || withdrawal.Status != entities.TransferStatusPendingAnchor {
+		return err
+	}
 
-	strbody := form.Encode()
+	now := pl.now()
+	withdrawal.Status = entities.TransferStatusCompleted
+	withdrawal.CompletedAt = &now
+	err = pl.entityManager.Persist(withdrawal)
+	if err != nil {
+		return err
+	}
+	pl.notifyTransferStatus(ctx, withdrawal)
+	return nil
+}
 
-	req, err := http.NewRequest("POST", url, strings.NewReader(strbody))
+// notifyTransferStatus sends a new status of a withdrawal to `callbacks.transfer_status`.
+// Errors are only logged, the payment has already been processed.
+func (pl *PaymentListener) notifyTransferStatus(ctx context.Context, withdrawal *entities.TransferTransaction) {
+	err := NotifyTransferStatus(ctx, pl.client, pl.config, withdrawal)
 	if err != nil {
-		return nil, errors.Wrap(err, "configure http request failed")
+		pl.log.WithFields(logrus.Fields{"err": err, "id": withdrawal.PublicID}).Error("Error sending withdrawal status to transfer status callback")
 	}
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+}
 
-	if pl.config.MACKey != "" {
-		rawMAC, err := pl.getMAC(pl.config.MACKey, []byte(strbody))
-		if err != nil {
-			return nil, errors.Wrap(err, "getMAC failed")
+// newReceiveCallback creates version 2 receive callback payload. Values resolved for
+// version 1 payload (route, customer ID) are reused.
+func newReceiveCallback(payment horizon.PaymentResponse, dbPayment *entities.ReceivedPayment, values url.Values) *bridge.ReceiveCallback {
+	payload := &bridge.ReceiveCallback{
+		Version:     config.PayloadVersion2,
+		Event:       bridge.CallbackEventPaymentReceived,
+		ID:          payment.ID,
+		PagingToken: payment.PagingToken,
+		ProcessedAt: dbPayment.ProcessedAt,
+		From:        payment.From,
+		FromMuxed:   payment.FromMuxed,
+		FromMuxedID: payment.FromMuxedID,
+		To:          payment.To,
+		ToMuxed:     payment.ToMuxed,
+		ToMuxedID:   payment.ToMuxedID,
+		Route:       values.Get("route"),
+		Amount:      payment.Amount,
+		Asset:       values.Get("asset"),
+		AssetCode:   payment.AssetCode,
+		AssetIssuer: payment.AssetIssuer,
+		CustomerID:  values.Get("customer_id"),
+		Operation: bridge.CallbackOperation{
+			Type:            payment.Type,
+			SourceAccount:   payment.SourceAccount,
+			TransactionHash: payment.TransactionHash,
+			CreatedAt:       payment.CreatedAt,
+		},
+	}
+	payload.Memo.Type = payment.Memo.Type
+	payload.Memo.Value = payment.Memo.Value
+
+	if payment.Transaction.Hash != "" {
+		payload.Transaction = &bridge.CallbackTransaction{
+			Hash:            payment.Transaction.Hash,
+			Ledger:          payment.Transaction.Ledger,
+			LedgerCloseTime: payment.Transaction.CreatedAt,
+			FeeCharged:      payment.Transaction.FeeCharged.String(),
+			SourceAccount:   payment.Transaction.SourceAccount,
+			FeeAccount:      payment.Transaction.FeeAccount,
+		}
+	}
+
+	if payment.Type == "create_claimable_balance" {
+		payload.Event = bridge.CallbackEventClaimableBalanceCreated
+		payload.ClaimableBalanceID = payment.BalanceID
+	}
+
+	if id := values.Get("sep31_transaction_id"); id != "" {
+		payload.Sep31 = &bridge.CallbackSep31{
+			TransactionID: id,
+			Status:        values.Get("sep31_status"),
+			SenderID:      values.Get("sep31_sender_id"),
+			ReceiverID:    values.Get("sep31_receiver_id"),
+			AmountFee:     values.Get("sep31_amount_fee"),
+			AmountOut:     values.Get("sep31_amount_out"),
+		}
+		if fields := values.Get("sep31_fields"); json.Valid([]byte(fields)) {
+			raw := json.RawMessage(fields)
+			payload.Sep31.Fields = &raw
 		}
+	}
+
+	if id := values.Get("withdrawal_id"); id != "" {
+		payload.Withdrawal = &bridge.CallbackWithdrawal{
+			ID:        id,
+			Status:    values.Get("withdrawal_status"),
+			Account:   values.Get("withdrawal_account"),
+			Type:      values.Get("withdrawal_type"),
+			AmountFee: values.Get("withdrawal_amount_fee"),
+			AmountOut: values.Get("withdrawal_amount_out"),
+		}
+		if fields := values.Get("withdrawal_fields"); json.Valid([]byte(fields)) {
+			raw := json.RawMessage(fields)
+			payload.Withdrawal.Fields = &raw
+		}
+	}
+
+	if data := values.Get("data"); data != "" && json.Valid([]byte(data)) {
+		raw := json.RawMessage(data)
+		payload.Data = &raw
+
+		var authData compliance.AuthData
+		err := json.Unmarshal(raw, &authData)
+		if err == nil && json.Valid([]byte(authData.Memo)) {
+			decoded := json.RawMessage(authData.Memo)
+			payload.Memo.Decoded = &decoded
+		}
+	}
+
+	if dbPayment.FromAddress != nil {
+		payload.FromAddress = *dbPayment.FromAddress
+	}
+
+	if dbPayment.ConvertedAmount != nil {
+		payload.Conversion = &bridge.CallbackConversion{
+			Rate:     *dbPayment.ExchangeRate,
+			Amount:   *dbPayment.ConvertedAmount,
+			Currency: *dbPayment.ConvertedCurrency,
+		}
+	}
+
+	return payload
+}
+
+// setTransaction sets details of the transaction loaded with the payment memo. Fields Horizon
+// did not send are left empty.
+func (pl *PaymentListener) setTransaction(dbPayment *entities.ReceivedPayment, payment horizon.PaymentResponse) {
+	transaction := payment.Transaction
+	if transaction.Hash == "" {
+		return
+	}
+
+	dbPayment.TransactionHash = &transaction.Hash
+	ledger := int64(transaction.Ledger)
+	dbPayment.Ledger = &ledger
 
-		encMAC := base64.StdEncoding.EncodeToString(rawMAC)
-		req.Header.Set("X_PAYLOAD_MAC", encMAC)
+	if closeTime, err := time.Parse(time.RFC3339, transaction.CreatedAt); err == nil {
+		dbPayment.LedgerCloseTime = &closeTime
 	}
 
-	resp, err := pl.client.Do(req)
+	if fee, err := transaction.FeeCharged.Int64(); err == nil {
+		dbPayment.FeeCharged = &fee
+	}
+
+	if transaction.SourceAccount != "" {
+		dbPayment.TransactionSourceAccount = &transaction.SourceAccount
+	}
+}
+
+// isDust returns true when payment amount is below `dust.minimums` of its asset
+func (pl *PaymentListener) isDust(payment horizon.PaymentResponse) bool {
+	minimum, ok := pl.config.DustMinimumFor(payment.AssetCode, payment.AssetIssuer)
+	if !ok {
+		return false
+	}
+
+	value, err := amount.Parse(payment.Amount)
+	if err != nil {
+		pl.log.WithFields(logrus.Fields{"amount": payment.Amount, "err": err}).Warn("Cannot parse amount")
+		return false
+	}
+	return value < minimum
+}
+
+// convertAmount sets exchange rate and converted amount fields of dbPayment. Rates source
+// errors are logged but do not stop the payment from being processed.
+func (pl *PaymentListener) convertAmount(dbPayment *entities.ReceivedPayment, payment horizon.PaymentResponse) {
+	rate, err := pl.rates.GetRate(payment.AssetCode)
 	if err != nil {
-		return nil, errors.Wrap(err, "http request errored")
+		pl.log.WithFields(logrus.Fields{"err": err, "asset_code": payment.AssetCode}).Warn("Cannot get exchange rate")
+		return
+	}
+
+	convertedAmount, err := rates.Convert(payment.Amount, rate)
+	if err != nil {
+		pl.log.WithFields(logrus.Fields{"err": err}).Warn("Cannot convert amount")
+		return
 	}
 
-	return resp, nil
+	currency := pl.rates.Currency()
+	dbPayment.ExchangeRate = &rate
+	dbPayment.ConvertedAmount = &convertedAmount
+	dbPayment.ConvertedCurrency = &currency
 }
 
-func (pl *PaymentListener) getMAC(key string, raw []byte) ([]byte, error) {
+// resolveSender sets federation address of the payment sender using federation server
+// of sender's home domain. Lookup errors are logged but do not stop the payment from being processed.
+func (pl *PaymentListener) resolveSender(ctx context.Context, dbPayment *entities.ReceivedPayment, payment horizon.PaymentResponse) {
+	account, err := pl.horizon.LoadAccount(ctx, payment.From)
+	if err != nil {
+		pl.log.WithFields(logrus.Fields{"err": err, "from": payment.From}).Warn("Cannot load sender account")
+		return
+	}
+
+	if account.HomeDomain == "" {
+		return
+	}
 
-	rawkey, err := strkey.Decode(strkey.VersionByteSeed, pl.config.MACKey)
+	response, err := pl.federation.ReverseResolve(payment.From, account.HomeDomain)
 	if err != nil {
-		return nil, errors.Wrap(err, "invalid MAC key")
+		pl.log.WithFields(logrus.Fields{
+			"err":         err,
+			"from":        payment.From,
+			"home_domain": account.HomeDomain,
+		}).Warn("Cannot resolve sender federation address")
+		return
 	}
 
-	macer := hmac.New(sha256.New, rawkey)
-	macer.Write(raw)
-	return macer.Sum(nil), nil
+	dbPayment.FromAddress = &response.StellarAddress
+}
+
+func (pl *PaymentListener) postForm(
+	ctx context.Context,
+	url string,
+	form url.Values,
+) (*http.Response, error) {
+	return pl.postFormUsing(ctx, pl.client, url, form)
+}
+
+func (pl *PaymentListener) postFormUsing(
+	ctx context.Context,
+	client HTTP,
+	url string,
+	form url.Values,
+) (*http.Response, error) {
+	signer, err := newSigner(pl.config)
+	if err != nil {
+		return nil, err
+	}
+	return postForm(ctx, client, signer, url, form)
 }
@@ -1,6 +1,7 @@
 package listener
 
 import (
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/base64"
@@ -10,6 +11,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"sort"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -19,8 +23,11 @@ import (
 	"github.com/stellar/gateway/horizon"
 	"github.com/stellar/gateway/mocks"
 	"github.com/stellar/gateway/net"
+	"github.com/stellar/gateway/protocols/bridge"
 	"github.com/stellar/gateway/protocols/compliance"
+	"github.com/stellar/gateway/protocols/federation"
 	"github.com/stellar/gateway/protocols/memo"
+	"github.com/stellar/gateway/protocols/signature"
 	"github.com/stellar/go/strkey"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -57,29 +64,33 @@ func TestPaymentListener(t *testing.T) {
 	require.NoError(t, err)
 
 	paymentListener.client = mockHTTPClient
+	paymentListener.sender, err = NewCallbackSender(mockHTTPClient, config)
+	require.NoError(t, err)
+	expectCallbackAttempts(mockEntityManager, mockRepository)
 
 	Convey("PaymentListener", t, func() {
 		operation := horizon.PaymentResponse{
 			ID:          "1",
 			From:        "GBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFZYYQ",
 			PagingToken: "2",
-			Amount:      "200",
+			Amount:      "200.0000000",
 		}
 
 		mocks.PredefinedTime = time.Now()
 
 		dbPayment := entities.ReceivedPayment{
-			OperationID: operation.ID,
-			ProcessedAt: mocks.PredefinedTime,
-			PagingToken: operation.PagingToken,
+			OperationID:      operation.ID,
+			ProcessedAt:      mocks.PredefinedTime,
+			PagingToken:      operation.PagingToken,
+			ReceivingAccount: config.Accounts.ReceivingAccountID,
 		}
 
 		Convey("When operation exists", func() {
 			operation.Type = "payment"
-			mockRepository.On("GetReceivedPaymentByID", int64(1)).Return(&entities.ReceivedPayment{}, nil).Once()
+			mockRepository.On("GetReceivedPaymentByOperationID", "1").Return(&entities.ReceivedPayment{}, nil).Once()
 
 			Convey("it should save the status", func() {
-				err := paymentListener.onPayment(operation)
+				err := paymentListener.onPayment(context.Background(), config.Accounts.ReceivingAccountID, operation)
 				assert.Nil(t, err)
 				mockEntityManager.AssertExpectations(t)
 			})
@@ -89,10 +100,10 @@ func TestPaymentListener(t *testing.T) {
 			operation.Type = "create_account"
 			dbPayment.Status = "Not a payment operation"
 			mockEntityManager.On("Persist", &dbPayment).Return(nil).Once()
-			mockRepository.On("GetReceivedPaymentByID", int64(1)).Return(nil, nil).Once()
+			mockRepository.On("GetReceivedPaymentByOperationID", "1").Return(nil, nil).Once()
 
 			Convey("it should save the status", func() {
-				err := paymentListener.onPayment(operation)
+				err := paymentListener.onPayment(context.Background(), config.Accounts.ReceivingAccountID, operation)
 				assert.Nil(t, err)
 				mockEntityManager.AssertExpectations(t)
 			})
@@ -103,10 +114,10 @@ func TestPaymentListener(t *testing.T) {
 			operation.To = "GDNXBMIJLLLXZYKZBHXJ45WQ4AJQBRVT776YKGQTDBHTSPMNAFO3OZOS"
 			dbPayment.Status = "Operation sent not received"
 			mockEntityManager.On("Persist", &dbPayment).Return(nil).Once()
-			mockRepository.On("GetReceivedPaymentByID", int64(1)).Return(nil, nil).Once()
+			mockRepository.On("GetReceivedPaymentByOperationID", "1").Return(nil, nil).Once()
 
 			Convey("it should save the status", func() {
-				err := paymentListener.onPayment(operation)
+				err := paymentListener.onPayment(context.Background(), config.Accounts.ReceivingAccountID, operation)
 				assert.Nil(t, err)
 				mockEntityManager.AssertExpectations(t)
 			})
@@ -118,11 +129,12 @@ func TestPaymentListener(t *testing.T) {
 			operation.AssetCode = "USD"
 			operation.AssetIssuer = "GC4WWLMUGZJMRVJM7JUVVZBY3LJ5HL4RKIPADEGKEMLAAJEDRONUGYG7"
 			dbPayment.Status = "Asset not allowed"
+			setPaymentDetails(&dbPayment, operation)
 			mockEntityManager.On("Persist", &dbPayment).Return(nil).Once()
-			mockRepository.On("GetReceivedPaymentByID", int64(1)).Return(nil, nil).Once()
+			mockRepository.On("GetReceivedPaymentByOperationID", "1").Return(nil, nil).Once()
 
 			Convey("it should save the status", func() {
-				err := paymentListener.onPayment(operation)
+				err := paymentListener.onPayment(context.Background(), config.Accounts.ReceivingAccountID, operation)
 				assert.Nil(t, err)
 				mockEntityManager.AssertExpectations(t)
 			})
@@ -134,11 +146,12 @@ func TestPaymentListener(t *testing.T) {
 			operation.AssetCode = "GBP"
 			operation.AssetIssuer = "GD4I7AFSLZGTDL34TQLWJOM2NHLIIOEKD5RHHZUW54HERBLSIRKUOXRR"
 			dbPayment.Status = "Asset not allowed"
+			setPaymentDetails(&dbPayment, operation)
 			mockEntityManager.On("Persist", &dbPayment).Return(nil).Once()
-			mockRepository.On("GetReceivedPaymentByID", int64(1)).Return(nil, nil).Once()
+			mockRepository.On("GetReceivedPaymentByOperationID", "1").Return(nil, nil).Once()
 
 			Convey("it should save the status", func() {
-				err := paymentListener.onPayment(operation)
+				err := paymentListener.onPayment(context.Background(), config.Accounts.ReceivingAccountID, operation)
 				assert.Nil(t, err)
 				mockEntityManager.AssertExpectations(t)
 			})
@@ -150,11 +163,11 @@ func TestPaymentListener(t *testing.T) {
 			operation.AssetCode = "USD"
 			operation.AssetIssuer = "GD4I7AFSLZGTDL34TQLWJOM2NHLIIOEKD5RHHZUW54HERBLSIRKUOXRR"
 
-			mockRepository.On("GetReceivedPaymentByID", int64(1)).Return(nil, nil).Once()
+			mockRepository.On("GetReceivedPaymentByOperationID", "1").Return(nil, nil).Once()
 			mockHorizon.On("LoadMemo", &operation).Return(errors.New("Connection error")).Once()
 
 			Convey("it should return error", func() {
-				err := paymentListener.onPayment(operation)
+				err := paymentListener.onPayment(context.Background(), config.Accounts.ReceivingAccountID, operation)
 				assert.Error(t, err)
 				mockHorizon.AssertExpectations(t)
 				mockEntityManager.AssertNotCalled(t, "Persist")
@@ -169,8 +182,9 @@ func TestPaymentListener(t *testing.T) {
 			operation.Memo.Type = "text"
 			operation.Memo.Value = "testing"
 
-			mockRepository.On("GetReceivedPaymentByID", int64(1)).Return(nil, nil).Once()
+			mockRepository.On("GetReceivedPaymentByOperationID", "1").Return(nil, nil).Once()
 			mockHorizon.On("LoadMemo", &operation).Return(nil).Once()
+			mockRepository.On("GetCustomerByMemo", "text", "testing").Return(nil, nil).Once()
 
 			mockHTTPClient.On(
 				"Do",
@@ -183,7 +197,7 @@ func TestPaymentListener(t *testing.T) {
 			).Once()
 
 			Convey("it should save the status", func() {
-				err := paymentListener.onPayment(operation)
+				err := paymentListener.onPayment(context.Background(), config.Accounts.ReceivingAccountID, operation)
 				assert.Error(t, err)
 				mockHorizon.AssertExpectations(t)
 				mockEntityManager.AssertNotCalled(t, "Persist")
@@ -199,9 +213,11 @@ func TestPaymentListener(t *testing.T) {
 			operation.Memo.Value = "testing"
 
 			dbPayment.Status = "Success"
+			setPaymentDetails(&dbPayment, operation)
 
-			mockRepository.On("GetReceivedPaymentByID", int64(1)).Return(nil, nil).Once()
+			mockRepository.On("GetReceivedPaymentByOperationID", "1").Return(nil, nil).Once()
 			mockHorizon.On("LoadMemo", &operation).Return(nil).Once()
+			mockRepository.On("GetCustomerByMemo", "text", "testing").Return(nil, nil).Once()
 			mockEntityManager.On("Persist", &dbPayment).Return(nil).Once()
 
 			mockHTTPClient.On(
@@ -215,9 +231,48 @@ func TestPaymentListener(t *testing.T) {
 			).Once()
 
 			Convey("it should save the status", func() {
-				err := paymentListener.onPayment(operation)
+				err := paymentListener.onPayment(context.Background(), config.Accounts.ReceivingAccountID, operation)
+				assert.Nil(t, err)
+				mockHorizon.AssertExpectations(t)
+				mockEntityManager.AssertExpectations(t)
+			})
+		})
+
+		Convey("When payment memo belongs to a customer", func() {
+			operation.Type = "payment"
+			operation.To = "GATKP6ZQM5CSLECPMTAC5226PE367QALCPM6AFHTSULPPZMT62OOPMQB"
+			operation.AssetCode = "USD"
+			operation.AssetIssuer = "GD4I7AFSLZGTDL34TQLWJOM2NHLIIOEKD5RHHZUW54HERBLSIRKUOXRR"
+			operation.Memo.Type = "id"
+			operation.Memo.Value = "123"
+
+			dbPayment.Status = "Success"
+			setPaymentDetails(&dbPayment, operation)
+
+			mockRepository.On("GetReceivedPaymentByOperationID", "1").Return(nil, nil).Once()
+			mockHorizon.On("LoadMemo", &operation).Return(nil).Once()
+			mockRepository.On("GetCustomerByMemo", "id", "123").Return(&entities.Customer{
+				CustomerID:  "customer-1",
+				CallbackURL: "http://customer_callback",
+			}, nil).Once()
+			mockEntityManager.On("Persist", &dbPayment).Return(nil).Once()
+
+			mockHTTPClient.On(
+				"Do",
+				mock.MatchedBy(func(req *http.Request) bool {
+					return req.URL.String() == "http://customer_callback" &&
+						req.PostFormValue("customer_id") == "customer-1"
+				}),
+			).Return(
+				net.BuildHTTPResponse(200, "ok"),
+				nil,
+			).Once()
+
+			Convey("it should send the callback to customer callback URL", func() {
+				err := paymentListener.onPayment(context.Background(), config.Accounts.ReceivingAccountID, operation)
 				assert.Nil(t, err)
 				mockHorizon.AssertExpectations(t)
+				mockRepository.AssertExpectations(t)
 				mockEntityManager.AssertExpectations(t)
 			})
 		})
@@ -229,8 +284,9 @@ func TestPaymentListener(t *testing.T) {
 			operation.AssetIssuer = "GD4I7AFSLZGTDL34TQLWJOM2NHLIIOEKD5RHHZUW54HERBLSIRKUOXRR"
 
 			dbPayment.Status = "Success"
+			setPaymentDetails(&dbPayment, operation)
 
-			mockRepository.On("GetReceivedPaymentByID", int64(1)).Return(nil, nil).Once()
+			mockRepository.On("GetReceivedPaymentByOperationID", "1").Return(nil, nil).Once()
 			mockHorizon.On("LoadMemo", &operation).Return(nil).Once()
 			mockEntityManager.On("Persist", &dbPayment).Return(nil).Once()
 
@@ -245,7 +301,7 @@ func TestPaymentListener(t *testing.T) {
 			).Once()
 
 			Convey("it should save the status", func() {
-				err := paymentListener.onPayment(operation)
+				err := paymentListener.onPayment(context.Background(), config.Accounts.ReceivingAccountID, operation)
 				assert.Nil(t, err)
 				mockHorizon.AssertExpectations(t)
 				mockEntityManager.AssertExpectations(t)
@@ -263,8 +319,9 @@ func TestPaymentListener(t *testing.T) {
 			operation.Memo.Value = "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9"
 
 			dbPayment.Status = "Success"
+			setPaymentDetails(&dbPayment, operation)
 
-			mockRepository.On("GetReceivedPaymentByID", int64(1)).Return(nil, nil).Once()
+			mockRepository.On("GetReceivedPaymentByOperationID", "1").Return(nil, nil).Once()
 			mockHorizon.On("LoadMemo", &operation).Return(nil).Once()
 			mockEntityManager.On("Persist", &dbPayment).Return(nil).Once()
 
@@ -309,7 +366,7 @@ func TestPaymentListener(t *testing.T) {
 			).Once()
 
 			Convey("it should save the status", func() {
-				err := paymentListener.onPayment(operation)
+				err := paymentListener.onPayment(context.Background(), config.Accounts.ReceivingAccountID, operation)
 				assert.Nil(t, err)
 				mockHorizon.AssertExpectations(t)
 				mockEntityManager.AssertExpectations(t)
@@ -318,6 +375,16 @@ func TestPaymentListener(t *testing.T) {
 	})
 }
 
+// setPaymentDetails sets fields of a received payment saved by the listener for payments sent to receiving account
+func setPaymentDetails(dbPayment *entities.ReceivedPayment, operation horizon.PaymentResponse) {
+	dbPayment.FromAccount = operation.From
+	dbPayment.Amount = operation.Amount
+	dbPayment.AssetCode = operation.AssetCode
+	dbPayment.AssetIssuer = operation.AssetIssuer
+	dbPayment.MemoType = operation.Memo.Type
+	dbPayment.Memo = operation.Memo.Value
+}
+
 func TestPostForm_MACKey(t *testing.T) {
 	validKey := "SABLR5HOI2IUOYB27TR4TO7HWDJIGSRJTT4UUTXXZOFVVPGQKJ5ME43J"
 	rawkey, err := strkey.Decode(strkey.VersionByteSeed, validKey)
@@ -347,19 +414,674 @@ func TestPostForm_MACKey(t *testing.T) {
 	require.NoError(t, err)
 
 	// no mac if the key is not set
-	_, err = pl.postForm(srv.URL+"/no_mac", url.Values{"foo": []string{"base"}})
+	_, err = pl.postForm(context.Background(), srv.URL+"/no_mac", url.Values{"foo": []string{"base"}})
 	require.NoError(t, err)
 
 	// generates a valid mac if a key is set.
 	cfg.MACKey = validKey
-	_, err = pl.postForm(srv.URL+"/mac", url.Values{"foo": []string{"base"}})
+	_, err = pl.postForm(context.Background(), srv.URL+"/mac", url.Values{"foo": []string{"base"}})
 	require.NoError(t, err)
 
 	// errors is the key is invalid
 	cfg.MACKey = "broken"
-	_, err = pl.postForm(srv.URL+"/mac", url.Values{"foo": []string{"base"}})
+	_, err = pl.postForm(context.Background(), srv.URL+"/mac", url.Values{"foo": []string{"base"}})
 
 	if assert.Error(t, err) {
 		assert.Contains(t, err.Error(), "invalid MAC key")
 	}
 }
+
+func TestPostForm_SigningKeys(t *testing.T) {
+	key, err := signature.ParseKey("2017-06", "SABLR5HOI2IUOYB27TR4TO7HWDJIGSRJTT4UUTXXZOFVVPGQKJ5ME43J")
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_, err := signature.VerifyRequest(req, key)
+		assert.NoError(t, err)
+		assert.Empty(t, req.Header.Get("X_PAYLOAD_MAC"))
+	}))
+	defer srv.Close()
+
+	cfg := &config.Config{
+		SigningKeys: []config.SigningKey{
+			{ID: "2017-06", Key: "SABLR5HOI2IUOYB27TR4TO7HWDJIGSRJTT4UUTXXZOFVVPGQKJ5ME43J"},
+			{ID: "2017-01", Key: "SBKKWO3ZVDDEHDJILGHPHCJCFD2GNUAYIUDMRAS326HLUEQ7ZFXWIGQK"},
+		},
+	}
+	pl, err := NewPaymentListener(cfg, nil, nil, nil, nil)
+	require.NoError(t, err)
+
+	_, err = pl.postForm(context.Background(), srv.URL, url.Values{"foo": []string{"base"}})
+	require.NoError(t, err)
+}
+
+func TestSendCallback_PayloadVersion(t *testing.T) {
+	var contentType, version string
+	var body []byte
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		contentType = req.Header.Get("Content-Type")
+		version = req.Header.Get("X-Payload-Version")
+		body, _ = ioutil.ReadAll(req.Body)
+	}))
+	defer srv.Close()
+
+	form := url.Values{"id": {"1"}}
+	payload := map[string]string{"id": "1"}
+
+	_, err := sendCallback(context.Background(), http.DefaultClient, Signer{}, srv.URL, config.PayloadVersion1, form, payload, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "application/x-www-form-urlencoded", contentType)
+	assert.Equal(t, "1", version)
+	assert.Equal(t, "id=1", string(body))
+
+	_, err = sendCallback(context.Background(), http.DefaultClient, Signer{}, srv.URL, config.PayloadVersion2, form, payload, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "application/json", contentType)
+	assert.Equal(t, "2", version)
+	assert.JSONEq(t, `{"id": "1"}`, string(body))
+}
+
+func TestNewReceiveCallback(t *testing.T) {
+	payment := horizon.PaymentResponse{
+		ID:              "1",
+		Type:            "payment",
+		TransactionHash: "a9ff2e5e9a8dca5e3a1f1e7b0f6d4e0f1a6c3c6a2f6d3e2d1c0b9a8f7e6d5c4b",
+		From:            "GBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFZYYQ",
+		To:              "GATKP6ZQM5CSLECPMTAC5226PE367QALCPM6AFHTSULPPZMT62OOPMQB",
+		Amount:          "10.0000000",
+		AssetCode:       "USD",
+		AssetIssuer:     "GBDOSO3K4JTGSWJSIHXAOFIBMAABVM3YK3FI6VJPKIHHM56XAFIUCGD6",
+		FromMuxed:       "MBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFYAAAAAAAAAAAAGZOKQ",
+		FromMuxedID:     "13",
+	}
+	payment.Memo.Type = "id"
+	payment.Memo.Value = "42"
+
+	fromAddress := "bob*acme.com"
+	rate, convertedAmount, currency := "2", "20.0000000", "EUR"
+	dbPayment := &entities.ReceivedPayment{
+		FromAddress:       &fromAddress,
+		ExchangeRate:      &rate,
+		ConvertedAmount:   &convertedAmount,
+		ConvertedCurrency: &currency,
+	}
+
+	values := url.Values{
+		"route":       {"42"},
+		"asset":       {"USD:GBDOSO3K4JTGSWJSIHXAOFIBMAABVM3YK3FI6VJPKIHHM56XAFIUCGD6"},
+		"customer_id": {"c1"},
+		"data":        {`{"sender": "alice*acme.com", "memo": "{\"transaction\": {\"route\": \"42\"}}"}`},
+	}
+
+	payload := newReceiveCallback(payment, dbPayment, values)
+	assert.Equal(t, 2, payload.Version)
+	assert.Equal(t, "payment_received", payload.Event)
+	assert.Equal(t, "payment", payload.Operation.Type)
+	assert.Equal(t, payment.TransactionHash, payload.Operation.TransactionHash)
+	assert.Equal(t, "42", payload.Route)
+	assert.Equal(t, "c1", payload.CustomerID)
+	assert.Equal(t, payment.FromMuxed, payload.FromMuxed)
+	assert.Equal(t, payment.FromMuxedID, payload.FromMuxedID)
+	assert.Equal(t, "bob*acme.com", payload.FromAddress)
+	assert.Equal(t, "id", payload.Memo.Type)
+	assert.Equal(t, "42", payload.Memo.Value)
+	if assert.NotNil(t, payload.Conversion) {
+		assert.Equal(t, "20.0000000", payload.Conversion.Amount)
+		assert.Equal(t, "EUR", payload.Conversion.Currency)
+	}
+
+	encoded, err := json.Marshal(payload)
+	require.NoError(t, err)
+	assert.Contains(t, string(encoded), `"data":{"sender":"alice*acme.com",`)
+	assert.Contains(t, string(encoded), `"decoded":{"transaction":{"route":"42"}}`)
+}
+
+func TestPaymentTransaction(t *testing.T) {
+	pl := &PaymentListener{config: &config.Config{}}
+
+	payment := horizon.PaymentResponse{ID: "1", Type: "payment", From: "GBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFZYYQ"}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"hash": "a9ff2e5e9a8dca5e3a1f1e7b0f6d4e0f1a6c3c6a2f6d3e2d1c0b9a8f7e6d5c4b",
+		"ledger": 1234,
+		"created_at": "2017-07-14T02:40:00Z",
+		"fee_charged": "200",
+		"source_account": "GBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFZYYQ"
+	}`), &payment.Transaction))
+
+	dbPayment := &entities.ReceivedPayment{OperationID: "1", FromAccount: payment.From}
+	pl.setTransaction(dbPayment, payment)
+	assert.Equal(t, payment.Transaction.Hash, *dbPayment.TransactionHash)
+	assert.Equal(t, int64(1234), *dbPayment.Ledger)
+	assert.Equal(t, time.Date(2017, 7, 14, 2, 40, 0, 0, time.UTC), *dbPayment.LedgerCloseTime)
+	assert.Equal(t, int64(200), *dbPayment.FeeCharged)
+	assert.Equal(t, payment.From, *dbPayment.TransactionSourceAccount)
+
+	payload := newReceiveCallback(payment, dbPayment, url.Values{})
+	if assert.NotNil(t, payload.Transaction) {
+		assert.Equal(t, payment.Transaction.Hash, payload.Transaction.Hash)
+		assert.Equal(t, uint64(1234), payload.Transaction.Ledger)
+		assert.Equal(t, "2017-07-14T02:40:00Z", payload.Transaction.LedgerCloseTime)
+		assert.Equal(t, "200", payload.Transaction.FeeCharged)
+		assert.Equal(t, payment.From, payload.Transaction.SourceAccount)
+		assert.Equal(t, "", payload.Transaction.FeeAccount)
+	}
+
+	// Resent callbacks contain stored transaction details
+	stored := pl.storedPayment(dbPayment)
+	assert.Equal(t, payment.Transaction.Hash, stored.TransactionHash)
+	assert.Equal(t, payment.Transaction, stored.Transaction)
+
+	// Older numeric fee_charged
+	var transaction horizon.PaymentTransaction
+	require.NoError(t, json.Unmarshal([]byte(`{"hash": "ab", "fee_charged": 100}`), &transaction))
+	assert.Equal(t, "100", transaction.FeeCharged.String())
+
+	assert.Nil(t, newReceiveCallback(horizon.PaymentResponse{}, &entities.ReceivedPayment{}, url.Values{}).Transaction)
+}
+
+func TestResolveSender(t *testing.T) {
+	mockHorizon := new(mocks.MockHorizon)
+	mockFederationResolver := new(mocks.MockFederationResolver)
+
+	pl, err := NewPaymentListener(&config.Config{}, nil, mockHorizon, nil, nil)
+	require.NoError(t, err)
+	pl.federation = mockFederationResolver
+
+	payment := horizon.PaymentResponse{From: "GBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFZYYQ"}
+
+	// no home domain
+	mockHorizon.On("LoadAccount", payment.From).Return(horizon.AccountResponse{}, nil).Once()
+	var dbPayment entities.ReceivedPayment
+	pl.resolveSender(context.Background(), &dbPayment, payment)
+	assert.Nil(t, dbPayment.FromAddress)
+
+	// resolved
+	mockHorizon.On("LoadAccount", payment.From).Return(horizon.AccountResponse{HomeDomain: "acme.com"}, nil).Once()
+	mockFederationResolver.On("ReverseResolve", payment.From, "acme.com").Return(
+		federation.Response{StellarAddress: "bob*acme.com", AccountID: payment.From},
+		nil,
+	).Once()
+	pl.resolveSender(context.Background(), &dbPayment, payment)
+	if assert.NotNil(t, dbPayment.FromAddress) {
+		assert.Equal(t, "bob*acme.com", *dbPayment.FromAddress)
+	}
+
+	// federation error does not set address
+	dbPayment = entities.ReceivedPayment{}
+	mockHorizon.On("LoadAccount", payment.From).Return(horizon.AccountResponse{HomeDomain: "acme.com"}, nil).Once()
+	mockFederationResolver.On("ReverseResolve", payment.From, "acme.com").Return(
+		federation.Response{},
+		errors.New("federation server unavailable"),
+	).Once()
+	pl.resolveSender(context.Background(), &dbPayment, payment)
+	assert.Nil(t, dbPayment.FromAddress)
+
+	mockHorizon.AssertExpectations(t)
+	mockFederationResolver.AssertExpectations(t)
+}
+
+func TestResolveMuxedDestination(t *testing.T) {
+	pl, err := NewPaymentListener(&config.Config{}, nil, new(mocks.MockHorizon), nil, nil)
+	require.NoError(t, err)
+
+	accountID := "GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ"
+	muxedAddress := "MA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVAAAAAAAAAAAAAJLK"
+
+	// regular account
+	payment := horizon.PaymentResponse{To: accountID}
+	assert.Equal(t, "", pl.resolveMuxedDestination(&payment))
+	assert.Equal(t, accountID, payment.To)
+
+	// muxed address in `to`
+	payment = horizon.PaymentResponse{To: muxedAddress}
+	assert.Equal(t, "9223372036854775808", pl.resolveMuxedDestination(&payment))
+	assert.Equal(t, accountID, payment.To)
+	assert.Equal(t, muxedAddress, payment.ToMuxed)
+	assert.Equal(t, "9223372036854775808", payment.ToMuxedID)
+
+	// `to_muxed_id` returned by horizon
+	payment = horizon.PaymentResponse{To: accountID, ToMuxed: muxedAddress, ToMuxedID: "9223372036854775808"}
+	assert.Equal(t, "9223372036854775808", pl.resolveMuxedDestination(&payment))
+	assert.Equal(t, accountID, payment.To)
+}
+
+func TestReprocess(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {}))
+	defer srv.Close()
+
+	c := &config.Config{
+		Assets: []config.Asset{{Code: "USD", Issuer: "GBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFZYYQ"}},
+	}
+	c.Accounts.ReceivingAccountID = "GATKP6ZQM5CSLECPMTAC5226PE367QALCPM6AFHTSULPPZMT62OOPMQB"
+	c.Callbacks.Receive = srv.URL
+
+	mockEntityManager := new(mocks.MockEntityManager)
+	mockHorizon := new(mocks.MockHorizon)
+	mockRepository := new(mocks.MockRepository)
+	expectCallbackAttempts(mockEntityManager, mockRepository)
+	pl, err := NewPaymentListener(c, mockEntityManager, mockHorizon, mockRepository, time.Now)
+	require.NoError(t, err)
+
+	id := int64(1)
+	dbPayment := &entities.ReceivedPayment{ID: &id, OperationID: "1234", Status: "Asset not allowed"}
+	dbPayment.SetExists()
+
+	operation := horizon.PaymentResponse{
+		ID:          "1234",
+		Type:        "payment",
+		From:        "GBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFZYYQ",
+		To:          c.Accounts.ReceivingAccountID,
+		AssetCode:   "USD",
+		AssetIssuer: "GBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFZYYQ",
+		Amount:      "100",
+	}
+
+	// Horizon error
+	mockHorizon.On("LoadOperation", "1234").Return(horizon.PaymentResponse{}, errors.New("not found")).Once()
+	assert.Error(t, pl.Reprocess(context.Background(), dbPayment))
+
+	mockHorizon.On("LoadOperation", "1234").Return(operation, nil).Once()
+	mockHorizon.On("LoadMemo", mock.AnythingOfType("*horizon.PaymentResponse")).Return(nil).Once()
+	mockEntityManager.On("Persist", dbPayment).Return(nil).Once()
+
+	require.NoError(t, pl.Reprocess(context.Background(), dbPayment))
+	assert.Equal(t, entities.ReceivedPaymentStatusSuccess, dbPayment.Status)
+	assert.Equal(t, "100.0000000", dbPayment.Amount)
+	assert.Equal(t, operation.From, dbPayment.FromAccount)
+	mockEntityManager.AssertExpectations(t)
+	mockHorizon.AssertExpectations(t)
+}
+
+func TestProcessPayment_ClaimableBalance(t *testing.T) {
+	var payload bridge.ReceiveCallback
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		json.NewDecoder(req.Body).Decode(&payload)
+	}))
+	defer srv.Close()
+
+	c := &config.Config{
+		Assets: []config.Asset{{Code: "USD", Issuer: "GBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFZYYQ"}},
+	}
+	c.Accounts.ReceivingAccountID = "GATKP6ZQM5CSLECPMTAC5226PE367QALCPM6AFHTSULPPZMT62OOPMQB"
+	c.Callbacks.Receive = srv.URL
+	c.Callbacks.ReceiveVersion = config.PayloadVersion2
+
+	mockEntityManager := new(mocks.MockEntityManager)
+	mockHorizon := new(mocks.MockHorizon)
+	mockRepository := new(mocks.MockRepository)
+	expectCallbackAttempts(mockEntityManager, mockRepository)
+	pl, err := NewPaymentListener(c, mockEntityManager, mockHorizon, mockRepository, time.Now)
+	require.NoError(t, err)
+
+	balanceID := "00000000929b20b72e5890ab51c24f1cc46fa01c4f318d8d33367d24dd614cfdf5491072"
+	operation := horizon.PaymentResponse{
+		ID:            "1234",
+		Type:          "create_claimable_balance",
+		SourceAccount: "GBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFZYYQ",
+		Asset:         "USD:GBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFZYYQ",
+		Amount:        "100",
+		Claimants:     []horizon.Claimant{{Destination: c.Accounts.ReceivingAccountID}},
+	}
+
+	// receiving account is not a claimant
+	dbPayment := &entities.ReceivedPayment{OperationID: "1234"}
+	other := operation
+	other.Claimants = []horizon.Claimant{{Destination: "GBDOSO3K4JTGSWJSIHXAOFIBMAABVM3YK3FI6VJPKIHHM56XAFIUCGD6"}}
+	mockEntityManager.On("Persist", dbPayment).Return(nil).Once()
+	require.NoError(t, pl.processPayment(context.Background(), other, dbPayment, false, nil))
+	assert.Equal(t, "Operation sent not received", dbPayment.Status)
+
+	dbPayment = &entities.ReceivedPayment{OperationID: "1234"}
+	mockHorizon.On("LoadClaimableBalanceID", mock.AnythingOfType("*horizon.PaymentResponse")).Return(nil).Run(func(args mock.Arguments) {
+		args.Get(0).(*horizon.PaymentResponse).BalanceID = balanceID
+	}).Once()
+	mockHorizon.On("LoadMemo", mock.AnythingOfType("*horizon.PaymentResponse")).Return(nil).Once()
+	mockEntityManager.On("Persist", dbPayment).Return(nil).Once()
+
+	require.NoError(t, pl.processPayment(context.Background(), operation, dbPayment, false, nil))
+	assert.Equal(t, entities.ReceivedPaymentStatusSuccess, dbPayment.Status)
+	assert.Equal(t, operation.SourceAccount, dbPayment.FromAccount)
+	assert.Equal(t, "USD", dbPayment.AssetCode)
+	assert.Equal(t, bridge.CallbackEventClaimableBalanceCreated, payload.Event)
+	assert.Equal(t, balanceID, payload.ClaimableBalanceID)
+	assert.Equal(t, "100.0000000", payload.Amount)
+
+	// claimed by the receiving account
+	dbPayment = &entities.ReceivedPayment{OperationID: "1235"}
+	claim := horizon.PaymentResponse{ID: "1235", Type: "claim_claimable_balance", Claimant: c.Accounts.ReceivingAccountID, BalanceID: balanceID}
+	mockEntityManager.On("Persist", dbPayment).Return(nil).Once()
+	require.NoError(t, pl.processPayment(context.Background(), claim, dbPayment, false, nil))
+	assert.Equal(t, "Claimable balance claimed", dbPayment.Status)
+
+	mockEntityManager.AssertExpectations(t)
+	mockHorizon.AssertExpectations(t)
+}
+
+func TestProcessPayment_MemoFilter(t *testing.T) {
+	received := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		received++
+	}))
+	defer srv.Close()
+
+	c := &config.Config{
+		Assets:     []config.Asset{{}},
+		MemoFilter: config.MemoFilter{Prefix: "eu-", Regexp: "^eu-[0-9]+$"},
+	}
+	c.Accounts.ReceivingAccountID = "GATKP6ZQM5CSLECPMTAC5226PE367QALCPM6AFHTSULPPZMT62OOPMQB"
+	c.Callbacks.Receive = srv.URL
+
+	mockEntityManager := new(mocks.MockEntityManager)
+	mockHorizon := new(mocks.MockHorizon)
+	mockRepository := new(mocks.MockRepository)
+	mockRepository.On("GetCustomerByMemo", "text", "eu-1").Return(nil, nil)
+	expectCallbackAttempts(mockEntityManager, mockRepository)
+	pl, err := NewPaymentListener(c, mockEntityManager, mockHorizon, mockRepository, time.Now)
+	require.NoError(t, err)
+
+	process := func(memoType, memo string) *entities.ReceivedPayment {
+		operation := horizon.PaymentResponse{
+			ID:     "1234",
+			Type:   "payment",
+			From:   "GBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFZYYQ",
+			To:     c.Accounts.ReceivingAccountID,
+			Amount: "10",
+		}
+		mockHorizon.On("LoadMemo", mock.AnythingOfType("*horizon.PaymentResponse")).Return(nil).Run(func(args mock.Arguments) {
+			args.Get(0).(*horizon.PaymentResponse).Memo.Type = memoType
+			args.Get(0).(*horizon.PaymentResponse).Memo.Value = memo
+		}).Once()
+
+		dbPayment := &entities.ReceivedPayment{OperationID: "1234"}
+		mockEntityManager.On("Persist", dbPayment).Return(nil).Once()
+		require.NoError(t, pl.processPayment(context.Background(), operation, dbPayment, false, nil))
+		return dbPayment
+	}
+
+	assert.Equal(t, entities.ReceivedPaymentStatusMemoFiltered, process("text", "us-1").Status)
+	assert.Equal(t, entities.ReceivedPaymentStatusMemoFiltered, process("text", "eu-x").Status)
+	assert.Equal(t, entities.ReceivedPaymentStatusMemoFiltered, process("id", "1").Status)
+	assert.Equal(t, 0, received)
+
+	assert.Equal(t, entities.ReceivedPaymentStatusSuccess, process("text", "eu-1").Status)
+	assert.Equal(t, 1, received)
+
+	mockEntityManager.AssertExpectations(t)
+	mockHorizon.AssertExpectations(t)
+}
+
+func TestProcessPayment_Types(t *testing.T) {
+	var received []url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		req.ParseForm()
+		received = append(received, req.PostForm)
+	}))
+	defer srv.Close()
+
+	c := &config.Config{Assets: []config.Asset{{}}}
+	c.Accounts.ReceivingAccountID = "GATKP6ZQM5CSLECPMTAC5226PE367QALCPM6AFHTSULPPZMT62OOPMQB"
+	c.Callbacks.Receive = srv.URL
+
+	mockEntityManager := new(mocks.MockEntityManager)
+	mockHorizon := new(mocks.MockHorizon)
+	mockRepository := new(mocks.MockRepository)
+	mockRepository.On("GetCustomerByMemo", "", "").Return(nil, nil)
+	expectCallbackAttempts(mockEntityManager, mockRepository)
+	pl, err := NewPaymentListener(c, mockEntityManager, mockHorizon, mockRepository, time.Now)
+	require.NoError(t, err)
+
+	newOperation := func(operationType string) horizon.PaymentResponse {
+		return horizon.PaymentResponse{
+			ID:          "1234",
+			Type:        operationType,
+			From:        "GBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFZYYQ",
+			FromMuxed:   "MBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFYAAAAAAAAAAAAGZOKQ",
+			FromMuxedID: "13",
+			To:          c.Accounts.ReceivingAccountID,
+			Amount:      "10",
+		}
+	}
+	process := func(operation horizon.PaymentResponse) *entities.ReceivedPayment {
+		dbPayment := &entities.ReceivedPayment{OperationID: operation.ID}
+		mockEntityManager.On("Persist", dbPayment).Return(nil).Once()
+		require.NoError(t, pl.processPayment(context.Background(), operation, dbPayment, false, nil))
+		return dbPayment
+	}
+
+	for _, operationType := range []string{"path_payment_strict_receive", "path_payment_strict_send"} {
+		t.Run(operationType, func(t *testing.T) {
+			received = nil
+			mockHorizon.On("LoadMemo", mock.AnythingOfType("*horizon.PaymentResponse")).Return(nil).Run(func(args mock.Arguments) {
+				transaction := &args.Get(0).(*horizon.PaymentResponse).Transaction
+				transaction.Hash = "a9ff2e5e9a8dca5e3a1f1e7b0f6d4e0f1a6c3c6a2f6d3e2d1c0b9a8f7e6d5c4b"
+				transaction.SourceAccount = "GBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFZYYQ"
+				transaction.FeeAccount = c.Accounts.ReceivingAccountID
+			}).Once()
+
+			assert.Equal(t, entities.ReceivedPaymentStatusSuccess, process(newOperation(operationType)).Status)
+			require.Len(t, received, 1)
+			assert.Equal(t, "10.0000000", received[0].Get("amount"))
+			assert.Equal(t, "MBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFYAAAAAAAAAAAAGZOKQ", received[0].Get("from_muxed"))
+			assert.Equal(t, "13", received[0].Get("from_muxed_id"))
+			assert.Equal(t, c.Accounts.ReceivingAccountID, received[0].Get("fee_account"))
+		})
+	}
+
+	received = nil
+	assert.Equal(t, "Not a payment operation", process(newOperation("manage_sell_offer")).Status)
+
+	// Operations of failed transactions are skipped
+	failed := newOperation("payment")
+	successful := false
+	failed.TransactionSuccessful = &successful
+	assert.Equal(t, "Transaction failed", process(failed).Status)
+	assert.Empty(t, received)
+
+	mockEntityManager.AssertExpectations(t)
+	mockHorizon.AssertExpectations(t)
+}
+
+func TestListen_MultipleReceivingAccounts(t *testing.T) {
+	c := &config.Config{PaymentsPoll: true}
+	c.Accounts.ReceivingAccountID = "GATKP6ZQM5CSLECPMTAC5226PE367QALCPM6AFHTSULPPZMT62OOPMQB"
+	c.Accounts.ReceivingAccountIDs = []string{"GBDOSO3K4JTGSWJSIHXAOFIBMAABVM3YK3FI6VJPKIHHM56XAFIUCGD6"}
+
+	mockHorizon := new(mocks.MockHorizon)
+	mockRepository := new(mocks.MockRepository)
+	pl, err := NewPaymentListener(c, new(mocks.MockEntityManager), mockHorizon, mockRepository, time.Now)
+	require.NoError(t, err)
+
+	streamed := make(chan string, 2)
+	cursors := map[string]string{}
+	for i, accountID := range c.Accounts.ReceivingAccounts() {
+		cursor := strconv.Itoa(100 + i)
+		cursors[accountID] = cursor
+		mockHorizon.On("LoadAccount", accountID).Return(horizon.AccountResponse{}, nil).Once()
+		mockRepository.On("GetLastCursorValue", accountID).Return(&cursor, nil).Once()
+		mockHorizon.On("StreamPayments", accountID, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				streamed <- *args.Get(1).(*string)
+			}).
+			Return(horizon.ErrStopStreaming).
+			Once()
+	}
+
+	require.NoError(t, pl.Listen())
+
+	received := []string{}
+	for range cursors {
+		select {
+		case cursor := <-streamed:
+			received = append(received, cursor)
+		case <-time.After(time.Second):
+			t.Fatal("account was not streamed")
+		}
+	}
+	sort.Strings(received)
+	assert.Equal(t, []string{"100", "101"}, received)
+	mockHorizon.AssertExpectations(t)
+	mockRepository.AssertExpectations(t)
+}
+
+func TestReconnectDelay(t *testing.T) {
+	assert.Equal(t, time.Second, reconnectDelay(1))
+	assert.Equal(t, 2*time.Second, reconnectDelay(2))
+	assert.Equal(t, 32*time.Second, reconnectDelay(6))
+	assert.Equal(t, time.Minute, reconnectDelay(7))
+	assert.Equal(t, time.Minute, reconnectDelay(1000))
+}
+
+func TestRecordCallbackAttempt(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(strings.Repeat("x", 1000)))
+	}))
+	defer srv.Close()
+
+	c := &config.Config{Tenant: "acme"}
+	c.Callbacks.Receive = srv.URL
+
+	mockEntityManager := new(mocks.MockEntityManager)
+	mockRepository := new(mocks.MockRepository)
+	pl, err := NewPaymentListener(c, mockEntityManager, new(mocks.MockHorizon), mockRepository, time.Now)
+	require.NoError(t, err)
+
+	var attempt *entities.CallbackAttempt
+	mockRepository.On("IsCallbackDelivered", callbackDeliveryID("1234", srv.URL)).Return(false, nil).Once()
+	mockRepository.On("CountCallbackAttempts", "1234").Return(2, nil).Once()
+	mockEntityManager.On("Persist", mock.AnythingOfType("*entities.CallbackAttempt")).Run(func(args mock.Arguments) {
+		attempt = args.Get(0).(*entities.CallbackAttempt)
+	}).Return(nil).Once()
+
+	payment := horizon.PaymentResponse{ID: "1234", Amount: "10"}
+	payment.Memo.Type = "none"
+	_, err = pl.sendReceiveCallback(context.Background(), payment, &entities.ReceivedPayment{}, false)
+	require.Error(t, err)
+
+	require.NotNil(t, attempt)
+	assert.Equal(t, "1234", attempt.OperationID)
+	assert.Equal(t, 3, attempt.Attempt)
+	assert.Equal(t, srv.URL, attempt.URL)
+	assert.False(t, attempt.Success)
+	assert.Equal(t, http.StatusServiceUnavailable, *attempt.StatusCode)
+	assert.Len(t, *attempt.Response, maxCallbackResponseLength)
+	assert.Equal(t, err.Error(), *attempt.Error)
+	assert.Equal(t, "acme", attempt.Tenant)
+	mockEntityManager.AssertExpectations(t)
+	mockRepository.AssertExpectations(t)
+}
+
+// expectCallbackAttempts accepts any number of callback attempts saved in the audit log and
+// callback deliveries (none of the callbacks has been delivered before)
+func expectCallbackAttempts(mockEntityManager *mocks.MockEntityManager, mockRepository *mocks.MockRepository) {
+	mockRepository.On("CountCallbackAttempts", mock.AnythingOfType("string")).Return(0, nil)
+	mockEntityManager.On("Persist", mock.AnythingOfType("*entities.CallbackAttempt")).Return(nil)
+	mockRepository.On("IsCallbackDelivered", mock.AnythingOfType("string")).Return(false, nil)
+	mockRepository.On("SaveCallbackDelivery", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).Return(nil)
+}
+
+func TestProcessPayment_Dust(t *testing.T) {
+	received := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		received++
+	}))
+	defer srv.Close()
+
+	c := &config.Config{
+		Assets: []config.Asset{{}},
+		Dust:   config.Dust{Minimums: []config.DustMinimum{{Asset: config.Asset{}, Amount: "1"}}},
+	}
+	c.Accounts.ReceivingAccountID = "GATKP6ZQM5CSLECPMTAC5226PE367QALCPM6AFHTSULPPZMT62OOPMQB"
+	c.Callbacks.Receive = srv.URL
+
+	mockEntityManager := new(mocks.MockEntityManager)
+	mockHorizon := new(mocks.MockHorizon)
+	mockRepository := new(mocks.MockRepository)
+	mockRepository.On("GetCustomerByMemo", "", "").Return(nil, nil)
+	expectCallbackAttempts(mockEntityManager, mockRepository)
+	pl, err := NewPaymentListener(c, mockEntityManager, mockHorizon, mockRepository, time.Now)
+	require.NoError(t, err)
+
+	process := func(value string) *entities.ReceivedPayment {
+		operation := horizon.PaymentResponse{
+			ID:     "1234",
+			Type:   "payment",
+			From:   "GBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFZYYQ",
+			To:     c.Accounts.ReceivingAccountID,
+			Amount: value,
+		}
+		mockHorizon.On("LoadMemo", mock.AnythingOfType("*horizon.PaymentResponse")).Return(nil).Once()
+
+		dbPayment := &entities.ReceivedPayment{OperationID: "1234"}
+		mockEntityManager.On("Persist", dbPayment).Return(nil).Once()
+		require.NoError(t, pl.processPayment(context.Background(), operation, dbPayment, false, nil))
+		return dbPayment
+	}
+
+	dbPayment := process("0.9999999")
+	assert.Equal(t, entities.ReceivedPaymentStatusDustSkipped, dbPayment.Status)
+	assert.Equal(t, "0.9999999", dbPayment.Amount)
+	assert.Equal(t, 0, received)
+
+	assert.Equal(t, entities.ReceivedPaymentStatusSuccess, process("1").Status)
+	assert.Equal(t, 1, received)
+
+	mockEntityManager.AssertExpectations(t)
+	mockHorizon.AssertExpectations(t)
+}
+
+func TestProcessPayment_ComplianceError(t *testing.T) {
+	received := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		received++
+	}))
+	defer srv.Close()
+	complianceServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer complianceServer.Close()
+
+	c := &config.Config{
+		Assets:     []config.Asset{{}},
+		Compliance: complianceServer.URL,
+	}
+	c.Accounts.ReceivingAccountID = "GATKP6ZQM5CSLECPMTAC5226PE367QALCPM6AFHTSULPPZMT62OOPMQB"
+	c.Callbacks.Receive = srv.URL
+
+	mockEntityManager := new(mocks.MockEntityManager)
+	mockHorizon := new(mocks.MockHorizon)
+	mockRepository := new(mocks.MockRepository)
+	expectCallbackAttempts(mockEntityManager, mockRepository)
+	pl, err := NewPaymentListener(c, mockEntityManager, mockHorizon, mockRepository, time.Now)
+	require.NoError(t, err)
+
+	operation := horizon.PaymentResponse{
+		ID:     "1234",
+		Type:   "payment",
+		From:   "GBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFZYYQ",
+		To:     c.Accounts.ReceivingAccountID,
+		Amount: "10",
+	}
+	mockHorizon.On("LoadMemo", mock.AnythingOfType("*horizon.PaymentResponse")).Return(nil).Run(func(args mock.Arguments) {
+		args.Get(0).(*horizon.PaymentResponse).Memo.Type = "hash"
+		args.Get(0).(*horizon.PaymentResponse).Memo.Value = "ASNFZ4mrze8BI0VniavN7wEjRWeJq83vASNFZ4mrze8="
+	}).Once()
+
+	dbPayment := &entities.ReceivedPayment{OperationID: "1234"}
+	assert.EqualError(t, pl.processPayment(context.Background(), operation, dbPayment, false, nil), "Error response from compliance server")
+	assert.NotEqual(t, entities.ReceivedPaymentStatusSuccess, dbPayment.Status)
+
+	// Resent callback fails too
+	dbPayment = &entities.ReceivedPayment{
+		OperationID: "1234",
+		FromAccount: operation.From,
+		Amount:      "10",
+		MemoType:    "hash",
+		Memo:        "ASNFZ4mrze8BI0VniavN7wEjRWeJq83vASNFZ4mrze8=",
+	}
+	assert.EqualError(t, pl.ResendCallback(context.Background(), dbPayment), "Error response from compliance server")
+	assert.Equal(t, 0, received)
+
+	mockHorizon.AssertExpectations(t)
+}
@@ -0,0 +1,56 @@
+package listener
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/protocols/bridge"
+)
+
+// NotifyPaymentSent sends a result of a transaction sent by /payment endpoint to
+// `callbacks.sent`. Status is `failure` when errorCode is not empty. It does nothing when the
+// callback is not set. The transaction has already been submitted so callers only log errors.
+func NotifyPaymentSent(ctx context.Context, client HTTP, c *config.Config, request *bridge.PaymentRequest, transactionID string, ledger *uint64, errorCode string) error {
+	if c.Callbacks.Sent == "" {
+		return nil
+	}
+
+	signer, err := newSigner(c)
+	if err != nil {
+		return err
+	}
+
+	resp, err := postForm(ctx, client, signer, c.Callbacks.Sent, paymentSentValues(c.Tenant, request, transactionID, ledger, errorCode))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("sent callback response status code indicates error (%d)", resp.StatusCode)
+	}
+	return nil
+}
+
+// paymentSentValues returns `callbacks.sent` params: result of the transaction followed by
+// params of the original /payment request (except `source` secret seed)
+func paymentSentValues(tenant string, request *bridge.PaymentRequest, transactionID string, ledger *uint64, errorCode string) url.Values {
+	values := request.ToValues()
+	values.Del("source")
+
+	values.Set("transaction_id", transactionID)
+	values.Set("tenant", tenant)
+	values.Set("status", string(entities.SentTransactionStatusSuccess))
+	if errorCode != "" {
+		values.Set("status", string(entities.SentTransactionStatusFailure))
+		values.Set("error", errorCode)
+	}
+	if ledger != nil {
+		values.Set("ledger", strconv.FormatUint(*ledger, 10))
+	}
+	return values
+}
@@ -0,0 +1,60 @@
+package listener
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotifyPaymentSent(t *testing.T) {
+	var received []url.Values
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		require.NoError(t, req.ParseForm())
+		received = append(received, req.PostForm)
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	c := &config.Config{Tenant: "acme"}
+	request := &bridge.PaymentRequest{
+		Source:      "SDZT3EJZ7FZRYNTLOZ7VH6G5UYBFO2IO3Q5PGONMILPCZU3AL7QNZHTE",
+		Destination: "GBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFZYYQ",
+		Amount:      "20",
+		AssetCode:   "USD",
+		AssetIssuer: "GDSIKW43UA6JTOA47WVEBCZ4MYC74M3GNKNXTVDXFHXYYTNO5GGVN632",
+		Metadata:    `{"order":1}`,
+	}
+
+	// Not sent when the callback is not set
+	require.NoError(t, NotifyPaymentSent(context.Background(), http.DefaultClient, c, request, "hash", nil, ""))
+	assert.Empty(t, received)
+
+	c.Callbacks.Sent = srv.URL
+	ledger := uint64(123)
+	require.NoError(t, NotifyPaymentSent(context.Background(), http.DefaultClient, c, request, "hash", &ledger, ""))
+	require.Len(t, received, 1)
+	assert.Equal(t, "hash", received[0].Get("transaction_id"))
+	assert.Equal(t, "acme", received[0].Get("tenant"))
+	assert.Equal(t, "success", received[0].Get("status"))
+	assert.Equal(t, "123", received[0].Get("ledger"))
+	assert.Equal(t, request.Destination, received[0].Get("destination"))
+	assert.Equal(t, "20", received[0].Get("amount"))
+	assert.Equal(t, `{"order":1}`, received[0].Get("metadata"))
+	assert.NotContains(t, received[0], "source")
+	assert.NotContains(t, received[0], "error")
+
+	status = http.StatusInternalServerError
+	assert.Error(t, NotifyPaymentSent(context.Background(), http.DefaultClient, c, request, "hash", nil, "transaction_bad_seq"))
+	require.Len(t, received, 2)
+	assert.Equal(t, "failure", received[1].Get("status"))
+	assert.Equal(t, "transaction_bad_seq", received[1].Get("error"))
+	assert.NotContains(t, received[1], "ledger")
+}
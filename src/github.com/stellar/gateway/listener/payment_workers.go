@@ -0,0 +1,102 @@
+package listener
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/horizon"
+)
+
+// errTurnAborted is returned when a payment is not saved because one of earlier payments of
+// the stream has not been processed (the listener has been stopped)
+var errTurnAborted = errors.New("earlier payment has not been processed")
+
+// paymentRetryInterval is a time between attempts to process a payment by a worker
+var paymentRetryInterval = 10 * time.Second
+
+// paymentTurn orders payments of a stream processed concurrently by workers. A payment is
+// saved only after all earlier payments are done, so the cursor (paging token of the last
+// saved payment) never moves past a payment that has not been processed.
+type paymentTurn struct {
+	previous    *paymentTurn
+	pagingToken string
+	done        chan struct{}
+	// ok is set before done is closed
+	ok bool
+}
+
+func newPaymentTurn(previous *paymentTurn, pagingToken string) *paymentTurn {
+	return &paymentTurn{previous: previous, pagingToken: pagingToken, done: make(chan struct{})}
+}
+
+// waitPrevious waits until all earlier payments are done. It returns false when one of them
+// has not been processed. It returns true for nil turn (payment processed serially).
+func (t *paymentTurn) waitPrevious() bool {
+	if t == nil || t.previous == nil {
+		return true
+	}
+	return t.previous.wait()
+}
+
+// wait waits until the payment is done and returns true when it has been processed
+func (t *paymentTurn) wait() bool {
+	<-t.done
+	return t.ok
+}
+
+// finish marks the payment as done
+func (t *paymentTurn) finish(ok bool) {
+	t.ok = ok
+	// Release earlier turns
+	t.previous = nil
+	close(t.done)
+}
+
+// concurrentHandler returns a stream handler processing payments by workers. Handler blocks
+// when all workers are busy. last is set to the turn of the last streamed payment.
+func (pl *PaymentListener) concurrentHandler(ctx context.Context, accountID string, workers chan struct{}, last **paymentTurn) horizon.PaymentHandler {
+	return func(payment horizon.PaymentResponse) error {
+		// Payment will be processed again after restart (or by the new lease holder) as
+		// cursor is not moved
+		if !pl.isLeader() || !pl.drainer.begin() {
+			return horizon.ErrStopStreaming
+		}
+
+		workers <- struct{}{}
+		turn := newPaymentTurn(*last, payment.PagingToken)
+		*last = turn
+
+		go func() {
+			defer func() {
+				<-workers
+				pl.drainer.done()
+			}()
+			pl.processInTurn(ctx, accountID, payment, turn)
+		}()
+		return nil
+	}
+}
+
+// processInTurn processes a payment until it succeeds or the listener is stopped
+func (pl *PaymentListener) processInTurn(ctx context.Context, accountID string, payment horizon.PaymentResponse, turn *paymentTurn) {
+	for {
+		err := pl.onPaymentInTurn(ctx, accountID, payment, turn)
+		if err == nil {
+			turn.finish(turn.waitPrevious())
+			return
+		}
+
+		if err == errTurnAborted || pl.drainer.isStopped() {
+			turn.finish(false)
+			return
+		}
+
+		pl.log.WithFields(logrus.Fields{"err": err, "id": payment.ID}).Error("Error processing payment. Retrying...")
+		select {
+		case <-ctx.Done():
+		case <-time.After(paymentRetryInterval):
+		}
+	}
+}
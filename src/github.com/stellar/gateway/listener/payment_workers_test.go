@@ -0,0 +1,60 @@
+package listener
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConcurrentHandler(t *testing.T) {
+	c := &config.Config{ListenerWorkers: 3}
+	c.Accounts.ReceivingAccountID = "GATKP6ZQM5CSLECPMTAC5226PE367QALCPM6AFHTSULPPZMT62OOPMQB"
+
+	mockEntityManager := new(mocks.MockEntityManager)
+	mockRepository := new(mocks.MockRepository)
+	pl, err := NewPaymentListener(c, mockEntityManager, new(mocks.MockHorizon), mockRepository, time.Now)
+	require.NoError(t, err)
+
+	// The first payment is processed last but it's saved first
+	mockRepository.On("GetReceivedPaymentByOperationID", "1").Return(nil, nil).After(50 * time.Millisecond)
+	mockRepository.On("GetReceivedPaymentByOperationID", mock.AnythingOfType("string")).Return(nil, nil)
+
+	var mutex sync.Mutex
+	saved := []string{}
+	mockEntityManager.On("Persist", mock.AnythingOfType("*entities.ReceivedPayment")).Run(func(args mock.Arguments) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		saved = append(saved, args.Get(0).(*entities.ReceivedPayment).OperationID)
+	}).Return(nil)
+
+	var last *paymentTurn
+	handler := pl.concurrentHandler(context.Background(), c.Accounts.ReceivingAccountID, make(chan struct{}, c.ListenerWorkers), &last)
+	for _, id := range []string{"1", "2", "3", "4"} {
+		require.NoError(t, handler(horizon.PaymentResponse{ID: id, PagingToken: id, Type: "create_account"}))
+	}
+
+	require.True(t, last.wait())
+	assert.Equal(t, "4", last.pagingToken)
+	assert.Equal(t, []string{"1", "2", "3", "4"}, saved)
+}
+
+func TestPaymentTurnAborted(t *testing.T) {
+	first := newPaymentTurn(nil, "1")
+	second := newPaymentTurn(first, "2")
+
+	first.finish(false)
+	assert.False(t, second.waitPrevious())
+	assert.True(t, first.waitPrevious())
+
+	var serial *paymentTurn
+	assert.True(t, serial.waitPrevious())
+}
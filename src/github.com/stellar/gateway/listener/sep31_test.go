@@ -0,0 +1,94 @@
+package listener
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/mocks"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSep31Payment(t *testing.T) {
+	var callback bridge.ReceiveCallback
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		json.Unmarshal(body, &callback)
+	}))
+	defer srv.Close()
+
+	issuer := "GAMVF7G4GJC4A7JMFJWLUAEIBFQD5RT3DCB5DC5TJDEKQBBACQ4JJVEE"
+	c := &config.Config{}
+	c.Callbacks.Receive = srv.URL
+	c.Callbacks.ReceiveVersion = 2
+	c.Sep31.Assets = []config.Sep31Asset{{Asset: config.Asset{Code: "USD", Issuer: issuer}}}
+
+	now := time.Now()
+	mockEntityManager := new(mocks.MockEntityManager)
+	mockRepository := new(mocks.MockRepository)
+	expectCallbackAttempts(mockEntityManager, mockRepository)
+	pl, err := NewPaymentListener(c, mockEntityManager, new(mocks.MockHorizon), mockRepository, func() time.Time { return now })
+	require.NoError(t, err)
+
+	transaction := &entities.Sep31Transaction{
+		PublicID:    "a1b2",
+		Status:      entities.Sep31StatusPendingSender,
+		AmountIn:    "100.0000000",
+		AmountFee:   "1.0000000",
+		AmountOut:   "99.0000000",
+		AssetCode:   "USD",
+		AssetIssuer: issuer,
+		SenderID:    "sender",
+		ReceiverID:  "receiver",
+		Fields:      `{"purpose":"gift"}`,
+		MemoType:    "hash",
+		Memo:        "bWVtbw==",
+	}
+	transaction.SetExists()
+
+	payment := horizon.PaymentResponse{
+		ID:          "1234",
+		From:        "GBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFZYYQ",
+		Amount:      "100.0000000",
+		AssetCode:   "USD",
+		AssetIssuer: issuer,
+	}
+	payment.Links.Transaction.Href = "https://horizon.stellar.org/transactions/abcd"
+	payment.Memo.Type = "hash"
+	payment.Memo.Value = "bWVtbw=="
+
+	mockRepository.On("GetSep31TransactionByMemo", "hash", "bWVtbw==").Return(transaction, nil)
+	mockEntityManager.On("Persist", transaction).Return(nil).Twice()
+
+	_, err = pl.sendReceiveCallback(context.Background(), payment, &entities.ReceivedPayment{}, false)
+	require.NoError(t, err)
+	assert.Equal(t, entities.Sep31StatusCompleted, transaction.Status)
+	assert.Equal(t, now, *transaction.CompletedAt)
+	assert.Equal(t, "abcd", *transaction.StellarTransactionID)
+	assert.Equal(t, "receiver", callback.Route)
+	require.NotNil(t, callback.Sep31)
+	assert.Equal(t, "a1b2", callback.Sep31.TransactionID)
+	assert.Equal(t, "99.0000000", callback.Sep31.AmountOut)
+	assert.JSONEq(t, `{"purpose":"gift"}`, string(*callback.Sep31.Fields))
+
+	// Payments with a wrong amount are delivered but transaction is not completed
+	transaction.Status = entities.Sep31StatusPendingSender
+	payment.Amount = "50.0000000"
+	mockEntityManager.On("Persist", transaction).Return(nil).Once()
+
+	_, err = pl.sendReceiveCallback(context.Background(), payment, &entities.ReceivedPayment{}, false)
+	require.NoError(t, err)
+	assert.Equal(t, entities.Sep31StatusError, transaction.Status)
+	assert.Equal(t, "Received 50.0000000 USD:"+issuer+", expected 100.0000000 USD:"+issuer, *transaction.StatusMessage)
+
+	mockEntityManager.AssertExpectations(t)
+}
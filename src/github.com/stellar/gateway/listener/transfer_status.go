@@ -0,0 +1,63 @@
+package listener
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/db/entities"
+)
+
+// NotifyTransferStatus sends the current status of a SEP-6 or SEP-24 transfer transaction to
+// `callbacks.transfer_status`. It does nothing when the callback is not set. Transactions have
+// already been persisted so callers only log errors.
+func NotifyTransferStatus(ctx context.Context, client HTTP, c *config.Config, transaction *entities.TransferTransaction) error {
+	if c.Callbacks.TransferStatus == "" {
+		return nil
+	}
+
+	signer, err := newSigner(c)
+	if err != nil {
+		return err
+	}
+
+	resp, err := postForm(ctx, client, signer, c.Callbacks.TransferStatus, transferStatusValues(c.Tenant, transaction))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("transfer status callback response status code indicates error (%d)", resp.StatusCode)
+	}
+	return nil
+}
+
+// transferStatusValues returns `callbacks.transfer_status` params of a transaction
+func transferStatusValues(tenant string, transaction *entities.TransferTransaction) url.Values {
+	values := url.Values{
+		"id":           {transaction.PublicID},
+		"tenant":       {tenant},
+		"kind":         {transaction.Kind},
+		"status":       {transaction.Status},
+		"account":      {transaction.Account},
+		"asset_code":   {transaction.AssetCode},
+		"asset_issuer": {transaction.AssetIssuer},
+		"type":         {transaction.Type},
+	}
+
+	optional := map[string]*string{
+		"amount_in":              transaction.AmountIn,
+		"amount_fee":             transaction.AmountFee,
+		"amount_out":             transaction.AmountOut,
+		"stellar_transaction_id": transaction.StellarTransactionID,
+		"message":                transaction.StatusMessage,
+	}
+	for name, value := range optional {
+		if value != nil {
+			values.Set(name, *value)
+		}
+	}
+	return values
+}
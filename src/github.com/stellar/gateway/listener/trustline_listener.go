@@ -0,0 +1,134 @@
+package listener
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/metrics"
+	"github.com/stellar/gateway/protocols"
+	"github.com/stellar/gateway/protocols/bridge"
+)
+
+// TrustlineListener is listening for new trustlines to assets of IssuingAccount
+// and sends them to `callbacks.trustline`
+type TrustlineListener struct {
+	client  HTTP
+	config  *config.Config
+	horizon horizon.HorizonInterface
+	log     *logrus.Entry
+}
+
+// NewTrustlineListener creates a new TrustlineListener
+func NewTrustlineListener(config *config.Config, horizon horizon.HorizonInterface) (tl TrustlineListener) {
+	tl.client = &http.Client{
+		Timeout: config.Timeouts.CallbacksTimeout(),
+	}
+	tl.config = config
+	tl.horizon = horizon
+	tl.log = logrus.WithFields(logrus.Fields{
+		"service": "TrustlineListener",
+	})
+	if config.Tenant != "" {
+		tl.log = tl.log.WithField("tenant", config.Tenant)
+	}
+	return
+}
+
+// Listen starts listening for new trustlines. Trustlines created while the
+// bridge server was not running are not sent. It runs until the server exits.
+func (tl *TrustlineListener) Listen() {
+	go func() {
+		cursor := "now"
+		var failures int
+		for {
+			tl.log.WithFields(logrus.Fields{
+				"issuer": tl.config.Accounts.IssuingAccountID,
+				"cursor": cursor,
+			}).Info("Started listening for new trustlines")
+
+			err := tl.horizon.StreamEffects(context.Background(), &cursor, func(effect horizon.EffectResponse) error {
+				err := tl.onEffect(effect)
+				if err == nil {
+					cursor = effect.PagingToken
+					failures = 0
+				}
+				return err
+			})
+			if err != nil {
+				failures++
+				delay := reconnectDelay(failures)
+				tl.log.WithFields(logrus.Fields{"err": err, "delay": delay}).Error("Error while streaming")
+				time.Sleep(delay)
+			} else {
+				failures = 0
+			}
+			tl.log.Info("Streaming connection closed. Restarting...")
+		}
+	}()
+}
+
+func (tl *TrustlineListener) onEffect(effect horizon.EffectResponse) error {
+	if effect.Type != "trustline_created" || effect.AssetIssuer != tl.config.Accounts.IssuingAccountID {
+		return nil
+	}
+
+	tl.log.WithFields(logrus.Fields{
+		"trustor":    effect.Account,
+		"asset_code": effect.AssetCode,
+	}).Info("New trustline")
+
+	asset := protocols.Asset{Code: effect.AssetCode, Issuer: effect.AssetIssuer}.String()
+	form := url.Values{
+		"id":           {effect.ID},
+		"account_id":   {effect.Account},
+		"asset_code":   {effect.AssetCode},
+		"asset_issuer": {effect.AssetIssuer},
+		"asset":        {asset},
+		"limit":        {effect.Limit},
+	}
+	payload := bridge.TrustlineCallback{
+		Version:     config.PayloadVersion2,
+		Event:       bridge.CallbackEventTrustlineCreated,
+		ID:          effect.ID,
+		AccountID:   effect.Account,
+		Asset:       asset,
+		AssetCode:   effect.AssetCode,
+		AssetIssuer: effect.AssetIssuer,
+		Limit:       effect.Limit,
+	}
+
+	version := config.PayloadVersion(tl.config.Callbacks.TrustlineVersion)
+	signer, err := newSigner(tl.config)
+	if err != nil {
+		return err
+	}
+
+	resp, err := sendCallback(context.Background(), tl.client, signer, tl.config.CurrentCallbacks().Trustline, version, form, payload, nil)
+	if err != nil {
+		tl.log.Error("Error sending request to trustline callback")
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		tl.log.WithFields(logrus.Fields{
+			"status": resp.StatusCode,
+			"body":   string(body),
+		}).Error("Error response from trustline callback")
+		return fmt.Errorf("Error response from trustline callback (%d)", resp.StatusCode)
+	}
+
+	metrics.AddCounter("trustlines_created", 1, metrics.Tags{
+		"asset_code": effect.AssetCode,
+		"tenant":     tl.config.Tenant,
+	})
+	return nil
+}
@@ -0,0 +1,61 @@
+package listener
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/horizon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrustlineListener_OnEffect(t *testing.T) {
+	issuer := "GD4I7AFSLZGTDL34TQLWJOM2NHLIIOEKD5RHHZUW54HERBLSIRKUOXRR"
+	var received []*http.Request
+	status := http.StatusOK
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		received = append(received, r)
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	cfg := &config.Config{}
+	cfg.Accounts.IssuingAccountID = issuer
+	cfg.Callbacks.Trustline = srv.URL
+	tl := NewTrustlineListener(cfg, nil)
+
+	effect := horizon.EffectResponse{
+		ID:          "0000000012884905985-0000000001",
+		Type:        "trustline_created",
+		Account:     "GATKP6ZQM5CSLECPMTAC5226PE367QALCPM6AFHTSULPPZMT62OOPMQB",
+		AssetType:   "credit_alphanum4",
+		AssetCode:   "USD",
+		AssetIssuer: issuer,
+		Limit:       "922337203685.4775807",
+	}
+
+	// Other effects are skipped
+	other := effect
+	other.Type = "trustline_updated"
+	assert.NoError(t, tl.onEffect(other))
+	other = effect
+	other.AssetIssuer = "GATKP6ZQM5CSLECPMTAC5226PE367QALCPM6AFHTSULPPZMT62OOPMQB"
+	assert.NoError(t, tl.onEffect(other))
+	assert.Len(t, received, 0)
+
+	assert.NoError(t, tl.onEffect(effect))
+	if assert.Len(t, received, 1) {
+		assert.Equal(t, effect.Account, received[0].PostForm.Get("account_id"))
+		assert.Equal(t, "USD", received[0].PostForm.Get("asset_code"))
+		assert.Equal(t, issuer, received[0].PostForm.Get("asset_issuer"))
+		assert.Equal(t, "USD:"+issuer, received[0].PostForm.Get("asset"))
+	}
+
+	// Error response is returned so the effect is sent again
+	status = http.StatusInternalServerError
+	assert.Error(t, tl.onEffect(effect))
+}
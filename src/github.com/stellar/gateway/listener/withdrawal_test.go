@@ -0,0 +1,113 @@
+package listener
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/mocks"
+	"github.com/stellar/gateway/protocols/bridge"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithdrawalPayment(t *testing.T) {
+	var callback bridge.ReceiveCallback
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		json.Unmarshal(body, &callback)
+	}))
+	defer srv.Close()
+
+	var statuses []string
+	statusSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		req.ParseForm()
+		statuses = append(statuses, req.PostForm.Get("id")+":"+req.PostForm.Get("status"))
+	}))
+	defer statusSrv.Close()
+
+	issuer := "GAMVF7G4GJC4A7JMFJWLUAEIBFQD5RT3DCB5DC5TJDEKQBBACQ4JJVEE"
+	c := &config.Config{}
+	c.Callbacks.Receive = srv.URL
+	c.Callbacks.ReceiveVersion = 2
+	c.Callbacks.TransferStatus = statusSrv.URL
+	c.TransferServer.Assets = []config.TransferAsset{{
+		Asset:     config.Asset{Code: "USD", Issuer: issuer},
+		Withdraw:  true,
+		FeeFixed:  "1",
+		MaxAmount: "1000",
+	}}
+
+	now := time.Now()
+	mockEntityManager := new(mocks.MockEntityManager)
+	mockRepository := new(mocks.MockRepository)
+	expectCallbackAttempts(mockEntityManager, mockRepository)
+	pl, err := NewPaymentListener(c, mockEntityManager, new(mocks.MockHorizon), mockRepository, func() time.Time { return now })
+	require.NoError(t, err)
+
+	memoType, memo := "hash", "bWVtbw=="
+	withdrawal := &entities.TransferTransaction{
+		PublicID:    "a1b2",
+		Kind:        entities.TransferKindWithdrawal,
+		Status:      entities.TransferStatusPendingUserTransferStart,
+		AssetCode:   "USD",
+		AssetIssuer: issuer,
+		Account:     "GBIHSMPXC2KJ3NJVHEYTG3KCHYEUQRT45X6AWYWXMAXZOAX4F5LFZYYQ",
+		Type:        "bank_account",
+		Fields:      `{"dest":"DE89370400440532013000"}`,
+		MemoType:    &memoType,
+		Memo:        &memo,
+	}
+	withdrawal.SetExists()
+
+	payment := horizon.PaymentResponse{
+		ID:          "1234",
+		From:        withdrawal.Account,
+		Amount:      "100.0000000",
+		AssetCode:   "USD",
+		AssetIssuer: issuer,
+	}
+	payment.Links.Transaction.Href = "https://horizon.stellar.org/transactions/abcd"
+	payment.Memo.Type = "hash"
+	payment.Memo.Value = memo
+
+	mockRepository.On("GetWithdrawalByMemo", "hash", memo).Return(withdrawal, nil)
+	mockEntityManager.On("Persist", withdrawal).Return(nil).Twice()
+
+	// Amounts of withdrawals without amount are set when the payment is received
+	_, err = pl.sendReceiveCallback(context.Background(), payment, &entities.ReceivedPayment{}, false)
+	require.NoError(t, err)
+	assert.Equal(t, entities.TransferStatusCompleted, withdrawal.Status)
+	assert.Equal(t, now, *withdrawal.CompletedAt)
+	assert.Equal(t, "abcd", *withdrawal.StellarTransactionID)
+	assert.Equal(t, "99.0000000", *withdrawal.AmountOut)
+	assert.Equal(t, "a1b2", callback.Route)
+	require.NotNil(t, callback.Withdrawal)
+	assert.Equal(t, "bank_account", callback.Withdrawal.Type)
+	assert.Equal(t, "1.0000000", callback.Withdrawal.AmountFee)
+	assert.JSONEq(t, `{"dest":"DE89370400440532013000"}`, string(*callback.Withdrawal.Fields))
+	assert.Equal(t, []string{"a1b2:pending_anchor", "a1b2:completed"}, statuses)
+
+	// Payments over the asset limit are delivered but withdrawal is not completed
+	withdrawal.Status = entities.TransferStatusPendingUserTransferStart
+	withdrawal.AmountIn, withdrawal.AmountFee, withdrawal.AmountOut = nil, nil, nil
+	payment.Amount = "5000.0000000"
+	callback = bridge.ReceiveCallback{}
+	mockEntityManager.On("Persist", withdrawal).Return(nil).Once()
+
+	_, err = pl.sendReceiveCallback(context.Background(), payment, &entities.ReceivedPayment{}, false)
+	require.NoError(t, err)
+	assert.Equal(t, entities.TransferStatusError, withdrawal.Status)
+	assert.Equal(t, "Received 5000.0000000 is not within the asset limits or it does not cover the fee", *withdrawal.StatusMessage)
+	assert.Empty(t, callback.Withdrawal.AmountOut)
+	assert.Equal(t, "a1b2:error", statuses[len(statuses)-1])
+
+	mockEntityManager.AssertExpectations(t)
+}
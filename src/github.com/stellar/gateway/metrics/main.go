@@ -0,0 +1,179 @@
+// Package metrics collects application metrics and forwards them to configured sinks.
+package metrics
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Tags are key-value pairs describing a metric sample
+type Tags map[string]string
+
+// Sink receives metric updates. Implementations must be safe for concurrent use.
+type Sink interface {
+	Gauge(name string, value float64, tags Tags)
+	Count(name string, delta float64, tags Tags)
+	Timing(name string, d time.Duration, tags Tags)
+}
+
+// Kind is a type of a metric
+type Kind string
+
+const (
+	// KindGauge is a metric that can go up and down
+	KindGauge Kind = "gauge"
+	// KindCounter is a metric that only goes up
+	KindCounter Kind = "counter"
+	// KindHistogram is a distribution of durations in seconds
+	KindHistogram Kind = "histogram"
+)
+
+// DefaultBuckets are upper bounds (in seconds) of histogram buckets
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+// Sample is a current value of a metric. Histograms have Count, Sum and Buckets
+// (cumulative counts of observations less than or equal to DefaultBuckets) set instead of Value.
+type Sample struct {
+	Name    string
+	Kind    Kind
+	Tags    Tags
+	Value   float64
+	Count   uint64
+	Sum     float64
+	Buckets []uint64
+}
+
+// Registry keeps current values of metrics and forwards all updates to sinks
+type Registry struct {
+	mutex   sync.Mutex
+	samples map[string]*Sample
+	sinks   []Sink
+}
+
+// NewRegistry creates a new Registry
+func NewRegistry() *Registry {
+	return &Registry{samples: make(map[string]*Sample)}
+}
+
+// Default is a registry used by package level functions
+var Default = NewRegistry()
+
+// AddSink adds a sink that will receive all future updates
+func (r *Registry) AddSink(sink Sink) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.sinks = append(r.sinks, sink)
+}
+
+// SetGauge sets the value of a gauge
+func (r *Registry) SetGauge(name string, value float64, tags Tags) {
+	r.mutex.Lock()
+	r.sample(name, KindGauge, tags).Value = value
+	sinks := r.sinks
+	r.mutex.Unlock()
+
+	for _, sink := range sinks {
+		sink.Gauge(name, value, tags)
+	}
+}
+
+// AddCounter increments the value of a counter by delta
+func (r *Registry) AddCounter(name string, delta float64, tags Tags) {
+	r.mutex.Lock()
+	r.sample(name, KindCounter, tags).Value += delta
+	sinks := r.sinks
+	r.mutex.Unlock()
+
+	for _, sink := range sinks {
+		sink.Count(name, delta, tags)
+	}
+}
+
+// ObserveDuration adds a duration to a histogram
+func (r *Registry) ObserveDuration(name string, d time.Duration, tags Tags) {
+	seconds := d.Seconds()
+
+	r.mutex.Lock()
+	sample := r.sample(name, KindHistogram, tags)
+	if sample.Buckets == nil {
+		sample.Buckets = make([]uint64, len(DefaultBuckets))
+	}
+	sample.Count++
+	sample.Sum += seconds
+	for i, bound := range DefaultBuckets {
+		if seconds <= bound {
+			sample.Buckets[i]++
+		}
+	}
+	sinks := r.sinks
+	r.mutex.Unlock()
+
+	for _, sink := range sinks {
+		sink.Timing(name, d, tags)
+	}
+}
+
+// Samples returns current values of all metrics sorted by name and tags
+func (r *Registry) Samples() []Sample {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	keys := make([]string, 0, len(r.samples))
+	for key := range r.samples {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	samples := make([]Sample, 0, len(keys))
+	for _, key := range keys {
+		sample := *r.samples[key]
+		if sample.Buckets != nil {
+			sample.Buckets = append([]uint64(nil), sample.Buckets...)
+		}
+		samples = append(samples, sample)
+	}
+	return samples
+}
+
+func (r *Registry) sample(name string, kind Kind, tags Tags) *Sample {
+	key := name + "{" + tags.String() + "}"
+	sample, ok := r.samples[key]
+	if !ok {
+		sample = &Sample{Name: name, Kind: kind, Tags: tags}
+		r.samples[key] = sample
+	}
+	return sample
+}
+
+// String returns tags in `key=value` format sorted by key
+func (t Tags) String() string {
+	pairs := make([]string, 0, len(t))
+	for key, value := range t {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// SetGauge sets the value of a gauge in Default registry
+func SetGauge(name string, value float64, tags Tags) {
+	Default.SetGauge(name, value, tags)
+}
+
+// AddCounter increments the value of a counter in Default registry
+func AddCounter(name string, delta float64, tags Tags) {
+	Default.AddCounter(name, delta, tags)
+}
+
+// ObserveDuration adds a duration to a histogram in Default registry
+func ObserveDuration(name string, d time.Duration, tags Tags) {
+	Default.ObserveDuration(name, d, tags)
+}
+
+// Since adds time elapsed since start to a histogram in Default registry. It's useful
+// with defer: `defer metrics.Since("request_duration_seconds", time.Now(), nil)`.
+func Since(name string, start time.Time, tags Tags) {
+	Default.ObserveDuration(name, time.Since(start), tags)
+}
@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingSink struct {
+	gauges  []float64
+	counts  []float64
+	timings []time.Duration
+}
+
+func (s *recordingSink) Gauge(name string, value float64, tags Tags) {
+	s.gauges = append(s.gauges, value)
+}
+
+func (s *recordingSink) Count(name string, delta float64, tags Tags) {
+	s.counts = append(s.counts, delta)
+}
+
+func (s *recordingSink) Timing(name string, d time.Duration, tags Tags) {
+	s.timings = append(s.timings, d)
+}
+
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry()
+	sink := &recordingSink{}
+	registry.AddSink(sink)
+
+	registry.SetGauge("balance", 10, Tags{"account": "base"})
+	registry.SetGauge("balance", 5, Tags{"account": "base"})
+	registry.SetGauge("balance", 7, Tags{"account": "issuing"})
+	registry.AddCounter("payments", 1, nil)
+	registry.AddCounter("payments", 2, nil)
+
+	assert.Equal(t, []float64{10, 5, 7}, sink.gauges)
+	assert.Equal(t, []float64{1, 2}, sink.counts)
+
+	samples := registry.Samples()
+	if assert.Len(t, samples, 3) {
+		assert.Equal(t, Sample{Name: "balance", Kind: KindGauge, Tags: Tags{"account": "base"}, Value: 5}, samples[0])
+		assert.Equal(t, Sample{Name: "balance", Kind: KindGauge, Tags: Tags{"account": "issuing"}, Value: 7}, samples[1])
+		assert.Equal(t, Sample{Name: "payments", Kind: KindCounter, Value: 3}, samples[2])
+	}
+}
+
+func TestRegistry_ObserveDuration(t *testing.T) {
+	registry := NewRegistry()
+	sink := &recordingSink{}
+	registry.AddSink(sink)
+
+	registry.ObserveDuration("duration", 20*time.Millisecond, nil)
+	registry.ObserveDuration("duration", 2*time.Second, nil)
+
+	assert.Equal(t, []time.Duration{20 * time.Millisecond, 2 * time.Second}, sink.timings)
+
+	samples := registry.Samples()
+	if assert.Len(t, samples, 1) {
+		assert.Equal(t, KindHistogram, samples[0].Kind)
+		assert.Equal(t, uint64(2), samples[0].Count)
+		assert.InDelta(t, 2.02, samples[0].Sum, 1e-9)
+		// 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, ...
+		assert.Equal(t, []uint64{0, 0, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 2}, samples[0].Buckets)
+	}
+}
+
+func TestTagsString(t *testing.T) {
+	assert.Equal(t, "", Tags{}.String())
+	assert.Equal(t, "a=1,b=2", Tags{"b": "2", "a": "1"}.String())
+}
@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Handler returns an http.Handler serving metrics of registry in Prometheus text format
+func Handler(r *Registry) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		r.WritePrometheus(w)
+	})
+}
+
+// WritePrometheus writes current values of all metrics in Prometheus text format
+func (r *Registry) WritePrometheus(w io.Writer) error {
+	buf := bufio.NewWriter(w)
+
+	var lastName string
+	for _, sample := range r.Samples() {
+		if sample.Name != lastName {
+			fmt.Fprintf(buf, "# TYPE %s %s\n", sample.Name, sample.Kind)
+			lastName = sample.Name
+		}
+
+		if sample.Kind != KindHistogram {
+			fmt.Fprintf(buf, "%s%s %s\n", sample.Name, labels(sample.Tags, "", ""), formatFloat(sample.Value))
+			continue
+		}
+
+		for i, bound := range DefaultBuckets {
+			fmt.Fprintf(buf, "%s_bucket%s %d\n", sample.Name, labels(sample.Tags, "le", formatFloat(bound)), sample.Buckets[i])
+		}
+		fmt.Fprintf(buf, "%s_bucket%s %d\n", sample.Name, labels(sample.Tags, "le", "+Inf"), sample.Count)
+		fmt.Fprintf(buf, "%s_sum%s %s\n", sample.Name, labels(sample.Tags, "", ""), formatFloat(sample.Sum))
+		fmt.Fprintf(buf, "%s_count%s %d\n", sample.Name, labels(sample.Tags, "", ""), sample.Count)
+	}
+
+	return buf.Flush()
+}
+
+// labels formats tags (and an extra label when extraKey is not empty) as `{key="value",...}`
+func labels(tags Tags, extraKey, extraValue string) string {
+	pairs := make([]string, 0, len(tags)+1)
+	for key, value := range tags {
+		pairs = append(pairs, key+`="`+escapeLabel(value)+`"`)
+	}
+	sort.Strings(pairs)
+
+	if extraKey != "" {
+		pairs = append(pairs, extraKey+`="`+extraValue+`"`)
+	}
+
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+var labelReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabel(value string) string {
+	return labelReplacer.Replace(value)
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWritePrometheus(t *testing.T) {
+	registry := NewRegistry()
+	registry.SetGauge("account_balance", 100.5, Tags{"account": "base", "tenant": `a"b`})
+	registry.AddCounter("payments_received", 2, nil)
+	registry.ObserveDuration("callback_duration_seconds", 20*time.Millisecond, Tags{"tenant": ""})
+
+	var buf bytes.Buffer
+	require.NoError(t, registry.WritePrometheus(&buf))
+
+	expected := `# TYPE account_balance gauge
+account_balance{account="base",tenant="a\"b"} 100.5
+# TYPE callback_duration_seconds histogram
+callback_duration_seconds_bucket{tenant="",le="0.005"} 0
+callback_duration_seconds_bucket{tenant="",le="0.01"} 0
+callback_duration_seconds_bucket{tenant="",le="0.025"} 1
+callback_duration_seconds_bucket{tenant="",le="0.05"} 1
+callback_duration_seconds_bucket{tenant="",le="0.1"} 1
+callback_duration_seconds_bucket{tenant="",le="0.25"} 1
+callback_duration_seconds_bucket{tenant="",le="0.5"} 1
+callback_duration_seconds_bucket{tenant="",le="1"} 1
+callback_duration_seconds_bucket{tenant="",le="2.5"} 1
+callback_duration_seconds_bucket{tenant="",le="5"} 1
+callback_duration_seconds_bucket{tenant="",le="10"} 1
+callback_duration_seconds_bucket{tenant="",le="30"} 1
+callback_duration_seconds_bucket{tenant="",le="60"} 1
+callback_duration_seconds_bucket{tenant="",le="+Inf"} 1
+callback_duration_seconds_sum{tenant=""} 0.02
+callback_duration_seconds_count{tenant=""} 1
+# TYPE payments_received counter
+payments_received 2
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestHandler(t *testing.T) {
+	registry := NewRegistry()
+	registry.AddCounter("payments_received", 1, nil)
+
+	w := httptest.NewRecorder()
+	Handler(registry).ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+
+	assert.Equal(t, "text/plain; version=0.0.4", w.Header().Get("Content-Type"))
+	assert.True(t, strings.Contains(w.Body.String(), "payments_received 1\n"))
+}
@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StatsDSink sends metric updates to a StatsD server over UDP. Tags are sent using
+// DogStatsD extension (`|#key:value,...`) understood by Datadog agent.
+type StatsDSink struct {
+	conn   net.Conn
+	prefix string
+	tags   []string
+}
+
+// NewStatsDSink creates a new StatsDSink sending updates to address (`host:port`).
+// prefix is prepended to every metric name and tags (`key:value`) are added to every update.
+func NewStatsDSink(address, prefix string, tags []string) (*StatsDSink, error) {
+	conn, err := net.Dial("udp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	if prefix != "" && !strings.HasSuffix(prefix, ".") {
+		prefix += "."
+	}
+
+	return &StatsDSink{conn: conn, prefix: prefix, tags: tags}, nil
+}
+
+// Gauge sends gauge update
+func (s *StatsDSink) Gauge(name string, value float64, tags Tags) {
+	s.send(name, value, "g", tags)
+}
+
+// Count sends counter increment
+func (s *StatsDSink) Count(name string, delta float64, tags Tags) {
+	s.send(name, delta, "c", tags)
+}
+
+// Timing sends duration in milliseconds
+func (s *StatsDSink) Timing(name string, d time.Duration, tags Tags) {
+	s.send(name, d.Seconds()*1000, "ms", tags)
+}
+
+// Close closes the connection
+func (s *StatsDSink) Close() error {
+	return s.conn.Close()
+}
+
+// send writes a single update. Errors are ignored: metrics are best effort and UDP
+// writes fail only when the server is unreachable.
+func (s *StatsDSink) send(name string, value float64, kind string, tags Tags) {
+	s.conn.Write([]byte(s.line(name, value, kind, tags)))
+}
+
+func (s *StatsDSink) line(name string, value float64, kind string, tags Tags) string {
+	line := s.prefix + name + ":" + strconv.FormatFloat(value, 'f', -1, 64) + "|" + kind
+
+	all := make([]string, 0, len(s.tags)+len(tags))
+	all = append(all, s.tags...)
+	for key, value := range tags {
+		if value == "" {
+			continue
+		}
+		all = append(all, key+":"+value)
+	}
+
+	if len(all) == 0 {
+		return line
+	}
+
+	sort.Strings(all[len(s.tags):])
+	return line + "|#" + strings.Join(all, ",")
+}
@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsDSink(t *testing.T) {
+	server, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer server.Close()
+
+	sink, err := NewStatsDSink(server.LocalAddr().String(), "bridge", []string{"env:test"})
+	require.NoError(t, err)
+	defer sink.Close()
+
+	read := func() string {
+		buf := make([]byte, 1024)
+		server.SetReadDeadline(time.Now().Add(time.Second))
+		n, _, err := server.ReadFrom(buf)
+		require.NoError(t, err)
+		return string(buf[:n])
+	}
+
+	sink.Gauge("account_balance", 10.5, Tags{"account": "base", "tenant": ""})
+	assert.Equal(t, "bridge.account_balance:10.5|g|#env:test,account:base", read())
+
+	sink.Count("trustlines_created", 1, Tags{"tenant": "acme", "asset_code": "USD"})
+	assert.Equal(t, "bridge.trustlines_created:1|c|#env:test,asset_code:USD,tenant:acme", read())
+
+	sink.Timing("receive_callback_duration_seconds", 1500*time.Millisecond, nil)
+	assert.Equal(t, "bridge.receive_callback_duration_seconds:1500|ms|#env:test", read())
+}
+
+func TestStatsDSinkLine(t *testing.T) {
+	sink := &StatsDSink{}
+	assert.Equal(t, "payments:2|c", sink.line("payments", 2, "c", nil))
+}
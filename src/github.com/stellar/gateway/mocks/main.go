@@ -1,6 +1,7 @@
 package mocks
 
 import (
+	"context"
 	"net/http"
 	"net/url"
 	"time"
@@ -9,6 +10,7 @@ import (
 	"github.com/stellar/gateway/horizon"
 	"github.com/stellar/gateway/protocols/federation"
 	"github.com/stellar/gateway/protocols/stellartoml"
+	"github.com/stellar/gateway/signer"
 	"github.com/stellar/go-stellar-base/xdr"
 	"github.com/stretchr/testify/mock"
 )
@@ -47,6 +49,12 @@ func (m *MockFederationResolver) GetDestination(federationURL, address string) (
 	return a.Get(0).(federation.Response), a.Error(1)
 }
 
+// ReverseResolve is a mocking a method
+func (m *MockFederationResolver) ReverseResolve(accountID, domain string) (response federation.Response, err error) {
+	a := m.Called(accountID, domain)
+	return a.Get(0).(federation.Response), a.Error(1)
+}
+
 // MockHTTPClient ...
 type MockHTTPClient struct {
 	mock.Mock
@@ -64,43 +72,124 @@ func (m *MockHTTPClient) Do(req *http.Request) (resp *http.Response, err error)
 	return a.Get(0).(*http.Response), a.Error(1)
 }
 
-// MockHorizon ...
+// MockHorizon mocks horizon.HorizonInterface. ctx arguments are not passed to Called, so
+// expectations match the other arguments only.
 type MockHorizon struct {
 	mock.Mock
 }
 
 // LoadAccount is a mocking a method
-func (m *MockHorizon) LoadAccount(accountID string) (response horizon.AccountResponse, err error) {
+func (m *MockHorizon) LoadAccount(ctx context.Context, accountID string) (response horizon.AccountResponse, err error) {
 	a := m.Called(accountID)
 	return a.Get(0).(horizon.AccountResponse), a.Error(1)
 }
 
 // LoadMemo is a mocking a method
-func (m *MockHorizon) LoadMemo(p *horizon.PaymentResponse) (err error) {
+func (m *MockHorizon) LoadMemo(ctx context.Context, p *horizon.PaymentResponse) (err error) {
+	a := m.Called(p)
+	return a.Error(0)
+}
+
+// LoadOperation is a mocking a method
+func (m *MockHorizon) LoadOperation(ctx context.Context, operationID string) (payment horizon.PaymentResponse, err error) {
+	a := m.Called(operationID)
+	return a.Get(0).(horizon.PaymentResponse), a.Error(1)
+}
+
+// LoadTransaction is a mocking a method
+func (m *MockHorizon) LoadTransaction(ctx context.Context, hash string) (transaction horizon.TransactionResponse, err error) {
+	a := m.Called(hash)
+	return a.Get(0).(horizon.TransactionResponse), a.Error(1)
+}
+
+// LoadClaimableBalanceID is a mocking a method
+func (m *MockHorizon) LoadClaimableBalanceID(ctx context.Context, p *horizon.PaymentResponse) (err error) {
 	a := m.Called(p)
 	return a.Error(0)
 }
 
+// LoadFeeStats is a mocking a method
+func (m *MockHorizon) LoadFeeStats(ctx context.Context) (response horizon.FeeStatsResponse, err error) {
+	a := m.Called()
+	return a.Get(0).(horizon.FeeStatsResponse), a.Error(1)
+}
+
+// LoadAsset is a mocking a method
+func (m *MockHorizon) LoadAsset(ctx context.Context, assetCode, assetIssuer string) (response horizon.AssetResponse, err error) {
+	a := m.Called(assetCode, assetIssuer)
+	return a.Get(0).(horizon.AssetResponse), a.Error(1)
+}
+
+// LoadRoot is a mocking a method
+func (m *MockHorizon) LoadRoot(ctx context.Context) (response horizon.RootResponse, err error) {
+	a := m.Called()
+	return a.Get(0).(horizon.RootResponse), a.Error(1)
+}
+
+// FindPaths is a mocking a method
+func (m *MockHorizon) FindPaths(ctx context.Context, sourceAsset, destinationAsset, destinationAmount string) (paths []horizon.PathResponse, err error) {
+	a := m.Called(sourceAsset, destinationAsset, destinationAmount)
+	if a.Get(0) == nil {
+		return nil, a.Error(1)
+	}
+	return a.Get(0).([]horizon.PathResponse), a.Error(1)
+}
+
+// LoadOrderBook is a mocking a method
+func (m *MockHorizon) LoadOrderBook(ctx context.Context, sellingAsset, buyingAsset string) (response horizon.OrderBookResponse, err error) {
+	a := m.Called(sellingAsset, buyingAsset)
+	return a.Get(0).(horizon.OrderBookResponse), a.Error(1)
+}
+
+// StreamOperations is a mocking a method
+func (m *MockHorizon) StreamOperations(ctx context.Context, accountID string, cursor *string, onPaymentHandler horizon.PaymentHandler) (err error) {
+	a := m.Called(accountID, cursor, onPaymentHandler)
+	return a.Error(0)
+}
+
 // StreamPayments is a mocking a method
-func (m *MockHorizon) StreamPayments(accountID string, cursor *string, onPaymentHandler horizon.PaymentHandler) (err error) {
+func (m *MockHorizon) StreamPayments(ctx context.Context, accountID string, cursor *string, onPaymentHandler horizon.PaymentHandler) (err error) {
 	a := m.Called(accountID, cursor, onPaymentHandler)
 	return a.Error(0)
 }
 
+// StreamEffects is a mocking a method
+func (m *MockHorizon) StreamEffects(ctx context.Context, cursor *string, onEffectHandler horizon.EffectHandler) (err error) {
+	a := m.Called(cursor, onEffectHandler)
+	return a.Error(0)
+}
+
 // SubmitTransaction is a mocking a method
-func (m *MockHorizon) SubmitTransaction(txeBase64 string) (response horizon.SubmitTransactionResponse, err error) {
+func (m *MockHorizon) SubmitTransaction(ctx context.Context, txeBase64 string) (response horizon.SubmitTransactionResponse, err error) {
 	a := m.Called(txeBase64)
 	return a.Get(0).(horizon.SubmitTransactionResponse), a.Error(1)
 }
 
+// MockRatesProvider ...
+type MockRatesProvider struct {
+	mock.Mock
+}
+
+// GetRate is a mocking a method
+func (m *MockRatesProvider) GetRate(assetCode string) (rate string, err error) {
+	a := m.Called(assetCode)
+	return a.String(0), a.Error(1)
+}
+
+// Currency is a mocking a method
+func (m *MockRatesProvider) Currency() string {
+	a := m.Called()
+	return a.String(0)
+}
+
 // MockRepository ...
 type MockRepository struct {
 	mock.Mock
 }
 
 // GetLastCursorValue is a mocking a method
-func (m *MockRepository) GetLastCursorValue() (cursor *string, err error) {
-	a := m.Called()
+func (m *MockRepository) GetLastCursorValue(receivingAccount string) (cursor *string, err error) {
+	a := m.Called(receivingAccount)
 	return a.Get(0).(*string), a.Error(1)
 }
 
@@ -140,6 +229,363 @@ func (m *MockRepository) GetReceivedPaymentByID(id int64) (*entities.ReceivedPay
 	return a.Get(0).(*entities.ReceivedPayment), a.Error(1)
 }
 
+// GetReceivedPaymentByOperationID is a mocking a method
+func (m *MockRepository) GetReceivedPaymentByOperationID(operationID string) (*entities.ReceivedPayment, error) {
+	a := m.Called(operationID)
+	if a.Get(0) == nil {
+		return nil, a.Error(1)
+	}
+	return a.Get(0).(*entities.ReceivedPayment), a.Error(1)
+}
+
+// GetCustomerByID is a mocking a method
+func (m *MockRepository) GetCustomerByID(id int64) (*entities.Customer, error) {
+	a := m.Called(id)
+	if a.Get(0) == nil {
+		return nil, a.Error(1)
+	}
+	return a.Get(0).(*entities.Customer), a.Error(1)
+}
+
+// GetCustomerByMemo is a mocking a method
+func (m *MockRepository) GetCustomerByMemo(memoType, memo string) (*entities.Customer, error) {
+	a := m.Called(memoType, memo)
+	if a.Get(0) == nil {
+		return nil, a.Error(1)
+	}
+	return a.Get(0).(*entities.Customer), a.Error(1)
+}
+
+// GetCustomers is a mocking a method
+func (m *MockRepository) GetCustomers() ([]entities.Customer, error) {
+	a := m.Called()
+	if a.Get(0) == nil {
+		return nil, a.Error(1)
+	}
+	return a.Get(0).([]entities.Customer), a.Error(1)
+}
+
+// GetPaymentLinksByReceivedPaymentID is a mocking a method
+func (m *MockRepository) GetPaymentLinksByReceivedPaymentID(receivedPaymentID int64) ([]entities.PaymentLink, error) {
+	a := m.Called(receivedPaymentID)
+	if a.Get(0) == nil {
+		return nil, a.Error(1)
+	}
+	return a.Get(0).([]entities.PaymentLink), a.Error(1)
+}
+
+// GetPaymentLinkByTransactionID is a mocking a method
+func (m *MockRepository) GetPaymentLinkByTransactionID(transactionID string) (*entities.PaymentLink, error) {
+	a := m.Called(transactionID)
+	if a.Get(0) == nil {
+		return nil, a.Error(1)
+	}
+	return a.Get(0).(*entities.PaymentLink), a.Error(1)
+}
+
+// GetReceivedPaymentsAfterID is a mocking a method
+func (m *MockRepository) GetReceivedPaymentsAfterID(id int64, limit int) ([]entities.ReceivedPayment, error) {
+	a := m.Called(id, limit)
+	if a.Get(0) == nil {
+		return nil, a.Error(1)
+	}
+	return a.Get(0).([]entities.ReceivedPayment), a.Error(1)
+}
+
+// GetReceivedPayments is a mocking a method
+func (m *MockRepository) GetReceivedPayments(filter entities.ReceivedPaymentFilter) ([]entities.ReceivedPayment, error) {
+	a := m.Called(filter)
+	if a.Get(0) == nil {
+		return nil, a.Error(1)
+	}
+	return a.Get(0).([]entities.ReceivedPayment), a.Error(1)
+}
+
+// GetSentPayments is a mocking a method
+func (m *MockRepository) GetSentPayments(filter entities.SentTransactionFilter) ([]entities.SentTransaction, error) {
+	a := m.Called(filter)
+	if a.Get(0) == nil {
+		return nil, a.Error(1)
+	}
+	return a.Get(0).([]entities.SentTransaction), a.Error(1)
+}
+
+// GetDueCallbackRetries is a mocking a method
+func (m *MockRepository) GetDueCallbackRetries(now time.Time, limit int) ([]entities.CallbackRetry, error) {
+	a := m.Called(now, limit)
+	if a.Get(0) == nil {
+		return nil, a.Error(1)
+	}
+	return a.Get(0).([]entities.CallbackRetry), a.Error(1)
+}
+
+// GetDuePendingCompliance is a mocking a method
+func (m *MockRepository) GetDuePendingCompliance(now time.Time, limit int) ([]entities.PendingCompliance, error) {
+	a := m.Called(now, limit)
+	if a.Get(0) == nil {
+		return nil, a.Error(1)
+	}
+	return a.Get(0).([]entities.PendingCompliance), a.Error(1)
+}
+
+// GetQueueDepths is a mocking a method
+func (m *MockRepository) GetQueueDepths() (entities.QueueDepths, error) {
+	a := m.Called()
+	return a.Get(0).(entities.QueueDepths), a.Error(1)
+}
+
+// Ping is a mocking a method
+func (m *MockRepository) Ping() error {
+	a := m.Called()
+	return a.Error(0)
+}
+
+// CountCallbackAttempts is a mocking a method
+func (m *MockRepository) CountCallbackAttempts(operationID string) (int, error) {
+	a := m.Called(operationID)
+	return a.Int(0), a.Error(1)
+}
+
+// AcquireLease is a mocking a method
+func (m *MockRepository) AcquireLease(name, holder string, now, expiresAt time.Time) (bool, error) {
+	a := m.Called(name, holder, now, expiresAt)
+	return a.Bool(0), a.Error(1)
+}
+
+// ReleaseLease is a mocking a method
+func (m *MockRepository) ReleaseLease(name, holder string) error {
+	a := m.Called(name, holder)
+	return a.Error(0)
+}
+
+// IsCallbackDelivered is a mocking a method
+func (m *MockRepository) IsCallbackDelivered(deliveryID string) (bool, error) {
+	a := m.Called(deliveryID)
+	return a.Bool(0), a.Error(1)
+}
+
+// SaveCallbackDelivery is a mocking a method
+func (m *MockRepository) SaveCallbackDelivery(deliveryID, operationID, url string, deliveredAt time.Time) error {
+	a := m.Called(deliveryID, operationID, url, deliveredAt)
+	return a.Error(0)
+}
+
+// SaveAuthNonce is a mocking a method
+func (m *MockRepository) SaveAuthNonce(signingKey, nonce string, createdAt time.Time) (bool, error) {
+	a := m.Called(signingKey, nonce, createdAt)
+	return a.Bool(0), a.Error(1)
+}
+
+// DeleteAuthNoncesBefore is a mocking a method
+func (m *MockRepository) DeleteAuthNoncesBefore(before time.Time) error {
+	a := m.Called(before)
+	return a.Error(0)
+}
+
+// GetCallbackAttempts is a mocking a method
+func (m *MockRepository) GetCallbackAttempts(filter entities.CallbackAttemptFilter) ([]entities.CallbackAttempt, error) {
+	a := m.Called(filter)
+	if a.Get(0) == nil {
+		return nil, a.Error(1)
+	}
+	return a.Get(0).([]entities.CallbackAttempt), a.Error(1)
+}
+
+// GetSentTransactionByTransactionID is a mocking a method
+func (m *MockRepository) GetSentTransactionByTransactionID(transactionID string) (*entities.SentTransaction, error) {
+	a := m.Called(transactionID)
+	if a.Get(0) == nil {
+		return nil, a.Error(1)
+	}
+	return a.Get(0).(*entities.SentTransaction), a.Error(1)
+}
+
+// GetPendingTransactionByTransactionID is a mocking a method
+func (m *MockRepository) GetPendingTransactionByTransactionID(transactionID string) (*entities.PendingTransaction, error) {
+	a := m.Called(transactionID)
+	if a.Get(0) == nil {
+		return nil, a.Error(1)
+	}
+	return a.Get(0).(*entities.PendingTransaction), a.Error(1)
+}
+
+// UpdatePendingTransaction is a mocking a method
+func (m *MockRepository) UpdatePendingTransaction(pending *entities.PendingTransaction) (bool, error) {
+	a := m.Called(pending)
+	return a.Bool(0), a.Error(1)
+}
+
+// GetLimitCounter is a mocking a method
+func (m *MockRepository) GetLimitCounter(key string, windowStart time.Time) (*entities.LimitCounter, error) {
+	a := m.Called(key, windowStart)
+	if a.Get(0) == nil {
+		return nil, a.Error(1)
+	}
+	return a.Get(0).(*entities.LimitCounter), a.Error(1)
+}
+
+// GetSep31TransactionByPublicID is a mocking a method
+func (m *MockRepository) GetSep31TransactionByPublicID(id string) (*entities.Sep31Transaction, error) {
+	a := m.Called(id)
+	if a.Get(0) == nil {
+		return nil, a.Error(1)
+	}
+	return a.Get(0).(*entities.Sep31Transaction), a.Error(1)
+}
+
+// GetSep31TransactionByMemo is a mocking a method
+func (m *MockRepository) GetSep31TransactionByMemo(memoType, memo string) (*entities.Sep31Transaction, error) {
+	a := m.Called(memoType, memo)
+	if a.Get(0) == nil {
+		return nil, a.Error(1)
+	}
+	return a.Get(0).(*entities.Sep31Transaction), a.Error(1)
+}
+
+// GetTransferTransactionByPublicID is a mocking a method
+func (m *MockRepository) GetTransferTransactionByPublicID(id string) (*entities.TransferTransaction, error) {
+	a := m.Called(id)
+	if a.Get(0) == nil {
+		return nil, a.Error(1)
+	}
+	return a.Get(0).(*entities.TransferTransaction), a.Error(1)
+}
+
+// GetWithdrawalByMemo is a mocking a method
+func (m *MockRepository) GetWithdrawalByMemo(memoType, memo string) (*entities.TransferTransaction, error) {
+	a := m.Called(memoType, memo)
+	if a.Get(0) == nil {
+		return nil, a.Error(1)
+	}
+	return a.Get(0).(*entities.TransferTransaction), a.Error(1)
+}
+
+// GetKYCCustomerByID is a mocking a method
+func (m *MockRepository) GetKYCCustomerByID(id int64) (*entities.KYCCustomer, error) {
+	a := m.Called(id)
+	if a.Get(0) == nil {
+		return nil, a.Error(1)
+	}
+	return a.Get(0).(*entities.KYCCustomer), a.Error(1)
+}
+
+// GetKYCCustomerByAddress is a mocking a method
+func (m *MockRepository) GetKYCCustomerByAddress(address string) (*entities.KYCCustomer, error) {
+	a := m.Called(address)
+	if a.Get(0) == nil {
+		return nil, a.Error(1)
+	}
+	return a.Get(0).(*entities.KYCCustomer), a.Error(1)
+}
+
+// GetSep12CustomerByPublicID is a mocking a method
+func (m *MockRepository) GetSep12CustomerByPublicID(id string) (*entities.Sep12Customer, error) {
+	a := m.Called(id)
+	if a.Get(0) == nil {
+		return nil, a.Error(1)
+	}
+	return a.Get(0).(*entities.Sep12Customer), a.Error(1)
+}
+
+// GetSep12Customer is a mocking a method
+func (m *MockRepository) GetSep12Customer(account, memoType, memo string) (*entities.Sep12Customer, error) {
+	a := m.Called(account, memoType, memo)
+	if a.Get(0) == nil {
+		return nil, a.Error(1)
+	}
+	return a.Get(0).(*entities.Sep12Customer), a.Error(1)
+}
+
+// GetKYCCustomerByRoute is a mocking a method
+func (m *MockRepository) GetKYCCustomerByRoute(route string) (*entities.KYCCustomer, error) {
+	a := m.Called(route)
+	if a.Get(0) == nil {
+		return nil, a.Error(1)
+	}
+	return a.Get(0).(*entities.KYCCustomer), a.Error(1)
+}
+
+// GetKYCCustomers is a mocking a method
+func (m *MockRepository) GetKYCCustomers() ([]entities.KYCCustomer, error) {
+	a := m.Called()
+	if a.Get(0) == nil {
+		return nil, a.Error(1)
+	}
+	return a.Get(0).([]entities.KYCCustomer), a.Error(1)
+}
+
+// GetComplianceTransactionByID is a mocking a method
+func (m *MockRepository) GetComplianceTransactionByID(id int64) (*entities.ComplianceTransaction, error) {
+	a := m.Called(id)
+	if a.Get(0) == nil {
+		return nil, a.Error(1)
+	}
+	return a.Get(0).(*entities.ComplianceTransaction), a.Error(1)
+}
+
+// GetComplianceTransactionByTransactionID is a mocking a method
+func (m *MockRepository) GetComplianceTransactionByTransactionID(direction, transactionID string) (*entities.ComplianceTransaction, error) {
+	a := m.Called(direction, transactionID)
+	if a.Get(0) == nil {
+		return nil, a.Error(1)
+	}
+	return a.Get(0).(*entities.ComplianceTransaction), a.Error(1)
+}
+
+// GetComplianceTransactions is a mocking a method
+func (m *MockRepository) GetComplianceTransactions(filter entities.ComplianceTransactionFilter) ([]entities.ComplianceTransaction, error) {
+	a := m.Called(filter)
+	if a.Get(0) == nil {
+		return nil, a.Error(1)
+	}
+	return a.Get(0).([]entities.ComplianceTransaction), a.Error(1)
+}
+
+// GetFederationRecord is a mocking a method
+func (m *MockRepository) GetFederationRecord(query, name string) (*entities.FederationRecord, error) {
+	a := m.Called(query, name)
+	if a.Get(0) == nil {
+		return nil, a.Error(1)
+	}
+	return a.Get(0).(*entities.FederationRecord), a.Error(1)
+}
+
+// GetAPIKeyByID is a mocking a method
+func (m *MockRepository) GetAPIKeyByID(id int64) (*entities.APIKey, error) {
+	a := m.Called(id)
+	if a.Get(0) == nil {
+		return nil, a.Error(1)
+	}
+	return a.Get(0).(*entities.APIKey), a.Error(1)
+}
+
+// GetAPIKeyByHash is a mocking a method
+func (m *MockRepository) GetAPIKeyByHash(keyHash string) (*entities.APIKey, error) {
+	a := m.Called(keyHash)
+	if a.Get(0) == nil {
+		return nil, a.Error(1)
+	}
+	return a.Get(0).(*entities.APIKey), a.Error(1)
+}
+
+// GetAPIKeys is a mocking a method
+func (m *MockRepository) GetAPIKeys() ([]entities.APIKey, error) {
+	a := m.Called()
+	if a.Get(0) == nil {
+		return nil, a.Error(1)
+	}
+	return a.Get(0).([]entities.APIKey), a.Error(1)
+}
+
+// GetSentTransactionByIdempotencyKey is a mocking a method
+func (m *MockRepository) GetSentTransactionByIdempotencyKey(key string) (*entities.SentTransaction, error) {
+	a := m.Called(key)
+	if a.Get(0) == nil {
+		return nil, a.Error(1)
+	}
+	return a.Get(0).(*entities.SentTransaction), a.Error(1)
+}
+
 // MockSignerVerifier ...
 type MockSignerVerifier struct {
 	mock.Mock
@@ -174,23 +620,36 @@ func (m *MockStellartomlResolver) GetStellarTomlByAddress(address string) (stell
 	return a.Get(0).(stellartoml.StellarToml), a.Error(1)
 }
 
-// MockTransactionSubmitter ...
+// MockTransactionSubmitter mocks submitter.TransactionSubmitterInterface. ctx arguments are not
+// passed to Called, like in MockHorizon.
 type MockTransactionSubmitter struct {
 	mock.Mock
 }
 
 // SubmitTransaction is a mocking a method
-func (ts *MockTransactionSubmitter) SubmitTransaction(seed string, operation, memo interface{}) (response horizon.SubmitTransactionResponse, err error) {
+func (ts *MockTransactionSubmitter) SubmitTransaction(ctx context.Context, seed string, operation, memo interface{}) (response horizon.SubmitTransactionResponse, err error) {
 	a := ts.Called(seed, operation, memo)
 	return a.Get(0).(horizon.SubmitTransactionResponse), a.Error(1)
 }
 
 // SignAndSubmitRawTransaction is a mocking a method
-func (ts *MockTransactionSubmitter) SignAndSubmitRawTransaction(seed string, tx *xdr.Transaction) (response horizon.SubmitTransactionResponse, err error) {
+func (ts *MockTransactionSubmitter) SignAndSubmitRawTransaction(ctx context.Context, seed string, tx *xdr.Transaction) (response horizon.SubmitTransactionResponse, err error) {
 	a := ts.Called(seed, tx)
 	return a.Get(0).(horizon.SubmitTransactionResponse), a.Error(1)
 }
 
+// ClaimClaimableBalance is a mocking a method
+func (ts *MockTransactionSubmitter) ClaimClaimableBalance(ctx context.Context, seed, balanceID string) (response horizon.SubmitTransactionResponse, err error) {
+	a := ts.Called(seed, balanceID)
+	return a.Get(0).(horizon.SubmitTransactionResponse), a.Error(1)
+}
+
+// CreateAccount is a mocking a method
+func (ts *MockTransactionSubmitter) CreateAccount(ctx context.Context, seed string, newAccount signer.Signer, startingBalance xdr.Int64, assets []xdr.Asset, sponsored bool) (response horizon.SubmitTransactionResponse, err error) {
+	a := ts.Called(seed, newAccount, startingBalance, assets, sponsored)
+	return a.Get(0).(horizon.SubmitTransactionResponse), a.Error(1)
+}
+
 // PredefinedTime is a time.Time object that will be returned by Now() function
 var PredefinedTime time.Time
 
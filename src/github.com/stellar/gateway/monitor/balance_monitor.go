@@ -0,0 +1,115 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/metrics"
+	"github.com/stellar/go/amount"
+)
+
+// BalanceMonitor checks XLM balances of accounts and sends an alert when a balance
+// falls below account's MinBalance. A single alert is sent until the balance is restored.
+type BalanceMonitor struct {
+	horizon  horizon.HorizonInterface
+	accounts []Account
+	interval time.Duration
+	low      map[string]bool
+	log      *logrus.Entry
+}
+
+// NewBalanceMonitor creates a new BalanceMonitor. Accounts without MinBalance are skipped.
+func NewBalanceMonitor(h horizon.HorizonInterface, accounts []Account, interval time.Duration) *BalanceMonitor {
+	m := &BalanceMonitor{
+		horizon:  h,
+		interval: interval,
+		low:      make(map[string]bool),
+		log:      logrus.WithFields(logrus.Fields{"service": "BalanceMonitor"}),
+	}
+
+	for _, account := range accounts {
+		if account.MinBalance != "" {
+			m.accounts = append(m.accounts, account)
+		}
+	}
+	return m
+}
+
+// Start starts checking balances every interval
+func (m *BalanceMonitor) Start() {
+	if len(m.accounts) == 0 {
+		return
+	}
+
+	go func() {
+		for {
+			m.Check()
+			time.Sleep(m.interval)
+		}
+	}()
+}
+
+// Check checks balances of all accounts once
+func (m *BalanceMonitor) Check() {
+	for _, account := range m.accounts {
+		err := m.checkAccount(account)
+		if err != nil {
+			m.log.WithFields(logrus.Fields{
+				"err":        err,
+				"account_id": account.AccountID,
+			}).Error("Error checking account balance")
+		}
+	}
+}
+
+func (m *BalanceMonitor) checkAccount(account Account) error {
+	response, err := m.horizon.LoadAccount(context.Background(), account.AccountID)
+	if err != nil {
+		return err
+	}
+
+	balance, err := amount.Parse(response.NativeBalance())
+	if err != nil {
+		return err
+	}
+
+	minBalance, err := amount.Parse(account.MinBalance)
+	if err != nil {
+		return err
+	}
+
+	tags := metrics.Tags{"account": account.Role, "account_id": account.AccountID, "tenant": account.Tenant}
+	value, _ := strconv.ParseFloat(amount.String(balance), 64)
+	metrics.SetGauge("account_balance", value, tags)
+
+	key := account.Tenant + "/" + account.AccountID
+	if balance >= minBalance {
+		if m.low[key] {
+			m.log.WithFields(logrus.Fields{"account_id": account.AccountID}).Info("Account balance restored")
+		}
+		m.low[key] = false
+		metrics.SetGauge("account_balance_low", 0, tags)
+		return nil
+	}
+
+	metrics.SetGauge("account_balance_low", 1, tags)
+	if m.low[key] {
+		return nil
+	}
+	m.low[key] = true
+
+	return account.Alerter.Alert(Alert{
+		Type:    "low_balance",
+		Account: account,
+		Message: fmt.Sprintf(
+			"Balance of %s account is %s XLM, below minimum of %s XLM",
+			account.Role,
+			amount.String(balance),
+			account.MinBalance,
+		),
+	})
+}
@@ -0,0 +1,62 @@
+package monitor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingAlerter struct {
+	alerts []Alert
+}
+
+func (a *recordingAlerter) Alert(alert Alert) error {
+	a.alerts = append(a.alerts, alert)
+	return nil
+}
+
+func TestBalanceMonitor(t *testing.T) {
+	mockHorizon := new(mocks.MockHorizon)
+	alerter := &recordingAlerter{}
+
+	accountID := "GAJBUSUTGTS3MAU2KP6MWJFJACDN4ZJ5YCET23U6XYZZ7WUD2OYQQUR2"
+	monitor := NewBalanceMonitor(mockHorizon, []Account{
+		{Role: "base", AccountID: accountID, MinBalance: "100", Alerter: alerter},
+		{Role: "issuing", AccountID: "GCOGCYU77DLEVYCXDQM7F32M5PCKES6VU3Z5GURF6U6OA5LFOVTRYPOX", Alerter: alerter},
+	}, time.Minute)
+
+	balance := func(b string) horizon.AccountResponse {
+		return horizon.AccountResponse{
+			Balances: []horizon.Balance{
+				{AssetType: "credit_alphanum4", AssetCode: "USD", Balance: "1000.0000000"},
+				{AssetType: "native", Balance: b},
+			},
+		}
+	}
+
+	mockHorizon.On("LoadAccount", accountID).Return(balance("150.0000000"), nil).Once()
+	monitor.Check()
+	assert.Len(t, alerter.alerts, 0)
+
+	// Alert is sent once while balance is low
+	mockHorizon.On("LoadAccount", accountID).Return(balance("99.9999999"), nil).Twice()
+	monitor.Check()
+	monitor.Check()
+	if assert.Len(t, alerter.alerts, 1) {
+		assert.Equal(t, "low_balance", alerter.alerts[0].Type)
+		assert.Equal(t, "base", alerter.alerts[0].Account.Role)
+		assert.Equal(t, "Balance of base account is 99.9999999 XLM, below minimum of 100 XLM", alerter.alerts[0].Message)
+	}
+
+	// New alert is sent after balance has been restored and fell again
+	mockHorizon.On("LoadAccount", accountID).Return(balance("100.0000000"), nil).Once()
+	monitor.Check()
+	mockHorizon.On("LoadAccount", accountID).Return(balance("1.0000000"), nil).Once()
+	monitor.Check()
+	assert.Len(t, alerter.alerts, 2)
+
+	mockHorizon.AssertExpectations(t)
+}
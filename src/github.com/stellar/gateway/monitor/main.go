@@ -0,0 +1,133 @@
+// Package monitor periodically checks Stellar accounts used by the bridge server
+// and sends alerts when they need operator's attention.
+package monitor
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/signer"
+	"github.com/stellar/go/support/errors"
+)
+
+// Account is a Stellar account observed by monitors
+type Account struct {
+	// Role is a name of the account in config: base, authorizing, issuing or receiving
+	Role       string
+	AccountID  string
+	Tenant     string
+	MinBalance string
+	Alerter    AlerterInterface
+}
+
+// Accounts returns all accounts configured in c. Alerts are sent to `callbacks.alert` of c.
+// signers returns addresses of key references.
+func Accounts(c *config.Config, signers *signer.Signers) (accounts []Account) {
+	alerter := NewHTTPAlerter(c.Callbacks.Alert)
+
+	add := func(role, accountID, minBalance string) {
+		if accountID == "" {
+			return
+		}
+		accounts = append(accounts, Account{
+			Role:       role,
+			AccountID:  accountID,
+			Tenant:     c.Tenant,
+			MinBalance: minBalance,
+			Alerter:    alerter,
+		})
+	}
+
+	add("base", seedAddress(signers, c.Accounts.BaseSeed), c.Monitor.MinBalance.Base)
+	add("authorizing", seedAddress(signers, c.Accounts.AuthorizingSeed), c.Monitor.MinBalance.Authorizing)
+	add("issuing", c.Accounts.IssuingAccountID, c.Monitor.MinBalance.Issuing)
+	add("receiving", c.Accounts.ReceivingAccountID, c.Monitor.MinBalance.Receiving)
+	return
+}
+
+func seedAddress(signers *signer.Signers, seed string) string {
+	if seed == "" {
+		return ""
+	}
+	s, err := signers.Signer(seed)
+	if err != nil {
+		return ""
+	}
+	return s.Address()
+}
+
+// Alert represents an alert sent to `callbacks.alert`
+type Alert struct {
+	Type    string
+	Account Account
+	Message string
+}
+
+// ToValues creates url.Values from alert
+func (a Alert) ToValues() url.Values {
+	return url.Values{
+		"type":       {a.Type},
+		"account":    {a.Account.Role},
+		"account_id": {a.Account.AccountID},
+		"tenant":     {a.Account.Tenant},
+		"message":    {a.Message},
+	}
+}
+
+// AlerterInterface helps mocking HTTPAlerter
+type AlerterInterface interface {
+	Alert(alert Alert) error
+}
+
+// HTTP represents an http client that an alerter can use to make HTTP requests.
+type HTTP interface {
+	PostForm(url string, data url.Values) (resp *http.Response, err error)
+}
+
+// HTTPAlerter logs alerts and sends them to an HTTP callback if URL is set
+type HTTPAlerter struct {
+	URL    string
+	client HTTP
+	log    *logrus.Entry
+}
+
+const alertTimeout = 10 * time.Second
+
+// NewHTTPAlerter creates a new HTTPAlerter
+func NewHTTPAlerter(url string) *HTTPAlerter {
+	return &HTTPAlerter{
+		URL:    url,
+		client: &http.Client{Timeout: alertTimeout},
+		log:    logrus.WithFields(logrus.Fields{"service": "Alerter"}),
+	}
+}
+
+// Alert sends an alert
+func (a *HTTPAlerter) Alert(alert Alert) error {
+	a.log.WithFields(logrus.Fields{
+		"type":       alert.Type,
+		"account":    alert.Account.Role,
+		"account_id": alert.Account.AccountID,
+		"tenant":     alert.Account.Tenant,
+	}).Warn(alert.Message)
+
+	if a.URL == "" {
+		return nil
+	}
+
+	resp, err := a.client.PostForm(a.URL, alert.ToValues())
+	if err != nil {
+		return errors.Wrap(err, "http request errored")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("alert callback response status code indicates error (%d)", resp.StatusCode)
+	}
+
+	return nil
+}
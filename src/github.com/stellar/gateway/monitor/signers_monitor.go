@@ -0,0 +1,195 @@
+package monitor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/metrics"
+)
+
+// Policy contains expected signers (key => weight) and thresholds of an account
+type Policy struct {
+	Signers    map[string]int32   `json:"signers"`
+	Thresholds horizon.Thresholds `json:"thresholds"`
+}
+
+// NewPolicy creates Policy from the current state of an account
+func NewPolicy(account horizon.AccountResponse) Policy {
+	policy := Policy{
+		Signers:    make(map[string]int32),
+		Thresholds: account.Thresholds,
+	}
+	for _, signer := range account.Signers {
+		policy.Signers[signer.ID()] = signer.Weight
+	}
+	return policy
+}
+
+// Diff returns a list of differences between expected policy p and actual policy
+func (p Policy) Diff(actual Policy) (diff []string) {
+	for key, weight := range p.Signers {
+		actualWeight, ok := actual.Signers[key]
+		if !ok {
+			diff = append(diff, fmt.Sprintf("signer %s removed", key))
+		} else if actualWeight != weight {
+			diff = append(diff, fmt.Sprintf("signer %s weight changed from %d to %d", key, weight, actualWeight))
+		}
+	}
+
+	for key, weight := range actual.Signers {
+		if _, ok := p.Signers[key]; !ok {
+			diff = append(diff, fmt.Sprintf("signer %s added with weight %d", key, weight))
+		}
+	}
+	sort.Strings(diff)
+
+	if p.Thresholds != actual.Thresholds {
+		diff = append(diff, fmt.Sprintf(
+			"thresholds changed from %d/%d/%d to %d/%d/%d",
+			p.Thresholds.LowThreshold, p.Thresholds.MedThreshold, p.Thresholds.HighThreshold,
+			actual.Thresholds.LowThreshold, actual.Thresholds.MedThreshold, actual.Thresholds.HighThreshold,
+		))
+	}
+	return
+}
+
+// SignersMonitor compares signers and thresholds of accounts with a snapshot
+// saved in a JSON file (account ID => Policy). Accounts missing in the snapshot
+// are added to it using their current state. The snapshot is never updated
+// when a change is detected: operator must update the file to accept it.
+type SignersMonitor struct {
+	horizon      horizon.HorizonInterface
+	accounts     []Account
+	snapshotPath string
+	snapshot     map[string]Policy
+	// reported contains the last reported drift of every account to prevent sending duplicate alerts
+	reported map[string]string
+	interval time.Duration
+	log      *logrus.Entry
+}
+
+// NewSignersMonitor creates a new SignersMonitor and loads the snapshot file if it exists
+func NewSignersMonitor(
+	h horizon.HorizonInterface,
+	accounts []Account,
+	snapshotPath string,
+	interval time.Duration,
+) (*SignersMonitor, error) {
+	m := &SignersMonitor{
+		horizon:      h,
+		snapshotPath: snapshotPath,
+		snapshot:     make(map[string]Policy),
+		reported:     make(map[string]string),
+		interval:     interval,
+		log:          logrus.WithFields(logrus.Fields{"service": "SignersMonitor"}),
+	}
+
+	// The same account can be used by many tenants
+	seen := make(map[string]bool)
+	for _, account := range accounts {
+		if !seen[account.AccountID] {
+			seen[account.AccountID] = true
+			m.accounts = append(m.accounts, account)
+		}
+	}
+
+	data, err := ioutil.ReadFile(snapshotPath)
+	if os.IsNotExist(err) {
+		return m, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(data, &m.snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot parse signers snapshot %s: %s", snapshotPath, err)
+	}
+
+	return m, nil
+}
+
+// Start starts checking accounts every interval
+func (m *SignersMonitor) Start() {
+	go func() {
+		for {
+			m.Check()
+			time.Sleep(m.interval)
+		}
+	}()
+}
+
+// Check checks all accounts once
+func (m *SignersMonitor) Check() {
+	snapshotChanged := false
+
+	for _, account := range m.accounts {
+		response, err := m.horizon.LoadAccount(context.Background(), account.AccountID)
+		if err != nil {
+			m.log.WithFields(logrus.Fields{
+				"err":        err,
+				"account_id": account.AccountID,
+			}).Error("Error loading account")
+			continue
+		}
+
+		actual := NewPolicy(response)
+		expected, ok := m.snapshot[account.AccountID]
+		if !ok {
+			m.log.WithFields(logrus.Fields{"account_id": account.AccountID}).Info("Adding account to signers snapshot")
+			m.snapshot[account.AccountID] = actual
+			snapshotChanged = true
+			continue
+		}
+
+		m.checkAccount(account, expected, actual)
+	}
+
+	if snapshotChanged {
+		err := m.saveSnapshot()
+		if err != nil {
+			m.log.WithFields(logrus.Fields{"err": err}).Error("Error saving signers snapshot")
+		}
+	}
+}
+
+func (m *SignersMonitor) checkAccount(account Account, expected, actual Policy) {
+	tags := metrics.Tags{"account": account.Role, "account_id": account.AccountID, "tenant": account.Tenant}
+
+	diff := strings.Join(expected.Diff(actual), ", ")
+	if diff == "" {
+		metrics.SetGauge("account_signers_drift", 0, tags)
+		m.reported[account.AccountID] = ""
+		return
+	}
+
+	metrics.SetGauge("account_signers_drift", 1, tags)
+	if m.reported[account.AccountID] == diff {
+		return
+	}
+	m.reported[account.AccountID] = diff
+
+	err := account.Alerter.Alert(Alert{
+		Type:    "signers_changed",
+		Account: account,
+		Message: fmt.Sprintf("Signers of %s account do not match the snapshot: %s", account.Role, diff),
+	})
+	if err != nil {
+		m.log.WithFields(logrus.Fields{"err": err}).Error("Error sending alert")
+	}
+}
+
+func (m *SignersMonitor) saveSnapshot() error {
+	data, err := json.MarshalIndent(m.snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(m.snapshotPath, data, 0600)
+}
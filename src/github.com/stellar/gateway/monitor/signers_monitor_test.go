@@ -0,0 +1,66 @@
+package monitor
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignersMonitor(t *testing.T) {
+	dir, err := ioutil.TempDir("", "signers")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	snapshotPath := filepath.Join(dir, "snapshot.json")
+
+	mockHorizon := new(mocks.MockHorizon)
+	alerter := &recordingAlerter{}
+	accountID := "GAJBUSUTGTS3MAU2KP6MWJFJACDN4ZJ5YCET23U6XYZZ7WUD2OYQQUR2"
+	accounts := []Account{{Role: "base", AccountID: accountID, Alerter: alerter}}
+
+	original := horizon.AccountResponse{
+		Signers:    []horizon.Signer{{PublicKey: accountID, Weight: 1}},
+		Thresholds: horizon.Thresholds{LowThreshold: 0, MedThreshold: 1, HighThreshold: 1},
+	}
+	changed := horizon.AccountResponse{
+		Signers: []horizon.Signer{
+			{Key: accountID, Weight: 1},
+			{Key: "GCOGCYU77DLEVYCXDQM7F32M5PCKES6VU3Z5GURF6U6OA5LFOVTRYPOX", Weight: 1},
+		},
+		Thresholds: horizon.Thresholds{LowThreshold: 0, MedThreshold: 1, HighThreshold: 2},
+	}
+
+	// First check saves the snapshot
+	monitor, err := NewSignersMonitor(mockHorizon, accounts, snapshotPath, time.Minute)
+	require.NoError(t, err)
+	mockHorizon.On("LoadAccount", accountID).Return(original, nil).Once()
+	monitor.Check()
+	assert.Len(t, alerter.alerts, 0)
+	_, err = os.Stat(snapshotPath)
+	assert.NoError(t, err)
+
+	// Snapshot is loaded after restart and a change is reported once
+	monitor, err = NewSignersMonitor(mockHorizon, accounts, snapshotPath, time.Minute)
+	require.NoError(t, err)
+	mockHorizon.On("LoadAccount", accountID).Return(changed, nil).Twice()
+	monitor.Check()
+	monitor.Check()
+	if assert.Len(t, alerter.alerts, 1) {
+		assert.Equal(t, "signers_changed", alerter.alerts[0].Type)
+		assert.Equal(
+			t,
+			"Signers of base account do not match the snapshot: "+
+				"signer GCOGCYU77DLEVYCXDQM7F32M5PCKES6VU3Z5GURF6U6OA5LFOVTRYPOX added with weight 1, "+
+				"thresholds changed from 0/1/1 to 0/1/2",
+			alerter.alerts[0].Message,
+		)
+	}
+
+	mockHorizon.AssertExpectations(t)
+}
@@ -0,0 +1,196 @@
+// Package mtls configures mutual TLS between bridge and compliance servers. The bridge server
+// presents a client certificate and pins CAs of the compliance server certificate, the
+// compliance server rejects requests to its internal endpoints without a valid client
+// certificate.
+package mtls
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// ClientConfig contains values of `compliance_tls` config group of bridge server
+type ClientConfig struct {
+	// CAFile is a PEM file with certificates of CAs the compliance server certificate must be
+	// signed by. System roots are not trusted when it's set.
+	CAFile string `mapstructure:"ca_file"`
+	// CertificateFile and PrivateKeyFile are a client certificate sent to the compliance server
+	CertificateFile string `mapstructure:"certificate_file"`
+	PrivateKeyFile  string `mapstructure:"private_key_file"`
+	// ServerName is a name verified in the compliance server certificate (default: host of
+	// `compliance` URL)
+	ServerName string `mapstructure:"server_name"`
+}
+
+// Enabled returns true when requests to the compliance server use ClientConfig
+func (c ClientConfig) Enabled() bool {
+	return c.CAFile != "" || c.CertificateFile != "" || c.PrivateKeyFile != "" || c.ServerName != ""
+}
+
+// Validate validates the config and loads certificates
+func (c ClientConfig) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+
+	if (c.CertificateFile == "") != (c.PrivateKeyFile == "") {
+		return errors.New("compliance_tls.certificate_file and compliance_tls.private_key_file params must be set together")
+	}
+
+	_, err := c.TLSConfig()
+	if err != nil {
+		return fmt.Errorf("Invalid compliance_tls params: %s", err)
+	}
+	return nil
+}
+
+// TLSConfig loads certificates and returns a client tls.Config
+func (c ClientConfig) TLSConfig() (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: c.ServerName,
+	}
+
+	if c.CAFile != "" {
+		pool, err := loadCertPool(c.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		config.RootCAs = pool
+	}
+
+	if c.CertificateFile != "" {
+		certificate, err := tls.LoadX509KeyPair(c.CertificateFile, c.PrivateKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+
+	return config, nil
+}
+
+// NewClient creates an http.Client sending requests using TLSConfig
+func (c ClientConfig) NewClient(timeout time.Duration) (*http.Client, error) {
+	config, err := c.TLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: config,
+		},
+	}, nil
+}
+
+// ServerConfig contains values of `internal_tls` config group of compliance server
+type ServerConfig struct {
+	// CertificateFile and PrivateKeyFile are a certificate of the internal server
+	CertificateFile string `mapstructure:"certificate_file"`
+	PrivateKeyFile  string `mapstructure:"private_key_file"`
+	// ClientCAFile is a PEM file with certificates of CAs client certificates must be signed
+	// by. Requests without a valid client certificate are rejected when it's set.
+	ClientCAFile string `mapstructure:"client_ca_file"`
+	// ClientNames limits accepted client certificates to ones with one of the names in
+	// the common name or DNS names. All certificates signed by ClientCAFile are accepted
+	// when empty.
+	ClientNames []string `mapstructure:"client_names"`
+}
+
+// Enabled returns true when the internal server is served over TLS
+func (c ServerConfig) Enabled() bool {
+	return c.CertificateFile != "" || c.PrivateKeyFile != ""
+}
+
+// Validate validates the config and loads certificates
+func (c ServerConfig) Validate() error {
+	if !c.Enabled() {
+		if c.ClientCAFile != "" || len(c.ClientNames) > 0 {
+			return errors.New("internal_tls.certificate_file and internal_tls.private_key_file params are required")
+		}
+		return nil
+	}
+
+	if c.CertificateFile == "" || c.PrivateKeyFile == "" {
+		return errors.New("internal_tls.certificate_file and internal_tls.private_key_file params must be set together")
+	}
+
+	if len(c.ClientNames) > 0 && c.ClientCAFile == "" {
+		return errors.New("internal_tls.client_names param requires internal_tls.client_ca_file")
+	}
+
+	_, err := c.TLSConfig()
+	if err != nil {
+		return fmt.Errorf("Invalid internal_tls params: %s", err)
+	}
+	return nil
+}
+
+// TLSConfig loads certificates and returns a server tls.Config requiring client
+// certificates when ClientCAFile is set
+func (c ServerConfig) TLSConfig() (*tls.Config, error) {
+	certificate, err := tls.LoadX509KeyPair(c.CertificateFile, c.PrivateKeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{certificate},
+	}
+
+	if c.ClientCAFile != "" {
+		pool, err := loadCertPool(c.ClientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+		config.ClientCAs = pool
+	}
+
+	if len(c.ClientNames) > 0 {
+		config.VerifyPeerCertificate = c.verifyClientName
+	}
+
+	return config, nil
+}
+
+// verifyClientName checks if a verified client certificate has one of ClientNames
+func (c ServerConfig) verifyClientName(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+	if len(verifiedChains) == 0 || len(verifiedChains[0]) == 0 {
+		return errors.New("client certificate is required")
+	}
+
+	certificate := verifiedChains[0][0]
+	names := append([]string{certificate.Subject.CommonName}, certificate.DNSNames...)
+	for _, name := range names {
+		for _, allowed := range c.ClientNames {
+			if name == allowed {
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("client certificate %s is not allowed", certificate.Subject.CommonName)
+}
+
+func loadCertPool(file string) (*x509.CertPool, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no certificates found in %s", file)
+	}
+	return pool, nil
+}
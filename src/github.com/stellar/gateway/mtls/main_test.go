@@ -0,0 +1,153 @@
+package mtls
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCA struct {
+	certificate *x509.Certificate
+	key         *ecdsa.PrivateKey
+	dir         string
+	serial      int64
+}
+
+func newTestCA(t *testing.T, dir, name string) *testCA {
+	ca := &testCA{dir: dir}
+	ca.key, ca.certificate = ca.issue(t, name, &x509.Certificate{
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	})
+	ca.write(t, name+".pem", "CERTIFICATE", ca.certificate.Raw)
+	return ca
+}
+
+// issue creates a certificate signed by the CA (self-signed when the CA has no certificate yet)
+func (ca *testCA) issue(t *testing.T, name string, template *x509.Certificate) (*ecdsa.PrivateKey, *x509.Certificate) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	ca.serial++
+	template.SerialNumber = big.NewInt(ca.serial)
+	template.Subject = pkix.Name{CommonName: name}
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+
+	parent, signer := template, key
+	if ca.certificate != nil {
+		parent, signer = ca.certificate, ca.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, signer)
+	require.NoError(t, err)
+	certificate, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return key, certificate
+}
+
+// issueFiles creates a certificate and returns paths of its certificate and key files
+func (ca *testCA) issueFiles(t *testing.T, name string, template *x509.Certificate) (string, string) {
+	key, certificate := ca.issue(t, name, template)
+	der, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return ca.write(t, name+".pem", "CERTIFICATE", certificate.Raw), ca.write(t, name+".key", "EC PRIVATE KEY", der)
+}
+
+func (ca *testCA) write(t *testing.T, name, blockType string, der []byte) string {
+	path := filepath.Join(ca.dir, name)
+	err := ioutil.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600)
+	require.NoError(t, err)
+	return path
+}
+
+func TestMutualTLS(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mtls")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ca := newTestCA(t, dir, "ca")
+	otherCA := newTestCA(t, dir, "other-ca")
+
+	serverCertificate, serverKey := ca.issueFiles(t, "compliance", &x509.Certificate{
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1")},
+	})
+	clientTemplate := func() *x509.Certificate {
+		return &x509.Certificate{ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}}
+	}
+	bridgeCertificate, bridgeKey := ca.issueFiles(t, "bridge", clientTemplate())
+	unknownCertificate, unknownKey := ca.issueFiles(t, "unknown", clientTemplate())
+	otherCertificate, otherKey := otherCA.issueFiles(t, "bridge-other", clientTemplate())
+
+	serverConfig := ServerConfig{
+		CertificateFile: serverCertificate,
+		PrivateKeyFile:  serverKey,
+		ClientCAFile:    filepath.Join(dir, "ca.pem"),
+		ClientNames:     []string{"bridge"},
+	}
+	require.NoError(t, serverConfig.Validate())
+	tlsConfig, err := serverConfig.TLSConfig()
+	require.NoError(t, err)
+
+	s := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	s.TLS = tlsConfig
+	s.StartTLS()
+	defer s.Close()
+
+	tests := []struct {
+		name   string
+		config ClientConfig
+		ok     bool
+	}{
+		{"valid", ClientConfig{CAFile: filepath.Join(dir, "ca.pem"), CertificateFile: bridgeCertificate, PrivateKeyFile: bridgeKey}, true},
+		{"no client certificate", ClientConfig{CAFile: filepath.Join(dir, "ca.pem")}, false},
+		{"name not allowed", ClientConfig{CAFile: filepath.Join(dir, "ca.pem"), CertificateFile: unknownCertificate, PrivateKeyFile: unknownKey}, false},
+		{"unknown client CA", ClientConfig{CAFile: filepath.Join(dir, "ca.pem"), CertificateFile: otherCertificate, PrivateKeyFile: otherKey}, false},
+		{"pinned CA", ClientConfig{CAFile: filepath.Join(dir, "other-ca.pem"), CertificateFile: bridgeCertificate, PrivateKeyFile: bridgeKey}, false},
+	}
+
+	for _, test := range tests {
+		require.NoError(t, test.config.Validate(), test.name)
+		client, err := test.config.NewClient(5 * time.Second)
+		require.NoError(t, err, test.name)
+
+		resp, err := client.Get(s.URL)
+		if test.ok {
+			if assert.NoError(t, err, test.name) {
+				assert.Equal(t, http.StatusOK, resp.StatusCode, test.name)
+				resp.Body.Close()
+			}
+		} else {
+			assert.Error(t, err, test.name)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, ClientConfig{}.Validate())
+	assert.Error(t, ClientConfig{CertificateFile: "client.pem"}.Validate())
+	assert.Error(t, ClientConfig{CAFile: "/nonexistent/ca.pem"}.Validate())
+
+	assert.NoError(t, ServerConfig{}.Validate())
+	assert.Error(t, ServerConfig{ClientCAFile: "ca.pem"}.Validate())
+	assert.Error(t, ServerConfig{CertificateFile: "server.pem"}.Validate())
+	assert.Error(t, ServerConfig{CertificateFile: "server.pem", PrivateKeyFile: "server.key", ClientNames: []string{"bridge"}}.Validate())
+	assert.Error(t, ServerConfig{CertificateFile: "/nonexistent/server.pem", PrivateKeyFile: "/nonexistent/server.key"}.Validate())
+}
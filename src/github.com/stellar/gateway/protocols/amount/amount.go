@@ -0,0 +1,90 @@
+package amount
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/stellar/go-stellar-base/xdr"
+)
+
+// Precision is a number of digits after the decimal point supported by Stellar
+const Precision = 7
+
+var (
+	// ErrMalformed is returned when amount is not a decimal number
+	ErrMalformed = errors.New("amount must be a decimal number like 100 or 12.5")
+	// ErrExponent is returned when amount is in exponent notation
+	ErrExponent = errors.New("exponent notation is not supported")
+	// ErrNegative is returned when amount is negative
+	ErrNegative = errors.New("amount cannot be negative")
+	// ErrTooPrecise is returned when amount has more than Precision digits after the decimal point
+	ErrTooPrecise = errors.New("amount cannot have more than 7 digits after the decimal point")
+	// ErrTooLarge is returned when amount does not fit in 64 bits
+	ErrTooLarge = errors.New("amount is too large")
+)
+
+var amountRegexp = regexp.MustCompile(`^([0-9]+)(?:\.([0-9]*))?$`)
+
+// Amount is an amount of an asset represented as a number of stroops (1/10^7 of a unit)
+type Amount int64
+
+// Parse parses a decimal amount, ex. "100" or "12.5". Unlike amount.Parse of go-stellar-base
+// it does not accept exponent notation or fractions and does not round amounts with more than
+// 7 digits after the decimal point. Trailing zeros beyond the 7th digit are accepted.
+func Parse(v string) (Amount, error) {
+	if strings.ContainsAny(v, "eE") {
+		return 0, ErrExponent
+	}
+
+	if strings.HasPrefix(v, "-") {
+		return 0, ErrNegative
+	}
+
+	matches := amountRegexp.FindStringSubmatch(v)
+	if matches == nil {
+		return 0, ErrMalformed
+	}
+
+	integer, fraction := matches[1], matches[2]
+
+	if len(fraction) > Precision {
+		if strings.Trim(fraction[Precision:], "0") != "" {
+			return 0, ErrTooPrecise
+		}
+		fraction = fraction[:Precision]
+	}
+
+	fraction += strings.Repeat("0", Precision-len(fraction))
+
+	stroops, err := strconv.ParseInt(integer+fraction, 10, 64)
+	if err != nil {
+		return 0, ErrTooLarge
+	}
+
+	return Amount(stroops), nil
+}
+
+// Normalize parses amount and returns it in canonical form with 7 digits after the decimal point
+func Normalize(v string) (string, error) {
+	a, err := Parse(v)
+	if err != nil {
+		return "", err
+	}
+	return a.String(), nil
+}
+
+// String returns amount in canonical form with 7 digits after the decimal point, ex. "12.5000000"
+func (a Amount) String() string {
+	s := strconv.FormatInt(int64(a), 10)
+	if len(s) <= Precision {
+		s = strings.Repeat("0", Precision-len(s)+1) + s
+	}
+	return s[:len(s)-Precision] + "." + s[len(s)-Precision:]
+}
+
+// XDR returns amount as xdr.Int64
+func (a Amount) XDR() xdr.Int64 {
+	return xdr.Int64(a)
+}
@@ -0,0 +1,39 @@
+package amount
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		value      string
+		normalized string
+		err        error
+	}{
+		{"100", "100.0000000", nil},
+		{"12.5", "12.5000000", nil},
+		{"0.0000001", "0.0000001", nil},
+		{"0", "0.0000000", nil},
+		{"1.", "1.0000000", nil},
+		{"1.00000010", "1.0000001", nil},
+		{"922337203685.4775807", "922337203685.4775807", nil},
+		{"1.00000001", "", ErrTooPrecise},
+		{"1e3", "", ErrExponent},
+		{"1.5E-2", "", ErrExponent},
+		{"-1", "", ErrNegative},
+		{"", "", ErrMalformed},
+		{".5", "", ErrMalformed},
+		{"1/2", "", ErrMalformed},
+		{" 1", "", ErrMalformed},
+		{"0x10", "", ErrMalformed},
+		{"922337203685.4775808", "", ErrTooLarge},
+	}
+
+	for _, test := range tests {
+		normalized, err := Normalize(test.value)
+		assert.Equal(t, test.err, err, test.value)
+		assert.Equal(t, test.normalized, normalized, test.value)
+	}
+}
@@ -0,0 +1,2 @@
+// Package amount implements strict parsing and normalization of asset amounts
+package amount
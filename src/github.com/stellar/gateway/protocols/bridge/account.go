@@ -0,0 +1,39 @@
+package bridge
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/stellar/gateway/protocols"
+)
+
+var (
+	// AccountNotFound is an error response
+	AccountNotFound = &protocols.ErrorResponse{Code: "account_not_found", Message: "Account does not exist.", Status: http.StatusNotFound}
+)
+
+// AccountBalance represents a single balance returned by /accounts/:id/balances endpoint
+type AccountBalance struct {
+	// Asset is `native` or `CODE:ISSUER`
+	Asset       string `json:"asset"`
+	AssetCode   string `json:"asset_code,omitempty"`
+	AssetIssuer string `json:"asset_issuer,omitempty"`
+	Balance     string `json:"balance"`
+	// Limit and authorization flags of the trustline are not returned for native balance
+	Limit                             string `json:"limit,omitempty"`
+	IsAuthorized                      *bool  `json:"is_authorized,omitempty"`
+	IsAuthorizedToMaintainLiabilities *bool  `json:"is_authorized_to_maintain_liabilities,omitempty"`
+}
+
+// AccountBalancesResponse represents response returned by /accounts/:id/balances endpoint of bridge server
+type AccountBalancesResponse struct {
+	protocols.SuccessResponse
+	AccountID string           `json:"account_id"`
+	Balances  []AccountBalance `json:"balances"`
+}
+
+// Marshal marshals AccountBalancesResponse
+func (response *AccountBalancesResponse) Marshal() []byte {
+	json, _ := json.MarshalIndent(response, "", "  ")
+	return json
+}
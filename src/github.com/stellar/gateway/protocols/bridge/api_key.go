@@ -0,0 +1,121 @@
+package bridge
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/stellar/gateway/bridge/config"
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/protocols"
+)
+
+var (
+	// Unauthenticated is an error response
+	Unauthenticated = &protocols.ErrorResponse{Code: "unauthenticated", Message: "Valid API key or token is required.", Status: http.StatusUnauthorized}
+	// PermissionDenied is an error response
+	PermissionDenied = &protocols.ErrorResponse{Code: "permission_denied", Message: "API key or token does not have a permission required by this endpoint.", Status: http.StatusForbidden}
+	// APIKeyNotFound is an error response
+	APIKeyNotFound = &protocols.ErrorResponse{Code: "api_key_not_found", Message: "API key not found.", Status: http.StatusNotFound}
+)
+
+// NewPermissionDeniedError creates and returns a new PermissionDenied error. permission is
+// a permission required by the endpoint (empty when the endpoint belongs to another tenant).
+func NewPermissionDeniedError(permission string) *protocols.ErrorResponse {
+	data := map[string]interface{}{"permission": permission}
+	return &protocols.ErrorResponse{
+		Status:  PermissionDenied.Status,
+		Code:    PermissionDenied.Code,
+		Message: PermissionDenied.Message,
+		Data:    data,
+		LogData: data,
+	}
+}
+
+// APIKeyRequest represents request made to POST /admin/api-keys endpoint of bridge server
+type APIKeyRequest struct {
+	Name string `name:"name" required:""`
+	// Permissions is a comma-separated list of permissions
+	Permissions string `name:"permissions" required:""`
+	Tenant      string `name:"tenant"`
+
+	protocols.FormRequest
+}
+
+// FromRequest will populate request fields using http.Request.
+func (request *APIKeyRequest) FromRequest(r *http.Request) {
+	request.FormRequest.FromRequest(r, request)
+}
+
+// ToValues will create url.Values from request.
+func (request *APIKeyRequest) ToValues() url.Values {
+	return request.FormRequest.ToValues(request)
+}
+
+// Validate validates if request fields are valid. Useful when checking if a request is correct.
+func (request *APIKeyRequest) Validate() error {
+	err := request.FormRequest.CheckRequired(request)
+	if err != nil {
+		return err
+	}
+
+	if len(request.Name) > 255 {
+		return protocols.NewInvalidParameterError("name", request.Name)
+	}
+
+	for _, permission := range strings.Split(request.Permissions, ",") {
+		if !config.IsValidPermission(permission) {
+			return protocols.NewInvalidParameterError("permissions", request.Permissions)
+		}
+	}
+
+	return nil
+}
+
+// APIKey represents an API key returned by /admin/api-keys endpoints of bridge server.
+// Key is only returned when the key is created.
+type APIKey struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	Key         string    `json:"key,omitempty"`
+	Tenant      string    `json:"tenant,omitempty"`
+	Permissions []string  `json:"permissions"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// NewAPIKey creates APIKey from a DB entity
+func NewAPIKey(apiKey *entities.APIKey) APIKey {
+	return APIKey{
+		ID:          *apiKey.ID,
+		Name:        apiKey.Name,
+		Tenant:      apiKey.Tenant,
+		Permissions: apiKey.PermissionsList(),
+		CreatedAt:   apiKey.CreatedAt,
+	}
+}
+
+// APIKeyResponse represents response returned by POST /admin/api-keys endpoint of bridge server
+type APIKeyResponse struct {
+	protocols.SuccessResponse
+	APIKey
+}
+
+// Marshal marshals APIKeyResponse
+func (response *APIKeyResponse) Marshal() []byte {
+	json, _ := json.MarshalIndent(response, "", "  ")
+	return json
+}
+
+// APIKeysResponse represents response returned by GET /admin/api-keys endpoint of bridge server
+type APIKeysResponse struct {
+	protocols.SuccessResponse
+	APIKeys []APIKey `json:"api_keys"`
+}
+
+// Marshal marshals APIKeysResponse
+func (response *APIKeysResponse) Marshal() []byte {
+	json, _ := json.MarshalIndent(response, "", "  ")
+	return json
+}
@@ -39,7 +39,7 @@ func (request *AuthorizeRequest) ToValues() url.Values {
 }
 
 // Validate validates if request fields are valid. Useful when checking if a request is correct.
-func (request *AuthorizeRequest) Validate(allowedAssets []config.Asset, issuingAccountID string) error {
+func (request *AuthorizeRequest) Validate(allowedAssets config.AssetFilter, issuingAccountID string) error {
 	err := request.FormRequest.CheckRequired(request)
 	if err != nil {
 		return err
@@ -51,15 +51,7 @@ func (request *AuthorizeRequest) Validate(allowedAssets []config.Asset, issuingA
 	}
 
 	// Is asset allowed?
-	allowed := false
-	for _, asset := range allowedAssets {
-		if asset.Code == request.AssetCode && asset.Issuer == issuingAccountID {
-			allowed = true
-			break
-		}
-	}
-
-	if !allowed {
+	if !allowedAssets.Allows(request.AssetCode, issuingAccountID) {
 		return protocols.NewInvalidParameterError("asset_code", request.AssetCode)
 	}
 
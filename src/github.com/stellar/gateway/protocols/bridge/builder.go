@@ -34,6 +34,14 @@ const (
 	OperationTypeInflation OperationType = "inflation"
 	// OperationTypeManageData represents manage_data operation
 	OperationTypeManageData OperationType = "manage_data"
+	// OperationTypeBumpSequence represents bump_sequence operation
+	OperationTypeBumpSequence OperationType = "bump_sequence"
+	// OperationTypeBeginSponsoringFutureReserves represents begin_sponsoring_future_reserves operation
+	OperationTypeBeginSponsoringFutureReserves OperationType = "begin_sponsoring_future_reserves"
+	// OperationTypeEndSponsoringFutureReserves represents end_sponsoring_future_reserves operation
+	OperationTypeEndSponsoringFutureReserves OperationType = "end_sponsoring_future_reserves"
+	// OperationTypeRevokeSponsorship represents revoke_sponsorship operation
+	OperationTypeRevokeSponsorship OperationType = "revoke_sponsorship"
 )
 
 // BuilderRequest represents request made to /builder endpoint of bridge server
@@ -42,6 +50,7 @@ type BuilderRequest struct {
 	SequenceNumber string `json:"sequence_number"`
 	Operations     []Operation
 	Signers        []string
+	TransactionPreconditions
 }
 
 // Process parses operations and creates OperationBody object for each operation
@@ -96,6 +105,22 @@ func (r BuilderRequest) Process() error {
 			var manageData ManageDataOperationBody
 			err = json.Unmarshal(operation.RawBody, &manageData)
 			operationBody = manageData
+		case OperationTypeBumpSequence:
+			var bumpSequence BumpSequenceOperationBody
+			err = json.Unmarshal(operation.RawBody, &bumpSequence)
+			operationBody = bumpSequence
+		case OperationTypeBeginSponsoringFutureReserves:
+			var beginSponsoring BeginSponsoringFutureReservesOperationBody
+			err = json.Unmarshal(operation.RawBody, &beginSponsoring)
+			operationBody = beginSponsoring
+		case OperationTypeEndSponsoringFutureReserves:
+			var endSponsoring EndSponsoringFutureReservesOperationBody
+			err = json.Unmarshal(operation.RawBody, &endSponsoring)
+			operationBody = endSponsoring
+		case OperationTypeRevokeSponsorship:
+			var revokeSponsorship RevokeSponsorshipOperationBody
+			err = json.Unmarshal(operation.RawBody, &revokeSponsorship)
+			operationBody = revokeSponsorship
 		default:
 			return protocols.NewInvalidParameterError("operations["+strconv.Itoa(i)+"][type]", string(operation.Type))
 		}
@@ -129,7 +154,7 @@ func (r BuilderRequest) Validate() error {
 		}
 	}
 
-	return nil
+	return r.TransactionPreconditions.Validate()
 }
 
 // Operation struct contains operation type and body
@@ -145,6 +170,17 @@ type OperationBody interface {
 	Validate() error
 }
 
+// HasRawOperations returns true when the request contains operations implementing
+// RawOperationBody. It must be called after Process.
+func (r BuilderRequest) HasRawOperations() bool {
+	for _, operation := range r.Operations {
+		if _, ok := operation.Body.(RawOperationBody); ok {
+			return true
+		}
+	}
+	return false
+}
+
 // BuilderResponse represents response returned by /builder endpoint of bridge server
 type BuilderResponse struct {
 	protocols.SuccessResponse
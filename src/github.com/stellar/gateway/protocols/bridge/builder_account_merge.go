@@ -28,6 +28,11 @@ func (op AccountMergeOperationBody) Validate() error {
 		return protocols.NewInvalidParameterError("destination", op.Destination)
 	}
 
+	// Account cannot be merged into itself
+	if op.Source != nil && *op.Source == op.Destination {
+		return protocols.NewInvalidParameterError("destination", op.Destination)
+	}
+
 	if op.Source != nil && !protocols.IsValidAccountID(*op.Source) {
 		return protocols.NewInvalidParameterError("source", *op.Source)
 	}
@@ -0,0 +1,51 @@
+package bridge
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strconv"
+
+	"github.com/stellar/gateway/protocols"
+	b "github.com/stellar/go-stellar-base/build"
+)
+
+// Bump sequence was added to the protocol after the version supported by go-stellar-base
+// so bump_sequence operation implements RawOperationBody.
+const operationTypeBumpSequence = 11
+
+// BumpSequenceOperationBody represents bump_sequence operation
+type BumpSequenceOperationBody struct {
+	Source *string
+	BumpTo string `json:"bump_to"`
+}
+
+// ToTransactionMutator returns a mutator failing with an error, see RawOperationBody
+func (op BumpSequenceOperationBody) ToTransactionMutator() b.TransactionMutator {
+	return rawOperationMutator{}
+}
+
+// RawOperation returns XDR-encoded operation body
+func (op BumpSequenceOperationBody) RawOperation() (*string, uint32, []byte, error) {
+	bumpTo, err := strconv.ParseInt(op.BumpTo, 10, 64)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	var body bytes.Buffer
+	binary.Write(&body, binary.BigEndian, bumpTo)
+	return op.Source, operationTypeBumpSequence, body.Bytes(), nil
+}
+
+// Validate validates if operation body is valid.
+func (op BumpSequenceOperationBody) Validate() error {
+	bumpTo, err := strconv.ParseInt(op.BumpTo, 10, 64)
+	if err != nil || bumpTo < 0 {
+		return protocols.NewInvalidParameterError("bump_to", op.BumpTo)
+	}
+
+	if op.Source != nil && !protocols.IsValidAccountID(*op.Source) {
+		return protocols.NewInvalidParameterError("source", *op.Source)
+	}
+
+	return nil
+}
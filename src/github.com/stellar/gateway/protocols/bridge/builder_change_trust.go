@@ -40,8 +40,8 @@ func (op ChangeTrustOperationBody) Validate() error {
 	}
 
 	if op.Limit != nil {
-		if !protocols.IsValidAmount(*op.Limit) {
-			return protocols.NewInvalidParameterError("limit", *op.Limit)
+		if err := protocols.ValidateAmount("limit", *op.Limit, false); err != nil {
+			return err
 		}
 	}
 
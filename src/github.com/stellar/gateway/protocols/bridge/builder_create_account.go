@@ -32,8 +32,8 @@ func (op CreateAccountOperationBody) Validate() error {
 		return protocols.NewInvalidParameterError("destination", op.Destination)
 	}
 
-	if !protocols.IsValidAmount(op.StartingBalance) {
-		return protocols.NewInvalidParameterError("starting_balance", op.StartingBalance)
+	if err := protocols.ValidateAmount("starting_balance", op.StartingBalance, true); err != nil {
+		return err
 	}
 
 	if op.Source != nil && !protocols.IsValidAccountID(*op.Source) {
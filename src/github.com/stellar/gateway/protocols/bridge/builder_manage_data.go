@@ -35,7 +35,7 @@ func (op ManageDataOperationBody) ToTransactionMutator() b.TransactionMutator {
 
 // Validate validates if operation body is valid.
 func (op ManageDataOperationBody) Validate() error {
-	if len(op.Name) > 64 {
+	if op.Name == "" || len(op.Name) > 64 {
 		return protocols.NewInvalidParameterError("name", op.Name)
 	}
 
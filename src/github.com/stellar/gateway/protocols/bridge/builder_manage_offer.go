@@ -46,6 +46,10 @@ func (op ManageOfferOperationBody) ToTransactionMutator() b.TransactionMutator {
 
 // Validate validates if operation body is valid.
 func (op ManageOfferOperationBody) Validate() error {
+	if err := protocols.ValidateAmount("amount", op.Amount, false); err != nil {
+		return err
+	}
+
 	if op.OfferID != nil {
 		_, err := strconv.ParseUint(*op.OfferID, 10, 64)
 		if err != nil {
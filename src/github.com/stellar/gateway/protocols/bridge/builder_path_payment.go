@@ -66,12 +66,12 @@ func (op PathPaymentOperationBody) Validate() error {
 		return protocols.NewInvalidParameterError("destination", op.Destination)
 	}
 
-	if !protocols.IsValidAmount(op.SendMax) {
-		return protocols.NewInvalidParameterError("send_max", op.SendMax)
+	if err := protocols.ValidateAmount("send_max", op.SendMax, true); err != nil {
+		return err
 	}
 
-	if !protocols.IsValidAmount(op.DestinationAmount) {
-		return protocols.NewInvalidParameterError("destination_amount", op.DestinationAmount)
+	if err := protocols.ValidateAmount("destination_amount", op.DestinationAmount, true); err != nil {
+		return err
 	}
 
 	if !op.SendAsset.Validate() {
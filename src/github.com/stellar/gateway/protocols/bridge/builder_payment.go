@@ -44,8 +44,8 @@ func (op PaymentOperationBody) Validate() error {
 		return protocols.NewInvalidParameterError("destination", op.Destination)
 	}
 
-	if !protocols.IsValidAmount(op.Amount) {
-		return protocols.NewInvalidParameterError("amount", op.Amount)
+	if err := protocols.ValidateAmount("amount", op.Amount, true); err != nil {
+		return err
 	}
 
 	if !op.Asset.Validate() {
@@ -1,6 +1,8 @@
 package bridge
 
 import (
+	"strconv"
+
 	"github.com/stellar/gateway/protocols"
 	b "github.com/stellar/go-stellar-base/build"
 )
@@ -75,16 +77,67 @@ func (op SetOptionsOperationBody) ToTransactionMutator() b.TransactionMutator {
 	return b.SetOptions(mutators...)
 }
 
+// Maximum values of set_options fields
+const (
+	maxWeight           = 255
+	maxHomeDomainLength = 32
+)
+
+// accountFlags contains flags that can be set or cleared using set_options operation:
+// AUTH_REQUIRED, AUTH_REVOCABLE and AUTH_IMMUTABLE
+var accountFlags = map[int]bool{1: true, 2: true, 4: true}
+
 // Validate validates if operation body is valid.
 func (op SetOptionsOperationBody) Validate() error {
 	if op.InflationDest != nil && !protocols.IsValidAccountID(*op.InflationDest) {
 		return protocols.NewInvalidParameterError("inflation_dest", *op.InflationDest)
 	}
 
+	set := make(map[int]bool)
+	if op.SetFlags != nil {
+		for _, flag := range *op.SetFlags {
+			if !accountFlags[flag] {
+				return protocols.NewInvalidParameterError("set_flags", strconv.Itoa(flag))
+			}
+			set[flag] = true
+		}
+	}
+
+	if op.ClearFlags != nil {
+		for _, flag := range *op.ClearFlags {
+			if !accountFlags[flag] || set[flag] {
+				return protocols.NewInvalidParameterError("clear_flags", strconv.Itoa(flag))
+			}
+		}
+	}
+
+	weights := []struct {
+		name  string
+		value *uint32
+	}{
+		{"master_weight", op.MasterWeight},
+		{"low_threshold", op.LowThreshold},
+		{"medium_threshold", op.MediumThreshold},
+		{"high_threshold", op.HighThreshold},
+	}
+	for _, weight := range weights {
+		if weight.value != nil && *weight.value > maxWeight {
+			return protocols.NewInvalidParameterError(weight.name, strconv.FormatUint(uint64(*weight.value), 10))
+		}
+	}
+
+	if op.HomeDomain != nil && len(*op.HomeDomain) > maxHomeDomainLength {
+		return protocols.NewInvalidParameterError("home_domain", *op.HomeDomain)
+	}
+
 	if op.Signer != nil {
 		if !protocols.IsValidAccountID(op.Signer.PublicKey) {
 			return protocols.NewInvalidParameterError("signer.public_key", op.Signer.PublicKey)
 		}
+
+		if op.Signer.Weight > maxWeight {
+			return protocols.NewInvalidParameterError("signer.weight", strconv.FormatUint(uint64(op.Signer.Weight), 10))
+		}
 	}
 
 	if op.Source != nil && !protocols.IsValidAccountID(*op.Source) {
@@ -0,0 +1,293 @@
+package bridge
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+
+	"github.com/stellar/gateway/protocols"
+	b "github.com/stellar/go-stellar-base/build"
+	"github.com/stellar/go-stellar-base/xdr"
+)
+
+// Sponsored reserves were added to the protocol after the version supported by
+// go-stellar-base so sponsorship operations implement RawOperationBody.
+const (
+	operationTypeBeginSponsoringFutureReserves = 16
+	operationTypeEndSponsoringFutureReserves   = 17
+	operationTypeRevokeSponsorship             = 18
+
+	revokeSponsorshipLedgerEntry = 0
+	revokeSponsorshipSigner      = 1
+
+	ledgerEntryTypeClaimableBalance = 4
+)
+
+// RawOperationBody is implemented by builder operations that cannot be built using
+// go-stellar-base. ToTransactionMutator of these operations returns a mutator failing with
+// an error so transactions containing them must be encoded using RawOperation.
+type RawOperationBody interface {
+	OperationBody
+	// RawOperation returns operation source account (nil when not set), operation type and
+	// XDR-encoded operation body
+	RawOperation() (source *string, operationType uint32, body []byte, err error)
+}
+
+// rawOperationMutator is returned by ToTransactionMutator of RawOperationBody operations
+type rawOperationMutator struct{}
+
+// MutateTransaction always fails: the operation is not supported by go-stellar-base
+func (rawOperationMutator) MutateTransaction(*b.TransactionBuilder) error {
+	return errors.New("operation must be encoded using RawOperation")
+}
+
+// BeginSponsoringFutureReservesOperationBody represents begin_sponsoring_future_reserves operation
+type BeginSponsoringFutureReservesOperationBody struct {
+	Source      *string
+	SponsoredID string `json:"sponsored_id"`
+}
+
+// ToTransactionMutator returns a mutator failing with an error, see RawOperationBody
+func (op BeginSponsoringFutureReservesOperationBody) ToTransactionMutator() b.TransactionMutator {
+	return rawOperationMutator{}
+}
+
+// RawOperation returns XDR-encoded operation body
+func (op BeginSponsoringFutureReservesOperationBody) RawOperation() (*string, uint32, []byte, error) {
+	var body bytes.Buffer
+	err := writeAccountID(&body, op.SponsoredID)
+	return op.Source, operationTypeBeginSponsoringFutureReserves, body.Bytes(), err
+}
+
+// Validate validates if operation body is valid.
+func (op BeginSponsoringFutureReservesOperationBody) Validate() error {
+	if !protocols.IsValidAccountID(op.SponsoredID) {
+		return protocols.NewInvalidParameterError("sponsored_id", op.SponsoredID)
+	}
+
+	if op.Source != nil && !protocols.IsValidAccountID(*op.Source) {
+		return protocols.NewInvalidParameterError("source", *op.Source)
+	}
+
+	return nil
+}
+
+// EndSponsoringFutureReservesOperationBody represents end_sponsoring_future_reserves operation.
+// Source must be the sponsored account.
+type EndSponsoringFutureReservesOperationBody struct {
+	Source *string
+}
+
+// ToTransactionMutator returns a mutator failing with an error, see RawOperationBody
+func (op EndSponsoringFutureReservesOperationBody) ToTransactionMutator() b.TransactionMutator {
+	return rawOperationMutator{}
+}
+
+// RawOperation returns XDR-encoded operation body (the operation has no body)
+func (op EndSponsoringFutureReservesOperationBody) RawOperation() (*string, uint32, []byte, error) {
+	return op.Source, operationTypeEndSponsoringFutureReserves, nil, nil
+}
+
+// Validate validates if operation body is valid.
+func (op EndSponsoringFutureReservesOperationBody) Validate() error {
+	if op.Source != nil && !protocols.IsValidAccountID(*op.Source) {
+		return protocols.NewInvalidParameterError("source", *op.Source)
+	}
+
+	return nil
+}
+
+// RevokeSponsorshipOperationBody represents revoke_sponsorship operation. Exactly one of the
+// sponsored entries must be set.
+type RevokeSponsorshipOperationBody struct {
+	Source *string
+	// AccountID revokes sponsorship of an account
+	AccountID *string `json:"account_id"`
+	// Trustline revokes sponsorship of a trustline
+	Trustline *RevokeSponsorshipTrustline `json:"trustline"`
+	// Offer revokes sponsorship of an offer
+	Offer *RevokeSponsorshipOffer `json:"offer"`
+	// Data revokes sponsorship of a data entry
+	Data *RevokeSponsorshipData `json:"data"`
+	// ClaimableBalanceID revokes sponsorship of a claimable balance (hex-encoded, as returned by Horizon)
+	ClaimableBalanceID *string `json:"claimable_balance_id"`
+	// Signer revokes sponsorship of a signer
+	Signer *RevokeSponsorshipSigner `json:"signer"`
+}
+
+// RevokeSponsorshipTrustline is a trustline in revoke_sponsorship operation body
+type RevokeSponsorshipTrustline struct {
+	AccountID string          `json:"account_id"`
+	Asset     protocols.Asset `json:"asset"`
+}
+
+// RevokeSponsorshipOffer is an offer in revoke_sponsorship operation body
+type RevokeSponsorshipOffer struct {
+	SellerID string `json:"seller_id"`
+	OfferID  uint64 `json:"offer_id"`
+}
+
+// RevokeSponsorshipData is a data entry in revoke_sponsorship operation body
+type RevokeSponsorshipData struct {
+	AccountID string `json:"account_id"`
+	Name      string `json:"name"`
+}
+
+// RevokeSponsorshipSigner is a signer in revoke_sponsorship operation body
+type RevokeSponsorshipSigner struct {
+	AccountID string `json:"account_id"`
+	// PublicKey of the ed25519 signer
+	PublicKey string `json:"public_key"`
+}
+
+// ToTransactionMutator returns a mutator failing with an error, see RawOperationBody
+func (op RevokeSponsorshipOperationBody) ToTransactionMutator() b.TransactionMutator {
+	return rawOperationMutator{}
+}
+
+// RawOperation returns XDR-encoded operation body
+func (op RevokeSponsorshipOperationBody) RawOperation() (*string, uint32, []byte, error) {
+	var body bytes.Buffer
+	err := op.writeBody(&body)
+	return op.Source, operationTypeRevokeSponsorship, body.Bytes(), err
+}
+
+func (op RevokeSponsorshipOperationBody) writeBody(body *bytes.Buffer) error {
+	if op.Signer != nil {
+		writeUint32(body, revokeSponsorshipSigner)
+		err := writeAccountID(body, op.Signer.AccountID)
+		if err != nil {
+			return err
+		}
+		// Ed25519 signer key has the same encoding as account ID
+		return writeAccountID(body, op.Signer.PublicKey)
+	}
+
+	writeUint32(body, revokeSponsorshipLedgerEntry)
+
+	if op.ClaimableBalanceID != nil {
+		id, err := hex.DecodeString(*op.ClaimableBalanceID)
+		if err != nil {
+			return err
+		}
+		writeUint32(body, ledgerEntryTypeClaimableBalance)
+		body.Write(id)
+		return nil
+	}
+
+	var key xdr.LedgerKey
+	switch {
+	case op.AccountID != nil:
+		var accountID xdr.AccountId
+		err := accountID.SetAddress(*op.AccountID)
+		if err != nil {
+			return err
+		}
+		key.Type = xdr.LedgerEntryTypeAccount
+		key.Account = &xdr.LedgerKeyAccount{AccountId: accountID}
+	case op.Trustline != nil:
+		var accountID xdr.AccountId
+		err := accountID.SetAddress(op.Trustline.AccountID)
+		if err != nil {
+			return err
+		}
+		asset, err := op.Trustline.Asset.ToBaseAsset().ToXdrObject()
+		if err != nil {
+			return err
+		}
+		key.Type = xdr.LedgerEntryTypeTrustline
+		key.TrustLine = &xdr.LedgerKeyTrustLine{AccountId: accountID, Asset: asset}
+	case op.Offer != nil:
+		var sellerID xdr.AccountId
+		err := sellerID.SetAddress(op.Offer.SellerID)
+		if err != nil {
+			return err
+		}
+		key.Type = xdr.LedgerEntryTypeOffer
+		key.Offer = &xdr.LedgerKeyOffer{SellerId: sellerID, OfferId: xdr.Uint64(op.Offer.OfferID)}
+	case op.Data != nil:
+		var accountID xdr.AccountId
+		err := accountID.SetAddress(op.Data.AccountID)
+		if err != nil {
+			return err
+		}
+		key.Type = xdr.LedgerEntryTypeData
+		key.Data = &xdr.LedgerKeyData{AccountId: accountID, DataName: xdr.String64(op.Data.Name)}
+	default:
+		return errors.New("sponsored entry not set")
+	}
+
+	_, err := xdr.Marshal(body, key)
+	return err
+}
+
+// Validate validates if operation body is valid.
+func (op RevokeSponsorshipOperationBody) Validate() error {
+	entries := 0
+	for _, set := range []bool{op.AccountID != nil, op.Trustline != nil, op.Offer != nil, op.Data != nil, op.ClaimableBalanceID != nil, op.Signer != nil} {
+		if set {
+			entries++
+		}
+	}
+	if entries != 1 {
+		return protocols.NewInvalidParameterError("body", "", map[string]interface{}{"err": "exactly one sponsored entry must be set"})
+	}
+
+	switch {
+	case op.AccountID != nil:
+		if !protocols.IsValidAccountID(*op.AccountID) {
+			return protocols.NewInvalidParameterError("account_id", *op.AccountID)
+		}
+	case op.Trustline != nil:
+		if !protocols.IsValidAccountID(op.Trustline.AccountID) {
+			return protocols.NewInvalidParameterError("trustline.account_id", op.Trustline.AccountID)
+		}
+		if op.Trustline.Asset.Code == "" || !op.Trustline.Asset.Validate() {
+			return protocols.NewInvalidParameterError("trustline.asset", op.Trustline.Asset.String())
+		}
+	case op.Offer != nil:
+		if !protocols.IsValidAccountID(op.Offer.SellerID) {
+			return protocols.NewInvalidParameterError("offer.seller_id", op.Offer.SellerID)
+		}
+	case op.Data != nil:
+		if !protocols.IsValidAccountID(op.Data.AccountID) {
+			return protocols.NewInvalidParameterError("data.account_id", op.Data.AccountID)
+		}
+		if op.Data.Name == "" || len(op.Data.Name) > 64 {
+			return protocols.NewInvalidParameterError("data.name", op.Data.Name)
+		}
+	case op.ClaimableBalanceID != nil:
+		if !claimableBalanceID.MatchString(*op.ClaimableBalanceID) {
+			return protocols.NewInvalidParameterError("claimable_balance_id", *op.ClaimableBalanceID)
+		}
+	case op.Signer != nil:
+		if !protocols.IsValidAccountID(op.Signer.AccountID) {
+			return protocols.NewInvalidParameterError("signer.account_id", op.Signer.AccountID)
+		}
+		if !protocols.IsValidAccountID(op.Signer.PublicKey) {
+			return protocols.NewInvalidParameterError("signer.public_key", op.Signer.PublicKey)
+		}
+	}
+
+	if op.Source != nil && !protocols.IsValidAccountID(*op.Source) {
+		return protocols.NewInvalidParameterError("source", *op.Source)
+	}
+
+	return nil
+}
+
+// writeAccountID writes XDR-encoded account ID of address to buf
+func writeAccountID(buf *bytes.Buffer, address string) error {
+	var accountID xdr.AccountId
+	err := accountID.SetAddress(address)
+	if err != nil {
+		return err
+	}
+	_, err = xdr.Marshal(buf, accountID)
+	return err
+}
+
+func writeUint32(buf *bytes.Buffer, value uint32) {
+	binary.Write(buf, binary.BigEndian, value)
+}
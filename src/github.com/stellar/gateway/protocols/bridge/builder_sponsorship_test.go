@@ -0,0 +1,89 @@
+package bridge
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	"github.com/stellar/gateway/protocols"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuilderRequestSponsorship(t *testing.T) {
+	var request BuilderRequest
+	err := json.Unmarshal([]byte(`{
+  "source": "`+testIssuer+`",
+  "sequence_number": "1",
+  "operations": [
+    {"type": "begin_sponsoring_future_reserves", "body": {"sponsored_id": "GCOEGO43PFSLE4K7WRZQNRO3PIOTRLKRASP32W7DSPBF65XFT4V6PSV3"}},
+    {"type": "end_sponsoring_future_reserves", "body": {"source": "GCOEGO43PFSLE4K7WRZQNRO3PIOTRLKRASP32W7DSPBF65XFT4V6PSV3"}},
+    {"type": "revoke_sponsorship", "body": {"trustline": {"account_id": "GCOEGO43PFSLE4K7WRZQNRO3PIOTRLKRASP32W7DSPBF65XFT4V6PSV3", "asset": "USD:`+testIssuer+`"}}}
+  ]
+}`), &request)
+	require.NoError(t, err)
+	require.NoError(t, request.Process())
+	require.NoError(t, request.Validate())
+	assert.True(t, request.HasRawOperations())
+
+	source, operationType, body, err := request.Operations[0].Body.(RawOperationBody).RawOperation()
+	require.NoError(t, err)
+	assert.Nil(t, source)
+	assert.Equal(t, uint32(16), operationType)
+	assert.Len(t, body, 36)
+
+	source, operationType, body, err = request.Operations[1].Body.(RawOperationBody).RawOperation()
+	require.NoError(t, err)
+	assert.Equal(t, "GCOEGO43PFSLE4K7WRZQNRO3PIOTRLKRASP32W7DSPBF65XFT4V6PSV3", *source)
+	assert.Equal(t, uint32(17), operationType)
+	assert.Empty(t, body)
+
+	_, operationType, body, err = request.Operations[2].Body.(RawOperationBody).RawOperation()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(18), operationType)
+	// ledger entry, trustline, account ID, credit_alphanum4, code, issuer
+	require.Len(t, body, 4+4+36+4+4+36)
+	assert.Equal(t, uint32(0), binary.BigEndian.Uint32(body))
+	assert.Equal(t, uint32(1), binary.BigEndian.Uint32(body[4:]))
+
+	request.Operations = request.Operations[:0]
+	assert.False(t, request.HasRawOperations())
+}
+
+func TestRevokeSponsorshipValidate(t *testing.T) {
+	account := testIssuer
+	balanceID := "00000000da0d57da7d4850e7fc10d2a9d0ebc731f7afb40574c03395b17d49149b91f5be"
+	invalidBalanceID := "da0d57da"
+
+	op := RevokeSponsorshipOperationBody{ClaimableBalanceID: &balanceID}
+	require.NoError(t, op.Validate())
+	_, _, body, err := op.RawOperation()
+	require.NoError(t, err)
+	// ledger entry, claimable balance, balance ID
+	assert.Len(t, body, 4+4+36)
+	assert.Equal(t, uint32(4), binary.BigEndian.Uint32(body[4:]))
+
+	op = RevokeSponsorshipOperationBody{Signer: &RevokeSponsorshipSigner{AccountID: account, PublicKey: account}}
+	require.NoError(t, op.Validate())
+	_, _, body, err = op.RawOperation()
+	require.NoError(t, err)
+	assert.Len(t, body, 4+36+36)
+	assert.Equal(t, uint32(1), binary.BigEndian.Uint32(body))
+
+	op = RevokeSponsorshipOperationBody{Data: &RevokeSponsorshipData{AccountID: account, Name: "config"}}
+	assert.NoError(t, op.Validate())
+	op = RevokeSponsorshipOperationBody{Offer: &RevokeSponsorshipOffer{SellerID: account, OfferID: 10}}
+	assert.NoError(t, op.Validate())
+
+	op = RevokeSponsorshipOperationBody{}
+	assert.Equal(t, "body", op.Validate().(*protocols.ErrorResponse).Data["name"])
+	op = RevokeSponsorshipOperationBody{AccountID: &account, ClaimableBalanceID: &balanceID}
+	assert.Equal(t, "body", op.Validate().(*protocols.ErrorResponse).Data["name"])
+	op = RevokeSponsorshipOperationBody{ClaimableBalanceID: &invalidBalanceID}
+	assert.Equal(t, "claimable_balance_id", op.Validate().(*protocols.ErrorResponse).Data["name"])
+	op = RevokeSponsorshipOperationBody{Trustline: &RevokeSponsorshipTrustline{AccountID: account}}
+	assert.Equal(t, "trustline.asset", op.Validate().(*protocols.ErrorResponse).Data["name"])
+
+	begin := BeginSponsoringFutureReservesOperationBody{SponsoredID: "bob"}
+	assert.Equal(t, "sponsored_id", begin.Validate().(*protocols.ErrorResponse).Data["name"])
+}
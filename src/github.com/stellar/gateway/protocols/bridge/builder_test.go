@@ -0,0 +1,116 @@
+package bridge
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	"github.com/stellar/gateway/protocols"
+	b "github.com/stellar/go-stellar-base/build"
+	"github.com/stellar/go-stellar-base/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDestination = "GCOEGO43PFSLE4K7WRZQNRO3PIOTRLKRASP32W7DSPBF65XFT4V6PSV3"
+
+func processOperation(t *testing.T, operationType OperationType, body string) OperationBody {
+	request := BuilderRequest{Operations: []Operation{{Type: operationType, RawBody: json.RawMessage(body)}}}
+	require.NoError(t, request.Process())
+	return request.Operations[0].Body
+}
+
+func invalidParameter(err error) interface{} {
+	if err == nil {
+		return nil
+	}
+	return err.(*protocols.ErrorResponse).Data["name"]
+}
+
+func buildOperation(t *testing.T, operation OperationBody) *b.TransactionBuilder {
+	tx := b.Transaction(
+		b.SourceAccount{AddressOrSeed: testIssuer},
+		b.Sequence{Sequence: 1},
+		b.Network{Passphrase: network.TestNetworkPassphrase},
+		operation.ToTransactionMutator(),
+	)
+	require.NoError(t, tx.Err)
+	return tx
+}
+
+func TestBuilderSetOptions(t *testing.T) {
+	operation := processOperation(t, OperationTypeSetOptions, `{
+  "set_flags": [1, 2],
+  "clear_flags": [4],
+  "master_weight": 10,
+  "low_threshold": 1,
+  "medium_threshold": 2,
+  "high_threshold": 255,
+  "home_domain": "example.com",
+  "signer": {"public_key": "`+testDestination+`", "weight": 5}
+}`)
+	require.NoError(t, operation.Validate())
+
+	setOptions := buildOperation(t, operation).TX.Operations[0].Body.SetOptionsOp
+	assert.EqualValues(t, 3, *setOptions.SetFlags)
+	assert.EqualValues(t, 4, *setOptions.ClearFlags)
+	assert.EqualValues(t, 255, *setOptions.HighThreshold)
+	assert.EqualValues(t, "example.com", *setOptions.HomeDomain)
+	assert.EqualValues(t, 5, setOptions.Signer.Weight)
+
+	tests := map[string]string{
+		`{"set_flags": [3]}`:                                                   "set_flags",
+		`{"clear_flags": [8]}`:                                                 "clear_flags",
+		`{"set_flags": [1], "clear_flags": [1]}`:                               "clear_flags",
+		`{"master_weight": 256}`:                                               "master_weight",
+		`{"medium_threshold": 1000}`:                                           "medium_threshold",
+		`{"home_domain": "a-very-long-home-domain.example.com"}`:               "home_domain",
+		`{"signer": {"public_key": "bob", "weight": 1}}`:                       "signer.public_key",
+		`{"signer": {"public_key": "` + testDestination + `", "weight": 256}}`: "signer.weight",
+		`{"inflation_dest": "bob"}`:                                            "inflation_dest",
+	}
+	for body, name := range tests {
+		assert.Equal(t, name, invalidParameter(processOperation(t, OperationTypeSetOptions, body).Validate()), body)
+	}
+}
+
+func TestBuilderAccountMerge(t *testing.T) {
+	operation := processOperation(t, OperationTypeAccountMerge, `{"destination": "`+testDestination+`"}`)
+	require.NoError(t, operation.Validate())
+	destination := buildOperation(t, operation).TX.Operations[0].Body.Destination
+	assert.Equal(t, testDestination, destination.Address())
+
+	operation = processOperation(t, OperationTypeAccountMerge, `{"source": "`+testDestination+`", "destination": "`+testDestination+`"}`)
+	assert.Equal(t, "destination", invalidParameter(operation.Validate()))
+	operation = processOperation(t, OperationTypeAccountMerge, `{"destination": "bob"}`)
+	assert.Equal(t, "destination", invalidParameter(operation.Validate()))
+}
+
+func TestBuilderManageData(t *testing.T) {
+	operation := processOperation(t, OperationTypeManageData, `{"name": "config", "data": "AQIDBAUG"}`)
+	require.NoError(t, operation.Validate())
+	manageData := buildOperation(t, operation).TX.Operations[0].Body.ManageDataOp
+	assert.Equal(t, []byte{1, 2, 3, 4, 5, 6}, []byte(*manageData.DataValue))
+
+	// Empty data removes the entry
+	operation = processOperation(t, OperationTypeManageData, `{"name": "config"}`)
+	require.NoError(t, operation.Validate())
+	assert.Nil(t, buildOperation(t, operation).TX.Operations[0].Body.ManageDataOp.DataValue)
+
+	assert.Equal(t, "name", invalidParameter(processOperation(t, OperationTypeManageData, `{"name": ""}`).Validate()))
+	assert.Equal(t, "data", invalidParameter(processOperation(t, OperationTypeManageData, `{"name": "config", "data": "not base64"}`).Validate()))
+}
+
+func TestBuilderBumpSequence(t *testing.T) {
+	operation := processOperation(t, OperationTypeBumpSequence, `{"bump_to": "1234567890123"}`)
+	require.NoError(t, operation.Validate())
+
+	source, operationType, body, err := operation.(RawOperationBody).RawOperation()
+	require.NoError(t, err)
+	assert.Nil(t, source)
+	assert.Equal(t, uint32(11), operationType)
+	assert.Equal(t, uint64(1234567890123), binary.BigEndian.Uint64(body))
+
+	assert.Equal(t, "bump_to", invalidParameter(processOperation(t, OperationTypeBumpSequence, `{"bump_to": "-1"}`).Validate()))
+	assert.Equal(t, "bump_to", invalidParameter(processOperation(t, OperationTypeBumpSequence, `{}`).Validate()))
+}
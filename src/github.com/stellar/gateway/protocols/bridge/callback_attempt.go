@@ -0,0 +1,134 @@
+package bridge
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/protocols"
+)
+
+// Limits of /admin/callbacks request params
+const (
+	DefaultCallbacksLimit = 50
+	MaxCallbacksLimit     = 200
+)
+
+// CallbacksRequest represents request made to /admin/callbacks endpoint of bridge server
+type CallbacksRequest struct {
+	// OperationID is an ID of the received payment operation
+	OperationID string
+	Success     string
+	StatusCode  string
+	// Since and Until are RFC 3339 timestamps
+	Since string
+	Until string
+	Limit string
+}
+
+// FromRequest will populate request fields using query params of http.Request.
+func (request *CallbacksRequest) FromRequest(r *http.Request) {
+	query := r.URL.Query()
+	request.OperationID = query.Get("operation_id")
+	request.Success = query.Get("success")
+	request.StatusCode = query.Get("status_code")
+	request.Since = query.Get("since")
+	request.Until = query.Get("until")
+	request.Limit = query.Get("limit")
+}
+
+// Parse validates request params and returns a filter of callback attempts
+func (request *CallbacksRequest) Parse() (filter entities.CallbackAttemptFilter, err error) {
+	filter.OperationID = request.OperationID
+	filter.Limit = DefaultCallbacksLimit
+
+	if request.Success != "" {
+		success, parseErr := strconv.ParseBool(request.Success)
+		if parseErr != nil {
+			err = protocols.NewInvalidParameterError("success", request.Success)
+			return
+		}
+		filter.Success = &success
+	}
+
+	if request.StatusCode != "" {
+		statusCode, parseErr := strconv.Atoi(request.StatusCode)
+		if parseErr != nil {
+			err = protocols.NewInvalidParameterError("status_code", request.StatusCode)
+			return
+		}
+		filter.StatusCode = &statusCode
+	}
+
+	if request.Since != "" {
+		since, parseErr := time.Parse(time.RFC3339, request.Since)
+		if parseErr != nil {
+			err = protocols.NewInvalidParameterError("since", request.Since)
+			return
+		}
+		filter.Since = &since
+	}
+
+	if request.Until != "" {
+		until, parseErr := time.Parse(time.RFC3339, request.Until)
+		if parseErr != nil {
+			err = protocols.NewInvalidParameterError("until", request.Until)
+			return
+		}
+		filter.Until = &until
+	}
+
+	if request.Limit != "" {
+		filter.Limit, err = strconv.Atoi(request.Limit)
+		if err != nil || filter.Limit < 1 || filter.Limit > MaxCallbacksLimit {
+			err = protocols.NewInvalidParameterError("limit", request.Limit)
+			return
+		}
+	}
+
+	return
+}
+
+// CallbackAttempt represents a receive callback attempt returned by /admin/callbacks endpoint of bridge server
+type CallbackAttempt struct {
+	ID          int64     `json:"id"`
+	OperationID string    `json:"operation_id"`
+	Attempt     int       `json:"attempt"`
+	URL         string    `json:"url,omitempty"`
+	Success     bool      `json:"success"`
+	StatusCode  *int      `json:"status_code,omitempty"`
+	LatencyMs   int64     `json:"latency_ms"`
+	Response    *string   `json:"response,omitempty"`
+	Error       *string   `json:"error,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// NewCallbackAttempt creates CallbackAttempt from a DB entity
+func NewCallbackAttempt(attempt *entities.CallbackAttempt) CallbackAttempt {
+	return CallbackAttempt{
+		ID:          *attempt.ID,
+		OperationID: attempt.OperationID,
+		Attempt:     attempt.Attempt,
+		URL:         attempt.URL,
+		Success:     attempt.Success,
+		StatusCode:  attempt.StatusCode,
+		LatencyMs:   attempt.LatencyMs,
+		Response:    attempt.Response,
+		Error:       attempt.Error,
+		CreatedAt:   attempt.CreatedAt,
+	}
+}
+
+// CallbacksResponse represents response returned by /admin/callbacks endpoint of bridge server
+type CallbacksResponse struct {
+	protocols.SuccessResponse
+	Callbacks []CallbackAttempt `json:"callbacks"`
+}
+
+// Marshal marshals CallbacksResponse
+func (response *CallbacksResponse) Marshal() []byte {
+	json, _ := json.MarshalIndent(response, "", "  ")
+	return json
+}
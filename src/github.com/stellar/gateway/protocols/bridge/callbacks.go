@@ -0,0 +1,124 @@
+package bridge
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Events sent in `event` field of version 2 callback payloads
+const (
+	CallbackEventPaymentReceived  = "payment_received"
+	CallbackEventTrustlineCreated = "trustline_created"
+	// CallbackEventClaimableBalanceCreated is sent instead of CallbackEventPaymentReceived
+	// when a claimable balance the receiving account can claim is created
+	CallbackEventClaimableBalanceCreated = "claimable_balance_created"
+)
+
+// ReceiveCallback is a version 2 (JSON) payload of `callbacks.receive`
+type ReceiveCallback struct {
+	// Version is a payload schema version, always 2
+	Version     int       `json:"version"`
+	Event       string    `json:"event"`
+	ID          string    `json:"id"`
+	PagingToken string    `json:"paging_token,omitempty"`
+	ProcessedAt time.Time `json:"processed_at"`
+	From        string    `json:"from"`
+	FromMuxed   string    `json:"from_muxed,omitempty"`
+	FromMuxedID string    `json:"from_muxed_id,omitempty"`
+	FromAddress string    `json:"from_address,omitempty"`
+	To          string    `json:"to"`
+	ToMuxed     string    `json:"to_muxed,omitempty"`
+	ToMuxedID   string    `json:"to_muxed_id,omitempty"`
+	Route       string    `json:"route"`
+	Amount      string    `json:"amount"`
+	Asset       string    `json:"asset"`
+	AssetCode   string    `json:"asset_code,omitempty"`
+	AssetIssuer string    `json:"asset_issuer,omitempty"`
+	Memo        struct {
+		Type  string `json:"type"`
+		Value string `json:"value"`
+		// Decoded is a memo preimage (Stellar Memo Convention JSON object) returned by
+		// the compliance server for `hash` memos
+		Decoded *json.RawMessage `json:"decoded,omitempty"`
+	} `json:"memo"`
+	// Operation contains details of the operation the payment was received in
+	Operation CallbackOperation `json:"operation"`
+	// Transaction contains details of the transaction the operation belongs to
+	Transaction *CallbackTransaction `json:"transaction,omitempty"`
+	// Data is AuthData JSON object sent by the compliance server
+	Data       *json.RawMessage    `json:"data,omitempty"`
+	CustomerID string              `json:"customer_id,omitempty"`
+	Conversion *CallbackConversion `json:"conversion,omitempty"`
+	// ClaimableBalanceID is sent with CallbackEventClaimableBalanceCreated events. Balance
+	// can be claimed using /claim endpoint.
+	ClaimableBalanceID string `json:"claimable_balance_id,omitempty"`
+	// Sep31 is sent with payments of SEP-31 transactions
+	Sep31 *CallbackSep31 `json:"sep31,omitempty"`
+	// Withdrawal is sent with payments of SEP-6 withdrawals
+	Withdrawal *CallbackWithdrawal `json:"withdrawal,omitempty"`
+}
+
+// CallbackOperation contains details of an operation a callback is sent for
+type CallbackOperation struct {
+	Type            string `json:"type"`
+	SourceAccount   string `json:"source_account,omitempty"`
+	TransactionHash string `json:"transaction_hash,omitempty"`
+	CreatedAt       string `json:"created_at,omitempty"`
+}
+
+// CallbackTransaction contains details of a transaction a callback is sent for
+type CallbackTransaction struct {
+	Hash            string `json:"hash"`
+	Ledger          uint64 `json:"ledger"`
+	LedgerCloseTime string `json:"ledger_close_time,omitempty"`
+	// FeeCharged is a fee paid by the transaction in stroops
+	FeeCharged    string `json:"fee_charged,omitempty"`
+	SourceAccount string `json:"source_account,omitempty"`
+	// FeeAccount paid the fee when it's not the source account (fee bump transactions)
+	FeeAccount string `json:"fee_account,omitempty"`
+}
+
+// CallbackSep31 contains details of a SEP-31 transaction a received payment belongs to
+type CallbackSep31 struct {
+	TransactionID string `json:"transaction_id"`
+	Status        string `json:"status"`
+	SenderID      string `json:"sender_id"`
+	ReceiverID    string `json:"receiver_id"`
+	AmountFee     string `json:"amount_fee"`
+	AmountOut     string `json:"amount_out"`
+	// Fields is a JSON object with `transaction` fields sent by the sending anchor
+	Fields *json.RawMessage `json:"fields,omitempty"`
+}
+
+// CallbackWithdrawal contains details of a SEP-6 withdrawal a received payment belongs to
+type CallbackWithdrawal struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	// Account is the user's Stellar account
+	Account   string `json:"account"`
+	Type      string `json:"type"`
+	AmountFee string `json:"amount_fee,omitempty"`
+	AmountOut string `json:"amount_out,omitempty"`
+	// Fields is a JSON object with other params of the withdrawal request (ex. `dest`)
+	Fields *json.RawMessage `json:"fields,omitempty"`
+}
+
+// CallbackConversion contains a received amount converted to `exchange_rates.currency`
+type CallbackConversion struct {
+	Rate     string `json:"rate"`
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// TrustlineCallback is a version 2 (JSON) payload of `callbacks.trustline`
+type TrustlineCallback struct {
+	// Version is a payload schema version, always 2
+	Version     int    `json:"version"`
+	Event       string `json:"event"`
+	ID          string `json:"id"`
+	AccountID   string `json:"account_id"`
+	Asset       string `json:"asset"`
+	AssetCode   string `json:"asset_code"`
+	AssetIssuer string `json:"asset_issuer"`
+	Limit       string `json:"limit"`
+}
@@ -0,0 +1,102 @@
+package bridge
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"net/http"
+	"net/url"
+	"regexp"
+
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/protocols"
+)
+
+var (
+	// ClaimBalanceNotFound is an error response
+	ClaimBalanceNotFound = &protocols.ErrorResponse{Code: "claim_balance_not_found", Message: "Claimable balance does not exist or has already been claimed.", Status: http.StatusBadRequest}
+	// ClaimCannotClaim is an error response
+	ClaimCannotClaim = &protocols.ErrorResponse{Code: "claim_cannot_claim", Message: "Receiving account is not a claimant of the balance or the claim predicate is not satisfied.", Status: http.StatusBadRequest}
+	// ClaimLineFull is an error response
+	ClaimLineFull = &protocols.ErrorResponse{Code: "claim_line_full", Message: "Receiving account trustline limit would be exceeded.", Status: http.StatusBadRequest}
+	// ClaimNoTrust is an error response
+	ClaimNoTrust = &protocols.ErrorResponse{Code: "claim_no_trust", Message: "Receiving account does not trust the balance asset.", Status: http.StatusBadRequest}
+	// ClaimNotAuthorized is an error response
+	ClaimNotAuthorized = &protocols.ErrorResponse{Code: "claim_not_authorized", Message: "Receiving account is not authorized to hold the balance asset.", Status: http.StatusBadRequest}
+	// ClaimNoReceivingSeed is an error response
+	ClaimNoReceivingSeed = &protocols.ErrorResponse{Code: "claim_no_receiving_seed", Message: "accounts.receiving_seed is required to claim balances.", Status: http.StatusBadRequest}
+)
+
+// Claimable balance IDs are returned by Horizon as hex-encoded type (always 0) and hash
+var claimableBalanceID = regexp.MustCompile("^00000000[0-9a-f]{64}$")
+
+// ClaimRequest represents request made to /claim endpoint of bridge server
+type ClaimRequest struct {
+	BalanceID string `name:"balance_id" required:""`
+
+	protocols.FormRequest
+}
+
+// FromRequest will populate request fields using http.Request.
+func (request *ClaimRequest) FromRequest(r *http.Request) {
+	request.FormRequest.FromRequest(r, request)
+}
+
+// ToValues will create url.Values from request.
+func (request *ClaimRequest) ToValues() url.Values {
+	return request.FormRequest.ToValues(request)
+}
+
+// Validate validates if request fields are valid. Useful when checking if a request is correct.
+func (request *ClaimRequest) Validate() error {
+	err := request.FormRequest.CheckRequired(request)
+	if err != nil {
+		return err
+	}
+
+	if !claimableBalanceID.MatchString(request.BalanceID) {
+		return protocols.NewInvalidParameterError("balance_id", request.BalanceID)
+	}
+
+	return nil
+}
+
+// ErrorFromClaimResponse checks if horizon.SubmitTransactionResponse of a claim transaction is an
+// error response and creates ErrorResponse for it. Operation results of claim transactions cannot
+// be decoded using go-stellar-base so result code is read from the result XDR directly.
+func ErrorFromClaimResponse(response horizon.SubmitTransactionResponse) *protocols.ErrorResponse {
+	if response.Ledger != nil || response.Extras == nil {
+		return nil
+	}
+
+	result, err := base64.StdEncoding.DecodeString(response.Extras.ResultXdr)
+	if err != nil {
+		return protocols.NewInternalServerError(
+			"Error decoding xdr.TransactionResult",
+			map[string]interface{}{"err": err},
+		)
+	}
+
+	// int64 feeCharged, int32 code, uint32 results count, int32 opINNER, int32 operation type,
+	// int32 operation result code
+	const txFailed, opInner, operationResultOffset = -1, 0, 24
+	if len(result) < operationResultOffset+4 ||
+		int32(binary.BigEndian.Uint32(result[8:])) != txFailed ||
+		int32(binary.BigEndian.Uint32(result[16:])) != opInner {
+		return ErrorFromHorizonResponse(response)
+	}
+
+	switch int32(binary.BigEndian.Uint32(result[operationResultOffset:])) {
+	case -1:
+		return ClaimBalanceNotFound
+	case -2:
+		return ClaimCannotClaim
+	case -3:
+		return ClaimLineFull
+	case -4:
+		return ClaimNoTrust
+	case -5:
+		return ClaimNotAuthorized
+	default:
+		return protocols.InternalServerError
+	}
+}
@@ -0,0 +1,45 @@
+package bridge
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stellar/gateway/horizon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorFromClaimResponse(t *testing.T) {
+	ledger := uint64(1)
+	assert.Nil(t, ErrorFromClaimResponse(horizon.SubmitTransactionResponse{Ledger: &ledger}))
+
+	// fee charged, tx_failed, 1 result, op_inner, claim_claimable_balance, claim_claimable_balance_cannot_claim
+	var result bytes.Buffer
+	binary.Write(&result, binary.BigEndian, []int32{0, 100, -1, 1, 0, 15, -2, 0})
+	response := horizon.SubmitTransactionResponse{
+		Extras: &horizon.SubmitTransactionResponseExtras{ResultXdr: base64.StdEncoding.EncodeToString(result.Bytes())},
+	}
+	assert.Equal(t, ClaimCannotClaim, ErrorFromClaimResponse(response))
+
+	// tx_bad_seq
+	response.Extras.ResultXdr = "AAAAAAAAAAD////7AAAAAA=="
+	assert.Equal(t, TransactionBadSequence, ErrorFromClaimResponse(response))
+}
+
+func TestClaimRequestValidate(t *testing.T) {
+	validate := func(balanceID string) error {
+		r := httptest.NewRequest("POST", "/claim", strings.NewReader(url.Values{"balance_id": {balanceID}}.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		request := &ClaimRequest{}
+		request.FromRequest(r)
+		return request.Validate()
+	}
+
+	assert.NoError(t, validate("00000000929b20b72e5890ab51c24f1cc46fa01c4f318d8d33367d24dd614cfdf5491072"))
+	assert.Error(t, validate("929b20b72e5890ab51c24f1cc46fa01c4f318d8d33367d24dd614cfdf5491072"))
+	assert.Error(t, validate(""))
+}
@@ -0,0 +1,205 @@
+package bridge
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/protocols"
+	"github.com/stellar/go-stellar-base/keypair"
+)
+
+var (
+	// CreateAccountMalformed is an error response
+	CreateAccountMalformed = &protocols.ErrorResponse{Code: "create_account_malformed", Message: "Starting balance is invalid.", Status: http.StatusBadRequest}
+	// CreateAccountUnderfunded is an error response
+	CreateAccountUnderfunded = &protocols.ErrorResponse{Code: "create_account_underfunded", Message: "Not enough funds in the funding account to send starting balance.", Status: http.StatusBadRequest}
+	// CreateAccountLowReserve is an error response
+	CreateAccountLowReserve = &protocols.ErrorResponse{Code: "create_account_low_reserve", Message: "Starting balance is lower than the minimum balance of an account. Use sponsored reserves or a higher starting balance.", Status: http.StatusBadRequest}
+	// CreateAccountAlreadyExist is an error response
+	CreateAccountAlreadyExist = &protocols.ErrorResponse{Code: "create_account_already_exist", Message: "Account already exists.", Status: http.StatusBadRequest}
+	// CreateAccountNoBaseSeed is an error response
+	CreateAccountNoBaseSeed = &protocols.ErrorResponse{Code: "create_account_no_base_seed", Message: "accounts.base_seed is required to create accounts.", Status: http.StatusBadRequest}
+)
+
+// CreateAccountRequest represents request made to /create-account endpoint of bridge server
+type CreateAccountRequest struct {
+	// Secret seed of the new account. Random keypair is generated when empty.
+	Seed string `name:"seed"`
+	// Amount of XLM sent to the new account. `create_account.starting_balance` is used when empty.
+	StartingBalance string `name:"starting_balance"`
+	// Comma-separated `CODE:ISSUER` assets trusted by the new account
+	Trustlines string `name:"trustlines"`
+	// `true` if reserves of the new account and its trustlines are sponsored by the base account
+	Sponsored string `name:"sponsored"`
+
+	// Assets parsed from Trustlines by Validate
+	Assets []protocols.Asset
+
+	protocols.FormRequest
+}
+
+// FromRequest will populate request fields using http.Request.
+func (request *CreateAccountRequest) FromRequest(r *http.Request) {
+	request.FormRequest.FromRequest(r, request)
+}
+
+// ToValues will create url.Values from request.
+func (request *CreateAccountRequest) ToValues() url.Values {
+	return request.FormRequest.ToValues(request)
+}
+
+// Validate validates if request fields are valid. Useful when checking if a request is correct.
+// Assets are set using trustlines param.
+func (request *CreateAccountRequest) Validate() error {
+	err := request.FormRequest.CheckRequired(request)
+	if err != nil {
+		return err
+	}
+
+	if request.Seed != "" {
+		kp, err := keypair.Parse(request.Seed)
+		if _, ok := kp.(*keypair.Full); err != nil || !ok {
+			return protocols.NewInvalidParameterError("seed", request.Seed)
+		}
+	}
+
+	if request.StartingBalance != "" {
+		err = protocols.ValidateAmount("starting_balance", request.StartingBalance, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	if request.Sponsored != "" {
+		_, err = strconv.ParseBool(request.Sponsored)
+		if err != nil {
+			return protocols.NewInvalidParameterError("sponsored", request.Sponsored)
+		}
+	}
+
+	request.Assets = nil
+	for _, value := range strings.Split(request.Trustlines, ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		asset, err := protocols.ParseAsset(value)
+		if err != nil || asset.Code == "" {
+			return protocols.NewInvalidParameterError("trustlines", request.Trustlines)
+		}
+		request.Assets = append(request.Assets, asset)
+	}
+
+	return nil
+}
+
+// IsSponsored returns true when reserves of the new account are sponsored
+func (request *CreateAccountRequest) IsSponsored() bool {
+	sponsored, _ := strconv.ParseBool(request.Sponsored)
+	return sponsored
+}
+
+// CreateAccountResponse represents response returned by /create-account endpoint of bridge server
+type CreateAccountResponse struct {
+	protocols.SuccessResponse
+	AccountID string `json:"account_id"`
+	// Seed is returned only when the keypair has been generated by the server
+	Seed      string `json:"seed,omitempty"`
+	Hash      string `json:"hash"`
+	Ledger    uint64 `json:"ledger"`
+	Sponsored bool   `json:"sponsored"`
+	// Balances of the new account. It's empty when the account could not be loaded.
+	Balances []horizon.Balance `json:"balances"`
+}
+
+// Marshal marshals CreateAccountResponse
+func (response *CreateAccountResponse) Marshal() []byte {
+	json, _ := json.MarshalIndent(response, "", "  ")
+	return json
+}
+
+// ErrorFromCreateAccountResponse checks if horizon.SubmitTransactionResponse of a transaction
+// sent by /create-account is an error response and creates ErrorResponse for it. Operation
+// results of sponsored transactions cannot be decoded using go-stellar-base so the first
+// failed operation is found in the result XDR directly.
+func ErrorFromCreateAccountResponse(response horizon.SubmitTransactionResponse) *protocols.ErrorResponse {
+	if response.Ledger != nil || response.Extras == nil {
+		return nil
+	}
+
+	result, err := base64.StdEncoding.DecodeString(response.Extras.ResultXdr)
+	if err != nil {
+		return protocols.NewInternalServerError(
+			"Error decoding xdr.TransactionResult",
+			map[string]interface{}{"err": err},
+		)
+	}
+
+	// int64 feeCharged, int32 code, uint32 results count, then every result is int32 code and,
+	// for opINNER, int32 operation type and int32 operation result code (results of all
+	// operations sent by /create-account have no other fields)
+	const txFailed, opInner, opBadAuth, resultsOffset = -1, 0, -1, 16
+	readInt32 := func(offset int) (int32, bool) {
+		if len(result) < offset+4 {
+			return 0, false
+		}
+		return int32(binary.BigEndian.Uint32(result[offset:])), true
+	}
+
+	code, ok := readInt32(8)
+	if !ok || code != txFailed {
+		return ErrorFromHorizonResponse(response)
+	}
+
+	for offset := resultsOffset; offset < len(result); {
+		code, ok := readInt32(offset)
+		if !ok {
+			break
+		}
+		if code != opInner {
+			if code == opBadAuth {
+				return TransactionBadAuth
+			}
+			return protocols.InternalServerError
+		}
+
+		operationType, _ := readInt32(offset + 4)
+		operationCode, ok := readInt32(offset + 8)
+		if !ok {
+			break
+		}
+		offset += 12
+
+		if operationCode == 0 {
+			continue
+		}
+
+		switch {
+		case operationType == 0 && operationCode == -1:
+			return CreateAccountMalformed
+		case operationType == 0 && operationCode == -2:
+			return CreateAccountUnderfunded
+		case operationType == 0 && operationCode == -3:
+			return CreateAccountLowReserve
+		case operationType == 0 && operationCode == -4:
+			return CreateAccountAlreadyExist
+		case operationType == 6 && operationCode == -1:
+			return ChangeTrustMalformed
+		case operationType == 6 && operationCode == -2:
+			return ChangeTrustNoIssuer
+		case operationType == 6 && operationCode == -4:
+			return ChangeTrustLowReserve
+		default:
+			return protocols.InternalServerError
+		}
+	}
+
+	return protocols.InternalServerError
+}
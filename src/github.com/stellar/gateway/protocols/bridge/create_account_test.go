@@ -0,0 +1,31 @@
+package bridge
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stellar/gateway/horizon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorFromCreateAccountResponse(t *testing.T) {
+	response := func(values ...int32) horizon.SubmitTransactionResponse {
+		var result bytes.Buffer
+		binary.Write(&result, binary.BigEndian, values)
+		return horizon.SubmitTransactionResponse{
+			Extras: &horizon.SubmitTransactionResponseExtras{ResultXdr: base64.StdEncoding.EncodeToString(result.Bytes())},
+		}
+	}
+
+	// begin_sponsoring succeeded, create_account_already_exist
+	assert.Equal(t, CreateAccountAlreadyExist, ErrorFromCreateAccountResponse(response(0, 100, -1, 2, 0, 16, 0, 0, 0, -4, 0)))
+	// create_account succeeded, change_trust_no_issuer
+	assert.Equal(t, ChangeTrustNoIssuer, ErrorFromCreateAccountResponse(response(0, 100, -1, 2, 0, 0, 0, 0, 6, -2, 0)))
+	// tx_bad_auth
+	assert.Equal(t, TransactionBadAuth, ErrorFromCreateAccountResponse(response(0, 100, -6, 0, 0)))
+
+	ledger := uint64(1)
+	assert.Nil(t, ErrorFromCreateAccountResponse(horizon.SubmitTransactionResponse{Ledger: &ledger}))
+}
@@ -0,0 +1,116 @@
+package bridge
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/protocols"
+)
+
+var (
+	// CustomerNotFound is an error response
+	CustomerNotFound = &protocols.ErrorResponse{Code: "customer_not_found", Message: "Customer not found.", Status: http.StatusNotFound}
+	// CustomerMemoTaken is an error response
+	CustomerMemoTaken = &protocols.ErrorResponse{Code: "customer_memo_taken", Message: "Memo is already assigned to another customer.", Status: http.StatusBadRequest}
+)
+
+// CustomerRequest represents request made to /admin/customers endpoints of bridge server
+type CustomerRequest struct {
+	MemoType    string `name:"memo_type" required:""`
+	Memo        string `name:"memo" required:""`
+	CustomerID  string `name:"customer_id" required:""`
+	CallbackURL string `name:"callback_url"`
+
+	protocols.FormRequest
+}
+
+// FromRequest will populate request fields using http.Request.
+func (request *CustomerRequest) FromRequest(r *http.Request) {
+	request.FormRequest.FromRequest(r, request)
+}
+
+// ToValues will create url.Values from request.
+func (request *CustomerRequest) ToValues() url.Values {
+	return request.FormRequest.ToValues(request)
+}
+
+// Validate validates if request fields are valid. Useful when checking if a request is correct.
+func (request *CustomerRequest) Validate() error {
+	err := request.FormRequest.CheckRequired(request)
+	if err != nil {
+		return err
+	}
+
+	switch request.MemoType {
+	case "id":
+		_, err = strconv.ParseUint(request.Memo, 10, 64)
+		if err != nil {
+			return protocols.NewInvalidParameterError("memo", request.Memo)
+		}
+	case "text":
+		if len(request.Memo) > 28 {
+			return protocols.NewInvalidParameterError("memo", request.Memo)
+		}
+	default:
+		return protocols.NewInvalidParameterError("memo_type", request.MemoType)
+	}
+
+	if request.CallbackURL != "" {
+		_, err = url.ParseRequestURI(request.CallbackURL)
+		if err != nil {
+			return protocols.NewInvalidParameterError("callback_url", request.CallbackURL)
+		}
+	}
+
+	return nil
+}
+
+// Customer represents a customer returned by /admin/customers endpoints of bridge server
+type Customer struct {
+	ID          int64     `json:"id"`
+	MemoType    string    `json:"memo_type"`
+	Memo        string    `json:"memo"`
+	CustomerID  string    `json:"customer_id"`
+	CallbackURL string    `json:"callback_url,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// NewCustomer creates Customer from a DB entity
+func NewCustomer(customer *entities.Customer) Customer {
+	return Customer{
+		ID:          *customer.ID,
+		MemoType:    customer.MemoType,
+		Memo:        customer.Memo,
+		CustomerID:  customer.CustomerID,
+		CallbackURL: customer.CallbackURL,
+		CreatedAt:   customer.CreatedAt,
+	}
+}
+
+// CustomerResponse represents response returned by /admin/customers/:id endpoint of bridge server
+type CustomerResponse struct {
+	protocols.SuccessResponse
+	Customer
+}
+
+// Marshal marshals CustomerResponse
+func (response *CustomerResponse) Marshal() []byte {
+	json, _ := json.MarshalIndent(response, "", "  ")
+	return json
+}
+
+// CustomersResponse represents response returned by /admin/customers endpoint of bridge server
+type CustomersResponse struct {
+	protocols.SuccessResponse
+	Customers []Customer `json:"customers"`
+}
+
+// Marshal marshals CustomersResponse
+func (response *CustomersResponse) Marshal() []byte {
+	json, _ := json.MarshalIndent(response, "", "  ")
+	return json
+}
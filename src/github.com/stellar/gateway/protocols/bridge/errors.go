@@ -79,6 +79,19 @@ func ErrorFromHorizonResponse(response horizon.SubmitTransactionResponse) *proto
 				default:
 					return protocols.InternalServerError
 				}
+			} else if operationsResult.Tr.ChangeTrustResult != nil {
+				switch operationsResult.Tr.ChangeTrustResult.Code {
+				case xdr.ChangeTrustResultCodeChangeTrustMalformed:
+					return ChangeTrustMalformed
+				case xdr.ChangeTrustResultCodeChangeTrustNoIssuer:
+					return ChangeTrustNoIssuer
+				case xdr.ChangeTrustResultCodeChangeTrustInvalidLimit:
+					return ChangeTrustInvalidLimit
+				case xdr.ChangeTrustResultCodeChangeTrustLowReserve:
+					return ChangeTrustLowReserve
+				default:
+					return protocols.InternalServerError
+				}
 			} else if operationsResult.Tr.PaymentResult != nil {
 				switch operationsResult.Tr.PaymentResult.Code {
 				case xdr.PaymentResultCodePaymentMalformed:
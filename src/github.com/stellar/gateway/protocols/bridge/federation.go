@@ -0,0 +1,30 @@
+package bridge
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/stellar/gateway/protocols"
+)
+
+var (
+	// FederationNotFound is an error response
+	FederationNotFound = &protocols.ErrorResponse{Code: "not_found", Message: "Stellar address not found.", Status: http.StatusNotFound}
+	// FederationTypeNotSupported is an error response
+	FederationTypeNotSupported = &protocols.ErrorResponse{Code: "not_implemented", Message: "Only `name` federation requests are supported.", Status: http.StatusNotImplemented}
+)
+
+// FederationResponse represents response returned by /federation endpoint of bridge server
+type FederationResponse struct {
+	protocols.SuccessResponse
+	StellarAddress string `json:"stellar_address"`
+	AccountID      string `json:"account_id"`
+	MemoType       string `json:"memo_type,omitempty"`
+	Memo           string `json:"memo,omitempty"`
+}
+
+// Marshal marshals FederationResponse
+func (response *FederationResponse) Marshal() []byte {
+	json, _ := json.MarshalIndent(response, "", "  ")
+	return json
+}
@@ -0,0 +1,40 @@
+package bridge
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/stellar/gateway/protocols"
+)
+
+// FlushFederationCacheRequest represents request made to POST /admin/federation-cache/flush
+// endpoint of bridge server. All cached addresses are flushed when Address is empty.
+type FlushFederationCacheRequest struct {
+	Address string `name:"address"`
+
+	protocols.FormRequest
+}
+
+// FromRequest will populate request fields using http.Request.
+func (request *FlushFederationCacheRequest) FromRequest(r *http.Request) {
+	request.FormRequest.FromRequest(r, request)
+}
+
+// ToValues will create url.Values from request.
+func (request *FlushFederationCacheRequest) ToValues() url.Values {
+	return request.FormRequest.ToValues(request)
+}
+
+// FlushFederationCacheResponse represents response returned by POST /admin/federation-cache/flush
+// endpoint of bridge server
+type FlushFederationCacheResponse struct {
+	protocols.SuccessResponse
+	Flushed int `json:"flushed"`
+}
+
+// Marshal marshals FlushFederationCacheResponse
+func (response *FlushFederationCacheResponse) Marshal() []byte {
+	json, _ := json.MarshalIndent(response, "", "  ")
+	return json
+}
@@ -0,0 +1,30 @@
+package bridge
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// StatusUnavailable is a status of HealthResponse when one of the checks failed
+const StatusUnavailable = "unavailable"
+
+// HealthResponse represents response returned by GET /healthz and GET /readyz endpoints of
+// bridge server. Checks contains StatusOK or an error of every check run by /readyz.
+type HealthResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// HTTPStatus returns http.StatusServiceUnavailable when one of the checks failed
+func (response *HealthResponse) HTTPStatus() int {
+	if response.Status != StatusOK {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusOK
+}
+
+// Marshal marshals HealthResponse
+func (response *HealthResponse) Marshal() []byte {
+	json, _ := json.MarshalIndent(response, "", "  ")
+	return json
+}
@@ -0,0 +1,53 @@
+package bridge
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/stellar/gateway/protocols"
+	"github.com/stellar/gateway/signer"
+)
+
+var (
+	// KeystoreLocked is an error response
+	KeystoreLocked = &protocols.ErrorResponse{Code: "keystore_locked", Message: "Keystore is locked, unlock it using admin API.", Status: http.StatusServiceUnavailable}
+	// KeystoreInvalidPassphrase is an error response
+	KeystoreInvalidPassphrase = &protocols.ErrorResponse{Code: "invalid_passphrase", Message: "Invalid keystore passphrase.", Status: http.StatusBadRequest}
+)
+
+// UnlockKeystoreRequest represents request made to POST /admin/keystore/unlock endpoint of
+// bridge server
+type UnlockKeystoreRequest struct {
+	Passphrase string `name:"passphrase" required:""`
+
+	protocols.FormRequest
+}
+
+// FromRequest will populate request fields using http.Request.
+func (request *UnlockKeystoreRequest) FromRequest(r *http.Request) {
+	request.FormRequest.FromRequest(r, request)
+}
+
+// ToValues will create url.Values from request.
+func (request *UnlockKeystoreRequest) ToValues() url.Values {
+	return request.FormRequest.ToValues(request)
+}
+
+// Validate validates if request fields are valid. Useful when checking if a request is correct.
+func (request *UnlockKeystoreRequest) Validate() error {
+	return request.FormRequest.CheckRequired(request)
+}
+
+// KeystoreResponse represents response returned by /admin/keystore endpoints of bridge server
+type KeystoreResponse struct {
+	protocols.SuccessResponse
+	Locked bool                 `json:"locked"`
+	Keys   []signer.KeystoreKey `json:"keys"`
+}
+
+// Marshal marshals KeystoreResponse
+func (response *KeystoreResponse) Marshal() []byte {
+	json, _ := json.MarshalIndent(response, "", "  ")
+	return json
+}
@@ -0,0 +1,62 @@
+package bridge
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"strconv"
+
+	"github.com/stellar/gateway/protocols"
+	b "github.com/stellar/go-stellar-base/build"
+	"github.com/stellar/go-stellar-base/xdr"
+)
+
+// MemoMutator returns transaction mutator setting memo of memoType (`id`, `text`, `hash` or
+// `return`) or nil when memoType is empty. Values of `hash` and `return` memos are 32 bytes
+// encoded using hex or base64 (as returned by Horizon).
+func MemoMutator(memoType, memo string) (b.TransactionMutator, error) {
+	switch memoType {
+	case "":
+		return nil, nil
+	case "id":
+		id, err := strconv.ParseUint(memo, 10, 64)
+		if err != nil {
+			return nil, protocols.NewInvalidParameterError("memo", memo)
+		}
+		return b.MemoID{id}, nil
+	case "text":
+		if len(memo) > 28 {
+			return nil, protocols.NewInvalidParameterError("memo", memo)
+		}
+		return &b.MemoText{memo}, nil
+	case "hash", "return":
+		hash, ok := DecodeMemoHash(memo)
+		if !ok {
+			return nil, protocols.NewInvalidParameterError("memo", memo)
+		}
+		if memoType == "return" {
+			return &b.MemoReturn{hash}, nil
+		}
+		return &b.MemoHash{hash}, nil
+	default:
+		return nil, protocols.NewInvalidParameterError("memo_type", memoType)
+	}
+}
+
+// DecodeMemoHash decodes 32-byte value of `hash` or `return` memo encoded using hex or base64
+func DecodeMemoHash(memo string) (hash xdr.Hash, ok bool) {
+	memoBytes, err := hex.DecodeString(memo)
+	if err != nil {
+		memoBytes, err = base64.StdEncoding.DecodeString(memo)
+	}
+	if err != nil || len(memoBytes) != len(hash) {
+		return hash, false
+	}
+	copy(hash[:], memoBytes)
+	return hash, true
+}
+
+// IsBinaryMemo returns true when memoType is `hash` or `return`. Values of binary memos are
+// not human readable so they are not used as routes of received payments.
+func IsBinaryMemo(memoType string) bool {
+	return memoType == "hash" || memoType == "return"
+}
@@ -0,0 +1,55 @@
+package bridge
+
+import (
+	"testing"
+
+	"github.com/stellar/gateway/protocols"
+	b "github.com/stellar/go-stellar-base/build"
+	"github.com/stellar/go-stellar-base/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoMutator(t *testing.T) {
+	hexHash := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	base64Hash := "ASNFZ4mrze8BI0VniavN7wEjRWeJq83vASNFZ4mrze8="
+
+	memo := func(memoType, value string) xdr.Memo {
+		mutator, err := MemoMutator(memoType, value)
+		require.NoError(t, err)
+		tx := b.Transaction(mutator)
+		require.NoError(t, tx.Err)
+		return tx.TX.Memo
+	}
+
+	mutator, err := MemoMutator("", "")
+	assert.NoError(t, err)
+	assert.Nil(t, mutator)
+
+	assert.Equal(t, xdr.Uint64(123), *memo("id", "123").Id)
+	assert.Equal(t, "hello", *memo("text", "hello").Text)
+
+	for _, value := range []string{hexHash, base64Hash} {
+		hashMemo := memo("hash", value)
+		assert.Equal(t, xdr.MemoTypeMemoHash, hashMemo.Type)
+		assert.Equal(t, byte(0x01), hashMemo.Hash[0])
+
+		returnMemo := memo("return", value)
+		assert.Equal(t, xdr.MemoTypeMemoReturn, returnMemo.Type)
+		assert.Equal(t, *hashMemo.Hash, *returnMemo.RetHash)
+	}
+
+	invalid := []struct{ memoType, value, param string }{
+		{"id", "abc", "memo"},
+		{"text", "this text is longer than 28 chars", "memo"},
+		{"hash", "0123", "memo"},
+		{"return", "ASNFZ4mrze8=", "memo"},
+		{"return", "not a hash", "memo"},
+		{"none", "", "memo_type"},
+	}
+	for _, test := range invalid {
+		_, err := MemoMutator(test.memoType, test.value)
+		require.Error(t, err, test.memoType+" "+test.value)
+		assert.Equal(t, test.param, err.(*protocols.ErrorResponse).Data["name"])
+	}
+}
@@ -0,0 +1,86 @@
+package bridge
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/stellar/gateway/protocols"
+	"github.com/stellar/gateway/protocols/muxed"
+)
+
+// MuxedAddressRequest represents request made to /muxed-address endpoint of bridge server.
+// Either `address` or both `account_id` and `memo_id` must be set.
+type MuxedAddressRequest struct {
+	Address   string `name:"address"`
+	AccountID string `name:"account_id"`
+	MemoID    string `name:"memo_id"`
+
+	protocols.FormRequest
+}
+
+// FromRequest will populate request fields using http.Request.
+func (request *MuxedAddressRequest) FromRequest(r *http.Request) {
+	request.FormRequest.FromRequest(r, request)
+}
+
+// ToValues will create url.Values from request.
+func (request *MuxedAddressRequest) ToValues() url.Values {
+	return request.FormRequest.ToValues(request)
+}
+
+// Process converts address to account ID and memo ID or account ID and memo ID to address,
+// depending on which parameters are set.
+func (request *MuxedAddressRequest) Process() (*MuxedAddressResponse, error) {
+	if request.Address != "" {
+		accountID, id, err := muxed.Decode(request.Address)
+		if err != nil {
+			return nil, protocols.NewInvalidParameterError("address", request.Address)
+		}
+
+		return &MuxedAddressResponse{
+			Address:   request.Address,
+			AccountID: accountID,
+			MemoID:    strconv.FormatUint(id, 10),
+		}, nil
+	}
+
+	if request.AccountID == "" {
+		return nil, protocols.NewMissingParameter("account_id")
+	}
+
+	if request.MemoID == "" {
+		return nil, protocols.NewMissingParameter("memo_id")
+	}
+
+	id, err := strconv.ParseUint(request.MemoID, 10, 64)
+	if err != nil {
+		return nil, protocols.NewInvalidParameterError("memo_id", request.MemoID)
+	}
+
+	address, err := muxed.Encode(request.AccountID, id)
+	if err != nil {
+		return nil, protocols.NewInvalidParameterError("account_id", request.AccountID)
+	}
+
+	return &MuxedAddressResponse{
+		Address:   address,
+		AccountID: request.AccountID,
+		MemoID:    request.MemoID,
+	}, nil
+}
+
+// MuxedAddressResponse represents response returned by /muxed-address endpoint of bridge server
+type MuxedAddressResponse struct {
+	protocols.SuccessResponse
+	Address   string `json:"address"`
+	AccountID string `json:"account_id"`
+	MemoID    string `json:"memo_id"`
+}
+
+// Marshal marshals MuxedAddressResponse
+func (response *MuxedAddressResponse) Marshal() []byte {
+	json, _ := json.MarshalIndent(response, "", "  ")
+	return json
+}
@@ -1,9 +1,12 @@
 package bridge
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/stellar/gateway/protocols"
 	"github.com/stellar/gateway/protocols/compliance"
@@ -17,10 +20,23 @@ var (
 	PaymentCannotResolveDestination = &protocols.ErrorResponse{Code: "cannot_resolve_destination", Message: "Cannot resolve federated Stellar address.", Status: http.StatusBadRequest}
 	// PaymentCannotUseMemo is an error response
 	PaymentCannotUseMemo = &protocols.ErrorResponse{Code: "cannot_use_memo", Message: "Memo given in request but federation returned memo fields.", Status: http.StatusBadRequest}
+	// PaymentMemoRequired is an error response
+	PaymentMemoRequired = &protocols.ErrorResponse{Code: "memo_required", Message: "Destination account requires a memo (SEP-29).", Status: http.StatusBadRequest}
 	// PaymentSourceNotExist is an error response
 	PaymentSourceNotExist = &protocols.ErrorResponse{Code: "source_not_exist", Message: "Source account does not exist.", Status: http.StatusBadRequest}
 	// PaymentAssetCodeNotAllowed is an error response
 	PaymentAssetCodeNotAllowed = &protocols.ErrorResponse{Code: "asset_code_not_allowed", Message: "Given asset_code not allowed.", Status: http.StatusBadRequest}
+	// PaymentIdempotencyKeyInProgress is an error response
+	PaymentIdempotencyKeyInProgress = &protocols.ErrorResponse{Code: "idempotency_key_in_progress", Message: "Payment with this idempotency_key is being sent or its result is unknown.", Status: http.StatusConflict}
+	// PaymentLimitExceeded is an error response
+	PaymentLimitExceeded = &protocols.ErrorResponse{Code: "payment_limit_exceeded", Message: "Payment exceeds one of configured limits.", Status: http.StatusForbidden}
+
+	// regulated assets
+
+	// PaymentNotApproved is an error response
+	PaymentNotApproved = &protocols.ErrorResponse{Code: "payment_not_approved", Message: "Payment of a regulated asset has been rejected by the approve callback.", Status: http.StatusForbidden}
+	// ApproveCallbackFailed is an error response
+	ApproveCallbackFailed = &protocols.ErrorResponse{Code: "approve_callback_failed", Message: "Approve callback did not respond with a known status.", Status: http.StatusBadGateway}
 
 	// compliance
 
@@ -57,12 +73,20 @@ var (
 	PaymentOverSendmax = &protocols.ErrorResponse{Code: "payment_over_sendmax", Message: "Could not satisfy sendmax.", Status: http.StatusBadRequest}
 )
 
+// MaxMetadataLength is the maximum length of `metadata` param of /payment request
+const MaxMetadataLength = 4096
+
+// MaxIdempotencyKeyLength is the maximum length of `idempotency_key` param of /payment request
+const MaxIdempotencyKeyLength = 128
+
 // PaymentRequest represents request made to /payment endpoint of the bridge server
 type PaymentRequest struct {
 	// Source account secret
 	Source string `name:"source"`
 	// Sender address (like alice*stellar.org)
 	Sender string `name:"sender"`
+	// ID of a SEP-12 customer of the compliance server used as sender info
+	SenderCustomerID string `name:"sender_customer_id"`
 	// Destination address (like bob*stellar.org)
 	Destination string `name:"destination" required:""`
 	// Memo type
@@ -75,16 +99,34 @@ type PaymentRequest struct {
 	AssetCode string `name:"asset_code"`
 	// Issuer of the asset destination should receive
 	AssetIssuer string `name:"asset_issuer"`
+	// Asset destination should receive as `CODE:ISSUER` or `native`. Alternative to asset_code and asset_issuer.
+	Asset string `name:"asset"`
 	// Only for path_payment
 	SendMax string `name:"send_max"`
 	// Only for path_payment
 	SendAssetCode string `name:"send_asset_code"`
 	// Only for path_payment
 	SendAssetIssuer string `name:"send_asset_issuer"`
+	// Only for path_payment. Alternative to send_asset_code and send_asset_issuer.
+	SendAsset string `name:"send_asset"`
 	// path[n][asset_code] path[n][asset_issuer]
 	Path []protocols.Asset `name:"path"`
 	// Extra memo
 	ExtraMemo string `name:"extra_memo"`
+	// Operation ID of the received payment this payment originates from
+	ReceivedPaymentID string `name:"received_payment_id"`
+	// ID of a SEP-6 deposit completed by this payment
+	TransferID string `name:"transfer_id"`
+	// Opaque JSON object stored with the sent transaction
+	Metadata string `name:"metadata"`
+	// Client generated key. Requests with a key that has already been used return the original result.
+	IdempotencyKey string `name:"idempotency_key"`
+	// Preconditions of the transaction, see TransactionPreconditions
+	MinTime        string `name:"min_time"`
+	MaxTime        string `name:"max_time"`
+	MinLedger      string `name:"min_ledger"`
+	MaxLedger      string `name:"max_ledger"`
+	MinSequenceAge string `name:"min_sequence_age"`
 
 	protocols.FormRequest
 }
@@ -99,32 +141,77 @@ func (request *PaymentRequest) ToValues() url.Values {
 	return request.FormRequest.ToValues(request)
 }
 
-// ToComplianceSendRequest transforms PaymentRequest to compliance.SendRequest
-func (request *PaymentRequest) ToComplianceSendRequest() compliance.SendRequest {
-	sourceKeypair, _ := keypair.Parse(request.Source)
+// PaymentPreviewResponse represents response returned by /payment/preview endpoint of bridge server
+type PaymentPreviewResponse struct {
+	protocols.SuccessResponse
+	// Unsigned transaction that would be submitted
+	TransactionXdr string `json:"transaction_xdr"`
+	// Fee of the transaction in stroops
+	Fee uint32 `json:"fee"`
+	// Errors the transaction would fail with. It's empty when the payment can be sent.
+	Errors []*protocols.ErrorResponse `json:"errors"`
+}
+
+// Marshal marshals PaymentPreviewResponse
+func (response *PaymentPreviewResponse) Marshal() []byte {
+	json, _ := json.MarshalIndent(response, "", "  ")
+	return json
+}
+
+// ToComplianceSendRequest transforms PaymentRequest to compliance.SendRequest. sourceAddress
+// is an address of Source which can be a key reference.
+func (request *PaymentRequest) ToComplianceSendRequest(sourceAddress string) compliance.SendRequest {
 	return compliance.SendRequest{
 		// Compliance does not sign transaction, it just needs public key
-		Source:          sourceKeypair.Address(),
-		Sender:          request.Sender,
-		Destination:     request.Destination,
-		Amount:          request.Amount,
-		AssetCode:       request.AssetCode,
-		AssetIssuer:     request.AssetIssuer,
-		SendMax:         request.SendMax,
-		SendAssetCode:   request.SendAssetCode,
-		SendAssetIssuer: request.SendAssetIssuer,
-		Path:            request.Path,
-		ExtraMemo:       request.ExtraMemo,
+		Source:           sourceAddress,
+		Sender:           request.Sender,
+		Destination:      request.Destination,
+		Amount:           request.Amount,
+		AssetCode:        request.AssetCode,
+		AssetIssuer:      request.AssetIssuer,
+		SendMax:          request.SendMax,
+		SendAssetCode:    request.SendAssetCode,
+		SendAssetIssuer:  request.SendAssetIssuer,
+		Path:             request.Path,
+		ExtraMemo:        request.ExtraMemo,
+		SenderCustomerID: request.SenderCustomerID,
 	}
 }
 
 // Validate validates if request fields are valid. Useful when checking if a request is correct.
+// Code and issuer fields are set using asset and send_asset params when they are present.
 func (request *PaymentRequest) Validate() error {
 	err := request.FormRequest.CheckRequired(request)
 	if err != nil {
 		return err
 	}
 
+	if request.Asset != "" {
+		err = parseAssetParam("asset", request.Asset, &request.AssetCode, &request.AssetIssuer)
+		if err != nil {
+			return err
+		}
+	}
+
+	if request.SendAsset != "" {
+		err = parseAssetParam("send_asset", request.SendAsset, &request.SendAssetCode, &request.SendAssetIssuer)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = protocols.ValidateAmount("amount", request.Amount, true)
+	if err != nil {
+		return err
+	}
+
+	if request.SendMax != "" {
+		err = protocols.ValidateAmount("send_max", request.SendMax, true)
+		if err != nil {
+			return err
+		}
+	}
+
 	if request.Source != "" {
 		_, err = keypair.Parse(request.Source)
 		if err != nil {
@@ -173,6 +260,71 @@ func (request *PaymentRequest) Validate() error {
 		}
 	}
 
+	if request.Metadata != "" {
+		var metadata map[string]interface{}
+		err = json.Unmarshal([]byte(request.Metadata), &metadata)
+		if err != nil || metadata == nil || len(request.Metadata) > MaxMetadataLength {
+			return protocols.NewInvalidParameterError("metadata", request.Metadata)
+		}
+	}
+
+	if len(request.IdempotencyKey) > MaxIdempotencyKeyLength {
+		return protocols.NewInvalidParameterError("idempotency_key", request.IdempotencyKey)
+	}
+
+	preconditions, err := request.Preconditions()
+	if err != nil {
+		return err
+	}
+
+	return preconditions.Validate()
+}
+
+// Preconditions returns preconditions of the transaction set by min_time, max_time, min_ledger,
+// max_ledger and min_sequence_age params
+func (request *PaymentRequest) Preconditions() (preconditions TransactionPreconditions, err error) {
+	params := []struct {
+		name  string
+		value string
+		bits  int
+		set   func(uint64)
+	}{
+		{"min_time", request.MinTime, 64, func(v uint64) { preconditions.MinTime = v }},
+		{"max_time", request.MaxTime, 64, func(v uint64) { preconditions.MaxTime = v }},
+		{"min_ledger", request.MinLedger, 32, func(v uint64) { preconditions.MinLedger = uint32(v) }},
+		{"max_ledger", request.MaxLedger, 32, func(v uint64) { preconditions.MaxLedger = uint32(v) }},
+		{"min_sequence_age", request.MinSequenceAge, 64, func(v uint64) { preconditions.MinSequenceAge = v }},
+	}
+
+	for _, param := range params {
+		if param.value == "" {
+			continue
+		}
+
+		value, parseErr := strconv.ParseUint(param.value, 10, param.bits)
+		if parseErr != nil {
+			err = protocols.NewInvalidParameterError(param.name, param.value)
+			return
+		}
+		param.set(value)
+	}
+	return
+}
+
+// parseAssetParam sets code and issuer using canonical asset string in value of name param.
+// It returns an error if the asset is invalid or code and issuer are already set to a different asset.
+func parseAssetParam(name, value string, code, issuer *string) error {
+	asset, err := protocols.ParseAsset(value)
+	if err != nil {
+		return protocols.NewInvalidParameterError(name, value, map[string]interface{}{"err": err})
+	}
+
+	if (*code != "" || *issuer != "") && (protocols.Asset{Code: *code, Issuer: *issuer}) != asset {
+		return protocols.NewInvalidParameterError(name, value, map[string]interface{}{"err": "asset does not match code and issuer params"})
+	}
+
+	*code = asset.Code
+	*issuer = asset.Issuer
 	return nil
 }
 
@@ -181,6 +333,17 @@ func validateStellarAddress(address string) bool {
 	return len(tokens) == 2
 }
 
+// NewPaymentNotApprovedError creates a new PaymentNotApproved error with the reason returned by
+// the approve callback
+func NewPaymentNotApprovedError(reason string) *protocols.ErrorResponse {
+	return &protocols.ErrorResponse{
+		Status:  PaymentNotApproved.Status,
+		Code:    PaymentNotApproved.Code,
+		Message: PaymentNotApproved.Message,
+		Data:    map[string]interface{}{"error": reason},
+	}
+}
+
 // NewPaymentPendingError creates a new PaymentPending error
 func NewPaymentPendingError(seconds int) *protocols.ErrorResponse {
 	return &protocols.ErrorResponse{
@@ -190,3 +353,23 @@ func NewPaymentPendingError(seconds int) *protocols.ErrorResponse {
 		Data:    map[string]interface{}{"pending": seconds},
 	}
 }
+
+// NewPaymentLimitExceededError creates a new PaymentLimitExceeded error response with the name of
+// the exceeded limit, its maximum amount and the amount that can still be sent. resetsAt is omitted when zero.
+func NewPaymentLimitExceededError(limit, max, remaining string, resetsAt time.Time) *protocols.ErrorResponse {
+	data := map[string]interface{}{
+		"limit":     limit,
+		"max":       max,
+		"remaining": remaining,
+	}
+	if !resetsAt.IsZero() {
+		data["resets_at"] = resetsAt
+	}
+
+	return &protocols.ErrorResponse{
+		Status:  PaymentLimitExceeded.Status,
+		Code:    PaymentLimitExceeded.Code,
+		Message: PaymentLimitExceeded.Message,
+		Data:    data,
+	}
+}
@@ -0,0 +1,264 @@
+package bridge
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/protocols"
+)
+
+// Limits of /received-payments and /sent-payments request params
+const (
+	DefaultPaymentsExportLimit = 50
+	MaxPaymentsExportLimit     = 200
+)
+
+// CSV columns of /received-payments and /sent-payments responses
+var (
+	receivedPaymentsCSVHeader = []string{"id", "status", "processed_at", "from", "to", "amount", "asset", "memo_type", "memo", "refund_transaction_id"}
+	sentPaymentsCSVHeader     = []string{"hash", "status", "submitted_at", "succeeded_at", "ledger", "source", "destination", "amount", "asset", "metadata"}
+)
+
+var receivedPaymentStatuses = []string{
+	entities.ReceivedPaymentStatusSuccess,
+	entities.ReceivedPaymentStatusRefunded,
+	entities.ReceivedPaymentStatusResolved,
+	entities.ReceivedPaymentStatusIgnored,
+	entities.ReceivedPaymentStatusCallbackPending,
+	entities.ReceivedPaymentStatusDeadLetter,
+	entities.ReceivedPaymentStatusCallbackFailed,
+	entities.ReceivedPaymentStatusMemoFiltered,
+	entities.ReceivedPaymentStatusDustSkipped,
+	entities.ReceivedPaymentStatusDustSwept,
+}
+
+var sentTransactionStatuses = []entities.SentTransactionStatus{
+	entities.SentTransactionStatusSending,
+	entities.SentTransactionStatusSuccess,
+	entities.SentTransactionStatusFailure,
+	entities.SentTransactionStatusTimeout,
+}
+
+// PaymentsExportRequest represents request made to /received-payments or /sent-payments
+// endpoint of bridge server
+type PaymentsExportRequest struct {
+	// Cursor returned by the previous request. Empty cursor returns payments from the beginning.
+	Cursor string
+	Status string
+	// Asset is `native` or `CODE:ISSUER`
+	Asset string
+	// Since and Until are RFC 3339 timestamps
+	Since string
+	Until string
+	Limit string
+}
+
+// FromRequest will populate request fields using query params of http.Request.
+func (request *PaymentsExportRequest) FromRequest(r *http.Request) {
+	query := r.URL.Query()
+	request.Cursor = query.Get("cursor")
+	request.Status = query.Get("status")
+	request.Asset = query.Get("asset")
+	request.Since = query.Get("since")
+	request.Until = query.Get("until")
+	request.Limit = query.Get("limit")
+}
+
+// ParseReceived validates request params and returns a filter of received payments
+func (request *PaymentsExportRequest) ParseReceived() (filter entities.ReceivedPaymentFilter, err error) {
+	filter.Cursor, filter.Since, filter.Until, filter.Limit, err = request.parse()
+	if err != nil {
+		return
+	}
+
+	if request.Status != "" {
+		for _, status := range receivedPaymentStatuses {
+			if request.Status == status {
+				filter.Status = status
+			}
+		}
+		if filter.Status == "" {
+			err = protocols.NewInvalidParameterError("status", request.Status)
+			return
+		}
+	}
+
+	if request.Asset != "" {
+		asset, parseErr := protocols.ParseAsset(request.Asset)
+		if parseErr != nil {
+			err = protocols.NewInvalidParameterError("asset", request.Asset)
+			return
+		}
+		filter.AssetCode = &asset.Code
+		filter.AssetIssuer = &asset.Issuer
+	}
+
+	return
+}
+
+// ParseSent validates request params and returns a filter of sent payments
+func (request *PaymentsExportRequest) ParseSent() (filter entities.SentTransactionFilter, err error) {
+	filter.Cursor, filter.Since, filter.Until, filter.Limit, err = request.parse()
+	if err != nil {
+		return
+	}
+
+	if request.Status != "" {
+		for _, status := range sentTransactionStatuses {
+			if entities.SentTransactionStatus(request.Status) == status {
+				filter.Status = status
+			}
+		}
+		if filter.Status == "" {
+			err = protocols.NewInvalidParameterError("status", request.Status)
+			return
+		}
+	}
+
+	if request.Asset != "" {
+		asset, parseErr := protocols.ParseAsset(request.Asset)
+		if parseErr != nil {
+			err = protocols.NewInvalidParameterError("asset", request.Asset)
+			return
+		}
+		filter.Asset = asset.String()
+	}
+
+	return
+}
+
+// parse validates params common to both endpoints. Defaults are used for params that are not set.
+func (request *PaymentsExportRequest) parse() (cursor int64, since, until *time.Time, limit int, err error) {
+	limit = DefaultPaymentsExportLimit
+
+	if request.Cursor != "" {
+		cursor, err = strconv.ParseInt(request.Cursor, 10, 64)
+		if err != nil || cursor < 0 {
+			err = protocols.NewInvalidParameterError("cursor", request.Cursor)
+			return
+		}
+	}
+
+	if request.Since != "" {
+		parsed, parseErr := time.Parse(time.RFC3339, request.Since)
+		if parseErr != nil {
+			err = protocols.NewInvalidParameterError("since", request.Since)
+			return
+		}
+		since = &parsed
+	}
+
+	if request.Until != "" {
+		parsed, parseErr := time.Parse(time.RFC3339, request.Until)
+		if parseErr != nil {
+			err = protocols.NewInvalidParameterError("until", request.Until)
+			return
+		}
+		until = &parsed
+	}
+
+	if request.Limit != "" {
+		limit, err = strconv.Atoi(request.Limit)
+		if err != nil || limit < 1 || limit > MaxPaymentsExportLimit {
+			err = protocols.NewInvalidParameterError("limit", request.Limit)
+			return
+		}
+	}
+
+	return
+}
+
+// ReceivedPaymentsResponse represents response returned by /received-payments endpoint of bridge server
+type ReceivedPaymentsResponse struct {
+	protocols.SuccessResponse
+	Payments []ReceivedPayment `json:"payments"`
+	// Cursor to send in the next request
+	Cursor string `json:"cursor"`
+}
+
+// Marshal marshals ReceivedPaymentsResponse
+func (response *ReceivedPaymentsResponse) Marshal() []byte {
+	json, _ := json.MarshalIndent(response, "", "  ")
+	return json
+}
+
+// MarshalCSV marshals payments of ReceivedPaymentsResponse to CSV with a header row
+func (response *ReceivedPaymentsResponse) MarshalCSV() []byte {
+	records := [][]string{receivedPaymentsCSVHeader}
+	for _, payment := range response.Payments {
+		records = append(records, []string{
+			payment.ID,
+			payment.Status,
+			payment.ProcessedAt.Format(time.RFC3339),
+			payment.From,
+			payment.To,
+			payment.Amount,
+			payment.Asset,
+			payment.MemoType,
+			payment.Memo,
+			stringValue(payment.RefundTransactionID),
+		})
+	}
+	return marshalCSV(records)
+}
+
+// SentPaymentsResponse represents response returned by /sent-payments endpoint of bridge server
+type SentPaymentsResponse struct {
+	protocols.SuccessResponse
+	Payments []SentTransaction `json:"payments"`
+	// Cursor to send in the next request
+	Cursor string `json:"cursor"`
+}
+
+// Marshal marshals SentPaymentsResponse
+func (response *SentPaymentsResponse) Marshal() []byte {
+	json, _ := json.MarshalIndent(response, "", "  ")
+	return json
+}
+
+// MarshalCSV marshals payments of SentPaymentsResponse to CSV with a header row
+func (response *SentPaymentsResponse) MarshalCSV() []byte {
+	records := [][]string{sentPaymentsCSVHeader}
+	for _, payment := range response.Payments {
+		var succeededAt, ledger string
+		if payment.SucceededAt != nil {
+			succeededAt = payment.SucceededAt.Format(time.RFC3339)
+		}
+		if payment.Ledger != nil {
+			ledger = strconv.FormatUint(*payment.Ledger, 10)
+		}
+
+		records = append(records, []string{
+			payment.Hash,
+			payment.Status,
+			payment.SubmittedAt.Format(time.RFC3339),
+			succeededAt,
+			ledger,
+			payment.Source,
+			payment.Destination,
+			payment.Amount,
+			payment.Asset,
+			string(payment.Metadata),
+		})
+	}
+	return marshalCSV(records)
+}
+
+func marshalCSV(records [][]string) []byte {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	writer.WriteAll(records)
+	return buffer.Bytes()
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
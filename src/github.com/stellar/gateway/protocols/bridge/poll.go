@@ -0,0 +1,82 @@
+package bridge
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/stellar/gateway/protocols"
+)
+
+// Limits of /payments/poll request params
+const (
+	DefaultPollTimeout = 30 * time.Second
+	MaxPollTimeout     = 60 * time.Second
+	DefaultPollLimit   = 50
+	MaxPollLimit       = 200
+)
+
+// PollRequest represents request made to /payments/poll endpoint of bridge server
+type PollRequest struct {
+	// Cursor returned by the previous request. Empty cursor returns payments from the beginning.
+	Cursor  string
+	Timeout string
+	Limit   string
+}
+
+// FromRequest will populate request fields using query params of http.Request.
+func (request *PollRequest) FromRequest(r *http.Request) {
+	query := r.URL.Query()
+	request.Cursor = query.Get("cursor")
+	request.Timeout = query.Get("timeout")
+	request.Limit = query.Get("limit")
+}
+
+// Parse validates request params and returns their values. Defaults are used for params that are not set.
+func (request *PollRequest) Parse() (cursor int64, timeout time.Duration, limit int, err error) {
+	timeout = DefaultPollTimeout
+	limit = DefaultPollLimit
+
+	if request.Cursor != "" {
+		cursor, err = strconv.ParseInt(request.Cursor, 10, 64)
+		if err != nil || cursor < 0 {
+			err = protocols.NewInvalidParameterError("cursor", request.Cursor)
+			return
+		}
+	}
+
+	if request.Timeout != "" {
+		var seconds int
+		seconds, err = strconv.Atoi(request.Timeout)
+		if err != nil || seconds < 0 || time.Duration(seconds)*time.Second > MaxPollTimeout {
+			err = protocols.NewInvalidParameterError("timeout", request.Timeout)
+			return
+		}
+		timeout = time.Duration(seconds) * time.Second
+	}
+
+	if request.Limit != "" {
+		limit, err = strconv.Atoi(request.Limit)
+		if err != nil || limit < 1 || limit > MaxPollLimit {
+			err = protocols.NewInvalidParameterError("limit", request.Limit)
+			return
+		}
+	}
+
+	return
+}
+
+// PollResponse represents response returned by /payments/poll endpoint of bridge server
+type PollResponse struct {
+	protocols.SuccessResponse
+	Payments []ReceivedPayment `json:"payments"`
+	// Cursor to send in the next request
+	Cursor string `json:"cursor"`
+}
+
+// Marshal marshals PollResponse
+func (response *PollResponse) Marshal() []byte {
+	json, _ := json.MarshalIndent(response, "", "  ")
+	return json
+}
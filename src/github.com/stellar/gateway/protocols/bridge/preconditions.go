@@ -0,0 +1,47 @@
+package bridge
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/stellar/gateway/protocols"
+)
+
+// PaymentPreconditionsNotSupported is an error response
+var PaymentPreconditionsNotSupported = &protocols.ErrorResponse{Code: "preconditions_not_supported", Message: "Preconditions cannot be set for payments sent using the compliance server. Ledger bounds and minimum sequence age cannot be set when channel accounts are used.", Status: http.StatusBadRequest}
+
+// TransactionPreconditions contains preconditions of transactions built by /payment and
+// /builder endpoints. Zero values are not set.
+type TransactionPreconditions struct {
+	// MinTime and MaxTime are UNIX timestamps
+	MinTime uint64 `json:"min_time"`
+	MaxTime uint64 `json:"max_time"`
+	// MinLedger and MaxLedger are ledger sequence numbers
+	MinLedger uint32 `json:"min_ledger"`
+	MaxLedger uint32 `json:"max_ledger"`
+	// MinSequenceAge is a number of seconds since the source account sequence number changed
+	MinSequenceAge uint64 `json:"min_sequence_age"`
+}
+
+// Validate validates if preconditions are valid.
+func (p TransactionPreconditions) Validate() error {
+	if p.MaxTime != 0 && p.MinTime > p.MaxTime {
+		return protocols.NewInvalidParameterError("max_time", strconv.FormatUint(p.MaxTime, 10))
+	}
+
+	if p.MaxLedger != 0 && p.MinLedger > p.MaxLedger {
+		return protocols.NewInvalidParameterError("max_ledger", strconv.FormatUint(uint64(p.MaxLedger), 10))
+	}
+
+	return nil
+}
+
+// IsV2 returns true when preconditions other than time bounds are set
+func (p TransactionPreconditions) IsV2() bool {
+	return p.MinLedger != 0 || p.MaxLedger != 0 || p.MinSequenceAge != 0
+}
+
+// IsEmpty returns true when no precondition is set
+func (p TransactionPreconditions) IsEmpty() bool {
+	return p == TransactionPreconditions{}
+}
@@ -0,0 +1,48 @@
+package bridge
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stellar/gateway/protocols"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaymentRequestPreconditions(t *testing.T) {
+	request := PaymentRequest{MaxTime: "1600000000", MinLedger: "10", MinSequenceAge: "60"}
+	preconditions, err := request.Preconditions()
+	require.NoError(t, err)
+	assert.Equal(t, TransactionPreconditions{MaxTime: 1600000000, MinLedger: 10, MinSequenceAge: 60}, preconditions)
+	assert.True(t, preconditions.IsV2())
+	assert.NoError(t, preconditions.Validate())
+
+	request = PaymentRequest{MaxLedger: "4294967296"}
+	_, err = request.Preconditions()
+	assert.Equal(t, "max_ledger", err.(*protocols.ErrorResponse).Data["name"])
+
+	preconditions = TransactionPreconditions{MinTime: 200, MaxTime: 100}
+	assert.Equal(t, "max_time", preconditions.Validate().(*protocols.ErrorResponse).Data["name"])
+	preconditions = TransactionPreconditions{MinTime: 200}
+	assert.NoError(t, preconditions.Validate())
+	assert.False(t, preconditions.IsV2())
+	assert.True(t, TransactionPreconditions{}.IsEmpty())
+}
+
+func TestBuilderRequestPreconditions(t *testing.T) {
+	var request BuilderRequest
+	err := json.Unmarshal([]byte(`{
+  "source": "`+testIssuer+`",
+  "sequence_number": "1",
+  "operations": [],
+  "min_time": 100,
+  "max_time": 200,
+  "max_ledger": 1000
+}`), &request)
+	require.NoError(t, err)
+	assert.Equal(t, TransactionPreconditions{MinTime: 100, MaxTime: 200, MaxLedger: 1000}, request.TransactionPreconditions)
+	assert.NoError(t, request.Validate())
+
+	request.MinLedger = 1001
+	assert.Equal(t, "max_ledger", request.Validate().(*protocols.ErrorResponse).Data["name"])
+}
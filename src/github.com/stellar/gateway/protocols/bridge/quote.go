@@ -0,0 +1,118 @@
+package bridge
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/protocols"
+)
+
+// QuoteRequest represents request made to /quote endpoint of the bridge server
+type QuoteRequest struct {
+	// SourceAsset and DestAsset are `native` or `CODE:ISSUER`
+	SourceAsset string
+	DestAsset   string
+	// Amount of DestAsset the destination should receive
+	Amount string
+}
+
+// FromRequest will populate request fields using query params of http.Request.
+func (request *QuoteRequest) FromRequest(r *http.Request) {
+	query := r.URL.Query()
+	request.SourceAsset = query.Get("source_asset")
+	request.DestAsset = query.Get("dest_asset")
+	request.Amount = query.Get("amount")
+}
+
+// Parse validates request params and returns source and destination assets
+func (request *QuoteRequest) Parse() (sourceAsset, destAsset protocols.Asset, err error) {
+	switch {
+	case request.SourceAsset == "":
+		err = protocols.NewMissingParameter("source_asset")
+		return
+	case request.DestAsset == "":
+		err = protocols.NewMissingParameter("dest_asset")
+		return
+	case request.Amount == "":
+		err = protocols.NewMissingParameter("amount")
+		return
+	}
+
+	sourceAsset, parseErr := protocols.ParseAsset(request.SourceAsset)
+	if parseErr != nil {
+		err = protocols.NewInvalidParameterError("source_asset", request.SourceAsset, map[string]interface{}{"err": parseErr})
+		return
+	}
+
+	destAsset, parseErr = protocols.ParseAsset(request.DestAsset)
+	if parseErr != nil {
+		err = protocols.NewInvalidParameterError("dest_asset", request.DestAsset, map[string]interface{}{"err": parseErr})
+		return
+	}
+
+	if sourceAsset == destAsset {
+		err = protocols.NewInvalidParameterError("dest_asset", request.DestAsset, map[string]interface{}{"err": "dest_asset is the same as source_asset"})
+		return
+	}
+
+	err = protocols.ValidateAmount("amount", request.Amount, true)
+	return
+}
+
+// Quote is a conversion achievable using a single payment path
+type Quote struct {
+	// SourceAmount is an amount of the source asset needed to send the requested amount
+	SourceAmount string `json:"source_amount"`
+	DestAmount   string `json:"dest_amount"`
+	// Rate is an amount of the destination asset received for one unit of the source asset
+	Rate string `json:"rate"`
+	// Slippage is a percentage the rate is worse than the spot rate of the path (the rate of
+	// the best offers on the path). It's empty when the spot rate is not known.
+	Slippage string `json:"slippage,omitempty"`
+	// Path contains intermediate assets as `native` or `CODE:ISSUER`
+	Path []string `json:"path"`
+}
+
+// NewQuote creates Quote from a path found by Horizon. spotRate is 0 when it is not known.
+func NewQuote(path horizon.PathResponse, spotRate float64) Quote {
+	quote := Quote{
+		SourceAmount: path.SourceAmount,
+		DestAmount:   path.DestinationAmount,
+		Path:         []string{},
+	}
+
+	for _, asset := range path.Path {
+		quote.Path = append(quote.Path, protocols.Asset{Code: asset.AssetCode, Issuer: asset.AssetIssuer}.String())
+	}
+
+	sourceAmount, _ := strconv.ParseFloat(path.SourceAmount, 64)
+	destAmount, _ := strconv.ParseFloat(path.DestinationAmount, 64)
+	if sourceAmount == 0 {
+		return quote
+	}
+
+	rate := destAmount / sourceAmount
+	quote.Rate = strconv.FormatFloat(rate, 'f', 7, 64)
+	if spotRate > 0 {
+		quote.Slippage = strconv.FormatFloat((spotRate-rate)/spotRate*100, 'f', 2, 64)
+	}
+	return quote
+}
+
+// QuoteResponse represents response returned by /quote endpoint of bridge server. Quotes are
+// sorted by source amount, the best one is first.
+type QuoteResponse struct {
+	protocols.SuccessResponse
+	SourceAsset string  `json:"source_asset"`
+	DestAsset   string  `json:"dest_asset"`
+	Amount      string  `json:"amount"`
+	Quotes      []Quote `json:"quotes"`
+}
+
+// Marshal marshals QuoteResponse
+func (response *QuoteResponse) Marshal() []byte {
+	json, _ := json.MarshalIndent(response, "", "  ")
+	return json
+}
@@ -0,0 +1,59 @@
+package bridge
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/protocols"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuoteRequestParse(t *testing.T) {
+	issuer := "GDSIKW43UA6JTOA47WVEBCZ4MYC74M3GNKNXTVDXFHXYYTNO5GGVN632"
+	request := &QuoteRequest{}
+	request.FromRequest(httptest.NewRequest("GET", "/quote?source_asset=EUR:"+issuer+"&dest_asset=native&amount=10", nil))
+
+	sourceAsset, destAsset, err := request.Parse()
+	require.NoError(t, err)
+	assert.Equal(t, protocols.Asset{Code: "EUR", Issuer: issuer}, sourceAsset)
+	assert.Equal(t, protocols.Asset{}, destAsset)
+
+	tests := []struct {
+		request QuoteRequest
+		code    string
+		name    string
+	}{
+		{QuoteRequest{DestAsset: "native", Amount: "10"}, "missing_parameter", "source_asset"},
+		{QuoteRequest{SourceAsset: "EUR", DestAsset: "native", Amount: "10"}, "invalid_parameter", "source_asset"},
+		{QuoteRequest{SourceAsset: "native", DestAsset: "native", Amount: "10"}, "invalid_parameter", "dest_asset"},
+		{QuoteRequest{SourceAsset: "EUR:" + issuer, DestAsset: "native", Amount: "0"}, "invalid_parameter", "amount"},
+	}
+	for _, test := range tests {
+		_, _, err := test.request.Parse()
+		require.Error(t, err)
+		errorResponse := err.(*protocols.ErrorResponse)
+		assert.Equal(t, test.code, errorResponse.Code)
+		assert.Equal(t, test.name, errorResponse.Data["name"])
+	}
+}
+
+func TestNewQuote(t *testing.T) {
+	path := horizon.PathResponse{
+		SourceAmount:      "9.0000000",
+		DestinationAmount: "10.0000000",
+		Path:              []horizon.PathAsset{{AssetType: "native"}, {AssetType: "credit_alphanum4", AssetCode: "BTC", AssetIssuer: "GDSIKW43UA6JTOA47WVEBCZ4MYC74M3GNKNXTVDXFHXYYTNO5GGVN632"}},
+	}
+
+	quote := NewQuote(path, 1.125)
+	assert.Equal(t, "9.0000000", quote.SourceAmount)
+	assert.Equal(t, "10.0000000", quote.DestAmount)
+	assert.Equal(t, "1.1111111", quote.Rate)
+	assert.Equal(t, "1.23", quote.Slippage)
+	assert.Equal(t, []string{"native", "BTC:GDSIKW43UA6JTOA47WVEBCZ4MYC74M3GNKNXTVDXFHXYYTNO5GGVN632"}, quote.Path)
+
+	// Spot rate not known
+	quote = NewQuote(path, 0)
+	assert.Equal(t, "", quote.Slippage)
+}
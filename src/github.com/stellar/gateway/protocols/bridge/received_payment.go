@@ -0,0 +1,124 @@
+package bridge
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/protocols"
+)
+
+var (
+	// ReceivedPaymentNotFound is an error response
+	ReceivedPaymentNotFound = &protocols.ErrorResponse{Code: "received_payment_not_found", Message: "Received payment not found.", Status: http.StatusNotFound}
+	// ReceivedPaymentNoDetails is an error response
+	ReceivedPaymentNoDetails = &protocols.ErrorResponse{Code: "received_payment_no_details", Message: "Payment details are not stored for this operation.", Status: http.StatusBadRequest}
+	// ReceiveCallbackNotConfigured is an error response
+	ReceiveCallbackNotConfigured = &protocols.ErrorResponse{Code: "receive_callback_not_configured", Message: "Receive callback is not configured. accounts.receiving_account_id and callbacks.receive are required.", Status: http.StatusBadRequest}
+	// ReceiveCallbackFailed is an error response
+	ReceiveCallbackFailed = &protocols.ErrorResponse{Code: "receive_callback_failed", Message: "Receive callback did not respond with 200 OK.", Status: http.StatusBadGateway}
+	// ReceivedPaymentNotReprocessable is an error response
+	ReceivedPaymentNotReprocessable = &protocols.ErrorResponse{Code: "received_payment_not_reprocessable", Message: "Payment has already been processed, refunded, resolved or ignored.", Status: http.StatusBadRequest}
+	// PaymentListenerNotRunning is an error response
+	PaymentListenerNotRunning = &protocols.ErrorResponse{Code: "payment_listener_not_running", Message: "Received payments are not processed by this server. accounts.receiving_account_id is required.", Status: http.StatusBadRequest}
+	// ReprocessFailed is an error response
+	ReprocessFailed = &protocols.ErrorResponse{Code: "reprocess_failed", Message: "Payment could not be processed. Check server logs for details.", Status: http.StatusBadGateway}
+	// ReceivedPaymentNotDeadLetter is an error response
+	ReceivedPaymentNotDeadLetter = &protocols.ErrorResponse{Code: "received_payment_not_dead_letter", Message: "Only payments with Dead letter or Callback failed status can be requeued.", Status: http.StatusBadRequest}
+	// DeadLetterNotConfigured is an error response
+	DeadLetterNotConfigured = &protocols.ErrorResponse{Code: "dead_letter_not_configured", Message: "Payments are not requeued by this server. callback_retry.max_attempts or dead_letter.max_attempts is required.", Status: http.StatusBadRequest}
+	// SentPaymentNotFound is an error response
+	SentPaymentNotFound = &protocols.ErrorResponse{Code: "sent_payment_not_found", Message: "Transaction has not been sent with metadata or received_payment_id.", Status: http.StatusNotFound}
+)
+
+// ReceivedPayment represents a received payment returned by /admin/received-payments endpoints of bridge server
+type ReceivedPayment struct {
+	ID                       string     `json:"id"`
+	Status                   string     `json:"status"`
+	ProcessedAt              time.Time  `json:"processed_at"`
+	From                     string     `json:"from,omitempty"`
+	FromAddress              *string    `json:"from_address,omitempty"`
+	To                       string     `json:"to,omitempty"`
+	Amount                   string     `json:"amount,omitempty"`
+	AssetCode                string     `json:"asset_code,omitempty"`
+	AssetIssuer              string     `json:"asset_issuer,omitempty"`
+	Asset                    string     `json:"asset,omitempty"`
+	MemoType                 string     `json:"memo_type,omitempty"`
+	Memo                     string     `json:"memo,omitempty"`
+	RefundTransactionID      *string    `json:"refund_transaction_id,omitempty"`
+	ResolutionReason         *string    `json:"resolution_reason,omitempty"`
+	ResolvedBy               *string    `json:"resolved_by,omitempty"`
+	ResolvedAt               *time.Time `json:"resolved_at,omitempty"`
+	TransactionHash          *string    `json:"transaction_hash,omitempty"`
+	Ledger                   *int64     `json:"ledger,omitempty"`
+	LedgerCloseTime          *time.Time `json:"ledger_close_time,omitempty"`
+	FeeCharged               *int64     `json:"fee_charged,omitempty"`
+	TransactionSourceAccount *string    `json:"transaction_source_account,omitempty"`
+	OutgoingTransactions     []string   `json:"outgoing_transactions"`
+}
+
+// NewReceivedPayment creates ReceivedPayment from a DB entity and links of transactions sent on its behalf
+func NewReceivedPayment(payment *entities.ReceivedPayment, links []entities.PaymentLink) ReceivedPayment {
+	response := ReceivedPayment{
+		ID:                       payment.OperationID,
+		Status:                   payment.Status,
+		ProcessedAt:              payment.ProcessedAt,
+		From:                     payment.FromAccount,
+		FromAddress:              payment.FromAddress,
+		To:                       payment.ReceivingAccount,
+		Amount:                   payment.Amount,
+		AssetCode:                payment.AssetCode,
+		AssetIssuer:              payment.AssetIssuer,
+		MemoType:                 payment.MemoType,
+		Memo:                     payment.Memo,
+		RefundTransactionID:      payment.RefundTransactionID,
+		ResolutionReason:         payment.ResolutionReason,
+		ResolvedBy:               payment.ResolvedBy,
+		ResolvedAt:               payment.ResolvedAt,
+		TransactionHash:          payment.TransactionHash,
+		Ledger:                   payment.Ledger,
+		LedgerCloseTime:          payment.LedgerCloseTime,
+		FeeCharged:               payment.FeeCharged,
+		TransactionSourceAccount: payment.TransactionSourceAccount,
+		OutgoingTransactions:     []string{},
+	}
+
+	// Asset details are not stored for operations that are not payments
+	if payment.Amount != "" {
+		response.Asset = protocols.Asset{Code: payment.AssetCode, Issuer: payment.AssetIssuer}.String()
+	}
+
+	for _, link := range links {
+		response.OutgoingTransactions = append(response.OutgoingTransactions, link.TransactionID)
+	}
+
+	return response
+}
+
+// ReceivedPaymentResponse represents response returned by /admin/received-payments/:id endpoint of bridge server
+type ReceivedPaymentResponse struct {
+	protocols.SuccessResponse
+	ReceivedPayment
+}
+
+// Marshal marshals ReceivedPaymentResponse
+func (response *ReceivedPaymentResponse) Marshal() []byte {
+	json, _ := json.MarshalIndent(response, "", "  ")
+	return json
+}
+
+// SentPaymentResponse represents response returned by /admin/sent-payments/:id endpoint of bridge server
+type SentPaymentResponse struct {
+	protocols.SuccessResponse
+	TransactionID   string           `json:"transaction_id"`
+	Status          string           `json:"status,omitempty"`
+	Metadata        *json.RawMessage `json:"metadata,omitempty"`
+	ReceivedPayment *ReceivedPayment `json:"received_payment,omitempty"`
+}
+
+// Marshal marshals SentPaymentResponse
+func (response *SentPaymentResponse) Marshal() []byte {
+	json, _ := json.MarshalIndent(response, "", "  ")
+	return json
+}
@@ -0,0 +1,45 @@
+package bridge
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/stellar/gateway/protocols"
+	b "github.com/stellar/go-stellar-base/build"
+)
+
+var (
+	// ReceivedPaymentNotRefundable is an error response
+	ReceivedPaymentNotRefundable = &protocols.ErrorResponse{Code: "received_payment_not_refundable", Message: "Payment has already been refunded or was not received by the receiving account.", Status: http.StatusBadRequest}
+	// RefundNoBaseSeed is an error response
+	RefundNoBaseSeed = &protocols.ErrorResponse{Code: "refund_no_base_seed", Message: "accounts.base_seed is required to refund payments.", Status: http.StatusBadRequest}
+)
+
+// RefundRequest represents request made to /admin/received-payments/:id/refund endpoint of bridge server
+type RefundRequest struct {
+	MemoType string `name:"memo_type"`
+	Memo     string `name:"memo"`
+
+	protocols.FormRequest
+}
+
+// FromRequest will populate request fields using http.Request.
+func (request *RefundRequest) FromRequest(r *http.Request) {
+	request.FormRequest.FromRequest(r, request)
+}
+
+// ToValues will create url.Values from request.
+func (request *RefundRequest) ToValues() url.Values {
+	return request.FormRequest.ToValues(request)
+}
+
+// Validate validates if request fields are valid. Useful when checking if a request is correct.
+func (request *RefundRequest) Validate() error {
+	_, err := request.MemoMutator()
+	return err
+}
+
+// MemoMutator returns transaction mutator setting memo of the refund transaction or nil when memo is not set
+func (request *RefundRequest) MemoMutator() (b.TransactionMutator, error) {
+	return MemoMutator(request.MemoType, request.Memo)
+}
@@ -0,0 +1,58 @@
+package bridge
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/protocols"
+)
+
+var (
+	// ReceivedPaymentNotResolvable is an error response
+	ReceivedPaymentNotResolvable = &protocols.ErrorResponse{Code: "received_payment_not_resolvable", Message: "Payment has already been processed, refunded, resolved or ignored.", Status: http.StatusBadRequest}
+)
+
+// resolveStatuses maps statuses accepted by /admin/received-payments/:id/resolve to payment statuses
+var resolveStatuses = map[string]string{
+	"resolved": entities.ReceivedPaymentStatusResolved,
+	"ignored":  entities.ReceivedPaymentStatusIgnored,
+}
+
+// ResolveRequest represents request made to /admin/received-payments/:id/resolve endpoint of bridge server
+type ResolveRequest struct {
+	Status   string `name:"status" required:""`
+	Reason   string `name:"reason" required:""`
+	Operator string `name:"operator" required:""`
+
+	protocols.FormRequest
+}
+
+// FromRequest will populate request fields using http.Request.
+func (request *ResolveRequest) FromRequest(r *http.Request) {
+	request.FormRequest.FromRequest(r, request)
+}
+
+// ToValues will create url.Values from request.
+func (request *ResolveRequest) ToValues() url.Values {
+	return request.FormRequest.ToValues(request)
+}
+
+// Validate validates if request fields are valid. Useful when checking if a request is correct.
+func (request *ResolveRequest) Validate() error {
+	err := request.FormRequest.CheckRequired(request)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := resolveStatuses[request.Status]; !ok {
+		return protocols.NewInvalidParameterError("status", request.Status)
+	}
+
+	return nil
+}
+
+// PaymentStatus returns status the received payment should be set to
+func (request *ResolveRequest) PaymentStatus() string {
+	return resolveStatuses[request.Status]
+}
@@ -0,0 +1,111 @@
+package bridge
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/protocols"
+	"github.com/stellar/go-stellar-base/keypair"
+)
+
+// SandboxPaymentRequest represents request made to /admin/sandbox/payments endpoint of bridge server
+type SandboxPaymentRequest struct {
+	// Sender account ID. Random account is used when empty.
+	From string `name:"from"`
+	// Destination account ID. Receiving account is used when empty.
+	To          string `name:"to"`
+	Amount      string `name:"amount" required:""`
+	AssetCode   string `name:"asset_code"`
+	AssetIssuer string `name:"asset_issuer"`
+	// Asset as `CODE:ISSUER` or `native`. Alternative to asset_code and asset_issuer.
+	Asset    string `name:"asset"`
+	MemoType string `name:"memo_type"`
+	Memo     string `name:"memo"`
+
+	protocols.FormRequest
+}
+
+// FromRequest will populate request fields using http.Request.
+func (request *SandboxPaymentRequest) FromRequest(r *http.Request) {
+	request.FormRequest.FromRequest(r, request)
+}
+
+// ToValues will create url.Values from request.
+func (request *SandboxPaymentRequest) ToValues() url.Values {
+	return request.FormRequest.ToValues(request)
+}
+
+// Validate validates if request fields are valid. Useful when checking if a request is correct.
+func (request *SandboxPaymentRequest) Validate() error {
+	err := request.FormRequest.CheckRequired(request)
+	if err != nil {
+		return err
+	}
+
+	if request.Asset != "" {
+		err = parseAssetParam("asset", request.Asset, &request.AssetCode, &request.AssetIssuer)
+		if err != nil {
+			return err
+		}
+	}
+
+	for name, accountID := range map[string]string{"from": request.From, "to": request.To, "asset_issuer": request.AssetIssuer} {
+		if accountID == "" {
+			continue
+		}
+		_, err = keypair.Parse(accountID)
+		if err != nil {
+			return protocols.NewInvalidParameterError(name, accountID)
+		}
+	}
+
+	err = protocols.ValidateAmount("amount", request.Amount, true)
+	if err != nil {
+		return err
+	}
+
+	if request.AssetCode == "" && request.AssetIssuer != "" {
+		return protocols.NewMissingParameter("asset_code")
+	}
+
+	if request.AssetCode != "" && request.AssetIssuer == "" {
+		return protocols.NewMissingParameter("asset_issuer")
+	}
+
+	if len(request.AssetCode) > 12 {
+		return protocols.NewInvalidParameterError("asset_code", request.AssetCode)
+	}
+
+	switch request.MemoType {
+	case "":
+		if request.Memo != "" {
+			return protocols.NewMissingParameter("memo_type")
+		}
+	case "id":
+		_, err = strconv.ParseUint(request.Memo, 10, 64)
+		if err != nil {
+			return protocols.NewInvalidParameterError("memo", request.Memo)
+		}
+	case "text", "hash", "return":
+		break
+	default:
+		return protocols.NewInvalidParameterError("memo_type", request.MemoType)
+	}
+
+	return nil
+}
+
+// SandboxPaymentResponse represents response returned by /admin/sandbox/payments endpoint of bridge server
+type SandboxPaymentResponse struct {
+	protocols.SuccessResponse
+	horizon.PaymentResponse
+}
+
+// Marshal marshals SandboxPaymentResponse
+func (response *SandboxPaymentResponse) Marshal() []byte {
+	json, _ := json.MarshalIndent(response, "", "  ")
+	return json
+}
@@ -0,0 +1,161 @@
+package bridge
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/protocols"
+)
+
+var (
+	// Sep31Unauthorized is an error response
+	Sep31Unauthorized = &protocols.ErrorResponse{Code: "sep31_unauthorized", Message: "Valid SEP-10 token is required in Authorization header.", Status: http.StatusUnauthorized}
+	// Sep31AssetNotSupported is an error response
+	Sep31AssetNotSupported = &protocols.ErrorResponse{Code: "sep31_asset_not_supported", Message: "Asset cannot be received using SEP-31.", Status: http.StatusBadRequest}
+	// Sep31InvalidAmount is an error response
+	Sep31InvalidAmount = &protocols.ErrorResponse{Code: "sep31_invalid_amount", Message: "Amount is not within the asset min_amount and max_amount or it does not cover the fee.", Status: http.StatusBadRequest}
+	// Sep31TransactionInfoNeeded is an error response
+	Sep31TransactionInfoNeeded = &protocols.ErrorResponse{Code: "transaction_info_needed", Message: "Required transaction fields are missing.", Status: http.StatusBadRequest}
+	// Sep31TransactionNotFound is an error response
+	Sep31TransactionNotFound = &protocols.ErrorResponse{Code: "sep31_transaction_not_found", Message: "Transaction not found.", Status: http.StatusNotFound}
+)
+
+// NewSep31TransactionInfoNeededError creates a new Sep31TransactionInfoNeeded error response
+// with descriptions of missing `transaction` fields
+func NewSep31TransactionInfoNeededError(fields map[string]Sep31Field) *protocols.ErrorResponse {
+	return &protocols.ErrorResponse{
+		Status:  Sep31TransactionInfoNeeded.Status,
+		Code:    Sep31TransactionInfoNeeded.Code,
+		Message: Sep31TransactionInfoNeeded.Message,
+		Data:    map[string]interface{}{"fields": map[string]interface{}{"transaction": fields}},
+	}
+}
+
+// Sep31Field describes a field the sending anchor must provide
+type Sep31Field struct {
+	Description string   `json:"description"`
+	Optional    bool     `json:"optional,omitempty"`
+	Choices     []string `json:"choices,omitempty"`
+}
+
+// Sep31AssetInfo describes an asset returned by /sep31/info endpoint
+type Sep31AssetInfo struct {
+	Enabled    bool   `json:"enabled"`
+	FeeFixed   string `json:"fee_fixed,omitempty"`
+	FeePercent string `json:"fee_percent,omitempty"`
+	MinAmount  string `json:"min_amount,omitempty"`
+	MaxAmount  string `json:"max_amount,omitempty"`
+	Fields     struct {
+		Transaction map[string]Sep31Field `json:"transaction"`
+	} `json:"fields"`
+}
+
+// Sep31InfoResponse represents response returned by /sep31/info endpoint of bridge server
+type Sep31InfoResponse struct {
+	protocols.SuccessResponse
+	// Receive maps asset codes to assets info
+	Receive map[string]Sep31AssetInfo `json:"receive"`
+}
+
+// Marshal marshals Sep31InfoResponse
+func (response *Sep31InfoResponse) Marshal() []byte {
+	json, _ := json.MarshalIndent(response, "", "  ")
+	return json
+}
+
+// Sep31TransactionRequest represents request made to POST /sep31/transactions endpoint of bridge server.
+// It's sent as JSON.
+type Sep31TransactionRequest struct {
+	Amount      string `json:"amount"`
+	AssetCode   string `json:"asset_code"`
+	AssetIssuer string `json:"asset_issuer"`
+	SenderID    string `json:"sender_id"`
+	ReceiverID  string `json:"receiver_id"`
+	Fields      struct {
+		Transaction map[string]string `json:"transaction"`
+	} `json:"fields"`
+}
+
+// Validate validates if request fields are valid. Asset and transaction fields are checked by the handler.
+func (request *Sep31TransactionRequest) Validate() error {
+	for name, value := range map[string]string{
+		"amount":      request.Amount,
+		"asset_code":  request.AssetCode,
+		"sender_id":   request.SenderID,
+		"receiver_id": request.ReceiverID,
+	} {
+		if value == "" {
+			return protocols.NewMissingParameter(name)
+		}
+	}
+
+	if !protocols.IsValidAmount(request.Amount) {
+		return protocols.NewInvalidParameterError("amount", request.Amount)
+	}
+
+	return nil
+}
+
+// Sep31CreateTransactionResponse represents response returned by POST /sep31/transactions endpoint of bridge server
+type Sep31CreateTransactionResponse struct {
+	protocols.SuccessResponse
+	ID               string `json:"id"`
+	StellarAccountID string `json:"stellar_account_id"`
+	StellarMemoType  string `json:"stellar_memo_type"`
+	StellarMemo      string `json:"stellar_memo"`
+}
+
+// Marshal marshals Sep31CreateTransactionResponse
+func (response *Sep31CreateTransactionResponse) Marshal() []byte {
+	json, _ := json.MarshalIndent(response, "", "  ")
+	return json
+}
+
+// Sep31Transaction represents a transaction returned by GET /sep31/transactions/:id endpoint of bridge server
+type Sep31Transaction struct {
+	ID                   string     `json:"id"`
+	Status               string     `json:"status"`
+	StatusMessage        *string    `json:"status_message,omitempty"`
+	AmountIn             string     `json:"amount_in"`
+	AmountFee            string     `json:"amount_fee"`
+	AmountOut            string     `json:"amount_out"`
+	StellarAccountID     string     `json:"stellar_account_id"`
+	StellarMemoType      string     `json:"stellar_memo_type"`
+	StellarMemo          string     `json:"stellar_memo"`
+	StellarTransactionID *string    `json:"stellar_transaction_id,omitempty"`
+	StartedAt            time.Time  `json:"started_at"`
+	CompletedAt          *time.Time `json:"completed_at,omitempty"`
+}
+
+// NewSep31Transaction creates Sep31Transaction from a DB entity. stellarAccountID is the account
+// payments are sent to.
+func NewSep31Transaction(transaction *entities.Sep31Transaction, stellarAccountID string) Sep31Transaction {
+	return Sep31Transaction{
+		ID:                   transaction.PublicID,
+		Status:               transaction.Status,
+		StatusMessage:        transaction.StatusMessage,
+		AmountIn:             transaction.AmountIn,
+		AmountFee:            transaction.AmountFee,
+		AmountOut:            transaction.AmountOut,
+		StellarAccountID:     stellarAccountID,
+		StellarMemoType:      transaction.MemoType,
+		StellarMemo:          transaction.Memo,
+		StellarTransactionID: transaction.StellarTransactionID,
+		StartedAt:            transaction.StartedAt,
+		CompletedAt:          transaction.CompletedAt,
+	}
+}
+
+// Sep31TransactionResponse represents response returned by GET /sep31/transactions/:id endpoint of bridge server
+type Sep31TransactionResponse struct {
+	protocols.SuccessResponse
+	Transaction Sep31Transaction `json:"transaction"`
+}
+
+// Marshal marshals Sep31TransactionResponse
+func (response *Sep31TransactionResponse) Marshal() []byte {
+	json, _ := json.MarshalIndent(response, "", "  ")
+	return json
+}
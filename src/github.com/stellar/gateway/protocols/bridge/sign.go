@@ -0,0 +1,105 @@
+package bridge
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"regexp"
+
+	"github.com/stellar/gateway/protocols"
+)
+
+var (
+	// PaymentSignaturesRequired is an error response
+	PaymentSignaturesRequired = &protocols.ErrorResponse{Code: "signatures_required", Message: "Source account requires more signatures than the bridge server can add. DB is required to collect them using /sign endpoint.", Status: http.StatusBadRequest}
+	// SignTransactionNotFound is an error response
+	SignTransactionNotFound = &protocols.ErrorResponse{Code: "sign_transaction_not_found", Message: "Transaction collecting signatures not found.", Status: http.StatusNotFound}
+	// SignTransactionSubmitted is an error response
+	SignTransactionSubmitted = &protocols.ErrorResponse{Code: "sign_transaction_submitted", Message: "Transaction has already been submitted.", Status: http.StatusBadRequest}
+	// SignEnvelopeMismatch is an error response
+	SignEnvelopeMismatch = &protocols.ErrorResponse{Code: "sign_envelope_mismatch", Message: "Envelope contains a different transaction.", Status: http.StatusBadRequest}
+	// SignInvalidSignature is an error response
+	SignInvalidSignature = &protocols.ErrorResponse{Code: "sign_invalid_signature", Message: "Signature is not a valid signature of the transaction by a signer of the source account.", Status: http.StatusBadRequest}
+)
+
+var transactionID = regexp.MustCompile("^[0-9a-f]{64}$")
+
+// SignRequest represents request made to /sign endpoint of bridge server. Signatures are sent
+// in a transaction envelope signed by other signers or as a single signature with its signer.
+type SignRequest struct {
+	TransactionID string `name:"transaction_id" required:""`
+	// Base64-encoded envelope of the transaction with signatures to add
+	EnvelopeXdr string `name:"envelope_xdr"`
+	// Account ID of a signer of Signature
+	PublicKey string `name:"public_key"`
+	// Base64-encoded ed25519 signature of the transaction hash
+	Signature string `name:"signature"`
+
+	protocols.FormRequest
+}
+
+// FromRequest will populate request fields using http.Request.
+func (request *SignRequest) FromRequest(r *http.Request) {
+	request.FormRequest.FromRequest(r, request)
+}
+
+// ToValues will create url.Values from request.
+func (request *SignRequest) ToValues() url.Values {
+	return request.FormRequest.ToValues(request)
+}
+
+// Validate validates if request fields are valid. Useful when checking if a request is correct.
+func (request *SignRequest) Validate() error {
+	err := request.FormRequest.CheckRequired(request)
+	if err != nil {
+		return err
+	}
+
+	if !transactionID.MatchString(request.TransactionID) {
+		return protocols.NewInvalidParameterError("transaction_id", request.TransactionID)
+	}
+
+	if request.EnvelopeXdr == "" && request.Signature == "" {
+		return protocols.NewMissingParameter("signature")
+	}
+
+	if request.Signature != "" {
+		if !protocols.IsValidAccountID(request.PublicKey) {
+			return protocols.NewInvalidParameterError("public_key", request.PublicKey)
+		}
+
+		signature, err := base64.StdEncoding.DecodeString(request.Signature)
+		if err != nil || len(signature) != 64 {
+			return protocols.NewInvalidParameterError("signature", request.Signature)
+		}
+	}
+
+	return nil
+}
+
+// PendingTransactionResponse represents response returned by /payment and /sign endpoints
+// of bridge server when the transaction needs more signatures
+type PendingTransactionResponse struct {
+	// Status is always `pending_signatures`
+	Status        string `json:"status"`
+	TransactionID string `json:"transaction_id"`
+	// Envelope with all signatures collected so far
+	EnvelopeXdr    string `json:"envelope_xdr"`
+	RequiredWeight int32  `json:"required_weight"`
+	SignedWeight   int32  `json:"signed_weight"`
+}
+
+// PendingTransactionStatus is a status of PendingTransactionResponse
+const PendingTransactionStatus = "pending_signatures"
+
+// HTTPStatus returns http.StatusAccepted: the transaction has not been submitted yet
+func (response *PendingTransactionResponse) HTTPStatus() int {
+	return http.StatusAccepted
+}
+
+// Marshal marshals PendingTransactionResponse
+func (response *PendingTransactionResponse) Marshal() []byte {
+	json, _ := json.MarshalIndent(response, "", "  ")
+	return json
+}
@@ -0,0 +1,74 @@
+package bridge
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/server"
+)
+
+// Statuses of StatusResponse
+const (
+	StatusOK        = "ok"
+	StatusUnhealthy = "unhealthy"
+)
+
+// StatusResponse represents response returned by GET /admin/status endpoint of bridge server.
+// Status is StatusUnhealthy when the DB or Horizon cannot be reached.
+type StatusResponse struct {
+	Status   string                `json:"status"`
+	Version  string                `json:"version"`
+	Tenant   string                `json:"tenant"`
+	Horizon  HorizonStatus         `json:"horizon"`
+	Database *DatabaseStatus       `json:"database,omitempty"`
+	Listener ListenerStatus        `json:"listener"`
+	Queues   *entities.QueueDepths `json:"queues,omitempty"`
+	// RecentErrors are the last messages logged with error level, newest first
+	RecentErrors []server.RecentError `json:"recent_errors"`
+}
+
+// HorizonStatus contains the latest ledger ingested by Horizon
+type HorizonStatus struct {
+	LatestLedger int32  `json:"latest_ledger,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+// DatabaseStatus contains DB connectivity. It's returned only when the DB is configured.
+type DatabaseStatus struct {
+	Type      string `json:"type"`
+	Connected bool   `json:"connected"`
+	Error     string `json:"error,omitempty"`
+}
+
+// ListenerStatus contains state of the payment listener
+type ListenerStatus struct {
+	Running bool `json:"running"`
+	// Leader is true when this instance holds the listener lease (or the lease is disabled)
+	Leader   bool                    `json:"leader"`
+	Accounts []ListenerAccountStatus `json:"accounts"`
+}
+
+// ListenerAccountStatus contains cursor of a receiving account stream. Ledger is a ledger of
+// the last saved payment and Lag is a number of ledgers Horizon ingested since then.
+type ListenerAccountStatus struct {
+	AccountID string `json:"account_id"`
+	Cursor    string `json:"cursor,omitempty"`
+	Ledger    int32  `json:"ledger,omitempty"`
+	Lag       *int32 `json:"lag,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// HTTPStatus returns http.StatusServiceUnavailable when the status is unhealthy
+func (response *StatusResponse) HTTPStatus() int {
+	if response.Status == StatusUnhealthy {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusOK
+}
+
+// Marshal marshals StatusResponse
+func (response *StatusResponse) Marshal() []byte {
+	json, _ := json.MarshalIndent(response, "", "  ")
+	return json
+}
@@ -0,0 +1,71 @@
+package bridge
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/protocols"
+)
+
+var (
+	// TransactionNotFound is an error response
+	TransactionNotFound = &protocols.ErrorResponse{Code: "transaction_not_found", Message: "Transaction has not been sent or built by this server.", Status: http.StatusNotFound}
+)
+
+// SentTransaction represents a transaction sent or built by bridge server returned by
+// /transactions/:hash endpoint
+type SentTransaction struct {
+	Hash        string     `json:"hash"`
+	Status      string     `json:"status"`
+	Source      string     `json:"source"`
+	SubmittedAt time.Time  `json:"submitted_at"`
+	SucceededAt *time.Time `json:"succeeded_at,omitempty"`
+	Ledger      *uint64    `json:"ledger,omitempty"`
+	ResultXdr   *string    `json:"result_xdr,omitempty"`
+	// Payment details are returned for transactions sent by /payment endpoint
+	Destination string          `json:"destination,omitempty"`
+	Amount      string          `json:"amount,omitempty"`
+	Asset       string          `json:"asset,omitempty"`
+	Metadata    json.RawMessage `json:"metadata,omitempty"`
+}
+
+// NewSentTransaction creates a SentTransaction from the entity
+func NewSentTransaction(transaction *entities.SentTransaction) SentTransaction {
+	response := SentTransaction{
+		Hash:        transaction.TransactionID,
+		Status:      string(transaction.Status),
+		Source:      transaction.Source,
+		SubmittedAt: transaction.SubmittedAt,
+		SucceededAt: transaction.SucceededAt,
+		Ledger:      transaction.Ledger,
+		ResultXdr:   transaction.ResultXdr,
+	}
+
+	if transaction.Destination != nil {
+		response.Destination = *transaction.Destination
+	}
+	if transaction.Amount != nil {
+		response.Amount = *transaction.Amount
+	}
+	if transaction.Asset != nil {
+		response.Asset = *transaction.Asset
+	}
+	if transaction.Metadata != nil {
+		response.Metadata = json.RawMessage(*transaction.Metadata)
+	}
+	return response
+}
+
+// TransactionResponse represents response returned by /transactions/:hash endpoint of bridge server
+type TransactionResponse struct {
+	protocols.SuccessResponse
+	Transaction SentTransaction `json:"transaction"`
+}
+
+// Marshal marshals TransactionResponse
+func (response *TransactionResponse) Marshal() []byte {
+	json, _ := json.MarshalIndent(response, "", "  ")
+	return json
+}
@@ -0,0 +1,319 @@
+package bridge
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/protocols"
+)
+
+var (
+	// TransferUnauthorized is an error response
+	TransferUnauthorized = &protocols.ErrorResponse{Code: "transfer_unauthorized", Message: "Valid SEP-10 token is required in Authorization header.", Status: http.StatusUnauthorized}
+	// TransferAssetNotSupported is an error response
+	TransferAssetNotSupported = &protocols.ErrorResponse{Code: "transfer_asset_not_supported", Message: "Asset cannot be deposited or withdrawn.", Status: http.StatusBadRequest}
+	// TransferInvalidAmount is an error response
+	TransferInvalidAmount = &protocols.ErrorResponse{Code: "transfer_invalid_amount", Message: "Amount is not within the asset min_amount and max_amount or it does not cover the fee.", Status: http.StatusBadRequest}
+	// TransferTransactionNotFound is an error response
+	TransferTransactionNotFound = &protocols.ErrorResponse{Code: "transfer_transaction_not_found", Message: "Transaction not found.", Status: http.StatusNotFound}
+	// TransferTransactionCompleted is an error response
+	TransferTransactionCompleted = &protocols.ErrorResponse{Code: "transfer_transaction_completed", Message: "Transaction is completed and cannot be updated.", Status: http.StatusBadRequest}
+	// DepositCallbackFailed is an error response
+	DepositCallbackFailed = &protocols.ErrorResponse{Code: "deposit_callback_failed", Message: "Deposit callback did not return deposit instructions.", Status: http.StatusBadGateway}
+)
+
+// transferRequestParams are params of TransferRequest that are not stored in Fields
+var transferRequestParams = map[string]bool{
+	"asset_code": true,
+	"account":    true,
+	"memo_type":  true,
+	"memo":       true,
+	"amount":     true,
+	"type":       true,
+	"lang":       true,
+}
+
+// TransferRequest represents request made to /sep6/deposit or /sep6/withdraw endpoint of
+// bridge server (params are sent in a query string) or to /sep24/transactions/.../interactive
+// endpoints (params are sent in a form or JSON).
+type TransferRequest struct {
+	AssetCode string
+	// Account must be the account authenticated using SEP-10 when it's set
+	Account string
+	// MemoType and Memo are set on the deposit payment
+	MemoType string
+	Memo     string
+	Amount   string
+	Type     string
+	// Fields are other params, ex. `dest` and `dest_extra` of withdrawals or `email_address`
+	Fields map[string]string
+}
+
+// FromRequest will populate request fields using query params of http.Request.
+func (request *TransferRequest) FromRequest(r *http.Request) {
+	request.FromValues(r.URL.Query())
+}
+
+// FromValues will populate request fields using params of /sep6 query string or /sep24 form.
+func (request *TransferRequest) FromValues(query url.Values) {
+	request.AssetCode = query.Get("asset_code")
+	request.Account = query.Get("account")
+	request.MemoType = query.Get("memo_type")
+	request.Memo = query.Get("memo")
+	request.Amount = query.Get("amount")
+	request.Type = query.Get("type")
+
+	request.Fields = map[string]string{}
+	for name := range query {
+		if !transferRequestParams[name] {
+			request.Fields[name] = query.Get(name)
+		}
+	}
+}
+
+// Validate validates if request fields are valid. Asset and type are checked by the handler.
+func (request *TransferRequest) Validate() error {
+	if request.AssetCode == "" {
+		return protocols.NewMissingParameter("asset_code")
+	}
+
+	if request.Account != "" && !protocols.IsValidAccountID(request.Account) {
+		return protocols.NewInvalidParameterError("account", request.Account)
+	}
+
+	if request.Amount != "" && !protocols.IsValidAmount(request.Amount) {
+		return protocols.NewInvalidParameterError("amount", request.Amount)
+	}
+
+	if request.MemoType == "return" {
+		return protocols.NewInvalidParameterError("memo_type", request.MemoType)
+	}
+	_, err := MemoMutator(request.MemoType, request.Memo)
+	return err
+}
+
+// TransferField describes a request param the wallet can send
+type TransferField struct {
+	Description string   `json:"description"`
+	Optional    bool     `json:"optional,omitempty"`
+	Choices     []string `json:"choices,omitempty"`
+}
+
+// TransferAssetInfo describes an asset returned by /sep6/info endpoint
+type TransferAssetInfo struct {
+	Enabled                bool                     `json:"enabled"`
+	AuthenticationRequired bool                     `json:"authentication_required"`
+	FeeFixed               string                   `json:"fee_fixed,omitempty"`
+	FeePercent             string                   `json:"fee_percent,omitempty"`
+	MinAmount              string                   `json:"min_amount,omitempty"`
+	MaxAmount              string                   `json:"max_amount,omitempty"`
+	Fields                 map[string]TransferField `json:"fields,omitempty"`
+	// Types are withdrawal types with their fields
+	Types map[string]TransferWithdrawType `json:"types,omitempty"`
+}
+
+// TransferWithdrawType describes a withdrawal type returned by /sep6/info endpoint
+type TransferWithdrawType struct {
+	Fields map[string]TransferField `json:"fields"`
+}
+
+// TransferFeatureInfo tells if an optional endpoint is enabled
+type TransferFeatureInfo struct {
+	Enabled                bool `json:"enabled"`
+	AuthenticationRequired bool `json:"authentication_required,omitempty"`
+}
+
+// TransferInfoResponse represents response returned by /sep6/info endpoint of bridge server
+type TransferInfoResponse struct {
+	protocols.SuccessResponse
+	// Deposit and Withdraw map asset codes to assets info
+	Deposit      map[string]TransferAssetInfo `json:"deposit"`
+	Withdraw     map[string]TransferAssetInfo `json:"withdraw"`
+	Fee          TransferFeatureInfo          `json:"fee"`
+	Transactions TransferFeatureInfo          `json:"transactions"`
+	Transaction  TransferFeatureInfo          `json:"transaction"`
+}
+
+// Marshal marshals TransferInfoResponse
+func (response *TransferInfoResponse) Marshal() []byte {
+	json, _ := json.MarshalIndent(response, "", "  ")
+	return json
+}
+
+// TransferDepositResponse represents response returned by /sep6/deposit endpoint of bridge server
+type TransferDepositResponse struct {
+	protocols.SuccessResponse
+	ID         string           `json:"id"`
+	How        string           `json:"how"`
+	ETA        int              `json:"eta,omitempty"`
+	FeeFixed   string           `json:"fee_fixed,omitempty"`
+	FeePercent string           `json:"fee_percent,omitempty"`
+	MinAmount  string           `json:"min_amount,omitempty"`
+	MaxAmount  string           `json:"max_amount,omitempty"`
+	ExtraInfo  *json.RawMessage `json:"extra_info,omitempty"`
+}
+
+// Marshal marshals TransferDepositResponse
+func (response *TransferDepositResponse) Marshal() []byte {
+	json, _ := json.MarshalIndent(response, "", "  ")
+	return json
+}
+
+// TransferWithdrawResponse represents response returned by /sep6/withdraw endpoint of bridge server
+type TransferWithdrawResponse struct {
+	protocols.SuccessResponse
+	ID         string `json:"id"`
+	AccountID  string `json:"account_id"`
+	MemoType   string `json:"memo_type"`
+	Memo       string `json:"memo"`
+	FeeFixed   string `json:"fee_fixed,omitempty"`
+	FeePercent string `json:"fee_percent,omitempty"`
+	MinAmount  string `json:"min_amount,omitempty"`
+	MaxAmount  string `json:"max_amount,omitempty"`
+}
+
+// Marshal marshals TransferWithdrawResponse
+func (response *TransferWithdrawResponse) Marshal() []byte {
+	json, _ := json.MarshalIndent(response, "", "  ")
+	return json
+}
+
+// TransferTransaction represents a transaction returned by /sep6/transaction endpoint of bridge server
+type TransferTransaction struct {
+	ID                    string     `json:"id"`
+	Kind                  string     `json:"kind"`
+	Status                string     `json:"status"`
+	AmountIn              *string    `json:"amount_in,omitempty"`
+	AmountOut             *string    `json:"amount_out,omitempty"`
+	AmountFee             *string    `json:"amount_fee,omitempty"`
+	From                  string     `json:"from,omitempty"`
+	To                    string     `json:"to,omitempty"`
+	DepositMemoType       *string    `json:"deposit_memo_type,omitempty"`
+	DepositMemo           *string    `json:"deposit_memo,omitempty"`
+	WithdrawAnchorAccount string     `json:"withdraw_anchor_account,omitempty"`
+	WithdrawMemoType      *string    `json:"withdraw_memo_type,omitempty"`
+	WithdrawMemo          *string    `json:"withdraw_memo,omitempty"`
+	StellarTransactionID  *string    `json:"stellar_transaction_id,omitempty"`
+	Message               *string    `json:"message,omitempty"`
+	StartedAt             time.Time  `json:"started_at"`
+	CompletedAt           *time.Time `json:"completed_at,omitempty"`
+}
+
+// NewTransferTransaction creates TransferTransaction from a DB entity. withdrawAnchorAccount
+// is the account withdrawal payments are sent to.
+func NewTransferTransaction(transaction *entities.TransferTransaction, withdrawAnchorAccount string) TransferTransaction {
+	response := TransferTransaction{
+		ID:                   transaction.PublicID,
+		Kind:                 transaction.Kind,
+		Status:               transaction.Status,
+		AmountIn:             transaction.AmountIn,
+		AmountOut:            transaction.AmountOut,
+		AmountFee:            transaction.AmountFee,
+		StellarTransactionID: transaction.StellarTransactionID,
+		Message:              transaction.StatusMessage,
+		StartedAt:            transaction.StartedAt,
+		CompletedAt:          transaction.CompletedAt,
+	}
+
+	if transaction.Kind == entities.TransferKindWithdrawal {
+		response.From = transaction.Account
+		response.WithdrawAnchorAccount = withdrawAnchorAccount
+		response.WithdrawMemoType = transaction.MemoType
+		response.WithdrawMemo = transaction.Memo
+	} else {
+		response.To = transaction.Account
+		response.DepositMemoType = transaction.MemoType
+		response.DepositMemo = transaction.Memo
+	}
+	return response
+}
+
+// TransferTransactionResponse represents response returned by /sep6/transaction endpoint of bridge server
+type TransferTransactionResponse struct {
+	protocols.SuccessResponse
+	Transaction TransferTransaction `json:"transaction"`
+}
+
+// Marshal marshals TransferTransactionResponse
+func (response *TransferTransactionResponse) Marshal() []byte {
+	json, _ := json.MarshalIndent(response, "", "  ")
+	return json
+}
+
+// TransferUpdateRequest represents request made to PUT /admin/transfers/:id endpoint of bridge
+// server
+type TransferUpdateRequest struct {
+	Status  string `name:"status" required:""`
+	Amount  string `name:"amount"`
+	Message string `name:"message"`
+
+	protocols.FormRequest
+}
+
+// FromRequest will populate request fields using http.Request.
+func (request *TransferUpdateRequest) FromRequest(r *http.Request) {
+	request.FormRequest.FromRequest(r, request)
+}
+
+// ToValues will create url.Values from request.
+func (request *TransferUpdateRequest) ToValues() url.Values {
+	return request.FormRequest.ToValues(request)
+}
+
+// Validate validates if request fields are valid. Only statuses set by the anchor are accepted,
+// transactions are completed by /payment requests or received payments.
+func (request *TransferUpdateRequest) Validate() error {
+	err := request.FormRequest.CheckRequired(request)
+	if err != nil {
+		return err
+	}
+
+	switch request.Status {
+	case entities.TransferStatusPendingUserTransferStart, entities.TransferStatusPendingAnchor, entities.TransferStatusError:
+	default:
+		return protocols.NewInvalidParameterError("status", request.Status)
+	}
+
+	if request.Amount != "" && !protocols.IsValidAmount(request.Amount) {
+		return protocols.NewInvalidParameterError("amount", request.Amount)
+	}
+	return nil
+}
+
+// Interactive response types
+const (
+	InteractiveCustomerInfoNeeded = "interactive_customer_info_needed"
+)
+
+// InteractiveInfoResponse represents response returned by /sep24/info endpoint of bridge server
+type InteractiveInfoResponse struct {
+	protocols.SuccessResponse
+	Deposit  map[string]TransferAssetInfo `json:"deposit"`
+	Withdraw map[string]TransferAssetInfo `json:"withdraw"`
+	Fee      TransferFeatureInfo          `json:"fee"`
+}
+
+// Marshal marshals InteractiveInfoResponse
+func (response *InteractiveInfoResponse) Marshal() []byte {
+	json, _ := json.MarshalIndent(response, "", "  ")
+	return json
+}
+
+// InteractiveResponse represents response returned by /sep24/transactions/deposit/interactive
+// and /sep24/transactions/withdraw/interactive endpoints of bridge server. The wallet opens URL
+// in a popup or a webview to hand the user off to the anchor's web app.
+type InteractiveResponse struct {
+	protocols.SuccessResponse
+	Type string `json:"type"`
+	URL  string `json:"url"`
+	ID   string `json:"id"`
+}
+
+// Marshal marshals InteractiveResponse
+func (response *InteractiveResponse) Marshal() []byte {
+	json, _ := json.MarshalIndent(response, "", "  ")
+	return json
+}
@@ -0,0 +1,161 @@
+package bridge
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/stellar/gateway/protocols"
+	"github.com/stellar/go-stellar-base/keypair"
+)
+
+var (
+	// ChangeTrustMalformed is an error response
+	ChangeTrustMalformed = &protocols.ErrorResponse{Code: "change_trust_malformed", Message: "Asset or limit is malformed.", Status: http.StatusBadRequest}
+	// ChangeTrustNoIssuer is an error response
+	ChangeTrustNoIssuer = &protocols.ErrorResponse{Code: "change_trust_no_issuer", Message: "Asset issuer does not exist.", Status: http.StatusBadRequest}
+	// ChangeTrustInvalidLimit is an error response
+	ChangeTrustInvalidLimit = &protocols.ErrorResponse{Code: "change_trust_invalid_limit", Message: "Limit is lower than the current balance. Trustlines with a balance cannot be removed.", Status: http.StatusBadRequest}
+	// ChangeTrustLowReserve is an error response
+	ChangeTrustLowReserve = &protocols.ErrorResponse{Code: "change_trust_low_reserve", Message: "Not enough funds to create a new trustline.", Status: http.StatusBadRequest}
+	// AllowTrustNoAuthorizingSeed is an error response
+	AllowTrustNoAuthorizingSeed = &protocols.ErrorResponse{Code: "allow_trust_no_authorizing_seed", Message: "accounts.authorizing_seed is required when source is not set.", Status: http.StatusBadRequest}
+)
+
+// TrustRequest represents request made to /trust endpoint of bridge server
+type TrustRequest struct {
+	// Secret seed of the account creating or changing the trustline
+	Source string `name:"source"`
+	// Code of the trusted asset
+	AssetCode string `name:"asset_code"`
+	// Issuer of the trusted asset
+	AssetIssuer string `name:"asset_issuer"`
+	// Trusted asset as `CODE:ISSUER`. Alternative to asset_code and asset_issuer.
+	Asset string `name:"asset"`
+	// Maximum balance of the asset. Maximum limit when empty, 0 removes the trustline.
+	Limit string `name:"limit"`
+
+	protocols.FormRequest
+}
+
+// FromRequest will populate request fields using http.Request.
+func (request *TrustRequest) FromRequest(r *http.Request) {
+	request.FormRequest.FromRequest(r, request)
+}
+
+// ToValues will create url.Values from request.
+func (request *TrustRequest) ToValues() url.Values {
+	return request.FormRequest.ToValues(request)
+}
+
+// Validate validates if request fields are valid. Useful when checking if a request is correct.
+// Code and issuer fields are set using asset param when it's present.
+func (request *TrustRequest) Validate() error {
+	err := request.FormRequest.CheckRequired(request)
+	if err != nil {
+		return err
+	}
+
+	if request.Asset != "" {
+		err = parseAssetParam("asset", request.Asset, &request.AssetCode, &request.AssetIssuer)
+		if err != nil {
+			return err
+		}
+	}
+
+	if request.AssetCode == "" {
+		return protocols.NewMissingParameter("asset_code")
+	}
+
+	if request.AssetIssuer == "" {
+		return protocols.NewMissingParameter("asset_issuer")
+	}
+
+	if !protocols.IsValidAssetCode(request.AssetCode) {
+		return protocols.NewInvalidParameterError("asset_code", request.AssetCode)
+	}
+
+	_, err = keypair.Parse(request.AssetIssuer)
+	if err != nil {
+		return protocols.NewInvalidParameterError("asset_issuer", request.AssetIssuer)
+	}
+
+	if request.Limit != "" {
+		err = protocols.ValidateAmount("limit", request.Limit, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	if request.Source != "" {
+		_, err = keypair.Parse(request.Source)
+		if err != nil {
+			return protocols.NewInvalidParameterError("source", request.Source)
+		}
+	}
+
+	return nil
+}
+
+// AllowTrustRequest represents request made to /allow-trust endpoint of bridge server
+type AllowTrustRequest struct {
+	// Secret seed of the issuing account
+	Source string `name:"source"`
+	// Account ID of the trustor
+	AccountID string `name:"account_id" required:""`
+	// Code of the asset issued by the source account
+	AssetCode string `name:"asset_code" required:""`
+	// `true` (default) authorizes the trustline, `false` revokes the authorization
+	Authorize string `name:"authorize"`
+
+	protocols.FormRequest
+}
+
+// FromRequest will populate request fields using http.Request.
+func (request *AllowTrustRequest) FromRequest(r *http.Request) {
+	request.FormRequest.FromRequest(r, request)
+}
+
+// ToValues will create url.Values from request.
+func (request *AllowTrustRequest) ToValues() url.Values {
+	return request.FormRequest.ToValues(request)
+}
+
+// Validate validates if request fields are valid. Useful when checking if a request is correct.
+func (request *AllowTrustRequest) Validate() error {
+	err := request.FormRequest.CheckRequired(request)
+	if err != nil {
+		return err
+	}
+
+	_, err = keypair.Parse(request.AccountID)
+	if err != nil {
+		return protocols.NewInvalidParameterError("account_id", request.AccountID)
+	}
+
+	if !protocols.IsValidAssetCode(request.AssetCode) {
+		return protocols.NewInvalidParameterError("asset_code", request.AssetCode)
+	}
+
+	if request.Authorize != "" {
+		_, err = strconv.ParseBool(request.Authorize)
+		if err != nil {
+			return protocols.NewInvalidParameterError("authorize", request.Authorize)
+		}
+	}
+
+	if request.Source != "" {
+		_, err = keypair.Parse(request.Source)
+		if err != nil {
+			return protocols.NewInvalidParameterError("source", request.Source)
+		}
+	}
+
+	return nil
+}
+
+// Authorized returns false when the request revokes the authorization
+func (request *AllowTrustRequest) Authorized() bool {
+	authorize, err := strconv.ParseBool(request.Authorize)
+	return err != nil || authorize
+}
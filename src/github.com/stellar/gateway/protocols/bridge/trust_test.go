@@ -0,0 +1,80 @@
+package bridge
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/protocols"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testIssuer = "GDSIKW43UA6JTOA47WVEBCZ4MYC74M3GNKNXTVDXFHXYYTNO5GGVN632"
+
+func TestTrustRequestValidate(t *testing.T) {
+	validate := func(values url.Values) (*TrustRequest, error) {
+		r := httptest.NewRequest("POST", "/trust", strings.NewReader(values.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		request := &TrustRequest{}
+		request.FromRequest(r)
+		return request, request.Validate()
+	}
+
+	request, err := validate(url.Values{"asset": {"USD:" + testIssuer}, "limit": {"0"}})
+	require.NoError(t, err)
+	assert.Equal(t, "USD", request.AssetCode)
+	assert.Equal(t, testIssuer, request.AssetIssuer)
+
+	_, err = validate(url.Values{"asset_code": {"USD"}, "asset_issuer": {testIssuer}})
+	assert.NoError(t, err)
+
+	_, err = validate(url.Values{"asset_code": {"USD"}})
+	assert.Equal(t, "asset_issuer", err.(*protocols.ErrorResponse).Data["name"])
+	_, err = validate(url.Values{"asset": {"native"}})
+	assert.Equal(t, "asset_code", err.(*protocols.ErrorResponse).Data["name"])
+	_, err = validate(url.Values{"asset": {"USD:" + testIssuer}, "limit": {"-1"}})
+	assert.Equal(t, "limit", err.(*protocols.ErrorResponse).Data["name"])
+	_, err = validate(url.Values{"asset": {"USD:" + testIssuer}, "source": {"bob"}})
+	assert.Equal(t, "source", err.(*protocols.ErrorResponse).Data["name"])
+}
+
+func TestAllowTrustRequestValidate(t *testing.T) {
+	validate := func(values url.Values) (*AllowTrustRequest, error) {
+		r := httptest.NewRequest("POST", "/allow-trust", strings.NewReader(values.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		request := &AllowTrustRequest{}
+		request.FromRequest(r)
+		return request, request.Validate()
+	}
+
+	request, err := validate(url.Values{"account_id": {testIssuer}, "asset_code": {"USD"}})
+	require.NoError(t, err)
+	assert.True(t, request.Authorized())
+
+	request, err = validate(url.Values{"account_id": {testIssuer}, "asset_code": {"USD"}, "authorize": {"false"}})
+	require.NoError(t, err)
+	assert.False(t, request.Authorized())
+
+	_, err = validate(url.Values{"account_id": {testIssuer}, "asset_code": {"USD"}, "authorize": {"maybe"}})
+	assert.Equal(t, "authorize", err.(*protocols.ErrorResponse).Data["name"])
+	_, err = validate(url.Values{"account_id": {"bob"}, "asset_code": {"USD"}})
+	assert.Equal(t, "account_id", err.(*protocols.ErrorResponse).Data["name"])
+	_, err = validate(url.Values{"account_id": {testIssuer}})
+	assert.Error(t, err)
+}
+
+func TestErrorFromHorizonResponseChangeTrust(t *testing.T) {
+	// fee charged, tx_failed, 1 result, op_inner, change_trust, change_trust_low_reserve, ext
+	var result bytes.Buffer
+	binary.Write(&result, binary.BigEndian, []int32{0, 100, -1, 1, 0, 6, -4, 0})
+	response := horizon.SubmitTransactionResponse{
+		Extras: &horizon.SubmitTransactionResponseExtras{ResultXdr: base64.StdEncoding.EncodeToString(result.Bytes())},
+	}
+	assert.Equal(t, ChangeTrustLowReserve, ErrorFromHorizonResponse(response))
+}
@@ -0,0 +1,50 @@
+package bridge
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Statuses of TxApproveResponse
+const (
+	TxApproveStatusSuccess        = "success"
+	TxApproveStatusRevised        = "revised"
+	TxApproveStatusPending        = "pending"
+	TxApproveStatusActionRequired = "action_required"
+	TxApproveStatusRejected       = "rejected"
+)
+
+// TxApproveResponse represents response returned by /tx_approve endpoint of bridge server
+// (SEP-8 approval server)
+type TxApproveResponse struct {
+	Status string `json:"status"`
+	// Tx is a base64-encoded transaction envelope signed by the issuer (success and revised)
+	Tx      string `json:"tx,omitempty"`
+	Message string `json:"message,omitempty"`
+	// Timeout is a number of milliseconds after which the transaction can be sent again (pending)
+	Timeout      *int     `json:"timeout,omitempty"`
+	ActionURL    string   `json:"action_url,omitempty"`
+	ActionMethod string   `json:"action_method,omitempty"`
+	ActionFields []string `json:"action_fields,omitempty"`
+	// Error is a reason of the rejection
+	Error string `json:"error,omitempty"`
+}
+
+// NewTxApproveRejectedResponse creates a new rejected TxApproveResponse
+func NewTxApproveRejectedResponse(reason string) *TxApproveResponse {
+	return &TxApproveResponse{Status: TxApproveStatusRejected, Error: reason}
+}
+
+// HTTPStatus returns 400 for rejected transactions and 200 OK otherwise
+func (response *TxApproveResponse) HTTPStatus() int {
+	if response.Status == TxApproveStatusRejected {
+		return http.StatusBadRequest
+	}
+	return http.StatusOK
+}
+
+// Marshal marshals TxApproveResponse
+func (response *TxApproveResponse) Marshal() []byte {
+	json, _ := json.MarshalIndent(response, "", "  ")
+	return json
+}
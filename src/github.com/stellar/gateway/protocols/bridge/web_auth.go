@@ -0,0 +1,42 @@
+package bridge
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/stellar/gateway/protocols"
+)
+
+var (
+	// WebAuthAccountNotAllowed is an error response
+	WebAuthAccountNotAllowed = &protocols.ErrorResponse{Code: "web_auth_account_not_allowed", Message: "Account is not allowed to authenticate.", Status: http.StatusForbidden}
+	// WebAuthInvalidChallenge is an error response
+	WebAuthInvalidChallenge = &protocols.ErrorResponse{Code: "web_auth_invalid_challenge", Message: "Transaction is not a valid challenge or it has expired.", Status: http.StatusBadRequest}
+	// WebAuthNotSigned is an error response
+	WebAuthNotSigned = &protocols.ErrorResponse{Code: "web_auth_not_signed", Message: "Challenge is not signed by the client account signers.", Status: http.StatusUnauthorized}
+)
+
+// WebAuthChallengeResponse represents response returned by GET /auth endpoint of bridge server
+type WebAuthChallengeResponse struct {
+	protocols.SuccessResponse
+	Transaction       string `json:"transaction"`
+	NetworkPassphrase string `json:"network_passphrase"`
+}
+
+// Marshal marshals WebAuthChallengeResponse
+func (response *WebAuthChallengeResponse) Marshal() []byte {
+	json, _ := json.MarshalIndent(response, "", "  ")
+	return json
+}
+
+// WebAuthTokenResponse represents response returned by POST /auth endpoint of bridge server
+type WebAuthTokenResponse struct {
+	protocols.SuccessResponse
+	Token string `json:"token"`
+}
+
+// Marshal marshals WebAuthTokenResponse
+func (response *WebAuthTokenResponse) Marshal() []byte {
+	json, _ := json.MarshalIndent(response, "", "  ")
+	return json
+}
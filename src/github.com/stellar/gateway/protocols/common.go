@@ -1,15 +1,21 @@
 package protocols
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"reflect"
+	"strings"
 
 	"github.com/facebookgo/structtag"
 	"github.com/stellar/go-stellar-base/build"
 )
 
+// NativeAssetString is a canonical string representation of native asset
+const NativeAssetString = "native"
+
 // Asset represents native or credit asset
 type Asset struct {
 	Code   string `name:"asset_code" json:"code"`
@@ -24,9 +30,49 @@ func (a Asset) ToBaseAsset() build.Asset {
 	return build.CreditAsset(a.Code, a.Issuer)
 }
 
-// String returns string representation of this asset
+// ParseAsset parses canonical string representation of an asset: `native` or `CODE:ISSUER`
+func ParseAsset(s string) (Asset, error) {
+	if s == NativeAssetString {
+		return Asset{}, nil
+	}
+
+	tokens := strings.Split(s, ":")
+	if len(tokens) != 2 || tokens[0] == "" || tokens[1] == "" {
+		return Asset{}, errors.New("asset must be `native` or `CODE:ISSUER`")
+	}
+
+	asset := Asset{Code: tokens[0], Issuer: tokens[1]}
+	if !asset.Validate() {
+		return Asset{}, fmt.Errorf("invalid asset: %s", s)
+	}
+
+	return asset, nil
+}
+
+// String returns canonical string representation of this asset: `native` or `CODE:ISSUER`
 func (a Asset) String() string {
-	return fmt.Sprintf("%+v", a)
+	if a.Code == "" && a.Issuer == "" {
+		return NativeAssetString
+	}
+	return a.Code + ":" + a.Issuer
+}
+
+// UnmarshalJSON accepts both canonical string representation of an asset and
+// `{"code": "...", "issuer": "..."}` object
+func (a *Asset) UnmarshalJSON(data []byte) error {
+	var s string
+	if json.Unmarshal(data, &s) == nil {
+		asset, err := ParseAsset(s)
+		if err != nil {
+			return err
+		}
+		*a = asset
+		return nil
+	}
+
+	// Type without UnmarshalJSON method to prevent recursion
+	type asset Asset
+	return json.Unmarshal(data, (*asset)(a))
 }
 
 // Validate checks if asset params are correct.
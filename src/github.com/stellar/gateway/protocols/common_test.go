@@ -1,6 +1,7 @@
 package protocols_test
 
 import (
+	"encoding/json"
 	"net/http"
 	"reflect"
 	"testing"
@@ -43,3 +44,30 @@ func TestProtocols(t *testing.T) {
 		})
 	})
 }
+
+func TestAsset(t *testing.T) {
+	issuer := "GDSIKW43UA6JTOA47WVEBCZ4MYC74M3GNKNXTVDXFHXYYTNO5GGVN632"
+
+	asset, err := protocols.ParseAsset("USD:" + issuer)
+	assert.NoError(t, err)
+	assert.Equal(t, protocols.Asset{Code: "USD", Issuer: issuer}, asset)
+	assert.Equal(t, "USD:"+issuer, asset.String())
+
+	asset, err = protocols.ParseAsset("native")
+	assert.NoError(t, err)
+	assert.Equal(t, protocols.Asset{}, asset)
+	assert.Equal(t, "native", asset.String())
+
+	for _, invalid := range []string{"", "USD", "USD:", ":" + issuer, "USD:GABC", "TOOLONGASSETCODE:" + issuer, "USD:" + issuer + ":X"} {
+		_, err = protocols.ParseAsset(invalid)
+		assert.Error(t, err, invalid)
+	}
+
+	var assets []protocols.Asset
+	err = json.Unmarshal([]byte(`["EUR:`+issuer+`", "native", {"code": "USD", "issuer": "`+issuer+`"}]`), &assets)
+	assert.NoError(t, err)
+	assert.Equal(t, []protocols.Asset{{Code: "EUR", Issuer: issuer}, {}, {Code: "USD", Issuer: issuer}}, assets)
+
+	err = json.Unmarshal([]byte(`"USD"`), &asset)
+	assert.Error(t, err)
+}
@@ -40,6 +40,15 @@ type AuthData struct {
 	Tx string `json:"tx"`
 	// The full text of the memo the hash of this memo is included in the transaction.
 	Memo string `json:"memo"`
+	// Memo encrypted using EncryptMemo with the receiver's ENCRYPTION_KEY. Memo is empty when
+	// EncryptedMemo is set.
+	EncryptedMemo string `json:"encrypted_memo,omitempty"`
+	// Unix time the request was created at. Receivers with replay protection reject requests
+	// outside of their window.
+	Timestamp int64 `json:"timestamp,omitempty"`
+	// Random value, unique for every request of the sender. Receivers with replay protection
+	// reject requests with a nonce they have already seen.
+	Nonce string `json:"nonce,omitempty"`
 }
 
 // Marshal marshals AuthData
@@ -0,0 +1,126 @@
+package compliance
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+
+	"github.com/stellar/go-stellar-base/strkey"
+)
+
+// Memo preimages are encrypted using X25519 key agreement between an ephemeral key of the
+// sender and the receiver's ENCRYPTION_KEY published in stellar.toml. The message key is
+// derived using HKDF-SHA256 and the memo is encrypted using AES-256-GCM. EncryptedMemo is
+// base64-encoded ephemeral public key, nonce and ciphertext.
+
+const encryptionInfo = "stellar compliance memo"
+
+// EncryptionPublicKey returns a public key (`G...`) of encryption key seed (`keys.encryption_key`)
+// that must be published as ENCRYPTION_KEY in stellar.toml
+func EncryptionPublicKey(seed string) (string, error) {
+	privateKey, err := encryptionPrivateKey(seed)
+	if err != nil {
+		return "", err
+	}
+	return strkey.Encode(strkey.VersionByteAccountID, privateKey.PublicKey().Bytes())
+}
+
+// EncryptMemo encrypts memo preimage so it can be decrypted only by the owner of encryptionKey
+// (ENCRYPTION_KEY of the receiver)
+func EncryptMemo(memo []byte, encryptionKey string) (string, error) {
+	raw, err := strkey.Decode(strkey.VersionByteAccountID, encryptionKey)
+	if err != nil {
+		return "", err
+	}
+	publicKey, err := ecdh.X25519().NewPublicKey(raw)
+	if err != nil {
+		return "", err
+	}
+
+	ephemeral, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return "", err
+	}
+
+	aead, err := memoCipher(ephemeral, publicKey, ephemeral.PublicKey())
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return "", err
+	}
+
+	encrypted := append(ephemeral.PublicKey().Bytes(), nonce...)
+	encrypted = aead.Seal(encrypted, nonce, memo, nil)
+	return base64.StdEncoding.EncodeToString(encrypted), nil
+}
+
+// DecryptMemo decrypts memo preimage encrypted by EncryptMemo using public key of seed
+func DecryptMemo(encryptedMemo, seed string) ([]byte, error) {
+	privateKey, err := encryptionPrivateKey(seed)
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted, err := base64.StdEncoding.DecodeString(encryptedMemo)
+	if err != nil {
+		return nil, err
+	}
+
+	const keyLength = 32
+	if len(encrypted) < keyLength {
+		return nil, errors.New("encrypted memo too short")
+	}
+	ephemeral, err := ecdh.X25519().NewPublicKey(encrypted[:keyLength])
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := memoCipher(privateKey, ephemeral, ephemeral)
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted = encrypted[keyLength:]
+	if len(encrypted) < aead.NonceSize() {
+		return nil, errors.New("encrypted memo too short")
+	}
+	return aead.Open(nil, encrypted[:aead.NonceSize()], encrypted[aead.NonceSize():], nil)
+}
+
+// encryptionPrivateKey returns X25519 private key of Stellar seed
+func encryptionPrivateKey(seed string) (*ecdh.PrivateKey, error) {
+	raw, err := strkey.Decode(strkey.VersionByteSeed, seed)
+	if err != nil {
+		return nil, err
+	}
+	return ecdh.X25519().NewPrivateKey(raw)
+}
+
+// memoCipher returns AES-GCM cipher with a key agreed between privateKey and publicKey.
+// ephemeral public key is bound to the key.
+func memoCipher(privateKey *ecdh.PrivateKey, publicKey, ephemeral *ecdh.PublicKey) (cipher.AEAD, error) {
+	secret, err := privateKey.ECDH(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := hkdf.Key(sha256.New, secret, ephemeral.Bytes(), encryptionInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,36 @@
+package compliance
+
+import (
+	"testing"
+
+	"github.com/stellar/go-stellar-base/keypair"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoEncryption(t *testing.T) {
+	receiver, err := keypair.Random()
+	require.NoError(t, err)
+	other, err := keypair.Random()
+	require.NoError(t, err)
+
+	encryptionKey, err := EncryptionPublicKey(receiver.Seed())
+	require.NoError(t, err)
+	assert.NotEqual(t, receiver.Address(), encryptionKey)
+
+	memo := []byte(`{"transaction":{"sender_info":"{\"name\":\"John Doe\"}"}}`)
+	encrypted, err := EncryptMemo(memo, encryptionKey)
+	require.NoError(t, err)
+	assert.NotContains(t, encrypted, "John")
+
+	decrypted, err := DecryptMemo(encrypted, receiver.Seed())
+	require.NoError(t, err)
+	assert.Equal(t, memo, decrypted)
+
+	_, err = DecryptMemo(encrypted, other.Seed())
+	assert.Error(t, err)
+	_, err = DecryptMemo("AAAA", receiver.Seed())
+	assert.Error(t, err)
+	_, err = EncryptMemo(memo, "bad")
+	assert.Error(t, err)
+}
@@ -7,6 +7,13 @@ import (
 )
 
 var (
+	// Auth endpoint
+
+	// AuthRequestExpired is an error response
+	AuthRequestExpired = &protocols.ErrorResponse{Code: "auth_request_expired", Message: "Auth request timestamp is missing or outside of the replay protection window.", Status: http.StatusBadRequest}
+	// AuthRequestReplayed is an error response
+	AuthRequestReplayed = &protocols.ErrorResponse{Code: "auth_request_replayed", Message: "Auth request nonce has already been used.", Status: http.StatusBadRequest}
+
 	// /receive
 
 	// TransactionNotFoundError is an error response
@@ -18,4 +25,8 @@ var (
 	CannotResolveDestination = &protocols.ErrorResponse{Code: "cannot_resolve_destination", Message: "Cannot resolve federated Stellar address.", Status: http.StatusBadRequest}
 	// AuthServerNotDefined is an error response
 	AuthServerNotDefined = &protocols.ErrorResponse{Code: "auth_server_not_defined", Message: "No AUTH_SERVER defined in stellar.toml file.", Status: http.StatusBadRequest}
+	// EncryptionKeyInvalid is an error response
+	EncryptionKeyInvalid = &protocols.ErrorResponse{Code: "encryption_key_invalid", Message: "Invalid ENCRYPTION_KEY in stellar.toml file.", Status: http.StatusBadRequest}
+	// SenderInfoNotFound is an error response
+	SenderInfoNotFound = &protocols.ErrorResponse{Code: "sender_info_not_found", Message: "Compliance information of the sender not found.", Status: http.StatusBadRequest}
 )
@@ -10,6 +10,7 @@ import (
 // FetchInfoRequest represents a request sent to fetch_info callback
 type FetchInfoRequest struct {
 	Address     string `name:"address" required:""`
+	Fields      string `name:"fields"`
 	formRequest protocols.FormRequest
 }
 
@@ -0,0 +1,124 @@
+package compliance
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/protocols"
+)
+
+var (
+	// KYCCustomerNotFound is an error response
+	KYCCustomerNotFound = &protocols.ErrorResponse{Code: "kyc_customer_not_found", Message: "KYC customer not found.", Status: http.StatusNotFound}
+	// KYCCustomerAddressTaken is an error response
+	KYCCustomerAddressTaken = &protocols.ErrorResponse{Code: "kyc_customer_address_taken", Message: "Address or route is already assigned to another customer.", Status: http.StatusBadRequest}
+)
+
+// KYCCustomerRequest represents request made to /kyc/customers endpoints of compliance server
+type KYCCustomerRequest struct {
+	// Address is a Stellar address of the customer, ex. `alice*acme.com`
+	Address string `name:"address" required:""`
+	// Route is a route of incoming payments of the customer
+	Route string `name:"route"`
+	// Info is a JSON object with SEP-9 fields
+	Info string `name:"info"`
+	// Status is `ok` (default), `pending` or `denied`
+	Status string `name:"status"`
+	// ShareInfo is `true` when info can be shared with FIs sending payments to the customer
+	ShareInfo string `name:"share_info"`
+
+	protocols.FormRequest
+}
+
+// FromRequest will populate request fields using http.Request.
+func (request *KYCCustomerRequest) FromRequest(r *http.Request) {
+	request.FormRequest.FromRequest(r, request)
+}
+
+// ToValues will create url.Values from request.
+func (request *KYCCustomerRequest) ToValues() url.Values {
+	return request.FormRequest.ToValues(request)
+}
+
+// Validate validates if request fields are valid. Useful when checking if a request is correct.
+func (request *KYCCustomerRequest) Validate() error {
+	err := request.FormRequest.CheckRequired(request)
+	if err != nil {
+		return err
+	}
+
+	if request.Info != "" {
+		var fields map[string]interface{}
+		if json.Unmarshal([]byte(request.Info), &fields) != nil {
+			return protocols.NewInvalidParameterError("info", request.Info)
+		}
+	}
+
+	switch request.Status {
+	case "", entities.KYCStatusOk, entities.KYCStatusPending, entities.KYCStatusDenied:
+	default:
+		return protocols.NewInvalidParameterError("status", request.Status)
+	}
+
+	switch request.ShareInfo {
+	case "", "true", "false":
+	default:
+		return protocols.NewInvalidParameterError("share_info", request.ShareInfo)
+	}
+
+	return nil
+}
+
+// KYCCustomer represents a customer returned by /kyc/customers endpoints of compliance server
+type KYCCustomer struct {
+	ID        int64            `json:"id"`
+	Address   string           `json:"address"`
+	Route     *string          `json:"route,omitempty"`
+	Info      *json.RawMessage `json:"info"`
+	Status    string           `json:"status"`
+	ShareInfo bool             `json:"share_info"`
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at"`
+}
+
+// NewKYCCustomer creates KYCCustomer from a DB entity
+func NewKYCCustomer(customer *entities.KYCCustomer) KYCCustomer {
+	info := json.RawMessage(customer.Info)
+	return KYCCustomer{
+		ID:        *customer.ID,
+		Address:   customer.Address,
+		Route:     customer.Route,
+		Info:      &info,
+		Status:    customer.Status,
+		ShareInfo: customer.ShareInfo,
+		CreatedAt: customer.CreatedAt,
+		UpdatedAt: customer.UpdatedAt,
+	}
+}
+
+// KYCCustomerResponse represents response returned by /kyc/customers/:id endpoint of compliance server
+type KYCCustomerResponse struct {
+	protocols.SuccessResponse
+	KYCCustomer
+}
+
+// Marshal marshals KYCCustomerResponse
+func (response *KYCCustomerResponse) Marshal() []byte {
+	json, _ := json.MarshalIndent(response, "", "  ")
+	return json
+}
+
+// KYCCustomersResponse represents response returned by /kyc/customers endpoint of compliance server
+type KYCCustomersResponse struct {
+	protocols.SuccessResponse
+	Customers []KYCCustomer `json:"customers"`
+}
+
+// Marshal marshals KYCCustomersResponse
+func (response *KYCCustomersResponse) Marshal() []byte {
+	json, _ := json.MarshalIndent(response, "", "  ")
+	return json
+}
@@ -34,6 +34,8 @@ type SendRequest struct {
 	Path []protocols.Asset `name:"path"`
 	// Extra memo
 	ExtraMemo string `name:"extra_memo" required:""`
+	// SenderCustomerID is an ID of a SEP-12 customer which fields are sent as sender info
+	SenderCustomerID string `name:"sender_customer_id"`
 
 	protocols.FormRequest
 }
@@ -0,0 +1,141 @@
+package compliance
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/protocols"
+)
+
+var (
+	// Sep12Unauthorized is an error response
+	Sep12Unauthorized = &protocols.ErrorResponse{Code: "sep12_unauthorized", Message: "Valid SEP-10 token is required in Authorization header.", Status: http.StatusUnauthorized}
+	// Sep12CustomerNotFound is an error response
+	Sep12CustomerNotFound = &protocols.ErrorResponse{Code: "sep12_customer_not_found", Message: "Customer not found.", Status: http.StatusNotFound}
+	// Sep12CustomerNotAccepted is an error response
+	Sep12CustomerNotAccepted = &protocols.ErrorResponse{Code: "sep12_customer_not_accepted", Message: "Customer has not been accepted.", Status: http.StatusBadRequest}
+)
+
+// Sep12Field describes a SEP-9 field in /sep12/customer responses
+type Sep12Field struct {
+	Type        string   `json:"type"`
+	Description string   `json:"description"`
+	Choices     []string `json:"choices,omitempty"`
+	Optional    bool     `json:"optional,omitempty"`
+}
+
+// Sep12CustomerResponse represents response returned by GET /sep12/customer endpoint of
+// compliance server. Fields are fields the customer has not provided yet.
+type Sep12CustomerResponse struct {
+	protocols.SuccessResponse
+	ID             string                `json:"id,omitempty"`
+	Status         string                `json:"status"`
+	Message        *string               `json:"message,omitempty"`
+	Fields         map[string]Sep12Field `json:"fields,omitempty"`
+	ProvidedFields map[string]Sep12Field `json:"provided_fields,omitempty"`
+}
+
+// Marshal marshals Sep12CustomerResponse
+func (response *Sep12CustomerResponse) Marshal() []byte {
+	json, _ := json.MarshalIndent(response, "", "  ")
+	return json
+}
+
+// Sep12PutCustomerResponse represents response returned by PUT /sep12/customer endpoint of
+// compliance server
+type Sep12PutCustomerResponse struct {
+	ID string `json:"id"`
+}
+
+// HTTPStatus returns http.StatusAccepted
+func (response *Sep12PutCustomerResponse) HTTPStatus() int {
+	return http.StatusAccepted
+}
+
+// Marshal marshals Sep12PutCustomerResponse
+func (response *Sep12PutCustomerResponse) Marshal() []byte {
+	json, _ := json.MarshalIndent(response, "", "  ")
+	return json
+}
+
+// Sep12StatusRequest represents request made to PUT /sep12/customers/:id endpoint of
+// compliance server
+type Sep12StatusRequest struct {
+	// Status is `ACCEPTED`, `PROCESSING` or `REJECTED`
+	Status string `name:"status" required:""`
+	// Message is a reason of the status shown to the customer
+	Message string `name:"message"`
+
+	protocols.FormRequest
+}
+
+// FromRequest will populate request fields using http.Request.
+func (request *Sep12StatusRequest) FromRequest(r *http.Request) {
+	request.FormRequest.FromRequest(r, request)
+}
+
+// ToValues will create url.Values from request.
+func (request *Sep12StatusRequest) ToValues() url.Values {
+	return request.FormRequest.ToValues(request)
+}
+
+// Validate validates if request fields are valid. Useful when checking if a request is correct.
+func (request *Sep12StatusRequest) Validate() error {
+	err := request.FormRequest.CheckRequired(request)
+	if err != nil {
+		return err
+	}
+
+	switch request.Status {
+	case entities.Sep12StatusAccepted, entities.Sep12StatusProcessing, entities.Sep12StatusRejected:
+	default:
+		return protocols.NewInvalidParameterError("status", request.Status)
+	}
+	return nil
+}
+
+// Sep12Customer represents a customer returned by /sep12/customers/:id endpoints of
+// compliance server
+type Sep12Customer struct {
+	ID        string           `json:"id"`
+	Account   string           `json:"account"`
+	MemoType  string           `json:"memo_type,omitempty"`
+	Memo      string           `json:"memo,omitempty"`
+	Fields    *json.RawMessage `json:"fields"`
+	Status    string           `json:"status"`
+	Message   *string          `json:"message,omitempty"`
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at"`
+}
+
+// NewSep12Customer creates Sep12Customer from a DB entity
+func NewSep12Customer(customer *entities.Sep12Customer) Sep12Customer {
+	fields := json.RawMessage(customer.Fields)
+	return Sep12Customer{
+		ID:        customer.PublicID,
+		Account:   customer.Account,
+		MemoType:  customer.MemoType,
+		Memo:      customer.Memo,
+		Fields:    &fields,
+		Status:    customer.Status,
+		Message:   customer.Message,
+		CreatedAt: customer.CreatedAt,
+		UpdatedAt: customer.UpdatedAt,
+	}
+}
+
+// Sep12CustomerDetailsResponse represents response returned by /sep12/customers/:id endpoints
+// of compliance server
+type Sep12CustomerDetailsResponse struct {
+	protocols.SuccessResponse
+	Sep12Customer
+}
+
+// Marshal marshals Sep12CustomerDetailsResponse
+func (response *Sep12CustomerDetailsResponse) Marshal() []byte {
+	json, _ := json.MarshalIndent(response, "", "  ")
+	return json
+}
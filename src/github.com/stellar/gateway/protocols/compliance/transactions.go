@@ -0,0 +1,178 @@
+package compliance
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/protocols"
+)
+
+// Limits of /compliance/transactions request params
+const (
+	DefaultTransactionsLimit = 50
+	MaxTransactionsLimit     = 200
+)
+
+var (
+	// ComplianceTransactionNotFound is an error response
+	ComplianceTransactionNotFound = &protocols.ErrorResponse{Code: "compliance_transaction_not_found", Message: "Compliance transaction not found.", Status: http.StatusNotFound}
+	// ComplianceTransactionNotApproved is an error response
+	ComplianceTransactionNotApproved = &protocols.ErrorResponse{Code: "compliance_transaction_not_approved", Message: "Only approved transactions can be completed.", Status: http.StatusBadRequest}
+)
+
+// Status returns a status of the compliance transaction the response was returned for:
+// denied when the transaction or info was denied, approved when both were accepted and
+// pending otherwise
+func (response *AuthResponse) Status() string {
+	switch {
+	case response.TxStatus == AuthStatusDenied || response.InfoStatus == AuthStatusDenied:
+		return entities.ComplianceStatusDenied
+	case response.TxStatus == AuthStatusOk && response.InfoStatus == AuthStatusOk:
+		return entities.ComplianceStatusApproved
+	default:
+		return entities.ComplianceStatusPending
+	}
+}
+
+// TransactionsRequest represents request made to GET /compliance/transactions endpoint of
+// compliance server
+type TransactionsRequest struct {
+	// Direction is `sent` or `received`
+	Direction     string
+	TransactionID string
+	// Domain is a domain of the counterparty FI
+	Domain string
+	Status string
+	// Since and Until are RFC 3339 timestamps
+	Since string
+	Until string
+	Limit string
+}
+
+// FromRequest will populate request fields using query params of http.Request.
+func (request *TransactionsRequest) FromRequest(r *http.Request) {
+	query := r.URL.Query()
+	request.Direction = query.Get("direction")
+	request.TransactionID = query.Get("transaction_id")
+	request.Domain = query.Get("domain")
+	request.Status = query.Get("status")
+	request.Since = query.Get("since")
+	request.Until = query.Get("until")
+	request.Limit = query.Get("limit")
+}
+
+// Parse validates request params and returns a filter of compliance transactions
+func (request *TransactionsRequest) Parse() (filter entities.ComplianceTransactionFilter, err error) {
+	filter.TransactionID = request.TransactionID
+	filter.CounterpartyDomain = request.Domain
+	filter.Limit = DefaultTransactionsLimit
+
+	switch request.Direction {
+	case "", entities.ComplianceDirectionSent, entities.ComplianceDirectionReceived:
+		filter.Direction = request.Direction
+	default:
+		err = protocols.NewInvalidParameterError("direction", request.Direction)
+		return
+	}
+
+	switch request.Status {
+	case "", entities.ComplianceStatusPending, entities.ComplianceStatusDenied,
+		entities.ComplianceStatusApproved, entities.ComplianceStatusCompleted:
+		filter.Status = request.Status
+	default:
+		err = protocols.NewInvalidParameterError("status", request.Status)
+		return
+	}
+
+	if request.Since != "" {
+		since, parseErr := time.Parse(time.RFC3339, request.Since)
+		if parseErr != nil {
+			err = protocols.NewInvalidParameterError("since", request.Since)
+			return
+		}
+		filter.Since = &since
+	}
+
+	if request.Until != "" {
+		until, parseErr := time.Parse(time.RFC3339, request.Until)
+		if parseErr != nil {
+			err = protocols.NewInvalidParameterError("until", request.Until)
+			return
+		}
+		filter.Until = &until
+	}
+
+	if request.Limit != "" {
+		filter.Limit, err = strconv.Atoi(request.Limit)
+		if err != nil || filter.Limit < 1 || filter.Limit > MaxTransactionsLimit {
+			err = protocols.NewInvalidParameterError("limit", request.Limit)
+			return
+		}
+	}
+
+	return
+}
+
+// Transaction represents a compliance transaction returned by /compliance/transactions
+// endpoints of compliance server
+type Transaction struct {
+	ID                 int64     `json:"id"`
+	Direction          string    `json:"direction"`
+	TransactionID      string    `json:"transaction_id"`
+	Memo               string    `json:"memo"`
+	Sender             string    `json:"sender"`
+	Route              string    `json:"route"`
+	CounterpartyDomain string    `json:"counterparty_domain"`
+	Status             string    `json:"status"`
+	TxStatus           string    `json:"tx_status"`
+	InfoStatus         string    `json:"info_status"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+// NewTransaction creates Transaction from a DB entity
+func NewTransaction(transaction *entities.ComplianceTransaction) Transaction {
+	return Transaction{
+		ID:                 *transaction.ID,
+		Direction:          transaction.Direction,
+		TransactionID:      transaction.TransactionID,
+		Memo:               transaction.Memo,
+		Sender:             transaction.Sender,
+		Route:              transaction.Route,
+		CounterpartyDomain: transaction.CounterpartyDomain,
+		Status:             transaction.Status,
+		TxStatus:           transaction.TxStatus,
+		InfoStatus:         transaction.InfoStatus,
+		CreatedAt:          transaction.CreatedAt,
+		UpdatedAt:          transaction.UpdatedAt,
+	}
+}
+
+// TransactionResponse represents response returned by /compliance/transactions/:id endpoints
+// of compliance server
+type TransactionResponse struct {
+	protocols.SuccessResponse
+	Transaction
+}
+
+// Marshal marshals TransactionResponse
+func (response *TransactionResponse) Marshal() []byte {
+	json, _ := json.MarshalIndent(response, "", "  ")
+	return json
+}
+
+// TransactionsResponse represents response returned by GET /compliance/transactions endpoint
+// of compliance server
+type TransactionsResponse struct {
+	protocols.SuccessResponse
+	Transactions []Transaction `json:"transactions"`
+}
+
+// Marshal marshals TransactionsResponse
+func (response *TransactionsResponse) Marshal() []byte {
+	json, _ := json.MarshalIndent(response, "", "  ")
+	return json
+}
@@ -12,6 +12,8 @@ var (
 	InvalidParameterError = &ErrorResponse{Code: "invalid_parameter", Message: "Invalid parameter.", Status: http.StatusBadRequest}
 	// MissingParameterError is an error response
 	MissingParameterError = &ErrorResponse{Code: "missing_parameter", Message: "Required parameter is missing.", Status: http.StatusBadRequest}
+	// RateLimitExceededError is an error response
+	RateLimitExceededError = &ErrorResponse{Code: "rate_limit_exceeded", Message: "Too many requests, please try again later.", Status: http.StatusTooManyRequests}
 )
 
 // NewInternalServerError creates and returns a new InternalServerError
@@ -55,6 +57,19 @@ func NewMissingParameter(name string) *ErrorResponse {
 	}
 }
 
+// NewRateLimitExceededError creates and returns a new RateLimitExceededError. limit is `ip` or
+// `api_key` and retryAfter is a number of seconds after which the request can be sent again.
+func NewRateLimitExceededError(limit string, retryAfter int) *ErrorResponse {
+	data := map[string]interface{}{"limit": limit, "retry_after": retryAfter}
+	return &ErrorResponse{
+		Status:  RateLimitExceededError.Status,
+		Code:    RateLimitExceededError.Code,
+		Message: RateLimitExceededError.Message,
+		Data:    data,
+		LogData: data,
+	}
+}
+
 // ErrorResponse represents error response and implements server.Response and error interfaces
 type ErrorResponse struct {
 	// HTTP status code
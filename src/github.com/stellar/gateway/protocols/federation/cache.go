@@ -0,0 +1,147 @@
+package federation
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stellar/gateway/protocols/stellartoml"
+)
+
+// cacheMaxEntries is a number of cached addresses after which expired entries are removed
+// from the cache. New addresses are not cached while all cached entries are fresh.
+const cacheMaxEntries = 10000
+
+// Cache implements ResolverInterface caching results of Resolve of another ResolverInterface
+// for TTL. Addresses that do not exist (federation server or stellar.toml responded with
+// 404 Not Found or the domain does not exist) are cached for NegativeTTL, other errors are
+// not cached. GetDestination and ReverseResolve requests are passed through.
+type Cache struct {
+	resolver    ResolverInterface
+	ttl         time.Duration
+	negativeTTL time.Duration
+	now         func() time.Time
+
+	mutex   sync.Mutex
+	entries map[string]cachedResolution
+}
+
+type cachedResolution struct {
+	response    Response
+	stellarToml stellartoml.StellarToml
+	err         error
+	expiresAt   time.Time
+}
+
+var _ ResolverInterface = &Cache{}
+
+// NewCache creates a new Cache of resolver results. Addresses that do not exist are not
+// cached when negativeTTL is 0.
+func NewCache(resolver ResolverInterface, ttl, negativeTTL time.Duration) *Cache {
+	return &Cache{
+		resolver:    resolver,
+		ttl:         ttl,
+		negativeTTL: negativeTTL,
+		now:         time.Now,
+		entries:     map[string]cachedResolution{},
+	}
+}
+
+// Resolve resolves federation address or returns it from the cache. Account IDs and muxed
+// addresses are resolved without requests so they are not cached.
+func (c *Cache) Resolve(address string) (response Response, stellarToml stellartoml.StellarToml, err error) {
+	c.mutex.Lock()
+	cached, ok := c.entries[address]
+	c.mutex.Unlock()
+	if ok && c.now().Before(cached.expiresAt) {
+		return cached.response, cached.stellarToml, cached.err
+	}
+
+	response, stellarToml, err = c.resolver.Resolve(address)
+	if !strings.Contains(address, "*") {
+		return
+	}
+
+	ttl := c.ttl
+	if err != nil {
+		if !isNotFound(err) {
+			return
+		}
+		ttl = c.negativeTTL
+	}
+	if ttl == 0 {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if len(c.entries) >= cacheMaxEntries {
+		c.removeExpired()
+	}
+	if len(c.entries) < cacheMaxEntries {
+		c.entries[address] = cachedResolution{
+			response:    response,
+			stellarToml: stellarToml,
+			err:         err,
+			expiresAt:   c.now().Add(ttl),
+		}
+	}
+	return
+}
+
+func (c *Cache) removeExpired() {
+	now := c.now()
+	for address, cached := range c.entries {
+		if !now.Before(cached.expiresAt) {
+			delete(c.entries, address)
+		}
+	}
+}
+
+// GetDestination resolves federation address using server specified federationURL
+func (c *Cache) GetDestination(federationURL, address string) (response Response, err error) {
+	return c.resolver.GetDestination(federationURL, address)
+}
+
+// ReverseResolve finds federation address of accountID using federation server of domain
+func (c *Cache) ReverseResolve(accountID, domain string) (response Response, err error) {
+	return c.resolver.ReverseResolve(accountID, domain)
+}
+
+// Flush removes address from the cache (all addresses when address is empty) and returns
+// the number of removed entries
+func (c *Cache) Flush(address string) int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if address == "" {
+		flushed := len(c.entries)
+		c.entries = map[string]cachedResolution{}
+		return flushed
+	}
+
+	if _, ok := c.entries[address]; !ok {
+		return 0
+	}
+	delete(c.entries, address)
+	return 1
+}
+
+// isNotFound returns true when err means that the address does not exist
+func isNotFound(err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusNotFound
+	}
+
+	var stellarTomlErr *stellartoml.StatusError
+	if errors.As(err, &stellarTomlErr) {
+		return stellarTomlErr.StatusCode == http.StatusNotFound
+	}
+
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
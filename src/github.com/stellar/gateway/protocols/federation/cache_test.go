@@ -0,0 +1,78 @@
+package federation
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stellar/gateway/protocols/stellartoml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingResolver struct {
+	ResolverInterface
+	requests map[string]int
+	errs     map[string]error
+}
+
+func (r *countingResolver) Resolve(address string) (response Response, stellarToml stellartoml.StellarToml, err error) {
+	r.requests[address]++
+	response.AccountID = "GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ"
+	return response, stellarToml, r.errs[address]
+}
+
+func TestCache(t *testing.T) {
+	resolver := &countingResolver{requests: map[string]int{}, errs: map[string]error{
+		"missing*acme.com":    &StatusError{StatusCode: 404},
+		"bob*missing.com":     &stellartoml.StatusError{StatusCode: 404},
+		"bob*nxdomain.com":    &url.Error{Op: "Get", URL: "https://nxdomain.com", Err: &net.OpError{Op: "dial", Err: &net.DNSError{Name: "nxdomain.com", IsNotFound: true}}},
+		"bob*unavailable.com": &StatusError{StatusCode: 503},
+		"bob*timeout.com":     errors.New("timeout"),
+	}}
+	now := time.Now()
+	cache := NewCache(resolver, time.Minute, 10*time.Second)
+	cache.now = func() time.Time { return now }
+
+	resolve := func(address string) error {
+		_, _, err := cache.Resolve(address)
+		return err
+	}
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, resolve("bob*acme.com"))
+		for _, address := range []string{"missing*acme.com", "bob*missing.com", "bob*nxdomain.com", "bob*unavailable.com", "bob*timeout.com"} {
+			assert.Error(t, resolve(address), address)
+		}
+		require.NoError(t, resolve("GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ"))
+	}
+
+	// Not found addresses are cached, other errors are not
+	assert.Equal(t, map[string]int{
+		"bob*acme.com":        1,
+		"missing*acme.com":    1,
+		"bob*missing.com":     1,
+		"bob*nxdomain.com":    1,
+		"bob*unavailable.com": 2,
+		"bob*timeout.com":     2,
+		"GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ": 2,
+	}, resolver.requests)
+
+	// Negative results expire first
+	now = now.Add(30 * time.Second)
+	require.NoError(t, resolve("bob*acme.com"))
+	assert.Error(t, resolve("missing*acme.com"))
+	assert.Equal(t, 1, resolver.requests["bob*acme.com"])
+	assert.Equal(t, 2, resolver.requests["missing*acme.com"])
+
+	// Flush
+	assert.Equal(t, 1, cache.Flush("bob*acme.com"))
+	assert.Equal(t, 0, cache.Flush("bob*acme.com"))
+	require.NoError(t, resolve("bob*acme.com"))
+	assert.Equal(t, 2, resolver.requests["bob*acme.com"])
+	assert.Equal(t, 4, cache.Flush(""))
+	require.NoError(t, resolve("bob*acme.com"))
+	assert.Equal(t, 3, resolver.requests["bob*acme.com"])
+}
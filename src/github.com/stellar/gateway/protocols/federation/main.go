@@ -16,6 +16,16 @@ import (
 type ResolverInterface interface {
 	Resolve(address string) (response Response, stellarToml stellartoml.StellarToml, err error)
 	GetDestination(federationURL, address string) (response Response, err error)
+	ReverseResolve(accountID, domain string) (response Response, err error)
+}
+
+// StatusError is returned when federation server responds with an error status code
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return "Federation response status code (" + strconv.Itoa(e.StatusCode) + ") indicates error"
 }
 
 // Resolver resolves federation query
@@ -23,11 +33,13 @@ type Resolver struct {
 	StellarTomlResolver *stellartoml.Resolver `inject:""`
 }
 
-// Resolve resolves federation address or account ID.
+// Resolve resolves federation address, account ID or muxed (M...) address. Muxed addresses
+// (also returned by federation servers) are resolved to account ID and `id` memo.
 func (r *Resolver) Resolve(address string) (response Response, stellarToml stellartoml.StellarToml, err error) {
 	tokens := strings.Split(address, "*")
 	if len(tokens) == 1 {
 		response.AccountID = address
+		err = response.resolveMuxed()
 	} else if len(tokens) == 2 {
 		stellarToml, err = r.StellarTomlResolver.GetStellarToml(tokens[1])
 		if err != nil {
@@ -50,33 +62,65 @@ func (r *Resolver) Resolve(address string) (response Response, stellarToml stell
 
 // GetDestination resolves federation address using server specified federationURL
 func (r *Resolver) GetDestination(federationURL, address string) (response Response, err error) {
+	response, err = r.query(federationURL, "name", address)
+	if err != nil {
+		return
+	}
+
+	if (response.MemoType != "") && (response.Memo == "") {
+		err = errors.New("Invalid federation response (memo).")
+		return
+	}
+
+	err = response.resolveMuxed()
+	return
+}
+
+// ReverseResolve finds federation address of accountID using federation server of domain.
+// domain is usually a home_domain of the account.
+func (r *Resolver) ReverseResolve(accountID, domain string) (response Response, err error) {
+	stellarToml, err := r.StellarTomlResolver.GetStellarToml(domain)
+	if err != nil {
+		return
+	}
+
+	if stellarToml.FederationServer == "" {
+		err = errors.New("stellar.toml does not contain FEDERATION_SERVER value")
+		return
+	}
+
+	response, err = r.query(stellarToml.FederationServer, "id", accountID)
+	if err != nil {
+		return
+	}
+
+	if response.StellarAddress == "" {
+		err = errors.New("Invalid federation response (stellar_address).")
+	}
+	return
+}
+
+func (r *Resolver) query(federationURL, queryType, q string) (response Response, err error) {
 	if !strings.HasPrefix(federationURL, "https://") {
 		err = errors.New("Only HTTPS federation servers allowed")
 		return
 	}
 
 	qstr := url.Values{}
-	qstr.Add("type", "name")
-	qstr.Add("q", address)
+	qstr.Add("type", queryType)
+	qstr.Add("q", q)
 
 	resp, err := http.Get(federationURL + "?" + qstr.Encode())
 	if err != nil {
 		return
 	}
 	if !(resp.StatusCode >= 200 && resp.StatusCode < 300) {
-		err = errors.New("Federation response status code (" + strconv.Itoa(resp.StatusCode) + ") indicates error")
+		err = &StatusError{StatusCode: resp.StatusCode}
 		return
 	}
 
 	var bs []byte
 	bs, err = ioutil.ReadAll(resp.Body)
 	err = json.Unmarshal(bs, &response)
-	if err != nil {
-		return
-	}
-
-	if (response.MemoType != "") && (response.Memo == "") {
-		err = errors.New("Invalid federation response (memo).")
-	}
 	return
 }
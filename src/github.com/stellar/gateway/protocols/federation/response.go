@@ -1,8 +1,52 @@
 package federation
 
+import (
+	"errors"
+	"strconv"
+
+	"github.com/stellar/gateway/protocols/muxed"
+)
+
 // Response represents response returned by federation server
 type Response struct {
-	AccountID string `json:"account_id"`
-	MemoType  string `json:"memo_type"`
-	Memo      string `json:"memo"`
+	StellarAddress string `json:"stellar_address"`
+	AccountID      string `json:"account_id"`
+	MemoType       string `json:"memo_type"`
+	Memo           string `json:"memo"`
+	// MuxedAddress is a muxed (M...) address of AccountID and `id` memo. It is set by Resolver
+	// when the destination can be represented as a muxed address.
+	MuxedAddress string `json:"-"`
+}
+
+// resolveMuxed splits muxed AccountID into G... account ID and `id` memo. MuxedAddress
+// is set for muxed account IDs and account IDs with `id` memo.
+func (response *Response) resolveMuxed() error {
+	if muxed.IsMuxed(response.AccountID) {
+		if response.MemoType != "" {
+			return errors.New("Invalid federation response (memo not allowed with muxed account_id).")
+		}
+
+		accountID, id, err := muxed.Decode(response.AccountID)
+		if err != nil {
+			return err
+		}
+
+		response.MuxedAddress = response.AccountID
+		response.AccountID = accountID
+		response.MemoType = "id"
+		response.Memo = strconv.FormatUint(id, 10)
+		return nil
+	}
+
+	if response.MemoType == "id" {
+		id, err := strconv.ParseUint(response.Memo, 10, 64)
+		if err != nil {
+			return nil
+		}
+
+		// Invalid account IDs are rejected by the caller
+		response.MuxedAddress, _ = muxed.Encode(response.AccountID, id)
+	}
+
+	return nil
 }
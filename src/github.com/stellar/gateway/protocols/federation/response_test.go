@@ -0,0 +1,50 @@
+package federation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveMuxedAddress(t *testing.T) {
+	accountID := "GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ"
+	muxedAddress := "MA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVAAAAAAAAAAAAAJLK"
+
+	resolver := &Resolver{}
+
+	response, _, err := resolver.Resolve(muxedAddress)
+	require.NoError(t, err)
+	assert.Equal(t, Response{
+		AccountID:    accountID,
+		MemoType:     "id",
+		Memo:         "9223372036854775808",
+		MuxedAddress: muxedAddress,
+	}, response)
+
+	response, _, err = resolver.Resolve(accountID)
+	require.NoError(t, err)
+	assert.Equal(t, Response{AccountID: accountID}, response)
+
+	_, _, err = resolver.Resolve("MA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVAAAAAAAAAAAAAJLL")
+	assert.Error(t, err)
+}
+
+func TestResponseResolveMuxed(t *testing.T) {
+	accountID := "GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ"
+	muxedAddress := "MA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVAAAAAAAAAAAAAJLK"
+
+	// account ID with `id` memo returned by federation server
+	response := Response{AccountID: accountID, MemoType: "id", Memo: "9223372036854775808"}
+	require.NoError(t, response.resolveMuxed())
+	assert.Equal(t, muxedAddress, response.MuxedAddress)
+
+	// `text` memo cannot be represented as muxed address
+	response = Response{AccountID: accountID, MemoType: "text", Memo: "bob"}
+	require.NoError(t, response.resolveMuxed())
+	assert.Equal(t, "", response.MuxedAddress)
+
+	// muxed account ID with memo
+	response = Response{AccountID: muxedAddress, MemoType: "text", Memo: "bob"}
+	assert.Error(t, response.resolveMuxed())
+}
@@ -0,0 +1,2 @@
+// Package muxed implements encoding and decoding of muxed account addresses (M...) defined in SEP-23
+package muxed
@@ -0,0 +1,74 @@
+package muxed
+
+import (
+	"bytes"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"strings"
+
+	"github.com/stellar/go/crc16"
+	"github.com/stellar/go/strkey"
+)
+
+// versionByte is the strkey version byte of muxed accounts. Base32-encodes to 'M...'
+const versionByte = 12 << 3
+
+// payloadLength is a length of ed25519 public key followed by 64-bit ID
+const payloadLength = 32 + 8
+
+var (
+	// ErrInvalidAccountID is returned when account ID is not a valid G... address
+	ErrInvalidAccountID = errors.New("account ID must be a valid G... address")
+	// ErrInvalidAddress is returned when address is not a valid M... address
+	ErrInvalidAddress = errors.New("address must be a valid M... muxed address")
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// IsMuxed returns true if address looks like a muxed address. It does not validate the address.
+func IsMuxed(address string) bool {
+	return strings.HasPrefix(address, "M")
+}
+
+// Encode returns muxed address of account ID and memo ID
+func Encode(accountID string, id uint64) (string, error) {
+	key, err := strkey.Decode(strkey.VersionByteAccountID, accountID)
+	if err != nil {
+		return "", ErrInvalidAccountID
+	}
+
+	var raw bytes.Buffer
+	raw.WriteByte(versionByte)
+	raw.Write(key)
+	binary.Write(&raw, binary.BigEndian, id)
+	raw.Write(crc16.Checksum(raw.Bytes()))
+
+	return encoding.EncodeToString(raw.Bytes()), nil
+}
+
+// Decode returns account ID and memo ID encoded in muxed address
+func Decode(address string) (accountID string, id uint64, err error) {
+	raw, err := encoding.DecodeString(address)
+	if err != nil || len(raw) != 1+payloadLength+2 || raw[0] != versionByte {
+		return "", 0, ErrInvalidAddress
+	}
+
+	data, checksum := raw[:len(raw)-2], raw[len(raw)-2:]
+	if crc16.Validate(data, checksum) != nil {
+		return "", 0, ErrInvalidAddress
+	}
+
+	// Non-canonical encodings (ex. with non-zero unused bits) must be rejected
+	if encoding.EncodeToString(raw) != address {
+		return "", 0, ErrInvalidAddress
+	}
+
+	accountID, err = strkey.Encode(strkey.VersionByteAccountID, data[1:33])
+	if err != nil {
+		return "", 0, ErrInvalidAddress
+	}
+
+	id = binary.BigEndian.Uint64(data[33:])
+	return accountID, id, nil
+}
@@ -0,0 +1,54 @@
+package muxed
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const accountID = "GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ"
+
+func TestEncodeDecode(t *testing.T) {
+	tests := []struct {
+		id      uint64
+		address string
+	}{
+		{0, "MA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJUAAAAAAAAAAAACJUQ"},
+		{9223372036854775808, "MA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVAAAAAAAAAAAAAJLK"},
+	}
+
+	for _, test := range tests {
+		address, err := Encode(accountID, test.id)
+		assert.NoError(t, err)
+		assert.Equal(t, test.address, address)
+		assert.True(t, IsMuxed(address))
+
+		decodedAccountID, id, err := Decode(test.address)
+		assert.NoError(t, err)
+		assert.Equal(t, accountID, decodedAccountID)
+		assert.Equal(t, test.id, id)
+	}
+}
+
+func TestEncodeInvalidAccountID(t *testing.T) {
+	_, err := Encode("GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGA", 1)
+	assert.Equal(t, ErrInvalidAccountID, err)
+
+	_, err = Encode("SBU2RRGLXH3E5CQHTD3ODLDF2BWDCYUSSBLLZ5GNW7JXHDIYKXZWHOKR", 1)
+	assert.Equal(t, ErrInvalidAccountID, err)
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	tests := []string{
+		"",
+		accountID,
+		"MA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJUAAAAAAAAAAAACJUR",
+		"MA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJUAAAAAAAAAAAACJU",
+		"MA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJUAAAAAAAAAAAACJUQ===",
+	}
+
+	for _, address := range tests {
+		_, _, err := Decode(address)
+		assert.Equal(t, ErrInvalidAddress, err, address)
+	}
+}
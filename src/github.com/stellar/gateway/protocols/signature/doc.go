@@ -0,0 +1,11 @@
+// Package signature implements payload signature v2 of bridge server callbacks. It can be used by
+// callback receivers to verify requests.
+//
+// Requests are signed with every configured key (so keys can be rotated without downtime) and
+// the signature is sent in X-Payload-Signature header:
+//
+//	X-Payload-Signature: t=1500000000,v2=key-2:BASE64,v2=key-1:BASE64
+//
+// where `t` is a unix timestamp of the request and every `v2` element contains a key ID and
+// base64-encoded HMAC-SHA256 of `<t>.<key ID>.<raw request body>` computed using the key.
+package signature
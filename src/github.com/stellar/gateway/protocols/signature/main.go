@@ -0,0 +1,140 @@
+package signature
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stellar/go/strkey"
+)
+
+// Header is a name of the header containing payload signature
+const Header = "X-Payload-Signature"
+
+// DefaultTolerance is a maximum allowed difference between signature timestamp and current time
+const DefaultTolerance = 5 * time.Minute
+
+var (
+	// ErrNoSignature is returned when a header does not contain a signature made with any of keys
+	ErrNoSignature = errors.New("no signature made with known key")
+	// ErrInvalidHeader is returned when a header is malformed
+	ErrInvalidHeader = errors.New("invalid signature header")
+	// ErrExpired is returned when signature timestamp is outside of the tolerance
+	ErrExpired = errors.New("signature timestamp outside of tolerance")
+	// ErrInvalidSignature is returned when a signature does not match the payload
+	ErrInvalidSignature = errors.New("invalid signature")
+)
+
+// Key is a MAC key identified by ID
+type Key struct {
+	ID     string
+	Secret []byte
+}
+
+// ParseKey creates a Key from ID and a Stellar secret seed (S...). ID cannot contain `,`, `:`
+// and `=` characters.
+func ParseKey(id, seed string) (Key, error) {
+	if id == "" || strings.ContainsAny(id, ",:=") {
+		return Key{}, errors.New("key ID must be non-empty and cannot contain `,`, `:` and `=`")
+	}
+
+	secret, err := strkey.Decode(strkey.VersionByteSeed, seed)
+	if err != nil {
+		return Key{}, errors.New("invalid key")
+	}
+
+	return Key{ID: id, Secret: secret}, nil
+}
+
+// Sign returns a header value with signatures of body made at time t with every key
+func Sign(body []byte, t time.Time, keys ...Key) string {
+	timestamp := strconv.FormatInt(t.Unix(), 10)
+
+	elements := []string{"t=" + timestamp}
+	for _, key := range keys {
+		elements = append(elements, "v2="+key.ID+":"+base64.StdEncoding.EncodeToString(mac(key, timestamp, body)))
+	}
+	return strings.Join(elements, ",")
+}
+
+// Verify checks that header contains a valid signature of body made with one of keys and its
+// timestamp does not differ from now by more than tolerance.
+func Verify(header string, body []byte, now time.Time, tolerance time.Duration, keys ...Key) error {
+	var timestamp string
+	signatures := make(map[string][]byte)
+
+	for _, element := range strings.Split(header, ",") {
+		tokens := strings.SplitN(strings.TrimSpace(element), "=", 2)
+		if len(tokens) != 2 {
+			return ErrInvalidHeader
+		}
+
+		switch tokens[0] {
+		case "t":
+			timestamp = tokens[1]
+		case "v2":
+			value := strings.SplitN(tokens[1], ":", 2)
+			if len(value) != 2 {
+				return ErrInvalidHeader
+			}
+			signature, err := base64.StdEncoding.DecodeString(value[1])
+			if err != nil {
+				return ErrInvalidHeader
+			}
+			signatures[value[0]] = signature
+		}
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidHeader
+	}
+
+	diff := now.Sub(time.Unix(unix, 0))
+	if diff > tolerance || diff < -tolerance {
+		return ErrExpired
+	}
+
+	for _, key := range keys {
+		signature, ok := signatures[key.ID]
+		if !ok {
+			continue
+		}
+
+		if !hmac.Equal(signature, mac(key, timestamp, body)) {
+			return ErrInvalidSignature
+		}
+		return nil
+	}
+
+	return ErrNoSignature
+}
+
+// VerifyRequest reads body of r and verifies its signature using Verify with DefaultTolerance.
+// It returns the body so it can be decoded after verification.
+func VerifyRequest(r *http.Request, keys ...Key) ([]byte, error) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	header := r.Header.Get(Header)
+	if header == "" {
+		return nil, ErrNoSignature
+	}
+
+	return body, Verify(header, body, time.Now(), DefaultTolerance, keys...)
+}
+
+func mac(key Key, timestamp string, body []byte) []byte {
+	macer := hmac.New(sha256.New, key.Secret)
+	macer.Write([]byte(timestamp + "." + key.ID + "."))
+	macer.Write(body)
+	return macer.Sum(nil)
+}
@@ -0,0 +1,46 @@
+package signature
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	oldKey, err := ParseKey("2017-01", "SABLR5HOI2IUOYB27TR4TO7HWDJIGSRJTT4UUTXXZOFVVPGQKJ5ME43J")
+	require.NoError(t, err)
+	newKey, err := ParseKey("2017-06", "SBKKWO3ZVDDEHDJILGHPHCJCFD2GNUAYIUDMRAS326HLUEQ7ZFXWIGQK")
+	require.NoError(t, err)
+
+	body := []byte(`{"id":"1234"}`)
+	now := time.Unix(1500000000, 0)
+
+	header := Sign(body, now, newKey, oldKey)
+	assert.True(t, strings.HasPrefix(header, "t=1500000000,v2=2017-06:"))
+
+	// Receivers that know only one of the keys
+	assert.NoError(t, Verify(header, body, now, DefaultTolerance, oldKey))
+	assert.NoError(t, Verify(header, body, now.Add(time.Minute), DefaultTolerance, newKey))
+
+	assert.Equal(t, ErrInvalidSignature, Verify(header, []byte(`{"id":"4321"}`), now, DefaultTolerance, newKey))
+	assert.Equal(t, ErrExpired, Verify(header, body, now.Add(time.Hour), DefaultTolerance, newKey))
+	assert.Equal(t, ErrNoSignature, Verify(Sign(body, now, oldKey), body, now, DefaultTolerance, newKey))
+	assert.Equal(t, ErrInvalidHeader, Verify("t=now", body, now, DefaultTolerance, newKey))
+
+	// Key ID is signed
+	forged := strings.Replace(Sign(body, now, Key{ID: "2017-07", Secret: newKey.Secret}), "2017-07", "2017-06", 1)
+	assert.Equal(t, ErrInvalidSignature, Verify(forged, body, now, DefaultTolerance, newKey))
+
+	req := httptest.NewRequest("POST", "/receive", strings.NewReader(string(body)))
+	req.Header.Set(Header, Sign(body, time.Now(), newKey))
+	verified, err := VerifyRequest(req, oldKey, newKey)
+	require.NoError(t, err)
+	assert.Equal(t, body, verified)
+
+	_, err = ParseKey("a:b", "SBKKWO3ZVDDEHDJILGHPHCJCFD2GNUAYIUDMRAS326HLUEQ7ZFXWIGQK")
+	assert.Error(t, err)
+}
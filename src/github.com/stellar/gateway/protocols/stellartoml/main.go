@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/BurntSushi/toml"
 )
@@ -15,25 +17,81 @@ type ResolverInterface interface {
 	GetStellarTomlByAddress(address string) (stellarToml StellarToml, err error)
 }
 
-// Resolver resolves stellar.toml file
-type Resolver struct{}
+// HTTP represents an http client that a Resolver can use to fetch stellar.toml files
+type HTTP interface {
+	Do(req *http.Request) (resp *http.Response, err error)
+}
+
+// Resolver resolves stellar.toml file. Resolver created using NewResolver caches stellar.toml
+// files of every domain for TTL so AUTH_SERVER, FEDERATION_SERVER and SIGNING_KEY changes are
+// picked up without restarting. Zero value Resolver fetches stellar.toml on every call.
+// Errors are not cached.
+type Resolver struct {
+	ttl    time.Duration
+	client HTTP
+	now    func() time.Time
+
+	mutex sync.Mutex
+	cache map[string]cachedStellarToml
+}
+
+type cachedStellarToml struct {
+	stellarToml StellarToml
+	fetchedAt   time.Time
+}
+
+const (
+	defaultTTL     = 5 * time.Minute
+	requestTimeout = 10 * time.Second
+)
+
+// NewResolver creates a new caching Resolver. When ttl is 0 the default of 5 minutes is used.
+func NewResolver(ttl time.Duration) *Resolver {
+	if ttl == 0 {
+		ttl = defaultTTL
+	}
+
+	return &Resolver{
+		ttl:    ttl,
+		client: &http.Client{Timeout: requestTimeout},
+		now:    time.Now,
+		cache:  make(map[string]cachedStellarToml),
+	}
+}
+
+// StatusError is returned when stellar.toml response status code is not 200 OK
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("stellar.toml response status code indicates error (%d)", e.StatusCode)
+}
 
 // GetStellarToml returns stellar.toml file for a given domain
 func (r *Resolver) GetStellarToml(domain string) (stellarToml StellarToml, err error) {
-	var resp *http.Response
-	resp, err = http.Get("https://" + domain + "/.well-known/stellar.toml")
-	if err != nil {
-		return
+	if r.cache == nil {
+		return r.fetch(domain)
 	}
-	if resp.StatusCode != 200 {
-		err = fmt.Errorf(
-			"stellar.toml response status code indicates error (%d)",
-			resp.StatusCode,
-		)
+
+	domain = strings.ToLower(domain)
+
+	r.mutex.Lock()
+	cached, ok := r.cache[domain]
+	r.mutex.Unlock()
+
+	if ok && r.now().Sub(cached.fetchedAt) < r.ttl {
+		return cached.stellarToml, nil
+	}
+
+	stellarToml, err = r.fetch(domain)
+	if err != nil {
 		return
 	}
 
-	_, err = toml.DecodeReader(resp.Body, &stellarToml)
+	r.mutex.Lock()
+	r.cache[domain] = cachedStellarToml{stellarToml: stellarToml, fetchedAt: r.now()}
+	r.mutex.Unlock()
 	return
 }
 
@@ -47,3 +105,29 @@ func (r *Resolver) GetStellarTomlByAddress(address string) (stellarToml StellarT
 	}
 	return
 }
+
+func (r *Resolver) fetch(domain string) (stellarToml StellarToml, err error) {
+	client := r.client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req, err := http.NewRequest("GET", "https://"+domain+"/.well-known/stellar.toml", nil)
+	if err != nil {
+		return
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		err = &StatusError{StatusCode: resp.StatusCode}
+		return
+	}
+
+	_, err = toml.DecodeReader(resp.Body, &stellarToml)
+	return
+}
@@ -0,0 +1,69 @@
+package stellartoml
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingHTTP struct {
+	urls []string
+	body string
+	err  error
+}
+
+func (c *countingHTTP) Do(req *http.Request) (*http.Response, error) {
+	c.urls = append(c.urls, req.URL.String())
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &http.Response{StatusCode: 200, Body: ioutil.NopCloser(strings.NewReader(c.body))}, nil
+}
+
+func TestResolverCache(t *testing.T) {
+	client := &countingHTTP{body: `AUTH_SERVER = "https://acme.com/auth"
+SIGNING_KEY = "GBYJZW5XFAI6XV73H5SAIUYK6XZI4CGGVBUBO3ANA2SV7KKDAXTV6AEB"`}
+	now := time.Now()
+	resolver := NewResolver(time.Minute)
+	resolver.client = client
+	resolver.now = func() time.Time { return now }
+
+	stellarToml, err := resolver.GetStellarTomlByAddress("alice*acme.com")
+	require.NoError(t, err)
+	assert.Equal(t, "https://acme.com/auth", stellarToml.AuthServer)
+	assert.Equal(t, []string{"https://acme.com/.well-known/stellar.toml"}, client.urls)
+
+	// cached, domains are case-insensitive
+	_, err = resolver.GetStellarToml("ACME.com")
+	require.NoError(t, err)
+	assert.Len(t, client.urls, 1)
+
+	// other domain
+	_, err = resolver.GetStellarToml("example.com")
+	require.NoError(t, err)
+	assert.Len(t, client.urls, 2)
+
+	// expired
+	client.body = `AUTH_SERVER = "https://acme.com/auth2"`
+	now = now.Add(2 * time.Minute)
+	stellarToml, err = resolver.GetStellarToml("acme.com")
+	require.NoError(t, err)
+	assert.Equal(t, "https://acme.com/auth2", stellarToml.AuthServer)
+	assert.Len(t, client.urls, 3)
+
+	// errors are not cached
+	client.err = errors.New("unavailable")
+	now = now.Add(2 * time.Minute)
+	_, err = resolver.GetStellarToml("acme.com")
+	assert.Error(t, err)
+	client.err = nil
+	_, err = resolver.GetStellarToml("acme.com")
+	require.NoError(t, err)
+	assert.Len(t, client.urls, 5)
+}
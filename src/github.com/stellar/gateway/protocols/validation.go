@@ -1,7 +1,9 @@
 package protocols
 
 import (
-	"github.com/stellar/go-stellar-base/amount"
+	"errors"
+
+	"github.com/stellar/gateway/protocols/amount"
 	"github.com/stellar/go-stellar-base/keypair"
 )
 
@@ -30,3 +32,20 @@ func IsValidAmount(a string) bool {
 	}
 	return true
 }
+
+// ValidateAmount returns InvalidParameterError explaining why value of name param is not a valid
+// amount or nil if it is. When positive is true zero amount is not valid either.
+func ValidateAmount(name, value string, positive bool) error {
+	a, err := amount.Parse(value)
+	if err == nil && positive && a == 0 {
+		err = errors.New("amount must be greater than zero")
+	}
+
+	if err != nil {
+		errorResponse := NewInvalidParameterError(name, value, map[string]interface{}{"err": err})
+		errorResponse.Data["reason"] = err.Error()
+		return errorResponse
+	}
+
+	return nil
+}
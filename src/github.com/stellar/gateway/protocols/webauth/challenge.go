@@ -0,0 +1,171 @@
+package webauth
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"time"
+
+	b "github.com/stellar/go-stellar-base/build"
+	"github.com/stellar/go-stellar-base/keypair"
+	"github.com/stellar/go-stellar-base/xdr"
+)
+
+// ChallengeTimeout is a time a challenge transaction is valid for
+const ChallengeTimeout = 5 * time.Minute
+
+// nonceLength is a number of random bytes in a challenge. Base64-encoded nonce is 64 bytes long,
+// a maximum length of a data entry value.
+const nonceLength = 48
+
+// v1 envelopes (sent by newer SDKs) are binary compatible with the original envelope
+// format when their envelope type is removed
+const envelopeTypeTx = 2
+
+var (
+	// ErrInvalidChallenge is returned when a transaction is not a challenge created by the server
+	ErrInvalidChallenge = errors.New("invalid challenge transaction")
+	// ErrChallengeExpired is returned when a challenge transaction time bounds do not contain current time
+	ErrChallengeExpired = errors.New("challenge transaction expired")
+)
+
+// NewChallenge returns a base64-encoded challenge transaction for clientAccountID signed
+// by serverSeed
+func NewChallenge(serverSeed, clientAccountID, homeDomain, networkPassphrase string, now time.Time) (string, error) {
+	nonce := make([]byte, nonceLength)
+	_, err := rand.Read(nonce)
+	if err != nil {
+		return "", err
+	}
+
+	tx := b.Transaction(
+		b.SourceAccount{serverSeed},
+		b.Sequence{0},
+		b.Network{networkPassphrase},
+		b.SetData(
+			homeDomain+" auth",
+			[]byte(base64.StdEncoding.EncodeToString(nonce)),
+			b.SourceAccount{clientAccountID},
+		),
+	)
+	if tx.Err != nil {
+		return "", tx.Err
+	}
+
+	tx.TX.TimeBounds = &xdr.TimeBounds{
+		MinTime: xdr.Uint64(now.Unix()),
+		MaxTime: xdr.Uint64(now.Add(ChallengeTimeout).Unix()),
+	}
+
+	txe := tx.Sign(serverSeed)
+	return txe.Base64()
+}
+
+// Challenge is a challenge transaction sent back by a client
+type Challenge struct {
+	// ClientAccountID is an account authenticated by the challenge
+	ClientAccountID string
+
+	hash       [32]byte
+	signatures []xdr.DecoratedSignature
+}
+
+// ReadChallenge decodes a base64-encoded challenge transaction and checks that it has been
+// created and signed by serverAccountID for homeDomain and it has not expired
+func ReadChallenge(txeB64, serverAccountID, homeDomain, networkPassphrase string, now time.Time) (*Challenge, error) {
+	raw, err := base64.StdEncoding.DecodeString(txeB64)
+	if err != nil || len(raw) < 4 {
+		return nil, ErrInvalidChallenge
+	}
+	if binary.BigEndian.Uint32(raw) == envelopeTypeTx {
+		raw = raw[4:]
+	}
+
+	var envelope xdr.TransactionEnvelope
+	_, err = xdr.Unmarshal(bytes.NewReader(raw), &envelope)
+	if err != nil {
+		return nil, ErrInvalidChallenge
+	}
+
+	tx := envelope.Tx
+	if tx.SourceAccount.Address() != serverAccountID || tx.SeqNum != 0 || len(tx.Operations) != 1 {
+		return nil, ErrInvalidChallenge
+	}
+
+	op := tx.Operations[0]
+	if op.SourceAccount == nil || op.Body.ManageDataOp == nil {
+		return nil, ErrInvalidChallenge
+	}
+
+	data := op.Body.ManageDataOp
+	if string(data.DataName) != homeDomain+" auth" || data.DataValue == nil || len(*data.DataValue) != 64 {
+		return nil, ErrInvalidChallenge
+	}
+
+	if tx.TimeBounds == nil {
+		return nil, ErrInvalidChallenge
+	}
+	unix := xdr.Uint64(now.Unix())
+	if unix < tx.TimeBounds.MinTime || unix > tx.TimeBounds.MaxTime {
+		return nil, ErrChallengeExpired
+	}
+
+	hash, err := transactionHash(tx, networkPassphrase)
+	if err != nil {
+		return nil, ErrInvalidChallenge
+	}
+
+	challenge := &Challenge{
+		ClientAccountID: op.SourceAccount.Address(),
+		hash:            hash,
+		signatures:      envelope.Signatures,
+	}
+
+	if !challenge.SignedBy(serverAccountID) {
+		return nil, ErrInvalidChallenge
+	}
+
+	return challenge, nil
+}
+
+// Hash returns a hash of the challenge transaction
+func (c *Challenge) Hash() [32]byte {
+	return c.hash
+}
+
+// SignedBy returns true when the challenge contains a valid signature of accountID key
+func (c *Challenge) SignedBy(accountID string) bool {
+	kp, err := keypair.Parse(accountID)
+	if err != nil {
+		return false
+	}
+
+	hint := kp.Hint()
+	for _, sig := range c.signatures {
+		if sig.Hint == xdr.SignatureHint(hint) && kp.Verify(c.hash[:], sig.Signature) == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func transactionHash(tx xdr.Transaction, networkPassphrase string) (hash [32]byte, err error) {
+	var payload bytes.Buffer
+	networkID := sha256.Sum256([]byte(networkPassphrase))
+	payload.Write(networkID[:])
+
+	_, err = xdr.Marshal(&payload, xdr.EnvelopeTypeEnvelopeTypeTx)
+	if err != nil {
+		return
+	}
+
+	_, err = xdr.Marshal(&payload, tx)
+	if err != nil {
+		return
+	}
+
+	return sha256.Sum256(payload.Bytes()), nil
+}
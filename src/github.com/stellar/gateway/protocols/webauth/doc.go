@@ -0,0 +1,9 @@
+// Package webauth implements SEP-10 Stellar Web Authentication used by bridge server `/auth`
+// endpoint.
+//
+// A client requests a challenge transaction for its account. The challenge is signed by the
+// server account, has sequence number 0 (so it can never be submitted to the network) and
+// contains a single manage_data operation with the client account as a source, `<home domain>
+// auth` name and a random value. The client signs the challenge with its keys and sends it back.
+// When it's valid, the server issues a JWT (HS256) with the client account as a subject.
+package webauth
@@ -0,0 +1,88 @@
+package webauth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidToken is returned when a token is malformed or its signature is invalid
+	ErrInvalidToken = errors.New("invalid token")
+	// ErrTokenExpired is returned when a token has expired
+	ErrTokenExpired = errors.New("token expired")
+)
+
+// header of HS256 tokens
+var tokenHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// Claims are claims of tokens issued by the server
+type Claims struct {
+	// Issuer is a home domain of the server
+	Issuer string `json:"iss"`
+	// Subject is an authenticated account ID
+	Subject   string `json:"sub"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+	// ID is a hex-encoded hash of the challenge transaction
+	ID string `json:"jti,omitempty"`
+}
+
+// NewToken returns a JWT with claims signed using HMAC-SHA256 with key
+func NewToken(claims Claims, key []byte) (string, error) {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := tokenHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(sign(unsigned, key)), nil
+}
+
+// ParseToken verifies a token signed with key and returns its claims
+func ParseToken(token string, key []byte, now time.Time) (claims Claims, err error) {
+	err = DecodeToken(token, key, &claims)
+	if err != nil {
+		return
+	}
+
+	if now.Unix() >= claims.ExpiresAt {
+		err = ErrTokenExpired
+	}
+	return
+}
+
+// DecodeToken verifies a HS256 token signed with key and decodes its payload into claims.
+// Expiration is not checked.
+func DecodeToken(token string, key []byte, claims interface{}) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != tokenHeader {
+		return ErrInvalidToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(signature, sign(parts[0]+"."+parts[1], key)) {
+		return ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return ErrInvalidToken
+	}
+
+	err = json.Unmarshal(payload, claims)
+	if err != nil {
+		return ErrInvalidToken
+	}
+	return nil
+}
+
+func sign(unsigned string, key []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(unsigned))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,97 @@
+package webauth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stellar/go-stellar-base/keypair"
+	"github.com/stellar/go-stellar-base/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	serverSeed      = "SDZT3EJZ7FZRYNTLOZ7VH6G5UYBFO2IO3Q5PGONMILPCZU3AL7QNZHTE"
+	serverAccountID = "GCLOMB72ODBFUGK4E2BK7VMR3RNZ5WSTMEOGNA2YUVHFR3WMH2XBAB6H"
+	clientSeed      = "SDWTLFPALQSP225BSMX7HPZ7ZEAYSUYNDLJ5QI3YGVBNRUIIELWH3XUV"
+	passphrase      = "Test SDF Network ; September 2015"
+)
+
+func signChallenge(t *testing.T, txeB64, seed string) string {
+	var envelope xdr.TransactionEnvelope
+	require.NoError(t, xdr.SafeUnmarshalBase64(txeB64, &envelope))
+
+	hash, err := transactionHash(envelope.Tx, passphrase)
+	require.NoError(t, err)
+
+	kp, err := keypair.Parse(seed)
+	require.NoError(t, err)
+	sig, err := kp.SignDecorated(hash[:])
+	require.NoError(t, err)
+	envelope.Signatures = append(envelope.Signatures, sig)
+
+	signed, err := xdr.MarshalBase64(envelope)
+	require.NoError(t, err)
+	return signed
+}
+
+func TestChallenge(t *testing.T) {
+	client, err := keypair.Parse(clientSeed)
+	require.NoError(t, err)
+
+	now := time.Unix(1500000000, 0)
+	txeB64, err := NewChallenge(serverSeed, client.Address(), "example.com", passphrase, now)
+	require.NoError(t, err)
+
+	challenge, err := ReadChallenge(txeB64, serverAccountID, "example.com", passphrase, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, client.Address(), challenge.ClientAccountID)
+	assert.False(t, challenge.SignedBy(client.Address()))
+
+	challenge, err = ReadChallenge(signChallenge(t, txeB64, clientSeed), serverAccountID, "example.com", passphrase, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.True(t, challenge.SignedBy(client.Address()))
+
+	_, err = ReadChallenge(txeB64, serverAccountID, "example.com", passphrase, now.Add(ChallengeTimeout+time.Second))
+	assert.Equal(t, ErrChallengeExpired, err)
+
+	_, err = ReadChallenge(txeB64, serverAccountID, "other.com", passphrase, now)
+	assert.Equal(t, ErrInvalidChallenge, err)
+
+	_, err = ReadChallenge(txeB64, client.Address(), "example.com", passphrase, now)
+	assert.Equal(t, ErrInvalidChallenge, err)
+
+	// Challenge created by the client
+	forged, err := NewChallenge(clientSeed, client.Address(), "example.com", passphrase, now)
+	require.NoError(t, err)
+	_, err = ReadChallenge(forged, serverAccountID, "example.com", passphrase, now)
+	assert.Equal(t, ErrInvalidChallenge, err)
+}
+
+func TestToken(t *testing.T) {
+	key := []byte("secret")
+	now := time.Unix(1500000000, 0)
+	claims := Claims{
+		Issuer:    "https://example.com/auth",
+		Subject:   serverAccountID,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(time.Hour).Unix(),
+		ID:        "abcd",
+	}
+
+	token, err := NewToken(claims, key)
+	require.NoError(t, err)
+
+	parsed, err := ParseToken(token, key, now)
+	require.NoError(t, err)
+	assert.Equal(t, claims, parsed)
+
+	_, err = ParseToken(token, []byte("other"), now)
+	assert.Equal(t, ErrInvalidToken, err)
+
+	_, err = ParseToken(token, key, now.Add(time.Hour))
+	assert.Equal(t, ErrTokenExpired, err)
+
+	_, err = ParseToken("invalid", key, now)
+	assert.Equal(t, ErrInvalidToken, err)
+}
@@ -0,0 +1,202 @@
+// Package ratelimit limits requests of clients using token buckets kept in memory or in Redis
+// (so limits are shared by all instances of a server).
+package ratelimit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Limits reported by Limiter.Check
+const (
+	LimitIP     = "ip"
+	LimitAPIKey = "api_key"
+)
+
+// pruneInterval is an interval of removing full buckets from MemoryStore
+const pruneInterval = time.Minute
+
+// Config contains values of `rate_limit` config group of bridge and compliance servers
+type Config struct {
+	PerIP     Rule `mapstructure:"per_ip"`
+	PerAPIKey Rule `mapstructure:"per_api_key"`
+	// Endpoints are limited paths. Default endpoints are chosen by the server.
+	Endpoints []string
+	// RedisURL makes all instances of the server share limits using Redis, ex.
+	// `redis://:password@localhost:6379/0`. Buckets are kept in memory when empty.
+	RedisURL string `mapstructure:"redis_url"`
+	// TrustProxy makes client IP the first address of X-Forwarded-For header. It should be
+	// set only when the server is behind a proxy setting this header.
+	TrustProxy bool `mapstructure:"trust_proxy"`
+}
+
+// Rule allows RequestsPerMinute requests on average with up to Burst requests at once
+type Rule struct {
+	RequestsPerMinute int `mapstructure:"requests_per_minute"`
+	// Burst is a size of the bucket (default: RequestsPerMinute)
+	Burst int
+}
+
+// Enabled returns true when the rule limits requests
+func (r Rule) Enabled() bool {
+	return r.RequestsPerMinute > 0
+}
+
+func (r Rule) rate() float64 {
+	return float64(r.RequestsPerMinute) / 60
+}
+
+func (r Rule) burst() int {
+	if r.Burst > 0 {
+		return r.Burst
+	}
+	return r.RequestsPerMinute
+}
+
+// Enabled returns true when requests are limited
+func (c Config) Enabled() bool {
+	return c.PerIP.Enabled() || c.PerAPIKey.Enabled()
+}
+
+// Validate validates the config
+func (c Config) Validate() error {
+	if c.PerIP.RequestsPerMinute < 0 || c.PerIP.Burst < 0 {
+		return errors.New("rate_limit.per_ip params cannot be negative")
+	}
+
+	if c.PerAPIKey.RequestsPerMinute < 0 || c.PerAPIKey.Burst < 0 {
+		return errors.New("rate_limit.per_api_key params cannot be negative")
+	}
+
+	if c.RedisURL != "" {
+		_, err := NewRedisStore(c.RedisURL)
+		if err != nil {
+			return errors.New("Invalid rate_limit.redis_url param")
+		}
+	}
+	return nil
+}
+
+// NewLimiter creates a Limiter. prefix is added to keys of buckets so servers can share Redis.
+func (c Config) NewLimiter(prefix string) (*Limiter, error) {
+	var store Store = NewMemoryStore()
+	if c.RedisURL != "" {
+		redisStore, err := NewRedisStore(c.RedisURL)
+		if err != nil {
+			return nil, err
+		}
+		store = redisStore
+	}
+
+	return &Limiter{
+		PerIP:     c.PerIP,
+		PerAPIKey: c.PerAPIKey,
+		Store:     store,
+		Prefix:    prefix,
+		now:       time.Now,
+	}, nil
+}
+
+// Store keeps token buckets. Implementations must be safe for concurrent use.
+type Store interface {
+	// Take takes a token from the bucket of key refilled with rate tokens per second up to
+	// burst tokens. When the bucket is empty, retryAfter is a time until the next token.
+	Take(key string, rate float64, burst int, now time.Time) (allowed bool, retryAfter time.Duration, err error)
+}
+
+// Limiter limits requests of IP addresses and API keys
+type Limiter struct {
+	PerIP     Rule
+	PerAPIKey Rule
+	Store     Store
+	Prefix    string
+	now       func() time.Time
+}
+
+// Check takes a token from buckets of ip and apiKey (when not empty). When a limit is exceeded
+// it's returned (LimitIP or LimitAPIKey) with a time after which the request can be sent again.
+func (l *Limiter) Check(ip, apiKey string) (limit string, retryAfter time.Duration, err error) {
+	now := l.now()
+
+	if l.PerIP.Enabled() {
+		allowed, retryAfter, err := l.Store.Take(l.Prefix+"ip:"+ip, l.PerIP.rate(), l.PerIP.burst(), now)
+		if err != nil || !allowed {
+			return LimitIP, retryAfter, err
+		}
+	}
+
+	if l.PerAPIKey.Enabled() && apiKey != "" {
+		// API keys are secrets so they are not used as bucket keys
+		hash := sha256.Sum256([]byte(apiKey))
+		allowed, retryAfter, err := l.Store.Take(l.Prefix+"api_key:"+hex.EncodeToString(hash[:16]), l.PerAPIKey.rate(), l.PerAPIKey.burst(), now)
+		if err != nil || !allowed {
+			return LimitAPIKey, retryAfter, err
+		}
+	}
+
+	return "", 0, nil
+}
+
+// MemoryStore keeps buckets in memory. Full buckets are removed periodically.
+type MemoryStore struct {
+	mutex    sync.Mutex
+	buckets  map[string]*bucket
+	prunedAt time.Time
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	// fullAt is a time when the bucket is refilled
+	fullAt time.Time
+}
+
+// NewMemoryStore creates a new MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: make(map[string]*bucket)}
+}
+
+// Take implements Store
+func (s *MemoryStore) Take(key string, rate float64, burst int, now time.Time) (bool, time.Duration, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if now.Sub(s.prunedAt) >= pruneInterval {
+		for key, b := range s.buckets {
+			if !now.Before(b.fullAt) {
+				delete(s.buckets, key)
+			}
+		}
+		s.prunedAt = now
+	}
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(burst), updated: now}
+		s.buckets[key] = b
+	}
+
+	b.tokens += now.Sub(b.updated).Seconds() * rate
+	if b.tokens > float64(burst) {
+		b.tokens = float64(burst)
+	}
+	b.updated = now
+
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+	b.fullAt = now.Add(secondsDuration((float64(burst) - b.tokens) / rate))
+
+	if !allowed {
+		return false, secondsDuration((1 - b.tokens) / rate), nil
+	}
+	return true, 0, nil
+}
+
+func secondsDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
@@ -0,0 +1,143 @@
+package ratelimit
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore(t *testing.T) {
+	store := NewMemoryStore()
+	now := time.Unix(1500000000, 0)
+
+	// 2 requests at once, then 1 request per 30 seconds
+	for i := 0; i < 2; i++ {
+		allowed, _, err := store.Take("a", 2.0/60, 2, now)
+		require.NoError(t, err)
+		assert.True(t, allowed)
+	}
+
+	allowed, retryAfter, err := store.Take("a", 2.0/60, 2, now)
+	require.NoError(t, err)
+	assert.False(t, allowed)
+	assert.Equal(t, 30*time.Second, retryAfter)
+
+	// Other keys have their own buckets
+	allowed, _, _ = store.Take("b", 2.0/60, 2, now)
+	assert.True(t, allowed)
+
+	allowed, retryAfter, _ = store.Take("a", 2.0/60, 2, now.Add(20*time.Second))
+	assert.False(t, allowed)
+	assert.Equal(t, 10*time.Second, retryAfter)
+
+	allowed, _, _ = store.Take("a", 2.0/60, 2, now.Add(30*time.Second))
+	assert.True(t, allowed)
+
+	// Full buckets are removed
+	store.Take("c", 2.0/60, 2, now.Add(10*time.Minute))
+	assert.Len(t, store.buckets, 1)
+}
+
+func TestLimiter(t *testing.T) {
+	now := time.Unix(1500000000, 0)
+	limiter := &Limiter{
+		PerIP:     Rule{RequestsPerMinute: 3, Burst: 2},
+		PerAPIKey: Rule{RequestsPerMinute: 60, Burst: 1},
+		Store:     NewMemoryStore(),
+		Prefix:    "bridge:",
+		now:       func() time.Time { return now },
+	}
+
+	limit, _, err := limiter.Check("127.0.0.1", "key")
+	require.NoError(t, err)
+	assert.Equal(t, "", limit)
+
+	limit, retryAfter, err := limiter.Check("127.0.0.2", "key")
+	require.NoError(t, err)
+	assert.Equal(t, LimitAPIKey, limit)
+	assert.Equal(t, time.Second, retryAfter)
+
+	limit, _, _ = limiter.Check("127.0.0.1", "")
+	assert.Equal(t, "", limit)
+
+	limit, retryAfter, _ = limiter.Check("127.0.0.1", "")
+	assert.Equal(t, LimitIP, limit)
+	assert.Equal(t, 20*time.Second, retryAfter)
+
+	// API keys are hashed
+	for key := range limiter.Store.(*MemoryStore).buckets {
+		assert.NotEqual(t, "bridge:api_key:key", key)
+	}
+}
+
+func TestRedisStore(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	commands := make(chan []string, 10)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		reader := bufio.NewReader(conn)
+		replies := []string{"+OK\r\n", "+OK\r\n", "*2\r\n:1\r\n$3\r\n4.5\r\n", "*2\r\n:0\r\n$4\r\n0.25\r\n", "-ERR unknown command\r\n"}
+		for _, reply := range replies {
+			command, err := readReply(reader)
+			if err != nil {
+				return
+			}
+			var args []string
+			for _, arg := range command.([]interface{}) {
+				args = append(args, arg.(string))
+			}
+			commands <- args
+			conn.Write([]byte(reply))
+		}
+	}()
+
+	store, err := NewRedisStore("redis://:secret@" + listener.Addr().String() + "/2")
+	require.NoError(t, err)
+	now := time.Unix(1500000000, 0)
+
+	allowed, _, err := store.Take("bridge:ip:127.0.0.1", 0.5, 10, now)
+	require.NoError(t, err)
+	assert.True(t, allowed)
+	assert.Equal(t, []string{"AUTH", "secret"}, <-commands)
+	assert.Equal(t, []string{"SELECT", "2"}, <-commands)
+
+	eval := <-commands
+	assert.Equal(t, "EVAL", eval[0])
+	assert.Equal(t, []string{"1", "bridge:ip:127.0.0.1", "0.5", "10", "1500000000000"}, eval[2:])
+
+	allowed, retryAfter, err := store.Take("bridge:ip:127.0.0.1", 0.5, 10, now)
+	require.NoError(t, err)
+	assert.False(t, allowed)
+	assert.Equal(t, 1500*time.Millisecond, retryAfter)
+
+	_, _, err = store.Take("bridge:ip:127.0.0.1", 0.5, 10, now)
+	assert.EqualError(t, err, "redis: ERR unknown command")
+}
+
+func TestReadReply(t *testing.T) {
+	reply, err := readReply(bufio.NewReader(strings.NewReader("*3\r\n$-1\r\n-ERR x\r\n:7\r\n")))
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{nil, redisError("ERR x"), int64(7)}, reply)
+
+	_, err = readReply(bufio.NewReader(strings.NewReader("?\r\n")))
+	assert.Error(t, err)
+}
+
+func TestConfigValidate(t *testing.T) {
+	assert.NoError(t, Config{PerIP: Rule{RequestsPerMinute: 60}, RedisURL: "redis://localhost"}.Validate())
+	assert.Error(t, Config{PerIP: Rule{RequestsPerMinute: -1}}.Validate())
+	assert.Error(t, Config{RedisURL: "http://localhost:6379"}.Validate())
+}
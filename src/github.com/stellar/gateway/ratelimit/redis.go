@@ -0,0 +1,215 @@
+package ratelimit
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// redisTimeout is a timeout of connecting to Redis and of every command
+const redisTimeout = 5 * time.Second
+
+// takeScript atomically refills and takes a token from a bucket kept in a hash with `tokens`
+// and `updated` (milliseconds) fields. Buckets expire when they'd be full. It returns 1 when
+// the token has been taken and the number of tokens left.
+const takeScript = `
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'updated')
+local tokens = tonumber(bucket[1]) or burst
+local updated = tonumber(bucket[2]) or now
+tokens = math.min(burst, tokens + math.max(0, now - updated) / 1000 * rate)
+local allowed = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+end
+redis.call('HMSET', KEYS[1], 'tokens', tostring(tokens), 'updated', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil((burst - tokens) / rate * 1000) + 1)
+return {allowed, tostring(tokens)}
+`
+
+// RedisStore keeps buckets in Redis so limits are shared by all instances of a server. It
+// uses a single connection which is reopened after errors.
+type RedisStore struct {
+	address  string
+	password string
+	db       string
+
+	mutex  sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// redisError is an error reply of Redis
+type redisError string
+
+func (e redisError) Error() string {
+	return "redis: " + string(e)
+}
+
+// NewRedisStore creates a RedisStore using `redis://[:password@]host[:port][/db]` URL.
+// Connection is opened by the first Take.
+func NewRedisStore(redisURL string) (*RedisStore, error) {
+	u, err := url.Parse(redisURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "redis" || u.Hostname() == "" {
+		return nil, errors.New("redis URL must be redis://[:password@]host[:port][/db]")
+	}
+
+	store := &RedisStore{address: u.Host, db: strings.TrimPrefix(u.Path, "/")}
+	if u.Port() == "" {
+		store.address += ":6379"
+	}
+	if u.User != nil {
+		store.password, _ = u.User.Password()
+	}
+	return store, nil
+}
+
+// Take implements Store
+func (s *RedisStore) Take(key string, rate float64, burst int, now time.Time) (bool, time.Duration, error) {
+	reply, err := s.do(
+		"EVAL", takeScript, "1", key,
+		strconv.FormatFloat(rate, 'f', -1, 64),
+		strconv.Itoa(burst),
+		strconv.FormatInt(now.UnixNano()/int64(time.Millisecond), 10),
+	)
+	if err != nil {
+		return false, 0, err
+	}
+
+	values, ok := reply.([]interface{})
+	if !ok || len(values) != 2 {
+		return false, 0, fmt.Errorf("redis: unexpected reply %v", reply)
+	}
+	allowed, _ := values[0].(int64)
+	tokensString, _ := values[1].(string)
+	tokens, err := strconv.ParseFloat(tokensString, 64)
+	if err != nil {
+		return false, 0, fmt.Errorf("redis: unexpected reply %v", reply)
+	}
+
+	if allowed != 1 {
+		return false, secondsDuration((1 - tokens) / rate), nil
+	}
+	return true, 0, nil
+}
+
+// do sends a command and returns its reply: string, int64, []interface{}, nil or redisError
+func (s *RedisStore) do(args ...string) (interface{}, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.conn == nil {
+		err := s.connect()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	reply, err := s.command(args...)
+	if _, ok := err.(redisError); err != nil && !ok {
+		// Connection is in unknown state
+		s.conn.Close()
+		s.conn = nil
+	}
+	return reply, err
+}
+
+func (s *RedisStore) connect() error {
+	conn, err := net.DialTimeout("tcp", s.address, redisTimeout)
+	if err != nil {
+		return err
+	}
+	s.conn = conn
+	s.reader = bufio.NewReader(conn)
+
+	if s.password != "" {
+		_, err = s.command("AUTH", s.password)
+	}
+	if err == nil && s.db != "" && s.db != "0" {
+		_, err = s.command("SELECT", s.db)
+	}
+	if err != nil {
+		conn.Close()
+		s.conn = nil
+	}
+	return err
+}
+
+func (s *RedisStore) command(args ...string) (interface{}, error) {
+	s.conn.SetDeadline(time.Now().Add(redisTimeout))
+
+	var command strings.Builder
+	fmt.Fprintf(&command, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&command, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	_, err := io.WriteString(s.conn, command.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return readReply(s.reader)
+}
+
+// readReply reads a single RESP reply
+func readReply(r *bufio.Reader) (interface{}, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, errors.New("redis: empty reply")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, redisError(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		length, err := strconv.Atoi(line[1:])
+		if err != nil || length < 0 {
+			return nil, err
+		}
+		data := make([]byte, length+2)
+		_, err = io.ReadFull(r, data)
+		if err != nil {
+			return nil, err
+		}
+		return string(data[:length]), nil
+	case '*':
+		length, err := strconv.Atoi(line[1:])
+		if err != nil || length < 0 {
+			return nil, err
+		}
+		values := make([]interface{}, length)
+		for i := range values {
+			values[i], err = readReply(r)
+			if redisErr, ok := err.(redisError); ok {
+				// Error elements are returned as values to keep reading the array
+				values[i] = redisErr
+			} else if err != nil {
+				return nil, err
+			}
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("redis: unknown reply type %q", line[0])
+	}
+}
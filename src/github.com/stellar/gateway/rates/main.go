@@ -0,0 +1,158 @@
+// Package rates provides exchange rates used to convert received amounts to a fiat currency.
+package rates
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/stellar/go/support/errors"
+)
+
+// ProviderInterface helps mocking Provider
+type ProviderInterface interface {
+	// GetRate returns the price of a single unit of an asset in the provider's currency
+	GetRate(assetCode string) (rate string, err error)
+	// Currency returns the currency rates are quoted in
+	Currency() string
+}
+
+// HTTP represents an http client that a provider can use to make HTTP requests.
+type HTTP interface {
+	Do(req *http.Request) (resp *http.Response, err error)
+}
+
+// RateResponse is a response returned by a rates HTTP source
+type RateResponse struct {
+	Rate string `json:"rate"`
+}
+
+// HTTPProvider fetches exchange rates from an HTTP source and caches them for TTL.
+//
+// The source is queried with `GET <url>?asset_code=<code>&currency=<currency>` and must
+// respond with a JSON object containing a `rate` field. Native asset is queried as `XLM`.
+type HTTPProvider struct {
+	URL      string
+	currency string
+	ttl      time.Duration
+	client   HTTP
+	now      func() time.Time
+
+	mutex sync.Mutex
+	cache map[string]cachedRate
+}
+
+type cachedRate struct {
+	rate      string
+	fetchedAt time.Time
+}
+
+const (
+	defaultTTL     = 60 * time.Second
+	requestTimeout = 10 * time.Second
+)
+
+// NewHTTPProvider creates a new HTTPProvider. When ttl is 0 the default of 60 seconds is used.
+func NewHTTPProvider(sourceURL, currency string, ttl time.Duration) *HTTPProvider {
+	if ttl == 0 {
+		ttl = defaultTTL
+	}
+
+	return &HTTPProvider{
+		URL:      sourceURL,
+		currency: currency,
+		ttl:      ttl,
+		client:   &http.Client{Timeout: requestTimeout},
+		now:      time.Now,
+		cache:    make(map[string]cachedRate),
+	}
+}
+
+// Currency returns the currency rates are quoted in
+func (p *HTTPProvider) Currency() string {
+	return p.currency
+}
+
+// GetRate returns a cached rate or fetches a fresh one from the source
+func (p *HTTPProvider) GetRate(assetCode string) (string, error) {
+	if assetCode == "" {
+		assetCode = "XLM"
+	}
+
+	p.mutex.Lock()
+	cached, ok := p.cache[assetCode]
+	p.mutex.Unlock()
+
+	if ok && p.now().Sub(cached.fetchedAt) < p.ttl {
+		return cached.rate, nil
+	}
+
+	rate, err := p.fetch(assetCode)
+	if err != nil {
+		return "", err
+	}
+
+	p.mutex.Lock()
+	p.cache[assetCode] = cachedRate{rate: rate, fetchedAt: p.now()}
+	p.mutex.Unlock()
+
+	return rate, nil
+}
+
+func (p *HTTPProvider) fetch(assetCode string) (string, error) {
+	query := url.Values{}
+	query.Set("asset_code", assetCode)
+	query.Set("currency", p.currency)
+
+	req, err := http.NewRequest("GET", p.URL+"?"+query.Encode(), nil)
+	if err != nil {
+		return "", errors.Wrap(err, "configure http request failed")
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", errors.Wrap(err, "http request errored")
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", errors.Wrap(err, "reading response failed")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("rates source response status code indicates error (%d)", resp.StatusCode)
+	}
+
+	var response RateResponse
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return "", errors.Wrap(err, "cannot unmarshal rates source response")
+	}
+
+	if _, ok := new(big.Rat).SetString(response.Rate); !ok || response.Rate == "" {
+		return "", fmt.Errorf("invalid rate returned by rates source: %s", response.Rate)
+	}
+
+	return response.Rate, nil
+}
+
+// Convert multiplies amount by rate and returns the result with 7 decimal places
+func Convert(amount, rate string) (string, error) {
+	a, ok := new(big.Rat).SetString(amount)
+	if !ok {
+		return "", fmt.Errorf("invalid amount: %s", amount)
+	}
+
+	r, ok := new(big.Rat).SetString(rate)
+	if !ok {
+		return "", fmt.Errorf("invalid rate: %s", rate)
+	}
+
+	return new(big.Rat).Mul(a, r).FloatString(7), nil
+}
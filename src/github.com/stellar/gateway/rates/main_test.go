@@ -0,0 +1,71 @@
+package rates
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPProvider(t *testing.T) {
+	requests := 0
+	handler := http.NewServeMux()
+	handler.HandleFunc("/rates", func(w http.ResponseWriter, req *http.Request) {
+		requests++
+		assert.Equal(t, "EUR", req.URL.Query().Get("currency"))
+		switch req.URL.Query().Get("asset_code") {
+		case "USD":
+			w.Write([]byte(`{"rate": "0.9"}`))
+		case "XLM":
+			w.Write([]byte(`{"rate": "0.25"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	now := time.Now()
+	provider := NewHTTPProvider(srv.URL+"/rates", "EUR", time.Minute)
+	provider.now = func() time.Time { return now }
+
+	assert.Equal(t, "EUR", provider.Currency())
+
+	rate, err := provider.GetRate("USD")
+	require.NoError(t, err)
+	assert.Equal(t, "0.9", rate)
+	assert.Equal(t, 1, requests)
+
+	// cached
+	rate, err = provider.GetRate("USD")
+	require.NoError(t, err)
+	assert.Equal(t, "0.9", rate)
+	assert.Equal(t, 1, requests)
+
+	// expired
+	now = now.Add(2 * time.Minute)
+	_, err = provider.GetRate("USD")
+	require.NoError(t, err)
+	assert.Equal(t, 2, requests)
+
+	// native
+	rate, err = provider.GetRate("")
+	require.NoError(t, err)
+	assert.Equal(t, "0.25", rate)
+
+	_, err = provider.GetRate("GBP")
+	assert.Error(t, err)
+}
+
+func TestConvert(t *testing.T) {
+	converted, err := Convert("100.5", "0.9")
+	require.NoError(t, err)
+	assert.Equal(t, "90.4500000", converted)
+
+	_, err = Convert("abc", "0.9")
+	assert.Error(t, err)
+}
@@ -0,0 +1,257 @@
+// Package sandbox fabricates Stellar network behavior locally so the bridge server can be
+// used for development without network access.
+package sandbox
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/submitter"
+)
+
+// DefaultBalance is a native balance of every account in the sandbox
+const DefaultBalance = "10000.0000000"
+
+// Horizon implements horizon.HorizonInterface without connecting to a Horizon server. In sandbox:
+// - every account exists, with DefaultBalance and sequence number tracked locally,
+// - every submitted transaction succeeds instantly in a new ledger,
+// - payments streamed by StreamPayments (and returned by LoadOperation) are generated using ReceivePayment,
+// - StreamEffects never returns any effect.
+type Horizon struct {
+	networkPassphrase string
+	log               *logrus.Entry
+
+	mutex     sync.Mutex
+	sequences map[string]uint64
+	ledger    uint64
+	// ID of the last operation, used for IDs and paging tokens of generated payments
+	operationID uint64
+	streams     map[string]chan horizon.PaymentResponse
+	payments    map[string]horizon.PaymentResponse
+	// Ledgers of submitted transactions by hash
+	transactions map[string]uint64
+}
+
+var _ horizon.HorizonInterface = &Horizon{}
+
+// streamBuffer is a number of generated payments kept for an account until StreamPayments is called
+const streamBuffer = 100
+
+// NewHorizon creates a new sandbox Horizon
+func NewHorizon(networkPassphrase string) *Horizon {
+	return &Horizon{
+		networkPassphrase: networkPassphrase,
+		log:               logrus.WithFields(logrus.Fields{"service": "SandboxHorizon"}),
+		sequences:         make(map[string]uint64),
+		ledger:            1,
+		streams:           make(map[string]chan horizon.PaymentResponse),
+		payments:          make(map[string]horizon.PaymentResponse),
+		transactions:      make(map[string]uint64),
+	}
+}
+
+// LoadAccount returns an account with DefaultBalance
+func (h *Horizon) LoadAccount(ctx context.Context, accountID string) (response horizon.AccountResponse, err error) {
+	h.mutex.Lock()
+	sequence := h.sequences[accountID]
+	h.mutex.Unlock()
+
+	response = horizon.AccountResponse{
+		AccountID:      accountID,
+		SequenceNumber: strconv.FormatUint(sequence, 10),
+		Balances:       []horizon.Balance{{AssetType: "native", Balance: DefaultBalance}},
+		Signers:        []horizon.Signer{{Key: accountID, Weight: 1}},
+	}
+	return
+}
+
+// LoadMemo does nothing: memo of generated payments is already set
+func (h *Horizon) LoadMemo(ctx context.Context, p *horizon.PaymentResponse) (err error) {
+	return
+}
+
+// LoadOperation returns a payment generated using ReceivePayment
+func (h *Horizon) LoadOperation(ctx context.Context, operationID string) (payment horizon.PaymentResponse, err error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	payment, ok := h.payments[operationID]
+	if !ok {
+		err = errors.New("operation not found: " + operationID)
+	}
+	return
+}
+
+// LoadTransaction returns a transaction submitted using SubmitTransaction
+func (h *Horizon) LoadTransaction(ctx context.Context, hash string) (transaction horizon.TransactionResponse, err error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	ledger, ok := h.transactions[hash]
+	if !ok {
+		err = &horizon.StatusError{StatusCode: http.StatusNotFound, Body: []byte("transaction not found: " + hash)}
+		return
+	}
+
+	successful := true
+	transaction = horizon.TransactionResponse{Hash: hash, Ledger: ledger, Successful: &successful}
+	return
+}
+
+// StreamPayments calls onPaymentHandler with payments generated for accountID using
+// ReceivePayment. It returns only when onPaymentHandler returns horizon.ErrStopStreaming or
+// ctx is done.
+func (h *Horizon) StreamPayments(ctx context.Context, accountID string, cursor *string, onPaymentHandler horizon.PaymentHandler) (err error) {
+	payments := h.stream(accountID)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case payment := <-payments:
+			err = onPaymentHandler(payment)
+			if err == horizon.ErrStopStreaming {
+				return
+			} else if err != nil {
+				h.log.WithFields(logrus.Fields{"id": payment.ID, "err": err}).Error("Error from handler")
+			}
+		}
+	}
+}
+
+// LoadClaimableBalanceID always fails: claimable balances are not simulated
+func (h *Horizon) LoadClaimableBalanceID(ctx context.Context, p *horizon.PaymentResponse) (err error) {
+	return errors.New("claimable balances are not supported in sandbox mode")
+}
+
+// LoadFeeStats returns stats of ledgers without surge pricing: every fee is 100 stroops
+func (h *Horizon) LoadFeeStats(ctx context.Context) (response horizon.FeeStatsResponse, err error) {
+	response.LastLedgerBaseFee = "100"
+	response.FeeCharged = make(map[string]string)
+	for _, p := range []string{"min", "mode", "max", "p10", "p20", "p30", "p40", "p50", "p60", "p70", "p80", "p90", "p95", "p99"} {
+		response.FeeCharged[p] = "100"
+	}
+	return
+}
+
+// LoadRoot returns network passphrase of the sandbox and the ledger of the last submitted
+// transaction
+func (h *Horizon) LoadRoot(ctx context.Context) (response horizon.RootResponse, err error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	response.HorizonVersion = "sandbox"
+	response.NetworkPassphrase = h.networkPassphrase
+	response.HistoryLatestLedger = int32(h.ledger)
+	return
+}
+
+// FindPaths returns a direct path converting sourceAsset to destinationAsset 1:1: order books
+// are not simulated
+func (h *Horizon) FindPaths(ctx context.Context, sourceAsset, destinationAsset, destinationAmount string) (paths []horizon.PathResponse, err error) {
+	paths = []horizon.PathResponse{{SourceAmount: destinationAmount, DestinationAmount: destinationAmount, Path: []horizon.PathAsset{}}}
+	return
+}
+
+// LoadAsset returns an asset of an issuing account without authorization flags
+func (h *Horizon) LoadAsset(ctx context.Context, assetCode, assetIssuer string) (response horizon.AssetResponse, err error) {
+	response = horizon.AssetResponse{
+		AssetType:   "credit_alphanum4",
+		AssetCode:   assetCode,
+		AssetIssuer: assetIssuer,
+	}
+	if len(assetCode) > 4 {
+		response.AssetType = "credit_alphanum12"
+	}
+	return
+}
+
+// LoadOrderBook returns an order book converting assets 1:1
+func (h *Horizon) LoadOrderBook(ctx context.Context, sellingAsset, buyingAsset string) (response horizon.OrderBookResponse, err error) {
+	response.Bids = []horizon.OrderBookEntry{{Price: "1.0000000", Amount: "922337203685.4775807"}}
+	response.Asks = []horizon.OrderBookEntry{{Price: "1.0000000", Amount: "922337203685.4775807"}}
+	return
+}
+
+// StreamOperations streams payments generated using ReceivePayment, like StreamPayments.
+func (h *Horizon) StreamOperations(ctx context.Context, accountID string, cursor *string, onPaymentHandler horizon.PaymentHandler) (err error) {
+	return h.StreamPayments(ctx, accountID, cursor, onPaymentHandler)
+}
+
+// StreamEffects never returns any effect. It returns only when ctx is done.
+func (h *Horizon) StreamEffects(ctx context.Context, cursor *string, onEffectHandler horizon.EffectHandler) (err error) {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+// SubmitTransaction bumps sequence number of the transaction source account and returns a success response
+func (h *Horizon) SubmitTransaction(ctx context.Context, txeBase64 string) (response horizon.SubmitTransactionResponse, err error) {
+	// Envelopes with operations unknown to go-stellar-base (ex. sponsored reserves) are
+	// read without decoding operations
+	source, sequence, hash, err := submitter.ParseRawEnvelope(txeBase64, h.networkPassphrase)
+	if err != nil {
+		return
+	}
+
+	response.Hash = hex.EncodeToString(hash[:])
+
+	h.mutex.Lock()
+	h.sequences[source] = sequence
+	h.ledger++
+	ledger := h.ledger
+	h.transactions[response.Hash] = ledger
+	h.mutex.Unlock()
+
+	response.Ledger = &ledger
+
+	h.log.WithFields(logrus.Fields{"hash": response.Hash, "ledger": ledger}).Info("Transaction accepted")
+	return
+}
+
+// ReceivePayment generates a payment to `payment.To` account. ID, paging token and type
+// of the payment are set by the sandbox. Payment is returned with these fields set.
+func (h *Horizon) ReceivePayment(payment horizon.PaymentResponse) (horizon.PaymentResponse, error) {
+	h.mutex.Lock()
+	h.operationID++
+	h.ledger++
+	payment.ID = strconv.FormatUint(h.operationID, 10)
+	h.mutex.Unlock()
+
+	payment.PagingToken = payment.ID
+	payment.Type = "payment"
+	if payment.AssetCode == "" {
+		payment.AssetType = "native"
+	} else if len(payment.AssetCode) <= 4 {
+		payment.AssetType = "credit_alphanum4"
+	} else {
+		payment.AssetType = "credit_alphanum12"
+	}
+
+	h.mutex.Lock()
+	h.payments[payment.ID] = payment
+	h.mutex.Unlock()
+
+	select {
+	case h.stream(payment.To) <- payment:
+		return payment, nil
+	default:
+		return payment, errors.New("too many payments waiting to be streamed for " + payment.To)
+	}
+}
+
+// stream returns a channel of generated payments of a given account
+func (h *Horizon) stream(accountID string) chan horizon.PaymentResponse {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	stream, ok := h.streams[accountID]
+	if !ok {
+		stream = make(chan horizon.PaymentResponse, streamBuffer)
+		h.streams[accountID] = stream
+	}
+	return stream
+}
@@ -0,0 +1,72 @@
+package sandbox
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stellar/gateway/horizon"
+	b "github.com/stellar/go-stellar-base/build"
+	"github.com/stellar/go-stellar-base/keypair"
+	"github.com/stellar/go-stellar-base/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubmitTransaction(t *testing.T) {
+	h := NewHorizon(network.TestNetworkPassphrase)
+	source, err := keypair.Random()
+	require.NoError(t, err)
+
+	account, err := h.LoadAccount(context.Background(), source.Address())
+	require.NoError(t, err)
+	assert.Equal(t, "0", account.SequenceNumber)
+	assert.Equal(t, DefaultBalance, account.NativeBalance())
+
+	tx := b.Transaction(
+		b.SourceAccount{source.Seed()},
+		b.Sequence{1},
+		b.Network{network.TestNetworkPassphrase},
+		b.Payment(
+			b.Destination{source.Address()},
+			b.NativeAmount{"10"},
+		),
+	)
+	require.NoError(t, tx.Err)
+	envelope := tx.Sign(source.Seed())
+	txe, err := envelope.Base64()
+	require.NoError(t, err)
+
+	response, err := h.SubmitTransaction(context.Background(), txe)
+	require.NoError(t, err)
+	expectedHash, err := tx.HashHex()
+	require.NoError(t, err)
+	assert.Equal(t, expectedHash, response.Hash)
+	require.NotNil(t, response.Ledger)
+
+	account, err = h.LoadAccount(context.Background(), source.Address())
+	require.NoError(t, err)
+	assert.Equal(t, "1", account.SequenceNumber)
+}
+
+func TestReceivePayment(t *testing.T) {
+	h := NewHorizon(network.TestNetworkPassphrase)
+
+	generated, err := h.ReceivePayment(horizon.PaymentResponse{To: "GA", Amount: "5", AssetCode: "USD", AssetIssuer: "GB"})
+	require.NoError(t, err)
+	assert.Equal(t, "1", generated.ID)
+	assert.Equal(t, "credit_alphanum4", generated.AssetType)
+
+	received := make(chan horizon.PaymentResponse)
+	go h.StreamPayments(context.Background(), "GA", nil, func(payment horizon.PaymentResponse) error {
+		received <- payment
+		return nil
+	})
+
+	select {
+	case payment := <-received:
+		assert.Equal(t, generated, payment)
+	case <-time.After(time.Second):
+		t.Fatal("payment not streamed")
+	}
+}
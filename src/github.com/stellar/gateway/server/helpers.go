@@ -2,6 +2,7 @@ package server
 
 import (
 	"net/http"
+	"strings"
 )
 
 // Response represents response that can be returned by a server
@@ -17,3 +18,19 @@ func Write(w http.ResponseWriter, response Response) {
 	}
 	w.Write(response.Marshal())
 }
+
+// CSVResponse represents response that can be returned as CSV
+type CSVResponse interface {
+	MarshalCSV() []byte
+}
+
+// AcceptsCSV returns true when the client requested CSV in Accept header
+func AcceptsCSV(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), "text/csv")
+}
+
+// WriteCSV writes a CSV response to the given http.ResponseWriter
+func WriteCSV(w http.ResponseWriter, response CSVResponse) {
+	w.Header().Set("Content-Type", "text/csv")
+	w.Write(response.MarshalCSV())
+}
@@ -1,7 +1,18 @@
 package server
 
 import (
+	"errors"
+	"math"
+	"net"
 	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/metrics"
+	"github.com/stellar/gateway/protocols"
+	"github.com/stellar/gateway/ratelimit"
+	"github.com/stellar/gateway/tracing"
 )
 
 // StripTrailingSlashMiddleware strips trailing slash.
@@ -39,7 +50,7 @@ func HeadersMiddleware() func(next http.Handler) http.Handler {
 func APIKeyMiddleware(apiKey string) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		fn := func(w http.ResponseWriter, r *http.Request) {
-			k := r.PostFormValue("apiKey")
+			k := r.FormValue("apiKey")
 			if k != apiKey {
 				http.Error(w, "Forbidden", http.StatusForbidden)
 				return
@@ -49,3 +60,193 @@ func APIKeyMiddleware(apiKey string) func(next http.Handler) http.Handler {
 		return http.HandlerFunc(fn)
 	}
 }
+
+// TenantMiddleware selects a tenant for a request. Requests containing apiKey of one of
+// the tenants are routed to `/tenants/<name>` endpoints. Requests sent directly to
+// `/tenants/<name>` endpoints must contain tenant's apiKey if it's set and all other
+// requests must contain defaultAPIKey if it's set. Otherwise http.StatusForbidden is written.
+func TenantMiddleware(defaultAPIKey string, tenantAPIKeys map[string]string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			k := r.FormValue("apiKey")
+
+			if strings.HasPrefix(r.URL.Path, "/tenants/") {
+				name := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/tenants/"), "/", 2)[0]
+				if tenantAPIKeys[name] != "" && k != tenantAPIKeys[name] {
+					http.Error(w, "Forbidden", http.StatusForbidden)
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			for name, tenantAPIKey := range tenantAPIKeys {
+				if tenantAPIKey != "" && k == tenantAPIKey {
+					r.URL.Path = "/tenants/" + name + r.URL.Path
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+
+			if defaultAPIKey != "" && k != defaultAPIKey {
+				http.Error(w, "Forbidden", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		}
+		return http.HandlerFunc(fn)
+	}
+}
+
+// ExceptPathsMiddleware calls middleware for all requests except requests to paths. Paths
+// ending with `/` match all paths starting with them.
+func ExceptPathsMiddleware(middleware func(next http.Handler) http.Handler, paths ...string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		wrapped := middleware(next)
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			for _, path := range paths {
+				if r.URL.Path == path || (strings.HasSuffix(path, "/") && strings.HasPrefix(r.URL.Path, path)) {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+			wrapped.ServeHTTP(w, r)
+		}
+		return http.HandlerFunc(fn)
+	}
+}
+
+// BearerTokenMiddleware checks a token in `Authorization: Bearer <token>` header of requests
+// to paths (also `/tenants/<name>` prefixed) using verify and writes http.StatusUnauthorized
+// if it's missing or incorrect.
+func BearerTokenMiddleware(paths []string, verify func(token string) error) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			path := withoutTenant(r.URL.Path)
+			for _, protected := range paths {
+				if path != protected {
+					continue
+				}
+
+				token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+				if token == "" || verify(token) != nil {
+					http.Error(w, "Unauthorized", http.StatusUnauthorized)
+					return
+				}
+				break
+			}
+			next.ServeHTTP(w, r)
+		}
+		return http.HandlerFunc(fn)
+	}
+}
+
+// TracingMiddleware records a server span of every request when tracing is enabled. The span
+// continues a trace of the client when the request contains `traceparent` header and can be read
+// using tracing.FromRequest. Responses with 5xx status codes are recorded as errors.
+func TracingMiddleware() func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			span := tracing.StartFromHeader(r.Method+" "+r.URL.Path, r.Header)
+			if span == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			span.SetAttribute("http.method", r.Method)
+			span.SetAttribute("http.target", r.URL.Path)
+			if id := RequestID(r); id != "" {
+				span.SetAttribute("request_id", id)
+			}
+
+			sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(sw, r.WithContext(tracing.ContextWithSpan(r.Context(), span)))
+
+			span.SetAttribute("http.status_code", sw.status)
+			var err error
+			if sw.status >= http.StatusInternalServerError {
+				err = errors.New(http.StatusText(sw.status))
+			}
+			span.End(err)
+		}
+		return http.HandlerFunc(fn)
+	}
+}
+
+// statusWriter remembers status code of a response
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// RateLimitMiddleware limits requests to paths (also `/tenants/<name>` prefixed) using limiter
+// and writes http.StatusTooManyRequests when a limit is exceeded. Client IP is the first address
+// of X-Forwarded-For header when trustProxy is true. Requests are allowed when limiter fails.
+func RateLimitMiddleware(paths []string, limiter *ratelimit.Limiter, trustProxy bool) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			path := withoutTenant(r.URL.Path)
+			limited := false
+			for _, limitedPath := range paths {
+				if path == limitedPath {
+					limited = true
+					break
+				}
+			}
+			if !limited {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ip := clientIP(r, trustProxy)
+			limit, retryAfter, err := limiter.Check(ip, r.FormValue("apiKey"))
+			if err != nil {
+				Logger(r).WithFields(logrus.Fields{"err": err}).Error("Error checking rate limit")
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if limit != "" {
+				metrics.AddCounter("rate_limited_requests", 1, metrics.Tags{"limit": limit})
+				seconds := int(math.Ceil(retryAfter.Seconds()))
+				Logger(r).WithFields(logrus.Fields{"ip": ip, "limit": limit, "path": r.URL.Path}).Info("Rate limit exceeded")
+				w.Header().Set("Retry-After", strconv.Itoa(seconds))
+				Write(w, protocols.NewRateLimitExceededError(limit, seconds))
+				return
+			}
+			next.ServeHTTP(w, r)
+		}
+		return http.HandlerFunc(fn)
+	}
+}
+
+// withoutTenant returns path without `/tenants/<name>` prefix
+func withoutTenant(path string) string {
+	if strings.HasPrefix(path, "/tenants/") {
+		parts := strings.SplitN(strings.TrimPrefix(path, "/tenants/"), "/", 2)
+		if len(parts) == 2 {
+			return "/" + parts[1]
+		}
+	}
+	return path
+}
+
+// clientIP returns IP address of a client sending r
+func clientIP(r *http.Request, trustProxy bool) string {
+	if trustProxy {
+		if forwardedFor := r.Header.Get("X-Forwarded-For"); forwardedFor != "" {
+			return strings.TrimSpace(strings.Split(forwardedFor, ",")[0])
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,167 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stellar/gateway/ratelimit"
+	"github.com/stellar/gateway/tracing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTenantMiddleware(t *testing.T) {
+	var path string
+	handler := TenantMiddleware("default-api-key-value", map[string]string{
+		"acme":  "acme-api-key-value",
+		"other": "",
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+	}))
+
+	tests := []struct {
+		url    string
+		status int
+		path   string
+	}{
+		{"/payment?apiKey=default-api-key-value", http.StatusOK, "/payment"},
+		{"/payment?apiKey=acme-api-key-value", http.StatusOK, "/tenants/acme/payment"},
+		{"/payment?apiKey=wrong", http.StatusForbidden, ""},
+		{"/payment", http.StatusForbidden, ""},
+		{"/tenants/acme/payment?apiKey=acme-api-key-value", http.StatusOK, "/tenants/acme/payment"},
+		{"/tenants/acme/payment?apiKey=default-api-key-value", http.StatusForbidden, ""},
+		{"/tenants/other/payment", http.StatusOK, "/tenants/other/payment"},
+	}
+
+	for _, test := range tests {
+		path = ""
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", test.url, nil)
+		handler.ServeHTTP(w, r)
+
+		assert.Equal(t, test.status, w.Code, test.url)
+		assert.Equal(t, test.path, path, test.url)
+	}
+}
+
+func TestBearerTokenMiddleware(t *testing.T) {
+	handler := BearerTokenMiddleware([]string{"/payment"}, func(token string) error {
+		if token != "valid" {
+			return errors.New("invalid token")
+		}
+		return nil
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	tests := []struct {
+		url           string
+		authorization string
+		status        int
+	}{
+		{"/payment", "Bearer valid", http.StatusOK},
+		{"/payment", "Bearer invalid", http.StatusUnauthorized},
+		{"/payment", "", http.StatusUnauthorized},
+		{"/tenants/acme/payment", "", http.StatusUnauthorized},
+		{"/tenants/acme/payment", "Bearer valid", http.StatusOK},
+		{"/builder", "", http.StatusOK},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", test.url, nil)
+		r.Header.Set("Authorization", test.authorization)
+		handler.ServeHTTP(w, r)
+
+		assert.Equal(t, test.status, w.Code, test.url)
+	}
+}
+
+func TestRequestIDMiddleware(t *testing.T) {
+	var id string
+	var logged interface{}
+	handler := RequestIDMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id = RequestID(r)
+		logged = Logger(r).Data["request_id"]
+	}))
+
+	tests := []struct {
+		header    string
+		generated bool
+	}{
+		{"abc-123", false},
+		{"", true},
+		{"with space", true},
+		{strings.Repeat("a", 129), true},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/payment", nil)
+		r.Header.Set(RequestIDHeader, test.header)
+		handler.ServeHTTP(w, r)
+
+		if test.generated {
+			assert.Len(t, id, 32, test.header)
+		} else {
+			assert.Equal(t, test.header, id)
+		}
+		assert.Equal(t, id, w.Header().Get(RequestIDHeader))
+		assert.Equal(t, id, logged)
+	}
+
+	r, _ := http.NewRequest("GET", "/payment", nil)
+	assert.Equal(t, "", RequestID(r))
+	assert.NotContains(t, Logger(r).Data, "request_id")
+}
+
+func TestTracingMiddleware(t *testing.T) {
+	tracing.Default = tracing.NewTracer("bridge", "http://localhost/v1/traces", http.DefaultClient)
+	defer func() { tracing.Default = nil }()
+
+	var traceID interface{}
+	handler := RequestIDMiddleware()(TracingMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NotNil(t, tracing.FromRequest(r))
+		traceID = Logger(r).Data["trace_id"]
+		w.WriteHeader(http.StatusInternalServerError)
+	})))
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("POST", "/payment", nil)
+	r.Header.Set(tracing.TraceparentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	handler.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", traceID)
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	limiter, err := ratelimit.Config{PerIP: ratelimit.Rule{RequestsPerMinute: 1}}.NewLimiter("test:")
+	assert.NoError(t, err)
+	handler := RateLimitMiddleware([]string{"/payment"}, limiter, true)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	tests := []struct {
+		url          string
+		forwardedFor string
+		status       int
+	}{
+		{"/payment", "", http.StatusOK},
+		{"/tenants/acme/payment", "", http.StatusTooManyRequests},
+		{"/payment", "10.0.0.1, 127.0.0.1", http.StatusOK},
+		{"/payment", "10.0.0.1", http.StatusTooManyRequests},
+		{"/builder", "", http.StatusOK},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", test.url, nil)
+		r.RemoteAddr = "127.0.0.1:5000"
+		r.Header.Set("X-Forwarded-For", test.forwardedFor)
+		handler.ServeHTTP(w, r)
+
+		assert.Equal(t, test.status, w.Code, test.url)
+		if test.status == http.StatusTooManyRequests {
+			assert.Equal(t, "60", w.Header().Get("Retry-After"))
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package server
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+)
+
+// RecentError is a message logged with error level (or above)
+type RecentError struct {
+	Time    time.Time         `json:"time"`
+	Level   string            `json:"level"`
+	Message string            `json:"message"`
+	Fields  map[string]string `json:"fields,omitempty"`
+}
+
+// RecentErrors is a logrus hook keeping the last Size messages logged with error level
+// (or above) so they can be returned by status endpoints
+type RecentErrors struct {
+	Size int
+
+	mutex  sync.Mutex
+	errors []RecentError
+}
+
+var _ logrus.Hook = &RecentErrors{}
+
+// Levels returns levels of messages kept by the hook
+func (h *RecentErrors) Levels() []logrus.Level {
+	return []logrus.Level{logrus.ErrorLevel, logrus.FatalLevel, logrus.PanicLevel}
+}
+
+// Fire saves a logged message. The oldest message is removed when Size is reached.
+func (h *RecentErrors) Fire(entry *logrus.Entry) error {
+	recent := RecentError{
+		Time:    entry.Time,
+		Level:   entry.Level.String(),
+		Message: entry.Message,
+	}
+	if len(entry.Data) > 0 {
+		recent.Fields = make(map[string]string, len(entry.Data))
+		for name, value := range entry.Data {
+			recent.Fields[name] = fmt.Sprint(value)
+		}
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.errors = append(h.errors, recent)
+	if len(h.errors) > h.Size {
+		h.errors = h.errors[len(h.errors)-h.Size:]
+	}
+	return nil
+}
+
+// Errors returns kept messages, newest first
+func (h *RecentErrors) Errors() []RecentError {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	errors := make([]RecentError, len(h.errors))
+	for i, recent := range h.errors {
+		errors[len(h.errors)-1-i] = recent
+	}
+	return errors
+}
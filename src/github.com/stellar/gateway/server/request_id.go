@@ -0,0 +1,79 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/tracing"
+)
+
+// RequestIDHeader is a header containing ID of a request. It's sent back in responses and
+// passed to other servers, ex. bridge server sends it to compliance server.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength is the maximum length of request IDs accepted from clients
+const maxRequestIDLength = 128
+
+type requestIDKey struct{}
+
+// RequestIDMiddleware assigns an ID to every request. ID sent by a client in X-Request-ID header
+// is used if it's valid, otherwise a random ID is generated. ID is sent in X-Request-ID
+// response header and can be read using RequestID.
+func RequestIDMiddleware() func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get(RequestIDHeader)
+			if !validRequestID(id) {
+				id = newRequestID()
+			}
+
+			w.Header().Set(RequestIDHeader, id)
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), requestIDKey{}, id)))
+		}
+		return http.HandlerFunc(fn)
+	}
+}
+
+// RequestID returns ID of a request assigned by RequestIDMiddleware or empty string
+func RequestID(r *http.Request) string {
+	id, _ := r.Context().Value(requestIDKey{}).(string)
+	return id
+}
+
+// Logger returns a logger adding `request_id` (and `trace_id` when tracing is enabled) field
+// to all entries logged while handling r
+func Logger(r *http.Request) *logrus.Entry {
+	entry := logrus.NewEntry(logrus.StandardLogger())
+	if id := RequestID(r); id != "" {
+		entry = entry.WithField("request_id", id)
+	}
+	if traceID := tracing.FromRequest(r).TraceID(); traceID != "" {
+		entry = entry.WithField("trace_id", traceID)
+	}
+	return entry
+}
+
+func newRequestID() string {
+	id := make([]byte, 16)
+	_, err := rand.Read(id)
+	if err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(id)
+}
+
+// validRequestID returns true when id is not empty and contains only printable ASCII characters
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
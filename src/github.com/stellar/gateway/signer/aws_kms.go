@@ -0,0 +1,120 @@
+package signer
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/signer/v4"
+)
+
+// awsKMS signs using ECC_NIST_EDWARDS25519 keys of AWS KMS. Requests to KMS JSON API are
+// signed using AWS Signature Version 4.
+type awsKMS struct {
+	endpoint      string
+	region        string
+	requestSigner *v4.Signer
+	client        *http.Client
+}
+
+func newAWSKMS(c AWSKMSConfig) *awsKMS {
+	endpoint := c.Endpoint
+	if endpoint == "" {
+		endpoint = "https://kms." + c.Region + ".amazonaws.com"
+	}
+
+	return &awsKMS{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		region:   c.Region,
+		requestSigner: v4.NewSigner(credentials.NewChainCredentials([]credentials.Provider{
+			&credentials.EnvProvider{},
+			&credentials.SharedCredentialsProvider{Profile: c.Profile},
+		})),
+		client: &http.Client{Timeout: requestTimeout},
+	}
+}
+
+func (k *awsKMS) signer(keyID string) (Signer, error) {
+	var response struct {
+		PublicKey []byte
+		KeySpec   string
+	}
+
+	err := k.do("GetPublicKey", map[string]interface{}{"KeyId": keyID}, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.KeySpec != "ECC_NIST_EDWARDS25519" {
+		return nil, fmt.Errorf("KMS key %s is not an ECC_NIST_EDWARDS25519 key", keyID)
+	}
+
+	publicKey, err := x509.ParsePKIXPublicKey(response.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	ed25519PublicKey, ok := publicKey.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("KMS key %s is not an ed25519 key", keyID)
+	}
+
+	return newRemoteSigner(ed25519PublicKey, func(input []byte) ([]byte, error) {
+		var response struct {
+			Signature []byte
+		}
+
+		err := k.do("Sign", map[string]interface{}{
+			"KeyId":            keyID,
+			"Message":          input,
+			"MessageType":      "RAW",
+			"SigningAlgorithm": "ED25519_SHA_512",
+		}, &response)
+		return response.Signature, err
+	})
+}
+
+// do calls action of KMS API. []byte fields of request and response are base64-encoded
+// like blobs of the API.
+func (k *awsKMS) do(action string, request, response interface{}) error {
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	body := bytes.NewReader(payload)
+	req, err := http.NewRequest("POST", k.endpoint+"/", body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "TrentService."+action)
+
+	_, err = k.requestSigner.Sign(req, body, "kms", k.region, time.Now())
+	if err != nil {
+		return err
+	}
+
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("KMS %s error (%d): %s", action, resp.StatusCode, content)
+	}
+	return json.Unmarshal(content, response)
+}
@@ -0,0 +1,247 @@
+package signer
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/stellar/go-stellar-base/keypair"
+	"github.com/stellar/go-stellar-base/xdr"
+)
+
+// keystoreIterations is a number of PBKDF2-HMAC-SHA256 iterations of new keystores
+const keystoreIterations = 600000
+
+// ErrInvalidPassphrase is returned when the keystore cannot be decrypted using a passphrase
+var ErrInvalidPassphrase = errors.New("invalid keystore passphrase")
+
+// keystoreFile is a JSON keystore file. Secret seeds are encrypted using AES-256-GCM with
+// a key derived from the passphrase, addresses are stored in plaintext so accounts can be
+// loaded before the keystore is unlocked.
+type keystoreFile struct {
+	Salt       []byte        `json:"salt"`
+	Iterations int           `json:"iterations"`
+	Keys       []keystoreKey `json:"keys"`
+}
+
+type keystoreKey struct {
+	Name       string `json:"name"`
+	Address    string `json:"address"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// Keystore is a file of encrypted secret seeds. It's locked until Unlock is called with
+// a passphrase of the keystore.
+type Keystore struct {
+	data  keystoreFile
+	mutex sync.RWMutex
+	// seeds are decrypted seeds by key name, nil when the keystore is locked
+	seeds map[string]string
+}
+
+// KeystoreKey is a name and an address of a key in the keystore
+type KeystoreKey struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+}
+
+// OpenKeystore reads a locked keystore from file
+func OpenKeystore(file string) (*Keystore, error) {
+	data, err := readKeystoreFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return &Keystore{data: data}, nil
+}
+
+func readKeystoreFile(file string) (data keystoreFile, err error) {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(content, &data)
+	if err != nil {
+		return
+	}
+
+	if len(data.Salt) == 0 || data.Iterations <= 0 {
+		err = errors.New("invalid keystore file")
+	}
+	return
+}
+
+// AddKey adds a secret seed to keystore file encrypted using passphrase. The file is created
+// when it doesn't exist, otherwise passphrase must be the passphrase of the keystore.
+func AddKey(file, passphrase, name, seed string) (address string, err error) {
+	if name == "" {
+		return "", errors.New("key name is required")
+	}
+
+	kp, err := keypair.Parse(seed)
+	if err != nil {
+		return
+	}
+	if _, ok := kp.(*keypair.Full); !ok {
+		return "", errors.New("key must be a secret seed")
+	}
+
+	data, err := readKeystoreFile(file)
+	if os.IsNotExist(err) {
+		data = keystoreFile{Salt: make([]byte, 32), Iterations: keystoreIterations}
+		_, err = rand.Read(data.Salt)
+	}
+	if err != nil {
+		return
+	}
+
+	aead, err := data.aead(passphrase)
+	if err != nil {
+		return
+	}
+
+	for _, key := range data.Keys {
+		if key.Name == name {
+			return "", fmt.Errorf("key %s already exists", name)
+		}
+
+		_, err = aead.Open(nil, key.Nonce, key.Ciphertext, []byte(key.Name))
+		if err != nil {
+			return "", ErrInvalidPassphrase
+		}
+	}
+
+	key := keystoreKey{Name: name, Address: kp.Address(), Nonce: make([]byte, aead.NonceSize())}
+	_, err = rand.Read(key.Nonce)
+	if err != nil {
+		return
+	}
+	key.Ciphertext = aead.Seal(nil, key.Nonce, []byte(seed), []byte(name))
+	data.Keys = append(data.Keys, key)
+
+	content, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return
+	}
+
+	err = ioutil.WriteFile(file, content, 0600)
+	return kp.Address(), err
+}
+
+// aead returns AES-256-GCM cipher with a key derived from passphrase
+func (data keystoreFile) aead(passphrase string) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(pbkdf2([]byte(passphrase), data.Salt, data.Iterations, 32))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Keys returns names and addresses of keys in the keystore
+func (k *Keystore) Keys() []KeystoreKey {
+	keys := make([]KeystoreKey, 0, len(k.data.Keys))
+	for _, key := range k.data.Keys {
+		keys = append(keys, KeystoreKey{Name: key.Name, Address: key.Address})
+	}
+	return keys
+}
+
+// Locked returns true until the keystore is unlocked
+func (k *Keystore) Locked() bool {
+	k.mutex.RLock()
+	defer k.mutex.RUnlock()
+	return k.seeds == nil
+}
+
+// Unlock decrypts keys of the keystore. It returns ErrInvalidPassphrase when passphrase
+// is not the passphrase of the keystore.
+func (k *Keystore) Unlock(passphrase string) error {
+	aead, err := k.data.aead(passphrase)
+	if err != nil {
+		return err
+	}
+
+	seeds := make(map[string]string)
+	for _, key := range k.data.Keys {
+		seed, err := aead.Open(nil, key.Nonce, key.Ciphertext, []byte(key.Name))
+		if err != nil {
+			return ErrInvalidPassphrase
+		}
+		seeds[key.Name] = string(seed)
+	}
+
+	k.mutex.Lock()
+	k.seeds = seeds
+	k.mutex.Unlock()
+	return nil
+}
+
+func (k *Keystore) signer(name string) (Signer, error) {
+	for _, key := range k.data.Keys {
+		if key.Name == name {
+			return &keystoreSigner{keystore: k, name: name, address: key.Address}, nil
+		}
+	}
+	return nil, fmt.Errorf("key %s not found in keystore", name)
+}
+
+// keystoreSigner signs using a key of the keystore. Its address is known when the keystore
+// is locked but it returns ErrLocked when signing.
+type keystoreSigner struct {
+	keystore *Keystore
+	name     string
+	address  string
+}
+
+func (s *keystoreSigner) Address() string {
+	return s.address
+}
+
+func (s *keystoreSigner) SignDecorated(input []byte) (xdr.DecoratedSignature, error) {
+	s.keystore.mutex.RLock()
+	seed, unlocked := s.keystore.seeds[s.name]
+	s.keystore.mutex.RUnlock()
+
+	if !unlocked {
+		return xdr.DecoratedSignature{}, ErrLocked
+	}
+
+	kp, err := keypair.Parse(seed)
+	if err != nil {
+		return xdr.DecoratedSignature{}, err
+	}
+	return kp.SignDecorated(input)
+}
+
+// pbkdf2 derives a key from password using PBKDF2 with HMAC-SHA256 (RFC 8018)
+func pbkdf2(password, salt []byte, iterations, keyLength int) []byte {
+	prf := hmac.New(sha256.New, password)
+	var key []byte
+	for block := uint32(1); len(key) < keyLength; block++ {
+		prf.Reset()
+		prf.Write(salt)
+		binary.Write(prf, binary.BigEndian, block)
+		u := prf.Sum(nil)
+		t := append([]byte{}, u...)
+		for i := 1; i < iterations; i++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for j := range t {
+				t[j] ^= u[j]
+			}
+		}
+		key = append(key, t...)
+	}
+	return key[:keyLength]
+}
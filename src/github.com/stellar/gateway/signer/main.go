@@ -0,0 +1,294 @@
+// Package signer signs transactions of the bridge server accounts. Accounts can be configured
+// using plaintext secret seeds or key references: `keystore:<name>` (a key in the local
+// encrypted keystore), `vault:<name>` (ed25519 key of HashiCorp Vault transit secrets engine)
+// and `aws_kms:<key id>` (ECC_NIST_EDWARDS25519 key of AWS KMS). Keys of references never
+// leave the keystore or KMS unencrypted.
+package signer
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/stellar/go-stellar-base/build"
+	"github.com/stellar/go-stellar-base/keypair"
+	"github.com/stellar/go-stellar-base/strkey"
+	"github.com/stellar/go-stellar-base/xdr"
+)
+
+// Prefixes of key references
+const (
+	PrefixKeystore = "keystore:"
+	PrefixVault    = "vault:"
+	PrefixAWSKMS   = "aws_kms:"
+)
+
+// ErrLocked is returned when signing with a key of the keystore before it's unlocked
+var ErrLocked = errors.New("keystore is locked")
+
+// Signer signs transaction hashes of a Stellar account. keypair.KP is a Signer.
+type Signer interface {
+	Address() string
+	SignDecorated(input []byte) (xdr.DecoratedSignature, error)
+}
+
+// IsReference returns true when seed is a key reference instead of a secret seed
+func IsReference(seed string) bool {
+	return strings.HasPrefix(seed, PrefixKeystore) ||
+		strings.HasPrefix(seed, PrefixVault) ||
+		strings.HasPrefix(seed, PrefixAWSKMS)
+}
+
+// Config contains values of `signer` config group of bridge server
+type Config struct {
+	Keystore KeystoreConfig
+	Vault    VaultConfig
+	AWSKMS   AWSKMSConfig `mapstructure:"aws_kms"`
+}
+
+// KeystoreConfig contains values of `signer.keystore` config group
+type KeystoreConfig struct {
+	// File is a keystore file created using `bridge keystore add` command
+	File string
+	// PassphraseEnv is a name of an environment variable containing a passphrase of the
+	// keystore. When empty, the keystore is unlocked using POST /admin/keystore/unlock.
+	PassphraseEnv string `mapstructure:"passphrase_env"`
+}
+
+// VaultConfig contains values of `signer.vault` config group
+type VaultConfig struct {
+	URL string
+	// Token is a Vault token (default: VAULT_TOKEN environment variable)
+	Token string
+	// Mount is a path the transit secrets engine is mounted at (default: transit)
+	Mount string
+}
+
+// AWSKMSConfig contains values of `signer.aws_kms` config group. Credentials are read from
+// AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY environment variables or the shared
+// credentials file.
+type AWSKMSConfig struct {
+	Region string
+	// Endpoint is a URL of KMS API (default: https://kms.<region>.amazonaws.com)
+	Endpoint string
+	// Profile is a profile of the shared credentials file (default: default)
+	Profile string
+}
+
+// Validate validates the config
+func (c Config) Validate() error {
+	if c.Keystore.File != "" {
+		_, err := OpenKeystore(c.Keystore.File)
+		if err != nil {
+			return fmt.Errorf("Cannot open signer.keystore.file: %s", err)
+		}
+	} else if c.Keystore.PassphraseEnv != "" {
+		return errors.New("signer.keystore.passphrase_env param requires signer.keystore.file")
+	}
+
+	if c.Vault.URL != "" {
+		vaultURL, err := url.Parse(c.Vault.URL)
+		if err != nil || (vaultURL.Scheme != "http" && vaultURL.Scheme != "https") {
+			return errors.New("Invalid signer.vault.url param")
+		}
+	}
+
+	if c.AWSKMS.Endpoint != "" {
+		if c.AWSKMS.Region == "" {
+			return errors.New("signer.aws_kms.endpoint param requires signer.aws_kms.region")
+		}
+
+		endpoint, err := url.Parse(c.AWSKMS.Endpoint)
+		if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") {
+			return errors.New("Invalid signer.aws_kms.endpoint param")
+		}
+	}
+
+	return nil
+}
+
+// ValidateReference returns an error when seed is a key reference of a backend which is
+// not configured
+func (c Config) ValidateReference(seed string) error {
+	prefix, name := splitReference(seed)
+	if name == "" {
+		return fmt.Errorf("key name is missing in %s", seed)
+	}
+
+	switch {
+	case prefix == PrefixKeystore && c.Keystore.File == "":
+		return fmt.Errorf("%s requires signer.keystore.file", seed)
+	case prefix == PrefixVault && c.Vault.URL == "":
+		return fmt.Errorf("%s requires signer.vault.url", seed)
+	case prefix == PrefixAWSKMS && c.AWSKMS.Region == "":
+		return fmt.Errorf("%s requires signer.aws_kms.region", seed)
+	}
+	return nil
+}
+
+func splitReference(seed string) (prefix, name string) {
+	i := strings.Index(seed, ":")
+	return seed[:i+1], seed[i+1:]
+}
+
+// backend returns signers of keys in a keystore or KMS
+type backend interface {
+	signer(name string) (Signer, error)
+}
+
+// Signers returns signers of secret seeds and key references of configured backends.
+// Signers of references are created once, ex. KMS public keys are loaded on the first use.
+type Signers struct {
+	keystore *Keystore
+	backends map[string]backend
+	mutex    sync.Mutex
+	signers  map[string]Signer
+}
+
+// New creates Signers of backends configured in c. The keystore is unlocked when
+// `passphrase_env` is set.
+func New(c Config) (*Signers, error) {
+	s := &Signers{
+		backends: make(map[string]backend),
+		signers:  make(map[string]Signer),
+	}
+
+	if c.Keystore.File != "" {
+		keystore, err := OpenKeystore(c.Keystore.File)
+		if err != nil {
+			return nil, err
+		}
+
+		if c.Keystore.PassphraseEnv != "" {
+			err = keystore.Unlock(os.Getenv(c.Keystore.PassphraseEnv))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		s.keystore = keystore
+		s.backends[PrefixKeystore] = keystore
+	}
+
+	if c.Vault.URL != "" {
+		s.backends[PrefixVault] = newVault(c.Vault)
+	}
+
+	if c.AWSKMS.Region != "" {
+		s.backends[PrefixAWSKMS] = newAWSKMS(c.AWSKMS)
+	}
+
+	return s, nil
+}
+
+// Keystore returns the keystore or nil when it's not configured
+func (s *Signers) Keystore() *Keystore {
+	if s == nil {
+		return nil
+	}
+	return s.keystore
+}
+
+// Signer returns a Signer of a secret seed or a key reference. Secret seeds can be used
+// with nil Signers.
+func (s *Signers) Signer(seed string) (Signer, error) {
+	if !IsReference(seed) {
+		kp, err := keypair.Parse(seed)
+		if err != nil {
+			return nil, err
+		}
+		return kp, nil
+	}
+
+	if s == nil {
+		return nil, fmt.Errorf("signer is not configured for %s", seed)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if signer, ok := s.signers[seed]; ok {
+		return signer, nil
+	}
+
+	prefix, name := splitReference(seed)
+	backend, ok := s.backends[prefix]
+	if !ok {
+		return nil, fmt.Errorf("signer is not configured for %s", seed)
+	}
+
+	signer, err := backend.signer(name)
+	if err != nil {
+		return nil, err
+	}
+
+	s.signers[seed] = signer
+	return signer, nil
+}
+
+// SignEnvelope returns an envelope of tx signed by signers
+func SignEnvelope(tx *build.TransactionBuilder, signers ...Signer) (txe build.TransactionEnvelopeBuilder, err error) {
+	txe.Mutate(tx)
+	if txe.Err != nil {
+		return txe, txe.Err
+	}
+
+	hash, err := tx.Hash()
+	if err != nil {
+		return
+	}
+
+	for _, signer := range signers {
+		var sig xdr.DecoratedSignature
+		sig, err = signer.SignDecorated(hash[:])
+		if err != nil {
+			return
+		}
+		txe.E.Signatures = append(txe.E.Signatures, sig)
+	}
+	return
+}
+
+// remoteSigner signs using a KMS. Signatures returned by the KMS are verified so a key
+// which doesn't match the account is detected before a transaction is submitted.
+type remoteSigner struct {
+	publicKey ed25519.PublicKey
+	address   string
+	sign      func(input []byte) ([]byte, error)
+}
+
+func newRemoteSigner(publicKey ed25519.PublicKey, sign func(input []byte) ([]byte, error)) (Signer, error) {
+	if len(publicKey) != ed25519.PublicKeySize {
+		return nil, errors.New("invalid ed25519 public key")
+	}
+
+	address, err := strkey.Encode(strkey.VersionByteAccountID, publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &remoteSigner{publicKey: publicKey, address: address, sign: sign}, nil
+}
+
+func (s *remoteSigner) Address() string {
+	return s.address
+}
+
+func (s *remoteSigner) SignDecorated(input []byte) (xdr.DecoratedSignature, error) {
+	signature, err := s.sign(input)
+	if err != nil {
+		return xdr.DecoratedSignature{}, err
+	}
+
+	if !ed25519.Verify(s.publicKey, input, signature) {
+		return xdr.DecoratedSignature{}, fmt.Errorf("invalid signature of %s", s.address)
+	}
+
+	var hint xdr.SignatureHint
+	copy(hint[:], s.publicKey[len(s.publicKey)-4:])
+	return xdr.DecoratedSignature{Hint: hint, Signature: xdr.Signature(signature)}, nil
+}
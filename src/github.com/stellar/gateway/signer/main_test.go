@@ -0,0 +1,229 @@
+package signer
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stellar/go-stellar-base/keypair"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSeed = "SDZT3EJZ7FZRYNTLOZ7VH6G5UYBFO2IO3Q5PGONMILPCZU3AL7QNZHTE"
+
+func TestPBKDF2(t *testing.T) {
+	// RFC 7914 test vector
+	key := pbkdf2([]byte("passwd"), []byte("salt"), 1, 64)
+	assert.Equal(t, "55ac046e56e3089fec1691c22544b605f94185216dde0465e68b9d57c20dacbc49ca9cccf179b645991664b39d77ef317c71b845b1e30bd509112041d3a19783", hex.EncodeToString(key))
+}
+
+func TestKeystore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keystore")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "keystore.json")
+
+	kp, err := keypair.Parse(testSeed)
+	require.NoError(t, err)
+
+	address, err := AddKey(file, "passphrase", "base", testSeed)
+	require.NoError(t, err)
+	assert.Equal(t, kp.Address(), address)
+
+	_, err = AddKey(file, "other passphrase", "other", "SABY7FRMMJWPBTKQQ2ZN43AUJQ3Z2ZAK36VYSG2SPE2ABNQXA66H5E5G")
+	assert.Equal(t, ErrInvalidPassphrase, err)
+	_, err = AddKey(file, "passphrase", "base", "SABY7FRMMJWPBTKQQ2ZN43AUJQ3Z2ZAK36VYSG2SPE2ABNQXA66H5E5G")
+	assert.Error(t, err)
+	_, err = AddKey(file, "passphrase", "address", kp.Address())
+	assert.Error(t, err)
+
+	content, err := ioutil.ReadFile(file)
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), testSeed)
+
+	signers, err := New(Config{Keystore: KeystoreConfig{File: file}})
+	require.NoError(t, err)
+	keystore := signers.Keystore()
+	assert.True(t, keystore.Locked())
+	assert.Equal(t, []KeystoreKey{{Name: "base", Address: kp.Address()}}, keystore.Keys())
+
+	s, err := signers.Signer("keystore:base")
+	require.NoError(t, err)
+	assert.Equal(t, kp.Address(), s.Address())
+
+	_, err = signers.Signer("keystore:unknown")
+	assert.Error(t, err)
+	_, err = signers.Signer("vault:base")
+	assert.Error(t, err)
+
+	hash := make([]byte, 32)
+	_, err = s.SignDecorated(hash)
+	assert.Equal(t, ErrLocked, err)
+
+	assert.Equal(t, ErrInvalidPassphrase, keystore.Unlock("wrong"))
+	assert.True(t, keystore.Locked())
+
+	require.NoError(t, keystore.Unlock("passphrase"))
+	assert.False(t, keystore.Locked())
+
+	sig, err := s.SignDecorated(hash)
+	require.NoError(t, err)
+	expected, err := kp.SignDecorated(hash)
+	require.NoError(t, err)
+	assert.Equal(t, expected, sig)
+
+	os.Setenv("TEST_KEYSTORE_PASSPHRASE", "passphrase")
+	defer os.Unsetenv("TEST_KEYSTORE_PASSPHRASE")
+	signers, err = New(Config{Keystore: KeystoreConfig{File: file, PassphraseEnv: "TEST_KEYSTORE_PASSPHRASE"}})
+	require.NoError(t, err)
+	assert.False(t, signers.Keystore().Locked())
+}
+
+func TestSeeds(t *testing.T) {
+	var signers *Signers
+	s, err := signers.Signer(testSeed)
+	require.NoError(t, err)
+	assert.Equal(t, "GCLOMB72ODBFUGK4E2BK7VMR3RNZ5WSTMEOGNA2YUVHFR3WMH2XBAB6H", s.Address())
+
+	_, err = signers.Signer("keystore:base")
+	assert.Error(t, err)
+	assert.Nil(t, signers.Keystore())
+}
+
+func TestVault(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	var requests []string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		assert.Equal(t, "vault-token", r.Header.Get("X-Vault-Token"))
+
+		switch r.URL.Path {
+		case "/v1/stellar/keys/base":
+			w.Write([]byte(`{"data": {"type": "ed25519", "latest_version": 2, "keys": {
+				"1": {"public_key": "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="},
+				"2": {"public_key": "` + base64.StdEncoding.EncodeToString(publicKey) + `"}
+			}}}`))
+		case "/v1/stellar/sign/base":
+			var request struct {
+				Input      []byte `json:"input"`
+				KeyVersion int    `json:"key_version"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			assert.Equal(t, 2, request.KeyVersion)
+
+			signature := base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, request.Input))
+			w.Write([]byte(`{"data": {"signature": "vault:v2:` + signature + `"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors": []}`))
+		}
+	}))
+	defer s.Close()
+
+	signers, err := New(Config{Vault: VaultConfig{URL: s.URL, Token: "vault-token", Mount: "stellar"}})
+	require.NoError(t, err)
+
+	signer, err := signers.Signer("vault:base")
+	require.NoError(t, err)
+
+	hash := make([]byte, 32)
+	sig, err := signer.SignDecorated(hash)
+	require.NoError(t, err)
+	assert.True(t, ed25519.Verify(publicKey, hash, sig.Signature))
+
+	kp, err := keypair.Parse(signer.Address())
+	require.NoError(t, err)
+	assert.NoError(t, kp.Verify(hash, sig.Signature))
+	assert.Equal(t, kp.Hint(), [4]byte(sig.Hint))
+
+	// Public key is loaded once
+	_, err = signers.Signer("vault:base")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"GET /v1/stellar/keys/base", "POST /v1/stellar/sign/base"}, requests)
+
+	_, err = signers.Signer("vault:unknown")
+	assert.Error(t, err)
+}
+
+func TestAWSKMS(t *testing.T) {
+	os.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	os.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	defer os.Unsetenv("AWS_ACCESS_KEY_ID")
+	defer os.Unsetenv("AWS_SECRET_ACCESS_KEY")
+
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	require.NoError(t, err)
+
+	// Signatures of another key are rejected
+	_, otherKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/"))
+		assert.Contains(t, r.Header.Get("Authorization"), "/eu-west-1/kms/aws4_request")
+
+		var request struct {
+			KeyId            string
+			Message          []byte
+			SigningAlgorithm string
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		switch r.Header.Get("X-Amz-Target") {
+		case "TrentService.GetPublicKey":
+			json.NewEncoder(w).Encode(map[string]interface{}{"KeyId": request.KeyId, "KeySpec": "ECC_NIST_EDWARDS25519", "PublicKey": der})
+		case "TrentService.Sign":
+			assert.Equal(t, "ED25519_SHA_512", request.SigningAlgorithm)
+			key := privateKey
+			if request.KeyId == "alias/other" {
+				key = otherKey
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"Signature": ed25519.Sign(key, request.Message)})
+		}
+	}))
+	defer s.Close()
+
+	signers, err := New(Config{AWSKMS: AWSKMSConfig{Region: "eu-west-1", Endpoint: s.URL}})
+	require.NoError(t, err)
+
+	signer, err := signers.Signer("aws_kms:alias/base")
+	require.NoError(t, err)
+
+	hash := make([]byte, 32)
+	sig, err := signer.SignDecorated(hash)
+	require.NoError(t, err)
+	assert.True(t, ed25519.Verify(publicKey, hash, sig.Signature))
+
+	signer, err = signers.Signer("aws_kms:alias/other")
+	require.NoError(t, err)
+	_, err = signer.SignDecorated(hash)
+	assert.Error(t, err)
+}
+
+func TestConfigValidate(t *testing.T) {
+	assert.NoError(t, Config{}.Validate())
+	assert.Error(t, Config{Keystore: KeystoreConfig{File: "/nonexistent/keystore.json"}}.Validate())
+	assert.Error(t, Config{Keystore: KeystoreConfig{PassphraseEnv: "PASSPHRASE"}}.Validate())
+	assert.Error(t, Config{Vault: VaultConfig{URL: "localhost:8200"}}.Validate())
+	assert.Error(t, Config{AWSKMS: AWSKMSConfig{Endpoint: "http://localhost:4566"}}.Validate())
+
+	c := Config{Vault: VaultConfig{URL: "http://localhost:8200"}}
+	assert.NoError(t, c.ValidateReference("vault:base"))
+	assert.Error(t, c.ValidateReference("vault:"))
+	assert.Error(t, c.ValidateReference("keystore:base"))
+	assert.Error(t, c.ValidateReference("aws_kms:alias/base"))
+}
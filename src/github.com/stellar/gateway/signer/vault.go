@@ -0,0 +1,127 @@
+package signer
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const requestTimeout = 10 * time.Second
+
+// vault signs using ed25519 keys of HashiCorp Vault transit secrets engine
+type vault struct {
+	url    string
+	token  string
+	mount  string
+	client *http.Client
+}
+
+func newVault(c VaultConfig) *vault {
+	v := &vault{
+		url:    strings.TrimSuffix(c.URL, "/"),
+		token:  c.Token,
+		mount:  c.Mount,
+		client: &http.Client{Timeout: requestTimeout},
+	}
+	if v.token == "" {
+		v.token = os.Getenv("VAULT_TOKEN")
+	}
+	if v.mount == "" {
+		v.mount = "transit"
+	}
+	return v
+}
+
+// signer reads the latest version of key name. The version is used for all signatures so
+// rotating the key in Vault doesn't change the account.
+func (v *vault) signer(name string) (Signer, error) {
+	var response struct {
+		Data struct {
+			Type          string `json:"type"`
+			LatestVersion int    `json:"latest_version"`
+			Keys          map[string]struct {
+				PublicKey string `json:"public_key"`
+			} `json:"keys"`
+		} `json:"data"`
+	}
+
+	err := v.do("GET", "/keys/"+name, nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Data.Type != "ed25519" {
+		return nil, fmt.Errorf("vault key %s is not an ed25519 key", name)
+	}
+
+	version := response.Data.LatestVersion
+	publicKey, err := base64.StdEncoding.DecodeString(response.Data.Keys[strconv.Itoa(version)].PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return newRemoteSigner(ed25519.PublicKey(publicKey), func(input []byte) ([]byte, error) {
+		var response struct {
+			Data struct {
+				Signature string `json:"signature"`
+			} `json:"data"`
+		}
+
+		err := v.do("POST", "/sign/"+name, map[string]interface{}{
+			"input":       base64.StdEncoding.EncodeToString(input),
+			"key_version": version,
+		}, &response)
+		if err != nil {
+			return nil, err
+		}
+
+		// vault:v<version>:<base64 signature>
+		parts := strings.SplitN(response.Data.Signature, ":", 3)
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("invalid vault signature: %s", response.Data.Signature)
+		}
+		return base64.StdEncoding.DecodeString(parts[2])
+	})
+}
+
+func (v *vault) do(method, path string, body, response interface{}) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		payload, err = json.Marshal(body)
+		if err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest(method, v.url+"/v1/"+v.mount+path, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Vault-Token", v.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("vault error (%d): %s", resp.StatusCode, content)
+	}
+	return json.Unmarshal(content, response)
+}
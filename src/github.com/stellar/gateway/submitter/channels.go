@@ -0,0 +1,111 @@
+package submitter
+
+import (
+	"context"
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/go-stellar-base/xdr"
+)
+
+// ChannelPool is a pool of channel accounts. A channel account is used as a source account
+// of a transaction (it pays the fee and provides the sequence number) while operations keep
+// the original source account. It allows submitting many transactions of a single account
+// concurrently without sequence number conflicts.
+type ChannelPool struct {
+	channels chan *Account
+}
+
+// NewChannelPool creates a ChannelPool of loaded accounts
+func NewChannelPool(accounts []*Account) *ChannelPool {
+	pool := &ChannelPool{channels: make(chan *Account, len(accounts))}
+	for _, account := range accounts {
+		pool.channels <- account
+	}
+	return pool
+}
+
+// Acquire returns a free channel account. It blocks until one of channels is released or
+// ctx is done.
+func (p *ChannelPool) Acquire(ctx context.Context) (*Account, error) {
+	select {
+	case channel := <-p.channels:
+		return channel, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Release returns channel account acquired using Acquire to the pool
+func (p *ChannelPool) Release(channel *Account) {
+	p.channels <- channel
+}
+
+// InitChannels loads channel accounts of seeds and creates a ChannelPool used by
+// SignAndSubmitRawTransaction
+func (ts *TransactionSubmitter) InitChannels(ctx context.Context, seeds []string) error {
+	accounts := make([]*Account, 0, len(seeds))
+	for _, seed := range seeds {
+		account, err := ts.LoadAccount(ctx, seed)
+		if err != nil {
+			return err
+		}
+		accounts = append(accounts, account)
+	}
+
+	ts.Channels = NewChannelPool(accounts)
+	return nil
+}
+
+// signAndSubmitWithChannel submits transaction of account using a channel account from
+// Channels as a transaction source. Operations without a source account are sent from
+// the original transaction source. Transaction is signed by both accounts.
+func (ts *TransactionSubmitter) signAndSubmitWithChannel(ctx context.Context, account *Account, tx *xdr.Transaction) (response horizon.SubmitTransactionResponse, err error) {
+	channel, err := ts.Channels.Acquire(ctx)
+	if err != nil {
+		return
+	}
+	defer ts.Channels.Release(channel)
+
+	for i := range tx.Operations {
+		if tx.Operations[i].SourceAccount == nil {
+			source := tx.SourceAccount
+			tx.Operations[i].SourceAccount = &source
+		}
+	}
+
+	err = tx.SourceAccount.SetAddress(channel.Keypair.Address())
+	if err != nil {
+		return
+	}
+
+	// Channel is not used by other transactions until it's released
+	channel.SequenceNumber++
+	tx.SeqNum = xdr.SequenceNumber(channel.SequenceNumber)
+
+	fee := ts.FeeStrategy.Fee(ctx)
+	tx.Fee = xdr.Uint32(fee * uint32(len(tx.Operations)))
+
+	hash, err := TransactionHash(tx, ts.Network.Passphrase)
+	if err != nil {
+		ts.log.Print("Error calculating transaction hash")
+		return
+	}
+
+	envelopeXdr := xdr.TransactionEnvelope{Tx: *tx}
+	for _, signer := range []*Account{channel, account} {
+		var sig xdr.DecoratedSignature
+		sig, err = signer.Keypair.SignDecorated(hash[:])
+		if err != nil {
+			ts.log.Print("Error signing a transaction")
+			return
+		}
+		envelopeXdr.Signatures = append(envelopeXdr.Signatures, sig)
+	}
+
+	txeB64, err := xdr.MarshalBase64(envelopeXdr)
+	if err != nil {
+		ts.log.Print("Cannot encode transaction envelope")
+		return
+	}
+
+	return ts.submitEnvelope(ctx, account.Keypair.Address(), channel, hash, txeB64, len(tx.Operations), fee)
+}
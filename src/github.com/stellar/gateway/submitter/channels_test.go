@@ -0,0 +1,137 @@
+package submitter
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stellar/gateway/db/entities"
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/mocks"
+	b "github.com/stellar/go-stellar-base/build"
+	"github.com/stellar/go-stellar-base/keypair"
+	"github.com/stellar/go-stellar-base/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannelPool(t *testing.T) {
+	first, second := &Account{Seed: "1"}, &Account{Seed: "2"}
+	pool := NewChannelPool([]*Account{first, second})
+	acquire := func(ctx context.Context) *Account {
+		channel, err := pool.Acquire(ctx)
+		require.NoError(t, err)
+		return channel
+	}
+
+	assert.Equal(t, first, acquire(context.Background()))
+	assert.Equal(t, second, acquire(context.Background()))
+
+	acquired := make(chan *Account)
+	go func() {
+		acquired <- acquire(context.Background())
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("channel acquired while all channels are used")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	pool.Release(first)
+	assert.Equal(t, first, <-acquired)
+
+	// Waiting for a channel is cancelled with ctx
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := pool.Acquire(ctx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestSignAndSubmitRawTransaction_Channels(t *testing.T) {
+	mockHorizon := new(mocks.MockHorizon)
+	mockEntityManager := new(mocks.MockEntityManager)
+	ts := NewTransactionSubmitter(mockHorizon, mockEntityManager, "Test SDF Network ; September 2015", time.Now)
+
+	seed := "SDZT3EJZ7FZRYNTLOZ7VH6G5UYBFO2IO3Q5PGONMILPCZU3AL7QNZHTE"
+	accountID := "GCLOMB72ODBFUGK4E2BK7VMR3RNZ5WSTMEOGNA2YUVHFR3WMH2XBAB6H"
+	channelSeed := "SABY7FRMMJWPBTKQQ2ZN43AUJQ3Z2ZAK36VYSG2SPE2ABNQXA66H5E5G"
+	channel, err := keypair.Parse(channelSeed)
+	require.NoError(t, err)
+
+	mockHorizon.On("LoadAccount", accountID).Return(horizon.AccountResponse{SequenceNumber: "100"}, nil).Once()
+	mockHorizon.On("LoadAccount", channel.Address()).Return(horizon.AccountResponse{SequenceNumber: "200"}, nil).Once()
+	require.NoError(t, ts.InitAccount(context.Background(), seed))
+	require.NoError(t, ts.InitChannels(context.Background(), []string{channelSeed}))
+
+	var envelope xdr.TransactionEnvelope
+	ledger := uint64(123)
+	mockHorizon.On("SubmitTransaction", mock.AnythingOfType("string")).Return(
+		horizon.SubmitTransactionResponse{Ledger: &ledger},
+		nil,
+	).Run(func(args mock.Arguments) {
+		require.NoError(t, xdr.SafeUnmarshalBase64(args.String(0), &envelope))
+	}).Once()
+	mockEntityManager.On("Persist", mock.AnythingOfType("*entities.SentTransaction")).Return(nil).Run(func(args mock.Arguments) {
+		assert.Equal(t, accountID, args.Get(0).(*entities.SentTransaction).Source)
+	}).Twice()
+
+	tx := b.Transaction(
+		b.SourceAccount{AddressOrSeed: seed},
+		b.Payment(
+			b.Destination{AddressOrSeed: "GB3W7VQ2A2IOQIS4LUFUMRC2DWXONUDH24ROLE6RS4NGUNHVSXKCABOM"},
+			b.NativeAmount{Amount: "100"},
+		),
+	)
+	require.NoError(t, tx.Err)
+
+	_, err = ts.SignAndSubmitRawTransaction(context.Background(), seed, tx.TX)
+	require.NoError(t, err)
+
+	assert.Equal(t, channel.Address(), envelope.Tx.SourceAccount.Address())
+	assert.Equal(t, xdr.SequenceNumber(201), envelope.Tx.SeqNum)
+	require.NotNil(t, envelope.Tx.Operations[0].SourceAccount)
+	assert.Equal(t, accountID, envelope.Tx.Operations[0].SourceAccount.Address())
+	assert.Len(t, envelope.Signatures, 2)
+	// Sequence number of the account is not used
+	assert.Equal(t, uint64(100), ts.Accounts[seed].SequenceNumber)
+
+	mockHorizon.AssertExpectations(t)
+	mockEntityManager.AssertExpectations(t)
+}
+
+func TestGetAccount_Concurrent(t *testing.T) {
+	mockHorizon := new(mocks.MockHorizon)
+	ts := NewTransactionSubmitter(mockHorizon, new(mocks.MockEntityManager), "Test SDF Network ; September 2015", time.Now)
+
+	seed := "SDZT3EJZ7FZRYNTLOZ7VH6G5UYBFO2IO3Q5PGONMILPCZU3AL7QNZHTE"
+	accountID := "GCLOMB72ODBFUGK4E2BK7VMR3RNZ5WSTMEOGNA2YUVHFR3WMH2XBAB6H"
+
+	// Accounts that failed to load are not stored
+	mockHorizon.On("LoadAccount", accountID).Return(horizon.AccountResponse{}, errors.New("timeout")).Once()
+	_, err := ts.GetAccount(context.Background(), seed)
+	assert.Error(t, err)
+
+	mockHorizon.On("LoadAccount", accountID).Return(horizon.AccountResponse{SequenceNumber: "100"}, nil).Once()
+	accounts := make([]*Account, 10)
+	var wg sync.WaitGroup
+	for i := range accounts {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			account, err := WithLogger(&ts, ts.log).(*TransactionSubmitter).GetAccount(context.Background(), seed)
+			assert.NoError(t, err)
+			accounts[i] = account
+		}(i)
+	}
+	wg.Wait()
+
+	for _, account := range accounts {
+		assert.Equal(t, accounts[0], account)
+	}
+	assert.Equal(t, uint64(100), accounts[0].SequenceNumber)
+	mockHorizon.AssertExpectations(t)
+}
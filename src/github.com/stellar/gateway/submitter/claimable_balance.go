@@ -0,0 +1,61 @@
+package submitter
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/go-stellar-base/strkey"
+)
+
+// Claimable balances were added to the protocol after the version supported by
+// go-stellar-base so claim transactions are encoded using rawTransaction.
+const (
+	operationTypeClaimClaimableBalance = 15
+	claimableBalanceIDLength           = 36 // int32 type (0) + 32 bytes hash
+)
+
+// ClaimClaimableBalance submits a transaction claiming a claimable balance by the account
+// of seed. balanceID is a hex-encoded balance ID as returned by Horizon.
+func (ts *TransactionSubmitter) ClaimClaimableBalance(ctx context.Context, seed, balanceID string) (response horizon.SubmitTransactionResponse, err error) {
+	id, err := hex.DecodeString(balanceID)
+	if err != nil || len(id) != claimableBalanceIDLength {
+		err = errors.New("invalid claimable balance ID")
+		return
+	}
+
+	account, err := ts.GetAccount(ctx, seed)
+	if err != nil {
+		return
+	}
+
+	accountID, err := strkey.Decode(strkey.VersionByteAccountID, account.Keypair.Address())
+	if err != nil {
+		return
+	}
+
+	account.Mutex.Lock()
+	account.SequenceNumber++
+	sequence := account.SequenceNumber
+	account.Mutex.Unlock()
+
+	fee := ts.FeeStrategy.Fee(ctx)
+	tx := claimTransaction(accountID, sequence, fee, id)
+	hash := rawTransactionHash(tx, ts.Network.Passphrase)
+
+	txeB64, err := signRawTransaction(tx, hash, Preconditions{}, account.Keypair)
+	if err != nil {
+		ts.log.Print("Error signing a transaction")
+		return
+	}
+
+	return ts.submitEnvelope(ctx, account.Keypair.Address(), account, hash, txeB64, 1, fee)
+}
+
+// claimTransaction returns XDR of a transaction with a single claim_claimable_balance operation
+func claimTransaction(accountID []byte, sequence uint64, fee uint32, balanceID []byte) []byte {
+	// Encoding fails only when operation source account is set
+	operation, _ := RawOperation(nil, operationTypeClaimClaimableBalance, balanceID)
+	return rawTransaction(accountID, sequence, fee, Preconditions{}, [][]byte{operation})
+}
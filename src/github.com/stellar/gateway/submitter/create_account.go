@@ -0,0 +1,116 @@
+package submitter
+
+import (
+	"context"
+	"math"
+
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/signer"
+	"github.com/stellar/go-stellar-base/strkey"
+	"github.com/stellar/go-stellar-base/xdr"
+)
+
+// Sponsored reserves were added to the protocol after the version supported by
+// go-stellar-base so sponsored transactions are encoded using rawTransaction.
+const (
+	operationTypeBeginSponsoringFutureReserves = 16
+	operationTypeEndSponsoringFutureReserves   = 17
+)
+
+// CreateAccount submits a transaction creating newAccount with startingBalance funded by the
+// account of seed. Trustlines of assets are added to the new account. When sponsored is true,
+// the funding account sponsors reserves of the new account and its trustlines so
+// startingBalance can be zero. The transaction is also signed by newAccount when it contains
+// its operations.
+func (ts *TransactionSubmitter) CreateAccount(ctx context.Context, seed string, newAccount signer.Signer, startingBalance xdr.Int64, assets []xdr.Asset, sponsored bool) (response horizon.SubmitTransactionResponse, err error) {
+	operations, err := createAccountOperations(newAccount.Address(), startingBalance, assets, sponsored)
+	if err != nil {
+		return
+	}
+
+	account, err := ts.GetAccount(ctx, seed)
+	if err != nil {
+		return
+	}
+
+	accountID, err := strkey.Decode(strkey.VersionByteAccountID, account.Keypair.Address())
+	if err != nil {
+		return
+	}
+
+	account.Mutex.Lock()
+	account.SequenceNumber++
+	sequence := account.SequenceNumber
+	account.Mutex.Unlock()
+
+	fee := ts.FeeStrategy.Fee(ctx)
+	tx := rawTransaction(accountID, sequence, fee, Preconditions{}, operations)
+	hash := rawTransactionHash(tx, ts.Network.Passphrase)
+
+	signers := []signer.Signer{account.Keypair}
+	if len(operations) > 1 {
+		signers = append(signers, newAccount)
+	}
+
+	txeB64, err := signRawTransaction(tx, hash, Preconditions{}, signers...)
+	if err != nil {
+		ts.log.Print("Error signing a transaction")
+		return
+	}
+
+	return ts.submitEnvelope(ctx, account.Keypair.Address(), account, hash, txeB64, len(operations), fee)
+}
+
+// createAccountOperations returns XDR of operations creating destination account. Operations
+// other than create_account are sent from the destination account.
+func createAccountOperations(destination string, startingBalance xdr.Int64, assets []xdr.Asset, sponsored bool) (operations [][]byte, err error) {
+	var destinationID xdr.AccountId
+	err = destinationID.SetAddress(destination)
+	if err != nil {
+		return
+	}
+
+	add := func(operation []byte, err error) error {
+		operations = append(operations, operation)
+		return err
+	}
+
+	if sponsored {
+		var body []byte
+		body, err = marshalXDR(destinationID)
+		if err == nil {
+			err = add(RawOperation(nil, operationTypeBeginSponsoringFutureReserves, body))
+		}
+		if err != nil {
+			return
+		}
+	}
+
+	err = add(marshalXDR(xdr.Operation{
+		Body: xdr.OperationBody{
+			Type:            xdr.OperationTypeCreateAccount,
+			CreateAccountOp: &xdr.CreateAccountOp{Destination: destinationID, StartingBalance: startingBalance},
+		},
+	}))
+	if err != nil {
+		return
+	}
+
+	for _, asset := range assets {
+		err = add(marshalXDR(xdr.Operation{
+			SourceAccount: &destinationID,
+			Body: xdr.OperationBody{
+				Type:          xdr.OperationTypeChangeTrust,
+				ChangeTrustOp: &xdr.ChangeTrustOp{Line: asset, Limit: xdr.Int64(math.MaxInt64)},
+			},
+		}))
+		if err != nil {
+			return
+		}
+	}
+
+	if sponsored {
+		err = add(RawOperation(&destinationID, operationTypeEndSponsoringFutureReserves, nil))
+	}
+	return
+}
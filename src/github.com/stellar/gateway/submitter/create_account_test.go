@@ -0,0 +1,50 @@
+package submitter
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stellar/go-stellar-base/build"
+	"github.com/stellar/go-stellar-base/keypair"
+	"github.com/stellar/go-stellar-base/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAccountOperations(t *testing.T) {
+	destination, err := keypair.Random()
+	require.NoError(t, err)
+	asset, err := build.CreditAsset("USD", "GDSIKW43UA6JTOA47WVEBCZ4MYC74M3GNKNXTVDXFHXYYTNO5GGVN632").ToXdrObject()
+	require.NoError(t, err)
+
+	operations, err := createAccountOperations(destination.Address(), 20000000, []xdr.Asset{asset}, false)
+	require.NoError(t, err)
+	require.Len(t, operations, 2)
+
+	var createAccount, changeTrust xdr.Operation
+	_, err = xdr.Unmarshal(bytes.NewReader(operations[0]), &createAccount)
+	require.NoError(t, err)
+	assert.Nil(t, createAccount.SourceAccount)
+	assert.Equal(t, xdr.Int64(20000000), createAccount.Body.CreateAccountOp.StartingBalance)
+
+	_, err = xdr.Unmarshal(bytes.NewReader(operations[1]), &changeTrust)
+	require.NoError(t, err)
+	assert.Equal(t, destination.Address(), changeTrust.SourceAccount.Address())
+	assert.Equal(t, xdr.OperationTypeChangeTrust, changeTrust.Body.Type)
+
+	operations, err = createAccountOperations(destination.Address(), 0, []xdr.Asset{asset}, true)
+	require.NoError(t, err)
+	require.Len(t, operations, 4)
+
+	// begin_sponsoring_future_reserves: no source, type, sponsored account
+	assert.Equal(t, uint32(0), binary.BigEndian.Uint32(operations[0]))
+	assert.Equal(t, uint32(operationTypeBeginSponsoringFutureReserves), binary.BigEndian.Uint32(operations[0][4:]))
+	// end_sponsoring_future_reserves: sent by the sponsored account, no body
+	assert.Equal(t, uint32(1), binary.BigEndian.Uint32(operations[3]))
+	assert.Len(t, operations[3], 4+36+4)
+	assert.Equal(t, uint32(operationTypeEndSponsoringFutureReserves), binary.BigEndian.Uint32(operations[3][40:]))
+
+	_, err = createAccountOperations("bob", 0, nil, false)
+	assert.Error(t, err)
+}
@@ -0,0 +1,220 @@
+package submitter
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/metrics"
+	"github.com/stellar/gateway/signer"
+	"github.com/stellar/go-stellar-base/strkey"
+	"github.com/stellar/go-stellar-base/xdr"
+)
+
+const (
+	// DefaultBaseFee is a fee per operation used when FeeStrategy.BaseFee is not set
+	DefaultBaseFee = 100
+	// feeStatsTTL is a time fee stats are cached for (a ledger closes every ~5 seconds)
+	feeStatsTTL = 5 * time.Second
+)
+
+// Fee-bump transactions were added to the protocol after the version supported by
+// go-stellar-base so they are encoded here. Transactions built by go-stellar-base use the
+// original envelope format which is binary compatible with v1 envelope (inner transaction
+// of a fee-bump transaction) when prefixed with its envelope type.
+const (
+	envelopeTypeTx        = 2
+	envelopeTypeTxFeeBump = 5
+
+	resultCodeTxFeeBumpInnerSuccess = 1
+	resultCodeTxInsufficientFee     = -9
+	resultCodeTxFeeBumpInnerFailed  = -13
+)
+
+// FeeStrategy chooses fees of transactions and resubmits transactions failed with
+// tx_insufficient_fee in fee-bump transactions. Fees are in stroops per operation.
+type FeeStrategy struct {
+	Horizon           horizon.HorizonInterface
+	NetworkPassphrase string
+	// BaseFee is a fee of transactions when Percentile is not set. Default: DefaultBaseFee.
+	BaseFee uint32
+	// Percentile of fees charged in recent ledgers used as a fee (when higher than BaseFee)
+	Percentile int
+	// MaxFee caps fees. Transactions are not fee-bumped when it's not set.
+	MaxFee uint32
+
+	mutex         sync.Mutex
+	percentileFee uint32
+	loadedAt      time.Time
+}
+
+// Fee returns a fee per operation of a new transaction
+func (s *FeeStrategy) Fee(ctx context.Context) uint32 {
+	fee := s.BaseFee
+	if fee == 0 {
+		fee = DefaultBaseFee
+	}
+
+	if s.Percentile != 0 {
+		percentileFee, err := s.loadPercentileFee(ctx)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{"err": err}).Warn("Cannot load fee stats, using base fee")
+		} else if percentileFee > fee {
+			fee = percentileFee
+		}
+	}
+
+	if s.MaxFee != 0 && fee > s.MaxFee {
+		fee = s.MaxFee
+	}
+	return fee
+}
+
+func (s *FeeStrategy) loadPercentileFee(ctx context.Context) (uint32, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if time.Since(s.loadedAt) < feeStatsTTL {
+		return s.percentileFee, nil
+	}
+
+	stats, err := s.Horizon.LoadFeeStats(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	fee, err := stats.FeeChargedPercentile(s.Percentile)
+	if err != nil {
+		return 0, err
+	}
+
+	s.percentileFee = fee
+	s.loadedAt = time.Now()
+	return fee, nil
+}
+
+// Submit submits a signed transaction envelope with a given number of operations and a fee
+// per operation. When the transaction fails with tx_insufficient_fee and the fee is lower
+// than MaxFee, it's resubmitted in a fee-bump transaction paid by feeSource with the fee
+// doubled (up to MaxFee). The result of the inner transaction of a failed fee-bump transaction
+// is returned in response.Extras.ResultXdr so it can be checked like any other result.
+func (s *FeeStrategy) Submit(ctx context.Context, txeB64 string, operations int, fee uint32, feeSource signer.Signer) (response horizon.SubmitTransactionResponse, err error) {
+	response, err = s.Horizon.SubmitTransaction(ctx, txeB64)
+
+	for err == nil && resultCode(response) == resultCodeTxInsufficientFee && fee < s.MaxFee {
+		fee *= 2
+		if fee > s.MaxFee {
+			fee = s.MaxFee
+		}
+
+		var feeBumpB64 string
+		feeBumpB64, err = feeBumpEnvelope(txeB64, feeSource, int64(fee)*int64(operations+1), s.NetworkPassphrase)
+		if err != nil {
+			return
+		}
+
+		logrus.WithFields(logrus.Fields{"fee": fee, "fee_source": feeSource.Address()}).Info("Insufficient fee, resubmitting fee-bump transaction")
+		metrics.AddCounter("transactions_fee_bumped", 1, nil)
+		response, err = s.Horizon.SubmitTransaction(ctx, feeBumpB64)
+	}
+
+	if err == nil {
+		unwrapFeeBumpResult(&response)
+	}
+	return
+}
+
+// feeBumpEnvelope returns a fee-bump transaction envelope wrapping transaction envelope
+// txeB64 with a total fee paid by feeSource
+func feeBumpEnvelope(txeB64 string, feeSource signer.Signer, fee int64, networkPassphrase string) (string, error) {
+	inner, err := base64.StdEncoding.DecodeString(txeB64)
+	if err != nil || len(inner) < 4 {
+		return "", errors.New("invalid transaction envelope")
+	}
+
+	// Original format envelope starts with a key type of the source account (0)
+	// which matches a v1 envelope with ed25519 source account
+	switch binary.BigEndian.Uint32(inner) {
+	case uint32(xdr.CryptoKeyTypeKeyTypeEd25519):
+	case envelopeTypeTx:
+		inner = inner[4:]
+	default:
+		return "", errors.New("unsupported transaction envelope type")
+	}
+
+	feeSourceID, err := strkey.Decode(strkey.VersionByteAccountID, feeSource.Address())
+	if err != nil {
+		return "", err
+	}
+
+	var tx bytes.Buffer
+	writeUint32(&tx, uint32(xdr.CryptoKeyTypeKeyTypeEd25519))
+	tx.Write(feeSourceID)
+	binary.Write(&tx, binary.BigEndian, fee)
+	writeUint32(&tx, envelopeTypeTx)
+	tx.Write(inner)
+	writeUint32(&tx, 0) // ext
+
+	var payload bytes.Buffer
+	networkID := sha256.Sum256([]byte(networkPassphrase))
+	payload.Write(networkID[:])
+	writeUint32(&payload, envelopeTypeTxFeeBump)
+	payload.Write(tx.Bytes())
+	hash := sha256.Sum256(payload.Bytes())
+
+	sig, err := feeSource.SignDecorated(hash[:])
+	if err != nil {
+		return "", err
+	}
+
+	var envelope bytes.Buffer
+	writeUint32(&envelope, envelopeTypeTxFeeBump)
+	envelope.Write(tx.Bytes())
+	writeUint32(&envelope, 1)
+	_, err = xdr.Marshal(&envelope, sig)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(envelope.Bytes()), nil
+}
+
+// resultCode returns a result code of a failed transaction or 0 when the transaction succeeded
+// or the result cannot be decoded
+func resultCode(response horizon.SubmitTransactionResponse) int32 {
+	if response.Ledger != nil || response.Extras == nil {
+		return 0
+	}
+
+	result, err := base64.StdEncoding.DecodeString(response.Extras.ResultXdr)
+	if err != nil || len(result) < 12 {
+		return 0
+	}
+
+	// int64 feeCharged is followed by the result code
+	return int32(binary.BigEndian.Uint32(result[8:12]))
+}
+
+// unwrapFeeBumpResult replaces a result of a failed fee-bump transaction with a result of
+// its inner transaction
+func unwrapFeeBumpResult(response *horizon.SubmitTransactionResponse) {
+	code := resultCode(*response)
+	if code != resultCodeTxFeeBumpInnerSuccess && code != resultCodeTxFeeBumpInnerFailed {
+		return
+	}
+
+	result, _ := base64.StdEncoding.DecodeString(response.Extras.ResultXdr)
+	// feeCharged (8 bytes), code (4 bytes) and inner transaction hash (32 bytes) are
+	// followed by the inner result and ext (4 bytes)
+	if len(result) < 48 {
+		return
+	}
+	response.Extras.ResultXdr = base64.StdEncoding.EncodeToString(result[44 : len(result)-4])
+}
@@ -0,0 +1,106 @@
+package submitter
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/mocks"
+	b "github.com/stellar/go-stellar-base/build"
+	"github.com/stellar/go-stellar-base/keypair"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeeStrategyFee(t *testing.T) {
+	mockHorizon := new(mocks.MockHorizon)
+
+	s := &FeeStrategy{Horizon: mockHorizon}
+	assert.Equal(t, uint32(DefaultBaseFee), s.Fee(context.Background()))
+
+	s = &FeeStrategy{Horizon: mockHorizon, BaseFee: 100, Percentile: 90, MaxFee: 200}
+	mockHorizon.On("LoadFeeStats").Return(horizon.FeeStatsResponse{
+		FeeCharged: map[string]string{"p90": "150"},
+	}, nil).Once()
+	assert.Equal(t, uint32(150), s.Fee(context.Background()))
+	// Fee stats are cached
+	assert.Equal(t, uint32(150), s.Fee(context.Background()))
+	mockHorizon.AssertExpectations(t)
+
+	s = &FeeStrategy{Horizon: mockHorizon, BaseFee: 100, Percentile: 99, MaxFee: 200}
+	mockHorizon.On("LoadFeeStats").Return(horizon.FeeStatsResponse{
+		FeeCharged: map[string]string{"p99": "5000"},
+	}, nil).Once()
+	assert.Equal(t, uint32(200), s.Fee(context.Background()))
+}
+
+func TestFeeStrategySubmit(t *testing.T) {
+	seed := "SDZT3EJZ7FZRYNTLOZ7VH6G5UYBFO2IO3Q5PGONMILPCZU3AL7QNZHTE"
+	kp, err := keypair.Parse(seed)
+	require.NoError(t, err)
+
+	tx := b.Transaction(
+		b.SourceAccount{seed},
+		b.Sequence{1},
+		b.TestNetwork,
+		b.Payment(
+			b.Destination{"GCLOMB72ODBFUGK4E2BK7VMR3RNZ5WSTMEOGNA2YUVHFR3WMH2XBAB6H"},
+			b.NativeAmount{"10"},
+		),
+	)
+	require.NoError(t, tx.Err)
+	txe := tx.Sign(seed)
+	txeB64, err := txe.Base64()
+	require.NoError(t, err)
+
+	mockHorizon := new(mocks.MockHorizon)
+	s := &FeeStrategy{Horizon: mockHorizon, NetworkPassphrase: b.TestNetwork.Passphrase, MaxFee: 300}
+
+	// tx_insufficient_fee
+	mockHorizon.On("SubmitTransaction", txeB64).Return(horizon.SubmitTransactionResponse{
+		Extras: &horizon.SubmitTransactionResponseExtras{ResultXdr: "AAAAAAAAAAD////3AAAAAA=="},
+	}, nil).Once()
+
+	var feeBumpB64 string
+	ledger := uint64(10)
+	mockHorizon.On("SubmitTransaction", mock.AnythingOfType("string")).Run(func(args mock.Arguments) {
+		feeBumpB64 = args.String(0)
+	}).Return(horizon.SubmitTransactionResponse{Ledger: &ledger}, nil).Once()
+
+	response, err := s.Submit(context.Background(), txeB64, 1, 100, kp)
+	require.NoError(t, err)
+	assert.Equal(t, &ledger, response.Ledger)
+	mockHorizon.AssertExpectations(t)
+
+	feeBump, err := base64.StdEncoding.DecodeString(feeBumpB64)
+	require.NoError(t, err)
+	inner, err := base64.StdEncoding.DecodeString(txeB64)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint32(envelopeTypeTxFeeBump), binary.BigEndian.Uint32(feeBump[0:4]))
+	// Fee of 2 operations (fee-bump and payment) doubled
+	assert.Equal(t, uint64(400), binary.BigEndian.Uint64(feeBump[40:48]))
+	assert.Equal(t, uint32(envelopeTypeTx), binary.BigEndian.Uint32(feeBump[48:52]))
+	assert.True(t, bytes.HasPrefix(feeBump[52:], inner))
+}
+
+func TestUnwrapFeeBumpResult(t *testing.T) {
+	var result bytes.Buffer
+	binary.Write(&result, binary.BigEndian, int64(200))
+	binary.Write(&result, binary.BigEndian, int32(resultCodeTxFeeBumpInnerFailed))
+	result.Write(make([]byte, 32))
+	// tx_bad_seq result of the inner transaction
+	inner, _ := base64.StdEncoding.DecodeString("AAAAAAAAAAD////7AAAAAA==")
+	result.Write(inner)
+	binary.Write(&result, binary.BigEndian, int32(0))
+
+	response := horizon.SubmitTransactionResponse{
+		Extras: &horizon.SubmitTransactionResponseExtras{ResultXdr: base64.StdEncoding.EncodeToString(result.Bytes())},
+	}
+	unwrapFeeBumpResult(&response)
+	assert.Equal(t, "AAAAAAAAAAD////7AAAAAA==", response.Extras.ResultXdr)
+}
@@ -0,0 +1,102 @@
+package submitter
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/stellar/gateway/signer"
+	"github.com/stellar/go-stellar-base/xdr"
+)
+
+// Preconditions of a transaction. Zero values are not set. Time bounds are supported by
+// go-stellar-base but ledger bounds and minimum sequence age were added to the protocol later:
+// transactions using them are encoded in the newer envelope format (ENVELOPE_TYPE_TX) with
+// PRECOND_V2 preconditions.
+type Preconditions struct {
+	// MinTime and MaxTime are UNIX timestamps
+	MinTime, MaxTime uint64
+	// MinLedger and MaxLedger are ledger sequence numbers
+	MinLedger, MaxLedger uint32
+	// MinSequenceAge is a number of seconds since the source account sequence number changed
+	MinSequenceAge uint64
+}
+
+const (
+	preconditionsTypeNone = 0
+	preconditionsTypeTime = 1
+	preconditionsTypeV2   = 2
+)
+
+// IsV2 returns true when preconditions cannot be encoded as time bounds only
+func (p Preconditions) IsV2() bool {
+	return p.MinLedger != 0 || p.MaxLedger != 0 || p.MinSequenceAge != 0
+}
+
+// TimeBounds returns time bounds of preconditions or nil when they are not set
+func (p Preconditions) TimeBounds() *xdr.TimeBounds {
+	if p.MinTime == 0 && p.MaxTime == 0 {
+		return nil
+	}
+	return &xdr.TimeBounds{MinTime: xdr.Uint64(p.MinTime), MaxTime: xdr.Uint64(p.MaxTime)}
+}
+
+// write writes XDR of preconditions to buf. Preconditions without PRECOND_V2 values have the
+// same encoding as optional time bounds of the original transaction format.
+func (p Preconditions) write(buf *bytes.Buffer) {
+	if !p.IsV2() {
+		if p.TimeBounds() == nil {
+			writeUint32(buf, preconditionsTypeNone)
+			return
+		}
+		writeUint32(buf, preconditionsTypeTime)
+		p.writeTimeBounds(buf)
+		return
+	}
+
+	writeUint32(buf, preconditionsTypeV2)
+	if p.TimeBounds() == nil {
+		writeUint32(buf, 0)
+	} else {
+		writeUint32(buf, 1)
+		p.writeTimeBounds(buf)
+	}
+	if p.MinLedger == 0 && p.MaxLedger == 0 {
+		writeUint32(buf, 0)
+	} else {
+		writeUint32(buf, 1)
+		writeUint32(buf, p.MinLedger)
+		writeUint32(buf, p.MaxLedger)
+	}
+	writeUint32(buf, 0) // no min sequence number
+	binary.Write(buf, binary.BigEndian, p.MinSequenceAge)
+	writeUint32(buf, 0) // min sequence ledger gap
+	writeUint32(buf, 0) // no extra signers
+}
+
+func (p Preconditions) writeTimeBounds(buf *bytes.Buffer) {
+	binary.Write(buf, binary.BigEndian, p.MinTime)
+	binary.Write(buf, binary.BigEndian, p.MaxTime)
+}
+
+// EncodeTransaction returns base64-encoded envelope and hash of tx built by go-stellar-base
+// with preconditions, signed by signers. Time bounds of tx are replaced by preconditions.
+func EncodeTransaction(tx *xdr.Transaction, preconditions Preconditions, networkPassphrase string, signers ...signer.Signer) (txeB64 string, hash [32]byte, err error) {
+	withoutTimeBounds := *tx
+	withoutTimeBounds.TimeBounds = nil
+	encoded, err := marshalXDR(withoutTimeBounds)
+	if err != nil {
+		return
+	}
+
+	// Absent time bounds are encoded as a single 0 after source account (key type and key),
+	// fee and sequence number
+	const timeBoundsOffset = 4 + 32 + 4 + 8
+	var raw bytes.Buffer
+	raw.Write(encoded[:timeBoundsOffset])
+	preconditions.write(&raw)
+	raw.Write(encoded[timeBoundsOffset+4:])
+
+	hash = rawTransactionHash(raw.Bytes(), networkPassphrase)
+	txeB64, err = signRawTransaction(raw.Bytes(), hash, preconditions, signers...)
+	return
+}
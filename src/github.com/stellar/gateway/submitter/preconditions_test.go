@@ -0,0 +1,90 @@
+package submitter
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stellar/go-stellar-base/build"
+	"github.com/stellar/go-stellar-base/keypair"
+	"github.com/stellar/go-stellar-base/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeTransaction(t *testing.T) {
+	source, err := keypair.Random()
+	require.NoError(t, err)
+	cosigner, err := keypair.Random()
+	require.NoError(t, err)
+
+	tx := build.Transaction(
+		build.SourceAccount{source.Address()},
+		build.Sequence{10},
+		build.TestNetwork,
+		build.Payment(build.Destination{cosigner.Address()}, build.NativeAmount{"1"}),
+	)
+	require.NoError(t, tx.Err)
+
+	// Time bounds only: the same envelope as built by go-stellar-base
+	preconditions := Preconditions{MinTime: 100, MaxTime: 200}
+	txeB64, hash, err := EncodeTransaction(tx.TX, preconditions, build.TestNetwork.Passphrase, source)
+	require.NoError(t, err)
+
+	tx.TX.TimeBounds = preconditions.TimeBounds()
+	txe := tx.Sign(source.Seed())
+	expected, err := txe.Base64()
+	require.NoError(t, err)
+	assert.Equal(t, expected, txeB64)
+	expectedHash, err := tx.Hash()
+	require.NoError(t, err)
+	assert.Equal(t, expectedHash, hash)
+
+	decoded, err := DecodeRawEnvelope(txeB64)
+	require.NoError(t, err)
+	assert.Equal(t, *tx.TX, decoded)
+
+	// The same transaction in the newer envelope format
+	envelope, err := base64.StdEncoding.DecodeString(txeB64)
+	require.NoError(t, err)
+	prefix := make([]byte, 4)
+	binary.BigEndian.PutUint32(prefix, envelopeTypeTx)
+	decoded, err = DecodeRawEnvelope(base64.StdEncoding.EncodeToString(append(prefix, envelope...)))
+	require.NoError(t, err)
+	assert.Equal(t, *tx.TX, decoded)
+
+	// Ledger bounds and minimum sequence age
+	preconditions = Preconditions{MaxTime: 200, MinLedger: 5, MinSequenceAge: 60}
+	txeB64, hash, err = EncodeTransaction(tx.TX, preconditions, build.TestNetwork.Passphrase, source)
+	require.NoError(t, err)
+	assert.NotEqual(t, expectedHash, hash)
+
+	envelope, err = base64.StdEncoding.DecodeString(txeB64)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(envelopeTypeTx), binary.BigEndian.Uint32(envelope))
+	// envelope type, source account, fee, sequence number
+	assert.Equal(t, uint32(preconditionsTypeV2), binary.BigEndian.Uint32(envelope[4+36+4+8:]))
+
+	address, sequence, parsedHash, err := ParseRawEnvelope(txeB64, build.TestNetwork.Passphrase)
+	require.NoError(t, err)
+	assert.Equal(t, source.Address(), address)
+	assert.Equal(t, uint64(10), sequence)
+	assert.Equal(t, hash, parsedHash)
+
+	signatures, err := RawEnvelopeSignatures(txeB64)
+	require.NoError(t, err)
+	require.Len(t, signatures, 1)
+	assert.NoError(t, source.Verify(hash[:], signatures[0].Signature))
+
+	signature, err := cosigner.SignDecorated(hash[:])
+	require.NoError(t, err)
+	txeB64, err = SetRawEnvelopeSignatures(txeB64, []xdr.DecoratedSignature{signatures[0], signature})
+	require.NoError(t, err)
+
+	signatures, err = RawEnvelopeSignatures(txeB64)
+	require.NoError(t, err)
+	assert.Len(t, signatures, 2)
+	_, _, parsedHash, err = ParseRawEnvelope(txeB64, build.TestNetwork.Passphrase)
+	require.NoError(t, err)
+	assert.Equal(t, hash, parsedHash)
+}
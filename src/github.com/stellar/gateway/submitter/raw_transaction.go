@@ -0,0 +1,242 @@
+package submitter
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+
+	"github.com/stellar/gateway/signer"
+	"github.com/stellar/go-stellar-base/strkey"
+	"github.com/stellar/go-stellar-base/xdr"
+)
+
+// Transactions with operations added to the protocol after the version supported by
+// go-stellar-base are encoded by helpers in this file. They use the original envelope
+// format which is still accepted by the network, unless they have PRECOND_V2 preconditions
+// (see Preconditions).
+
+// rawTransaction returns XDR of a transaction of accountID (raw ed25519 public key) without
+// memo. operations are XDR-encoded operations, fee is per operation. Source account has the
+// same encoding in both envelope formats.
+func rawTransaction(accountID []byte, sequence uint64, fee uint32, preconditions Preconditions, operations [][]byte) []byte {
+	var tx bytes.Buffer
+	writeUint32(&tx, uint32(xdr.CryptoKeyTypeKeyTypeEd25519))
+	tx.Write(accountID)
+	writeUint32(&tx, fee*uint32(len(operations)))
+	binary.Write(&tx, binary.BigEndian, sequence)
+	preconditions.write(&tx)
+	writeUint32(&tx, uint32(xdr.MemoTypeMemoNone))
+	writeUint32(&tx, uint32(len(operations)))
+	for _, operation := range operations {
+		tx.Write(operation)
+	}
+	writeUint32(&tx, 0) // ext
+	return tx.Bytes()
+}
+
+// RawOperation returns XDR of an operation of operationType with XDR-encoded body. Operation
+// source account is not set when source is nil.
+func RawOperation(source *xdr.AccountId, operationType uint32, body []byte) ([]byte, error) {
+	var operation bytes.Buffer
+	if source == nil {
+		writeUint32(&operation, 0)
+	} else {
+		writeUint32(&operation, 1)
+		_, err := xdr.Marshal(&operation, source)
+		if err != nil {
+			return nil, err
+		}
+	}
+	writeUint32(&operation, operationType)
+	operation.Write(body)
+	return operation.Bytes(), nil
+}
+
+// BuildRawTransaction returns base64-encoded envelope and hash of a transaction of source
+// with XDR-encoded operations (see RawOperation) and preconditions signed by signers. fee is
+// per operation.
+func BuildRawTransaction(source string, sequence uint64, fee uint32, preconditions Preconditions, operations [][]byte, networkPassphrase string, signers ...signer.Signer) (txeB64 string, hash [32]byte, err error) {
+	accountID, err := strkey.Decode(strkey.VersionByteAccountID, source)
+	if err != nil {
+		return
+	}
+
+	tx := rawTransaction(accountID, sequence, fee, preconditions, operations)
+	hash = rawTransactionHash(tx, networkPassphrase)
+	txeB64, err = signRawTransaction(tx, hash, preconditions, signers...)
+	return
+}
+
+// marshalXDR returns XDR of value, ex. an operation supported by go-stellar-base
+func marshalXDR(value interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	_, err := xdr.Marshal(&buf, value)
+	return buf.Bytes(), err
+}
+
+// rawTransactionHash returns hash of transaction tx signed by its signers
+func rawTransactionHash(tx []byte, networkPassphrase string) [32]byte {
+	var payload bytes.Buffer
+	networkID := sha256.Sum256([]byte(networkPassphrase))
+	payload.Write(networkID[:])
+	writeUint32(&payload, uint32(xdr.EnvelopeTypeEnvelopeTypeTx))
+	payload.Write(tx)
+	return sha256.Sum256(payload.Bytes())
+}
+
+// signRawTransaction returns base64-encoded envelope of transaction tx with hash signed by
+// signers. Transactions with PRECOND_V2 preconditions use ENVELOPE_TYPE_TX envelope.
+func signRawTransaction(tx []byte, hash [32]byte, preconditions Preconditions, signers ...signer.Signer) (string, error) {
+	signatures := make([]xdr.DecoratedSignature, 0, len(signers))
+	for _, s := range signers {
+		sig, err := s.SignDecorated(hash[:])
+		if err != nil {
+			return "", err
+		}
+		signatures = append(signatures, sig)
+	}
+
+	var envelope bytes.Buffer
+	if preconditions.IsV2() {
+		writeUint32(&envelope, envelopeTypeTx)
+	}
+	envelope.Write(tx)
+	err := writeSignatures(&envelope, signatures)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(envelope.Bytes()), nil
+}
+
+// writeSignatures writes XDR of envelope signatures to buf
+func writeSignatures(buf *bytes.Buffer, signatures []xdr.DecoratedSignature) error {
+	writeUint32(buf, uint32(len(signatures)))
+	for _, signature := range signatures {
+		_, err := xdr.Marshal(buf, signature)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ParseRawEnvelope returns source account, sequence number and hash of a transaction of
+// base64-encoded envelope txeB64 without decoding its operations, so it also works with
+// envelopes built by rawTransaction. Signatures must be ed25519 signatures.
+func ParseRawEnvelope(txeB64, networkPassphrase string) (source string, sequence uint64, hash [32]byte, err error) {
+	envelope, err := splitRawEnvelope(txeB64)
+	if err != nil {
+		return
+	}
+
+	source, err = strkey.Encode(strkey.VersionByteAccountID, envelope.tx[4:36])
+	if err != nil {
+		return
+	}
+	sequence = binary.BigEndian.Uint64(envelope.tx[40:48])
+	hash = rawTransactionHash(envelope.tx, networkPassphrase)
+	return
+}
+
+// DecodeRawEnvelope decodes transaction of base64-encoded envelope txeB64 (see ParseRawEnvelope).
+// Transactions of the newer envelope format are decoded when they have no preconditions other
+// than time bounds as they have the same encoding as transactions of the original format then.
+func DecodeRawEnvelope(txeB64 string) (tx xdr.Transaction, err error) {
+	envelope, err := splitRawEnvelope(txeB64)
+	if err != nil {
+		return
+	}
+
+	_, err = xdr.Unmarshal(bytes.NewReader(envelope.tx), &tx)
+	return
+}
+
+// RawEnvelopeSignatures returns signatures of base64-encoded envelope txeB64 (see ParseRawEnvelope)
+func RawEnvelopeSignatures(txeB64 string) ([]xdr.DecoratedSignature, error) {
+	envelope, err := splitRawEnvelope(txeB64)
+	if err != nil {
+		return nil, err
+	}
+
+	var signatures []xdr.DecoratedSignature
+	_, err = xdr.Unmarshal(bytes.NewReader(envelope.signatures), &signatures)
+	return signatures, err
+}
+
+// SetRawEnvelopeSignatures returns base64-encoded envelope txeB64 (see ParseRawEnvelope) with
+// its signatures replaced by signatures
+func SetRawEnvelopeSignatures(txeB64 string, signatures []xdr.DecoratedSignature) (string, error) {
+	envelope, err := splitRawEnvelope(txeB64)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	buf.Write(envelope.prefix)
+	buf.Write(envelope.tx)
+	err = writeSignatures(&buf, signatures)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// rawEnvelope is a transaction envelope split to envelope type (empty in the original format),
+// transaction and signatures
+type rawEnvelope struct {
+	prefix, tx, signatures []byte
+}
+
+func splitRawEnvelope(txeB64 string) (envelope rawEnvelope, err error) {
+	data, err := base64.StdEncoding.DecodeString(txeB64)
+	if err != nil {
+		return
+	}
+
+	// Envelopes of the newer format start with the envelope type. Source account of the
+	// transaction (muxed account) has the same encoding as in the original format.
+	prefixLength := 0
+	if len(data) >= 4 && binary.BigEndian.Uint32(data) == envelopeTypeTx {
+		prefixLength = 4
+	}
+
+	// key type, ed25519 key, fee, sequence number
+	const headerLength, signatureLength = 4 + 32 + 4 + 8, 4 + 4 + 64
+	if len(data) < prefixLength+headerLength+4 {
+		err = errors.New("transaction envelope too short")
+		return
+	}
+	if binary.BigEndian.Uint32(data[prefixLength:]) != uint32(xdr.CryptoKeyTypeKeyTypeEd25519) {
+		err = errors.New("unsupported transaction envelope")
+		return
+	}
+
+	// Signatures count is the only field following the transaction that has a known position
+	// when read backwards
+	txLength := -1
+	for signatures := 0; signatures <= 20; signatures++ {
+		offset := len(data) - 4 - signatures*signatureLength
+		if offset < prefixLength+headerLength {
+			break
+		}
+		if binary.BigEndian.Uint32(data[offset:]) == uint32(signatures) {
+			txLength = offset
+			break
+		}
+	}
+	if txLength == -1 {
+		err = errors.New("invalid transaction envelope signatures")
+		return
+	}
+
+	envelope.prefix = data[:prefixLength]
+	envelope.tx = data[prefixLength:txLength]
+	envelope.signatures = data[txLength:]
+	return
+}
+
+func writeUint32(buf *bytes.Buffer, value uint32) {
+	binary.Write(buf, binary.BigEndian, value)
+}
@@ -2,6 +2,7 @@ package submitter
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -13,31 +14,47 @@ import (
 	"github.com/stellar/gateway/db"
 	"github.com/stellar/gateway/db/entities"
 	"github.com/stellar/gateway/horizon"
+	"github.com/stellar/gateway/metrics"
+	"github.com/stellar/gateway/signer"
 	"github.com/stellar/go-stellar-base/build"
 	"github.com/stellar/go-stellar-base/hash"
-	"github.com/stellar/go-stellar-base/keypair"
 	"github.com/stellar/go-stellar-base/xdr"
 )
 
 // TransactionSubmitterInterface helps mocking TransactionSubmitter
 type TransactionSubmitterInterface interface {
-	SubmitTransaction(seed string, operation, memo interface{}) (response horizon.SubmitTransactionResponse, err error)
-	SignAndSubmitRawTransaction(seed string, tx *xdr.Transaction) (response horizon.SubmitTransactionResponse, err error)
+	SubmitTransaction(ctx context.Context, seed string, operation, memo interface{}) (response horizon.SubmitTransactionResponse, err error)
+	SignAndSubmitRawTransaction(ctx context.Context, seed string, tx *xdr.Transaction) (response horizon.SubmitTransactionResponse, err error)
+	ClaimClaimableBalance(ctx context.Context, seed, balanceID string) (response horizon.SubmitTransactionResponse, err error)
+	CreateAccount(ctx context.Context, seed string, newAccount signer.Signer, startingBalance xdr.Int64, assets []xdr.Asset, sponsored bool) (response horizon.SubmitTransactionResponse, err error)
 }
 
 // TransactionSubmitter submits transactions to Stellar Network
 type TransactionSubmitter struct {
-	Horizon       horizon.HorizonInterface
-	Accounts      map[string]*Account // seed => *Account
+	Horizon  horizon.HorizonInterface
+	Accounts map[string]*Account // seed => *Account
+	// accountsMutex guards Accounts. It's a pointer so copies made by WithLogger share it.
+	accountsMutex *sync.Mutex
 	EntityManager db.EntityManagerInterface
 	Network       build.Network
-	log           *logrus.Entry
-	now           func() time.Time
+	// FeeStrategy chooses fees of submitted transactions
+	FeeStrategy *FeeStrategy
+	// Channels are source accounts of transactions submitted using SignAndSubmitRawTransaction
+	// when set, see ChannelPool
+	Channels *ChannelPool
+	// Tenant is saved with every sent transaction
+	Tenant string
+	// Signers returns signers of seeds and key references of accounts. Only secret seeds
+	// can be used when it's nil.
+	Signers *signer.Signers
+	log     *logrus.Entry
+	now     func() time.Time
 }
 
 // Account represents account used to signing and sending transactions
 type Account struct {
-	Keypair        keypair.KP
+	Keypair signer.Signer
+	// Seed is a secret seed or a key reference of the account
 	Seed           string
 	SequenceNumber uint64
 	Mutex          sync.Mutex
@@ -53,7 +70,9 @@ func NewTransactionSubmitter(
 	ts.Horizon = horizon
 	ts.EntityManager = entityManager
 	ts.Accounts = make(map[string]*Account)
+	ts.accountsMutex = &sync.Mutex{}
 	ts.Network = build.Network{networkPassphrase}
+	ts.FeeStrategy = &FeeStrategy{Horizon: horizon, NetworkPassphrase: networkPassphrase}
 	ts.log = logrus.WithFields(logrus.Fields{
 		"service": "TransactionSubmitter",
 	})
@@ -61,16 +80,30 @@ func NewTransactionSubmitter(
 	return
 }
 
+// WithLogger returns a copy of ts logging using log, ex. to add request ID to logs of
+// transactions submitted while handling a request. Accounts and channels are shared with
+// the original. Other implementations (mocks) are returned unchanged.
+func WithLogger(ts TransactionSubmitterInterface, log *logrus.Entry) TransactionSubmitterInterface {
+	transactionSubmitter, ok := ts.(*TransactionSubmitter)
+	if !ok {
+		return ts
+	}
+	copy := *transactionSubmitter
+	copy.log = log.WithField("service", "TransactionSubmitter")
+	return &copy
+}
+
 // LoadAccount loads currect state of Stellar account
-func (ts *TransactionSubmitter) LoadAccount(seed string) (account *Account, err error) {
+func (ts *TransactionSubmitter) LoadAccount(ctx context.Context, seed string) (account *Account, err error) {
 	account = &Account{}
-	account.Keypair, err = keypair.Parse(seed)
+	account.Keypair, err = ts.Signers.Signer(seed)
 	if err != nil {
 		ts.log.Print("Invalid seed")
 		return
 	}
 
-	accountResponse, err := ts.Horizon.LoadAccount(account.Keypair.Address())
+	// Sequence number must be current so the account is not loaded from the cache
+	accountResponse, err := horizon.Uncached(ts.Horizon).LoadAccount(ctx, account.Keypair.Address())
 	if err != nil {
 		return
 	}
@@ -81,16 +114,23 @@ func (ts *TransactionSubmitter) LoadAccount(seed string) (account *Account, err
 }
 
 // InitAccount loads an account and returns error if it fails
-func (ts *TransactionSubmitter) InitAccount(seed string) (err error) {
-	_, err = ts.GetAccount(seed)
+func (ts *TransactionSubmitter) InitAccount(ctx context.Context, seed string) (err error) {
+	_, err = ts.GetAccount(ctx, seed)
 	return
 }
 
-// GetAccount returns an account by a given seed
-func (ts *TransactionSubmitter) GetAccount(seed string) (account *Account, err error) {
+// GetAccount returns an account by a given seed. The account is loaded on first use, only
+// once even when transactions are submitted concurrently.
+func (ts *TransactionSubmitter) GetAccount(ctx context.Context, seed string) (account *Account, err error) {
+	ts.accountsMutex.Lock()
+	defer ts.accountsMutex.Unlock()
+
 	account, exist := ts.Accounts[seed]
 	if !exist {
-		account, err = ts.LoadAccount(seed)
+		account, err = ts.LoadAccount(ctx, seed)
+		if err != nil {
+			return
+		}
 		ts.Accounts[seed] = account
 	}
 	return
@@ -98,19 +138,28 @@ func (ts *TransactionSubmitter) GetAccount(seed string) (account *Account, err e
 
 // SignAndSubmitRawTransaction will:
 // - update sequence number of the transaction to the current one,
+// - set its fee using FeeStrategy,
 // - sign it,
 // - submit it to the network.
-func (ts *TransactionSubmitter) SignAndSubmitRawTransaction(seed string, tx *xdr.Transaction) (response horizon.SubmitTransactionResponse, err error) {
-	account, err := ts.GetAccount(seed)
+// When Channels are set, a channel account is used as a source of the transaction.
+func (ts *TransactionSubmitter) SignAndSubmitRawTransaction(ctx context.Context, seed string, tx *xdr.Transaction) (response horizon.SubmitTransactionResponse, err error) {
+	account, err := ts.GetAccount(ctx, seed)
 	if err != nil {
 		return
 	}
 
+	if ts.Channels != nil {
+		return ts.signAndSubmitWithChannel(ctx, account, tx)
+	}
+
 	account.Mutex.Lock()
 	account.SequenceNumber++
 	tx.SeqNum = xdr.SequenceNumber(account.SequenceNumber)
 	account.Mutex.Unlock()
 
+	fee := ts.FeeStrategy.Fee(ctx)
+	tx.Fee = xdr.Uint32(fee * uint32(len(tx.Operations)))
+
 	hash, err := TransactionHash(tx, ts.Network.Passphrase)
 	if err != nil {
 		ts.log.Print("Error calculating transaction hash")
@@ -134,33 +183,55 @@ func (ts *TransactionSubmitter) SignAndSubmitRawTransaction(seed string, tx *xdr
 		return
 	}
 
-	transactionHashBytes, err := TransactionHash(tx, ts.Network.Passphrase)
-	if err != nil {
-		ts.log.WithFields(logrus.Fields{"err": err}).Warn("Error calculating tx hash")
-		return
+	return ts.submitEnvelope(ctx, account.Keypair.Address(), account, hash, txeB64, len(tx.Operations), fee)
+}
+
+// persist saves sentTransaction. Sent transactions are not saved when DB is not configured.
+func (ts *TransactionSubmitter) persist(sentTransaction *entities.SentTransaction) error {
+	if ts.EntityManager == nil {
+		return nil
 	}
+	return ts.EntityManager.Persist(sentTransaction)
+}
 
+// submitEnvelope saves a signed transaction envelope sent from source and submits it to the
+// network using FeeStrategy (fee is per operation). account is the transaction source account
+// (source or a channel account): it pays fee-bump fees and its sequence number is synced when
+// the transaction fails with tx_bad_seq.
+func (ts *TransactionSubmitter) submitEnvelope(ctx context.Context, source string, account *Account, hash [32]byte, txeB64 string, operations int, fee uint32) (response horizon.SubmitTransactionResponse, err error) {
 	sentTransaction := &entities.SentTransaction{
-		TransactionID: hex.EncodeToString(transactionHashBytes[:]),
+		TransactionID: hex.EncodeToString(hash[:]),
 		Status:        entities.SentTransactionStatusSending,
-		Source:        account.Keypair.Address(),
+		Source:        source,
 		SubmittedAt:   ts.now(),
 		EnvelopeXdr:   txeB64,
+		Tenant:        ts.Tenant,
 	}
-	err = ts.EntityManager.Persist(sentTransaction)
+	err = ts.persist(sentTransaction)
 	if err != nil {
+		ts.log.WithField("transaction_id", sentTransaction.TransactionID).Error("Error saving sent transaction ", err)
 		return
 	}
 
-	response, err = ts.Horizon.SubmitTransaction(txeB64)
+	response, err = ts.FeeStrategy.Submit(ctx, txeB64, operations, fee, account.Keypair)
 	if err != nil {
+		metrics.AddCounter("transactions_submitted", 1, metrics.Tags{"result": "error"})
 		ts.log.Error("Error submitting transaction ", err)
+
+		// Transaction could have been included in a ledger anyway
+		sentTransaction.MarkTimedOut()
+		persistErr := ts.persist(sentTransaction)
+		if persistErr != nil {
+			ts.log.Error("Error saving sent transaction ", persistErr)
+		}
 		return
 	}
 
 	if response.Ledger != nil {
+		metrics.AddCounter("transactions_submitted", 1, metrics.Tags{"result": "success"})
 		sentTransaction.MarkSucceeded(*response.Ledger)
 	} else {
+		metrics.AddCounter("transactions_submitted", 1, metrics.Tags{"result": "failure"})
 		var result string
 		if response.Extras != nil {
 			result = response.Extras.ResultXdr
@@ -169,16 +240,22 @@ func (ts *TransactionSubmitter) SignAndSubmitRawTransaction(seed string, tx *xdr
 		}
 		sentTransaction.MarkFailed(result)
 	}
-	err = ts.EntityManager.Persist(sentTransaction)
+	err = ts.persist(sentTransaction)
 	if err != nil {
+		ts.log.WithField("transaction_id", sentTransaction.TransactionID).Error("Error saving sent transaction ", err)
 		return
 	}
 
+	ts.log.WithFields(logrus.Fields{
+		"transaction_id": sentTransaction.TransactionID,
+		"status":         sentTransaction.Status,
+	}).Info("Sent transaction saved")
+
 	// Sync sequence number
 	if response.Extras != nil && response.Extras.ResultXdr == "AAAAAAAAAAD////7AAAAAA==" {
 		account.Mutex.Lock()
 		ts.log.Print("Syncing sequence number for ", account.Keypair.Address())
-		accountResponse, err2 := ts.Horizon.LoadAccount(account.Keypair.Address())
+		accountResponse, err2 := horizon.Uncached(ts.Horizon).LoadAccount(ctx, account.Keypair.Address())
 		if err2 != nil {
 			ts.log.Error("Error updating sequence number ", err)
 		} else {
@@ -190,8 +267,8 @@ func (ts *TransactionSubmitter) SignAndSubmitRawTransaction(seed string, tx *xdr
 }
 
 // SubmitTransaction builds and submits transaction to Stellar network
-func (ts *TransactionSubmitter) SubmitTransaction(seed string, operation, memo interface{}) (response horizon.SubmitTransactionResponse, err error) {
-	account, err := ts.GetAccount(seed)
+func (ts *TransactionSubmitter) SubmitTransaction(ctx context.Context, seed string, operation, memo interface{}) (response horizon.SubmitTransactionResponse, err error) {
+	account, err := ts.GetAccount(ctx, seed)
 	if err != nil {
 		return
 	}
@@ -204,7 +281,7 @@ func (ts *TransactionSubmitter) SubmitTransaction(seed string, operation, memo i
 	}
 
 	mutators := []build.TransactionMutator{
-		build.SourceAccount{account.Seed},
+		build.SourceAccount{account.Keypair.Address()},
 		ts.Network,
 		operationMutator,
 	}
@@ -221,7 +298,7 @@ func (ts *TransactionSubmitter) SubmitTransaction(seed string, operation, memo i
 
 	txBuilder := build.Transaction(mutators...)
 
-	return ts.SignAndSubmitRawTransaction(seed, txBuilder.TX)
+	return ts.SignAndSubmitRawTransaction(ctx, seed, txBuilder.TX)
 }
 
 // BuildTransaction is used in compliance server. The sequence number in built transaction will be equal 0!
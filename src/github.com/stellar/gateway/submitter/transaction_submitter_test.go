@@ -1,6 +1,7 @@
 package submitter
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -32,7 +33,7 @@ func TestTransactionSubmitter(t *testing.T) {
 			)
 
 			Convey("When seed is invalid", func() {
-				_, err := transactionSubmitter.LoadAccount("invalidSeed")
+				_, err := transactionSubmitter.LoadAccount(context.Background(), "invalidSeed")
 				assert.NotNil(t, err)
 			})
 
@@ -45,7 +46,7 @@ func TestTransactionSubmitter(t *testing.T) {
 					errors.New("Account not found"),
 				).Once()
 
-				_, err := transactionSubmitter.LoadAccount(seed)
+				_, err := transactionSubmitter.LoadAccount(context.Background(), seed)
 				assert.NotNil(t, err)
 				mockHorizon.AssertExpectations(t)
 			})
@@ -62,7 +63,7 @@ func TestTransactionSubmitter(t *testing.T) {
 					nil,
 				).Once()
 
-				account, err := transactionSubmitter.LoadAccount(seed)
+				account, err := transactionSubmitter.LoadAccount(context.Background(), seed)
 				assert.Nil(t, err)
 				assert.Equal(t, account.Keypair.Address(), accountID)
 				assert.Equal(t, account.Seed, seed)
@@ -97,7 +98,7 @@ func TestTransactionSubmitter(t *testing.T) {
 						nil,
 					).Once()
 
-					err := transactionSubmitter.InitAccount(seed)
+					err := transactionSubmitter.InitAccount(context.Background(), seed)
 					assert.Nil(t, err)
 
 					txB64 := "AAAAAJbmB/pwwloZXCaCr9WR3Fue2lNhHGaDWKVOWO7MPq4QAAAAZAAk2eQAAAABAAAAAAAAAAAAAAABAAAAAAAAAAEAAAAAd2/WGgaQ6CJcXQtGRFodrubQZ9ci5ZPRlxpqNPWV1CAAAAAAAAAAADuaygAAAAAAAAAAAcw+rhAAAABAyFjIMIZOtstCWtZlVBDj1AhTmsk5v1i2GGY4by2b5mgZoXXGgFTB8sfbQav0LzFKCcxY8h+9xPMT2e9xznAfDw=="
@@ -138,7 +139,7 @@ func TestTransactionSubmitter(t *testing.T) {
 						nil,
 					).Once()
 
-					_, err = transactionSubmitter.SubmitTransaction(seed, operation, nil)
+					_, err = transactionSubmitter.SubmitTransaction(context.Background(), seed, operation, nil)
 					assert.Nil(t, err)
 					mockHorizon.AssertExpectations(t)
 				})
@@ -162,7 +163,7 @@ func TestTransactionSubmitter(t *testing.T) {
 						nil,
 					).Once()
 
-					err := transactionSubmitter.InitAccount(seed)
+					err := transactionSubmitter.InitAccount(context.Background(), seed)
 					assert.Nil(t, err)
 
 					txB64 := "AAAAAJbmB/pwwloZXCaCr9WR3Fue2lNhHGaDWKVOWO7MPq4QAAAAZAAk2eQAAAABAAAAAAAAAAAAAAABAAAAAAAAAAEAAAAAd2/WGgaQ6CJcXQtGRFodrubQZ9ci5ZPRlxpqNPWV1CAAAAAAAAAAADuaygAAAAAAAAAAAcw+rhAAAABAyFjIMIZOtstCWtZlVBDj1AhTmsk5v1i2GGY4by2b5mgZoXXGgFTB8sfbQav0LzFKCcxY8h+9xPMT2e9xznAfDw=="
@@ -215,7 +216,7 @@ func TestTransactionSubmitter(t *testing.T) {
 						nil,
 					).Once()
 
-					_, err = transactionSubmitter.SubmitTransaction(seed, operation, nil)
+					_, err = transactionSubmitter.SubmitTransaction(context.Background(), seed, operation, nil)
 					assert.Nil(t, err)
 					assert.Equal(t, uint64(100), transactionSubmitter.Accounts[seed].SequenceNumber)
 					mockHorizon.AssertExpectations(t)
@@ -240,7 +241,7 @@ func TestTransactionSubmitter(t *testing.T) {
 						nil,
 					).Once()
 
-					err := transactionSubmitter.InitAccount(seed)
+					err := transactionSubmitter.InitAccount(context.Background(), seed)
 					assert.Nil(t, err)
 
 					txB64 := "AAAAAJbmB/pwwloZXCaCr9WR3Fue2lNhHGaDWKVOWO7MPq4QAAAAZAAk2eQAAAABAAAAAAAAAAAAAAABAAAAAAAAAAEAAAAAd2/WGgaQ6CJcXQtGRFodrubQZ9ci5ZPRlxpqNPWV1CAAAAAAAAAAADuaygAAAAAAAAAAAcw+rhAAAABAyFjIMIZOtstCWtZlVBDj1AhTmsk5v1i2GGY4by2b5mgZoXXGgFTB8sfbQav0LzFKCcxY8h+9xPMT2e9xznAfDw=="
@@ -277,7 +278,7 @@ func TestTransactionSubmitter(t *testing.T) {
 						nil,
 					).Once()
 
-					response, err := transactionSubmitter.SubmitTransaction(seed, operation, nil)
+					response, err := transactionSubmitter.SubmitTransaction(context.Background(), seed, operation, nil)
 					assert.Nil(t, err)
 					assert.Equal(t, *response.Ledger, ledger)
 					assert.Equal(t, uint64(10372672437354497), transactionSubmitter.Accounts[seed].SequenceNumber)
@@ -312,7 +313,7 @@ func TestTransactionSubmitter(t *testing.T) {
 						nil,
 					).Once()
 
-					err := transactionSubmitter.InitAccount(seed)
+					err := transactionSubmitter.InitAccount(context.Background(), seed)
 					assert.Nil(t, err)
 
 					txB64 := "AAAAAJbmB/pwwloZXCaCr9WR3Fue2lNhHGaDWKVOWO7MPq4QAAAAZAAk2eQAAAABAAAAAAAAAAEAAAAIVGVzdGluZyEAAAABAAAAAAAAAAEAAAAAd2/WGgaQ6CJcXQtGRFodrubQZ9ci5ZPRlxpqNPWV1CAAAAAAAAAAADuaygAAAAAAAAAAAcw+rhAAAABAU5ahFsd28sVKSUFcmAiEf+zSLXhf9HG/pJuQirR0s43zs7Y43vM8T3sIvJWHgwMADaZiy/D+evYWd/vS/uO8Ag=="
@@ -349,7 +350,7 @@ func TestTransactionSubmitter(t *testing.T) {
 						nil,
 					).Once()
 
-					response, err := transactionSubmitter.SubmitTransaction(seed, operation, memo)
+					response, err := transactionSubmitter.SubmitTransaction(context.Background(), seed, operation, memo)
 					assert.Nil(t, err)
 					assert.Equal(t, *response.Ledger, ledger)
 					assert.Equal(t, uint64(10372672437354497), transactionSubmitter.Accounts[seed].SequenceNumber)
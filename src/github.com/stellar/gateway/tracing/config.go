@@ -0,0 +1,50 @@
+package tracing
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// exportTimeout is a timeout of requests sending spans to the collector
+const exportTimeout = 10 * time.Second
+
+// Config contains values of `tracing` config group of bridge and compliance servers
+type Config struct {
+	// OTLPEndpoint is a base URL of OTLP/HTTP collector, ex. `http://localhost:4318`. Spans
+	// are sent to `<otlp_endpoint>/v1/traces`. Tracing is disabled when empty.
+	OTLPEndpoint string `mapstructure:"otlp_endpoint"`
+	// ServiceName is `service.name` of exported spans (default: name of the server)
+	ServiceName string `mapstructure:"service_name"`
+}
+
+// Enabled returns true when spans are exported
+func (c Config) Enabled() bool {
+	return c.OTLPEndpoint != ""
+}
+
+// Validate validates the config
+func (c Config) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+
+	endpoint, err := url.Parse(c.OTLPEndpoint)
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") {
+		return errors.New("Invalid tracing.otlp_endpoint param")
+	}
+	return nil
+}
+
+// NewTracer creates a Tracer exporting spans to OTLPEndpoint. defaultServiceName is used when
+// ServiceName is empty.
+func (c Config) NewTracer(defaultServiceName string) *Tracer {
+	serviceName := c.ServiceName
+	if serviceName == "" {
+		serviceName = defaultServiceName
+	}
+	endpoint := strings.TrimSuffix(c.OTLPEndpoint, "/") + "/v1/traces"
+	return NewTracer(serviceName, endpoint, &http.Client{Timeout: exportTimeout})
+}
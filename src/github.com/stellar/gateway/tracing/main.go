@@ -0,0 +1,175 @@
+// Package tracing records OpenTelemetry spans and exports them to a collector using OTLP/HTTP.
+// Spans are linked across servers using W3C Trace Context (`traceparent` header).
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TraceparentHeader is W3C Trace Context header carrying the trace and the parent span
+const TraceparentHeader = "traceparent"
+
+// Kind is a kind of a span as defined by OpenTelemetry
+type Kind int
+
+const (
+	// KindInternal is an operation inside the server
+	KindInternal Kind = 1
+	// KindServer is a handled request
+	KindServer Kind = 2
+	// KindClient is a request sent to other server
+	KindClient Kind = 3
+)
+
+// Default is a tracer used by package level functions. Tracing is disabled when it's nil:
+// Start returns nil and all Span methods do nothing for nil spans.
+var Default *Tracer
+
+// Span is a single timed operation of a trace
+type Span struct {
+	tracer     *Tracer
+	traceID    [16]byte
+	spanID     [8]byte
+	parentID   [8]byte
+	name       string
+	kind       Kind
+	start      time.Time
+	end        time.Time
+	mutex      sync.Mutex
+	attributes map[string]interface{}
+	err        string
+}
+
+// Start starts a new trace using Default tracer
+func Start(name string, kind Kind) *Span {
+	if Default == nil {
+		return nil
+	}
+	span := Default.newSpan(name, kind)
+	span.traceID = newTraceID()
+	return span
+}
+
+// StartFromHeader starts a server span of a request. The span continues a trace of the client
+// when headers contain a valid `traceparent` header, otherwise it starts a new trace.
+func StartFromHeader(name string, header http.Header) *Span {
+	if Default == nil {
+		return nil
+	}
+	span := Default.newSpan(name, KindServer)
+	traceID, parentID, ok := parseTraceparent(header.Get(TraceparentHeader))
+	if ok {
+		span.traceID = traceID
+		span.parentID = parentID
+	} else {
+		span.traceID = newTraceID()
+	}
+	return span
+}
+
+// Child starts a span of an operation done as a part of s
+func (s *Span) Child(name string, kind Kind) *Span {
+	if s == nil {
+		return nil
+	}
+	span := s.tracer.newSpan(name, kind)
+	span.traceID = s.traceID
+	span.parentID = s.spanID
+	return span
+}
+
+// SetAttribute sets an attribute of the span. Values can be strings, bools, ints and floats,
+// other values are converted to strings.
+func (s *Span) SetAttribute(key string, value interface{}) {
+	if s == nil {
+		return
+	}
+	s.mutex.Lock()
+	s.attributes[key] = value
+	s.mutex.Unlock()
+}
+
+// Inject adds `traceparent` header of the span to header so a server receiving the request
+// continues the trace
+func (s *Span) Inject(header http.Header) {
+	if s == nil {
+		return
+	}
+	header.Set(TraceparentHeader, "00-"+hex.EncodeToString(s.traceID[:])+"-"+hex.EncodeToString(s.spanID[:])+"-01")
+}
+
+// TraceID returns hex encoded ID of the trace or empty string when s is nil
+func (s *Span) TraceID() string {
+	if s == nil {
+		return ""
+	}
+	return hex.EncodeToString(s.traceID[:])
+}
+
+// End ends the span and queues it for export. Span status is error when err is not nil.
+func (s *Span) End(err error) {
+	if s == nil {
+		return
+	}
+	s.mutex.Lock()
+	s.end = time.Now()
+	if err != nil {
+		s.err = err.Error()
+	}
+	s.mutex.Unlock()
+	s.tracer.queue(s)
+}
+
+type spanKey struct{}
+
+// ContextWithSpan returns a copy of ctx containing span
+func ContextWithSpan(ctx context.Context, span *Span) context.Context {
+	return context.WithValue(ctx, spanKey{}, span)
+}
+
+// FromRequest returns a span of a request started by server.TracingMiddleware or nil
+func FromRequest(r *http.Request) *Span {
+	span, _ := r.Context().Value(spanKey{}).(*Span)
+	return span
+}
+
+// parseTraceparent returns trace ID and parent span ID of version 00 `traceparent` header
+func parseTraceparent(value string) (traceID [16]byte, parentID [8]byte, ok bool) {
+	parts := strings.Split(value, "-")
+	if len(parts) != 4 || parts[0] != "00" || len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return
+	}
+
+	_, err := hex.Decode(traceID[:], []byte(parts[1]))
+	if err != nil || traceID == [16]byte{} {
+		return
+	}
+	_, err = hex.Decode(parentID[:], []byte(parts[2]))
+	if err != nil || parentID == [8]byte{} {
+		return
+	}
+	return traceID, parentID, true
+}
+
+func newTraceID() (id [16]byte) {
+	randomBytes(id[:])
+	return
+}
+
+func newSpanID() (id [8]byte) {
+	randomBytes(id[:])
+	return
+}
+
+func randomBytes(b []byte) {
+	_, err := rand.Read(b)
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,112 @@
+package tracing
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisabled(t *testing.T) {
+	span := Start("test", KindInternal)
+	assert.Nil(t, span)
+
+	// Methods of nil spans do nothing
+	child := span.Child("child", KindClient)
+	assert.Nil(t, child)
+	child.SetAttribute("key", "value")
+	header := http.Header{}
+	child.Inject(header)
+	assert.Empty(t, header)
+	child.End(nil)
+	assert.Equal(t, "", span.TraceID())
+}
+
+func TestParseTraceparent(t *testing.T) {
+	traceID, parentID, ok := parseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	require.True(t, ok)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", hex.EncodeToString(traceID[:]))
+	assert.Equal(t, "00f067aa0ba902b7", hex.EncodeToString(parentID[:]))
+
+	for _, value := range []string{
+		"",
+		"01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e473z-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+	} {
+		_, _, ok := parseTraceparent(value)
+		assert.False(t, ok, value)
+	}
+}
+
+func TestExport(t *testing.T) {
+	var body []byte
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/traces", r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		body, _ = ioutil.ReadAll(r.Body)
+	}))
+	defer collector.Close()
+
+	Default = Config{OTLPEndpoint: collector.URL + "/"}.NewTracer("bridge")
+	defer func() { Default = nil }()
+
+	header := http.Header{}
+	header.Set(TraceparentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	span := StartFromHeader("POST /payment", header)
+	span.SetAttribute("http.status_code", 500)
+	span.SetAttribute("http.method", "POST")
+
+	child := span.Child("compliance.send", KindClient)
+	child.Inject(header)
+	child.End(nil)
+	span.End(errors.New("Internal Server Error"))
+
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-"+hex.EncodeToString(child.spanID[:])+"-01", header.Get(TraceparentHeader))
+
+	require.NoError(t, Default.Export())
+
+	var request otlpRequest
+	require.NoError(t, json.Unmarshal(body, &request))
+	require.Len(t, request.ResourceSpans, 1)
+	assert.Equal(t, "service.name", request.ResourceSpans[0].Resource.Attributes[0].Key)
+	assert.Equal(t, "bridge", *request.ResourceSpans[0].Resource.Attributes[0].Value.StringValue)
+
+	spans := request.ResourceSpans[0].ScopeSpans[0].Spans
+	require.Len(t, spans, 2)
+
+	assert.Equal(t, "compliance.send", spans[0].Name)
+	assert.Equal(t, KindClient, spans[0].Kind)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].TraceID)
+	assert.Equal(t, hex.EncodeToString(span.spanID[:]), spans[0].ParentSpanID)
+	assert.Nil(t, spans[0].Status)
+
+	assert.Equal(t, "POST /payment", spans[1].Name)
+	assert.Equal(t, KindServer, spans[1].Kind)
+	assert.Equal(t, "00f067aa0ba902b7", spans[1].ParentSpanID)
+	assert.Equal(t, &otlpStatus{Code: otlpStatusCodeError, Message: "Internal Server Error"}, spans[1].Status)
+	require.Len(t, spans[1].Attributes, 2)
+	assert.Equal(t, "http.method", spans[1].Attributes[0].Key)
+	assert.Equal(t, "POST", *spans[1].Attributes[0].Value.StringValue)
+	assert.Equal(t, "http.status_code", spans[1].Attributes[1].Key)
+	assert.Equal(t, "500", *spans[1].Attributes[1].Value.IntValue)
+
+	// Queue is empty after export
+	body = nil
+	require.NoError(t, Default.Export())
+	assert.Nil(t, body)
+}
+
+func TestConfigValidate(t *testing.T) {
+	assert.NoError(t, Config{}.Validate())
+	assert.NoError(t, Config{OTLPEndpoint: "http://localhost:4318"}.Validate())
+	assert.Error(t, Config{OTLPEndpoint: "localhost:4318"}.Validate())
+}
@@ -0,0 +1,292 @@
+package tracing
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/stellar/go/support/errors"
+)
+
+const (
+	// exportInterval is an interval of sending queued spans
+	exportInterval = 5 * time.Second
+	// exportBatchSize is a number of queued spans sent without waiting for exportInterval
+	exportBatchSize = 512
+	// maxQueuedSpans is a number of queued spans after which new spans are dropped
+	maxQueuedSpans = 4096
+	// scopeName is OpenTelemetry instrumentation scope of all spans
+	scopeName = "github.com/stellar/gateway"
+)
+
+// Tracer creates spans and exports ended spans in batches to OTLP/HTTP endpoint (`/v1/traces`
+// of a collector) using JSON encoding
+type Tracer struct {
+	ServiceName string
+	Endpoint    string
+	Client      *http.Client
+
+	mutex   sync.Mutex
+	spans   []*Span
+	dropped int
+	flush   chan struct{}
+	stop    chan struct{}
+	stopWG  sync.WaitGroup
+}
+
+// NewTracer creates a new Tracer. Spans are exported after Start is called.
+func NewTracer(serviceName, endpoint string, client *http.Client) *Tracer {
+	return &Tracer{
+		ServiceName: serviceName,
+		Endpoint:    endpoint,
+		Client:      client,
+		flush:       make(chan struct{}, 1),
+		stop:        make(chan struct{}),
+	}
+}
+
+// Start exports queued spans in background until Stop is called
+func (t *Tracer) Start() {
+	t.stopWG.Add(1)
+	go t.run()
+}
+
+// Stop stops exporting spans in background and exports remaining spans
+func (t *Tracer) Stop() {
+	if t == nil {
+		return
+	}
+	close(t.stop)
+	t.stopWG.Wait()
+}
+
+func (t *Tracer) run() {
+	defer t.stopWG.Done()
+
+	ticker := time.NewTicker(exportInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-t.flush:
+		case <-t.stop:
+			t.export()
+			return
+		}
+		t.export()
+	}
+}
+
+// export exports queued spans. Spans are dropped when exporting fails.
+func (t *Tracer) export() {
+	t.mutex.Lock()
+	dropped := t.dropped
+	t.dropped = 0
+	t.mutex.Unlock()
+
+	if dropped > 0 {
+		log.WithField("spans", dropped).Warn("Span queue full, spans dropped")
+	}
+
+	err := t.Export()
+	if err != nil {
+		log.WithField("err", err).Error("Error exporting spans")
+	}
+}
+
+// Export sends all queued spans to Endpoint
+func (t *Tracer) Export() error {
+	t.mutex.Lock()
+	spans := t.spans
+	t.spans = nil
+	t.mutex.Unlock()
+
+	if len(spans) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(t.request(spans))
+	if err != nil {
+		return errors.Wrap(err, "cannot marshal spans")
+	}
+
+	resp, err := t.Client.Post(t.Endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "http request errored")
+	}
+	defer resp.Body.Close()
+	// Response body must be read to reuse the connection
+	ioutil.ReadAll(resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.Errorf("response status code indicates error (%d)", resp.StatusCode)
+	}
+	return nil
+}
+
+func (t *Tracer) newSpan(name string, kind Kind) *Span {
+	return &Span{
+		tracer:     t,
+		spanID:     newSpanID(),
+		name:       name,
+		kind:       kind,
+		start:      time.Now(),
+		attributes: make(map[string]interface{}),
+	}
+}
+
+// queue adds an ended span to spans exported by the next export
+func (t *Tracer) queue(span *Span) {
+	t.mutex.Lock()
+	if len(t.spans) >= maxQueuedSpans {
+		t.dropped++
+		t.mutex.Unlock()
+		return
+	}
+	t.spans = append(t.spans, span)
+	full := len(t.spans) >= exportBatchSize
+	t.mutex.Unlock()
+
+	if full {
+		select {
+		case t.flush <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// OTLP JSON encoding of ExportTraceServiceRequest. IDs are hex encoded and 64-bit integers
+// are encoded as strings.
+type (
+	otlpRequest struct {
+		ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+	}
+
+	otlpResourceSpans struct {
+		Resource   otlpResource     `json:"resource"`
+		ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+	}
+
+	otlpResource struct {
+		Attributes []otlpAttribute `json:"attributes"`
+	}
+
+	otlpScopeSpans struct {
+		Scope otlpScope  `json:"scope"`
+		Spans []otlpSpan `json:"spans"`
+	}
+
+	otlpScope struct {
+		Name string `json:"name"`
+	}
+
+	otlpSpan struct {
+		TraceID           string          `json:"traceId"`
+		SpanID            string          `json:"spanId"`
+		ParentSpanID      string          `json:"parentSpanId,omitempty"`
+		Name              string          `json:"name"`
+		Kind              Kind            `json:"kind"`
+		StartTimeUnixNano string          `json:"startTimeUnixNano"`
+		EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+		Attributes        []otlpAttribute `json:"attributes,omitempty"`
+		Status            *otlpStatus     `json:"status,omitempty"`
+	}
+
+	otlpStatus struct {
+		Code    int    `json:"code"`
+		Message string `json:"message,omitempty"`
+	}
+
+	otlpAttribute struct {
+		Key   string    `json:"key"`
+		Value otlpValue `json:"value"`
+	}
+
+	otlpValue struct {
+		StringValue *string  `json:"stringValue,omitempty"`
+		BoolValue   *bool    `json:"boolValue,omitempty"`
+		IntValue    *string  `json:"intValue,omitempty"`
+		DoubleValue *float64 `json:"doubleValue,omitempty"`
+	}
+)
+
+// otlpStatusCodeError is STATUS_CODE_ERROR, spans without errors have unset status
+const otlpStatusCodeError = 2
+
+func (t *Tracer) request(spans []*Span) otlpRequest {
+	encoded := make([]otlpSpan, 0, len(spans))
+	for _, span := range spans {
+		encoded = append(encoded, span.otlp())
+	}
+
+	return otlpRequest{ResourceSpans: []otlpResourceSpans{{
+		Resource: otlpResource{Attributes: []otlpAttribute{
+			{Key: "service.name", Value: attributeValue(t.ServiceName)},
+		}},
+		ScopeSpans: []otlpScopeSpans{{
+			Scope: otlpScope{Name: scopeName},
+			Spans: encoded,
+		}},
+	}}}
+}
+
+func (s *Span) otlp() otlpSpan {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	span := otlpSpan{
+		TraceID:           hex.EncodeToString(s.traceID[:]),
+		SpanID:            hex.EncodeToString(s.spanID[:]),
+		Name:              s.name,
+		Kind:              s.kind,
+		StartTimeUnixNano: strconv.FormatInt(s.start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(s.end.UnixNano(), 10),
+	}
+
+	if s.parentID != [8]byte{} {
+		span.ParentSpanID = hex.EncodeToString(s.parentID[:])
+	}
+
+	keys := make([]string, 0, len(s.attributes))
+	for key := range s.attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		span.Attributes = append(span.Attributes, otlpAttribute{Key: key, Value: attributeValue(s.attributes[key])})
+	}
+
+	if s.err != "" {
+		span.Status = &otlpStatus{Code: otlpStatusCodeError, Message: s.err}
+	}
+	return span
+}
+
+func attributeValue(value interface{}) otlpValue {
+	switch value := value.(type) {
+	case string:
+		return otlpValue{StringValue: &value}
+	case bool:
+		return otlpValue{BoolValue: &value}
+	case int:
+		s := strconv.Itoa(value)
+		return otlpValue{IntValue: &s}
+	case int64:
+		s := strconv.FormatInt(value, 10)
+		return otlpValue{IntValue: &s}
+	case float64:
+		return otlpValue{DoubleValue: &value}
+	default:
+		s := fmt.Sprint(value)
+		return otlpValue{StringValue: &s}
+	}
+}